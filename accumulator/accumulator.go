@@ -5,13 +5,15 @@
 //
 
 // Package accumulator implements the cryptographic accumulator as described in https://eprint.iacr.org/2020/777.pdf
-// It also implements the zero knowledge proof of knowledge protocol
+// It also implements the zero knowledge proof of knowledge protocols
 // described in section 7 of the paper.
-// Note: the paper only describes for non-membership witness case, but we don't
-// use non-membership witness. We only implement the membership witness case.
+// Accumulators created with New only support membership witnesses.
+// Accumulators created with NewUniversal are initialized as described in section 6
+// and also support non-membership witnesses and proofs.
 package accumulator
 
 import (
+	"encoding/binary"
 	"fmt"
 
 	"git.sr.ht/~sircmpwn/go-bare"
@@ -46,6 +48,32 @@ func (acc *Accumulator) New(curve *curves.PairingCurve) (*Accumulator, error) {
 	return acc, nil
 }
 
+// universalDomain separates the fixed elements of a universal accumulator
+// from the hashed elements it accumulates
+const universalDomain = "go-sonr universal accumulator v1"
+
+// NewUniversal creates a new universal accumulator that supports non-membership witnesses.
+// As described in section 6 of <https://eprint.iacr.org/2020/777.pdf>, it computes
+// V0 = prod(y + α) * P for the n+1 fixed elements y returned by UniversalElements,
+// where n is the maximum number of elements the accumulator will hold.
+func (acc *Accumulator) NewUniversal(curve *curves.PairingCurve, key *SecretKey, n int) (*Accumulator, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("n should be positive")
+	}
+	return acc.WithElements(curve, key, UniversalElements(curve, n))
+}
+
+// UniversalElements returns the n+1 fixed elements accumulated by NewUniversal.
+// They are never removed and are needed to create non-membership witnesses.
+func UniversalElements(curve *curves.PairingCurve, n int) []Element {
+	elements := make([]Element, n+1)
+	for i := range elements {
+		data := binary.BigEndian.AppendUint64([]byte(universalDomain), uint64(i))
+		elements[i] = curve.Scalar.Hash(data)
+	}
+	return elements
+}
+
 // WithElements initializes a new accumulator prefilled with entries
 // Each member is assumed to be hashed
 // V = prod(y + α) * V0, for all y∈ Y_V
@@ -26,6 +26,11 @@ func (sk *SecretKey) New(curve *curves.PairingCurve, seed []byte) (*SecretKey, e
 	return sk, nil
 }
 
+// Zeroize overwrites the secret alpha with zero
+func (sk *SecretKey) Zeroize() {
+	curves.Zeroize(sk.value)
+}
+
 // GetPublicKey creates a public key from SecretKey sk
 func (sk SecretKey) GetPublicKey(curve *curves.PairingCurve) (*PublicKey, error) {
 	if sk.value == nil || curve == nil {
@@ -103,8 +103,53 @@ func (p polynomialPoint) Mul(rhs curves.Scalar) (polynomialPoint, error) {
 	return result, nil
 }
 
+// MulPoly for PolynomialG1 computes p * rhs, p is a polynomial, rhs is a polynomial of scalars
+func (p polynomialPoint) MulPoly(rhs polynomial) (polynomialPoint, error) {
+	if len(p) == 0 || len(rhs) == 0 {
+		return nil, fmt.Errorf("p and rhs cannot be empty")
+	}
+	for i, c := range p {
+		if c == nil {
+			return nil, fmt.Errorf("coefficient in p at %d is nil", i)
+		}
+	}
+	for i, c := range rhs {
+		if c == nil {
+			return nil, fmt.Errorf("coefficient in rhs at %d is nil", i)
+		}
+	}
+
+	prod := make(polynomialPoint, len(p)+len(rhs)-1)
+	for i := 0; i < len(prod); i++ {
+		prod[i] = p[0].Identity()
+	}
+	for i, cp := range p {
+		for j, cr := range rhs {
+			prod[i+j] = prod[i+j].Add(cp.Mul(cr))
+		}
+	}
+	return prod, nil
+}
+
 type polynomial []curves.Scalar
 
+// roots constructs the polynomial prod(y_t - x), t = 1...n
+// which is dA(x) or dD(x) for its values
+func roots(values []Element, one curves.Scalar) (polynomial, error) {
+	result := polynomial{one}
+	for i, value := range values {
+		if value == nil {
+			return nil, fmt.Errorf("value at %d is nil", i)
+		}
+		var err error
+		result, err = result.Mul(polynomial{value, one.Neg()})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
 // Add adds two polynomials
 func (p polynomial) Add(rhs polynomial) (polynomial, error) {
 	maxLen := int(math.Max(float64(len(p)), float64(len(rhs))))
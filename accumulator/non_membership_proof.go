@@ -0,0 +1,454 @@
+package accumulator
+
+import (
+	crand "crypto/rand"
+	"errors"
+	"fmt"
+
+	"git.sr.ht/~sircmpwn/go-bare"
+
+	"github.com/go-sonr/crypto/core/curves"
+)
+
+// NonMembershipProofCommitting contains value computed in Proof of knowledge and
+// Blinding phases as described in section 7 of https://eprint.iacr.org/2020/777.pdf
+type NonMembershipProofCommitting struct {
+	eC             curves.Point
+	tSigma         curves.Point
+	tRho           curves.Point
+	eD             curves.Point
+	eDInv          curves.Point
+	deltaSigma     curves.Scalar
+	deltaRho       curves.Scalar
+	blindingFactor curves.Scalar
+	rSigma         curves.Scalar
+	rRho           curves.Scalar
+	rDeltaSigma    curves.Scalar
+	rDeltaRho      curves.Scalar
+	rU             curves.Scalar
+	rV             curves.Scalar
+	rW             curves.Scalar
+	sigma          curves.Scalar
+	rho            curves.Scalar
+	tau            curves.Scalar
+	w              curves.Scalar
+	capRA          curves.Point
+	capRB          curves.Point
+	capRSigma      curves.Point
+	capRRho        curves.Point
+	capRDeltaSigma curves.Point
+	capRDeltaRho   curves.Point
+	capRE          curves.Scalar
+	accumulator    curves.Point
+	witnessValue   curves.Scalar
+	witnessD       curves.Scalar
+}
+
+// New initiates values of NonMembershipProofCommitting
+func (npc *NonMembershipProofCommitting) New(
+	witness *NonMembershipWitness,
+	acc *Accumulator,
+	pp *ProofParams,
+	pk *PublicKey,
+) (*NonMembershipProofCommitting, error) {
+	return npc.NewWithBlinding(witness, acc, pp, pk, witness.y.Random(crand.Reader))
+}
+
+// NewWithBlinding initiates values of NonMembershipProofCommitting using blinding
+// as the blinding factor r_y of the witness value y. Using the blinding factor
+// of the same message in another proof under the same challenge yields the same
+// response for y, which binds the element to e.g. a signed credential attribute.
+func (npc *NonMembershipProofCommitting) NewWithBlinding(
+	witness *NonMembershipWitness,
+	acc *Accumulator,
+	pp *ProofParams,
+	pk *PublicKey,
+	blinding curves.Scalar,
+) (*NonMembershipProofCommitting, error) {
+	if witness == nil || witness.c == nil || witness.d == nil || witness.y == nil || blinding == nil {
+		return nil, fmt.Errorf("witness and blinding should not be nil")
+	}
+	dInv, err := witness.d.Invert()
+	if err != nil {
+		return nil, err
+	}
+	k := pp.k()
+	p := pp.x.Generator()
+
+	// Randomly select σ, ρ, τ, π
+	sigma := witness.y.Random(crand.Reader)
+	rho := witness.y.Random(crand.Reader)
+	tau := witness.y.Random(crand.Reader)
+	pi := witness.y.Random(crand.Reader)
+
+	// E_C = C + (σ + ρ)Z
+	eC := pp.z.Mul(sigma.Add(rho)).Add(witness.c)
+
+	// T_σ = σX
+	tSigma := pp.x.Mul(sigma)
+
+	// T_ρ = ρY
+	tRho := pp.y.Mul(rho)
+
+	// E_d = dP + τK
+	eD := p.Mul(witness.d).Add(k.Mul(tau))
+
+	// E_{d^-1} = d^-1 P + πK
+	eDInv := p.Mul(dInv).Add(k.Mul(pi))
+
+	// δ_σ = yσ
+	deltaSigma := witness.y.Mul(sigma)
+
+	// δ_ρ = yρ
+	deltaRho := witness.y.Mul(rho)
+
+	// w = -dπ, so that P = d E_{d^-1} + wK
+	w := witness.d.Mul(pi).Neg()
+
+	// Randomly pick r_σ,r_ρ,r_δσ,r_δρ,r_u,r_v,r_w
+	rY := blinding
+	rSigma := witness.y.Random(crand.Reader)
+	rRho := witness.y.Random(crand.Reader)
+	rDeltaSigma := witness.y.Random(crand.Reader)
+	rDeltaRho := witness.y.Random(crand.Reader)
+	rU := witness.y.Random(crand.Reader)
+	rV := witness.y.Random(crand.Reader)
+	rW := witness.y.Random(crand.Reader)
+
+	// R_A = r_u P + r_v K
+	capRA := p.Mul(rU).Add(k.Mul(rV))
+
+	// R_B = r_u E_{d^-1} + r_w K
+	capRB := eDInv.Mul(rU).Add(k.Mul(rW))
+
+	// R_σ = r_σ X
+	capRSigma := pp.x.Mul(rSigma)
+
+	// R_ρ = r_ρ Y
+	capRRho := pp.y.Mul(rRho)
+
+	// R_δσ = r_y T_σ - r_δσ X
+	capRDeltaSigma := tSigma.Mul(rY).Add(pp.x.Neg().Mul(rDeltaSigma))
+
+	// R_δρ = r_y T_ρ - r_δρ Y
+	capRDeltaRho := tRho.Mul(rY).Add(pp.y.Neg().Mul(rDeltaRho))
+
+	// r_y E_C + (-r_δσ - r_δρ)Z - r_v K
+	lhs := eC.Mul(rY).Add(pp.z.Mul(rDeltaSigma.Add(rDeltaRho).Neg())).Add(k.Mul(rV.Neg()))
+
+	// (-r_σ - r_ρ)Z
+	rhs := pp.z.Mul(rSigma.Add(rRho).Neg())
+
+	// Prepare
+	lhsPrep, ok := lhs.(curves.PairingPoint)
+	if !ok {
+		return nil, errors.New("incorrect type conversion")
+	}
+	rhsPrep, ok := rhs.(curves.PairingPoint)
+	if !ok {
+		return nil, errors.New("incorrect type conversion")
+	}
+	g2Prep, ok := pk.value.Generator().(curves.PairingPoint)
+	if !ok {
+		return nil, errors.New("incorrect type conversion")
+	}
+
+	// Pairing
+	capRE := g2Prep.MultiPairing(lhsPrep, g2Prep, rhsPrep, pk.value)
+
+	return &NonMembershipProofCommitting{
+		eC,
+		tSigma,
+		tRho,
+		eD,
+		eDInv,
+		deltaSigma,
+		deltaRho,
+		rY,
+		rSigma,
+		rRho,
+		rDeltaSigma,
+		rDeltaRho,
+		rU,
+		rV,
+		rW,
+		sigma,
+		rho,
+		tau,
+		w,
+		capRA,
+		capRB,
+		capRSigma,
+		capRRho,
+		capRDeltaSigma,
+		capRDeltaRho,
+		capRE,
+		acc.value,
+		witness.y,
+		witness.d,
+	}, nil
+}
+
+// GetChallengeBytes returns bytes that need to be hashed for generating challenge.
+// V || Ec || E_d || E_d^-1 || T_sigma || T_rho || R_A || R_B || R_E || R_sigma || R_rho || R_delta_sigma || R_delta_rho
+func (npc NonMembershipProofCommitting) GetChallengeBytes() []byte {
+	res := npc.accumulator.ToAffineCompressed()
+	res = append(res, npc.eC.ToAffineCompressed()...)
+	res = append(res, npc.eD.ToAffineCompressed()...)
+	res = append(res, npc.eDInv.ToAffineCompressed()...)
+	res = append(res, npc.tSigma.ToAffineCompressed()...)
+	res = append(res, npc.tRho.ToAffineCompressed()...)
+	res = append(res, npc.capRA.ToAffineCompressed()...)
+	res = append(res, npc.capRB.ToAffineCompressed()...)
+	res = append(res, npc.capRE.Bytes()...)
+	res = append(res, npc.capRSigma.ToAffineCompressed()...)
+	res = append(res, npc.capRRho.ToAffineCompressed()...)
+	res = append(res, npc.capRDeltaSigma.ToAffineCompressed()...)
+	res = append(res, npc.capRDeltaRho.ToAffineCompressed()...)
+	return res
+}
+
+// GenProof computes the s values for Fiat-Shamir and return the actual
+// proof to be sent to the verifier given the challenge c.
+func (npc *NonMembershipProofCommitting) GenProof(c curves.Scalar) *NonMembershipProof {
+	return &NonMembershipProof{
+		eC:     npc.eC,
+		tSigma: npc.tSigma,
+		tRho:   npc.tRho,
+		eD:     npc.eD,
+		eDInv:  npc.eDInv,
+		// s_σ = r_σ + c*σ
+		sSigma: schnorr(npc.rSigma, npc.sigma, c),
+		// s_ρ = r_ρ + c*ρ
+		sRho: schnorr(npc.rRho, npc.rho, c),
+		// s_δσ = rδσ + c*δ_σ
+		sDeltaSigma: schnorr(npc.rDeltaSigma, npc.deltaSigma, c),
+		// s_δρ = rδρ + c*δ_ρ
+		sDeltaRho: schnorr(npc.rDeltaRho, npc.deltaRho, c),
+		// s_u = r_u + c*d
+		sU: schnorr(npc.rU, npc.witnessD, c),
+		// s_v = r_v + c*τ
+		sV: schnorr(npc.rV, npc.tau, c),
+		// s_w = r_w + c*w
+		sW: schnorr(npc.rW, npc.w, c),
+		// s_y = r_y + c*y
+		sY: schnorr(npc.blindingFactor, npc.witnessValue, c),
+	}
+}
+
+type nonMembershipProofMarshal struct {
+	EC          []byte `bare:"e_c"`
+	TSigma      []byte `bare:"t_sigma"`
+	TRho        []byte `bare:"t_rho"`
+	ED          []byte `bare:"e_d"`
+	EDInv       []byte `bare:"e_d_inv"`
+	SSigma      []byte `bare:"s_sigma"`
+	SRho        []byte `bare:"s_rho"`
+	SDeltaSigma []byte `bare:"s_delta_sigma"`
+	SDeltaRho   []byte `bare:"s_delta_rho"`
+	SU          []byte `bare:"s_u"`
+	SV          []byte `bare:"s_v"`
+	SW          []byte `bare:"s_w"`
+	SY          []byte `bare:"s_y"`
+	Curve       string `bare:"curve"`
+}
+
+// NonMembershipProof contains values in the proof to be verified
+type NonMembershipProof struct {
+	eC          curves.Point
+	tSigma      curves.Point
+	tRho        curves.Point
+	eD          curves.Point
+	eDInv       curves.Point
+	sSigma      curves.Scalar
+	sRho        curves.Scalar
+	sDeltaSigma curves.Scalar
+	sDeltaRho   curves.Scalar
+	sU          curves.Scalar
+	sV          curves.Scalar
+	sW          curves.Scalar
+	sY          curves.Scalar
+}
+
+// ElementResponse returns the response s_y for the hidden element y
+func (np NonMembershipProof) ElementResponse() curves.Scalar {
+	return np.sY
+}
+
+// Finalize computes values in the proof to be verified.
+func (np *NonMembershipProof) Finalize(acc *Accumulator, pp *ProofParams, pk *PublicKey, challenge curves.Scalar) (*NonMembershipProofFinal, error) {
+	k := pp.k()
+	p := pp.x.Generator()
+	negC := challenge.Neg()
+
+	// R_A = s_u P + s_v K - c E_d
+	capRA := p.Mul(np.sU).Add(k.Mul(np.sV)).Add(np.eD.Mul(negC))
+
+	// R_B = s_u E_{d^-1} + s_w K - c P
+	capRB := np.eDInv.Mul(np.sU).Add(k.Mul(np.sW)).Add(p.Mul(negC))
+
+	// R_σ = s_σ X - c T_σ
+	capRSigma := pp.x.Mul(np.sSigma).Add(np.tSigma.Mul(negC))
+
+	// R_ρ = s_ρ Y - c T_ρ
+	capRRho := pp.y.Mul(np.sRho).Add(np.tRho.Mul(negC))
+
+	// R_δσ = s_y T_σ - s_δσ X
+	capRDeltaSigma := np.tSigma.Mul(np.sY).Add(pp.x.Neg().Mul(np.sDeltaSigma))
+
+	// R_δρ = s_y T_ρ - s_δρ Y
+	capRDeltaRho := np.tRho.Mul(np.sY).Add(pp.y.Neg().Mul(np.sDeltaRho))
+
+	// E_c * s_y + (-s_delta_sigma - s_delta_rho) * Z - s_v * K + c * (E_d - V)
+	lhs := np.eC.SumOfProducts(
+		[]curves.Point{np.eC, pp.z, k, np.eD.Sub(acc.value)},
+		[]curves.Scalar{np.sY, np.sDeltaSigma.Add(np.sDeltaRho).Neg(), np.sV.Neg(), challenge},
+	)
+
+	// (-s_sigma - s_rho) * Z + E_c * c
+	rhs := np.eC.SumOfProducts(
+		[]curves.Point{np.eC, pp.z},
+		[]curves.Scalar{challenge, np.sSigma.Add(np.sRho).Neg()},
+	)
+
+	// Prepare
+	lhsPrep, ok := lhs.(curves.PairingPoint)
+	if !ok {
+		return nil, errors.New("incorrect type conversion")
+	}
+	g2Prep, ok := pk.value.Generator().(curves.PairingPoint)
+	if !ok {
+		return nil, errors.New("incorrect type conversion")
+	}
+	rhsPrep, ok := rhs.(curves.PairingPoint)
+	if !ok {
+		return nil, errors.New("incorrect type conversion")
+	}
+
+	// capRE
+	capRE := g2Prep.MultiPairing(lhsPrep, g2Prep, rhsPrep, pk.value)
+
+	return &NonMembershipProofFinal{
+		acc.value,
+		np.eC,
+		np.eD,
+		np.eDInv,
+		np.tSigma,
+		np.tRho,
+		capRA,
+		capRB,
+		capRE,
+		capRSigma,
+		capRRho,
+		capRDeltaSigma,
+		capRDeltaRho,
+	}, nil
+}
+
+// MarshalBinary converts NonMembershipProof to bytes
+func (np NonMembershipProof) MarshalBinary() ([]byte, error) {
+	tv := &nonMembershipProofMarshal{
+		EC:          np.eC.ToAffineCompressed(),
+		TSigma:      np.tSigma.ToAffineCompressed(),
+		TRho:        np.tRho.ToAffineCompressed(),
+		ED:          np.eD.ToAffineCompressed(),
+		EDInv:       np.eDInv.ToAffineCompressed(),
+		SSigma:      np.sSigma.Bytes(),
+		SRho:        np.sRho.Bytes(),
+		SDeltaSigma: np.sDeltaSigma.Bytes(),
+		SDeltaRho:   np.sDeltaRho.Bytes(),
+		SU:          np.sU.Bytes(),
+		SV:          np.sV.Bytes(),
+		SW:          np.sW.Bytes(),
+		SY:          np.sY.Bytes(),
+		Curve:       np.eC.CurveName(),
+	}
+	return bare.Marshal(tv)
+}
+
+// UnmarshalBinary converts bytes to NonMembershipProof
+func (np *NonMembershipProof) UnmarshalBinary(data []byte) error {
+	if data == nil {
+		return fmt.Errorf("expected non-zero byte sequence")
+	}
+	tv := new(nonMembershipProofMarshal)
+	err := bare.Unmarshal(data, tv)
+	if err != nil {
+		return err
+	}
+	curve := curves.GetCurveByName(tv.Curve)
+	if curve == nil {
+		return fmt.Errorf("invalid curve")
+	}
+	points := make([]curves.Point, 5)
+	for i, bytes := range [][]byte{tv.EC, tv.TSigma, tv.TRho, tv.ED, tv.EDInv} {
+		points[i], err = curve.NewIdentityPoint().FromAffineCompressed(bytes)
+		if err != nil {
+			return err
+		}
+	}
+	scalars := make([]curves.Scalar, 8)
+	for i, bytes := range [][]byte{tv.SSigma, tv.SRho, tv.SDeltaSigma, tv.SDeltaRho, tv.SU, tv.SV, tv.SW, tv.SY} {
+		scalars[i], err = curve.NewScalar().SetBytes(bytes)
+		if err != nil {
+			return err
+		}
+	}
+
+	np.eC = points[0]
+	np.tSigma = points[1]
+	np.tRho = points[2]
+	np.eD = points[3]
+	np.eDInv = points[4]
+	np.sSigma = scalars[0]
+	np.sRho = scalars[1]
+	np.sDeltaSigma = scalars[2]
+	np.sDeltaRho = scalars[3]
+	np.sU = scalars[4]
+	np.sV = scalars[5]
+	np.sW = scalars[6]
+	np.sY = scalars[7]
+
+	return nil
+}
+
+// NonMembershipProofFinal contains values that are input to Fiat-Shamir Heuristic
+type NonMembershipProofFinal struct {
+	accumulator    curves.Point
+	eC             curves.Point
+	eD             curves.Point
+	eDInv          curves.Point
+	tSigma         curves.Point
+	tRho           curves.Point
+	capRA          curves.Point
+	capRB          curves.Point
+	capRE          curves.Scalar
+	capRSigma      curves.Point
+	capRRho        curves.Point
+	capRDeltaSigma curves.Point
+	capRDeltaRho   curves.Point
+}
+
+// GetChallengeBytes returns the bytes hashed by GetChallenge, for combining
+// the proof with other proofs under a single challenge
+func (m NonMembershipProofFinal) GetChallengeBytes() []byte {
+	res := m.accumulator.ToAffineCompressed()
+	res = append(res, m.eC.ToAffineCompressed()...)
+	res = append(res, m.eD.ToAffineCompressed()...)
+	res = append(res, m.eDInv.ToAffineCompressed()...)
+	res = append(res, m.tSigma.ToAffineCompressed()...)
+	res = append(res, m.tRho.ToAffineCompressed()...)
+	res = append(res, m.capRA.ToAffineCompressed()...)
+	res = append(res, m.capRB.ToAffineCompressed()...)
+	res = append(res, m.capRE.Bytes()...)
+	res = append(res, m.capRSigma.ToAffineCompressed()...)
+	res = append(res, m.capRRho.ToAffineCompressed()...)
+	res = append(res, m.capRDeltaSigma.ToAffineCompressed()...)
+	res = append(res, m.capRDeltaRho.ToAffineCompressed()...)
+	return res
+}
+
+// GetChallenge computes Fiat-Shamir Heuristic taking input values of NonMembershipProofFinal
+func (m NonMembershipProofFinal) GetChallenge(curve *curves.PairingCurve) curves.Scalar {
+	return curve.Scalar.Hash(m.GetChallengeBytes())
+}
@@ -0,0 +1,115 @@
+package accumulator
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+)
+
+func TestNonMembershipProof(t *testing.T) {
+	curve := curves.BLS12381(&curves.PointBls12381G1{})
+	sk, _ := new(SecretKey).New(curve, []byte("1234567890"))
+	pk, _ := sk.GetPublicKey(curve)
+
+	elements := hashElements(curve, 0, 7)
+	acc, all := newUniversalAccumulator(t, curve, sk, elements)
+
+	y := curve.Scalar.Hash([]byte("not a member"))
+	wit, err := new(NonMembershipWitness).New(y, acc, sk, all)
+	require.NoError(t, err)
+
+	params, err := new(ProofParams).New(curve, pk, []byte("entropy"))
+	require.NoError(t, err)
+
+	npc, err := new(NonMembershipProofCommitting).New(wit, acc, params, pk)
+	require.NoError(t, err)
+	challenge := curve.Scalar.Hash(npc.GetChallengeBytes())
+	proof := npc.GenProof(challenge)
+
+	data, err := proof.MarshalBinary()
+	require.NoError(t, err)
+	received := new(NonMembershipProof)
+	require.NoError(t, received.UnmarshalBinary(data))
+
+	finalProof, err := received.Finalize(acc, params, pk, challenge)
+	require.NoError(t, err)
+	require.Equal(t, 0, challenge.Cmp(finalProof.GetChallenge(curve)))
+
+	// Proofs against another accumulator state fail
+	_, coefficients, err := acc.Update(sk, hashElements(curve, 100, 102), []Element{})
+	require.NoError(t, err)
+	finalProof, err = received.Finalize(acc, params, pk, challenge)
+	require.NoError(t, err)
+	require.NotEqual(t, 0, challenge.Cmp(finalProof.GetChallenge(curve)))
+
+	// ... until the witness catches up
+	_, err = wit.BatchUpdate(hashElements(curve, 100, 102), []Element{}, coefficients)
+	require.NoError(t, err)
+	npc, err = new(NonMembershipProofCommitting).New(wit, acc, params, pk)
+	require.NoError(t, err)
+	challenge = curve.Scalar.Hash(npc.GetChallengeBytes())
+	finalProof, err = npc.GenProof(challenge).Finalize(acc, params, pk, challenge)
+	require.NoError(t, err)
+	require.Equal(t, 0, challenge.Cmp(finalProof.GetChallenge(curve)))
+}
+
+func TestNonMembershipProofForgedWitness(t *testing.T) {
+	curve := curves.BLS12381(&curves.PointBls12381G1{})
+	sk, _ := new(SecretKey).New(curve, []byte("1234567890"))
+	pk, _ := sk.GetPublicKey(curve)
+
+	elements := hashElements(curve, 0, 7)
+	acc, all := newUniversalAccumulator(t, curve, sk, elements)
+	wit, err := new(NonMembershipWitness).New(curve.Scalar.Hash([]byte("not a member")), acc, sk, all)
+	require.NoError(t, err)
+	params, err := new(ProofParams).New(curve, pk, []byte("entropy"))
+	require.NoError(t, err)
+
+	// A member claiming another element's witness
+	forged := &NonMembershipWitness{wit.c, wit.d, elements[0]}
+	npc, err := new(NonMembershipProofCommitting).New(forged, acc, params, pk)
+	require.NoError(t, err)
+	challenge := curve.Scalar.Hash(npc.GetChallengeBytes())
+	finalProof, err := npc.GenProof(challenge).Finalize(acc, params, pk, challenge)
+	require.NoError(t, err)
+	require.NotEqual(t, 0, challenge.Cmp(finalProof.GetChallenge(curve)))
+}
+
+func TestProofsShareElementBlinding(t *testing.T) {
+	curve := curves.BLS12381(&curves.PointBls12381G1{})
+	sk, _ := new(SecretKey).New(curve, []byte("1234567890"))
+	pk, _ := sk.GetPublicKey(curve)
+	params, err := new(ProofParams).New(curve, pk, []byte("entropy"))
+	require.NoError(t, err)
+
+	// y is in the allow list and not in the revocation list
+	y := curve.Scalar.Hash([]byte("credential id"))
+	allowed, err := new(Accumulator).WithElements(curve, sk, append(hashElements(curve, 0, 5), y))
+	require.NoError(t, err)
+	mw, err := new(MembershipWitness).New(y, allowed, sk)
+	require.NoError(t, err)
+	revoked, all := newUniversalAccumulator(t, curve, sk, hashElements(curve, 5, 10))
+	nmw, err := new(NonMembershipWitness).New(y, revoked, sk, all)
+	require.NoError(t, err)
+
+	blinding := curve.Scalar.Random(crand.Reader)
+	mpc, err := new(MembershipProofCommitting).NewWithBlinding(mw, allowed, params, pk, blinding)
+	require.NoError(t, err)
+	npc, err := new(NonMembershipProofCommitting).NewWithBlinding(nmw, revoked, params, pk, blinding)
+	require.NoError(t, err)
+	challenge := curve.Scalar.Hash(append(mpc.GetChallengeBytes(), npc.GetChallengeBytes()...))
+
+	mp := mpc.GenProof(challenge)
+	np := npc.GenProof(challenge)
+	require.Equal(t, 0, mp.ElementResponse().Cmp(np.ElementResponse()))
+
+	mFinal, err := mp.Finalize(allowed, params, pk, challenge)
+	require.NoError(t, err)
+	nFinal, err := np.Finalize(revoked, params, pk, challenge)
+	require.NoError(t, err)
+	computed := curve.Scalar.Hash(append(mFinal.GetChallengeBytes(), nFinal.GetChallengeBytes()...))
+	require.Equal(t, 0, challenge.Cmp(computed))
+}
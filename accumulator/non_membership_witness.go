@@ -0,0 +1,208 @@
+package accumulator
+
+import (
+	"errors"
+	"fmt"
+
+	"git.sr.ht/~sircmpwn/go-bare"
+
+	"github.com/go-sonr/crypto/core/curves"
+)
+
+// NonMembershipWitness contains the witness c, the value d and the value y
+// not accumulated with respect to the accumulator state.
+type NonMembershipWitness struct {
+	c curves.Point
+	d curves.Scalar
+	y curves.Scalar
+}
+
+// New creates a new non-membership witness for y as described in section 2 of <https://eprint.iacr.org/2020/777.pdf>.
+// elements must be all values accumulated in acc, including the UniversalElements
+// the accumulator was created with.
+// d = prod(y_i - y), C = (V - d*P) / (y + α)
+func (nmw *NonMembershipWitness) New(y Element, acc *Accumulator, sk *SecretKey, elements []Element) (*NonMembershipWitness, error) {
+	if acc.value == nil || acc.value.IsIdentity() {
+		return nil, fmt.Errorf("value of accumulator should not be nil")
+	}
+	if sk.value == nil || sk.value.IsZero() {
+		return nil, fmt.Errorf("secret key should not be nil")
+	}
+	if y == nil || y.IsZero() {
+		return nil, fmt.Errorf("y should not be nil")
+	}
+
+	// V = prod(y_i + α) * P
+	f, err := sk.BatchAdditions(elements)
+	if err != nil {
+		return nil, err
+	}
+	p := acc.value.Generator()
+	if !p.Mul(f).Equal(acc.value) {
+		return nil, fmt.Errorf("elements do not match the accumulator")
+	}
+
+	// d = prod(y_i - y), which is zero if y has been accumulated
+	d, err := dad(elements, y)
+	if err != nil {
+		return nil, err
+	}
+	if d.IsZero() {
+		return nil, fmt.Errorf("y is a member of the accumulator")
+	}
+
+	// 1/(y + α)
+	t, err := y.Add(sk.value).Invert()
+	if err != nil {
+		return nil, err
+	}
+	nmw.c = acc.value.Sub(p.Mul(d)).Mul(t)
+	nmw.d = d
+	nmw.y = y.Add(y.Zero())
+	return nmw, nil
+}
+
+// Verify the NonMembershipWitness nmw is a valid witness as per section 2 in
+// <https://eprint.iacr.org/2020/777>
+func (nmw NonMembershipWitness) Verify(pk *PublicKey, acc *Accumulator) error {
+	if nmw.c == nil || nmw.d == nil || nmw.y == nil || nmw.d.IsZero() || nmw.y.IsZero() {
+		return fmt.Errorf("c, d and y should not be nil")
+	}
+
+	if pk.value == nil || pk.value.IsIdentity() {
+		return fmt.Errorf("invalid public key")
+	}
+	if acc.value == nil || acc.value.IsIdentity() {
+		return fmt.Errorf("accumulator value should not be nil")
+	}
+
+	// tildeP is a G2 generator.
+	g2, ok := pk.value.Generator().(curves.PairingPoint)
+	if !ok {
+		return errors.New("incorrect type conversion")
+	}
+
+	// y*tildeP + tildeQ
+	p, ok := g2.Mul(nmw.y).Add(pk.value).(curves.PairingPoint)
+	if !ok {
+		return errors.New("incorrect type conversion")
+	}
+
+	// Prepare
+	witness, ok := nmw.c.(curves.PairingPoint)
+	if !ok {
+		return errors.New("incorrect type conversion")
+	}
+	// d*P - V
+	v, ok := acc.value.Generator().Mul(nmw.d).Sub(acc.value).(curves.PairingPoint)
+	if !ok {
+		return errors.New("incorrect type conversion")
+	}
+
+	// Check e(witness, y*tildeP + tildeQ) * e(d*P - V, tildeP) == Identity
+	result := p.MultiPairing(witness, p, v, g2)
+	if !result.IsOne() {
+		return fmt.Errorf("invalid result")
+	}
+
+	return nil
+}
+
+// ApplyDelta returns C' = dA(y)/dD(y)*C + 1/dD(y) * <Gamma_y, Omega>
+// and d' = dA(y)/dD(y)*d according to the witness update protocol described
+// in section 4 of https://eprint.iacr.org/2020/777.pdf
+func (nmw *NonMembershipWitness) ApplyDelta(delta *Delta) (*NonMembershipWitness, error) {
+	if nmw.c == nil || nmw.d == nil || nmw.y == nil || delta == nil {
+		return nil, fmt.Errorf("y, c, d or delta should not be nil")
+	}
+	// If this fails, then this value was added.
+	if delta.d.IsZero() {
+		return nil, fmt.Errorf("y has been added to the accumulator")
+	}
+
+	// C' = dA(y)/dD(y)*C + 1/dD(y) * <Gamma_y, Omega>
+	nmw.c = nmw.c.Mul(delta.d).Add(delta.p)
+	// d' = dA(y)/dD(y)*d
+	nmw.d = nmw.d.Mul(delta.d)
+	return nmw, nil
+}
+
+// BatchUpdate performs batch update as described in section 4
+func (nmw *NonMembershipWitness) BatchUpdate(additions []Element, deletions []Element, coefficients []Coefficient) (*NonMembershipWitness, error) {
+	delta, err := evaluateDelta(nmw.y, additions, deletions, coefficients)
+	if err != nil {
+		return nil, err
+	}
+	return nmw.ApplyDelta(delta)
+}
+
+// MultiBatchUpdate performs multi-batch update using epoch as described in section 4.2
+func (nmw *NonMembershipWitness) MultiBatchUpdate(A [][]Element, D [][]Element, C [][]Coefficient) (*NonMembershipWitness, error) {
+	delta, err := evaluateDeltas(nmw.y, A, D, C)
+	if err != nil {
+		return nil, fmt.Errorf("evaluateDeltas fails")
+	}
+	return nmw.ApplyDelta(delta)
+}
+
+// ApplyUpdate performs the batch update described by um, which may cover several epochs
+func (nmw *NonMembershipWitness) ApplyUpdate(um *UpdateMessage) (*NonMembershipWitness, error) {
+	if um == nil {
+		return nil, fmt.Errorf("update message should not be nil")
+	}
+	return nmw.BatchUpdate(um.additions, um.deletions, um.coefficients)
+}
+
+// MarshalBinary converts a non-membership witness to bytes
+func (nmw NonMembershipWitness) MarshalBinary() ([]byte, error) {
+	if nmw.c == nil || nmw.d == nil || nmw.y == nil {
+		return nil, fmt.Errorf("c, d and y value should not be nil")
+	}
+
+	result := append(nmw.c.ToAffineCompressed(), nmw.d.Bytes()...)
+	result = append(result, nmw.y.Bytes()...)
+	tv := &structMarshal{
+		Value: result,
+		Curve: nmw.c.CurveName(),
+	}
+	return bare.Marshal(tv)
+}
+
+// UnmarshalBinary converts bytes into NonMembershipWitness
+func (nmw *NonMembershipWitness) UnmarshalBinary(data []byte) error {
+	if data == nil {
+		return fmt.Errorf("input data should not be nil")
+	}
+	tv := new(structMarshal)
+	err := bare.Unmarshal(data, tv)
+	if err != nil {
+		return err
+	}
+	curve := curves.GetCurveByName(tv.Curve)
+	if curve == nil {
+		return fmt.Errorf("invalid curve")
+	}
+
+	ptLength := len(curve.Point.ToAffineCompressed())
+	scLength := len(curve.Scalar.Bytes())
+	expectedLength := ptLength + 2*scLength
+	if len(tv.Value) != expectedLength {
+		return fmt.Errorf("invalid byte sequence")
+	}
+	cValue, err := curve.Point.FromAffineCompressed(tv.Value[:ptLength])
+	if err != nil {
+		return err
+	}
+	dValue, err := curve.Scalar.SetBytes(tv.Value[ptLength : ptLength+scLength])
+	if err != nil {
+		return err
+	}
+	yValue, err := curve.Scalar.SetBytes(tv.Value[ptLength+scLength:])
+	if err != nil {
+		return err
+	}
+	nmw.c = cValue
+	nmw.d = dValue
+	nmw.y = yValue
+	return nil
+}
@@ -0,0 +1,105 @@
+package accumulator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+)
+
+func newUniversalAccumulator(t *testing.T, curve *curves.PairingCurve, sk *SecretKey, elements []Element) (*Accumulator, []Element) {
+	acc, err := new(Accumulator).NewUniversal(curve, sk, 100)
+	require.NoError(t, err)
+	acc, err = acc.AddElements(sk, elements)
+	require.NoError(t, err)
+	return acc, append(UniversalElements(curve, 100), elements...)
+}
+
+func Test_Non_Membership(t *testing.T) {
+	curve := curves.BLS12381(&curves.PointBls12381G1{})
+	sk, _ := new(SecretKey).New(curve, []byte("1234567890"))
+	pk, _ := sk.GetPublicKey(curve)
+
+	elements := hashElements(curve, 0, 7)
+	acc, all := newUniversalAccumulator(t, curve, sk, elements)
+	require.False(t, acc.value.Equal(curve.NewG1GeneratorPoint()))
+
+	y := curve.Scalar.Hash([]byte("not a member"))
+	wit, err := new(NonMembershipWitness).New(y, acc, sk, all)
+	require.NoError(t, err)
+	require.NoError(t, wit.Verify(pk, acc))
+
+	// Members and fixed elements have no non-membership witness
+	_, err = new(NonMembershipWitness).New(elements[3], acc, sk, all)
+	require.Error(t, err)
+	_, err = new(NonMembershipWitness).New(all[0], acc, sk, all)
+	require.Error(t, err)
+
+	// The manager must know every accumulated element
+	_, err = new(NonMembershipWitness).New(y, acc, sk, elements)
+	require.Error(t, err)
+
+	// Test wrong cases, forge a wrong witness
+	wrongWit := NonMembershipWitness{wit.c, wit.d.Add(curve.Scalar.One()), wit.y}
+	require.Error(t, wrongWit.Verify(pk, acc))
+	wrongWit = NonMembershipWitness{wit.c, wit.d, elements[3]}
+	require.Error(t, wrongWit.Verify(pk, acc))
+	wrongWit = NonMembershipWitness{wit.c, curve.Scalar.Zero(), wit.y}
+	require.Error(t, wrongWit.Verify(pk, acc))
+
+	data, err := wit.MarshalBinary()
+	require.NoError(t, err)
+	newWit := new(NonMembershipWitness)
+	require.NoError(t, newWit.UnmarshalBinary(data))
+	require.True(t, wit.c.Equal(newWit.c))
+	require.Equal(t, 0, wit.d.Cmp(newWit.d))
+	require.Equal(t, 0, wit.y.Cmp(newWit.y))
+	require.Error(t, newWit.UnmarshalBinary(data[:len(data)-1]))
+}
+
+func Test_Non_Membership_Batch_Update(t *testing.T) {
+	curve := curves.BLS12381(&curves.PointBls12381G1{})
+	sk, _ := new(SecretKey).New(curve, []byte("1234567890"))
+	pk, _ := sk.GetPublicKey(curve)
+
+	elements := hashElements(curve, 0, 10)
+	acc, all := newUniversalAccumulator(t, curve, sk, elements)
+
+	y := curve.Scalar.Hash([]byte("not a member"))
+	wit, err := new(NonMembershipWitness).New(y, acc, sk, all)
+	require.NoError(t, err)
+	wit2, err := new(NonMembershipWitness).New(y, acc, sk, all)
+	require.NoError(t, err)
+
+	additions := hashElements(curve, 100, 103)
+	deletions := elements[2:5]
+	_, coefficients, err := acc.Update(sk, additions, deletions)
+	require.NoError(t, err)
+	_, err = wit.BatchUpdate(additions, deletions, coefficients)
+	require.NoError(t, err)
+	require.NoError(t, wit.Verify(pk, acc))
+	update1, err := new(UpdateMessage).New(additions, deletions, coefficients)
+	require.NoError(t, err)
+
+	_, coefficients, err = acc.Update(sk, []Element{}, elements[5:6])
+	require.NoError(t, err)
+	update2, err := new(UpdateMessage).New([]Element{}, elements[5:6], coefficients)
+	require.NoError(t, err)
+	_, err = wit.ApplyUpdate(update2)
+	require.NoError(t, err)
+	require.NoError(t, wit.Verify(pk, acc))
+
+	// Catching up on both epochs at once
+	combined, err := CombineUpdates([]*UpdateMessage{update1, update2})
+	require.NoError(t, err)
+	_, err = wit2.ApplyUpdate(combined)
+	require.NoError(t, err)
+	require.NoError(t, wit2.Verify(pk, acc))
+
+	// Adding y invalidates its non-membership witness
+	_, coefficients, err = acc.Update(sk, []Element{y}, []Element{})
+	require.NoError(t, err)
+	_, err = wit.BatchUpdate([]Element{y}, []Element{}, coefficients)
+	require.Error(t, err)
+}
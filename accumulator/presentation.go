@@ -0,0 +1,166 @@
+package accumulator
+
+import (
+	crand "crypto/rand"
+	"errors"
+	"fmt"
+
+	"git.sr.ht/~sircmpwn/go-bare"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
+)
+
+const (
+	presentationDomain = "go-sonr accumulator presentation v1"
+
+	// PresentationNonceSize is the size of the nonces made by NewPresentationNonce
+	PresentationNonceSize = 32
+
+	// MinPresentationNonceSize is the smallest nonce a presentation accepts
+	MinPresentationNonceSize = 16
+)
+
+// Presentation is a zero knowledge proof that the holder of a membership
+// witness knows an element of an accumulator, bound to the nonce of a
+// verifier or to the transcript of a larger presentation so that it cannot
+// be replayed. The element itself is not revealed.
+type Presentation struct {
+	proof     *MembershipProof
+	challenge curves.Scalar
+}
+
+type presentationMarshal struct {
+	Proof     []byte `bare:"proof"`
+	Challenge []byte `bare:"challenge"`
+	Curve     string `bare:"curve"`
+}
+
+// NewPresentationNonce returns a fresh nonce for a verifier to send to a holder
+func NewPresentationNonce() ([]byte, error) {
+	nonce := make([]byte, PresentationNonceSize)
+	if _, err := crand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return nonce, nil
+}
+
+func nonceTranscript(nonce []byte) (*transcript.Transcript, error) {
+	if len(nonce) < MinPresentationNonceSize {
+		return nil, fmt.Errorf("nonce must be at least %d bytes", MinPresentationNonceSize)
+	}
+	tr := transcript.New(presentationDomain)
+	tr.AppendMessage([]byte("nonce"), nonce)
+	return tr, nil
+}
+
+// NewPresentation proves to the verifier that sent nonce that the element
+// of witness is in acc
+func NewPresentation(witness *MembershipWitness, acc *Accumulator, pp *ProofParams, pk *PublicKey, nonce []byte) (*Presentation, error) {
+	tr, err := nonceTranscript(nonce)
+	if err != nil {
+		return nil, err
+	}
+	mpc, err := new(MembershipProofCommitting).New(witness, acc, pp, pk)
+	if err != nil {
+		return nil, err
+	}
+	return mpc.Present(tr)
+}
+
+// Present completes the proof with a challenge drawn from tr, after the
+// proof commitments. The transcript carries whatever else the presentation
+// proves, so that every part is bound to the same challenge.
+func (mpc *MembershipProofCommitting) Present(tr *transcript.Transcript) (*Presentation, error) {
+	if tr == nil {
+		return nil, fmt.Errorf("transcript should not be nil")
+	}
+	tr.AppendMessage([]byte("accumulator membership"), mpc.GetChallengeBytes())
+	challenge, err := tr.ChallengeScalar([]byte("challenge"), mpc.witnessValue)
+	if err != nil {
+		return nil, err
+	}
+	return &Presentation{proof: mpc.GenProof(challenge), challenge: challenge}, nil
+}
+
+// Verify checks a presentation made for nonce against acc
+func (p *Presentation) Verify(acc *Accumulator, pp *ProofParams, pk *PublicKey, nonce []byte) error {
+	tr, err := nonceTranscript(nonce)
+	if err != nil {
+		return err
+	}
+	return p.VerifyTranscript(tr, acc, pp, pk)
+}
+
+// VerifyTranscript checks a presentation made with Present on a transcript
+// in the same state as tr
+func (p *Presentation) VerifyTranscript(tr *transcript.Transcript, acc *Accumulator, pp *ProofParams, pk *PublicKey) error {
+	if tr == nil || acc == nil || pp == nil || pk == nil || p.proof == nil || p.challenge == nil {
+		return fmt.Errorf("presentation, transcript and keys should not be nil")
+	}
+	if p.proof.eC.IsIdentity() {
+		return errors.New("invalid presentation")
+	}
+	final, err := p.proof.Finalize(acc, pp, pk, p.challenge)
+	if err != nil {
+		return err
+	}
+	tr.AppendMessage([]byte("accumulator membership"), final.GetChallengeBytes())
+	challenge, err := tr.ChallengeScalar([]byte("challenge"), p.challenge)
+	if err != nil {
+		return err
+	}
+	if challenge.Cmp(p.challenge) != 0 {
+		return errors.New("invalid presentation")
+	}
+	return nil
+}
+
+// ElementResponse returns the response for the hidden element, which equals
+// the response of another proof on the same element under the same
+// challenge when both used the same blinding
+func (p *Presentation) ElementResponse() curves.Scalar {
+	return p.proof.ElementResponse()
+}
+
+// MarshalBinary converts a Presentation to bytes
+func (p Presentation) MarshalBinary() ([]byte, error) {
+	if p.proof == nil || p.challenge == nil {
+		return nil, fmt.Errorf("presentation is empty")
+	}
+	proof, err := p.proof.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return bare.Marshal(&presentationMarshal{
+		Proof:     proof,
+		Challenge: p.challenge.Bytes(),
+		Curve:     p.proof.eC.CurveName(),
+	})
+}
+
+// UnmarshalBinary converts bytes to a Presentation
+func (p *Presentation) UnmarshalBinary(data []byte) error {
+	if data == nil {
+		return fmt.Errorf("expected non-zero byte sequence")
+	}
+	tv := new(presentationMarshal)
+	if err := bare.Unmarshal(data, tv); err != nil {
+		return err
+	}
+	curve := curves.GetCurveByName(tv.Curve)
+	if curve == nil {
+		return fmt.Errorf("invalid curve")
+	}
+	proof := new(MembershipProof)
+	if err := proof.UnmarshalBinary(tv.Proof); err != nil {
+		return err
+	}
+	challenge, err := curve.NewScalar().SetBytes(tv.Challenge)
+	if err != nil {
+		return err
+	}
+	p.proof = proof
+	p.challenge = challenge
+	return nil
+}
@@ -0,0 +1,85 @@
+package accumulator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
+)
+
+func TestPresentation(t *testing.T) {
+	curve := curves.BLS12381(&curves.PointBls12381G1{})
+	sk, err := new(SecretKey).New(curve, []byte("1234567890"))
+	require.NoError(t, err)
+	pk, err := sk.GetPublicKey(curve)
+	require.NoError(t, err)
+	elements := []Element{
+		curve.Scalar.Hash([]byte("3")),
+		curve.Scalar.Hash([]byte("4")),
+		curve.Scalar.Hash([]byte("5")),
+	}
+	acc, err := new(Accumulator).WithElements(curve, sk, elements)
+	require.NoError(t, err)
+	wit, err := new(MembershipWitness).New(elements[1], acc, sk)
+	require.NoError(t, err)
+	params, err := new(ProofParams).New(curve, pk, []byte("entropy"))
+	require.NoError(t, err)
+
+	nonce, err := NewPresentationNonce()
+	require.NoError(t, err)
+	p, err := NewPresentation(wit, acc, params, pk, nonce)
+	require.NoError(t, err)
+	require.NoError(t, p.Verify(acc, params, pk, nonce))
+
+	data, err := p.MarshalBinary()
+	require.NoError(t, err)
+	decoded := new(Presentation)
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	require.NoError(t, decoded.Verify(acc, params, pk, nonce))
+
+	// bound to the nonce
+	other, err := NewPresentationNonce()
+	require.NoError(t, err)
+	require.Error(t, p.Verify(acc, params, pk, other))
+	_, err = NewPresentation(wit, acc, params, pk, nonce[:8])
+	require.Error(t, err)
+
+	// and to the accumulator
+	removed, err := new(Accumulator).WithElements(curve, sk, elements)
+	require.NoError(t, err)
+	_, err = removed.Remove(sk, elements[1])
+	require.NoError(t, err)
+	require.Error(t, p.Verify(removed, params, pk, nonce))
+	p, err = NewPresentation(wit, removed, params, pk, nonce)
+	require.NoError(t, err)
+	require.Error(t, p.Verify(removed, params, pk, nonce))
+}
+
+func TestPresentationTranscript(t *testing.T) {
+	curve := curves.BLS12381(&curves.PointBls12381G1{})
+	sk, err := new(SecretKey).New(curve, []byte("1234567890"))
+	require.NoError(t, err)
+	pk, err := sk.GetPublicKey(curve)
+	require.NoError(t, err)
+	element := curve.Scalar.Hash([]byte("credential id"))
+	acc, err := new(Accumulator).WithElements(curve, sk, []Element{element})
+	require.NoError(t, err)
+	wit, err := new(MembershipWitness).New(element, acc, sk)
+	require.NoError(t, err)
+	params, err := new(ProofParams).New(curve, pk, []byte("entropy"))
+	require.NoError(t, err)
+
+	context := func(session string) *transcript.Transcript {
+		tr := transcript.New("credential presentation")
+		tr.AppendMessage([]byte("session"), []byte(session))
+		return tr
+	}
+	mpc, err := new(MembershipProofCommitting).New(wit, acc, params, pk)
+	require.NoError(t, err)
+	p, err := mpc.Present(context("session 1"))
+	require.NoError(t, err)
+	require.NoError(t, p.VerifyTranscript(context("session 1"), acc, params, pk))
+	require.Error(t, p.VerifyTranscript(context("session 2"), acc, params, pk))
+}
@@ -24,7 +24,9 @@ type proofParamsMarshal struct {
 	Curve string `bare:"curve"`
 }
 
-// ProofParams contains four distinct public generators of G1 - X, Y, Z
+const proofParamsKDomain = "go-sonr accumulator proof params K v1"
+
+// ProofParams contains four distinct public generators of G1 - X, Y, Z and K
 type ProofParams struct {
 	x, y, z curves.Point
 }
@@ -49,6 +51,15 @@ func (p *ProofParams) New(curve *curves.PairingCurve, pk *PublicKey, entropy []b
 	return p, nil
 }
 
+// k returns the generator K of G1 used by non-membership proofs.
+// It is derived from X, Y and Z so the parameters keep their encoding.
+func (p *ProofParams) k() curves.Point {
+	data := append([]byte(proofParamsKDomain), p.x.ToAffineCompressed()...)
+	data = append(data, p.y.ToAffineCompressed()...)
+	data = append(data, p.z.ToAffineCompressed()...)
+	return p.x.Hash(data)
+}
+
 // MarshalBinary converts ProofParams to bytes
 func (p *ProofParams) MarshalBinary() ([]byte, error) {
 	if p.x == nil || p.y == nil || p.z == nil {
@@ -129,6 +140,23 @@ func (mpc *MembershipProofCommitting) New(
 	pp *ProofParams,
 	pk *PublicKey,
 ) (*MembershipProofCommitting, error) {
+	return mpc.NewWithBlinding(witness, acc, pp, pk, witness.y.Random(crand.Reader))
+}
+
+// NewWithBlinding initiates values of MembershipProofCommitting using blinding
+// as the blinding factor r_y of the witness value y. Using the blinding factor
+// of the same message in another proof under the same challenge yields the same
+// response for y, which binds the element to e.g. a signed credential attribute.
+func (mpc *MembershipProofCommitting) NewWithBlinding(
+	witness *MembershipWitness,
+	acc *Accumulator,
+	pp *ProofParams,
+	pk *PublicKey,
+	blinding curves.Scalar,
+) (*MembershipProofCommitting, error) {
+	if witness == nil || witness.c == nil || witness.y == nil || blinding == nil {
+		return nil, fmt.Errorf("witness and blinding should not be nil")
+	}
 	// Randomly select σ, ρ
 	sigma := witness.y.Random(crand.Reader)
 	rho := witness.y.Random(crand.Reader)
@@ -157,7 +185,7 @@ func (mpc *MembershipProofCommitting) New(
 	deltaRho = deltaRho.Mul(rho)
 
 	// Randomly pick r_σ,r_ρ,r_δσ,r_δρ
-	rY := witness.y.Random(crand.Reader)
+	rY := blinding
 	rSigma := witness.y.Random(crand.Reader)
 	rRho := witness.y.Random(crand.Reader)
 	rDeltaSigma := witness.y.Random(crand.Reader)
@@ -325,6 +353,11 @@ type MembershipProof struct {
 	sY          curves.Scalar
 }
 
+// ElementResponse returns the response s_y for the hidden element y
+func (mp MembershipProof) ElementResponse() curves.Scalar {
+	return mp.sY
+}
+
 // Finalize computes values in the proof to be verified.
 func (mp *MembershipProof) Finalize(acc *Accumulator, pp *ProofParams, pk *PublicKey, challenge curves.Scalar) (*MembershipProofFinal, error) {
 	// R_σ = s_δ X + c T_σ
@@ -355,33 +388,17 @@ func (mp *MembershipProof) Finalize(acc *Accumulator, pp *ProofParams, pk *Publi
 	g2 := pk.value.Generator()
 
 	// Compute capRE, the pairing
-	// E_c * s_y
-	eCsY := mp.eC.Mul(mp.sY)
-
-	// (-s_delta_sigma - s_delta_rho) * Z
-	exp := mp.sDeltaSigma
-	exp = exp.Add(mp.sDeltaRho)
-	exp = exp.Neg()
-	expZ := pp.z.Mul(exp)
-
-	// (-c) * V
-	exp = challenge.Neg()
-	expV := acc.value.Mul(exp)
-
 	// E_c * s_y + (-s_delta_sigma - s_delta_rho) * Z + (-c) * V
-	lhs := eCsY.Add(expZ).Add(expV)
-
-	// (-s_sigma - s_rho) * Z
-	exp = mp.sSigma
-	exp = exp.Add(mp.sRho)
-	exp = exp.Neg()
-	expZ2 := pp.z.Mul(exp)
-
-	// E_c * c
-	cEc := mp.eC.Mul(challenge)
+	lhs := mp.eC.SumOfProducts(
+		[]curves.Point{mp.eC, pp.z, acc.value},
+		[]curves.Scalar{mp.sY, mp.sDeltaSigma.Add(mp.sDeltaRho).Neg(), challenge.Neg()},
+	)
 
 	// (-s_sigma - s_rho) * Z + E_c * c
-	rhs := cEc.Add(expZ2)
+	rhs := mp.eC.SumOfProducts(
+		[]curves.Point{mp.eC, pp.z},
+		[]curves.Scalar{challenge, mp.sSigma.Add(mp.sRho).Neg()},
+	)
 
 	// Prepare
 	lhsPrep, ok := lhs.(curves.PairingPoint)
@@ -502,8 +519,9 @@ type MembershipProofFinal struct {
 	capRDeltaRho   curves.Point
 }
 
-// GetChallenge computes Fiat-Shamir Heuristic taking input values of MembershipProofFinal
-func (m MembershipProofFinal) GetChallenge(curve *curves.PairingCurve) curves.Scalar {
+// GetChallengeBytes returns the bytes hashed by GetChallenge, for combining
+// the proof with other proofs under a single challenge
+func (m MembershipProofFinal) GetChallengeBytes() []byte {
 	res := m.accumulator.ToAffineCompressed()
 	res = append(res, m.eC.ToAffineCompressed()...)
 	res = append(res, m.tSigma.ToAffineCompressed()...)
@@ -513,6 +531,11 @@ func (m MembershipProofFinal) GetChallenge(curve *curves.PairingCurve) curves.Sc
 	res = append(res, m.capRRho.ToAffineCompressed()...)
 	res = append(res, m.capRDeltaSigma.ToAffineCompressed()...)
 	res = append(res, m.capRDeltaRho.ToAffineCompressed()...)
-	challenge := curve.Scalar.Hash(res)
+	return res
+}
+
+// GetChallenge computes Fiat-Shamir Heuristic taking input values of MembershipProofFinal
+func (m MembershipProofFinal) GetChallenge(curve *curves.PairingCurve) curves.Scalar {
+	challenge := curve.Scalar.Hash(m.GetChallengeBytes())
 	return challenge
 }
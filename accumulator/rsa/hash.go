@@ -0,0 +1,42 @@
+package rsa
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math/big"
+)
+
+const (
+	elementDomain   = "go-sonr rsa accumulator element v1"
+	challengeDomain = "go-sonr rsa accumulator PoE v1"
+	primeBits       = 256
+)
+
+// HashToPrime maps data to a 256-bit prime, the value an element is accumulated as
+func HashToPrime(data []byte) *big.Int {
+	return hashToPrime(elementDomain, data)
+}
+
+// hashToPrime hashes data with an increasing counter until the
+// result with its top and bottom bits set is a probable prime
+func hashToPrime(domain string, data ...[]byte) *big.Int {
+	h := sha256.New()
+	var counter [8]byte
+	for i := uint64(0); ; i++ {
+		h.Reset()
+		_, _ = h.Write([]byte(domain))
+		binary.BigEndian.PutUint64(counter[:], i)
+		_, _ = h.Write(counter[:])
+		for _, d := range data {
+			binary.BigEndian.PutUint64(counter[:], uint64(len(d)))
+			_, _ = h.Write(counter[:])
+			_, _ = h.Write(d)
+		}
+		x := new(big.Int).SetBytes(h.Sum(nil))
+		x.SetBit(x, primeBits-1, 1)
+		x.SetBit(x, 0, 1)
+		if x.ProbablyPrime(20) {
+			return x
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package rsa
+
+import (
+	"fmt"
+	"math/big"
+
+	"git.sr.ht/~sircmpwn/go-bare"
+
+	crypto "github.com/go-sonr/crypto/core"
+	"github.com/go-sonr/crypto/internal"
+)
+
+// ExpProof is a Wesolowski proof of exponentiation that u^x = w for a
+// public integer x, see section 3.1 of https://eprint.iacr.org/2018/1188.pdf.
+// Verifying it takes two exponentiations by 256-bit exponents instead of one
+// by x, which grows with the number of elements.
+type ExpProof struct {
+	q *big.Int
+}
+
+// proveExp computes Q = u^{floor(x/l)} for the challenge prime l = H(N, u, x, w)
+func proveExp(pk *PublicKey, u, x, w *big.Int) *ExpProof {
+	l := expChallenge(pk, u, x, w)
+	return &ExpProof{q: new(big.Int).Exp(u, new(big.Int).Quo(x, l), pk.n)}
+}
+
+// Verify checks that u^x = w by Q^l * u^{x mod l} = w
+func (p *ExpProof) Verify(pk *PublicKey, u, x, w *big.Int) error {
+	if p == nil || pk == nil || crypto.AnyNil(p.q, u, x, w, pk.n) {
+		return internal.ErrNilArguments
+	}
+	for _, v := range []*big.Int{p.q, u, w} {
+		if err := inUnits(v, pk.n); err != nil {
+			return err
+		}
+	}
+	if x.Sign() <= 0 {
+		return fmt.Errorf("exponent must be positive")
+	}
+	l := expChallenge(pk, u, x, w)
+	r := new(big.Int).Mod(x, l)
+	lhs := new(big.Int).Exp(p.q, l, pk.n)
+	lhs.Mul(lhs, new(big.Int).Exp(u, r, pk.n))
+	lhs.Mod(lhs, pk.n)
+	if lhs.Cmp(w) != 0 {
+		return fmt.Errorf("invalid proof of exponentiation")
+	}
+	return nil
+}
+
+// MarshalBinary converts ExpProof to bytes
+func (p ExpProof) MarshalBinary() ([]byte, error) {
+	if p.q == nil {
+		return nil, fmt.Errorf("proof cannot be nil")
+	}
+	return bare.Marshal(&valueMarshal{Value: p.q.Bytes()})
+}
+
+// UnmarshalBinary sets ExpProof from bytes
+func (p *ExpProof) UnmarshalBinary(data []byte) error {
+	tv := new(valueMarshal)
+	if err := bare.Unmarshal(data, tv); err != nil {
+		return err
+	}
+	p.q = new(big.Int).SetBytes(tv.Value)
+	return nil
+}
+
+func expChallenge(pk *PublicKey, u, x, w *big.Int) *big.Int {
+	return hashToPrime(challengeDomain, pk.n.Bytes(), pk.g.Bytes(), u.Bytes(), x.Bytes(), w.Bytes())
+}
@@ -0,0 +1,261 @@
+// Package rsa implements a dynamic accumulator in the group of quadratic residues
+// modulo an RSA modulus N, a group of unknown order, as described in
+// https://eprint.iacr.org/2018/1188.pdf (Boneh, Bünz and Fisch).
+// It needs no pairing-friendly curve, which makes it an alternative to the
+// pairing-based accumulator in the parent package.
+//
+// Elements are hashed to 256-bit primes and the accumulator value is
+// A = G^{x_1 * x_2 * ...}. A membership witness for x is W with W^x = A.
+// The accumulator manager knows the factorization of N, which lets it delete
+// elements and issue witnesses; anyone can add elements and update witnesses.
+// Additions, deletions and batch memberships come with Wesolowski proofs of
+// exponentiation so they can be verified without exponentiating by the
+// product of all elements.
+package rsa
+
+import (
+	"fmt"
+	"math/big"
+
+	"git.sr.ht/~sircmpwn/go-bare"
+
+	crypto "github.com/go-sonr/crypto/core"
+	"github.com/go-sonr/crypto/internal"
+)
+
+// MinModulusBits is the smallest modulus NewKeys accepts
+const MinModulusBits = 2048
+
+type publicKeyMarshal struct {
+	N []byte `bare:"n"`
+	G []byte `bare:"g"`
+}
+
+type valueMarshal struct {
+	Value []byte `bare:"value"`
+}
+
+type secretKeyMarshal struct {
+	P []byte `bare:"p"`
+	Q []byte `bare:"q"`
+}
+
+// PublicKey is the modulus N and the generator G of the quadratic residues
+type PublicKey struct {
+	n, g *big.Int
+}
+
+// SecretKey is the factorization of N into two safe primes, only held by the accumulator manager
+type SecretKey struct {
+	p, q *big.Int
+}
+
+// NewKeys creates a modulus of `bits` bits from two random safe primes
+func NewKeys(bits uint) (*PublicKey, *SecretKey, error) {
+	if bits < MinModulusBits {
+		return nil, nil, fmt.Errorf("modulus must have at least %d bits", MinModulusBits)
+	}
+	p, err := crypto.GenerateSafePrime(bits / 2)
+	if err != nil {
+		return nil, nil, err
+	}
+	q, err := crypto.GenerateSafePrime(bits / 2)
+	if err != nil {
+		return nil, nil, err
+	}
+	return NewKeysFromPrimes(p, q)
+}
+
+// NewKeysFromPrimes creates keys from two distinct safe primes p and q and a random generator
+func NewKeysFromPrimes(p, q *big.Int) (*PublicKey, *SecretKey, error) {
+	if crypto.AnyNil(p, q) {
+		return nil, nil, internal.ErrNilArguments
+	}
+	if p.Cmp(q) == 0 {
+		return nil, nil, fmt.Errorf("p and q must be distinct")
+	}
+	for _, v := range []*big.Int{p, q} {
+		if !v.ProbablyPrime(20) || !new(big.Int).Rsh(v, 1).ProbablyPrime(20) {
+			return nil, nil, fmt.Errorf("p and q must be safe primes")
+		}
+	}
+	n := new(big.Int).Mul(p, q)
+
+	// G = r^2 mod N generates the quadratic residues with overwhelming probability
+	var g *big.Int
+	for {
+		r, err := crypto.Rand(n)
+		if err != nil {
+			return nil, nil, err
+		}
+		if new(big.Int).GCD(nil, nil, r, n).Cmp(crypto.One) != 0 {
+			continue
+		}
+		g = new(big.Int).Exp(r, big.NewInt(2), n)
+		if g.Cmp(crypto.One) != 0 {
+			break
+		}
+	}
+	return &PublicKey{n: n, g: g}, &SecretKey{p: new(big.Int).Set(p), q: new(big.Int).Set(q)}, nil
+}
+
+// order returns the order p'q' of the quadratic residues, with p = 2p'+1 and q = 2q'+1
+func (sk SecretKey) order() *big.Int {
+	p := new(big.Int).Rsh(sk.p, 1)
+	q := new(big.Int).Rsh(sk.q, 1)
+	return p.Mul(p, q)
+}
+
+// root returns v^{1/x} mod N
+func (sk SecretKey) root(pk *PublicKey, v, x *big.Int) (*big.Int, error) {
+	e := new(big.Int).ModInverse(x, sk.order())
+	if e == nil {
+		return nil, fmt.Errorf("element is not invertible")
+	}
+	return new(big.Int).Exp(v, e, pk.n), nil
+}
+
+// MarshalBinary converts PublicKey to bytes
+func (pk PublicKey) MarshalBinary() ([]byte, error) {
+	if crypto.AnyNil(pk.n, pk.g) {
+		return nil, fmt.Errorf("public key cannot be nil")
+	}
+	return bare.Marshal(&publicKeyMarshal{N: pk.n.Bytes(), G: pk.g.Bytes()})
+}
+
+// UnmarshalBinary sets PublicKey from bytes
+func (pk *PublicKey) UnmarshalBinary(data []byte) error {
+	tv := new(publicKeyMarshal)
+	if err := bare.Unmarshal(data, tv); err != nil {
+		return err
+	}
+	n := new(big.Int).SetBytes(tv.N)
+	g := new(big.Int).SetBytes(tv.G)
+	if n.BitLen() < MinModulusBits/2 || n.Bit(0) == 0 {
+		return fmt.Errorf("invalid modulus")
+	}
+	if err := inUnits(g, n); err != nil {
+		return err
+	}
+	pk.n = n
+	pk.g = g
+	return nil
+}
+
+// MarshalBinary converts SecretKey to bytes
+func (sk SecretKey) MarshalBinary() ([]byte, error) {
+	if crypto.AnyNil(sk.p, sk.q) {
+		return nil, fmt.Errorf("secret key cannot be nil")
+	}
+	return bare.Marshal(&secretKeyMarshal{P: sk.p.Bytes(), Q: sk.q.Bytes()})
+}
+
+// UnmarshalBinary sets SecretKey from bytes
+func (sk *SecretKey) UnmarshalBinary(data []byte) error {
+	tv := new(secretKeyMarshal)
+	if err := bare.Unmarshal(data, tv); err != nil {
+		return err
+	}
+	sk.p = new(big.Int).SetBytes(tv.P)
+	sk.q = new(big.Int).SetBytes(tv.Q)
+	return nil
+}
+
+// Accumulator is a quadratic residue modulo N
+type Accumulator struct {
+	value *big.Int
+}
+
+// New creates an empty accumulator A = G
+func (acc *Accumulator) New(pk *PublicKey) (*Accumulator, error) {
+	if pk == nil || crypto.AnyNil(pk.n, pk.g) {
+		return nil, internal.ErrNilArguments
+	}
+	acc.value = new(big.Int).Set(pk.g)
+	return acc, nil
+}
+
+// Add accumulates elements, A' = A^{x_1 * x_2 * ...}. It needs no secret key.
+// The returned proof convinces VerifyAdd that A' is correct.
+func (acc *Accumulator) Add(pk *PublicKey, elements [][]byte) (*Accumulator, *ExpProof, error) {
+	if acc.value == nil || pk == nil {
+		return nil, nil, fmt.Errorf("accumulator and public key should not be nil")
+	}
+	x := product(elements)
+	before := acc.value
+	after := new(big.Int).Exp(before, x, pk.n)
+	proof := proveExp(pk, before, x, after)
+	acc.value = after
+	return acc, proof, nil
+}
+
+// Remove deletes elements from the accumulator, A' = A^{1/(x_1 * x_2 * ...)}.
+// The returned proof convinces VerifyRemove that A' is correct.
+func (acc *Accumulator) Remove(pk *PublicKey, sk *SecretKey, elements [][]byte) (*Accumulator, *ExpProof, error) {
+	if acc.value == nil || pk == nil || sk == nil {
+		return nil, nil, fmt.Errorf("accumulator and keys should not be nil")
+	}
+	x := product(elements)
+	before := acc.value
+	after, err := sk.root(pk, before, x)
+	if err != nil {
+		return nil, nil, err
+	}
+	proof := proveExp(pk, after, x, before)
+	acc.value = after
+	return acc, proof, nil
+}
+
+// VerifyAdd checks that after is before with elements added
+func VerifyAdd(pk *PublicKey, before, after *Accumulator, elements [][]byte, proof *ExpProof) error {
+	if before == nil || after == nil {
+		return internal.ErrNilArguments
+	}
+	return proof.Verify(pk, before.value, product(elements), after.value)
+}
+
+// VerifyRemove checks that after is before with elements deleted
+func VerifyRemove(pk *PublicKey, before, after *Accumulator, elements [][]byte, proof *ExpProof) error {
+	if before == nil || after == nil {
+		return internal.ErrNilArguments
+	}
+	return proof.Verify(pk, after.value, product(elements), before.value)
+}
+
+// MarshalBinary converts Accumulator to bytes
+func (acc Accumulator) MarshalBinary() ([]byte, error) {
+	if acc.value == nil {
+		return nil, fmt.Errorf("accumulator cannot be nil")
+	}
+	return bare.Marshal(&valueMarshal{Value: acc.value.Bytes()})
+}
+
+// UnmarshalBinary sets Accumulator from bytes
+func (acc *Accumulator) UnmarshalBinary(data []byte) error {
+	tv := new(valueMarshal)
+	if err := bare.Unmarshal(data, tv); err != nil {
+		return err
+	}
+	acc.value = new(big.Int).SetBytes(tv.Value)
+	return nil
+}
+
+// product hashes elements to primes and multiplies them
+func product(elements [][]byte) *big.Int {
+	x := big.NewInt(1)
+	for _, e := range elements {
+		x.Mul(x, HashToPrime(e))
+	}
+	return x
+}
+
+// inUnits checks that 0 < x < n and gcd(x, n) = 1
+func inUnits(x, n *big.Int) error {
+	if x.Sign() <= 0 || x.Cmp(n) >= 0 {
+		return internal.ErrZmMembership
+	}
+	if new(big.Int).GCD(nil, nil, x, n).Cmp(crypto.One) != 0 {
+		return fmt.Errorf("value is not a unit modulo N")
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package rsa
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/internal"
+)
+
+// Safe primes for a 2048-bit test modulus
+var (
+	testP = internal.B10("94210786053667323206442523040419729883258172350738703980637961803118626748668924192069593010365236618255120977661397310932923345291377692570649198560048403943687994859423283474169530971418656709749020402756179383990602363122039939937953514870699284906666247063852187255623958659551404494107714695311474384687")
+	testQ = internal.B10("130291226847076770981564372061529572170236135412763130013877155698259035960569046218348763182598589633420963942796327547969527085797839549642610021986391589746295634536750785366034581957858065740296991986002552598751827526181747791647357767502200771965093659353354985289411489453223546075843993686648576029043")
+)
+
+func newTestKeys(t *testing.T) (*PublicKey, *SecretKey) {
+	pk, sk, err := NewKeysFromPrimes(testP, testQ)
+	require.NoError(t, err)
+	return pk, sk
+}
+
+func testElements(from, to int) [][]byte {
+	elements := make([][]byte, 0, to-from)
+	for i := from; i < to; i++ {
+		elements = append(elements, []byte(fmt.Sprintf("element %d", i)))
+	}
+	return elements
+}
+
+func TestNewKeys(t *testing.T) {
+	_, _, err := NewKeys(1024)
+	require.Error(t, err)
+	_, _, err = NewKeysFromPrimes(testP, testP)
+	require.Error(t, err)
+	// 2p + 1 for a safe prime p is not a safe prime
+	_, _, err = NewKeysFromPrimes(testP, new(big.Int).Add(new(big.Int).Lsh(testP, 1), big.NewInt(1)))
+	require.Error(t, err)
+
+	pk, sk := newTestKeys(t)
+	data, err := pk.MarshalBinary()
+	require.NoError(t, err)
+	pk2 := new(PublicKey)
+	require.NoError(t, pk2.UnmarshalBinary(data))
+	require.Equal(t, 0, pk.n.Cmp(pk2.n))
+	require.Equal(t, 0, pk.g.Cmp(pk2.g))
+
+	data, err = sk.MarshalBinary()
+	require.NoError(t, err)
+	sk2 := new(SecretKey)
+	require.NoError(t, sk2.UnmarshalBinary(data))
+	require.Equal(t, 0, sk.order().Cmp(sk2.order()))
+}
+
+func TestHashToPrime(t *testing.T) {
+	x := HashToPrime([]byte("element"))
+	require.Equal(t, primeBits, x.BitLen())
+	require.True(t, x.ProbablyPrime(20))
+	require.Equal(t, 0, x.Cmp(HashToPrime([]byte("element"))))
+	require.NotEqual(t, 0, x.Cmp(HashToPrime([]byte("element2"))))
+}
+
+func TestAccumulatorAddRemove(t *testing.T) {
+	pk, sk := newTestKeys(t)
+	acc, err := new(Accumulator).New(pk)
+	require.NoError(t, err)
+
+	elements := testElements(0, 10)
+	before := &Accumulator{acc.value}
+	_, proof, err := acc.Add(pk, elements)
+	require.NoError(t, err)
+	require.NoError(t, VerifyAdd(pk, before, acc, elements, proof))
+	require.Error(t, VerifyAdd(pk, before, acc, elements[1:], proof))
+	require.Error(t, VerifyAdd(pk, before, acc, testElements(1, 11), proof))
+
+	before = &Accumulator{acc.value}
+	_, proof, err = acc.Remove(pk, sk, elements[2:4])
+	require.NoError(t, err)
+	require.NoError(t, VerifyRemove(pk, before, acc, elements[2:4], proof))
+	require.Error(t, VerifyRemove(pk, before, acc, elements[2:5], proof))
+	require.Error(t, VerifyAdd(pk, before, acc, elements[2:4], proof))
+
+	// Removing what was added gives the same value in any order
+	expected, err := new(Accumulator).New(pk)
+	require.NoError(t, err)
+	_, _, err = expected.Add(pk, append(append([][]byte{}, elements[:2]...), elements[4:]...))
+	require.NoError(t, err)
+	require.Equal(t, 0, expected.value.Cmp(acc.value))
+
+	data, err := acc.MarshalBinary()
+	require.NoError(t, err)
+	acc2 := new(Accumulator)
+	require.NoError(t, acc2.UnmarshalBinary(data))
+	require.Equal(t, 0, acc.value.Cmp(acc2.value))
+
+	data, err = proof.MarshalBinary()
+	require.NoError(t, err)
+	proof2 := new(ExpProof)
+	require.NoError(t, proof2.UnmarshalBinary(data))
+	require.NoError(t, VerifyRemove(pk, before, acc, elements[2:4], proof2))
+}
@@ -0,0 +1,192 @@
+package rsa
+
+import (
+	"fmt"
+	"math/big"
+
+	"git.sr.ht/~sircmpwn/go-bare"
+
+	crypto "github.com/go-sonr/crypto/core"
+	"github.com/go-sonr/crypto/internal"
+)
+
+type witnessMarshal struct {
+	X []byte `bare:"x"`
+	W []byte `bare:"w"`
+}
+
+// MembershipWitness is the witness W with W^x = A for x the product of
+// the primes of one or more elements
+type MembershipWitness struct {
+	x, w *big.Int
+}
+
+// New creates a membership witness for elements, W = A^{1/x}.
+// The manager does not check that elements are accumulated, the witness
+// is only valid if they are.
+func (mw *MembershipWitness) New(pk *PublicKey, sk *SecretKey, acc *Accumulator, elements [][]byte) (*MembershipWitness, error) {
+	if pk == nil || sk == nil || acc == nil || acc.value == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if len(elements) == 0 {
+		return nil, fmt.Errorf("elements should not be empty")
+	}
+	x := product(elements)
+	w, err := sk.root(pk, acc.value, x)
+	if err != nil {
+		return nil, err
+	}
+	mw.x = x
+	mw.w = w
+	return mw, nil
+}
+
+// NewFromElements creates a membership witness for elements without the secret key
+// from all others, the elements accumulated in acc except elements,
+// W = G^{prod(others)}
+func (mw *MembershipWitness) NewFromElements(pk *PublicKey, elements, others [][]byte) (*MembershipWitness, error) {
+	if pk == nil || pk.g == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if len(elements) == 0 {
+		return nil, fmt.Errorf("elements should not be empty")
+	}
+	mw.x = product(elements)
+	mw.w = new(big.Int).Exp(pk.g, product(others), pk.n)
+	return mw, nil
+}
+
+// Verify checks that W^x = A by exponentiating with x
+func (mw MembershipWitness) Verify(pk *PublicKey, acc *Accumulator) error {
+	if pk == nil || acc == nil || crypto.AnyNil(mw.x, mw.w, acc.value) {
+		return internal.ErrNilArguments
+	}
+	if err := inUnits(mw.w, pk.n); err != nil {
+		return err
+	}
+	if new(big.Int).Exp(mw.w, mw.x, pk.n).Cmp(acc.value) != 0 {
+		return fmt.Errorf("invalid witness")
+	}
+	return nil
+}
+
+// Prove returns a proof of exponentiation for the witness, so verifying a
+// witness for many elements does not need an exponentiation by their product
+func (mw MembershipWitness) Prove(pk *PublicKey, acc *Accumulator) (*ExpProof, error) {
+	if err := mw.Verify(pk, acc); err != nil {
+		return nil, err
+	}
+	return proveExp(pk, mw.w, mw.x, acc.value), nil
+}
+
+// VerifyMembership checks with a proof from Prove that the witness value w proves
+// all elements are accumulated in acc
+func VerifyMembership(pk *PublicKey, acc *Accumulator, elements [][]byte, w *big.Int, proof *ExpProof) error {
+	if acc == nil {
+		return internal.ErrNilArguments
+	}
+	if len(elements) == 0 {
+		return fmt.Errorf("elements should not be empty")
+	}
+	return proof.Verify(pk, w, product(elements), acc.value)
+}
+
+// Value returns the witness W
+func (mw MembershipWitness) Value() *big.Int {
+	return new(big.Int).Set(mw.w)
+}
+
+// Aggregate combines mw with a witness for disjoint elements into a
+// witness for both with Shamir's trick: for a*x_1 + b*x_2 = 1,
+// W = W_1^b * W_2^a satisfies W^{x_1 * x_2} = A
+func (mw *MembershipWitness) Aggregate(pk *PublicKey, other *MembershipWitness) (*MembershipWitness, error) {
+	if pk == nil || other == nil || crypto.AnyNil(mw.x, mw.w, other.x, other.w) {
+		return nil, internal.ErrNilArguments
+	}
+	a, b, err := bezout(mw.x, other.x)
+	if err != nil {
+		return nil, fmt.Errorf("witnesses share elements")
+	}
+	w1, err := expSigned(mw.w, b, pk.n)
+	if err != nil {
+		return nil, err
+	}
+	w2, err := expSigned(other.w, a, pk.n)
+	if err != nil {
+		return nil, err
+	}
+	mw.w = w1.Mul(w1, w2).Mod(w1, pk.n)
+	mw.x = new(big.Int).Mul(mw.x, other.x)
+	return mw, nil
+}
+
+// UpdateAdd updates the witness after additions were added, W' = W^{prod(additions)}
+func (mw *MembershipWitness) UpdateAdd(pk *PublicKey, additions [][]byte) (*MembershipWitness, error) {
+	if pk == nil || crypto.AnyNil(mw.x, mw.w) {
+		return nil, internal.ErrNilArguments
+	}
+	mw.w = new(big.Int).Exp(mw.w, product(additions), pk.n)
+	return mw, nil
+}
+
+// UpdateRemove updates the witness after deletions were removed, resulting in acc.
+// For a*x + b*y = 1 with y = prod(deletions), W' = W^b * A'^a
+func (mw *MembershipWitness) UpdateRemove(pk *PublicKey, acc *Accumulator, deletions [][]byte) (*MembershipWitness, error) {
+	if pk == nil || acc == nil || crypto.AnyNil(mw.x, mw.w, acc.value) {
+		return nil, internal.ErrNilArguments
+	}
+	a, b, err := bezout(mw.x, product(deletions))
+	if err != nil {
+		return nil, fmt.Errorf("witness element has been removed")
+	}
+	w, err := expSigned(mw.w, b, pk.n)
+	if err != nil {
+		return nil, err
+	}
+	v, err := expSigned(acc.value, a, pk.n)
+	if err != nil {
+		return nil, err
+	}
+	mw.w = w.Mul(w, v).Mod(w, pk.n)
+	return mw, nil
+}
+
+// MarshalBinary converts a membership witness to bytes
+func (mw MembershipWitness) MarshalBinary() ([]byte, error) {
+	if crypto.AnyNil(mw.x, mw.w) {
+		return nil, fmt.Errorf("x and w value should not be nil")
+	}
+	return bare.Marshal(&witnessMarshal{X: mw.x.Bytes(), W: mw.w.Bytes()})
+}
+
+// UnmarshalBinary converts bytes into MembershipWitness
+func (mw *MembershipWitness) UnmarshalBinary(data []byte) error {
+	tv := new(witnessMarshal)
+	if err := bare.Unmarshal(data, tv); err != nil {
+		return err
+	}
+	mw.x = new(big.Int).SetBytes(tv.X)
+	mw.w = new(big.Int).SetBytes(tv.W)
+	return nil
+}
+
+// bezout returns a, b with a*x + b*y = 1, or an error if x and y are not coprime
+func bezout(x, y *big.Int) (*big.Int, *big.Int, error) {
+	a, b := new(big.Int), new(big.Int)
+	if new(big.Int).GCD(a, b, x, y).Cmp(crypto.One) != 0 {
+		return nil, nil, fmt.Errorf("values are not coprime")
+	}
+	return a, b, nil
+}
+
+// expSigned computes v^e mod n for a possibly negative e
+func expSigned(v, e, n *big.Int) (*big.Int, error) {
+	if e.Sign() >= 0 {
+		return new(big.Int).Exp(v, e, n), nil
+	}
+	inv := new(big.Int).ModInverse(v, n)
+	if inv == nil {
+		return nil, fmt.Errorf("value is not invertible")
+	}
+	return inv.Exp(inv, new(big.Int).Neg(e), n), nil
+}
@@ -0,0 +1,104 @@
+package rsa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMembershipWitness(t *testing.T) {
+	pk, sk := newTestKeys(t)
+	elements := testElements(0, 10)
+	acc, err := new(Accumulator).New(pk)
+	require.NoError(t, err)
+	_, _, err = acc.Add(pk, elements)
+	require.NoError(t, err)
+
+	wit, err := new(MembershipWitness).New(pk, sk, acc, elements[3:4])
+	require.NoError(t, err)
+	require.NoError(t, wit.Verify(pk, acc))
+
+	// Anyone who knows the other elements computes the same witness
+	others := append(append([][]byte{}, elements[:3]...), elements[4:]...)
+	wit2, err := new(MembershipWitness).NewFromElements(pk, elements[3:4], others)
+	require.NoError(t, err)
+	require.Equal(t, 0, wit.w.Cmp(wit2.w))
+
+	// A witness does not prove another element
+	wrong := &MembershipWitness{HashToPrime(elements[4]), wit.w}
+	require.Error(t, wrong.Verify(pk, acc))
+
+	data, err := wit.MarshalBinary()
+	require.NoError(t, err)
+	wit3 := new(MembershipWitness)
+	require.NoError(t, wit3.UnmarshalBinary(data))
+	require.NoError(t, wit3.Verify(pk, acc))
+}
+
+func TestMembershipWitnessUpdate(t *testing.T) {
+	pk, sk := newTestKeys(t)
+	elements := testElements(0, 10)
+	acc, err := new(Accumulator).New(pk)
+	require.NoError(t, err)
+	_, _, err = acc.Add(pk, elements)
+	require.NoError(t, err)
+	wit, err := new(MembershipWitness).New(pk, sk, acc, elements[3:4])
+	require.NoError(t, err)
+
+	additions := testElements(10, 15)
+	_, _, err = acc.Add(pk, additions)
+	require.NoError(t, err)
+	require.Error(t, wit.Verify(pk, acc))
+	_, err = wit.UpdateAdd(pk, additions)
+	require.NoError(t, err)
+	require.NoError(t, wit.Verify(pk, acc))
+
+	deletions := append(append([][]byte{}, elements[5:7]...), additions[1])
+	_, _, err = acc.Remove(pk, sk, deletions)
+	require.NoError(t, err)
+	require.Error(t, wit.Verify(pk, acc))
+	_, err = wit.UpdateRemove(pk, acc, deletions)
+	require.NoError(t, err)
+	require.NoError(t, wit.Verify(pk, acc))
+
+	// The witness of a deleted element cannot be updated
+	_, _, err = acc.Remove(pk, sk, elements[3:4])
+	require.NoError(t, err)
+	_, err = wit.UpdateRemove(pk, acc, elements[3:4])
+	require.Error(t, err)
+}
+
+func TestBatchMembership(t *testing.T) {
+	pk, sk := newTestKeys(t)
+	elements := testElements(0, 20)
+	acc, err := new(Accumulator).New(pk)
+	require.NoError(t, err)
+	_, _, err = acc.Add(pk, elements)
+	require.NoError(t, err)
+
+	wit, err := new(MembershipWitness).New(pk, sk, acc, elements[0:1])
+	require.NoError(t, err)
+	for i := 1; i < 8; i++ {
+		other, err := new(MembershipWitness).New(pk, sk, acc, elements[i:i+1])
+		require.NoError(t, err)
+		_, err = wit.Aggregate(pk, other)
+		require.NoError(t, err)
+	}
+	require.NoError(t, wit.Verify(pk, acc))
+
+	// Aggregating overlapping witnesses fails
+	other, err := new(MembershipWitness).New(pk, sk, acc, elements[2:3])
+	require.NoError(t, err)
+	_, err = (&MembershipWitness{wit.x, wit.w}).Aggregate(pk, other)
+	require.Error(t, err)
+
+	proof, err := wit.Prove(pk, acc)
+	require.NoError(t, err)
+	require.NoError(t, VerifyMembership(pk, acc, elements[:8], wit.Value(), proof))
+	require.Error(t, VerifyMembership(pk, acc, elements[:7], wit.Value(), proof))
+	require.Error(t, VerifyMembership(pk, acc, elements[1:9], wit.Value(), proof))
+
+	// Proofs cannot be made for invalid witnesses
+	_, err = (&MembershipWitness{HashToPrime(elements[9]), wit.w}).Prove(pk, acc)
+	require.Error(t, err)
+}
@@ -0,0 +1,208 @@
+package accumulator
+
+import (
+	"fmt"
+
+	"git.sr.ht/~sircmpwn/go-bare"
+
+	"github.com/go-sonr/crypto/core/curves"
+)
+
+// UpdateMessage is the public data a witness holder needs to follow one or more
+// batch updates of the accumulator: the added and deleted elements and the
+// coefficients of the update polynomial Ω(x)
+type UpdateMessage struct {
+	additions    []Element
+	deletions    []Element
+	coefficients []Coefficient
+}
+
+type updateMarshal struct {
+	Curve        string `bare:"curve"`
+	Additions    []byte `bare:"additions"`
+	Deletions    []byte `bare:"deletions"`
+	Coefficients []byte `bare:"coefficients"`
+}
+
+// New creates an update message from the output of Accumulator.Update
+func (um *UpdateMessage) New(additions []Element, deletions []Element, coefficients []Coefficient) (*UpdateMessage, error) {
+	if len(coefficients) == 0 {
+		return nil, fmt.Errorf("coefficients should not be empty")
+	}
+	for _, c := range coefficients {
+		if c == nil {
+			return nil, fmt.Errorf("some coefficient is nil")
+		}
+	}
+	for _, e := range append(append([]Element{}, additions...), deletions...) {
+		if e == nil {
+			return nil, fmt.Errorf("some element is nil")
+		}
+	}
+	um.additions = append([]Element{}, additions...)
+	um.deletions = append([]Element{}, deletions...)
+	um.coefficients = append([]Coefficient{}, coefficients...)
+	return um, nil
+}
+
+// CombineUpdates merges the update messages of consecutive epochs, oldest first,
+// into a single message. The combined polynomial is
+// Ω_{i->j}(x) = ∑ i..j dA_{t+1->j}(x) * dD_{i->t-1}(x) * Ω_t(x)
+// as described in section 4.2 of https://eprint.iacr.org/2020/777.pdf.
+// Applying it with MembershipWitness.ApplyUpdate gives the same witness as
+// applying every epoch in turn, but holders only evaluate one polynomial.
+// It only uses public values so anyone can compute it.
+func CombineUpdates(updates []*UpdateMessage) (*UpdateMessage, error) {
+	if len(updates) == 0 {
+		return nil, fmt.Errorf("updates should not be empty")
+	}
+	for _, u := range updates {
+		if u == nil || len(u.coefficients) == 0 {
+			return nil, fmt.Errorf("invalid update message")
+		}
+	}
+	one := updates[0].coefficients[0].Scalar().One()
+
+	// dA_s(x) and dD_s(x) for every epoch s
+	size := len(updates)
+	aa := make([]polynomial, size)
+	dd := make([]polynomial, size)
+	for s, u := range updates {
+		var err error
+		aa[s], err = roots(u.additions, one)
+		if err != nil {
+			return nil, err
+		}
+		dd[s], err = roots(u.deletions, one)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var omega polynomialPoint
+	for t, u := range updates {
+		// dA_{t+1->j}(x) * dD_{i->t-1}(x)
+		factor := polynomial{one}
+		var err error
+		for k := t + 1; k < size; k++ {
+			factor, err = factor.Mul(aa[k])
+			if err != nil {
+				return nil, err
+			}
+		}
+		for h := 0; h < t; h++ {
+			factor, err = factor.Mul(dd[h])
+			if err != nil {
+				return nil, err
+			}
+		}
+		pp := make(polynomialPoint, len(u.coefficients))
+		for i, c := range u.coefficients {
+			pp[i] = c
+		}
+		pp, err = pp.MulPoly(factor)
+		if err != nil {
+			return nil, err
+		}
+		omega, err = omega.Add(pp)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := &UpdateMessage{
+		additions:    []Element{},
+		deletions:    []Element{},
+		coefficients: make([]Coefficient, len(omega)),
+	}
+	for i, c := range omega {
+		result.coefficients[i] = c
+	}
+	for _, u := range updates {
+		result.additions = append(result.additions, u.additions...)
+		result.deletions = append(result.deletions, u.deletions...)
+	}
+	return result, nil
+}
+
+// MarshalBinary converts an update message to bytes
+func (um UpdateMessage) MarshalBinary() ([]byte, error) {
+	if len(um.coefficients) == 0 {
+		return nil, fmt.Errorf("coefficients should not be empty")
+	}
+	tv := &updateMarshal{
+		Curve: um.coefficients[0].CurveName(),
+	}
+	for _, e := range um.additions {
+		tv.Additions = append(tv.Additions, e.Bytes()...)
+	}
+	for _, e := range um.deletions {
+		tv.Deletions = append(tv.Deletions, e.Bytes()...)
+	}
+	for _, c := range um.coefficients {
+		tv.Coefficients = append(tv.Coefficients, c.ToAffineCompressed()...)
+	}
+	return bare.Marshal(tv)
+}
+
+// UnmarshalBinary converts bytes into an update message
+func (um *UpdateMessage) UnmarshalBinary(data []byte) error {
+	if data == nil {
+		return fmt.Errorf("input data should not be nil")
+	}
+	tv := new(updateMarshal)
+	err := bare.Unmarshal(data, tv)
+	if err != nil {
+		return err
+	}
+	curve := curves.GetCurveByName(tv.Curve)
+	if curve == nil {
+		return fmt.Errorf("invalid curve")
+	}
+
+	ptLength := len(curve.Point.ToAffineCompressed())
+	scLength := len(curve.Scalar.Bytes())
+	if len(tv.Coefficients) == 0 || len(tv.Coefficients)%ptLength != 0 ||
+		len(tv.Additions)%scLength != 0 || len(tv.Deletions)%scLength != 0 {
+		return fmt.Errorf("invalid byte sequence")
+	}
+	additions, err := unmarshalElements(curve, tv.Additions, scLength)
+	if err != nil {
+		return err
+	}
+	deletions, err := unmarshalElements(curve, tv.Deletions, scLength)
+	if err != nil {
+		return err
+	}
+	coefficients := make([]Coefficient, len(tv.Coefficients)/ptLength)
+	for i := range coefficients {
+		coefficients[i], err = curve.NewIdentityPoint().FromAffineCompressed(tv.Coefficients[i*ptLength : (i+1)*ptLength])
+		if err != nil {
+			return err
+		}
+	}
+	um.additions = additions
+	um.deletions = deletions
+	um.coefficients = coefficients
+	return nil
+}
+
+func unmarshalElements(curve *curves.Curve, data []byte, scLength int) ([]Element, error) {
+	elements := make([]Element, len(data)/scLength)
+	for i := range elements {
+		var err error
+		elements[i], err = curve.NewScalar().SetBytes(data[i*scLength : (i+1)*scLength])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return elements, nil
+}
+
+// ApplyUpdate performs the batch update described by um, which may cover several epochs
+func (mw *MembershipWitness) ApplyUpdate(um *UpdateMessage) (*MembershipWitness, error) {
+	if um == nil {
+		return nil, fmt.Errorf("update message should not be nil")
+	}
+	return mw.BatchUpdate(um.additions, um.deletions, um.coefficients)
+}
@@ -0,0 +1,118 @@
+package accumulator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+)
+
+func hashElements(curve *curves.PairingCurve, from, to int) []Element {
+	elements := make([]Element, 0, to-from)
+	for i := from; i < to; i++ {
+		elements = append(elements, curve.Scalar.Hash([]byte(fmt.Sprintf("element %d", i))))
+	}
+	return elements
+}
+
+func Test_Update_Message_Combine(t *testing.T) {
+	curve := curves.BLS12381(&curves.PointBls12381G1{})
+	sk, _ := new(SecretKey).New(curve, []byte("1234567890"))
+	pk, _ := sk.GetPublicKey(curve)
+
+	elements := hashElements(curve, 0, 20)
+	acc, err := new(Accumulator).WithElements(curve, sk, elements)
+	require.NoError(t, err)
+
+	wit, err := new(MembershipWitness).New(elements[3], acc, sk)
+	require.NoError(t, err)
+	wit2, err := new(MembershipWitness).New(elements[3], acc, sk)
+	require.NoError(t, err)
+
+	epochs := []struct {
+		adds, dels []Element
+	}{
+		{hashElements(curve, 100, 102), elements[10:13]},
+		{[]Element{}, elements[13:15]},
+		{hashElements(curve, 102, 105), []Element{}},
+		{hashElements(curve, 105, 106), elements[15:16]},
+	}
+	updates := make([]*UpdateMessage, len(epochs))
+	for i, epoch := range epochs {
+		_, coefficients, err := acc.Update(sk, epoch.adds, epoch.dels)
+		require.NoError(t, err)
+		updates[i], err = new(UpdateMessage).New(epoch.adds, epoch.dels, coefficients)
+		require.NoError(t, err)
+
+		// Following every epoch in turn
+		_, err = wit.ApplyUpdate(updates[i])
+		require.NoError(t, err)
+		require.NoError(t, wit.Verify(pk, acc))
+	}
+
+	// Catching up with one combined message sent over the wire
+	combined, err := CombineUpdates(updates)
+	require.NoError(t, err)
+	data, err := combined.MarshalBinary()
+	require.NoError(t, err)
+	received := new(UpdateMessage)
+	require.NoError(t, received.UnmarshalBinary(data))
+	require.Len(t, received.additions, 6)
+	require.Len(t, received.deletions, 6)
+
+	require.Error(t, wit2.Verify(pk, acc))
+	_, err = wit2.ApplyUpdate(received)
+	require.NoError(t, err)
+	require.NoError(t, wit2.Verify(pk, acc))
+	require.True(t, wit.c.Equal(wit2.c))
+}
+
+func Test_Update_Message_Deleted_Element(t *testing.T) {
+	curve := curves.BLS12381(&curves.PointBls12381G1{})
+	sk, _ := new(SecretKey).New(curve, []byte("1234567890"))
+
+	elements := hashElements(curve, 0, 10)
+	acc, err := new(Accumulator).WithElements(curve, sk, elements)
+	require.NoError(t, err)
+	wit, err := new(MembershipWitness).New(elements[3], acc, sk)
+	require.NoError(t, err)
+
+	_, coefficients1, err := acc.Update(sk, hashElements(curve, 100, 101), []Element{})
+	require.NoError(t, err)
+	update1, err := new(UpdateMessage).New(hashElements(curve, 100, 101), []Element{}, coefficients1)
+	require.NoError(t, err)
+	_, coefficients2, err := acc.Update(sk, []Element{}, elements[3:4])
+	require.NoError(t, err)
+	update2, err := new(UpdateMessage).New([]Element{}, elements[3:4], coefficients2)
+	require.NoError(t, err)
+
+	combined, err := CombineUpdates([]*UpdateMessage{update1, update2})
+	require.NoError(t, err)
+	_, err = wit.ApplyUpdate(combined)
+	require.Error(t, err)
+}
+
+func Test_Update_Message_Marshal(t *testing.T) {
+	curve := curves.BLS12381(&curves.PointBls12381G1{})
+	_, err := new(UpdateMessage).New(hashElements(curve, 0, 1), nil, nil)
+	require.Error(t, err)
+	_, err = CombineUpdates(nil)
+	require.Error(t, err)
+
+	um, err := new(UpdateMessage).New(hashElements(curve, 0, 2), hashElements(curve, 2, 3), []Coefficient{
+		curve.PointG1.Generator().Mul(curve.Scalar.New(10)),
+		curve.PointG1.Generator().Mul(curve.Scalar.New(11)),
+	})
+	require.NoError(t, err)
+	data, err := um.MarshalBinary()
+	require.NoError(t, err)
+	newUM := new(UpdateMessage)
+	require.NoError(t, newUM.UnmarshalBinary(data))
+	require.Len(t, newUM.additions, 2)
+	require.Len(t, newUM.deletions, 1)
+	require.Equal(t, 0, um.deletions[0].Cmp(newUM.deletions[0]))
+	require.True(t, um.coefficients[1].Equal(newUM.coefficients[1]))
+	require.Error(t, newUM.UnmarshalBinary(data[:len(data)-1]))
+}
@@ -0,0 +1,74 @@
+// Package aead provides nonce misuse resistant authenticated encryption:
+// AES-GCM-SIV of RFC 8452 https://www.rfc-editor.org/rfc/rfc8452.html and
+// XChaCha20-Poly1305, whose 24 byte nonces can be drawn at random.
+//
+// NewCommitting wraps either in a key committing mode, a ciphertext opens under
+// exactly one key, which matters when keys are picked by an attacker such as
+// in password or multi-recipient encryption. Key combines both behind a Seal
+// and Open API keyed from a shared secret, like the output of an ECDH or a
+// KEM, and is the safe default for consumers of keyexchange and hpke.
+package aead
+
+import (
+	"crypto/cipher"
+	"fmt"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// Algorithm identifies an AEAD
+type Algorithm byte
+
+const (
+	// AES128GCMSIV is AES-128-GCM-SIV of RFC 8452
+	AES128GCMSIV Algorithm = iota + 1
+	// AES256GCMSIV is AES-256-GCM-SIV of RFC 8452
+	AES256GCMSIV
+	// XChaCha20Poly1305 is ChaCha20-Poly1305 with 24 byte nonces,
+	// draft-irtf-cfrg-xchacha
+	XChaCha20Poly1305
+)
+
+var errOpen = fmt.Errorf("aead: message authentication failed")
+
+// String returns the name of the algorithm
+func (a Algorithm) String() string {
+	switch a {
+	case AES128GCMSIV:
+		return "AES-128-GCM-SIV"
+	case AES256GCMSIV:
+		return "AES-256-GCM-SIV"
+	case XChaCha20Poly1305:
+		return "XChaCha20-Poly1305"
+	default:
+		return fmt.Sprintf("Algorithm(%d)", byte(a))
+	}
+}
+
+// KeySize returns the key size of the algorithm, 0 if it is unknown
+func (a Algorithm) KeySize() int {
+	switch a {
+	case AES128GCMSIV:
+		return 16
+	case AES256GCMSIV:
+		return 32
+	case XChaCha20Poly1305:
+		return chacha20poly1305.KeySize
+	default:
+		return 0
+	}
+}
+
+// New returns the AEAD a keyed with key
+func New(a Algorithm, key []byte) (cipher.AEAD, error) {
+	if a.KeySize() == 0 {
+		return nil, fmt.Errorf("unsupported AEAD %s", a)
+	}
+	if len(key) != a.KeySize() {
+		return nil, fmt.Errorf("%s key must be %d bytes", a, a.KeySize())
+	}
+	if a == XChaCha20Poly1305 {
+		return chacha20poly1305.NewX(key)
+	}
+	return NewAESGCMSIV(key)
+}
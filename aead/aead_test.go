@@ -0,0 +1,157 @@
+package aead
+
+import (
+	"bytes"
+	"crypto/cipher"
+	crand "crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func unhex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func TestPolyval(t *testing.T) {
+	// RFC 8452 appendix A
+	p := newPolyval(unhex("25629347589242761d31f826ba4b757b"))
+	p.update(unhex("4f4f95668c83dfb6401762bb2d01a262d1a24ddd2721d006bbe45f20d3c9f362"))
+	var out [16]byte
+	p.sum(&out)
+	require.Equal(t, "f7a3b47b846119fae5b7866cf5e5b77e", hex.EncodeToString(out[:]))
+}
+
+func TestAESGCMSIVVectors(t *testing.T) {
+	// RFC 8452 appendix C
+	vectors := []struct {
+		key, nonce, plaintext, ad, result string
+	}{
+		{
+			"01000000000000000000000000000000", "030000000000000000000000", "", "",
+			"dc20e2d83f25705bb49e439eca56de25",
+		},
+		{
+			"01000000000000000000000000000000", "030000000000000000000000", "0100000000000000", "",
+			"b5d839330ac7b786578782fff6013b815b287c22493a364c",
+		},
+		{
+			"0100000000000000000000000000000000000000000000000000000000000000", "030000000000000000000000", "", "",
+			"07f5f4169bbf55a8400cd47ea6fd400f",
+		},
+	}
+	for _, v := range vectors {
+		aead, err := NewAESGCMSIV(unhex(v.key))
+		require.NoError(t, err)
+		ct := aead.Seal(nil, unhex(v.nonce), unhex(v.plaintext), unhex(v.ad))
+		require.Equal(t, v.result, hex.EncodeToString(ct))
+		pt, err := aead.Open(nil, unhex(v.nonce), ct, unhex(v.ad))
+		require.NoError(t, err)
+		require.Equal(t, v.plaintext, hex.EncodeToString(pt))
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, a := range []Algorithm{AES128GCMSIV, AES256GCMSIV, XChaCha20Poly1305} {
+		t.Run(a.String(), func(t *testing.T) {
+			key := make([]byte, a.KeySize())
+			_, _ = crand.Read(key)
+			for _, newAEAD := range []func(Algorithm, []byte) (cipher.AEAD, error){New, NewCommitting} {
+				aead, err := newAEAD(a, key)
+				require.NoError(t, err)
+				nonce := make([]byte, aead.NonceSize())
+				_, _ = crand.Read(nonce)
+				for _, size := range []int{0, 1, 15, 16, 17, 100, 1000} {
+					msg := make([]byte, size)
+					_, _ = crand.Read(msg)
+					ad := []byte("additional data")
+					ct := aead.Seal([]byte("prefix"), nonce, msg, ad)
+					require.Len(t, ct, len("prefix")+size+aead.Overhead())
+					pt, err := aead.Open(nil, nonce, ct[len("prefix"):], ad)
+					require.NoError(t, err)
+					require.True(t, bytes.Equal(msg, pt))
+
+					_, err = aead.Open(nil, nonce, ct[len("prefix"):], nil)
+					require.Error(t, err)
+					ct[len(ct)-1] ^= 1
+					_, err = aead.Open(nil, nonce, ct[len("prefix"):], ad)
+					require.Error(t, err)
+				}
+			}
+		})
+	}
+	_, err := New(Algorithm(0), nil)
+	require.Error(t, err)
+	_, err = New(AES256GCMSIV, make([]byte, 16))
+	require.Error(t, err)
+}
+
+func TestCommitting(t *testing.T) {
+	key1 := make([]byte, 32)
+	key2 := make([]byte, 32)
+	_, _ = crand.Read(key1)
+	_, _ = crand.Read(key2)
+	c1, err := NewCommitting(AES256GCMSIV, key1)
+	require.NoError(t, err)
+	c2, err := NewCommitting(AES256GCMSIV, key2)
+	require.NoError(t, err)
+
+	nonce := make([]byte, c1.NonceSize())
+	ct := c1.Seal(nil, nonce, []byte("message"), nil)
+	_, err = c2.Open(nil, nonce, ct, nil)
+	require.Error(t, err)
+
+	// the commitment depends on the nonce as well as the key
+	ct2 := c1.Seal(nil, []byte("other nonce!"), []byte("message"), nil)
+	require.NotEqual(t, ct[:CommitmentSize], ct2[:CommitmentSize])
+	_, err = c1.Open(nil, nonce, ct[:CommitmentSize], nil)
+	require.Error(t, err)
+}
+
+func TestKey(t *testing.T) {
+	shared := make([]byte, 32)
+	_, _ = crand.Read(shared)
+	for _, a := range []Algorithm{AES128GCMSIV, AES256GCMSIV, XChaCha20Poly1305} {
+		sender, err := NewKey(a, shared, nil, []byte("aead test"))
+		require.NoError(t, err)
+		recipient, err := NewKey(a, shared, nil, []byte("aead test"))
+		require.NoError(t, err)
+		other, err := NewKey(a, shared, nil, []byte("other use"))
+		require.NoError(t, err)
+
+		ct, err := sender.Seal([]byte("hello"), []byte("ad"))
+		require.NoError(t, err)
+		require.Len(t, ct, 5+sender.Overhead())
+		pt, err := recipient.Open(ct, []byte("ad"))
+		require.NoError(t, err)
+		require.Equal(t, []byte("hello"), pt)
+
+		_, err = other.Open(ct, []byte("ad"))
+		require.Error(t, err)
+		_, err = recipient.Open(ct[:sender.Overhead()-1], []byte("ad"))
+		require.Error(t, err)
+
+		ct2, err := sender.Seal([]byte("hello"), []byte("ad"))
+		require.NoError(t, err)
+		require.NotEqual(t, ct, ct2)
+	}
+	_, err := NewKey(Algorithm(9), shared, nil, nil)
+	require.Error(t, err)
+	_, err = NewKey(AES256GCMSIV, nil, nil, nil)
+	require.Error(t, err)
+}
+
+func BenchmarkAESGCMSIV(b *testing.B) {
+	aead, _ := NewAESGCMSIV(make([]byte, 32))
+	nonce := make([]byte, aead.NonceSize())
+	msg := make([]byte, 4096)
+	b.SetBytes(int64(len(msg)))
+	for i := 0; i < b.N; i++ {
+		_ = aead.Seal(nil, nonce, msg, nil)
+	}
+}
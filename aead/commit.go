@@ -0,0 +1,76 @@
+package aead
+
+import (
+	"crypto/cipher"
+	"crypto/sha256"
+	"crypto/subtle"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/go-sonr/crypto/core/secret"
+)
+
+// CommitmentSize is the size of the key commitment prefixed to the
+// ciphertexts of a committing AEAD
+const CommitmentSize = 32
+
+const commitLabel = "sonr-aead-commit-v1"
+
+// committing derives a fresh encryption key and a commitment to the key and
+// nonce with HKDF-SHA256 for every message, the CommitKey transform of
+// Bellare and Hoang, "Efficient Schemes for Committing Authenticated
+// Encryption". Finding two keys with the same commitment requires a collision
+// of HMAC-SHA256, so a ciphertext only opens under the key that sealed it.
+type committing struct {
+	alg   Algorithm
+	key   []byte
+	nonce int
+}
+
+// NewCommitting returns a key committing AEAD of a keyed with key. Ciphertexts
+// are CommitmentSize bytes longer than those of New.
+func NewCommitting(a Algorithm, key []byte) (cipher.AEAD, error) {
+	inner, err := New(a, key)
+	if err != nil {
+		return nil, err
+	}
+	return &committing{alg: a, key: append([]byte{}, key...), nonce: inner.NonceSize()}, nil
+}
+
+func (c *committing) NonceSize() int {
+	return c.nonce
+}
+
+func (c *committing) Overhead() int {
+	return CommitmentSize + 16
+}
+
+// derive returns the commitment and the AEAD keyed with the message key
+func (c *committing) derive(nonce []byte) ([]byte, cipher.AEAD) {
+	if len(nonce) != c.nonce {
+		panic("aead: incorrect nonce length given to committing AEAD")
+	}
+	info := append([]byte(commitLabel), byte(c.alg))
+	out := make([]byte, CommitmentSize+c.alg.KeySize())
+	_, _ = io.ReadFull(hkdf.New(sha256.New, c.key, nonce, info), out)
+	defer secret.Wipe(out[CommitmentSize:])
+	aead, _ := New(c.alg, out[CommitmentSize:])
+	return out[:CommitmentSize], aead
+}
+
+func (c *committing) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	commitment, aead := c.derive(nonce)
+	return aead.Seal(append(dst, commitment...), nonce, plaintext, additionalData)
+}
+
+func (c *committing) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(ciphertext) < c.Overhead() {
+		return nil, errOpen
+	}
+	commitment, aead := c.derive(nonce)
+	if subtle.ConstantTimeCompare(commitment, ciphertext[:CommitmentSize]) != 1 {
+		return nil, errOpen
+	}
+	return aead.Open(dst, nonce, ciphertext[CommitmentSize:], additionalData)
+}
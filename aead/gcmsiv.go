@@ -0,0 +1,143 @@
+package aead
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/subtle"
+	"encoding/binary"
+	"fmt"
+)
+
+const (
+	gcmSIVNonceSize = 12
+	gcmSIVTagSize   = 16
+	// gcmSIVMaxSize is the plaintext and additional data limit of RFC 8452
+	gcmSIVMaxSize = 1 << 36
+)
+
+// gcmSIV is AES-GCM-SIV of RFC 8452. Per message authentication and
+// encryption keys are derived from the key generating key and the nonce, and
+// the tag is a PRF of the plaintext, so repeating a nonce only reveals whether
+// two messages are equal.
+type gcmSIV struct {
+	block  cipher.Block
+	keyLen int
+}
+
+// NewAESGCMSIV returns AES-128-GCM-SIV or AES-256-GCM-SIV for a 16 or 32 byte key
+func NewAESGCMSIV(key []byte) (cipher.AEAD, error) {
+	if len(key) != 16 && len(key) != 32 {
+		return nil, fmt.Errorf("AES-GCM-SIV key must be 16 or 32 bytes")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return &gcmSIV{block: block, keyLen: len(key)}, nil
+}
+
+func (g *gcmSIV) NonceSize() int {
+	return gcmSIVNonceSize
+}
+
+func (g *gcmSIV) Overhead() int {
+	return gcmSIVTagSize
+}
+
+// deriveKeys is the key derivation of RFC 8452 section 4, every key is built
+// from the first half of AES(K, LE32(i) || nonce) blocks
+func (g *gcmSIV) deriveKeys(nonce []byte) ([]byte, cipher.Block) {
+	var in, out [16]byte
+	copy(in[4:], nonce)
+	keys := make([]byte, 0, 16+g.keyLen)
+	for i := uint32(0); len(keys) < cap(keys); i++ {
+		binary.LittleEndian.PutUint32(in[:4], i)
+		g.block.Encrypt(out[:], in[:])
+		keys = append(keys, out[:8]...)
+	}
+	enc, _ := aes.NewCipher(keys[16:])
+	return keys[:16], enc
+}
+
+// tag computes AES(encKey, POLYVAL(authKey, ad, plaintext, lengths) ^ nonce)
+func (g *gcmSIV) tag(out *[16]byte, authKey []byte, enc cipher.Block, nonce, plaintext, additionalData []byte) {
+	p := newPolyval(authKey)
+	p.update(additionalData)
+	p.update(plaintext)
+	var lengths [16]byte
+	binary.LittleEndian.PutUint64(lengths[:8], uint64(len(additionalData))*8)
+	binary.LittleEndian.PutUint64(lengths[8:], uint64(len(plaintext))*8)
+	p.block(lengths[:])
+	p.sum(out)
+	for i := range nonce {
+		out[i] ^= nonce[i]
+	}
+	out[15] &= 0x7F
+	enc.Encrypt(out[:], out[:])
+}
+
+// ctr xors in with the keystream of AES-CTR from the tag with the top bit
+// set, the first 32 bits count little endian
+func ctr(enc cipher.Block, tag *[16]byte, dst, in []byte) {
+	counter := *tag
+	counter[15] |= 0x80
+	var ks [16]byte
+	for len(in) > 0 {
+		enc.Encrypt(ks[:], counter[:])
+		n := subtle.XORBytes(dst, in, ks[:])
+		dst, in = dst[n:], in[n:]
+		binary.LittleEndian.PutUint32(counter[:4], binary.LittleEndian.Uint32(counter[:4])+1)
+	}
+}
+
+func (g *gcmSIV) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	if len(nonce) != gcmSIVNonceSize {
+		panic("aead: incorrect nonce length given to AES-GCM-SIV")
+	}
+	if uint64(len(plaintext)) > gcmSIVMaxSize || uint64(len(additionalData)) > gcmSIVMaxSize {
+		panic("aead: message too large for AES-GCM-SIV")
+	}
+	authKey, enc := g.deriveKeys(nonce)
+	var tag [16]byte
+	g.tag(&tag, authKey, enc, nonce, plaintext, additionalData)
+
+	ret, out := sliceForAppend(dst, len(plaintext)+gcmSIVTagSize)
+	ctr(enc, &tag, out, plaintext)
+	copy(out[len(plaintext):], tag[:])
+	return ret
+}
+
+func (g *gcmSIV) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(nonce) != gcmSIVNonceSize {
+		panic("aead: incorrect nonce length given to AES-GCM-SIV")
+	}
+	if len(ciphertext) < gcmSIVTagSize || uint64(len(ciphertext)) > gcmSIVMaxSize+gcmSIVTagSize ||
+		uint64(len(additionalData)) > gcmSIVMaxSize {
+		return nil, errOpen
+	}
+	authKey, enc := g.deriveKeys(nonce)
+	var tag, expected [16]byte
+	copy(tag[:], ciphertext[len(ciphertext)-gcmSIVTagSize:])
+	ciphertext = ciphertext[:len(ciphertext)-gcmSIVTagSize]
+
+	ret, out := sliceForAppend(dst, len(ciphertext))
+	ctr(enc, &tag, out, ciphertext)
+	g.tag(&expected, authKey, enc, nonce, out, additionalData)
+	if subtle.ConstantTimeCompare(tag[:], expected[:]) != 1 {
+		clear(out)
+		return nil, errOpen
+	}
+	return ret, nil
+}
+
+// sliceForAppend extends in by n bytes, returning the whole slice and the tail
+func sliceForAppend(in []byte, n int) (head, tail []byte) {
+	if total := len(in) + n; cap(in) >= total {
+		head = in[:total]
+	} else {
+		head = make([]byte, total)
+		copy(head, in)
+	}
+	tail = head[len(in):]
+	return head, tail
+}
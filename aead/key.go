@@ -0,0 +1,65 @@
+package aead
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"io"
+
+	"github.com/go-sonr/crypto/core/secret"
+	"github.com/go-sonr/crypto/keyexchange"
+)
+
+const keyLabel = "sonr-aead-key-v1"
+
+// Key seals and opens messages with a key committing AEAD and random nonces.
+// Ciphertexts are nonce || commitment || ciphertext || tag.
+type Key struct {
+	aead cipher.AEAD
+}
+
+// NewKey derives a Key for a from a shared secret with HKDF-SHA256, such as
+// the output of keyexchange.ECDH or a KEM. The salt may be empty, info binds
+// the key to its use.
+func NewKey(a Algorithm, sharedSecret, salt, info []byte) (*Key, error) {
+	if a.KeySize() == 0 {
+		return nil, fmt.Errorf("unsupported AEAD %s", a)
+	}
+	labeled := append(append([]byte(keyLabel), byte(a)), info...)
+	key, err := keyexchange.HKDF(sha256.New, sharedSecret, salt, labeled, a.KeySize())
+	if err != nil {
+		return nil, err
+	}
+	defer secret.Wipe(key)
+	aead, err := NewCommitting(a, key)
+	if err != nil {
+		return nil, err
+	}
+	return &Key{aead: aead}, nil
+}
+
+// Overhead returns the difference between ciphertext and plaintext sizes
+func (k *Key) Overhead() int {
+	return k.aead.NonceSize() + k.aead.Overhead()
+}
+
+// Seal encrypts and authenticates plaintext and authenticates additionalData
+// under a random nonce
+func (k *Key) Seal(plaintext, additionalData []byte) ([]byte, error) {
+	n := k.aead.NonceSize()
+	out := make([]byte, n, len(plaintext)+k.Overhead())
+	if _, err := io.ReadFull(rand.Reader, out); err != nil {
+		return nil, err
+	}
+	return k.aead.Seal(out, out[:n], plaintext, additionalData), nil
+}
+
+// Open authenticates and decrypts a ciphertext of Seal
+func (k *Key) Open(ciphertext, additionalData []byte) ([]byte, error) {
+	n := k.aead.NonceSize()
+	if len(ciphertext) < k.Overhead() {
+		return nil, errOpen
+	}
+	return k.aead.Open(nil, ciphertext[:n], ciphertext[n:], additionalData)
+}
@@ -0,0 +1,86 @@
+package aead
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// polyval is the universal hash POLYVAL of RFC 8452 section 3 over
+// GF(2^128) = GF(2)[x] / (x^128 + x^127 + x^126 + x^121 + 1). Field elements
+// are little endian, bit i of the 128 bit integer is the coefficient of x^i.
+type polyval struct {
+	h0, h1 uint64
+	s0, s1 uint64
+}
+
+func newPolyval(h []byte) *polyval {
+	return &polyval{
+		h0: binary.LittleEndian.Uint64(h[:8]),
+		h1: binary.LittleEndian.Uint64(h[8:]),
+	}
+}
+
+// update absorbs data zero padded to a multiple of 16 bytes
+func (p *polyval) update(data []byte) {
+	for len(data) >= 16 {
+		p.block(data[:16])
+		data = data[16:]
+	}
+	if len(data) > 0 {
+		var last [16]byte
+		copy(last[:], data)
+		p.block(last[:])
+	}
+}
+
+// block sets S = dot(S + X, H)
+func (p *polyval) block(x []byte) {
+	p.s0 ^= binary.LittleEndian.Uint64(x[:8])
+	p.s1 ^= binary.LittleEndian.Uint64(x[8:])
+	p.s0, p.s1 = dot(p.s0, p.s1, p.h0, p.h1)
+}
+
+func (p *polyval) sum(out *[16]byte) {
+	binary.LittleEndian.PutUint64(out[:8], p.s0)
+	binary.LittleEndian.PutUint64(out[8:], p.s1)
+}
+
+// dot returns a * b * x^-128, the Montgomery product of RFC 8452 section 3
+func dot(a0, a1, b0, b1 uint64) (uint64, uint64) {
+	// Karatsuba carry-less product p3 p2 p1 p0 of a and b
+	lo1, lo0 := clmul(a0, b0)
+	hi1, hi0 := clmul(a1, b1)
+	mid1, mid0 := clmul(a0^a1, b0^b1)
+	mid0 ^= lo0 ^ hi0
+	mid1 ^= lo1 ^ hi1
+	p0, p1, p2, p3 := lo0, lo1^mid0, hi0^mid1, hi1
+
+	// Montgomery reduction one word at a time, the modulus is 1 mod x^64 so
+	// adding m * modulus with m the low word clears it
+	p1 ^= p0<<57 ^ p0<<62 ^ p0<<63
+	p2 ^= p0>>7 ^ p0>>2 ^ p0>>1 ^ p0
+	p2 ^= p1<<57 ^ p1<<62 ^ p1<<63
+	p3 ^= p1>>7 ^ p1>>2 ^ p1>>1 ^ p1
+	return p2, p3
+}
+
+// clmul returns the 128 bit carry-less product of x and y, high word first
+func clmul(x, y uint64) (uint64, uint64) {
+	lo := bmul64(x, y)
+	hi := bits.Reverse64(bmul64(bits.Reverse64(x), bits.Reverse64(y))) >> 1
+	return hi, lo
+}
+
+// bmul64 is the low half of a carry-less product in constant time, integer
+// multiplications of operands with holes in every fourth bit keep the carries
+// from spilling into the bits that are kept
+func bmul64(x, y uint64) uint64 {
+	const m0, m1, m2, m3 = 0x1111111111111111, 0x2222222222222222, 0x4444444444444444, 0x8888888888888888
+	x0, x1, x2, x3 := x&m0, x&m1, x&m2, x&m3
+	y0, y1, y2, y3 := y&m0, y&m1, y&m2, y&m3
+	z0 := x0*y0 ^ x1*y3 ^ x2*y2 ^ x3*y1
+	z1 := x0*y1 ^ x1*y0 ^ x2*y3 ^ x3*y2
+	z2 := x0*y2 ^ x1*y1 ^ x2*y0 ^ x3*y3
+	z3 := x0*y3 ^ x1*y2 ^ x2*y1 ^ x3*y0
+	return z0&m0 | z1&m1 | z2&m2 | z3&m3
+}
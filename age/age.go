@@ -0,0 +1,304 @@
+// Package age encrypts files in the age v1 format of
+// https://age-encryption.org/v1, so files encrypted to a did:key can be
+// decrypted with the age tools and the other way around.
+//
+// A file is a header and a payload. The header wraps a random 16 byte file
+// key to each recipient in a stanza and is authenticated with an HMAC under
+// the file key. The payload is encrypted in 64 KiB chunks with the STREAM
+// construction over ChaCha20-Poly1305. Only the X25519 stanza is
+// implemented; Ed25519 did:keys are mapped to X25519 as in their did:key
+// documents.
+package age
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-sonr/crypto/core/secret"
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/keyexchange"
+)
+
+const (
+	intro       = "age-encryption.org/v1\n"
+	stanzaStart = "->"
+	footer      = "---"
+
+	fileKeySize = 16
+	// columns is the width of wrapped stanza bodies
+	columns = 64
+	// maxLineSize bounds the header lines accepted when decrypting
+	maxLineSize = 4096
+)
+
+var b64 = base64.RawStdEncoding.Strict()
+
+// Stanza is a header entry wrapping the file key to one recipient
+type Stanza struct {
+	Type string
+	Args []string
+	Body []byte
+}
+
+// Recipient wraps a file key into stanzas
+type Recipient interface {
+	Wrap(fileKey []byte) ([]*Stanza, error)
+}
+
+// Identity unwraps a file key from the stanzas of a header. It returns
+// ErrIncorrectIdentity when none of the stanzas is addressed to it.
+type Identity interface {
+	Unwrap(stanzas []*Stanza) ([]byte, error)
+}
+
+// ErrIncorrectIdentity is returned when an identity cannot unwrap any stanza
+var ErrIncorrectIdentity = fmt.Errorf("no identity matched any of the recipients")
+
+// Encrypt writes the header for recipients to dst and returns a writer that
+// encrypts the payload. Close must be called to write the final chunk.
+func Encrypt(dst io.Writer, recipients ...Recipient) (io.WriteCloser, error) {
+	return encrypt(dst, rand.Reader, recipients)
+}
+
+func encrypt(dst io.Writer, reader io.Reader, recipients []Recipient) (io.WriteCloser, error) {
+	if len(recipients) == 0 {
+		return nil, fmt.Errorf("no recipients specified")
+	}
+	fileKey := make([]byte, fileKeySize)
+	defer secret.Wipe(fileKey)
+	if _, err := io.ReadFull(reader, fileKey); err != nil {
+		return nil, err
+	}
+
+	var stanzas []*Stanza
+	for _, r := range recipients {
+		if r == nil {
+			return nil, internal.ErrNilArguments
+		}
+		s, err := r.Wrap(fileKey)
+		if err != nil {
+			return nil, fmt.Errorf("wrapping file key: %w", err)
+		}
+		stanzas = append(stanzas, s...)
+	}
+	header, err := marshalHeader(stanzas, fileKey)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dst.Write(header); err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, streamNonceSize)
+	if _, err := io.ReadFull(reader, nonce); err != nil {
+		return nil, err
+	}
+	if _, err := dst.Write(nonce); err != nil {
+		return nil, err
+	}
+	return newWriter(dst, fileKey, nonce)
+}
+
+// Decrypt reads the header from src, unwraps the file key with the first
+// identity that matches a stanza and returns a reader of the payload.
+func Decrypt(src io.Reader, identities ...Identity) (io.Reader, error) {
+	if len(identities) == 0 {
+		return nil, fmt.Errorf("no identities specified")
+	}
+	br := bufio.NewReader(src)
+	stanzas, signed, mac, err := parseHeader(br)
+	if err != nil {
+		return nil, fmt.Errorf("parsing header: %w", err)
+	}
+
+	var fileKey []byte
+	for _, id := range identities {
+		if id == nil {
+			return nil, internal.ErrNilArguments
+		}
+		fileKey, err = id.Unwrap(stanzas)
+		if err == ErrIncorrectIdentity {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		break
+	}
+	if fileKey == nil {
+		return nil, ErrIncorrectIdentity
+	}
+	defer secret.Wipe(fileKey)
+
+	expected, err := headerMAC(fileKey, signed)
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal(expected, mac) {
+		return nil, fmt.Errorf("bad header MAC")
+	}
+
+	nonce := make([]byte, streamNonceSize)
+	if _, err := io.ReadFull(br, nonce); err != nil {
+		return nil, fmt.Errorf("reading payload nonce: %w", err)
+	}
+	return newReader(br, fileKey, nonce)
+}
+
+// headerMAC is HMAC-SHA256 keyed with HKDF-SHA256(fileKey, "header") over
+// the header up to and including the footer marker
+func headerMAC(fileKey, signed []byte) ([]byte, error) {
+	key, err := keyexchange.HKDF(sha256.New, fileKey, nil, []byte("header"), 32)
+	if err != nil {
+		return nil, err
+	}
+	defer secret.Wipe(key)
+	h := hmac.New(sha256.New, key)
+	_, _ = h.Write(signed)
+	return h.Sum(nil), nil
+}
+
+func marshalHeader(stanzas []*Stanza, fileKey []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(intro)
+	for _, s := range stanzas {
+		if err := s.marshal(&buf); err != nil {
+			return nil, err
+		}
+	}
+	buf.WriteString(footer)
+	mac, err := headerMAC(fileKey, buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	buf.WriteString(" " + b64.EncodeToString(mac) + "\n")
+	return buf.Bytes(), nil
+}
+
+func (s *Stanza) marshal(w *bytes.Buffer) error {
+	if !validArg(s.Type) {
+		return fmt.Errorf("invalid stanza type %q", s.Type)
+	}
+	w.WriteString(stanzaStart + " " + s.Type)
+	for _, a := range s.Args {
+		if !validArg(a) {
+			return fmt.Errorf("invalid stanza argument %q", a)
+		}
+		w.WriteString(" " + a)
+	}
+	w.WriteString("\n")
+	// the body is wrapped at 64 columns and ends with a short, possibly
+	// empty, line
+	body := b64.EncodeToString(s.Body)
+	for len(body) >= columns {
+		w.WriteString(body[:columns] + "\n")
+		body = body[columns:]
+	}
+	w.WriteString(body + "\n")
+	return nil
+}
+
+// parseHeader returns the stanzas, the bytes covered by the MAC and the MAC
+func parseHeader(br *bufio.Reader) ([]*Stanza, []byte, []byte, error) {
+	var signed bytes.Buffer
+	line, err := readLine(br, &signed)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if line+"\n" != intro {
+		return nil, nil, nil, fmt.Errorf("unsupported version line %q", line)
+	}
+
+	var stanzas []*Stanza
+	for {
+		mark := signed.Len()
+		line, err := readLine(br, &signed)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if strings.HasPrefix(line, footer) {
+			rest, ok := strings.CutPrefix(line, footer+" ")
+			if !ok {
+				return nil, nil, nil, fmt.Errorf("malformed footer")
+			}
+			mac, err := b64.DecodeString(rest)
+			if err != nil || len(mac) != sha256.Size {
+				return nil, nil, nil, fmt.Errorf("malformed header MAC")
+			}
+			return stanzas, signed.Bytes()[:mark+len(footer)], mac, nil
+		}
+
+		args := strings.Split(line, " ")
+		if args[0] != stanzaStart || len(args) < 2 {
+			return nil, nil, nil, fmt.Errorf("malformed stanza line %q", line)
+		}
+		for _, a := range args[1:] {
+			if !validArg(a) {
+				return nil, nil, nil, fmt.Errorf("malformed stanza argument %q", a)
+			}
+		}
+		s := &Stanza{Type: args[1], Args: args[2:]}
+		for {
+			line, err := readLine(br, &signed)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			if len(line) > columns {
+				return nil, nil, nil, fmt.Errorf("stanza body line too long")
+			}
+			b, err := b64.DecodeString(line)
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("malformed stanza body: %w", err)
+			}
+			s.Body = append(s.Body, b...)
+			if len(line) < columns {
+				break
+			}
+		}
+		stanzas = append(stanzas, s)
+	}
+}
+
+// readLine reads a line without its newline and records it in signed
+func readLine(br *bufio.Reader, signed *bytes.Buffer) (string, error) {
+	var line []byte
+	for {
+		chunk, err := br.ReadSlice('\n')
+		line = append(line, chunk...)
+		if len(line) > maxLineSize {
+			return "", fmt.Errorf("header line too long")
+		}
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		if err == io.EOF {
+			return "", io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return "", err
+		}
+		break
+	}
+	signed.Write(line)
+	return string(line[:len(line)-1]), nil
+}
+
+// validArg reports whether a is a non-empty string of printable ASCII
+func validArg(a string) bool {
+	if a == "" {
+		return false
+	}
+	for i := 0; i < len(a); i++ {
+		if a[i] < 33 || a[i] > 126 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,189 @@
+package age
+
+import (
+	"bufio"
+	"bytes"
+	crand "crypto/rand"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/keys/parsers"
+)
+
+func TestX25519Encoding(t *testing.T) {
+	// the identity of the age testkit
+	const (
+		identity  = "AGE-SECRET-KEY-1GFPYYSJZGFPYYSJZGFPYYSJZGFPYYSJZGFPYYSJZGFPYYSJZGFPQ4EGAEX"
+		recipient = "age1zvkyg2lqzraa2lnjvqej32nkuu0ues2s82hzrye869xeexvn73equnujwj"
+	)
+	id, err := ParseX25519Identity(identity)
+	require.NoError(t, err)
+	require.Equal(t, identity, id.String())
+	require.Equal(t, recipient, id.Recipient().String())
+	r, err := ParseX25519Recipient(recipient)
+	require.NoError(t, err)
+	require.Equal(t, id.Recipient().Bytes(), r.Bytes())
+
+	_, err = ParseX25519Recipient(strings.Replace(recipient, "age1", "agf1", 1))
+	require.Error(t, err)
+	_, err = ParseX25519Identity(identity[:len(identity)-1] + "Y")
+	require.Error(t, err)
+}
+
+// The files in testdata were encrypted by the reference implementation,
+// filippo.io/age v1.2.1: testkit.age to the testkit identity, with a payload of
+// two chunks, and example.age, from the age repository, to its example key.
+func TestReferenceFiles(t *testing.T) {
+	for _, tc := range []struct {
+		file     string
+		identity string
+		payload  []byte
+	}{
+		{
+			file:     "testdata/testkit.age",
+			identity: "AGE-SECRET-KEY-1GFPYYSJZGFPYYSJZGFPYYSJZGFPYYSJZGFPYYSJZGFPYYSJZGFPQ4EGAEX",
+			payload:  bytes.Repeat([]byte("age v1 interop\n"), 4370),
+		},
+		{
+			file:     "testdata/example.age",
+			identity: "AGE-SECRET-KEY-184JMZMVQH3E6U0PSL869004Y3U2NYV7R30EU99CSEDNPH02YUVFSZW44VU",
+			payload:  []byte("Black lives matter."),
+		},
+	} {
+		data, err := os.ReadFile(tc.file)
+		require.NoError(t, err)
+		id, err := ParseX25519Identity(tc.identity)
+		require.NoError(t, err)
+
+		r, err := Decrypt(bytes.NewReader(data), id)
+		require.NoError(t, err, tc.file)
+		got, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, tc.payload, got)
+
+		// the header written for the same stanzas and file key is the same bytes
+		br := bufio.NewReader(bytes.NewReader(data))
+		stanzas, _, _, err := parseHeader(br)
+		require.NoError(t, err)
+		require.Len(t, stanzas, 1)
+		require.Equal(t, "X25519", stanzas[0].Type)
+		fileKey, err := id.Unwrap(stanzas)
+		require.NoError(t, err)
+		header, err := marshalHeader(stanzas, fileKey)
+		require.NoError(t, err)
+		require.Equal(t, data[:len(header)], header)
+	}
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	alice, err := GenerateX25519Identity()
+	require.NoError(t, err)
+	bob, err := GenerateX25519Identity()
+	require.NoError(t, err)
+	eve, err := GenerateX25519Identity()
+	require.NoError(t, err)
+
+	for _, size := range []int{0, 1, chunkSize - 1, chunkSize, 2*chunkSize + 5} {
+		payload := make([]byte, size)
+		_, _ = crand.Read(payload)
+
+		var buf bytes.Buffer
+		w, err := Encrypt(&buf, alice.Recipient(), bob.Recipient())
+		require.NoError(t, err)
+		_, err = w.Write(payload)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		require.True(t, strings.HasPrefix(buf.String(), intro+"-> X25519 "))
+
+		for _, id := range []Identity{alice, bob} {
+			r, err := Decrypt(bytes.NewReader(buf.Bytes()), eve, id)
+			require.NoError(t, err)
+			got, err := io.ReadAll(r)
+			require.NoError(t, err)
+			require.True(t, bytes.Equal(payload, got))
+		}
+		_, err = Decrypt(bytes.NewReader(buf.Bytes()), eve)
+		require.ErrorIs(t, err, ErrIncorrectIdentity)
+
+		if size > 0 {
+			r, err := Decrypt(bytes.NewReader(buf.Bytes()[:buf.Len()-1]), alice)
+			require.NoError(t, err)
+			_, err = io.ReadAll(r)
+			require.Error(t, err)
+		}
+	}
+}
+
+func TestHeaderTampering(t *testing.T) {
+	id, err := GenerateX25519Identity()
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	w, err := Encrypt(&buf, id.Recipient())
+	require.NoError(t, err)
+	_, _ = w.Write([]byte("backup"))
+	require.NoError(t, w.Close())
+
+	// an extra stanza is caught by the header MAC
+	tampered := strings.Replace(buf.String(), "\n---", "\n-> grease x\n\n---", 1)
+	_, err = Decrypt(strings.NewReader(tampered), id)
+	require.ErrorContains(t, err, "MAC")
+
+	// trailing data after the final chunk
+	r, err := Decrypt(io.MultiReader(bytes.NewReader(buf.Bytes()), strings.NewReader("x")), id)
+	require.NoError(t, err)
+	_, err = io.ReadAll(r)
+	require.Error(t, err)
+
+	_, err = Decrypt(strings.NewReader("age-encryption.org/v2\n"), id)
+	require.Error(t, err)
+}
+
+func TestDIDRecipient(t *testing.T) {
+	priv, pub, err := crypto.GenerateEd25519Key(crand.Reader)
+	require.NoError(t, err)
+	did, err := parsers.NewKeyDID(pub)
+	require.NoError(t, err)
+	r, err := RecipientFromDID(did)
+	require.NoError(t, err)
+	parsed, err := ParseDIDRecipient(did.String())
+	require.NoError(t, err)
+	require.Equal(t, r.String(), parsed.String())
+
+	raw, err := priv.Raw()
+	require.NoError(t, err)
+	id, err := NewEd25519Identity(raw)
+	require.NoError(t, err)
+	require.Equal(t, r.String(), id.Recipient().String())
+
+	// the key agreement key of the did:key document is the same recipient
+	doc, err := did.Resolve()
+	require.NoError(t, err)
+	require.NotEmpty(t, doc.KeyAgreement)
+	xdid := parsers.KeyPrefix + ":" + strings.SplitN(doc.KeyAgreement[0], "#", 2)[1]
+	xr, err := ParseDIDRecipient(xdid)
+	require.NoError(t, err)
+	require.Equal(t, r.String(), xr.String())
+
+	var buf bytes.Buffer
+	w, err := Encrypt(&buf, r)
+	require.NoError(t, err)
+	_, _ = w.Write([]byte("to did:key"))
+	require.NoError(t, w.Close())
+	out, err := Decrypt(&buf, id)
+	require.NoError(t, err)
+	got, err := io.ReadAll(out)
+	require.NoError(t, err)
+	require.Equal(t, []byte("to did:key"), got)
+
+	_, secp, err := crypto.GenerateSecp256k1Key(crand.Reader)
+	require.NoError(t, err)
+	sdid, err := parsers.NewKeyDID(secp)
+	require.NoError(t, err)
+	_, err = RecipientFromDID(sdid)
+	require.Error(t, err)
+}
@@ -0,0 +1,189 @@
+package age
+
+import (
+	"bufio"
+	"crypto/cipher"
+	"crypto/sha256"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/chacha20poly1305"
+
+	"github.com/go-sonr/crypto/keyexchange"
+)
+
+const (
+	streamNonceSize = 16
+	chunkSize       = 64 * 1024
+	encChunkSize    = chunkSize + chacha20poly1305.Overhead
+	// lastChunkFlag is the final byte of the nonce of the last chunk
+	lastChunkFlag = 0x01
+)
+
+// stream is the STREAM construction of age: ChaCha20-Poly1305 under
+// HKDF-SHA256(fileKey, nonce, "payload") with an 11 byte big endian chunk
+// counter and a last chunk flag as the nonce
+type stream struct {
+	aead  cipher.AEAD
+	nonce [chacha20poly1305.NonceSize]byte
+}
+
+func newStream(fileKey, nonce []byte) (*stream, error) {
+	key, err := keyexchange.HKDF(sha256.New, fileKey, nonce, []byte("payload"), chacha20poly1305.KeySize)
+	if err != nil {
+		return nil, err
+	}
+	a, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, err
+	}
+	return &stream{aead: a}, nil
+}
+
+func (s *stream) next(last bool) ([]byte, error) {
+	if last {
+		s.nonce[len(s.nonce)-1] = lastChunkFlag
+	}
+	nonce := append([]byte{}, s.nonce[:]...)
+	// increment the counter, bytes 0 to 10
+	for i := len(s.nonce) - 2; i >= 0; i-- {
+		s.nonce[i]++
+		if s.nonce[i] != 0 {
+			return nonce, nil
+		}
+	}
+	return nil, fmt.Errorf("payload has too many chunks")
+}
+
+func (s *stream) first() bool {
+	for _, b := range s.nonce[:len(s.nonce)-1] {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+type writer struct {
+	dst    io.Writer
+	stream *stream
+	buf    []byte
+	closed bool
+}
+
+func newWriter(dst io.Writer, fileKey, nonce []byte) (*writer, error) {
+	s, err := newStream(fileKey, nonce)
+	if err != nil {
+		return nil, err
+	}
+	return &writer{dst: dst, stream: s, buf: make([]byte, 0, encChunkSize)}, nil
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, fmt.Errorf("write to closed age writer")
+	}
+	written := 0
+	for len(p) > 0 {
+		// a full chunk is only sealed once more data arrives, so the last
+		// chunk may be full but is never empty unless the payload is
+		if len(w.buf) == chunkSize {
+			if err := w.flush(false); err != nil {
+				return written, err
+			}
+		}
+		n := copy(w.buf[len(w.buf):chunkSize], p)
+		w.buf = w.buf[:len(w.buf)+n]
+		p = p[n:]
+		written += n
+	}
+	return written, nil
+}
+
+func (w *writer) flush(last bool) error {
+	nonce, err := w.stream.next(last)
+	if err != nil {
+		return err
+	}
+	chunk := w.stream.aead.Seal(w.buf[:0], nonce, w.buf, nil)
+	if _, err := w.dst.Write(chunk); err != nil {
+		return err
+	}
+	w.buf = w.buf[:0]
+	return nil
+}
+
+// Close seals the final chunk, it does not close the destination
+func (w *writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	return w.flush(true)
+}
+
+type reader struct {
+	src    *bufio.Reader
+	stream *stream
+	buf    []byte
+	out    []byte
+	done   bool
+}
+
+func newReader(src *bufio.Reader, fileKey, nonce []byte) (*reader, error) {
+	s, err := newStream(fileKey, nonce)
+	if err != nil {
+		return nil, err
+	}
+	return &reader{src: src, stream: s, buf: make([]byte, encChunkSize)}, nil
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	for len(r.out) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		if err := r.readChunk(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.out)
+	r.out = r.out[n:]
+	return n, nil
+}
+
+func (r *reader) readChunk() error {
+	n, err := io.ReadFull(r.src, r.buf)
+	switch {
+	case err == io.EOF:
+		return fmt.Errorf("payload is truncated")
+	case err == io.ErrUnexpectedEOF:
+		r.done = true
+	case err != nil:
+		return err
+	default:
+		// a full chunk is the last one if nothing follows it
+		if _, err := r.src.Peek(1); err == io.EOF {
+			r.done = true
+		} else if err != nil {
+			return err
+		}
+	}
+	if n < chacha20poly1305.Overhead {
+		return fmt.Errorf("payload chunk is too short")
+	}
+	first := r.stream.first()
+	nonce, err := r.stream.next(r.done)
+	if err != nil {
+		return err
+	}
+	out, err := r.stream.aead.Open(r.buf[:0], nonce, r.buf[:n], nil)
+	if err != nil {
+		return fmt.Errorf("decrypting payload chunk: %w", err)
+	}
+	if r.done && len(out) == 0 && !first {
+		return fmt.Errorf("last payload chunk is empty")
+	}
+	r.out = out
+	return nil
+}
@@ -0,0 +1,246 @@
+package age
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/btcutil/bech32"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	mb "github.com/multiformats/go-multibase"
+	varint "github.com/multiformats/go-varint"
+	"golang.org/x/crypto/chacha20poly1305"
+
+	"github.com/go-sonr/crypto/core/secret"
+	"github.com/go-sonr/crypto/keyexchange"
+	"github.com/go-sonr/crypto/keys/parsers"
+)
+
+const (
+	x25519Label     = "age-encryption.org/v1/X25519"
+	x25519Type      = "X25519"
+	recipientHRP    = "age"
+	identityHRP     = "AGE-SECRET-KEY-"
+	wrappedKeySize  = fileKeySize + chacha20poly1305.Overhead
+	x25519KeyLength = 32
+)
+
+// X25519Recipient is the public key of an age X25519 recipient
+type X25519Recipient struct {
+	pk []byte
+}
+
+// NewX25519Recipient returns the recipient of an X25519 public key
+func NewX25519Recipient(pub []byte) (*X25519Recipient, error) {
+	if len(pub) != x25519KeyLength {
+		return nil, fmt.Errorf("X25519 public key must be %d bytes", x25519KeyLength)
+	}
+	return &X25519Recipient{pk: append([]byte{}, pub...)}, nil
+}
+
+// ParseX25519Recipient parses a recipient in its age1 Bech32 encoding
+func ParseX25519Recipient(s string) (*X25519Recipient, error) {
+	hrp, pub, err := bech32.DecodeToBase256(s)
+	if err != nil {
+		return nil, fmt.Errorf("malformed recipient %q: %w", s, err)
+	}
+	if hrp != recipientHRP {
+		return nil, fmt.Errorf("malformed recipient %q: invalid prefix %q", s, hrp)
+	}
+	return NewX25519Recipient(pub)
+}
+
+// RecipientFromDID returns the X25519 recipient of an Ed25519 did:key, the
+// key agreement key of its did:key document
+func RecipientFromDID(id parsers.DIDKey) (*X25519Recipient, error) {
+	if id.PubKey == nil {
+		return nil, fmt.Errorf("did:key has no public key")
+	}
+	if id.Type() != crypto.Ed25519 {
+		return nil, fmt.Errorf("unsupported key type for age: %s", id.Type())
+	}
+	raw, err := id.Raw()
+	if err != nil {
+		return nil, err
+	}
+	pub, err := parsers.Ed25519ToX25519PublicKey(raw)
+	if err != nil {
+		return nil, err
+	}
+	return NewX25519Recipient(pub)
+}
+
+// ParseDIDRecipient returns the recipient of an Ed25519 or X25519 did:key
+func ParseDIDRecipient(did string) (*X25519Recipient, error) {
+	fingerprint, ok := strings.CutPrefix(did, parsers.KeyPrefix+":")
+	if !ok {
+		return nil, fmt.Errorf("decentralized identifier is not a 'key' type")
+	}
+	_, data, err := mb.Decode(fingerprint)
+	if err != nil {
+		return nil, fmt.Errorf("decoding multibase: %w", err)
+	}
+	// X25519 did:keys only carry a key agreement key and are not parsed
+	// into a DIDKey
+	if codec, n, err := varint.FromUvarint(data); err == nil && codec == parsers.MulticodecKindX25519PubKey {
+		return NewX25519Recipient(data[n:])
+	}
+	id, err := parsers.Parse(did)
+	if err != nil {
+		return nil, err
+	}
+	return RecipientFromDID(id)
+}
+
+// String returns the age1 Bech32 encoding of the recipient
+func (r *X25519Recipient) String() string {
+	s, _ := bech32.EncodeFromBase256(recipientHRP, r.pk)
+	return s
+}
+
+// Bytes returns the X25519 public key
+func (r *X25519Recipient) Bytes() []byte {
+	return append([]byte{}, r.pk...)
+}
+
+// Wrap wraps the file key to the recipient in an X25519 stanza
+func (r *X25519Recipient) Wrap(fileKey []byte) ([]*Stanza, error) {
+	share, shared, err := keyexchange.X25519.Ephemeral(r.pk, rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	defer secret.Wipe(shared)
+	key, err := wrapKey(shared, share, r.pk)
+	if err != nil {
+		return nil, err
+	}
+	defer secret.Wipe(key)
+	a, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, err
+	}
+	return []*Stanza{{
+		Type: x25519Type,
+		Args: []string{b64.EncodeToString(share)},
+		Body: a.Seal(nil, make([]byte, a.NonceSize()), fileKey, nil),
+	}}, nil
+}
+
+// wrapKey is HKDF-SHA256 of the shared secret salted with the ephemeral
+// share and the recipient
+func wrapKey(shared, share, recipient []byte) ([]byte, error) {
+	salt := append(append([]byte{}, share...), recipient...)
+	return keyexchange.HKDF(sha256.New, shared, salt, []byte(x25519Label), chacha20poly1305.KeySize)
+}
+
+// X25519Identity is the private key of an age X25519 recipient
+type X25519Identity struct {
+	key *keyexchange.PrivateKey
+}
+
+// GenerateX25519Identity draws a new identity from crypto/rand
+func GenerateX25519Identity() (*X25519Identity, error) {
+	key, err := keyexchange.X25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return &X25519Identity{key: key}, nil
+}
+
+// NewX25519Identity returns the identity of an X25519 private key
+func NewX25519Identity(sk []byte) (*X25519Identity, error) {
+	key, err := keyexchange.X25519.NewPrivateKey(sk)
+	if err != nil {
+		return nil, err
+	}
+	return &X25519Identity{key: key}, nil
+}
+
+// NewEd25519Identity returns the X25519 identity of an Ed25519 private key,
+// which decrypts files encrypted to RecipientFromDID of its did:key
+func NewEd25519Identity(priv ed25519.PrivateKey) (*X25519Identity, error) {
+	if len(priv) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("invalid Ed25519 private key length: %d", len(priv))
+	}
+	// the X25519 scalar is the first half of SHA-512(seed) as in RFC 8032,
+	// X25519 clamps it
+	h := sha512.Sum512(priv.Seed())
+	defer secret.Wipe(h[:])
+	return NewX25519Identity(h[:32])
+}
+
+// ParseX25519Identity parses an identity in its AGE-SECRET-KEY-1 Bech32
+// encoding
+func ParseX25519Identity(s string) (*X25519Identity, error) {
+	hrp, sk, err := bech32.DecodeToBase256(s)
+	if err != nil {
+		return nil, fmt.Errorf("malformed secret key: %w", err)
+	}
+	defer secret.Wipe(sk)
+	if !strings.EqualFold(hrp, identityHRP) {
+		return nil, fmt.Errorf("malformed secret key: unknown type %q", hrp)
+	}
+	return NewX25519Identity(sk)
+}
+
+// String returns the AGE-SECRET-KEY-1 Bech32 encoding of the identity
+func (i *X25519Identity) String() string {
+	sk := i.key.Bytes()
+	defer secret.Wipe(sk)
+	s, _ := bech32.EncodeFromBase256(strings.ToLower(identityHRP), sk)
+	return strings.ToUpper(s)
+}
+
+// Recipient returns the recipient of the identity
+func (i *X25519Identity) Recipient() *X25519Recipient {
+	return &X25519Recipient{pk: i.key.PublicKey()}
+}
+
+// Zeroize overwrites the private key with zeros
+func (i *X25519Identity) Zeroize() {
+	i.key.Zeroize()
+}
+
+// Unwrap returns the file key of the first X25519 stanza addressed to the
+// identity
+func (i *X25519Identity) Unwrap(stanzas []*Stanza) ([]byte, error) {
+	for _, s := range stanzas {
+		if s.Type != x25519Type {
+			continue
+		}
+		if len(s.Args) != 1 {
+			return nil, fmt.Errorf("invalid X25519 stanza")
+		}
+		share, err := b64.DecodeString(s.Args[0])
+		if err != nil || len(share) != x25519KeyLength {
+			return nil, fmt.Errorf("invalid X25519 stanza share")
+		}
+		if len(s.Body) != wrappedKeySize {
+			return nil, fmt.Errorf("invalid X25519 stanza body")
+		}
+		shared, err := i.key.ECDH(share)
+		if err != nil {
+			return nil, fmt.Errorf("invalid X25519 stanza share: %w", err)
+		}
+		key, err := wrapKey(shared, share, i.key.PublicKey())
+		secret.Wipe(shared)
+		if err != nil {
+			return nil, err
+		}
+		a, err := chacha20poly1305.New(key)
+		secret.Wipe(key)
+		if err != nil {
+			return nil, err
+		}
+		fileKey, err := a.Open(nil, make([]byte, a.NonceSize()), s.Body, nil)
+		if err != nil {
+			// the stanza is addressed to another recipient
+			continue
+		}
+		return fileKey, nil
+	}
+	return nil, ErrIncorrectIdentity
+}
@@ -0,0 +1,232 @@
+// Package backup defines an encrypted, versioned backup format for the key
+// shares of the threshold signature schemes, so a wallet can be recovered
+// after the device holding a share is lost.
+//
+// A Share holds a tecdsa/dklsv1 DKG result or a ted25519 key share with the
+// joint public key, verification commitments and chain metadata. Seal
+// encrypts it under a passphrase with Argon2id and a key committing
+// XChaCha20-Poly1305, and Open decrypts it and checks the share against its
+// commitments before returning it. EncodeText and EncodeWords turn sealed
+// backups into QR code alphanumeric text or words of a BIP-39 wordlist, both
+// with a checksum that catches transcription errors before decryption.
+package backup
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/protocol"
+	"github.com/go-sonr/crypto/core/secret"
+	"github.com/go-sonr/crypto/tecdsa/dklsv1"
+	"github.com/go-sonr/crypto/ted25519/ted25519"
+)
+
+// Scheme is the threshold signature scheme of a share
+type Scheme string
+
+const (
+	// SchemeDKLs is a two party ECDSA share of tecdsa/dklsv1
+	SchemeDKLs Scheme = "dkls18"
+	// SchemeTEd25519 is a Shamir share of a ted25519 key
+	SchemeTEd25519 Scheme = "ted25519"
+)
+
+const (
+	partyAlice = "alice"
+	partyBob   = "bob"
+)
+
+// Chain describes what a key is used for
+type Chain struct {
+	// ID is the chain identifier, such as a CAIP-2 id
+	ID string `json:"id,omitempty"`
+	// Address is the address of the key on the chain
+	Address string `json:"address,omitempty"`
+	// Path is the derivation path of the key
+	Path string `json:"path,omitempty"`
+	// Code is the BIP-32 chain code for deriving child keys
+	Code []byte `json:"code,omitempty"`
+}
+
+// Share is the content of a backup
+type Share struct {
+	Scheme Scheme `json:"scheme"`
+	// Curve is the name of the curve of the key
+	Curve string `json:"curve"`
+	// Party is alice or bob for DKLs shares and the identifier of ted25519
+	// shares
+	Party string `json:"party"`
+	// Threshold and Total are the share configuration of ted25519 shares
+	Threshold int `json:"threshold,omitempty"`
+	Total     int `json:"total,omitempty"`
+	// PublicKey is the compressed joint public key
+	PublicKey []byte `json:"publicKey"`
+	// Secret is the JSON protocol message of a DKLs share or the bytes of a
+	// ted25519 share
+	Secret []byte `json:"secret"`
+	// Commitments are the public share of a DKLs share or the Feldman
+	// commitments of a ted25519 share
+	Commitments [][]byte  `json:"commitments"`
+	Chain       Chain     `json:"chain"`
+	CreatedAt   time.Time `json:"created"`
+}
+
+// NewDKLsShare returns the share of a DKG or refresh result of Alice or Bob
+func NewDKLsShare(curve *curves.Curve, result *protocol.Message, chain Chain) (*Share, error) {
+	if curve == nil || result == nil {
+		return nil, fmt.Errorf("backup: curve and result are required")
+	}
+	raw, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	s := &Share{
+		Scheme:    SchemeDKLs,
+		Curve:     curve.Name,
+		Secret:    raw,
+		Chain:     chain,
+		CreatedAt: time.Now().UTC(),
+	}
+	switch result.Metadata["round"] {
+	case "alice-output":
+		s.Party = partyAlice
+	case "bob-output":
+		s.Party = partyBob
+	default:
+		return nil, fmt.Errorf("backup: message is not a DKLs result")
+	}
+	pub, share, err := s.dklsKeys(result)
+	if err != nil {
+		return nil, err
+	}
+	s.PublicKey = pub.ToAffineCompressed()
+	s.Commitments = [][]byte{curve.ScalarBaseMult(share).ToAffineCompressed()}
+	return s, nil
+}
+
+// NewTEd25519Share returns a ted25519 key share of the key pub, with the
+// commitments and configuration it was split with
+func NewTEd25519Share(share *ted25519.KeyShare, pub ted25519.PublicKey, commitments ted25519.Commitments, config *ted25519.ShareConfiguration, chain Chain) (*Share, error) {
+	if share == nil || share.ShamirShare == nil || config == nil {
+		return nil, fmt.Errorf("backup: share and configuration are required")
+	}
+	s := &Share{
+		Scheme:      SchemeTEd25519,
+		Curve:       curves.ED25519Name,
+		Party:       strconv.FormatUint(uint64(share.Identifier), 10),
+		Threshold:   config.T,
+		Total:       config.N,
+		PublicKey:   append([]byte(nil), pub...),
+		Secret:      share.Bytes(),
+		Commitments: commitments.CommitmentsToBytes(),
+		Chain:       chain,
+		CreatedAt:   time.Now().UTC(),
+	}
+	if err := s.Verify(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Wipe overwrites the secret of the share
+func (s *Share) Wipe() {
+	secret.Wipe(s.Secret)
+}
+
+// Verify checks the secret of the share against its public key and
+// commitments
+func (s *Share) Verify() error {
+	switch s.Scheme {
+	case SchemeDKLs:
+		_, err := s.DKLsResult()
+		return err
+	case SchemeTEd25519:
+		_, _, _, err := s.TEd25519()
+		return err
+	default:
+		return fmt.Errorf("backup: unknown scheme %q", s.Scheme)
+	}
+}
+
+// DKLsResult returns the DKG result message of a DKLs share, for
+// dklsv1.NewAliceSign and the other protocols of the party
+func (s *Share) DKLsResult() (*protocol.Message, error) {
+	if s.Scheme != SchemeDKLs {
+		return nil, fmt.Errorf("backup: share is not a DKLs share")
+	}
+	curve := curves.GetCurveByName(s.Curve)
+	if curve == nil {
+		return nil, fmt.Errorf("backup: unknown curve %q", s.Curve)
+	}
+	m := &protocol.Message{}
+	if err := json.Unmarshal(s.Secret, m); err != nil {
+		return nil, fmt.Errorf("backup: invalid DKLs result: %w", err)
+	}
+	pub, share, err := s.dklsKeys(m)
+	if err != nil {
+		return nil, err
+	}
+	if pub.CurveName() != curve.Name || !bytes.Equal(pub.ToAffineCompressed(), s.PublicKey) {
+		return nil, fmt.Errorf("backup: DKLs result does not match the public key")
+	}
+	if len(s.Commitments) != 1 || !bytes.Equal(curve.ScalarBaseMult(share).ToAffineCompressed(), s.Commitments[0]) {
+		return nil, fmt.Errorf("backup: DKLs share does not match its commitment")
+	}
+	return m, nil
+}
+
+// dklsKeys decodes the joint public key and secret share of a DKLs result
+func (s *Share) dklsKeys(m *protocol.Message) (curves.Point, curves.Scalar, error) {
+	switch s.Party {
+	case partyAlice:
+		out, err := dklsv1.DecodeAliceDkgResult(m)
+		if err != nil {
+			return nil, nil, fmt.Errorf("backup: invalid DKLs result: %w", err)
+		}
+		return out.PublicKey, out.SecretKeyShare, nil
+	case partyBob:
+		out, err := dklsv1.DecodeBobDkgResult(m)
+		if err != nil {
+			return nil, nil, fmt.Errorf("backup: invalid DKLs result: %w", err)
+		}
+		return out.PublicKey, out.SecretKeyShare, nil
+	default:
+		return nil, nil, fmt.Errorf("backup: unknown DKLs party %q", s.Party)
+	}
+}
+
+// TEd25519 returns the key share, commitments and configuration of a
+// ted25519 share, after verifying the share against the commitments
+func (s *Share) TEd25519() (*ted25519.KeyShare, ted25519.Commitments, *ted25519.ShareConfiguration, error) {
+	if s.Scheme != SchemeTEd25519 {
+		return nil, nil, nil, fmt.Errorf("backup: share is not a ted25519 share")
+	}
+	// shares are a 4 byte identifier and a minimal big-endian value
+	if len(s.Secret) <= 4 || len(s.Secret) > 4+ted25519.PublicKeySize {
+		return nil, nil, nil, fmt.Errorf("backup: invalid ted25519 share")
+	}
+	config := &ted25519.ShareConfiguration{T: s.Threshold, N: s.Total}
+	if config.T < 2 || config.T > config.N {
+		return nil, nil, nil, fmt.Errorf("backup: invalid ted25519 share configuration")
+	}
+	commitments, err := ted25519.CommitmentsFromBytes(s.Commitments)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("backup: invalid ted25519 commitments: %w", err)
+	}
+	if len(commitments) == 0 || !bytes.Equal(commitments[0].ToAffineCompressed(), s.PublicKey) {
+		return nil, nil, nil, fmt.Errorf("backup: ted25519 commitments do not match the public key")
+	}
+	share := ted25519.KeyShareFromBytes(s.Secret)
+	if strconv.FormatUint(uint64(share.Identifier), 10) != s.Party {
+		return nil, nil, nil, fmt.Errorf("backup: ted25519 share identifier does not match")
+	}
+	ok, err := share.VerifyVSS(commitments, config)
+	if err != nil || !ok {
+		return nil, nil, nil, fmt.Errorf("backup: ted25519 share does not match its commitments")
+	}
+	return share, commitments, config, nil
+}
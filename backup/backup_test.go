@@ -0,0 +1,164 @@
+package backup
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/protocol"
+	"github.com/go-sonr/crypto/kdf"
+	"github.com/go-sonr/crypto/mnemonic"
+	"github.com/go-sonr/crypto/tecdsa/dklsv1"
+	"github.com/go-sonr/crypto/ted25519/ted25519"
+)
+
+// testParams keeps Argon2id cheap in tests
+var testParams = kdf.Argon2id{Time: 1, Memory: 64, Threads: 1}
+
+func runDKG(t *testing.T, curve *curves.Curve) (*protocol.Message, *protocol.Message) {
+	alice := dklsv1.NewAliceDkg(curve, protocol.Version1)
+	bob := dklsv1.NewBobDkg(curve, protocol.Version1)
+	var (
+		msg        *protocol.Message
+		aErr, bErr error
+	)
+	for aErr != protocol.ErrProtocolFinished || bErr != protocol.ErrProtocolFinished {
+		msg, bErr = bob.Next(msg)
+		if bErr != protocol.ErrProtocolFinished {
+			require.NoError(t, bErr)
+		}
+		msg, aErr = alice.Next(msg)
+		if aErr != protocol.ErrProtocolFinished {
+			require.NoError(t, aErr)
+		}
+	}
+	a, err := alice.Result(protocol.Version1)
+	require.NoError(t, err)
+	b, err := bob.Result(protocol.Version1)
+	require.NoError(t, err)
+	return a, b
+}
+
+func TestDKLsBackup(t *testing.T) {
+	curve := curves.K256()
+	aliceResult, bobResult := runDKG(t, curve)
+	chain := Chain{ID: "eip155:1", Path: "m/44'/60'/0'/0/0", Code: make([]byte, 32)}
+
+	alice, err := NewDKLsShare(curve, aliceResult, chain)
+	require.NoError(t, err)
+	require.Equal(t, "alice", alice.Party)
+	bob, err := NewDKLsShare(curve, bobResult, chain)
+	require.NoError(t, err)
+	require.Equal(t, "bob", bob.Party)
+	require.Equal(t, alice.PublicKey, bob.PublicKey)
+	require.NotEqual(t, alice.Commitments, bob.Commitments)
+
+	sealed, err := Seal(alice, []byte("passphrase"), testParams)
+	require.NoError(t, err)
+	_, err = Open(sealed, []byte("wrong"))
+	require.ErrorIs(t, err, ErrDecrypt)
+	restored, err := Open(sealed, []byte("passphrase"))
+	require.NoError(t, err)
+	require.Equal(t, chain, restored.Chain)
+	require.Equal(t, alice.PublicKey, restored.PublicKey)
+
+	// the restored result runs the protocols of the party
+	m, err := restored.DKLsResult()
+	require.NoError(t, err)
+	_, err = dklsv1.NewAliceSign(curve, nil, []byte("message"), m, protocol.Version1)
+	require.NoError(t, err)
+
+	// a share that does not match its commitment is rejected
+	bad := *alice
+	bad.Commitments = bob.Commitments
+	require.Error(t, bad.Verify())
+	_, err = Seal(&bad, []byte("passphrase"), testParams)
+	require.Error(t, err)
+
+	// large backups are split into QR code parts
+	parts, err := EncodeText(sealed, 2000)
+	require.NoError(t, err)
+	require.Greater(t, len(parts), 1)
+	for _, p := range parts {
+		require.LessOrEqual(t, len(p), 2000+len("SNRB1:00/00:"))
+	}
+	parts[0], parts[1] = parts[1], parts[0]
+	decoded, err := DecodeText(parts...)
+	require.NoError(t, err)
+	require.Equal(t, sealed, decoded)
+	_, err = DecodeText(parts[1:]...)
+	require.Error(t, err)
+}
+
+func TestTEd25519Backup(t *testing.T) {
+	config := &ted25519.ShareConfiguration{T: 2, N: 3}
+	pub, shares, commitments, err := ted25519.GenerateSharedKey(config)
+	require.NoError(t, err)
+
+	share, err := NewTEd25519Share(shares[1], pub, commitments, config, Chain{ID: "solana:mainnet"})
+	require.NoError(t, err)
+	require.Equal(t, "2", share.Party)
+	_, err = NewTEd25519Share(shares[1], pub, commitments[1:], config, Chain{})
+	require.Error(t, err)
+
+	sealed, err := Seal(share, []byte("passphrase"), testParams)
+	require.NoError(t, err)
+
+	wordlist, err := mnemonic.GetWordlist(mnemonic.English)
+	require.NoError(t, err)
+	words, err := EncodeWords(sealed, wordlist)
+	require.NoError(t, err)
+	decoded, err := DecodeWords(words, wordlist)
+	require.NoError(t, err)
+	require.Equal(t, sealed, decoded)
+
+	// a mistyped word fails the checksum
+	fields := strings.Fields(words)
+	fields[3] = "zoo"
+	if fields[3] == strings.Fields(words)[3] {
+		fields[3] = "abandon"
+	}
+	_, err = DecodeWords(strings.Join(fields, " "), wordlist)
+	require.Error(t, err)
+
+	parts, err := EncodeText(sealed, 0)
+	require.NoError(t, err)
+	require.Len(t, parts, 1)
+	decoded, err = DecodeText(parts...)
+	require.NoError(t, err)
+
+	restored, err := Open(decoded, []byte("passphrase"))
+	require.NoError(t, err)
+	got, gotCommitments, gotConfig, err := restored.TEd25519()
+	require.NoError(t, err)
+	require.Equal(t, shares[1].Bytes(), got.Bytes())
+	require.Len(t, gotCommitments, len(commitments))
+	require.Equal(t, config, gotConfig)
+
+	// the restored share reconstructs the key with another share
+	secret, err := ted25519.Reconstruct([]*ted25519.KeyShare{shares[0], got}, config)
+	require.NoError(t, err)
+	expected, err := ted25519.Reconstruct([]*ted25519.KeyShare{shares[0], shares[1]}, config)
+	require.NoError(t, err)
+	require.Equal(t, expected, secret)
+}
+
+func TestFrame(t *testing.T) {
+	for n := 0; n < 40; n++ {
+		data := make([]byte, n)
+		for i := range data {
+			data[i] = byte(i * 7)
+		}
+		wordlist, err := mnemonic.GetWordlist(mnemonic.English)
+		require.NoError(t, err)
+		words, err := EncodeWords(data, wordlist)
+		require.NoError(t, err)
+		decoded, err := DecodeWords(words, wordlist)
+		require.NoError(t, err)
+		require.Equal(t, data, decoded)
+	}
+	_, err := Open([]byte("SNRB"), []byte("passphrase"))
+	require.Error(t, err)
+}
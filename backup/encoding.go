@@ -0,0 +1,186 @@
+package backup
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-sonr/crypto/mnemonic"
+)
+
+const (
+	// textPrefix starts every part of a text encoded backup
+	textPrefix = "SNRB1"
+	// checksumSize is the size of the SHA-256 checksum of framed backups
+	checksumSize = 4
+)
+
+// text is base32 without padding, all in the QR code alphanumeric set
+var text = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// frame prefixes data with its length and appends a checksum, so encodings
+// that pad data can be decoded exactly and corrupted transcriptions are
+// caught before decryption
+func frame(data []byte) []byte {
+	out := binary.BigEndian.AppendUint32(make([]byte, 0, 4+len(data)+checksumSize), uint32(len(data)))
+	out = append(out, data...)
+	sum := sha256.Sum256(out)
+	return append(out, sum[:checksumSize]...)
+}
+
+// unframe checks a frame and returns its data, ignoring the trailing zero
+// padding of encodings
+func unframe(f []byte) ([]byte, error) {
+	if len(f) < 4+checksumSize {
+		return nil, fmt.Errorf("backup: encoding is too short")
+	}
+	n := binary.BigEndian.Uint32(f)
+	if uint64(n) > uint64(len(f)-4-checksumSize) {
+		return nil, fmt.Errorf("backup: encoding is truncated")
+	}
+	end := 4 + int(n)
+	padding := f[end+checksumSize:]
+	if len(padding) > 1 || bytes.Count(padding, []byte{0}) != len(padding) {
+		return nil, fmt.Errorf("backup: invalid encoding padding")
+	}
+	sum := sha256.Sum256(f[:end])
+	if !bytes.Equal(sum[:checksumSize], f[end:end+checksumSize]) {
+		return nil, fmt.Errorf("backup: checksum mismatch")
+	}
+	return f[4:end], nil
+}
+
+// EncodeText encodes a sealed backup as text in the QR code alphanumeric
+// set, split into parts of at most partSize characters for QR code
+// sequences, or one part when partSize is zero. Each part is
+// SNRB1:<index>/<count>:<base32>.
+func EncodeText(data []byte, partSize int) ([]string, error) {
+	encoded := text.EncodeToString(frame(data))
+	if partSize == 0 {
+		partSize = len(encoded)
+	}
+	if partSize <= 0 {
+		return nil, fmt.Errorf("backup: invalid part size %d", partSize)
+	}
+	count := (len(encoded) + partSize - 1) / partSize
+	parts := make([]string, count)
+	for i := range parts {
+		end := min((i+1)*partSize, len(encoded))
+		parts[i] = fmt.Sprintf("%s:%d/%d:%s", textPrefix, i+1, count, encoded[i*partSize:end])
+	}
+	return parts, nil
+}
+
+// DecodeText decodes the parts of EncodeText, in any order, into a sealed
+// backup
+func DecodeText(parts ...string) ([]byte, error) {
+	type part struct {
+		index int
+		data  string
+	}
+	decoded := make([]part, 0, len(parts))
+	count := 0
+	for _, p := range parts {
+		fields := strings.SplitN(strings.TrimSpace(p), ":", 3)
+		if len(fields) != 3 || fields[0] != textPrefix {
+			return nil, fmt.Errorf("backup: invalid text part")
+		}
+		i, n, ok := strings.Cut(fields[1], "/")
+		if !ok {
+			return nil, fmt.Errorf("backup: invalid text part index")
+		}
+		index, err := strconv.Atoi(i)
+		if err != nil {
+			return nil, fmt.Errorf("backup: invalid text part index")
+		}
+		total, err := strconv.Atoi(n)
+		if err != nil || total < 1 || index < 1 || index > total || (count != 0 && total != count) {
+			return nil, fmt.Errorf("backup: invalid text part index")
+		}
+		count = total
+		decoded = append(decoded, part{index: index, data: fields[2]})
+	}
+	if count == 0 || len(decoded) != count {
+		return nil, fmt.Errorf("backup: expected %d text parts, got %d", count, len(decoded))
+	}
+	sort.Slice(decoded, func(i, j int) bool { return decoded[i].index < decoded[j].index })
+	var sb strings.Builder
+	for i, p := range decoded {
+		if p.index != i+1 {
+			return nil, fmt.Errorf("backup: duplicate text part %d", p.index)
+		}
+		sb.WriteString(p.data)
+	}
+	f, err := text.DecodeString(strings.ToUpper(sb.String()))
+	if err != nil {
+		return nil, fmt.Errorf("backup: invalid text encoding: %w", err)
+	}
+	return unframe(f)
+}
+
+// EncodeWords encodes a sealed backup as words of wordlist, 11 bits a word
+// as in BIP-39 mnemonics
+func EncodeWords(data []byte, wordlist *mnemonic.Wordlist) (string, error) {
+	if wordlist == nil {
+		return "", fmt.Errorf("backup: wordlist is required")
+	}
+	f := frame(data)
+	count := (len(f)*8 + 10) / 11
+	words := make([]string, count)
+	for i := range words {
+		w, err := wordlist.Word(readBits(f, i*11))
+		if err != nil {
+			return "", err
+		}
+		words[i] = w
+	}
+	return strings.Join(words, wordlist.Separator()), nil
+}
+
+// DecodeWords decodes the words of EncodeWords into a sealed backup
+func DecodeWords(words string, wordlist *mnemonic.Wordlist) ([]byte, error) {
+	if wordlist == nil {
+		return nil, fmt.Errorf("backup: wordlist is required")
+	}
+	fields := strings.Fields(words)
+	f := make([]byte, (len(fields)*11+7)/8)
+	for i, w := range fields {
+		index, ok := wordlist.Index(w)
+		if !ok {
+			return nil, fmt.Errorf("backup: word %q is not in the %s wordlist", w, wordlist.Language())
+		}
+		writeBits(f, i*11, index)
+	}
+	// the bits of the last word past the frame are zero, drop the partial
+	// byte they leave
+	f = f[:len(fields)*11/8]
+	return unframe(f)
+}
+
+// readBits reads the 11 bits of data at bit offset, zero past its end
+func readBits(data []byte, offset int) int {
+	v := 0
+	for i := 0; i < 11; i++ {
+		bit := offset + i
+		v <<= 1
+		if bit/8 < len(data) && data[bit/8]&(0x80>>(bit%8)) != 0 {
+			v |= 1
+		}
+	}
+	return v
+}
+
+// writeBits writes v as 11 bits of data at bit offset
+func writeBits(data []byte, offset, v int) {
+	for i := 0; i < 11; i++ {
+		if v&(1<<(10-i)) != 0 {
+			bit := offset + i
+			data[bit/8] |= 0x80 >> (bit % 8)
+		}
+	}
+}
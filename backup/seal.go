@@ -0,0 +1,120 @@
+package backup
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/go-sonr/crypto/aead"
+	"github.com/go-sonr/crypto/core/secret"
+	"github.com/go-sonr/crypto/kdf"
+)
+
+// Version is the version of the backup format Seal writes
+const Version = 1
+
+const (
+	magic    = "SNRB"
+	keyInfo  = "sonr-backup-v1"
+	saltSize = 16
+	// headerSize is magic, version, Argon2id time, memory and threads, and
+	// salt
+	headerSize = len(magic) + 1 + 4 + 4 + 1 + saltSize
+)
+
+// ErrDecrypt is returned by Open when a backup cannot be decrypted, usually
+// because of a wrong passphrase
+var ErrDecrypt = errors.New("backup: decryption failed")
+
+// cipherAlgorithm encrypts backups
+const cipherAlgorithm = aead.XChaCha20Poly1305
+
+// Seal encrypts share under passphrase. The key is derived with Argon2id
+// and params, kdf.DefaultArgon2id unless backups are restored on
+// constrained devices.
+//
+// Backups are magic || version || time || memory || threads || salt ||
+// ciphertext, with the header authenticated as additional data.
+func Seal(share *Share, passphrase []byte, params kdf.Argon2id) ([]byte, error) {
+	if share == nil || len(passphrase) == 0 {
+		return nil, fmt.Errorf("backup: share and passphrase are required")
+	}
+	if err := share.Verify(); err != nil {
+		return nil, err
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	header := make([]byte, headerSize)
+	copy(header, magic)
+	header[4] = Version
+	binary.BigEndian.PutUint32(header[5:], params.Time)
+	binary.BigEndian.PutUint32(header[9:], params.Memory)
+	header[13] = params.Threads
+	if _, err := io.ReadFull(rand.Reader, header[14:]); err != nil {
+		return nil, err
+	}
+	plaintext, err := json.Marshal(share)
+	if err != nil {
+		return nil, err
+	}
+	defer secret.Wipe(plaintext)
+	key, err := deriveKey(passphrase, header)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := key.Seal(plaintext, header)
+	if err != nil {
+		return nil, err
+	}
+	return append(header, ciphertext...), nil
+}
+
+// Open decrypts a backup of Seal with passphrase and verifies the share
+// against its commitments
+func Open(data, passphrase []byte) (*Share, error) {
+	if len(data) < headerSize || string(data[:len(magic)]) != magic {
+		return nil, fmt.Errorf("backup: not a backup")
+	}
+	if data[4] != Version {
+		return nil, fmt.Errorf("backup: unsupported version %d", data[4])
+	}
+	header := data[:headerSize]
+	key, err := deriveKey(passphrase, header)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := key.Open(data[headerSize:], header)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	defer secret.Wipe(plaintext)
+	share := &Share{}
+	if err := json.Unmarshal(plaintext, share); err != nil {
+		return nil, fmt.Errorf("backup: invalid share: %w", err)
+	}
+	if err := share.Verify(); err != nil {
+		share.Wipe()
+		return nil, err
+	}
+	return share, nil
+}
+
+// deriveKey derives the key of a backup from passphrase and the parameters
+// and salt of its header
+func deriveKey(passphrase, header []byte) (*aead.Key, error) {
+	params := kdf.Argon2id{
+		Time:    binary.BigEndian.Uint32(header[5:]),
+		Memory:  binary.BigEndian.Uint32(header[9:]),
+		Threads: header[13],
+	}
+	ikm, err := params.Key(passphrase, header[14:], cipherAlgorithm.KeySize())
+	if err != nil {
+		return nil, err
+	}
+	defer secret.Wipe(ikm)
+	return aead.NewKey(cipherAlgorithm, ikm, nil, []byte(keyInfo))
+}
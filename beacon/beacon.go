@@ -0,0 +1,270 @@
+// Package beacon runs a distributed verifiable randomness beacon in the
+// style of drand, https://drand.love/docs/specification/.
+//
+// A group shares a BLS12-381 key with a distributed key generation such as
+// dkg/pedersen. In every round each node signs the message of the round
+// with its share, and any threshold of valid partial signatures aggregate
+// to the threshold BLS signature of the group, whose hash is the
+// randomness of the round. BLS signatures are unique, so the randomness is
+// fixed by the group key yet unpredictable until a threshold contributes,
+// and anyone holding the group key can verify it.
+//
+// A chained beacon signs each round together with the signature of the
+// previous round, starting from a genesis seed, so a verifier can check a
+// run of rounds links back to a round it trusts. An unchained beacon signs
+// the round number alone, so rounds can be signed ahead of their
+// predecessors.
+package beacon
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/go-sonr/crypto/core/protocol/messages"
+	"github.com/go-sonr/crypto/dkg/pedersen"
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/signatures/bls/bls_sig"
+	"github.com/go-sonr/crypto/signatures/bls/tbls"
+)
+
+// Group is the public information of a beacon, all a verifier needs
+type Group struct {
+	Key *tbls.PublicKey
+	// Genesis is the previous signature of round 1 of a chained beacon
+	Genesis []byte
+	// Chained is whether each round signs the previous signature
+	Chained bool
+}
+
+// Round is the output of a beacon round
+type Round struct {
+	Number uint64
+	// Previous is the signature of the previous round of a chained beacon,
+	// or the genesis seed for round 1
+	Previous  []byte
+	Signature *bls_sig.Signature
+}
+
+// Node is a member of the group contributing partial signatures
+type Node struct {
+	*Group
+	share *tbls.KeyShare
+}
+
+// NewGroup returns the beacon of key. genesis must be set for a chained
+// beacon and empty for an unchained one.
+func NewGroup(key *tbls.PublicKey, genesis []byte, chained bool) (*Group, error) {
+	if key == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if chained && len(genesis) == 0 {
+		return nil, fmt.Errorf("beacon: a chained beacon needs a genesis seed")
+	}
+	if !chained && len(genesis) != 0 {
+		return nil, fmt.Errorf("beacon: an unchained beacon has no genesis seed")
+	}
+	return &Group{Key: key, Genesis: append([]byte(nil), genesis...), Chained: chained}, nil
+}
+
+// NewNode returns the node of the group signing with share, whose public
+// key must be the key of the group
+func NewNode(group *Group, share *tbls.KeyShare) (*Node, error) {
+	if group == nil || share == nil {
+		return nil, internal.ErrNilArguments
+	}
+	a, err := share.Key.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	b, err := group.Key.Key.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(a, b) {
+		return nil, fmt.Errorf("beacon: share is not a share of the group key")
+	}
+	return &Node{Group: group, share: share}, nil
+}
+
+// FromPedersen returns the node of a dkg/pedersen participant, with the
+// group of the DKG. A nil scheme is bls_sig.NewSigPop().
+func FromPedersen(scheme tbls.Scheme, result *pedersen.Result, genesis []byte, chained bool) (*Node, error) {
+	share, err := tbls.FromPedersen(scheme, result)
+	if err != nil {
+		return nil, err
+	}
+	group, err := NewGroup(share.PublicKey, genesis, chained)
+	if err != nil {
+		return nil, err
+	}
+	return NewNode(group, share)
+}
+
+// ID returns the identifier of the node in the group
+func (n *Node) ID() uint32 {
+	return n.share.ID
+}
+
+// Contribute returns the partial signature of the node for round number,
+// given the previous round of a chained beacon. previous is nil for round
+// 1 and for unchained beacons.
+func (n *Node) Contribute(number uint64, previous *Round) (*bls_sig.PartialSignature, error) {
+	prev, err := n.previous(number, previous)
+	if err != nil {
+		return nil, err
+	}
+	return n.share.Sign(tbls.BeaconMessage(number, prev))
+}
+
+// previous returns the previous signature a round signs, checking previous
+// is the round before number
+func (g *Group) previous(number uint64, previous *Round) ([]byte, error) {
+	if number == 0 {
+		return nil, fmt.Errorf("beacon: rounds start at 1")
+	}
+	if !g.Chained {
+		return nil, nil
+	}
+	if number == 1 {
+		return g.Genesis, nil
+	}
+	if previous == nil || previous.Signature == nil {
+		return nil, fmt.Errorf("beacon: round %d needs round %d", number, number-1)
+	}
+	if previous.Number != number-1 {
+		return nil, fmt.Errorf("beacon: round %d does not follow round %d", number, previous.Number)
+	}
+	return previous.Signature.MarshalBinary()
+}
+
+// VerifyContribution checks a partial signature of round number against
+// the public share of its signer
+func (g *Group) VerifyContribution(number uint64, previous *Round, partial *bls_sig.PartialSignature) error {
+	prev, err := g.previous(number, previous)
+	if err != nil {
+		return err
+	}
+	return g.Key.VerifyPartial(tbls.BeaconMessage(number, prev), partial)
+}
+
+// Aggregate combines the partial signatures of round number, ignoring
+// invalid and duplicate ones, into the round. It fails when fewer than
+// threshold nodes contributed correctly.
+func (g *Group) Aggregate(number uint64, previous *Round, partials ...*bls_sig.PartialSignature) (*Round, error) {
+	prev, err := g.previous(number, previous)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := g.Key.Combine(tbls.BeaconMessage(number, prev), partials...)
+	if err != nil {
+		return nil, err
+	}
+	return &Round{Number: number, Previous: prev, Signature: sig}, nil
+}
+
+// Verify checks the signature of a round. It trusts the previous signature
+// the round carries, which VerifyChain checks against the rounds before.
+func (g *Group) Verify(r *Round) error {
+	if r == nil || r.Signature == nil {
+		return internal.ErrNilArguments
+	}
+	if r.Number == 0 {
+		return fmt.Errorf("beacon: rounds start at 1")
+	}
+	switch {
+	case !g.Chained && len(r.Previous) != 0:
+		return fmt.Errorf("beacon: round %d of an unchained beacon has a previous signature", r.Number)
+	case g.Chained && r.Number == 1 && !bytes.Equal(r.Previous, g.Genesis):
+		return fmt.Errorf("beacon: round 1 does not follow the genesis seed")
+	case g.Chained && len(r.Previous) == 0:
+		return fmt.Errorf("beacon: round %d has no previous signature", r.Number)
+	}
+	if err := g.Key.Verify(tbls.BeaconMessage(r.Number, r.Previous), r.Signature); err != nil {
+		return fmt.Errorf("beacon: round %d: %w", r.Number, err)
+	}
+	return nil
+}
+
+// VerifyChain checks consecutive rounds, each signed by the group and, for
+// a chained beacon, signing the signature of the round before. The first
+// round is anchored to the genesis seed if it is round 1, and otherwise
+// trusted to follow the round its previous signature is from.
+func (g *Group) VerifyChain(rounds ...*Round) error {
+	for i, r := range rounds {
+		if err := g.Verify(r); err != nil {
+			return err
+		}
+		if i == 0 {
+			continue
+		}
+		if r.Number != rounds[i-1].Number+1 {
+			return fmt.Errorf("beacon: round %d does not follow round %d", r.Number, rounds[i-1].Number)
+		}
+		if !g.Chained {
+			continue
+		}
+		prev, err := rounds[i-1].Signature.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(r.Previous, prev) {
+			return fmt.Errorf("beacon: round %d does not sign round %d", r.Number, rounds[i-1].Number)
+		}
+	}
+	return nil
+}
+
+// Randomness returns the 32 byte random output of the round
+func (r *Round) Randomness() ([]byte, error) {
+	if r == nil {
+		return nil, internal.ErrNilArguments
+	}
+	return tbls.Randomness(r.Signature)
+}
+
+const roundType = "beacon/round"
+
+// MarshalBinary encodes the round in the canonical messages format.
+func (r *Round) MarshalBinary() ([]byte, error) {
+	if r == nil || r.Signature == nil {
+		return nil, internal.ErrNilArguments
+	}
+	sig, err := r.Signature.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	var number [8]byte
+	binary.BigEndian.PutUint64(number[:], r.Number)
+	enc := messages.NewEncoder(roundType, nil)
+	enc.WriteBytes(number[:])
+	enc.WriteBytes(r.Previous)
+	enc.WriteBytes(sig)
+	return enc.Finish()
+}
+
+// UnmarshalBinary decodes a round encoded by MarshalBinary.
+func (r *Round) UnmarshalBinary(data []byte) error {
+	dec, err := messages.NewDecoder(data, roundType)
+	if err != nil {
+		return err
+	}
+	number := dec.ReadBytes()
+	previous := dec.ReadBytes()
+	sig := dec.ReadBytes()
+	if err = dec.Finish(); err != nil {
+		return err
+	}
+	if len(number) != 8 {
+		return fmt.Errorf("beacon: invalid round number")
+	}
+	signature := new(bls_sig.Signature)
+	if err = signature.UnmarshalBinary(sig); err != nil {
+		return err
+	}
+	if len(previous) == 0 {
+		previous = nil
+	}
+	r.Number, r.Previous, r.Signature = binary.BigEndian.Uint64(number), previous, signature
+	return nil
+}
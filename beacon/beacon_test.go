@@ -0,0 +1,203 @@
+package beacon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/dkg/pedersen"
+	"github.com/go-sonr/crypto/signatures/bls/bls_sig"
+)
+
+// runDkg runs the Pedersen DKG of limit participants over BLS12-381 G1
+func runDkg(t *testing.T, threshold, limit uint32) map[uint32]*pedersen.Result {
+	curve := curves.BLS12381G1()
+	participants := make(map[uint32]*pedersen.Participant, limit)
+	for i := uint32(1); i <= limit; i++ {
+		var others []uint32
+		for j := uint32(1); j <= limit; j++ {
+			if i != j {
+				others = append(others, j)
+			}
+		}
+		p, err := pedersen.NewParticipant(i, threshold, curve, []byte("beacon test"), others...)
+		require.NoError(t, err)
+		participants[i] = p
+	}
+
+	bcast1 := make(map[uint32]*pedersen.Round1Bcast)
+	p2p1 := make(map[uint32]pedersen.Round1P2PSend)
+	for id, p := range participants {
+		b, s, err := p.Round1(nil)
+		require.NoError(t, err)
+		bcast1[id], p2p1[id] = b, s
+	}
+	bcast2 := make(map[uint32]*pedersen.Round2Bcast)
+	for id, p := range participants {
+		in := make(map[uint32]*pedersen.Round1P2PSendPacket)
+		for from, s := range p2p1 {
+			if from != id {
+				in[from] = s[id]
+			}
+		}
+		b, err := p.Round2(bcast1, in)
+		require.NoError(t, err)
+		bcast2[id] = b
+	}
+	bcast3 := make(map[uint32]*pedersen.Round3Bcast)
+	for id, p := range participants {
+		b, err := p.Round3(bcast2)
+		require.NoError(t, err)
+		bcast3[id] = b
+	}
+	bcast4 := make(map[uint32]*pedersen.Round4Bcast)
+	for id, p := range participants {
+		b, err := p.Round4(bcast3)
+		require.NoError(t, err)
+		bcast4[id] = b
+	}
+	bcast5 := make(map[uint32]*pedersen.Round5Bcast)
+	for id, p := range participants {
+		b, err := p.Round5(bcast4)
+		require.NoError(t, err)
+		bcast5[id] = b
+	}
+	bcast6 := make(map[uint32]*pedersen.Round6Bcast)
+	for id, p := range participants {
+		b, err := p.Round6(bcast5)
+		require.NoError(t, err)
+		bcast6[id] = b
+	}
+	results := make(map[uint32]*pedersen.Result, limit)
+	for id, p := range participants {
+		r, err := p.Finalize(bcast6)
+		require.NoError(t, err)
+		results[id] = r
+	}
+	return results
+}
+
+func newNodes(t *testing.T, genesis []byte, chained bool) []*Node {
+	results := runDkg(t, 3, 5)
+	nodes := make([]*Node, 0, len(results))
+	for id := uint32(1); id <= 5; id++ {
+		n, err := FromPedersen(nil, results[id], genesis, chained)
+		require.NoError(t, err)
+		nodes = append(nodes, n)
+	}
+	return nodes
+}
+
+// runRound has the nodes contribute to round number and aggregates the
+// contributions of the first three
+func runRound(t *testing.T, nodes []*Node, number uint64, previous *Round) *Round {
+	var partials []*bls_sig.PartialSignature
+	for _, n := range nodes[:3] {
+		p, err := n.Contribute(number, previous)
+		require.NoError(t, err)
+		require.NoError(t, nodes[4].VerifyContribution(number, previous, p))
+		partials = append(partials, p)
+	}
+	r, err := nodes[4].Aggregate(number, previous, partials...)
+	require.NoError(t, err)
+	return r
+}
+
+func TestChainedBeacon(t *testing.T) {
+	nodes := newNodes(t, []byte("genesis"), true)
+	group := nodes[0].Group
+
+	var rounds []*Round
+	var previous *Round
+	seen := map[string]bool{}
+	for number := uint64(1); number <= 4; number++ {
+		r := runRound(t, nodes, number, previous)
+		require.NoError(t, group.Verify(r))
+		out, err := r.Randomness()
+		require.NoError(t, err)
+		require.Len(t, out, 32)
+		require.False(t, seen[string(out)])
+		seen[string(out)] = true
+		rounds = append(rounds, r)
+		previous = r
+	}
+	require.NoError(t, group.VerifyChain(rounds...))
+	require.NoError(t, group.VerifyChain(rounds[2:]...))
+
+	// another quorum signs the same round
+	var partials []*bls_sig.PartialSignature
+	for _, n := range nodes[2:] {
+		p, err := n.Contribute(2, rounds[0])
+		require.NoError(t, err)
+		partials = append(partials, p)
+	}
+	again, err := group.Aggregate(2, rounds[0], partials...)
+	require.NoError(t, err)
+	a, err := again.Randomness()
+	require.NoError(t, err)
+	b, err := rounds[1].Randomness()
+	require.NoError(t, err)
+	require.Equal(t, a, b)
+
+	// gaps, reordering and forks break the chain
+	require.Error(t, group.VerifyChain(rounds[0], rounds[2]))
+	require.Error(t, group.VerifyChain(rounds[1], rounds[0]))
+	fork := *rounds[2]
+	fork.Previous = append([]byte(nil), rounds[0].Previous...)
+	require.Error(t, group.Verify(&fork))
+	require.Error(t, group.VerifyChain(rounds[1], &fork))
+
+	// a round must follow its predecessor
+	_, err = nodes[0].Contribute(3, rounds[0])
+	require.Error(t, err)
+	_, err = nodes[0].Contribute(2, nil)
+	require.Error(t, err)
+	_, err = nodes[0].Contribute(0, nil)
+	require.Error(t, err)
+
+	// a contribution to another round does not count
+	p, err := nodes[0].Contribute(1, nil)
+	require.NoError(t, err)
+	require.Error(t, group.VerifyContribution(2, rounds[0], p))
+}
+
+func TestUnchainedBeacon(t *testing.T) {
+	nodes := newNodes(t, nil, false)
+	group := nodes[0].Group
+
+	// rounds do not depend on each other
+	r7 := runRound(t, nodes, 7, nil)
+	r6 := runRound(t, nodes, 6, nil)
+	require.NoError(t, group.VerifyChain(r6, r7))
+	require.Error(t, group.VerifyChain(r7, r6))
+
+	r6.Number = 8
+	require.Error(t, group.Verify(r6))
+
+	_, err := NewGroup(group.Key, []byte("genesis"), false)
+	require.Error(t, err)
+	_, err = NewGroup(group.Key, nil, true)
+	require.Error(t, err)
+}
+
+func TestRoundMarshalBinary(t *testing.T) {
+	nodes := newNodes(t, []byte("genesis"), true)
+	r1 := runRound(t, nodes, 1, nil)
+	r2 := runRound(t, nodes, 2, r1)
+
+	data, err := r2.MarshalBinary()
+	require.NoError(t, err)
+	decoded := new(Round)
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	require.Equal(t, r2.Number, decoded.Number)
+	require.NoError(t, nodes[0].VerifyChain(r1, decoded))
+	require.Error(t, decoded.UnmarshalBinary(data[:len(data)-1]))
+}
+
+func TestNewNodeRejectsForeignShare(t *testing.T) {
+	a := newNodes(t, []byte("genesis"), true)
+	b := newNodes(t, []byte("genesis"), true)
+	_, err := NewNode(a[0].Group, b[0].share)
+	require.Error(t, err)
+}
@@ -0,0 +1,155 @@
+package bitcoin
+
+import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/sharing"
+	"github.com/go-sonr/crypto/signatures/schnorr/bip340"
+)
+
+func TestSegwitAddress(t *testing.T) {
+	// BIP-173 and BIP-350 test vectors
+	for _, tc := range []struct {
+		addr    string
+		version byte
+		program string
+	}{
+		{"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", 0, "751e76e8199196d454941c45d1b3a323f1433bd6"},
+		{"bc1pw508d6qejxtdg4y5r3zarvary0c5xw7kw508d6qejxtdg4y5r3zarvary0c5xw7kt5nd6y", 1, "751e76e8199196d454941c45d1b3a323f1433bd6751e76e8199196d454941c45d1b3a323f1433bd6"},
+	} {
+		version, program, err := DecodeSegwitAddress(Mainnet, tc.addr)
+		require.NoError(t, err)
+		require.Equal(t, tc.version, version)
+		require.Equal(t, tc.program, hex.EncodeToString(program))
+
+		addr, err := EncodeSegwitAddress(Mainnet, version, program)
+		require.NoError(t, err)
+		require.Equal(t, tc.addr, addr)
+	}
+
+	// Version 0 with a bech32m checksum
+	_, _, err := DecodeSegwitAddress(Mainnet, "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kemeawh")
+	require.Error(t, err)
+	_, _, err = DecodeSegwitAddress(Testnet, "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4")
+	require.Error(t, err)
+}
+
+func TestP2WPKH(t *testing.T) {
+	g := curves.K256().Point.Generator()
+	addr, err := P2WPKHAddress(g, Mainnet)
+	require.NoError(t, err)
+	require.Equal(t, "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", addr)
+
+	script, err := P2WPKHScript(g)
+	require.NoError(t, err)
+	require.Equal(t, "0014751e76e8199196d454941c45d1b3a323f1433bd6", hex.EncodeToString(script))
+
+	_, err = P2WPKHAddress(curves.ED25519().Point.Generator(), Mainnet)
+	require.Error(t, err)
+	_, err = P2WPKHScript(nil)
+	require.Error(t, err)
+}
+
+func TestP2TRKeyPath(t *testing.T) {
+	// BIP-86 m/86'/0'/0'/0/0 of the abandon ... about mnemonic
+	b, _ := hex.DecodeString("02cc8a4bc64d897bddc5fbc2f670f7a8ba0b386779106cf1223c6fc5d7cd6fc115")
+	internalKey, err := curves.K256().Point.FromAffineCompressed(b)
+	require.NoError(t, err)
+
+	q, _, err := TaprootOutputKey(internalKey, nil)
+	require.NoError(t, err)
+	require.Equal(t, "a60869f0dbcf1dc659c9cecbaf8050135ea9e8cdc487053f1dc6880949dc684c", hex.EncodeToString(bip340.XOnly(q)))
+
+	addr, err := P2TRAddress(internalKey, nil, Mainnet)
+	require.NoError(t, err)
+	require.Equal(t, "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr", addr)
+
+	script, err := P2TRScript(internalKey, nil)
+	require.NoError(t, err)
+	require.Equal(t, "5120a60869f0dbcf1dc659c9cecbaf8050135ea9e8cdc487053f1dc6880949dc684c", hex.EncodeToString(script))
+}
+
+func TestTweakSecretKey(t *testing.T) {
+	curve := curves.K256()
+	tree := NewTapTree(NewTapscriptLeaf([]byte{0x51}), NewTapscriptLeaf([]byte{0x52}), NewTapscriptLeaf([]byte{0x53}))
+	for i := 0; i < 10; i++ {
+		sk := curve.Scalar.Random(crand.Reader)
+		internalKey := curve.ScalarBaseMult(sk)
+		for _, root := range [][]byte{nil, tree.Hash()} {
+			q, _, err := TaprootOutputKey(internalKey, root)
+			require.NoError(t, err)
+			tweaked, err := TweakSecretKey(sk, root)
+			require.NoError(t, err)
+			require.Equal(t, bip340.XOnly(q), bip340.XOnly(curve.ScalarBaseMult(tweaked)))
+		}
+	}
+}
+
+func TestTweakShamirShare(t *testing.T) {
+	curve := curves.K256()
+	shamir, err := sharing.NewShamir(2, 3, curve)
+	require.NoError(t, err)
+	tree := NewTapTree(NewTapscriptLeaf([]byte{0x51}))
+
+	sk := curve.Scalar.Random(crand.Reader)
+	internalKey := curve.ScalarBaseMult(sk)
+	shares, err := shamir.Split(sk, crand.Reader)
+	require.NoError(t, err)
+
+	tweaked := make([]*sharing.ShamirShare, len(shares))
+	for i, share := range shares {
+		tweaked[i], err = TweakShamirShare(share, internalKey, tree.Hash())
+		require.NoError(t, err)
+	}
+	expected, err := TweakSecretKey(sk, tree.Hash())
+	require.NoError(t, err)
+	combined, err := shamir.Combine(tweaked[1], tweaked[2])
+	require.NoError(t, err)
+	require.Equal(t, 0, expected.Cmp(combined))
+
+	q, _, err := TaprootOutputKey(internalKey, tree.Hash())
+	require.NoError(t, err)
+	signer, err := bip340.SecretKeyFromScalar(combined)
+	require.NoError(t, err)
+	msg := make([]byte, 32)
+	sig, err := signer.Sign(msg)
+	require.NoError(t, err)
+	pub, err := bip340.ParsePublicKey(bip340.XOnly(q))
+	require.NoError(t, err)
+	require.NoError(t, pub.Verify(msg, sig))
+}
+
+func TestControlBlock(t *testing.T) {
+	curve := curves.K256()
+	internalKey := curve.ScalarBaseMult(curve.Scalar.Random(crand.Reader))
+	leaves := []TapLeaf{NewTapscriptLeaf([]byte{0x51}), NewTapscriptLeaf([]byte{0x52}), NewTapscriptLeaf([]byte{0x53})}
+	tree := NewTapTree(leaves...)
+	q, parity, err := TaprootOutputKey(internalKey, tree.Hash())
+	require.NoError(t, err)
+
+	for _, leaf := range leaves {
+		cb, err := ControlBlock(internalKey, tree, leaf)
+		require.NoError(t, err)
+		require.Equal(t, 0, (len(cb)-33)%32)
+		require.Equal(t, byte(TapscriptLeafVersion)|parity, cb[0])
+
+		// Recompute the output key from the control block as BIP-341 does
+		k := leaf.Hash()
+		for i := 33; i < len(cb); i += 32 {
+			k = tapBranch(k, cb[i:i+32])
+		}
+		p, err := bip340.LiftX(cb[1:33])
+		require.NoError(t, err)
+		r, _, err := TaprootOutputKey(p, k)
+		require.NoError(t, err)
+		require.True(t, q.Equal(r))
+	}
+
+	_, err = ControlBlock(internalKey, tree, NewTapscriptLeaf([]byte{0x54}))
+	require.Error(t, err)
+}
@@ -0,0 +1,49 @@
+package bitcoin
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"golang.org/x/crypto/ripemd160" //nolint:staticcheck // HASH160 of bitcoin
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/internal"
+)
+
+// Hash160 is RIPEMD-160 of SHA-256, the hash of P2WPKH public keys
+func Hash160(data []byte) []byte {
+	sum := sha256.Sum256(data)
+	h := ripemd160.New()
+	h.Write(sum[:])
+	return h.Sum(nil)
+}
+
+func checkPublicKey(pub curves.Point) error {
+	if pub == nil {
+		return internal.ErrNilArguments
+	}
+	if pub.CurveName() != curves.K256Name {
+		return fmt.Errorf("public key is not a secp256k1 point")
+	}
+	if pub.IsIdentity() {
+		return internal.ErrZeroValue
+	}
+	return nil
+}
+
+// P2WPKHScript is the version 0 pay to witness public key hash output
+// script of a public key
+func P2WPKHScript(pub curves.Point) ([]byte, error) {
+	if err := checkPublicKey(pub); err != nil {
+		return nil, err
+	}
+	return witnessScript(0, Hash160(pub.ToAffineCompressed())), nil
+}
+
+// P2WPKHAddress is the P2WPKH address of a public key on net
+func P2WPKHAddress(pub curves.Point, net Network) (string, error) {
+	if err := checkPublicKey(pub); err != nil {
+		return "", err
+	}
+	return EncodeSegwitAddress(net, 0, Hash160(pub.ToAffineCompressed()))
+}
@@ -0,0 +1,155 @@
+// Package bitcoin derives segwit outputs and addresses from secp256k1
+// public keys: P2WPKH outputs and BIP-341 taproot outputs, with the taproot
+// tweak applied to single keys or to the Shamir shares of a threshold key
+// so that threshold wallets can spend by key path.
+package bitcoin
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/btcutil/bech32"
+)
+
+// Network is the human readable part of the segwit addresses of a network
+type Network string
+
+const (
+	// Mainnet is the bitcoin main network
+	Mainnet Network = "bc"
+	// Testnet is the bitcoin test and signet networks
+	Testnet Network = "tb"
+	// Regtest is the bitcoin regression test network
+	Regtest Network = "bcrt"
+)
+
+const (
+	bech32Const  = 1
+	bech32mConst = 0x2bc830a3
+	charset      = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+)
+
+func polymod(values []byte) uint32 {
+	gen := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>i)&1 == 1 {
+				chk ^= gen[i]
+			}
+		}
+	}
+	return chk
+}
+
+func hrpExpand(hrp string) []byte {
+	out := make([]byte, 0, 2*len(hrp)+1)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]>>5)
+	}
+	out = append(out, 0)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]&31)
+	}
+	return out
+}
+
+// checksumConst is bech32 for version 0 programs and bech32m for later
+// versions, BIP-350
+func checksumConst(version byte) uint32 {
+	if version == 0 {
+		return bech32Const
+	}
+	return bech32mConst
+}
+
+// EncodeSegwitAddress encodes a witness program as a segwit address
+func EncodeSegwitAddress(net Network, version byte, program []byte) (string, error) {
+	if err := checkProgram(version, program); err != nil {
+		return "", err
+	}
+	conv, err := bech32.ConvertBits(program, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	data := append([]byte{version}, conv...)
+	hrp := string(net)
+	values := append(hrpExpand(hrp), data...)
+	mod := polymod(append(values, 0, 0, 0, 0, 0, 0)) ^ checksumConst(version)
+	var b strings.Builder
+	b.WriteString(hrp + "1")
+	for _, d := range data {
+		b.WriteByte(charset[d])
+	}
+	for i := 0; i < 6; i++ {
+		b.WriteByte(charset[(mod>>(5*(5-i)))&31])
+	}
+	return b.String(), nil
+}
+
+// DecodeSegwitAddress returns the witness version and program of a segwit
+// address of net
+func DecodeSegwitAddress(net Network, addr string) (byte, []byte, error) {
+	if strings.ToLower(addr) != addr && strings.ToUpper(addr) != addr {
+		return 0, nil, fmt.Errorf("mixed case address")
+	}
+	addr = strings.ToLower(addr)
+	if len(addr) > 90 {
+		return 0, nil, fmt.Errorf("address too long")
+	}
+	sep := strings.LastIndexByte(addr, '1')
+	if sep < 1 || sep+7 > len(addr) {
+		return 0, nil, fmt.Errorf("malformed address")
+	}
+	if addr[:sep] != string(net) {
+		return 0, nil, fmt.Errorf("address is not for network %s", net)
+	}
+	data := make([]byte, 0, len(addr)-sep-1)
+	for _, c := range addr[sep+1:] {
+		i := strings.IndexRune(charset, c)
+		if i < 0 {
+			return 0, nil, fmt.Errorf("invalid address character %q", c)
+		}
+		data = append(data, byte(i))
+	}
+	if len(data) < 7 {
+		return 0, nil, fmt.Errorf("malformed address")
+	}
+	version := data[0]
+	if polymod(append(hrpExpand(string(net)), data...)) != checksumConst(version) {
+		return 0, nil, fmt.Errorf("invalid address checksum")
+	}
+	program, err := bech32.ConvertBits(data[1:len(data)-6], 5, 8, false)
+	if err != nil {
+		return 0, nil, err
+	}
+	if err := checkProgram(version, program); err != nil {
+		return 0, nil, err
+	}
+	return version, program, nil
+}
+
+func checkProgram(version byte, program []byte) error {
+	if version > 16 {
+		return fmt.Errorf("invalid witness version %d", version)
+	}
+	if len(program) < 2 || len(program) > 40 {
+		return fmt.Errorf("invalid witness program length %d", len(program))
+	}
+	if version == 0 && len(program) != 20 && len(program) != 32 {
+		return fmt.Errorf("invalid version 0 witness program length %d", len(program))
+	}
+	return nil
+}
+
+// witnessScript is the scriptPubKey of a witness program
+func witnessScript(version byte, program []byte) []byte {
+	op := version
+	if version > 0 {
+		// OP_1 to OP_16
+		op = 0x50 + version
+	}
+	return append([]byte{op, byte(len(program))}, program...)
+}
@@ -0,0 +1,245 @@
+package bitcoin
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/sharing"
+	"github.com/go-sonr/crypto/signatures/schnorr/bip340"
+)
+
+const (
+	// TapscriptLeafVersion is the leaf version of BIP-342 tapscripts
+	TapscriptLeafVersion = 0xc0
+
+	tagTapLeaf   = "TapLeaf"
+	tagTapBranch = "TapBranch"
+	tagTapTweak  = "TapTweak"
+)
+
+// TapLeaf is a script of a taproot script tree
+type TapLeaf struct {
+	Version byte
+	Script  []byte
+}
+
+// NewTapscriptLeaf returns a leaf with the tapscript leaf version
+func NewTapscriptLeaf(script []byte) TapLeaf {
+	return TapLeaf{Version: TapscriptLeafVersion, Script: script}
+}
+
+// Hash is the tagged TapLeaf hash of the leaf version and compact size
+// prefixed script
+func (l TapLeaf) Hash() []byte {
+	return bip340.TaggedHash(tagTapLeaf, []byte{l.Version}, compactSize(len(l.Script)), l.Script)
+}
+
+func compactSize(n int) []byte {
+	switch {
+	case n < 0xfd:
+		return []byte{byte(n)}
+	case n <= 0xffff:
+		return []byte{0xfd, byte(n), byte(n >> 8)}
+	default:
+		return []byte{0xfe, byte(n), byte(n >> 8), byte(n >> 16), byte(n >> 24)}
+	}
+}
+
+// TapTree is a taproot script tree, either a leaf or a branch of two
+// subtrees
+type TapTree struct {
+	Leaf        *TapLeaf
+	Left, Right *TapTree
+}
+
+// NewTapTree returns a balanced tree of the leaves in order, nil without
+// leaves
+func NewTapTree(leaves ...TapLeaf) *TapTree {
+	switch len(leaves) {
+	case 0:
+		return nil
+	case 1:
+		leaf := leaves[0]
+		return &TapTree{Leaf: &leaf}
+	default:
+		mid := (len(leaves) + 1) / 2
+		return &TapTree{Left: NewTapTree(leaves[:mid]...), Right: NewTapTree(leaves[mid:]...)}
+	}
+}
+
+// Hash is the merkle root of the tree. Branches hash their children in
+// lexicographic order.
+func (t *TapTree) Hash() []byte {
+	if t.Leaf != nil {
+		return t.Leaf.Hash()
+	}
+	return tapBranch(t.Left.Hash(), t.Right.Hash())
+}
+
+func tapBranch(a, b []byte) []byte {
+	if bytes.Compare(a, b) > 0 {
+		a, b = b, a
+	}
+	return bip340.TaggedHash(tagTapBranch, a, b)
+}
+
+// MerkleProof returns the hashes from a leaf to the root, the path of the
+// control block of a script path spend
+func (t *TapTree) MerkleProof(leaf TapLeaf) ([][]byte, error) {
+	want := leaf.Hash()
+	var walk func(n *TapTree) ([][]byte, bool)
+	walk = func(n *TapTree) ([][]byte, bool) {
+		if n.Leaf != nil {
+			return nil, bytes.Equal(n.Leaf.Hash(), want)
+		}
+		if path, ok := walk(n.Left); ok {
+			return append(path, n.Right.Hash()), true
+		}
+		if path, ok := walk(n.Right); ok {
+			return append(path, n.Left.Hash()), true
+		}
+		return nil, false
+	}
+	path, ok := walk(t)
+	if !ok {
+		return nil, fmt.Errorf("leaf is not in the tree")
+	}
+	return path, nil
+}
+
+// TaprootTweak is the BIP-341 tweak of an internal key with the merkle
+// root of a script tree, nil for key path only outputs
+func TaprootTweak(internalKey curves.Point, merkleRoot []byte) (curves.Scalar, error) {
+	if err := checkPublicKey(internalKey); err != nil {
+		return nil, err
+	}
+	if merkleRoot != nil && len(merkleRoot) != 32 {
+		return nil, fmt.Errorf("merkle root must be 32 bytes")
+	}
+	h := bip340.TaggedHash(tagTapTweak, bip340.XOnly(internalKey), merkleRoot)
+	t := new(big.Int).SetBytes(h)
+	if t.Cmp(curves.K256Curve().Params().N) >= 0 {
+		return nil, fmt.Errorf("taproot tweak exceeds the group order")
+	}
+	return curves.K256().Scalar.SetBigInt(t)
+}
+
+// TaprootOutputKey is the output key Q = lift(P) + tG of an internal key P
+// and the parity of its y coordinate
+func TaprootOutputKey(internalKey curves.Point, merkleRoot []byte) (curves.Point, byte, error) {
+	t, err := TaprootTweak(internalKey, merkleRoot)
+	if err != nil {
+		return nil, 0, err
+	}
+	p := internalKey
+	if !bip340.HasEvenY(p) {
+		p = p.Neg()
+	}
+	q := p.Add(curves.K256().ScalarBaseMult(t))
+	if q.IsIdentity() {
+		return nil, 0, fmt.Errorf("taproot output key is the point at infinity")
+	}
+	return q, q.ToAffineCompressed()[0] & 1, nil
+}
+
+// P2TRScript is the version 1 output script of an internal key and an
+// optional script tree
+func P2TRScript(internalKey curves.Point, tree *TapTree) ([]byte, error) {
+	q, _, err := TaprootOutputKey(internalKey, rootOf(tree))
+	if err != nil {
+		return nil, err
+	}
+	return witnessScript(1, bip340.XOnly(q)), nil
+}
+
+// P2TRAddress is the taproot address of an internal key and an optional
+// script tree on net
+func P2TRAddress(internalKey curves.Point, tree *TapTree, net Network) (string, error) {
+	q, _, err := TaprootOutputKey(internalKey, rootOf(tree))
+	if err != nil {
+		return "", err
+	}
+	return EncodeSegwitAddress(net, 1, bip340.XOnly(q))
+}
+
+// ControlBlock is the control block that spends leaf of tree by script
+// path: the leaf version with the output key parity, the internal key and
+// the merkle proof
+func ControlBlock(internalKey curves.Point, tree *TapTree, leaf TapLeaf) ([]byte, error) {
+	if tree == nil {
+		return nil, internal.ErrNilArguments
+	}
+	_, parity, err := TaprootOutputKey(internalKey, tree.Hash())
+	if err != nil {
+		return nil, err
+	}
+	path, err := tree.MerkleProof(leaf)
+	if err != nil {
+		return nil, err
+	}
+	out := append([]byte{leaf.Version | parity}, bip340.XOnly(internalKey)...)
+	for _, h := range path {
+		out = append(out, h...)
+	}
+	return out, nil
+}
+
+func rootOf(tree *TapTree) []byte {
+	if tree == nil {
+		return nil
+	}
+	return tree.Hash()
+}
+
+// TweakSecretKey returns the secret key of the taproot output key of the
+// internal key of sk, for key path spends with BIP-340 signatures
+func TweakSecretKey(sk curves.Scalar, merkleRoot []byte) (curves.Scalar, error) {
+	if sk == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if _, ok := sk.(*curves.ScalarK256); !ok {
+		return nil, fmt.Errorf("secret key is not a secp256k1 scalar")
+	}
+	if sk.IsZero() {
+		return nil, internal.ErrZeroValue
+	}
+	p := curves.K256().ScalarBaseMult(sk)
+	t, err := TaprootTweak(p, merkleRoot)
+	if err != nil {
+		return nil, err
+	}
+	if !bip340.HasEvenY(p) {
+		sk = sk.Neg()
+	}
+	return sk.Add(t), nil
+}
+
+// TweakShamirShare returns the share of the taproot output secret key of a
+// Shamir share of the internal secret key. The Lagrange coefficients of any
+// signing set sum to one, so negating every share when the internal key
+// has an odd y and adding the tweak to every share shares the tweaked key
+// with the same threshold.
+func TweakShamirShare(share *sharing.ShamirShare, internalKey curves.Point, merkleRoot []byte) (*sharing.ShamirShare, error) {
+	if share == nil {
+		return nil, internal.ErrNilArguments
+	}
+	curve := curves.K256()
+	if err := share.Validate(curve); err != nil {
+		return nil, err
+	}
+	t, err := TaprootTweak(internalKey, merkleRoot)
+	if err != nil {
+		return nil, err
+	}
+	s, err := curve.Scalar.SetBytes(share.Value)
+	if err != nil {
+		return nil, err
+	}
+	if !bip340.HasEvenY(internalKey) {
+		s = s.Neg()
+	}
+	return &sharing.ShamirShare{Id: share.Id, Value: s.Add(t).Bytes()}, nil
+}
@@ -0,0 +1,350 @@
+package bulletproof
+
+import (
+	crand "crypto/rand"
+	"math/big"
+	"math/bits"
+
+	"github.com/pkg/errors"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
+)
+
+// PlusRangeProof is a Bulletproofs+ range proof, see Figure 3 of https://eprint.iacr.org/2020/735.pdf
+// It is one point and two scalars shorter than a Bulletproofs range proof for the same range.
+// capA is a commitment to a_L and a_R using randomness alpha, wip is the weighted inner product argument.
+type PlusRangeProof struct {
+	capA  curves.Point
+	wip   *wipProof
+	curve *curves.Curve
+}
+
+// PlusRangeParams creates and verifies Bulletproofs+ range proofs of values in [0, 2^n) committed as
+// V = v·g + gamma·h, with the same commitments as RangeParams for the same curve and domain.
+type PlusRangeParams struct {
+	curve          *curves.Curve
+	generators     *ippGenerators
+	g, h           curves.Point
+	maxAggregation int
+}
+
+// NewPlusRangeParams creates Bulletproofs+ parameters for up to maxAggregation values per proof, which must be a
+// power of two.
+func NewPlusRangeParams(curve *curves.Curve, maxAggregation int, domain []byte) (*PlusRangeParams, error) {
+	if curve == nil {
+		return nil, errors.New("curve cannot be nil")
+	}
+	if maxAggregation < 1 || !isPowerOfTwo(maxAggregation) {
+		return nil, errors.New("maximum aggregation must be a positive power of two")
+	}
+	generators, err := getGeneratorPoints(MaxRangeBits*maxAggregation, append([]byte("bp+ range"), domain...), *curve)
+	if err != nil {
+		return nil, errors.Wrap(err, "range NewPlusRangeParams")
+	}
+	return &PlusRangeParams{
+		curve:          curve,
+		generators:     generators,
+		g:              curve.NewGeneratorPoint(),
+		h:              curve.Point.Hash(append([]byte("range h"), domain...)),
+		maxAggregation: maxAggregation,
+	}, nil
+}
+
+// Commit returns the commitment v·g + gamma·h.
+func (params *PlusRangeParams) Commit(v uint64, gamma curves.Scalar) (curves.Point, error) {
+	if gamma == nil {
+		return nil, errors.New("gamma cannot be nil")
+	}
+	vs, err := params.curve.Scalar.SetBigInt(new(big.Int).SetUint64(v))
+	if err != nil {
+		return nil, err
+	}
+	return getcapV(vs, gamma, params.g, params.h), nil
+}
+
+// Prove proves that v is in [0, 2^n) and returns the proof with the commitment to v with blinding gamma.
+func (params *PlusRangeParams) Prove(v uint64, gamma curves.Scalar, n int, transcript *transcript.Transcript) (*PlusRangeProof, curves.Point, error) {
+	proof, capV, err := params.ProveAggregated([]uint64{v}, []curves.Scalar{gamma}, n, transcript)
+	if err != nil {
+		return nil, nil, err
+	}
+	return proof, capV[0], nil
+}
+
+// ProveAggregated proves that every value is in [0, 2^n) and returns the proof with the commitments.
+// The number of values must be a power of two.
+func (params *PlusRangeParams) ProveAggregated(v []uint64, gamma []curves.Scalar, n int, transcript *transcript.Transcript) (*PlusRangeProof, []curves.Point, error) {
+	if len(v) != len(gamma) {
+		return nil, nil, errors.New("number of values and blindings differ")
+	}
+	if err := params.check(len(v), n); err != nil {
+		return nil, nil, err
+	}
+	if transcript == nil {
+		return nil, nil, errors.New("transcript cannot be nil")
+	}
+	if n < MaxRangeBits {
+		for _, vi := range v {
+			if vi>>uint(n) != 0 {
+				return nil, nil, errors.New("value is out of range")
+			}
+		}
+	}
+	curve := *params.curve
+	m := len(v)
+	nm := n * m
+	capV := make([]curves.Point, m)
+	for i := range v {
+		var err error
+		if gamma[i] == nil {
+			return nil, nil, errors.New("gamma cannot be nil")
+		}
+		if capV[i], err = params.Commit(v[i], gamma[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// a_L holds the bits of the values, a_R = a_L - 1^nm
+	aL := make([]curves.Scalar, nm)
+	aR := make([]curves.Scalar, nm)
+	for j, vj := range v {
+		for i := 0; i < n; i++ {
+			if vj>>uint(i)&1 == 1 {
+				aL[j*n+i] = curve.Scalar.One()
+				aR[j*n+i] = curve.Scalar.Zero()
+			} else {
+				aL[j*n+i] = curve.Scalar.Zero()
+				aR[j*n+i] = curve.Scalar.One().Neg()
+			}
+		}
+	}
+	proofG := params.generators.G[:nm]
+	proofH := params.generators.H[:nm]
+	alpha := curve.Scalar.Random(crand.Reader)
+	capA := curve.Point.SumOfProducts(concatPoints(proofG, proofH, []curves.Point{params.h}), concatScalars(aL, aR, []curves.Scalar{alpha}))
+
+	y, z, err := plusyz(curve, capV, capA, n, transcript)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "bp+ range prove")
+	}
+	d, _ := plusd(curve, z, n, m)
+	yPows := powers(curve, y, nm+2)
+
+	// a_L^ = a_L - z·1, a_R^ = a_R + d∘y^(nm..1) + z·1
+	aLHat := make([]curves.Scalar, nm)
+	aRHat := make([]curves.Scalar, nm)
+	for i := 0; i < nm; i++ {
+		aLHat[i] = aL[i].Sub(z)
+		aRHat[i] = aR[i].Add(d[i].Mul(yPows[nm-i])).Add(z)
+	}
+	// alpha^ = alpha + y^(nm+1)·Σ z^2j·gamma_j
+	z2j := z.Square()
+	for j := range gamma {
+		alpha = alpha.Add(yPows[nm+1].Mul(z2j).Mul(gamma[j]))
+		z2j = z2j.Mul(z.Square())
+	}
+
+	wip, err := wipProve(curve, proofG, proofH, params.g, params.h, aLHat, aRHat, alpha, y, transcript)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "bp+ range prove")
+	}
+	return &PlusRangeProof{capA: capA, wip: wip, curve: params.curve}, capV, nil
+}
+
+// Verify checks that the commitment capV holds a value in [0, 2^n).
+func (params *PlusRangeParams) Verify(proof *PlusRangeProof, capV curves.Point, n int, transcript *transcript.Transcript) error {
+	return params.VerifyAggregated(proof, []curves.Point{capV}, n, transcript)
+}
+
+// VerifyAggregated checks that every commitment holds a value in [0, 2^n).
+func (params *PlusRangeParams) VerifyAggregated(proof *PlusRangeProof, capV []curves.Point, n int, transcript *transcript.Transcript) error {
+	if err := params.check(len(capV), n); err != nil {
+		return err
+	}
+	if transcript == nil {
+		return errors.New("transcript cannot be nil")
+	}
+	if proof == nil || proof.capA == nil || proof.wip == nil || proof.wip.capA == nil || proof.wip.capB == nil ||
+		proof.wip.r == nil || proof.wip.s == nil || proof.wip.delta == nil {
+		return errors.New("range proof is incomplete")
+	}
+	for _, p := range capV {
+		if p == nil || p.CurveName() != params.curve.Name || !p.IsOnCurve() {
+			return errors.New("invalid commitment")
+		}
+	}
+	curve := *params.curve
+	m := len(capV)
+	nm := n * m
+	proofG := params.generators.G[:nm]
+	proofH := params.generators.H[:nm]
+
+	y, z, err := plusyz(curve, capV, proof.capA, n, transcript)
+	if err != nil {
+		return errors.Wrap(err, "bp+ range verify")
+	}
+	d, sumD := plusd(curve, z, n, m)
+	yPows := powers(curve, y, nm+2)
+
+	// A^ = A - z·<1, G> + <d∘y^(nm..1) + z·1, H> + zeta·g + y^(nm+1)·Σ z^2j·V_j
+	// with zeta = (z - z²)·Σ y^i - z·y^(nm+1)·Σ d_i
+	sumY := curve.Scalar.Zero()
+	for i := 1; i <= nm; i++ {
+		sumY = sumY.Add(yPows[i])
+	}
+	zeta := z.Sub(z.Square()).Mul(sumY).Sub(z.Mul(yPows[nm+1]).Mul(sumD))
+	points := concatPoints([]curves.Point{proof.capA, params.g}, proofG, proofH, capV)
+	scalars := make([]curves.Scalar, 0, len(points))
+	scalars = append(scalars, curve.Scalar.One(), zeta)
+	for i := 0; i < nm; i++ {
+		scalars = append(scalars, z.Neg())
+	}
+	for i := 0; i < nm; i++ {
+		scalars = append(scalars, d[i].Mul(yPows[nm-i]).Add(z))
+	}
+	z2j := z.Square()
+	for range capV {
+		scalars = append(scalars, yPows[nm+1].Mul(z2j))
+		z2j = z2j.Mul(z.Square())
+	}
+	capAHat := curve.Point.SumOfProducts(points, scalars)
+
+	ok, err := wipVerify(curve, proofG, proofH, params.g, params.h, capAHat, y, proof.wip, transcript)
+	if err != nil {
+		return errors.Wrap(err, "bp+ range verify")
+	}
+	if !ok {
+		return errors.New("range proof is invalid")
+	}
+	return nil
+}
+
+// MarshalBinary returns A, the final A, B, r', s', delta' of the weighted inner product argument and its L, R pairs.
+func (proof *PlusRangeProof) MarshalBinary() []byte {
+	var out []byte
+	out = append(out, proof.capA.ToAffineCompressed()...)
+	out = append(out, proof.wip.capA.ToAffineCompressed()...)
+	out = append(out, proof.wip.capB.ToAffineCompressed()...)
+	out = append(out, proof.wip.r.Bytes()...)
+	out = append(out, proof.wip.s.Bytes()...)
+	out = append(out, proof.wip.delta.Bytes()...)
+	for i := range proof.wip.capLs {
+		out = append(out, proof.wip.capLs[i].ToAffineCompressed()...)
+		out = append(out, proof.wip.capRs[i].ToAffineCompressed()...)
+	}
+	return out
+}
+
+// UnmarshalPlusRangeProof parses a proof made by MarshalBinary for count values of n bits.
+func (params *PlusRangeParams) UnmarshalPlusRangeProof(data []byte, count, n int) (*PlusRangeProof, error) {
+	if err := params.check(count, n); err != nil {
+		return nil, err
+	}
+	curve := params.curve
+	scalarLen := len(curve.NewScalar().Bytes())
+	pointLen := len(curve.NewGeneratorPoint().ToAffineCompressed())
+	rounds := bits.TrailingZeros(uint(count * n))
+	if len(data) != 3*pointLen+3*scalarLen+2*rounds*pointLen {
+		return nil, errors.New("range proof has the wrong length")
+	}
+	ptr := 0
+	readPoint := func() (curves.Point, error) {
+		p, err := curve.Point.FromAffineCompressed(data[ptr : ptr+pointLen])
+		ptr += pointLen
+		return p, err
+	}
+	readScalar := func() (curves.Scalar, error) {
+		s, err := curve.NewScalar().SetBytes(data[ptr : ptr+scalarLen])
+		ptr += scalarLen
+		return s, err
+	}
+	proof := &PlusRangeProof{wip: &wipProof{}, curve: curve}
+	var err error
+	if proof.capA, err = readPoint(); err != nil {
+		return nil, errors.Wrap(err, "bp+ range UnmarshalBinary")
+	}
+	if proof.wip.capA, err = readPoint(); err != nil {
+		return nil, errors.Wrap(err, "bp+ range UnmarshalBinary")
+	}
+	if proof.wip.capB, err = readPoint(); err != nil {
+		return nil, errors.Wrap(err, "bp+ range UnmarshalBinary")
+	}
+	if proof.wip.r, err = readScalar(); err != nil {
+		return nil, errors.Wrap(err, "bp+ range UnmarshalBinary")
+	}
+	if proof.wip.s, err = readScalar(); err != nil {
+		return nil, errors.Wrap(err, "bp+ range UnmarshalBinary")
+	}
+	if proof.wip.delta, err = readScalar(); err != nil {
+		return nil, errors.Wrap(err, "bp+ range UnmarshalBinary")
+	}
+	proof.wip.capLs = make([]curves.Point, rounds)
+	proof.wip.capRs = make([]curves.Point, rounds)
+	for i := 0; i < rounds; i++ {
+		if proof.wip.capLs[i], err = readPoint(); err != nil {
+			return nil, errors.Wrap(err, "bp+ range UnmarshalBinary")
+		}
+		if proof.wip.capRs[i], err = readPoint(); err != nil {
+			return nil, errors.Wrap(err, "bp+ range UnmarshalBinary")
+		}
+	}
+	return proof, nil
+}
+
+func (params *PlusRangeParams) check(count, n int) error {
+	if n < MinRangeBits || n > MaxRangeBits || !isPowerOfTwo(n) {
+		return errors.Errorf("range must be a power of two between %d and %d bits", MinRangeBits, MaxRangeBits)
+	}
+	if count < 1 || !isPowerOfTwo(count) || count > params.maxAggregation {
+		return errors.Errorf("number of values must be a power of two of at most %d", params.maxAggregation)
+	}
+	return nil
+}
+
+// plusyz binds the statement and A to the transcript and returns the challenges y, z.
+func plusyz(curve curves.Curve, capV []curves.Point, capA curves.Point, n int, transcript *transcript.Transcript) (curves.Scalar, curves.Scalar, error) {
+	transcript.AppendMessage([]byte("dom-sep"), []byte("bp+ range proof"))
+	transcript.AppendMessage([]byte("n"), big.NewInt(int64(n)).Bytes())
+	transcript.AppendMessage([]byte("m"), big.NewInt(int64(len(capV))).Bytes())
+	for _, v := range capV {
+		transcript.AppendPoint([]byte("V"), v)
+	}
+	transcript.AppendPoint([]byte("A"), capA)
+	y, err := challengeScalar(curve, []byte("y"), transcript)
+	if err != nil {
+		return nil, nil, err
+	}
+	z, err := challengeScalar(curve, []byte("z"), transcript)
+	if err != nil {
+		return nil, nil, err
+	}
+	return y, z, nil
+}
+
+// plusd returns d with d_(j·n+i) = z^(2(j+1))·2^i and the sum of its entries.
+func plusd(curve curves.Curve, z curves.Scalar, n, m int) ([]curves.Scalar, curves.Scalar) {
+	d := make([]curves.Scalar, 0, n*m)
+	twoN := get2nVector(n, curve)
+	sum := curve.Scalar.Zero()
+	z2j := z.Square()
+	for j := 0; j < m; j++ {
+		for i := 0; i < n; i++ {
+			di := z2j.Mul(twoN[i])
+			d = append(d, di)
+			sum = sum.Add(di)
+		}
+		z2j = z2j.Mul(z.Square())
+	}
+	return d, sum
+}
+
+// powers returns [1, x, x², ..., x^(k-1)].
+func powers(curve curves.Curve, x curves.Scalar, k int) []curves.Scalar {
+	out := make([]curves.Scalar, k)
+	out[0] = curve.Scalar.One()
+	for i := 1; i < k; i++ {
+		out[i] = out[i-1].Mul(x)
+	}
+	return out
+}
@@ -0,0 +1,125 @@
+package bulletproof
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
+)
+
+func TestWipProveVerify(t *testing.T) {
+	curve := curves.ED25519()
+	for _, n := range []int{1, 2, 8} {
+		gens, err := getGeneratorPoints(n, []byte("wip"), *curve)
+		require.NoError(t, err)
+		g := curve.Point.Hash([]byte("g"))
+		h := curve.Point.Hash([]byte("h"))
+		a := getBlindingVector(n, *curve)
+		b := getBlindingVector(n, *curve)
+		alpha := curve.Scalar.Random(crand.Reader)
+		y := curve.Scalar.Random(crand.Reader)
+		c, err := weightedInnerProduct(a, b, y)
+		require.NoError(t, err)
+		capP := curve.Point.SumOfProducts(concatPoints(gens.G, gens.H, []curves.Point{g, h}), concatScalars(a, b, []curves.Scalar{c, alpha}))
+
+		proof, err := wipProve(*curve, gens.G, gens.H, g, h, a, b, alpha, y, transcript.New("test"))
+		require.NoError(t, err)
+		ok, err := wipVerify(*curve, gens.G, gens.H, g, h, capP, y, proof, transcript.New("test"))
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		ok, err = wipVerify(*curve, gens.G, gens.H, g, h, capP.Add(g), y, proof, transcript.New("test"))
+		require.NoError(t, err)
+		require.False(t, ok)
+	}
+}
+
+func TestPlusRangeProveVerify(t *testing.T) {
+	curve := curves.ED25519()
+	params, err := NewPlusRangeParams(curve, 4, []byte("test"))
+	require.NoError(t, err)
+	for _, n := range []int{8, 16, 32, 64} {
+		for _, v := range []uint64{0, 1, 1<<uint(n) - 1} {
+			gamma := curve.Scalar.Random(crand.Reader)
+			proof, capV, err := params.Prove(v, gamma, n, transcript.New("test"))
+			require.NoError(t, err)
+			require.NoError(t, params.Verify(proof, capV, n, transcript.New("test")))
+			require.Error(t, params.Verify(proof, capV, n, transcript.New("other")))
+			require.Error(t, params.Verify(proof, capV.Add(curve.NewGeneratorPoint()), n, transcript.New("test")))
+		}
+	}
+}
+
+func TestPlusRangeOutOfRange(t *testing.T) {
+	curve := curves.ED25519()
+	params, err := NewPlusRangeParams(curve, 1, []byte("test"))
+	require.NoError(t, err)
+	gamma := curve.Scalar.Random(crand.Reader)
+	_, _, err = params.Prove(256, gamma, 8, transcript.New("test"))
+	require.Error(t, err)
+	_, _, err = params.Prove(1, gamma, 12, transcript.New("test"))
+	require.Error(t, err)
+
+	proof, capV, err := params.Prove(1000, gamma, 16, transcript.New("test"))
+	require.NoError(t, err)
+	require.Error(t, params.Verify(proof, capV, 8, transcript.New("test")))
+}
+
+func TestPlusRangeAggregated(t *testing.T) {
+	curve := curves.ED25519()
+	params, err := NewPlusRangeParams(curve, 4, []byte("test"))
+	require.NoError(t, err)
+	v := []uint64{5, 0, 1<<32 - 1, 12345}
+	gamma := getBlindingVector(len(v), *curve)
+	proof, capV, err := params.ProveAggregated(v, gamma, 32, transcript.New("test"))
+	require.NoError(t, err)
+	require.NoError(t, params.VerifyAggregated(proof, capV, 32, transcript.New("test")))
+
+	capV[0], capV[3] = capV[3], capV[0]
+	require.Error(t, params.VerifyAggregated(proof, capV, 32, transcript.New("test")))
+	require.Error(t, params.VerifyAggregated(proof, capV[:2], 32, transcript.New("test")))
+}
+
+func TestPlusRangeMarshal(t *testing.T) {
+	curve := curves.ED25519()
+	params, err := NewPlusRangeParams(curve, 2, []byte("test"))
+	require.NoError(t, err)
+	gamma := getBlindingVector(2, *curve)
+	proof, capV, err := params.ProveAggregated([]uint64{3, 4}, gamma, 64, transcript.New("test"))
+	require.NoError(t, err)
+	data := proof.MarshalBinary()
+	parsed, err := params.UnmarshalPlusRangeProof(data, 2, 64)
+	require.NoError(t, err)
+	require.NoError(t, params.VerifyAggregated(parsed, capV, 64, transcript.New("test")))
+
+	_, err = params.UnmarshalPlusRangeProof(data[1:], 2, 64)
+	require.Error(t, err)
+
+	// Bulletproofs+ proofs are shorter than Bulletproofs proofs
+	bp, err := NewRangeParams(curve, 2, []byte("test"))
+	require.NoError(t, err)
+	bpProof, _, err := bp.ProveAggregated([]uint64{3, 4}, gamma, 64, transcript.New("test"))
+	require.NoError(t, err)
+	require.Less(t, len(data), len(bpProof.MarshalBinary()))
+}
+
+func TestPlusRangeCommitmentsMatchRangeParams(t *testing.T) {
+	curve := curves.K256()
+	plus, err := NewPlusRangeParams(curve, 1, []byte("test"))
+	require.NoError(t, err)
+	bp, err := NewRangeParams(curve, 1, []byte("test"))
+	require.NoError(t, err)
+	gamma := curve.Scalar.Random(crand.Reader)
+	c1, err := plus.Commit(42, gamma)
+	require.NoError(t, err)
+	c2, err := bp.Commit(42, gamma)
+	require.NoError(t, err)
+	require.True(t, c1.Equal(c2))
+
+	proof, capV, err := plus.Prove(42, gamma, 64, transcript.New("test"))
+	require.NoError(t, err)
+	require.NoError(t, plus.Verify(proof, capV, 64, transcript.New("test")))
+}
@@ -0,0 +1,228 @@
+package bulletproof
+
+import (
+	crand "crypto/rand"
+
+	"github.com/pkg/errors"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
+)
+
+// wipProof is the weighted inner product argument of Bulletproofs+, see Figure 1 of https://eprint.iacr.org/2020/735.pdf
+// It proves knowledge of a, b, alpha with P = <a, G> + <b, H> + (a ⊙_y b)·g + alpha·h, where
+// a ⊙_y b = Σ a_i·b_i·y^i for i = 1..n.
+// capLs, capRs are the commitments of the recursion, capA, capB, r, s, delta those of the final round.
+type wipProof struct {
+	capLs, capRs []curves.Point
+	capA, capB   curves.Point
+	r, s, delta  curves.Scalar
+}
+
+// wipProve runs the prover of the weighted inner product argument over vectors whose length is a power of two.
+func wipProve(curve curves.Curve, gs, hs []curves.Point, g, h curves.Point, a, b []curves.Scalar, alpha, y curves.Scalar, transcript *transcript.Transcript) (*wipProof, error) {
+	if len(a) != len(b) || len(a) != len(gs) || len(a) != len(hs) || len(a) == 0 || !isPowerOfTwo(len(a)) {
+		return nil, errors.New("wip vectors must have the same power of two length")
+	}
+	proof := &wipProof{}
+	for len(a) > 1 {
+		half := len(a) / 2
+		a1, a2 := a[:half], a[half:]
+		b1, b2 := b[:half], b[half:]
+		g1, g2 := gs[:half], gs[half:]
+		h1, h2 := hs[:half], hs[half:]
+
+		yHalf := scalarPow(curve, y, half)
+		yHalfInv, err := yHalf.Invert()
+		if err != nil {
+			return nil, errors.Wrap(err, "wip prove")
+		}
+		cL, err := weightedInnerProduct(a1, b2, y)
+		if err != nil {
+			return nil, errors.Wrap(err, "wip prove")
+		}
+		cR, err := weightedInnerProduct(a2, b1, y)
+		if err != nil {
+			return nil, errors.Wrap(err, "wip prove")
+		}
+		cR = cR.Mul(yHalf)
+		dL := curve.Scalar.Random(crand.Reader)
+		dR := curve.Scalar.Random(crand.Reader)
+
+		// L = <a1·y^-n̂, G2> + <b2, H1> + cL·g + dL·h
+		capL := curve.Point.SumOfProducts(
+			concatPoints(g2, h1, []curves.Point{g, h}),
+			concatScalars(multiplyScalarToScalarVector(yHalfInv, a1), b2, []curves.Scalar{cL, dL}),
+		)
+		// R = <a2·y^n̂, G1> + <b1, H2> + cR·g + dR·h
+		capR := curve.Point.SumOfProducts(
+			concatPoints(g1, h2, []curves.Point{g, h}),
+			concatScalars(multiplyScalarToScalarVector(yHalf, a2), b1, []curves.Scalar{cR, dR}),
+		)
+		proof.capLs = append(proof.capLs, capL)
+		proof.capRs = append(proof.capRs, capR)
+
+		e, err := wipRoundChallenge(curve, capL, capR, transcript)
+		if err != nil {
+			return nil, errors.Wrap(err, "wip prove")
+		}
+		eInv, err := e.Invert()
+		if err != nil {
+			return nil, errors.Wrap(err, "wip prove")
+		}
+
+		gs, hs = foldWipGenerators(g1, g2, h1, h2, e, eInv, yHalfInv)
+		// a' = e·a1 + y^n̂·e^-1·a2, b' = e^-1·b1 + e·b2
+		a = make([]curves.Scalar, half)
+		b = make([]curves.Scalar, half)
+		yHalfeInv := yHalf.Mul(eInv)
+		for i := 0; i < half; i++ {
+			a[i] = a1[i].Mul(e).Add(a2[i].Mul(yHalfeInv))
+			b[i] = b1[i].Mul(eInv).Add(b2[i].Mul(e))
+		}
+		// alpha' = dL·e² + alpha + dR·e^-2
+		alpha = alpha.Add(dL.Mul(e.Square())).Add(dR.Mul(eInv.Square()))
+	}
+
+	r := curve.Scalar.Random(crand.Reader)
+	s := curve.Scalar.Random(crand.Reader)
+	delta := curve.Scalar.Random(crand.Reader)
+	eta := curve.Scalar.Random(crand.Reader)
+	// A = r·G + s·H + (r·y·b + s·y·a)·g + delta·h, B = (r·y·s)·g + eta·h
+	proof.capA = curve.Point.SumOfProducts(
+		[]curves.Point{gs[0], hs[0], g, h},
+		[]curves.Scalar{r, s, r.Mul(y).Mul(b[0]).Add(s.Mul(y).Mul(a[0])), delta},
+	)
+	proof.capB = curve.Point.SumOfProducts([]curves.Point{g, h}, []curves.Scalar{r.Mul(y).Mul(s), eta})
+	e, err := wipFinalChallenge(curve, proof.capA, proof.capB, transcript)
+	if err != nil {
+		return nil, errors.Wrap(err, "wip prove")
+	}
+	// r' = r + a·e, s' = s + b·e, delta' = eta + delta·e + alpha·e²
+	proof.r = r.Add(a[0].Mul(e))
+	proof.s = s.Add(b[0].Mul(e))
+	proof.delta = eta.Add(delta.Mul(e)).Add(alpha.Mul(e.Square()))
+	return proof, nil
+}
+
+// wipVerify checks a weighted inner product argument for the statement P.
+func wipVerify(curve curves.Curve, gs, hs []curves.Point, g, h, capP curves.Point, y curves.Scalar, proof *wipProof, transcript *transcript.Transcript) (bool, error) {
+	if len(gs) != len(hs) || len(gs) == 0 || !isPowerOfTwo(len(gs)) {
+		return false, errors.New("wip generators must have the same power of two length")
+	}
+	if len(proof.capLs) != len(proof.capRs) || 1<<len(proof.capLs) != len(gs) {
+		return false, errors.New("wip proof has the wrong number of rounds")
+	}
+	for i := range proof.capLs {
+		half := len(gs) / 2
+		yHalf := scalarPow(curve, y, half)
+		yHalfInv, err := yHalf.Invert()
+		if err != nil {
+			return false, errors.Wrap(err, "wip verify")
+		}
+		e, err := wipRoundChallenge(curve, proof.capLs[i], proof.capRs[i], transcript)
+		if err != nil {
+			return false, errors.Wrap(err, "wip verify")
+		}
+		eInv, err := e.Invert()
+		if err != nil {
+			return false, errors.Wrap(err, "wip verify")
+		}
+		gs, hs = foldWipGenerators(gs[:half], gs[half:], hs[:half], hs[half:], e, eInv, yHalfInv)
+		// P' = e²·L + P + e^-2·R
+		capP = curve.Point.SumOfProducts(
+			[]curves.Point{proof.capLs[i], capP, proof.capRs[i]},
+			[]curves.Scalar{e.Square(), curve.Scalar.One(), eInv.Square()},
+		)
+	}
+
+	e, err := wipFinalChallenge(curve, proof.capA, proof.capB, transcript)
+	if err != nil {
+		return false, errors.Wrap(err, "wip verify")
+	}
+	// e²·P + e·A + B = (r'·e)·G + (s'·e)·H + (r'·y·s')·g + delta'·h
+	lhs := curve.Point.SumOfProducts(
+		[]curves.Point{capP, proof.capA, proof.capB},
+		[]curves.Scalar{e.Square(), e, curve.Scalar.One()},
+	)
+	rhs := curve.Point.SumOfProducts(
+		[]curves.Point{gs[0], hs[0], g, h},
+		[]curves.Scalar{proof.r.Mul(e), proof.s.Mul(e), proof.r.Mul(y).Mul(proof.s), proof.delta},
+	)
+	return lhs.Equal(rhs), nil
+}
+
+// foldWipGenerators returns G' = e^-1·G1 + e·y^-n̂·G2 and H' = e·H1 + e^-1·H2.
+func foldWipGenerators(g1, g2, h1, h2 []curves.Point, e, eInv, yHalfInv curves.Scalar) ([]curves.Point, []curves.Point) {
+	gs := make([]curves.Point, len(g1))
+	hs := make([]curves.Point, len(h1))
+	eyHalfInv := e.Mul(yHalfInv)
+	for i := range g1 {
+		gs[i] = g1[i].Mul(eInv).Add(g2[i].Mul(eyHalfInv))
+		hs[i] = h1[i].Mul(e).Add(h2[i].Mul(eInv))
+	}
+	return gs, hs
+}
+
+// weightedInnerProduct returns Σ a_i·b_i·y^(i+1) for i = 0..n-1.
+func weightedInnerProduct(a, b []curves.Scalar, y curves.Scalar) (curves.Scalar, error) {
+	if len(a) != len(b) || len(a) == 0 {
+		return nil, errors.New("length of scalar vectors must be the same and non-zero")
+	}
+	yi := y
+	out := a[0].Mul(b[0]).Mul(yi)
+	for i := 1; i < len(a); i++ {
+		yi = yi.Mul(y)
+		out = out.Add(a[i].Mul(b[i]).Mul(yi))
+	}
+	return out, nil
+}
+
+func wipRoundChallenge(curve curves.Curve, capL, capR curves.Point, transcript *transcript.Transcript) (curves.Scalar, error) {
+	transcript.AppendPoint([]byte("wipL"), capL)
+	transcript.AppendPoint([]byte("wipR"), capR)
+	return challengeScalar(curve, []byte("wipe"), transcript)
+}
+
+func wipFinalChallenge(curve curves.Curve, capA, capB curves.Point, transcript *transcript.Transcript) (curves.Scalar, error) {
+	transcript.AppendPoint([]byte("wipA"), capA)
+	transcript.AppendPoint([]byte("wipB"), capB)
+	return challengeScalar(curve, []byte("wipe"), transcript)
+}
+
+// challengeScalar extracts a non-zero challenge from the transcript.
+func challengeScalar(curve curves.Curve, label []byte, transcript *transcript.Transcript) (curves.Scalar, error) {
+	c, err := transcript.ChallengeScalar(label, curve.NewScalar())
+	if err != nil {
+		return nil, err
+	}
+	if c.IsZero() {
+		return nil, errors.New("challenge is zero")
+	}
+	return c, nil
+}
+
+// scalarPow returns x^k for k >= 0.
+func scalarPow(curve curves.Curve, x curves.Scalar, k int) curves.Scalar {
+	out := curve.Scalar.One()
+	for i := 0; i < k; i++ {
+		out = out.Mul(x)
+	}
+	return out
+}
+
+func concatPoints(vectors ...[]curves.Point) []curves.Point {
+	var out []curves.Point
+	for _, v := range vectors {
+		out = append(out, v...)
+	}
+	return out
+}
+
+func concatScalars(vectors ...[]curves.Scalar) []curves.Scalar {
+	var out []curves.Scalar
+	for _, v := range vectors {
+		out = append(out, v...)
+	}
+	return out
+}
@@ -8,10 +8,10 @@
 package bulletproof
 
 import (
-	"github.com/gtank/merlin"
 	"github.com/pkg/errors"
 
 	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
 )
 
 // InnerProductProver is the struct used to create InnerProductProofs
@@ -41,7 +41,7 @@ type ippRecursion struct {
 	capLs, capRs []curves.Point
 	g, h         []curves.Point
 	u, capP      curves.Point
-	transcript   *merlin.Transcript
+	transcript   *transcript.Transcript
 }
 
 // NewInnerProductProver initializes a new prover
@@ -74,7 +74,7 @@ func NewInnerProductProof(curve *curves.Curve) *InnerProductProof {
 // See section 4.2 on pg 20
 // The conversion specifies generators to use (g and hPrime), as well as the two vectors l, r of which the inner product is tHat
 // Additionally, note that the P used for the IPP is in fact P*h^-mu from the range proof.
-func (prover *InnerProductProver) rangeToIPP(proofG, proofH []curves.Point, l, r []curves.Scalar, tHat curves.Scalar, capPhmuinv, u curves.Point, transcript *merlin.Transcript) (*InnerProductProof, error) {
+func (prover *InnerProductProver) rangeToIPP(proofG, proofH []curves.Point, l, r []curves.Scalar, tHat curves.Scalar, capPhmuinv, u curves.Point, transcript *transcript.Transcript) (*InnerProductProof, error) {
 	// Note that P as a witness is only g^l * h^r
 	// P needs to be in the form of g^l * h^r * u^<l,r>
 	// Calculate the final P including the u^<l,r> term
@@ -132,7 +132,7 @@ func (prover *InnerProductProver) getP(a, b []curves.Scalar, u curves.Point) (cu
 // Prove executes the prover protocol on pg 16 of https://eprint.iacr.org/2017/1066.pdf
 // It generates an inner product proof for vectors a and b, using u to blind the inner product in P
 // A transcript is used for the Fiat Shamir heuristic.
-func (prover *InnerProductProver) Prove(a, b []curves.Scalar, u curves.Point, transcript *merlin.Transcript) (*InnerProductProof, error) {
+func (prover *InnerProductProver) Prove(a, b []curves.Scalar, u curves.Point, transcript *transcript.Transcript) (*InnerProductProof, error) {
 	// Vectors must have length power of two
 	if !isPowerOfTwo(len(a)) {
 		return nil, errors.New("ipp vector length must be power of two")
@@ -327,15 +327,14 @@ func (prover *InnerProductProver) proveRecursive(recursionParams *ippRecursion)
 // For each recursion, it takes the current state of the transcript and appends the newly calculated L and R values
 // A new scalar is then read from the transcript
 // See section 4.4 pg22 of https://eprint.iacr.org/2017/1066.pdf
-func (prover *InnerProductProver) calcx(capL, capR curves.Point, transcript *merlin.Transcript) (curves.Scalar, error) {
+func (prover *InnerProductProver) calcx(capL, capR curves.Point, transcript *transcript.Transcript) (curves.Scalar, error) {
 	// Add the newest capL and capR values to transcript
-	transcript.AppendMessage([]byte("addRecursiveL"), capL.ToAffineUncompressed())
-	transcript.AppendMessage([]byte("addRecursiveR"), capR.ToAffineUncompressed())
+	transcript.AppendPoint([]byte("addRecursiveL"), capL)
+	transcript.AppendPoint([]byte("addRecursiveR"), capR)
 	// Read 64 bytes from, set to scalar
-	outBytes := transcript.ExtractBytes([]byte("getx"), 64)
-	x, err := prover.curve.NewScalar().SetBytesWide(outBytes)
+	x, err := transcript.ChallengeScalar([]byte("getx"), prover.curve.NewScalar())
 	if err != nil {
-		return nil, errors.Wrap(err, "calcx NewScalar SetBytesWide")
+		return nil, errors.Wrap(err, "calcx ChallengeScalar")
 	}
 
 	return x, nil
@@ -4,10 +4,10 @@ import (
 	crand "crypto/rand"
 	"testing"
 
-	"github.com/gtank/merlin"
 	"github.com/stretchr/testify/require"
 
 	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
 )
 
 func TestIPPHappyPath(t *testing.T) {
@@ -17,8 +17,8 @@ func TestIPPHappyPath(t *testing.T) {
 	a := randScalarVec(8, *curve)
 	b := randScalarVec(8, *curve)
 	u := curve.Point.Random(crand.Reader)
-	transcript := merlin.NewTranscript("test")
-	proof, err := prover.Prove(a, b, u, transcript)
+	tr := transcript.New("test")
+	proof, err := prover.Prove(a, b, u, tr)
 	require.NoError(t, err)
 	require.Equal(t, 3, len(proof.capLs))
 	require.Equal(t, 3, len(proof.capRs))
@@ -31,8 +31,8 @@ func TestIPPMismatchedVectors(t *testing.T) {
 	a := randScalarVec(4, *curve)
 	b := randScalarVec(8, *curve)
 	u := curve.Point.Random(crand.Reader)
-	transcript := merlin.NewTranscript("test")
-	_, err = prover.Prove(a, b, u, transcript)
+	tr := transcript.New("test")
+	_, err = prover.Prove(a, b, u, tr)
 	require.Error(t, err)
 }
 
@@ -43,8 +43,8 @@ func TestIPPNonPowerOfTwoLengthVectors(t *testing.T) {
 	a := randScalarVec(3, *curve)
 	b := randScalarVec(3, *curve)
 	u := curve.Point.Random(crand.Reader)
-	transcript := merlin.NewTranscript("test")
-	_, err = prover.Prove(a, b, u, transcript)
+	tr := transcript.New("test")
+	_, err = prover.Prove(a, b, u, tr)
 	require.Error(t, err)
 }
 
@@ -55,8 +55,8 @@ func TestIPPZeroLengthVectors(t *testing.T) {
 	a := randScalarVec(0, *curve)
 	b := randScalarVec(0, *curve)
 	u := curve.Point.Random(crand.Reader)
-	transcript := merlin.NewTranscript("test")
-	_, err = prover.Prove(a, b, u, transcript)
+	tr := transcript.New("test")
+	_, err = prover.Prove(a, b, u, tr)
 	require.Error(t, err)
 }
 
@@ -67,8 +67,8 @@ func TestIPPGreaterThanMaxLengthVectors(t *testing.T) {
 	a := randScalarVec(16, *curve)
 	b := randScalarVec(16, *curve)
 	u := curve.Point.Random(crand.Reader)
-	transcript := merlin.NewTranscript("test")
-	_, err = prover.Prove(a, b, u, transcript)
+	tr := transcript.New("test")
+	_, err = prover.Prove(a, b, u, tr)
 	require.Error(t, err)
 }
 
@@ -79,8 +79,8 @@ func TestIPPMarshal(t *testing.T) {
 	a := randScalarVec(8, *curve)
 	b := randScalarVec(8, *curve)
 	u := curve.Point.Random(crand.Reader)
-	transcript := merlin.NewTranscript("test")
-	proof, err := prover.Prove(a, b, u, transcript)
+	tr := transcript.New("test")
+	proof, err := prover.Prove(a, b, u, tr)
 	require.NoError(t, err)
 
 	proofMarshaled := proof.MarshalBinary()
@@ -1,10 +1,10 @@
 package bulletproof
 
 import (
-	"github.com/gtank/merlin"
 	"github.com/pkg/errors"
 
 	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
 )
 
 // InnerProductVerifier is the struct used to verify inner product proofs
@@ -29,7 +29,7 @@ func NewInnerProductVerifier(maxVectorLength int, domain []byte, curve curves.Cu
 
 // Verify verifies the given proof inputs
 // It implements the final comparison of section 3.1 on pg17 of https://eprint.iacr.org/2017/1066.pdf
-func (verifier *InnerProductVerifier) Verify(capP, u curves.Point, proof *InnerProductProof, transcript *merlin.Transcript) (bool, error) {
+func (verifier *InnerProductVerifier) Verify(capP, u curves.Point, proof *InnerProductProof, transcript *transcript.Transcript) (bool, error) {
 	if len(proof.capLs) != len(proof.capRs) {
 		return false, errors.New("ipp capLs and capRs must be same length")
 	}
@@ -71,7 +71,7 @@ func (verifier *InnerProductVerifier) Verify(capP, u curves.Point, proof *InnerP
 
 // Verify verifies the given proof inputs
 // It implements the final comparison of section 3.1 on pg17 of https://eprint.iacr.org/2017/1066.pdf
-func (verifier *InnerProductVerifier) VerifyFromRangeProof(proofG, proofH []curves.Point, capPhmuinv, u curves.Point, tHat curves.Scalar, proof *InnerProductProof, transcript *merlin.Transcript) (bool, error) {
+func (verifier *InnerProductVerifier) VerifyFromRangeProof(proofG, proofH []curves.Point, capPhmuinv, u curves.Point, tHat curves.Scalar, proof *InnerProductProof, transcript *transcript.Transcript) (bool, error) {
 	// Get generators for each elem in a, b and one more for u
 	// len(Ls) = log n, therefore can just exponentiate
 	n := 1 << len(proof.capLs)
@@ -139,18 +139,17 @@ func (verifier *InnerProductVerifier) getLHS(u curves.Point, proof *InnerProduct
 // getxs calculates the x values from Ls and Rs
 // Note that each x is read from the transcript, then the L and R at a certain index are written to the transcript
 // This mirrors the reading of xs and writing of Ls and Rs in the prover.
-func getxs(transcript *merlin.Transcript, capLs, capRs []curves.Point, curve curves.Curve) ([]curves.Scalar, error) {
+func getxs(transcript *transcript.Transcript, capLs, capRs []curves.Point, curve curves.Curve) ([]curves.Scalar, error) {
 	xs := make([]curves.Scalar, len(capLs))
 	for i, capLi := range capLs {
 		capRi := capRs[i]
 		// Add the newest L and R values to transcript
-		transcript.AppendMessage([]byte("addRecursiveL"), capLi.ToAffineUncompressed())
-		transcript.AppendMessage([]byte("addRecursiveR"), capRi.ToAffineUncompressed())
+		transcript.AppendPoint([]byte("addRecursiveL"), capLi)
+		transcript.AppendPoint([]byte("addRecursiveR"), capRi)
 		// Read 64 bytes from, set to scalar
-		outBytes := transcript.ExtractBytes([]byte("getx"), 64)
-		x, err := curve.NewScalar().SetBytesWide(outBytes)
+		x, err := transcript.ChallengeScalar([]byte("getx"), curve.NewScalar())
 		if err != nil {
-			return nil, errors.Wrap(err, "calcx NewScalar SetBytesWide")
+			return nil, errors.Wrap(err, "calcx ChallengeScalar")
 		}
 		xs[i] = x
 	}
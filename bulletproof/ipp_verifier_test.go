@@ -4,10 +4,10 @@ import (
 	crand "crypto/rand"
 	"testing"
 
-	"github.com/gtank/merlin"
 	"github.com/stretchr/testify/require"
 
 	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
 )
 
 func TestIPPVerifyHappyPath(t *testing.T) {
@@ -18,7 +18,7 @@ func TestIPPVerifyHappyPath(t *testing.T) {
 	a := randScalarVec(vecLength, *curve)
 	b := randScalarVec(vecLength, *curve)
 	u := curve.Point.Random(crand.Reader)
-	transcriptProver := merlin.NewTranscript("test")
+	transcriptProver := transcript.New("test")
 	proof, err := prover.Prove(a, b, u, transcriptProver)
 	require.NoError(t, err)
 
@@ -26,7 +26,7 @@ func TestIPPVerifyHappyPath(t *testing.T) {
 	require.NoError(t, err)
 	capP, err := prover.getP(a, b, u)
 	require.NoError(t, err)
-	transcriptVerifier := merlin.NewTranscript("test")
+	transcriptVerifier := transcript.New("test")
 	verified, err := verifier.Verify(capP, u, proof, transcriptVerifier)
 	require.NoError(t, err)
 	require.True(t, verified)
@@ -39,12 +39,12 @@ func BenchmarkIPPVerification(bench *testing.B) {
 	a := randScalarVec(vecLength, *curve)
 	b := randScalarVec(vecLength, *curve)
 	u := curve.Point.Random(crand.Reader)
-	transcriptProver := merlin.NewTranscript("test")
+	transcriptProver := transcript.New("test")
 	proof, _ := prover.Prove(a, b, u, transcriptProver)
 
 	verifier, _ := NewInnerProductVerifier(vecLength, []byte("test"), *curve)
 	capP, _ := prover.getP(a, b, u)
-	transcriptVerifier := merlin.NewTranscript("test")
+	transcriptVerifier := transcript.New("test")
 	verified, _ := verifier.Verify(capP, u, proof, transcriptVerifier)
 	require.True(bench, verified)
 }
@@ -62,7 +62,7 @@ func TestIPPVerifyInvalidProof(t *testing.T) {
 	aPrime := randScalarVec(64, *curve)
 	bPrime := randScalarVec(64, *curve)
 	uPrime := curve.Point.Random(crand.Reader)
-	transcriptProver := merlin.NewTranscript("test")
+	transcriptProver := transcript.New("test")
 
 	proofPrime, err := prover.Prove(aPrime, bPrime, uPrime, transcriptProver)
 	require.NoError(t, err)
@@ -71,7 +71,7 @@ func TestIPPVerifyInvalidProof(t *testing.T) {
 	require.NoError(t, err)
 	capP, err := prover.getP(a, b, u)
 	require.NoError(t, err)
-	transcriptVerifier := merlin.NewTranscript("test")
+	transcriptVerifier := transcript.New("test")
 	// Check for different capP, u from proof
 	verified, err := verifier.Verify(capP, u, proofPrime, transcriptVerifier)
 	require.NoError(t, err)
@@ -0,0 +1,304 @@
+package bulletproof
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
+)
+
+// This file contains the constraint system API of the arithmetic circuit proofs of section 5 of
+// https://eprint.iacr.org/2017/1066.pdf, in the form of the "gadget" API of the dalek bulletproofs library:
+// a circuit is a set of multiplication gates a_L·a_R = a_O and linear constraints over the gate wires and the
+// committed values, and gadgets are functions that add gates and constraints to a ConstraintSystem.
+// The same gadget code runs on the prover, which knows the assignments, and on the verifier, which does not.
+
+type variableKind int
+
+const (
+	variableOne variableKind = iota
+	variableCommitted
+	variableMultiplierLeft
+	variableMultiplierRight
+	variableMultiplierOutput
+)
+
+// Variable is a wire of a constraint system: a committed value, an input or output of a multiplication gate, or the
+// constant One.
+type Variable struct {
+	kind  variableKind
+	index int
+}
+
+// One is the variable with the constant value 1.
+var One = Variable{kind: variableOne}
+
+// Term is a variable with a coefficient.
+type Term struct {
+	Variable    Variable
+	Coefficient curves.Scalar
+}
+
+// LinearCombination is a sum of terms.
+type LinearCombination []Term
+
+// Times returns the linear combination c·v.
+func (v Variable) Times(c curves.Scalar) LinearCombination {
+	return LinearCombination{{Variable: v, Coefficient: c}}
+}
+
+// Plus returns lc + other.
+func (lc LinearCombination) Plus(other LinearCombination) LinearCombination {
+	out := make(LinearCombination, 0, len(lc)+len(other))
+	return append(append(out, lc...), other...)
+}
+
+// Minus returns lc - other.
+func (lc LinearCombination) Minus(other LinearCombination) LinearCombination {
+	return lc.Plus(other.Scale(nil))
+}
+
+// Scale returns c·lc, or -lc for a nil c.
+func (lc LinearCombination) Scale(c curves.Scalar) LinearCombination {
+	out := make(LinearCombination, len(lc))
+	for i, t := range lc {
+		if c == nil {
+			out[i] = Term{Variable: t.Variable, Coefficient: t.Coefficient.Neg()}
+		} else {
+			out[i] = Term{Variable: t.Variable, Coefficient: t.Coefficient.Mul(c)}
+		}
+	}
+	return out
+}
+
+// ConstraintSystem is implemented by R1CSProver and R1CSVerifier.
+type ConstraintSystem interface {
+	// Curve returns the curve of the scalars of the constraint system.
+	Curve() *curves.Curve
+	// Multiply adds a multiplication gate with inputs left and right and returns its left, right and output wires.
+	Multiply(left, right LinearCombination) (Variable, Variable, Variable)
+	// AllocateMultiplier adds a multiplication gate with the given inputs, which are nil on the verifier, and
+	// returns its left, right and output wires.
+	AllocateMultiplier(left, right curves.Scalar) (Variable, Variable, Variable, error)
+	// Constrain adds the constraint lc = 0.
+	Constrain(lc LinearCombination)
+}
+
+// R1CSParams holds the generators of arithmetic circuit proofs with at most a fixed number of multiplication gates.
+// Values are committed as V = v·g + gamma·h, like in RangeParams for the same curve and domain.
+type R1CSParams struct {
+	curve        curves.Curve
+	generators   *ippGenerators
+	g, h, u      curves.Point
+	ippProver    *InnerProductProver
+	ippVerifier  *InnerProductVerifier
+	maxGateCount int
+}
+
+// R1CSProof is a proof that the committed values satisfy a constraint system.
+// capAI, capAO, capS commit to the gate inputs, outputs and blinding vectors, capT* to the coefficients of t(X),
+// and ipp proves <l(x), r(x)> = tHat.
+type R1CSProof struct {
+	capAI, capAO, capS                curves.Point
+	capT1, capT3, capT4, capT5, capT6 curves.Point
+	taux, mu, tHat                    curves.Scalar
+	ipp                               *InnerProductProof
+}
+
+// NewR1CSParams creates parameters for circuits of up to maxGateCount multiplication gates.
+func NewR1CSParams(curve *curves.Curve, maxGateCount int, domain []byte) (*R1CSParams, error) {
+	if curve == nil {
+		return nil, errors.New("curve cannot be nil")
+	}
+	if maxGateCount < 1 {
+		return nil, errors.New("gate count must be positive")
+	}
+	capacity := paddedGateCount(maxGateCount)
+	generators, err := getGeneratorPoints(capacity, append([]byte("r1cs"), domain...), *curve)
+	if err != nil {
+		return nil, errors.Wrap(err, "r1cs NewR1CSParams")
+	}
+	ippProver, err := NewInnerProductProver(capacity, append([]byte("r1cs ipp"), domain...), *curve)
+	if err != nil {
+		return nil, errors.Wrap(err, "r1cs NewR1CSParams")
+	}
+	ippVerifier, err := NewInnerProductVerifier(capacity, append([]byte("r1cs ipp"), domain...), *curve)
+	if err != nil {
+		return nil, errors.Wrap(err, "r1cs NewR1CSParams")
+	}
+	return &R1CSParams{
+		curve:        *curve,
+		generators:   generators,
+		g:            curve.NewGeneratorPoint(),
+		h:            curve.Point.Hash(append([]byte("range h"), domain...)),
+		u:            curve.Point.Hash(append([]byte("r1cs u"), domain...)),
+		ippProver:    ippProver,
+		ippVerifier:  ippVerifier,
+		maxGateCount: maxGateCount,
+	}, nil
+}
+
+// circuit holds the gates and constraints shared by the prover and the verifier.
+type circuit struct {
+	transcript  *transcript.Transcript
+	gates       int
+	committed   int
+	constraints []LinearCombination
+}
+
+func (c *circuit) multiplierVariables() (Variable, Variable, Variable) {
+	i := c.gates
+	c.gates++
+	return Variable{variableMultiplierLeft, i}, Variable{variableMultiplierRight, i}, Variable{variableMultiplierOutput, i}
+}
+
+// flatten combines the constraints with powers of z into the vectors w_L, w_R, w_O, w_V and the constant w_c of
+// W_L·a_L + W_R·a_R + W_O·a_O = W_V·v + c, for n gates padded to gateCount.
+func (c *circuit) flatten(curve curves.Curve, z curves.Scalar, gateCount int) (wL, wR, wO, wV []curves.Scalar, wc curves.Scalar, err error) {
+	wL = zeroVector(curve, gateCount)
+	wR = zeroVector(curve, gateCount)
+	wO = zeroVector(curve, gateCount)
+	wV = zeroVector(curve, c.committed)
+	wc = curve.Scalar.Zero()
+	zq := z
+	for _, lc := range c.constraints {
+		for _, term := range lc {
+			if term.Coefficient == nil {
+				return nil, nil, nil, nil, nil, errors.New("coefficient cannot be nil")
+			}
+			coefficient := zq.Mul(term.Coefficient)
+			i := term.Variable.index
+			switch term.Variable.kind {
+			case variableMultiplierLeft:
+				if i >= c.gates {
+					return nil, nil, nil, nil, nil, errors.New("unknown multiplier")
+				}
+				wL[i] = wL[i].Add(coefficient)
+			case variableMultiplierRight:
+				if i >= c.gates {
+					return nil, nil, nil, nil, nil, errors.New("unknown multiplier")
+				}
+				wR[i] = wR[i].Add(coefficient)
+			case variableMultiplierOutput:
+				if i >= c.gates {
+					return nil, nil, nil, nil, nil, errors.New("unknown multiplier")
+				}
+				wO[i] = wO[i].Add(coefficient)
+			case variableCommitted:
+				if i >= c.committed {
+					return nil, nil, nil, nil, nil, errors.New("unknown committed variable")
+				}
+				wV[i] = wV[i].Sub(coefficient)
+			case variableOne:
+				wc = wc.Sub(coefficient)
+			}
+		}
+		zq = zq.Mul(z)
+	}
+	return wL, wR, wO, wV, wc, nil
+}
+
+// commitGates binds the gate count and the first commitments to the transcript and returns the challenges y, z.
+func (c *circuit) commitGates(curve curves.Curve, capAI, capAO, capS curves.Point) (curves.Scalar, curves.Scalar, error) {
+	c.transcript.AppendMessage([]byte("n"), big.NewInt(int64(c.gates)).Bytes())
+	c.transcript.AppendMessage([]byte("m"), big.NewInt(int64(c.committed)).Bytes())
+	c.transcript.AppendPoint([]byte("A_I"), capAI)
+	c.transcript.AppendPoint([]byte("A_O"), capAO)
+	c.transcript.AppendPoint([]byte("S"), capS)
+	y, err := challengeScalar(curve, []byte("y"), c.transcript)
+	if err != nil {
+		return nil, nil, err
+	}
+	z, err := challengeScalar(curve, []byte("z"), c.transcript)
+	if err != nil {
+		return nil, nil, err
+	}
+	return y, z, nil
+}
+
+// challengeX binds the commitments to t(X) to the transcript and returns the challenge x.
+func (c *circuit) challengeX(curve curves.Curve, proof *R1CSProof) (curves.Scalar, error) {
+	for _, p := range []curves.Point{proof.capT1, proof.capT3, proof.capT4, proof.capT5, proof.capT6} {
+		c.transcript.AppendPoint([]byte("T"), p)
+	}
+	return challengeScalar(curve, []byte("x"), c.transcript)
+}
+
+// challengeW binds tHat, taux and mu to the transcript and returns the challenge w for the inner product argument.
+func (c *circuit) challengeW(curve curves.Curve, proof *R1CSProof) (curves.Scalar, error) {
+	c.transcript.AppendScalar([]byte("t_x"), proof.tHat)
+	c.transcript.AppendScalar([]byte("t_x_blinding"), proof.taux)
+	c.transcript.AppendScalar([]byte("e_blinding"), proof.mu)
+	return challengeScalar(curve, []byte("w"), c.transcript)
+}
+
+// MarshalBinary returns A_I, A_O, S, T1, T3, T4, T5, T6, taux, mu, tHat and the inner product proof.
+func (proof *R1CSProof) MarshalBinary() []byte {
+	var out []byte
+	for _, p := range []curves.Point{proof.capAI, proof.capAO, proof.capS, proof.capT1, proof.capT3, proof.capT4, proof.capT5, proof.capT6} {
+		out = append(out, p.ToAffineCompressed()...)
+	}
+	out = append(out, proof.taux.Bytes()...)
+	out = append(out, proof.mu.Bytes()...)
+	out = append(out, proof.tHat.Bytes()...)
+	return append(out, proof.ipp.MarshalBinary()...)
+}
+
+// UnmarshalR1CSProof parses a proof made by MarshalBinary.
+func (params *R1CSParams) UnmarshalR1CSProof(data []byte) (*R1CSProof, error) {
+	scalarLen := len(params.curve.NewScalar().Bytes())
+	pointLen := len(params.curve.NewGeneratorPoint().ToAffineCompressed())
+	fixed := 8*pointLen + 5*scalarLen
+	if len(data) < fixed || (len(data)-fixed)%(2*pointLen) != 0 {
+		return nil, errors.New("r1cs proof has the wrong length")
+	}
+	points := make([]curves.Point, 8)
+	ptr := 0
+	for i := range points {
+		p, err := params.curve.Point.FromAffineCompressed(data[ptr : ptr+pointLen])
+		if err != nil {
+			return nil, errors.Wrap(err, "r1cs UnmarshalBinary")
+		}
+		points[i] = p
+		ptr += pointLen
+	}
+	scalars := make([]curves.Scalar, 3)
+	for i := range scalars {
+		s, err := params.curve.NewScalar().SetBytes(data[ptr : ptr+scalarLen])
+		if err != nil {
+			return nil, errors.Wrap(err, "r1cs UnmarshalBinary")
+		}
+		scalars[i] = s
+		ptr += scalarLen
+	}
+	ipp := NewInnerProductProof(&params.curve)
+	if err := ipp.UnmarshalBinary(data[ptr:]); err != nil {
+		return nil, errors.Wrap(err, "r1cs UnmarshalBinary")
+	}
+	return &R1CSProof{
+		capAI: points[0], capAO: points[1], capS: points[2],
+		capT1: points[3], capT3: points[4], capT4: points[5], capT5: points[6], capT6: points[7],
+		taux: scalars[0], mu: scalars[1], tHat: scalars[2],
+		ipp: ipp,
+	}, nil
+}
+
+// paddedGateCount returns the smallest power of two that is at least n and 2, the size of the vectors of the inner
+// product argument.
+func paddedGateCount(n int) int {
+	padded := 2
+	for padded < n {
+		padded <<= 1
+	}
+	return padded
+}
+
+func zeroVector(curve curves.Curve, n int) []curves.Scalar {
+	out := make([]curves.Scalar, n)
+	for i := range out {
+		out[i] = curve.Scalar.Zero()
+	}
+	return out
+}
@@ -0,0 +1,82 @@
+package bulletproof
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/go-sonr/crypto/core/curves"
+)
+
+// Gadgets take the assignment of their inputs as scalars, which are nil on the verifier.
+// Hash preimage gadgets are not provided, they need a circuit friendly hash function that the rest of the
+// library does not implement.
+
+// RangeGadget constrains v to [0, 2^n) with n gates, one per bit. value is the assignment of v on the prover.
+func RangeGadget(cs ConstraintSystem, v LinearCombination, value curves.Scalar, n int) error {
+	if n < 1 {
+		return errors.New("range must have at least one bit")
+	}
+	curve := cs.Curve()
+	one := curve.Scalar.One()
+	var bitsOf []bool
+	if value != nil {
+		b := value.BigInt()
+		bitsOf = make([]bool, n)
+		for i := range bitsOf {
+			bitsOf[i] = b.Bit(i) == 1
+		}
+	}
+	sum := LinearCombination{}
+	power := curve.Scalar.One()
+	for i := 0; i < n; i++ {
+		var left, right curves.Scalar
+		if bitsOf != nil {
+			if bitsOf[i] {
+				left, right = curve.Scalar.One(), curve.Scalar.Zero()
+			} else {
+				left, right = curve.Scalar.Zero(), curve.Scalar.One()
+			}
+		}
+		// a·b = 0 and a + b = 1 make a a bit and b = 1 - a
+		a, b, o, err := cs.AllocateMultiplier(left, right)
+		if err != nil {
+			return err
+		}
+		cs.Constrain(o.Times(one))
+		cs.Constrain(a.Times(one).Plus(b.Times(one)).Minus(One.Times(one)))
+		sum = sum.Plus(a.Times(power))
+		power = power.Double()
+	}
+	cs.Constrain(v.Minus(sum))
+	return nil
+}
+
+// ProductGadget constrains out = left·right and returns the output wire.
+func ProductGadget(cs ConstraintSystem, left, right, out LinearCombination) Variable {
+	_, _, o := cs.Multiply(left, right)
+	cs.Constrain(out.Minus(o.Times(cs.Curve().Scalar.One())))
+	return o
+}
+
+// NotEqualGadget constrains left != right, given the assignment of left - right on the prover. It shows that the
+// difference has an inverse.
+func NotEqualGadget(cs ConstraintSystem, left, right LinearCombination, difference curves.Scalar) error {
+	var diff, inv curves.Scalar
+	if difference != nil {
+		if difference.IsZero() {
+			return errors.New("values are equal")
+		}
+		var err error
+		if inv, err = difference.Invert(); err != nil {
+			return err
+		}
+		diff = difference
+	}
+	one := cs.Curve().Scalar.One()
+	a, _, o, err := cs.AllocateMultiplier(diff, inv)
+	if err != nil {
+		return err
+	}
+	cs.Constrain(left.Minus(right).Minus(a.Times(one)))
+	cs.Constrain(o.Times(one).Minus(One.Times(one)))
+	return nil
+}
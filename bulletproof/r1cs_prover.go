@@ -0,0 +1,237 @@
+package bulletproof
+
+import (
+	crand "crypto/rand"
+
+	"github.com/pkg/errors"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
+)
+
+// R1CSProver builds a constraint system together with its assignment and proves that it is satisfied.
+// Evaluation errors of the gadgets are kept and returned by Prove.
+type R1CSProver struct {
+	circuit
+	params     *R1CSParams
+	v, gamma   []curves.Scalar
+	aL, aR, aO []curves.Scalar
+	err        error
+}
+
+// NewProver returns a prover that writes the proof to transcript.
+func (params *R1CSParams) NewProver(transcript *transcript.Transcript) (*R1CSProver, error) {
+	if transcript == nil {
+		return nil, errors.New("transcript cannot be nil")
+	}
+	transcript.AppendMessage([]byte("dom-sep"), []byte("r1cs v1"))
+	return &R1CSProver{circuit: circuit{transcript: transcript}, params: params}, nil
+}
+
+// Curve returns the curve of the parameters.
+func (p *R1CSProver) Curve() *curves.Curve {
+	return &p.params.curve
+}
+
+// Commit commits to v with blinding gamma and returns the commitment V = v·g + gamma·h, which the verifier needs,
+// and the variable of v.
+func (p *R1CSProver) Commit(v, gamma curves.Scalar) (curves.Point, Variable, error) {
+	if v == nil || gamma == nil {
+		return nil, Variable{}, errors.New("value and blinding cannot be nil")
+	}
+	capV := getcapV(v, gamma, p.params.g, p.params.h)
+	p.transcript.AppendPoint([]byte("V"), capV)
+	p.v = append(p.v, v)
+	p.gamma = append(p.gamma, gamma)
+	i := p.committed
+	p.committed++
+	return capV, Variable{kind: variableCommitted, index: i}, nil
+}
+
+// Multiply adds a gate for left·right and constrains its inputs to left and right.
+func (p *R1CSProver) Multiply(left, right LinearCombination) (Variable, Variable, Variable) {
+	l := p.eval(left)
+	r := p.eval(right)
+	lv, rv, ov := p.allocate(l, r)
+	one := p.params.curve.Scalar.One()
+	p.Constrain(left.Minus(lv.Times(one)))
+	p.Constrain(right.Minus(rv.Times(one)))
+	return lv, rv, ov
+}
+
+// AllocateMultiplier adds a gate for left·right without constraining its inputs.
+func (p *R1CSProver) AllocateMultiplier(left, right curves.Scalar) (Variable, Variable, Variable, error) {
+	if left == nil || right == nil {
+		return Variable{}, Variable{}, Variable{}, errors.New("prover needs the inputs of the multiplier")
+	}
+	l, r, o := p.allocate(left, right)
+	return l, r, o, nil
+}
+
+// Constrain adds the constraint lc = 0.
+func (p *R1CSProver) Constrain(lc LinearCombination) {
+	p.constraints = append(p.constraints, lc)
+}
+
+func (p *R1CSProver) allocate(left, right curves.Scalar) (Variable, Variable, Variable) {
+	p.aL = append(p.aL, left)
+	p.aR = append(p.aR, right)
+	p.aO = append(p.aO, left.Mul(right))
+	return p.multiplierVariables()
+}
+
+// Value returns the assignment of the variables of lc.
+func (p *R1CSProver) Value(lc LinearCombination) curves.Scalar {
+	return p.eval(lc)
+}
+
+func (p *R1CSProver) eval(lc LinearCombination) curves.Scalar {
+	out := p.params.curve.Scalar.Zero()
+	for _, term := range lc {
+		if term.Coefficient == nil {
+			p.fail(errors.New("coefficient cannot be nil"))
+			continue
+		}
+		var value curves.Scalar
+		i := term.Variable.index
+		switch term.Variable.kind {
+		case variableOne:
+			value = p.params.curve.Scalar.One()
+		case variableCommitted:
+			if i < len(p.v) {
+				value = p.v[i]
+			}
+		case variableMultiplierLeft:
+			if i < len(p.aL) {
+				value = p.aL[i]
+			}
+		case variableMultiplierRight:
+			if i < len(p.aR) {
+				value = p.aR[i]
+			}
+		case variableMultiplierOutput:
+			if i < len(p.aO) {
+				value = p.aO[i]
+			}
+		}
+		if value == nil {
+			p.fail(errors.New("unknown variable"))
+			continue
+		}
+		out = out.Add(value.Mul(term.Coefficient))
+	}
+	return out
+}
+
+func (p *R1CSProver) fail(err error) {
+	if p.err == nil {
+		p.err = err
+	}
+}
+
+// Prove proves that the assignment satisfies the constraints. See section 5.3 of
+// https://eprint.iacr.org/2017/1066.pdf, with the weights of the constraints flattened with powers of z.
+func (p *R1CSProver) Prove() (*R1CSProof, error) {
+	if p.err != nil {
+		return nil, errors.Wrap(p.err, "r1cs prove")
+	}
+	if p.gates > p.params.maxGateCount {
+		return nil, errors.Errorf("circuit has %d gates, more than %d", p.gates, p.params.maxGateCount)
+	}
+	curve := p.params.curve
+	n := paddedGateCount(p.gates)
+	aL := append(append([]curves.Scalar{}, p.aL...), zeroVector(curve, n-p.gates)...)
+	aR := append(append([]curves.Scalar{}, p.aR...), zeroVector(curve, n-p.gates)...)
+	aO := append(append([]curves.Scalar{}, p.aO...), zeroVector(curve, n-p.gates)...)
+	proofG := p.params.generators.G[:n]
+	proofH := p.params.generators.H[:n]
+
+	alpha := curve.Scalar.Random(crand.Reader)
+	beta := curve.Scalar.Random(crand.Reader)
+	rho := curve.Scalar.Random(crand.Reader)
+	sL := getBlindingVector(n, curve)
+	sR := getBlindingVector(n, curve)
+	proof := &R1CSProof{
+		capAI: curve.Point.SumOfProducts(concatPoints(proofG, proofH, []curves.Point{p.params.h}), concatScalars(aL, aR, []curves.Scalar{alpha})),
+		capAO: curve.Point.SumOfProducts(concatPoints(proofG, []curves.Point{p.params.h}), concatScalars(aO, []curves.Scalar{beta})),
+		capS:  curve.Point.SumOfProducts(concatPoints(proofG, proofH, []curves.Point{p.params.h}), concatScalars(sL, sR, []curves.Scalar{rho})),
+	}
+	y, z, err := p.commitGates(curve, proof.capAI, proof.capAO, proof.capS)
+	if err != nil {
+		return nil, errors.Wrap(err, "r1cs prove")
+	}
+	wL, wR, wO, wV, _, err := p.flatten(curve, z, n)
+	if err != nil {
+		return nil, errors.Wrap(err, "r1cs prove")
+	}
+	yInv, err := y.Invert()
+	if err != nil {
+		return nil, errors.Wrap(err, "r1cs prove")
+	}
+	yPows := powers(curve, y, n)
+	yInvPows := powers(curve, yInv, n)
+
+	// l(X) = l1·X + l2·X² + l3·X³, r(X) = r0 + r1·X + r3·X³
+	yInvwR, _ := multiplyPairwiseScalarVectors(yInvPows, wR)
+	l1, _ := addPairwiseScalarVectors(aL, yInvwR)
+	l2 := aO
+	l3 := sL
+	r0, _ := subtractPairwiseScalarVectors(wO, yPows)
+	yPowsaR, _ := multiplyPairwiseScalarVectors(yPows, aR)
+	r1, _ := addPairwiseScalarVectors(yPowsaR, wL)
+	r3, _ := multiplyPairwiseScalarVectors(yPows, sR)
+
+	ip := func(a, b []curves.Scalar) curves.Scalar {
+		c, _ := innerProduct(a, b)
+		return c
+	}
+	t1 := ip(l1, r0)
+	t3 := ip(l2, r1).Add(ip(l3, r0))
+	t4 := ip(l1, r3).Add(ip(l3, r1))
+	t5 := ip(l2, r3)
+	t6 := ip(l3, r3)
+	tau1 := curve.Scalar.Random(crand.Reader)
+	tau3 := curve.Scalar.Random(crand.Reader)
+	tau4 := curve.Scalar.Random(crand.Reader)
+	tau5 := curve.Scalar.Random(crand.Reader)
+	tau6 := curve.Scalar.Random(crand.Reader)
+	proof.capT1 = getcapV(t1, tau1, p.params.g, p.params.h)
+	proof.capT3 = getcapV(t3, tau3, p.params.g, p.params.h)
+	proof.capT4 = getcapV(t4, tau4, p.params.g, p.params.h)
+	proof.capT5 = getcapV(t5, tau5, p.params.g, p.params.h)
+	proof.capT6 = getcapV(t6, tau6, p.params.g, p.params.h)
+
+	x, err := p.challengeX(curve, proof)
+	if err != nil {
+		return nil, errors.Wrap(err, "r1cs prove")
+	}
+	xs := powers(curve, x, 7)
+	l := make([]curves.Scalar, n)
+	r := make([]curves.Scalar, n)
+	for i := 0; i < n; i++ {
+		l[i] = l1[i].Mul(xs[1]).Add(l2[i].Mul(xs[2])).Add(l3[i].Mul(xs[3]))
+		r[i] = r0[i].Add(r1[i].Mul(xs[1])).Add(r3[i].Mul(xs[3]))
+	}
+	proof.tHat = ip(l, r)
+	// taux = Σ tau_i·x^i + x²·<w_V, gamma>
+	proof.taux = tau1.Mul(xs[1]).Add(tau3.Mul(xs[3])).Add(tau4.Mul(xs[4])).Add(tau5.Mul(xs[5])).Add(tau6.Mul(xs[6]))
+	if p.committed > 0 {
+		proof.taux = proof.taux.Add(ip(wV, p.gamma).Mul(xs[2]))
+	}
+	proof.mu = alpha.Mul(xs[1]).Add(beta.Mul(xs[2])).Add(rho.Mul(xs[3]))
+
+	w, err := p.challengeW(curve, proof)
+	if err != nil {
+		return nil, errors.Wrap(err, "r1cs prove")
+	}
+	hPrime, err := gethPrime(proofH, y, curve)
+	if err != nil {
+		return nil, errors.Wrap(err, "r1cs prove")
+	}
+	capP := curve.Point.SumOfProducts(concatPoints(proofG, hPrime), concatScalars(l, r))
+	proof.ipp, err = p.params.ippProver.rangeToIPP(proofG, hPrime, l, r, proof.tHat, capP, p.params.u.Mul(w), p.transcript)
+	if err != nil {
+		return nil, errors.Wrap(err, "r1cs prove")
+	}
+	return proof, nil
+}
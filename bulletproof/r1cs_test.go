@@ -0,0 +1,137 @@
+package bulletproof
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
+)
+
+// productCircuit proves knowledge of the openings of V_x, V_y, V_z with x·y = z and z in [0, 2^8).
+func productCircuit(cs ConstraintSystem, x, y, z Variable, values []curves.Scalar) error {
+	one := cs.Curve().Scalar.One()
+	ProductGadget(cs, x.Times(one), y.Times(one), z.Times(one))
+	var zValue curves.Scalar
+	if values != nil {
+		zValue = values[2]
+	}
+	return RangeGadget(cs, z.Times(one), zValue, 8)
+}
+
+func proveProduct(t *testing.T, params *R1CSParams, values []curves.Scalar) (*R1CSProof, []curves.Point, error) {
+	t.Helper()
+	curve := params.curve
+	prover, err := params.NewProver(transcript.New("test"))
+	require.NoError(t, err)
+	capV := make([]curves.Point, len(values))
+	vars := make([]Variable, len(values))
+	for i, v := range values {
+		capV[i], vars[i], err = prover.Commit(v, curve.Scalar.Random(crand.Reader))
+		require.NoError(t, err)
+	}
+	require.NoError(t, productCircuit(prover, vars[0], vars[1], vars[2], values))
+	proof, err := prover.Prove()
+	return proof, capV, err
+}
+
+func verifyProduct(t *testing.T, params *R1CSParams, proof *R1CSProof, capV []curves.Point, label string) error {
+	t.Helper()
+	verifier, err := params.NewVerifier(transcript.New(label))
+	require.NoError(t, err)
+	vars := make([]Variable, len(capV))
+	for i, p := range capV {
+		vars[i], err = verifier.Commit(p)
+		require.NoError(t, err)
+	}
+	require.NoError(t, productCircuit(verifier, vars[0], vars[1], vars[2], nil))
+	return verifier.Verify(proof)
+}
+
+func TestR1CSProveVerify(t *testing.T) {
+	curve := curves.ED25519()
+	params, err := NewR1CSParams(curve, 16, []byte("test"))
+	require.NoError(t, err)
+	values := []curves.Scalar{curve.Scalar.New(7), curve.Scalar.New(31), curve.Scalar.New(217)}
+	proof, capV, err := proveProduct(t, params, values)
+	require.NoError(t, err)
+	require.NoError(t, verifyProduct(t, params, proof, capV, "test"))
+
+	require.Error(t, verifyProduct(t, params, proof, capV, "other"))
+	require.Error(t, verifyProduct(t, params, proof, []curves.Point{capV[1], capV[0], capV[0]}, "test"))
+}
+
+func TestR1CSWrongWitness(t *testing.T) {
+	curve := curves.ED25519()
+	params, err := NewR1CSParams(curve, 16, []byte("test"))
+	require.NoError(t, err)
+
+	// 7·31 != 218
+	proof, capV, err := proveProduct(t, params, []curves.Scalar{curve.Scalar.New(7), curve.Scalar.New(31), curve.Scalar.New(218)})
+	require.NoError(t, err)
+	require.Error(t, verifyProduct(t, params, proof, capV, "test"))
+
+	// 16·16 = 256 is out of range
+	proof, capV, err = proveProduct(t, params, []curves.Scalar{curve.Scalar.New(16), curve.Scalar.New(16), curve.Scalar.New(256)})
+	require.NoError(t, err)
+	require.Error(t, verifyProduct(t, params, proof, capV, "test"))
+}
+
+func TestR1CSTooManyGates(t *testing.T) {
+	curve := curves.ED25519()
+	params, err := NewR1CSParams(curve, 4, []byte("test"))
+	require.NoError(t, err)
+	_, _, err = proveProduct(t, params, []curves.Scalar{curve.Scalar.New(1), curve.Scalar.New(1), curve.Scalar.New(1)})
+	require.Error(t, err)
+}
+
+func TestR1CSNotEqualGadget(t *testing.T) {
+	curve := curves.K256()
+	params, err := NewR1CSParams(curve, 2, []byte("test"))
+	require.NoError(t, err)
+	one := curve.Scalar.One()
+
+	prover, err := params.NewProver(transcript.New("test"))
+	require.NoError(t, err)
+	capA, a, err := prover.Commit(curve.Scalar.New(3), curve.Scalar.Random(crand.Reader))
+	require.NoError(t, err)
+	capB, b, err := prover.Commit(curve.Scalar.New(5), curve.Scalar.Random(crand.Reader))
+	require.NoError(t, err)
+	require.NoError(t, NotEqualGadget(prover, a.Times(one), b.Times(one), prover.Value(a.Times(one).Minus(b.Times(one)))))
+	proof, err := prover.Prove()
+	require.NoError(t, err)
+
+	verifier, err := params.NewVerifier(transcript.New("test"))
+	require.NoError(t, err)
+	a, err = verifier.Commit(capA)
+	require.NoError(t, err)
+	b, err = verifier.Commit(capB)
+	require.NoError(t, err)
+	require.NoError(t, NotEqualGadget(verifier, a.Times(one), b.Times(one), nil))
+	require.NoError(t, verifier.Verify(proof))
+
+	prover, err = params.NewProver(transcript.New("test"))
+	require.NoError(t, err)
+	_, a, err = prover.Commit(curve.Scalar.New(3), curve.Scalar.Random(crand.Reader))
+	require.NoError(t, err)
+	require.Error(t, NotEqualGadget(prover, a.Times(one), a.Times(one), prover.Value(a.Times(one).Minus(a.Times(one)))))
+}
+
+func TestR1CSMarshal(t *testing.T) {
+	curve := curves.ED25519()
+	params, err := NewR1CSParams(curve, 16, []byte("test"))
+	require.NoError(t, err)
+	values := []curves.Scalar{curve.Scalar.New(3), curve.Scalar.New(4), curve.Scalar.New(12)}
+	proof, capV, err := proveProduct(t, params, values)
+	require.NoError(t, err)
+
+	data := proof.MarshalBinary()
+	parsed, err := params.UnmarshalR1CSProof(data)
+	require.NoError(t, err)
+	require.NoError(t, verifyProduct(t, params, parsed, capV, "test"))
+
+	_, err = params.UnmarshalR1CSProof(data[:len(data)-1])
+	require.Error(t, err)
+}
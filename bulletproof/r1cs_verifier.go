@@ -0,0 +1,146 @@
+package bulletproof
+
+import (
+	"math/bits"
+
+	"github.com/pkg/errors"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
+)
+
+// R1CSVerifier builds the same constraint system as the prover, without the assignment, and checks proofs for it.
+type R1CSVerifier struct {
+	circuit
+	params      *R1CSParams
+	commitments []curves.Point
+}
+
+// NewVerifier returns a verifier that reads the proof from transcript.
+func (params *R1CSParams) NewVerifier(transcript *transcript.Transcript) (*R1CSVerifier, error) {
+	if transcript == nil {
+		return nil, errors.New("transcript cannot be nil")
+	}
+	transcript.AppendMessage([]byte("dom-sep"), []byte("r1cs v1"))
+	return &R1CSVerifier{circuit: circuit{transcript: transcript}, params: params}, nil
+}
+
+// Curve returns the curve of the parameters.
+func (v *R1CSVerifier) Curve() *curves.Curve {
+	return &v.params.curve
+}
+
+// Commit adds the commitment capV of the prover and returns the variable of its value.
+func (v *R1CSVerifier) Commit(capV curves.Point) (Variable, error) {
+	if capV == nil || capV.CurveName() != v.params.curve.Name || !capV.IsOnCurve() {
+		return Variable{}, errors.New("invalid commitment")
+	}
+	v.transcript.AppendPoint([]byte("V"), capV)
+	v.commitments = append(v.commitments, capV)
+	i := v.committed
+	v.committed++
+	return Variable{kind: variableCommitted, index: i}, nil
+}
+
+// Multiply adds a gate for left·right and constrains its inputs to left and right.
+func (v *R1CSVerifier) Multiply(left, right LinearCombination) (Variable, Variable, Variable) {
+	l, r, o := v.multiplierVariables()
+	one := v.params.curve.Scalar.One()
+	v.Constrain(left.Minus(l.Times(one)))
+	v.Constrain(right.Minus(r.Times(one)))
+	return l, r, o
+}
+
+// AllocateMultiplier adds a gate. The inputs are ignored, the verifier does not know them.
+func (v *R1CSVerifier) AllocateMultiplier(_, _ curves.Scalar) (Variable, Variable, Variable, error) {
+	l, r, o := v.multiplierVariables()
+	return l, r, o, nil
+}
+
+// Constrain adds the constraint lc = 0.
+func (v *R1CSVerifier) Constrain(lc LinearCombination) {
+	v.constraints = append(v.constraints, lc)
+}
+
+// Verify checks that proof shows the commitments satisfy the constraint system.
+func (v *R1CSVerifier) Verify(proof *R1CSProof) error {
+	if v.gates > v.params.maxGateCount {
+		return errors.Errorf("circuit has %d gates, more than %d", v.gates, v.params.maxGateCount)
+	}
+	if proof == nil || proof.capAI == nil || proof.capAO == nil || proof.capS == nil ||
+		proof.capT1 == nil || proof.capT3 == nil || proof.capT4 == nil || proof.capT5 == nil || proof.capT6 == nil ||
+		proof.taux == nil || proof.mu == nil || proof.tHat == nil || proof.ipp == nil || proof.ipp.a == nil || proof.ipp.b == nil {
+		return errors.New("r1cs proof is incomplete")
+	}
+	curve := v.params.curve
+	n := paddedGateCount(v.gates)
+	if len(proof.ipp.capLs) != len(proof.ipp.capRs) || len(proof.ipp.capLs) != bits.TrailingZeros(uint(n)) {
+		return errors.New("r1cs proof has the wrong number of rounds")
+	}
+	proofG := v.params.generators.G[:n]
+	proofH := v.params.generators.H[:n]
+
+	y, z, err := v.commitGates(curve, proof.capAI, proof.capAO, proof.capS)
+	if err != nil {
+		return errors.Wrap(err, "r1cs verify")
+	}
+	wL, wR, wO, wV, wc, err := v.flatten(curve, z, n)
+	if err != nil {
+		return errors.Wrap(err, "r1cs verify")
+	}
+	x, err := v.challengeX(curve, proof)
+	if err != nil {
+		return errors.Wrap(err, "r1cs verify")
+	}
+	w, err := v.challengeW(curve, proof)
+	if err != nil {
+		return errors.Wrap(err, "r1cs verify")
+	}
+	yInv, err := y.Invert()
+	if err != nil {
+		return errors.Wrap(err, "r1cs verify")
+	}
+	yPows := powers(curve, y, n)
+	yInvPows := powers(curve, yInv, n)
+	xs := powers(curve, x, 7)
+
+	// delta = <y^-n ⊙ w_R, w_L>
+	yInvwR, _ := multiplyPairwiseScalarVectors(yInvPows, wR)
+	delta, _ := innerProduct(yInvwR, wL)
+
+	// tHat·g + taux·h = x²·(<w_V, V> + (w_c + delta)·g) + Σ x^i·T_i
+	lhs := getcapV(proof.tHat, proof.taux, v.params.g, v.params.h)
+	rhs := curve.Point.SumOfProducts(
+		concatPoints(v.commitments, []curves.Point{v.params.g, proof.capT1, proof.capT3, proof.capT4, proof.capT5, proof.capT6}),
+		concatScalars(
+			multiplyScalarToScalarVector(xs[2], wV),
+			[]curves.Scalar{xs[2].Mul(wc.Add(delta)), xs[1], xs[3], xs[4], xs[5], xs[6]},
+		),
+	)
+	if !lhs.Equal(rhs) {
+		return errors.New("r1cs proof is invalid")
+	}
+
+	// P = x·A_I + x²·A_O + x³·S - mu·h + <x·y^-n ⊙ w_R, G> + <x·w_L + w_O - y^n, H'>
+	hPrime, err := gethPrime(proofH, y, curve)
+	if err != nil {
+		return errors.Wrap(err, "r1cs verify")
+	}
+	gScalars := multiplyScalarToScalarVector(x, yInvwR)
+	hScalars := make([]curves.Scalar, n)
+	for i := range hScalars {
+		hScalars[i] = wL[i].Mul(x).Add(wO[i]).Sub(yPows[i])
+	}
+	capP := curve.Point.SumOfProducts(
+		concatPoints([]curves.Point{proof.capAI, proof.capAO, proof.capS, v.params.h}, proofG, hPrime),
+		concatScalars([]curves.Scalar{xs[1], xs[2], xs[3], proof.mu.Neg()}, gScalars, hScalars),
+	)
+	ok, err := v.params.ippVerifier.VerifyFromRangeProof(proofG, hPrime, capP, v.params.u.Mul(w), proof.tHat, proof.ipp, v.transcript)
+	if err != nil {
+		return errors.Wrap(err, "r1cs verify")
+	}
+	if !ok {
+		return errors.New("r1cs proof is invalid")
+	}
+	return nil
+}
@@ -3,17 +3,17 @@ package bulletproof
 import (
 	crand "crypto/rand"
 
-	"github.com/gtank/merlin"
 	"github.com/pkg/errors"
 
 	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
 )
 
 // BatchProve proves that a list of scalars v are in the range n.
 // It implements the aggregating logarithmic proofs defined on pg21.
 // Instead of taking a single value and a single blinding factor, BatchProve takes in a list of values and list of
 // blinding factors.
-func (prover *RangeProver) BatchProve(v, gamma []curves.Scalar, n int, proofGenerators RangeProofGenerators, transcript *merlin.Transcript) (*RangeProof, error) {
+func (prover *RangeProver) BatchProve(v, gamma []curves.Scalar, n int, proofGenerators RangeProofGenerators, transcript *transcript.Transcript) (*RangeProof, error) {
 	// Define nm as the total bits required for secrets, calculated as number of secrets * n
 	m := len(v)
 	nm := n * m
@@ -193,8 +193,7 @@ func (prover *RangeProver) BatchProve(v, gamma []curves.Scalar, n int, proofGene
 	// P is redefined in batched case, see bottom equation on pg21.
 	capPhmu := getPhmuBatched(proofG, hPrime, proofGenerators.h, capA, capS, x, y, z, mu, n, m, prover.curve)
 
-	wBytes := transcript.ExtractBytes([]byte("getw"), 64)
-	w, err := prover.curve.NewScalar().SetBytesWide(wBytes)
+	w, err := transcript.ChallengeScalar([]byte("getw"), prover.curve.NewScalar())
 	if err != nil {
 		return nil, errors.Wrap(err, "rangeproof prove")
 	}
@@ -254,23 +253,21 @@ func getaLBatched(v []curves.Scalar, n int, curve curves.Curve) ([]curves.Scalar
 	return aL, nil
 }
 
-func calcyzBatched(capV []curves.Point, capA, capS curves.Point, transcript *merlin.Transcript, curve curves.Curve) (curves.Scalar, curves.Scalar, error) {
+func calcyzBatched(capV []curves.Point, capA, capS curves.Point, transcript *transcript.Transcript, curve curves.Curve) (curves.Scalar, curves.Scalar, error) {
 	// Add the A,S values to transcript
 	for _, capVi := range capV {
-		transcript.AppendMessage([]byte("addV"), capVi.ToAffineUncompressed())
+		transcript.AppendPoint([]byte("addV"), capVi)
 	}
-	transcript.AppendMessage([]byte("addcapA"), capA.ToAffineUncompressed())
-	transcript.AppendMessage([]byte("addcapS"), capS.ToAffineUncompressed())
+	transcript.AppendPoint([]byte("addcapA"), capA)
+	transcript.AppendPoint([]byte("addcapS"), capS)
 	// Read 64 bytes twice from, set to scalar for y and z
-	yBytes := transcript.ExtractBytes([]byte("gety"), 64)
-	y, err := curve.NewScalar().SetBytesWide(yBytes)
+	y, err := transcript.ChallengeScalar([]byte("gety"), curve.NewScalar())
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "calcyz NewScalar SetBytesWide")
+		return nil, nil, errors.Wrap(err, "calcyz ChallengeScalar")
 	}
-	zBytes := transcript.ExtractBytes([]byte("getz"), 64)
-	z, err := curve.NewScalar().SetBytesWide(zBytes)
+	z, err := transcript.ChallengeScalar([]byte("getz"), curve.NewScalar())
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "calcyz NewScalar SetBytesWide")
+		return nil, nil, errors.Wrap(err, "calcyz ChallengeScalar")
 	}
 
 	return y, z, nil
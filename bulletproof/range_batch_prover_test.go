@@ -4,10 +4,10 @@ import (
 	crand "crypto/rand"
 	"testing"
 
-	"github.com/gtank/merlin"
 	"github.com/stretchr/testify/require"
 
 	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
 )
 
 func TestRangeBatchProverHappyPath(t *testing.T) {
@@ -34,8 +34,8 @@ func TestRangeBatchProverHappyPath(t *testing.T) {
 		h: h,
 		u: u,
 	}
-	transcript := merlin.NewTranscript("test")
-	proof, err := prover.BatchProve(v, gamma, n, proofGenerators, transcript)
+	tr := transcript.New("test")
+	proof, err := prover.BatchProve(v, gamma, n, proofGenerators, tr)
 	require.NoError(t, err)
 	require.NotNil(t, proof)
 	require.Equal(t, 10, len(proof.ipp.capLs))
@@ -84,8 +84,8 @@ func TestRangeBatchProverMarshal(t *testing.T) {
 		h: h,
 		u: u,
 	}
-	transcript := merlin.NewTranscript("test")
-	proof, err := prover.BatchProve(v, gamma, n, proofGenerators, transcript)
+	tr := transcript.New("test")
+	proof, err := prover.BatchProve(v, gamma, n, proofGenerators, tr)
 	require.NoError(t, err)
 
 	proofMarshaled := proof.MarshalBinary()
@@ -1,16 +1,16 @@
 package bulletproof
 
 import (
-	"github.com/gtank/merlin"
 	"github.com/pkg/errors"
 
 	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
 )
 
 // VerifyBatched verifies a given batched range proof.
 // It takes in a list of commitments to the secret values as capV instead of a single commitment to a single point
 // when compared to the unbatched single range proof case.
-func (verifier *RangeVerifier) VerifyBatched(proof *RangeProof, capV []curves.Point, proofGenerators RangeProofGenerators, n int, transcript *merlin.Transcript) (bool, error) {
+func (verifier *RangeVerifier) VerifyBatched(proof *RangeProof, capV []curves.Point, proofGenerators RangeProofGenerators, n int, transcript *transcript.Transcript) (bool, error) {
 	// Define nm as the total bits required for secrets, calculated as number of secrets * n
 	m := len(capV)
 	nm := n * m
@@ -34,8 +34,7 @@ func (verifier *RangeVerifier) VerifyBatched(proof *RangeProof, capV []curves.Po
 		return false, errors.Wrap(err, "rangeproof verify")
 	}
 
-	wBytes := transcript.ExtractBytes([]byte("getw"), 64)
-	w, err := verifier.curve.NewScalar().SetBytesWide(wBytes)
+	w, err := transcript.ChallengeScalar([]byte("getw"), verifier.curve.NewScalar())
 	if err != nil {
 		return false, errors.Wrap(err, "rangeproof prove")
 	}
@@ -4,10 +4,10 @@ import (
 	crand "crypto/rand"
 	"testing"
 
-	"github.com/gtank/merlin"
 	"github.com/stretchr/testify/require"
 
 	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
 )
 
 func TestRangeBatchVerifyHappyPath(t *testing.T) {
@@ -33,13 +33,13 @@ func TestRangeBatchVerifyHappyPath(t *testing.T) {
 		h: h,
 		u: u,
 	}
-	transcript := merlin.NewTranscript("test")
-	proof, err := prover.BatchProve(v, gamma, n, proofGenerators, transcript)
+	tr := transcript.New("test")
+	proof, err := prover.BatchProve(v, gamma, n, proofGenerators, tr)
 	require.NoError(t, err)
 
 	verifier, err := NewRangeVerifier(n*4, []byte("rangeDomain"), []byte("ippDomain"), *curve)
 	require.NoError(t, err)
-	transcriptVerifier := merlin.NewTranscript("test")
+	transcriptVerifier := transcript.New("test")
 	capV := getcapVBatched(v, gamma, g, h)
 	verified, err := verifier.VerifyBatched(proof, capV, proofGenerators, n, transcriptVerifier)
 	require.NoError(t, err)
@@ -69,8 +69,8 @@ func TestRangeBatchVerifyNotInRange(t *testing.T) {
 		h: h,
 		u: u,
 	}
-	transcript := merlin.NewTranscript("test")
-	_, err = prover.BatchProve(v, gamma, n, proofGenerators, transcript)
+	tr := transcript.New("test")
+	_, err = prover.BatchProve(v, gamma, n, proofGenerators, tr)
 	require.Error(t, err)
 }
 
@@ -97,13 +97,13 @@ func TestRangeBatchVerifyNonRandom(t *testing.T) {
 		h: h,
 		u: u,
 	}
-	transcript := merlin.NewTranscript("test")
-	proof, err := prover.BatchProve(v, gamma, n, proofGenerators, transcript)
+	tr := transcript.New("test")
+	proof, err := prover.BatchProve(v, gamma, n, proofGenerators, tr)
 	require.NoError(t, err)
 
 	verifier, err := NewRangeVerifier(n*4, []byte("rangeDomain"), []byte("ippDomain"), *curve)
 	require.NoError(t, err)
-	transcriptVerifier := merlin.NewTranscript("test")
+	transcriptVerifier := transcript.New("test")
 	capV := getcapVBatched(v, gamma, g, h)
 	verified, err := verifier.VerifyBatched(proof, capV, proofGenerators, n, transcriptVerifier)
 	require.NoError(t, err)
@@ -133,13 +133,13 @@ func TestRangeBatchVerifyInvalid(t *testing.T) {
 		h: h,
 		u: u,
 	}
-	transcript := merlin.NewTranscript("test")
-	proof, err := prover.BatchProve(v, gamma, n, proofGenerators, transcript)
+	tr := transcript.New("test")
+	proof, err := prover.BatchProve(v, gamma, n, proofGenerators, tr)
 	require.NoError(t, err)
 
 	verifier, err := NewRangeVerifier(n*4, []byte("rangeDomain"), []byte("ippDomain"), *curve)
 	require.NoError(t, err)
-	transcriptVerifier := merlin.NewTranscript("test")
+	transcriptVerifier := transcript.New("test")
 	capV := getcapVBatched(v, gamma, g, h)
 	capV[0] = curve.Point.Random(crand.Reader)
 	verified, err := verifier.VerifyBatched(proof, capV, proofGenerators, n, transcriptVerifier)
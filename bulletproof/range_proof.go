@@ -0,0 +1,264 @@
+package bulletproof
+
+import (
+	"math/big"
+	"math/bits"
+	"runtime"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
+)
+
+// Range sizes supported by RangeParams. The size must be a power of two for the inner product argument.
+const (
+	MinRangeBits = 8
+	MaxRangeBits = 64
+)
+
+// RangeParams bundles a prover, a verifier and the commitment generators for range proofs of values in
+// [0, 2^n) committed as V = v·g + gamma·h. Proofs made with one set of params verify only with params created
+// from the same curve, domain and at least the same aggregation size.
+type RangeParams struct {
+	curve          *curves.Curve
+	prover         *RangeProver
+	verifier       *RangeVerifier
+	generators     RangeProofGenerators
+	maxAggregation int
+}
+
+// NewRangeProofGenerators returns the generators g, h for value commitments and u for the inner product argument.
+// Nobody may know the discrete logs between them.
+func NewRangeProofGenerators(g, h, u curves.Point) (*RangeProofGenerators, error) {
+	if g == nil || h == nil || u == nil {
+		return nil, errors.New("generators cannot be nil")
+	}
+	if g.CurveName() != h.CurveName() || g.CurveName() != u.CurveName() {
+		return nil, errors.New("generators are on different curves")
+	}
+	if g.IsIdentity() || h.IsIdentity() || u.IsIdentity() || g.Equal(h) || g.Equal(u) || h.Equal(u) {
+		return nil, errors.New("generators must be distinct and not the identity")
+	}
+	return &RangeProofGenerators{g: g, h: h, u: u}, nil
+}
+
+// NewRangeParams creates range proof parameters for up to maxAggregation values per proof, which must be a power
+// of two. g is the standard generator of the curve, h and u and the vector generators are hashed from domain.
+func NewRangeParams(curve *curves.Curve, maxAggregation int, domain []byte) (*RangeParams, error) {
+	if curve == nil {
+		return nil, errors.New("curve cannot be nil")
+	}
+	if maxAggregation < 1 || !isPowerOfTwo(maxAggregation) {
+		return nil, errors.New("maximum aggregation must be a positive power of two")
+	}
+	maxVectorLength := MaxRangeBits * maxAggregation
+	prover, err := NewRangeProver(maxVectorLength, append([]byte("range"), domain...), append([]byte("ipp"), domain...), *curve)
+	if err != nil {
+		return nil, errors.Wrap(err, "range NewRangeParams")
+	}
+	verifier, err := NewRangeVerifier(maxVectorLength, append([]byte("range"), domain...), append([]byte("ipp"), domain...), *curve)
+	if err != nil {
+		return nil, errors.Wrap(err, "range NewRangeParams")
+	}
+	generators, err := NewRangeProofGenerators(
+		curve.NewGeneratorPoint(),
+		curve.Point.Hash(append([]byte("range h"), domain...)),
+		curve.Point.Hash(append([]byte("range u"), domain...)),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "range NewRangeParams")
+	}
+	return &RangeParams{
+		curve:          curve,
+		prover:         prover,
+		verifier:       verifier,
+		generators:     *generators,
+		maxAggregation: maxAggregation,
+	}, nil
+}
+
+// Commit returns the commitment v·g + gamma·h.
+func (params *RangeParams) Commit(v uint64, gamma curves.Scalar) (curves.Point, error) {
+	if gamma == nil {
+		return nil, errors.New("gamma cannot be nil")
+	}
+	vs, err := params.scalar(v)
+	if err != nil {
+		return nil, err
+	}
+	return getcapV(vs, gamma, params.generators.g, params.generators.h), nil
+}
+
+// Prove proves that v is in [0, 2^n) and returns the proof with the commitment to v with blinding gamma.
+func (params *RangeParams) Prove(v uint64, gamma curves.Scalar, n int, transcript *transcript.Transcript) (*RangeProof, curves.Point, error) {
+	proof, capV, err := params.ProveAggregated([]uint64{v}, []curves.Scalar{gamma}, n, transcript)
+	if err != nil {
+		return nil, nil, err
+	}
+	return proof, capV[0], nil
+}
+
+// ProveAggregated proves that every value is in [0, 2^n) with one proof of size logarithmic in the number of
+// values, and returns the proof with the commitments. The number of values must be a power of two.
+func (params *RangeParams) ProveAggregated(v []uint64, gamma []curves.Scalar, n int, transcript *transcript.Transcript) (*RangeProof, []curves.Point, error) {
+	if len(v) != len(gamma) {
+		return nil, nil, errors.New("number of values and blindings differ")
+	}
+	if err := params.check(len(v), n); err != nil {
+		return nil, nil, err
+	}
+	if transcript == nil {
+		return nil, nil, errors.New("transcript cannot be nil")
+	}
+	if n < MaxRangeBits {
+		for _, vi := range v {
+			if vi>>uint(n) != 0 {
+				return nil, nil, errors.New("value is out of range")
+			}
+		}
+	}
+	vs := make([]curves.Scalar, len(v))
+	capV := make([]curves.Point, len(v))
+	for i := range v {
+		if gamma[i] == nil {
+			return nil, nil, errors.New("gamma cannot be nil")
+		}
+		var err error
+		if vs[i], err = params.scalar(v[i]); err != nil {
+			return nil, nil, err
+		}
+		capV[i] = getcapV(vs[i], gamma[i], params.generators.g, params.generators.h)
+	}
+	var proof *RangeProof
+	var err error
+	if len(v) == 1 {
+		proof, err = params.prover.Prove(vs[0], gamma[0], n, params.generators, transcript)
+	} else {
+		proof, err = params.prover.BatchProve(vs, gamma, n, params.generators, transcript)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return proof, capV, nil
+}
+
+// Verify checks that the commitment capV holds a value in [0, 2^n).
+func (params *RangeParams) Verify(proof *RangeProof, capV curves.Point, n int, transcript *transcript.Transcript) error {
+	return params.VerifyAggregated(proof, []curves.Point{capV}, n, transcript)
+}
+
+// VerifyAggregated checks that every commitment holds a value in [0, 2^n).
+func (params *RangeParams) VerifyAggregated(proof *RangeProof, capV []curves.Point, n int, transcript *transcript.Transcript) error {
+	if err := params.check(len(capV), n); err != nil {
+		return err
+	}
+	if transcript == nil {
+		return errors.New("transcript cannot be nil")
+	}
+	if err := params.checkProof(proof, len(capV), n); err != nil {
+		return err
+	}
+	for _, p := range capV {
+		if p == nil || p.CurveName() != params.curve.Name || !p.IsOnCurve() {
+			return errors.New("invalid commitment")
+		}
+	}
+	var ok bool
+	var err error
+	if len(capV) == 1 {
+		ok, err = params.verifier.Verify(proof, capV[0], params.generators, n, transcript)
+	} else {
+		ok, err = params.verifier.VerifyBatched(proof, capV, params.generators, n, transcript)
+	}
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("range proof is invalid")
+	}
+	return nil
+}
+
+// BatchVerify verifies several independent proofs, each with its commitments and transcript, on all CPUs.
+// It returns the error of the first invalid proof.
+func (params *RangeParams) BatchVerify(proofs []*RangeProof, capV [][]curves.Point, n int, transcripts []*transcript.Transcript) error {
+	if len(proofs) != len(capV) || len(proofs) != len(transcripts) {
+		return errors.New("number of proofs, commitments and transcripts differ")
+	}
+	errs := make([]error, len(proofs))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < runtime.NumCPU() && w < len(proofs); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				errs[i] = params.VerifyAggregated(proofs[i], capV[i], n, transcripts[i])
+			}
+		}()
+	}
+	for i := range proofs {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			return errors.Wrapf(err, "proof %d", i)
+		}
+	}
+	return nil
+}
+
+// UnmarshalRangeProof parses a proof made by MarshalBinary for count values of n bits, rejecting data of the wrong
+// length.
+func (params *RangeParams) UnmarshalRangeProof(data []byte, count, n int) (*RangeProof, error) {
+	if err := params.check(count, n); err != nil {
+		return nil, err
+	}
+	if len(data) != rangeProofSize(params.curve, count*n) {
+		return nil, errors.New("range proof has the wrong length")
+	}
+	proof := NewRangeProof(params.curve)
+	if err := proof.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+func (params *RangeParams) check(count, n int) error {
+	if n < MinRangeBits || n > MaxRangeBits || !isPowerOfTwo(n) {
+		return errors.Errorf("range must be a power of two between %d and %d bits", MinRangeBits, MaxRangeBits)
+	}
+	if count < 1 || !isPowerOfTwo(count) || count > params.maxAggregation {
+		return errors.Errorf("number of values must be a power of two of at most %d", params.maxAggregation)
+	}
+	return nil
+}
+
+func (params *RangeParams) checkProof(proof *RangeProof, count, n int) error {
+	if proof == nil || proof.ipp == nil ||
+		proof.capA == nil || proof.capS == nil || proof.capT1 == nil || proof.capT2 == nil ||
+		proof.taux == nil || proof.mu == nil || proof.tHat == nil || proof.ipp.a == nil || proof.ipp.b == nil {
+		return errors.New("range proof is incomplete")
+	}
+	if len(proof.ipp.capLs) != len(proof.ipp.capRs) || len(proof.ipp.capLs) != bits.TrailingZeros(uint(count*n)) {
+		return errors.New("range proof has the wrong number of rounds")
+	}
+	return nil
+}
+
+func (params *RangeParams) scalar(v uint64) (curves.Scalar, error) {
+	return params.curve.Scalar.SetBigInt(new(big.Int).SetUint64(v))
+}
+
+// rangeProofSize is the length of a marshaled proof: A, S, T1, T2, taux, mu, tHat, then a, b and one L, R pair
+// per round of the inner product argument.
+func rangeProofSize(curve *curves.Curve, vectorLength int) int {
+	scalarLen := len(curve.NewScalar().Bytes())
+	pointLen := len(curve.NewGeneratorPoint().ToAffineCompressed())
+	rounds := bits.TrailingZeros(uint(vectorLength))
+	return 4*pointLen + 5*scalarLen + 2*rounds*pointLen
+}
@@ -0,0 +1,151 @@
+package bulletproof
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
+)
+
+func TestRangeParamsProveVerify(t *testing.T) {
+	curve := curves.ED25519()
+	params, err := NewRangeParams(curve, 4, []byte("test"))
+	require.NoError(t, err)
+	for _, n := range []int{8, 16, 32, 64} {
+		for _, v := range []uint64{0, 1, 1<<uint(n) - 1} {
+			gamma := curve.Scalar.Random(crand.Reader)
+			proof, capV, err := params.Prove(v, gamma, n, transcript.New("test"))
+			require.NoError(t, err)
+			expected, err := params.Commit(v, gamma)
+			require.NoError(t, err)
+			require.True(t, expected.Equal(capV))
+			require.NoError(t, params.Verify(proof, capV, n, transcript.New("test")))
+			require.Error(t, params.Verify(proof, capV, n, transcript.New("other")))
+		}
+	}
+}
+
+func TestRangeParamsOutOfRange(t *testing.T) {
+	curve := curves.ED25519()
+	params, err := NewRangeParams(curve, 1, []byte("test"))
+	require.NoError(t, err)
+	gamma := curve.Scalar.Random(crand.Reader)
+	_, _, err = params.Prove(256, gamma, 8, transcript.New("test"))
+	require.Error(t, err)
+	_, _, err = params.Prove(1, gamma, 12, transcript.New("test"))
+	require.Error(t, err)
+	_, _, err = params.Prove(1, gamma, 128, transcript.New("test"))
+	require.Error(t, err)
+	_, _, err = params.ProveAggregated([]uint64{1, 2}, []curves.Scalar{gamma, gamma}, 8, transcript.New("test"))
+	require.Error(t, err)
+
+	// a proof for a 16 bit value does not show that it fits in 8 bits
+	proof, capV, err := params.Prove(1000, gamma, 16, transcript.New("test"))
+	require.NoError(t, err)
+	require.Error(t, params.Verify(proof, capV, 8, transcript.New("test")))
+}
+
+func TestRangeParamsWrongCommitment(t *testing.T) {
+	curve := curves.ED25519()
+	params, err := NewRangeParams(curve, 1, []byte("test"))
+	require.NoError(t, err)
+	proof, _, err := params.Prove(42, curve.Scalar.Random(crand.Reader), 64, transcript.New("test"))
+	require.NoError(t, err)
+	other, err := params.Commit(42, curve.Scalar.Random(crand.Reader))
+	require.NoError(t, err)
+	require.Error(t, params.Verify(proof, other, 64, transcript.New("test")))
+
+	// parameters from another domain
+	otherParams, err := NewRangeParams(curve, 1, []byte("other"))
+	require.NoError(t, err)
+	gamma := curve.Scalar.Random(crand.Reader)
+	proof, capV, err := params.Prove(42, gamma, 64, transcript.New("test"))
+	require.NoError(t, err)
+	require.Error(t, otherParams.Verify(proof, capV, 64, transcript.New("test")))
+}
+
+func TestRangeParamsAggregated(t *testing.T) {
+	curve := curves.ED25519()
+	params, err := NewRangeParams(curve, 4, []byte("test"))
+	require.NoError(t, err)
+	v := []uint64{1, 2, 3, 1<<32 - 1}
+	gamma := make([]curves.Scalar, len(v))
+	for i := range gamma {
+		gamma[i] = curve.Scalar.Random(crand.Reader)
+	}
+	proof, capV, err := params.ProveAggregated(v, gamma, 32, transcript.New("test"))
+	require.NoError(t, err)
+	require.Len(t, capV, 4)
+	require.NoError(t, params.VerifyAggregated(proof, capV, 32, transcript.New("test")))
+
+	capV[1], capV[2] = capV[2], capV[1]
+	require.Error(t, params.VerifyAggregated(proof, capV, 32, transcript.New("test")))
+	require.Error(t, params.VerifyAggregated(proof, capV[:2], 32, transcript.New("test")))
+
+	_, _, err = params.ProveAggregated(v[:3], gamma[:3], 32, transcript.New("test"))
+	require.Error(t, err)
+}
+
+func TestRangeParamsMarshal(t *testing.T) {
+	curve := curves.ED25519()
+	params, err := NewRangeParams(curve, 2, []byte("test"))
+	require.NoError(t, err)
+	gamma := []curves.Scalar{curve.Scalar.Random(crand.Reader), curve.Scalar.Random(crand.Reader)}
+	proof, capV, err := params.ProveAggregated([]uint64{7, 9}, gamma, 64, transcript.New("test"))
+	require.NoError(t, err)
+
+	data := proof.MarshalBinary()
+	parsed, err := params.UnmarshalRangeProof(data, 2, 64)
+	require.NoError(t, err)
+	require.NoError(t, params.VerifyAggregated(parsed, capV, 64, transcript.New("test")))
+
+	_, err = params.UnmarshalRangeProof(data[:len(data)-1], 2, 64)
+	require.Error(t, err)
+	_, err = params.UnmarshalRangeProof(data, 1, 64)
+	require.Error(t, err)
+	_, err = params.UnmarshalRangeProof(nil, 2, 64)
+	require.Error(t, err)
+}
+
+func TestRangeParamsBatchVerify(t *testing.T) {
+	curve := curves.ED25519()
+	params, err := NewRangeParams(curve, 2, []byte("test"))
+	require.NoError(t, err)
+	var proofs []*RangeProof
+	var commitments [][]curves.Point
+	var transcripts []*transcript.Transcript
+	for i := 0; i < 3; i++ {
+		gamma := []curves.Scalar{curve.Scalar.Random(crand.Reader), curve.Scalar.Random(crand.Reader)}
+		proof, capV, err := params.ProveAggregated([]uint64{uint64(i), uint64(i + 1)}, gamma, 16, transcript.New("test"))
+		require.NoError(t, err)
+		proofs = append(proofs, proof)
+		commitments = append(commitments, capV)
+		transcripts = append(transcripts, transcript.New("test"))
+	}
+	require.NoError(t, params.BatchVerify(proofs, commitments, 16, transcripts))
+
+	transcripts = []*transcript.Transcript{transcript.New("test"), transcript.New("test"), transcript.New("test")}
+	commitments[2], commitments[1] = commitments[1], commitments[2]
+	require.Error(t, params.BatchVerify(proofs, commitments, 16, transcripts))
+	require.Error(t, params.BatchVerify(proofs, commitments[:2], 16, transcripts))
+}
+
+func TestNewRangeProofGenerators(t *testing.T) {
+	curve := curves.ED25519()
+	g := curve.Point.Random(crand.Reader)
+	h := curve.Point.Random(crand.Reader)
+	u := curve.Point.Random(crand.Reader)
+	_, err := NewRangeProofGenerators(g, h, u)
+	require.NoError(t, err)
+	_, err = NewRangeProofGenerators(g, g, u)
+	require.Error(t, err)
+	_, err = NewRangeProofGenerators(g, h, curve.NewIdentityPoint())
+	require.Error(t, err)
+	_, err = NewRangeProofGenerators(g, h, curves.K256().NewGeneratorPoint())
+	require.Error(t, err)
+	_, err = NewRangeParams(curve, 3, nil)
+	require.Error(t, err)
+}
@@ -11,10 +11,10 @@ import (
 	crand "crypto/rand"
 	"math/big"
 
-	"github.com/gtank/merlin"
 	"github.com/pkg/errors"
 
 	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
 )
 
 // RangeProver is the struct used to create RangeProofs
@@ -85,7 +85,7 @@ func NewRangeProof(curve *curves.Curve) *RangeProof {
 // gamma is a scalar used for as a blinding factor
 // g, h, u are unique points used as generators for the blinding factor
 // transcript is a merlin transcript to be used for the fiat shamir heuristic.
-func (prover *RangeProver) Prove(v, gamma curves.Scalar, n int, proofGenerators RangeProofGenerators, transcript *merlin.Transcript) (*RangeProof, error) {
+func (prover *RangeProver) Prove(v, gamma curves.Scalar, n int, proofGenerators RangeProofGenerators, transcript *transcript.Transcript) (*RangeProof, error) {
 	// n must be less than or equal to the number of generators generated
 	if n > len(prover.generators.G) {
 		return nil, errors.New("ipp vector length must be less than or equal to maxVectorLength")
@@ -259,8 +259,7 @@ func (prover *RangeProver) Prove(v, gamma curves.Scalar, n int, proofGenerators
 		return nil, errors.Wrap(err, "rangeproof prove")
 	}
 
-	wBytes := transcript.ExtractBytes([]byte("getw"), 64)
-	w, err := prover.curve.NewScalar().SetBytesWide(wBytes)
+	w, err := transcript.ChallengeScalar([]byte("getw"), prover.curve.NewScalar())
 	if err != nil {
 		return nil, errors.Wrap(err, "rangeproof prove")
 	}
@@ -437,21 +436,19 @@ func cmoveScalar(x, y curves.Scalar, which int, curve curves.Curve) (curves.Scal
 // It takes the current state of the transcript and appends the newly calculated capA and capS values
 // Two new scalars are then read from the transcript
 // See section 4.4 pg22 of https://eprint.iacr.org/2017/1066.pdf
-func calcyz(capV, capA, capS curves.Point, transcript *merlin.Transcript, curve curves.Curve) (curves.Scalar, curves.Scalar, error) {
+func calcyz(capV, capA, capS curves.Point, transcript *transcript.Transcript, curve curves.Curve) (curves.Scalar, curves.Scalar, error) {
 	// Add the A,S values to transcript
-	transcript.AppendMessage([]byte("addV"), capV.ToAffineUncompressed())
-	transcript.AppendMessage([]byte("addcapA"), capA.ToAffineUncompressed())
-	transcript.AppendMessage([]byte("addcapS"), capS.ToAffineUncompressed())
+	transcript.AppendPoint([]byte("addV"), capV)
+	transcript.AppendPoint([]byte("addcapA"), capA)
+	transcript.AppendPoint([]byte("addcapS"), capS)
 	// Read 64 bytes twice from, set to scalar for y and z
-	yBytes := transcript.ExtractBytes([]byte("gety"), 64)
-	y, err := curve.NewScalar().SetBytesWide(yBytes)
+	y, err := transcript.ChallengeScalar([]byte("gety"), curve.NewScalar())
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "calcyz NewScalar SetBytesWide")
+		return nil, nil, errors.Wrap(err, "calcyz ChallengeScalar")
 	}
-	zBytes := transcript.ExtractBytes([]byte("getz"), 64)
-	z, err := curve.NewScalar().SetBytesWide(zBytes)
+	z, err := transcript.ChallengeScalar([]byte("getz"), curve.NewScalar())
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "calcyz NewScalar SetBytesWide")
+		return nil, nil, errors.Wrap(err, "calcyz ChallengeScalar")
 	}
 
 	return y, z, nil
@@ -461,15 +458,14 @@ func calcyz(capV, capA, capS curves.Point, transcript *merlin.Transcript, curve
 // It takes the current state of the transcript and appends the newly calculated capT1 and capT2 values
 // A new scalar is then read from the transcript
 // See section 4.4 pg22 of https://eprint.iacr.org/2017/1066.pdf
-func calcx(capT1, capT2 curves.Point, transcript *merlin.Transcript, curve curves.Curve) (curves.Scalar, error) {
+func calcx(capT1, capT2 curves.Point, transcript *transcript.Transcript, curve curves.Curve) (curves.Scalar, error) {
 	// Add the Tau1,2 values to transcript
-	transcript.AppendMessage([]byte("addcapT1"), capT1.ToAffineUncompressed())
-	transcript.AppendMessage([]byte("addcapT2"), capT2.ToAffineUncompressed())
+	transcript.AppendPoint([]byte("addcapT1"), capT1)
+	transcript.AppendPoint([]byte("addcapT2"), capT2)
 	// Read 64 bytes from, set to scalar
-	outBytes := transcript.ExtractBytes([]byte("getx"), 64)
-	x, err := curve.NewScalar().SetBytesWide(outBytes)
+	x, err := transcript.ChallengeScalar([]byte("getx"), curve.NewScalar())
 	if err != nil {
-		return nil, errors.Wrap(err, "calcx NewScalar SetBytesWide")
+		return nil, errors.Wrap(err, "calcx ChallengeScalar")
 	}
 
 	return x, nil
@@ -4,10 +4,10 @@ import (
 	crand "crypto/rand"
 	"testing"
 
-	"github.com/gtank/merlin"
 	"github.com/stretchr/testify/require"
 
 	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
 )
 
 func TestRangeProverHappyPath(t *testing.T) {
@@ -25,8 +25,8 @@ func TestRangeProverHappyPath(t *testing.T) {
 		h: h,
 		u: u,
 	}
-	transcript := merlin.NewTranscript("test")
-	proof, err := prover.Prove(v, gamma, n, proofGenerators, transcript)
+	tr := transcript.New("test")
+	proof, err := prover.Prove(v, gamma, n, proofGenerators, tr)
 	require.NoError(t, err)
 	require.NotNil(t, proof)
 	require.Equal(t, 8, len(proof.ipp.capLs))
@@ -68,8 +68,8 @@ func TestRangeProverMarshal(t *testing.T) {
 		h: h,
 		u: u,
 	}
-	transcript := merlin.NewTranscript("test")
-	proof, err := prover.Prove(v, gamma, n, proofGenerators, transcript)
+	tr := transcript.New("test")
+	proof, err := prover.Prove(v, gamma, n, proofGenerators, tr)
 	require.NoError(t, err)
 
 	proofMarshaled := proof.MarshalBinary()
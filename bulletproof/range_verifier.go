@@ -1,10 +1,10 @@
 package bulletproof
 
 import (
-	"github.com/gtank/merlin"
 	"github.com/pkg/errors"
 
 	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
 )
 
 // RangeVerifier is the struct used to verify RangeProofs
@@ -39,7 +39,7 @@ func NewRangeVerifier(maxVectorLength int, rangeDomain, ippDomain []byte, curve
 // n is the power that specifies the upper bound of the range, ie. 2^n
 // g, h, u are unique points used as generators for the blinding factor
 // transcript is a merlin transcript to be used for the fiat shamir heuristic.
-func (verifier *RangeVerifier) Verify(proof *RangeProof, capV curves.Point, proofGenerators RangeProofGenerators, n int, transcript *merlin.Transcript) (bool, error) {
+func (verifier *RangeVerifier) Verify(proof *RangeProof, capV curves.Point, proofGenerators RangeProofGenerators, n int, transcript *transcript.Transcript) (bool, error) {
 	// Length of vectors must be less than the number of generators generated
 	if n > len(verifier.generators.G) {
 		return false, errors.New("ipp vector length must be less than maxVectorLength")
@@ -59,8 +59,7 @@ func (verifier *RangeVerifier) Verify(proof *RangeProof, capV curves.Point, proo
 		return false, errors.Wrap(err, "rangeproof verify")
 	}
 
-	wBytes := transcript.ExtractBytes([]byte("getw"), 64)
-	w, err := verifier.curve.NewScalar().SetBytesWide(wBytes)
+	w, err := transcript.ChallengeScalar([]byte("getw"), verifier.curve.NewScalar())
 	if err != nil {
 		return false, errors.Wrap(err, "rangeproof prove")
 	}
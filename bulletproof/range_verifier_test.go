@@ -4,10 +4,10 @@ import (
 	crand "crypto/rand"
 	"testing"
 
-	"github.com/gtank/merlin"
 	"github.com/stretchr/testify/require"
 
 	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
 )
 
 func TestRangeVerifyHappyPath(t *testing.T) {
@@ -25,13 +25,13 @@ func TestRangeVerifyHappyPath(t *testing.T) {
 		h: h,
 		u: u,
 	}
-	transcript := merlin.NewTranscript("test")
-	proof, err := prover.Prove(v, gamma, n, proofGenerators, transcript)
+	tr := transcript.New("test")
+	proof, err := prover.Prove(v, gamma, n, proofGenerators, tr)
 	require.NoError(t, err)
 
 	verifier, err := NewRangeVerifier(n, []byte("rangeDomain"), []byte("ippDomain"), *curve)
 	require.NoError(t, err)
-	transcriptVerifier := merlin.NewTranscript("test")
+	transcriptVerifier := transcript.New("test")
 	capV := getcapV(v, gamma, g, h)
 	verified, err := verifier.Verify(proof, capV, proofGenerators, n, transcriptVerifier)
 	require.NoError(t, err)
@@ -53,8 +53,8 @@ func TestRangeVerifyNotInRange(t *testing.T) {
 		h: h,
 		u: u,
 	}
-	transcript := merlin.NewTranscript("test")
-	_, err = prover.Prove(v, gamma, n, proofGenerators, transcript)
+	tr := transcript.New("test")
+	_, err = prover.Prove(v, gamma, n, proofGenerators, tr)
 	require.Error(t, err)
 }
 
@@ -73,13 +73,13 @@ func TestRangeVerifyNonRandom(t *testing.T) {
 		h: h,
 		u: u,
 	}
-	transcript := merlin.NewTranscript("test")
-	proof, err := prover.Prove(v, gamma, n, proofGenerators, transcript)
+	tr := transcript.New("test")
+	proof, err := prover.Prove(v, gamma, n, proofGenerators, tr)
 	require.NoError(t, err)
 
 	verifier, err := NewRangeVerifier(n, []byte("rangeDomain"), []byte("ippDomain"), *curve)
 	require.NoError(t, err)
-	transcriptVerifier := merlin.NewTranscript("test")
+	transcriptVerifier := transcript.New("test")
 	capV := getcapV(v, gamma, g, h)
 	verified, err := verifier.Verify(proof, capV, proofGenerators, n, transcriptVerifier)
 	require.NoError(t, err)
@@ -0,0 +1,318 @@
+// Package commitments implements Pedersen commitments over any curve in core/curves.
+//
+// A commitment to v with blinding r is C = vG + rH, where nobody knows the discrete log of H with respect to G.
+// Commitments are perfectly hiding and computationally binding, and additively homomorphic: the sum of two
+// commitments is a commitment to the sum of the values with the sum of the blindings.
+package commitments
+
+import (
+	"crypto/rand"
+	"fmt"
+	"strconv"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/internal"
+)
+
+// Params are the generators G and H of Pedersen commitments.
+type Params struct {
+	G, H curves.Point
+}
+
+// Commitment is a Pedersen commitment C = vG + rH.
+type Commitment struct {
+	Point curves.Point
+}
+
+// NewParams returns parameters with the standard generator of the curve as G and H hashed to the curve from domain,
+// so that the discrete log of H is unknown. Different domains give independent parameters.
+func NewParams(curve *curves.Curve, domain []byte) (*Params, error) {
+	if curve == nil {
+		return nil, internal.ErrNilArguments
+	}
+	return NewParamsWithGenerators(curve.NewGeneratorPoint(), curve.Point.Hash(append([]byte("pedersen H"), domain...)))
+}
+
+// NewParamsWithGenerators returns parameters with the given generators. The caller is responsible for nobody knowing
+// the discrete log of h with respect to g.
+func NewParamsWithGenerators(g, h curves.Point) (*Params, error) {
+	if g == nil || h == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if g.CurveName() != h.CurveName() {
+		return nil, fmt.Errorf("generators are on different curves")
+	}
+	if g.IsIdentity() || h.IsIdentity() || !g.IsOnCurve() || !h.IsOnCurve() {
+		return nil, fmt.Errorf("invalid generator")
+	}
+	if g.Equal(h) {
+		return nil, fmt.Errorf("generators must be distinct")
+	}
+	return &Params{G: g, H: h}, nil
+}
+
+// Commit commits to value with a random blinding and returns the commitment and the blinding.
+func (p *Params) Commit(value curves.Scalar) (*Commitment, curves.Scalar, error) {
+	curve, err := p.curve()
+	if err != nil {
+		return nil, nil, err
+	}
+	blinding := curve.Scalar.Random(rand.Reader)
+	c, err := p.CommitWithBlinding(value, blinding)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c, blinding, nil
+}
+
+// CommitWithBlinding returns the commitment value·G + blinding·H.
+func (p *Params) CommitWithBlinding(value, blinding curves.Scalar) (*Commitment, error) {
+	curve, err := p.curve()
+	if err != nil {
+		return nil, err
+	}
+	if err = checkScalars(curve, value, blinding); err != nil {
+		return nil, err
+	}
+	return &Commitment{Point: p.G.Mul(value).Add(p.H.Mul(blinding))}, nil
+}
+
+// Open checks that c is a commitment to value with blinding.
+func (p *Params) Open(c *Commitment, value, blinding curves.Scalar) error {
+	if err := c.validate(p); err != nil {
+		return err
+	}
+	expected, err := p.CommitWithBlinding(value, blinding)
+	if err != nil {
+		return err
+	}
+	if !expected.Point.Equal(c.Point) {
+		return fmt.Errorf("commitment does not open to the value")
+	}
+	return nil
+}
+
+// Add returns the commitment to the sum of the values and blindings of c and d.
+func (c *Commitment) Add(d *Commitment) (*Commitment, error) {
+	if err := c.sameCurve(d); err != nil {
+		return nil, err
+	}
+	return &Commitment{Point: c.Point.Add(d.Point)}, nil
+}
+
+// Sub returns the commitment to the difference of the values and blindings of c and d.
+func (c *Commitment) Sub(d *Commitment) (*Commitment, error) {
+	if err := c.sameCurve(d); err != nil {
+		return nil, err
+	}
+	return &Commitment{Point: c.Point.Sub(d.Point)}, nil
+}
+
+// ScalarMul returns the commitment to k times the value with k times the blinding of c.
+func (c *Commitment) ScalarMul(k curves.Scalar) (*Commitment, error) {
+	if c == nil || c.Point == nil || k == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if k.Point().CurveName() != c.Point.CurveName() {
+		return nil, fmt.Errorf("scalar is not on curve %s", c.Point.CurveName())
+	}
+	return &Commitment{Point: c.Point.Mul(k)}, nil
+}
+
+// AddValue returns the commitment to the value of c plus value, with the same blinding.
+func (p *Params) AddValue(c *Commitment, value curves.Scalar) (*Commitment, error) {
+	if err := c.validate(p); err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if value.Point().CurveName() != c.Point.CurveName() {
+		return nil, fmt.Errorf("scalar is not on curve %s", c.Point.CurveName())
+	}
+	return &Commitment{Point: c.Point.Add(p.G.Mul(value))}, nil
+}
+
+// Equal returns true if both commitments are the same point.
+func (c *Commitment) Equal(d *Commitment) bool {
+	if c == nil || d == nil || c.Point == nil || d.Point == nil {
+		return false
+	}
+	return c.Point.Equal(d.Point)
+}
+
+// SumBlindings returns the sum of the blindings, which is the blinding of the sum of their commitments.
+func SumBlindings(blindings ...curves.Scalar) (curves.Scalar, error) {
+	if len(blindings) == 0 {
+		return nil, fmt.Errorf("no blindings")
+	}
+	curve, err := scalarCurve(blindings[0])
+	if err != nil {
+		return nil, err
+	}
+	if err = checkScalars(curve, blindings...); err != nil {
+		return nil, err
+	}
+	sum := curve.Scalar.Zero()
+	for _, r := range blindings {
+		sum = sum.Add(r)
+	}
+	return sum, nil
+}
+
+// BalancingBlinding returns the blinding r for one more output such that the sum of the inputs minus the sum of the
+// outputs commits to zero blinding, i.e. Σ inputs - Σ outputs - r = 0. Committing the last output with r makes the
+// commitments of balanced values balance as well.
+func BalancingBlinding(inputs, outputs []curves.Scalar) (curves.Scalar, error) {
+	in, err := SumBlindings(inputs...)
+	if err != nil {
+		return nil, err
+	}
+	if len(outputs) == 0 {
+		return in, nil
+	}
+	out, err := SumBlindings(outputs...)
+	if err != nil {
+		return nil, err
+	}
+	if out.Point().CurveName() != in.Point().CurveName() {
+		return nil, fmt.Errorf("blindings are on different curves")
+	}
+	return in.Sub(out), nil
+}
+
+// VectorParams are the generators G_0, ..., G_{n-1} and H of vector Pedersen commitments.
+type VectorParams struct {
+	G []curves.Point
+	H curves.Point
+}
+
+// NewVectorParams returns parameters for vectors of n values, with all generators hashed to the curve from domain.
+func NewVectorParams(curve *curves.Curve, n int, domain []byte) (*VectorParams, error) {
+	if curve == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if n < 1 {
+		return nil, fmt.Errorf("vector length must be positive")
+	}
+	params := &VectorParams{
+		G: make([]curves.Point, n),
+		H: curve.Point.Hash(append([]byte("pedersen H"), domain...)),
+	}
+	for i := range params.G {
+		params.G[i] = curve.Point.Hash(append([]byte("pedersen G"+strconv.Itoa(i)), domain...))
+	}
+	return params, nil
+}
+
+// Commit commits to values, which may be fewer than the generators, with a random blinding.
+func (p *VectorParams) Commit(values []curves.Scalar) (*Commitment, curves.Scalar, error) {
+	if p == nil || p.H == nil {
+		return nil, nil, internal.ErrNilArguments
+	}
+	curve := curves.GetCurveByName(p.H.CurveName())
+	if curve == nil {
+		return nil, nil, fmt.Errorf("unsupported curve %s", p.H.CurveName())
+	}
+	blinding := curve.Scalar.Random(rand.Reader)
+	c, err := p.CommitWithBlinding(values, blinding)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c, blinding, nil
+}
+
+// CommitWithBlinding returns the commitment Σ values[i]·G_i + blinding·H.
+func (p *VectorParams) CommitWithBlinding(values []curves.Scalar, blinding curves.Scalar) (*Commitment, error) {
+	if p == nil || p.H == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if len(values) > len(p.G) {
+		return nil, fmt.Errorf("%d values exceed the %d generators", len(values), len(p.G))
+	}
+	curve := curves.GetCurveByName(p.H.CurveName())
+	if curve == nil {
+		return nil, fmt.Errorf("unsupported curve %s", p.H.CurveName())
+	}
+	if err := checkScalars(curve, append([]curves.Scalar{blinding}, values...)...); err != nil {
+		return nil, err
+	}
+	point := p.H.Mul(blinding)
+	if len(values) > 0 {
+		point = point.Add(curve.Point.SumOfProducts(p.G[:len(values)], values))
+	}
+	return &Commitment{Point: point}, nil
+}
+
+// Open checks that c is a commitment to values with blinding.
+func (p *VectorParams) Open(c *Commitment, values []curves.Scalar, blinding curves.Scalar) error {
+	if c == nil || c.Point == nil {
+		return internal.ErrNilArguments
+	}
+	expected, err := p.CommitWithBlinding(values, blinding)
+	if err != nil {
+		return err
+	}
+	if !expected.Point.Equal(c.Point) {
+		return fmt.Errorf("commitment does not open to the values")
+	}
+	return nil
+}
+
+func (p *Params) curve() (*curves.Curve, error) {
+	if p == nil || p.G == nil || p.H == nil {
+		return nil, internal.ErrNilArguments
+	}
+	curve := curves.GetCurveByName(p.G.CurveName())
+	if curve == nil {
+		return nil, fmt.Errorf("unsupported curve %s", p.G.CurveName())
+	}
+	return curve, nil
+}
+
+func (c *Commitment) validate(p *Params) error {
+	curve, err := p.curve()
+	if err != nil {
+		return err
+	}
+	if c == nil || c.Point == nil {
+		return internal.ErrNilArguments
+	}
+	if c.Point.CurveName() != curve.Name || !c.Point.IsOnCurve() {
+		return fmt.Errorf("commitment is not on curve %s", curve.Name)
+	}
+	return nil
+}
+
+func (c *Commitment) sameCurve(d *Commitment) error {
+	if c == nil || d == nil || c.Point == nil || d.Point == nil {
+		return internal.ErrNilArguments
+	}
+	if c.Point.CurveName() != d.Point.CurveName() {
+		return fmt.Errorf("commitments are on different curves")
+	}
+	return nil
+}
+
+func scalarCurve(s curves.Scalar) (*curves.Curve, error) {
+	if s == nil {
+		return nil, internal.ErrNilArguments
+	}
+	curve := curves.GetCurveByName(s.Point().CurveName())
+	if curve == nil {
+		return nil, fmt.Errorf("unsupported curve %s", s.Point().CurveName())
+	}
+	return curve, nil
+}
+
+func checkScalars(curve *curves.Curve, scalars ...curves.Scalar) error {
+	for _, s := range scalars {
+		if s == nil {
+			return internal.ErrNilArguments
+		}
+		if s.Point().CurveName() != curve.Name {
+			return fmt.Errorf("scalar is not on curve %s", curve.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package commitments
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/internal"
+)
+
+var testCurves = []*curves.Curve{
+	curves.K256(),
+	curves.P256(),
+	curves.ED25519(),
+	curves.BLS12381G1(),
+	curves.PALLAS(),
+}
+
+func TestCommitOpen(t *testing.T) {
+	for _, curve := range testCurves {
+		params, err := NewParams(curve, []byte("test"))
+		require.NoError(t, err)
+		v := curve.Scalar.Random(rand.Reader)
+		c, r, err := params.Commit(v)
+		require.NoError(t, err)
+		require.NoError(t, params.Open(c, v, r), curve.Name)
+		require.Error(t, params.Open(c, v.Add(curve.Scalar.One()), r))
+		require.Error(t, params.Open(c, v, r.Add(curve.Scalar.One())))
+
+		// hiding: the same value commits to different points
+		d, _, err := params.Commit(v)
+		require.NoError(t, err)
+		require.False(t, c.Equal(d))
+
+		other, err := NewParams(curve, []byte("other"))
+		require.NoError(t, err)
+		require.False(t, params.H.Equal(other.H))
+		require.Error(t, other.Open(c, v, r))
+	}
+}
+
+func TestParamsErrors(t *testing.T) {
+	curve := curves.K256()
+	g := curve.NewGeneratorPoint()
+	_, err := NewParamsWithGenerators(g, g)
+	require.Error(t, err)
+	_, err = NewParamsWithGenerators(g, curve.NewIdentityPoint())
+	require.Error(t, err)
+	_, err = NewParamsWithGenerators(g, curves.P256().NewGeneratorPoint())
+	require.Error(t, err)
+	_, err = NewParamsWithGenerators(nil, g)
+	require.ErrorIs(t, err, internal.ErrNilArguments)
+
+	params, err := NewParams(curve, nil)
+	require.NoError(t, err)
+	_, err = params.CommitWithBlinding(curves.P256().Scalar.One(), curve.Scalar.One())
+	require.Error(t, err)
+}
+
+func TestHomomorphic(t *testing.T) {
+	for _, curve := range testCurves {
+		params, err := NewParams(curve, []byte("test"))
+		require.NoError(t, err)
+		a, b := curve.Scalar.New(20), curve.Scalar.New(22)
+		ca, ra, err := params.Commit(a)
+		require.NoError(t, err)
+		cb, rb, err := params.Commit(b)
+		require.NoError(t, err)
+
+		sum, err := ca.Add(cb)
+		require.NoError(t, err)
+		rs, err := SumBlindings(ra, rb)
+		require.NoError(t, err)
+		require.NoError(t, params.Open(sum, curve.Scalar.New(42), rs))
+
+		diff, err := cb.Sub(ca)
+		require.NoError(t, err)
+		require.NoError(t, params.Open(diff, curve.Scalar.New(2), rb.Sub(ra)))
+
+		k := curve.Scalar.New(3)
+		prod, err := sum.ScalarMul(k)
+		require.NoError(t, err)
+		require.NoError(t, params.Open(prod, curve.Scalar.New(126), rs.Mul(k)))
+
+		plus, err := params.AddValue(ca, curve.Scalar.New(5))
+		require.NoError(t, err)
+		require.NoError(t, params.Open(plus, curve.Scalar.New(25), ra))
+	}
+}
+
+func TestBalancingBlinding(t *testing.T) {
+	curve := curves.K256()
+	params, err := NewParams(curve, []byte("test"))
+	require.NoError(t, err)
+
+	// inputs 30 + 12 = outputs 40 + 2
+	in1, rIn1, err := params.Commit(curve.Scalar.New(30))
+	require.NoError(t, err)
+	in2, rIn2, err := params.Commit(curve.Scalar.New(12))
+	require.NoError(t, err)
+	out1, rOut1, err := params.Commit(curve.Scalar.New(40))
+	require.NoError(t, err)
+	r, err := BalancingBlinding([]curves.Scalar{rIn1, rIn2}, []curves.Scalar{rOut1})
+	require.NoError(t, err)
+	out2, err := params.CommitWithBlinding(curve.Scalar.New(2), r)
+	require.NoError(t, err)
+
+	in, err := in1.Add(in2)
+	require.NoError(t, err)
+	out, err := out1.Add(out2)
+	require.NoError(t, err)
+	require.True(t, in.Equal(out))
+
+	_, err = SumBlindings()
+	require.Error(t, err)
+	_, err = BalancingBlinding([]curves.Scalar{rIn1}, []curves.Scalar{curves.P256().Scalar.One()})
+	require.Error(t, err)
+}
+
+func TestVectorCommitment(t *testing.T) {
+	for _, curve := range testCurves {
+		params, err := NewVectorParams(curve, 4, []byte("test"))
+		require.NoError(t, err)
+		values := []curves.Scalar{
+			curve.Scalar.Random(rand.Reader),
+			curve.Scalar.Random(rand.Reader),
+			curve.Scalar.Random(rand.Reader),
+		}
+		c, r, err := params.Commit(values)
+		require.NoError(t, err)
+		require.NoError(t, params.Open(c, values, r))
+
+		swapped := []curves.Scalar{values[1], values[0], values[2]}
+		require.Error(t, params.Open(c, swapped, r))
+
+		// vector commitments are homomorphic element-wise
+		d, s, err := params.Commit(values)
+		require.NoError(t, err)
+		sum, err := c.Add(d)
+		require.NoError(t, err)
+		doubled := []curves.Scalar{values[0].Double(), values[1].Double(), values[2].Double()}
+		require.NoError(t, params.Open(sum, doubled, r.Add(s)))
+
+		_, _, err = params.Commit(make([]curves.Scalar, 5))
+		require.Error(t, err)
+	}
+	_, err := NewVectorParams(curves.K256(), 0, nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,50 @@
+package curves
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBaseMultTable(t *testing.T) {
+	names := []string{
+		K256Name, P256Name, ED25519Name, Ristretto255Name, Decaf448Name, PallasName, VestaName,
+		BLS12381G1Name, BLS12381G2Name, BLS12377G1Name, BLS12377G2Name,
+	}
+	for _, name := range names {
+		curve := GetCurveByName(name)
+		t.Run(name, func(t *testing.T) {
+			table := curve.BaseMultTable()
+			require.Same(t, table, curve.BaseMultTable())
+			require.True(t, table.Mul(curve.Scalar.Zero()).IsIdentity())
+			require.True(t, table.Mul(curve.Scalar.One()).Equal(curve.NewGeneratorPoint()))
+			require.True(t, table.Mul(curve.Scalar.One().Neg()).Equal(curve.NewGeneratorPoint().Neg()))
+			for i := 0; i < 10; i++ {
+				s := curve.Scalar.Random(crand.Reader)
+				require.True(t, table.Mul(s).Equal(curve.ScalarBaseMult(s)))
+			}
+
+			p := curve.Point.Random(crand.Reader)
+			s := curve.Scalar.Random(crand.Reader)
+			require.True(t, NewBaseMultTable(p).Mul(s).Equal(p.Mul(s)))
+		})
+	}
+}
+
+func BenchmarkBaseMultTable(b *testing.B) {
+	for _, curve := range []*Curve{K256(), P256(), PALLAS(), BLS12381G1()} {
+		s := curve.Scalar.Random(crand.Reader)
+		table := curve.BaseMultTable()
+		b.Run(curve.Name+"/ScalarBaseMult", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				curve.ScalarBaseMult(s)
+			}
+		})
+		b.Run(curve.Name+"/BaseMultTable", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				table.Mul(s)
+			}
+		})
+	}
+}
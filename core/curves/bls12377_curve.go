@@ -244,6 +244,11 @@ func (s *ScalarBls12377) Bytes() []byte {
 	return s.value.FillBytes(out[:])
 }
 
+// Zeroize overwrites the scalar with zero
+func (s *ScalarBls12377) Zeroize() {
+	zeroizeBigInt(s.value)
+}
+
 func (s *ScalarBls12377) SetBytes(bytes []byte) (Scalar, error) {
 	value := new(big.Int).SetBytes(bytes)
 	t := new(big.Int).Mod(value, bls12377modulus)
@@ -240,6 +240,13 @@ func (s *ScalarBls12381) Bytes() []byte {
 	return internal.ReverseScalarBytes(t[:])
 }
 
+// Zeroize overwrites the scalar with zero
+func (s *ScalarBls12381) Zeroize() {
+	if s.Value != nil {
+		s.Value.SetZero()
+	}
+}
+
 func (s *ScalarBls12381) SetBytes(bytes []byte) (Scalar, error) {
 	if len(bytes) != 32 {
 		return nil, fmt.Errorf("invalid length")
@@ -862,6 +869,32 @@ func multiPairing(points ...PairingPoint) Scalar {
 	return &ScalarBls12381Gt{value}
 }
 
+// Bls12381BatchMultiPairingCheck reports whether every multi-pairing in
+// batches is one. Each batch lists G1, G2 pairs in the order taken by
+// MultiPairing. The batches are combined with random scalars from reader
+// and verified with a single final exponentiation.
+func Bls12381BatchMultiPairingCheck(reader io.Reader, batches ...[]PairingPoint) (bool, error) {
+	engines := make([]*bls12381.Engine, len(batches))
+	for i, points := range batches {
+		if len(points)%2 != 0 {
+			return false, fmt.Errorf("batch %d has an odd number of points", i)
+		}
+		engines[i] = new(bls12381.Engine)
+		for j := 0; j < len(points); j += 2 {
+			pt1, ok := points[j].(*PointBls12381G1)
+			if !ok {
+				return false, fmt.Errorf("batch %d: invalid point at index %d", i, j)
+			}
+			pt2, ok := points[j+1].(*PointBls12381G2)
+			if !ok {
+				return false, fmt.Errorf("batch %d: invalid point at index %d", i, j+1)
+			}
+			engines[i].AddPair(pt1.Value, pt2.Value)
+		}
+	}
+	return bls12381.BatchCheck(reader, engines...)
+}
+
 func (s *ScalarBls12381Gt) Random(reader io.Reader) Scalar {
 	value, err := new(bls12381.Gt).Random(reader)
 	if err != nil {
@@ -0,0 +1,38 @@
+package curves
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBls12381BatchMultiPairingCheck(t *testing.T) {
+	bls := BLS12381G1()
+	g1 := bls.NewGeneratorPoint().(PairingPoint)
+	g2 := g1.OtherGroup().Generator().(PairingPoint)
+
+	batches := make([][]PairingPoint, 4)
+	for i := range batches {
+		s := bls.Scalar.Random(crand.Reader)
+		// e(s*G1, G2) * e(-G1, s*G2) = 1
+		batches[i] = []PairingPoint{
+			g1.Mul(s).(PairingPoint), g2,
+			g1.Neg().(PairingPoint), g2.Mul(s).(PairingPoint),
+		}
+		require.True(t, g1.MultiPairing(batches[i]...).IsOne())
+	}
+	ok, err := Bls12381BatchMultiPairingCheck(crand.Reader, batches...)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	batches[1] = append(batches[1], g1, g2)
+	ok, err = Bls12381BatchMultiPairingCheck(crand.Reader, batches...)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, err = Bls12381BatchMultiPairingCheck(crand.Reader, []PairingPoint{g1})
+	require.Error(t, err)
+	_, err = Bls12381BatchMultiPairingCheck(crand.Reader, []PairingPoint{g2, g1})
+	require.Error(t, err)
+}
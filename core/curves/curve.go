@@ -16,6 +16,7 @@ import (
 	"math/big"
 	"sync"
 
+	"github.com/go-sonr/crypto/core/curves/native"
 	"github.com/go-sonr/crypto/core/curves/native/bls12381"
 )
 
@@ -43,19 +44,31 @@ var (
 
 	pallasInitonce sync.Once
 	pallas         Curve
+
+	ristretto255Initonce sync.Once
+	ristretto255         Curve
+
+	vestaInitonce sync.Once
+	vesta         Curve
+
+	decaf448Initonce sync.Once
+	decaf448         Curve
 )
 
 const (
-	K256Name       = "secp256k1"
-	BLS12381G1Name = "BLS12381G1"
-	BLS12381G2Name = "BLS12381G2"
-	BLS12831Name   = "BLS12831"
-	P256Name       = "P-256"
-	ED25519Name    = "ed25519"
-	PallasName     = "pallas"
-	BLS12377G1Name = "BLS12377G1"
-	BLS12377G2Name = "BLS12377G2"
-	BLS12377Name   = "BLS12377"
+	K256Name         = "secp256k1"
+	BLS12381G1Name   = "BLS12381G1"
+	BLS12381G2Name   = "BLS12381G2"
+	BLS12831Name     = "BLS12831"
+	P256Name         = "P-256"
+	ED25519Name      = "ed25519"
+	PallasName       = "pallas"
+	BLS12377G1Name   = "BLS12377G1"
+	BLS12377G2Name   = "BLS12377G2"
+	BLS12377Name     = "BLS12377"
+	Ristretto255Name = "ristretto255"
+	VestaName        = "vesta"
+	Decaf448Name     = "decaf448"
 )
 
 const scalarBytes = 32
@@ -149,15 +162,16 @@ func unmarshalScalar(input []byte) (*Curve, []byte, error) {
 }
 
 func scalarMarshalBinary(scalar Scalar) ([]byte, error) {
-	// All scalars are 32 bytes long
-	// The last 32 bytes are the actual value
+	// Scalars are at least 32 bytes long
+	// The last bytes are the actual value
 	// The first remaining bytes are the curve name
 	// separated by a colon
 	name := []byte(scalar.Point().CurveName())
-	output := make([]byte, len(name)+1+scalarBytes)
+	value := scalar.Bytes()
+	output := make([]byte, len(name)+1+len(value))
 	copy(output[:len(name)], name)
 	output[len(name)] = byte(':')
-	copy(output[len(name)+1:], scalar.Bytes())
+	copy(output[len(name)+1:], value)
 	return output, nil
 }
 
@@ -176,15 +190,16 @@ func scalarUnmarshalBinary(input []byte) (Scalar, error) {
 }
 
 func scalarMarshalText(scalar Scalar) ([]byte, error) {
-	// All scalars are 32 bytes long
+	// Scalars are at least 32 bytes long
 	// For text encoding we put the curve name first for readability
 	// separated by a colon, then the hex encoding of the scalar
 	// which avoids the base64 weakness with strict mode or not
 	name := []byte(scalar.Point().CurveName())
-	output := make([]byte, len(name)+1+scalarBytes*2)
+	value := scalar.Bytes()
+	output := make([]byte, len(name)+1+len(value)*2)
 	copy(output[:len(name)], name)
 	output[len(name)] = byte(':')
-	_ = hex.Encode(output[len(name)+1:], scalar.Bytes())
+	_ = hex.Encode(output[len(name)+1:], value)
 	return output, nil
 }
 
@@ -196,12 +211,11 @@ func scalarUnmarshalText(input []byte) (Scalar, error) {
 	if err != nil {
 		return nil, err
 	}
-	var t [scalarBytes]byte
-	_, err = hex.Decode(t[:], data)
+	t, err := hex.DecodeString(string(data))
 	if err != nil {
 		return nil, err
 	}
-	return curve.Scalar.SetBytes(t[:])
+	return curve.Scalar.SetBytes(t)
 }
 
 func scalarMarshalJson(scalar Scalar) ([]byte, error) {
@@ -376,6 +390,19 @@ func (c Curve) ScalarBaseMult(sc Scalar) Point {
 	return c.Point.Generator().Mul(sc)
 }
 
+// SumOfProducts computes sum_i scalars[i] * points[i] with a single multi-scalar
+// multiplication instead of one Mul and Add per term.
+// Returns nil if the lengths of the arguments are not equal.
+func (c Curve) SumOfProducts(points []Point, scalars []Scalar) Point {
+	if len(points) != len(scalars) {
+		return nil
+	}
+	if len(points) == 0 {
+		return c.Point.Identity()
+	}
+	return c.Point.SumOfProducts(points, scalars)
+}
+
 func (c Curve) NewGeneratorPoint() Point {
 	return c.Point.Generator()
 }
@@ -412,6 +439,10 @@ func (c Curve) ToEllipticCurve() (elliptic.Curve, error) {
 		return nil, err
 	case BLS12377Name:
 		return nil, err
+	case Ristretto255Name:
+		return nil, err
+	case VestaName:
+		return nil, err
 	default:
 		return nil, err
 	}
@@ -478,6 +509,12 @@ func GetCurveByName(name string) *Curve {
 		return BLS12377G2()
 	case BLS12377Name:
 		return BLS12377G1()
+	case Ristretto255Name:
+		return RISTRETTO255()
+	case VestaName:
+		return VESTA()
+	case Decaf448Name:
+		return DECAF448()
 	default:
 		return nil
 	}
@@ -636,6 +673,48 @@ func pallasInit() {
 	}
 }
 
+// VESTA returns the Vesta curve, the cycle partner of Pallas
+func VESTA() *Curve {
+	vestaInitonce.Do(vestaInit)
+	return &vesta
+}
+
+func vestaInit() {
+	vesta = Curve{
+		Scalar: new(ScalarVesta).Zero(),
+		Point:  new(PointVesta).Identity(),
+		Name:   VestaName,
+	}
+}
+
+// RISTRETTO255 returns the ristretto255 group, its scalars are ed25519 scalars
+func RISTRETTO255() *Curve {
+	ristretto255Initonce.Do(ristretto255Init)
+	return &ristretto255
+}
+
+func ristretto255Init() {
+	ristretto255 = Curve{
+		Scalar: new(ScalarEd25519).Zero(),
+		Point:  new(PointRistretto255).Identity(),
+		Name:   Ristretto255Name,
+	}
+}
+
+// DECAF448 returns the decaf448 group of RFC 9496
+func DECAF448() *Curve {
+	decaf448Initonce.Do(decaf448Init)
+	return &decaf448
+}
+
+func decaf448Init() {
+	decaf448 = Curve{
+		Scalar: new(ScalarDecaf448).Zero(),
+		Point:  new(PointDecaf448).Identity(),
+		Name:   Decaf448Name,
+	}
+}
+
 // https://tools.ietf.org/html/draft-irtf-cfrg-hash-to-curve-11#appendix-G.2.1
 func osswu3mod4(u *big.Int, p *sswuParams) (x, y *big.Int) {
 	params := p.Params
@@ -773,6 +852,23 @@ type sswuParams struct {
 	C1, C2, A, B, Z *big.Int
 }
 
+// pippengerScalarBits bounds the bit length of the scalars of the 256-bit curves
+const pippengerScalarBits = 256
+
+// scalarBits bounds the bit length of the scalars of the curve of p
+func scalarBits(p Point) int {
+	return 8 * len(p.Scalar().Bytes())
+}
+
+// pippengerDigit returns the w bits of k starting at bit offset
+func pippengerDigit(k *big.Int, offset, w int) int {
+	var d int
+	for i := 0; i < w; i++ {
+		d |= int(k.Bit(offset+i)) << i
+	}
+	return d
+}
+
 // sumOfProductsPippenger implements a version of Pippenger's algorithm.
 //
 // The algorithm works as follows:
@@ -813,7 +909,8 @@ type sswuParams struct {
 // However, if `w` is too big and `n` is not too big, then `(2^w/2)*A` could dominate.
 // Therefore, the optimal choice of `w` grows slowly as `n` grows.
 //
-// # For constant time we use a fixed window of 6
+// The window is picked by native.PippengerWindow from `n` alone, so it never
+// depends on the scalars.
 //
 // This algorithm is adapted from section 4 of <https://eprint.iacr.org/2012/549.pdf>.
 // and https://cacr.uwaterloo.ca/techreports/2010/cacr2010-26.pdf
@@ -822,10 +919,11 @@ func sumOfProductsPippenger(points []Point, scalars []*big.Int) Point {
 		return nil
 	}
 
-	const w = 6
+	bits := scalarBits(points[0])
+	w := native.PippengerWindow(len(points), bits)
 
 	bucketSize := (1 << w) - 1
-	windows := make([]Point, 255/w+1)
+	windows := make([]Point, (bits+w-1)/w)
 	for i := range windows {
 		windows[i] = points[0].Identity()
 	}
@@ -837,7 +935,7 @@ func sumOfProductsPippenger(points []Point, scalars []*big.Int) Point {
 		}
 
 		for i := 0; i < len(scalars); i++ {
-			index := bucketSize & int(new(big.Int).Rsh(scalars[i], uint(w*j)).Int64())
+			index := pippengerDigit(scalars[i], w*j, w)
 			if index != 0 {
 				bucket[index-1] = bucket[index-1].Add(points[i])
 			}
@@ -0,0 +1,37 @@
+package curves
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurveSumOfProducts(t *testing.T) {
+	names := []string{
+		K256Name, P256Name, ED25519Name, Ristretto255Name, Decaf448Name, PallasName, VestaName,
+		BLS12381G1Name, BLS12381G2Name, BLS12377G1Name, BLS12377G2Name,
+	}
+	for _, name := range names {
+		curve := GetCurveByName(name)
+		t.Run(name, func(t *testing.T) {
+			require.True(t, curve.SumOfProducts(nil, nil).IsIdentity())
+			require.Nil(t, curve.SumOfProducts([]Point{curve.NewGeneratorPoint()}, nil))
+
+			// Sizes on both sides of the window changes
+			for _, n := range []int{1, 5, 70} {
+				points := make([]Point, n)
+				scalars := make([]Scalar, n)
+				expected := curve.NewIdentityPoint()
+				for i := range points {
+					points[i] = curve.Point.Random(crand.Reader)
+					scalars[i] = curve.Scalar.Random(crand.Reader)
+					expected = expected.Add(points[i].Mul(scalars[i]))
+				}
+				actual := curve.SumOfProducts(points, scalars)
+				require.NotNil(t, actual)
+				require.True(t, actual.Equal(expected), "n = %d", n)
+			}
+		})
+	}
+}
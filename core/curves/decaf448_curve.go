@@ -0,0 +1,761 @@
+// NOTE that decaf448 scalars use math/big and are NOT constant time, only
+// point multiplication is.
+
+package curves
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"math/big"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/go-sonr/crypto/core/curves/native"
+	"github.com/go-sonr/crypto/internal"
+)
+
+// decaf448Order is the order 2^446 - 13818066809895115352007386748515426880336692474882178609894547503885
+// of the decaf448 group
+var decaf448Order = bhex("3fffffffffffffffffffffffffffffffffffffffffffffffffffffff7cca23e9c44edb49aed63690216cc2728dc58f552378c292ab5844f3")
+
+var (
+	// edwards448 d = -39081
+	decaf448D = new(fp448).neg(fp448FromInt(39081))
+	// 1 - d and 1 - 2d
+	decaf448OneMinusD    = fp448FromInt(39082)
+	decaf448OneMinusTwoD = fp448FromInt(78163)
+	// the nonnegative square root of -d and its inverse
+	decaf448SqrtMinusD    = decaf448Sqrt(fp448FromInt(39081))
+	decaf448InvSqrtMinusD = new(fp448).invert(decaf448SqrtMinusD)
+	decaf448Generator     = mustDecodeDecaf448(bhex("6666666666666666666666666666666666666666666666666666666633333333333333333333333333333333333333333333333333333333").Bytes())
+)
+
+func decaf448Sqrt(a *fp448) *fp448 {
+	r := new(fp448)
+	r.sqrtRatio(a, fp448One())
+	return r
+}
+
+func mustDecodeDecaf448(b []byte) *decaf448Point {
+	p, err := decodeDecaf448(b)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// ScalarDecaf448 is a scalar of the decaf448 group, encoded in 56 little endian bytes
+type ScalarDecaf448 struct {
+	value *big.Int
+}
+
+// PointDecaf448 is an element of the prime order decaf448 group of RFC 9496
+// https://www.rfc-editor.org/rfc/rfc9496.html built on edwards448
+type PointDecaf448 struct {
+	value *decaf448Point
+}
+
+func (s *ScalarDecaf448) Random(reader io.Reader) Scalar {
+	if reader == nil {
+		return nil
+	}
+	var seed [112]byte
+	_, _ = reader.Read(seed[:])
+	return s.Hash(seed[:])
+}
+
+// Hash reduces 112 bytes of the SHAKE256 output of bytes
+func (s *ScalarDecaf448) Hash(bytes []byte) Scalar {
+	var wide [112]byte
+	sha3.ShakeSum256(wide[:], bytes)
+	sc, _ := s.SetBytesWide(wide[:])
+	return sc
+}
+
+func (s *ScalarDecaf448) Zero() Scalar {
+	return &ScalarDecaf448{value: big.NewInt(0)}
+}
+
+func (s *ScalarDecaf448) One() Scalar {
+	return &ScalarDecaf448{value: big.NewInt(1)}
+}
+
+func (s *ScalarDecaf448) IsZero() bool {
+	return subtle.ConstantTimeCompare(s.value.Bytes(), []byte{}) == 1
+}
+
+func (s *ScalarDecaf448) IsOne() bool {
+	return subtle.ConstantTimeCompare(s.value.Bytes(), []byte{1}) == 1
+}
+
+func (s *ScalarDecaf448) IsOdd() bool {
+	return s.value.Bit(0) == 1
+}
+
+func (s *ScalarDecaf448) IsEven() bool {
+	return s.value.Bit(0) == 0
+}
+
+func (s *ScalarDecaf448) New(value int) Scalar {
+	v := big.NewInt(int64(value))
+	return &ScalarDecaf448{value: v.Mod(v, decaf448Order)}
+}
+
+func (s *ScalarDecaf448) Cmp(rhs Scalar) int {
+	r, ok := rhs.(*ScalarDecaf448)
+	if ok {
+		return s.value.Cmp(r.value)
+	} else {
+		return -2
+	}
+}
+
+func (s *ScalarDecaf448) Square() Scalar {
+	return s.Mul(s)
+}
+
+func (s *ScalarDecaf448) Double() Scalar {
+	return s.Add(s)
+}
+
+func (s *ScalarDecaf448) Invert() (Scalar, error) {
+	if s.IsZero() {
+		return nil, fmt.Errorf("inverse doesn't exist")
+	}
+	return &ScalarDecaf448{value: new(big.Int).ModInverse(s.value, decaf448Order)}, nil
+}
+
+func (s *ScalarDecaf448) Sqrt() (Scalar, error) {
+	v := new(big.Int).ModSqrt(s.value, decaf448Order)
+	if v == nil {
+		return nil, fmt.Errorf("not a square")
+	}
+	return &ScalarDecaf448{value: v}, nil
+}
+
+func (s *ScalarDecaf448) Cube() Scalar {
+	return s.Mul(s).Mul(s)
+}
+
+func (s *ScalarDecaf448) Add(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarDecaf448)
+	if ok {
+		v := new(big.Int).Add(s.value, r.value)
+		return &ScalarDecaf448{value: v.Mod(v, decaf448Order)}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarDecaf448) Sub(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarDecaf448)
+	if ok {
+		v := new(big.Int).Sub(s.value, r.value)
+		return &ScalarDecaf448{value: v.Mod(v, decaf448Order)}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarDecaf448) Mul(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarDecaf448)
+	if ok {
+		v := new(big.Int).Mul(s.value, r.value)
+		return &ScalarDecaf448{value: v.Mod(v, decaf448Order)}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarDecaf448) MulAdd(y, z Scalar) Scalar {
+	p := s.Mul(y)
+	if p == nil {
+		return nil
+	}
+	return p.Add(z)
+}
+
+func (s *ScalarDecaf448) Div(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarDecaf448)
+	if ok {
+		v := new(big.Int).ModInverse(r.value, decaf448Order)
+		if v == nil {
+			return nil
+		}
+		v.Mul(v, s.value)
+		return &ScalarDecaf448{value: v.Mod(v, decaf448Order)}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarDecaf448) Neg() Scalar {
+	v := new(big.Int).Neg(s.value)
+	return &ScalarDecaf448{value: v.Mod(v, decaf448Order)}
+}
+
+func (s *ScalarDecaf448) SetBigInt(v *big.Int) (Scalar, error) {
+	if v == nil {
+		return nil, fmt.Errorf("invalid value")
+	}
+	return &ScalarDecaf448{value: new(big.Int).Mod(v, decaf448Order)}, nil
+}
+
+func (s *ScalarDecaf448) BigInt() *big.Int {
+	return new(big.Int).Set(s.value)
+}
+
+// Bytes returns the 56 byte little endian encoding of the scalar
+func (s *ScalarDecaf448) Bytes() []byte {
+	var out [fp448Size]byte
+	return internal.ReverseScalarBytes(s.value.FillBytes(out[:]))
+}
+
+// Zeroize overwrites the scalar with zero
+func (s *ScalarDecaf448) Zeroize() {
+	zeroizeBigInt(s.value)
+}
+
+// SetBytes decodes a canonical 56 byte little endian scalar
+func (s *ScalarDecaf448) SetBytes(bytes []byte) (Scalar, error) {
+	if len(bytes) != fp448Size {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	value := new(big.Int).SetBytes(internal.ReverseScalarBytes(bytes))
+	if value.Cmp(decaf448Order) >= 0 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	return &ScalarDecaf448{value}, nil
+}
+
+// SetBytesWide reduces 56 to 128 little endian bytes modulo the group order
+func (s *ScalarDecaf448) SetBytesWide(bytes []byte) (Scalar, error) {
+	if len(bytes) < fp448Size || len(bytes) > 128 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	value := new(big.Int).SetBytes(internal.ReverseScalarBytes(bytes))
+	return &ScalarDecaf448{value: value.Mod(value, decaf448Order)}, nil
+}
+
+func (s *ScalarDecaf448) Point() Point {
+	return new(PointDecaf448).Identity()
+}
+
+func (s *ScalarDecaf448) Clone() Scalar {
+	return &ScalarDecaf448{value: new(big.Int).Set(s.value)}
+}
+
+func (s *ScalarDecaf448) MarshalBinary() ([]byte, error) {
+	return scalarMarshalBinary(s)
+}
+
+func (s *ScalarDecaf448) UnmarshalBinary(input []byte) error {
+	sc, err := scalarUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarDecaf448)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.value = ss.value
+	return nil
+}
+
+func (s *ScalarDecaf448) MarshalText() ([]byte, error) {
+	return scalarMarshalText(s)
+}
+
+func (s *ScalarDecaf448) UnmarshalText(input []byte) error {
+	sc, err := scalarUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarDecaf448)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.value = ss.value
+	return nil
+}
+
+func (s *ScalarDecaf448) MarshalJSON() ([]byte, error) {
+	return scalarMarshalJson(s)
+}
+
+func (s *ScalarDecaf448) UnmarshalJSON(input []byte) error {
+	sc, err := scalarUnmarshalJson(input)
+	if err != nil {
+		return err
+	}
+	S, ok := sc.(*ScalarDecaf448)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	s.value = S.value
+	return nil
+}
+
+func (p *PointDecaf448) Random(reader io.Reader) Point {
+	var seed [112]byte
+	_, _ = reader.Read(seed[:])
+	return p.Hash(seed[:])
+}
+
+// Hash maps the 112 byte SHAKE256 output of bytes to the group with the
+// one-way map of RFC 9496 section 5.3.4
+func (p *PointDecaf448) Hash(bytes []byte) Point {
+	var h [112]byte
+	sha3.ShakeSum256(h[:], bytes)
+	return decaf448FromUniformBytes(h[:])
+}
+
+func (p *PointDecaf448) Identity() Point {
+	return &PointDecaf448{value: decaf448Identity()}
+}
+
+func (p *PointDecaf448) Generator() Point {
+	g := *decaf448Generator
+	return &PointDecaf448{value: &g}
+}
+
+func (p *PointDecaf448) IsIdentity() bool {
+	return p.Equal(p.Identity())
+}
+
+func (p *PointDecaf448) IsNegative() bool {
+	// Negative points don't exist in decaf448
+	return false
+}
+
+func (p *PointDecaf448) IsOnCurve() bool {
+	// Every representable element is in the group
+	return true
+}
+
+func (p *PointDecaf448) Double() Point {
+	return &PointDecaf448{value: new(decaf448Point).add(p.value, p.value)}
+}
+
+func (p *PointDecaf448) Scalar() Scalar {
+	return new(ScalarDecaf448).Zero()
+}
+
+func (p *PointDecaf448) Neg() Point {
+	return &PointDecaf448{value: new(decaf448Point).neg(p.value)}
+}
+
+func (p *PointDecaf448) Add(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointDecaf448)
+	if ok {
+		return &PointDecaf448{value: new(decaf448Point).add(p.value, r.value)}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointDecaf448) Sub(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointDecaf448)
+	if ok {
+		n := new(decaf448Point).neg(r.value)
+		return &PointDecaf448{value: n.add(p.value, n)}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointDecaf448) Mul(rhs Scalar) Point {
+	if rhs == nil {
+		return nil
+	}
+	s, ok := rhs.(*ScalarDecaf448)
+	if !ok {
+		return nil
+	}
+	table := p.value.table()
+	return &PointDecaf448{value: table.mul(s.Bytes())}
+}
+
+func (p *PointDecaf448) Equal(rhs Point) bool {
+	r, ok := rhs.(*PointDecaf448)
+	if ok {
+		return p.value.equal(r.value)
+	} else {
+		return false
+	}
+}
+
+func (p *PointDecaf448) Set(x, y *big.Int) (Point, error) {
+	return nil, fmt.Errorf("decaf448 elements have no affine coordinates")
+}
+
+// ToAffineCompressed returns the 56 byte canonical encoding of RFC 9496 section 5.3.2
+func (p *PointDecaf448) ToAffineCompressed() []byte {
+	return p.value.encode()
+}
+
+// ToAffineUncompressed is the same as ToAffineCompressed, decaf448 has a single encoding
+func (p *PointDecaf448) ToAffineUncompressed() []byte {
+	return p.value.encode()
+}
+
+// FromAffineCompressed decodes the 56 byte canonical encoding and rejects every other input
+func (p *PointDecaf448) FromAffineCompressed(inBytes []byte) (Point, error) {
+	value, err := decodeDecaf448(inBytes)
+	if err != nil {
+		return nil, err
+	}
+	return &PointDecaf448{value}, nil
+}
+
+func (p *PointDecaf448) FromAffineUncompressed(inBytes []byte) (Point, error) {
+	return p.FromAffineCompressed(inBytes)
+}
+
+func (p *PointDecaf448) CurveName() string {
+	return Decaf448Name
+}
+
+func (p *PointDecaf448) SumOfProducts(points []Point, scalars []Scalar) Point {
+	nScalars := make([]*big.Int, len(scalars))
+	for i, sc := range scalars {
+		s, ok := sc.(*ScalarDecaf448)
+		if !ok {
+			return nil
+		}
+		nScalars[i] = s.BigInt()
+	}
+	for _, pt := range points {
+		if _, ok := pt.(*PointDecaf448); !ok {
+			return nil
+		}
+	}
+	if len(points) == 0 {
+		return p.Identity()
+	}
+	return sumOfProductsPippenger(points, nScalars)
+}
+
+// BatchMul multiplies p by every scalar in scalars. The multiples of p are
+// computed once and shared by all the multiplications.
+func (p *PointDecaf448) BatchMul(scalars []Scalar) ([]Point, error) {
+	table := p.value.table()
+	out := make([]Point, len(scalars))
+	for i, sc := range scalars {
+		s, ok := sc.(*ScalarDecaf448)
+		if !ok {
+			return nil, fmt.Errorf("invalid scalar at index %d", i)
+		}
+		out[i] = &PointDecaf448{value: table.mul(s.Bytes())}
+	}
+	return out, nil
+}
+
+// Decaf448BatchDecode decodes a list of canonical encodings, failing on the
+// first input that is not a valid decaf448 element.
+func Decaf448BatchDecode(inBytes [][]byte) ([]*PointDecaf448, error) {
+	out := make([]*PointDecaf448, len(inBytes))
+	for i, b := range inBytes {
+		pt, err := new(PointDecaf448).FromAffineCompressed(b)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		out[i] = pt.(*PointDecaf448)
+	}
+	return out, nil
+}
+
+// Decaf448BatchEncode returns the canonical encodings of points
+func Decaf448BatchEncode(points []*PointDecaf448) [][]byte {
+	out := make([][]byte, len(points))
+	for i, pt := range points {
+		out[i] = pt.ToAffineCompressed()
+	}
+	return out
+}
+
+func (p *PointDecaf448) MarshalBinary() ([]byte, error) {
+	return pointMarshalBinary(p)
+}
+
+func (p *PointDecaf448) UnmarshalBinary(input []byte) error {
+	pt, err := pointUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointDecaf448)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointDecaf448) MarshalText() ([]byte, error) {
+	return pointMarshalText(p)
+}
+
+func (p *PointDecaf448) UnmarshalText(input []byte) error {
+	pt, err := pointUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointDecaf448)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointDecaf448) MarshalJSON() ([]byte, error) {
+	return pointMarshalJSON(p)
+}
+
+func (p *PointDecaf448) UnmarshalJSON(input []byte) error {
+	pt, err := pointUnmarshalJSON(input)
+	if err != nil {
+		return err
+	}
+	P, ok := pt.(*PointDecaf448)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	p.value = P.value
+	return nil
+}
+
+// Decaf448Elligator is the MAP function of RFC 9496 section 5.3.4, it maps
+// 56 bytes to a group element
+func Decaf448Elligator(b []byte) (*PointDecaf448, error) {
+	if len(b) != fp448Size {
+		return nil, fmt.Errorf("invalid length")
+	}
+	return &PointDecaf448{value: decaf448Map(b)}, nil
+}
+
+// Decaf448FromUniformBytes is the one-way map of RFC 9496 section 5.3.4 on 112 bytes
+func Decaf448FromUniformBytes(b []byte) (*PointDecaf448, error) {
+	if len(b) != 2*fp448Size {
+		return nil, fmt.Errorf("invalid length")
+	}
+	return decaf448FromUniformBytes(b), nil
+}
+
+func decaf448FromUniformBytes(b []byte) *PointDecaf448 {
+	p0 := decaf448Map(b[:fp448Size])
+	p1 := decaf448Map(b[fp448Size:])
+	return &PointDecaf448{value: p0.add(p0, p1)}
+}
+
+// hashToDecaf448 is hash_to_decaf448 of RFC 9380 appendix C using expand_message_xof with SHAKE256
+func hashToDecaf448(msg, dst []byte) *PointDecaf448 {
+	return decaf448FromUniformBytes(native.ExpandMsgXof(native.EllipticPointHasherShake256(), msg, dst, 2*fp448Size))
+}
+
+// decaf448Point is a point of edwards448 x^2 + y^2 = 1 + d x^2 y^2 in
+// extended coordinates x = X/Z, y = Y/Z and xy = T/Z, standing for its class
+// modulo the 4-torsion
+type decaf448Point struct {
+	x, y, z, t fp448
+}
+
+func decaf448Identity() *decaf448Point {
+	return &decaf448Point{y: *fp448One(), z: *fp448One()}
+}
+
+// add sets r = p + q with the complete addition of
+// https://eprint.iacr.org/2008/522.pdf section 3.1 for a = 1
+func (r *decaf448Point) add(p, q *decaf448Point) *decaf448Point {
+	var a, b, c, d, e, f, g, h, t fp448
+	a.mul(&p.x, &q.x)
+	b.mul(&p.y, &q.y)
+	c.mul(&p.t, &q.t)
+	c.mul(&c, decaf448D)
+	d.mul(&p.z, &q.z)
+	e.add(&p.x, &p.y)
+	t.add(&q.x, &q.y)
+	e.mul(&e, &t)
+	e.sub(&e, &a)
+	e.sub(&e, &b)
+	f.sub(&d, &c)
+	g.add(&d, &c)
+	h.sub(&b, &a)
+	r.x.mul(&e, &f)
+	r.y.mul(&g, &h)
+	r.t.mul(&e, &h)
+	r.z.mul(&f, &g)
+	return r
+}
+
+func (r *decaf448Point) neg(p *decaf448Point) *decaf448Point {
+	r.x.neg(&p.x)
+	r.y = p.y
+	r.z = p.z
+	r.t.neg(&p.t)
+	return r
+}
+
+// equal is the equality of RFC 9496 section 5.3.3, x1 * y2 == y1 * x2
+func (r *decaf448Point) equal(q *decaf448Point) bool {
+	var a, b fp448
+	a.mul(&r.x, &q.y)
+	b.mul(&r.y, &q.x)
+	return a.equal(&b)
+}
+
+func (r *decaf448Point) cmov(p *decaf448Point, c uint64) {
+	r.x.cmov(&p.x, c)
+	r.y.cmov(&p.y, c)
+	r.z.cmov(&p.z, c)
+	r.t.cmov(&p.t, c)
+}
+
+// decaf448Table holds the multiples 0 to 15 of a point
+type decaf448Table [16]decaf448Point
+
+func (r *decaf448Point) table() *decaf448Table {
+	t := new(decaf448Table)
+	t[0] = *decaf448Identity()
+	t[1] = *r
+	for i := 2; i < len(t); i++ {
+		t[i].add(&t[i-1], r)
+	}
+	return t
+}
+
+// mul returns k times the point of the table for the 56 byte little endian
+// k, reading every entry of the table for each 4-bit window
+func (t *decaf448Table) mul(k []byte) *decaf448Point {
+	acc := decaf448Identity()
+	for i := len(k) - 1; i >= 0; i-- {
+		for _, nibble := range []byte{k[i] >> 4, k[i] & 0x0f} {
+			for j := 0; j < 4; j++ {
+				acc.add(acc, acc)
+			}
+			var q decaf448Point
+			for j := range t {
+				q.cmov(&t[j], uint64(subtle.ConstantTimeByteEq(byte(j), nibble)))
+			}
+			acc.add(acc, &q)
+		}
+	}
+	return acc
+}
+
+// encode is the encoding of RFC 9496 section 5.3.2
+func (r *decaf448Point) encode() []byte {
+	var u1, u2, tmp, invsqrt, ratio, s fp448
+	tmp.add(&r.x, &r.t)
+	u1.sub(&r.x, &r.t)
+	u1.mul(&u1, &tmp)
+	tmp.square(&r.x)
+	tmp.mul(&tmp, &u1)
+	tmp.mul(&tmp, decaf448OneMinusD)
+	invsqrt.sqrtRatio(fp448One(), &tmp)
+	ratio.mul(&invsqrt, &u1)
+	ratio.mul(&ratio, decaf448SqrtMinusD)
+	ratio.abs(&ratio)
+	u2.mul(decaf448InvSqrtMinusD, &ratio)
+	u2.mul(&u2, &r.z)
+	u2.sub(&u2, &r.t)
+	s.mul(decaf448OneMinusD, &invsqrt)
+	s.mul(&s, &r.x)
+	s.mul(&s, &u2)
+	s.abs(&s)
+	return s.bytes()
+}
+
+// decodeDecaf448 is the decoding of RFC 9496 section 5.3.1
+func decodeDecaf448(b []byte) (*decaf448Point, error) {
+	if len(b) != fp448Size {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	if !fp448IsCanonical(b) || b[0]&1 == 1 {
+		return nil, fmt.Errorf("invalid decaf448 encoding")
+	}
+	var s, ss, u1, u2, tmp, invsqrt, u3 fp448
+	s.setBytes(b)
+	ss.square(&s)
+	u1.add(fp448One(), &ss)
+	u2.square(&u1)
+	tmp.mul(&ss, decaf448D)
+	tmp.add(&tmp, &tmp)
+	tmp.add(&tmp, &tmp)
+	u2.sub(&u2, &tmp)
+	tmp.square(&u1)
+	tmp.mul(&tmp, &u2)
+	if !invsqrt.sqrtRatio(fp448One(), &tmp) {
+		return nil, fmt.Errorf("invalid decaf448 encoding")
+	}
+	u3.add(&s, &s)
+	u3.mul(&u3, &invsqrt)
+	u3.mul(&u3, &u1)
+	u3.mul(&u3, decaf448SqrtMinusD)
+	u3.abs(&u3)
+
+	p := new(decaf448Point)
+	p.x.mul(&u3, &invsqrt)
+	p.x.mul(&p.x, &u2)
+	p.x.mul(&p.x, decaf448InvSqrtMinusD)
+	p.y.sub(fp448One(), &ss)
+	p.y.mul(&p.y, &invsqrt)
+	p.y.mul(&p.y, &u1)
+	p.z = *fp448One()
+	p.t.mul(&p.x, &p.y)
+	return p, nil
+}
+
+// decaf448Map is the MAP function of RFC 9496 section 5.3.4
+func decaf448Map(b []byte) *decaf448Point {
+	var t, r, u0, u1, tmp, v, vPrime, sgn, s, ss, w0, w1, w2, w3 fp448
+	// the 56 bytes are reduced modulo p
+	t.setBytes(b)
+	r.square(&t)
+	r.neg(&r)
+	u0.sub(&r, fp448One())
+	u0.mul(&u0, decaf448D)
+	u1.add(&u0, fp448One())
+	tmp.sub(&u0, &r)
+	u1.mul(&u1, &tmp)
+	tmp.add(&r, fp448One())
+	tmp.mul(&tmp, &u1)
+	wasSquare := v.sqrtRatio(decaf448OneMinusTwoD, &tmp)
+	c := uint64(1)
+	if wasSquare {
+		c = 0
+	}
+	vPrime = v
+	tmp.mul(&t, &v)
+	vPrime.cmov(&tmp, c)
+	sgn = *fp448One()
+	tmp.neg(fp448One())
+	sgn.cmov(&tmp, c)
+	s.add(&r, fp448One())
+	s.mul(&s, &vPrime)
+
+	ss.square(&s)
+	w0.abs(&s)
+	w0.add(&w0, &w0)
+	w1.add(&ss, fp448One())
+	w2.sub(&ss, fp448One())
+	w3.sub(&r, fp448One())
+	w3.mul(&w3, &vPrime)
+	w3.mul(&w3, &s)
+	w3.mul(&w3, decaf448OneMinusTwoD)
+	w3.add(&w3, &sgn)
+
+	p := new(decaf448Point)
+	p.x.mul(&w0, &w3)
+	p.y.mul(&w2, &w1)
+	p.z.mul(&w1, &w3)
+	p.t.mul(&w0, &w2)
+	return p
+}
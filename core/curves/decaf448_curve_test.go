@@ -0,0 +1,181 @@
+package curves
+
+import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFp448Arithmetic(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		var ab, bb [fp448Size]byte
+		_, err := crand.Read(ab[:])
+		require.NoError(t, err)
+		_, err = crand.Read(bb[:])
+		require.NoError(t, err)
+		var a, b, z fp448
+		a.setBytes(ab[:])
+		b.setBytes(bb[:])
+		x, y := a.bigInt(), b.bigInt()
+
+		expected := new(big.Int).Mul(x, y)
+		require.Equal(t, expected.Mod(expected, fp448PBig), z.mul(&a, &b).bigInt())
+		expected = new(big.Int).Add(x, y)
+		require.Equal(t, expected.Mod(expected, fp448PBig), z.add(&a, &b).bigInt())
+		expected = new(big.Int).Sub(x, y)
+		require.Equal(t, expected.Mod(expected, fp448PBig), z.sub(&a, &b).bigInt())
+		require.Equal(t, new(big.Int).ModInverse(x, fp448PBig), z.invert(&a).bigInt())
+	}
+}
+
+func TestPointDecaf448Multiples(t *testing.T) {
+	// RFC 9496 appendix A.2
+	multiples := []string{
+		"0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		"6666666666666666666666666666666666666666666666666666666633333333333333333333333333333333333333333333333333333333",
+		"c898eb4f87f97c564c6fd61fc7e49689314a1f818ec85eeb3bd5514ac816d38778f69ef347a89fca817e66defdedce178c7cc709b2116e75",
+		"a0c09bf2ba7208fda0f4bfe3d0f5b29a543012306d43831b5adc6fe7f8596fa308763db15468323b11cf6e4aeb8c18fe44678f44545a69bc",
+		"b46f1836aa287c0a5a5653f0ec5ef9e903f436e21c1570c29ad9e5f596da97eeaf17150ae30bcb3174d04bc2d712c8c7789d7cb4fda138f4",
+		"1c5bbecf4741dfaae79db72dface00eaaac502c2060934b6eaaeca6a20bd3da9e0be8777f7d02033d1b15884232281a41fc7f80eed04af5e",
+		"86ff0182d40f7f9edb7862515821bd67bfd6165a3c44de95d7df79b8779ccf6460e3c68b70c16aaa280f2d7b3f22d745b97a89906cfc476c",
+		"502bcb6842eb06f0e49032bae87c554c031d6d4d2d7694efbf9c468d48220c50f8ca28843364d70cee92d6fe246e61448f9db9808b3b2408",
+		"0c9810f1e2ebd389caa789374d78007974ef4d17227316f40e578b336827da3f6b482a4794eb6a3975b971b5e1388f52e91ea2f1bcb0f912",
+	}
+	curve := DECAF448()
+	p := curve.NewIdentityPoint()
+	for i, expected := range multiples {
+		require.Equal(t, expected, hex.EncodeToString(p.ToAffineCompressed()))
+		require.True(t, curve.ScalarBaseMult(curve.Scalar.New(i)).Equal(p))
+		q, err := curve.Point.FromAffineCompressed(p.ToAffineCompressed())
+		require.NoError(t, err)
+		require.True(t, q.Equal(p))
+		p = p.Add(curve.NewGeneratorPoint())
+	}
+}
+
+func TestPointDecaf448RejectsBadEncodings(t *testing.T) {
+	// p itself and a negative encoding
+	for _, enc := range []string{
+		"fffffffffffffffffffffffffffffffffffffffffffffffffffffffffeffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		"0100000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+	} {
+		b, _ := hex.DecodeString(enc)
+		_, err := DECAF448().Point.FromAffineCompressed(b)
+		require.Error(t, err)
+	}
+	_, err := DECAF448().Point.FromAffineCompressed(make([]byte, 55))
+	require.Error(t, err)
+}
+
+func TestPointDecaf448OPRFVectors(t *testing.T) {
+	// RFC 9497 appendix A.2.1, decaf448-SHAKE256 OPRF mode
+	dst := []byte("HashToGroup-OPRFV1-\x00-decaf448-SHAKE256")
+	sk, _ := hex.DecodeString("e8b1375371fd11ebeb224f832dcc16d371b4188951c438f751425699ed29ecc80c6c13e558ccd67634fd82eac94aa8d1f0d7fee990695d1e")
+	blind, _ := hex.DecodeString("64d37aed22a27f5191de1c1d69fadb899d8862b58eb4220029e036ec65fa3833a26e9388336361686ff1f83df55046504dfecad8549ba112")
+	tests := []struct {
+		input, blinded, evaluated string
+	}{
+		{
+			"00",
+			"e0ae01c4095f08e03b19baf47ffdc19cb7d98e583160522a3c7d6a0b2111cd93a126a46b7b41b730cd7fc943d4e28e590ed33ae475885f6c",
+			"50ce4e60eed006e22e7027454b5a4b8319eb2bc8ced609eb19eb3ad42fb19e06ba12d382cbe7ae342a0cad6ead0ef8f91f00bb7f0cd9c0a2",
+		},
+		{
+			"5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a",
+			"86a88dc5c6331ecfcb1d9aacb50a68213803c462e377577cacc00af28e15f0ddbc2e3d716f2f39ef95f3ec1314a2c64d940a9f295d8f13bb",
+			"162e9fa6e9d527c3cd734a31bf122a34dbd5bcb7bb23651f1768a7a9274cc116c03b58afa6f0dede3994a60066c76370e7328e7062fd5819",
+		},
+	}
+	curve := DECAF448()
+	k, err := curve.Scalar.SetBytes(sk)
+	require.NoError(t, err)
+	r, err := curve.Scalar.SetBytes(blind)
+	require.NoError(t, err)
+	for _, test := range tests {
+		input, _ := hex.DecodeString(test.input)
+		p, err := curve.HashToPoint(input, dst)
+		require.NoError(t, err)
+		blinded := p.Mul(r)
+		require.Equal(t, test.blinded, hex.EncodeToString(blinded.ToAffineCompressed()))
+		require.Equal(t, test.evaluated, hex.EncodeToString(blinded.Mul(k).ToAffineCompressed()))
+	}
+}
+
+func TestPointDecaf448Arithmetic(t *testing.T) {
+	curve := DECAF448()
+	a := curve.Scalar.Random(crand.Reader)
+	b := curve.Scalar.Random(crand.Reader)
+	p := curve.Point.Random(crand.Reader)
+	require.True(t, p.Mul(a).Add(p.Mul(b)).Equal(p.Mul(a.Add(b))))
+	require.True(t, p.Sub(p).IsIdentity())
+	require.True(t, p.Double().Equal(p.Add(p)))
+	require.True(t, p.Neg().Add(p).IsIdentity())
+	require.True(t, curve.Point.SumOfProducts([]Point{p, p}, []Scalar{a, b}).Equal(p.Mul(a.Add(b))))
+
+	inv, err := a.Invert()
+	require.NoError(t, err)
+	require.True(t, p.Mul(a).Mul(inv).Equal(p))
+	_, err = curve.Scalar.Zero().Invert()
+	require.Error(t, err)
+
+	bin, err := p.(*PointDecaf448).MarshalBinary()
+	require.NoError(t, err)
+	q := new(PointDecaf448)
+	require.NoError(t, q.UnmarshalBinary(bin))
+	require.True(t, q.Equal(p))
+
+	text, err := a.(*ScalarDecaf448).MarshalText()
+	require.NoError(t, err)
+	c := new(ScalarDecaf448)
+	require.NoError(t, c.UnmarshalText(text))
+	require.Equal(t, 0, c.Cmp(a))
+}
+
+func TestPointDecaf448UniformBytes(t *testing.T) {
+	in := make([]byte, 2*fp448Size)
+	_, err := crand.Read(in)
+	require.NoError(t, err)
+	p, err := Decaf448FromUniformBytes(in)
+	require.NoError(t, err)
+	p0, err := Decaf448Elligator(in[:fp448Size])
+	require.NoError(t, err)
+	p1, err := Decaf448Elligator(in[fp448Size:])
+	require.NoError(t, err)
+	require.True(t, p0.Add(p1).Equal(p))
+
+	_, err = Decaf448FromUniformBytes(in[:fp448Size])
+	require.Error(t, err)
+	_, err = Decaf448Elligator(in)
+	require.Error(t, err)
+}
+
+func TestPointDecaf448Batch(t *testing.T) {
+	curve := DECAF448()
+	points := make([]*PointDecaf448, 8)
+	scalars := make([]Scalar, len(points))
+	for i := range points {
+		points[i] = curve.Point.Random(crand.Reader).(*PointDecaf448)
+		scalars[i] = curve.Scalar.Random(crand.Reader)
+	}
+
+	multiples, err := points[0].BatchMul(scalars)
+	require.NoError(t, err)
+	for i, m := range multiples {
+		require.True(t, m.Equal(points[0].Mul(scalars[i])))
+	}
+	_, err = points[0].BatchMul([]Scalar{curve.Scalar, new(ScalarK256).Zero()})
+	require.Error(t, err)
+
+	decoded, err := Decaf448BatchDecode(Decaf448BatchEncode(points))
+	require.NoError(t, err)
+	for i, pt := range decoded {
+		require.True(t, pt.Equal(points[i]))
+	}
+	bad := Decaf448BatchEncode(points)
+	bad[3] = make([]byte, 55)
+	_, err = Decaf448BatchDecode(bad)
+	require.Error(t, err)
+}
@@ -0,0 +1,235 @@
+package curves
+
+import (
+	"math/big"
+	"math/bits"
+
+	"github.com/go-sonr/crypto/internal"
+)
+
+// fp448 is an element of GF(2^448 - 2^224 - 1) in eight 56-bit little endian
+// limbs. Operations keep every limb a few units below 2^57 but do not fully
+// reduce the value, bytes returns the canonical encoding.
+type fp448 [8]uint64
+
+const (
+	fp448Mask = 1<<56 - 1
+	fp448Size = 56
+)
+
+var (
+	fp448P     = fp448{fp448Mask, fp448Mask, fp448Mask, fp448Mask, fp448Mask - 1, fp448Mask, fp448Mask, fp448Mask}
+	fp448PBig  = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 448), new(big.Int).Add(new(big.Int).Lsh(big.NewInt(1), 224), big.NewInt(1)))
+	fp448PM2   = new(big.Int).Sub(fp448PBig, big.NewInt(2))
+	fp448PM3D4 = new(big.Int).Rsh(new(big.Int).Sub(fp448PBig, big.NewInt(3)), 2)
+)
+
+func fp448One() *fp448 {
+	return &fp448{1}
+}
+
+func fp448FromInt(v uint64) *fp448 {
+	return new(fp448).setBytes([]byte{byte(v), byte(v >> 8), byte(v >> 16), byte(v >> 24)})
+}
+
+// setBytes sets z to the little endian value of b, at most 56 bytes, which
+// need not be reduced
+func (z *fp448) setBytes(b []byte) *fp448 {
+	var buf [fp448Size]byte
+	copy(buf[:], b)
+	for i := range z {
+		var limb uint64
+		for j := 6; j >= 0; j-- {
+			limb = limb<<8 | uint64(buf[7*i+j])
+		}
+		z[i] = limb
+	}
+	return z
+}
+
+// bytes returns the 56 byte little endian encoding of the reduced value
+func (z *fp448) bytes() []byte {
+	t := *z
+	t.reduce()
+	out := make([]byte, fp448Size)
+	for i, limb := range t {
+		for j := 0; j < 7; j++ {
+			out[7*i+j] = byte(limb >> (8 * j))
+		}
+	}
+	return out
+}
+
+// fp448IsCanonical reports whether the little endian b is below p
+func fp448IsCanonical(b []byte) bool {
+	var t fp448
+	t.setBytes(b)
+	var borrow uint64
+	for i := range t {
+		borrow = (t[i] - fp448P[i] - borrow) >> 63
+	}
+	return borrow == 1
+}
+
+// carry propagates the limbs above 56 bits, folding 2^448 = 2^224 + 1
+func (z *fp448) carry() {
+	for i := 0; i < 7; i++ {
+		z[i+1] += z[i] >> 56
+		z[i] &= fp448Mask
+	}
+	c := z[7] >> 56
+	z[7] &= fp448Mask
+	z[0] += c
+	z[4] += c
+}
+
+// reduce sets z to its value in [0, p)
+func (z *fp448) reduce() {
+	z.carry()
+	z.carry()
+	z.carry()
+	var t fp448
+	var borrow uint64
+	for i := range z {
+		d := z[i] - fp448P[i] - borrow
+		borrow = d >> 63
+		t[i] = d & fp448Mask
+	}
+	// keep z if it was below p
+	z.cmov(&t, 1-borrow)
+}
+
+// cmov sets z to a if c is 1 and leaves it if c is 0
+func (z *fp448) cmov(a *fp448, c uint64) {
+	mask := -c
+	for i := range z {
+		z[i] ^= mask & (z[i] ^ a[i])
+	}
+}
+
+func (z *fp448) add(a, b *fp448) *fp448 {
+	for i := range z {
+		z[i] = a[i] + b[i]
+	}
+	z.carry()
+	return z
+}
+
+// sub adds 2p before subtracting to keep the limbs positive
+func (z *fp448) sub(a, b *fp448) *fp448 {
+	for i := range z {
+		z[i] = a[i] + 2*fp448P[i] - b[i]
+	}
+	z.carry()
+	return z
+}
+
+func (z *fp448) neg(a *fp448) *fp448 {
+	return z.sub(&fp448{}, a)
+}
+
+func (z *fp448) mul(a, b *fp448) *fp448 {
+	// 128-bit column sums, each below 2^120
+	var hi, lo [15]uint64
+	for i := 0; i < 8; i++ {
+		for j := 0; j < 8; j++ {
+			h, l := bits.Mul64(a[i], b[j])
+			var c uint64
+			lo[i+j], c = bits.Add64(lo[i+j], l, 0)
+			hi[i+j] += h + c
+		}
+	}
+	for k := 14; k >= 8; k-- {
+		var c uint64
+		lo[k-4], c = bits.Add64(lo[k-4], lo[k], 0)
+		hi[k-4] += hi[k] + c
+		lo[k-8], c = bits.Add64(lo[k-8], lo[k], 0)
+		hi[k-8] += hi[k] + c
+	}
+	var chi, clo uint64
+	for i := 0; i < 8; i++ {
+		var c uint64
+		clo, c = bits.Add64(lo[i], clo, 0)
+		chi += hi[i] + c
+		z[i] = clo & fp448Mask
+		clo = clo>>56 | chi<<8
+		chi >>= 56
+	}
+	// the carry out of the top limb is below 2^72
+	z[0] += clo & fp448Mask
+	z[4] += clo & fp448Mask
+	z[1] += clo>>56 | chi<<8
+	z[5] += clo>>56 | chi<<8
+	z.carry()
+	return z
+}
+
+func (z *fp448) square(a *fp448) *fp448 {
+	return z.mul(a, a)
+}
+
+// exp sets z to a^e for a public exponent e
+func (z *fp448) exp(a *fp448, e *big.Int) *fp448 {
+	base := *a
+	r := *fp448One()
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		r.square(&r)
+		if e.Bit(i) == 1 {
+			r.mul(&r, &base)
+		}
+	}
+	*z = r
+	return z
+}
+
+func (z *fp448) invert(a *fp448) *fp448 {
+	return z.exp(a, fp448PM2)
+}
+
+func (z *fp448) isZero() bool {
+	var acc byte
+	for _, b := range z.bytes() {
+		acc |= b
+	}
+	return acc == 0
+}
+
+func (z *fp448) equal(a *fp448) bool {
+	var t fp448
+	return t.sub(z, a).isZero()
+}
+
+// isNegative reports whether the reduced value is odd, IS_NEGATIVE of RFC 9496
+func (z *fp448) isNegative() uint64 {
+	return uint64(z.bytes()[0] & 1)
+}
+
+// abs is CT_ABS of RFC 9496
+func (z *fp448) abs(a *fp448) *fp448 {
+	var n fp448
+	n.neg(a)
+	*z = *a
+	z.cmov(&n, a.isNegative())
+	return z
+}
+
+// sqrtRatio is SQRT_RATIO_M1 of RFC 9496 section 5.2: the nonnegative square
+// root of u/v if it exists, and of -u/v otherwise
+func (z *fp448) sqrtRatio(u, v *fp448) (wasSquare bool) {
+	var uv, uv3, r, check fp448
+	uv.mul(u, v)
+	uv3.square(v)
+	uv3.mul(&uv3, &uv)
+	// r = u*v*(u*v^3)^((p-3)/4)
+	r.exp(&uv3, fp448PM3D4)
+	r.mul(&r, &uv)
+	check.square(&r)
+	check.mul(&check, v)
+	wasSquare = check.equal(u)
+	z.abs(&r)
+	return wasSquare
+}
+
+func (z *fp448) bigInt() *big.Int {
+	return new(big.Int).SetBytes(internal.ReverseScalarBytes(z.bytes()))
+}
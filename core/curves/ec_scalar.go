@@ -40,59 +40,14 @@ type K256Scalar struct{}
 // Static interface assertion
 var _ EcScalar = (*K256Scalar)(nil)
 
-// warning: the Euclidean alg which Mod uses is not constant-time.
-
 func NewK256Scalar() *K256Scalar {
 	return &K256Scalar{}
 }
 
-func (k K256Scalar) Add(x, y *big.Int) *big.Int {
-	v := new(big.Int).Add(x, y)
-	v.Mod(v, btcec.S256().N)
-	return v
-}
-
-func (k K256Scalar) Sub(x, y *big.Int) *big.Int {
-	v := new(big.Int).Sub(x, y)
-	v.Mod(v, btcec.S256().N)
-	return v
-}
-
-func (k K256Scalar) Neg(x *big.Int) *big.Int {
-	v := new(big.Int).Sub(btcec.S256().N, x)
-	v.Mod(v, btcec.S256().N)
-	return v
-}
-
-func (k K256Scalar) Mul(x, y *big.Int) *big.Int {
-	v := new(big.Int).Mul(x, y)
-	v.Mod(v, btcec.S256().N)
-	return v
-}
-
-func (k K256Scalar) Div(x, y *big.Int) *big.Int {
-	t := new(big.Int).ModInverse(y, btcec.S256().N)
-	return k.Mul(x, t)
-}
-
 func (k K256Scalar) Hash(input []byte) *big.Int {
 	return new(ScalarK256).Hash(input).BigInt()
 }
 
-func (k K256Scalar) Random() (*big.Int, error) {
-	b := make([]byte, 48)
-	n, err := crand.Read(b)
-	if err != nil {
-		return nil, err
-	}
-	if n != 48 {
-		return nil, fmt.Errorf("insufficient bytes read")
-	}
-	v := new(big.Int).SetBytes(b)
-	v.Mod(v, btcec.S256().N)
-	return v, nil
-}
-
 func (k K256Scalar) IsValid(x *big.Int) bool {
 	return core.In(x, btcec.S256().N) == nil
 }
@@ -112,53 +67,10 @@ func NewP256Scalar() *P256Scalar {
 	return &P256Scalar{}
 }
 
-func (k P256Scalar) Add(x, y *big.Int) *big.Int {
-	v := new(big.Int).Add(x, y)
-	v.Mod(v, elliptic.P256().Params().N)
-	return v
-}
-
-func (k P256Scalar) Sub(x, y *big.Int) *big.Int {
-	v := new(big.Int).Sub(x, y)
-	v.Mod(v, elliptic.P256().Params().N)
-	return v
-}
-
-func (k P256Scalar) Neg(x *big.Int) *big.Int {
-	v := new(big.Int).Sub(elliptic.P256().Params().N, x)
-	v.Mod(v, elliptic.P256().Params().N)
-	return v
-}
-
-func (k P256Scalar) Mul(x, y *big.Int) *big.Int {
-	v := new(big.Int).Mul(x, y)
-	v.Mod(v, elliptic.P256().Params().N)
-	return v
-}
-
-func (k P256Scalar) Div(x, y *big.Int) *big.Int {
-	t := new(big.Int).ModInverse(y, elliptic.P256().Params().N)
-	return k.Mul(x, t)
-}
-
 func (k P256Scalar) Hash(input []byte) *big.Int {
 	return new(ScalarP256).Hash(input).BigInt()
 }
 
-func (k P256Scalar) Random() (*big.Int, error) {
-	b := make([]byte, 48)
-	n, err := crand.Read(b)
-	if err != nil {
-		return nil, err
-	}
-	if n != 48 {
-		return nil, fmt.Errorf("insufficient bytes read")
-	}
-	v := new(big.Int).SetBytes(b)
-	v.Mod(v, elliptic.P256().Params().N)
-	return v, nil
-}
-
 func (k P256Scalar) IsValid(x *big.Int) bool {
 	return core.In(x, elliptic.P256().Params().N) == nil
 }
@@ -0,0 +1,118 @@
+//go:build hardened
+
+package curves
+
+import (
+	crand "crypto/rand"
+	"fmt"
+	"math/big"
+
+	"github.com/go-sonr/crypto/core/curves/native"
+	"github.com/go-sonr/crypto/core/curves/native/k256/fq"
+	p256fq "github.com/go-sonr/crypto/core/curves/native/p256/fq"
+	"github.com/go-sonr/crypto/internal"
+)
+
+// HardenedScalars reports whether K256Scalar and P256Scalar are backed by the
+// constant-time field arithmetic. Build with -tags hardened to enable it.
+const HardenedScalars = true
+
+// hardenedFieldFromBigInt loads x into f without reducing it through big.Int.
+// Values up to 512 bits are reduced with the Montgomery wide reduction; only the
+// sign and the bit length of x, never its bits, select the path taken.
+func hardenedFieldFromBigInt(f *native.Field, x *big.Int) *native.Field {
+	if x.Sign() < 0 || x.BitLen() > 8*native.WideFieldBytes {
+		return f.SetBigInt(x)
+	}
+	var wide [native.WideFieldBytes]byte
+	x.FillBytes(wide[:])
+	copy(wide[:], internal.ReverseScalarBytes(wide[:]))
+	return f.SetBytesWide(&wide)
+}
+
+// hardenedRandom reads 64 bytes of entropy and reduces them into f.
+func hardenedRandom(f *native.Field) (*big.Int, error) {
+	var wide [native.WideFieldBytes]byte
+	n, err := crand.Read(wide[:])
+	if err != nil {
+		return nil, err
+	}
+	if n != native.WideFieldBytes {
+		return nil, fmt.Errorf("insufficient bytes read")
+	}
+	return f.SetBytesWide(&wide).BigInt(), nil
+}
+
+func (k K256Scalar) Add(x, y *big.Int) *big.Int {
+	a := hardenedFieldFromBigInt(fq.K256FqNew(), x)
+	b := hardenedFieldFromBigInt(fq.K256FqNew(), y)
+	return a.Add(a, b).BigInt()
+}
+
+func (k K256Scalar) Sub(x, y *big.Int) *big.Int {
+	a := hardenedFieldFromBigInt(fq.K256FqNew(), x)
+	b := hardenedFieldFromBigInt(fq.K256FqNew(), y)
+	return a.Sub(a, b).BigInt()
+}
+
+func (k K256Scalar) Neg(x *big.Int) *big.Int {
+	a := hardenedFieldFromBigInt(fq.K256FqNew(), x)
+	return a.Neg(a).BigInt()
+}
+
+func (k K256Scalar) Mul(x, y *big.Int) *big.Int {
+	a := hardenedFieldFromBigInt(fq.K256FqNew(), x)
+	b := hardenedFieldFromBigInt(fq.K256FqNew(), y)
+	return a.Mul(a, b).BigInt()
+}
+
+func (k K256Scalar) Div(x, y *big.Int) *big.Int {
+	a := hardenedFieldFromBigInt(fq.K256FqNew(), x)
+	b := hardenedFieldFromBigInt(fq.K256FqNew(), y)
+	t, wasInverted := fq.K256FqNew().Invert(b)
+	if !wasInverted {
+		return nil
+	}
+	return a.Mul(a, t).BigInt()
+}
+
+func (k K256Scalar) Random() (*big.Int, error) {
+	return hardenedRandom(fq.K256FqNew())
+}
+
+func (k P256Scalar) Add(x, y *big.Int) *big.Int {
+	a := hardenedFieldFromBigInt(p256fq.P256FqNew(), x)
+	b := hardenedFieldFromBigInt(p256fq.P256FqNew(), y)
+	return a.Add(a, b).BigInt()
+}
+
+func (k P256Scalar) Sub(x, y *big.Int) *big.Int {
+	a := hardenedFieldFromBigInt(p256fq.P256FqNew(), x)
+	b := hardenedFieldFromBigInt(p256fq.P256FqNew(), y)
+	return a.Sub(a, b).BigInt()
+}
+
+func (k P256Scalar) Neg(x *big.Int) *big.Int {
+	a := hardenedFieldFromBigInt(p256fq.P256FqNew(), x)
+	return a.Neg(a).BigInt()
+}
+
+func (k P256Scalar) Mul(x, y *big.Int) *big.Int {
+	a := hardenedFieldFromBigInt(p256fq.P256FqNew(), x)
+	b := hardenedFieldFromBigInt(p256fq.P256FqNew(), y)
+	return a.Mul(a, b).BigInt()
+}
+
+func (k P256Scalar) Div(x, y *big.Int) *big.Int {
+	a := hardenedFieldFromBigInt(p256fq.P256FqNew(), x)
+	b := hardenedFieldFromBigInt(p256fq.P256FqNew(), y)
+	t, wasInverted := p256fq.P256FqNew().Invert(b)
+	if !wasInverted {
+		return nil
+	}
+	return a.Mul(a, t).BigInt()
+}
+
+func (k P256Scalar) Random() (*big.Int, error) {
+	return hardenedRandom(p256fq.P256FqNew())
+}
@@ -0,0 +1,109 @@
+package curves
+
+import (
+	"crypto/elliptic"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func testEcScalarArithmetic(t *testing.T, s EcScalar, n *big.Int) {
+	t.Helper()
+	for i := 0; i < 25; i++ {
+		x, err := s.Random()
+		require.NoError(t, err)
+		y, err := s.Random()
+		require.NoError(t, err)
+		require.True(t, s.IsValid(x))
+
+		exp := new(big.Int).Add(x, y)
+		require.Equal(t, 0, exp.Mod(exp, n).Cmp(s.Add(x, y)))
+		exp = new(big.Int).Sub(x, y)
+		require.Equal(t, 0, exp.Mod(exp, n).Cmp(s.Sub(x, y)))
+		exp = new(big.Int).Neg(x)
+		require.Equal(t, 0, exp.Mod(exp, n).Cmp(s.Neg(x)))
+		exp = new(big.Int).Mul(x, y)
+		require.Equal(t, 0, exp.Mod(exp, n).Cmp(s.Mul(x, y)))
+		exp = new(big.Int).ModInverse(y, n)
+		exp.Mul(exp, x)
+		require.Equal(t, 0, exp.Mod(exp, n).Cmp(s.Div(x, y)))
+	}
+
+	// Unreduced inputs are accepted by both backends
+	wide := new(big.Int).Lsh(n, 200)
+	wide.Add(wide, big.NewInt(5))
+	require.Equal(t, 0, big.NewInt(10).Cmp(s.Add(wide, wide)))
+}
+
+func TestEcScalarK256Arithmetic(t *testing.T) {
+	testEcScalarArithmetic(t, NewK256Scalar(), btcec.S256().N)
+}
+
+func TestEcScalarP256Arithmetic(t *testing.T) {
+	testEcScalarArithmetic(t, NewP256Scalar(), elliptic.P256().Params().N)
+}
+
+// variableTimeBigIntMethods are the big.Int operations whose running time
+// depends on the value of their operands.
+var variableTimeBigIntMethods = map[string]bool{
+	"Add": true, "Sub": true, "Mul": true, "Neg": true,
+	"Mod": true, "ModInverse": true, "ModSqrt": true, "Exp": true,
+	"Div": true, "DivMod": true, "Quo": true, "QuoRem": true, "Rem": true,
+	"GCD": true, "Sqrt": true, "Cmp": true, "CmpAbs": true,
+}
+
+// TestHardenedPathsAvoidVariableTimeBigInt audits the sources of the hardened
+// scalar backend and the k256/p256 field arithmetic it relies on.
+func TestHardenedPathsAvoidVariableTimeBigInt(t *testing.T) {
+	allowed := map[string]bool{
+		// Fallback for negative or wider than 512-bit inputs only
+		"SetBigInt": true,
+	}
+	files := []string{
+		"ec_scalar_hardened.go",
+		"native/field.go",
+		"native/k256/fq/fq.go",
+		"native/k256/fp/fp.go",
+		"native/p256/fq/fq.go",
+		"native/p256/fp/fp.go",
+	}
+	for _, name := range files {
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, name, nil, 0)
+		require.NoError(t, err)
+
+		info := &types.Info{Types: map[ast.Expr]types.TypeAndValue{}}
+		conf := types.Config{Importer: importer.Default(), Error: func(error) {}}
+		_, _ = conf.Check(file.Name.Name, fset, []*ast.File{file}, info)
+
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil || allowed[fn.Name.Name] {
+				continue
+			}
+			ast.Inspect(fn.Body, func(node ast.Node) bool {
+				call, ok := node.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				sel, ok := call.Fun.(*ast.SelectorExpr)
+				if !ok || !variableTimeBigIntMethods[sel.Sel.Name] {
+					return true
+				}
+				tv, ok := info.Types[sel.X]
+				if ok && types.TypeString(tv.Type, nil) == "*math/big.Int" {
+					t.Errorf("%s: %s calls variable-time big.Int.%s",
+						fset.Position(call.Pos()), fn.Name.Name, sel.Sel.Name)
+				}
+				return true
+			})
+		}
+	}
+}
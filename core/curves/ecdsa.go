@@ -9,6 +9,9 @@ package curves
 import (
 	"crypto/ecdsa"
 	"math/big"
+	"runtime"
+	"sync"
+	"sync/atomic"
 )
 
 // EcdsaVerify runs a curve- or algorithm-specific ECDSA verification function on input
@@ -37,3 +40,41 @@ func VerifyEcdsa(pk *EcPoint, hash []byte, sig *EcdsaSignature) bool {
 		},
 		hash, sig.R, sig.S)
 }
+
+// BatchVerifyEcdsa checks every signature in parallel with verify, VerifyEcdsa
+// if nil, and returns true only if all of them are valid. ECDSA has no
+// algebraic batch check, so the work is split across runtime.GOMAXPROCS(0)
+// goroutines which stop early once an invalid signature is found.
+func BatchVerifyEcdsa(verify EcdsaVerify, pks []*EcPoint, hashes [][]byte, sigs []*EcdsaSignature) bool {
+	if len(pks) != len(hashes) || len(pks) != len(sigs) {
+		return false
+	}
+	if verify == nil {
+		verify = VerifyEcdsa
+	}
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(sigs) {
+		workers = len(sigs)
+	}
+
+	var next atomic.Int64
+	var failed atomic.Bool
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for !failed.Load() {
+				i := int(next.Add(1) - 1)
+				if i >= len(sigs) {
+					return
+				}
+				if pks[i] == nil || sigs[i] == nil || !verify(pks[i], hashes[i], sigs[i]) {
+					failed.Store(true)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return !failed.Load()
+}
@@ -0,0 +1,37 @@
+package curves
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	crand "crypto/rand"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchVerifyEcdsa(t *testing.T) {
+	const n = 50
+	pks := make([]*EcPoint, n)
+	hashes := make([][]byte, n)
+	sigs := make([]*EcdsaSignature, n)
+	for i := 0; i < n; i++ {
+		sk, err := ecdsa.GenerateKey(elliptic.P256(), crand.Reader)
+		require.NoError(t, err)
+		digest := sha256.Sum256([]byte{byte(i)})
+		r, s, err := ecdsa.Sign(crand.Reader, sk, digest[:])
+		require.NoError(t, err)
+		pks[i] = &EcPoint{Curve: sk.Curve, X: sk.X, Y: sk.Y}
+		hashes[i] = digest[:]
+		sigs[i] = &EcdsaSignature{R: r, S: s}
+	}
+	require.True(t, BatchVerifyEcdsa(nil, pks, hashes, sigs))
+	require.True(t, BatchVerifyEcdsa(VerifyEcdsa, nil, nil, nil))
+	require.False(t, BatchVerifyEcdsa(nil, pks[1:], hashes, sigs))
+
+	hashes[n-1] = hashes[0]
+	require.False(t, BatchVerifyEcdsa(nil, pks, hashes, sigs))
+	hashes[n-1] = nil
+	sigs[n-1] = nil
+	require.False(t, BatchVerifyEcdsa(nil, pks, hashes, sigs))
+}
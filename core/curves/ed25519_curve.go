@@ -242,6 +242,13 @@ func (s *ScalarEd25519) Bytes() []byte {
 	return s.value.Bytes()
 }
 
+// Zeroize overwrites the scalar with zero
+func (s *ScalarEd25519) Zeroize() {
+	if s.value != nil {
+		s.value.Set(edwards25519.NewScalar())
+	}
+}
+
 // SetBytes takes input a 32-byte long array and returns a ed25519 scalar.
 // The input must be 32-byte long and must be a reduced bytes.
 func (s *ScalarEd25519) SetBytes(input []byte) (Scalar, error) {
@@ -630,6 +637,31 @@ func (p *PointEd25519) SumOfProducts(points []Point, scalars []Scalar) Point {
 	return &PointEd25519{value: pt}
 }
 
+// VarTimeSumOfProducts is SumOfProducts in variable time, for public inputs only
+func (p *PointEd25519) VarTimeSumOfProducts(points []Point, scalars []Scalar) Point {
+	if len(points) != len(scalars) {
+		return nil
+	}
+	nScalars := make([]*edwards25519.Scalar, len(scalars))
+	nPoints := make([]*edwards25519.Point, len(points))
+	for i, sc := range scalars {
+		s, ok := sc.(*ScalarEd25519)
+		if !ok {
+			return nil
+		}
+		nScalars[i] = s.value
+	}
+	for i, pt := range points {
+		pp, ok := pt.(*PointEd25519)
+		if !ok {
+			return nil
+		}
+		nPoints[i] = pp.value
+	}
+	pt := edwards25519.NewIdentityPoint().VarTimeMultiScalarMult(nScalars, nPoints)
+	return &PointEd25519{value: pt}
+}
+
 func (p *PointEd25519) VarTimeDoubleScalarBaseMult(a Scalar, A Point, b Scalar) Point {
 	AA, ok := A.(*PointEd25519)
 	if !ok {
@@ -0,0 +1,175 @@
+package curves
+
+import (
+	"fmt"
+	"math/big"
+
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+
+	"github.com/go-sonr/crypto/core/curves/native"
+	"github.com/go-sonr/crypto/core/curves/native/bls12381"
+	secp256k1 "github.com/go-sonr/crypto/core/curves/native/k256"
+	p256n "github.com/go-sonr/crypto/core/curves/native/p256"
+	"github.com/go-sonr/crypto/internal"
+)
+
+// HashToPoint hashes msg to a point with the random oracle encoding of RFC 9380
+// https://www.rfc-editor.org/rfc/rfc9380.html using the domain separation tag dst.
+// The suites are
+//   - secp256k1: secp256k1_XMD:SHA-256_SSWU_RO_
+//   - P-256: P256_XMD:SHA-256_SSWU_RO_
+//   - ed25519: edwards25519_XMD:SHA-512_ELL2_RO_
+//   - ristretto255: hash_to_ristretto255 with expand_message_xmd and SHA-512
+//   - decaf448: hash_to_decaf448 with expand_message_xof and SHAKE256
+//   - BLS12-381: BLS12381G1_XMD:SHA-256_SSWU_RO_ and BLS12381G2_XMD:SHA-256_SSWU_RO_
+func (c Curve) HashToPoint(msg, dst []byte) (Point, error) {
+	if len(dst) == 0 {
+		return nil, fmt.Errorf("domain separation tag cannot be empty")
+	}
+	switch c.Name {
+	case K256Name:
+		value := secp256k1.K256PointNew()
+		if err := value.Arithmetic.Hash(value, native.EllipticPointHasherSha256(), msg, dst); err != nil {
+			return nil, err
+		}
+		return &PointK256{value}, nil
+	case P256Name:
+		value := p256n.P256PointNew()
+		if err := value.Arithmetic.Hash(value, native.EllipticPointHasherSha256(), msg, dst); err != nil {
+			return nil, err
+		}
+		return &PointP256{value}, nil
+	case ED25519Name:
+		return hashToEdwards25519(msg, dst), nil
+	case Ristretto255Name:
+		return hashToRistretto255(msg, dst), nil
+	case Decaf448Name:
+		return hashToDecaf448(msg, dst), nil
+	case BLS12381G1Name:
+		return &PointBls12381G1{new(bls12381.G1).Hash(native.EllipticPointHasherSha256(), msg, dst)}, nil
+	case BLS12381G2Name:
+		return &PointBls12381G2{new(bls12381.G2).Hash(native.EllipticPointHasherSha256(), msg, dst)}, nil
+	default:
+		return nil, fmt.Errorf("hash to curve is not supported for %s", c.Name)
+	}
+}
+
+var (
+	// curve25519Prime is 2^255 - 19
+	curve25519Prime, _ = new(big.Int).SetString("7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffed", 16)
+
+	ell2J      = bigToFieldElement(big.NewInt(486662))
+	ell2SqrtM1 = bigToFieldElement(new(big.Int).Exp(big.NewInt(2), new(big.Int).Rsh(new(big.Int).Sub(curve25519Prime, big.NewInt(1)), 2), curve25519Prime))
+	// ell2C2 is 2^((p + 3) / 8)
+	ell2C2 = bigToFieldElement(new(big.Int).Exp(big.NewInt(2), new(big.Int).Rsh(new(big.Int).Add(curve25519Prime, big.NewInt(3)), 3), curve25519Prime))
+	// ell2SqrtNegA2 is sqrt(-486664) with sgn0 equal to 0
+	ell2SqrtNegA2 = func() *field.Element {
+		v := new(big.Int).ModSqrt(new(big.Int).Sub(curve25519Prime, big.NewInt(486664)), curve25519Prime)
+		if v.Bit(0) == 1 {
+			v.Sub(curve25519Prime, v)
+		}
+		return bigToFieldElement(v)
+	}()
+)
+
+// hashToEdwards25519 is edwards25519_XMD:SHA-512_ELL2_RO_ of RFC 9380 section 6.8.2
+func hashToEdwards25519(msg, dst []byte) *PointEd25519 {
+	u := native.ExpandMsgXmd(native.EllipticPointHasherSha512(), msg, dst, 96)
+	q0 := mapToEdwards25519(hashToCurve25519Field(u[:48]))
+	q1 := mapToEdwards25519(hashToCurve25519Field(u[48:]))
+	p := new(edwards25519.Point).Add(q0, q1)
+	return &PointEd25519{value: p.MultByCofactor(p)}
+}
+
+// hashToCurve25519Field reduces a 48 byte big endian string modulo 2^255 - 19
+func hashToCurve25519Field(b []byte) *field.Element {
+	var wide [64]byte
+	copy(wide[:], internal.ReverseScalarBytes(b))
+	fe, _ := new(field.Element).SetWideBytes(wide[:])
+	return fe
+}
+
+// mapToEdwards25519 is the straight line map_to_curve_elligator2_edwards25519 of RFC 9380 appendix G.2.2
+func mapToEdwards25519(u *field.Element) *edwards25519.Point {
+	xMn, xMd, yMn, yMd := mapToCurve25519(u)
+	one := new(field.Element).One()
+
+	xn := new(field.Element).Multiply(xMn, yMd)
+	xn.Multiply(xn, ell2SqrtNegA2)
+	xd := new(field.Element).Multiply(xMd, yMn)
+	yn := new(field.Element).Subtract(xMn, xMd)
+	yd := new(field.Element).Add(xMn, xMd)
+	tv1 := new(field.Element).Multiply(xd, yd)
+	e := tv1.Equal(new(field.Element).Zero())
+	xn.Select(new(field.Element).Zero(), xn, e)
+	xd.Select(one, xd, e)
+	yn.Select(one, yn, e)
+	yd.Select(one, yd, e)
+
+	// extended coordinates of (xn / xd, yn / yd)
+	x := new(field.Element).Multiply(xn, yd)
+	y := new(field.Element).Multiply(yn, xd)
+	z := new(field.Element).Multiply(xd, yd)
+	t := new(field.Element).Multiply(xn, yn)
+	p, err := new(edwards25519.Point).SetExtendedCoordinates(x, y, z, t)
+	if err != nil {
+		// the map always lands on the curve
+		panic(err)
+	}
+	return p
+}
+
+// mapToCurve25519 is the straight line map_to_curve_elligator2_curve25519 of RFC 9380 appendix G.2.1
+func mapToCurve25519(u *field.Element) (xn, xd, y, yd *field.Element) {
+	one := new(field.Element).One()
+	tv1 := new(field.Element).Square(u)
+	tv1.Add(tv1, tv1)
+	xd = new(field.Element).Add(tv1, one)
+	x1n := new(field.Element).Negate(ell2J)
+	tv2 := new(field.Element).Square(xd)
+	gxd := new(field.Element).Multiply(tv2, xd)
+	gx1 := new(field.Element).Multiply(ell2J, tv1)
+	gx1.Multiply(gx1, x1n)
+	gx1.Add(gx1, tv2)
+	gx1.Multiply(gx1, x1n)
+	tv3 := new(field.Element).Square(gxd)
+	tv2.Square(tv3)
+	tv3.Multiply(tv3, gxd)
+	tv3.Multiply(tv3, gx1)
+	tv2.Multiply(tv2, tv3)
+	y11 := new(field.Element).Pow22523(tv2)
+	y11.Multiply(y11, tv3)
+	y12 := new(field.Element).Multiply(y11, ell2SqrtM1)
+	tv2.Square(y11)
+	tv2.Multiply(tv2, gxd)
+	e1 := tv2.Equal(gx1)
+	y1 := new(field.Element).Select(y11, y12, e1)
+	x2n := new(field.Element).Multiply(x1n, tv1)
+	y21 := new(field.Element).Multiply(y11, u)
+	y21.Multiply(y21, ell2C2)
+	y22 := new(field.Element).Multiply(y21, ell2SqrtM1)
+	gx2 := new(field.Element).Multiply(gx1, tv1)
+	tv2.Square(y21)
+	tv2.Multiply(tv2, gxd)
+	e2 := tv2.Equal(gx2)
+	y2 := new(field.Element).Select(y21, y22, e2)
+	tv2.Square(y1)
+	tv2.Multiply(tv2, gxd)
+	e3 := tv2.Equal(gx1)
+	xn = new(field.Element).Select(x1n, x2n, e3)
+	y = new(field.Element).Select(y1, y2, e3)
+	e4 := y.IsNegative()
+	y.Select(new(field.Element).Negate(y), y, e3^e4)
+	return xn, xd, y, one
+}
+
+func bigToFieldElement(v *big.Int) *field.Element {
+	var buf [32]byte
+	v.FillBytes(buf[:])
+	fe, err := new(field.Element).SetBytes(internal.ReverseScalarBytes(buf[:]))
+	if err != nil {
+		panic(err)
+	}
+	return fe
+}
@@ -0,0 +1,100 @@
+package curves
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Test vectors from RFC 9380 appendix J
+func TestHashToPointVectors(t *testing.T) {
+	tests := []struct {
+		curve *Curve
+		dst   string
+		msg   string
+		x, y  string
+	}{
+		{
+			curve: K256(),
+			dst:   "QUUX-V01-CS02-with-secp256k1_XMD:SHA-256_SSWU_RO_",
+			msg:   "",
+			x:     "c1cae290e291aee617ebaef1be6d73861479c48b841eaba9b7b5852ddfeb1346",
+			y:     "64fa678e07ae116126f08b022a94af6de15985c996c3a91b64c406a960e51067",
+		},
+		{
+			curve: P256(),
+			dst:   "QUUX-V01-CS02-with-P256_XMD:SHA-256_SSWU_RO_",
+			msg:   "",
+			x:     "2c15230b26dbc6fc9a37051158c95b79656e17a1a920b11394ca91c44247d3e4",
+			y:     "8a7a74985cc5c776cdfe4b1f19884970453912e9d31528c060be9ab5c43e8415",
+		},
+		{
+			curve: ED25519(),
+			dst:   "QUUX-V01-CS02-with-edwards25519_XMD:SHA-512_ELL2_RO_",
+			msg:   "",
+			x:     "3c3da6925a3c3c268448dcabb47ccde5439559d9599646a8260e47b1e4822fc6",
+			y:     "09a6c8561a0b22bef63124c588ce4c62ea83a3c899763af26d795302e115dc21",
+		},
+		{
+			curve: BLS12381G1(),
+			dst:   "QUUX-V01-CS02-with-BLS12381G1_XMD:SHA-256_SSWU_RO_",
+			msg:   "",
+			x:     "052926add2207b76ca4fa57a8734416c8dc95e24501772c814278700eed6d1e4e8cf62d9c09db0fac349612b759e79a1",
+			y:     "08ba738453bfed09cb546dbb0783dbb3a5f1f566ed67bb6be0e8c67e2e81a4cc68ee29813bb7994998f3eae0c9c6a265",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.curve.Name, func(t *testing.T) {
+			p, err := test.curve.HashToPoint([]byte(test.msg), []byte(test.dst))
+			require.NoError(t, err)
+			x, err := hex.DecodeString(test.x)
+			require.NoError(t, err)
+			y, err := hex.DecodeString(test.y)
+			require.NoError(t, err)
+
+			switch test.curve.Name {
+			case ED25519Name:
+				// little endian y with the parity of x in the top bit
+				expected := make([]byte, len(y))
+				for i := range y {
+					expected[i] = y[len(y)-1-i]
+				}
+				expected[31] |= (x[len(x)-1] & 1) << 7
+				require.Equal(t, expected, p.ToAffineCompressed())
+			case BLS12381G1Name:
+				require.Equal(t, append(x, y...), p.ToAffineUncompressed())
+			default:
+				require.Equal(t, append(append([]byte{4}, x...), y...), p.ToAffineUncompressed())
+			}
+		})
+	}
+}
+
+func TestHashToPoint(t *testing.T) {
+	dst := []byte("go-sonr-test")
+	for _, curve := range []*Curve{K256(), P256(), ED25519(), DECAF448(), BLS12381G1(), BLS12381G2()} {
+		p1, err := curve.HashToPoint([]byte("a"), dst)
+		require.NoError(t, err)
+		require.True(t, p1.IsOnCurve())
+		require.False(t, p1.IsIdentity())
+
+		p2, err := curve.HashToPoint([]byte("a"), dst)
+		require.NoError(t, err)
+		require.True(t, p1.Equal(p2))
+
+		p3, err := curve.HashToPoint([]byte("b"), dst)
+		require.NoError(t, err)
+		require.False(t, p1.Equal(p3))
+
+		p4, err := curve.HashToPoint([]byte("a"), []byte("other-dst"))
+		require.NoError(t, err)
+		require.False(t, p1.Equal(p4))
+
+		_, err = curve.HashToPoint([]byte("a"), nil)
+		require.Error(t, err)
+	}
+
+	_, err := PALLAS().HashToPoint([]byte("a"), dst)
+	require.Error(t, err)
+}
@@ -294,6 +294,13 @@ func (s *ScalarK256) Bytes() []byte {
 	return internal.ReverseScalarBytes(t[:])
 }
 
+// Zeroize overwrites the scalar with zero
+func (s *ScalarK256) Zeroize() {
+	if s.value != nil {
+		s.value.SetZero()
+	}
+}
+
 func (s *ScalarK256) SetBytes(bytes []byte) (Scalar, error) {
 	if len(bytes) != 32 {
 		return nil, fmt.Errorf("invalid length")
@@ -15,6 +15,8 @@ const (
 	FieldBytes           = 48
 	WideFieldBytes       = 96
 	DoubleWideFieldBytes = 192
+	// scalarBits is the bit length of the scalar field modulus
+	scalarBits = 255
 )
 
 // mac Multiply and Accumulate - compute a + (b * c) + d, return the result and new carry
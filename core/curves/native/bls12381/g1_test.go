@@ -410,3 +410,38 @@ func TestSumOfProducts(t *testing.T) {
 	_, _ = rhs.SumOfProducts([]*G1{u, h0}, []*native.Field{c, sHat})
 	require.Equal(t, 1, uTilde.Equal(rhs))
 }
+
+func TestG1SumOfProductsWindows(t *testing.T) {
+	var b [64]byte
+	for _, n := range []int{0, 2, 5, 40, 300} {
+		points := make([]*G1, n)
+		scalars := make([]*native.Field, n)
+		expected := new(G1).Identity()
+		for i := range points {
+			points[i], _ = new(G1).Random(crand.Reader)
+			_, _ = crand.Read(b[:])
+			scalars[i] = Bls12381FqNew().SetBytesWide(&b)
+			expected.Add(expected, new(G1).Mul(points[i], scalars[i]))
+		}
+		actual, err := new(G1).SumOfProducts(points, scalars)
+		require.NoError(t, err)
+		require.Equal(t, 1, expected.Equal(actual))
+	}
+	_, err := new(G1).SumOfProducts([]*G1{new(G1).Generator()}, nil)
+	require.Error(t, err)
+}
+
+func BenchmarkG1SumOfProducts(b *testing.B) {
+	var seed [64]byte
+	points := make([]*G1, 256)
+	scalars := make([]*native.Field, len(points))
+	for i := range points {
+		points[i], _ = new(G1).Random(crand.Reader)
+		_, _ = crand.Read(seed[:])
+		scalars[i] = Bls12381FqNew().SetBytesWide(&seed)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = new(G1).SumOfProducts(points, scalars)
+	}
+}
@@ -969,45 +969,31 @@ func (g2 *G2) CMove(arg1, arg2 *G2, choice int) *G2 {
 
 // SumOfProducts computes the multi-exponentiation for the specified
 // points and scalars and stores the result in `g2`.
+// It uses Pippenger's bucket method with a window chosen from the number of terms.
 // Returns an error if the lengths of the arguments is not equal.
 func (g2 *G2) SumOfProducts(points []*G2, scalars []*native.Field) (*G2, error) {
-	const Upper = 256
-	const W = 4
-	const Windows = Upper / W // careful--use ceiling division in case this doesn't divide evenly
-	var sum G2
 	if len(points) != len(scalars) {
 		return nil, fmt.Errorf("length mismatch")
 	}
+	w := native.PippengerWindow(len(points), scalarBits)
+	digits := native.PippengerDigits(scalars, w, scalarBits)
+	windows := make([]G2, (scalarBits+w-1)/w)
+	buckets := make([]G2, 1<<w)
+	var sum G2
 
-	bucketSize := 1 << W
-	windows := make([]G2, Windows)
-	bytes := make([][32]byte, len(scalars))
-	buckets := make([]G2, bucketSize)
-	for i := 0; i < len(windows); i++ {
-		windows[i].Identity()
-	}
-
-	for i, scalar := range scalars {
-		bytes[i] = scalar.Bytes()
-	}
-
-	for j := 0; j < len(windows); j++ {
-		for i := 0; i < bucketSize; i++ {
+	for j := range windows {
+		for i := range buckets {
 			buckets[i].Identity()
 		}
-
-		for i := 0; i < len(scalars); i++ {
-			// j*W to get the nibble
-			// >> 3 to convert to byte, / 8
-			// (W * j & W) gets the nibble, mod W
-			// 1 << W - 1 to get the offset
-			index := bytes[i][j*W>>3] >> (W * j & W) & (1<<W - 1) // little-endian
+		for i := range points {
+			index := digits[i][j]
 			buckets[index].Add(&buckets[index], points[i])
 		}
 
+		// windows[j] = sum_i i * buckets[i]
+		windows[j].Identity()
 		sum.Identity()
-
-		for i := bucketSize - 1; i > 0; i-- {
+		for i := len(buckets) - 1; i > 0; i-- {
 			sum.Add(&sum, &buckets[i])
 			windows[j].Add(&windows[j], &sum)
 		}
@@ -1015,10 +1001,9 @@ func (g2 *G2) SumOfProducts(points []*G2, scalars []*native.Field) (*G2, error)
 
 	g2.Identity()
 	for i := len(windows) - 1; i >= 0; i-- {
-		for j := 0; j < W; j++ {
+		for j := 0; j < w; j++ {
 			g2.Double(g2)
 		}
-
 		g2.Add(g2, &windows[i])
 	}
 	return g2, nil
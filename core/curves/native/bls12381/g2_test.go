@@ -552,3 +552,38 @@ func TestG2SumOfProducts(t *testing.T) {
 	_, _ = rhs.SumOfProducts([]*G2{u, h0}, []*native.Field{c, sHat})
 	require.Equal(t, 1, uTilde.Equal(rhs))
 }
+
+func TestG2SumOfProductsWindows(t *testing.T) {
+	var b [64]byte
+	for _, n := range []int{0, 2, 5, 40, 300} {
+		points := make([]*G2, n)
+		scalars := make([]*native.Field, n)
+		expected := new(G2).Identity()
+		for i := range points {
+			points[i], _ = new(G2).Random(crand.Reader)
+			_, _ = crand.Read(b[:])
+			scalars[i] = Bls12381FqNew().SetBytesWide(&b)
+			expected.Add(expected, new(G2).Mul(points[i], scalars[i]))
+		}
+		actual, err := new(G2).SumOfProducts(points, scalars)
+		require.NoError(t, err)
+		require.Equal(t, 1, expected.Equal(actual))
+	}
+	_, err := new(G2).SumOfProducts([]*G2{new(G2).Generator()}, nil)
+	require.Error(t, err)
+}
+
+func BenchmarkG2SumOfProducts(b *testing.B) {
+	var seed [64]byte
+	points := make([]*G2, 256)
+	scalars := make([]*native.Field, len(points))
+	for i := range points {
+		points[i], _ = new(G2).Random(crand.Reader)
+		_, _ = crand.Read(seed[:])
+		scalars[i] = Bls12381FqNew().SetBytesWide(&seed)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = new(G2).SumOfProducts(points, scalars)
+	}
+}
@@ -1,5 +1,10 @@
 package bls12381
 
+import (
+	"fmt"
+	"io"
+)
+
 const coefficientsG2 = 68
 
 type Engine struct {
@@ -52,6 +57,28 @@ func (e *Engine) AddPairInvG2(g1 *G1, g2 *G2) *Engine {
 	return e.AddPair(g1, &p)
 }
 
+// BatchCheck reports whether the pairing product of every engine is one.
+// Each engine is scaled by a random scalar read from reader so all of them
+// share a single Miller loop and final exponentiation. An engine whose
+// product is not one makes the check fail except with negligible probability.
+func BatchCheck(reader io.Reader, engines ...*Engine) (bool, error) {
+	var combined Engine
+	for _, e := range engines {
+		var seed [64]byte
+		if _, err := io.ReadFull(reader, seed[:]); err != nil {
+			return false, err
+		}
+		r := Bls12381FqNew().SetBytesWide(&seed)
+		if r.IsZero() == 1 {
+			return false, fmt.Errorf("invalid random scalar")
+		}
+		for i := range e.pairs {
+			combined.AddPair(new(G1).Mul(&e.pairs[i].g1, r), &e.pairs[i].g2)
+		}
+	}
+	return combined.Check(), nil
+}
+
 func (e *Engine) Reset() *Engine {
 	e.pairs = []pair{}
 	return e
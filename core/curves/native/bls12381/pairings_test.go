@@ -1,6 +1,7 @@
 package bls12381
 
 import (
+	"bytes"
 	crand "crypto/rand"
 	"testing"
 
@@ -62,3 +63,34 @@ func TestMultiPairing(t *testing.T) {
 	actual := e2.Result()
 	require.Equal(t, 1, expected.Equal(actual))
 }
+
+func TestBatchCheck(t *testing.T) {
+	const Tests = 5
+	engines := make([]*Engine, Tests)
+	for i := range engines {
+		var seed [64]byte
+		_, _ = crand.Read(seed[:])
+		s := Bls12381FqNew().SetBytesWide(&seed)
+		// e(s*G1, G2) * e(-G1, s*G2) = 1
+		engines[i] = new(Engine).
+			AddPair(new(G1).Mul(new(G1).Generator(), s), new(G2).Generator()).
+			AddPairInvG1(new(G1).Generator(), new(G2).Mul(new(G2).Generator(), s))
+		require.True(t, engines[i].Check())
+	}
+	ok, err := BatchCheck(crand.Reader, engines...)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = BatchCheck(crand.Reader)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// A single false equation fails the whole batch
+	engines[2].AddPair(new(G1).Generator(), new(G2).Generator())
+	ok, err = BatchCheck(crand.Reader, engines...)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, err = BatchCheck(bytes.NewReader(nil), engines...)
+	require.Error(t, err)
+}
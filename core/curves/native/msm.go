@@ -0,0 +1,37 @@
+package native
+
+// PippengerWindow returns the bucket window width for a multi-scalar
+// multiplication of n terms with scalars of the given bit length. Each of
+// the bits/w windows costs n additions to fill the buckets and 2^(w+1) to
+// sum them, pick the w minimizing the total.
+func PippengerWindow(n, bits int) int {
+	best, bestCost := 1, 0
+	for w := 1; w <= 16; w++ {
+		cost := (bits + w - 1) / w * (n + 1<<(w+1))
+		if w == 1 || cost < bestCost {
+			best, bestCost = w, cost
+		}
+	}
+	return best
+}
+
+// PippengerDigits splits the first bits of each scalar into little-endian
+// windows of w bits
+func PippengerDigits(scalars []*Field, w, bits int) [][]uint32 {
+	windows := (bits + w - 1) / w
+	digits := make([][]uint32, len(scalars))
+	for i, s := range scalars {
+		b := s.Bytes()
+		digits[i] = make([]uint32, windows)
+		for j := 0; j < windows; j++ {
+			offset := j * w
+			var v uint32
+			for k := 0; k < w && offset+k < bits; k++ {
+				bit := offset + k
+				v |= uint32(b[bit>>3]>>(bit&7)&1) << k
+			}
+			digits[i][j] = v
+		}
+	}
+	return digits
+}
@@ -78,6 +78,13 @@ func (fq *Fq) IsOne() bool {
 	return fq.Equal(r)
 }
 
+// IsOdd returns true if the canonical representation of fq is odd
+func (fq *Fq) IsOdd() bool {
+	tv := new(fiat_pasta_fq_non_montgomery_domain_field_element)
+	fiat_pasta_fq_from_montgomery(tv, (*fiat_pasta_fq_montgomery_domain_field_element)(fq))
+	return tv[0]&0x01 == 0x01
+}
+
 // Set fp == rhs
 func (fq *Fq) Set(rhs *Fq) *Fq {
 	fq[0] = rhs[0]
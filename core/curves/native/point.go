@@ -407,23 +407,20 @@ func (p *EllipticPoint) ToAffine(clone *EllipticPoint) *EllipticPoint {
 
 // SumOfProducts computes the multi-exponentiation for the specified
 // points and scalars and stores the result in `p`.
+// It uses Pippenger's bucket method with a window chosen from the number of terms.
 // Returns an error if the lengths of the arguments is not equal.
 func (p *EllipticPoint) SumOfProducts(points []*EllipticPoint, scalars []*Field) (*EllipticPoint, error) {
 	const Upper = 256
-	const W = 4
-	const Windows = Upper / W // careful--use ceiling division in case this doesn't divide evenly
 	if len(points) != len(scalars) {
 		return nil, fmt.Errorf("length mismatch")
 	}
 
-	bucketSize := 1 << W
-	windows := make([]*EllipticPoint, Windows)
-	bytes := make([][32]byte, len(scalars))
+	w := PippengerWindow(len(points), Upper)
+	digits := PippengerDigits(scalars, w, Upper)
+	bucketSize := 1 << w
+	windows := make([]*EllipticPoint, (Upper+w-1)/w)
 	buckets := make([]*EllipticPoint, bucketSize)
 
-	for i, scalar := range scalars {
-		bytes[i] = scalar.Bytes()
-	}
 	for i := range windows {
 		windows[i] = new(EllipticPoint).Set(p).Identity()
 	}
@@ -440,11 +437,7 @@ func (p *EllipticPoint) SumOfProducts(points []*EllipticPoint, scalars []*Field)
 		}
 
 		for i := 0; i < len(scalars); i++ {
-			// j*W to get the nibble
-			// >> 3 to convert to byte, / 8
-			// (W * j & W) gets the nibble, mod W
-			// 1 << W - 1 to get the offset
-			index := bytes[i][j*W>>3] >> (W * j & W) & (1<<W - 1) // little-endian
+			index := digits[i][j]
 			buckets[index].Add(buckets[index], points[i])
 		}
 
@@ -458,7 +451,7 @@ func (p *EllipticPoint) SumOfProducts(points []*EllipticPoint, scalars []*Field)
 
 	p.Identity()
 	for i := len(windows) - 1; i >= 0; i-- {
-		for j := 0; j < W; j++ {
+		for j := 0; j < w; j++ {
 			p.Double(p)
 		}
 
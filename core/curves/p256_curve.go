@@ -292,6 +292,13 @@ func (s *ScalarP256) Bytes() []byte {
 	return internal.ReverseScalarBytes(t[:])
 }
 
+// Zeroize overwrites the scalar with zero
+func (s *ScalarP256) Zeroize() {
+	if s.value != nil {
+		s.value.SetZero()
+	}
+}
+
 func (s *ScalarP256) SetBytes(bytes []byte) (Scalar, error) {
 	if len(bytes) != 32 {
 		return nil, fmt.Errorf("invalid length")
@@ -18,6 +18,7 @@ import (
 
 	"golang.org/x/crypto/blake2b"
 
+	"github.com/go-sonr/crypto/core/curves/native"
 	"github.com/go-sonr/crypto/core/curves/native/pasta/fp"
 	"github.com/go-sonr/crypto/core/curves/native/pasta/fq"
 )
@@ -401,6 +402,13 @@ func (s *ScalarPallas) Bytes() []byte {
 	return t[:]
 }
 
+// Zeroize overwrites the scalar with zero
+func (s *ScalarPallas) Zeroize() {
+	if s.value != nil {
+		s.value.SetZero()
+	}
+}
+
 func (s *ScalarPallas) SetBytes(bytes []byte) (Scalar, error) {
 	if len(bytes) != 32 {
 		return nil, fmt.Errorf("invalid length")
@@ -836,24 +844,48 @@ func (p *Ep) Sub(lhs, rhs *Ep) *Ep {
 	return p.Add(lhs, new(Ep).Neg(rhs))
 }
 
+// Mul computes scalar*point with the GLV endomorphism, see pasta_glv.go
 func (p *Ep) Mul(point *Ep, scalar *fq.Fq) *Ep {
-	bytes := scalar.Bytes()
+	k1, k2 := pallasGlv.decompose(scalar.BigInt())
+	w1 := glvWindows(k1)
+	w2 := glvWindows(k2)
+
+	// precomputed[i] = i*point and endo[i] = i*lambda*point, signed like k1, k2
 	precomputed := [16]*Ep{}
 	precomputed[0] = new(Ep).Identity()
 	precomputed[1] = new(Ep).Set(point)
+	if k1.Sign() < 0 {
+		precomputed[1].Neg(precomputed[1])
+	}
 	for i := 2; i < 16; i += 2 {
 		precomputed[i] = new(Ep).Double(precomputed[i>>1])
-		precomputed[i+1] = new(Ep).Add(precomputed[i], point)
+		precomputed[i+1] = new(Ep).Add(precomputed[i], precomputed[1])
+	}
+	endo := [16]*Ep{}
+	for i := range endo {
+		endo[i] = new(Ep).endo(precomputed[i])
+		if (k1.Sign() < 0) != (k2.Sign() < 0) {
+			endo[i].Neg(endo[i])
+		}
 	}
-	p.Identity()
-	for i := 0; i < 256; i += 4 {
-		// Brouwer / windowing method. window size of 4.
+
+	r := new(Ep).Identity()
+	for i := range w1 {
+		// Interleaved windowing method. window size of 4.
 		for j := 0; j < 4; j++ {
-			p.Double(p)
+			r.Double(r)
 		}
-		window := bytes[32-1-i>>3] >> (4 - i&0x04) & 0x0F
-		p.Add(p, precomputed[window])
+		r.Add(r, precomputed[w1[i]])
+		r.Add(r, endo[w2[i]])
 	}
+	return p.Set(r)
+}
+
+// endo sets p = (zeta*x, y) which is lambda*other
+func (p *Ep) endo(other *Ep) *Ep {
+	p.x = new(fp.Fp).Mul(pallasZeta, other.x)
+	p.y = new(fp.Fp).Set(other.y)
+	p.z = new(fp.Fp).Set(other.z)
 	return p
 }
 
@@ -1032,10 +1064,10 @@ func sumOfProductsPippengerPallas(points []*Ep, scalars []*big.Int) *Ep {
 		return nil
 	}
 
-	const w = 6
+	w := native.PippengerWindow(len(points), pippengerScalarBits)
 
 	bucketSize := (1 << w) - 1
-	windows := make([]*Ep, 255/w+1)
+	windows := make([]*Ep, (pippengerScalarBits+w-1)/w)
 	for i := range windows {
 		windows[i] = new(Ep).Identity()
 	}
@@ -1047,7 +1079,7 @@ func sumOfProductsPippengerPallas(points []*Ep, scalars []*big.Int) *Ep {
 		}
 
 		for i := 0; i < len(scalars); i++ {
-			index := bucketSize & int(new(big.Int).Rsh(scalars[i], uint(w*j)).Int64())
+			index := pippengerDigit(scalars[i], w*j, w)
 			if index != 0 {
 				bucket[index-1].Add(bucket[index-1], points[i])
 			}
@@ -0,0 +1,301 @@
+package curves
+
+import (
+	"crypto/sha512"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/bwesterb/go-ristretto"
+
+	"github.com/go-sonr/crypto/core/curves/native"
+)
+
+// PointRistretto255 is an element of the prime order ristretto255 group of
+// RFC 9496 https://www.rfc-editor.org/rfc/rfc9496.html built on edwards25519.
+// The group order is the ed25519 subgroup order, so ristretto255 uses
+// ScalarEd25519 for its scalars.
+type PointRistretto255 struct {
+	value *ristretto.Point
+}
+
+// toRistrettoScalar converts an ed25519 scalar, both are 32 byte little endian
+func toRistrettoScalar(rhs Scalar) *ristretto.Scalar {
+	r, ok := rhs.(*ScalarEd25519)
+	if !ok {
+		return nil
+	}
+	var buf [32]byte
+	copy(buf[:], r.Bytes())
+	return new(ristretto.Scalar).SetBytes(&buf)
+}
+
+func (p *PointRistretto255) Random(reader io.Reader) Point {
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	return p.Hash(seed[:])
+}
+
+// Hash maps the SHA-512 digest of bytes to the group with the one-way map of RFC 9496 section 4.3.4
+func (p *PointRistretto255) Hash(bytes []byte) Point {
+	h := sha512.Sum512(bytes)
+	return ristretto255FromUniformBytes(h[:])
+}
+
+func (p *PointRistretto255) Identity() Point {
+	return &PointRistretto255{value: new(ristretto.Point).SetZero()}
+}
+
+func (p *PointRistretto255) Generator() Point {
+	return &PointRistretto255{value: new(ristretto.Point).SetBase()}
+}
+
+func (p *PointRistretto255) IsIdentity() bool {
+	return p.Equal(p.Identity())
+}
+
+func (p *PointRistretto255) IsNegative() bool {
+	// Negative points don't exist in ristretto255
+	return false
+}
+
+func (p *PointRistretto255) IsOnCurve() bool {
+	// Every representable element is in the group
+	return true
+}
+
+func (p *PointRistretto255) Double() Point {
+	return &PointRistretto255{value: new(ristretto.Point).Double(p.value)}
+}
+
+func (p *PointRistretto255) Scalar() Scalar {
+	return new(ScalarEd25519).Zero()
+}
+
+func (p *PointRistretto255) Neg() Point {
+	return &PointRistretto255{value: new(ristretto.Point).Neg(p.value)}
+}
+
+func (p *PointRistretto255) Add(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointRistretto255)
+	if ok {
+		return &PointRistretto255{value: new(ristretto.Point).Add(p.value, r.value)}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointRistretto255) Sub(rhs Point) Point {
+	if rhs == nil {
+		return nil
+	}
+	r, ok := rhs.(*PointRistretto255)
+	if ok {
+		return &PointRistretto255{value: new(ristretto.Point).Sub(p.value, r.value)}
+	} else {
+		return nil
+	}
+}
+
+func (p *PointRistretto255) Mul(rhs Scalar) Point {
+	if rhs == nil {
+		return nil
+	}
+	s := toRistrettoScalar(rhs)
+	if s == nil {
+		return nil
+	}
+	return &PointRistretto255{value: new(ristretto.Point).ScalarMult(p.value, s)}
+}
+
+func (p *PointRistretto255) Equal(rhs Point) bool {
+	r, ok := rhs.(*PointRistretto255)
+	if ok {
+		return p.value.Equals(r.value)
+	} else {
+		return false
+	}
+}
+
+func (p *PointRistretto255) Set(x, y *big.Int) (Point, error) {
+	return nil, fmt.Errorf("ristretto255 elements have no affine coordinates")
+}
+
+// ToAffineCompressed returns the 32 byte canonical encoding of RFC 9496 section 4.3.2
+func (p *PointRistretto255) ToAffineCompressed() []byte {
+	return p.value.Bytes()
+}
+
+// ToAffineUncompressed is the same as ToAffineCompressed, ristretto255 has a single encoding
+func (p *PointRistretto255) ToAffineUncompressed() []byte {
+	return p.value.Bytes()
+}
+
+// FromAffineCompressed decodes the 32 byte canonical encoding and rejects every other input
+func (p *PointRistretto255) FromAffineCompressed(inBytes []byte) (Point, error) {
+	if len(inBytes) != 32 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+	var buf [32]byte
+	copy(buf[:], inBytes)
+	value := new(ristretto.Point)
+	if !value.SetBytes(&buf) {
+		return nil, fmt.Errorf("invalid ristretto255 encoding")
+	}
+	return &PointRistretto255{value}, nil
+}
+
+func (p *PointRistretto255) FromAffineUncompressed(inBytes []byte) (Point, error) {
+	return p.FromAffineCompressed(inBytes)
+}
+
+func (p *PointRistretto255) CurveName() string {
+	return Ristretto255Name
+}
+
+func (p *PointRistretto255) SumOfProducts(points []Point, scalars []Scalar) Point {
+	nScalars := make([]*big.Int, len(scalars))
+	for i, sc := range scalars {
+		s, ok := sc.(*ScalarEd25519)
+		if !ok {
+			return nil
+		}
+		nScalars[i] = s.BigInt()
+	}
+	for _, pt := range points {
+		if _, ok := pt.(*PointRistretto255); !ok {
+			return nil
+		}
+	}
+	if len(points) == 0 {
+		return p.Identity()
+	}
+	return sumOfProductsPippenger(points, nScalars)
+}
+
+// BatchMul multiplies p by every scalar in scalars. The multiples of p are
+// computed once and shared by all the multiplications.
+func (p *PointRistretto255) BatchMul(scalars []Scalar) ([]Point, error) {
+	var table ristretto.ScalarMultTable
+	table.Compute(p.value)
+	out := make([]Point, len(scalars))
+	for i, sc := range scalars {
+		s := toRistrettoScalar(sc)
+		if s == nil {
+			return nil, fmt.Errorf("invalid scalar at index %d", i)
+		}
+		out[i] = &PointRistretto255{value: new(ristretto.Point).ScalarMultTable(&table, s)}
+	}
+	return out, nil
+}
+
+// Ristretto255BatchDecode decodes a list of canonical encodings, failing on the
+// first input that is not a valid ristretto255 element.
+func Ristretto255BatchDecode(inBytes [][]byte) ([]*PointRistretto255, error) {
+	out := make([]*PointRistretto255, len(inBytes))
+	for i, b := range inBytes {
+		pt, err := new(PointRistretto255).FromAffineCompressed(b)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		out[i] = pt.(*PointRistretto255)
+	}
+	return out, nil
+}
+
+// Ristretto255BatchEncode returns the canonical encodings of points
+func Ristretto255BatchEncode(points []*PointRistretto255) [][]byte {
+	out := make([][]byte, len(points))
+	for i, pt := range points {
+		out[i] = pt.ToAffineCompressed()
+	}
+	return out
+}
+
+func (p *PointRistretto255) MarshalBinary() ([]byte, error) {
+	return pointMarshalBinary(p)
+}
+
+func (p *PointRistretto255) UnmarshalBinary(input []byte) error {
+	pt, err := pointUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointRistretto255)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointRistretto255) MarshalText() ([]byte, error) {
+	return pointMarshalText(p)
+}
+
+func (p *PointRistretto255) UnmarshalText(input []byte) error {
+	pt, err := pointUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointRistretto255)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointRistretto255) MarshalJSON() ([]byte, error) {
+	return pointMarshalJSON(p)
+}
+
+func (p *PointRistretto255) UnmarshalJSON(input []byte) error {
+	pt, err := pointUnmarshalJSON(input)
+	if err != nil {
+		return err
+	}
+	P, ok := pt.(*PointRistretto255)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	p.value = P.value
+	return nil
+}
+
+// Ristretto255Elligator is the MAP function of RFC 9496 section 4.3.4, it maps
+// 32 bytes to a group element. The top bit of the last byte is ignored.
+func Ristretto255Elligator(b []byte) (*PointRistretto255, error) {
+	if len(b) != 32 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	var r [32]byte
+	copy(r[:], b)
+	return &PointRistretto255{value: new(ristretto.Point).SetElligator(&r)}, nil
+}
+
+// Ristretto255FromUniformBytes is the one-way map of RFC 9496 section 4.3.4 on 64 bytes
+func Ristretto255FromUniformBytes(b []byte) (*PointRistretto255, error) {
+	if len(b) != 64 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	return ristretto255FromUniformBytes(b), nil
+}
+
+// ristretto255FromUniformBytes is the one-way map of RFC 9496 section 4.3.4 on 64 bytes
+func ristretto255FromUniformBytes(b []byte) *PointRistretto255 {
+	var r0, r1 [32]byte
+	copy(r0[:], b[:32])
+	copy(r1[:], b[32:64])
+	p0 := new(ristretto.Point).SetElligator(&r0)
+	p1 := new(ristretto.Point).SetElligator(&r1)
+	return &PointRistretto255{value: p0.Add(p0, p1)}
+}
+
+// hashToRistretto255 is hash_to_ristretto255 of RFC 9380 appendix B using expand_message_xmd with SHA-512
+func hashToRistretto255(msg, dst []byte) *PointRistretto255 {
+	return ristretto255FromUniformBytes(native.ExpandMsgXmd(native.EllipticPointHasherSha512(), msg, dst, 64))
+}
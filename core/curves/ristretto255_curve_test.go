@@ -0,0 +1,123 @@
+package curves
+
+import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPointRistretto255Multiples(t *testing.T) {
+	// RFC 9496 appendix A.1
+	multiples := []string{
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"e2f2ae0a6abc4e71a884a961c500515f58e30b6aa582dd8db6a65945e08d2d76",
+		"6a493210f7499cd17fecb510ae0cea23a110e8d5b901f8acadd3095c73a3b919",
+		"94741f5d5d52755ece4f23f044ee27d5d1ea1e2bd196b462166b16152a9d0259",
+	}
+	curve := RISTRETTO255()
+	p := curve.NewIdentityPoint()
+	for i, expected := range multiples {
+		require.Equal(t, expected, hex.EncodeToString(p.ToAffineCompressed()))
+		require.True(t, curve.ScalarBaseMult(curve.Scalar.New(i)).Equal(p))
+		q, err := curve.Point.FromAffineCompressed(p.ToAffineCompressed())
+		require.NoError(t, err)
+		require.True(t, q.Equal(p))
+		p = p.Add(curve.NewGeneratorPoint())
+	}
+}
+
+func TestPointRistretto255RejectsBadEncodings(t *testing.T) {
+	// RFC 9496 appendix A.2, non-canonical, negative and off curve encodings
+	for _, enc := range []string{
+		"00ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		"0100000000000000000000000000000000000000000000000000000000000000",
+		"26948d35ca62e643e26a83177332e6b6afeb9d08e4268b650f1f5bbd8d81d371",
+	} {
+		b, _ := hex.DecodeString(enc)
+		_, err := RISTRETTO255().Point.FromAffineCompressed(b)
+		require.Error(t, err)
+	}
+	_, err := RISTRETTO255().Point.FromAffineCompressed(make([]byte, 31))
+	require.Error(t, err)
+}
+
+func TestPointRistretto255Hash(t *testing.T) {
+	// RFC 9496 appendix A.3
+	p := RISTRETTO255().Point.Hash([]byte("Ristretto is traditionally a short shot of espresso coffee"))
+	require.Equal(t, "3066f82a1a747d45120d1740f14358531a8f04bbffe6a819f86dfe50f44a0a46", hex.EncodeToString(p.ToAffineCompressed()))
+}
+
+func TestPointRistretto255Arithmetic(t *testing.T) {
+	curve := RISTRETTO255()
+	a := curve.Scalar.Random(crand.Reader)
+	b := curve.Scalar.Random(crand.Reader)
+	p := curve.Point.Random(crand.Reader)
+	require.True(t, p.Mul(a).Add(p.Mul(b)).Equal(p.Mul(a.Add(b))))
+	require.True(t, p.Sub(p).IsIdentity())
+	require.True(t, p.Double().Equal(p.Add(p)))
+	require.True(t, p.Neg().Add(p).IsIdentity())
+	require.True(t, curve.Point.SumOfProducts([]Point{p, p}, []Scalar{a, b}).Equal(p.Mul(a.Add(b))))
+
+	bin, err := p.(*PointRistretto255).MarshalBinary()
+	require.NoError(t, err)
+	q := new(PointRistretto255)
+	require.NoError(t, q.UnmarshalBinary(bin))
+	require.True(t, q.Equal(p))
+}
+
+func TestPointRistretto255UniformBytes(t *testing.T) {
+	// RFC 9496 appendix A.3, the input is the SHA-512 digest used by Hash
+	in, _ := hex.DecodeString("5d1be09e3d0c82fc538112490e35701979d99e06ca3e2b5b54bffe8b4dc772c14d98b696a1bbfb5ca32c436cc61c16563790306c79eaca7705668b47dffe5bb6")
+	p, err := Ristretto255FromUniformBytes(in)
+	require.NoError(t, err)
+	require.Equal(t, "3066f82a1a747d45120d1740f14358531a8f04bbffe6a819f86dfe50f44a0a46", hex.EncodeToString(p.ToAffineCompressed()))
+
+	p0, err := Ristretto255Elligator(in[:32])
+	require.NoError(t, err)
+	p1, err := Ristretto255Elligator(in[32:])
+	require.NoError(t, err)
+	require.True(t, p0.Add(p1).Equal(p))
+
+	_, err = Ristretto255FromUniformBytes(in[:32])
+	require.Error(t, err)
+	_, err = Ristretto255Elligator(in)
+	require.Error(t, err)
+}
+
+func TestPointRistretto255Batch(t *testing.T) {
+	curve := RISTRETTO255()
+	points := make([]*PointRistretto255, 8)
+	scalars := make([]Scalar, len(points))
+	expected := curve.NewIdentityPoint()
+	for i := range points {
+		points[i] = curve.Point.Random(crand.Reader).(*PointRistretto255)
+		scalars[i] = curve.Scalar.Random(crand.Reader)
+		expected = expected.Add(points[i].Mul(scalars[i]))
+	}
+
+	generic := make([]Point, len(points))
+	for i, pt := range points {
+		generic[i] = pt
+	}
+	require.True(t, curve.Point.SumOfProducts(generic, scalars).Equal(expected))
+
+	multiples, err := points[0].BatchMul(scalars)
+	require.NoError(t, err)
+	for i, m := range multiples {
+		require.True(t, m.Equal(points[0].Mul(scalars[i])))
+	}
+	_, err = points[0].BatchMul([]Scalar{curve.Scalar, new(ScalarK256).Zero()})
+	require.Error(t, err)
+
+	decoded, err := Ristretto255BatchDecode(Ristretto255BatchEncode(points))
+	require.NoError(t, err)
+	for i, pt := range decoded {
+		require.True(t, pt.Equal(points[i]))
+	}
+	bad := Ristretto255BatchEncode(points)
+	bad[3] = make([]byte, 31)
+	_, err = Ristretto255BatchDecode(bad)
+	require.Error(t, err)
+}
@@ -0,0 +1,899 @@
+package curves
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/go-sonr/crypto/core/curves/native/pasta/fp"
+	"github.com/go-sonr/crypto/core/curves/native/pasta/fq"
+)
+
+// Vesta is the second curve of the Pasta cycle, y^2 = x^3 + 5 over the Pallas
+// scalar field. Its group order is the Pallas base field modulus, so Vesta
+// points have coordinates in fq.Fq and Vesta scalars are fp.Fp elements.
+
+const vestaDst = "vesta_XMD:BLAKE2b_SVDW_RO_"
+
+var (
+	vestaB = new(fq.Fq).SetUint64(5)
+	// Constants of the Shallue-van de Woestijne map with Z = 1,
+	// see https://www.rfc-editor.org/rfc/rfc9380.html#section-6.6.1
+	vestaSvdwZ, vestaSvdwC1, vestaSvdwC2, vestaSvdwC3, vestaSvdwC4 = vestaSvdwConstants()
+)
+
+func vestaSvdwConstants() (z, c1, c2, c3, c4 *fq.Fq) {
+	z = new(fq.Fq).SetOne()
+	// c1 = g(Z)
+	c1 = rhsVesta(z)
+	// c2 = -Z / 2
+	c2, _ = new(fq.Fq).Invert(new(fq.Fq).Double(z))
+	c2.Mul(c2, z)
+	c2.Neg(c2)
+	// c3 = sqrt(-g(Z) * 3Z^2) with sgn0(c3) = 0
+	threeZ2 := new(fq.Fq).Square(z)
+	threeZ2.Mul(threeZ2, new(fq.Fq).SetUint64(3))
+	c3 = new(fq.Fq).Mul(c1, threeZ2)
+	c3.Neg(c3)
+	c3, _ = c3.Sqrt(c3)
+	if c3.IsOdd() {
+		c3.Neg(c3)
+	}
+	// c4 = -4g(Z) / 3Z^2
+	c4, _ = new(fq.Fq).Invert(threeZ2)
+	c4.Mul(c4, c1)
+	c4.Mul(c4, new(fq.Fq).SetUint64(4))
+	c4.Neg(c4)
+	return z, c1, c2, c3, c4
+}
+
+type ScalarVesta struct {
+	value *fp.Fp
+}
+
+func (s *ScalarVesta) Random(reader io.Reader) Scalar {
+	if reader == nil {
+		return nil
+	}
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	return s.Hash(seed[:])
+}
+
+func (s *ScalarVesta) Hash(bytes []byte) Scalar {
+	h, _ := blake2b.New(64, []byte{})
+	xmd, err := expandMsgXmd(h, bytes, []byte(vestaDst), 64)
+	if err != nil {
+		return nil
+	}
+	var t [64]byte
+	copy(t[:], xmd)
+	return &ScalarVesta{
+		value: new(fp.Fp).SetBytesWide(&t),
+	}
+}
+
+func (s *ScalarVesta) Zero() Scalar {
+	return &ScalarVesta{
+		value: new(fp.Fp).SetZero(),
+	}
+}
+
+func (s *ScalarVesta) One() Scalar {
+	return &ScalarVesta{
+		value: new(fp.Fp).SetOne(),
+	}
+}
+
+func (s *ScalarVesta) IsZero() bool {
+	return s.value.IsZero()
+}
+
+func (s *ScalarVesta) IsOne() bool {
+	return s.value.IsOne()
+}
+
+func (s *ScalarVesta) IsOdd() bool {
+	return s.value.IsOdd()
+}
+
+func (s *ScalarVesta) IsEven() bool {
+	return !s.value.IsOdd()
+}
+
+func (s *ScalarVesta) New(value int) Scalar {
+	v := big.NewInt(int64(value))
+	return &ScalarVesta{
+		value: new(fp.Fp).SetBigInt(v),
+	}
+}
+
+func (s *ScalarVesta) Cmp(rhs Scalar) int {
+	r, ok := rhs.(*ScalarVesta)
+	if ok {
+		return s.value.Cmp(r.value)
+	} else {
+		return -2
+	}
+}
+
+func (s *ScalarVesta) Square() Scalar {
+	return &ScalarVesta{
+		value: new(fp.Fp).Square(s.value),
+	}
+}
+
+func (s *ScalarVesta) Double() Scalar {
+	return &ScalarVesta{
+		value: new(fp.Fp).Double(s.value),
+	}
+}
+
+func (s *ScalarVesta) Invert() (Scalar, error) {
+	value, wasInverted := new(fp.Fp).Invert(s.value)
+	if !wasInverted {
+		return nil, fmt.Errorf("inverse doesn't exist")
+	}
+	return &ScalarVesta{
+		value,
+	}, nil
+}
+
+func (s *ScalarVesta) Sqrt() (Scalar, error) {
+	value, wasSquare := new(fp.Fp).Sqrt(s.value)
+	if !wasSquare {
+		return nil, fmt.Errorf("not a square")
+	}
+	return &ScalarVesta{
+		value,
+	}, nil
+}
+
+func (s *ScalarVesta) Cube() Scalar {
+	value := new(fp.Fp).Mul(s.value, s.value)
+	value.Mul(value, s.value)
+	return &ScalarVesta{
+		value,
+	}
+}
+
+func (s *ScalarVesta) Add(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarVesta)
+	if ok {
+		return &ScalarVesta{
+			value: new(fp.Fp).Add(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarVesta) Sub(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarVesta)
+	if ok {
+		return &ScalarVesta{
+			value: new(fp.Fp).Sub(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarVesta) Mul(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarVesta)
+	if ok {
+		return &ScalarVesta{
+			value: new(fp.Fp).Mul(s.value, r.value),
+		}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarVesta) MulAdd(y, z Scalar) Scalar {
+	return s.Mul(y).Add(z)
+}
+
+func (s *ScalarVesta) Div(rhs Scalar) Scalar {
+	r, ok := rhs.(*ScalarVesta)
+	if ok {
+		v, wasInverted := new(fp.Fp).Invert(r.value)
+		if !wasInverted {
+			return nil
+		}
+		v.Mul(v, s.value)
+		return &ScalarVesta{value: v}
+	} else {
+		return nil
+	}
+}
+
+func (s *ScalarVesta) Neg() Scalar {
+	return &ScalarVesta{
+		value: new(fp.Fp).Neg(s.value),
+	}
+}
+
+func (s *ScalarVesta) SetBigInt(v *big.Int) (Scalar, error) {
+	return &ScalarVesta{
+		value: new(fp.Fp).SetBigInt(v),
+	}, nil
+}
+
+func (s *ScalarVesta) BigInt() *big.Int {
+	return s.value.BigInt()
+}
+
+func (s *ScalarVesta) Bytes() []byte {
+	t := s.value.Bytes()
+	return t[:]
+}
+
+// Zeroize overwrites the scalar with zero
+func (s *ScalarVesta) Zeroize() {
+	if s.value != nil {
+		s.value.SetZero()
+	}
+}
+
+func (s *ScalarVesta) SetBytes(bytes []byte) (Scalar, error) {
+	if len(bytes) != 32 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	var seq [32]byte
+	copy(seq[:], bytes)
+	value, err := new(fp.Fp).SetBytes(&seq)
+	if err != nil {
+		return nil, err
+	}
+	return &ScalarVesta{
+		value,
+	}, nil
+}
+
+func (s *ScalarVesta) SetBytesWide(bytes []byte) (Scalar, error) {
+	if len(bytes) != 64 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	var seq [64]byte
+	copy(seq[:], bytes)
+	return &ScalarVesta{
+		value: new(fp.Fp).SetBytesWide(&seq),
+	}, nil
+}
+
+func (s *ScalarVesta) Point() Point {
+	return new(PointVesta).Identity()
+}
+
+func (s *ScalarVesta) Clone() Scalar {
+	return &ScalarVesta{
+		value: new(fp.Fp).Set(s.value),
+	}
+}
+
+func (s *ScalarVesta) GetFp() *fp.Fp {
+	return new(fp.Fp).Set(s.value)
+}
+
+func (s *ScalarVesta) SetFp(fp *fp.Fp) *ScalarVesta {
+	s.value = fp
+	return s
+}
+
+func (s *ScalarVesta) MarshalBinary() ([]byte, error) {
+	return scalarMarshalBinary(s)
+}
+
+func (s *ScalarVesta) UnmarshalBinary(input []byte) error {
+	sc, err := scalarUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarVesta)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.value = ss.value
+	return nil
+}
+
+func (s *ScalarVesta) MarshalText() ([]byte, error) {
+	return scalarMarshalText(s)
+}
+
+func (s *ScalarVesta) UnmarshalText(input []byte) error {
+	sc, err := scalarUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ss, ok := sc.(*ScalarVesta)
+	if !ok {
+		return fmt.Errorf("invalid scalar")
+	}
+	s.value = ss.value
+	return nil
+}
+
+func (s *ScalarVesta) MarshalJSON() ([]byte, error) {
+	return scalarMarshalJson(s)
+}
+
+func (s *ScalarVesta) UnmarshalJSON(input []byte) error {
+	sc, err := scalarUnmarshalJson(input)
+	if err != nil {
+		return err
+	}
+	S, ok := sc.(*ScalarVesta)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	s.value = S.value
+	return nil
+}
+
+type PointVesta struct {
+	value *Eq
+}
+
+func (p *PointVesta) Random(reader io.Reader) Point {
+	return &PointVesta{new(Eq).Random(reader)}
+}
+
+func (p *PointVesta) Hash(bytes []byte) Point {
+	return &PointVesta{new(Eq).Hash(bytes)}
+}
+
+func (p *PointVesta) Identity() Point {
+	return &PointVesta{new(Eq).Identity()}
+}
+
+func (p *PointVesta) Generator() Point {
+	return &PointVesta{new(Eq).Generator()}
+}
+
+func (p *PointVesta) IsIdentity() bool {
+	return p.value.IsIdentity()
+}
+
+func (p *PointVesta) IsNegative() bool {
+	return p.value.Y().IsOdd()
+}
+
+func (p *PointVesta) IsOnCurve() bool {
+	return p.value.IsOnCurve()
+}
+
+func (p *PointVesta) Double() Point {
+	return &PointVesta{new(Eq).Double(p.value)}
+}
+
+func (p *PointVesta) Scalar() Scalar {
+	return &ScalarVesta{new(fp.Fp).SetZero()}
+}
+
+func (p *PointVesta) Neg() Point {
+	return &PointVesta{new(Eq).Neg(p.value)}
+}
+
+func (p *PointVesta) Add(rhs Point) Point {
+	r, ok := rhs.(*PointVesta)
+	if !ok {
+		return nil
+	}
+	return &PointVesta{new(Eq).Add(p.value, r.value)}
+}
+
+func (p *PointVesta) Sub(rhs Point) Point {
+	r, ok := rhs.(*PointVesta)
+	if !ok {
+		return nil
+	}
+	return &PointVesta{new(Eq).Sub(p.value, r.value)}
+}
+
+func (p *PointVesta) Mul(rhs Scalar) Point {
+	s, ok := rhs.(*ScalarVesta)
+	if !ok {
+		return nil
+	}
+	return &PointVesta{new(Eq).Mul(p.value, s.value)}
+}
+
+func (p *PointVesta) Equal(rhs Point) bool {
+	r, ok := rhs.(*PointVesta)
+	if !ok {
+		return false
+	}
+	return p.value.Equal(r.value)
+}
+
+func (p *PointVesta) Set(x, y *big.Int) (Point, error) {
+	if x.Sign() == 0 && y.Sign() == 0 {
+		return &PointVesta{new(Eq).Identity()}, nil
+	}
+	value := &Eq{new(fq.Fq).SetBigInt(x), new(fq.Fq).SetBigInt(y), new(fq.Fq).SetOne()}
+	if !value.IsOnCurve() {
+		return nil, fmt.Errorf("point is not on the curve")
+	}
+	return &PointVesta{value}, nil
+}
+
+func (p *PointVesta) ToAffineCompressed() []byte {
+	return p.value.ToAffineCompressed()
+}
+
+func (p *PointVesta) ToAffineUncompressed() []byte {
+	return p.value.ToAffineUncompressed()
+}
+
+func (p *PointVesta) FromAffineCompressed(bytes []byte) (Point, error) {
+	value, err := new(Eq).FromAffineCompressed(bytes)
+	if err != nil {
+		return nil, err
+	}
+	return &PointVesta{value}, nil
+}
+
+func (p *PointVesta) FromAffineUncompressed(bytes []byte) (Point, error) {
+	value, err := new(Eq).FromAffineUncompressed(bytes)
+	if err != nil {
+		return nil, err
+	}
+	return &PointVesta{value}, nil
+}
+
+func (p *PointVesta) CurveName() string {
+	return VestaName
+}
+
+func (p *PointVesta) SumOfProducts(points []Point, scalars []Scalar) Point {
+	nScalars := make([]*big.Int, len(scalars))
+	for i, sc := range scalars {
+		s, ok := sc.(*ScalarVesta)
+		if !ok {
+			return nil
+		}
+		nScalars[i] = s.value.BigInt()
+	}
+	for _, pt := range points {
+		if _, ok := pt.(*PointVesta); !ok {
+			return nil
+		}
+	}
+	if len(points) == 0 {
+		return p.Identity()
+	}
+	return sumOfProductsPippenger(points, nScalars)
+}
+
+func (p *PointVesta) MarshalBinary() ([]byte, error) {
+	return pointMarshalBinary(p)
+}
+
+func (p *PointVesta) UnmarshalBinary(input []byte) error {
+	pt, err := pointUnmarshalBinary(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointVesta)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointVesta) MarshalText() ([]byte, error) {
+	return pointMarshalText(p)
+}
+
+func (p *PointVesta) UnmarshalText(input []byte) error {
+	pt, err := pointUnmarshalText(input)
+	if err != nil {
+		return err
+	}
+	ppt, ok := pt.(*PointVesta)
+	if !ok {
+		return fmt.Errorf("invalid point")
+	}
+	p.value = ppt.value
+	return nil
+}
+
+func (p *PointVesta) MarshalJSON() ([]byte, error) {
+	return pointMarshalJSON(p)
+}
+
+func (p *PointVesta) UnmarshalJSON(input []byte) error {
+	pt, err := pointUnmarshalJSON(input)
+	if err != nil {
+		return err
+	}
+	P, ok := pt.(*PointVesta)
+	if !ok {
+		return fmt.Errorf("invalid type")
+	}
+	p.value = P.value
+	return nil
+}
+
+func (p *PointVesta) X() *fq.Fq {
+	return p.value.X()
+}
+
+func (p *PointVesta) Y() *fq.Fq {
+	return p.value.Y()
+}
+
+func (p *PointVesta) GetEq() *Eq {
+	return new(Eq).Set(p.value)
+}
+
+// Eq is a Vesta point in Jacobian coordinates
+type Eq struct {
+	x *fq.Fq
+	y *fq.Fq
+	z *fq.Fq
+}
+
+func (p *Eq) Random(reader io.Reader) *Eq {
+	var seed [64]byte
+	_, _ = reader.Read(seed[:])
+	return p.Hash(seed[:])
+}
+
+// Hash maps bytes to the curve with the Shallue-van de Woestijne method,
+// Vesta has a = 0 and no 3-isogeny is needed
+func (p *Eq) Hash(bytes []byte) *Eq {
+	if bytes == nil {
+		bytes = []byte{}
+	}
+	h, _ := blake2b.New(64, []byte{})
+	u, _ := expandMsgXmd(h, bytes, []byte(vestaDst), 128)
+	var buf [64]byte
+	copy(buf[:], u[:64])
+	u0 := new(fq.Fq).SetBytesWide(&buf)
+	copy(buf[:], u[64:])
+	u1 := new(fq.Fq).SetBytesWide(&buf)
+
+	q0 := mapSvdwVesta(u0)
+	q1 := mapSvdwVesta(u1)
+	return p.Identity().Add(q0, q1)
+}
+
+func (p *Eq) Identity() *Eq {
+	p.x = new(fq.Fq).SetZero()
+	p.y = new(fq.Fq).SetZero()
+	p.z = new(fq.Fq).SetZero()
+	return p
+}
+
+func (p *Eq) Generator() *Eq {
+	p.x = new(fq.Fq).SetOne()
+	p.y = new(fq.Fq).SetBigInt(glvHex("1943666ea922ae6b13b64e3aae89754cacce3a7f298ba20c4e4389b9b0276a62"))
+	p.z = new(fq.Fq).SetOne()
+	return p
+}
+
+func (p *Eq) IsIdentity() bool {
+	return p.z.IsZero()
+}
+
+func (p *Eq) Double(other *Eq) *Eq {
+	if other.IsIdentity() {
+		p.Set(other)
+		return p
+	}
+	r := new(Eq)
+	// dbl-2009-l for a = 0, the same formula as Ep.Double
+	a := new(fq.Fq).Square(other.x)
+	b := new(fq.Fq).Square(other.y)
+	c := new(fq.Fq).Square(b)
+	r.x = new(fq.Fq).Add(other.x, b)
+	r.y = new(fq.Fq).Square(r.x)
+	r.z = new(fq.Fq).Sub(r.y, a)
+	r.x.Sub(r.z, c)
+	d := new(fq.Fq).Double(r.x)
+	e := new(fq.Fq).Add(a, new(fq.Fq).Double(a))
+	f := new(fq.Fq).Square(e)
+	r.y.Double(d)
+	r.x.Sub(f, r.y)
+	r.y.Sub(d, r.x)
+	f.Double(c)
+	f.Double(f)
+	f.Double(f)
+	r.z.Mul(e, r.y)
+	r.y.Sub(r.z, f)
+	f.Mul(other.y, other.z)
+	r.z.Double(f)
+	p.Set(r)
+	return p
+}
+
+func (p *Eq) Neg(other *Eq) *Eq {
+	p.x = new(fq.Fq).Set(other.x)
+	p.y = new(fq.Fq).Neg(other.y)
+	p.z = new(fq.Fq).Set(other.z)
+	return p
+}
+
+func (p *Eq) Add(lhs *Eq, rhs *Eq) *Eq {
+	if lhs.IsIdentity() {
+		return p.Set(rhs)
+	}
+	if rhs.IsIdentity() {
+		return p.Set(lhs)
+	}
+	z1z1 := new(fq.Fq).Square(lhs.z)
+	z2z2 := new(fq.Fq).Square(rhs.z)
+	u1 := new(fq.Fq).Mul(lhs.x, z2z2)
+	u2 := new(fq.Fq).Mul(rhs.x, z1z1)
+	s1 := new(fq.Fq).Mul(lhs.y, z2z2)
+	s1.Mul(s1, rhs.z)
+	s2 := new(fq.Fq).Mul(rhs.y, z1z1)
+	s2.Mul(s2, lhs.z)
+
+	if u1.Equal(u2) {
+		if s1.Equal(s2) {
+			return p.Double(lhs)
+		} else {
+			return p.Identity()
+		}
+	} else {
+		h := new(fq.Fq).Sub(u2, u1)
+		i := new(fq.Fq).Double(h)
+		i.Square(i)
+		j := new(fq.Fq).Mul(i, h)
+		r := new(fq.Fq).Sub(s2, s1)
+		r.Double(r)
+		v := new(fq.Fq).Mul(u1, i)
+		x3 := new(fq.Fq).Square(r)
+		x3.Sub(x3, j)
+		x3.Sub(x3, new(fq.Fq).Double(v))
+		s1.Mul(s1, j)
+		s1.Double(s1)
+		y3 := new(fq.Fq).Mul(r, new(fq.Fq).Sub(v, x3))
+		y3.Sub(y3, s1)
+		z3 := new(fq.Fq).Add(lhs.z, rhs.z)
+		z3.Square(z3)
+		z3.Sub(z3, z1z1)
+		z3.Sub(z3, z2z2)
+		z3.Mul(z3, h)
+		p.x = new(fq.Fq).Set(x3)
+		p.y = new(fq.Fq).Set(y3)
+		p.z = new(fq.Fq).Set(z3)
+
+		return p
+	}
+}
+
+func (p *Eq) Sub(lhs, rhs *Eq) *Eq {
+	return p.Add(lhs, new(Eq).Neg(rhs))
+}
+
+// Mul computes scalar*point with the GLV endomorphism, see pasta_glv.go
+func (p *Eq) Mul(point *Eq, scalar *fp.Fp) *Eq {
+	k1, k2 := vestaGlv.decompose(scalar.BigInt())
+	w1 := glvWindows(k1)
+	w2 := glvWindows(k2)
+
+	// precomputed[i] = i*point and endo[i] = i*lambda*point, signed like k1, k2
+	precomputed := [16]*Eq{}
+	precomputed[0] = new(Eq).Identity()
+	precomputed[1] = new(Eq).Set(point)
+	if k1.Sign() < 0 {
+		precomputed[1].Neg(precomputed[1])
+	}
+	for i := 2; i < 16; i += 2 {
+		precomputed[i] = new(Eq).Double(precomputed[i>>1])
+		precomputed[i+1] = new(Eq).Add(precomputed[i], precomputed[1])
+	}
+	endo := [16]*Eq{}
+	for i := range endo {
+		endo[i] = new(Eq).endo(precomputed[i])
+		if (k1.Sign() < 0) != (k2.Sign() < 0) {
+			endo[i].Neg(endo[i])
+		}
+	}
+
+	r := new(Eq).Identity()
+	for i := range w1 {
+		// Interleaved windowing method. window size of 4.
+		for j := 0; j < 4; j++ {
+			r.Double(r)
+		}
+		r.Add(r, precomputed[w1[i]])
+		r.Add(r, endo[w2[i]])
+	}
+	return p.Set(r)
+}
+
+// endo sets p = (zeta*x, y) which is lambda*other
+func (p *Eq) endo(other *Eq) *Eq {
+	p.x = new(fq.Fq).Mul(vestaZeta, other.x)
+	p.y = new(fq.Fq).Set(other.y)
+	p.z = new(fq.Fq).Set(other.z)
+	return p
+}
+
+func (p *Eq) Equal(other *Eq) bool {
+	lhs := new(Eq).Set(p)
+	rhs := new(Eq).Set(other)
+	lhs.toAffine()
+	rhs.toAffine()
+	return lhs.x.Equal(rhs.x) && lhs.y.Equal(rhs.y)
+}
+
+func (p *Eq) Set(other *Eq) *Eq {
+	p.x = new(fq.Fq).Set(other.x)
+	p.y = new(fq.Fq).Set(other.y)
+	p.z = new(fq.Fq).Set(other.z)
+	return p
+}
+
+func (p *Eq) toAffine() *Eq {
+	// mutates `p` in-place to convert it to "affine" form.
+	if p.IsIdentity() {
+		// warning: control flow / not constant-time
+		p.x.SetZero()
+		p.y.SetZero()
+		p.z.SetOne()
+		return p
+	}
+	zInv3, _ := new(fq.Fq).Invert(p.z) // z is necessarily nonzero
+	zInv2 := new(fq.Fq).Square(zInv3)
+	zInv3.Mul(zInv3, zInv2)
+	p.x.Mul(p.x, zInv2)
+	p.y.Mul(p.y, zInv3)
+	p.z.SetOne()
+	return p
+}
+
+// ToAffineCompressed uses the same encoding as Pallas, infinity is all zeros
+// and the top bit holds the sign of y
+func (p *Eq) ToAffineCompressed() []byte {
+	var inf [32]byte
+	p1 := new(Eq).Set(p)
+	p1.toAffine()
+	if p1.IsIdentity() {
+		return inf[:]
+	}
+	x := p1.x.Bytes()
+	x[31] |= (p1.y.Bytes()[0] & 1) << 7
+	return x[:]
+}
+
+func (p *Eq) ToAffineUncompressed() []byte {
+	p1 := new(Eq).Set(p)
+	p1.toAffine()
+	x := p1.x.Bytes()
+	y := p1.y.Bytes()
+	return append(x[:], y[:]...)
+}
+
+func (p *Eq) FromAffineCompressed(bytes []byte) (*Eq, error) {
+	if len(bytes) != 32 {
+		return nil, fmt.Errorf("invalid byte sequence")
+	}
+
+	var input [32]byte
+	copy(input[:], bytes)
+	sign := (input[31] >> 7) & 1
+	input[31] &= 0x7F
+
+	x := new(fq.Fq)
+	if _, err := x.SetBytes(&input); err != nil {
+		return nil, err
+	}
+	rhs := rhsVesta(x)
+	if _, square := rhs.Sqrt(rhs); !square {
+		return nil, fmt.Errorf("rhs of given x-coordinate is not a square")
+	}
+	if rhs.Bytes()[0]&1 != sign {
+		rhs.Neg(rhs)
+	}
+	p.x = x
+	p.y = rhs
+	p.z = new(fq.Fq).SetOne()
+	if !p.IsOnCurve() {
+		return nil, fmt.Errorf("invalid point")
+	}
+	return p, nil
+}
+
+func (p *Eq) FromAffineUncompressed(bytes []byte) (*Eq, error) {
+	if len(bytes) != 64 {
+		return nil, fmt.Errorf("invalid length")
+	}
+	p.z = new(fq.Fq).SetOne()
+	p.x = new(fq.Fq)
+	p.y = new(fq.Fq)
+	var x, y [32]byte
+	copy(x[:], bytes[:32])
+	copy(y[:], bytes[32:])
+	if _, err := p.x.SetBytes(&x); err != nil {
+		return nil, err
+	}
+	if _, err := p.y.SetBytes(&y); err != nil {
+		return nil, err
+	}
+	if !p.IsOnCurve() {
+		return nil, fmt.Errorf("invalid point")
+	}
+	return p, nil
+}
+
+// rhs of the curve equation
+func rhsVesta(x *fq.Fq) *fq.Fq {
+	x2 := new(fq.Fq).Square(x)
+	x3 := new(fq.Fq).Mul(x, x2)
+	return new(fq.Fq).Add(x3, vestaB)
+}
+
+func (p *Eq) X() *fq.Fq {
+	t := new(Eq).Set(p)
+	t.toAffine()
+	return new(fq.Fq).Set(t.x)
+}
+
+func (p *Eq) Y() *fq.Fq {
+	t := new(Eq).Set(p)
+	t.toAffine()
+	return new(fq.Fq).Set(t.y)
+}
+
+func (p *Eq) IsOnCurve() bool {
+	// y^2 = x^3 + bz^6
+	z2 := new(fq.Fq).Square(p.z)
+	z6 := new(fq.Fq).Mul(z2, new(fq.Fq).Square(z2))
+	x3 := new(fq.Fq).Mul(new(fq.Fq).Square(p.x), p.x)
+
+	lhs := new(fq.Fq).Square(p.y)
+	rhs := new(fq.Fq).Mul(vestaB, z6)
+	rhs.Add(rhs, x3)
+	return p.z.IsZero() || lhs.Equal(rhs)
+}
+
+func (p *Eq) CMove(lhs, rhs *Eq, condition int) *Eq {
+	p.x = new(fq.Fq).CMove(lhs.x, rhs.x, condition)
+	p.y = new(fq.Fq).CMove(lhs.y, rhs.y, condition)
+	p.z = new(fq.Fq).CMove(lhs.z, rhs.z, condition)
+	return p
+}
+
+// mapSvdwVesta is the straight-line Shallue-van de Woestijne map of
+// RFC 9380 appendix F.1 for A = 0, B = 5
+func mapSvdwVesta(u *fq.Fq) *Eq {
+	one := new(fq.Fq).SetOne()
+	tv1 := new(fq.Fq).Square(u)
+	tv1.Mul(tv1, vestaSvdwC1)
+	tv2 := new(fq.Fq).Add(one, tv1)
+	tv1.Sub(one, tv1)
+	tv3 := new(fq.Fq).Mul(tv1, tv2)
+	tv3.Invert(tv3)
+	tv4 := new(fq.Fq).Mul(u, tv1)
+	tv4.Mul(tv4, tv3)
+	tv4.Mul(tv4, vestaSvdwC3)
+	x1 := new(fq.Fq).Sub(vestaSvdwC2, tv4)
+	_, e1 := new(fq.Fq).Sqrt(rhsVesta(x1))
+	x2 := new(fq.Fq).Add(vestaSvdwC2, tv4)
+	_, e2 := new(fq.Fq).Sqrt(rhsVesta(x2))
+	e2 = e2 && !e1
+	x3 := new(fq.Fq).Square(tv2)
+	x3.Mul(x3, tv3)
+	x3.Square(x3)
+	x3.Mul(x3, vestaSvdwC4)
+	x3.Add(x3, vestaSvdwZ)
+	x := new(fq.Fq).CMove(x3, x1, bool2int[e1])
+	x.CMove(x, x2, bool2int[e2])
+	y, _ := new(fq.Fq).Sqrt(rhsVesta(x))
+	e3 := u.IsOdd() == y.IsOdd()
+	y.CMove(new(fq.Fq).Neg(y), y, bool2int[e3])
+
+	return &Eq{
+		x: x, y: y, z: new(fq.Fq).SetOne(),
+	}
+}
@@ -0,0 +1,118 @@
+package curves
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves/native/pasta/fp"
+	"github.com/go-sonr/crypto/core/curves/native/pasta/fq"
+)
+
+func TestPointVestaAddDoubleMul(t *testing.T) {
+	g := new(Eq).Generator()
+	require.True(t, g.IsOnCurve())
+	id := new(Eq).Identity()
+	require.True(t, new(Eq).Add(g, id).Equal(g))
+
+	g2 := new(Eq).Add(g, g)
+	require.True(t, new(Eq).Double(g).Equal(g2))
+	g3 := new(Eq).Add(g, g2)
+	require.True(t, g3.Equal(new(Eq).Mul(g, new(fp.Fp).SetUint64(3))))
+	g4 := new(Eq).Add(g3, g)
+	require.True(t, g4.Equal(new(Eq).Double(g2)))
+
+	// The group order is the Pallas base field modulus
+	minusOne := new(fp.Fp).Neg(new(fp.Fp).SetOne())
+	require.True(t, new(Eq).Mul(g, minusOne).Equal(new(Eq).Neg(g)))
+	require.True(t, new(Eq).Mul(g, new(fp.Fp).SetZero()).IsIdentity())
+}
+
+func TestPointVestaHash(t *testing.T) {
+	h0 := new(Eq).Hash(nil)
+	require.True(t, h0.IsOnCurve())
+	h1 := new(Eq).Hash([]byte{})
+	require.True(t, h0.Equal(h1))
+	h2 := new(Eq).Hash([]byte{1})
+	require.True(t, h2.IsOnCurve())
+	require.False(t, h2.Equal(h0))
+	for i := 0; i < 25; i++ {
+		var u fq.Fq
+		var seed [64]byte
+		_, _ = crand.Read(seed[:])
+		u.SetBytesWide(&seed)
+		require.True(t, mapSvdwVesta(&u).IsOnCurve())
+	}
+}
+
+func TestPointVestaSerialize(t *testing.T) {
+	curve := VESTA()
+	require.Equal(t, curve, GetCurveByName(VestaName))
+	for i := 0; i < 10; i++ {
+		p := curve.Point.Random(crand.Reader)
+		q, err := curve.Point.FromAffineCompressed(p.ToAffineCompressed())
+		require.NoError(t, err)
+		require.True(t, p.Equal(q))
+		q, err = curve.Point.FromAffineUncompressed(p.ToAffineUncompressed())
+		require.NoError(t, err)
+		require.True(t, p.Equal(q))
+
+		bin, err := p.(*PointVesta).MarshalBinary()
+		require.NoError(t, err)
+		r := new(PointVesta)
+		require.NoError(t, r.UnmarshalBinary(bin))
+		require.True(t, r.Equal(p))
+	}
+	id, err := curve.Point.FromAffineCompressed(curve.NewIdentityPoint().ToAffineCompressed())
+	require.Error(t, err)
+	require.Nil(t, id)
+}
+
+func TestPointVestaSumOfProducts(t *testing.T) {
+	curve := VESTA()
+	points := make([]Point, 8)
+	scalars := make([]Scalar, len(points))
+	expected := curve.NewIdentityPoint()
+	for i := range points {
+		points[i] = curve.Point.Random(crand.Reader)
+		scalars[i] = curve.Scalar.Random(crand.Reader)
+		expected = expected.Add(points[i].Mul(scalars[i]))
+	}
+	require.True(t, curve.Point.SumOfProducts(points, scalars).Equal(expected))
+}
+
+func TestPastaGlvMul(t *testing.T) {
+	for i := 0; i < 25; i++ {
+		s := new(ScalarPallas).Random(crand.Reader).(*ScalarPallas)
+		k1, k2 := pallasGlv.decompose(s.BigInt())
+		require.LessOrEqual(t, k1.BitLen(), glvScalarBits)
+		require.LessOrEqual(t, k2.BitLen(), glvScalarBits)
+
+		p := new(Ep).Random(crand.Reader)
+		expected := new(Ep).Identity()
+		for j := s.BigInt().BitLen() - 1; j >= 0; j-- {
+			expected.Double(expected)
+			if s.BigInt().Bit(j) == 1 {
+				expected.Add(expected, p)
+			}
+		}
+		require.True(t, new(Ep).Mul(p, s.value).Equal(expected))
+	}
+	for i := 0; i < 25; i++ {
+		s := new(ScalarVesta).Random(crand.Reader).(*ScalarVesta)
+		k1, k2 := vestaGlv.decompose(s.BigInt())
+		require.LessOrEqual(t, k1.BitLen(), glvScalarBits)
+		require.LessOrEqual(t, k2.BitLen(), glvScalarBits)
+
+		p := new(Eq).Random(crand.Reader)
+		expected := new(Eq).Identity()
+		for j := s.BigInt().BitLen() - 1; j >= 0; j-- {
+			expected.Double(expected)
+			if s.BigInt().Bit(j) == 1 {
+				expected.Add(expected, p)
+			}
+		}
+		require.True(t, new(Eq).Mul(p, s.value).Equal(expected))
+	}
+}
@@ -0,0 +1,30 @@
+package curves
+
+import (
+	"math/big"
+)
+
+// Zeroizer is implemented by secret values that can overwrite their memory
+// with zeros. A zeroized value is zero and may still be used afterwards.
+type Zeroizer interface {
+	Zeroize()
+}
+
+// Zeroize overwrites every scalar that implements Zeroizer.
+// Nil scalars and scalars of other types are skipped.
+func Zeroize(scalars ...Scalar) {
+	for _, s := range scalars {
+		if z, ok := s.(Zeroizer); ok {
+			z.Zeroize()
+		}
+	}
+}
+
+// zeroizeBigInt overwrites the words backing x before setting it to zero
+func zeroizeBigInt(x *big.Int) {
+	if x == nil {
+		return
+	}
+	clear(x.Bits())
+	x.SetInt64(0)
+}
@@ -0,0 +1,34 @@
+package curves
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScalarZeroize(t *testing.T) {
+	names := []string{
+		K256Name, P256Name, ED25519Name, PallasName, VestaName,
+		BLS12381G1Name, BLS12377G1Name,
+	}
+	for _, name := range names {
+		curve := GetCurveByName(name)
+		t.Run(name, func(t *testing.T) {
+			s := curve.Scalar.Random(crand.Reader)
+			b := s.Bytes()
+			_, ok := s.(Zeroizer)
+			require.True(t, ok)
+
+			// Bytes returns a copy
+			clear(b)
+			require.False(t, s.IsZero())
+
+			clone := s.Clone()
+			Zeroize(s, nil)
+			require.True(t, s.IsZero())
+			require.False(t, clone.IsZero())
+			require.Equal(t, 0, s.Add(clone).Cmp(clone))
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package core
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+)
+
+// MontgomeryModulus is an odd modulus with the precomputed values for constant-time modular exponentiation.
+//
+// big.Int.Exp takes time that depends on the values of its operands. Exp here runs a fixed 4-bit window over
+// fixed-size limbs with Montgomery multiplication, table lookups that touch every entry and a masked final
+// subtraction, so its running time depends only on the sizes of the modulus and the exponent.
+// The precomputation in NewMontgomeryModulus uses big.Int and is not constant time.
+type MontgomeryModulus struct {
+	m      *big.Int
+	limbs  []uint
+	mPrime uint   // -m^{-1} mod 2^W
+	rr     []uint // R² mod m for R = 2^(W·len(limbs))
+}
+
+// NewMontgomeryModulus precomputes the Montgomery parameters of the odd modulus m > 1.
+func NewMontgomeryModulus(m *big.Int) (*MontgomeryModulus, error) {
+	if m == nil {
+		return nil, fmt.Errorf("modulus cannot be nil")
+	}
+	if m.Sign() <= 0 || m.Bit(0) == 0 || m.Cmp(One) == 0 {
+		return nil, fmt.Errorf("modulus must be odd and greater than one")
+	}
+	n := len(m.Bits())
+	mm := &MontgomeryModulus{
+		m:     new(big.Int).Set(m),
+		limbs: toLimbs(m, n),
+	}
+
+	// Newton iteration for m^{-1} mod 2^W, each step doubles the number of correct bits
+	inv := uint(1)
+	for i := 0; i < 7; i++ {
+		inv *= 2 - mm.limbs[0]*inv
+	}
+	mm.mPrime = -inv
+
+	r := new(big.Int).Lsh(One, uint(2*n*bits.UintSize))
+	mm.rr = toLimbs(r.Mod(r, m), n)
+	return mm, nil
+}
+
+// Modulus returns the modulus.
+func (mm *MontgomeryModulus) Modulus() *big.Int {
+	return new(big.Int).Set(mm.m)
+}
+
+// Exp returns x^e mod m for a non-negative exponent e. The running time depends on the number of words of e but
+// not on its value or the value of x.
+func (mm *MontgomeryModulus) Exp(x, e *big.Int) (*big.Int, error) {
+	if x == nil || e == nil {
+		return nil, fmt.Errorf("arguments cannot be nil")
+	}
+	if e.Sign() < 0 {
+		return nil, fmt.Errorf("exponent must be non-negative")
+	}
+	n := len(mm.limbs)
+	base := toLimbs(new(big.Int).Mod(x, mm.m), n)
+
+	// table[i] = x^i in Montgomery form
+	scratch := make([]uint, n+1)
+	var table [16][]uint
+	one := make([]uint, n)
+	one[0] = 1
+	for i := range table {
+		table[i] = make([]uint, n)
+	}
+	mm.mul(table[0], one, mm.rr, scratch)
+	mm.mul(table[1], base, mm.rr, scratch)
+	for i := 2; i < len(table); i++ {
+		mm.mul(table[i], table[i-1], table[1], scratch)
+	}
+
+	acc := append([]uint{}, table[0]...)
+	entry := make([]uint, n)
+	words := e.Bits()
+	for i := len(words) - 1; i >= 0; i-- {
+		w := uint(words[i])
+		for shift := bits.UintSize - 4; shift >= 0; shift -= 4 {
+			for k := 0; k < 4; k++ {
+				mm.mul(acc, acc, acc, scratch)
+			}
+			selectEntry(entry, &table, (w>>uint(shift))&0xf)
+			mm.mul(acc, acc, entry, scratch)
+		}
+	}
+	// leave the Montgomery form
+	mm.mul(acc, acc, one, scratch)
+	return new(big.Int).SetBits(toWords(acc)), nil
+}
+
+// mul sets z = a·b·R^{-1} mod m for a, b < m, interleaving multiplication and reduction. z may alias a or b;
+// t is scratch space of len(limbs)+1 words.
+func (mm *MontgomeryModulus) mul(z, a, b, t []uint) {
+	m := mm.limbs
+	n := len(m)
+	a, b, z, t = a[:n], b[:n], z[:n], t[:n+1]
+	for j := range t {
+		t[j] = 0
+	}
+	for i := 0; i < n; i++ {
+		// t = (t + a·b[i] + q·m) / 2^W with q chosen so that the division is exact
+		bi := b[i]
+		c1, lo := mulAddWWW(a[0], bi, t[0], 0)
+		q := lo * mm.mPrime
+		c2, _ := mulAddWWW(q, m[0], lo, 0)
+		for j := 1; j < n; j++ {
+			c1, lo = mulAddWWW(a[j], bi, t[j], c1)
+			c2, t[j-1] = mulAddWWW(q, m[j], lo, c2)
+		}
+		hi, carry1 := bits.Add(t[n], c1, 0)
+		var carry2 uint
+		t[n-1], carry2 = bits.Add(hi, c2, 0)
+		t[n] = carry1 + carry2
+	}
+
+	// t < 2m: subtract m unless that borrows, selecting the result with a mask
+	var borrow uint
+	for j := 0; j < n; j++ {
+		z[j], borrow = bits.Sub(t[j], m[j], borrow)
+	}
+	_, borrow = bits.Sub(t[n], 0, borrow)
+	// borrow = 1 means t < m and t itself is the result
+	mask := -borrow
+	for j := 0; j < n; j++ {
+		z[j] = (t[j] & mask) | (z[j] &^ mask)
+	}
+}
+
+// selectEntry copies table[index] into out, reading every entry.
+func selectEntry(out []uint, table *[16][]uint, index uint) {
+	for j := range out {
+		out[j] = 0
+	}
+	for i := range table {
+		// mask is all ones when i == index
+		d := uint(i) ^ index
+		mask := ((d | -d) >> (bits.UintSize - 1)) - 1
+		for j, v := range table[i] {
+			out[j] |= v & mask
+		}
+	}
+}
+
+// mulAddWWW returns the high and low words of x·y + z + c.
+func mulAddWWW(x, y, z, c uint) (uint, uint) {
+	hi, lo := bits.Mul(x, y)
+	var carry uint
+	lo, carry = bits.Add(lo, z, 0)
+	hi += carry
+	lo, carry = bits.Add(lo, c, 0)
+	hi += carry
+	return hi, lo
+}
+
+func toLimbs(x *big.Int, n int) []uint {
+	out := make([]uint, n)
+	for i, w := range x.Bits() {
+		out[i] = uint(w)
+	}
+	return out
+}
+
+func toWords(x []uint) []big.Word {
+	out := make([]big.Word, len(x))
+	for i, w := range x {
+		out[i] = big.Word(w)
+	}
+	return out
+}
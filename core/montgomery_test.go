@@ -0,0 +1,45 @@
+package core
+
+import (
+	crand "crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMontgomeryExp(t *testing.T) {
+	for _, size := range []int{3, 64, 65, 255, 1024, 2048} {
+		m, err := crand.Int(crand.Reader, new(big.Int).Lsh(One, uint(size)))
+		require.NoError(t, err)
+		m.SetBit(m, 0, 1)
+		m.SetBit(m, size-1, 1)
+		mm, err := NewMontgomeryModulus(m)
+		require.NoError(t, err)
+		require.Equal(t, m, mm.Modulus())
+		for i := 0; i < 8; i++ {
+			x, err := crand.Int(crand.Reader, new(big.Int).Lsh(m, 1))
+			require.NoError(t, err)
+			e, err := crand.Int(crand.Reader, new(big.Int).Lsh(One, uint(size)))
+			require.NoError(t, err)
+			got, err := mm.Exp(x, e)
+			require.NoError(t, err)
+			require.Zero(t, new(big.Int).Exp(x, e, m).Cmp(got), "size %d", size)
+		}
+		got, err := mm.Exp(Two, Zero)
+		require.NoError(t, err)
+		require.Zero(t, new(big.Int).Mod(One, m).Cmp(got))
+		got, err = mm.Exp(Zero, Two)
+		require.NoError(t, err)
+		require.Equal(t, 0, got.Sign())
+	}
+
+	_, err := NewMontgomeryModulus(big.NewInt(10))
+	require.Error(t, err)
+	_, err = NewMontgomeryModulus(One)
+	require.Error(t, err)
+	mm, err := NewMontgomeryModulus(big.NewInt(11))
+	require.NoError(t, err)
+	_, err = mm.Exp(Two, big.NewInt(-1))
+	require.Error(t, err)
+}
@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"runtime"
 )
 
 // GenerateSafePrime creates a prime number `p`
@@ -40,3 +41,49 @@ func GenerateSafePrime(bits uint) (*big.Int, error) {
 
 	return p, nil
 }
+
+// GenerateSafePrimeParallel creates a safe prime like GenerateSafePrime, searching with `workers` goroutines.
+// A non-positive `workers` uses one goroutine per CPU.
+func GenerateSafePrimeParallel(bits uint, workers int) (*big.Int, error) {
+	if bits < 3 {
+		return nil, fmt.Errorf("safe prime size must be at least 3-bits")
+	}
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	type result struct {
+		p   *big.Int
+		err error
+	}
+	results := make(chan result, workers)
+	done := make(chan struct{})
+	defer close(done)
+
+	checks := int(math.Max(float64(bits)/16, 8))
+	for i := 0; i < workers; i++ {
+		go func() {
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				p, err := rand.Prime(rand.Reader, int(bits)-1)
+				if err != nil {
+					results <- result{err: err}
+					return
+				}
+				p.Add(p.Lsh(p, 1), One)
+				if p.ProbablyPrime(checks) {
+					results <- result{p: p}
+					return
+				}
+			}
+		}()
+	}
+
+	// every worker sends at most one result, so the buffered channel never blocks the losers
+	r := <-results
+	return r.p, r.err
+}
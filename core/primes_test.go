@@ -0,0 +1,22 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateSafePrimeParallel(t *testing.T) {
+	for _, workers := range []int{0, 1, 4} {
+		p, err := GenerateSafePrimeParallel(256, workers)
+		require.NoError(t, err)
+		require.Equal(t, 256, p.BitLen())
+		require.True(t, p.ProbablyPrime(20))
+		q := new(big.Int).Rsh(p, 1)
+		require.True(t, q.ProbablyPrime(20))
+	}
+
+	_, err := GenerateSafePrimeParallel(2, 0)
+	require.Error(t, err)
+}
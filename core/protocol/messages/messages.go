@@ -0,0 +1,296 @@
+// Package messages implements a canonical, versioned binary encoding for MPC round messages.
+//
+// Every encoded message starts with a header
+//
+//	magic "SNR" | version (1 byte) | type (length-prefixed) | curve name (length-prefixed, may be empty)
+//
+// followed by the fields of the message in a fixed order. Variable length values are prefixed with their length as
+// a big-endian uint32, scalars use their canonical byte form and points their compressed affine form. Decoding
+// rejects unknown versions, a different message type, non-canonical scalars, invalid points and trailing bytes, so
+// every message has exactly one encoding.
+//
+// The FROST DKG, the ted25519 FROST signing rounds and sharing.ShamirShare implement encoding.BinaryMarshaler with
+// this format, as do the participants of the FROST and Pedersen DKGs and the ted25519 FROST signer for their state. The DKLs18 rounds, key shares, presignatures and signatures use it for the payloads of their
+// protocol.Message envelopes, which carry the protocol, version and round.
+package messages
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/go-sonr/crypto/core/curves"
+)
+
+// Version1 is the first version of the encoding.
+const Version1 byte = 1
+
+// magic identifies an encoded message.
+var magic = []byte("SNR")
+
+// Encoder writes the fields of one message. The first error is kept and returned by Finish.
+type Encoder struct {
+	buf   bytes.Buffer
+	curve *curves.Curve
+	err   error
+}
+
+// NewEncoder starts a message of type msgType. curve is nil for messages without curve elements.
+func NewEncoder(msgType string, curve *curves.Curve) *Encoder {
+	e := &Encoder{curve: curve}
+	e.buf.Write(magic)
+	e.buf.WriteByte(Version1)
+	e.WriteBytes([]byte(msgType))
+	if curve == nil {
+		e.WriteBytes(nil)
+	} else {
+		e.WriteBytes([]byte(curve.Name))
+	}
+	return e
+}
+
+// WriteUint32 writes v as a big-endian uint32.
+func (e *Encoder) WriteUint32(v uint32) {
+	if e.err != nil {
+		return
+	}
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], v)
+	e.buf.Write(b[:])
+}
+
+// WriteUint32s writes the number of values followed by each value.
+func (e *Encoder) WriteUint32s(values []uint32) {
+	e.WriteUint32(uint32(len(values)))
+	for _, v := range values {
+		e.WriteUint32(v)
+	}
+}
+
+// WriteBytes writes a length-prefixed byte string.
+func (e *Encoder) WriteBytes(b []byte) {
+	if e.err != nil {
+		return
+	}
+	if uint64(len(b)) > math.MaxUint32 {
+		e.err = fmt.Errorf("field of %d bytes is too long", len(b))
+		return
+	}
+	e.WriteUint32(uint32(len(b)))
+	e.buf.Write(b)
+}
+
+// WriteScalar writes s, which must belong to the curve of the message.
+func (e *Encoder) WriteScalar(s curves.Scalar) {
+	if e.err != nil {
+		return
+	}
+	if s == nil {
+		e.err = fmt.Errorf("nil scalar")
+		return
+	}
+	if err := e.checkCurve(s.Point().CurveName()); err != nil {
+		e.err = err
+		return
+	}
+	e.WriteBytes(s.Bytes())
+}
+
+// WritePoint writes p, which must belong to the curve of the message.
+func (e *Encoder) WritePoint(p curves.Point) {
+	if e.err != nil {
+		return
+	}
+	if p == nil {
+		e.err = fmt.Errorf("nil point")
+		return
+	}
+	if err := e.checkCurve(p.CurveName()); err != nil {
+		e.err = err
+		return
+	}
+	e.WriteBytes(p.ToAffineCompressed())
+}
+
+// WritePoints writes the number of points followed by each point.
+func (e *Encoder) WritePoints(points []curves.Point) {
+	e.WriteUint32(uint32(len(points)))
+	for _, p := range points {
+		e.WritePoint(p)
+	}
+}
+
+// Finish returns the encoded message.
+func (e *Encoder) Finish() ([]byte, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+	return e.buf.Bytes(), nil
+}
+
+func (e *Encoder) checkCurve(name string) error {
+	if e.curve == nil {
+		return fmt.Errorf("message has no curve")
+	}
+	if name != e.curve.Name {
+		return fmt.Errorf("element of curve %s in a %s message", name, e.curve.Name)
+	}
+	return nil
+}
+
+// Decoder reads the fields of one message. The first error is kept and returned by Finish.
+type Decoder struct {
+	data  []byte
+	curve *curves.Curve
+	err   error
+}
+
+// NewDecoder parses the header of data and checks that it is a supported version of a msgType message.
+func NewDecoder(data []byte, msgType string) (*Decoder, error) {
+	if len(data) < len(magic)+1 || !bytes.Equal(data[:len(magic)], magic) {
+		return nil, fmt.Errorf("not an encoded message")
+	}
+	if data[len(magic)] != Version1 {
+		return nil, fmt.Errorf("unsupported message version %d", data[len(magic)])
+	}
+	d := &Decoder{data: data[len(magic)+1:]}
+	if t := d.ReadBytes(); d.err == nil && string(t) != msgType {
+		return nil, fmt.Errorf("expected a %s message, got %q", msgType, t)
+	}
+	name := d.ReadBytes()
+	if d.err != nil {
+		return nil, d.err
+	}
+	if len(name) > 0 {
+		d.curve = curves.GetCurveByName(string(name))
+		if d.curve == nil {
+			return nil, fmt.Errorf("unsupported curve %q", name)
+		}
+	}
+	return d, nil
+}
+
+// Curve returns the curve named in the header, or nil.
+func (d *Decoder) Curve() *curves.Curve {
+	return d.curve
+}
+
+// ReadUint32 reads a big-endian uint32.
+func (d *Decoder) ReadUint32() uint32 {
+	if d.err != nil {
+		return 0
+	}
+	if len(d.data) < 4 {
+		d.err = fmt.Errorf("message is truncated")
+		return 0
+	}
+	v := binary.BigEndian.Uint32(d.data)
+	d.data = d.data[4:]
+	return v
+}
+
+// ReadUint32s reads a count followed by that many values.
+func (d *Decoder) ReadUint32s() []uint32 {
+	n := d.ReadUint32()
+	if d.err != nil {
+		return nil
+	}
+	if uint64(n)*4 > uint64(len(d.data)) {
+		d.err = fmt.Errorf("message is truncated")
+		return nil
+	}
+	values := make([]uint32, n)
+	for i := range values {
+		values[i] = d.ReadUint32()
+	}
+	return values
+}
+
+// ReadBytes reads a length-prefixed byte string.
+func (d *Decoder) ReadBytes() []byte {
+	n := d.ReadUint32()
+	if d.err != nil {
+		return nil
+	}
+	if uint64(n) > uint64(len(d.data)) {
+		d.err = fmt.Errorf("message is truncated")
+		return nil
+	}
+	b := make([]byte, n)
+	copy(b, d.data)
+	d.data = d.data[n:]
+	return b
+}
+
+// ReadScalar reads a scalar of the curve of the message.
+func (d *Decoder) ReadScalar() curves.Scalar {
+	b := d.ReadBytes()
+	if d.err != nil {
+		return nil
+	}
+	if d.curve == nil {
+		d.err = fmt.Errorf("message has no curve")
+		return nil
+	}
+	s, err := d.curve.Scalar.SetBytes(b)
+	if err != nil {
+		d.err = fmt.Errorf("invalid scalar: %w", err)
+		return nil
+	}
+	if !bytes.Equal(s.Bytes(), b) {
+		d.err = fmt.Errorf("scalar is not canonical")
+		return nil
+	}
+	return s
+}
+
+// ReadPoint reads a point of the curve of the message.
+func (d *Decoder) ReadPoint() curves.Point {
+	b := d.ReadBytes()
+	if d.err != nil {
+		return nil
+	}
+	if d.curve == nil {
+		d.err = fmt.Errorf("message has no curve")
+		return nil
+	}
+	p, err := d.curve.Point.FromAffineCompressed(b)
+	if err != nil {
+		d.err = fmt.Errorf("invalid point: %w", err)
+		return nil
+	}
+	return p
+}
+
+// ReadPoints reads a count followed by that many points.
+func (d *Decoder) ReadPoints() []curves.Point {
+	n := d.ReadUint32()
+	if d.err != nil {
+		return nil
+	}
+	// every point takes at least its 4 byte length prefix
+	if uint64(n)*4 > uint64(len(d.data)) {
+		d.err = fmt.Errorf("message is truncated")
+		return nil
+	}
+	points := make([]curves.Point, n)
+	for i := range points {
+		points[i] = d.ReadPoint()
+	}
+	if d.err != nil {
+		return nil
+	}
+	return points
+}
+
+// Finish returns the first error met while decoding and rejects trailing bytes.
+func (d *Decoder) Finish() error {
+	if d.err != nil {
+		return d.err
+	}
+	if len(d.data) != 0 {
+		return fmt.Errorf("%d trailing bytes after message", len(d.data))
+	}
+	return nil
+}
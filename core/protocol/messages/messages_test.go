@@ -0,0 +1,102 @@
+package messages
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+)
+
+func TestRoundTrip(t *testing.T) {
+	for _, curve := range []*curves.Curve{curves.K256(), curves.P256(), curves.ED25519()} {
+		s := curve.Scalar.Random(crand.Reader)
+		p := curve.Point.Random(crand.Reader)
+		enc := NewEncoder("test", curve)
+		enc.WriteUint32(7)
+		enc.WriteBytes([]byte("payload"))
+		enc.WriteUint32s([]uint32{1, 3})
+		enc.WriteScalar(s)
+		enc.WritePoints([]curves.Point{p, p.Double()})
+		data, err := enc.Finish()
+		require.NoError(t, err)
+
+		dec, err := NewDecoder(data, "test")
+		require.NoError(t, err)
+		require.Equal(t, curve.Name, dec.Curve().Name)
+		require.Equal(t, uint32(7), dec.ReadUint32())
+		require.Equal(t, []byte("payload"), dec.ReadBytes())
+		require.Equal(t, []uint32{1, 3}, dec.ReadUint32s())
+		require.Equal(t, 0, s.Cmp(dec.ReadScalar()))
+		points := dec.ReadPoints()
+		require.NoError(t, dec.Finish())
+		require.Len(t, points, 2)
+		require.True(t, p.Equal(points[0]))
+		require.True(t, p.Double().Equal(points[1]))
+	}
+}
+
+func TestEncoderRejectsOtherCurve(t *testing.T) {
+	enc := NewEncoder("test", curves.K256())
+	enc.WriteScalar(curves.P256().Scalar.One())
+	_, err := enc.Finish()
+	require.Error(t, err)
+
+	enc = NewEncoder("test", nil)
+	enc.WritePoint(curves.K256().Point.Generator())
+	_, err = enc.Finish()
+	require.Error(t, err)
+}
+
+func TestDecoderRejectsMalformed(t *testing.T) {
+	curve := curves.K256()
+	enc := NewEncoder("test", curve)
+	enc.WriteScalar(curve.Scalar.One())
+	data, err := enc.Finish()
+	require.NoError(t, err)
+
+	_, err = NewDecoder(data, "other")
+	require.Error(t, err)
+
+	bad := append([]byte{}, data...)
+	bad[3] = Version1 + 1
+	_, err = NewDecoder(bad, "test")
+	require.Error(t, err)
+
+	_, err = NewDecoder(data[:2], "test")
+	require.Error(t, err)
+
+	// truncated field
+	dec, err := NewDecoder(data[:len(data)-1], "test")
+	require.NoError(t, err)
+	dec.ReadScalar()
+	require.Error(t, dec.Finish())
+
+	// trailing bytes
+	dec, err = NewDecoder(append(append([]byte{}, data...), 0), "test")
+	require.NoError(t, err)
+	dec.ReadScalar()
+	require.Error(t, dec.Finish())
+
+	// scalar not reduced modulo the group order
+	enc = NewEncoder("test", curve)
+	enc.WriteBytes(curve.Scalar.One().Neg().Bytes())
+	data, err = enc.Finish()
+	require.NoError(t, err)
+	data[len(data)-1]++
+	dec, err = NewDecoder(data, "test")
+	require.NoError(t, err)
+	dec.ReadScalar()
+	require.Error(t, dec.Finish())
+
+	// count larger than the message
+	enc = NewEncoder("test", nil)
+	enc.WriteUint32(1 << 30)
+	data, err = enc.Finish()
+	require.NoError(t, err)
+	dec, err = NewDecoder(data, "test")
+	require.NoError(t, err)
+	require.Nil(t, dec.ReadUint32s())
+	require.Error(t, dec.Finish())
+}
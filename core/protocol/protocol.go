@@ -27,6 +27,18 @@ const (
 	// Dkls18Refresh specifies the DKG protocol of the DKLs18 potocol.
 	Dkls18Refresh = "DKLs18-Refresh"
 
+	// Dkls18Reshare specifies the protocol moving DKLs18 key shares to a new pair of parties.
+	Dkls18Reshare = "DKLs18-Reshare"
+
+	// Dkls18Presign specifies the presigning and online signing protocol of the DKLs18 potocol.
+	Dkls18Presign = "DKLs18-Presign"
+
+	// Dkls18Derive specifies the non-hardened BIP32 child derivation protocol on DKLs18 key shares.
+	Dkls18Derive = "DKLs18-Derive"
+
+	// GarbledCircuit specifies the two party evaluation of a garbled circuit.
+	GarbledCircuit = "GC-2PC"
+
 	// versions will increment in 100 intervals, to leave room for adding other versions in between them if it is
 	// ever needed in the future.
 
@@ -80,23 +92,17 @@ func (m *Message) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON unmarshals the message from JSON.
 func (m *Message) UnmarshalJSON(data []byte) error {
-	var obj map[string]interface{}
-	if err := json.Unmarshal(data, &obj); err != nil {
+	type Alias Message
+	var a Alias
+	if err := json.Unmarshal(data, &a); err != nil {
 		return err
 	}
-	m.Payloads = make(map[string][]byte)
-	m.Metadata = make(map[string]string)
-	for k, v := range obj {
-		switch k {
-		case "payloads":
-			m.Payloads = v.(map[string][]byte)
-		case "metadata":
-			m.Metadata = v.(map[string]string)
-		case "protocol":
-			m.Protocol = v.(string)
-		case "version":
-			m.Version = uint(v.(float64))
-		}
+	*m = Message(a)
+	if m.Payloads == nil {
+		m.Payloads = make(map[string][]byte)
+	}
+	if m.Metadata == nil {
+		m.Metadata = make(map[string]string)
 	}
 	return nil
 }
@@ -112,3 +118,9 @@ type Iterator interface {
 	// Returns an error if an error was encountered during protocol execution.
 	Result(version uint) (*Message, error)
 }
+
+// Finisher is implemented by iterators that can report whether they have run all their steps without running one.
+type Finisher interface {
+	// Finished reports whether Next would return ErrProtocolFinished.
+	Finished() bool
+}
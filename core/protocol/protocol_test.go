@@ -0,0 +1,26 @@
+package protocol
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageEncoding(t *testing.T) {
+	m := &Message{
+		Payloads: map[string][]byte{"payload": {1, 2, 3}},
+		Metadata: map[string]string{"round": "alice-output"},
+		Protocol: Dkls18Dkg,
+		Version:  Version1,
+	}
+	s, err := EncodeMessage(m)
+	require.NoError(t, err)
+	decoded, err := DecodeMessage(s)
+	require.NoError(t, err)
+	require.Equal(t, m, decoded)
+
+	decoded, err = DecodeMessage("e30=") // {}
+	require.NoError(t, err)
+	require.NotNil(t, decoded.Payloads)
+	require.NotNil(t, decoded.Metadata)
+}
@@ -0,0 +1,332 @@
+package protocol
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// maxEarlyEnvelopes bounds how far ahead of the expected envelope the counterparty may be.
+const maxEarlyEnvelopes = 64
+
+// ErrTimeout is returned by Runner when a peer did not send its next message in time.
+var ErrTimeout = fmt.Errorf("timed out waiting for the counterparty")
+
+// Envelope carries the messages of one step or round of a session from one party to another.
+type Envelope struct {
+	// From is the sender of the envelope.
+	From string
+	// Seq numbers the envelopes of a sender, starting at 0, so that the receiver can put them back in order.
+	Seq uint32
+	// Done is set on the last envelope of a sender. In a two-party session it carries no message, in a round based
+	// session it carries the messages of the last round of the sender.
+	Done bool
+	// Message is the output of a step of the sender's iterator, or its point-to-point message of a round.
+	Message *Message
+	// Broadcast is the message of a round that the sender sent to every party.
+	Broadcast *Message
+}
+
+// Transport moves envelopes between the parties of one session. Implementations may deliver envelopes out of
+// order or more than once; Runner restores the order and drops duplicates.
+type Transport interface {
+	// Send delivers the envelope to the party to.
+	Send(to string, envelope *Envelope) error
+	// Receive returns the channel of envelopes sent to this party. It is closed when the transport shuts down.
+	Receive() <-chan *Envelope
+}
+
+// RoundInput is what a peer sent to a party in one round.
+type RoundInput struct {
+	// Broadcast is the message the peer sent to every party, nil if none.
+	Broadcast *Message
+	// Direct is the point-to-point message of the peer to this party, nil if none.
+	Direct *Message
+}
+
+// RoundOutput is what a party sends in one round.
+type RoundOutput struct {
+	// Broadcast is sent to every peer, nil if none.
+	Broadcast *Message
+	// Direct are the point-to-point messages by recipient.
+	Direct map[string]*Message
+}
+
+// RoundParty is a party of a protocol among any number of parties, such as a threshold DKG, run in rounds that
+// every party starts together.
+type RoundParty interface {
+	// Round runs the next round on the messages the peers sent in the previous round, by sender, and is nil for
+	// the first round. A finished party returns done with the messages of its last round and is not called again.
+	Round(in map[string]*RoundInput) (out *RoundOutput, done bool, err error)
+
+	// Result returns the final result, if any, of the completed protocol.
+	Result(version uint) (*Message, error)
+}
+
+// Runner drives a protocol over a Transport, either an Iterator against a counterparty with Run or a RoundParty
+// among a party set with RunRounds.
+//
+// Each step or round output is sent in a numbered envelope and the envelopes of each peer are used in order,
+// buffering envelopes that arrive early. In a two-party session, the party whose step returns no message has
+// finished and tells the counterparty with a Done envelope; a counterparty that receives it checks that its own
+// iterator has finished too, when the iterator is a Finisher.
+//
+// A round broadcast is sent to each peer in its envelope, so nothing stops a dishonest sender from sending
+// different broadcasts to different peers. Protocols that need every party to see the same broadcast must check
+// it, for example by echoing digests in the next round.
+type Runner struct {
+	// Self is the name of this party on the transport.
+	Self string
+	// Peer is the name of the counterparty on the transport, for Run.
+	Peer string
+	// Peers are the names of the other parties on the transport, for RunRounds.
+	Peers []string
+	// Transport delivers the envelopes.
+	Transport Transport
+	// Timeout bounds the wait for each message of a peer. Zero waits until the context is done.
+	Timeout time.Duration
+}
+
+// Run executes the protocol to completion. The initiator runs the first step without input; which party starts is
+// given by the protocol, e.g. Bob for the DKLs18 DKG and Alice for signing and refresh.
+func (r *Runner) Run(ctx context.Context, iterator Iterator, initiator bool) error {
+	if r.Transport == nil || iterator == nil {
+		return ErrNotInitialized
+	}
+	var (
+		sent uint32
+		box  = newMailbox([]string{r.Peer})
+	)
+	send := func(envelope *Envelope) error {
+		envelope.From, envelope.Seq = r.Self, sent
+		sent++
+		if err := r.Transport.Send(r.Peer, envelope); err != nil {
+			return fmt.Errorf("sending to %s: %w", r.Peer, err)
+		}
+		return nil
+	}
+	// step runs the iterator on input and reports whether this party has finished
+	step := func(input *Message) (bool, error) {
+		output, err := iterator.Next(input)
+		if errors.Is(err, ErrProtocolFinished) {
+			return true, send(&Envelope{Done: true})
+		}
+		if err != nil {
+			return false, err
+		}
+		if output == nil {
+			return true, send(&Envelope{Done: true})
+		}
+		return false, send(&Envelope{Message: output})
+	}
+
+	if initiator {
+		done, err := step(nil)
+		if err != nil || done {
+			return err
+		}
+	}
+	for {
+		envelope, err := r.receive(ctx, box, r.Peer)
+		if err != nil {
+			return err
+		}
+		if envelope.Done {
+			// the counterparty consumed our last message, so our iterator must have no steps left
+			if finisher, ok := iterator.(Finisher); ok && !finisher.Finished() {
+				return fmt.Errorf("%s finished before %s", r.Peer, r.Self)
+			}
+			return nil
+		}
+		if envelope.Message == nil {
+			return fmt.Errorf("envelope %d of %s has no message", envelope.Seq, r.Peer)
+		}
+		done, err := step(envelope.Message)
+		if err != nil || done {
+			return err
+		}
+	}
+}
+
+// Execute runs the protocol and returns the result of the iterator.
+func (r *Runner) Execute(ctx context.Context, iterator Iterator, initiator bool, version uint) (*Message, error) {
+	if err := r.Run(ctx, iterator, initiator); err != nil {
+		return nil, err
+	}
+	return iterator.Result(version)
+}
+
+// RunRounds executes a round based protocol among Self and Peers until the party has finished.
+func (r *Runner) RunRounds(ctx context.Context, party RoundParty) error {
+	if r.Transport == nil || party == nil || len(r.Peers) == 0 {
+		return ErrNotInitialized
+	}
+	var (
+		seq    uint32
+		in     map[string]*RoundInput
+		box    = newMailbox(r.Peers)
+		active = append([]string(nil), r.Peers...)
+	)
+	for {
+		out, done, err := party.Round(in)
+		if err != nil {
+			return err
+		}
+		if out == nil {
+			out = &RoundOutput{}
+		}
+		for to := range out.Direct {
+			if _, ok := box.next[to]; !ok {
+				return fmt.Errorf("message to unknown party %s", to)
+			}
+		}
+		for _, peer := range r.Peers {
+			envelope := &Envelope{From: r.Self, Seq: seq, Done: done, Broadcast: out.Broadcast, Message: out.Direct[peer]}
+			if err := r.Transport.Send(peer, envelope); err != nil {
+				return fmt.Errorf("sending to %s: %w", peer, err)
+			}
+		}
+		seq++
+		if done {
+			return nil
+		}
+		if len(active) == 0 {
+			return fmt.Errorf("every peer finished before %s", r.Self)
+		}
+
+		in = make(map[string]*RoundInput, len(active))
+		running := active[:0]
+		for _, peer := range active {
+			envelope, err := r.receive(ctx, box, peer)
+			if err != nil {
+				return err
+			}
+			if envelope.Broadcast != nil || envelope.Message != nil {
+				in[peer] = &RoundInput{Broadcast: envelope.Broadcast, Direct: envelope.Message}
+			}
+			// a finished peer sends nothing more
+			if !envelope.Done {
+				running = append(running, peer)
+			}
+		}
+		active = running
+	}
+}
+
+// ExecuteRounds runs a round based protocol and returns the result of the party.
+func (r *Runner) ExecuteRounds(ctx context.Context, party RoundParty, version uint) (*Message, error) {
+	if err := r.RunRounds(ctx, party); err != nil {
+		return nil, err
+	}
+	return party.Result(version)
+}
+
+// mailbox puts the envelopes of the peers back in order
+type mailbox struct {
+	// next is the sequence number of the next envelope of each peer
+	next map[string]uint32
+	// early buffers the envelopes of each peer that arrive before they are used
+	early map[string]map[uint32]*Envelope
+}
+
+func newMailbox(peers []string) *mailbox {
+	box := &mailbox{next: map[string]uint32{}, early: map[string]map[uint32]*Envelope{}}
+	for _, peer := range peers {
+		box.next[peer] = 0
+		box.early[peer] = map[uint32]*Envelope{}
+	}
+	return box
+}
+
+// receive returns the next envelope of the peer from, buffering the envelopes of every peer that arrive early.
+func (r *Runner) receive(ctx context.Context, box *mailbox, from string) (*Envelope, error) {
+	seq := box.next[from]
+	if envelope, ok := box.early[from][seq]; ok {
+		delete(box.early[from], seq)
+		box.next[from]++
+		return envelope, nil
+	}
+	var timeout <-chan time.Time
+	if r.Timeout > 0 {
+		timer := time.NewTimer(r.Timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timeout:
+			return nil, ErrTimeout
+		case envelope, ok := <-r.Transport.Receive():
+			if !ok {
+				return nil, fmt.Errorf("transport closed while waiting for %s", from)
+			}
+			if envelope == nil {
+				continue
+			}
+			early, known := box.early[envelope.From]
+			if !known {
+				return nil, fmt.Errorf("envelope from unexpected party %s", envelope.From)
+			}
+			expected := box.next[envelope.From]
+			switch {
+			case envelope.From == from && envelope.Seq == seq:
+				box.next[from]++
+				return envelope, nil
+			case envelope.Seq-expected > maxEarlyEnvelopes && envelope.Seq > expected:
+				return nil, fmt.Errorf("envelope %d of %s is too far ahead of %d", envelope.Seq, envelope.From, expected)
+			case envelope.Seq >= expected:
+				early[envelope.Seq] = envelope
+			}
+			// envelopes before the expected one were already used and are dropped as duplicates
+		}
+	}
+}
+
+// Pipe returns two connected in-memory transports for the parties a and b.
+func Pipe(a, b string) (Transport, Transport) {
+	transports := Mesh(a, b)
+	return transports[a], transports[b]
+}
+
+// Mesh returns connected in-memory transports for the parties, by party.
+func Mesh(parties ...string) map[string]Transport {
+	pipes := make(map[string]*pipe, len(parties))
+	for _, party := range parties {
+		pipes[party] = &pipe{inbox: make(chan *Envelope, 16*len(parties)), peers: map[string]*pipe{}}
+	}
+	transports := make(map[string]Transport, len(parties))
+	for _, party := range parties {
+		for _, peer := range parties {
+			if peer != party {
+				pipes[party].peers[peer] = pipes[peer]
+			}
+		}
+		transports[party] = pipes[party]
+	}
+	return transports
+}
+
+type pipe struct {
+	peers map[string]*pipe
+	inbox chan *Envelope
+}
+
+func (p *pipe) Send(to string, envelope *Envelope) error {
+	peer, ok := p.peers[to]
+	if !ok {
+		return fmt.Errorf("unknown party %s", to)
+	}
+	select {
+	case peer.inbox <- envelope:
+		return nil
+	default:
+		return fmt.Errorf("inbox of %s is full", to)
+	}
+}
+
+func (p *pipe) Receive() <-chan *Envelope {
+	return p.inbox
+}
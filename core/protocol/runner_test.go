@@ -0,0 +1,282 @@
+package protocol
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// counter is a toy protocol where the parties take turns incrementing a counter until it reaches limit.
+type counter struct {
+	limit uint32
+	value uint32
+	done  bool
+}
+
+func (c *counter) Next(input *Message) (*Message, error) {
+	if c.done {
+		return nil, ErrProtocolFinished
+	}
+	if input != nil {
+		bz := input.Payloads["counter"]
+		if len(bz) != 4 {
+			return nil, fmt.Errorf("invalid counter")
+		}
+		c.value = binary.BigEndian.Uint32(bz)
+	}
+	if c.value >= c.limit {
+		c.done = true
+		return nil, nil
+	}
+	c.value++
+	if c.value >= c.limit {
+		c.done = true
+	}
+	bz := make([]byte, 4)
+	binary.BigEndian.PutUint32(bz, c.value)
+	return &Message{Payloads: map[string][]byte{"counter": bz}, Version: Version1}, nil
+}
+
+func (c *counter) Finished() bool {
+	return c.done
+}
+
+func (c *counter) Result(uint) (*Message, error) {
+	if !c.done {
+		return nil, nil
+	}
+	bz := make([]byte, 4)
+	binary.BigEndian.PutUint32(bz, c.value)
+	return &Message{Payloads: map[string][]byte{"counter": bz}, Version: Version1}, nil
+}
+
+// stepCounter counts the steps an iterator runs.
+type stepCounter struct {
+	*counter
+	steps int
+}
+
+func (c *stepCounter) Next(input *Message) (*Message, error) {
+	c.steps++
+	return c.counter.Next(input)
+}
+
+func counterMessage(value uint32) *Message {
+	bz := make([]byte, 4)
+	binary.BigEndian.PutUint32(bz, value)
+	return &Message{Payloads: map[string][]byte{"counter": bz}, Version: Version1}
+}
+
+// summer is a toy round based protocol. In round 1 every party broadcasts its value and sends each peer the name
+// of the peer, in round 2 it checks the names and adds up the values. It lasts rounds rounds, the extra rounds
+// send nothing.
+type summer struct {
+	self   string
+	peers  []string
+	value  uint32
+	rounds int
+	round  int
+	sum    uint32
+}
+
+func (s *summer) Round(in map[string]*RoundInput) (*RoundOutput, bool, error) {
+	s.round++
+	switch s.round {
+	case 1:
+		out := &RoundOutput{Broadcast: counterMessage(s.value), Direct: map[string]*Message{}}
+		for _, peer := range s.peers {
+			out.Direct[peer] = &Message{Payloads: map[string][]byte{"to": []byte(peer)}}
+		}
+		return out, s.rounds == 1, nil
+	case 2:
+		if len(in) != len(s.peers) {
+			return nil, false, fmt.Errorf("%d inputs from %d peers", len(in), len(s.peers))
+		}
+		s.sum = s.value
+		for from, input := range in {
+			if input.Broadcast == nil || input.Direct == nil || string(input.Direct.Payloads["to"]) != s.self {
+				return nil, false, fmt.Errorf("invalid input from %s", from)
+			}
+			s.sum += binary.BigEndian.Uint32(input.Broadcast.Payloads["counter"])
+		}
+	}
+	return nil, s.round >= s.rounds, nil
+}
+
+func (s *summer) Result(uint) (*Message, error) {
+	return counterMessage(s.sum), nil
+}
+
+// runRounds runs one summer per transport, with the values 1, 2, ..., and returns their errors by party
+func runRounds(t *testing.T, transports map[string]Transport, rounds map[string]int) (map[string]*Message, map[string]error) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	names := make([]string, 0, len(transports))
+	for name := range transports {
+		names = append(names, name)
+	}
+	type outcome struct {
+		name   string
+		result *Message
+		err    error
+	}
+	outcomes := make(chan outcome, len(names))
+	for i, name := range names {
+		var peers []string
+		for _, peer := range names {
+			if peer != name {
+				peers = append(peers, peer)
+			}
+		}
+		party := &summer{self: name, peers: peers, value: uint32(i + 1), rounds: rounds[name]}
+		runner := &Runner{Self: name, Peers: peers, Transport: transports[name], Timeout: time.Second}
+		go func(name string) {
+			result, err := runner.ExecuteRounds(ctx, party, Version1)
+			outcomes <- outcome{name, result, err}
+		}(name)
+	}
+	results, errs := map[string]*Message{}, map[string]error{}
+	for range names {
+		out := <-outcomes
+		results[out.name], errs[out.name] = out.result, out.err
+	}
+	return results, errs
+}
+
+// duplicating delivers every envelope twice.
+type duplicating struct {
+	Transport
+}
+
+func (d *duplicating) Send(to string, envelope *Envelope) error {
+	if err := d.Transport.Send(to, envelope); err != nil {
+		return err
+	}
+	return d.Transport.Send(to, envelope)
+}
+
+func runPair(t *testing.T, a, b Transport, limit uint32) (*Message, *Message) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	first := &Runner{Self: "a", Peer: "b", Transport: a, Timeout: time.Second}
+	second := &Runner{Self: "b", Peer: "a", Transport: b, Timeout: time.Second}
+	type outcome struct {
+		result *Message
+		err    error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		result, err := second.Execute(ctx, &counter{limit: limit}, false, Version1)
+		done <- outcome{result, err}
+	}()
+	resultA, err := first.Execute(ctx, &counter{limit: limit}, true, Version1)
+	require.NoError(t, err)
+	out := <-done
+	require.NoError(t, out.err)
+	return resultA, out.result
+}
+
+func TestRunner(t *testing.T) {
+	for _, limit := range []uint32{1, 2, 7, 8} {
+		a, b := Pipe("a", "b")
+		resultA, resultB := runPair(t, a, b, limit)
+		require.Equal(t, resultA, resultB)
+		require.Equal(t, limit, binary.BigEndian.Uint32(resultA.Payloads["counter"]))
+	}
+}
+
+func TestRunnerDropsDuplicates(t *testing.T) {
+	a, b := Pipe("a", "b")
+	resultA, resultB := runPair(t, &duplicating{Transport: a}, &duplicating{Transport: b}, 9)
+	require.Equal(t, resultA, resultB)
+	require.Equal(t, uint32(9), binary.BigEndian.Uint32(resultA.Payloads["counter"]))
+}
+
+func TestRunnerBuffersEarlyEnvelopes(t *testing.T) {
+	a, b := Pipe("a", "b")
+	bz := make([]byte, 4)
+	binary.BigEndian.PutUint32(bz, 2)
+	// b's Done overtakes its message
+	require.NoError(t, b.Send("a", &Envelope{From: "b", Seq: 1, Done: true}))
+	require.NoError(t, b.Send("a", &Envelope{From: "b", Seq: 0, Message: &Message{Payloads: map[string][]byte{"counter": bz}}}))
+	runner := &Runner{Self: "a", Peer: "b", Transport: a, Timeout: time.Second}
+	result, err := runner.Execute(context.Background(), &counter{limit: 3}, true, Version1)
+	require.NoError(t, err)
+	require.Equal(t, uint32(3), binary.BigEndian.Uint32(result.Payloads["counter"]))
+
+	// b finishing while a still has steps left is an error, and a runs no step on the Done envelope
+	a, b = Pipe("a", "b")
+	require.NoError(t, b.Send("a", &Envelope{From: "b", Seq: 0, Done: true}))
+	runner.Transport = a
+	iterator := &stepCounter{counter: &counter{limit: 3}}
+	require.Error(t, runner.Run(context.Background(), iterator, true))
+	require.Equal(t, 1, iterator.steps)
+}
+
+func TestRunnerTimeout(t *testing.T) {
+	a, _ := Pipe("a", "b")
+	runner := &Runner{Self: "a", Peer: "b", Transport: a, Timeout: 10 * time.Millisecond}
+	err := runner.Run(context.Background(), &counter{limit: 4}, true)
+	require.ErrorIs(t, err, ErrTimeout)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	runner.Timeout = 0
+	err = runner.Run(ctx, &counter{limit: 4}, true)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRunnerRejectsUnexpectedSender(t *testing.T) {
+	a, b := Pipe("a", "b")
+	require.NoError(t, b.Send("a", &Envelope{From: "c", Message: &Message{}}))
+	runner := &Runner{Self: "a", Peer: "b", Transport: a, Timeout: time.Second}
+	require.Error(t, runner.Run(context.Background(), &counter{limit: 4}, false))
+}
+
+func TestRunnerRounds(t *testing.T) {
+	parties := []string{"a", "b", "c", "d"}
+	rounds := map[string]int{"a": 2, "b": 2, "c": 2, "d": 2}
+	results, errs := runRounds(t, Mesh(parties...), rounds)
+	for _, party := range parties {
+		require.NoError(t, errs[party])
+		require.Equal(t, uint32(1+2+3+4), binary.BigEndian.Uint32(results[party].Payloads["counter"]))
+	}
+
+	transports := Mesh(parties...)
+	for party, transport := range transports {
+		transports[party] = &duplicating{Transport: transport}
+	}
+	results, errs = runRounds(t, transports, rounds)
+	for _, party := range parties {
+		require.NoError(t, errs[party])
+		require.Equal(t, uint32(1+2+3+4), binary.BigEndian.Uint32(results[party].Payloads["counter"]))
+	}
+
+	// parties that finish early drop out, the others keep running
+	results, errs = runRounds(t, Mesh(parties...), map[string]int{"a": 2, "b": 2, "c": 4, "d": 4})
+	for _, party := range parties {
+		require.NoError(t, errs[party])
+		require.Equal(t, uint32(1+2+3+4), binary.BigEndian.Uint32(results[party].Payloads["counter"]))
+	}
+}
+
+func TestRunnerRoundsErrors(t *testing.T) {
+	// every peer of a finishes while a has rounds left
+	_, errs := runRounds(t, Mesh("a", "b"), map[string]int{"a": 4, "b": 2})
+	require.NoError(t, errs["b"])
+	require.ErrorContains(t, errs["a"], "every peer finished")
+
+	transports := Mesh("a", "b")
+	runner := &Runner{Self: "a", Peers: []string{"b"}, Transport: transports["a"], Timeout: 10 * time.Millisecond}
+	err := runner.RunRounds(context.Background(), &summer{self: "a", peers: []string{"b", "c"}, rounds: 2})
+	require.ErrorContains(t, err, "unknown party c")
+	err = runner.RunRounds(context.Background(), &summer{self: "a", peers: []string{"b"}, rounds: 2})
+	require.ErrorIs(t, err, ErrTimeout)
+	require.ErrorIs(t, (&Runner{Self: "a", Transport: transports["a"]}).RunRounds(context.Background(), &summer{}), ErrNotInitialized)
+}
@@ -0,0 +1,17 @@
+//go:build !unix
+
+package secret
+
+import (
+	"errors"
+)
+
+var errNoLock = errors.New("memory locking is not supported on this platform")
+
+func lockedAlloc(int) ([]byte, error) {
+	return nil, errNoLock
+}
+
+func lockedFree([]byte) error {
+	return errNoLock
+}
@@ -0,0 +1,30 @@
+//go:build unix
+
+package secret
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// lockedAlloc maps n bytes of anonymous memory and locks them into RAM
+func lockedAlloc(n int) ([]byte, error) {
+	if n == 0 {
+		return nil, unix.EINVAL
+	}
+	data, err := unix.Mmap(-1, 0, n, unix.PROT_READ|unix.PROT_WRITE, unix.MAP_ANON|unix.MAP_PRIVATE)
+	if err != nil {
+		return nil, err
+	}
+	if err = unix.Mlock(data); err != nil {
+		_ = unix.Munmap(data)
+		return nil, err
+	}
+	return data, nil
+}
+
+func lockedFree(data []byte) error {
+	if err := unix.Munlock(data); err != nil {
+		return err
+	}
+	return unix.Munmap(data)
+}
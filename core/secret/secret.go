@@ -0,0 +1,67 @@
+// Package secret holds the helpers for handling key material: wiping byte
+// slices and allocating buffers that are locked in memory so that the
+// operating system never writes them to swap.
+//
+// Go may copy values while moving them around, wiping only guarantees that
+// the memory it is given no longer holds the secret. Keep secrets in one
+// place, a Buffer or a type implementing curves.Zeroizer, and wipe it once
+// it is no longer needed.
+package secret
+
+import (
+	"runtime"
+)
+
+// Wipe overwrites b with zeros
+func Wipe(b []byte) {
+	clear(b)
+	runtime.KeepAlive(b)
+}
+
+// Buffer is a fixed size byte buffer for secrets. Where the platform supports
+// it the memory is allocated outside the Go heap and locked into RAM.
+type Buffer struct {
+	data   []byte
+	locked bool
+}
+
+// NewBuffer allocates a zeroed buffer of n bytes. The memory is locked when
+// the platform allows it; if locking fails the buffer falls back to the heap
+// and Locked reports false.
+func NewBuffer(n int) *Buffer {
+	if data, err := lockedAlloc(n); err == nil {
+		return &Buffer{data: data, locked: true}
+	}
+	return &Buffer{data: make([]byte, n)}
+}
+
+// NewBufferFromBytes copies b into a new buffer and wipes b
+func NewBufferFromBytes(b []byte) *Buffer {
+	buf := NewBuffer(len(b))
+	copy(buf.data, b)
+	Wipe(b)
+	return buf
+}
+
+// Bytes returns the memory of the buffer. It is only valid until Destroy.
+func (b *Buffer) Bytes() []byte {
+	return b.data
+}
+
+// Locked reports whether the buffer is locked in memory
+func (b *Buffer) Locked() bool {
+	return b.locked
+}
+
+// Destroy wipes the buffer and releases its memory.
+// The buffer is empty afterwards and Destroy may be called again.
+func (b *Buffer) Destroy() {
+	if b.data == nil {
+		return
+	}
+	Wipe(b.data)
+	if b.locked {
+		_ = lockedFree(b.data)
+	}
+	b.data, b.locked = nil, false
+}
@@ -0,0 +1,33 @@
+package secret
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWipe(t *testing.T) {
+	b := []byte{1, 2, 3, 4}
+	Wipe(b)
+	require.Equal(t, []byte{0, 0, 0, 0}, b)
+	Wipe(nil)
+}
+
+func TestBuffer(t *testing.T) {
+	src := []byte("correct horse battery staple")
+	want := append([]byte{}, src...)
+	buf := NewBufferFromBytes(src)
+	require.Equal(t, want, buf.Bytes())
+	require.Equal(t, make([]byte, len(src)), src)
+	// memory locking is best effort, RLIMIT_MEMLOCK may forbid it
+	t.Logf("locked: %v", buf.Locked())
+
+	buf.Destroy()
+	require.Nil(t, buf.Bytes())
+	require.False(t, buf.Locked())
+	buf.Destroy()
+
+	empty := NewBuffer(0)
+	require.Empty(t, empty.Bytes())
+	empty.Destroy()
+}
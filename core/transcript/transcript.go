@@ -0,0 +1,134 @@
+// Package transcript is the Fiat-Shamir transcript shared by the proofs of
+// this module. It is a merlin transcript, https://merlin.cool, whose
+// encodings of curve points and scalars and whose challenge extraction are
+// fixed in one place, so every protocol absorbs its inputs the same way.
+//
+// A transcript starts with the domain of the protocol. Every message is
+// appended under a label, and challenges are derived from everything appended
+// so far, which makes each challenge depend on the whole statement and all
+// earlier prover messages.
+package transcript
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+
+	"github.com/gtank/merlin"
+
+	"github.com/go-sonr/crypto/core/curves"
+)
+
+// ChallengeBytes is the number of bytes extracted for a challenge scalar,
+// twice the size of the scalars so that the reduction is unbiased
+const ChallengeBytes = 64
+
+// Transcript is a merlin transcript with helpers for curve points and scalars.
+// AppendMessage and ExtractBytes remain available for raw bytes.
+//
+// The merlin state cannot be exported, so a transcript of NewRecorded
+// records its operations, which is how MarshalBinary and UnmarshalBinary save
+// and restore it. Recording keeps every appended message in memory, so only
+// the transcripts of protocol parties that are persisted between rounds use
+// it. Transcripts only absorb public protocol messages.
+type Transcript struct {
+	*merlin.Transcript
+	domain string
+	record bool
+	ops    []op
+}
+
+// op is an operation on the transcript: an extraction of Extract bytes, or
+// an appended message when Extract is zero
+type op struct {
+	Label   []byte
+	Message []byte
+	Extract int
+}
+
+// New creates a transcript for the protocol with the given domain
+func New(domain string) *Transcript {
+	return &Transcript{Transcript: merlin.NewTranscript(domain), domain: domain}
+}
+
+// NewRecorded creates a transcript like New that records its operations, so
+// that it can be serialized with MarshalBinary
+func NewRecorded(domain string) *Transcript {
+	t := New(domain)
+	t.record = true
+	return t
+}
+
+// AppendMessage appends message under label
+func (t *Transcript) AppendMessage(label, message []byte) {
+	if t.record {
+		t.ops = append(t.ops, op{Label: append([]byte(nil), label...), Message: append([]byte(nil), message...)})
+	}
+	t.Transcript.AppendMessage(label, message)
+}
+
+// ExtractBytes derives outLen bytes under label from the transcript
+func (t *Transcript) ExtractBytes(label []byte, outLen int) []byte {
+	if t.record {
+		t.ops = append(t.ops, op{Label: append([]byte(nil), label...), Extract: outLen})
+	}
+	return t.Transcript.ExtractBytes(label, outLen)
+}
+
+type transcriptState struct {
+	Domain string
+	Ops    []op
+}
+
+// MarshalBinary serializes a transcript of NewRecorded
+func (t *Transcript) MarshalBinary() ([]byte, error) {
+	if !t.record {
+		return nil, fmt.Errorf("transcript: operations were not recorded")
+	}
+	buf := new(bytes.Buffer)
+	if err := gob.NewEncoder(buf).Encode(&transcriptState{Domain: t.domain, Ops: t.ops}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary restores a transcript serialized by MarshalBinary by
+// replaying its operations. The restored transcript keeps recording.
+func (t *Transcript) UnmarshalBinary(data []byte) error {
+	state := new(transcriptState)
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(state); err != nil {
+		return err
+	}
+	restored := NewRecorded(state.Domain)
+	for _, o := range state.Ops {
+		if o.Extract > 0 {
+			restored.ExtractBytes(o.Label, o.Extract)
+		} else {
+			restored.AppendMessage(o.Label, o.Message)
+		}
+	}
+	*t = *restored
+	return nil
+}
+
+// AppendPoint appends the compressed affine encoding of p under label
+func (t *Transcript) AppendPoint(label []byte, p curves.Point) {
+	t.AppendMessage(label, p.ToAffineCompressed())
+}
+
+// AppendPoints appends every point under label
+func (t *Transcript) AppendPoints(label []byte, points ...curves.Point) {
+	for _, p := range points {
+		t.AppendPoint(label, p)
+	}
+}
+
+// AppendScalar appends the canonical encoding of s under label
+func (t *Transcript) AppendScalar(label []byte, s curves.Scalar) {
+	t.AppendMessage(label, s.Bytes())
+}
+
+// ChallengeScalar derives a challenge in the scalar field of field, for example curve.Scalar
+func (t *Transcript) ChallengeScalar(label []byte, field curves.Scalar) (curves.Scalar, error) {
+	return field.SetBytesWide(t.ExtractBytes(label, ChallengeBytes))
+}
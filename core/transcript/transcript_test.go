@@ -0,0 +1,63 @@
+package transcript
+
+import (
+	"testing"
+
+	"github.com/gtank/merlin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+)
+
+func TestChallengeScalar(t *testing.T) {
+	for _, curve := range []*curves.Curve{curves.K256(), curves.P256(), curves.ED25519(), curves.PALLAS(), curves.BLS12381G1(), curves.BLS12377G1()} {
+		p := curve.Point.Generator()
+		s := curve.Scalar.New(7)
+		challenge := func(domain string, q curves.Point) curves.Scalar {
+			tr := New(domain)
+			tr.AppendPoint([]byte("point"), q)
+			tr.AppendScalar([]byte("scalar"), s)
+			c, err := tr.ChallengeScalar([]byte("challenge"), curve.Scalar)
+			require.NoError(t, err, curve.Name)
+			return c
+		}
+		c := challenge("test", p)
+		require.Equal(t, 0, c.Cmp(challenge("test", p)), curve.Name)
+		require.NotEqual(t, 0, c.Cmp(challenge("other", p)), curve.Name)
+		require.NotEqual(t, 0, c.Cmp(challenge("test", p.Double())), curve.Name)
+	}
+}
+
+func TestMerlinCompatible(t *testing.T) {
+	p := curves.K256().Point.Generator()
+	tr := New("test")
+	tr.AppendPoints([]byte("points"), p, p.Double())
+
+	m := merlin.NewTranscript("test")
+	m.AppendMessage([]byte("points"), p.ToAffineCompressed())
+	m.AppendMessage([]byte("points"), p.Double().ToAffineCompressed())
+	require.Equal(t, m.ExtractBytes([]byte("out"), 32), tr.ExtractBytes([]byte("out"), 32))
+}
+
+func TestMarshalBinary(t *testing.T) {
+	tr := NewRecorded("test")
+	tr.AppendMessage([]byte("empty"), nil)
+	tr.AppendScalar([]byte("scalar"), curves.K256().Scalar.New(3))
+	tr.ExtractBytes([]byte("first"), 16)
+	data, err := tr.MarshalBinary()
+	require.NoError(t, err)
+
+	restored := new(Transcript)
+	require.NoError(t, restored.UnmarshalBinary(data))
+	require.Equal(t, tr.ExtractBytes([]byte("out"), 32), restored.ExtractBytes([]byte("out"), 32))
+	require.Error(t, restored.UnmarshalBinary([]byte("garbage")))
+
+	// only recorded transcripts keep their messages
+	plain := New("test")
+	plain.AppendMessage([]byte("message"), []byte("not kept"))
+	require.Empty(t, plain.ops)
+	_, err = plain.MarshalBinary()
+	require.Error(t, err)
+	_, err = restored.MarshalBinary()
+	require.NoError(t, err)
+}
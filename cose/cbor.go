@@ -0,0 +1,334 @@
+package cose
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// The subset of CBOR of RFC 8949 that COSE structures use. Encoding is the
+// core deterministic encoding of section 4.2.1: shortest integer arguments,
+// definite lengths and map keys sorted by their encoding. Decoding rejects
+// indefinite lengths and non-shortest arguments.
+
+const (
+	majorUint   = 0
+	majorNegInt = 1
+	majorBytes  = 2
+	majorText   = 3
+	majorArray  = 4
+	majorMap    = 5
+	majorTag    = 6
+	majorSimple = 7
+
+	simpleFalse = 20
+	simpleTrue  = 21
+	simpleNull  = 22
+	simpleFloat = 27
+
+	// maxDepth bounds the nesting of decoded items
+	maxDepth = 16
+)
+
+// cborTag is a tagged data item
+type cborTag struct {
+	number  uint64
+	content any
+}
+
+func appendHead(b []byte, major byte, arg uint64) []byte {
+	m := major << 5
+	switch {
+	case arg < 24:
+		return append(b, m|byte(arg))
+	case arg <= math.MaxUint8:
+		return append(b, m|24, byte(arg))
+	case arg <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, m|25), uint16(arg))
+	case arg <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, m|26), uint32(arg))
+	default:
+		return binary.BigEndian.AppendUint64(append(b, m|27), arg)
+	}
+}
+
+// cborMarshal encodes nil, bool, int, int64, uint64, float64, []byte,
+// string, []any, map[any]any and cborTag values
+func cborMarshal(v any) ([]byte, error) {
+	return appendCBOR(nil, v, 0)
+}
+
+func appendCBOR(b []byte, v any, depth int) ([]byte, error) {
+	if depth > maxDepth {
+		return nil, fmt.Errorf("cbor: nesting too deep")
+	}
+	switch v := v.(type) {
+	case nil:
+		return append(b, majorSimple<<5|simpleNull), nil
+	case bool:
+		if v {
+			return append(b, majorSimple<<5|simpleTrue), nil
+		}
+		return append(b, majorSimple<<5|simpleFalse), nil
+	case int:
+		return appendCBOR(b, int64(v), depth)
+	case int64:
+		if v < 0 {
+			return appendHead(b, majorNegInt, uint64(-(v + 1))), nil
+		}
+		return appendHead(b, majorUint, uint64(v)), nil
+	case uint64:
+		return appendHead(b, majorUint, v), nil
+	case float64:
+		return binary.BigEndian.AppendUint64(append(b, majorSimple<<5|simpleFloat), math.Float64bits(v)), nil
+	case []byte:
+		return append(appendHead(b, majorBytes, uint64(len(v))), v...), nil
+	case string:
+		return append(appendHead(b, majorText, uint64(len(v))), v...), nil
+	case []any:
+		b = appendHead(b, majorArray, uint64(len(v)))
+		var err error
+		for _, item := range v {
+			if b, err = appendCBOR(b, item, depth+1); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case map[any]any:
+		type entry struct{ key, value []byte }
+		entries := make([]entry, 0, len(v))
+		for key, value := range v {
+			k, err := appendCBOR(nil, key, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			val, err := appendCBOR(nil, value, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, entry{k, val})
+		}
+		sort.Slice(entries, func(i, j int) bool {
+			return bytes.Compare(entries[i].key, entries[j].key) < 0
+		})
+		b = appendHead(b, majorMap, uint64(len(entries)))
+		for i, e := range entries {
+			if i > 0 && bytes.Equal(entries[i-1].key, e.key) {
+				return nil, fmt.Errorf("cbor: duplicate map key")
+			}
+			b = append(append(b, e.key...), e.value...)
+		}
+		return b, nil
+	case cborTag:
+		return appendCBOR(appendHead(b, majorTag, v.number), v.content, depth+1)
+	default:
+		return nil, fmt.Errorf("cbor: unsupported type %T", v)
+	}
+}
+
+// cborUnmarshal decodes a single data item that must span all of data.
+// Integers decode to int64, or uint64 when they do not fit, maps to
+// map[any]any and arrays to []any.
+func cborUnmarshal(data []byte) (any, error) {
+	d := &cborDecoder{data: data}
+	v, err := d.item(0)
+	if err != nil {
+		return nil, err
+	}
+	if d.off != len(d.data) {
+		return nil, fmt.Errorf("cbor: trailing data")
+	}
+	return v, nil
+}
+
+// Marshal encodes v with the deterministic encoding, for the structures
+// around COSE such as WebAuthn attestation objects
+func Marshal(v any) ([]byte, error) {
+	return cborMarshal(v)
+}
+
+// Unmarshal decodes a single data item that must span all of data
+func Unmarshal(data []byte) (any, error) {
+	return cborUnmarshal(data)
+}
+
+// UnmarshalPrefix decodes the data item at the start of data and returns
+// the bytes that follow it
+func UnmarshalPrefix(data []byte) (any, []byte, error) {
+	d := &cborDecoder{data: data}
+	v, err := d.item(0)
+	if err != nil {
+		return nil, nil, err
+	}
+	return v, data[d.off:], nil
+}
+
+type cborDecoder struct {
+	data []byte
+	off  int
+}
+
+func (d *cborDecoder) next(n uint64) ([]byte, error) {
+	if n > uint64(len(d.data)-d.off) {
+		return nil, fmt.Errorf("cbor: unexpected end of data")
+	}
+	b := d.data[d.off : d.off+int(n)]
+	d.off += int(n)
+	return b, nil
+}
+
+// head returns the major type and argument of the next item
+func (d *cborDecoder) head() (byte, uint64, byte, error) {
+	b, err := d.next(1)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	major, info := b[0]>>5, b[0]&0x1f
+	var arg uint64
+	switch {
+	case info < 24:
+		return major, uint64(info), info, nil
+	case info == 24:
+		b, err = d.next(1)
+		if err == nil {
+			arg = uint64(b[0])
+		}
+	case info == 25:
+		b, err = d.next(2)
+		if err == nil {
+			arg = uint64(binary.BigEndian.Uint16(b))
+		}
+	case info == 26:
+		b, err = d.next(4)
+		if err == nil {
+			arg = uint64(binary.BigEndian.Uint32(b))
+		}
+	case info == 27:
+		b, err = d.next(8)
+		if err == nil {
+			arg = binary.BigEndian.Uint64(b)
+		}
+	default:
+		return 0, 0, 0, fmt.Errorf("cbor: indefinite lengths are not supported")
+	}
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	// floats are not integer arguments, everything else must be shortest
+	if major != majorSimple && len(appendHead(nil, major, arg)) != 1+(1<<(info-24)) {
+		return 0, 0, 0, fmt.Errorf("cbor: non-shortest argument encoding")
+	}
+	return major, arg, info, nil
+}
+
+func (d *cborDecoder) item(depth int) (any, error) {
+	if depth > maxDepth {
+		return nil, fmt.Errorf("cbor: nesting too deep")
+	}
+	major, arg, info, err := d.head()
+	if err != nil {
+		return nil, err
+	}
+	switch major {
+	case majorUint:
+		if arg > math.MaxInt64 {
+			return arg, nil
+		}
+		return int64(arg), nil
+	case majorNegInt:
+		if arg > math.MaxInt64 {
+			return nil, fmt.Errorf("cbor: negative integer out of range")
+		}
+		return -int64(arg) - 1, nil
+	case majorBytes:
+		b, err := d.next(arg)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte{}, b...), nil
+	case majorText:
+		b, err := d.next(arg)
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	case majorArray:
+		if arg > uint64(len(d.data)-d.off) {
+			return nil, fmt.Errorf("cbor: unexpected end of data")
+		}
+		items := make([]any, arg)
+		for i := range items {
+			if items[i], err = d.item(depth + 1); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	case majorMap:
+		if arg > uint64(len(d.data)-d.off) {
+			return nil, fmt.Errorf("cbor: unexpected end of data")
+		}
+		m := make(map[any]any, arg)
+		for i := uint64(0); i < arg; i++ {
+			key, err := d.item(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			switch key.(type) {
+			case int64, uint64, string:
+			default:
+				return nil, fmt.Errorf("cbor: unsupported map key type %T", key)
+			}
+			if _, ok := m[key]; ok {
+				return nil, fmt.Errorf("cbor: duplicate map key %v", key)
+			}
+			if m[key], err = d.item(depth + 1); err != nil {
+				return nil, err
+			}
+		}
+		return m, nil
+	case majorTag:
+		content, err := d.item(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return cborTag{number: arg, content: content}, nil
+	default:
+		switch {
+		case info == simpleFalse:
+			return false, nil
+		case info == simpleTrue:
+			return true, nil
+		case info == simpleNull:
+			return nil, nil
+		case info == 25:
+			return float64(float16(uint16(arg))), nil
+		case info == 26:
+			return float64(math.Float32frombits(uint32(arg))), nil
+		case info == simpleFloat:
+			return math.Float64frombits(arg), nil
+		default:
+			return nil, fmt.Errorf("cbor: unsupported simple value %d", info)
+		}
+	}
+}
+
+// float16 converts an IEEE 754 half precision value
+func float16(h uint16) float32 {
+	sign := uint32(h>>15) << 31
+	exp := uint32(h>>10) & 0x1f
+	frac := uint32(h) & 0x3ff
+	switch exp {
+	case 0:
+		f := float32(frac) / (1 << 24)
+		if sign != 0 {
+			return -f
+		}
+		return f
+	case 0x1f:
+		return math.Float32frombits(sign | 0xff<<23 | frac<<13)
+	default:
+		return math.Float32frombits(sign | (exp+112)<<23 | frac<<13)
+	}
+}
@@ -0,0 +1,244 @@
+// Package cose produces and verifies the COSE_Sign1 and COSE_Sign
+// structures of RFC 9052 https://www.rfc-editor.org/rfc/rfc9052.html with
+// ES256, ES384, ES256K and EdDSA signatures from the key types of this
+// module: libp2p private keys sign and did:keys verify.
+//
+// The algorithm is always carried in the protected header, and
+// verification checks it against the verifier so a signature cannot be
+// replayed under another algorithm. Protected headers are signed as the
+// exact bytes received, external AAD binds application data that is not
+// part of the message, and a payload may be detached and supplied again
+// when verifying.
+package cose
+
+import (
+	"fmt"
+
+	"github.com/go-sonr/crypto/internal"
+)
+
+// Header labels of RFC 9052 section 3.1
+const (
+	HeaderAlgorithm   int64 = 1
+	HeaderCritical    int64 = 2
+	HeaderContentType int64 = 3
+	HeaderKeyID       int64 = 4
+)
+
+// CBOR tags of RFC 9052 section 2
+const (
+	TagSign1 = 18
+	TagSign  = 98
+)
+
+// Headers are the protected and unprotected header parameters of a COSE
+// structure. Labels are int64 or string values.
+type Headers struct {
+	Protected   map[any]any
+	Unprotected map[any]any
+
+	// rawProtected holds the protected header as signed or received
+	rawProtected []byte
+}
+
+// Algorithm returns the algorithm of the protected header
+func (h *Headers) Algorithm() (Algorithm, error) {
+	v, ok := lookup(h.Protected, HeaderAlgorithm)
+	if !ok {
+		return 0, fmt.Errorf("protected header has no algorithm")
+	}
+	alg, ok := v.(int64)
+	if !ok {
+		return 0, fmt.Errorf("unsupported algorithm %v", v)
+	}
+	return Algorithm(alg), nil
+}
+
+// KeyID returns the key identifier of either header
+func (h *Headers) KeyID() []byte {
+	for _, m := range []map[any]any{h.Protected, h.Unprotected} {
+		if v, ok := lookup(m, HeaderKeyID); ok {
+			if kid, ok := v.([]byte); ok {
+				return kid
+			}
+		}
+	}
+	return nil
+}
+
+// lookup returns the parameter of label, int labels are int64 once decoded
+// but may be int when set by the caller
+func lookup(m map[any]any, label int64) (any, bool) {
+	if v, ok := m[label]; ok {
+		return v, true
+	}
+	v, ok := m[int(label)]
+	return v, ok
+}
+
+// protect encodes the protected header for signing, setting the algorithm
+// of signer. A header that was already signed or received keeps its bytes.
+func (h *Headers) protect(alg Algorithm) ([]byte, error) {
+	if h.rawProtected != nil {
+		got, err := h.Algorithm()
+		if err != nil {
+			return nil, err
+		}
+		if got != alg {
+			return nil, fmt.Errorf("protected header algorithm %s does not match signer %s", got, alg)
+		}
+		return h.rawProtected, nil
+	}
+	if h.Protected == nil {
+		h.Protected = map[any]any{}
+	}
+	if v, ok := lookup(h.Protected, HeaderAlgorithm); ok && v != int64(alg) && v != int(alg) {
+		return nil, fmt.Errorf("protected header algorithm %v does not match signer %s", v, alg)
+	}
+	delete(h.Protected, int(HeaderAlgorithm))
+	h.Protected[HeaderAlgorithm] = int64(alg)
+	raw, err := h.encodeProtected()
+	if err != nil {
+		return nil, err
+	}
+	h.rawProtected = raw
+	return raw, nil
+}
+
+// encodeProtected returns the protected header bytes, the empty map is the
+// empty byte string
+func (h *Headers) encodeProtected() ([]byte, error) {
+	if h.rawProtected != nil {
+		return h.rawProtected, nil
+	}
+	if len(h.Protected) == 0 {
+		return []byte{}, nil
+	}
+	return cborMarshal(h.Protected)
+}
+
+// check validates the headers of a structure about to be verified by v
+func (h *Headers) check(v Verifier) error {
+	if v == nil {
+		return internal.ErrNilArguments
+	}
+	alg, err := h.Algorithm()
+	if err != nil {
+		return err
+	}
+	if alg != v.Algorithm() {
+		return fmt.Errorf("algorithm %s does not match verifier %s", alg, v.Algorithm())
+	}
+	return h.checkCritical()
+}
+
+// checkCritical rejects critical parameters this package does not process,
+// as required by RFC 9052 section 3.1
+func (h *Headers) checkCritical() error {
+	v, ok := lookup(h.Protected, HeaderCritical)
+	if !ok {
+		return nil
+	}
+	labels, ok := v.([]any)
+	if !ok || len(labels) == 0 {
+		return fmt.Errorf("malformed critical header parameter")
+	}
+	for _, l := range labels {
+		label, ok := l.(int64)
+		if !ok || label < HeaderAlgorithm || label > HeaderKeyID {
+			return fmt.Errorf("unsupported critical header parameter %v", l)
+		}
+		if _, ok := lookup(h.Protected, label); !ok {
+			return fmt.Errorf("critical header parameter %d is missing", label)
+		}
+	}
+	return nil
+}
+
+func (h *Headers) decode(protected, unprotected any) error {
+	raw, ok := protected.([]byte)
+	if !ok {
+		return fmt.Errorf("protected header must be a byte string")
+	}
+	h.Protected = map[any]any{}
+	if len(raw) > 0 {
+		v, err := cborUnmarshal(raw)
+		if err != nil {
+			return fmt.Errorf("protected header: %w", err)
+		}
+		if h.Protected, ok = v.(map[any]any); !ok {
+			return fmt.Errorf("protected header must be a map")
+		}
+	}
+	h.rawProtected = raw
+	if h.Unprotected, ok = unprotected.(map[any]any); !ok {
+		return fmt.Errorf("unprotected header must be a map")
+	}
+	for label := range h.Unprotected {
+		if _, ok := h.Protected[label]; ok {
+			return fmt.Errorf("header parameter %v is both protected and unprotected", label)
+		}
+	}
+	return nil
+}
+
+func (h *Headers) unprotected() map[any]any {
+	if h.Unprotected == nil {
+		return map[any]any{}
+	}
+	return h.Unprotected
+}
+
+// decodeMessage unwraps the optional tag and checks the array length
+func decodeMessage(data []byte, tag uint64, size int) ([]any, error) {
+	v, err := cborUnmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+	if t, ok := v.(cborTag); ok {
+		if t.number != tag {
+			return nil, fmt.Errorf("unexpected CBOR tag %d", t.number)
+		}
+		v = t.content
+	}
+	items, ok := v.([]any)
+	if !ok || len(items) != size {
+		return nil, fmt.Errorf("malformed COSE structure")
+	}
+	return items, nil
+}
+
+func payloadItem(payload []byte, detached bool) any {
+	if detached {
+		return nil
+	}
+	if payload == nil {
+		return []byte{}
+	}
+	return payload
+}
+
+func decodePayload(v any) ([]byte, bool, error) {
+	switch p := v.(type) {
+	case nil:
+		return nil, true, nil
+	case []byte:
+		return p, false, nil
+	default:
+		return nil, false, fmt.Errorf("payload must be a byte string or nil")
+	}
+}
+
+func sigStructure(context string, bodyProtected, signProtected, externalAAD, payload []byte) ([]byte, error) {
+	if externalAAD == nil {
+		externalAAD = []byte{}
+	}
+	if payload == nil {
+		payload = []byte{}
+	}
+	items := []any{context, bodyProtected}
+	if signProtected != nil {
+		items = append(items, signProtected)
+	}
+	return cborMarshal(append(items, externalAAD, payload))
+}
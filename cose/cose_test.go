@@ -0,0 +1,287 @@
+package cose
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	crand "crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/keys/parsers"
+)
+
+func hexBytes(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+func TestCBORRoundTrip(t *testing.T) {
+	for _, tc := range []struct {
+		value any
+		hex   string
+	}{
+		{int64(0), "00"},
+		{int64(23), "17"},
+		{int64(24), "1818"},
+		{int64(1000), "1903e8"},
+		{int64(-1), "20"},
+		{int64(-1000), "3903e7"},
+		{[]byte{1, 2, 3, 4}, "4401020304"},
+		{"IETF", "6449455446"},
+		{[]any{int64(1), []any{int64(2), int64(3)}}, "8201820203"},
+		{map[any]any{int64(1): int64(2), int64(3): int64(4)}, "a201020304"},
+		{map[any]any{"b": int64(1), int64(10): int64(2), int64(-1): int64(3)}, "a30a0220036162" + "01"},
+		{nil, "f6"},
+		{true, "f5"},
+		{cborTag{number: 18, content: []byte{}}, "d240"},
+	} {
+		enc, err := cborMarshal(tc.value)
+		require.NoError(t, err)
+		require.Equal(t, tc.hex, hex.EncodeToString(enc))
+		dec, err := cborUnmarshal(enc)
+		require.NoError(t, err)
+		require.Equal(t, tc.value, dec)
+	}
+
+	for _, bad := range []string{"1817", "5f4101ff", "a20101" + "0102", "8201", "0000", "fa3f800000" + "00"} {
+		_, err := cborUnmarshal(hexBytes(t, bad))
+		require.Error(t, err, bad)
+	}
+	f, err := cborUnmarshal(hexBytes(t, "f93c00"))
+	require.NoError(t, err)
+	require.Equal(t, 1.0, f)
+}
+
+// RFC 9052 Appendix C.2.1, a COSE_Sign1 ES256 example
+func TestSign1Vector(t *testing.T) {
+	pub := ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     new(big.Int).SetBytes(hexBytes(t, "bac5b11cad8f99f9c72b05cf4b9e26d244dc189f745228255a219a86d6a09eff")),
+		Y:     new(big.Int).SetBytes(hexBytes(t, "20138bf82dc1b6d562be0fa54ab7804a3a64b6d72ccfed6b6fb6ed28bbfc117e")),
+	}
+	key, err := crypto.ECDSAPublicKeyFromPubKey(pub)
+	require.NoError(t, err)
+	id, err := parsers.NewKeyDID(key)
+	require.NoError(t, err)
+	v, err := NewVerifier(id)
+	require.NoError(t, err)
+
+	data := hexBytes(t, "d28443a10126a10442313154546869732069732074686520636f6e74656e742e58408eb33e4ca31d1c465ab05aac34cc6b23d58fef5c083106c4d25a91aef0b0117e2af9a291aa32e14ab834dc56ed2a223444547e01f11d3b0916e5a4c345cacb36")
+	var msg Sign1Message
+	require.NoError(t, msg.UnmarshalCBOR(data))
+	require.Equal(t, []byte("This is the content."), msg.Payload)
+	require.Equal(t, []byte("11"), msg.KeyID())
+	require.NoError(t, msg.Verify(v, nil))
+	require.Error(t, msg.Verify(v, []byte("aad")))
+
+	enc, err := msg.MarshalCBOR()
+	require.NoError(t, err)
+	require.Equal(t, data, enc)
+}
+
+func keyPairs(t *testing.T) map[Algorithm]crypto.PrivKey {
+	keys := map[Algorithm]crypto.PrivKey{}
+	var err error
+	keys[AlgEdDSA], _, err = crypto.GenerateEd25519Key(crand.Reader)
+	require.NoError(t, err)
+	keys[AlgES256K], _, err = crypto.GenerateSecp256k1Key(crand.Reader)
+	require.NoError(t, err)
+	keys[AlgES256], _, err = crypto.GenerateECDSAKeyPairWithCurve(elliptic.P256(), crand.Reader)
+	require.NoError(t, err)
+	keys[AlgES384], _, err = crypto.GenerateECDSAKeyPairWithCurve(elliptic.P384(), crand.Reader)
+	require.NoError(t, err)
+	return keys
+}
+
+func verifierOf(t *testing.T, priv crypto.PrivKey) Verifier {
+	id, err := parsers.NewKeyDID(priv.GetPublic())
+	require.NoError(t, err)
+	id, err = parsers.Parse(id.String())
+	require.NoError(t, err)
+	v, err := NewVerifier(id)
+	require.NoError(t, err)
+	return v
+}
+
+func TestSign1(t *testing.T) {
+	keys := keyPairs(t)
+	for alg, priv := range keys {
+		t.Run(alg.String(), func(t *testing.T) {
+			s, err := NewSigner(priv)
+			require.NoError(t, err)
+			require.Equal(t, alg, s.Algorithm())
+			v := verifierOf(t, priv)
+			require.Equal(t, alg, v.Algorithm())
+
+			msg := NewSign1Message([]byte("payload"))
+			msg.Protected = map[any]any{HeaderContentType: "application/json"}
+			msg.Unprotected = map[any]any{HeaderKeyID: []byte("kid")}
+			require.NoError(t, msg.Sign(s, []byte("aad")))
+			require.Len(t, msg.Signature, alg.signatureSize())
+
+			data, err := msg.MarshalCBOR()
+			require.NoError(t, err)
+			var got Sign1Message
+			require.NoError(t, got.UnmarshalCBOR(data))
+			require.NoError(t, got.Verify(v, []byte("aad")))
+			require.Error(t, got.Verify(v, nil))
+			galg, err := got.Algorithm()
+			require.NoError(t, err)
+			require.Equal(t, alg, galg)
+
+			got.Payload = []byte("other")
+			require.Error(t, got.Verify(v, []byte("aad")))
+
+			// a verifier of another algorithm is rejected before verifying
+			for other, otherPriv := range keys {
+				if other != alg {
+					require.ErrorContains(t, msg.Verify(verifierOf(t, otherPriv), []byte("aad")), "does not match")
+				}
+			}
+
+			// detached payloads are supplied again by the verifier
+			msg.Detached = true
+			data, err = msg.MarshalCBOR()
+			require.NoError(t, err)
+			var detached Sign1Message
+			require.NoError(t, detached.UnmarshalCBOR(data))
+			require.True(t, detached.Detached)
+			require.Nil(t, detached.Payload)
+			detached.Payload = []byte("payload")
+			require.NoError(t, detached.Verify(v, []byte("aad")))
+		})
+	}
+}
+
+func TestSign(t *testing.T) {
+	keys := keyPairs(t)
+	msg := NewSignMessage([]byte("multi"))
+	msg.Protected = map[any]any{HeaderContentType: int64(0)}
+	for _, alg := range []Algorithm{AlgEdDSA, AlgES256, AlgES256K} {
+		s, err := NewSigner(keys[alg])
+		require.NoError(t, err)
+		require.NoError(t, msg.AddSignature(s, &Headers{Unprotected: map[any]any{HeaderKeyID: []byte(alg.String())}}, nil))
+	}
+	data, err := msg.MarshalCBOR()
+	require.NoError(t, err)
+	require.Equal(t, byte(0xd8), data[0])
+
+	var got SignMessage
+	require.NoError(t, got.UnmarshalCBOR(data))
+	require.Len(t, got.Signatures, 3)
+	for i, alg := range []Algorithm{AlgEdDSA, AlgES256, AlgES256K} {
+		v := verifierOf(t, keys[alg])
+		require.NoError(t, got.VerifySignature(i, v, nil))
+		require.NoError(t, got.Verify(v, nil))
+		require.Error(t, got.Verify(v, []byte("aad")))
+		require.Equal(t, []byte(alg.String()), got.Signatures[i].KeyID())
+	}
+	require.Error(t, got.Verify(verifierOf(t, keys[AlgES384]), nil))
+
+	// the body protected header is covered by every signature
+	got.Signatures[0].Signature[0] ^= 1
+	require.Error(t, got.VerifySignature(0, verifierOf(t, keys[AlgEdDSA]), nil))
+	tampered := got
+	tampered.rawProtected = []byte{0xa1, 0x03, 0x01}
+	require.Error(t, tampered.VerifySignature(1, verifierOf(t, keys[AlgES256]), nil))
+}
+
+func TestCriticalHeaders(t *testing.T) {
+	priv, _, err := crypto.GenerateEd25519Key(crand.Reader)
+	require.NoError(t, err)
+	s, err := NewSigner(priv)
+	require.NoError(t, err)
+	v := verifierOf(t, priv)
+
+	for _, tc := range []struct {
+		protected map[any]any
+		valid     bool
+	}{
+		{map[any]any{HeaderCritical: []any{HeaderContentType}, HeaderContentType: int64(0)}, true},
+		{map[any]any{HeaderCritical: []any{HeaderContentType}}, false},
+		{map[any]any{HeaderCritical: []any{int64(99)}, int64(99): true}, false},
+		{map[any]any{HeaderCritical: []any{}}, false},
+	} {
+		msg := NewSign1Message([]byte("crit"))
+		msg.Protected = tc.protected
+		require.NoError(t, msg.Sign(s, nil))
+		data, err := msg.MarshalCBOR()
+		require.NoError(t, err)
+		var got Sign1Message
+		require.NoError(t, got.UnmarshalCBOR(data))
+		if tc.valid {
+			require.NoError(t, got.Verify(v, nil))
+		} else {
+			require.Error(t, got.Verify(v, nil))
+		}
+	}
+
+	msg := NewSign1Message([]byte("alg"))
+	msg.Protected = map[any]any{HeaderAlgorithm: int64(AlgES256)}
+	require.Error(t, msg.Sign(s, nil))
+
+	var bad Sign1Message
+	require.Error(t, bad.UnmarshalCBOR(hexBytes(t, "d38440a1044131405840"+hex.EncodeToString(make([]byte, 64)))))
+	require.Error(t, bad.UnmarshalCBOR(hexBytes(t, "d8628440a040"+"40")))
+}
+
+func TestKeyRoundTrip(t *testing.T) {
+	p256, err := ecdsa.GenerateKey(elliptic.P256(), crand.Reader)
+	require.NoError(t, err)
+	ecPub, err := crypto.ECDSAPublicKeyFromPubKey(p256.PublicKey)
+	require.NoError(t, err)
+	_, edPub, err := crypto.GenerateEd25519Key(crand.Reader)
+	require.NoError(t, err)
+	_, k1Pub, err := crypto.GenerateSecp256k1Key(crand.Reader)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		pub crypto.PubKey
+		alg Algorithm
+	}{{ecPub, AlgES256}, {edPub, AlgEdDSA}, {k1Pub, AlgES256K}} {
+		id, err := parsers.NewKeyDID(tc.pub)
+		require.NoError(t, err)
+		k, err := NewKey(id)
+		require.NoError(t, err)
+		require.Equal(t, tc.alg, k.Algorithm)
+		enc, err := k.MarshalCBOR()
+		require.NoError(t, err)
+
+		dec, err := ParseKey(enc)
+		require.NoError(t, err)
+		require.Equal(t, tc.alg, dec.Algorithm)
+		require.Equal(t, id.String(), dec.DIDKey.String())
+
+		dec, rest, err := ParseKeyPrefix(append(enc, 0xa0))
+		require.NoError(t, err)
+		require.Equal(t, []byte{0xa0}, rest)
+		require.Equal(t, id.String(), dec.DIDKey.String())
+	}
+
+	// RFC 9052 Appendix C.7.1, the public key of "meriadoc.brandybuck@buckland.example" without its alg
+	key := map[any]any{
+		KeyLabelKty: KtyEC2,
+		KeyLabelCrv: CrvP256,
+		KeyLabelX:   hexBytes(t, "65eda5a12577c2bae829437fe338701a10aaa375e1bb5b5de108de439c08551d"),
+		KeyLabelY:   hexBytes(t, "1e52ed75701163f7f9e40ddf9f341b3dc9ba860af7e0ca7ca7e9eecd0084d19c"),
+	}
+	enc, err := cborMarshal(key)
+	require.NoError(t, err)
+	_, err = ParseKey(enc)
+	require.Error(t, err)
+	key[KeyLabelAlg] = int64(AlgES256)
+	enc, err = cborMarshal(key)
+	require.NoError(t, err)
+	_, err = ParseKey(enc)
+	require.NoError(t, err)
+	key[KeyLabelAlg] = int64(AlgES384)
+	enc, err = cborMarshal(key)
+	require.NoError(t, err)
+	_, err = ParseKey(enc)
+	require.Error(t, err)
+}
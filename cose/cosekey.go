@@ -0,0 +1,232 @@
+package cose
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/libp2p/go-libp2p/core/crypto"
+
+	"github.com/go-sonr/crypto/keys/parsers"
+)
+
+// COSE_Key labels and values of RFC 9052 section 7 and RFC 9053
+const (
+	KeyLabelKty int64 = 1
+	KeyLabelKid int64 = 2
+	KeyLabelAlg int64 = 3
+
+	// curve and coordinate labels of OKP and EC2 keys
+	KeyLabelCrv int64 = -1
+	KeyLabelX   int64 = -2
+	KeyLabelY   int64 = -3
+	KeyLabelD   int64 = -4
+
+	// modulus and exponent labels of RSA keys, RFC 8230
+	KeyLabelN int64 = -1
+	KeyLabelE int64 = -2
+
+	KtyOKP int64 = 1
+	KtyEC2 int64 = 2
+	KtyRSA int64 = 3
+
+	CrvP256      int64 = 1
+	CrvP384      int64 = 2
+	CrvEd25519   int64 = 6
+	CrvSecp256k1 int64 = 8
+)
+
+// Key is a public COSE_Key mapped to the did:key of its public key. The
+// algorithm is required and must match the key type and curve.
+type Key struct {
+	Algorithm Algorithm
+	KeyID     []byte
+	DIDKey    parsers.DIDKey
+}
+
+// NewKey returns the COSE_Key of a did:key with the algorithm of its key
+// type, RS256 for RSA keys
+func NewKey(id parsers.DIDKey) (*Key, error) {
+	if id.PubKey == nil {
+		return nil, fmt.Errorf("did:key has no public key")
+	}
+	if id.Type() == crypto.RSA {
+		return &Key{Algorithm: AlgRS256, DIDKey: id}, nil
+	}
+	v, err := NewVerifier(id)
+	if err != nil {
+		return nil, err
+	}
+	return &Key{Algorithm: v.Algorithm(), DIDKey: id}, nil
+}
+
+// ParseKey decodes a COSE_Key that spans all of data
+func ParseKey(data []byte) (*Key, error) {
+	v, err := cborUnmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return keyFromMap(v)
+}
+
+// ParseKeyPrefix decodes the COSE_Key at the start of data and returns the
+// bytes that follow it
+func ParseKeyPrefix(data []byte) (*Key, []byte, error) {
+	v, rest, err := UnmarshalPrefix(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	k, err := keyFromMap(v)
+	if err != nil {
+		return nil, nil, err
+	}
+	return k, rest, nil
+}
+
+func keyFromMap(v any) (*Key, error) {
+	m, ok := v.(map[any]any)
+	if !ok {
+		return nil, fmt.Errorf("COSE_Key is not a map")
+	}
+	if _, ok := m[KeyLabelD]; ok {
+		return nil, fmt.Errorf("COSE_Key holds a private key")
+	}
+	kty, ok := m[KeyLabelKty].(int64)
+	if !ok {
+		return nil, fmt.Errorf("COSE_Key has no key type")
+	}
+	alg, ok := m[KeyLabelAlg].(int64)
+	if !ok {
+		return nil, fmt.Errorf("COSE_Key has no algorithm")
+	}
+	k := &Key{Algorithm: Algorithm(alg)}
+	if kid, ok := m[KeyLabelKid]; ok {
+		if k.KeyID, ok = kid.([]byte); !ok {
+			return nil, fmt.Errorf("COSE_Key kid is not a byte string")
+		}
+	}
+
+	var (
+		pub crypto.PubKey
+		err error
+	)
+	switch kty {
+	case KtyOKP:
+		crv, _ := m[KeyLabelCrv].(int64)
+		x, _ := m[KeyLabelX].([]byte)
+		if crv != CrvEd25519 || k.Algorithm != AlgEdDSA {
+			return nil, fmt.Errorf("unsupported OKP key: crv %d, alg %s", crv, k.Algorithm)
+		}
+		pub, err = crypto.UnmarshalEd25519PublicKey(x)
+	case KtyEC2:
+		pub, err = ec2PubKey(m, k.Algorithm)
+	case KtyRSA:
+		if k.Algorithm != AlgRS256 && k.Algorithm != AlgPS256 {
+			return nil, fmt.Errorf("unsupported RSA algorithm %s", k.Algorithm)
+		}
+		n, _ := m[KeyLabelN].([]byte)
+		e, _ := m[KeyLabelE].([]byte)
+		exp := new(big.Int).SetBytes(e)
+		if len(n) == 0 || !exp.IsInt64() || exp.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("invalid RSA key")
+		}
+		der, err := x509.MarshalPKIXPublicKey(&rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exp.Int64())})
+		if err != nil {
+			return nil, err
+		}
+		pub, err = crypto.UnmarshalRsaPublicKey(der)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported COSE_Key type %d", kty)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if k.DIDKey, err = parsers.NewKeyDID(pub); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+func ec2PubKey(m map[any]any, alg Algorithm) (crypto.PubKey, error) {
+	crv, _ := m[KeyLabelCrv].(int64)
+	x, _ := m[KeyLabelX].([]byte)
+	y, ok := m[KeyLabelY].([]byte)
+	if !ok {
+		return nil, fmt.Errorf("EC2 key y coordinate must be a byte string")
+	}
+	var curve elliptic.Curve
+	switch {
+	case crv == CrvP256 && alg == AlgES256:
+		curve = elliptic.P256()
+	case crv == CrvP384 && alg == AlgES384:
+		curve = elliptic.P384()
+	case crv == CrvSecp256k1 && alg == AlgES256K:
+		if len(x) != 32 || len(y) != 32 {
+			return nil, fmt.Errorf("invalid secp256k1 coordinate length")
+		}
+		return crypto.UnmarshalSecp256k1PublicKey(append(append([]byte{0x04}, x...), y...))
+	default:
+		return nil, fmt.Errorf("unsupported EC2 key: crv %d, alg %s", crv, alg)
+	}
+	size := (curve.Params().BitSize + 7) / 8
+	if len(x) != size || len(y) != size {
+		return nil, fmt.Errorf("invalid %s coordinate length", curve.Params().Name)
+	}
+	pub := ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+	if !curve.IsOnCurve(pub.X, pub.Y) {
+		return nil, fmt.Errorf("point is not on curve %s", curve.Params().Name)
+	}
+	return crypto.ECDSAPublicKeyFromPubKey(pub)
+}
+
+// MarshalCBOR encodes the key as a COSE_Key with uncompressed coordinates
+func (k *Key) MarshalCBOR() ([]byte, error) {
+	if k.DIDKey.PubKey == nil {
+		return nil, fmt.Errorf("COSE_Key has no public key")
+	}
+	m := map[any]any{KeyLabelAlg: int64(k.Algorithm)}
+	if k.KeyID != nil {
+		m[KeyLabelKid] = k.KeyID
+	}
+	vk, err := k.DIDKey.VerifyKey()
+	if err != nil {
+		return nil, err
+	}
+	switch key := vk.(type) {
+	case ed25519.PublicKey:
+		m[KeyLabelKty], m[KeyLabelCrv], m[KeyLabelX] = KtyOKP, CrvEd25519, []byte(key)
+	case []byte:
+		pub, err := btcec.ParsePubKey(key)
+		if err != nil {
+			return nil, err
+		}
+		raw := pub.SerializeUncompressed()
+		m[KeyLabelKty], m[KeyLabelCrv], m[KeyLabelX], m[KeyLabelY] = KtyEC2, CrvSecp256k1, raw[1:33], raw[33:]
+	case *ecdsa.PublicKey:
+		var crv int64
+		switch key.Curve {
+		case elliptic.P256():
+			crv = CrvP256
+		case elliptic.P384():
+			crv = CrvP384
+		default:
+			return nil, fmt.Errorf("unsupported ECDSA curve: %s", key.Curve.Params().Name)
+		}
+		size := (key.Curve.Params().BitSize + 7) / 8
+		m[KeyLabelKty], m[KeyLabelCrv] = KtyEC2, crv
+		m[KeyLabelX], m[KeyLabelY] = key.X.FillBytes(make([]byte, size)), key.Y.FillBytes(make([]byte, size))
+	case *rsa.PublicKey:
+		m[KeyLabelKty], m[KeyLabelN], m[KeyLabelE] = KtyRSA, key.N.Bytes(), big.NewInt(int64(key.E)).Bytes()
+	default:
+		return nil, fmt.Errorf("unsupported key type for COSE_Key: %T", vk)
+	}
+	return cborMarshal(m)
+}
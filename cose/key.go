@@ -0,0 +1,263 @@
+package cose
+
+import (
+	gocrypto "crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+
+	"github.com/go-sonr/crypto/keys/parsers"
+)
+
+// Algorithm is a COSE algorithm identifier from the IANA registry
+type Algorithm int64
+
+const (
+	// AlgES256 is ECDSA on P-256 with SHA-256
+	AlgES256 Algorithm = -7
+	// AlgEdDSA is pure Ed25519
+	AlgEdDSA Algorithm = -8
+	// AlgES384 is ECDSA on P-384 with SHA-384
+	AlgES384 Algorithm = -35
+	// AlgPS256 is RSASSA-PSS with SHA-256
+	AlgPS256 Algorithm = -37
+	// AlgES256K is ECDSA on secp256k1 with SHA-256, RFC 8812
+	AlgES256K Algorithm = -47
+	// AlgRS256 is RSASSA-PKCS1-v1_5 with SHA-256, RFC 8812
+	AlgRS256 Algorithm = -257
+)
+
+// String returns the registered name of the algorithm
+func (a Algorithm) String() string {
+	switch a {
+	case AlgES256:
+		return "ES256"
+	case AlgEdDSA:
+		return "EdDSA"
+	case AlgES384:
+		return "ES384"
+	case AlgPS256:
+		return "PS256"
+	case AlgES256K:
+		return "ES256K"
+	case AlgRS256:
+		return "RS256"
+	default:
+		return fmt.Sprintf("Algorithm(%d)", int64(a))
+	}
+}
+
+// signatureSize is the size of a signature, ECDSA signatures are the fixed
+// size R || S of RFC 9053 section 2.1 rather than DER
+func (a Algorithm) signatureSize() int {
+	switch a {
+	case AlgES384:
+		return 96
+	case AlgES256, AlgEdDSA, AlgES256K:
+		return 64
+	default:
+		return 0
+	}
+}
+
+// Signer produces COSE signatures over a Sig_structure
+type Signer interface {
+	Algorithm() Algorithm
+	Sign(toBeSigned []byte) ([]byte, error)
+}
+
+// Verifier checks COSE signatures over a Sig_structure
+type Verifier interface {
+	Algorithm() Algorithm
+	Verify(toBeSigned, signature []byte) error
+}
+
+type signer struct {
+	alg  Algorithm
+	sign func(toBeSigned []byte) ([]byte, error)
+}
+
+func (s *signer) Algorithm() Algorithm {
+	return s.alg
+}
+
+func (s *signer) Sign(toBeSigned []byte) ([]byte, error) {
+	return s.sign(toBeSigned)
+}
+
+// NewSigner returns the signer of an Ed25519, P-256, P-384 or secp256k1
+// private key
+func NewSigner(priv crypto.PrivKey) (Signer, error) {
+	if priv == nil {
+		return nil, fmt.Errorf("private key is required")
+	}
+	if k, ok := priv.(cryptoSignerKey); ok {
+		return NewCryptoSigner(k.CryptoSigner())
+	}
+	switch priv.Type() {
+	case crypto.Ed25519:
+		return &signer{alg: AlgEdDSA, sign: priv.Sign}, nil
+	case crypto.Secp256k1:
+		return &signer{alg: AlgES256K, sign: func(toBeSigned []byte) ([]byte, error) {
+			der, err := priv.Sign(toBeSigned)
+			if err != nil {
+				return nil, err
+			}
+			return derToFixed(der, 32)
+		}}, nil
+	case crypto.ECDSA:
+		raw, err := priv.Raw()
+		if err != nil {
+			return nil, err
+		}
+		key, err := x509.ParseECPrivateKey(raw)
+		if err != nil {
+			return nil, err
+		}
+		switch key.Curve {
+		case elliptic.P256():
+			return &signer{alg: AlgES256, sign: func(toBeSigned []byte) ([]byte, error) {
+				digest := sha256.Sum256(toBeSigned)
+				return signECDSA(key, digest[:], 32)
+			}}, nil
+		case elliptic.P384():
+			return &signer{alg: AlgES384, sign: func(toBeSigned []byte) ([]byte, error) {
+				digest := sha512.Sum384(toBeSigned)
+				return signECDSA(key, digest[:], 48)
+			}}, nil
+		default:
+			return nil, fmt.Errorf("unsupported ECDSA curve: %s", key.Curve.Params().Name)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported key type for COSE: %s", priv.Type())
+	}
+}
+
+// cryptoSignerKey is a private key that only signs through a gocrypto.Signer,
+// such as the keys of the hardware package
+type cryptoSignerKey interface {
+	CryptoSigner() gocrypto.Signer
+}
+
+// NewCryptoSigner returns the signer of a gocrypto.Signer with an Ed25519,
+// P-256 or P-384 public key, for keys that cannot be exported
+func NewCryptoSigner(s gocrypto.Signer) (Signer, error) {
+	if s == nil {
+		return nil, fmt.Errorf("signer is required")
+	}
+	switch pub := s.Public().(type) {
+	case ed25519.PublicKey:
+		return &signer{alg: AlgEdDSA, sign: func(signingInput []byte) ([]byte, error) {
+			return s.Sign(rand.Reader, signingInput, gocrypto.Hash(0))
+		}}, nil
+	case *ecdsa.PublicKey:
+		var (
+			alg  Algorithm
+			hash gocrypto.Hash
+		)
+		switch pub.Curve {
+		case elliptic.P256():
+			alg, hash = AlgES256, gocrypto.SHA256
+		case elliptic.P384():
+			alg, hash = AlgES384, gocrypto.SHA384
+		default:
+			return nil, fmt.Errorf("unsupported ECDSA curve: %s", pub.Curve.Params().Name)
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		return &signer{alg: alg, sign: func(signingInput []byte) ([]byte, error) {
+			h := hash.New()
+			h.Write(signingInput)
+			der, err := s.Sign(rand.Reader, h.Sum(nil), hash)
+			if err != nil {
+				return nil, err
+			}
+			return derToFixed(der, size)
+		}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type for COSE: %T", pub)
+	}
+}
+
+func signECDSA(key *ecdsa.PrivateKey, digest []byte, size int) ([]byte, error) {
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, 2*size)
+	r.FillBytes(out[:size])
+	s.FillBytes(out[size:])
+	return out, nil
+}
+
+// derToFixed converts an ASN.1 DER ECDSA signature into R || S
+func derToFixed(der []byte, size int) ([]byte, error) {
+	var sig struct{ R, S *big.Int }
+	rest, err := asn1.Unmarshal(der, &sig)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 || sig.R.Sign() <= 0 || sig.S.Sign() <= 0 || sig.R.BitLen() > 8*size || sig.S.BitLen() > 8*size {
+		return nil, fmt.Errorf("invalid ECDSA signature")
+	}
+	out := make([]byte, 2*size)
+	sig.R.FillBytes(out[:size])
+	sig.S.FillBytes(out[size:])
+	return out, nil
+}
+
+type verifier struct {
+	alg Algorithm
+	id  parsers.DIDKey
+}
+
+// NewVerifier returns the verifier of the Ed25519, P-256, P-384 or
+// secp256k1 key of a did:key
+func NewVerifier(id parsers.DIDKey) (Verifier, error) {
+	if id.PubKey == nil {
+		return nil, fmt.Errorf("did:key has no public key")
+	}
+	var alg Algorithm
+	switch id.Type() {
+	case crypto.Ed25519:
+		alg = AlgEdDSA
+	case crypto.Secp256k1:
+		alg = AlgES256K
+	case crypto.ECDSA:
+		vk, err := id.VerifyKey()
+		if err != nil {
+			return nil, err
+		}
+		switch curve := vk.(*ecdsa.PublicKey).Curve; curve {
+		case elliptic.P256():
+			alg = AlgES256
+		case elliptic.P384():
+			alg = AlgES384
+		default:
+			return nil, fmt.Errorf("unsupported ECDSA curve: %s", curve.Params().Name)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported key type for COSE: %s", id.Type())
+	}
+	return &verifier{alg: alg, id: id}, nil
+}
+
+func (v *verifier) Algorithm() Algorithm {
+	return v.alg
+}
+
+func (v *verifier) Verify(toBeSigned, signature []byte) error {
+	// the did:key verifier also accepts DER, COSE only R || S
+	if len(signature) != v.alg.signatureSize() {
+		return parsers.ErrInvalidSignature
+	}
+	return v.id.Verify(toBeSigned, signature)
+}
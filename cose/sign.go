@@ -0,0 +1,244 @@
+package cose
+
+import (
+	"fmt"
+
+	"github.com/go-sonr/crypto/internal"
+)
+
+// Sign1Message is a COSE_Sign1 structure, a payload with a single signature
+type Sign1Message struct {
+	Headers
+	Payload []byte
+	// Detached leaves the payload out of the encoding, the verifier sets
+	// Payload before calling Verify
+	Detached  bool
+	Signature []byte
+}
+
+// NewSign1Message returns an unsigned COSE_Sign1 message over payload
+func NewSign1Message(payload []byte) *Sign1Message {
+	return &Sign1Message{Payload: payload}
+}
+
+// Sign signs the message, binding externalAAD, and sets the algorithm of
+// signer in the protected header
+func (m *Sign1Message) Sign(signer Signer, externalAAD []byte) error {
+	if signer == nil {
+		return internal.ErrNilArguments
+	}
+	protected, err := m.protect(signer.Algorithm())
+	if err != nil {
+		return err
+	}
+	tbs, err := sigStructure("Signature1", protected, nil, externalAAD, m.Payload)
+	if err != nil {
+		return err
+	}
+	m.Signature, err = signer.Sign(tbs)
+	return err
+}
+
+// Verify checks the signature of the message and externalAAD
+func (m *Sign1Message) Verify(verifier Verifier, externalAAD []byte) error {
+	if err := m.check(verifier); err != nil {
+		return err
+	}
+	protected, err := m.encodeProtected()
+	if err != nil {
+		return err
+	}
+	tbs, err := sigStructure("Signature1", protected, nil, externalAAD, m.Payload)
+	if err != nil {
+		return err
+	}
+	return verifier.Verify(tbs, m.Signature)
+}
+
+// MarshalCBOR returns the tagged COSE_Sign1 encoding of a signed message
+func (m *Sign1Message) MarshalCBOR() ([]byte, error) {
+	if m.Signature == nil {
+		return nil, fmt.Errorf("message is not signed")
+	}
+	protected, err := m.encodeProtected()
+	if err != nil {
+		return nil, err
+	}
+	return cborMarshal(cborTag{number: TagSign1, content: []any{
+		protected, m.unprotected(), payloadItem(m.Payload, m.Detached), m.Signature,
+	}})
+}
+
+// UnmarshalCBOR decodes a tagged or untagged COSE_Sign1 structure
+func (m *Sign1Message) UnmarshalCBOR(data []byte) error {
+	items, err := decodeMessage(data, TagSign1, 4)
+	if err != nil {
+		return err
+	}
+	var msg Sign1Message
+	if err = msg.decode(items[0], items[1]); err != nil {
+		return err
+	}
+	if msg.Payload, msg.Detached, err = decodePayload(items[2]); err != nil {
+		return err
+	}
+	var ok bool
+	if msg.Signature, ok = items[3].([]byte); !ok {
+		return fmt.Errorf("signature must be a byte string")
+	}
+	*m = msg
+	return nil
+}
+
+// Signature is a COSE_Signature, one signer of a COSE_Sign message
+type Signature struct {
+	Headers
+	Signature []byte
+}
+
+// SignMessage is a COSE_Sign structure, a payload with any number of
+// signatures
+type SignMessage struct {
+	Headers
+	Payload []byte
+	// Detached leaves the payload out of the encoding, the verifier sets
+	// Payload before calling Verify
+	Detached   bool
+	Signatures []*Signature
+}
+
+// NewSignMessage returns an unsigned COSE_Sign message over payload
+func NewSignMessage(payload []byte) *SignMessage {
+	return &SignMessage{Payload: payload}
+}
+
+// AddSignature appends a signature by signer with the given headers, which
+// may be nil, binding externalAAD. The body protected header is fixed by
+// the first signature.
+func (m *SignMessage) AddSignature(signer Signer, headers *Headers, externalAAD []byte) error {
+	if signer == nil {
+		return internal.ErrNilArguments
+	}
+	if m.rawProtected == nil {
+		raw, err := m.encodeProtected()
+		if err != nil {
+			return err
+		}
+		m.rawProtected = raw
+	}
+	sig := &Signature{}
+	if headers != nil {
+		sig.Protected, sig.Unprotected = headers.Protected, headers.Unprotected
+	}
+	signProtected, err := sig.protect(signer.Algorithm())
+	if err != nil {
+		return err
+	}
+	tbs, err := sigStructure("Signature", m.rawProtected, signProtected, externalAAD, m.Payload)
+	if err != nil {
+		return err
+	}
+	if sig.Signature, err = signer.Sign(tbs); err != nil {
+		return err
+	}
+	m.Signatures = append(m.Signatures, sig)
+	return nil
+}
+
+// VerifySignature checks the i-th signature of the message and externalAAD
+func (m *SignMessage) VerifySignature(i int, verifier Verifier, externalAAD []byte) error {
+	if i < 0 || i >= len(m.Signatures) {
+		return fmt.Errorf("message has no signature %d", i)
+	}
+	if err := m.checkCritical(); err != nil {
+		return err
+	}
+	sig := m.Signatures[i]
+	if err := sig.check(verifier); err != nil {
+		return err
+	}
+	bodyProtected, err := m.encodeProtected()
+	if err != nil {
+		return err
+	}
+	signProtected, err := sig.encodeProtected()
+	if err != nil {
+		return err
+	}
+	tbs, err := sigStructure("Signature", bodyProtected, signProtected, externalAAD, m.Payload)
+	if err != nil {
+		return err
+	}
+	return verifier.Verify(tbs, sig.Signature)
+}
+
+// Verify checks that some signature of the message verifies with verifier
+func (m *SignMessage) Verify(verifier Verifier, externalAAD []byte) error {
+	if len(m.Signatures) == 0 {
+		return fmt.Errorf("message is not signed")
+	}
+	var err error
+	for i := range m.Signatures {
+		if err = m.VerifySignature(i, verifier, externalAAD); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// MarshalCBOR returns the tagged COSE_Sign encoding of a signed message
+func (m *SignMessage) MarshalCBOR() ([]byte, error) {
+	if len(m.Signatures) == 0 {
+		return nil, fmt.Errorf("message is not signed")
+	}
+	protected, err := m.encodeProtected()
+	if err != nil {
+		return nil, err
+	}
+	sigs := make([]any, len(m.Signatures))
+	for i, sig := range m.Signatures {
+		signProtected, err := sig.encodeProtected()
+		if err != nil {
+			return nil, err
+		}
+		sigs[i] = []any{signProtected, sig.unprotected(), sig.Signature}
+	}
+	return cborMarshal(cborTag{number: TagSign, content: []any{
+		protected, m.unprotected(), payloadItem(m.Payload, m.Detached), sigs,
+	}})
+}
+
+// UnmarshalCBOR decodes a tagged or untagged COSE_Sign structure
+func (m *SignMessage) UnmarshalCBOR(data []byte) error {
+	items, err := decodeMessage(data, TagSign, 4)
+	if err != nil {
+		return err
+	}
+	var msg SignMessage
+	if err = msg.decode(items[0], items[1]); err != nil {
+		return err
+	}
+	if msg.Payload, msg.Detached, err = decodePayload(items[2]); err != nil {
+		return err
+	}
+	sigs, ok := items[3].([]any)
+	if !ok || len(sigs) == 0 {
+		return fmt.Errorf("signatures must be a non-empty array")
+	}
+	for _, s := range sigs {
+		fields, ok := s.([]any)
+		if !ok || len(fields) != 3 {
+			return fmt.Errorf("malformed COSE_Signature")
+		}
+		sig := &Signature{}
+		if err = sig.decode(fields[0], fields[1]); err != nil {
+			return err
+		}
+		if sig.Signature, ok = fields[2].([]byte); !ok {
+			return fmt.Errorf("signature must be a byte string")
+		}
+		msg.Signatures = append(msg.Signatures, sig)
+	}
+	*m = msg
+	return nil
+}
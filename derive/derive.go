@@ -0,0 +1,174 @@
+// Package derive derives a hierarchy of labeled subkeys from one root
+// secret with HKDF-SHA256 of RFC 5869.
+//
+// Every derivation takes a domain, a non-empty label naming what the key is
+// for, and a usage fixed by the method, so the same root never yields the
+// same bytes for two purposes. Children are roots of their own, giving a
+// tree of per application keys from a single secret. Curve scalars are
+// derived with the hash_to_field of RFC 9380.
+package derive
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/go-sonr/crypto/aead"
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/secret"
+	"github.com/go-sonr/crypto/keyexchange"
+)
+
+const (
+	// MinSecretSize is the minimum size of a root secret
+	MinSecretSize = 16
+	// KeySize is the size of child roots
+	KeySize = 32
+	// MaxDomainSize bounds domain labels
+	MaxDomainSize = 200
+
+	version = "sonr-derive-v1"
+)
+
+// usages of derived keys, bound into the HKDF info next to the domain
+const (
+	usageKey        = "key"
+	usageChild      = "child"
+	usageSigning    = "signing"
+	usageEncryption = "encryption"
+	usageScalar     = "scalar"
+	usageApp        = "app"
+)
+
+// Key is a root secret from which subkeys are derived
+type Key struct {
+	secret []byte
+}
+
+// New returns a root for secret, which is copied. The secret should be
+// uniformly random, derive passwords with the kdf package first.
+func New(secret []byte) (*Key, error) {
+	if len(secret) < MinSecretSize {
+		return nil, fmt.Errorf("derive: secret must be at least %d bytes", MinSecretSize)
+	}
+	return &Key{secret: append([]byte(nil), secret...)}, nil
+}
+
+// Wipe zeroes the root secret, the key cannot be used afterwards
+func (k *Key) Wipe() {
+	secret.Wipe(k.secret)
+	k.secret = nil
+}
+
+// checkDomain requires a label without NUL bytes, which keeps the encoding
+// of usage and domain unambiguous
+func checkDomain(domain string) error {
+	if domain == "" {
+		return fmt.Errorf("derive: domain is required")
+	}
+	if len(domain) > MaxDomainSize {
+		return fmt.Errorf("derive: domain longer than %d bytes", MaxDomainSize)
+	}
+	if bytes.IndexByte([]byte(domain), 0) >= 0 {
+		return fmt.Errorf("derive: domain contains a NUL byte")
+	}
+	return nil
+}
+
+// info returns version || 0x00 || usage || 0x00 || domain
+func info(usage, domain string) []byte {
+	return fmt.Appendf(nil, "%s\x00%s\x00%s", version, usage, domain)
+}
+
+func (k *Key) expand(usage, domain string, length int) ([]byte, error) {
+	if k == nil || len(k.secret) == 0 {
+		return nil, fmt.Errorf("derive: key is wiped")
+	}
+	if err := checkDomain(domain); err != nil {
+		return nil, err
+	}
+	return keyexchange.HKDF(sha256.New, k.secret, nil, info(usage, domain), length)
+}
+
+// Bytes derives length bytes of key material for domain
+func (k *Key) Bytes(domain string, length int) ([]byte, error) {
+	return k.expand(usageKey, domain, length)
+}
+
+// Child derives the root of the subtree domain
+func (k *Key) Child(domain string) (*Key, error) {
+	s, err := k.expand(usageChild, domain, KeySize)
+	if err != nil {
+		return nil, err
+	}
+	return &Key{secret: s}, nil
+}
+
+// Path derives the root at the end of a path of child domains
+func (k *Key) Path(domains ...string) (*Key, error) {
+	if len(domains) == 0 {
+		return nil, fmt.Errorf("derive: path is empty")
+	}
+	cur := k
+	for i, domain := range domains {
+		next, err := cur.Child(domain)
+		if i > 0 {
+			cur.Wipe()
+		}
+		if err != nil {
+			return nil, err
+		}
+		cur = next
+	}
+	return cur, nil
+}
+
+// Application derives the root of an application, identified by a reverse
+// DNS name or origin, so applications never share keys
+func (k *Key) Application(id string) (*Key, error) {
+	s, err := k.expand(usageApp, id, KeySize)
+	if err != nil {
+		return nil, err
+	}
+	return &Key{secret: s}, nil
+}
+
+// SigningKey derives a signing key of curve for domain
+func (k *Key) SigningKey(curve *curves.Curve, domain string) (curves.Scalar, error) {
+	return k.scalar(usageSigning, curve, domain)
+}
+
+// Scalar derives a non-zero scalar of curve for domain, for uses other
+// than signing such as blinding factors and MPC shares
+func (k *Key) Scalar(curve *curves.Curve, domain string) (curves.Scalar, error) {
+	return k.scalar(usageScalar, curve, domain)
+}
+
+func (k *Key) scalar(usage string, curve *curves.Curve, domain string) (curves.Scalar, error) {
+	if curve == nil {
+		return nil, fmt.Errorf("derive: curve is required")
+	}
+	ikm, err := k.expand(usage, domain, KeySize)
+	if err != nil {
+		return nil, err
+	}
+	defer secret.Wipe(ikm)
+	s, err := HashToScalar(curve, ikm, []byte(version+"-"+usage+"-"+curve.Name))
+	if err != nil {
+		return nil, err
+	}
+	if s.IsZero() {
+		return nil, fmt.Errorf("derive: derived scalar is zero")
+	}
+	return s, nil
+}
+
+// EncryptionKey derives a key of alg for domain
+func (k *Key) EncryptionKey(alg aead.Algorithm, domain string) (*aead.Key, error) {
+	ikm, err := k.expand(usageEncryption, domain, KeySize)
+	if err != nil {
+		return nil, err
+	}
+	defer secret.Wipe(ikm)
+	return aead.NewKey(alg, ikm, nil, []byte(version+"-"+alg.String()))
+}
@@ -0,0 +1,106 @@
+package derive
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/aead"
+	"github.com/go-sonr/crypto/core/curves"
+)
+
+func newTestKey(t *testing.T) *Key {
+	seed := make([]byte, 32)
+	_, err := crand.Read(seed)
+	require.NoError(t, err)
+	k, err := New(seed)
+	require.NoError(t, err)
+	return k
+}
+
+func TestDomainSeparation(t *testing.T) {
+	k := newTestKey(t)
+
+	a, err := k.Bytes("wallet", 32)
+	require.NoError(t, err)
+	b, err := k.Bytes("wallet", 32)
+	require.NoError(t, err)
+	require.Equal(t, a, b)
+	c, err := k.Bytes("vault", 32)
+	require.NoError(t, err)
+	require.NotEqual(t, a, c)
+
+	// the same domain under another usage is another key
+	child, err := k.Child("wallet")
+	require.NoError(t, err)
+	require.NotEqual(t, a, child.secret)
+	app, err := k.Application("wallet")
+	require.NoError(t, err)
+	require.NotEqual(t, child.secret, app.secret)
+
+	signing, err := k.SigningKey(curves.K256(), "wallet")
+	require.NoError(t, err)
+	scalar, err := k.Scalar(curves.K256(), "wallet")
+	require.NoError(t, err)
+	require.NotEqual(t, signing.Bytes(), scalar.Bytes())
+
+	for _, domain := range []string{"", "a\x00b", string(make([]byte, MaxDomainSize+1))} {
+		_, err = k.Bytes(domain, 32)
+		require.Error(t, err)
+	}
+	_, err = New(make([]byte, MinSecretSize-1))
+	require.Error(t, err)
+}
+
+func TestHierarchy(t *testing.T) {
+	k := newTestKey(t)
+
+	path, err := k.Path("org.sonr", "accounts", "0")
+	require.NoError(t, err)
+	a, err := k.Child("org.sonr")
+	require.NoError(t, err)
+	b, err := a.Child("accounts")
+	require.NoError(t, err)
+	c, err := b.Child("0")
+	require.NoError(t, err)
+	require.Equal(t, path.secret, c.secret)
+
+	enc, err := path.EncryptionKey(aead.XChaCha20Poly1305, "notes")
+	require.NoError(t, err)
+	ct, err := enc.Seal([]byte("plaintext"), nil)
+	require.NoError(t, err)
+	same, err := c.EncryptionKey(aead.XChaCha20Poly1305, "notes")
+	require.NoError(t, err)
+	pt, err := same.Open(ct, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte("plaintext"), pt)
+
+	c.Wipe()
+	_, err = c.Bytes("notes", 32)
+	require.Error(t, err)
+}
+
+func TestHashToField(t *testing.T) {
+	msg := []byte("abc")
+	for _, tc := range []struct {
+		curve *curves.Curve
+		dst   string
+	}{
+		{curves.K256(), "secp256k1_XMD:SHA-256_SSWU_RO_"},
+		{curves.P256(), "P256_XMD:SHA-256_SSWU_RO_"},
+	} {
+		s, err := HashToScalar(tc.curve, msg, []byte(tc.dst))
+		require.NoError(t, err)
+		require.Equal(t, tc.curve.Scalar.Hash(msg).Bytes(), s.Bytes(), tc.curve.Name)
+	}
+
+	s, err := HashToField(curves.ED25519(), msg, []byte("dst"), 3)
+	require.NoError(t, err)
+	require.Len(t, s, 3)
+	require.NotEqual(t, s[0].Bytes(), s[1].Bytes())
+	_, err = HashToField(curves.ED25519(), msg, nil, 1)
+	require.Error(t, err)
+	_, err = HashToField(curves.ED25519(), msg, []byte("dst"), 0)
+	require.Error(t, err)
+}
@@ -0,0 +1,59 @@
+package derive
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/curves/native"
+)
+
+// securityBits is the k of RFC 9380, the bias of a reduced element is
+// below 2^-k
+const securityBits = 128
+
+// order returns the order of the scalar field of curve
+func order(curve *curves.Curve) *big.Int {
+	q := curve.Scalar.One().Neg().BigInt()
+	return q.Add(q, big.NewInt(1))
+}
+
+// HashToField hashes msg to count scalars of curve with hash_to_field of
+// RFC 9380 section 5.2, expand_message_xmd with SHA-256 and dst. For
+// secp256k1 and P-256 a single element equals Scalar.Hash with the dst of
+// the curve suite.
+func HashToField(curve *curves.Curve, msg, dst []byte, count int) ([]curves.Scalar, error) {
+	if curve == nil {
+		return nil, fmt.Errorf("derive: curve is required")
+	}
+	if len(dst) == 0 {
+		return nil, fmt.Errorf("derive: domain separation tag is required")
+	}
+	q := order(curve)
+	// L = ceil((ceil(log2(q)) + k) / 8)
+	l := (q.BitLen() + securityBits + 7) / 8
+	if count <= 0 || count*l > 255*32 {
+		return nil, fmt.Errorf("derive: invalid element count %d", count)
+	}
+	uniform := native.ExpandMsgXmd(native.EllipticPointHasherSha256(), msg, dst, count*l)
+	out := make([]curves.Scalar, count)
+	for i := range out {
+		e := new(big.Int).SetBytes(uniform[i*l : (i+1)*l])
+		s, err := curve.Scalar.SetBigInt(e.Mod(e, q))
+		if err != nil {
+			return nil, err
+		}
+		out[i] = s
+	}
+	return out, nil
+}
+
+// HashToScalar hashes msg to a scalar of curve, HashToField with a count
+// of one
+func HashToScalar(curve *curves.Curve, msg, dst []byte) (curves.Scalar, error) {
+	s, err := HashToField(curve, msg, dst, 1)
+	if err != nil {
+		return nil, err
+	}
+	return s[0], nil
+}
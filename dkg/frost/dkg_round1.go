@@ -107,7 +107,7 @@ func (dp *DkgParticipant) Round1(secret []byte) (*Round1Bcast, Round1P2PSend, er
 	ki := dp.Curve.Scalar.Random(crand.Reader)
 
 	// Step 3 - Compute Ri = ki*G
-	Ri := dp.Curve.ScalarBaseMult(ki)
+	Ri := dp.Curve.BaseMultTable().Mul(ki)
 
 	// Step 4 - Compute Ci = H(i, CTX, g^{a_(i,0)}, R_i), where CTX is fixed context string
 	var msg []byte
@@ -166,3 +166,107 @@ func TestFullDkgRoundsWorks(t *testing.T) {
 	vk := testCurve.ScalarBaseMult(sk)
 	require.True(t, vk.Equal(p1.VerificationKey))
 }
+
+func TestDkgRoundMessagesMarshal(t *testing.T) {
+	p1, _, bcast1, _, p2psend1, _ := PrepareRound2Input(t)
+	data, err := bcast1.MarshalBinary()
+	require.NoError(t, err)
+	decoded := &Round1Bcast{}
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	require.Equal(t, len(bcast1.Verifiers.Commitments), len(decoded.Verifiers.Commitments))
+	for i, c := range bcast1.Verifiers.Commitments {
+		require.True(t, c.Equal(decoded.Verifiers.Commitments[i]))
+	}
+	require.Equal(t, 0, bcast1.Wi.Cmp(decoded.Wi))
+	require.Equal(t, 0, bcast1.Ci.Cmp(decoded.Ci))
+
+	data, err = p2psend1[2].MarshalBinary()
+	require.NoError(t, err)
+	share := &sharing.ShamirShare{}
+	require.NoError(t, share.UnmarshalBinary(data))
+	require.Equal(t, p2psend1[2], share)
+
+	// messages of one type do not decode as another
+	require.Error(t, (&Round2Bcast{}).UnmarshalBinary(data))
+
+	bcast2 := &Round2Bcast{VerificationKey: p1.Curve.Point.Generator(), VkShare: p1.Curve.Point.Generator().Double()}
+	data, err = bcast2.MarshalBinary()
+	require.NoError(t, err)
+	decoded2 := &Round2Bcast{}
+	require.NoError(t, decoded2.UnmarshalBinary(data))
+	require.True(t, bcast2.VerificationKey.Equal(decoded2.VerificationKey))
+	require.True(t, bcast2.VkShare.Equal(decoded2.VkShare))
+}
+
+// restore round trips the state of a participant
+func restore(t *testing.T, dp *DkgParticipant) *DkgParticipant {
+	data, err := dp.MarshalBinary()
+	require.NoError(t, err)
+	restored := &DkgParticipant{}
+	require.NoError(t, restored.UnmarshalBinary(data))
+	return restored
+}
+
+func TestDkgStateResume(t *testing.T) {
+	p1, err := NewDkgParticipant(1, 2, Ctx, testCurve, 2, 3)
+	require.NoError(t, err)
+	p2, err := NewDkgParticipant(2, 2, Ctx, testCurve, 1, 3)
+	require.NoError(t, err)
+	p3, err := NewDkgParticipant(3, 2, Ctx, testCurve, 1, 2)
+	require.NoError(t, err)
+	participants := []*DkgParticipant{restore(t, p1), p2, p3}
+
+	bcast := make(map[uint32]*Round1Bcast)
+	p2p := make(map[uint32]map[uint32]*sharing.ShamirShare)
+	for _, p := range participants {
+		p2p[p.Id] = make(map[uint32]*sharing.ShamirShare)
+	}
+	for _, p := range participants {
+		b, send, err := p.Round1(nil)
+		require.NoError(t, err)
+		bcast[p.Id] = b
+		for id, share := range send {
+			p2p[id][p.Id] = share
+		}
+	}
+
+	// a participant restored after round 1 has its sharing and refuses to run round 1 again
+	participants[0] = restore(t, participants[0])
+	_, _, err = participants[0].Round1(nil)
+	require.Error(t, err)
+
+	for _, p := range participants {
+		_, err = p.Round2(bcast, p2p[p.Id])
+		require.NoError(t, err)
+	}
+	restored := restore(t, participants[0])
+	require.Equal(t, 0, participants[0].SkShare.Cmp(restored.SkShare))
+	require.True(t, participants[0].VkShare.Equal(restored.VkShare))
+	require.True(t, participants[0].VerificationKey.Equal(restored.VerificationKey))
+	require.True(t, restored.VerificationKey.Equal(p2.VerificationKey))
+
+	s, _ := sharing.NewShamir(2, 3, testCurve)
+	sk, err := s.Combine(&sharing.ShamirShare{Id: restored.Id, Value: restored.SkShare.Bytes()},
+		&sharing.ShamirShare{Id: p3.Id, Value: p3.SkShare.Bytes()})
+	require.NoError(t, err)
+	require.True(t, testCurve.ScalarBaseMult(sk).Equal(restored.VerificationKey))
+}
+
+func TestDkgStateRejectsMalformed(t *testing.T) {
+	p1, _, bcast1, _, _, _ := PrepareRound2Input(t)
+	data, err := p1.MarshalBinary()
+	require.NoError(t, err)
+
+	require.Error(t, (&DkgParticipant{}).UnmarshalBinary(data[:len(data)-1]))
+	require.Error(t, (&DkgParticipant{}).UnmarshalBinary(append(data, 0)))
+
+	// a broadcast is not a state
+	data, err = bcast1.MarshalBinary()
+	require.NoError(t, err)
+	require.Error(t, (&DkgParticipant{}).UnmarshalBinary(data))
+
+	// a participant past the last round has no state
+	p1.round = 4
+	_, err = p1.MarshalBinary()
+	require.Error(t, err)
+}
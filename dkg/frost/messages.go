@@ -0,0 +1,78 @@
+package frost
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/protocol/messages"
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/sharing"
+)
+
+const (
+	round1BcastType = "frost-dkg/round1-bcast"
+	round2BcastType = "frost-dkg/round2-bcast"
+)
+
+// MarshalBinary encodes the broadcast in the canonical messages format.
+func (bcast *Round1Bcast) MarshalBinary() ([]byte, error) {
+	if bcast == nil || bcast.Verifiers == nil || bcast.Wi == nil || bcast.Ci == nil {
+		return nil, internal.ErrNilArguments
+	}
+	curve := curves.GetCurveByName(bcast.Ci.Point().CurveName())
+	if curve == nil {
+		return nil, errors.Errorf("unsupported curve %s", bcast.Ci.Point().CurveName())
+	}
+	enc := messages.NewEncoder(round1BcastType, curve)
+	enc.WritePoints(bcast.Verifiers.Commitments)
+	enc.WriteScalar(bcast.Wi)
+	enc.WriteScalar(bcast.Ci)
+	return enc.Finish()
+}
+
+// UnmarshalBinary decodes a broadcast encoded by MarshalBinary.
+func (bcast *Round1Bcast) UnmarshalBinary(data []byte) error {
+	dec, err := messages.NewDecoder(data, round1BcastType)
+	if err != nil {
+		return errors.Wrap(err, "couldn't decode round 1 broadcast")
+	}
+	commitments := dec.ReadPoints()
+	wi := dec.ReadScalar()
+	ci := dec.ReadScalar()
+	if err = dec.Finish(); err != nil {
+		return errors.Wrap(err, "couldn't decode round 1 broadcast")
+	}
+	bcast.Verifiers = &sharing.FeldmanVerifier{Commitments: commitments}
+	bcast.Wi, bcast.Ci = wi, ci
+	return nil
+}
+
+// MarshalBinary encodes the broadcast in the canonical messages format.
+func (bcast *Round2Bcast) MarshalBinary() ([]byte, error) {
+	if bcast == nil || bcast.VerificationKey == nil || bcast.VkShare == nil {
+		return nil, internal.ErrNilArguments
+	}
+	curve := curves.GetCurveByName(bcast.VerificationKey.CurveName())
+	if curve == nil {
+		return nil, errors.Errorf("unsupported curve %s", bcast.VerificationKey.CurveName())
+	}
+	enc := messages.NewEncoder(round2BcastType, curve)
+	enc.WritePoint(bcast.VerificationKey)
+	enc.WritePoint(bcast.VkShare)
+	return enc.Finish()
+}
+
+// UnmarshalBinary decodes a broadcast encoded by MarshalBinary.
+func (bcast *Round2Bcast) UnmarshalBinary(data []byte) error {
+	dec, err := messages.NewDecoder(data, round2BcastType)
+	if err != nil {
+		return errors.Wrap(err, "couldn't decode round 2 broadcast")
+	}
+	verificationKey := dec.ReadPoint()
+	vkShare := dec.ReadPoint()
+	if err = dec.Finish(); err != nil {
+		return errors.Wrap(err, "couldn't decode round 2 broadcast")
+	}
+	bcast.VerificationKey, bcast.VkShare = verificationKey, vkShare
+	return nil
+}
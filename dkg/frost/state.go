@@ -0,0 +1,143 @@
+package frost
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/protocol/messages"
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/sharing"
+)
+
+// The state of a participant is serialized in the canonical messages format, whose header carries the version of the
+// encoding, so that a participant that crashed between rounds resumes where it stopped. State must be persisted after
+// each round and before its output is sent: a participant restored from an older state runs a round again with fresh
+// randomness, and the other participants must not see both runs.
+//
+// Serialized state holds the secret shares of the participant and must be protected like them.
+const stateType = "frost-dkg/participant-state"
+
+// MarshalBinary encodes the state of the participant between rounds.
+func (dp *DkgParticipant) MarshalBinary() ([]byte, error) {
+	if dp == nil || dp.Curve == nil || dp.feldman == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if dp.round < 1 || dp.round > 3 {
+		return nil, internal.ErrInvalidRound
+	}
+	enc := messages.NewEncoder(stateType, dp.Curve)
+	enc.WriteUint32(uint32(dp.round))
+	enc.WriteUint32(dp.Id)
+	enc.WriteUint32(uint32(dp.ctx))
+	enc.WriteUint32(dp.feldman.Threshold)
+	enc.WriteUint32(dp.feldman.Limit)
+	others := make([]uint32, 0, len(dp.otherParticipantShares))
+	for id := range dp.otherParticipantShares {
+		others = append(others, id)
+	}
+	sort.Slice(others, func(i, j int) bool { return others[i] < others[j] })
+	enc.WriteUint32s(others)
+	if dp.round >= 2 {
+		if dp.verifiers == nil {
+			return nil, errors.New("missing round 1 verifiers")
+		}
+		enc.WritePoints(dp.verifiers.Commitments)
+		enc.WriteUint32(uint32(len(dp.secretShares)))
+		for _, share := range dp.secretShares {
+			enc.WriteUint32(share.Id)
+			enc.WriteBytes(share.Value)
+		}
+	}
+	if dp.round == 3 {
+		enc.WriteScalar(dp.SkShare)
+		enc.WritePoint(dp.VerificationKey)
+		enc.WritePoint(dp.VkShare)
+	}
+	data, err := enc.Finish()
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't encode participant state")
+	}
+	return data, nil
+}
+
+// UnmarshalBinary restores a participant encoded by MarshalBinary.
+func (dp *DkgParticipant) UnmarshalBinary(data []byte) error {
+	dec, err := messages.NewDecoder(data, stateType)
+	if err != nil {
+		return errors.Wrap(err, "couldn't decode participant state")
+	}
+	curve := dec.Curve()
+	if curve == nil {
+		return errors.New("participant state has no curve")
+	}
+	round := dec.ReadUint32()
+	id := dec.ReadUint32()
+	ctx := dec.ReadUint32()
+	threshold := dec.ReadUint32()
+	limit := dec.ReadUint32()
+	others := dec.ReadUint32s()
+	var commitments []curves.Point
+	var shares []*sharing.ShamirShare
+	var n uint32
+	if round >= 2 {
+		commitments = dec.ReadPoints()
+		n = dec.ReadUint32()
+		for i := uint32(0); i < n && i < limit; i++ {
+			shares = append(shares, &sharing.ShamirShare{Id: dec.ReadUint32(), Value: dec.ReadBytes()})
+		}
+	}
+	var skShare curves.Scalar
+	var verificationKey, vkShare curves.Point
+	if round == 3 {
+		skShare = dec.ReadScalar()
+		verificationKey = dec.ReadPoint()
+		vkShare = dec.ReadPoint()
+	}
+	if err = dec.Finish(); err != nil {
+		return errors.Wrap(err, "couldn't decode participant state")
+	}
+	if round < 1 || round > 3 {
+		return internal.ErrInvalidRound
+	}
+	if ctx > 0xff {
+		return errors.Errorf("invalid context %d", ctx)
+	}
+	if id == 0 || id > limit {
+		return errors.Errorf("invalid participant id %d", id)
+	}
+	if uint32(len(others))+1 != limit {
+		return errors.Errorf("%d other participants for a limit of %d", len(others), limit)
+	}
+	otherParticipantShares := make(map[uint32]*dkgParticipantData, len(others))
+	for _, other := range others {
+		if _, ok := otherParticipantShares[other]; ok || other == id {
+			return errors.Errorf("duplicate participant id %d", other)
+		}
+		otherParticipantShares[other] = &dkgParticipantData{Id: other}
+	}
+	feldman, err := sharing.NewFeldman(threshold, limit, curve)
+	if err != nil {
+		return err
+	}
+	var verifiers *sharing.FeldmanVerifier
+	if round >= 2 {
+		if uint32(len(commitments)) != threshold || n != limit || uint32(len(shares)) != limit {
+			return errors.New("round 1 sharing doesn't match the threshold and limit")
+		}
+		verifiers = &sharing.FeldmanVerifier{Commitments: commitments}
+	}
+	dp.round = int(round)
+	dp.Curve = curve
+	dp.Id = id
+	dp.ctx = byte(ctx)
+	dp.feldman = feldman
+	dp.otherParticipantShares = otherParticipantShares
+	dp.verifiers = verifiers
+	dp.secretShares = shares
+	dp.SkShare = skShare
+	dp.VerificationKey = verificationKey
+	dp.VkShare = vkShare
+	return nil
+}
@@ -0,0 +1,192 @@
+package pedersen
+
+import (
+	"fmt"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/sharing"
+)
+
+// Result is the output of the DKG for one participant
+type Result struct {
+	// SecretShare is the Shamir share x_i of the secret key
+	SecretShare *sharing.ShamirShare
+	// PublicKey is y = x·G
+	PublicKey curves.Point
+	// PublicShares are the commitments x_j·G to the secret shares of all participants
+	PublicShares map[uint32]curves.Point
+	// Qualified are the dealers whose secrets make up the key
+	Qualified []uint32
+	// Misbehavior lists every deviation from the protocol this participant could prove
+	Misbehavior []*Misbehavior
+
+	threshold, limit uint32
+	curve            *curves.Curve
+}
+
+// Finalize reconstructs the secrets of the convicted dealers from the shares revealed in round 6 and outputs the key.
+// bcast contains all Round6 broadcast from other participants to this participant
+func (dp *Participant) Finalize(bcast map[uint32]*Round6Bcast) (*Result, error) {
+	if dp == nil || dp.curve == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if dp.round != 7 {
+		return nil, internal.ErrInvalidRound
+	}
+	if bcast == nil {
+		return nil, internal.ErrNilArguments
+	}
+
+	// 1. x_i = Σ_{j ∈ QUAL} s_ji, y = Σ_{j ∈ QUAL} A_j0, y_k = Σ_{j ∈ QUAL} Σ_l A_jl·k^l
+	xs := make(map[uint32]curves.Scalar, len(dp.ids))
+	for _, id := range dp.ids {
+		xs[id] = dp.curve.Scalar.New(int(id))
+	}
+	sk := dp.curve.Scalar.Zero()
+	pk := dp.curve.NewIdentityPoint()
+	publicShares := make(map[uint32]curves.Point, len(dp.ids))
+	for _, id := range dp.ids {
+		publicShares[id] = dp.curve.NewIdentityPoint()
+	}
+	for _, dealer := range dp.qualified {
+		d := dp.dealers[dealer]
+		share, err := dp.curve.Scalar.SetBytes(d.share.Value)
+		if err != nil {
+			return nil, err
+		}
+		sk = sk.Add(share)
+		if !d.convicted {
+			pk = pk.Add(d.verifiers[0])
+			for _, id := range dp.ids {
+				publicShares[id] = publicShares[id].Add(evaluate(d.verifiers, xs[id]))
+			}
+			continue
+		}
+
+		// 2. f_j(k) = Σ λ_m(k)·s_jm over the revealed shares that match the Pedersen commitments of P_j
+		var ids []curves.Scalar
+		var values []curves.Scalar
+		for _, id := range dp.ids {
+			var packet *Round1P2PSendPacket
+			if id == dp.id {
+				packet = &Round1P2PSendPacket{SecretShare: d.share, BlindingShare: d.blindingShare}
+			} else if bcast[id] != nil {
+				packet = bcast[id].Shares[dealer]
+			}
+			if !dp.verifyPedersen(dealer, packet, id) {
+				if id != dealer {
+					dp.accuse(id, 6, fmt.Sprintf("missing or invalid share of participant %d", dealer))
+				}
+				continue
+			}
+			value, err := dp.curve.Scalar.SetBytes(packet.SecretShare.Value)
+			if err != nil {
+				return nil, err
+			}
+			ids = append(ids, xs[id])
+			values = append(values, value)
+		}
+		if uint32(len(values)) < dp.threshold {
+			return nil, fmt.Errorf("not enough shares to reconstruct the secret of participant %d", dealer)
+		}
+		ids, values = ids[:dp.threshold], values[:dp.threshold]
+		secret, err := interpolate(dp.curve, ids, values, dp.curve.Scalar.Zero())
+		if err != nil {
+			return nil, err
+		}
+		pk = pk.Add(dp.curve.ScalarBaseMult(secret))
+		for _, id := range dp.ids {
+			value, err := interpolate(dp.curve, ids, values, xs[id])
+			if err != nil {
+				return nil, err
+			}
+			publicShares[id] = publicShares[id].Add(dp.curve.ScalarBaseMult(value))
+		}
+	}
+
+	// This is a sanity check to make sure nothing went wrong
+	// when computing the public key and the shares
+	if pk.IsIdentity() || !pk.IsOnCurve() {
+		return nil, fmt.Errorf("invalid public key")
+	}
+	if !dp.curve.ScalarBaseMult(sk).Equal(publicShares[dp.id]) {
+		return nil, fmt.Errorf("secret share does not match the public share")
+	}
+
+	dp.round = 8
+
+	return &Result{
+		SecretShare:  &sharing.ShamirShare{Id: dp.id, Value: sk.Bytes()},
+		PublicKey:    pk,
+		PublicShares: publicShares,
+		Qualified:    dp.qualified,
+		Misbehavior:  dp.misbehavior,
+		threshold:    dp.threshold,
+		limit:        uint32(len(dp.ids)),
+		curve:        dp.curve,
+	}, nil
+}
+
+// Threshold returns the number of shares needed to use the key
+func (r *Result) Threshold() uint32 {
+	return r.threshold
+}
+
+// AdditiveShare converts the Shamir share into an additive share of the secret key among signers, which must
+// contain this participant and at least threshold participants. The additive shares of all signers sum to the key.
+func (r *Result) AdditiveShare(signers []uint32) (curves.Scalar, error) {
+	if r == nil || r.SecretShare == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if uint32(len(signers)) < r.threshold {
+		return nil, fmt.Errorf("at least %d signers are needed", r.threshold)
+	}
+	shamir, err := sharing.NewShamir(r.threshold, r.limit, r.curve)
+	if err != nil {
+		return nil, err
+	}
+	coefficients, err := shamir.LagrangeCoeffs(signers)
+	if err != nil {
+		return nil, err
+	}
+	lambda, ok := coefficients[r.SecretShare.Id]
+	if !ok {
+		return nil, fmt.Errorf("participant %d is not a signer", r.SecretShare.Id)
+	}
+	share, err := r.curve.Scalar.SetBytes(r.SecretShare.Value)
+	if err != nil {
+		return nil, err
+	}
+	return share.Mul(lambda), nil
+}
+
+// evaluate returns Σ A_l·x^l.
+func evaluate(verifiers []curves.Point, x curves.Scalar) curves.Point {
+	out := verifiers[len(verifiers)-1]
+	for l := len(verifiers) - 2; l >= 0; l-- {
+		out = out.Mul(x).Add(verifiers[l])
+	}
+	return out
+}
+
+// interpolate returns f(x) for the polynomial f through the points (xs_m, ys_m).
+func interpolate(curve *curves.Curve, xs, ys []curves.Scalar, x curves.Scalar) (curves.Scalar, error) {
+	result := curve.Scalar.Zero()
+	for i, xi := range xs {
+		num := curve.Scalar.One()
+		den := curve.Scalar.One()
+		for j, xj := range xs {
+			if i == j {
+				continue
+			}
+			num = num.Mul(xj.Sub(x))
+			den = den.Mul(xj.Sub(xi))
+		}
+		if den.IsZero() {
+			return nil, fmt.Errorf("divide by zero")
+		}
+		result = result.Add(ys[i].Mul(num.Div(den)))
+	}
+	return result, nil
+}
@@ -0,0 +1,145 @@
+// Package pedersen is an implementation of the Pedersen VSS based DKG of
+// https://link.springer.com/content/pdf/10.1007/s00145-006-0347-3.pdf
+// with complaint and justification rounds and identifiable misbehavior.
+package pedersen
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/go-sonr/crypto/commitments"
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/sharing"
+)
+
+// Participant is a DKG player that contains information needed to perform DKG rounds
+// and yield a secret key share and public key when finished
+type Participant struct {
+	round       int
+	curve       *curves.Curve
+	id          uint32
+	threshold   uint32
+	ids         []uint32
+	generator   curves.Point
+	pedersen    *sharing.Pedersen
+	dealing     *sharing.PedersenResult
+	dealers     map[uint32]*dealerData
+	complaints  []uint32
+	evidence    map[uint32]*Round1P2PSendPacket
+	qualified   []uint32
+	misbehavior []*Misbehavior
+}
+
+// Misbehavior identifies a participant that deviated from the protocol in a way the other participants can verify.
+type Misbehavior struct {
+	Id     uint32
+	Round  int
+	Reason string
+}
+
+func (m Misbehavior) Error() string {
+	return fmt.Sprintf("participant %d misbehaved in round %d: %s", m.Id, m.Round, m.Reason)
+}
+
+type dealerData struct {
+	commitments   []curves.Point
+	verifiers     []curves.Point
+	share         *sharing.ShamirShare
+	blindingShare *sharing.ShamirShare
+	accusers      []uint32
+	disqualified  bool
+	convicted     bool
+}
+
+// NewParticipant creates a participant ready to perform a DKG
+// `id` is the integer value identifier for this participant
+// `threshold` is the minimum number of shares needed to use the key
+// `ctx` derives the blinding generator of the Pedersen commitments, all participants must use the same value
+// `otherParticipants` is the integer value identifiers for the other participants
+// `id` and `otherParticipants` must be the set of integers 1,2,....,n
+func NewParticipant(id, threshold uint32, curve *curves.Curve, ctx []byte, otherParticipants ...uint32) (*Participant, error) {
+	if curve == nil || len(otherParticipants) == 0 {
+		return nil, internal.ErrNilArguments
+	}
+	ids := append([]uint32{id}, otherParticipants...)
+	if err := validIds(ids); err != nil {
+		return nil, err
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	params, err := commitments.NewParams(curve, append([]byte("dkg"), ctx...))
+	if err != nil {
+		return nil, err
+	}
+	pedersen, err := sharing.NewPedersen(threshold, uint32(len(ids)), params.H)
+	if err != nil {
+		return nil, err
+	}
+	dealers := make(map[uint32]*dealerData, len(ids))
+	for _, i := range ids {
+		dealers[i] = &dealerData{}
+	}
+	return &Participant{
+		round:     1,
+		curve:     curve,
+		id:        id,
+		threshold: threshold,
+		ids:       ids,
+		generator: params.H,
+		pedersen:  pedersen,
+		dealers:   dealers,
+	}, nil
+}
+
+// Determines if the SSIDs are exactly the values 1..n.
+func validIds(ids []uint32) error {
+	idMap := make(map[uint32]bool, len(ids))
+	for _, id := range ids {
+		idMap[id] = true
+	}
+	if len(idMap) != len(ids) {
+		return fmt.Errorf("the ID list %v contains duplicates", ids)
+	}
+	for i := 1; i <= len(ids); i++ {
+		if !idMap[uint32(i)] {
+			return fmt.Errorf("the ID list %v is invalid. Values must be 1,2,..,n", ids)
+		}
+	}
+	return nil
+}
+
+func (dp *Participant) accuse(id uint32, round int, reason string) {
+	dp.misbehavior = append(dp.misbehavior, &Misbehavior{Id: id, Round: round, Reason: reason})
+}
+
+func (dp *Participant) disqualify(id uint32, round int, reason string) {
+	dp.dealers[id].disqualified = true
+	dp.accuse(id, round, reason)
+}
+
+func (dp *Participant) verifyPedersen(dealer uint32, packet *Round1P2PSendPacket, recipient uint32) bool {
+	if packet == nil || packet.SecretShare == nil || packet.BlindingShare == nil ||
+		packet.SecretShare.Id != recipient || packet.BlindingShare.Id != recipient {
+		return false
+	}
+	verifier := sharing.PedersenVerifier{Generator: dp.generator, Commitments: dp.dealers[dealer].commitments}
+	return verifier.Verify(packet.SecretShare, packet.BlindingShare) == nil
+}
+
+func (dp *Participant) verifyFeldman(dealer uint32, share *sharing.ShamirShare) bool {
+	verifier := sharing.FeldmanVerifier{Commitments: dp.dealers[dealer].verifiers}
+	return verifier.Verify(share) == nil
+}
+
+// validCommitments checks that a broadcast has one commitment per coefficient, all on the curve.
+func (dp *Participant) validCommitments(points []curves.Point) bool {
+	if uint32(len(points)) != dp.threshold {
+		return false
+	}
+	for _, p := range points {
+		if p == nil || p.CurveName() != dp.curve.Name || !p.IsOnCurve() || p.IsIdentity() {
+			return false
+		}
+	}
+	return true
+}
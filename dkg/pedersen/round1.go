@@ -0,0 +1,80 @@
+package pedersen
+
+import (
+	crand "crypto/rand"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/sharing"
+)
+
+// Round1Bcast are the Pedersen commitments to the coefficients of the dealer's polynomials
+type Round1Bcast struct {
+	Commitments []curves.Point
+}
+
+// Round1P2PSend are the values that are sent to individual participants based
+// on the id
+type Round1P2PSend = map[uint32]*Round1P2PSendPacket
+
+// Round1P2PSendPacket are the shares generated from the secret for a specific participant
+type Round1P2PSendPacket struct {
+	SecretShare   *sharing.ShamirShare
+	BlindingShare *sharing.ShamirShare
+}
+
+// Round1 deals a random secret, or `secret` if it is not nil, with Pedersen VSS
+func (dp *Participant) Round1(secret []byte) (*Round1Bcast, Round1P2PSend, error) {
+	if dp == nil || dp.curve == nil {
+		return nil, nil, internal.ErrNilArguments
+	}
+	if dp.round != 1 {
+		return nil, nil, internal.ErrInvalidRound
+	}
+
+	var s curves.Scalar
+	var err error
+	if secret == nil {
+		s = dp.curve.Scalar.Random(crand.Reader)
+	} else {
+		s, err = dp.curve.Scalar.SetBytes(secret)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	if s.IsZero() {
+		return nil, nil, internal.ErrZeroValue
+	}
+
+	// 1. C_ik = a_ik·G + b_ik·H, s_ij = f_i(j), s'_ij = f'_i(j)
+	dp.dealing, err = dp.pedersen.Split(s, crand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	self := dp.dealers[dp.id]
+	self.commitments = dp.dealing.PedersenVerifier.Commitments
+	self.verifiers = dp.dealing.FeldmanVerifier.Commitments
+	self.share = dp.dealing.SecretShares[dp.id-1]
+	self.blindingShare = dp.dealing.BlindingShares[dp.id-1]
+
+	// 2. P2PSend s_ij, s'_ij to participant p_j
+	p2pSend := make(Round1P2PSend, len(dp.ids)-1)
+	for _, id := range dp.ids {
+		if id == dp.id {
+			continue
+		}
+		p2pSend[id] = dp.packet(id)
+	}
+
+	dp.round = 2
+
+	// 3. Broadcast {C_i1,...,C_it}
+	return &Round1Bcast{Commitments: self.commitments}, p2pSend, nil
+}
+
+func (dp *Participant) packet(id uint32) *Round1P2PSendPacket {
+	return &Round1P2PSendPacket{
+		SecretShare:   dp.dealing.SecretShares[id-1],
+		BlindingShare: dp.dealing.BlindingShares[id-1],
+	}
+}
@@ -0,0 +1,51 @@
+package pedersen
+
+import (
+	"github.com/go-sonr/crypto/internal"
+)
+
+// Round2Bcast are the dealers whose shares did not match their commitments
+type Round2Bcast struct {
+	Complaints []uint32
+}
+
+// Round2 verifies the shares received in round 1 and complains about the dealers of invalid or missing shares.
+// A dealer without a valid broadcast is disqualified immediately.
+// bcast contains all Round1 broadcast from other participants to this participant
+// p2p contains all Round1 P2P send message from other participants to this participant
+func (dp *Participant) Round2(bcast map[uint32]*Round1Bcast, p2p map[uint32]*Round1P2PSendPacket) (*Round2Bcast, error) {
+	if dp == nil || dp.curve == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if dp.round != 2 {
+		return nil, internal.ErrInvalidRound
+	}
+	if bcast == nil || p2p == nil {
+		return nil, internal.ErrNilArguments
+	}
+
+	for _, id := range dp.ids {
+		if id == dp.id {
+			continue
+		}
+		// 1. Disqualify P_j unless it broadcast t commitments
+		b := bcast[id]
+		if b == nil || !dp.validCommitments(b.Commitments) {
+			dp.disqualify(id, 1, "missing or invalid commitments")
+			continue
+		}
+		dp.dealers[id].commitments = b.Commitments
+
+		// 2. Complain about P_j unless s_ji·G + s'_ji·H = Σ C_jk·i^k
+		if !dp.verifyPedersen(id, p2p[id], dp.id) {
+			dp.complaints = append(dp.complaints, id)
+			continue
+		}
+		dp.dealers[id].share = p2p[id].SecretShare
+		dp.dealers[id].blindingShare = p2p[id].BlindingShare
+	}
+
+	dp.round = 3
+
+	return &Round2Bcast{Complaints: dp.complaints}, nil
+}
@@ -0,0 +1,52 @@
+package pedersen
+
+import (
+	"github.com/go-sonr/crypto/internal"
+)
+
+// Round3Bcast are the shares a dealer publishes to answer the complaints against it, by accuser
+type Round3Bcast struct {
+	Justifications map[uint32]*Round1P2PSendPacket
+}
+
+// Round3 records the complaints of round 2 and answers the complaints against this participant
+// bcast contains all Round2 broadcast from other participants to this participant
+func (dp *Participant) Round3(bcast map[uint32]*Round2Bcast) (*Round3Bcast, error) {
+	if dp == nil || dp.curve == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if dp.round != 3 {
+		return nil, internal.ErrInvalidRound
+	}
+	if bcast == nil {
+		return nil, internal.ErrNilArguments
+	}
+
+	for _, accuser := range dp.ids {
+		var complaints []uint32
+		if accuser == dp.id {
+			complaints = dp.complaints
+		} else if bcast[accuser] != nil {
+			complaints = bcast[accuser].Complaints
+		}
+		seen := make(map[uint32]bool, len(complaints))
+		for _, dealer := range complaints {
+			d, ok := dp.dealers[dealer]
+			if !ok || dealer == accuser || seen[dealer] {
+				continue
+			}
+			seen[dealer] = true
+			d.accusers = append(d.accusers, accuser)
+		}
+	}
+
+	// 1. Broadcast s_ij, s'_ij for every P_j that complained against P_i
+	justifications := make(map[uint32]*Round1P2PSendPacket, len(dp.dealers[dp.id].accusers))
+	for _, accuser := range dp.dealers[dp.id].accusers {
+		justifications[accuser] = dp.packet(accuser)
+	}
+
+	dp.round = 4
+
+	return &Round3Bcast{Justifications: justifications}, nil
+}
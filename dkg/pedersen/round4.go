@@ -0,0 +1,79 @@
+package pedersen
+
+import (
+	"fmt"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/internal"
+)
+
+// Round4Bcast are the Feldman commitments a_ik·G to the coefficients of the dealer's secret polynomial
+type Round4Bcast struct {
+	Verifiers []curves.Point
+}
+
+// Round4 checks the justifications of round 3 and builds the set QUAL of qualified dealers.
+// A dealer is disqualified if at least threshold participants complained against it or
+// if it did not answer a complaint with a share that matches its commitments.
+// bcast contains all Round3 broadcast from other participants to this participant
+func (dp *Participant) Round4(bcast map[uint32]*Round3Bcast) (*Round4Bcast, error) {
+	if dp == nil || dp.curve == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if dp.round != 4 {
+		return nil, internal.ErrInvalidRound
+	}
+	if bcast == nil {
+		return nil, internal.ErrNilArguments
+	}
+
+	for _, id := range dp.ids {
+		d := dp.dealers[id]
+		if d.disqualified || len(d.accusers) == 0 {
+			continue
+		}
+		if uint32(len(d.accusers)) >= dp.threshold {
+			dp.disqualify(id, 2, fmt.Sprintf("%d complaints", len(d.accusers)))
+			continue
+		}
+		var justifications map[uint32]*Round1P2PSendPacket
+		if id == dp.id {
+			justifications = make(map[uint32]*Round1P2PSendPacket, len(d.accusers))
+			for _, accuser := range d.accusers {
+				justifications[accuser] = dp.packet(accuser)
+			}
+		} else if bcast[id] != nil {
+			justifications = bcast[id].Justifications
+		}
+		for _, accuser := range d.accusers {
+			if !dp.verifyPedersen(id, justifications[accuser], accuser) {
+				dp.disqualify(id, 3, fmt.Sprintf("missing or invalid justification for participant %d", accuser))
+				break
+			}
+			// The published share replaces the one P_i did not receive
+			if accuser == dp.id {
+				d.share = justifications[accuser].SecretShare
+				d.blindingShare = justifications[accuser].BlindingShare
+			}
+		}
+	}
+
+	// 1. QUAL = {P_j | j not disqualified}, x_i = Σ_{j ∈ QUAL} s_ji
+	dp.qualified = nil
+	for _, id := range dp.ids {
+		if !dp.dealers[id].disqualified {
+			dp.qualified = append(dp.qualified, id)
+		}
+	}
+	if len(dp.qualified) == 0 {
+		return nil, fmt.Errorf("no qualified dealers")
+	}
+
+	dp.round = 5
+
+	// 2. Broadcast A_ik = a_ik·G if P_i is in QUAL
+	if dp.dealers[dp.id].disqualified {
+		return &Round4Bcast{}, nil
+	}
+	return &Round4Bcast{Verifiers: dp.dealers[dp.id].verifiers}, nil
+}
@@ -0,0 +1,50 @@
+package pedersen
+
+import (
+	"github.com/go-sonr/crypto/internal"
+)
+
+// Round5Bcast are the shares, by dealer, that do not match the dealer's Feldman commitments.
+// They prove the dealer misbehaved since they match its Pedersen commitments.
+type Round5Bcast struct {
+	Complaints map[uint32]*Round1P2PSendPacket
+}
+
+// Round5 checks the shares of the qualified dealers against their Feldman commitments.
+// A qualified dealer without valid Feldman commitments has its secret reconstructed in the next rounds.
+// bcast contains all Round4 broadcast from other participants to this participant
+func (dp *Participant) Round5(bcast map[uint32]*Round4Bcast) (*Round5Bcast, error) {
+	if dp == nil || dp.curve == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if dp.round != 5 {
+		return nil, internal.ErrInvalidRound
+	}
+	if bcast == nil {
+		return nil, internal.ErrNilArguments
+	}
+
+	complaints := make(map[uint32]*Round1P2PSendPacket)
+	for _, id := range dp.qualified {
+		if id == dp.id {
+			continue
+		}
+		d := dp.dealers[id]
+		b := bcast[id]
+		if b == nil || !dp.validCommitments(b.Verifiers) {
+			d.convicted = true
+			dp.accuse(id, 4, "missing or invalid public commitments")
+			continue
+		}
+		d.verifiers = b.Verifiers
+		// 1. Complain about P_j with s_ji, s'_ji unless s_ji·G = Σ A_jk·i^k
+		if !dp.verifyFeldman(id, d.share) {
+			complaints[id] = &Round1P2PSendPacket{SecretShare: d.share, BlindingShare: d.blindingShare}
+		}
+	}
+
+	dp.evidence = complaints
+	dp.round = 6
+
+	return &Round5Bcast{Complaints: complaints}, nil
+}
@@ -0,0 +1,63 @@
+package pedersen
+
+import (
+	"github.com/go-sonr/crypto/internal"
+)
+
+// Round6Bcast are this participant's shares, by dealer, of the dealers whose secret is reconstructed
+type Round6Bcast struct {
+	Shares map[uint32]*Round1P2PSendPacket
+}
+
+// Round6 checks the complaints of round 5 and reveals this participant's shares of every qualified dealer
+// that is proven to have published wrong Feldman commitments. A complaint with a share that does not match the
+// Pedersen commitments, or that matches the Feldman commitments, identifies the complainer as misbehaving.
+// bcast contains all Round5 broadcast from other participants to this participant
+func (dp *Participant) Round6(bcast map[uint32]*Round5Bcast) (*Round6Bcast, error) {
+	if dp == nil || dp.curve == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if dp.round != 6 {
+		return nil, internal.ErrInvalidRound
+	}
+	if bcast == nil {
+		return nil, internal.ErrNilArguments
+	}
+
+	for _, accuser := range dp.ids {
+		var complaints map[uint32]*Round1P2PSendPacket
+		if accuser == dp.id {
+			complaints = dp.evidence
+		} else if bcast[accuser] != nil {
+			complaints = bcast[accuser].Complaints
+		}
+		for _, dealer := range dp.qualified {
+			evidence, ok := complaints[dealer]
+			if !ok {
+				continue
+			}
+			d := dp.dealers[dealer]
+			switch {
+			case d.convicted:
+			case dealer == accuser || !dp.verifyPedersen(dealer, evidence, accuser) || dp.verifyFeldman(dealer, evidence.SecretShare):
+				dp.accuse(accuser, 5, "false complaint")
+			default:
+				d.convicted = true
+				dp.accuse(dealer, 4, "public commitments do not match the shares")
+			}
+		}
+	}
+
+	// 1. Reveal s_ji, s'_ji of every convicted P_j so that anyone can reconstruct f_j
+	shares := make(map[uint32]*Round1P2PSendPacket)
+	for _, dealer := range dp.qualified {
+		d := dp.dealers[dealer]
+		if d.convicted {
+			shares[dealer] = &Round1P2PSendPacket{SecretShare: d.share, BlindingShare: d.blindingShare}
+		}
+	}
+
+	dp.round = 7
+
+	return &Round6Bcast{Shares: shares}, nil
+}
@@ -0,0 +1,367 @@
+package pedersen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/sharing"
+)
+
+var testCtx = []byte("pedersen dkg test")
+
+// tamper lets a test change the messages of rounds 1, 3, 4 and 5 before they are delivered. With resume, every
+// participant is restored from its serialized state between rounds.
+type tamper struct {
+	round1 func(p2p map[uint32]Round1P2PSend)
+	round3 func(bcast map[uint32]*Round3Bcast)
+	round4 func(bcast map[uint32]*Round4Bcast)
+	round5 func(bcast map[uint32]*Round5Bcast)
+	resume bool
+}
+
+func newParticipants(t *testing.T, curve *curves.Curve, threshold, limit uint32) map[uint32]*Participant {
+	participants := make(map[uint32]*Participant, limit)
+	for i := uint32(1); i <= limit; i++ {
+		var others []uint32
+		for j := uint32(1); j <= limit; j++ {
+			if i != j {
+				others = append(others, j)
+			}
+		}
+		p, err := NewParticipant(i, threshold, curve, testCtx, others...)
+		require.NoError(t, err)
+		participants[i] = p
+	}
+	return participants
+}
+
+func runDkg(t *testing.T, participants map[uint32]*Participant, tm tamper) map[uint32]*Result {
+	if tm.resume {
+		restore(t, participants)
+	}
+	bcast1 := make(map[uint32]*Round1Bcast)
+	p2p1 := make(map[uint32]Round1P2PSend)
+	for id, p := range participants {
+		b, s, err := p.Round1(nil)
+		require.NoError(t, err)
+		bcast1[id], p2p1[id] = b, s
+	}
+	if tm.resume {
+		restore(t, participants)
+	}
+	if tm.round1 != nil {
+		tm.round1(p2p1)
+	}
+
+	bcast2 := make(map[uint32]*Round2Bcast)
+	for id, p := range participants {
+		in := make(map[uint32]*Round1P2PSendPacket)
+		for from, s := range p2p1 {
+			if from != id {
+				in[from] = s[id]
+			}
+		}
+		b, err := p.Round2(bcast1, in)
+		require.NoError(t, err)
+		bcast2[id] = b
+	}
+	if tm.resume {
+		restore(t, participants)
+	}
+
+	bcast3 := make(map[uint32]*Round3Bcast)
+	for id, p := range participants {
+		b, err := p.Round3(bcast2)
+		require.NoError(t, err)
+		bcast3[id] = b
+	}
+	if tm.resume {
+		restore(t, participants)
+	}
+	if tm.round3 != nil {
+		tm.round3(bcast3)
+	}
+
+	bcast4 := make(map[uint32]*Round4Bcast)
+	for id, p := range participants {
+		b, err := p.Round4(bcast3)
+		require.NoError(t, err)
+		bcast4[id] = b
+	}
+	if tm.resume {
+		restore(t, participants)
+	}
+	if tm.round4 != nil {
+		tm.round4(bcast4)
+	}
+
+	bcast5 := make(map[uint32]*Round5Bcast)
+	for id, p := range participants {
+		b, err := p.Round5(bcast4)
+		require.NoError(t, err)
+		bcast5[id] = b
+	}
+	if tm.resume {
+		restore(t, participants)
+	}
+	if tm.round5 != nil {
+		tm.round5(bcast5)
+	}
+
+	bcast6 := make(map[uint32]*Round6Bcast)
+	for id, p := range participants {
+		b, err := p.Round6(bcast5)
+		require.NoError(t, err)
+		bcast6[id] = b
+	}
+	if tm.resume {
+		restore(t, participants)
+	}
+
+	results := make(map[uint32]*Result)
+	for id, p := range participants {
+		r, err := p.Finalize(bcast6)
+		require.NoError(t, err)
+		results[id] = r
+	}
+	return results
+}
+
+// restore replaces every participant with one restored from its serialized state
+func restore(t *testing.T, participants map[uint32]*Participant) {
+	for id, p := range participants {
+		data, err := p.MarshalBinary()
+		require.NoError(t, err)
+		restored := &Participant{}
+		require.NoError(t, restored.UnmarshalBinary(data))
+		participants[id] = restored
+	}
+}
+
+// checkResults checks that the results of the honest participants agree and that any threshold shares reconstruct the public key.
+func checkResults(t *testing.T, curve *curves.Curve, threshold uint32, results map[uint32]*Result) {
+	var first *Result
+	for _, r := range results {
+		if first == nil {
+			first = r
+		}
+		require.True(t, r.PublicKey.Equal(first.PublicKey))
+		require.Equal(t, first.Qualified, r.Qualified)
+		for id, y := range first.PublicShares {
+			require.True(t, y.Equal(r.PublicShares[id]))
+		}
+	}
+
+	shamir, err := sharing.NewShamir(threshold, uint32(len(first.PublicShares)), curve)
+	require.NoError(t, err)
+	shares := make([]*sharing.ShamirShare, 0, threshold)
+	signers := make([]uint32, 0, threshold)
+	for id := uint32(len(first.PublicShares)); uint32(len(shares)) < threshold; id-- {
+		if r, ok := results[id]; ok {
+			shares = append(shares, r.SecretShare)
+			signers = append(signers, id)
+		}
+	}
+	secret, err := shamir.Combine(shares...)
+	require.NoError(t, err)
+	require.True(t, curve.ScalarBaseMult(secret).Equal(first.PublicKey))
+
+	sum := curve.Scalar.Zero()
+	for _, id := range signers {
+		share, err := results[id].AdditiveShare(signers)
+		require.NoError(t, err)
+		sum = sum.Add(share)
+	}
+	require.Equal(t, secret.Bytes(), sum.Bytes())
+}
+
+func TestDkgHonest(t *testing.T) {
+	for _, curve := range []*curves.Curve{curves.K256(), curves.ED25519(), curves.P256()} {
+		participants := newParticipants(t, curve, 3, 5)
+		results := runDkg(t, participants, tamper{})
+		checkResults(t, curve, 3, results)
+		for _, r := range results {
+			require.Equal(t, []uint32{1, 2, 3, 4, 5}, r.Qualified)
+			require.Empty(t, r.Misbehavior)
+		}
+	}
+}
+
+func TestDkgJustifiedComplaint(t *testing.T) {
+	curve := curves.K256()
+	participants := newParticipants(t, curve, 3, 5)
+	// Dealer 2 sends a wrong share to 4, then publishes the right one when 4 complains.
+	results := runDkg(t, participants, tamper{
+		round1: func(p2p map[uint32]Round1P2PSend) {
+			p2p[2][4] = p2p[2][5]
+		},
+	})
+	checkResults(t, curve, 3, results)
+	for _, r := range results {
+		require.Equal(t, []uint32{1, 2, 3, 4, 5}, r.Qualified)
+		require.Empty(t, r.Misbehavior)
+	}
+}
+
+func TestDkgInvalidJustification(t *testing.T) {
+	curve := curves.K256()
+	participants := newParticipants(t, curve, 3, 5)
+	// Dealer 2 sends no share to 4 and answers the complaint with 5's share.
+	results := runDkg(t, participants, tamper{
+		round1: func(p2p map[uint32]Round1P2PSend) {
+			delete(p2p[2], 4)
+		},
+		round3: func(bcast map[uint32]*Round3Bcast) {
+			bcast[2].Justifications[4] = participants[2].packet(5)
+		},
+	})
+	delete(results, 2)
+	checkResults(t, curve, 3, results)
+	for _, r := range results {
+		require.Equal(t, []uint32{1, 3, 4, 5}, r.Qualified)
+		require.Len(t, r.Misbehavior, 1)
+		require.Equal(t, uint32(2), r.Misbehavior[0].Id)
+		require.Equal(t, 3, r.Misbehavior[0].Round)
+	}
+}
+
+func TestDkgTooManyComplaints(t *testing.T) {
+	curve := curves.ED25519()
+	participants := newParticipants(t, curve, 2, 4)
+	results := runDkg(t, participants, tamper{
+		round1: func(p2p map[uint32]Round1P2PSend) {
+			p2p[1][2] = p2p[1][3]
+			p2p[1][3] = p2p[1][4]
+		},
+	})
+	delete(results, 1)
+	checkResults(t, curve, 2, results)
+	for _, r := range results {
+		require.Equal(t, []uint32{2, 3, 4}, r.Qualified)
+		require.Len(t, r.Misbehavior, 1)
+		require.Equal(t, uint32(1), r.Misbehavior[0].Id)
+	}
+}
+
+func TestDkgWrongPublicCommitments(t *testing.T) {
+	curve := curves.K256()
+	participants := newParticipants(t, curve, 3, 5)
+	var expected curves.Point
+	// Dealer 3 publishes Feldman commitments to another secret, its real secret is reconstructed.
+	results := runDkg(t, participants, tamper{
+		round4: func(bcast map[uint32]*Round4Bcast) {
+			expected = curve.NewIdentityPoint()
+			for _, b := range bcast {
+				expected = expected.Add(b.Verifiers[0])
+			}
+			verifiers := append([]curves.Point{}, bcast[3].Verifiers...)
+			verifiers[0] = verifiers[0].Add(curve.NewGeneratorPoint())
+			bcast[3] = &Round4Bcast{Verifiers: verifiers}
+		},
+	})
+	delete(results, 3)
+	checkResults(t, curve, 3, results)
+	for _, r := range results {
+		require.True(t, expected.Equal(r.PublicKey))
+		require.Equal(t, []uint32{1, 2, 3, 4, 5}, r.Qualified)
+		require.Len(t, r.Misbehavior, 1)
+		require.Equal(t, uint32(3), r.Misbehavior[0].Id)
+		require.Equal(t, 4, r.Misbehavior[0].Round)
+	}
+}
+
+func TestDkgFalseComplaint(t *testing.T) {
+	curve := curves.K256()
+	participants := newParticipants(t, curve, 3, 5)
+	// Participant 5 accuses dealer 1 with a valid share.
+	results := runDkg(t, participants, tamper{
+		round5: func(bcast map[uint32]*Round5Bcast) {
+			bcast[5].Complaints[1] = participants[1].packet(5)
+		},
+	})
+	delete(results, 5)
+	checkResults(t, curve, 3, results)
+	for _, r := range results {
+		require.Equal(t, []uint32{1, 2, 3, 4, 5}, r.Qualified)
+		require.Len(t, r.Misbehavior, 1)
+		require.Equal(t, uint32(5), r.Misbehavior[0].Id)
+		require.Equal(t, 5, r.Misbehavior[0].Round)
+	}
+}
+
+func TestDkgRounds(t *testing.T) {
+	_, err := NewParticipant(1, 2, nil, testCtx, 2)
+	require.Error(t, err)
+	_, err = NewParticipant(1, 2, curves.K256(), testCtx, 3)
+	require.Error(t, err)
+	_, err = NewParticipant(1, 3, curves.K256(), testCtx, 2)
+	require.Error(t, err)
+
+	p, err := NewParticipant(1, 2, curves.K256(), testCtx, 2)
+	require.NoError(t, err)
+	_, err = p.Round2(nil, nil)
+	require.ErrorIs(t, err, internal.ErrInvalidRound)
+	_, _, err = p.Round1([]byte{0})
+	require.Error(t, err)
+	_, _, err = p.Round1(nil)
+	require.NoError(t, err)
+	_, _, err = p.Round1(nil)
+	require.ErrorIs(t, err, internal.ErrInvalidRound)
+	_, err = p.Finalize(map[uint32]*Round6Bcast{})
+	require.ErrorIs(t, err, internal.ErrInvalidRound)
+}
+
+func TestDkgResume(t *testing.T) {
+	curve := curves.K256()
+	participants := newParticipants(t, curve, 3, 5)
+	// Dealer 2 answers the complaint of 4, dealer 3 publishes wrong public commitments and 5 accuses 1 falsely, with
+	// every participant restored between rounds.
+	results := runDkg(t, participants, tamper{
+		round1: func(p2p map[uint32]Round1P2PSend) {
+			p2p[2][4] = p2p[2][5]
+		},
+		round4: func(bcast map[uint32]*Round4Bcast) {
+			verifiers := append([]curves.Point{}, bcast[3].Verifiers...)
+			verifiers[0] = verifiers[0].Add(curve.NewGeneratorPoint())
+			bcast[3] = &Round4Bcast{Verifiers: verifiers}
+		},
+		round5: func(bcast map[uint32]*Round5Bcast) {
+			bcast[5].Complaints[1] = participants[1].packet(5)
+		},
+		resume: true,
+	})
+	delete(results, 3)
+	delete(results, 5)
+	checkResults(t, curve, 3, results)
+	for _, r := range results {
+		require.Equal(t, []uint32{1, 2, 3, 4, 5}, r.Qualified)
+		require.Len(t, r.Misbehavior, 2)
+	}
+
+	// a finalized participant keeps its round
+	restore(t, participants)
+	_, err := participants[1].Finalize(map[uint32]*Round6Bcast{})
+	require.ErrorIs(t, err, internal.ErrInvalidRound)
+}
+
+func TestDkgStateRejectsMalformed(t *testing.T) {
+	p, err := NewParticipant(1, 2, curves.K256(), testCtx, 2, 3)
+	require.NoError(t, err)
+	_, _, err = p.Round1(nil)
+	require.NoError(t, err)
+	data, err := p.MarshalBinary()
+	require.NoError(t, err)
+	require.NoError(t, (&Participant{}).UnmarshalBinary(data))
+
+	require.Error(t, (&Participant{}).UnmarshalBinary(data[:len(data)-1]))
+	require.Error(t, (&Participant{}).UnmarshalBinary(append(data, 0)))
+
+	// the shares of the dealing are for every participant
+	p.dealing.SecretShares = p.dealing.SecretShares[:2]
+	data, err = p.MarshalBinary()
+	require.NoError(t, err)
+	require.Error(t, (&Participant{}).UnmarshalBinary(data))
+}
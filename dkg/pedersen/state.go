@@ -0,0 +1,245 @@
+package pedersen
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/go-sonr/crypto/core/protocol/messages"
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/sharing"
+)
+
+// The state of a participant is serialized in the canonical messages format, whose header carries the version of the
+// encoding, so that a participant that crashed between rounds resumes where it stopped. State must be persisted after
+// each round and before its output is sent: a participant restored from an older state runs a round again, and
+// round 1 deals a fresh secret that the other participants must not see next to the first one.
+//
+// Serialized state holds the dealing and the received shares of the participant and must be protected like them.
+const stateType = "pedersen-dkg/participant-state"
+
+// lastRound is the round of a participant that has finalized the DKG
+const lastRound = 8
+
+// MarshalBinary encodes the state of the participant between rounds.
+func (dp *Participant) MarshalBinary() ([]byte, error) {
+	if dp == nil || dp.curve == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if dp.round < 1 || dp.round > lastRound {
+		return nil, internal.ErrInvalidRound
+	}
+	enc := messages.NewEncoder(stateType, dp.curve)
+	enc.WriteUint32(uint32(dp.round))
+	enc.WriteUint32(dp.id)
+	enc.WriteUint32(dp.threshold)
+	enc.WriteUint32s(dp.ids)
+	enc.WritePoint(dp.generator)
+	if dp.round > 1 {
+		if dp.dealing == nil {
+			return nil, fmt.Errorf("missing round 1 dealing")
+		}
+		enc.WriteScalar(dp.dealing.Blinding)
+		writeShares(enc, dp.dealing.SecretShares)
+		writeShares(enc, dp.dealing.BlindingShares)
+	}
+	for _, id := range dp.ids {
+		d := dp.dealers[id]
+		if d == nil {
+			return nil, fmt.Errorf("missing dealer %d", id)
+		}
+		enc.WritePoints(d.commitments)
+		enc.WritePoints(d.verifiers)
+		writeOptionalShare(enc, d.share)
+		writeOptionalShare(enc, d.blindingShare)
+		enc.WriteUint32s(d.accusers)
+		enc.WriteUint32(flag(d.disqualified) | flag(d.convicted)<<1)
+	}
+	enc.WriteUint32s(dp.complaints)
+	dealers := make([]uint32, 0, len(dp.evidence))
+	for dealer := range dp.evidence {
+		dealers = append(dealers, dealer)
+	}
+	sort.Slice(dealers, func(i, j int) bool { return dealers[i] < dealers[j] })
+	enc.WriteUint32s(dealers)
+	for _, dealer := range dealers {
+		packet := dp.evidence[dealer]
+		if packet == nil {
+			packet = &Round1P2PSendPacket{}
+		}
+		writeOptionalShare(enc, packet.SecretShare)
+		writeOptionalShare(enc, packet.BlindingShare)
+	}
+	enc.WriteUint32s(dp.qualified)
+	enc.WriteUint32(uint32(len(dp.misbehavior)))
+	for _, m := range dp.misbehavior {
+		enc.WriteUint32(m.Id)
+		enc.WriteUint32(uint32(m.Round))
+		enc.WriteBytes([]byte(m.Reason))
+	}
+	data, err := enc.Finish()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't encode participant state: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary restores a participant encoded by MarshalBinary.
+func (dp *Participant) UnmarshalBinary(data []byte) error {
+	dec, err := messages.NewDecoder(data, stateType)
+	if err != nil {
+		return fmt.Errorf("couldn't decode participant state: %w", err)
+	}
+	curve := dec.Curve()
+	if curve == nil {
+		return fmt.Errorf("participant state has no curve")
+	}
+	round := dec.ReadUint32()
+	id := dec.ReadUint32()
+	threshold := dec.ReadUint32()
+	ids := dec.ReadUint32s()
+	generator := dec.ReadPoint()
+	var dealing *sharing.PedersenResult
+	if round > 1 {
+		dealing = &sharing.PedersenResult{
+			Blinding:       dec.ReadScalar(),
+			SecretShares:   readShares(dec, len(ids)),
+			BlindingShares: readShares(dec, len(ids)),
+		}
+	}
+	dealers := make(map[uint32]*dealerData, len(ids))
+	for _, i := range ids {
+		d := &dealerData{
+			commitments:   dec.ReadPoints(),
+			verifiers:     dec.ReadPoints(),
+			share:         readOptionalShare(dec),
+			blindingShare: readOptionalShare(dec),
+			accusers:      nilIfEmpty(dec.ReadUint32s()),
+		}
+		flags := dec.ReadUint32()
+		d.disqualified, d.convicted = flags&1 != 0, flags&2 != 0
+		dealers[i] = d
+	}
+	complaints := nilIfEmpty(dec.ReadUint32s())
+	evidenceDealers := dec.ReadUint32s()
+	evidence := make(map[uint32]*Round1P2PSendPacket, len(evidenceDealers))
+	for _, dealer := range evidenceDealers {
+		evidence[dealer] = &Round1P2PSendPacket{SecretShare: readOptionalShare(dec), BlindingShare: readOptionalShare(dec)}
+	}
+	qualified := nilIfEmpty(dec.ReadUint32s())
+	var misbehavior []*Misbehavior
+	// every entry takes more than a byte, which bounds a corrupted count
+	for n, i := dec.ReadUint32(), uint32(0); i < n && i < uint32(len(data)); i++ {
+		misbehavior = append(misbehavior, &Misbehavior{
+			Id:     dec.ReadUint32(),
+			Round:  int(dec.ReadUint32()),
+			Reason: string(dec.ReadBytes()),
+		})
+	}
+	if err = dec.Finish(); err != nil {
+		return fmt.Errorf("couldn't decode participant state: %w", err)
+	}
+
+	if round < 1 || round > lastRound {
+		return internal.ErrInvalidRound
+	}
+	if err = validIds(ids); err != nil {
+		return err
+	}
+	if id == 0 || id > uint32(len(ids)) {
+		return fmt.Errorf("invalid participant id %d", id)
+	}
+	if !sort.SliceIsSorted(ids, func(i, j int) bool { return ids[i] < ids[j] }) {
+		return fmt.Errorf("participant ids are not sorted")
+	}
+	if round <= 5 {
+		// the complaints of round 5 are the first evidence
+		if len(evidence) > 0 {
+			return fmt.Errorf("evidence in the state of round %d", round)
+		}
+		evidence = nil
+	}
+	pedersen, err := sharing.NewPedersen(threshold, uint32(len(ids)), generator)
+	if err != nil {
+		return err
+	}
+	if dealing != nil {
+		self := dealers[id]
+		if len(dealing.SecretShares) != len(ids) || len(dealing.BlindingShares) != len(ids) {
+			return fmt.Errorf("dealing doesn't have a share for every participant")
+		}
+		if uint32(len(self.commitments)) != threshold || uint32(len(self.verifiers)) != threshold {
+			return fmt.Errorf("dealing doesn't match the threshold")
+		}
+		dealing.PedersenVerifier = &sharing.PedersenVerifier{Generator: generator, Commitments: self.commitments}
+		dealing.FeldmanVerifier = &sharing.FeldmanVerifier{Commitments: self.verifiers}
+	}
+
+	dp.round = int(round)
+	dp.curve = curve
+	dp.id = id
+	dp.threshold = threshold
+	dp.ids = ids
+	dp.generator = generator
+	dp.pedersen = pedersen
+	dp.dealing = dealing
+	dp.dealers = dealers
+	dp.complaints = complaints
+	dp.evidence = evidence
+	dp.qualified = qualified
+	dp.misbehavior = misbehavior
+	return nil
+}
+
+func flag(b bool) uint32 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func nilIfEmpty(ids []uint32) []uint32 {
+	if len(ids) == 0 {
+		return nil
+	}
+	return ids
+}
+
+// writeShares writes a dealing of one share per participant
+func writeShares(enc *messages.Encoder, shares []*sharing.ShamirShare) {
+	enc.WriteUint32(uint32(len(shares)))
+	for _, share := range shares {
+		if share == nil {
+			share = &sharing.ShamirShare{}
+		}
+		enc.WriteUint32(share.Id)
+		enc.WriteBytes(share.Value)
+	}
+}
+
+// readShares reads a dealing written by writeShares, which must have limit shares
+func readShares(dec *messages.Decoder, limit int) []*sharing.ShamirShare {
+	n := dec.ReadUint32()
+	shares := make([]*sharing.ShamirShare, 0, limit)
+	for i := uint32(0); i < n && i <= uint32(limit); i++ {
+		shares = append(shares, &sharing.ShamirShare{Id: dec.ReadUint32(), Value: dec.ReadBytes()})
+	}
+	return shares
+}
+
+// writeOptionalShare writes a share that the participant may not have received
+func writeOptionalShare(enc *messages.Encoder, share *sharing.ShamirShare) {
+	if share == nil {
+		enc.WriteUint32(0)
+		return
+	}
+	enc.WriteUint32(1)
+	enc.WriteUint32(share.Id)
+	enc.WriteBytes(share.Value)
+}
+
+func readOptionalShare(dec *messages.Decoder) *sharing.ShamirShare {
+	if dec.ReadUint32() == 0 {
+		return nil
+	}
+	return &sharing.ShamirShare{Id: dec.ReadUint32(), Value: dec.ReadBytes()}
+}
@@ -0,0 +1,142 @@
+package ecies
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+
+	"filippo.io/edwards25519"
+	eciesgo "github.com/ecies/go/v2"
+	"github.com/libp2p/go-libp2p/core/crypto"
+
+	"github.com/go-sonr/crypto/keyexchange"
+	"github.com/go-sonr/crypto/keys/parsers"
+)
+
+// x25519Info binds the HKDF output of Ed25519 recipients to this scheme
+const x25519Info = "sonr-ecies-x25519-aes256gcm"
+
+// EncryptToDID encrypts plaintext to the key of a did:key identifier.
+// Secp256k1 keys use Encrypt. Ed25519 keys are mapped to X25519 and the
+// ciphertext is the ephemeral public key, the AES-256-GCM nonce and the
+// sealed plaintext, keyed by HKDF-SHA256 over the shared secret.
+func EncryptToDID(id parsers.DIDKey, plaintext []byte) ([]byte, error) {
+	if id.PubKey == nil {
+		return nil, fmt.Errorf("did:key has no public key")
+	}
+	raw, err := id.Raw()
+	if err != nil {
+		return nil, err
+	}
+	switch id.Type() {
+	case crypto.Secp256k1:
+		pub, err := eciesgo.NewPublicKeyFromBytes(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid secp256k1 key: %w", err)
+		}
+		return Encrypt(pub, plaintext)
+	case crypto.Ed25519:
+		pub, err := ed25519ToX25519Public(raw)
+		if err != nil {
+			return nil, err
+		}
+		return encryptX25519(pub, plaintext)
+	default:
+		return nil, fmt.Errorf("unsupported key type for encryption: %s", id.Type())
+	}
+}
+
+// DecryptWithPrivKey decrypts a ciphertext of EncryptToDID with the
+// secp256k1 or Ed25519 private key behind the did:key
+func DecryptWithPrivKey(priv crypto.PrivKey, ciphertext []byte) ([]byte, error) {
+	if priv == nil {
+		return nil, fmt.Errorf("private key is required")
+	}
+	raw, err := priv.Raw()
+	if err != nil {
+		return nil, err
+	}
+	switch priv.Type() {
+	case crypto.Secp256k1:
+		return Decrypt(eciesgo.NewPrivateKeyFromBytes(raw), ciphertext)
+	case crypto.Ed25519:
+		if len(raw) != ed25519.PrivateKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 private key length: %d", len(raw))
+		}
+		return DecryptEd25519(ed25519.PrivateKey(raw), ciphertext)
+	default:
+		return nil, fmt.Errorf("unsupported key type for decryption: %s", priv.Type())
+	}
+}
+
+// DecryptEd25519 decrypts a ciphertext of EncryptToDID for an Ed25519 recipient
+func DecryptEd25519(priv ed25519.PrivateKey, ciphertext []byte) ([]byte, error) {
+	if len(priv) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("invalid Ed25519 private key length: %d", len(priv))
+	}
+	if len(ciphertext) < keyexchange.X25519.Size+12+16 {
+		return nil, fmt.Errorf("ciphertext is too short")
+	}
+	// the X25519 scalar is the clamped first half of SHA-512(seed) as in RFC 8032
+	h := sha512.Sum512(priv.Seed())
+	key, err := keyexchange.X25519.NewPrivateKey(h[:32])
+	if err != nil {
+		return nil, err
+	}
+	ephemeral := ciphertext[:keyexchange.X25519.Size]
+	shared, err := key.ECDH(ephemeral)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := x25519AEAD(shared, ephemeral, key.PublicKey())
+	if err != nil {
+		return nil, err
+	}
+	nonce := ciphertext[len(ephemeral) : len(ephemeral)+aead.NonceSize()]
+	return aead.Open(nil, nonce, ciphertext[len(ephemeral)+aead.NonceSize():], nil)
+}
+
+func encryptX25519(pub, plaintext []byte) ([]byte, error) {
+	ephemeral, shared, err := keyexchange.X25519.Ephemeral(pub, rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := x25519AEAD(shared, ephemeral, pub)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, len(ephemeral)+aead.NonceSize(), len(ephemeral)+aead.NonceSize()+len(plaintext)+aead.Overhead())
+	copy(out, ephemeral)
+	if _, err := rand.Read(out[len(ephemeral):]); err != nil {
+		return nil, err
+	}
+	return aead.Seal(out, out[len(ephemeral):], plaintext, nil), nil
+}
+
+// x25519AEAD derives the AES-256-GCM key salted with both public keys
+func x25519AEAD(shared, ephemeral, recipient []byte) (cipher.AEAD, error) {
+	salt := append(append([]byte{}, ephemeral...), recipient...)
+	key, err := keyexchange.HKDF(sha256.New, shared, salt, []byte(x25519Info), 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// ed25519ToX25519Public maps an Ed25519 public key to the birationally
+// equivalent X25519 public key
+func ed25519ToX25519Public(pub []byte) ([]byte, error) {
+	p, err := new(edwards25519.Point).SetBytes(pub)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Ed25519 key: %w", err)
+	}
+	return p.BytesMontgomery(), nil
+}
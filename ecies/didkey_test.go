@@ -0,0 +1,51 @@
+package ecies_test
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/ecies"
+	"github.com/go-sonr/crypto/keys/parsers"
+)
+
+func TestEncryptToDID(t *testing.T) {
+	for _, keyType := range []int{crypto.Ed25519, crypto.Secp256k1} {
+		priv, pub, err := crypto.GenerateKeyPairWithReader(keyType, 256, rand.Reader)
+		require.NoError(t, err)
+		id, err := parsers.NewKeyDID(pub)
+		require.NoError(t, err)
+		// round trip through the did:key string as a caller would
+		id, err = parsers.Parse(id.String())
+		require.NoError(t, err)
+
+		ct, err := ecies.EncryptToDID(id, []byte("hello did"))
+		require.NoError(t, err)
+		pt, err := ecies.DecryptWithPrivKey(priv, ct)
+		require.NoError(t, err)
+		require.Equal(t, []byte("hello did"), pt)
+
+		ct[len(ct)-1] ^= 1
+		_, err = ecies.DecryptWithPrivKey(priv, ct)
+		require.Error(t, err)
+
+		other, _, err := crypto.GenerateKeyPairWithReader(keyType, 256, rand.Reader)
+		require.NoError(t, err)
+		ct[len(ct)-1] ^= 1
+		_, err = ecies.DecryptWithPrivKey(other, ct)
+		require.Error(t, err)
+	}
+}
+
+func TestEncryptToDIDUnsupported(t *testing.T) {
+	_, pub, err := crypto.GenerateKeyPairWithReader(crypto.ECDSA, 256, rand.Reader)
+	require.NoError(t, err)
+	id, err := parsers.NewKeyDID(pub)
+	require.NoError(t, err)
+	_, err = ecies.EncryptToDID(id, []byte("hello"))
+	require.Error(t, err)
+	_, err = ecies.EncryptToDID(parsers.DIDKey{}, []byte("hello"))
+	require.Error(t, err)
+}
@@ -0,0 +1,251 @@
+// Package elgamal implements ElGamal encryption over any curve in core/curves.
+//
+// Standard ElGamal encrypts a curve point M as (rG, M + rY). Exponential ElGamal encrypts a scalar m as
+// (rG, mG + rY), which makes ciphertexts additively homomorphic at the cost of a discrete log on decryption, so it is
+// only practical for small plaintexts such as vote tallies or bids.
+package elgamal
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/internal"
+)
+
+// PublicKey is an ElGamal public key Y = xG.
+type PublicKey struct {
+	Y curves.Point
+}
+
+// SecretKey is an ElGamal secret key x.
+type SecretKey struct {
+	PublicKey
+	x curves.Scalar
+}
+
+// Ciphertext is an ElGamal ciphertext (C1, C2) = (rG, M + rY).
+type Ciphertext struct {
+	C1, C2 curves.Point
+}
+
+// NewKeys generates a random key pair on the curve.
+func NewKeys(curve *curves.Curve) (*PublicKey, *SecretKey, error) {
+	if curve == nil {
+		return nil, nil, internal.ErrNilArguments
+	}
+	sk, err := NewSecretKey(curve.Scalar.Random(rand.Reader))
+	if err != nil {
+		return nil, nil, err
+	}
+	return &sk.PublicKey, sk, nil
+}
+
+// NewSecretKey returns the key pair with secret x.
+func NewSecretKey(x curves.Scalar) (*SecretKey, error) {
+	if x == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if x.IsZero() {
+		return nil, fmt.Errorf("secret key cannot be zero")
+	}
+	return &SecretKey{PublicKey: PublicKey{Y: x.Point().Generator().Mul(x)}, x: x}, nil
+}
+
+// Encrypt encrypts the point msg and returns the ciphertext and its randomness r.
+func (pk *PublicKey) Encrypt(msg curves.Point) (*Ciphertext, curves.Scalar, error) {
+	curve, err := pk.curve()
+	if err != nil {
+		return nil, nil, err
+	}
+	if msg == nil {
+		return nil, nil, internal.ErrNilArguments
+	}
+	if msg.CurveName() != curve.Name {
+		return nil, nil, fmt.Errorf("message is on %s, key is on %s", msg.CurveName(), curve.Name)
+	}
+	r := curve.Scalar.Random(rand.Reader)
+	return pk.encrypt(curve, msg, r), r, nil
+}
+
+// EncryptScalar encrypts msg with exponential ElGamal, i.e. encrypts the point msg·G, and returns the ciphertext and
+// its randomness r.
+func (pk *PublicKey) EncryptScalar(msg curves.Scalar) (*Ciphertext, curves.Scalar, error) {
+	curve, err := pk.curve()
+	if err != nil {
+		return nil, nil, err
+	}
+	if msg == nil {
+		return nil, nil, internal.ErrNilArguments
+	}
+	return pk.Encrypt(curve.ScalarBaseMult(msg))
+}
+
+// EncryptWithNonce encrypts the point msg with the given randomness r.
+func (pk *PublicKey) EncryptWithNonce(msg curves.Point, r curves.Scalar) (*Ciphertext, error) {
+	curve, err := pk.curve()
+	if err != nil {
+		return nil, err
+	}
+	if msg == nil || r == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if msg.CurveName() != curve.Name {
+		return nil, fmt.Errorf("message is on %s, key is on %s", msg.CurveName(), curve.Name)
+	}
+	return pk.encrypt(curve, msg, r), nil
+}
+
+func (pk *PublicKey) encrypt(curve *curves.Curve, msg curves.Point, r curves.Scalar) *Ciphertext {
+	return &Ciphertext{
+		C1: curve.ScalarBaseMult(r),
+		C2: msg.Add(pk.Y.Mul(r)),
+	}
+}
+
+// Rerandomize returns a fresh encryption of the plaintext of c, (C1 + r'G, C2 + r'Y), and the added randomness r'.
+// The result is unlinkable to c for anyone without the secret key.
+func (pk *PublicKey) Rerandomize(c *Ciphertext) (*Ciphertext, curves.Scalar, error) {
+	curve, err := pk.curve()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = c.validate(curve); err != nil {
+		return nil, nil, err
+	}
+	r := curve.Scalar.Random(rand.Reader)
+	return &Ciphertext{
+		C1: c.C1.Add(curve.ScalarBaseMult(r)),
+		C2: c.C2.Add(pk.Y.Mul(r)),
+	}, r, nil
+}
+
+// Zeroize overwrites the secret key x with zero
+func (sk *SecretKey) Zeroize() {
+	curves.Zeroize(sk.x)
+}
+
+// Decrypt returns the point M = C2 - xC1 encrypted by c. For exponential ElGamal this is m·G.
+func (sk *SecretKey) Decrypt(c *Ciphertext) (curves.Point, error) {
+	curve, err := sk.curve()
+	if err != nil {
+		return nil, err
+	}
+	if err = c.validate(curve); err != nil {
+		return nil, err
+	}
+	return c.C2.Sub(c.C1.Mul(sk.x)), nil
+}
+
+// DecryptScalar decrypts an exponential ElGamal ciphertext of a plaintext m in [0, bound) by solving the discrete log
+// of m·G with baby-step giant-step, in time and memory proportional to √bound.
+func (sk *SecretKey) DecryptScalar(c *Ciphertext, bound uint64) (uint64, error) {
+	m, err := sk.Decrypt(c)
+	if err != nil {
+		return 0, err
+	}
+	curve, _ := sk.curve()
+	return DiscreteLog(curve, m, bound)
+}
+
+// DiscreteLog returns m in [0, bound) with m·G = point.
+func DiscreteLog(curve *curves.Curve, point curves.Point, bound uint64) (uint64, error) {
+	if curve == nil || point == nil {
+		return 0, internal.ErrNilArguments
+	}
+	if bound == 0 {
+		return 0, fmt.Errorf("bound must be positive")
+	}
+	steps := uint64(1)
+	for steps*steps < bound {
+		steps++
+	}
+
+	// baby steps: j·G for 0 <= j < steps
+	baby := make(map[string]uint64, steps)
+	g := curve.NewGeneratorPoint()
+	p := curve.NewIdentityPoint()
+	for j := uint64(0); j < steps; j++ {
+		baby[string(p.ToAffineCompressed())] = j
+		p = p.Add(g)
+	}
+
+	// giant steps: point - i·steps·G
+	giant := p.Neg()
+	p = point
+	for i := uint64(0); i < steps; i++ {
+		if j, ok := baby[string(p.ToAffineCompressed())]; ok {
+			if m := i*steps + j; m < bound {
+				return m, nil
+			}
+			break
+		}
+		p = p.Add(giant)
+	}
+	return 0, fmt.Errorf("plaintext is not in [0, %d)", bound)
+}
+
+// Add returns the encryption of the sum of the plaintexts of c and d.
+func (c *Ciphertext) Add(d *Ciphertext) (*Ciphertext, error) {
+	if err := c.sameCurve(d); err != nil {
+		return nil, err
+	}
+	return &Ciphertext{C1: c.C1.Add(d.C1), C2: c.C2.Add(d.C2)}, nil
+}
+
+// Sub returns the encryption of the difference of the plaintexts of c and d.
+func (c *Ciphertext) Sub(d *Ciphertext) (*Ciphertext, error) {
+	if err := c.sameCurve(d); err != nil {
+		return nil, err
+	}
+	return &Ciphertext{C1: c.C1.Sub(d.C1), C2: c.C2.Sub(d.C2)}, nil
+}
+
+// ScalarMul returns the encryption of the plaintext of c multiplied by k.
+func (c *Ciphertext) ScalarMul(k curves.Scalar) (*Ciphertext, error) {
+	if c == nil || c.C1 == nil || c.C2 == nil || k == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if k.Point().CurveName() != c.C1.CurveName() {
+		return nil, fmt.Errorf("scalar is not on curve %s", c.C1.CurveName())
+	}
+	return &Ciphertext{C1: c.C1.Mul(k), C2: c.C2.Mul(k)}, nil
+}
+
+func (c *Ciphertext) sameCurve(d *Ciphertext) error {
+	if c == nil || d == nil || c.C1 == nil || c.C2 == nil {
+		return internal.ErrNilArguments
+	}
+	curve := curves.GetCurveByName(c.C1.CurveName())
+	if curve == nil {
+		return fmt.Errorf("unsupported curve %s", c.C1.CurveName())
+	}
+	if err := c.validate(curve); err != nil {
+		return err
+	}
+	return d.validate(curve)
+}
+
+func (c *Ciphertext) validate(curve *curves.Curve) error {
+	if c == nil || c.C1 == nil || c.C2 == nil {
+		return internal.ErrNilArguments
+	}
+	if c.C1.CurveName() != curve.Name || c.C2.CurveName() != curve.Name {
+		return fmt.Errorf("ciphertext is not on curve %s", curve.Name)
+	}
+	if !c.C1.IsOnCurve() || !c.C2.IsOnCurve() {
+		return fmt.Errorf("ciphertext is not on curve %s", curve.Name)
+	}
+	return nil
+}
+
+func (pk *PublicKey) curve() (*curves.Curve, error) {
+	if pk == nil || pk.Y == nil {
+		return nil, internal.ErrNilArguments
+	}
+	curve := curves.GetCurveByName(pk.Y.CurveName())
+	if curve == nil {
+		return nil, fmt.Errorf("unsupported curve %s", pk.Y.CurveName())
+	}
+	return curve, nil
+}
@@ -0,0 +1,146 @@
+package elgamal
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+)
+
+var testCurves = []*curves.Curve{
+	curves.K256(),
+	curves.P256(),
+	curves.ED25519(),
+	curves.BLS12381G1(),
+	curves.PALLAS(),
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	for _, curve := range testCurves {
+		pk, sk, err := NewKeys(curve)
+		require.NoError(t, err)
+		msg := curve.Point.Random(rand.Reader)
+		c, r, err := pk.Encrypt(msg)
+		require.NoError(t, err)
+		require.True(t, c.C1.Equal(curve.ScalarBaseMult(r)))
+		got, err := sk.Decrypt(c)
+		require.NoError(t, err)
+		require.True(t, msg.Equal(got), curve.Name)
+
+		c2, err := pk.EncryptWithNonce(msg, r)
+		require.NoError(t, err)
+		require.True(t, c.C1.Equal(c2.C1))
+		require.True(t, c.C2.Equal(c2.C2))
+	}
+}
+
+func TestEncryptWrongCurve(t *testing.T) {
+	pk, sk, err := NewKeys(curves.K256())
+	require.NoError(t, err)
+	p256 := curves.P256()
+	_, _, err = pk.Encrypt(p256.Point.Random(rand.Reader))
+	require.Error(t, err)
+	other, _, err := NewKeys(p256)
+	require.NoError(t, err)
+	c, _, err := other.Encrypt(p256.Point.Random(rand.Reader))
+	require.NoError(t, err)
+	_, err = sk.Decrypt(c)
+	require.Error(t, err)
+	_, err = NewSecretKey(curves.K256().Scalar.Zero())
+	require.Error(t, err)
+}
+
+func TestHomomorphic(t *testing.T) {
+	for _, curve := range testCurves {
+		pk, sk, err := NewKeys(curve)
+		require.NoError(t, err)
+		a, _, err := pk.EncryptScalar(curve.Scalar.New(20))
+		require.NoError(t, err)
+		b, _, err := pk.EncryptScalar(curve.Scalar.New(22))
+		require.NoError(t, err)
+
+		sum, err := a.Add(b)
+		require.NoError(t, err)
+		m, err := sk.DecryptScalar(sum, 1000)
+		require.NoError(t, err)
+		require.Equal(t, uint64(42), m)
+
+		diff, err := b.Sub(a)
+		require.NoError(t, err)
+		m, err = sk.DecryptScalar(diff, 1000)
+		require.NoError(t, err)
+		require.Equal(t, uint64(2), m)
+
+		prod, err := sum.ScalarMul(curve.Scalar.New(3))
+		require.NoError(t, err)
+		m, err = sk.DecryptScalar(prod, 1000)
+		require.NoError(t, err)
+		require.Equal(t, uint64(126), m)
+
+		// out of range
+		_, err = sk.DecryptScalar(prod, 126)
+		require.Error(t, err)
+
+		_, err = a.ScalarMul(curves.ED25519().Scalar.New(2))
+		if curve.Name != curves.ED25519Name {
+			require.Error(t, err)
+		}
+	}
+}
+
+func TestDiscreteLog(t *testing.T) {
+	curve := curves.K256()
+	for _, bound := range []uint64{1, 2, 10, 100, 1 << 16} {
+		for _, m := range []uint64{0, bound / 2, bound - 1} {
+			got, err := DiscreteLog(curve, curve.ScalarBaseMult(curve.Scalar.New(int(m))), bound)
+			require.NoError(t, err)
+			require.Equal(t, m, got)
+		}
+		_, err := DiscreteLog(curve, curve.ScalarBaseMult(curve.Scalar.New(int(bound))), bound)
+		require.Error(t, err)
+	}
+	_, err := DiscreteLog(curve, curve.NewGeneratorPoint(), 0)
+	require.Error(t, err)
+}
+
+func TestRerandomize(t *testing.T) {
+	for _, curve := range testCurves {
+		pk, sk, err := NewKeys(curve)
+		require.NoError(t, err)
+		msg := curve.Point.Random(rand.Reader)
+		c, _, err := pk.Encrypt(msg)
+		require.NoError(t, err)
+		d, _, err := pk.Rerandomize(c)
+		require.NoError(t, err)
+		require.False(t, c.C1.Equal(d.C1))
+		require.False(t, c.C2.Equal(d.C2))
+		got, err := sk.Decrypt(d)
+		require.NoError(t, err)
+		require.True(t, msg.Equal(got))
+	}
+}
+
+func TestDecryptionProof(t *testing.T) {
+	sid := []byte("session")
+	for _, curve := range testCurves {
+		pk, sk, err := NewKeys(curve)
+		require.NoError(t, err)
+		msg := curve.Point.Random(rand.Reader)
+		c, _, err := pk.Encrypt(msg)
+		require.NoError(t, err)
+		got, proof, err := sk.ProveDecryption(c, sid)
+		require.NoError(t, err)
+		require.True(t, msg.Equal(got))
+		require.NoError(t, pk.VerifyDecryption(c, got, proof, sid))
+
+		require.Error(t, pk.VerifyDecryption(c, got, proof, []byte("other")))
+		require.Error(t, pk.VerifyDecryption(c, got.Add(curve.NewGeneratorPoint()), proof, sid))
+		other, _, err := NewKeys(curve)
+		require.NoError(t, err)
+		require.Error(t, other.VerifyDecryption(c, got, proof, sid))
+		bad := &DecryptionProof{C: proof.C, S: proof.S.Add(curve.Scalar.One())}
+		require.Error(t, pk.VerifyDecryption(c, got, bad, sid))
+	}
+}
@@ -0,0 +1,83 @@
+package elgamal
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/internal"
+)
+
+// DecryptionProof is a Chaum-Pedersen proof that M is the decryption of a ciphertext, i.e. that
+// log_G(Y) = log_C1(C2 - M), which lets anyone check a decryption without learning the secret key.
+type DecryptionProof struct {
+	C curves.Scalar
+	S curves.Scalar
+}
+
+// ProveDecryption decrypts c and proves that the result is correct. uniqueSessionId binds the proof to its context.
+func (sk *SecretKey) ProveDecryption(c *Ciphertext, uniqueSessionId []byte) (curves.Point, *DecryptionProof, error) {
+	msg, err := sk.Decrypt(c)
+	if err != nil {
+		return nil, nil, err
+	}
+	curve, _ := sk.curve()
+	// D = xC1, commitments A = kG and B = kC1
+	d := c.C2.Sub(msg)
+	k := curve.Scalar.Random(rand.Reader)
+	a := curve.ScalarBaseMult(k)
+	b := c.C1.Mul(k)
+	e, err := decryptionChallenge(curve, uniqueSessionId, sk.Y, c.C1, c.C2, d, a, b)
+	if err != nil {
+		return nil, nil, err
+	}
+	return msg, &DecryptionProof{C: e, S: e.Mul(sk.x).Add(k)}, nil
+}
+
+// VerifyDecryption checks that msg is the decryption of c under the public key.
+func (pk *PublicKey) VerifyDecryption(c *Ciphertext, msg curves.Point, proof *DecryptionProof, uniqueSessionId []byte) error {
+	curve, err := pk.curve()
+	if err != nil {
+		return err
+	}
+	if err = c.validate(curve); err != nil {
+		return err
+	}
+	if msg == nil || proof == nil || proof.C == nil || proof.S == nil {
+		return internal.ErrNilArguments
+	}
+	if msg.CurveName() != curve.Name {
+		return fmt.Errorf("message is not on curve %s", curve.Name)
+	}
+	if proof.C.Point().CurveName() != curve.Name || proof.S.Point().CurveName() != curve.Name {
+		return fmt.Errorf("proof is not on curve %s", curve.Name)
+	}
+	// A = sG - eY, B = sC1 - eD
+	d := c.C2.Sub(msg)
+	negE := proof.C.Neg()
+	a := curve.ScalarBaseMult(proof.S).Add(pk.Y.Mul(negE))
+	b := c.C1.Mul(proof.S).Add(d.Mul(negE))
+	e, err := decryptionChallenge(curve, uniqueSessionId, pk.Y, c.C1, c.C2, d, a, b)
+	if err != nil {
+		return err
+	}
+	if e.Cmp(proof.C) != 0 {
+		return fmt.Errorf("decryption proof verification failed")
+	}
+	return nil
+}
+
+func decryptionChallenge(curve *curves.Curve, uniqueSessionId []byte, points ...curves.Point) (curves.Scalar, error) {
+	hash := sha3.New256()
+	if _, err := hash.Write(uniqueSessionId); err != nil {
+		return nil, err
+	}
+	for _, p := range points {
+		if _, err := hash.Write(p.ToAffineCompressed()); err != nil {
+			return nil, err
+		}
+	}
+	return curve.Scalar.Hash(hash.Sum(nil)), nil
+}
@@ -0,0 +1,225 @@
+// Package envelope implements envelope encryption: payloads are encrypted
+// under a random data encryption key (DEK), and the DEK is wrapped with HPKE
+// of RFC 9180 to any number of recipients, which may be did:key identifiers.
+//
+// An Envelope is the header holding the wrapped DEKs. It commits to the DEK,
+// so every recipient decrypts the same payload, and it can be rewrapped to new
+// recipients without touching the payload. Payloads are encrypted in chunks
+// with the STREAM construction of Hoang, Reyhanitabar, Rogaway and Vizár,
+// "Online Authenticated-Encryption and its Nonce-Reuse Misuse-Resistance",
+// so large payloads never have to be held in memory and chunks cannot be
+// reordered, dropped or truncated.
+package envelope
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/go-sonr/crypto/aead"
+	"github.com/go-sonr/crypto/core/secret"
+	"github.com/go-sonr/crypto/hpke"
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/keyexchange"
+)
+
+const (
+	// Version is the envelope format version
+	Version = 1
+	// DEKSize is the size of a data encryption key
+	DEKSize = 32
+	// DefaultChunkSize is the plaintext size of a payload chunk
+	DefaultChunkSize = 64 * 1024
+	// MaxChunkSize bounds the chunk size accepted when opening an envelope
+	MaxChunkSize = 16 * 1024 * 1024
+
+	saltSize = 32
+	wrapInfo = "sonr-envelope-v1 wrap"
+)
+
+// DEK is a data encryption key
+type DEK struct {
+	key [DEKSize]byte
+}
+
+// GenerateDEK draws a DEK from reader
+func GenerateDEK(reader io.Reader) (*DEK, error) {
+	if reader == nil {
+		return nil, internal.ErrNilArguments
+	}
+	dek := new(DEK)
+	if _, err := io.ReadFull(reader, dek.key[:]); err != nil {
+		return nil, err
+	}
+	return dek, nil
+}
+
+// Zeroize overwrites the key with zeros
+func (d *DEK) Zeroize() {
+	secret.Wipe(d.key[:])
+}
+
+// derive expands the DEK into length bytes bound to the envelope salt and label
+func (d *DEK) derive(salt []byte, label string, alg aead.Algorithm, chunkSize, length int) ([]byte, error) {
+	info := fmt.Appendf(nil, "sonr-envelope-v1 %s %d %d", label, alg, chunkSize)
+	return keyexchange.HKDF(sha256.New, d.key[:], salt, info, length)
+}
+
+// WrappedKey is a DEK encrypted to one recipient
+type WrappedKey struct {
+	// KeyID is the KeyID of the recipient
+	KeyID string `json:"kid"`
+	// KEM is the HPKE KEM of the recipient
+	KEM hpke.KEM `json:"kem"`
+	// Enc is the HPKE encapsulated key
+	Enc []byte `json:"enc"`
+	// Ciphertext is the sealed DEK
+	Ciphertext []byte `json:"ct"`
+}
+
+// Envelope is the header of an encrypted payload
+type Envelope struct {
+	Version   int            `json:"v"`
+	Algorithm aead.Algorithm `json:"alg"`
+	ChunkSize int            `json:"chunk"`
+	// Salt makes the payload key unique to the envelope
+	Salt []byte `json:"salt"`
+	// Commitment binds the envelope to a single DEK
+	Commitment []byte       `json:"commit"`
+	Recipients []WrappedKey `json:"recipients"`
+}
+
+// New returns an envelope for a payload encrypted with alg under dek, with
+// dek wrapped to every recipient
+func New(dek *DEK, alg aead.Algorithm, recipients []Recipient, reader io.Reader) (*Envelope, error) {
+	if dek == nil || reader == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if alg.KeySize() == 0 {
+		return nil, fmt.Errorf("unsupported AEAD %s", alg)
+	}
+	e := &Envelope{Version: Version, Algorithm: alg, ChunkSize: DefaultChunkSize, Salt: make([]byte, saltSize)}
+	if _, err := io.ReadFull(reader, e.Salt); err != nil {
+		return nil, err
+	}
+	commitment, err := dek.derive(e.Salt, "commit", alg, e.ChunkSize, sha256.Size)
+	if err != nil {
+		return nil, err
+	}
+	e.Commitment = commitment
+	if err := e.Rotate(dek, recipients, reader); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// AddRecipient wraps dek to r, replacing any key already wrapped to r
+func (e *Envelope) AddRecipient(dek *DEK, r Recipient, reader io.Reader) error {
+	if err := e.checkDEK(dek); err != nil {
+		return err
+	}
+	enc, ct, err := r.suite().Seal(r.pk, []byte(wrapInfo), e.Commitment, dek.key[:], reader)
+	if err != nil {
+		return err
+	}
+	e.RemoveRecipient(r.KeyID())
+	e.Recipients = append(e.Recipients, WrappedKey{KeyID: r.KeyID(), KEM: r.kem, Enc: enc, Ciphertext: ct})
+	return nil
+}
+
+// RemoveRecipient drops the key wrapped to the recipient with keyID and
+// reports whether there was one. The recipient keeps the ability to decrypt
+// copies of the envelope it already holds.
+func (e *Envelope) RemoveRecipient(keyID string) bool {
+	for i, w := range e.Recipients {
+		if w.KeyID == keyID {
+			e.Recipients = append(e.Recipients[:i], e.Recipients[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Rotate replaces every wrapped key with dek wrapped to recipients, the
+// payload is unchanged
+func (e *Envelope) Rotate(dek *DEK, recipients []Recipient, reader io.Reader) error {
+	if len(recipients) == 0 {
+		return fmt.Errorf("an envelope needs at least one recipient")
+	}
+	e.Recipients = nil
+	for _, r := range recipients {
+		if err := e.AddRecipient(dek, r, reader); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Unwrap returns the DEK wrapped to id
+func (e *Envelope) Unwrap(id *Identity) (*DEK, error) {
+	keyID := id.recipient.KeyID()
+	for _, w := range e.Recipients {
+		if w.KeyID != keyID || w.KEM != id.recipient.kem {
+			continue
+		}
+		key, err := id.recipient.suite().Open(w.Enc, id.sk, []byte(wrapInfo), e.Commitment, w.Ciphertext)
+		if err != nil {
+			return nil, err
+		}
+		defer secret.Wipe(key)
+		if len(key) != DEKSize {
+			return nil, fmt.Errorf("invalid wrapped key")
+		}
+		dek := new(DEK)
+		copy(dek.key[:], key)
+		if err := e.checkDEK(dek); err != nil {
+			return nil, err
+		}
+		return dek, nil
+	}
+	return nil, fmt.Errorf("no key is wrapped to %s", keyID)
+}
+
+// checkDEK verifies the commitment of the envelope to dek
+func (e *Envelope) checkDEK(dek *DEK) error {
+	if dek == nil {
+		return internal.ErrNilArguments
+	}
+	commitment, err := dek.derive(e.Salt, "commit", e.Algorithm, e.ChunkSize, sha256.Size)
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare(commitment, e.Commitment) != 1 {
+		return fmt.Errorf("the key does not match the envelope commitment")
+	}
+	return nil
+}
+
+// MarshalBinary encodes the envelope as JSON
+func (e *Envelope) MarshalBinary() ([]byte, error) {
+	return json.Marshal(e)
+}
+
+// UnmarshalBinary decodes an envelope of MarshalBinary
+func (e *Envelope) UnmarshalBinary(data []byte) error {
+	var v Envelope
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.Version != Version {
+		return fmt.Errorf("unsupported envelope version %d", v.Version)
+	}
+	if v.Algorithm.KeySize() == 0 {
+		return fmt.Errorf("unsupported AEAD %s", v.Algorithm)
+	}
+	if v.ChunkSize <= 0 || v.ChunkSize > MaxChunkSize {
+		return fmt.Errorf("invalid chunk size %d", v.ChunkSize)
+	}
+	if len(v.Salt) != saltSize || len(v.Commitment) != sha256.Size {
+		return fmt.Errorf("invalid envelope")
+	}
+	*e = v
+	return nil
+}
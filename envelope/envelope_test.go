@@ -0,0 +1,202 @@
+package envelope
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	crand "crypto/rand"
+	"io"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/aead"
+	"github.com/go-sonr/crypto/keys/parsers"
+)
+
+func x25519Identity(t *testing.T) *Identity {
+	sk, err := ecdh.X25519().GenerateKey(crand.Reader)
+	require.NoError(t, err)
+	id, err := NewX25519Identity(sk.Bytes())
+	require.NoError(t, err)
+	return id
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	alice := x25519Identity(t)
+	bob := x25519Identity(t)
+	eve := x25519Identity(t)
+
+	for _, size := range []int{0, 1, DefaultChunkSize - 1, DefaultChunkSize, 3*DefaultChunkSize + 17} {
+		payload := make([]byte, size)
+		_, _ = crand.Read(payload)
+
+		var buf bytes.Buffer
+		w, err := Encrypt(&buf, []Recipient{alice.Recipient(), bob.Recipient()})
+		require.NoError(t, err)
+		_, err = w.Write(payload)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+
+		for _, id := range []*Identity{alice, bob} {
+			r, e, err := Decrypt(bytes.NewReader(buf.Bytes()), id)
+			require.NoError(t, err)
+			require.Len(t, e.Recipients, 2)
+			got, err := io.ReadAll(r)
+			require.NoError(t, err)
+			require.True(t, bytes.Equal(payload, got))
+		}
+		_, _, err = Decrypt(bytes.NewReader(buf.Bytes()), eve)
+		require.Error(t, err)
+	}
+}
+
+func TestStreamTampering(t *testing.T) {
+	id := x25519Identity(t)
+	dek, err := GenerateDEK(crand.Reader)
+	require.NoError(t, err)
+	for _, alg := range []aead.Algorithm{aead.AES256GCMSIV, aead.XChaCha20Poly1305} {
+		e, err := New(dek, alg, []Recipient{id.Recipient()}, crand.Reader)
+		require.NoError(t, err)
+		e.ChunkSize = 64
+		e.Commitment, err = dek.derive(e.Salt, "commit", alg, e.ChunkSize, 32)
+		require.NoError(t, err)
+
+		payload := make([]byte, 200)
+		_, _ = crand.Read(payload)
+		var buf bytes.Buffer
+		w, err := e.NewWriter(&buf, dek)
+		require.NoError(t, err)
+		for i := 0; i < len(payload); i += 7 {
+			_, err = w.Write(payload[i:min(i+7, len(payload))])
+			require.NoError(t, err)
+		}
+		require.NoError(t, w.Close())
+		ct := buf.Bytes()
+		chunk := e.ChunkSize + 16
+
+		read := func(data []byte) ([]byte, error) {
+			r, err := e.NewReader(bytes.NewReader(data), dek)
+			require.NoError(t, err)
+			return io.ReadAll(r)
+		}
+		got, err := read(ct)
+		require.NoError(t, err)
+		require.Equal(t, payload, got)
+
+		// truncated at a chunk boundary
+		_, err = read(ct[:3*chunk])
+		require.Error(t, err)
+		// a dropped final chunk
+		_, err = read(ct[:len(ct)-1])
+		require.Error(t, err)
+		// swapped chunks
+		swapped := append(append(append([]byte{}, ct[chunk:2*chunk]...), ct[:chunk]...), ct[2*chunk:]...)
+		_, err = read(swapped)
+		require.Error(t, err)
+		// trailing data
+		_, err = read(append(append([]byte{}, ct...), 0))
+		require.Error(t, err)
+	}
+}
+
+func TestRotate(t *testing.T) {
+	alice := x25519Identity(t)
+	bob := x25519Identity(t)
+	dek, err := GenerateDEK(crand.Reader)
+	require.NoError(t, err)
+	e, err := New(dek, aead.AES256GCMSIV, []Recipient{alice.Recipient()}, crand.Reader)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	w, err := e.NewWriter(&buf, dek)
+	require.NoError(t, err)
+	_, _ = w.Write([]byte("rotated payload"))
+	require.NoError(t, w.Close())
+
+	// alice rotates the wrapping key to bob without touching the payload
+	unwrapped, err := e.Unwrap(alice)
+	require.NoError(t, err)
+	require.NoError(t, e.Rotate(unwrapped, []Recipient{bob.Recipient()}, crand.Reader))
+	_, err = e.Unwrap(alice)
+	require.Error(t, err)
+
+	header, err := e.MarshalBinary()
+	require.NoError(t, err)
+	parsed := new(Envelope)
+	require.NoError(t, parsed.UnmarshalBinary(header))
+	bobDEK, err := parsed.Unwrap(bob)
+	require.NoError(t, err)
+	r, err := parsed.NewReader(bytes.NewReader(buf.Bytes()), bobDEK)
+	require.NoError(t, err)
+	got, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, []byte("rotated payload"), got)
+
+	require.NoError(t, parsed.AddRecipient(bobDEK, alice.Recipient(), crand.Reader))
+	require.NoError(t, parsed.AddRecipient(bobDEK, alice.Recipient(), crand.Reader))
+	require.Len(t, parsed.Recipients, 2)
+	require.True(t, parsed.RemoveRecipient(alice.Recipient().KeyID()))
+	require.False(t, parsed.RemoveRecipient(alice.Recipient().KeyID()))
+
+	// a different DEK fails the commitment
+	other, err := GenerateDEK(crand.Reader)
+	require.NoError(t, err)
+	require.Error(t, parsed.AddRecipient(other, alice.Recipient(), crand.Reader))
+	_, err = parsed.NewReader(bytes.NewReader(buf.Bytes()), other)
+	require.Error(t, err)
+	require.Error(t, parsed.Rotate(bobDEK, nil, crand.Reader))
+}
+
+func TestDIDRecipients(t *testing.T) {
+	edPriv, edPub, err := crypto.GenerateEd25519Key(crand.Reader)
+	require.NoError(t, err)
+	edDID, err := parsers.NewKeyDID(edPub)
+	require.NoError(t, err)
+	edRecipient, err := RecipientFromDID(edDID)
+	require.NoError(t, err)
+	raw, err := edPriv.Raw()
+	require.NoError(t, err)
+	edIdentity, err := NewEd25519Identity(ed25519.PrivateKey(raw))
+	require.NoError(t, err)
+	require.Equal(t, edRecipient.KeyID(), edIdentity.Recipient().KeyID())
+
+	ecPriv, err := ecdsa.GenerateKey(elliptic.P256(), crand.Reader)
+	require.NoError(t, err)
+	ecPub, err := crypto.ECDSAPublicKeyFromPubKey(ecPriv.PublicKey)
+	require.NoError(t, err)
+	ecDID, err := parsers.NewKeyDID(ecPub)
+	require.NoError(t, err)
+	parsed, err := parsers.Parse(ecDID.String())
+	require.NoError(t, err)
+	ecRecipient, err := RecipientFromDID(parsed)
+	require.NoError(t, err)
+	ecdhPriv, err := ecPriv.ECDH()
+	require.NoError(t, err)
+	ecIdentity, err := NewP256Identity(ecdhPriv.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, ecRecipient.KeyID(), ecIdentity.Recipient().KeyID())
+
+	var buf bytes.Buffer
+	w, err := Encrypt(&buf, []Recipient{edRecipient, ecRecipient})
+	require.NoError(t, err)
+	_, _ = w.Write([]byte("to did:key"))
+	require.NoError(t, w.Close())
+	for _, id := range []*Identity{edIdentity, ecIdentity} {
+		r, _, err := Decrypt(bytes.NewReader(buf.Bytes()), id)
+		require.NoError(t, err)
+		got, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, []byte("to did:key"), got)
+	}
+
+	_, secpPub, err := crypto.GenerateSecp256k1Key(crand.Reader)
+	require.NoError(t, err)
+	secpDID, err := parsers.NewKeyDID(secpPub)
+	require.NoError(t, err)
+	_, err = RecipientFromDID(secpDID)
+	require.Error(t, err)
+}
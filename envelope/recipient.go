@@ -0,0 +1,138 @@
+package envelope
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+
+	"github.com/go-sonr/crypto/core/secret"
+	"github.com/go-sonr/crypto/hpke"
+	"github.com/go-sonr/crypto/keyexchange"
+	"github.com/go-sonr/crypto/keys/parsers"
+)
+
+// Recipient is a public key a DEK can be wrapped to with HPKE
+type Recipient struct {
+	kem hpke.KEM
+	pk  []byte
+}
+
+// NewX25519Recipient returns a recipient for an X25519 public key
+func NewX25519Recipient(pub []byte) (Recipient, error) {
+	if _, err := ecdh.X25519().NewPublicKey(pub); err != nil {
+		return Recipient{}, fmt.Errorf("invalid X25519 public key: %w", err)
+	}
+	return Recipient{kem: hpke.KEMX25519HKDFSHA256, pk: append([]byte{}, pub...)}, nil
+}
+
+// NewP256Recipient returns a recipient for an uncompressed P-256 public key
+func NewP256Recipient(pub []byte) (Recipient, error) {
+	if _, err := ecdh.P256().NewPublicKey(pub); err != nil {
+		return Recipient{}, fmt.Errorf("invalid P-256 public key: %w", err)
+	}
+	return Recipient{kem: hpke.KEMP256HKDFSHA256, pk: append([]byte{}, pub...)}, nil
+}
+
+// RecipientFromDID returns the recipient of a did:key. Ed25519 keys are
+// mapped to X25519, P-256 keys are used directly.
+func RecipientFromDID(id parsers.DIDKey) (Recipient, error) {
+	if id.PubKey == nil {
+		return Recipient{}, fmt.Errorf("did:key has no public key")
+	}
+	switch id.Type() {
+	case crypto.Ed25519:
+		raw, err := id.Raw()
+		if err != nil {
+			return Recipient{}, err
+		}
+		pub, err := parsers.Ed25519ToX25519PublicKey(raw)
+		if err != nil {
+			return Recipient{}, err
+		}
+		return NewX25519Recipient(pub)
+	case crypto.ECDSA:
+		vk, err := id.VerifyKey()
+		if err != nil {
+			return Recipient{}, err
+		}
+		pub := vk.(*ecdsa.PublicKey)
+		if pub.Curve != elliptic.P256() {
+			return Recipient{}, fmt.Errorf("unsupported curve for encryption: %s", pub.Curve.Params().Name)
+		}
+		return NewP256Recipient(elliptic.Marshal(pub.Curve, pub.X, pub.Y))
+	default:
+		return Recipient{}, fmt.Errorf("unsupported key type for encryption: %s", id.Type())
+	}
+}
+
+// KeyID identifies the recipient in an envelope, the first 16 bytes of
+// SHA-256(kem || pk) in hex
+func (r Recipient) KeyID() string {
+	h := sha256.New()
+	_, _ = h.Write([]byte{byte(r.kem >> 8), byte(r.kem)})
+	_, _ = h.Write(r.pk)
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
+
+func (r Recipient) suite() hpke.Suite {
+	return hpke.Suite{KEM: r.kem, KDF: hpke.KDFHKDFSHA256, AEAD: hpke.AEADChaCha20Poly1305}
+}
+
+// Identity is a private key that unwraps the DEKs of its recipient
+type Identity struct {
+	recipient Recipient
+	sk        []byte
+}
+
+// NewX25519Identity returns the identity of an X25519 private key
+func NewX25519Identity(sk []byte) (*Identity, error) {
+	key, err := keyexchange.X25519.NewPrivateKey(sk)
+	if err != nil {
+		return nil, err
+	}
+	return &Identity{
+		recipient: Recipient{kem: hpke.KEMX25519HKDFSHA256, pk: key.PublicKey()},
+		sk:        key.Bytes(),
+	}, nil
+}
+
+// NewEd25519Identity returns the identity of the X25519 key of an Ed25519
+// private key, the recipient of RecipientFromDID for its did:key
+func NewEd25519Identity(priv ed25519.PrivateKey) (*Identity, error) {
+	if len(priv) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("invalid Ed25519 private key length: %d", len(priv))
+	}
+	// the X25519 scalar is the clamped first half of SHA-512(seed) as in RFC 8032
+	h := sha512.Sum512(priv.Seed())
+	defer secret.Wipe(h[:])
+	return NewX25519Identity(h[:32])
+}
+
+// NewP256Identity returns the identity of a P-256 private key
+func NewP256Identity(sk []byte) (*Identity, error) {
+	key, err := ecdh.P256().NewPrivateKey(sk)
+	if err != nil {
+		return nil, fmt.Errorf("invalid P-256 private key: %w", err)
+	}
+	return &Identity{
+		recipient: Recipient{kem: hpke.KEMP256HKDFSHA256, pk: key.PublicKey().Bytes()},
+		sk:        append([]byte{}, sk...),
+	}, nil
+}
+
+// Recipient returns the recipient of the identity
+func (i *Identity) Recipient() Recipient {
+	return i.recipient
+}
+
+// Zeroize overwrites the private key with zeros
+func (i *Identity) Zeroize() {
+	secret.Wipe(i.sk)
+}
@@ -0,0 +1,247 @@
+package envelope
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/go-sonr/crypto/aead"
+	"github.com/go-sonr/crypto/core/secret"
+)
+
+// DefaultAlgorithm is the payload AEAD of Encrypt
+const DefaultAlgorithm = aead.XChaCha20Poly1305
+
+// maxHeaderSize bounds the encoded envelope read by Decrypt
+const maxHeaderSize = 1 << 20
+
+// stream is the chunk AEAD of STREAM, chunk i is sealed under the nonce
+// 0 || i || last with i a 32 bit big endian counter and last a final chunk
+// flag. The payload key is unique to the envelope so nonces never repeat.
+type stream struct {
+	aead    cipher.AEAD
+	nonce   []byte
+	counter uint64
+}
+
+func (e *Envelope) stream(dek *DEK) (*stream, error) {
+	if err := e.checkDEK(dek); err != nil {
+		return nil, err
+	}
+	key, err := dek.derive(e.Salt, "payload", e.Algorithm, e.ChunkSize, e.Algorithm.KeySize())
+	if err != nil {
+		return nil, err
+	}
+	defer secret.Wipe(key)
+	a, err := aead.New(e.Algorithm, key)
+	if err != nil {
+		return nil, err
+	}
+	return &stream{aead: a, nonce: make([]byte, a.NonceSize())}, nil
+}
+
+func (s *stream) next(last bool) ([]byte, error) {
+	if s.counter > math.MaxUint32 {
+		return nil, fmt.Errorf("payload has too many chunks")
+	}
+	n := len(s.nonce)
+	binary.BigEndian.PutUint32(s.nonce[n-5:n-1], uint32(s.counter))
+	s.nonce[n-1] = 0
+	if last {
+		s.nonce[n-1] = 1
+	}
+	s.counter++
+	return s.nonce, nil
+}
+
+type writer struct {
+	dst    io.Writer
+	stream *stream
+	buf    []byte
+	size   int
+	closed bool
+}
+
+// NewWriter returns a writer encrypting the payload of the envelope to dst.
+// Close must be called to write the final chunk.
+func (e *Envelope) NewWriter(dst io.Writer, dek *DEK) (io.WriteCloser, error) {
+	s, err := e.stream(dek)
+	if err != nil {
+		return nil, err
+	}
+	return &writer{dst: dst, stream: s, buf: make([]byte, 0, e.ChunkSize+s.aead.Overhead()), size: e.ChunkSize}, nil
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, fmt.Errorf("write to closed envelope writer")
+	}
+	written := 0
+	for len(p) > 0 {
+		// a full chunk is only sealed once more data arrives, the final chunk
+		// is shorter than the chunk size
+		if len(w.buf) == w.size {
+			if err := w.flush(false); err != nil {
+				return written, err
+			}
+		}
+		n := copy(w.buf[len(w.buf):w.size], p)
+		w.buf = w.buf[:len(w.buf)+n]
+		p = p[n:]
+		written += n
+	}
+	return written, nil
+}
+
+func (w *writer) flush(last bool) error {
+	nonce, err := w.stream.next(last)
+	if err != nil {
+		return err
+	}
+	chunk := w.stream.aead.Seal(w.buf[:0], nonce, w.buf, nil)
+	if _, err := w.dst.Write(chunk); err != nil {
+		return err
+	}
+	w.buf = w.buf[:0]
+	return nil
+}
+
+// Close seals the final chunk, it does not close the destination
+func (w *writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	if len(w.buf) == w.size {
+		if err := w.flush(false); err != nil {
+			return err
+		}
+	}
+	return w.flush(true)
+}
+
+type reader struct {
+	src    io.Reader
+	stream *stream
+	buf    []byte
+	chunk  []byte
+	size   int
+	done   bool
+	err    error
+}
+
+// NewReader returns a reader decrypting the payload of the envelope from
+// src. Reads fail if the payload was modified, reordered or truncated.
+func (e *Envelope) NewReader(src io.Reader, dek *DEK) (io.Reader, error) {
+	s, err := e.stream(dek)
+	if err != nil {
+		return nil, err
+	}
+	size := e.ChunkSize + s.aead.Overhead()
+	return &reader{src: src, stream: s, buf: make([]byte, size), size: size}, nil
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	for len(r.chunk) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		if r.done {
+			return 0, io.EOF
+		}
+		r.err = r.readChunk()
+	}
+	n := copy(p, r.chunk)
+	r.chunk = r.chunk[n:]
+	return n, nil
+}
+
+func (r *reader) readChunk() error {
+	n, err := io.ReadFull(r.src, r.buf)
+	last := false
+	switch {
+	case errors.Is(err, io.EOF):
+		return fmt.Errorf("envelope payload is truncated")
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		last = true
+	case err != nil:
+		return err
+	}
+	nonce, err := r.stream.next(last)
+	if err != nil {
+		return err
+	}
+	chunk, err := r.stream.aead.Open(r.buf[:0], nonce, r.buf[:n], nil)
+	if err != nil {
+		return fmt.Errorf("envelope payload chunk %d: %w", r.stream.counter-1, err)
+	}
+	if last {
+		var extra [1]byte
+		if m, _ := r.src.Read(extra[:]); m > 0 {
+			return fmt.Errorf("trailing data after the envelope payload")
+		}
+		r.done = true
+	}
+	r.chunk = chunk
+	return nil
+}
+
+// Encrypt writes a new envelope for recipients followed by the payload written
+// to the returned writer, encrypted with DefaultAlgorithm. Close must be called
+// to write the final chunk.
+func Encrypt(dst io.Writer, recipients []Recipient) (io.WriteCloser, error) {
+	dek, err := GenerateDEK(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	defer dek.Zeroize()
+	e, err := New(dek, DefaultAlgorithm, recipients, rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	header, err := e.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	var size [4]byte
+	binary.BigEndian.PutUint32(size[:], uint32(len(header)))
+	if _, err := dst.Write(append(size[:], header...)); err != nil {
+		return nil, err
+	}
+	return e.NewWriter(dst, dek)
+}
+
+// Decrypt reads an envelope of Encrypt from src, unwraps its DEK with id and
+// returns a reader of the payload
+func Decrypt(src io.Reader, id *Identity) (io.Reader, *Envelope, error) {
+	var size [4]byte
+	if _, err := io.ReadFull(src, size[:]); err != nil {
+		return nil, nil, err
+	}
+	n := binary.BigEndian.Uint32(size[:])
+	if n > maxHeaderSize {
+		return nil, nil, fmt.Errorf("envelope header is too large")
+	}
+	header := make([]byte, n)
+	if _, err := io.ReadFull(src, header); err != nil {
+		return nil, nil, err
+	}
+	e := new(Envelope)
+	if err := e.UnmarshalBinary(header); err != nil {
+		return nil, nil, err
+	}
+	dek, err := e.Unwrap(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer dek.Zeroize()
+	r, err := e.NewReader(src, dek)
+	if err != nil {
+		return nil, nil, err
+	}
+	return r, e, nil
+}
@@ -0,0 +1,74 @@
+// Package eth provides the Ethereum flavour of secp256k1 ECDSA on the
+// curve of core/curves: recoverable [R || S || V] signatures over Keccak-256
+// hashes, public key recovery, account addresses and EIP-155 and EIP-191
+// signature conventions.
+package eth
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/go-sonr/crypto/core/curves"
+)
+
+// Address is an Ethereum account address
+type Address [20]byte
+
+// Keccak256 is the original Keccak-256 of Ethereum, not SHA3-256
+func Keccak256(data ...[]byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
+
+// AddressFromPublicKey returns the address of a secp256k1 public key,
+// the last 20 bytes of the Keccak-256 of its uncompressed coordinates
+func AddressFromPublicKey(pub curves.Point) (Address, error) {
+	if pub == nil || pub.CurveName() != curves.K256Name {
+		return Address{}, fmt.Errorf("public key is not a secp256k1 point")
+	}
+	if pub.IsIdentity() {
+		return Address{}, fmt.Errorf("public key cannot be the identity")
+	}
+	var a Address
+	copy(a[:], Keccak256(pub.ToAffineUncompressed()[1:])[12:])
+	return a, nil
+}
+
+// ParseAddress decodes a 0x prefixed hex address. Mixed case addresses
+// must carry a valid EIP-55 checksum.
+func ParseAddress(s string) (Address, error) {
+	var a Address
+	if !strings.HasPrefix(s, "0x") || len(s) != 42 {
+		return a, fmt.Errorf("address must be 0x followed by 40 hex digits")
+	}
+	if _, err := hex.Decode(a[:], []byte(s[2:])); err != nil {
+		return a, fmt.Errorf("invalid address: %w", err)
+	}
+	digits := s[2:]
+	if digits != strings.ToLower(digits) && digits != strings.ToUpper(digits) && a.String() != s {
+		return a, fmt.Errorf("invalid EIP-55 address checksum")
+	}
+	return a, nil
+}
+
+// String returns the EIP-55 mixed case checksum encoding
+func (a Address) String() string {
+	digits := []byte(hex.EncodeToString(a[:]))
+	hash := Keccak256(digits)
+	for i, c := range digits {
+		nibble := hash[i/2] >> 4
+		if i%2 == 1 {
+			nibble = hash[i/2] & 0x0f
+		}
+		if c >= 'a' && nibble >= 8 {
+			digits[i] = c - 'a' + 'A'
+		}
+	}
+	return "0x" + string(digits)
+}
@@ -0,0 +1,130 @@
+package eth
+
+import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddressChecksum(t *testing.T) {
+	// EIP-55 test vectors
+	for _, s := range []string{
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+		"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+	} {
+		a, err := ParseAddress(s)
+		require.NoError(t, err)
+		require.Equal(t, s, a.String())
+	}
+	_, err := ParseAddress("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed")
+	require.NoError(t, err)
+	_, err = ParseAddress("0x5AAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	require.Error(t, err)
+	_, err = ParseAddress("5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	require.Error(t, err)
+}
+
+func TestEcrecover(t *testing.T) {
+	// the go-ethereum crypto test vector
+	hash, _ := hex.DecodeString("ce0677bb30baa8cf067c88db9811f4333d131bf8bcf12fe7065d211dce971008")
+	sig, _ := hex.DecodeString("90f27b8b488db00b00606796d2987f6a5f59ae62ea05effe84fef5b8b0e549984a691139ad57a3f0b906637673aa2f63d1f55cb1a69199d4009eea23ceaddc9301")
+	pub, err := Ecrecover(hash, sig)
+	require.NoError(t, err)
+	require.Equal(t, "04e32df42865e97135acfb65f3bae71bdc86f4d49150ad6a440b6f15878109880a0a2b2667f7e725ceea70c673093bf67663e0312623c8e091b13cf2c0f11ef652", hex.EncodeToString(pub))
+
+	point, err := SigToPub(hash, sig)
+	require.NoError(t, err)
+	require.True(t, VerifySignature(point, hash, sig[:64]))
+	require.True(t, VerifySignature(point, hash, sig))
+	hash[0] ^= 1
+	require.False(t, VerifySignature(point, hash, sig[:64]))
+
+	bad := append([]byte{}, sig...)
+	bad[64] = 4
+	_, err = Ecrecover(hash, bad)
+	require.Error(t, err)
+	_, err = Ecrecover(hash, sig[:64])
+	require.Error(t, err)
+}
+
+func TestSignEthereum(t *testing.T) {
+	secret := k256.Scalar.Random(crand.Reader)
+	pub := k256.ScalarBaseMult(secret)
+	addr, err := AddressFromPublicKey(pub)
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		hash := Keccak256([]byte{byte(i)})
+		sig, err := SignEthereum(hash, secret)
+		require.NoError(t, err)
+		require.LessOrEqual(t, sig[64], byte(1))
+		require.LessOrEqual(t, new(big.Int).SetBytes(sig[32:64]).Cmp(halfOrder), 0)
+		got, err := RecoverAddress(hash, sig)
+		require.NoError(t, err)
+		require.Equal(t, addr, got)
+		require.True(t, VerifySignature(pub, hash, sig))
+
+		// the high S twin recovers the same key but does not verify
+		high := append([]byte{}, sig...)
+		new(big.Int).Sub(k256Order, new(big.Int).SetBytes(sig[32:64])).FillBytes(high[32:64])
+		high[64] ^= 1
+		got, err = RecoverAddress(hash, high)
+		require.NoError(t, err)
+		require.Equal(t, addr, got)
+		require.False(t, VerifySignature(pub, hash, high))
+	}
+	_, err = SignEthereum([]byte("short"), secret)
+	require.Error(t, err)
+}
+
+func TestPersonalSign(t *testing.T) {
+	// web3.eth.accounts.sign("Some data", key) from the web3.js documentation
+	hash := HashPersonalMessage([]byte("Some data"))
+	require.Equal(t, "1da44b586eb0729ff70a73c326926f6ed5a25f5b056e7f47fbc6e58d86871655", hex.EncodeToString(hash))
+	sig, _ := hex.DecodeString("b91467e570a6466aa9e9876cbcd013baba02900b8979d43fe208a4a4f339f5fd6007e74cd82e037b800186422fc2da167c747ef045e5d18a5f5d4300f8e1a0291c")
+	addr, err := RecoverPersonal([]byte("Some data"), sig)
+	require.NoError(t, err)
+	require.Equal(t, "0x2c7536E3605D9C16a7a3D7b1898e529396a65c23", addr.String())
+
+	key, _ := hex.DecodeString("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	secret, err := k256.Scalar.SetBytes(key)
+	require.NoError(t, err)
+	pub, err := AddressFromPublicKey(k256.ScalarBaseMult(secret))
+	require.NoError(t, err)
+	require.Equal(t, addr, pub)
+
+	signed, err := SignPersonal(secret, []byte("Some data"))
+	require.NoError(t, err)
+	require.Contains(t, []byte{27, 28}, signed[64])
+	got, err := RecoverPersonal([]byte("Some data"), signed)
+	require.NoError(t, err)
+	require.Equal(t, addr, got)
+}
+
+func TestEIP155(t *testing.T) {
+	require.Equal(t, int64(37), EIP155V(0, 1).Int64())
+	require.Equal(t, int64(38), EIP155V(1, 1).Int64())
+
+	for _, chainID := range []uint64{1, 5, 137, 1 << 62} {
+		for recID := byte(0); recID < 2; recID++ {
+			v := EIP155V(recID, chainID)
+			got, err := RecoveryID(v, chainID)
+			require.NoError(t, err)
+			require.Equal(t, recID, got)
+			id, ok := ChainIDFromV(v)
+			require.True(t, ok)
+			require.Equal(t, chainID, id)
+		}
+	}
+	_, err := RecoveryID(EIP155V(0, 1), 5)
+	require.Error(t, err)
+	recID, err := RecoveryID(big.NewInt(28), 1)
+	require.NoError(t, err)
+	require.Equal(t, byte(1), recID)
+	_, ok := ChainIDFromV(big.NewInt(27))
+	require.False(t, ok)
+}
@@ -0,0 +1,234 @@
+package eth
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/signatures/ecdsa"
+	"github.com/go-sonr/crypto/signatures/nonce"
+)
+
+// SignatureSize is the size of a recoverable signature, R || S || V
+const SignatureSize = 65
+
+var (
+	k256      = curves.K256()
+	k256Order = curves.K256Curve().Params().N
+	k256Prime = curves.K256Curve().Params().P
+	halfOrder = new(big.Int).Rsh(k256Order, 1)
+)
+
+// SignEthereum returns the recoverable signature R || S || V of a 32 byte
+// hash by a secp256k1 secret key, with a hedged RFC 6979 nonce. S is in the
+// lower half of the order, as EIP-2 requires, and V is the recovery id 0 or
+// 1.
+func SignEthereum(hash []byte, secret curves.Scalar) ([]byte, error) {
+	return SignEthereumWith(hash, secret, nonce.Default())
+}
+
+// SignEthereumWith is SignEthereum with nonces of nonces, such as the
+// deterministic nonces of RFC 6979 for reproducible signatures
+func SignEthereumWith(hash []byte, secret curves.Scalar, nonces nonce.Generator) ([]byte, error) {
+	if secret == nil || len(hash) != 32 {
+		return nil, internal.ErrNilArguments
+	}
+	if secret.IsZero() {
+		return nil, internal.ErrZeroValue
+	}
+	d, err := k256.Scalar.SetBigInt(secret.BigInt())
+	if err != nil {
+		return nil, err
+	}
+	defer curves.Zeroize(d)
+	sig, err := ecdsa.SignWith(k256, d, hash, nonces)
+	if err != nil {
+		return nil, err
+	}
+	recID := byte(sig.V)
+	if sig.S.Cmp(halfOrder) > 0 {
+		sig.S.Sub(k256Order, sig.S)
+		recID ^= 1
+	}
+	out := make([]byte, SignatureSize)
+	sig.R.FillBytes(out[:32])
+	sig.S.FillBytes(out[32:64])
+	out[64] = recID
+	return out, nil
+}
+
+// SigToPub returns the secp256k1 public key that produced a recoverable
+// signature over a 32 byte hash. V is the recovery id, 0 to 3.
+func SigToPub(hash, sig []byte) (curves.Point, error) {
+	if len(hash) != 32 {
+		return nil, fmt.Errorf("hash must be 32 bytes")
+	}
+	if len(sig) != SignatureSize {
+		return nil, fmt.Errorf("signature must be %d bytes", SignatureSize)
+	}
+	v := sig[64]
+	if v > 3 {
+		return nil, fmt.Errorf("invalid recovery id %d", v)
+	}
+	rInt := new(big.Int).SetBytes(sig[:32])
+	sInt := new(big.Int).SetBytes(sig[32:64])
+	if rInt.Sign() == 0 || sInt.Sign() == 0 || rInt.Cmp(k256Order) >= 0 || sInt.Cmp(k256Order) >= 0 {
+		return nil, fmt.Errorf("signature values out of range")
+	}
+
+	x := new(big.Int).Set(rInt)
+	if v&2 != 0 {
+		x.Add(x, k256Order)
+	}
+	if x.Cmp(k256Prime) >= 0 {
+		return nil, fmt.Errorf("invalid recovery id %d", v)
+	}
+	compressed := make([]byte, 33)
+	compressed[0] = 2 | v&1
+	x.FillBytes(compressed[1:])
+	R, err := k256.Point.FromAffineCompressed(compressed)
+	if err != nil {
+		return nil, fmt.Errorf("signature has no curve point: %w", err)
+	}
+
+	r, err := k256.Scalar.SetBigInt(rInt)
+	if err != nil {
+		return nil, err
+	}
+	s, err := k256.Scalar.SetBigInt(sInt)
+	if err != nil {
+		return nil, err
+	}
+	e, err := k256.Scalar.SetBigInt(new(big.Int).SetBytes(hash))
+	if err != nil {
+		return nil, err
+	}
+	rInv, err := r.Invert()
+	if err != nil {
+		return nil, err
+	}
+	// Q = r^-1 (s R - e G)
+	Q := k256.Point.SumOfProducts([]curves.Point{R, k256.Point.Generator()}, []curves.Scalar{s.Mul(rInv), e.Mul(rInv).Neg()})
+	if Q.IsIdentity() {
+		return nil, fmt.Errorf("recovered the identity")
+	}
+	return Q, nil
+}
+
+// Ecrecover returns the 65 byte uncompressed public key that produced a
+// recoverable signature over a 32 byte hash
+func Ecrecover(hash, sig []byte) ([]byte, error) {
+	pub, err := SigToPub(hash, sig)
+	if err != nil {
+		return nil, err
+	}
+	return pub.ToAffineUncompressed(), nil
+}
+
+// RecoverAddress returns the address of the account that signed a 32 byte
+// hash
+func RecoverAddress(hash, sig []byte) (Address, error) {
+	pub, err := SigToPub(hash, sig)
+	if err != nil {
+		return Address{}, err
+	}
+	return AddressFromPublicKey(pub)
+}
+
+// VerifySignature checks a 64 byte R || S signature, or a recoverable one
+// whose V is ignored, of a 32 byte hash against a public key. High S values
+// are rejected as malleable.
+func VerifySignature(pub curves.Point, hash, sig []byte) bool {
+	if len(sig) == SignatureSize {
+		sig = sig[:64]
+	}
+	if pub == nil || len(sig) != 64 || len(hash) != 32 {
+		return false
+	}
+	if new(big.Int).SetBytes(sig[32:]).Cmp(halfOrder) > 0 {
+		return false
+	}
+	for v := byte(0); v < 4; v++ {
+		q, err := SigToPub(hash, append(sig[:64:64], v))
+		if err == nil && q.Equal(pub) {
+			return true
+		}
+	}
+	return false
+}
+
+// EIP155V is the V of a transaction signature on chain chainID with
+// recovery id recID, recID + 35 + 2 chainID
+func EIP155V(recID byte, chainID uint64) *big.Int {
+	v := new(big.Int).SetUint64(chainID)
+	v.Lsh(v, 1)
+	return v.Add(v, big.NewInt(int64(recID)+35))
+}
+
+// RecoveryID returns the recovery id of the V of a signature. V may be the
+// recovery id itself, 27 or 28 of legacy signatures, or an EIP-155 V for
+// chain chainID.
+func RecoveryID(v *big.Int, chainID uint64) (byte, error) {
+	if v == nil || v.Sign() < 0 {
+		return 0, internal.ErrNilArguments
+	}
+	if v.IsUint64() {
+		switch u := v.Uint64(); u {
+		case 0, 1:
+			return byte(u), nil
+		case 27, 28:
+			return byte(u - 27), nil
+		}
+	}
+	recID := new(big.Int).Sub(v, EIP155V(0, chainID))
+	if !recID.IsInt64() || recID.Int64() < 0 || recID.Int64() > 1 {
+		return 0, fmt.Errorf("V %s is not for chain %d", v, chainID)
+	}
+	return byte(recID.Int64()), nil
+}
+
+// ChainIDFromV returns the chain of an EIP-155 V, false for legacy V
+func ChainIDFromV(v *big.Int) (uint64, bool) {
+	if v == nil || v.Cmp(big.NewInt(35)) < 0 {
+		return 0, false
+	}
+	chainID := new(big.Int).Sub(v, big.NewInt(35))
+	chainID.Rsh(chainID, 1)
+	if !chainID.IsUint64() {
+		return 0, false
+	}
+	return chainID.Uint64(), true
+}
+
+// HashPersonalMessage is the EIP-191 version 0x45 hash of a message that
+// personal_sign signs
+func HashPersonalMessage(msg []byte) []byte {
+	prefix := "\x19Ethereum Signed Message:\n" + strconv.Itoa(len(msg))
+	return Keccak256([]byte(prefix), msg)
+}
+
+// SignPersonal signs the EIP-191 hash of msg, returning R || S || V with V
+// 27 or 28 as wallets do
+func SignPersonal(secret curves.Scalar, msg []byte) ([]byte, error) {
+	sig, err := SignEthereum(HashPersonalMessage(msg), secret)
+	if err != nil {
+		return nil, err
+	}
+	sig[64] += 27
+	return sig, nil
+}
+
+// RecoverPersonal returns the address of the account that personal_sign
+// signed msg with. V may be 0, 1, 27 or 28.
+func RecoverPersonal(msg, sig []byte) (Address, error) {
+	if len(sig) != SignatureSize {
+		return Address{}, fmt.Errorf("signature must be %d bytes", SignatureSize)
+	}
+	sig = append([]byte{}, sig...)
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+	return RecoverAddress(HashPersonalMessage(msg), sig)
+}
@@ -0,0 +1,151 @@
+package gc
+
+import (
+	"fmt"
+)
+
+// Builder assembles a circuit gate by gate. Wires are numbered as they are
+// created, after the input wires.
+type Builder struct {
+	c *Circuit
+}
+
+// NewBuilder starts a circuit of the given numbers of input bits
+func NewBuilder(garblerInputs, evaluatorInputs int) *Builder {
+	return &Builder{c: &Circuit{
+		Wires:           garblerInputs + evaluatorInputs,
+		GarblerInputs:   garblerInputs,
+		EvaluatorInputs: evaluatorInputs,
+	}}
+}
+
+// GarblerInput returns the wire of bit i of the garbler's input
+func (b *Builder) GarblerInput(i int) int {
+	return i
+}
+
+// EvaluatorInput returns the wire of bit i of the evaluator's input
+func (b *Builder) EvaluatorInput(i int) int {
+	return b.c.GarblerInputs + i
+}
+
+// XOR returns the wire x ⊕ y
+func (b *Builder) XOR(x, y int) int {
+	return b.gate(Gate{Op: XOR, A: x, B: y})
+}
+
+// AND returns the wire x ∧ y
+func (b *Builder) AND(x, y int) int {
+	return b.gate(Gate{Op: AND, A: x, B: y})
+}
+
+// INV returns the wire ¬x
+func (b *Builder) INV(x int) int {
+	return b.gate(Gate{Op: INV, A: x})
+}
+
+// OR returns the wire x ∨ y, as x ⊕ y ⊕ (x ∧ y)
+func (b *Builder) OR(x, y int) int {
+	return b.XOR(b.XOR(x, y), b.AND(x, y))
+}
+
+// Output appends wires to the outputs of the circuit
+func (b *Builder) Output(wires ...int) {
+	b.c.Outputs = append(b.c.Outputs, wires...)
+}
+
+// Circuit returns the circuit built
+func (b *Builder) Circuit() (*Circuit, error) {
+	if err := b.c.Validate(); err != nil {
+		return nil, err
+	}
+	return b.c, nil
+}
+
+func (b *Builder) gate(g Gate) int {
+	g.Out = b.c.Wires
+	b.c.Wires++
+	b.c.Gates = append(b.c.Gates, g)
+	return g.Out
+}
+
+// majority returns the wire maj(x, y, z) with a single AND gate
+func (b *Builder) majority(x, y, z int) int {
+	return b.XOR(z, b.AND(b.XOR(x, z), b.XOR(y, z)))
+}
+
+// GreaterThan returns the circuit with the single output x > y, for the
+// unsigned integers x of the garbler and y of the evaluator of n bits, least
+// significant first. It has n AND gates.
+func GreaterThan(n int) (*Circuit, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("invalid bit length %d", n)
+	}
+	// x > y is the carry out of x + ¬y
+	b := NewBuilder(n, n)
+	carry := b.AND(b.GarblerInput(0), b.INV(b.EvaluatorInput(0)))
+	for i := 1; i < n; i++ {
+		carry = b.majority(b.GarblerInput(i), b.INV(b.EvaluatorInput(i)), carry)
+	}
+	b.Output(carry)
+	return b.Circuit()
+}
+
+// Equal returns the circuit with the single output x = y, for the inputs x
+// of the garbler and y of the evaluator of n bits. It has n-1 AND gates.
+func Equal(n int) (*Circuit, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("invalid bit length %d", n)
+	}
+	b := NewBuilder(n, n)
+	eq := b.INV(b.XOR(b.GarblerInput(0), b.EvaluatorInput(0)))
+	for i := 1; i < n; i++ {
+		eq = b.AND(eq, b.INV(b.XOR(b.GarblerInput(i), b.EvaluatorInput(i))))
+	}
+	b.Output(eq)
+	return b.Circuit()
+}
+
+// Add returns the circuit with the n bit output x + y mod 2^n, for the
+// unsigned integers x of the garbler and y of the evaluator of n bits, least
+// significant first. It has n-1 AND gates.
+func Add(n int) (*Circuit, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("invalid bit length %d", n)
+	}
+	b := NewBuilder(n, n)
+	sum := make([]int, n)
+	sum[0] = b.XOR(b.GarblerInput(0), b.EvaluatorInput(0))
+	if n > 1 {
+		carry := b.AND(b.GarblerInput(0), b.EvaluatorInput(0))
+		for i := 1; i < n; i++ {
+			x, y := b.GarblerInput(i), b.EvaluatorInput(i)
+			sum[i] = b.XOR(b.XOR(x, y), carry)
+			if i < n-1 {
+				carry = b.majority(x, y, carry)
+			}
+		}
+	}
+	b.Output(sum...)
+	return b.Circuit()
+}
+
+// Bits returns the n bits of x, least significant first, as circuit input
+func Bits(x uint64, n int) []bool {
+	bits := make([]bool, n)
+	for i := range bits {
+		bits[i] = i < 64 && x>>uint(i)&1 == 1
+	}
+	return bits
+}
+
+// Uint returns the integer of bits, least significant first
+func Uint(bits []bool) uint64 {
+	var x uint64
+	for i, b := range bits {
+		if b && i < 64 {
+			x |= 1 << uint(i)
+		}
+	}
+	return x
+}
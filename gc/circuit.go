@@ -0,0 +1,279 @@
+// Package gc evaluates boolean circuits between two parties with Yao's
+// garbled circuits, secure against semi-honest adversaries.
+//
+// The garbler encrypts the circuit gate by gate under random wire labels,
+// with the free-XOR technique of Kolesnikov and Schneider, so XOR and NOT
+// gates cost nothing, and the half-gates of Zahur, Rosulek and Evans,
+// https://eprint.iacr.org/2014/756.pdf, so each AND gate costs two labels.
+// The evaluator obtains the labels of its input bits by oblivious transfer
+// from the ot package, without the garbler learning the bits, evaluates the
+// circuit on them and decodes the output, which it returns to the garbler.
+//
+// Circuits are read from the Bristol Fashion format of SCALE-MAMBA, or built
+// with a Builder, which has ready circuits for comparisons, equality and
+// addition of unsigned integers. Garbler and Evaluator follow the
+// protocol.Iterator pattern and run over a protocol.Runner like the DKLs18
+// protocols.
+package gc
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/go-sonr/crypto/internal"
+)
+
+// MaxWires is the largest number of wires of a circuit
+const MaxWires = 1 << 26
+
+// Op is the operation of a gate
+type Op int
+
+const (
+	// XOR is the exclusive or of two wires
+	XOR Op = iota
+	// AND is the conjunction of two wires
+	AND
+	// INV is the negation of a wire
+	INV
+	// EQW copies a wire
+	EQW
+)
+
+// String returns the Bristol Fashion name of the operation
+func (o Op) String() string {
+	switch o {
+	case XOR:
+		return "XOR"
+	case AND:
+		return "AND"
+	case INV:
+		return "INV"
+	case EQW:
+		return "EQW"
+	default:
+		return fmt.Sprintf("Op(%d)", int(o))
+	}
+}
+
+// Gate computes wire Out from wire A, and wire B for binary operations
+type Gate struct {
+	Op  Op
+	A   int
+	B   int
+	Out int
+}
+
+// Circuit is a boolean circuit of two inputs. The wires of the garbler's
+// input come first, then those of the evaluator's input, and gates are in
+// topological order.
+type Circuit struct {
+	Wires           int
+	GarblerInputs   int
+	EvaluatorInputs int
+	Gates           []Gate
+	Outputs         []int
+}
+
+// Validate checks every gate reads wires already computed and every wire is
+// computed once
+func (c *Circuit) Validate() error {
+	if c == nil {
+		return internal.ErrNilArguments
+	}
+	if c.Wires <= 0 || c.Wires > MaxWires {
+		return fmt.Errorf("invalid number of wires %d", c.Wires)
+	}
+	inputs := c.GarblerInputs + c.EvaluatorInputs
+	if c.GarblerInputs < 0 || c.EvaluatorInputs < 0 || inputs > c.Wires {
+		return fmt.Errorf("invalid number of input wires")
+	}
+	if len(c.Outputs) == 0 {
+		return fmt.Errorf("circuit has no outputs")
+	}
+	set := make([]bool, c.Wires)
+	for i := 0; i < inputs; i++ {
+		set[i] = true
+	}
+	valid := func(w int) bool { return w >= 0 && w < c.Wires && set[w] }
+	for i, g := range c.Gates {
+		switch g.Op {
+		case XOR, AND:
+			if !valid(g.A) || !valid(g.B) {
+				return fmt.Errorf("gate %d reads an unset wire", i)
+			}
+		case INV, EQW:
+			if !valid(g.A) {
+				return fmt.Errorf("gate %d reads an unset wire", i)
+			}
+		default:
+			return fmt.Errorf("gate %d has unknown operation %v", i, g.Op)
+		}
+		if g.Out < 0 || g.Out >= c.Wires || set[g.Out] {
+			return fmt.Errorf("gate %d writes an invalid wire %d", i, g.Out)
+		}
+		set[g.Out] = true
+	}
+	for _, w := range c.Outputs {
+		if !valid(w) {
+			return fmt.Errorf("output wire %d is not set", w)
+		}
+	}
+	return nil
+}
+
+// ANDs returns the number of AND gates, which the size of the garbled
+// circuit is proportional to
+func (c *Circuit) ANDs() int {
+	n := 0
+	for _, g := range c.Gates {
+		if g.Op == AND {
+			n++
+		}
+	}
+	return n
+}
+
+// Eval evaluates the circuit in the clear
+func (c *Circuit) Eval(garbler, evaluator []bool) ([]bool, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	if len(garbler) != c.GarblerInputs || len(evaluator) != c.EvaluatorInputs {
+		return nil, fmt.Errorf("circuit takes %d and %d input bits", c.GarblerInputs, c.EvaluatorInputs)
+	}
+	wires := make([]bool, c.Wires)
+	copy(wires, garbler)
+	copy(wires[c.GarblerInputs:], evaluator)
+	for _, g := range c.Gates {
+		switch g.Op {
+		case XOR:
+			wires[g.Out] = wires[g.A] != wires[g.B]
+		case AND:
+			wires[g.Out] = wires[g.A] && wires[g.B]
+		case INV:
+			wires[g.Out] = !wires[g.A]
+		case EQW:
+			wires[g.Out] = wires[g.A]
+		}
+	}
+	out := make([]bool, len(c.Outputs))
+	for i, w := range c.Outputs {
+		out[i] = wires[w]
+	}
+	return out, nil
+}
+
+// ParseBristol reads a circuit in Bristol Fashion,
+// https://homes.esat.kuleuven.be/~nsmart/MPC/, with two input values, the
+// garbler's then the evaluator's. Its output wires are the last wires.
+func ParseBristol(r io.Reader) (*Circuit, error) {
+	if r == nil {
+		return nil, internal.ErrNilArguments
+	}
+	scanner := bufio.NewScanner(r)
+	var lines [][]string
+	for scanner.Scan() {
+		if fields := strings.Fields(scanner.Text()); len(fields) > 0 {
+			lines = append(lines, fields)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(lines) < 3 {
+		return nil, fmt.Errorf("bristol: missing header")
+	}
+	header, err := atoi(lines[0])
+	if err != nil || len(header) != 2 {
+		return nil, fmt.Errorf("bristol: invalid header")
+	}
+	inputs, err := atoi(lines[1])
+	if err != nil || len(inputs) != 3 || inputs[0] != 2 {
+		return nil, fmt.Errorf("bristol: need two input values")
+	}
+	outputs, err := atoi(lines[2])
+	if err != nil || len(outputs) < 2 || outputs[0] != len(outputs)-1 {
+		return nil, fmt.Errorf("bristol: invalid outputs")
+	}
+	gates, wires := header[0], header[1]
+	if wires > MaxWires {
+		return nil, fmt.Errorf("bristol: too many wires")
+	}
+	if len(lines)-3 != gates {
+		return nil, fmt.Errorf("bristol: %d gates declared, %d found", gates, len(lines)-3)
+	}
+	c := &Circuit{Wires: wires, GarblerInputs: inputs[1], EvaluatorInputs: inputs[2], Gates: make([]Gate, 0, gates)}
+	total := 0
+	for _, n := range outputs[1:] {
+		total += n
+	}
+	if total <= 0 || total > wires {
+		return nil, fmt.Errorf("bristol: invalid outputs")
+	}
+	for w := wires - total; w < wires; w++ {
+		c.Outputs = append(c.Outputs, w)
+	}
+	for i, line := range lines[3:] {
+		g, err := parseGate(line)
+		if err != nil {
+			return nil, fmt.Errorf("bristol: gate %d: %w", i, err)
+		}
+		c.Gates = append(c.Gates, g)
+	}
+	if err = c.Validate(); err != nil {
+		return nil, fmt.Errorf("bristol: %w", err)
+	}
+	return c, nil
+}
+
+func parseGate(fields []string) (Gate, error) {
+	if len(fields) < 4 {
+		return Gate{}, fmt.Errorf("too few fields")
+	}
+	op := fields[len(fields)-1]
+	nums, err := atoi(fields[:len(fields)-1])
+	if err != nil {
+		return Gate{}, err
+	}
+	switch op {
+	case "XOR", "AND":
+		if len(nums) != 5 || nums[0] != 2 || nums[1] != 1 {
+			return Gate{}, fmt.Errorf("%s takes two inputs and one output", op)
+		}
+		g := Gate{Op: XOR, A: nums[2], B: nums[3], Out: nums[4]}
+		if op == "AND" {
+			g.Op = AND
+		}
+		return g, nil
+	case "INV", "EQW":
+		if len(nums) != 4 || nums[0] != 1 || nums[1] != 1 {
+			return Gate{}, fmt.Errorf("%s takes one input and one output", op)
+		}
+		g := Gate{Op: INV, A: nums[2], Out: nums[3]}
+		if op == "EQW" {
+			g.Op = EQW
+		}
+		return g, nil
+	default:
+		return Gate{}, fmt.Errorf("unsupported operation %s", op)
+	}
+}
+
+func atoi(fields []string) ([]int, error) {
+	nums := make([]int, len(fields))
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, fmt.Errorf("negative number %d", n)
+		}
+		nums[i] = n
+	}
+	return nums, nil
+}
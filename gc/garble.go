@@ -0,0 +1,195 @@
+package gc
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/go-sonr/crypto/internal"
+)
+
+// LabelSize is the size of a wire label
+const LabelSize = 16
+
+// Label is the key standing for a value of a wire
+type Label [LabelSize]byte
+
+// GarbledCircuit is the garbled tables of the AND gates of a circuit, in
+// order, and the decoding bits of its outputs
+type GarbledCircuit struct {
+	Tables   [][2]Label
+	Decoding []bool
+}
+
+// Garbling is a garbled circuit with the secrets of the garbler: the global
+// offset Δ and the labels of value 0 of the input wires. The label of value
+// 1 of a wire is the label of value 0 xor Δ.
+type Garbling struct {
+	*GarbledCircuit
+	circuit *Circuit
+	delta   Label
+	inputs  []Label
+}
+
+// Garble garbles the circuit with randomness from reader
+func Garble(c *Circuit, reader io.Reader) (*Garbling, error) {
+	if reader == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	g := &Garbling{
+		GarbledCircuit: &GarbledCircuit{Tables: make([][2]Label, 0, c.ANDs())},
+		circuit:        c,
+		inputs:         make([]Label, c.GarblerInputs+c.EvaluatorInputs),
+	}
+	if _, err := io.ReadFull(reader, g.delta[:]); err != nil {
+		return nil, err
+	}
+	// the point-and-permute bit of the labels of a wire differ
+	g.delta[0] |= 1
+	wires := make([]Label, c.Wires)
+	for i := range g.inputs {
+		if _, err := io.ReadFull(reader, g.inputs[i][:]); err != nil {
+			return nil, err
+		}
+		wires[i] = g.inputs[i]
+	}
+
+	for _, gate := range c.Gates {
+		switch gate.Op {
+		case XOR:
+			wires[gate.Out] = xor(wires[gate.A], wires[gate.B])
+		case INV:
+			wires[gate.Out] = xor(wires[gate.A], g.delta)
+		case EQW:
+			wires[gate.Out] = wires[gate.A]
+		case AND:
+			j := uint64(len(g.Tables)) << 1
+			a0, b0 := wires[gate.A], wires[gate.B]
+			a1, b1 := xor(a0, g.delta), xor(b0, g.delta)
+			pa, pb := a0[0]&1, b0[0]&1
+
+			// generator half gate
+			ha0, ha1 := hash(a0, j), hash(a1, j)
+			tg := xor(ha0, ha1)
+			tg = xor(tg, mask(pb, g.delta))
+			wg := xor(ha0, mask(pa, tg))
+
+			// evaluator half gate
+			hb0, hb1 := hash(b0, j+1), hash(b1, j+1)
+			te := xor(xor(hb0, hb1), a0)
+			we := xor(hb0, mask(pb, xor(te, a0)))
+
+			wires[gate.Out] = xor(wg, we)
+			g.Tables = append(g.Tables, [2]Label{tg, te})
+		}
+	}
+	g.Decoding = make([]bool, len(c.Outputs))
+	for i, w := range c.Outputs {
+		g.Decoding[i] = wires[w][0]&1 == 1
+	}
+	return g, nil
+}
+
+// GarblerLabels returns the labels of the garbler's input bits, which
+// reveal nothing of them to the evaluator
+func (g *Garbling) GarblerLabels(bits []bool) ([]Label, error) {
+	if len(bits) != g.circuit.GarblerInputs {
+		return nil, fmt.Errorf("circuit takes %d garbler input bits", g.circuit.GarblerInputs)
+	}
+	labels := make([]Label, len(bits))
+	for i, b := range bits {
+		labels[i] = xor(g.inputs[i], mask(boolToBit(b), g.delta))
+	}
+	return labels, nil
+}
+
+// EvaluatorLabels returns the labels of values 0 and 1 of each input wire of
+// the evaluator, which it obtains by oblivious transfer
+func (g *Garbling) EvaluatorLabels() [][2]Label {
+	pairs := make([][2]Label, g.circuit.EvaluatorInputs)
+	for i := range pairs {
+		zero := g.inputs[g.circuit.GarblerInputs+i]
+		pairs[i] = [2]Label{zero, xor(zero, g.delta)}
+	}
+	return pairs
+}
+
+// Evaluate evaluates the garbled circuit of c on the labels of the inputs of
+// the garbler and the evaluator and decodes its output
+func Evaluate(c *Circuit, gc *GarbledCircuit, garbler, evaluator []Label) ([]bool, error) {
+	if gc == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	if len(garbler) != c.GarblerInputs || len(evaluator) != c.EvaluatorInputs {
+		return nil, fmt.Errorf("circuit takes %d and %d input labels", c.GarblerInputs, c.EvaluatorInputs)
+	}
+	if len(gc.Tables) != c.ANDs() || len(gc.Decoding) != len(c.Outputs) {
+		return nil, fmt.Errorf("garbled circuit does not match the circuit")
+	}
+	wires := make([]Label, c.Wires)
+	copy(wires, garbler)
+	copy(wires[c.GarblerInputs:], evaluator)
+	k := 0
+	for _, gate := range c.Gates {
+		switch gate.Op {
+		case XOR:
+			wires[gate.Out] = xor(wires[gate.A], wires[gate.B])
+		case INV, EQW:
+			wires[gate.Out] = wires[gate.A]
+		case AND:
+			j := uint64(k) << 1
+			a, b := wires[gate.A], wires[gate.B]
+			tg, te := gc.Tables[k][0], gc.Tables[k][1]
+			wg := xor(hash(a, j), mask(a[0]&1, tg))
+			we := xor(hash(b, j+1), mask(b[0]&1, xor(te, a)))
+			wires[gate.Out] = xor(wg, we)
+			k++
+		}
+	}
+	out := make([]bool, len(c.Outputs))
+	for i, w := range c.Outputs {
+		out[i] = (wires[w][0]&1 == 1) != gc.Decoding[i]
+	}
+	return out, nil
+}
+
+// hash is the tweakable hash H(label, tweak) of the half gates
+func hash(l Label, tweak uint64) Label {
+	var buf [LabelSize + 8]byte
+	copy(buf[:], l[:])
+	binary.BigEndian.PutUint64(buf[LabelSize:], tweak)
+	sum := sha256.Sum256(buf[:])
+	var out Label
+	copy(out[:], sum[:])
+	return out
+}
+
+func xor(a, b Label) Label {
+	for i := range a {
+		a[i] ^= b[i]
+	}
+	return a
+}
+
+// mask returns l if bit is 1 and the zero label if it is 0
+func mask(bit byte, l Label) Label {
+	m := -(bit & 1)
+	for i := range l {
+		l[i] &= m
+	}
+	return l
+}
+
+func boolToBit(b bool) byte {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,188 @@
+package gc
+
+import (
+	"context"
+	crand "crypto/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/protocol"
+)
+
+func garbleAndEvaluate(t *testing.T, c *Circuit, x, y []bool) []bool {
+	g, err := Garble(c, crand.Reader)
+	require.NoError(t, err)
+	garblerLabels, err := g.GarblerLabels(x)
+	require.NoError(t, err)
+	pairs := g.EvaluatorLabels()
+	evaluatorLabels := make([]Label, len(y))
+	for i, b := range y {
+		evaluatorLabels[i] = pairs[i][boolToBit(b)]
+	}
+	out, err := Evaluate(c, g.GarbledCircuit, garblerLabels, evaluatorLabels)
+	require.NoError(t, err)
+	return out
+}
+
+func TestCircuits(t *testing.T) {
+	const n = 8
+	gt, err := GreaterThan(n)
+	require.NoError(t, err)
+	require.Equal(t, n, gt.ANDs())
+	eq, err := Equal(n)
+	require.NoError(t, err)
+	add, err := Add(n)
+	require.NoError(t, err)
+
+	for _, v := range [][2]uint64{{0, 0}, {1, 0}, {0, 1}, {200, 199}, {17, 17}, {255, 255}, {128, 127}, {3, 250}} {
+		x, y := Bits(v[0], n), Bits(v[1], n)
+
+		out, err := gt.Eval(x, y)
+		require.NoError(t, err)
+		require.Equal(t, []bool{v[0] > v[1]}, out)
+		require.Equal(t, out, garbleAndEvaluate(t, gt, x, y))
+
+		out, err = eq.Eval(x, y)
+		require.NoError(t, err)
+		require.Equal(t, []bool{v[0] == v[1]}, out)
+		require.Equal(t, out, garbleAndEvaluate(t, eq, x, y))
+
+		out, err = add.Eval(x, y)
+		require.NoError(t, err)
+		require.Equal(t, (v[0]+v[1])%256, Uint(out))
+		require.Equal(t, out, garbleAndEvaluate(t, add, x, y))
+	}
+}
+
+func TestParseBristol(t *testing.T) {
+	// (x0 ∧ y0) ⊕ ¬x1, and x1 copied
+	src := `4 7
+2 2 1
+1 2
+
+2 1 0 2 3 AND
+1 1 1 4 INV
+2 1 3 4 5 XOR
+1 1 1 6 EQW
+`
+	c, err := ParseBristol(strings.NewReader(src))
+	require.NoError(t, err)
+	require.Equal(t, 2, c.GarblerInputs)
+	require.Equal(t, 1, c.EvaluatorInputs)
+	require.Equal(t, []int{5, 6}, c.Outputs)
+	for _, v := range [][3]bool{{false, false, false}, {true, false, true}, {true, true, true}, {false, true, true}} {
+		x, y := []bool{v[0], v[1]}, []bool{v[2]}
+		out, err := c.Eval(x, y)
+		require.NoError(t, err)
+		require.Equal(t, []bool{(v[0] && v[2]) != !v[1], v[1]}, out)
+		require.Equal(t, out, garbleAndEvaluate(t, c, x, y))
+	}
+
+	for _, bad := range []string{
+		"",
+		"1 3\n2 1 1\n1 1\n2 1 0 1 2 NAND\n",
+		"1 3\n2 1 1\n1 1\n2 1 0 2 2 AND\n",
+		"2 3\n2 1 1\n1 1\n2 1 0 1 2 AND\n",
+		"1 3\n3 1 1 1\n1 1\n2 1 0 1 2 AND\n",
+	} {
+		_, err = ParseBristol(strings.NewReader(bad))
+		require.Error(t, err)
+	}
+}
+
+func TestEvaluateRejectsMismatch(t *testing.T) {
+	c, err := Equal(4)
+	require.NoError(t, err)
+	g, err := Garble(c, crand.Reader)
+	require.NoError(t, err)
+	labels, err := g.GarblerLabels(Bits(3, 4))
+	require.NoError(t, err)
+	pairs := g.EvaluatorLabels()
+	evaluator := []Label{pairs[0][1], pairs[1][1], pairs[2][0], pairs[3][0]}
+
+	_, err = Evaluate(c, g.GarbledCircuit, labels[:3], evaluator)
+	require.Error(t, err)
+	_, err = Evaluate(c, &GarbledCircuit{Tables: g.Tables[1:], Decoding: g.Decoding}, labels, evaluator)
+	require.Error(t, err)
+	_, err = g.GarblerLabels(Bits(3, 5))
+	require.Error(t, err)
+}
+
+func runProtocol(t *testing.T, c *Circuit, x, y []bool) ([]bool, []bool) {
+	sid := []byte("gc test session")
+	garbler, err := NewGarbler(sid, c, x)
+	require.NoError(t, err)
+	evaluator, err := NewEvaluator(sid, c, y)
+	require.NoError(t, err)
+
+	a, b := protocol.Pipe("garbler", "evaluator")
+	ctx := context.Background()
+	errs := make(chan error, 1)
+	go func() {
+		runner := &protocol.Runner{Self: "garbler", Peer: "evaluator", Transport: a, Timeout: 10 * time.Second}
+		errs <- runner.Run(ctx, garbler, false)
+	}()
+	runner := &protocol.Runner{Self: "evaluator", Peer: "garbler", Transport: b, Timeout: 10 * time.Second}
+	require.NoError(t, runner.Run(ctx, evaluator, true))
+	require.NoError(t, <-errs)
+
+	garblerOut, err := garbler.Output()
+	require.NoError(t, err)
+	evaluatorOut, err := evaluator.Output()
+	require.NoError(t, err)
+	return garblerOut, evaluatorOut
+}
+
+func TestProtocol(t *testing.T) {
+	gt, err := GreaterThan(32)
+	require.NoError(t, err)
+	gOut, eOut := runProtocol(t, gt, Bits(1_000_000, 32), Bits(999_999, 32))
+	require.Equal(t, []bool{true}, gOut)
+	require.Equal(t, gOut, eOut)
+
+	add, err := Add(16)
+	require.NoError(t, err)
+	gOut, eOut = runProtocol(t, add, Bits(40_000, 16), Bits(30_000, 16))
+	require.Equal(t, uint64(70_000%65536), Uint(gOut))
+	require.Equal(t, gOut, eOut)
+}
+
+func TestProtocolResult(t *testing.T) {
+	c, err := Equal(8)
+	require.NoError(t, err)
+	garbler, err := NewGarbler([]byte("sid"), c, Bits(9, 8))
+	require.NoError(t, err)
+	evaluator, err := NewEvaluator([]byte("sid"), c, Bits(9, 8))
+	require.NoError(t, err)
+	_, err = garbler.Output()
+	require.Error(t, err)
+
+	// drive the rounds by hand
+	var msg *protocol.Message
+	for i := 0; i < 4; i++ {
+		msg, err = evaluator.Next(msg)
+		require.NoError(t, err)
+		msg, err = garbler.Next(msg)
+		require.NoError(t, err)
+	}
+	require.Nil(t, msg)
+	_, err = evaluator.Next(msg)
+	require.ErrorIs(t, err, protocol.ErrProtocolFinished)
+
+	out, err := garbler.Output()
+	require.NoError(t, err)
+	require.Equal(t, []bool{true}, out)
+	result, err := evaluator.Result(protocol.Version1)
+	require.NoError(t, err)
+	require.Equal(t, protocol.GarbledCircuit, result.Protocol)
+	_, err = evaluator.Result(protocol.Version0)
+	require.Error(t, err)
+
+	_, err = NewGarbler(nil, c, Bits(9, 8))
+	require.Error(t, err)
+	_, err = NewEvaluator([]byte("sid"), c, Bits(9, 7))
+	require.Error(t, err)
+}
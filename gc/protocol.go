@@ -0,0 +1,332 @@
+package gc
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/gob"
+	"fmt"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/protocol"
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/ot"
+	"github.com/go-sonr/crypto/ot/base/simplest"
+	"github.com/go-sonr/crypto/zkp/schnorr"
+)
+
+const payloadKey = "direct"
+
+// the messages of the protocol. The evaluator sends the odd rounds, which
+// run the base OTs of the ot package as their sender and extend them; the
+// garbler sends the even rounds, the last with the garbled circuit.
+type (
+	round1 struct{ Proof *schnorr.Proof }
+	round2 struct {
+		Choices []simplest.ReceiversMaskedChoices
+	}
+	round3 struct{ Challenge []simplest.OtChallenge }
+	round4 struct {
+		Responses []simplest.OtChallengeResponse
+	}
+	round5 struct {
+		Openings  []simplest.ChallengeOpening
+		Extension *ot.Extension
+	}
+	round6 struct {
+		Circuit     *GarbledCircuit
+		Labels      []Label
+		Ciphertexts [][2][]byte
+	}
+	round7 struct{ Output []bool }
+)
+
+// Garbler is the party garbling the circuit. It responds to the evaluator,
+// which starts the protocol.
+type Garbler struct {
+	stepper
+	circuit *Circuit
+	input   []bool
+	ot      *simplest.Receiver
+}
+
+// Evaluator is the party evaluating the garbled circuit. It starts the
+// protocol.
+type Evaluator struct {
+	stepper
+	circuit *Circuit
+	input   []bool
+	ot      *simplest.Sender
+	pads    *ot.ReceiverOutput
+}
+
+// NewGarbler returns the garbler of the circuit on its input bits. The
+// session id must be unique and the same for both parties.
+func NewGarbler(sessionId []byte, circuit *Circuit, input []bool) (*Garbler, error) {
+	if circuit == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if err := checkInput(sessionId, circuit, input, circuit.GarblerInputs); err != nil {
+		return nil, err
+	}
+	base, err := simplest.NewReceiver(curves.K256(), ot.Kappa, baseSessionId(sessionId))
+	if err != nil {
+		return nil, err
+	}
+	g := &Garbler{circuit: circuit, input: append([]bool(nil), input...), ot: base}
+	g.stepper = stepper{sessionId: sessionId, steps: []func(*protocol.Message) (*protocol.Message, error){
+		g.round2, g.round4, g.round6, g.finish,
+	}}
+	return g, nil
+}
+
+// NewEvaluator returns the evaluator of the circuit on its input bits
+func NewEvaluator(sessionId []byte, circuit *Circuit, input []bool) (*Evaluator, error) {
+	if circuit == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if err := checkInput(sessionId, circuit, input, circuit.EvaluatorInputs); err != nil {
+		return nil, err
+	}
+	base, err := simplest.NewSender(curves.K256(), ot.Kappa, baseSessionId(sessionId))
+	if err != nil {
+		return nil, err
+	}
+	e := &Evaluator{circuit: circuit, input: append([]bool(nil), input...), ot: base}
+	e.stepper = stepper{sessionId: sessionId, steps: []func(*protocol.Message) (*protocol.Message, error){
+		e.round1, e.round3, e.round5, e.round7,
+	}}
+	return e, nil
+}
+
+func checkInput(sessionId []byte, circuit *Circuit, input []bool, bits int) error {
+	if len(sessionId) == 0 {
+		return fmt.Errorf("session id cannot be empty")
+	}
+	if err := circuit.Validate(); err != nil {
+		return err
+	}
+	if circuit.EvaluatorInputs == 0 {
+		return fmt.Errorf("circuit has no input of the evaluator")
+	}
+	if len(input) != bits {
+		return fmt.Errorf("circuit takes %d input bits, got %d", bits, len(input))
+	}
+	return nil
+}
+
+func baseSessionId(sessionId []byte) [simplest.DigestSize]byte {
+	return sha3.Sum256(append([]byte("go-sonr gc base ot v1"), sessionId...))
+}
+
+func (e *Evaluator) round1(*protocol.Message) (*protocol.Message, error) {
+	proof, err := e.ot.Round1ComputeAndZkpToPublicKey()
+	if err != nil {
+		return nil, err
+	}
+	return encode(&round1{Proof: proof}, 1)
+}
+
+func (g *Garbler) round2(m *protocol.Message) (*protocol.Message, error) {
+	var in round1
+	if err := decode(m, &in); err != nil {
+		return nil, err
+	}
+	choices, err := g.ot.Round2VerifySchnorrAndPadTransfer(in.Proof)
+	if err != nil {
+		return nil, err
+	}
+	return encode(&round2{Choices: choices}, 2)
+}
+
+func (e *Evaluator) round3(m *protocol.Message) (*protocol.Message, error) {
+	var in round2
+	if err := decode(m, &in); err != nil {
+		return nil, err
+	}
+	challenge, err := e.ot.Round3PadTransfer(in.Choices)
+	if err != nil {
+		return nil, err
+	}
+	return encode(&round3{Challenge: challenge}, 3)
+}
+
+func (g *Garbler) round4(m *protocol.Message) (*protocol.Message, error) {
+	var in round3
+	if err := decode(m, &in); err != nil {
+		return nil, err
+	}
+	responses, err := g.ot.Round4RespondToChallenge(in.Challenge)
+	if err != nil {
+		return nil, err
+	}
+	return encode(&round4{Responses: responses}, 4)
+}
+
+func (e *Evaluator) round5(m *protocol.Message) (*protocol.Message, error) {
+	var in round4
+	if err := decode(m, &in); err != nil {
+		return nil, err
+	}
+	openings, err := e.ot.Round5Verify(in.Responses)
+	if err != nil {
+		return nil, err
+	}
+	receiver, err := ot.NewReceiver(e.ot.Output)
+	if err != nil {
+		return nil, err
+	}
+	pads, ext, err := receiver.Extend(e.sessionId, e.input)
+	if err != nil {
+		return nil, err
+	}
+	e.pads = pads
+	return encode(&round5{Openings: openings, Extension: ext}, 5)
+}
+
+func (g *Garbler) round6(m *protocol.Message) (*protocol.Message, error) {
+	var in round5
+	if err := decode(m, &in); err != nil {
+		return nil, err
+	}
+	if err := g.ot.Round6Verify(in.Openings); err != nil {
+		return nil, err
+	}
+	sender, err := ot.NewSender(g.ot.Output)
+	if err != nil {
+		return nil, err
+	}
+	pads, err := sender.Extend(g.sessionId, in.Extension)
+	if err != nil {
+		return nil, err
+	}
+	garbling, err := Garble(g.circuit, rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := garbling.GarblerLabels(g.input)
+	if err != nil {
+		return nil, err
+	}
+	pairs := garbling.EvaluatorLabels()
+	msgs := make([][2][]byte, len(pairs))
+	for i := range pairs {
+		msgs[i] = [2][]byte{pairs[i][0][:], pairs[i][1][:]}
+	}
+	ciphertexts, err := pads.Transfer(msgs)
+	if err != nil {
+		return nil, err
+	}
+	return encode(&round6{Circuit: garbling.GarbledCircuit, Labels: labels, Ciphertexts: ciphertexts}, 6)
+}
+
+func (e *Evaluator) round7(m *protocol.Message) (*protocol.Message, error) {
+	var in round6
+	if err := decode(m, &in); err != nil {
+		return nil, err
+	}
+	if in.Circuit == nil {
+		return nil, internal.ErrNilArguments
+	}
+	msgs, err := e.pads.Receive(in.Ciphertexts)
+	if err != nil {
+		return nil, err
+	}
+	labels := make([]Label, len(msgs))
+	for i, msg := range msgs {
+		if len(msg) != LabelSize {
+			return nil, fmt.Errorf("invalid label of input %d", i)
+		}
+		copy(labels[i][:], msg)
+	}
+	output, err := Evaluate(e.circuit, in.Circuit, in.Labels, labels)
+	if err != nil {
+		return nil, err
+	}
+	e.output = output
+	return encode(&round7{Output: output}, 7)
+}
+
+func (g *Garbler) finish(m *protocol.Message) (*protocol.Message, error) {
+	var in round7
+	if err := decode(m, &in); err != nil {
+		return nil, err
+	}
+	if len(in.Output) != len(g.circuit.Outputs) {
+		return nil, fmt.Errorf("circuit has %d outputs, got %d", len(g.circuit.Outputs), len(in.Output))
+	}
+	g.output = in.Output
+	return nil, nil
+}
+
+// stepper runs the rounds of a party in order, like the protocols of
+// tecdsa/dklsv1
+type stepper struct {
+	steps     []func(*protocol.Message) (*protocol.Message, error)
+	step      int
+	sessionId []byte
+	output    []bool
+}
+
+// Next runs the next round of the protocol
+func (s *stepper) Next(input *protocol.Message) (*protocol.Message, error) {
+	if s.step >= len(s.steps) {
+		return nil, protocol.ErrProtocolFinished
+	}
+	output, err := s.steps[s.step](input)
+	if err != nil {
+		return nil, err
+	}
+	s.step++
+	return output, nil
+}
+
+// Result returns the output bits of the circuit once the protocol finished
+func (s *stepper) Result(version uint) (*protocol.Message, error) {
+	if version != protocol.Version1 {
+		return nil, fmt.Errorf("only version 1 is supported")
+	}
+	if s.output == nil {
+		return nil, nil
+	}
+	return encode(&round7{Output: s.output}, len(s.steps))
+}
+
+// Output returns the output bits of the circuit once the protocol finished
+func (s *stepper) Output() ([]bool, error) {
+	if s.output == nil {
+		return nil, protocol.ErrNotInitialized
+	}
+	return append([]bool(nil), s.output...), nil
+}
+
+func registerTypes() {
+	gob.Register(&curves.ScalarK256{})
+	gob.Register(&curves.PointK256{})
+}
+
+func encode(v interface{}, round int) (*protocol.Message, error) {
+	registerTypes()
+	buf := new(bytes.Buffer)
+	if err := gob.NewEncoder(buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return &protocol.Message{
+		Protocol: protocol.GarbledCircuit,
+		Version:  protocol.Version1,
+		Payloads: map[string][]byte{payloadKey: buf.Bytes()},
+		Metadata: map[string]string{"round": fmt.Sprint(round)},
+	}, nil
+}
+
+func decode(m *protocol.Message, v interface{}) error {
+	if m == nil {
+		return internal.ErrNilArguments
+	}
+	if m.Protocol != protocol.GarbledCircuit || m.Version != protocol.Version1 {
+		return fmt.Errorf("not a version 1 message of %s", protocol.GarbledCircuit)
+	}
+	registerTypes()
+	return gob.NewDecoder(bytes.NewBuffer(m.Payloads[payloadKey])).Decode(v)
+}
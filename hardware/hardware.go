@@ -0,0 +1,96 @@
+// Package hardware abstracts private keys held by secure hardware behind
+// the same interfaces as software keys. A Signer is a crypto.Signer and a
+// Decrypter computes ECDH shared secrets, so keys that never leave a
+// macOS Secure Enclave, a TPM 2.0 or a PKCS#11 token sign and decrypt like
+// keys held in memory.
+//
+// PrivKey wraps a Signer as a libp2p private key whose Raw fails, so the
+// jose and cose signers, UCAN issuance and anything else that takes a
+// crypto.PrivKey can use a hardware key unchanged.
+//
+// Hardware backends hold P-256 keys, the curve all three support. Each
+// backend is compiled on the platforms and builds that can reach it and
+// returns ErrUnsupported elsewhere.
+package hardware
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+var (
+	// ErrUnsupported is returned by backends that are not available on
+	// this platform or build
+	ErrUnsupported = errors.New("hardware: backend is not supported on this platform")
+	// ErrNotExportable is returned when the private key of a hardware key is
+	// requested
+	ErrNotExportable = errors.New("hardware: private key is not exportable")
+)
+
+// Signer is a private key that signs digests, possibly on a device the key
+// never leaves. ECDSA signatures are ASN.1 DER encoded, as crypto.Signer
+// requires.
+type Signer interface {
+	crypto.Signer
+}
+
+// Decrypter is a private key that computes ECDH shared secrets, possibly on
+// a device the key never leaves
+type Decrypter interface {
+	Public() crypto.PublicKey
+	// ECDH returns the x-coordinate of the product of the private key and
+	// peer, as crypto/ecdh does
+	ECDH(peer *ecdh.PublicKey) ([]byte, error)
+}
+
+// Key is a hardware key that signs, computes shared secrets and holds
+// device resources until it is closed
+type Key interface {
+	Signer
+	Decrypter
+	io.Closer
+}
+
+// checkSHA256 checks that opts and digest select a SHA-256 ECDSA
+// signature, the only one the P-256 backends produce
+func checkSHA256(digest []byte, opts crypto.SignerOpts) error {
+	if opts == nil || opts.HashFunc() != crypto.SHA256 {
+		return fmt.Errorf("hardware: only SHA-256 digests are supported")
+	}
+	if len(digest) != crypto.SHA256.Size() {
+		return fmt.Errorf("hardware: digest must be %d bytes", crypto.SHA256.Size())
+	}
+	return nil
+}
+
+// checkP256Peer checks that an ECDH peer key is on P-256 and returns its
+// uncompressed encoding
+func checkP256Peer(peer *ecdh.PublicKey) ([]byte, error) {
+	if peer == nil || peer.Curve() != ecdh.P256() {
+		return nil, fmt.Errorf("hardware: peer key must be a P-256 key")
+	}
+	return peer.Bytes(), nil
+}
+
+// p256PublicKey decodes the coordinates of a P-256 public key
+func p256PublicKey(x, y []byte) (*ecdsa.PublicKey, error) {
+	pub := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+	if !pub.Curve.IsOnCurve(pub.X, pub.Y) {
+		return nil, fmt.Errorf("hardware: public key is not on P-256")
+	}
+	return pub, nil
+}
+
+// p256Uncompressed decodes an uncompressed P-256 point
+func p256Uncompressed(b []byte) (*ecdsa.PublicKey, error) {
+	if len(b) != 65 || b[0] != 4 {
+		return nil, fmt.Errorf("hardware: invalid uncompressed P-256 point")
+	}
+	return p256PublicKey(b[1:33], b[33:])
+}
@@ -0,0 +1,154 @@
+package hardware
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	crand "crypto/rand"
+	"crypto/sha256"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/cose"
+	"github.com/go-sonr/crypto/jose"
+	"github.com/go-sonr/crypto/ucan"
+)
+
+var (
+	_ Key = (*SoftwareKey)(nil)
+	_ Key = (*TPMKey)(nil)
+	_ Key = (*PKCS11Key)(nil)
+	_ Key = (*SecureEnclaveKey)(nil)
+)
+
+// testKey checks signatures and shared secrets of a P-256 key
+func testKey(t *testing.T, k Key) {
+	pub, ok := k.Public().(*ecdsa.PublicKey)
+	require.True(t, ok)
+	require.Equal(t, elliptic.P256(), pub.Curve)
+
+	digest := sha256.Sum256([]byte("hello"))
+	sig, err := k.Sign(crand.Reader, digest[:], crypto.SHA256)
+	require.NoError(t, err)
+	require.True(t, ecdsa.VerifyASN1(pub, digest[:], sig))
+	_, err = k.Sign(crand.Reader, digest[:], crypto.SHA384)
+	require.Error(t, err)
+
+	peer, err := ecdh.P256().GenerateKey(crand.Reader)
+	require.NoError(t, err)
+	shared, err := k.ECDH(peer.PublicKey())
+	require.NoError(t, err)
+	own, err := pub.ECDH()
+	require.NoError(t, err)
+	expected, err := peer.ECDH(own)
+	require.NoError(t, err)
+	require.Equal(t, expected, shared)
+}
+
+func TestSoftwareKey(t *testing.T) {
+	k, err := GenerateSoftwareKey(elliptic.P256(), crand.Reader)
+	require.NoError(t, err)
+	testKey(t, k)
+	require.NoError(t, k.Close())
+
+	_, err = GenerateSoftwareKey(nil, crand.Reader)
+	require.Error(t, err)
+}
+
+func TestPrivKey(t *testing.T) {
+	k, err := GenerateSoftwareKey(elliptic.P256(), crand.Reader)
+	require.NoError(t, err)
+	priv, err := PrivKey(k)
+	require.NoError(t, err)
+
+	_, err = priv.Raw()
+	require.ErrorIs(t, err, ErrNotExportable)
+	sig, err := priv.Sign([]byte("hello"))
+	require.NoError(t, err)
+	ok, err := priv.GetPublic().Verify([]byte("hello"), sig)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	id, err := DIDKey(k)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(id.String(), "did:key:zDn"))
+
+	// jose and cose sign with the signer behind the key
+	js, err := jose.NewSigner(priv)
+	require.NoError(t, err)
+	require.Equal(t, jose.ES256, js.Algorithm())
+	token, err := jose.Sign([]byte("payload"), js, nil)
+	require.NoError(t, err)
+	jv, err := jose.NewVerifier(id)
+	require.NoError(t, err)
+	_, payload, err := jose.Verify(token, jv)
+	require.NoError(t, err)
+	require.Equal(t, []byte("payload"), payload)
+
+	cs, err := cose.NewSigner(priv)
+	require.NoError(t, err)
+	msg := cose.NewSign1Message([]byte("payload"))
+	require.NoError(t, msg.Sign(cs, nil))
+	cv, err := cose.NewVerifier(id)
+	require.NoError(t, err)
+	require.NoError(t, msg.Verify(cv, nil))
+
+	// ucan issuance
+	d, err := ucan.NewBuilder(priv).
+		ToAudience(id.String()).
+		Claim(id.String()+"/photos", "crud/read").
+		ExpiresIn(time.Hour).
+		Build()
+	require.NoError(t, err)
+	_, err = ucan.NewVerifier(nil).Verify(context.Background(), d.Raw, id.String())
+	require.NoError(t, err)
+}
+
+func TestTPMKey(t *testing.T) {
+	path := os.Getenv("TPM_PATH")
+	if path == "" {
+		t.Skip("TPM_PATH not set")
+	}
+	tpm, err := OpenTPM(path)
+	require.NoError(t, err)
+	defer tpm.Close()
+
+	k, err := NewTPMKey(tpm, []byte("sonr-test"))
+	require.NoError(t, err)
+	defer k.Close()
+	testKey(t, k)
+
+	// the primary key is derived again from the same unique value
+	again, err := NewTPMKey(tpm, []byte("sonr-test"))
+	require.NoError(t, err)
+	defer again.Close()
+	require.True(t, k.pub.Equal(again.Public()))
+}
+
+func TestPKCS11Key(t *testing.T) {
+	module := os.Getenv("PKCS11_MODULE")
+	if module == "" {
+		t.Skip("PKCS11_MODULE not set")
+	}
+	cfg := PKCS11Config{
+		Module:     module,
+		TokenLabel: os.Getenv("PKCS11_TOKEN"),
+		PIN:        os.Getenv("PKCS11_PIN"),
+		KeyLabel:   "sonr-test-" + time.Now().Format("150405.000"),
+	}
+	k, err := GeneratePKCS11Key(cfg)
+	require.NoError(t, err)
+	defer k.Close()
+	testKey(t, k)
+
+	opened, err := OpenPKCS11Key(cfg)
+	require.NoError(t, err)
+	defer opened.Close()
+	require.True(t, k.Public().(*ecdsa.PublicKey).Equal(opened.Public()))
+}
@@ -0,0 +1,259 @@
+//go:build cgo
+
+package hardware
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"encoding/asn1"
+	"fmt"
+	"io"
+	"math/big"
+	"sync"
+
+	"github.com/miekg/pkcs11"
+)
+
+// oidP256 is the CKA_EC_PARAMS of P-256 keys, the DER of its named curve OID
+var oidP256 = asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}
+
+// PKCS11Config locates a key on a PKCS#11 token
+type PKCS11Config struct {
+	// Module is the path of the PKCS#11 library
+	Module string
+	// TokenLabel selects the token, the first token with a key when empty
+	TokenLabel string
+	// PIN is the user PIN of the token
+	PIN string
+	// KeyLabel is the CKA_LABEL of the key pair
+	KeyLabel string
+}
+
+// PKCS11Key is a P-256 key pair on a PKCS#11 token. The private key is
+// sensitive and not extractable, signatures and shared secrets are
+// computed by the token.
+type PKCS11Key struct {
+	mu      sync.Mutex
+	ctx     *pkcs11.Ctx
+	session pkcs11.SessionHandle
+	priv    pkcs11.ObjectHandle
+	pub     *ecdsa.PublicKey
+}
+
+// OpenPKCS11Key opens the key pair labelled cfg.KeyLabel
+func OpenPKCS11Key(cfg PKCS11Config) (*PKCS11Key, error) {
+	return openPKCS11(cfg, false)
+}
+
+// GeneratePKCS11Key generates a P-256 key pair labelled cfg.KeyLabel on the
+// token
+func GeneratePKCS11Key(cfg PKCS11Config) (*PKCS11Key, error) {
+	return openPKCS11(cfg, true)
+}
+
+func openPKCS11(cfg PKCS11Config, generate bool) (k *PKCS11Key, err error) {
+	if cfg.Module == "" || cfg.KeyLabel == "" {
+		return nil, fmt.Errorf("hardware: pkcs11 module and key label are required")
+	}
+	ctx := pkcs11.New(cfg.Module)
+	if ctx == nil {
+		return nil, fmt.Errorf("hardware: cannot load pkcs11 module %s", cfg.Module)
+	}
+	if err := ctx.Initialize(); err != nil {
+		ctx.Destroy()
+		return nil, fmt.Errorf("hardware: pkcs11 initialize: %w", err)
+	}
+	k = &PKCS11Key{ctx: ctx}
+	defer func() {
+		if err != nil {
+			_ = k.Close()
+		}
+	}()
+
+	slot, err := findSlot(ctx, cfg.TokenLabel)
+	if err != nil {
+		return nil, err
+	}
+	if k.session, err = ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION|pkcs11.CKF_RW_SESSION); err != nil {
+		return nil, fmt.Errorf("hardware: pkcs11 open session: %w", err)
+	}
+	if err = ctx.Login(k.session, pkcs11.CKU_USER, cfg.PIN); err != nil {
+		return nil, fmt.Errorf("hardware: pkcs11 login: %w", err)
+	}
+
+	var pubHandle pkcs11.ObjectHandle
+	if generate {
+		pubHandle, k.priv, err = k.generate(cfg.KeyLabel)
+	} else {
+		pubHandle, k.priv, err = k.find(cfg.KeyLabel)
+	}
+	if err != nil {
+		return nil, err
+	}
+	attrs, err := ctx.GetAttributeValue(k.session, pubHandle, []*pkcs11.Attribute{pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, nil)})
+	if err != nil {
+		return nil, fmt.Errorf("hardware: pkcs11 public key: %w", err)
+	}
+	// CKA_EC_POINT is the DER octet string of the uncompressed point
+	var point []byte
+	if rest, err := asn1.Unmarshal(attrs[0].Value, &point); err != nil || len(rest) != 0 {
+		return nil, fmt.Errorf("hardware: invalid pkcs11 ec point")
+	}
+	if k.pub, err = p256Uncompressed(point); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+func findSlot(ctx *pkcs11.Ctx, label string) (uint, error) {
+	slots, err := ctx.GetSlotList(true)
+	if err != nil {
+		return 0, fmt.Errorf("hardware: pkcs11 slots: %w", err)
+	}
+	for _, slot := range slots {
+		if label == "" {
+			return slot, nil
+		}
+		info, err := ctx.GetTokenInfo(slot)
+		if err == nil && info.Label == label {
+			return slot, nil
+		}
+	}
+	return 0, fmt.Errorf("hardware: pkcs11 token %q not found", label)
+}
+
+func (k *PKCS11Key) generate(label string) (pkcs11.ObjectHandle, pkcs11.ObjectHandle, error) {
+	params, err := asn1.Marshal(oidP256)
+	if err != nil {
+		return 0, 0, err
+	}
+	pub := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+		pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, params),
+		pkcs11.NewAttribute(pkcs11.CKA_VERIFY, true),
+	}
+	priv := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+		pkcs11.NewAttribute(pkcs11.CKA_PRIVATE, true),
+		pkcs11.NewAttribute(pkcs11.CKA_SENSITIVE, true),
+		pkcs11.NewAttribute(pkcs11.CKA_EXTRACTABLE, false),
+		pkcs11.NewAttribute(pkcs11.CKA_SIGN, true),
+		pkcs11.NewAttribute(pkcs11.CKA_DERIVE, true),
+	}
+	mech := []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_EC_KEY_PAIR_GEN, nil)}
+	pubHandle, privHandle, err := k.ctx.GenerateKeyPair(k.session, mech, pub, priv)
+	if err != nil {
+		return 0, 0, fmt.Errorf("hardware: pkcs11 generate: %w", err)
+	}
+	return pubHandle, privHandle, nil
+}
+
+func (k *PKCS11Key) find(label string) (pkcs11.ObjectHandle, pkcs11.ObjectHandle, error) {
+	pub, err := k.findObject(pkcs11.CKO_PUBLIC_KEY, label)
+	if err != nil {
+		return 0, 0, err
+	}
+	priv, err := k.findObject(pkcs11.CKO_PRIVATE_KEY, label)
+	if err != nil {
+		return 0, 0, err
+	}
+	return pub, priv, nil
+}
+
+func (k *PKCS11Key) findObject(class uint, label string) (pkcs11.ObjectHandle, error) {
+	template := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, class),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_EC),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+	}
+	if err := k.ctx.FindObjectsInit(k.session, template); err != nil {
+		return 0, fmt.Errorf("hardware: pkcs11 find: %w", err)
+	}
+	objects, _, err := k.ctx.FindObjects(k.session, 2)
+	if ferr := k.ctx.FindObjectsFinal(k.session); err == nil {
+		err = ferr
+	}
+	if err != nil {
+		return 0, fmt.Errorf("hardware: pkcs11 find: %w", err)
+	}
+	if len(objects) != 1 {
+		return 0, fmt.Errorf("hardware: expected one pkcs11 key labelled %q, found %d", label, len(objects))
+	}
+	return objects[0], nil
+}
+
+// Public returns the *ecdsa.PublicKey of the key
+func (k *PKCS11Key) Public() crypto.PublicKey {
+	return k.pub
+}
+
+// Sign signs a SHA-256 digest with CKM_ECDSA on the token
+func (k *PKCS11Key) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if err := checkSHA256(digest, opts); err != nil {
+		return nil, err
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if err := k.ctx.SignInit(k.session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_ECDSA, nil)}, k.priv); err != nil {
+		return nil, fmt.Errorf("hardware: pkcs11 sign: %w", err)
+	}
+	sig, err := k.ctx.Sign(k.session, digest)
+	if err != nil {
+		return nil, fmt.Errorf("hardware: pkcs11 sign: %w", err)
+	}
+	if len(sig) != 64 {
+		return nil, fmt.Errorf("hardware: unexpected pkcs11 signature length %d", len(sig))
+	}
+	// PKCS#11 signatures are R || S
+	return asn1.Marshal(struct{ R, S *big.Int }{new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])})
+}
+
+// ECDH computes the shared secret with a P-256 peer with CKM_ECDH1_DERIVE
+// on the token
+func (k *PKCS11Key) ECDH(peer *ecdh.PublicKey) ([]byte, error) {
+	raw, err := checkP256Peer(peer)
+	if err != nil {
+		return nil, err
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	mech := []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_ECDH1_DERIVE, pkcs11.NewECDH1DeriveParams(pkcs11.CKD_NULL, nil, raw))}
+	template := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_SECRET_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_GENERIC_SECRET),
+		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, false),
+		pkcs11.NewAttribute(pkcs11.CKA_SENSITIVE, false),
+		pkcs11.NewAttribute(pkcs11.CKA_EXTRACTABLE, true),
+		pkcs11.NewAttribute(pkcs11.CKA_VALUE_LEN, 32),
+	}
+	secret, err := k.ctx.DeriveKey(k.session, mech, k.priv, template)
+	if err != nil {
+		return nil, fmt.Errorf("hardware: pkcs11 ecdh: %w", err)
+	}
+	defer func() { _ = k.ctx.DestroyObject(k.session, secret) }()
+	attrs, err := k.ctx.GetAttributeValue(k.session, secret, []*pkcs11.Attribute{pkcs11.NewAttribute(pkcs11.CKA_VALUE, nil)})
+	if err != nil {
+		return nil, fmt.Errorf("hardware: pkcs11 ecdh: %w", err)
+	}
+	return attrs[0].Value, nil
+}
+
+// Close logs out, closes the session and unloads the module
+func (k *PKCS11Key) Close() error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.ctx == nil {
+		return nil
+	}
+	if k.session != 0 {
+		_ = k.ctx.Logout(k.session)
+		_ = k.ctx.CloseSession(k.session)
+	}
+	err := k.ctx.Finalize()
+	k.ctx.Destroy()
+	k.ctx = nil
+	return err
+}
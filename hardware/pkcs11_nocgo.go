@@ -0,0 +1,51 @@
+//go:build !cgo
+
+package hardware
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"io"
+)
+
+// PKCS11Config locates a key on a PKCS#11 token
+type PKCS11Config struct {
+	Module     string
+	TokenLabel string
+	PIN        string
+	KeyLabel   string
+}
+
+// PKCS11Key is a key on a PKCS#11 token, which needs cgo to load the
+// module
+type PKCS11Key struct{}
+
+// OpenPKCS11Key returns ErrUnsupported without cgo
+func OpenPKCS11Key(PKCS11Config) (*PKCS11Key, error) {
+	return nil, ErrUnsupported
+}
+
+// GeneratePKCS11Key returns ErrUnsupported without cgo
+func GeneratePKCS11Key(PKCS11Config) (*PKCS11Key, error) {
+	return nil, ErrUnsupported
+}
+
+// Public returns nil
+func (*PKCS11Key) Public() crypto.PublicKey {
+	return nil
+}
+
+// Sign returns ErrUnsupported
+func (*PKCS11Key) Sign(io.Reader, []byte, crypto.SignerOpts) ([]byte, error) {
+	return nil, ErrUnsupported
+}
+
+// ECDH returns ErrUnsupported
+func (*PKCS11Key) ECDH(*ecdh.PublicKey) ([]byte, error) {
+	return nil, ErrUnsupported
+}
+
+// Close does nothing
+func (*PKCS11Key) Close() error {
+	return nil
+}
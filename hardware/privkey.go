@@ -0,0 +1,87 @@
+package hardware
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+
+	p2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
+	pb "github.com/libp2p/go-libp2p/core/crypto/pb"
+
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/keys/parsers"
+)
+
+// privKey is a libp2p private key backed by a Signer
+type privKey struct {
+	signer Signer
+	pub    p2pcrypto.PubKey
+}
+
+// PrivKey wraps an ECDSA or Ed25519 signer as a libp2p private key. Sign
+// follows libp2p: ECDSA keys sign the SHA-256 digest of the data with a DER
+// signature. Raw returns ErrNotExportable.
+func PrivKey(s Signer) (p2pcrypto.PrivKey, error) {
+	if s == nil {
+		return nil, internal.ErrNilArguments
+	}
+	var (
+		pub p2pcrypto.PubKey
+		err error
+	)
+	switch key := s.Public().(type) {
+	case *ecdsa.PublicKey:
+		pub, err = p2pcrypto.ECDSAPublicKeyFromPubKey(*key)
+	case ed25519.PublicKey:
+		pub, err = p2pcrypto.UnmarshalEd25519PublicKey(key)
+	default:
+		return nil, fmt.Errorf("hardware: unsupported public key type %T", key)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &privKey{signer: s, pub: pub}, nil
+}
+
+// DIDKey returns the did:key of the public key of a signer
+func DIDKey(s Signer) (parsers.DIDKey, error) {
+	priv, err := PrivKey(s)
+	if err != nil {
+		return parsers.DIDKey{}, err
+	}
+	return parsers.NewKeyDID(priv.GetPublic())
+}
+
+// CryptoSigner returns the signer behind the key, the jose and cose
+// packages sign with it directly
+func (k *privKey) CryptoSigner() crypto.Signer {
+	return k.signer
+}
+
+func (k *privKey) Equals(o p2pcrypto.Key) bool {
+	other, ok := o.(p2pcrypto.PrivKey)
+	return ok && k.pub.Equals(other.GetPublic())
+}
+
+func (k *privKey) Raw() ([]byte, error) {
+	return nil, ErrNotExportable
+}
+
+func (k *privKey) Type() pb.KeyType {
+	return k.pub.Type()
+}
+
+func (k *privKey) Sign(data []byte) ([]byte, error) {
+	if k.pub.Type() == p2pcrypto.Ed25519 {
+		return k.signer.Sign(rand.Reader, data, crypto.Hash(0))
+	}
+	digest := sha256.Sum256(data)
+	return k.signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+}
+
+func (k *privKey) GetPublic() p2pcrypto.PubKey {
+	return k.pub
+}
@@ -0,0 +1,257 @@
+//go:build darwin && cgo
+
+package hardware
+
+/*
+#cgo LDFLAGS: -framework CoreFoundation -framework Security
+#include <stdlib.h>
+#include <string.h>
+#include <CoreFoundation/CoreFoundation.h>
+#include <Security/Security.h>
+
+static CFMutableDictionaryRef se_dict(void) {
+	return CFDictionaryCreateMutable(NULL, 0, &kCFTypeDictionaryKeyCallBacks, &kCFTypeDictionaryValueCallBacks);
+}
+
+static CFDataRef se_tag(const char *tag) {
+	return CFDataCreate(NULL, (const UInt8 *)tag, strlen(tag));
+}
+
+static SecKeyRef se_create(const char *tag, int permanent, CFErrorRef *err) {
+	SecAccessControlRef access = SecAccessControlCreateWithFlags(NULL,
+		kSecAttrAccessibleWhenUnlockedThisDeviceOnly, kSecAccessControlPrivateKeyUsage, err);
+	if (access == NULL) {
+		return NULL;
+	}
+	CFDataRef t = se_tag(tag);
+	CFMutableDictionaryRef priv = se_dict();
+	CFDictionarySetValue(priv, kSecAttrIsPermanent, permanent ? kCFBooleanTrue : kCFBooleanFalse);
+	CFDictionarySetValue(priv, kSecAttrApplicationTag, t);
+	CFDictionarySetValue(priv, kSecAttrAccessControl, access);
+	int bits = 256;
+	CFNumberRef size = CFNumberCreate(NULL, kCFNumberIntType, &bits);
+	CFMutableDictionaryRef attrs = se_dict();
+	CFDictionarySetValue(attrs, kSecAttrKeyType, kSecAttrKeyTypeECSECPrimeRandom);
+	CFDictionarySetValue(attrs, kSecAttrKeySizeInBits, size);
+	CFDictionarySetValue(attrs, kSecAttrTokenID, kSecAttrTokenIDSecureEnclave);
+	CFDictionarySetValue(attrs, kSecPrivateKeyAttrs, priv);
+	SecKeyRef key = SecKeyCreateRandomKey(attrs, err);
+	CFRelease(attrs);
+	CFRelease(size);
+	CFRelease(priv);
+	CFRelease(t);
+	CFRelease(access);
+	return key;
+}
+
+static CFMutableDictionaryRef se_query(const char *tag) {
+	CFDataRef t = se_tag(tag);
+	CFMutableDictionaryRef q = se_dict();
+	CFDictionarySetValue(q, kSecClass, kSecClassKey);
+	CFDictionarySetValue(q, kSecAttrKeyClass, kSecAttrKeyClassPrivate);
+	CFDictionarySetValue(q, kSecAttrApplicationTag, t);
+	CFDictionarySetValue(q, kSecAttrTokenID, kSecAttrTokenIDSecureEnclave);
+	CFRelease(t);
+	return q;
+}
+
+static SecKeyRef se_load(const char *tag, OSStatus *status) {
+	CFMutableDictionaryRef q = se_query(tag);
+	CFDictionarySetValue(q, kSecReturnRef, kCFBooleanTrue);
+	CFTypeRef out = NULL;
+	*status = SecItemCopyMatching(q, &out);
+	CFRelease(q);
+	return (SecKeyRef)out;
+}
+
+static OSStatus se_delete(const char *tag) {
+	CFMutableDictionaryRef q = se_query(tag);
+	OSStatus status = SecItemDelete(q);
+	CFRelease(q);
+	return status;
+}
+
+static CFDataRef se_public(SecKeyRef key, CFErrorRef *err) {
+	SecKeyRef pub = SecKeyCopyPublicKey(key);
+	if (pub == NULL) {
+		return NULL;
+	}
+	CFDataRef out = SecKeyCopyExternalRepresentation(pub, err);
+	CFRelease(pub);
+	return out;
+}
+
+static CFDataRef se_sign(SecKeyRef key, const UInt8 *digest, CFIndex n, CFErrorRef *err) {
+	CFDataRef d = CFDataCreate(NULL, digest, n);
+	CFDataRef sig = SecKeyCreateSignature(key, kSecKeyAlgorithmECDSASignatureDigestX962SHA256, d, err);
+	CFRelease(d);
+	return sig;
+}
+
+static CFDataRef se_ecdh(SecKeyRef key, const UInt8 *peer, CFIndex n, CFErrorRef *err) {
+	CFMutableDictionaryRef attrs = se_dict();
+	CFDictionarySetValue(attrs, kSecAttrKeyType, kSecAttrKeyTypeECSECPrimeRandom);
+	CFDictionarySetValue(attrs, kSecAttrKeyClass, kSecAttrKeyClassPublic);
+	CFDataRef d = CFDataCreate(NULL, peer, n);
+	SecKeyRef pk = SecKeyCreateWithData(d, attrs, err);
+	CFRelease(d);
+	CFRelease(attrs);
+	if (pk == NULL) {
+		return NULL;
+	}
+	CFMutableDictionaryRef params = se_dict();
+	CFDataRef out = SecKeyCopyKeyExchangeResult(key, kSecKeyAlgorithmECDHKeyExchangeStandard, pk, params, err);
+	CFRelease(params);
+	CFRelease(pk);
+	return out;
+}
+*/
+import "C"
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"fmt"
+	"io"
+	"sync"
+	"unsafe"
+)
+
+// SecureEnclaveKey is a P-256 key generated in the Secure Enclave of an
+// Apple device. The private key cannot leave the enclave, keys created
+// with permanent set are kept in the keychain under their application tag.
+type SecureEnclaveKey struct {
+	mu  sync.Mutex
+	ref C.SecKeyRef
+	pub *ecdsa.PublicKey
+}
+
+// NewSecureEnclaveKey generates a key in the Secure Enclave with the
+// application tag tag
+func NewSecureEnclaveKey(tag string, permanent bool) (*SecureEnclaveKey, error) {
+	ctag := C.CString(tag)
+	defer C.free(unsafe.Pointer(ctag))
+	p := C.int(0)
+	if permanent {
+		p = 1
+	}
+	var cerr C.CFErrorRef
+	ref := C.se_create(ctag, p, &cerr)
+	if ref == 0 {
+		return nil, cfError("create", cerr)
+	}
+	return newSecureEnclaveKey(ref)
+}
+
+// LoadSecureEnclaveKey loads the permanent key with the application tag
+// tag from the keychain
+func LoadSecureEnclaveKey(tag string) (*SecureEnclaveKey, error) {
+	ctag := C.CString(tag)
+	defer C.free(unsafe.Pointer(ctag))
+	var status C.OSStatus
+	ref := C.se_load(ctag, &status)
+	if status != C.errSecSuccess || ref == 0 {
+		return nil, fmt.Errorf("hardware: secure enclave load: status %d", int(status))
+	}
+	return newSecureEnclaveKey(ref)
+}
+
+// DeleteSecureEnclaveKey removes the permanent key with the application tag
+// tag from the keychain
+func DeleteSecureEnclaveKey(tag string) error {
+	ctag := C.CString(tag)
+	defer C.free(unsafe.Pointer(ctag))
+	if status := C.se_delete(ctag); status != C.errSecSuccess {
+		return fmt.Errorf("hardware: secure enclave delete: status %d", int(status))
+	}
+	return nil
+}
+
+func newSecureEnclaveKey(ref C.SecKeyRef) (*SecureEnclaveKey, error) {
+	k := &SecureEnclaveKey{ref: ref}
+	var cerr C.CFErrorRef
+	data := C.se_public(ref, &cerr)
+	if data == 0 {
+		_ = k.Close()
+		return nil, cfError("public key", cerr)
+	}
+	// the external representation of EC public keys is the X9.63
+	// uncompressed point
+	pub, err := p256Uncompressed(cfBytes(data))
+	if err != nil {
+		_ = k.Close()
+		return nil, err
+	}
+	k.pub = pub
+	return k, nil
+}
+
+// cfBytes copies and releases a CFData
+func cfBytes(data C.CFDataRef) []byte {
+	defer C.CFRelease(C.CFTypeRef(data))
+	return C.GoBytes(unsafe.Pointer(C.CFDataGetBytePtr(data)), C.int(C.CFDataGetLength(data)))
+}
+
+// cfError converts and releases a CFError
+func cfError(op string, cerr C.CFErrorRef) error {
+	if cerr == 0 {
+		return fmt.Errorf("hardware: secure enclave %s failed", op)
+	}
+	defer C.CFRelease(C.CFTypeRef(cerr))
+	return fmt.Errorf("hardware: secure enclave %s: error %d", op, int(C.CFErrorGetCode(cerr)))
+}
+
+// Public returns the *ecdsa.PublicKey of the key
+func (k *SecureEnclaveKey) Public() crypto.PublicKey {
+	return k.pub
+}
+
+// Sign signs a SHA-256 digest with ECDSA in the Secure Enclave
+func (k *SecureEnclaveKey) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if err := checkSHA256(digest, opts); err != nil {
+		return nil, err
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.ref == 0 {
+		return nil, fmt.Errorf("hardware: secure enclave key is closed")
+	}
+	var cerr C.CFErrorRef
+	sig := C.se_sign(k.ref, (*C.UInt8)(unsafe.Pointer(&digest[0])), C.CFIndex(len(digest)), &cerr)
+	if sig == 0 {
+		return nil, cfError("sign", cerr)
+	}
+	// X9.62 signatures are DER
+	return cfBytes(sig), nil
+}
+
+// ECDH computes the shared secret with a P-256 peer in the Secure Enclave
+func (k *SecureEnclaveKey) ECDH(peer *ecdh.PublicKey) ([]byte, error) {
+	raw, err := checkP256Peer(peer)
+	if err != nil {
+		return nil, err
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.ref == 0 {
+		return nil, fmt.Errorf("hardware: secure enclave key is closed")
+	}
+	var cerr C.CFErrorRef
+	out := C.se_ecdh(k.ref, (*C.UInt8)(unsafe.Pointer(&raw[0])), C.CFIndex(len(raw)), &cerr)
+	if out == 0 {
+		return nil, cfError("ecdh", cerr)
+	}
+	return cfBytes(out), nil
+}
+
+// Close releases the key reference, permanent keys stay in the keychain
+func (k *SecureEnclaveKey) Close() error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.ref != 0 {
+		C.CFRelease(C.CFTypeRef(k.ref))
+		k.ref = 0
+	}
+	return nil
+}
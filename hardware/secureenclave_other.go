@@ -0,0 +1,48 @@
+//go:build !darwin || !cgo
+
+package hardware
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"io"
+)
+
+// SecureEnclaveKey is a key in the Secure Enclave of an Apple device, which
+// needs darwin and cgo
+type SecureEnclaveKey struct{}
+
+// NewSecureEnclaveKey returns ErrUnsupported on this platform
+func NewSecureEnclaveKey(string, bool) (*SecureEnclaveKey, error) {
+	return nil, ErrUnsupported
+}
+
+// LoadSecureEnclaveKey returns ErrUnsupported on this platform
+func LoadSecureEnclaveKey(string) (*SecureEnclaveKey, error) {
+	return nil, ErrUnsupported
+}
+
+// DeleteSecureEnclaveKey returns ErrUnsupported on this platform
+func DeleteSecureEnclaveKey(string) error {
+	return ErrUnsupported
+}
+
+// Public returns nil
+func (*SecureEnclaveKey) Public() crypto.PublicKey {
+	return nil
+}
+
+// Sign returns ErrUnsupported
+func (*SecureEnclaveKey) Sign(io.Reader, []byte, crypto.SignerOpts) ([]byte, error) {
+	return nil, ErrUnsupported
+}
+
+// ECDH returns ErrUnsupported
+func (*SecureEnclaveKey) ECDH(*ecdh.PublicKey) ([]byte, error) {
+	return nil, ErrUnsupported
+}
+
+// Close does nothing
+func (*SecureEnclaveKey) Close() error {
+	return nil
+}
@@ -0,0 +1,66 @@
+package hardware
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"fmt"
+	"io"
+
+	"github.com/go-sonr/crypto/internal"
+)
+
+// SoftwareKey is a P-256 or P-384 key held in memory behind the hardware
+// interfaces, for development and for platforms without secure hardware
+type SoftwareKey struct {
+	key *ecdsa.PrivateKey
+}
+
+// GenerateSoftwareKey draws a key on curve from reader
+func GenerateSoftwareKey(curve elliptic.Curve, reader io.Reader) (*SoftwareKey, error) {
+	if curve == nil || reader == nil {
+		return nil, internal.ErrNilArguments
+	}
+	key, err := ecdsa.GenerateKey(curve, reader)
+	if err != nil {
+		return nil, err
+	}
+	return NewSoftwareKey(key)
+}
+
+// NewSoftwareKey wraps an ECDSA private key
+func NewSoftwareKey(key *ecdsa.PrivateKey) (*SoftwareKey, error) {
+	if key == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if key.Curve != elliptic.P256() && key.Curve != elliptic.P384() {
+		return nil, fmt.Errorf("hardware: unsupported curve %s", key.Curve.Params().Name)
+	}
+	return &SoftwareKey{key: key}, nil
+}
+
+// Public returns the *ecdsa.PublicKey of the key
+func (k *SoftwareKey) Public() crypto.PublicKey {
+	return &k.key.PublicKey
+}
+
+// Sign signs digest with ECDSA
+func (k *SoftwareKey) Sign(reader io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	return k.key.Sign(reader, digest, opts)
+}
+
+// ECDH computes the shared secret with peer
+func (k *SoftwareKey) ECDH(peer *ecdh.PublicKey) ([]byte, error) {
+	priv, err := k.key.ECDH()
+	if err != nil {
+		return nil, err
+	}
+	return priv.ECDH(peer)
+}
+
+// Close overwrites the private scalar
+func (k *SoftwareKey) Close() error {
+	k.key.D.SetInt64(0)
+	return nil
+}
@@ -0,0 +1,170 @@
+package hardware
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"encoding/asn1"
+	"fmt"
+	"io"
+	"math/big"
+	"sync"
+
+	"github.com/google/go-tpm/tpm2"
+	"github.com/google/go-tpm/tpm2/transport"
+
+	"github.com/go-sonr/crypto/internal"
+)
+
+// TPMKey is a P-256 primary key of the owner hierarchy of a TPM 2.0. The
+// key is derived inside the TPM from the owner seed and a caller chosen
+// unique value, so creating it again with the same value on the same TPM
+// yields the same key while the private key never leaves the TPM.
+type TPMKey struct {
+	mu     sync.Mutex
+	tpm    transport.TPM
+	handle tpm2.TPMHandle
+	name   tpm2.TPM2BName
+	pub    *ecdsa.PublicKey
+}
+
+// tpmKeyTemplate is an unrestricted P-256 key for ECDSA signatures and
+// ECDH, with unique as the entropy of the primary key derivation
+func tpmKeyTemplate(unique []byte) tpm2.TPMTPublic {
+	return tpm2.TPMTPublic{
+		Type:    tpm2.TPMAlgECC,
+		NameAlg: tpm2.TPMAlgSHA256,
+		ObjectAttributes: tpm2.TPMAObject{
+			FixedTPM:            true,
+			FixedParent:         true,
+			SensitiveDataOrigin: true,
+			UserWithAuth:        true,
+			Decrypt:             true,
+			SignEncrypt:         true,
+		},
+		Parameters: tpm2.NewTPMUPublicParms(
+			tpm2.TPMAlgECC,
+			&tpm2.TPMSECCParms{
+				Symmetric: tpm2.TPMTSymDefObject{Algorithm: tpm2.TPMAlgNull},
+				Scheme:    tpm2.TPMTECCScheme{Scheme: tpm2.TPMAlgNull},
+				CurveID:   tpm2.TPMECCNistP256,
+				KDF:       tpm2.TPMTKDFScheme{Scheme: tpm2.TPMAlgNull},
+			},
+		),
+		Unique: tpm2.NewTPMUPublicID(
+			tpm2.TPMAlgECC,
+			&tpm2.TPMSECCPoint{X: tpm2.TPM2BECCParameter{Buffer: unique}},
+		),
+	}
+}
+
+// NewTPMKey creates the primary key of unique in the owner hierarchy of
+// tpm. The key stays loaded until Close, which does not close tpm.
+func NewTPMKey(tpm transport.TPM, unique []byte) (*TPMKey, error) {
+	if tpm == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if len(unique) > 32 {
+		return nil, fmt.Errorf("hardware: tpm key unique value exceeds 32 bytes")
+	}
+	rsp, err := tpm2.CreatePrimary{
+		PrimaryHandle: tpm2.TPMRHOwner,
+		InPublic:      tpm2.New2B(tpmKeyTemplate(unique)),
+	}.Execute(tpm)
+	if err != nil {
+		return nil, fmt.Errorf("hardware: tpm create primary: %w", err)
+	}
+	k := &TPMKey{tpm: tpm, handle: rsp.ObjectHandle, name: rsp.Name}
+	if k.pub, err = tpmPublicKey(rsp.OutPublic); err != nil {
+		_ = k.Close()
+		return nil, err
+	}
+	return k, nil
+}
+
+func tpmPublicKey(out tpm2.TPM2BPublic) (*ecdsa.PublicKey, error) {
+	pub, err := out.Contents()
+	if err != nil {
+		return nil, err
+	}
+	point, err := pub.Unique.ECC()
+	if err != nil {
+		return nil, err
+	}
+	return p256PublicKey(point.X.Buffer, point.Y.Buffer)
+}
+
+func (k *TPMKey) authHandle() tpm2.AuthHandle {
+	return tpm2.AuthHandle{Handle: k.handle, Name: k.name, Auth: tpm2.PasswordAuth(nil)}
+}
+
+// Public returns the *ecdsa.PublicKey of the key
+func (k *TPMKey) Public() crypto.PublicKey {
+	return k.pub
+}
+
+// Sign signs a SHA-256 digest with ECDSA inside the TPM
+func (k *TPMKey) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if err := checkSHA256(digest, opts); err != nil {
+		return nil, err
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	rsp, err := tpm2.Sign{
+		KeyHandle: k.authHandle(),
+		Digest:    tpm2.TPM2BDigest{Buffer: digest},
+		InScheme: tpm2.TPMTSigScheme{
+			Scheme:  tpm2.TPMAlgECDSA,
+			Details: tpm2.NewTPMUSigScheme(tpm2.TPMAlgECDSA, &tpm2.TPMSSchemeHash{HashAlg: tpm2.TPMAlgSHA256}),
+		},
+		Validation: tpm2.TPMTTKHashCheck{Tag: tpm2.TPMSTHashCheck, Hierarchy: tpm2.TPMRHNull},
+	}.Execute(k.tpm)
+	if err != nil {
+		return nil, fmt.Errorf("hardware: tpm sign: %w", err)
+	}
+	sig, err := rsp.Signature.Signature.ECDSA()
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(struct{ R, S *big.Int }{
+		new(big.Int).SetBytes(sig.SignatureR.Buffer),
+		new(big.Int).SetBytes(sig.SignatureS.Buffer),
+	})
+}
+
+// ECDH computes the shared secret with a P-256 peer inside the TPM
+func (k *TPMKey) ECDH(peer *ecdh.PublicKey) ([]byte, error) {
+	raw, err := checkP256Peer(peer)
+	if err != nil {
+		return nil, err
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	rsp, err := tpm2.ECDHZGen{
+		KeyHandle: k.authHandle(),
+		InPoint: tpm2.New2B(tpm2.TPMSECCPoint{
+			X: tpm2.TPM2BECCParameter{Buffer: raw[1:33]},
+			Y: tpm2.TPM2BECCParameter{Buffer: raw[33:]},
+		}),
+	}.Execute(k.tpm)
+	if err != nil {
+		return nil, fmt.Errorf("hardware: tpm ecdh: %w", err)
+	}
+	point, err := rsp.OutPoint.Contents()
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(point.X.Buffer).FillBytes(make([]byte, 32)), nil
+}
+
+// Close flushes the key from the TPM
+func (k *TPMKey) Close() error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.handle == 0 {
+		return nil
+	}
+	_, err := tpm2.FlushContext{FlushHandle: k.handle}.Execute(k.tpm)
+	k.handle = 0
+	return err
+}
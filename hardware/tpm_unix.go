@@ -0,0 +1,19 @@
+//go:build !windows
+
+package hardware
+
+import (
+	"github.com/google/go-tpm/tpm2/transport"
+	"github.com/google/go-tpm/tpm2/transport/linuxtpm"
+)
+
+// DefaultTPMPath is the TPM resource manager of Linux
+const DefaultTPMPath = "/dev/tpmrm0"
+
+// OpenTPM opens the TPM device at path, DefaultTPMPath when empty
+func OpenTPM(path string) (transport.TPMCloser, error) {
+	if path == "" {
+		path = DefaultTPMPath
+	}
+	return linuxtpm.Open(path)
+}
@@ -0,0 +1,16 @@
+//go:build windows
+
+package hardware
+
+import (
+	"github.com/google/go-tpm/tpm2/transport"
+	"github.com/google/go-tpm/tpm2/transport/windowstpm"
+)
+
+// DefaultTPMPath is unused on Windows, where TBS brokers the TPM
+const DefaultTPMPath = ""
+
+// OpenTPM opens the TPM through TBS, path is ignored
+func OpenTPM(_ string) (transport.TPMCloser, error) {
+	return windowstpm.Open()
+}
@@ -0,0 +1,355 @@
+// Package hd implements hierarchical deterministic key derivation: BIP32 for secp256k1,
+// https://github.com/bitcoin/bips/blob/master/bip-0032.mediawiki, and SLIP-0010 for Ed25519 and P-256,
+// https://github.com/satoshilabs/slips/blob/master/slip-0010.md
+//
+// Ed25519 keys only support hardened derivation and have no public derivation. Extended keys serialize in the
+// BIP32 format for every curve, the Ed25519 key data being 0x00 followed by the private key or the public key.
+package hd
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"golang.org/x/crypto/ripemd160" //nolint:staticcheck
+
+	"github.com/go-sonr/crypto/core/curves"
+)
+
+// HardenedOffset is the first hardened child index.
+const HardenedOffset uint32 = 0x80000000
+
+var (
+	// ErrHardenedPublic is returned when deriving a hardened child from a public key.
+	ErrHardenedPublic = fmt.Errorf("cannot derive a hardened child from a public key")
+	// ErrInvalidChild is returned for the negligible fraction of secp256k1 indices without a valid child, the
+	// caller should use the next index.
+	ErrInvalidChild = fmt.Errorf("invalid child, use the next index")
+)
+
+// ExtendedKey is a private or public key with its chain code and position in the tree.
+type ExtendedKey struct {
+	curve             *curves.Curve
+	key               []byte // 32 byte private key, nil for public keys
+	pub               []byte // 33 byte public key
+	chainCode         []byte
+	depth             byte
+	parentFingerprint [4]byte
+	childIndex        uint32
+}
+
+// NewMasterKey returns the master private key of seed for curve, which must be K256, P256 or ED25519.
+func NewMasterKey(seed []byte, curve *curves.Curve) (*ExtendedKey, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, fmt.Errorf("seed must be between 16 and 64 bytes")
+	}
+	hmacKey, err := seedKey(curve)
+	if err != nil {
+		return nil, err
+	}
+	data := seed
+	for {
+		i := hmacSHA512([]byte(hmacKey), data)
+		il, ir := i[:32], i[32:]
+		if curve.Name == curves.ED25519Name || validScalar(curve, il) {
+			return newPrivate(curve, il, ir, 0, [4]byte{}, 0)
+		}
+		if curve.Name == curves.K256Name {
+			return nil, fmt.Errorf("invalid master key, use another seed")
+		}
+		// SLIP-0010: retry with I as the data
+		data = i
+	}
+}
+
+// Child returns the child at index, hardened if index >= HardenedOffset. Public keys only have non-hardened
+// children.
+func (k *ExtendedKey) Child(index uint32) (*ExtendedKey, error) {
+	hardened := index >= HardenedOffset
+	if k.curve.Name == curves.ED25519Name && !hardened {
+		return nil, fmt.Errorf("ed25519 only supports hardened derivation")
+	}
+	if !k.IsPrivate() && hardened {
+		return nil, ErrHardenedPublic
+	}
+	var data []byte
+	if hardened {
+		data = append([]byte{0}, k.key...)
+	} else {
+		data = append([]byte{}, k.pub...)
+	}
+	data = binary.BigEndian.AppendUint32(data, index)
+	fingerprint := k.Fingerprint()
+	for {
+		i := hmacSHA512(k.chainCode, data)
+		il, ir := i[:32], i[32:]
+		if k.curve.Name == curves.ED25519Name {
+			return newPrivate(k.curve, il, ir, k.depth+1, fingerprint, index)
+		}
+		child, err := k.derive(il, ir, fingerprint, index)
+		if err == nil {
+			return child, nil
+		}
+		if k.curve.Name == curves.K256Name {
+			return nil, err
+		}
+		// SLIP-0010: retry with 0x01 || IR || index
+		data = binary.BigEndian.AppendUint32(append([]byte{1}, ir...), index)
+	}
+}
+
+// ChildTweak returns the tweak and the chain code of the non-hardened child index of the public key pub with
+// chainCode. The child private key is the parent private key plus the tweak, which lets parties holding shares of
+// the parent key derive shares of the child without reconstructing it.
+func ChildTweak(curve *curves.Curve, pub, chainCode []byte, index uint32) (curves.Scalar, []byte, error) {
+	if _, err := seedKey(curve); err != nil {
+		return nil, nil, err
+	}
+	if curve.Name == curves.ED25519Name {
+		return nil, nil, fmt.Errorf("ed25519 only supports hardened derivation")
+	}
+	if index >= HardenedOffset {
+		return nil, nil, ErrHardenedPublic
+	}
+	if len(chainCode) != 32 {
+		return nil, nil, fmt.Errorf("chain code must be 32 bytes")
+	}
+	parent := &ExtendedKey{curve: curve, pub: pub, chainCode: chainCode}
+	if _, err := parent.Point(); err != nil {
+		return nil, nil, fmt.Errorf("invalid public key")
+	}
+	data := binary.BigEndian.AppendUint32(append([]byte{}, pub...), index)
+	for {
+		i := hmacSHA512(chainCode, data)
+		il, ir := i[:32], i[32:]
+		_, err := parent.derive(il, ir, [4]byte{}, index)
+		if err == nil {
+			tweak, err := scalar(curve, il)
+			if err != nil {
+				return nil, nil, err
+			}
+			return tweak, append([]byte{}, ir...), nil
+		}
+		if curve.Name == curves.K256Name {
+			return nil, nil, err
+		}
+		data = binary.BigEndian.AppendUint32(append([]byte{1}, ir...), index)
+	}
+}
+
+// derive computes k_i = parse256(IL) + k_par or K_i = parse256(IL)·G + K_par.
+func (k *ExtendedKey) derive(il, ir []byte, fingerprint [4]byte, index uint32) (*ExtendedKey, error) {
+	if !validScalar(k.curve, il) {
+		return nil, ErrInvalidChild
+	}
+	tweak, err := scalar(k.curve, il)
+	if err != nil {
+		return nil, err
+	}
+	if k.IsPrivate() {
+		parent, err := scalar(k.curve, k.key)
+		if err != nil {
+			return nil, err
+		}
+		child := tweak.Add(parent)
+		if child.IsZero() {
+			return nil, ErrInvalidChild
+		}
+		return newPrivate(k.curve, scalarBytes(child), ir, k.depth+1, fingerprint, index)
+	}
+	parent, err := k.curve.Point.FromAffineCompressed(k.pub)
+	if err != nil {
+		return nil, err
+	}
+	child := k.curve.ScalarBaseMult(tweak).Add(parent)
+	if child.IsIdentity() {
+		return nil, ErrInvalidChild
+	}
+	return &ExtendedKey{
+		curve:             k.curve,
+		pub:               child.ToAffineCompressed(),
+		chainCode:         append([]byte{}, ir...),
+		depth:             k.depth + 1,
+		parentFingerprint: fingerprint,
+		childIndex:        index,
+	}, nil
+}
+
+// Derive follows path, see ParsePath, from this key.
+func (k *ExtendedKey) Derive(path string) (*ExtendedKey, error) {
+	indices, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	key := k
+	for _, index := range indices {
+		if key, err = key.Child(index); err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+// Neuter returns the public key of k.
+func (k *ExtendedKey) Neuter() (*ExtendedKey, error) {
+	if k.curve.Name == curves.ED25519Name {
+		return nil, fmt.Errorf("ed25519 keys have no public derivation")
+	}
+	return &ExtendedKey{
+		curve:             k.curve,
+		pub:               k.pub,
+		chainCode:         k.chainCode,
+		depth:             k.depth,
+		parentFingerprint: k.parentFingerprint,
+		childIndex:        k.childIndex,
+	}, nil
+}
+
+// IsPrivate returns true for private keys.
+func (k *ExtendedKey) IsPrivate() bool {
+	return k.key != nil
+}
+
+// Curve returns the curve of the key.
+func (k *ExtendedKey) Curve() *curves.Curve {
+	return k.curve
+}
+
+// PrivateKey returns the 32 byte private key, or nil for a public key.
+func (k *ExtendedKey) PrivateKey() []byte {
+	return append([]byte(nil), k.key...)
+}
+
+// Scalar returns the private key as a scalar of a K256 or P256 key.
+func (k *ExtendedKey) Scalar() (curves.Scalar, error) {
+	if !k.IsPrivate() {
+		return nil, fmt.Errorf("not a private key")
+	}
+	if k.curve.Name == curves.ED25519Name {
+		return nil, fmt.Errorf("ed25519 private keys are seeds, not scalars")
+	}
+	return scalar(k.curve, k.key)
+}
+
+// PublicKey returns the 33 byte public key: compressed SEC1 for K256 and P256, 0x00 and the RFC 8032 public key
+// for Ed25519.
+func (k *ExtendedKey) PublicKey() []byte {
+	return append([]byte{}, k.pub...)
+}
+
+// Point returns the public key as a point.
+func (k *ExtendedKey) Point() (curves.Point, error) {
+	if k.curve.Name == curves.ED25519Name {
+		return k.curve.Point.FromAffineCompressed(k.pub[1:])
+	}
+	return k.curve.Point.FromAffineCompressed(k.pub)
+}
+
+// ChainCode returns the chain code.
+func (k *ExtendedKey) ChainCode() []byte {
+	return append([]byte{}, k.chainCode...)
+}
+
+// Depth returns the number of derivations from the master key.
+func (k *ExtendedKey) Depth() byte {
+	return k.depth
+}
+
+// ChildIndex returns the index of the key in its parent.
+func (k *ExtendedKey) ChildIndex() uint32 {
+	return k.childIndex
+}
+
+// Fingerprint returns the first 4 bytes of HASH160 of the public key.
+func (k *ExtendedKey) Fingerprint() [4]byte {
+	sha := sha256.Sum256(k.pub)
+	h := ripemd160.New()
+	h.Write(sha[:])
+	var out [4]byte
+	copy(out[:], h.Sum(nil))
+	return out
+}
+
+// Equal returns true if both keys are the same node of the same tree.
+func (k *ExtendedKey) Equal(other *ExtendedKey) bool {
+	return other != nil && k.curve.Name == other.curve.Name && bytes.Equal(k.key, other.key) &&
+		bytes.Equal(k.pub, other.pub) && bytes.Equal(k.chainCode, other.chainCode) && k.depth == other.depth &&
+		k.parentFingerprint == other.parentFingerprint && k.childIndex == other.childIndex
+}
+
+func newPrivate(curve *curves.Curve, key, chainCode []byte, depth byte, fingerprint [4]byte, index uint32) (*ExtendedKey, error) {
+	pub, err := publicKey(curve, key)
+	if err != nil {
+		return nil, err
+	}
+	return &ExtendedKey{
+		curve:             curve,
+		key:               append([]byte{}, key...),
+		pub:               pub,
+		chainCode:         append([]byte{}, chainCode...),
+		depth:             depth,
+		parentFingerprint: fingerprint,
+		childIndex:        index,
+	}, nil
+}
+
+func publicKey(curve *curves.Curve, key []byte) ([]byte, error) {
+	if curve.Name == curves.ED25519Name {
+		pub := ed25519.NewKeyFromSeed(key).Public().(ed25519.PublicKey)
+		return append([]byte{0}, pub...), nil
+	}
+	s, err := scalar(curve, key)
+	if err != nil {
+		return nil, err
+	}
+	return curve.ScalarBaseMult(s).ToAffineCompressed(), nil
+}
+
+func seedKey(curve *curves.Curve) (string, error) {
+	if curve == nil {
+		return "", fmt.Errorf("invalid curve")
+	}
+	switch curve.Name {
+	case curves.K256Name:
+		return "Bitcoin seed", nil
+	case curves.P256Name:
+		return "Nist256p1 seed", nil
+	case curves.ED25519Name:
+		return "ed25519 seed", nil
+	default:
+		return "", fmt.Errorf("unsupported curve %s", curve.Name)
+	}
+}
+
+// validScalar returns true if b is a big-endian integer in [1, n).
+func validScalar(curve *curves.Curve, b []byte) bool {
+	x := new(big.Int).SetBytes(b)
+	return x.Sign() > 0 && x.Cmp(order(curve)) < 0
+}
+
+func order(curve *curves.Curve) *big.Int {
+	return new(big.Int).Add(curve.Scalar.One().Neg().BigInt(), big.NewInt(1))
+}
+
+func scalar(curve *curves.Curve, b []byte) (curves.Scalar, error) {
+	if !validScalar(curve, b) {
+		return nil, fmt.Errorf("invalid private key")
+	}
+	return curve.Scalar.SetBigInt(new(big.Int).SetBytes(b))
+}
+
+func scalarBytes(s curves.Scalar) []byte {
+	out := make([]byte, 32)
+	return s.BigInt().FillBytes(out)
+}
+
+func hmacSHA512(key, data []byte) []byte {
+	mac := hmac.New(sha512.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
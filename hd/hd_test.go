@@ -0,0 +1,168 @@
+package hd
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+)
+
+var testSeed, _ = hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+
+func TestBIP32Vector1(t *testing.T) {
+	master, err := NewMasterKey(testSeed, curves.K256())
+	require.NoError(t, err)
+	vectors := []struct {
+		path, xprv, xpub string
+	}{
+		{
+			"m",
+			"xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi",
+			"xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8",
+		},
+		{
+			"m/0H",
+			"xprv9uHRZZhk6KAJC1avXpDAp4MDc3sQKNxDiPvvkX8Br5ngLNv1TxvUxt4cV1rGL5hj6KCesnDYUhd7oWgT11eZG7XnxHrnYeSvkzY7d2bhkJ7",
+			"xpub68Gmy5EdvgibQVfPdqkBBCHxA5htiqg55crXYuXoQRKfDBFA1WEjWgP6LHhwBZeNK1VTsfTFUHCdrfp1bgwQ9xv5ski8PX9rL2dZXvgGDnw",
+		},
+		{
+			"m/0H/1",
+			"xprv9wTYmMFdV23N2TdNG573QoEsfRrWKQgWeibmLntzniatZvR9BmLnvSxqu53Kw1UmYPxLgboyZQaXwTCg8MSY3H2EU4pWcQDnRnrVA1xe8fs",
+			"xpub6ASuArnXKPbfEwhqN6e3mwBcDTgzisQN1wXN9BJcM47sSikHjJf3UFHKkNAWbWMiGj7Wf5uMash7SyYq527Hqck2AxYysAA7xmALppuCkwQ",
+		},
+	}
+	for _, v := range vectors {
+		key, err := master.Derive(v.path)
+		require.NoError(t, err)
+		require.Equal(t, v.xprv, key.String())
+		pub, err := key.Neuter()
+		require.NoError(t, err)
+		require.Equal(t, v.xpub, pub.String())
+
+		parsed, err := ParseExtendedKey(v.xprv, curves.K256())
+		require.NoError(t, err)
+		require.True(t, parsed.Equal(key))
+		parsed, err = ParseExtendedKey(v.xpub, curves.K256())
+		require.NoError(t, err)
+		require.True(t, parsed.Equal(pub))
+	}
+}
+
+func TestSLIP10Vector1(t *testing.T) {
+	vectors := []struct {
+		curve                 *curves.Curve
+		path, chain, key, pub string
+	}{
+		{
+			curves.ED25519(), "m",
+			"90046a93de5380a72b5e45010748567d5ea02bbf6522f979e05c0d8d8ca9fffb",
+			"2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7",
+			"00a4b2856bfec510abab89753fac1ac0e1112364e7d250545963f135f2a33188ed",
+		},
+		{
+			curves.ED25519(), "m/0'",
+			"8b59aa11380b624e81507a27fedda59fea6d0b779a778918a2fd3590e16e9c69",
+			"68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3",
+			"008c8a13df77a28f3445213a0f432fde644acaa215fc72dcdf300d5efaa85d350c",
+		},
+		{
+			curves.P256(), "m",
+			"beeb672fe4621673f722f38529c07392fecaa61015c80c34f29ce8b41b3cb6ea",
+			"612091aaa12e22dd2abef664f8a01a82cae99ad7441b7ef8110424915c268bc2",
+			"0266874dc6ade47b3ecd096745ca09bcd29638dd52c2c12117b11ed3e458cfa9e8",
+		},
+	}
+	for _, v := range vectors {
+		master, err := NewMasterKey(testSeed, v.curve)
+		require.NoError(t, err)
+		key, err := master.Derive(v.path)
+		require.NoError(t, err)
+		require.Equal(t, v.chain, hex.EncodeToString(key.ChainCode()))
+		require.Equal(t, v.key, hex.EncodeToString(key.PrivateKey()))
+		require.Equal(t, v.pub, hex.EncodeToString(key.PublicKey()))
+
+		parsed, err := ParseExtendedKey(key.Serialize(Testnet), v.curve)
+		require.NoError(t, err)
+		require.True(t, parsed.Equal(key))
+	}
+}
+
+func TestPublicDerivation(t *testing.T) {
+	for _, curve := range []*curves.Curve{curves.K256(), curves.P256()} {
+		master, err := NewMasterKey(testSeed, curve)
+		require.NoError(t, err)
+		account, err := master.Derive("m/44'/60'/0'")
+		require.NoError(t, err)
+		accountPub, err := account.Neuter()
+		require.NoError(t, err)
+
+		child, err := account.Derive("m/0/7")
+		require.NoError(t, err)
+		childPub, err := accountPub.Derive("0/7")
+		require.NoError(t, err)
+		require.Equal(t, child.PublicKey(), childPub.PublicKey())
+		require.Equal(t, child.ChainCode(), childPub.ChainCode())
+		require.Equal(t, child.Fingerprint(), childPub.Fingerprint())
+
+		s, err := child.Scalar()
+		require.NoError(t, err)
+		p, err := childPub.Point()
+		require.NoError(t, err)
+		require.True(t, curve.ScalarBaseMult(s).Equal(p))
+
+		_, err = accountPub.Child(HardenedOffset)
+		require.ErrorIs(t, err, ErrHardenedPublic)
+	}
+}
+
+func TestEd25519HardenedOnly(t *testing.T) {
+	master, err := NewMasterKey(testSeed, curves.ED25519())
+	require.NoError(t, err)
+	_, err = master.Child(0)
+	require.Error(t, err)
+	_, err = master.Neuter()
+	require.Error(t, err)
+	_, err = master.Scalar()
+	require.Error(t, err)
+	key, err := master.Derive("m/44'/501'/0'")
+	require.NoError(t, err)
+	_, err = key.Point()
+	require.NoError(t, err)
+}
+
+func TestParsePath(t *testing.T) {
+	indices, err := ParsePath("m/44'/0h/1H/0/2147483647")
+	require.NoError(t, err)
+	require.Equal(t, []uint32{HardenedOffset + 44, HardenedOffset, HardenedOffset + 1, 0, HardenedOffset - 1}, indices)
+	require.Equal(t, "m/44'/0'/1'/0/2147483647", FormatPath(indices))
+
+	indices, err = ParsePath("m")
+	require.NoError(t, err)
+	require.Empty(t, indices)
+
+	for _, path := range []string{"m/", "m//1", "m/2147483648", "m/-1", "m/+1", "m/1x", "m/'", "x/1"} {
+		_, err = ParsePath(path)
+		require.Error(t, err, path)
+	}
+}
+
+func TestInvalidInputs(t *testing.T) {
+	_, err := NewMasterKey(testSeed[:15], curves.K256())
+	require.Error(t, err)
+	_, err = NewMasterKey(testSeed, curves.BLS12381G1())
+	require.Error(t, err)
+	_, err = NewMasterKey(testSeed, nil)
+	require.Error(t, err)
+
+	master, err := NewMasterKey(testSeed, curves.K256())
+	require.NoError(t, err)
+	s := master.String()
+	_, err = ParseExtendedKey(s[:len(s)-1]+"j", curves.K256())
+	require.Error(t, err)
+	_, err = ParseExtendedKey(s[:20], curves.K256())
+	require.Error(t, err)
+	_, err = ParseExtendedKey("0OIl", curves.K256())
+	require.Error(t, err)
+}
@@ -0,0 +1,53 @@
+package hd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParsePath parses a derivation path such as m/44'/0'/0'/0/0 into child indices. Hardened indices are marked by
+// ', h or H. The leading m is optional and m alone is the empty path.
+func ParsePath(path string) ([]uint32, error) {
+	path = strings.TrimSpace(path)
+	parts := strings.Split(path, "/")
+	if parts[0] == "m" {
+		parts = parts[1:]
+	}
+	indices := make([]uint32, 0, len(parts))
+	for _, part := range parts {
+		hardened := false
+		if n := len(part); n > 0 && (part[n-1] == '\'' || part[n-1] == 'h' || part[n-1] == 'H') {
+			hardened = true
+			part = part[:n-1]
+		}
+		if part == "" || part[0] == '+' || part[0] == '-' {
+			return nil, fmt.Errorf("invalid path component in %q", path)
+		}
+		index, err := strconv.ParseUint(part, 10, 32)
+		if err != nil || uint32(index) >= HardenedOffset {
+			return nil, fmt.Errorf("invalid path component in %q", path)
+		}
+		if hardened {
+			index += uint64(HardenedOffset)
+		}
+		indices = append(indices, uint32(index))
+	}
+	return indices, nil
+}
+
+// FormatPath formats child indices as a derivation path, marking hardened indices with '.
+func FormatPath(indices []uint32) string {
+	var b strings.Builder
+	b.WriteString("m")
+	for _, index := range indices {
+		b.WriteString("/")
+		if index >= HardenedOffset {
+			b.WriteString(strconv.FormatUint(uint64(index-HardenedOffset), 10))
+			b.WriteString("'")
+		} else {
+			b.WriteString(strconv.FormatUint(uint64(index), 10))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,118 @@
+package hd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/mr-tron/base58"
+
+	"github.com/go-sonr/crypto/core/curves"
+)
+
+// Network holds the version bytes of serialized extended keys.
+type Network struct {
+	Private uint32
+	Public  uint32
+}
+
+var (
+	// Mainnet serializes keys as xprv and xpub.
+	Mainnet = Network{Private: 0x0488ADE4, Public: 0x0488B21E}
+	// Testnet serializes keys as tprv and tpub.
+	Testnet = Network{Private: 0x04358394, Public: 0x043587CF}
+)
+
+const serializedLen = 78
+
+// Serialize encodes k in the 78 byte BIP32 format with the network's version bytes, Base58Check encoded.
+func (k *ExtendedKey) Serialize(net Network) string {
+	out := make([]byte, 0, serializedLen+4)
+	if k.IsPrivate() {
+		out = binary.BigEndian.AppendUint32(out, net.Private)
+	} else {
+		out = binary.BigEndian.AppendUint32(out, net.Public)
+	}
+	out = append(out, k.depth)
+	out = append(out, k.parentFingerprint[:]...)
+	out = binary.BigEndian.AppendUint32(out, k.childIndex)
+	out = append(out, k.chainCode...)
+	if k.IsPrivate() {
+		out = append(out, 0)
+		out = append(out, k.key...)
+	} else {
+		out = append(out, k.pub...)
+	}
+	return base58.Encode(append(out, checksum(out)...))
+}
+
+// String returns the mainnet serialization of k.
+func (k *ExtendedKey) String() string {
+	return k.Serialize(Mainnet)
+}
+
+// ParseExtendedKey decodes a serialized key of curve with mainnet or testnet version bytes.
+func ParseExtendedKey(s string, curve *curves.Curve) (*ExtendedKey, error) {
+	if _, err := seedKey(curve); err != nil {
+		return nil, err
+	}
+	data, err := base58.Decode(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) != serializedLen+4 {
+		return nil, fmt.Errorf("invalid extended key length")
+	}
+	payload := data[:serializedLen]
+	if !bytes.Equal(checksum(payload), data[serializedLen:]) {
+		return nil, fmt.Errorf("invalid extended key checksum")
+	}
+	var private bool
+	switch binary.BigEndian.Uint32(payload[:4]) {
+	case Mainnet.Private, Testnet.Private:
+		private = true
+	case Mainnet.Public, Testnet.Public:
+		private = false
+	default:
+		return nil, fmt.Errorf("unknown extended key version")
+	}
+	depth := payload[4]
+	var fingerprint [4]byte
+	copy(fingerprint[:], payload[5:9])
+	index := binary.BigEndian.Uint32(payload[9:13])
+	chainCode := payload[13:45]
+	keyData := payload[45:]
+	if depth == 0 && (fingerprint != [4]byte{} || index != 0) {
+		return nil, fmt.Errorf("invalid master key")
+	}
+	if private {
+		if keyData[0] != 0 {
+			return nil, fmt.Errorf("invalid private key")
+		}
+		if curve.Name != curves.ED25519Name && !validScalar(curve, keyData[1:]) {
+			return nil, fmt.Errorf("invalid private key")
+		}
+		return newPrivate(curve, keyData[1:], chainCode, depth, fingerprint, index)
+	}
+	if curve.Name == curves.ED25519Name {
+		return nil, fmt.Errorf("ed25519 keys have no public derivation")
+	}
+	if _, err := curve.Point.FromAffineCompressed(keyData); err != nil {
+		return nil, fmt.Errorf("invalid public key")
+	}
+	return &ExtendedKey{
+		curve:             curve,
+		pub:               append([]byte{}, keyData...),
+		chainCode:         append([]byte{}, chainCode...),
+		depth:             depth,
+		parentFingerprint: fingerprint,
+		childIndex:        index,
+	}, nil
+}
+
+func checksum(data []byte) []byte {
+	first := sha256.Sum256(data)
+	second := sha256.Sum256(first[:])
+	return second[:4]
+}
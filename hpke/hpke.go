@@ -0,0 +1,398 @@
+// Package hpke implements Hybrid Public Key Encryption of RFC 9180
+// https://www.rfc-editor.org/rfc/rfc9180.html with DHKEM(X25519) and
+// DHKEM(P-256), HKDF-SHA256, and AES-GCM or ChaCha20-Poly1305.
+//
+// A sender runs one of the Setup*S functions of a Suite with the recipient
+// public key and transmits the encapsulated key next to its ciphertexts. The
+// recipient runs the matching Setup*R function and opens the ciphertexts in
+// the order they were sealed.
+package hpke
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"io"
+	"math"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/go-sonr/crypto/internal"
+)
+
+// Mode is the HPKE mode of RFC 9180 section 5
+type Mode byte
+
+const (
+	// ModeBase encrypts to a recipient public key
+	ModeBase Mode = 0x00
+	// ModePSK additionally authenticates the sender with a pre-shared key
+	ModePSK Mode = 0x01
+	// ModeAuth additionally authenticates the sender with its private key
+	ModeAuth Mode = 0x02
+	// ModeAuthPSK authenticates the sender with both a key pair and a pre-shared key
+	ModeAuthPSK Mode = 0x03
+)
+
+// KDF is a key derivation function identifier of RFC 9180 section 7.2
+type KDF uint16
+
+// KDFHKDFSHA256 is HKDF-SHA256
+const KDFHKDFSHA256 KDF = 0x0001
+
+// AEAD is an authenticated encryption identifier of RFC 9180 section 7.3
+type AEAD uint16
+
+const (
+	// AEADAES128GCM is AES-128-GCM
+	AEADAES128GCM AEAD = 0x0001
+	// AEADAES256GCM is AES-256-GCM
+	AEADAES256GCM AEAD = 0x0002
+	// AEADChaCha20Poly1305 is ChaCha20-Poly1305
+	AEADChaCha20Poly1305 AEAD = 0x0003
+	// AEADExportOnly is a context that can only export secrets
+	AEADExportOnly AEAD = 0xFFFF
+)
+
+const labelVersion = "HPKE-v1"
+
+// Suite is a combination of KEM, KDF and AEAD
+type Suite struct {
+	KEM  KEM
+	KDF  KDF
+	AEAD AEAD
+}
+
+// Context is an encryption context established by a Setup function. A
+// Context is not safe for concurrent use.
+type Context struct {
+	aead      cipher.AEAD
+	baseNonce []byte
+	seq       uint64
+	exporter  []byte
+	kdf       *labeledKDF
+}
+
+// labeledKDF is LabeledExtract and LabeledExpand of RFC 9180 section 4
+type labeledKDF struct {
+	hash    func() hash.Hash
+	suiteID []byte
+}
+
+func (k *labeledKDF) extract(salt []byte, label string, ikm []byte) []byte {
+	labeled := make([]byte, 0, len(labelVersion)+len(k.suiteID)+len(label)+len(ikm))
+	labeled = append(labeled, labelVersion...)
+	labeled = append(labeled, k.suiteID...)
+	labeled = append(labeled, label...)
+	labeled = append(labeled, ikm...)
+	return hkdf.Extract(k.hash, labeled, salt)
+}
+
+func (k *labeledKDF) expand(prk []byte, label string, info []byte, length int) ([]byte, error) {
+	if length > math.MaxUint16 || length > 255*k.hash().Size() {
+		return nil, fmt.Errorf("invalid expand length %d", length)
+	}
+	labeled := make([]byte, 2, 2+len(labelVersion)+len(k.suiteID)+len(label)+len(info))
+	binary.BigEndian.PutUint16(labeled, uint16(length))
+	labeled = append(labeled, labelVersion...)
+	labeled = append(labeled, k.suiteID...)
+	labeled = append(labeled, label...)
+	labeled = append(labeled, info...)
+	out := make([]byte, length)
+	if _, err := io.ReadFull(hkdf.Expand(k.hash, prk, labeled), out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s Suite) id() []byte {
+	return []byte{'H', 'P', 'K', 'E',
+		byte(s.KEM >> 8), byte(s.KEM),
+		byte(s.KDF >> 8), byte(s.KDF),
+		byte(s.AEAD >> 8), byte(s.AEAD),
+	}
+}
+
+func (s Suite) kdf() (*labeledKDF, error) {
+	if s.KDF != KDFHKDFSHA256 {
+		return nil, fmt.Errorf("unsupported KDF 0x%04x", uint16(s.KDF))
+	}
+	return &labeledKDF{hash: sha256.New, suiteID: s.id()}, nil
+}
+
+// keyLength returns Nk and Nn of the AEAD
+func (s Suite) keyLength() (int, int, error) {
+	switch s.AEAD {
+	case AEADAES128GCM:
+		return 16, 12, nil
+	case AEADAES256GCM:
+		return 32, 12, nil
+	case AEADChaCha20Poly1305:
+		return chacha20poly1305.KeySize, chacha20poly1305.NonceSize, nil
+	case AEADExportOnly:
+		return 0, 0, nil
+	default:
+		return 0, 0, fmt.Errorf("unsupported AEAD 0x%04x", uint16(s.AEAD))
+	}
+}
+
+func (s Suite) newAEAD(key []byte) (cipher.AEAD, error) {
+	switch s.AEAD {
+	case AEADAES128GCM, AEADAES256GCM:
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		return cipher.NewGCM(block)
+	case AEADChaCha20Poly1305:
+		return chacha20poly1305.New(key)
+	default:
+		return nil, nil
+	}
+}
+
+// GenerateKeyPair draws a KEM key pair from reader and returns the
+// serialized private and public key
+func (s Suite) GenerateKeyPair(reader io.Reader) ([]byte, []byte, error) {
+	kem, err := newKEM(s.KEM)
+	if err != nil {
+		return nil, nil, err
+	}
+	return kem.generateKeyPair(reader)
+}
+
+// DeriveKeyPair deterministically derives a KEM key pair from ikm, which
+// must hold at least as many bytes of entropy as the private key
+func (s Suite) DeriveKeyPair(ikm []byte) ([]byte, []byte, error) {
+	kem, err := newKEM(s.KEM)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(ikm) < kem.nSk {
+		return nil, nil, fmt.Errorf("ikm must be at least %d bytes", kem.nSk)
+	}
+	return kem.deriveKeyPair(ikm)
+}
+
+// SetupBaseS establishes a sender context for pkR and returns the encapsulated key
+func (s Suite) SetupBaseS(pkR, info []byte, reader io.Reader) ([]byte, *Context, error) {
+	return s.setupS(ModeBase, pkR, info, nil, nil, nil, reader)
+}
+
+// SetupBaseR establishes the recipient context for enc
+func (s Suite) SetupBaseR(enc, skR, info []byte) (*Context, error) {
+	return s.setupR(ModeBase, enc, skR, info, nil, nil, nil)
+}
+
+// SetupPSKS establishes a sender context for pkR authenticated by psk
+func (s Suite) SetupPSKS(pkR, info, psk, pskID []byte, reader io.Reader) ([]byte, *Context, error) {
+	return s.setupS(ModePSK, pkR, info, psk, pskID, nil, reader)
+}
+
+// SetupPSKR establishes the recipient context for enc authenticated by psk
+func (s Suite) SetupPSKR(enc, skR, info, psk, pskID []byte) (*Context, error) {
+	return s.setupR(ModePSK, enc, skR, info, psk, pskID, nil)
+}
+
+// SetupAuthS establishes a sender context for pkR authenticated by the sender key skS
+func (s Suite) SetupAuthS(pkR, info, skS []byte, reader io.Reader) ([]byte, *Context, error) {
+	if skS == nil {
+		return nil, nil, internal.ErrNilArguments
+	}
+	return s.setupS(ModeAuth, pkR, info, nil, nil, skS, reader)
+}
+
+// SetupAuthR establishes the recipient context for enc from the sender public key pkS
+func (s Suite) SetupAuthR(enc, skR, info, pkS []byte) (*Context, error) {
+	if pkS == nil {
+		return nil, internal.ErrNilArguments
+	}
+	return s.setupR(ModeAuth, enc, skR, info, nil, nil, pkS)
+}
+
+// SetupAuthPSKS establishes a sender context for pkR authenticated by skS and psk
+func (s Suite) SetupAuthPSKS(pkR, info, psk, pskID, skS []byte, reader io.Reader) ([]byte, *Context, error) {
+	if skS == nil {
+		return nil, nil, internal.ErrNilArguments
+	}
+	return s.setupS(ModeAuthPSK, pkR, info, psk, pskID, skS, reader)
+}
+
+// SetupAuthPSKR establishes the recipient context for enc from pkS and psk
+func (s Suite) SetupAuthPSKR(enc, skR, info, psk, pskID, pkS []byte) (*Context, error) {
+	if pkS == nil {
+		return nil, internal.ErrNilArguments
+	}
+	return s.setupR(ModeAuthPSK, enc, skR, info, psk, pskID, pkS)
+}
+
+// Seal is the single shot base mode encryption of pt to pkR. It returns
+// the encapsulated key and the ciphertext.
+func (s Suite) Seal(pkR, info, aad, pt []byte, reader io.Reader) ([]byte, []byte, error) {
+	enc, ctx, err := s.SetupBaseS(pkR, info, reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	ct, err := ctx.Seal(aad, pt)
+	if err != nil {
+		return nil, nil, err
+	}
+	return enc, ct, nil
+}
+
+// Open is the single shot base mode decryption of ct
+func (s Suite) Open(enc, skR, info, aad, ct []byte) ([]byte, error) {
+	ctx, err := s.SetupBaseR(enc, skR, info)
+	if err != nil {
+		return nil, err
+	}
+	return ctx.Open(aad, ct)
+}
+
+func (s Suite) setupS(mode Mode, pkR, info, psk, pskID, skS []byte, reader io.Reader) ([]byte, *Context, error) {
+	skE, _, err := s.GenerateKeyPair(reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.setupSWithEphemeral(mode, pkR, info, psk, pskID, skS, skE)
+}
+
+// setupSWithEphemeral is setupS with a fixed ephemeral key for the test vectors
+func (s Suite) setupSWithEphemeral(mode Mode, pkR, info, psk, pskID, skS, skE []byte) ([]byte, *Context, error) {
+	kem, err := newKEM(s.KEM)
+	if err != nil {
+		return nil, nil, err
+	}
+	secret, enc, err := kem.encap(pkR, skS, skE)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx, err := s.keySchedule(mode, secret, info, psk, pskID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return enc, ctx, nil
+}
+
+func (s Suite) setupR(mode Mode, enc, skR, info, psk, pskID, pkS []byte) (*Context, error) {
+	kem, err := newKEM(s.KEM)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := kem.decap(enc, skR, pkS)
+	if err != nil {
+		return nil, err
+	}
+	return s.keySchedule(mode, secret, info, psk, pskID)
+}
+
+// keySchedule is KeySchedule of RFC 9180 section 5.1
+func (s Suite) keySchedule(mode Mode, sharedSecret, info, psk, pskID []byte) (*Context, error) {
+	if err := verifyPSKInputs(mode, psk, pskID); err != nil {
+		return nil, err
+	}
+	kdf, err := s.kdf()
+	if err != nil {
+		return nil, err
+	}
+	nk, nn, err := s.keyLength()
+	if err != nil {
+		return nil, err
+	}
+	pskIDHash := kdf.extract(nil, "psk_id_hash", pskID)
+	infoHash := kdf.extract(nil, "info_hash", info)
+	context := append(append([]byte{byte(mode)}, pskIDHash...), infoHash...)
+	secret := kdf.extract(sharedSecret, "secret", psk)
+
+	ctx := &Context{kdf: kdf}
+	if s.AEAD != AEADExportOnly {
+		key, err := kdf.expand(secret, "key", context, nk)
+		if err != nil {
+			return nil, err
+		}
+		if ctx.baseNonce, err = kdf.expand(secret, "base_nonce", context, nn); err != nil {
+			return nil, err
+		}
+		if ctx.aead, err = s.newAEAD(key); err != nil {
+			return nil, err
+		}
+	}
+	if ctx.exporter, err = kdf.expand(secret, "exp", context, kdf.hash().Size()); err != nil {
+		return nil, err
+	}
+	return ctx, nil
+}
+
+// verifyPSKInputs is VerifyPSKInputs of RFC 9180 section 5.1
+func verifyPSKInputs(mode Mode, psk, pskID []byte) error {
+	gotPSK := len(psk) > 0
+	gotPSKID := len(pskID) > 0
+	if gotPSK != gotPSKID {
+		return fmt.Errorf("inconsistent PSK inputs")
+	}
+	switch mode {
+	case ModeBase, ModeAuth:
+		if gotPSK {
+			return fmt.Errorf("PSK input provided when not needed")
+		}
+	case ModePSK, ModeAuthPSK:
+		if !gotPSK {
+			return fmt.Errorf("missing required PSK input")
+		}
+		if len(psk) < 32 {
+			return fmt.Errorf("PSK must have at least 32 bytes")
+		}
+	default:
+		return fmt.Errorf("invalid mode %d", mode)
+	}
+	return nil
+}
+
+func (c *Context) nonce() []byte {
+	nonce := append([]byte{}, c.baseNonce...)
+	var seq [8]byte
+	binary.BigEndian.PutUint64(seq[:], c.seq)
+	for i := range seq {
+		nonce[len(nonce)-8+i] ^= seq[i]
+	}
+	return nonce
+}
+
+// Seal encrypts pt with the associated data aad and the next sequence number
+func (c *Context) Seal(aad, pt []byte) ([]byte, error) {
+	if c.aead == nil {
+		return nil, fmt.Errorf("context is export only")
+	}
+	if c.seq == math.MaxUint64 {
+		return nil, fmt.Errorf("message limit reached")
+	}
+	ct := c.aead.Seal(nil, c.nonce(), pt, aad)
+	c.seq++
+	return ct, nil
+}
+
+// Open decrypts ct with the associated data aad and the next sequence number.
+// The sequence number only advances when decryption succeeds.
+func (c *Context) Open(aad, ct []byte) ([]byte, error) {
+	if c.aead == nil {
+		return nil, fmt.Errorf("context is export only")
+	}
+	if c.seq == math.MaxUint64 {
+		return nil, fmt.Errorf("message limit reached")
+	}
+	pt, err := c.aead.Open(nil, c.nonce(), ct, aad)
+	if err != nil {
+		return nil, err
+	}
+	c.seq++
+	return pt, nil
+}
+
+// Export derives length bytes bound to exporterContext from the context secret
+func (c *Context) Export(exporterContext []byte, length int) ([]byte, error) {
+	return c.kdf.expand(c.exporter, "sec", exporterContext, length)
+}
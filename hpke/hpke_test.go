@@ -0,0 +1,160 @@
+package hpke
+
+import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func unhex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+func TestRFC9180BaseVector(t *testing.T) {
+	// RFC 9180 appendix A.1.1, DHKEM(X25519, HKDF-SHA256), HKDF-SHA256, AES-128-GCM
+	suite := Suite{KEMX25519HKDFSHA256, KDFHKDFSHA256, AEADAES128GCM}
+	info := unhex(t, "4f6465206f6e2061204772656369616e2055726e")
+
+	skE, pkE, err := suite.DeriveKeyPair(unhex(t, "7268600d403fce431561aef583ee1613527cff655c1343f29812e66706df3234"))
+	require.NoError(t, err)
+	require.Equal(t, "52c4a758a802cd8b936eceea314432798d5baf2d7e9235dc084ab1b9cfa2f736", hex.EncodeToString(skE))
+	require.Equal(t, "37fda3567bdbd628e88668c3c8d7e97d1d1253b6d4ea6d44c150f741f1bf4431", hex.EncodeToString(pkE))
+	skR, pkR, err := suite.DeriveKeyPair(unhex(t, "6db9df30aa07dd42ee5e8181afdb977e538f5e1fec8a06223f33f7013e525037"))
+	require.NoError(t, err)
+	require.Equal(t, "4612c550263fc8ad58375df3f557aac531d26850903e55a9f23f21d8534e8ac8", hex.EncodeToString(skR))
+
+	enc, sender, err := suite.setupSWithEphemeral(ModeBase, pkR, info, nil, nil, nil, skE)
+	require.NoError(t, err)
+	require.Equal(t, pkE, enc)
+	receiver, err := suite.SetupBaseR(enc, skR, info)
+	require.NoError(t, err)
+	require.Equal(t, "56d890e5accaaf011cff4b7d", hex.EncodeToString(sender.baseNonce))
+	require.Equal(t, "45ff1c2e220db587171952c0592d5f5ebe103f1561a2614e38f2ffd47e99e3f8", hex.EncodeToString(sender.exporter))
+
+	ct, err := sender.Seal([]byte("Count-0"), []byte("Beauty is truth, truth beauty"))
+	require.NoError(t, err)
+	require.Equal(t, "f938558b5d72f1a23810b4be2ab4f84331acc02fc97babc53a52ae8218a355a96d8770ac83d07bea87e13c512a", hex.EncodeToString(ct))
+	pt, err := receiver.Open([]byte("Count-0"), ct)
+	require.NoError(t, err)
+	require.Equal(t, []byte("Beauty is truth, truth beauty"), pt)
+}
+
+func TestModes(t *testing.T) {
+	psk := make([]byte, 32)
+	pskID := []byte("psk id")
+	info := []byte("info")
+	for _, suite := range []Suite{
+		{KEMX25519HKDFSHA256, KDFHKDFSHA256, AEADAES128GCM},
+		{KEMX25519HKDFSHA256, KDFHKDFSHA256, AEADChaCha20Poly1305},
+		{KEMP256HKDFSHA256, KDFHKDFSHA256, AEADAES128GCM},
+		{KEMP256HKDFSHA256, KDFHKDFSHA256, AEADAES256GCM},
+	} {
+		skR, pkR, err := suite.GenerateKeyPair(crand.Reader)
+		require.NoError(t, err)
+		skS, pkS, err := suite.GenerateKeyPair(crand.Reader)
+		require.NoError(t, err)
+
+		type setup func() ([]byte, *Context, error)
+		type open func(enc []byte) (*Context, error)
+		for _, mode := range []struct {
+			s setup
+			r open
+		}{
+			{
+				func() ([]byte, *Context, error) { return suite.SetupBaseS(pkR, info, crand.Reader) },
+				func(enc []byte) (*Context, error) { return suite.SetupBaseR(enc, skR, info) },
+			},
+			{
+				func() ([]byte, *Context, error) { return suite.SetupPSKS(pkR, info, psk, pskID, crand.Reader) },
+				func(enc []byte) (*Context, error) { return suite.SetupPSKR(enc, skR, info, psk, pskID) },
+			},
+			{
+				func() ([]byte, *Context, error) { return suite.SetupAuthS(pkR, info, skS, crand.Reader) },
+				func(enc []byte) (*Context, error) { return suite.SetupAuthR(enc, skR, info, pkS) },
+			},
+			{
+				func() ([]byte, *Context, error) { return suite.SetupAuthPSKS(pkR, info, psk, pskID, skS, crand.Reader) },
+				func(enc []byte) (*Context, error) { return suite.SetupAuthPSKR(enc, skR, info, psk, pskID, pkS) },
+			},
+		} {
+			enc, sender, err := mode.s()
+			require.NoError(t, err)
+			receiver, err := mode.r(enc)
+			require.NoError(t, err)
+			for i := 0; i < 3; i++ {
+				ct, err := sender.Seal([]byte("aad"), []byte("message"))
+				require.NoError(t, err)
+				_, err = receiver.Open([]byte("other"), ct)
+				require.Error(t, err)
+				pt, err := receiver.Open([]byte("aad"), ct)
+				require.NoError(t, err)
+				require.Equal(t, []byte("message"), pt)
+			}
+			e1, err := sender.Export([]byte("ctx"), 48)
+			require.NoError(t, err)
+			e2, err := receiver.Export([]byte("ctx"), 48)
+			require.NoError(t, err)
+			require.Equal(t, e1, e2)
+		}
+
+		// a wrong sender key or psk derives another context
+		_, otherPkS, err := suite.GenerateKeyPair(crand.Reader)
+		require.NoError(t, err)
+		enc, sender, err := suite.SetupAuthS(pkR, info, skS, crand.Reader)
+		require.NoError(t, err)
+		ct, err := sender.Seal(nil, []byte("message"))
+		require.NoError(t, err)
+		receiver, err := suite.SetupAuthR(enc, skR, info, otherPkS)
+		require.NoError(t, err)
+		_, err = receiver.Open(nil, ct)
+		require.Error(t, err)
+
+		_, err = suite.SetupPSKR(enc, skR, info, make([]byte, 16), pskID)
+		require.Error(t, err)
+		_, err = suite.SetupPSKR(enc, skR, info, psk, nil)
+		require.Error(t, err)
+	}
+}
+
+func TestSingleShot(t *testing.T) {
+	suite := Suite{KEMP256HKDFSHA256, KDFHKDFSHA256, AEADChaCha20Poly1305}
+	skR, pkR, err := suite.GenerateKeyPair(crand.Reader)
+	require.NoError(t, err)
+	enc, ct, err := suite.Seal(pkR, nil, []byte("aad"), []byte("hello"), crand.Reader)
+	require.NoError(t, err)
+	require.Len(t, enc, 65)
+	pt, err := suite.Open(enc, skR, nil, []byte("aad"), ct)
+	require.NoError(t, err)
+	require.Equal(t, []byte("hello"), pt)
+
+	_, err = suite.Open(enc[:33], skR, nil, []byte("aad"), ct)
+	require.Error(t, err)
+	bad := append([]byte{}, enc...)
+	bad[64] ^= 1
+	_, err = suite.Open(bad, skR, nil, []byte("aad"), ct)
+	require.Error(t, err)
+}
+
+func TestExportOnly(t *testing.T) {
+	suite := Suite{KEMX25519HKDFSHA256, KDFHKDFSHA256, AEADExportOnly}
+	skR, pkR, err := suite.GenerateKeyPair(crand.Reader)
+	require.NoError(t, err)
+	enc, sender, err := suite.SetupBaseS(pkR, nil, crand.Reader)
+	require.NoError(t, err)
+	_, err = sender.Seal(nil, []byte("message"))
+	require.Error(t, err)
+	receiver, err := suite.SetupBaseR(enc, skR, nil)
+	require.NoError(t, err)
+	e1, err := sender.Export(nil, 32)
+	require.NoError(t, err)
+	e2, err := receiver.Export(nil, 32)
+	require.NoError(t, err)
+	require.Equal(t, e1, e2)
+
+	_, _, err = Suite{KEM: 0x0021, KDF: KDFHKDFSHA256, AEAD: AEADAES128GCM}.GenerateKeyPair(crand.Reader)
+	require.Error(t, err)
+}
@@ -0,0 +1,210 @@
+package hpke
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/keyexchange"
+)
+
+// KEM is a key encapsulation mechanism identifier of RFC 9180 section 7.1
+type KEM uint16
+
+const (
+	// KEMP256HKDFSHA256 is DHKEM(P-256, HKDF-SHA256)
+	KEMP256HKDFSHA256 KEM = 0x0010
+	// KEMX25519HKDFSHA256 is DHKEM(X25519, HKDF-SHA256)
+	KEMX25519HKDFSHA256 KEM = 0x0020
+)
+
+// dhkem is the DHKEM of RFC 9180 section 4.1
+type dhkem struct {
+	id          KEM
+	suiteID     []byte
+	nSecret     int
+	nPk         int
+	nSk         int
+	kdf         *labeledKDF
+	dh          func(sk, pk []byte) ([]byte, error)
+	publicKey   func(sk []byte) ([]byte, error)
+	deriveKey   func(k *dhkem, ikm []byte) ([]byte, error)
+	validatePub func(pk []byte) error
+}
+
+func newKEM(id KEM) (*dhkem, error) {
+	suiteID := []byte{'K', 'E', 'M', byte(id >> 8), byte(id)}
+	kdf := &labeledKDF{hash: sha256.New, suiteID: suiteID}
+	switch id {
+	case KEMX25519HKDFSHA256:
+		return &dhkem{
+			id: id, suiteID: suiteID, nSecret: 32, nPk: 32, nSk: 32, kdf: kdf,
+			dh: keyexchange.X25519.ScalarMult,
+			publicKey: func(sk []byte) ([]byte, error) {
+				key, err := keyexchange.X25519.NewPrivateKey(sk)
+				if err != nil {
+					return nil, err
+				}
+				return key.PublicKey(), nil
+			},
+			deriveKey: func(k *dhkem, ikm []byte) ([]byte, error) {
+				prk := k.kdf.extract(nil, "dkp_prk", ikm)
+				return k.kdf.expand(prk, "sk", nil, k.nSk)
+			},
+			validatePub: func(pk []byte) error {
+				if len(pk) != 32 {
+					return fmt.Errorf("invalid public key length")
+				}
+				return nil
+			},
+		}, nil
+	case KEMP256HKDFSHA256:
+		return &dhkem{
+			id: id, suiteID: suiteID, nSecret: 32, nPk: 65, nSk: 32, kdf: kdf,
+			dh: func(sk, pk []byte) ([]byte, error) {
+				s, err := curves.P256().Scalar.SetBytes(sk)
+				if err != nil {
+					return nil, err
+				}
+				p, err := curves.P256().Point.FromAffineUncompressed(pk)
+				if err != nil {
+					return nil, err
+				}
+				return keyexchange.ECDH(s, p)
+			},
+			publicKey: func(sk []byte) ([]byte, error) {
+				s, err := curves.P256().Scalar.SetBytes(sk)
+				if err != nil {
+					return nil, err
+				}
+				if s.IsZero() {
+					return nil, internal.ErrZeroValue
+				}
+				return curves.P256().ScalarBaseMult(s).ToAffineUncompressed(), nil
+			},
+			deriveKey: deriveP256Key,
+			validatePub: func(pk []byte) error {
+				p, err := curves.P256().Point.FromAffineUncompressed(pk)
+				if err != nil {
+					return err
+				}
+				if p.IsIdentity() || !p.IsOnCurve() {
+					return internal.ErrNotOnCurve
+				}
+				return nil
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported KEM 0x%04x", uint16(id))
+	}
+}
+
+// deriveP256Key is the rejection sampling DeriveKeyPair of RFC 9180 section 7.1.3
+func deriveP256Key(k *dhkem, ikm []byte) ([]byte, error) {
+	prk := k.kdf.extract(nil, "dkp_prk", ikm)
+	order := curves.NistP256Curve().Params().N
+	for counter := 0; counter < 256; counter++ {
+		candidate, err := k.kdf.expand(prk, "candidate", []byte{byte(counter)}, k.nSk)
+		if err != nil {
+			return nil, err
+		}
+		v := new(big.Int).SetBytes(candidate)
+		if v.Sign() != 0 && v.Cmp(order) < 0 {
+			return candidate, nil
+		}
+	}
+	return nil, fmt.Errorf("unable to derive key pair")
+}
+
+// deriveKeyPair returns the private and public key derived from ikm
+func (k *dhkem) deriveKeyPair(ikm []byte) ([]byte, []byte, error) {
+	sk, err := k.deriveKey(k, ikm)
+	if err != nil {
+		return nil, nil, err
+	}
+	pk, err := k.publicKey(sk)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sk, pk, nil
+}
+
+func (k *dhkem) generateKeyPair(reader io.Reader) ([]byte, []byte, error) {
+	if reader == nil {
+		return nil, nil, internal.ErrNilArguments
+	}
+	ikm := make([]byte, k.nSk)
+	if _, err := io.ReadFull(reader, ikm); err != nil {
+		return nil, nil, err
+	}
+	return k.deriveKeyPair(ikm)
+}
+
+func (k *dhkem) extractAndExpand(dh, kemContext []byte) ([]byte, error) {
+	prk := k.kdf.extract(nil, "eae_prk", dh)
+	return k.kdf.expand(prk, "shared_secret", kemContext, k.nSecret)
+}
+
+// encap is Encap, or AuthEncap when skS is set, with the ephemeral key skE
+func (k *dhkem) encap(pkR, skS, skE []byte) ([]byte, []byte, error) {
+	if err := k.validatePub(pkR); err != nil {
+		return nil, nil, fmt.Errorf("invalid recipient key: %w", err)
+	}
+	enc, err := k.publicKey(skE)
+	if err != nil {
+		return nil, nil, err
+	}
+	dh, err := k.dh(skE, pkR)
+	if err != nil {
+		return nil, nil, err
+	}
+	kemContext := append(append([]byte{}, enc...), pkR...)
+	if skS != nil {
+		pkS, err := k.publicKey(skS)
+		if err != nil {
+			return nil, nil, err
+		}
+		dhS, err := k.dh(skS, pkR)
+		if err != nil {
+			return nil, nil, err
+		}
+		dh = append(dh, dhS...)
+		kemContext = append(kemContext, pkS...)
+	}
+	secret, err := k.extractAndExpand(dh, kemContext)
+	if err != nil {
+		return nil, nil, err
+	}
+	return secret, enc, nil
+}
+
+// decap is Decap, or AuthDecap when pkS is set
+func (k *dhkem) decap(enc, skR, pkS []byte) ([]byte, error) {
+	if err := k.validatePub(enc); err != nil {
+		return nil, fmt.Errorf("invalid encapsulated key: %w", err)
+	}
+	pkR, err := k.publicKey(skR)
+	if err != nil {
+		return nil, err
+	}
+	dh, err := k.dh(skR, enc)
+	if err != nil {
+		return nil, err
+	}
+	kemContext := append(append([]byte{}, enc...), pkR...)
+	if pkS != nil {
+		if err := k.validatePub(pkS); err != nil {
+			return nil, fmt.Errorf("invalid sender key: %w", err)
+		}
+		dhS, err := k.dh(skR, pkS)
+		if err != nil {
+			return nil, err
+		}
+		dh = append(dh, dhS...)
+		kemContext = append(kemContext, pkS...)
+	}
+	return k.extractAndExpand(dh, kemContext)
+}
@@ -0,0 +1,152 @@
+// Package jose produces and verifies compact JSON Web Signatures of RFC
+// 7515 and JSON Web Tokens of RFC 7519 with EdDSA, ES256, ES384, ES256K and
+// RS256, signing with libp2p private keys and verifying with did:keys.
+//
+// The kid header may be a did:key or a did:key verification method id, in
+// which case ResolveDIDKey turns it into a verifier through keys/parsers.
+// Verification always checks the alg header against the verifier, so a
+// token cannot pick its own algorithm, and rejects critical headers.
+//
+// JSON Web Encryption of RFC 7516 is provided with ECDH-ES+A256KW key
+// agreement and A256GCM content encryption. Recipients may be did:keys,
+// Ed25519 keys are mapped to X25519, and a message to several recipients
+// uses the general JSON serialization.
+package jose
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/go-sonr/crypto/internal"
+)
+
+var b64 = base64.RawURLEncoding
+
+// Header is the protected header of a compact JWS
+type Header struct {
+	Algorithm   Algorithm `json:"alg"`
+	Type        string    `json:"typ,omitempty"`
+	ContentType string    `json:"cty,omitempty"`
+	KeyID       string    `json:"kid,omitempty"`
+	Critical    []string  `json:"crit,omitempty"`
+}
+
+// JWS is a parsed compact JWS whose signature is not yet verified
+type JWS struct {
+	Header    Header
+	Payload   []byte
+	Signature []byte
+
+	signingInput []byte
+}
+
+// Sign returns the compact JWS of payload signed by signer. The header may
+// be nil, its alg is set to the algorithm of signer.
+func Sign(payload []byte, signer Signer, header *Header) (string, error) {
+	if signer == nil {
+		return "", internal.ErrNilArguments
+	}
+	h := Header{}
+	if header != nil {
+		h = *header
+	}
+	if h.Algorithm != "" && h.Algorithm != signer.Algorithm() {
+		return "", fmt.Errorf("header algorithm %s does not match signer %s", h.Algorithm, signer.Algorithm())
+	}
+	h.Algorithm = signer.Algorithm()
+	encoded, err := json.Marshal(h)
+	if err != nil {
+		return "", err
+	}
+	signingInput := b64.EncodeToString(encoded) + "." + b64.EncodeToString(payload)
+	sig, err := signer.Sign([]byte(signingInput))
+	if err != nil {
+		return "", err
+	}
+	return signingInput + "." + b64.EncodeToString(sig), nil
+}
+
+// Parse decodes a compact JWS without verifying it
+func Parse(token string) (*JWS, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("compact JWS must have three parts")
+	}
+	header, err := b64.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("malformed JWS header: %w", err)
+	}
+	jws := &JWS{signingInput: []byte(parts[0] + "." + parts[1])}
+	dec := json.NewDecoder(bytes.NewReader(header))
+	if err := dec.Decode(&jws.Header); err != nil {
+		return nil, fmt.Errorf("malformed JWS header: %w", err)
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("malformed JWS header: trailing data")
+	}
+	if jws.Payload, err = b64.DecodeString(parts[1]); err != nil {
+		return nil, fmt.Errorf("malformed JWS payload: %w", err)
+	}
+	if jws.Signature, err = b64.DecodeString(parts[2]); err != nil {
+		return nil, fmt.Errorf("malformed JWS signature: %w", err)
+	}
+	return jws, nil
+}
+
+// Verify checks the signature of the JWS with verifier
+func (j *JWS) Verify(verifier Verifier) error {
+	if verifier == nil {
+		return internal.ErrNilArguments
+	}
+	if j.Header.Algorithm != verifier.Algorithm() {
+		return fmt.Errorf("algorithm %q does not match verifier %s", j.Header.Algorithm, verifier.Algorithm())
+	}
+	// no header extensions are understood, RFC 7515 section 4.1.11
+	if j.Header.Critical != nil {
+		return fmt.Errorf("unsupported critical header parameters %v", j.Header.Critical)
+	}
+	return verifier.Verify(j.signingInput, j.Signature)
+}
+
+// Verify parses a compact JWS and checks it with verifier, returning its
+// header and payload
+func Verify(token string, verifier Verifier) (*Header, []byte, error) {
+	jws, err := Parse(token)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := jws.Verify(verifier); err != nil {
+		return nil, nil, err
+	}
+	return &jws.Header, jws.Payload, nil
+}
+
+// VerifyResolved parses a compact JWS and checks it with the verifier
+// resolve returns for its kid. A nil resolve is ResolveDIDKey.
+func VerifyResolved(token string, resolve Resolver) (*Header, []byte, error) {
+	jws, err := Parse(token)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := jws.verifyResolved(resolve); err != nil {
+		return nil, nil, err
+	}
+	return &jws.Header, jws.Payload, nil
+}
+
+func (j *JWS) verifyResolved(resolve Resolver) error {
+	if resolve == nil {
+		resolve = ResolveDIDKey
+	}
+	if j.Header.KeyID == "" {
+		return fmt.Errorf("JWS has no kid to resolve")
+	}
+	verifier, err := resolve(j.Header.KeyID)
+	if err != nil {
+		return err
+	}
+	return j.Verify(verifier)
+}
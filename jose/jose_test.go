@@ -0,0 +1,196 @@
+package jose
+
+import (
+	"crypto/ed25519"
+	"crypto/elliptic"
+	crand "crypto/rand"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/keys/parsers"
+)
+
+// RFC 8037 Appendix A.4, an Ed25519 JWS
+func TestEdDSAVector(t *testing.T) {
+	seed, err := b64.DecodeString("nWGxne_9WmC6hEr0kuwsxERJxWl7MmkZcDusAxyuf2A")
+	require.NoError(t, err)
+	priv, err := crypto.UnmarshalEd25519PrivateKey(ed25519.NewKeyFromSeed(seed))
+	require.NoError(t, err)
+	s, err := NewSigner(priv)
+	require.NoError(t, err)
+
+	const expected = "eyJhbGciOiJFZERTQSJ9.RXhhbXBsZSBvZiBFZDI1NTE5IHNpZ25pbmc." +
+		"hgyY0il_MGCjP0JzlnLWG1PPOt7-09PGcvMg3AIbQR6dWbhijcNR4ki4iylGjg5BhVsPt9g7sVvpAr_MuM0KAg"
+	token, err := Sign([]byte("Example of Ed25519 signing"), s, nil)
+	require.NoError(t, err)
+	require.Equal(t, expected, token)
+
+	id, err := parsers.NewKeyDID(priv.GetPublic())
+	require.NoError(t, err)
+	jwk, err := id.ToJWK()
+	require.NoError(t, err)
+	require.Equal(t, "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo", jwk.X)
+	v, err := NewVerifier(id)
+	require.NoError(t, err)
+	_, payload, err := Verify(expected, v)
+	require.NoError(t, err)
+	require.Equal(t, []byte("Example of Ed25519 signing"), payload)
+}
+
+func generate(t *testing.T) map[Algorithm]crypto.PrivKey {
+	keys := map[Algorithm]crypto.PrivKey{}
+	var err error
+	keys[EdDSA], _, err = crypto.GenerateEd25519Key(crand.Reader)
+	require.NoError(t, err)
+	keys[ES256K], _, err = crypto.GenerateSecp256k1Key(crand.Reader)
+	require.NoError(t, err)
+	keys[ES256], _, err = crypto.GenerateECDSAKeyPairWithCurve(elliptic.P256(), crand.Reader)
+	require.NoError(t, err)
+	keys[ES384], _, err = crypto.GenerateECDSAKeyPairWithCurve(elliptic.P384(), crand.Reader)
+	require.NoError(t, err)
+	keys[RS256], _, err = crypto.GenerateRSAKeyPair(2048, crand.Reader)
+	require.NoError(t, err)
+	return keys
+}
+
+func TestSignVerify(t *testing.T) {
+	keys := generate(t)
+	for alg, priv := range keys {
+		t.Run(string(alg), func(t *testing.T) {
+			s, err := NewSigner(priv)
+			require.NoError(t, err)
+			require.Equal(t, alg, s.Algorithm())
+			id, err := parsers.NewKeyDID(priv.GetPublic())
+			require.NoError(t, err)
+
+			token, err := Sign([]byte(`{"hello":"jose"}`), s, &Header{KeyID: DIDKeyID(id), ContentType: "json"})
+			require.NoError(t, err)
+
+			header, payload, err := VerifyResolved(token, nil)
+			require.NoError(t, err)
+			require.Equal(t, alg, header.Algorithm)
+			require.Equal(t, "json", header.ContentType)
+			require.Equal(t, []byte(`{"hello":"jose"}`), payload)
+
+			// the kid may also be the bare did:key
+			token, err = Sign([]byte("bare"), s, &Header{KeyID: id.String()})
+			require.NoError(t, err)
+			_, _, err = VerifyResolved(token, ResolveDIDKey)
+			require.NoError(t, err)
+
+			parts := strings.Split(token, ".")
+			_, _, err = VerifyResolved(parts[0]+"."+b64.EncodeToString([]byte("other"))+"."+parts[2], nil)
+			require.Error(t, err)
+
+			for other, otherPriv := range keys {
+				if other == alg {
+					continue
+				}
+				otherID, err := parsers.NewKeyDID(otherPriv.GetPublic())
+				require.NoError(t, err)
+				v, err := NewVerifier(otherID)
+				require.NoError(t, err)
+				_, _, err = Verify(token, v)
+				require.ErrorContains(t, err, "does not match")
+			}
+		})
+	}
+}
+
+func TestAlgorithmConfusion(t *testing.T) {
+	priv, _, err := crypto.GenerateEd25519Key(crand.Reader)
+	require.NoError(t, err)
+	id, err := parsers.NewKeyDID(priv.GetPublic())
+	require.NoError(t, err)
+	s, err := NewSigner(priv)
+	require.NoError(t, err)
+
+	_, err = Sign([]byte("x"), s, &Header{Algorithm: ES256})
+	require.Error(t, err)
+
+	for _, header := range []string{`{"alg":"none","kid":"%s"}`, `{"alg":"EdDSA","kid":"%s","crit":["exp"]}`} {
+		h := b64.EncodeToString([]byte(strings.Replace(header, "%s", id.String(), 1)))
+		signingInput := h + "." + b64.EncodeToString([]byte("x"))
+		sig, err := s.Sign([]byte(signingInput))
+		require.NoError(t, err)
+		_, _, err = VerifyResolved(signingInput+"."+b64.EncodeToString(sig), nil)
+		require.Error(t, err)
+	}
+	_, _, err = VerifyResolved("a.b", nil)
+	require.Error(t, err)
+	_, _, err = VerifyResolved("e30.e30.", nil)
+	require.ErrorContains(t, err, "kid")
+}
+
+func TestJWT(t *testing.T) {
+	priv, _, err := crypto.GenerateSecp256k1Key(crand.Reader)
+	require.NoError(t, err)
+	id, err := parsers.NewKeyDID(priv.GetPublic())
+	require.NoError(t, err)
+	s, err := NewSigner(priv)
+	require.NoError(t, err)
+
+	now := time.Unix(1700000000, 0)
+	claims := &Claims{
+		Issuer:    id.String(),
+		Subject:   "alice",
+		Audience:  []string{"did:web:sonr.io"},
+		ExpiresAt: now.Add(time.Hour).Unix(),
+		NotBefore: now.Add(-time.Minute).Unix(),
+		IssuedAt:  now.Unix(),
+		ID:        "1",
+		Extra:     map[string]any{"scope": "vault:read", "iss": "ignored"},
+	}
+	token, err := SignJWT(claims, s, DIDKeyID(id))
+	require.NoError(t, err)
+
+	jws, err := Parse(token)
+	require.NoError(t, err)
+	require.Equal(t, "JWT", jws.Header.Type)
+	var raw map[string]any
+	require.NoError(t, json.Unmarshal(jws.Payload, &raw))
+	require.Equal(t, "did:web:sonr.io", raw["aud"])
+	require.Equal(t, id.String(), raw["iss"])
+
+	valid := Validation{Audience: "did:web:sonr.io", Issuer: id.String(), Now: func() time.Time { return now }}
+	got, err := VerifyJWT(token, nil, valid)
+	require.NoError(t, err)
+	require.Equal(t, "alice", got.Subject)
+	require.Equal(t, "vault:read", got.Extra["scope"])
+	require.Equal(t, claims.ExpiresAt, got.ExpiresAt)
+
+	late := valid
+	late.Now = func() time.Time { return now.Add(2 * time.Hour) }
+	_, err = VerifyJWT(token, nil, late)
+	require.ErrorContains(t, err, "expired")
+	late.Leeway = 2 * time.Hour
+	_, err = VerifyJWT(token, nil, late)
+	require.NoError(t, err)
+
+	early := valid
+	early.Now = func() time.Time { return now.Add(-time.Hour) }
+	_, err = VerifyJWT(token, nil, early)
+	require.ErrorContains(t, err, "not valid yet")
+
+	other := valid
+	other.Audience = "did:web:example.com"
+	_, err = VerifyJWT(token, nil, other)
+	require.Error(t, err)
+
+	// the issuer must be the did:key that signed the token
+	otherPriv, _, err := crypto.GenerateSecp256k1Key(crand.Reader)
+	require.NoError(t, err)
+	otherID, err := parsers.NewKeyDID(otherPriv.GetPublic())
+	require.NoError(t, err)
+	otherSigner, err := NewSigner(otherPriv)
+	require.NoError(t, err)
+	forged, err := SignJWT(claims, otherSigner, DIDKeyID(otherID))
+	require.NoError(t, err)
+	_, err = VerifyJWT(forged, nil, Validation{Now: valid.Now})
+	require.ErrorContains(t, err, "issuer")
+}
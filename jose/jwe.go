@@ -0,0 +1,535 @@
+package jose
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	p2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
+
+	"github.com/go-sonr/crypto/core/secret"
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/keyexchange"
+	"github.com/go-sonr/crypto/keys/parsers"
+)
+
+const (
+	// ECDHESA256KW is key agreement with an ephemeral key whose output
+	// wraps the content encryption key with AES-256 key wrap, RFC 7518
+	// section 4.6
+	ECDHESA256KW = "ECDH-ES+A256KW"
+	// A256GCM is AES-256-GCM content encryption, RFC 7518 section 5.3
+	A256GCM = "A256GCM"
+
+	cekSize = 32
+)
+
+// JWEHeader holds the JWE header parameters this package understands
+type JWEHeader struct {
+	Algorithm    string       `json:"alg,omitempty"`
+	Encryption   string       `json:"enc,omitempty"`
+	KeyID        string       `json:"kid,omitempty"`
+	EphemeralKey *parsers.JWK `json:"epk,omitempty"`
+	Type         string       `json:"typ,omitempty"`
+	ContentType  string       `json:"cty,omitempty"`
+	Critical     []string     `json:"crit,omitempty"`
+}
+
+// merge adds the parameters of o, a parameter may only be set once
+func (h *JWEHeader) merge(o *JWEHeader) error {
+	if o == nil {
+		return nil
+	}
+	for _, f := range []struct {
+		dst *string
+		src string
+	}{
+		{&h.Algorithm, o.Algorithm}, {&h.Encryption, o.Encryption}, {&h.KeyID, o.KeyID},
+		{&h.Type, o.Type}, {&h.ContentType, o.ContentType},
+	} {
+		if f.src == "" {
+			continue
+		}
+		if *f.dst != "" {
+			return fmt.Errorf("duplicate JWE header parameter")
+		}
+		*f.dst = f.src
+	}
+	if o.EphemeralKey != nil {
+		if h.EphemeralKey != nil {
+			return fmt.Errorf("duplicate JWE header parameter epk")
+		}
+		h.EphemeralKey = o.EphemeralKey
+	}
+	if o.Critical != nil {
+		return fmt.Errorf("unsupported critical header parameters %v", o.Critical)
+	}
+	return nil
+}
+
+// Recipient is the key agreement public key a JWE is encrypted to
+type Recipient struct {
+	kid string
+	pub *ecdh.PublicKey
+}
+
+// NewRecipient returns a P-256, P-384 or X25519 recipient identified by kid
+func NewRecipient(pub *ecdh.PublicKey, kid string) (*Recipient, error) {
+	if pub == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if _, err := curveName(pub.Curve()); err != nil {
+		return nil, err
+	}
+	return &Recipient{kid: kid, pub: pub}, nil
+}
+
+// RecipientFromDID returns the recipient of a did:key. Ed25519 keys are
+// mapped to X25519, P-256 and P-384 keys are used directly. The kid is the
+// did:key verification method id.
+func RecipientFromDID(did string) (*Recipient, error) {
+	id, err := parsers.Parse(did)
+	if err != nil {
+		return nil, err
+	}
+	var pub *ecdh.PublicKey
+	switch id.Type() {
+	case p2pcrypto.Ed25519:
+		raw, err := id.Raw()
+		if err != nil {
+			return nil, err
+		}
+		x, err := parsers.Ed25519ToX25519PublicKey(raw)
+		if err != nil {
+			return nil, err
+		}
+		if pub, err = ecdh.X25519().NewPublicKey(x); err != nil {
+			return nil, err
+		}
+	case p2pcrypto.ECDSA:
+		vk, err := id.VerifyKey()
+		if err != nil {
+			return nil, err
+		}
+		if pub, err = vk.(*ecdsa.PublicKey).ECDH(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported key type for JWE: %s", id.Type())
+	}
+	return NewRecipient(pub, DIDKeyID(id))
+}
+
+// KeyID returns the kid of the recipient
+func (r *Recipient) KeyID() string {
+	return r.kid
+}
+
+// DecryptionKey is the private key of a recipient
+type DecryptionKey struct {
+	kid string
+	key *ecdh.PrivateKey
+}
+
+// NewDecryptionKey returns the decryption key of a P-256, P-384 or X25519
+// private key identified by kid
+func NewDecryptionKey(key *ecdh.PrivateKey, kid string) (*DecryptionKey, error) {
+	if key == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if _, err := curveName(key.Curve()); err != nil {
+		return nil, err
+	}
+	return &DecryptionKey{kid: kid, key: key}, nil
+}
+
+// DecryptionKeyFromPrivKey returns the decryption key of the recipient
+// RecipientFromDID returns for the did:key of priv
+func DecryptionKeyFromPrivKey(priv p2pcrypto.PrivKey) (*DecryptionKey, error) {
+	if priv == nil {
+		return nil, internal.ErrNilArguments
+	}
+	id, err := parsers.NewKeyDID(priv.GetPublic())
+	if err != nil {
+		return nil, err
+	}
+	raw, err := priv.Raw()
+	if err != nil {
+		return nil, err
+	}
+	var key *ecdh.PrivateKey
+	switch priv.Type() {
+	case p2pcrypto.Ed25519:
+		if len(raw) != ed25519.PrivateKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 private key length: %d", len(raw))
+		}
+		// the X25519 scalar is the first half of SHA-512(seed) as in RFC 8032
+		h := sha512.Sum512(ed25519.PrivateKey(raw).Seed())
+		defer secret.Wipe(h[:])
+		if key, err = ecdh.X25519().NewPrivateKey(h[:32]); err != nil {
+			return nil, err
+		}
+	case p2pcrypto.ECDSA:
+		ec, err := x509.ParseECPrivateKey(raw)
+		if err != nil {
+			return nil, err
+		}
+		if key, err = ec.ECDH(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported key type for JWE: %s", priv.Type())
+	}
+	return NewDecryptionKey(key, DIDKeyID(id))
+}
+
+func curveName(c ecdh.Curve) (string, error) {
+	switch c {
+	case ecdh.P256():
+		return "P-256", nil
+	case ecdh.P384():
+		return "P-384", nil
+	case ecdh.X25519():
+		return "X25519", nil
+	default:
+		return "", fmt.Errorf("unsupported key agreement curve %v", c)
+	}
+}
+
+// ephemeralJWK encodes an ephemeral public key
+func ephemeralJWK(pub *ecdh.PublicKey) (*parsers.JWK, error) {
+	crv, err := curveName(pub.Curve())
+	if err != nil {
+		return nil, err
+	}
+	if pub.Curve() == ecdh.X25519() {
+		return &parsers.JWK{Kty: "OKP", Crv: crv, X: b64.EncodeToString(pub.Bytes())}, nil
+	}
+	// the uncompressed point is 04 || X || Y
+	b := pub.Bytes()[1:]
+	return &parsers.JWK{Kty: "EC", Crv: crv, X: b64.EncodeToString(b[:len(b)/2]), Y: b64.EncodeToString(b[len(b)/2:])}, nil
+}
+
+// parseEphemeralJWK decodes an ephemeral key on the curve of the recipient
+func parseEphemeralJWK(jwk *parsers.JWK, curve ecdh.Curve) (*ecdh.PublicKey, error) {
+	if jwk == nil {
+		return nil, fmt.Errorf("JWE recipient has no epk")
+	}
+	crv, err := curveName(curve)
+	if err != nil {
+		return nil, err
+	}
+	if jwk.Crv != crv {
+		return nil, fmt.Errorf("epk is on %s, key is on %s", jwk.Crv, crv)
+	}
+	x, err := b64.DecodeString(jwk.X)
+	if err != nil {
+		return nil, fmt.Errorf("malformed epk: %w", err)
+	}
+	if curve == ecdh.X25519() {
+		if jwk.Kty != "OKP" {
+			return nil, fmt.Errorf("malformed epk")
+		}
+		return curve.NewPublicKey(x)
+	}
+	y, err := b64.DecodeString(jwk.Y)
+	if err != nil || jwk.Kty != "EC" || len(x) != len(y) {
+		return nil, fmt.Errorf("malformed epk")
+	}
+	return curve.NewPublicKey(append(append([]byte{4}, x...), y...))
+}
+
+// kek derives the key encryption key of RFC 7518 section 4.6.2 with empty
+// apu and apv
+func kek(shared []byte) ([]byte, error) {
+	var otherInfo []byte
+	for _, field := range []string{ECDHESA256KW, "", ""} {
+		otherInfo = binary.BigEndian.AppendUint32(otherInfo, uint32(len(field)))
+		otherInfo = append(otherInfo, field...)
+	}
+	otherInfo = binary.BigEndian.AppendUint32(otherInfo, 8*cekSize)
+	return keyexchange.ConcatKDF(sha256.New, shared, otherInfo, cekSize)
+}
+
+// JWE is an encrypted message in the general JSON serialization of RFC
+// 7516 section 7.2.1
+type JWE struct {
+	Protected  string         `json:"protected,omitempty"`
+	Recipients []JWERecipient `json:"recipients"`
+	AAD        string         `json:"aad,omitempty"`
+	IV         string         `json:"iv"`
+	Ciphertext string         `json:"ciphertext"`
+	Tag        string         `json:"tag"`
+}
+
+// JWERecipient is the wrapped content encryption key of one recipient
+type JWERecipient struct {
+	Header       *JWEHeader `json:"header,omitempty"`
+	EncryptedKey string     `json:"encrypted_key,omitempty"`
+}
+
+// Encrypt encrypts plaintext with A256GCM to every recipient with
+// ECDH-ES+A256KW. The aad is authenticated but not encrypted, it is only
+// representable in the JSON serialization.
+func Encrypt(plaintext []byte, recipients []*Recipient, aad []byte) (*JWE, error) {
+	if len(recipients) == 0 {
+		return nil, fmt.Errorf("no recipients specified")
+	}
+	cek := make([]byte, cekSize)
+	defer secret.Wipe(cek)
+	if _, err := rand.Read(cek); err != nil {
+		return nil, err
+	}
+	jwe := &JWE{}
+	for _, r := range recipients {
+		if r == nil {
+			return nil, internal.ErrNilArguments
+		}
+		eph, err := r.pub.Curve().GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		shared, err := eph.ECDH(r.pub)
+		if err != nil {
+			return nil, err
+		}
+		key, err := kek(shared)
+		secret.Wipe(shared)
+		if err != nil {
+			return nil, err
+		}
+		wrapped, err := keyWrap(key, cek)
+		secret.Wipe(key)
+		if err != nil {
+			return nil, err
+		}
+		epk, err := ephemeralJWK(eph.PublicKey())
+		if err != nil {
+			return nil, err
+		}
+		jwe.Recipients = append(jwe.Recipients, JWERecipient{
+			Header:       &JWEHeader{Algorithm: ECDHESA256KW, KeyID: r.kid, EphemeralKey: epk},
+			EncryptedKey: b64.EncodeToString(wrapped),
+		})
+	}
+
+	// a single recipient header is protected, which the compact
+	// serialization requires
+	protected := &JWEHeader{Encryption: A256GCM}
+	if len(jwe.Recipients) == 1 {
+		if err := protected.merge(jwe.Recipients[0].Header); err != nil {
+			return nil, err
+		}
+		jwe.Recipients[0].Header = nil
+	}
+	encoded, err := json.Marshal(protected)
+	if err != nil {
+		return nil, err
+	}
+	jwe.Protected = b64.EncodeToString(encoded)
+	if aad != nil {
+		jwe.AAD = b64.EncodeToString(aad)
+	}
+
+	gcm, err := newGCM(cek)
+	if err != nil {
+		return nil, err
+	}
+	iv := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(iv); err != nil {
+		return nil, err
+	}
+	sealed := gcm.Seal(nil, iv, plaintext, jwe.additionalData())
+	jwe.IV = b64.EncodeToString(iv)
+	jwe.Ciphertext = b64.EncodeToString(sealed[:len(sealed)-gcm.Overhead()])
+	jwe.Tag = b64.EncodeToString(sealed[len(sealed)-gcm.Overhead():])
+	return jwe, nil
+}
+
+func newGCM(cek []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// additionalData is the protected header, and the aad when present, as in
+// RFC 7516 section 5.1 step 14
+func (j *JWE) additionalData() []byte {
+	if j.AAD == "" {
+		return []byte(j.Protected)
+	}
+	return []byte(j.Protected + "." + j.AAD)
+}
+
+// Compact returns the compact serialization of a single recipient JWE
+// without aad
+func (j *JWE) Compact() (string, error) {
+	if len(j.Recipients) != 1 || j.Recipients[0].Header != nil || j.AAD != "" {
+		return "", fmt.Errorf("only a single recipient JWE without aad or unprotected headers has a compact serialization")
+	}
+	return strings.Join([]string{j.Protected, j.Recipients[0].EncryptedKey, j.IV, j.Ciphertext, j.Tag}, "."), nil
+}
+
+// ParseJWE decodes a JWE in the compact, general JSON or flattened JSON
+// serialization
+func ParseJWE(data string) (*JWE, error) {
+	data = strings.TrimSpace(data)
+	if !strings.HasPrefix(data, "{") {
+		parts := strings.Split(data, ".")
+		if len(parts) != 5 {
+			return nil, fmt.Errorf("compact JWE must have five parts")
+		}
+		return &JWE{
+			Protected:  parts[0],
+			Recipients: []JWERecipient{{EncryptedKey: parts[1]}},
+			IV:         parts[2],
+			Ciphertext: parts[3],
+			Tag:        parts[4],
+		}, nil
+	}
+	var general struct {
+		JWE
+		// the flattened serialization of RFC 7516 section 7.2.2
+		Header       *JWEHeader `json:"header"`
+		EncryptedKey string     `json:"encrypted_key"`
+		Unprotected  any        `json:"unprotected"`
+	}
+	if err := json.Unmarshal([]byte(data), &general); err != nil {
+		return nil, fmt.Errorf("malformed JWE: %w", err)
+	}
+	if general.Unprotected != nil {
+		return nil, fmt.Errorf("shared unprotected JWE headers are not supported")
+	}
+	jwe := general.JWE
+	if jwe.Recipients == nil {
+		jwe.Recipients = []JWERecipient{{Header: general.Header, EncryptedKey: general.EncryptedKey}}
+	} else if general.Header != nil || general.EncryptedKey != "" {
+		return nil, fmt.Errorf("malformed JWE: both general and flattened members")
+	}
+	return &jwe, nil
+}
+
+// Decrypt unwraps the content encryption key of the recipient of key and
+// decrypts the JWE. A recipient whose kid matches the key is tried first,
+// then every recipient on the curve of the key.
+func (j *JWE) Decrypt(key *DecryptionKey) ([]byte, error) {
+	if key == nil {
+		return nil, internal.ErrNilArguments
+	}
+	raw, err := b64.DecodeString(j.Protected)
+	if err != nil {
+		return nil, fmt.Errorf("malformed JWE protected header: %w", err)
+	}
+	var protected JWEHeader
+	if err := json.Unmarshal(raw, &protected); err != nil {
+		return nil, fmt.Errorf("malformed JWE protected header: %w", err)
+	}
+	if protected.Critical != nil {
+		return nil, fmt.Errorf("unsupported critical header parameters %v", protected.Critical)
+	}
+
+	var headers []JWEHeader
+	for _, r := range j.Recipients {
+		h := protected
+		if err := h.merge(r.Header); err != nil {
+			return nil, err
+		}
+		if h.Encryption != A256GCM {
+			return nil, fmt.Errorf("unsupported JWE encryption %q", h.Encryption)
+		}
+		headers = append(headers, h)
+	}
+	order := make([]int, 0, len(headers))
+	for i, h := range headers {
+		if key.kid != "" && h.KeyID == key.kid {
+			order = append(order, i)
+		}
+	}
+	for i, h := range headers {
+		if key.kid == "" || h.KeyID != key.kid {
+			order = append(order, i)
+		}
+	}
+
+	crv, err := curveName(key.key.Curve())
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range order {
+		h := headers[i]
+		if h.Algorithm != ECDHESA256KW || h.EphemeralKey == nil || h.EphemeralKey.Crv != crv {
+			continue
+		}
+		cek, err := key.unwrap(&h, j.Recipients[i].EncryptedKey)
+		if err != nil {
+			continue
+		}
+		defer secret.Wipe(cek)
+		return j.open(cek)
+	}
+	return nil, fmt.Errorf("no JWE recipient matches the decryption key")
+}
+
+func (k *DecryptionKey) unwrap(h *JWEHeader, encryptedKey string) ([]byte, error) {
+	epk, err := parseEphemeralJWK(h.EphemeralKey, k.key.Curve())
+	if err != nil {
+		return nil, err
+	}
+	wrapped, err := b64.DecodeString(encryptedKey)
+	if err != nil {
+		return nil, err
+	}
+	shared, err := k.key.ECDH(epk)
+	if err != nil {
+		return nil, err
+	}
+	defer secret.Wipe(shared)
+	kek, err := kek(shared)
+	if err != nil {
+		return nil, err
+	}
+	defer secret.Wipe(kek)
+	cek, err := keyUnwrap(kek, wrapped)
+	if err != nil {
+		return nil, err
+	}
+	if len(cek) != cekSize {
+		return nil, fmt.Errorf("invalid content encryption key length %d", len(cek))
+	}
+	return cek, nil
+}
+
+func (j *JWE) open(cek []byte) ([]byte, error) {
+	iv, err := b64.DecodeString(j.IV)
+	if err != nil {
+		return nil, fmt.Errorf("malformed JWE iv: %w", err)
+	}
+	ciphertext, err := b64.DecodeString(j.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("malformed JWE ciphertext: %w", err)
+	}
+	tag, err := b64.DecodeString(j.Tag)
+	if err != nil {
+		return nil, fmt.Errorf("malformed JWE tag: %w", err)
+	}
+	gcm, err := newGCM(cek)
+	if err != nil {
+		return nil, err
+	}
+	if len(iv) != gcm.NonceSize() || len(tag) != gcm.Overhead() {
+		return nil, fmt.Errorf("invalid JWE iv or tag length")
+	}
+	return gcm.Open(nil, iv, append(ciphertext, tag...), j.additionalData())
+}
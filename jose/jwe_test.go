@@ -0,0 +1,181 @@
+package jose
+
+import (
+	"crypto/ecdh"
+	"crypto/elliptic"
+	crand "crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/keys/parsers"
+)
+
+// RFC 3394 section 4.6, 256 bits of key data with a 256 bit KEK
+func TestKeyWrapVector(t *testing.T) {
+	kek, _ := hex.DecodeString("000102030405060708090A0B0C0D0E0F101112131415161718191A1B1C1D1E1F")
+	key, _ := hex.DecodeString("00112233445566778899AABBCCDDEEFF000102030405060708090A0B0C0D0E0F")
+	expected, _ := hex.DecodeString("28C9F404C4B810F4CBCCB35CFB87F8263F5786E2D80ED326CBC7F0E71A99F43BFB988B9B7A02DD21")
+	wrapped, err := keyWrap(kek, key)
+	require.NoError(t, err)
+	require.Equal(t, expected, wrapped)
+	unwrapped, err := keyUnwrap(kek, wrapped)
+	require.NoError(t, err)
+	require.Equal(t, key, unwrapped)
+
+	wrapped[0] ^= 1
+	_, err = keyUnwrap(kek, wrapped)
+	require.Error(t, err)
+}
+
+func didRecipient(t *testing.T, priv crypto.PrivKey) (*Recipient, *DecryptionKey) {
+	id, err := parsers.NewKeyDID(priv.GetPublic())
+	require.NoError(t, err)
+	r, err := RecipientFromDID(id.String())
+	require.NoError(t, err)
+	k, err := DecryptionKeyFromPrivKey(priv)
+	require.NoError(t, err)
+	require.Equal(t, r.KeyID(), k.kid)
+	return r, k
+}
+
+func TestJWECompact(t *testing.T) {
+	ed, _, err := crypto.GenerateEd25519Key(crand.Reader)
+	require.NoError(t, err)
+	p256, _, err := crypto.GenerateECDSAKeyPairWithCurve(elliptic.P256(), crand.Reader)
+	require.NoError(t, err)
+	p384, _, err := crypto.GenerateECDSAKeyPairWithCurve(elliptic.P384(), crand.Reader)
+	require.NoError(t, err)
+
+	for _, priv := range []crypto.PrivKey{ed, p256, p384} {
+		r, k := didRecipient(t, priv)
+		jwe, err := Encrypt([]byte("credential"), []*Recipient{r}, nil)
+		require.NoError(t, err)
+		compact, err := jwe.Compact()
+		require.NoError(t, err)
+		require.Equal(t, 4, strings.Count(compact, "."))
+
+		parsed, err := ParseJWE(compact)
+		require.NoError(t, err)
+		pt, err := parsed.Decrypt(k)
+		require.NoError(t, err)
+		require.Equal(t, []byte("credential"), pt)
+
+		// the protected header carries alg, enc, kid and epk
+		raw, err := b64.DecodeString(parsed.Protected)
+		require.NoError(t, err)
+		var h JWEHeader
+		require.NoError(t, json.Unmarshal(raw, &h))
+		require.Equal(t, ECDHESA256KW, h.Algorithm)
+		require.Equal(t, A256GCM, h.Encryption)
+		require.Equal(t, r.KeyID(), h.KeyID)
+		require.NotNil(t, h.EphemeralKey)
+
+		parts := strings.Split(compact, ".")
+		parts[3] = b64.EncodeToString([]byte("0123456789"))
+		tampered, err := ParseJWE(strings.Join(parts, "."))
+		require.NoError(t, err)
+		_, err = tampered.Decrypt(k)
+		require.Error(t, err)
+	}
+}
+
+func TestJWEGeneral(t *testing.T) {
+	var privs []crypto.PrivKey
+	var recipients []*Recipient
+	for i := 0; i < 3; i++ {
+		priv, _, err := crypto.GenerateEd25519Key(crand.Reader)
+		require.NoError(t, err)
+		privs = append(privs, priv)
+		r, _ := didRecipient(t, priv)
+		recipients = append(recipients, r)
+	}
+	p256, _, err := crypto.GenerateECDSAKeyPairWithCurve(elliptic.P256(), crand.Reader)
+	require.NoError(t, err)
+	privs = append(privs, p256)
+	r, _ := didRecipient(t, p256)
+	recipients = append(recipients, r)
+
+	jwe, err := Encrypt([]byte("to many"), recipients, []byte("context"))
+	require.NoError(t, err)
+	_, err = jwe.Compact()
+	require.Error(t, err)
+	data, err := json.Marshal(jwe)
+	require.NoError(t, err)
+
+	parsed, err := ParseJWE(string(data))
+	require.NoError(t, err)
+	require.Len(t, parsed.Recipients, 4)
+	for _, priv := range privs {
+		k, err := DecryptionKeyFromPrivKey(priv)
+		require.NoError(t, err)
+		pt, err := parsed.Decrypt(k)
+		require.NoError(t, err)
+		require.Equal(t, []byte("to many"), pt)
+
+		// a key with an unknown kid still finds its recipient
+		anon, err := NewDecryptionKey(k.key, "")
+		require.NoError(t, err)
+		_, err = parsed.Decrypt(anon)
+		require.NoError(t, err)
+	}
+
+	stranger, _, err := crypto.GenerateEd25519Key(crand.Reader)
+	require.NoError(t, err)
+	_, k := didRecipient(t, stranger)
+	_, err = parsed.Decrypt(k)
+	require.Error(t, err)
+
+	// the aad and the protected header are authenticated
+	k, err = DecryptionKeyFromPrivKey(privs[0])
+	require.NoError(t, err)
+	modified := *parsed
+	modified.AAD = b64.EncodeToString([]byte("other"))
+	_, err = modified.Decrypt(k)
+	require.Error(t, err)
+	modified = *parsed
+	modified.Protected = b64.EncodeToString([]byte(`{"enc":"A256GCM","typ":"x"}`))
+	_, err = modified.Decrypt(k)
+	require.Error(t, err)
+}
+
+func TestJWEFlattened(t *testing.T) {
+	sk, err := ecdh.X25519().GenerateKey(crand.Reader)
+	require.NoError(t, err)
+	r, err := NewRecipient(sk.PublicKey(), "x25519-key")
+	require.NoError(t, err)
+	k, err := NewDecryptionKey(sk, "x25519-key")
+	require.NoError(t, err)
+
+	jwe, err := Encrypt([]byte("flat"), []*Recipient{r}, nil)
+	require.NoError(t, err)
+	flattened, err := json.Marshal(map[string]string{
+		"protected":     jwe.Protected,
+		"encrypted_key": jwe.Recipients[0].EncryptedKey,
+		"iv":            jwe.IV,
+		"ciphertext":    jwe.Ciphertext,
+		"tag":           jwe.Tag,
+	})
+	require.NoError(t, err)
+	parsed, err := ParseJWE(string(flattened))
+	require.NoError(t, err)
+	pt, err := parsed.Decrypt(k)
+	require.NoError(t, err)
+	require.Equal(t, []byte("flat"), pt)
+
+	_, err = ParseJWE(`{"unprotected":{"x":1},"recipients":[]}`)
+	require.Error(t, err)
+	_, err = ParseJWE("a.b.c")
+	require.Error(t, err)
+
+	_, secp, err := crypto.GenerateSecp256k1Key(crand.Reader)
+	require.NoError(t, err)
+	id, err := parsers.NewKeyDID(secp)
+	require.NoError(t, err)
+	_, err = RecipientFromDID(id.String())
+	require.Error(t, err)
+}
@@ -0,0 +1,184 @@
+package jose
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/keys/parsers"
+)
+
+// Claims is a JWT claims set. The registered claims of RFC 7519 section
+// 4.1 are fields, times are seconds since the epoch and zero when absent.
+// Other claims are kept in Extra.
+type Claims struct {
+	Issuer    string
+	Subject   string
+	Audience  []string
+	ExpiresAt int64
+	NotBefore int64
+	IssuedAt  int64
+	ID        string
+	Extra     map[string]any
+}
+
+var registeredClaims = []string{"iss", "sub", "aud", "exp", "nbf", "iat", "jti"}
+
+// MarshalJSON encodes the claims as one JSON object, a single audience is
+// a string
+func (c *Claims) MarshalJSON() ([]byte, error) {
+	m := make(map[string]any, len(c.Extra)+len(registeredClaims))
+	for k, v := range c.Extra {
+		m[k] = v
+	}
+	for _, k := range registeredClaims {
+		delete(m, k)
+	}
+	set := func(k string, v any, ok bool) {
+		if ok {
+			m[k] = v
+		}
+	}
+	set("iss", c.Issuer, c.Issuer != "")
+	set("sub", c.Subject, c.Subject != "")
+	if len(c.Audience) == 1 {
+		m["aud"] = c.Audience[0]
+	} else {
+		set("aud", c.Audience, len(c.Audience) > 1)
+	}
+	set("exp", c.ExpiresAt, c.ExpiresAt != 0)
+	set("nbf", c.NotBefore, c.NotBefore != 0)
+	set("iat", c.IssuedAt, c.IssuedAt != 0)
+	set("jti", c.ID, c.ID != "")
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON decodes a claims set
+func (c *Claims) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	var out Claims
+	fields := map[string]any{
+		"iss": &out.Issuer, "sub": &out.Subject, "jti": &out.ID,
+		"exp": &out.ExpiresAt, "nbf": &out.NotBefore, "iat": &out.IssuedAt,
+	}
+	for k, v := range raw {
+		if k == "aud" {
+			var single string
+			if err := json.Unmarshal(v, &single); err == nil {
+				out.Audience = []string{single}
+				continue
+			}
+			if err := json.Unmarshal(v, &out.Audience); err != nil {
+				return fmt.Errorf("invalid aud claim: %w", err)
+			}
+			continue
+		}
+		if field, ok := fields[k]; ok {
+			if err := json.Unmarshal(v, field); err != nil {
+				return fmt.Errorf("invalid %s claim: %w", k, err)
+			}
+			continue
+		}
+		if out.Extra == nil {
+			out.Extra = map[string]any{}
+		}
+		var value any
+		if err := json.Unmarshal(v, &value); err != nil {
+			return err
+		}
+		out.Extra[k] = value
+	}
+	*c = out
+	return nil
+}
+
+// Validation holds the checks VerifyJWT applies to the claims
+type Validation struct {
+	// Audience, when set, must be one of the aud claims
+	Audience string
+	// Issuer, when set, must be the iss claim
+	Issuer string
+	// Leeway is the clock skew allowed on exp and nbf
+	Leeway time.Duration
+	// Now defaults to time.Now
+	Now func() time.Time
+}
+
+// SignJWT returns a JWT of claims signed by signer with kid in its header.
+// The kid is usually the did:key of the signer, see DIDKeyID.
+func SignJWT(claims *Claims, signer Signer, kid string) (string, error) {
+	if claims == nil {
+		return "", internal.ErrNilArguments
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	return Sign(payload, signer, &Header{Type: "JWT", KeyID: kid})
+}
+
+// VerifyJWT verifies a JWT with the verifier resolve returns for its kid,
+// ResolveDIDKey when nil, and validates its claims. When the kid is a
+// did:key and the token has an issuer, the issuer must be that did:key.
+func VerifyJWT(token string, resolve Resolver, v Validation) (*Claims, error) {
+	jws, err := Parse(token)
+	if err != nil {
+		return nil, err
+	}
+	if jws.Header.Type != "" && !strings.EqualFold(jws.Header.Type, "JWT") {
+		return nil, fmt.Errorf("unexpected token type %q", jws.Header.Type)
+	}
+	if err := jws.verifyResolved(resolve); err != nil {
+		return nil, err
+	}
+	claims := new(Claims)
+	if err := json.Unmarshal(jws.Payload, claims); err != nil {
+		return nil, fmt.Errorf("malformed JWT claims: %w", err)
+	}
+	if did, _, _ := strings.Cut(jws.Header.KeyID, "#"); strings.HasPrefix(did, parsers.KeyPrefix+":") &&
+		claims.Issuer != "" && claims.Issuer != did {
+		return nil, fmt.Errorf("issuer %q is not the signing key %q", claims.Issuer, did)
+	}
+	if err := claims.Validate(v); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// Validate checks the time, audience and issuer claims
+func (c *Claims) Validate(v Validation) error {
+	now := time.Now()
+	if v.Now != nil {
+		now = v.Now()
+	}
+	if c.ExpiresAt != 0 && !now.Before(time.Unix(c.ExpiresAt, 0).Add(v.Leeway)) {
+		return fmt.Errorf("token has expired")
+	}
+	if c.NotBefore != 0 && now.Add(v.Leeway).Before(time.Unix(c.NotBefore, 0)) {
+		return fmt.Errorf("token is not valid yet")
+	}
+	if v.Issuer != "" && c.Issuer != v.Issuer {
+		return fmt.Errorf("unexpected issuer %q", c.Issuer)
+	}
+	if v.Audience != "" {
+		for _, aud := range c.Audience {
+			if aud == v.Audience {
+				return nil
+			}
+		}
+		return fmt.Errorf("token is not intended for %q", v.Audience)
+	}
+	return nil
+}
+
+// DIDKeyID returns the did:key verification method id of a key, the kid
+// ResolveDIDKey resolves
+func DIDKeyID(id parsers.DIDKey) string {
+	did := id.String()
+	return did + "#" + strings.TrimPrefix(did, parsers.KeyPrefix+":")
+}
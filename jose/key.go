@@ -0,0 +1,279 @@
+package jose
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"strings"
+
+	p2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
+
+	"github.com/go-sonr/crypto/keys/parsers"
+)
+
+// Algorithm is a JWS algorithm of RFC 7518 and RFC 8812
+type Algorithm string
+
+const (
+	// EdDSA is pure Ed25519, RFC 8037
+	EdDSA Algorithm = "EdDSA"
+	// ES256 is ECDSA on P-256 with SHA-256
+	ES256 Algorithm = "ES256"
+	// ES384 is ECDSA on P-384 with SHA-384
+	ES384 Algorithm = "ES384"
+	// ES256K is ECDSA on secp256k1 with SHA-256, RFC 8812
+	ES256K Algorithm = "ES256K"
+	// RS256 is RSASSA-PKCS1-v1_5 with SHA-256
+	RS256 Algorithm = "RS256"
+)
+
+// signatureSize is the size of fixed size signatures, ECDSA signatures are
+// R || S of RFC 7518 section 3.4 rather than DER
+func (a Algorithm) signatureSize() int {
+	switch a {
+	case ES384:
+		return 96
+	case EdDSA, ES256, ES256K:
+		return 64
+	default:
+		return 0
+	}
+}
+
+// Signer produces JWS signatures over a signing input
+type Signer interface {
+	Algorithm() Algorithm
+	Sign(signingInput []byte) ([]byte, error)
+}
+
+// Verifier checks JWS signatures over a signing input
+type Verifier interface {
+	Algorithm() Algorithm
+	Verify(signingInput, signature []byte) error
+}
+
+// Resolver returns the verifier of the key a kid refers to
+type Resolver func(kid string) (Verifier, error)
+
+type signer struct {
+	alg  Algorithm
+	sign func(signingInput []byte) ([]byte, error)
+}
+
+func (s *signer) Algorithm() Algorithm {
+	return s.alg
+}
+
+func (s *signer) Sign(signingInput []byte) ([]byte, error) {
+	return s.sign(signingInput)
+}
+
+// NewSigner returns the signer of an Ed25519, P-256, P-384, secp256k1 or
+// RSA private key
+func NewSigner(priv p2pcrypto.PrivKey) (Signer, error) {
+	if priv == nil {
+		return nil, fmt.Errorf("private key is required")
+	}
+	if k, ok := priv.(cryptoSignerKey); ok {
+		return NewCryptoSigner(k.CryptoSigner())
+	}
+	switch priv.Type() {
+	case p2pcrypto.Ed25519:
+		return &signer{alg: EdDSA, sign: priv.Sign}, nil
+	case p2pcrypto.RSA:
+		// libp2p signs RSA keys with PKCS #1 v1.5 over SHA-256
+		return &signer{alg: RS256, sign: priv.Sign}, nil
+	case p2pcrypto.Secp256k1:
+		return &signer{alg: ES256K, sign: func(signingInput []byte) ([]byte, error) {
+			der, err := priv.Sign(signingInput)
+			if err != nil {
+				return nil, err
+			}
+			return derToFixed(der, 32)
+		}}, nil
+	case p2pcrypto.ECDSA:
+		raw, err := priv.Raw()
+		if err != nil {
+			return nil, err
+		}
+		key, err := x509.ParseECPrivateKey(raw)
+		if err != nil {
+			return nil, err
+		}
+		switch key.Curve {
+		case elliptic.P256():
+			return &signer{alg: ES256, sign: func(signingInput []byte) ([]byte, error) {
+				digest := sha256.Sum256(signingInput)
+				return signECDSA(key, digest[:], 32)
+			}}, nil
+		case elliptic.P384():
+			return &signer{alg: ES384, sign: func(signingInput []byte) ([]byte, error) {
+				digest := sha512.Sum384(signingInput)
+				return signECDSA(key, digest[:], 48)
+			}}, nil
+		default:
+			return nil, fmt.Errorf("unsupported ECDSA curve: %s", key.Curve.Params().Name)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported key type for JWS: %s", priv.Type())
+	}
+}
+
+// cryptoSignerKey is a private key that only signs through a crypto.Signer,
+// such as the keys of the hardware package
+type cryptoSignerKey interface {
+	CryptoSigner() crypto.Signer
+}
+
+// NewCryptoSigner returns the signer of a crypto.Signer with an Ed25519,
+// P-256 or P-384 or RSA public key, for keys that cannot be exported
+func NewCryptoSigner(s crypto.Signer) (Signer, error) {
+	if s == nil {
+		return nil, fmt.Errorf("signer is required")
+	}
+	switch pub := s.Public().(type) {
+	case ed25519.PublicKey:
+		return &signer{alg: EdDSA, sign: func(signingInput []byte) ([]byte, error) {
+			return s.Sign(rand.Reader, signingInput, crypto.Hash(0))
+		}}, nil
+	case *ecdsa.PublicKey:
+		var (
+			alg  Algorithm
+			hash crypto.Hash
+		)
+		switch pub.Curve {
+		case elliptic.P256():
+			alg, hash = ES256, crypto.SHA256
+		case elliptic.P384():
+			alg, hash = ES384, crypto.SHA384
+		default:
+			return nil, fmt.Errorf("unsupported ECDSA curve: %s", pub.Curve.Params().Name)
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		return &signer{alg: alg, sign: func(signingInput []byte) ([]byte, error) {
+			h := hash.New()
+			h.Write(signingInput)
+			der, err := s.Sign(rand.Reader, h.Sum(nil), hash)
+			if err != nil {
+				return nil, err
+			}
+			return derToFixed(der, size)
+		}}, nil
+	case *rsa.PublicKey:
+		return &signer{alg: RS256, sign: func(signingInput []byte) ([]byte, error) {
+			digest := sha256.Sum256(signingInput)
+			return s.Sign(rand.Reader, digest[:], crypto.SHA256)
+		}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type for JWS: %T", pub)
+	}
+}
+
+func signECDSA(key *ecdsa.PrivateKey, digest []byte, size int) ([]byte, error) {
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, 2*size)
+	r.FillBytes(out[:size])
+	s.FillBytes(out[size:])
+	return out, nil
+}
+
+// derToFixed converts an ASN.1 DER ECDSA signature into R || S
+func derToFixed(der []byte, size int) ([]byte, error) {
+	var sig struct{ R, S *big.Int }
+	rest, err := asn1.Unmarshal(der, &sig)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 || sig.R.Sign() <= 0 || sig.S.Sign() <= 0 || sig.R.BitLen() > 8*size || sig.S.BitLen() > 8*size {
+		return nil, fmt.Errorf("invalid ECDSA signature")
+	}
+	out := make([]byte, 2*size)
+	sig.R.FillBytes(out[:size])
+	sig.S.FillBytes(out[size:])
+	return out, nil
+}
+
+type verifier struct {
+	alg Algorithm
+	id  parsers.DIDKey
+}
+
+// NewVerifier returns the verifier of the Ed25519, P-256, P-384, secp256k1
+// or RSA key of a did:key
+func NewVerifier(id parsers.DIDKey) (Verifier, error) {
+	if id.PubKey == nil {
+		return nil, fmt.Errorf("did:key has no public key")
+	}
+	var alg Algorithm
+	switch id.Type() {
+	case p2pcrypto.Ed25519:
+		alg = EdDSA
+	case p2pcrypto.RSA:
+		alg = RS256
+	case p2pcrypto.Secp256k1:
+		alg = ES256K
+	case p2pcrypto.ECDSA:
+		vk, err := id.VerifyKey()
+		if err != nil {
+			return nil, err
+		}
+		switch curve := vk.(*ecdsa.PublicKey).Curve; curve {
+		case elliptic.P256():
+			alg = ES256
+		case elliptic.P384():
+			alg = ES384
+		default:
+			return nil, fmt.Errorf("unsupported ECDSA curve: %s", curve.Params().Name)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported key type for JWS: %s", id.Type())
+	}
+	return &verifier{alg: alg, id: id}, nil
+}
+
+func (v *verifier) Algorithm() Algorithm {
+	return v.alg
+}
+
+func (v *verifier) Verify(signingInput, signature []byte) error {
+	if v.alg == RS256 {
+		// the did:key verifier also accepts PSS, RS256 is PKCS #1 v1.5 only
+		vk, err := v.id.VerifyKey()
+		if err != nil {
+			return err
+		}
+		digest := sha256.Sum256(signingInput)
+		if rsa.VerifyPKCS1v15(vk.(*rsa.PublicKey), crypto.SHA256, digest[:], signature) != nil {
+			return parsers.ErrInvalidSignature
+		}
+		return nil
+	}
+	// the did:key verifier also accepts DER, JWS only R || S
+	if len(signature) != v.alg.signatureSize() {
+		return parsers.ErrInvalidSignature
+	}
+	return v.id.Verify(signingInput, signature)
+}
+
+// ResolveDIDKey resolves a kid that is a did:key, or a did:key verification
+// method id with a fragment
+func ResolveDIDKey(kid string) (Verifier, error) {
+	did, _, _ := strings.Cut(kid, "#")
+	id, err := parsers.Parse(did)
+	if err != nil {
+		return nil, fmt.Errorf("resolving kid %q: %w", kid, err)
+	}
+	return NewVerifier(id)
+}
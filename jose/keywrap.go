@@ -0,0 +1,66 @@
+package jose
+
+import (
+	"crypto/aes"
+	"crypto/subtle"
+	"encoding/binary"
+	"fmt"
+)
+
+// keyWrapIV is the default initial value of RFC 3394 section 2.2.3.1
+var keyWrapIV = []byte{0xa6, 0xa6, 0xa6, 0xa6, 0xa6, 0xa6, 0xa6, 0xa6}
+
+// keyWrap wraps key with the AES key wrap of RFC 3394
+func keyWrap(kek, key []byte) ([]byte, error) {
+	if len(key)%8 != 0 || len(key) < 16 {
+		return nil, fmt.Errorf("wrapped key must be a multiple of 8 bytes of at least 16")
+	}
+	block, err := aes.NewCipher(kek)
+	if err != nil {
+		return nil, err
+	}
+	n := len(key) / 8
+	out := make([]byte, 8+len(key))
+	copy(out, keyWrapIV)
+	copy(out[8:], key)
+	var b [16]byte
+	for j := 0; j < 6; j++ {
+		for i := 1; i <= n; i++ {
+			copy(b[:8], out[:8])
+			copy(b[8:], out[8*i:8*i+8])
+			block.Encrypt(b[:], b[:])
+			t := uint64(n*j + i)
+			binary.BigEndian.PutUint64(out[:8], binary.BigEndian.Uint64(b[:8])^t)
+			copy(out[8*i:8*i+8], b[8:])
+		}
+	}
+	return out, nil
+}
+
+// keyUnwrap unwraps a key wrapped by keyWrap and checks its integrity
+func keyUnwrap(kek, wrapped []byte) ([]byte, error) {
+	if len(wrapped)%8 != 0 || len(wrapped) < 24 {
+		return nil, fmt.Errorf("invalid wrapped key length %d", len(wrapped))
+	}
+	block, err := aes.NewCipher(kek)
+	if err != nil {
+		return nil, err
+	}
+	n := len(wrapped)/8 - 1
+	out := append([]byte{}, wrapped...)
+	var b [16]byte
+	for j := 5; j >= 0; j-- {
+		for i := n; i >= 1; i-- {
+			t := uint64(n*j + i)
+			binary.BigEndian.PutUint64(b[:8], binary.BigEndian.Uint64(out[:8])^t)
+			copy(b[8:], out[8*i:8*i+8])
+			block.Decrypt(b[:], b[:])
+			copy(out[:8], b[:8])
+			copy(out[8*i:8*i+8], b[8:])
+		}
+	}
+	if subtle.ConstantTimeCompare(out[:8], keyWrapIV) != 1 {
+		return nil, fmt.Errorf("key unwrap integrity check failed")
+	}
+	return out[8:], nil
+}
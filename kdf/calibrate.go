@@ -0,0 +1,67 @@
+package kdf
+
+import (
+	"crypto"
+	"fmt"
+	"time"
+)
+
+// calibrationInput is the password and salt calibration derives from
+var calibrationInput = []byte("sonr-kdf-calibration")
+
+func measure(k KDF) (time.Duration, error) {
+	start := time.Now()
+	if _, err := k.Key(calibrationInput, calibrationInput, HashSize); err != nil {
+		return 0, err
+	}
+	return max(time.Since(start), time.Microsecond), nil
+}
+
+// CalibrateArgon2id returns Argon2id parameters over memory KiB with
+// threads whose derivations take about target on this machine, and at least
+// one pass. Memory hardness comes from memory, raise it before the time.
+func CalibrateArgon2id(memory uint32, threads uint8, target time.Duration) (Argon2id, error) {
+	a := Argon2id{Time: 1, Memory: memory, Threads: threads}
+	elapsed, err := measure(a)
+	if err != nil {
+		return Argon2id{}, err
+	}
+	if passes := target / elapsed; passes > 1 {
+		a.Time = uint32(min(passes, 1<<16))
+	}
+	return a, nil
+}
+
+// CalibrateScrypt returns scrypt parameters with block size r and
+// parallelism p and the largest N whose derivations take at most about
+// target on this machine, at least 2^10
+func CalibrateScrypt(r, p int, target time.Duration) (Scrypt, error) {
+	s := Scrypt{N: 1 << 10, R: r, P: p}
+	elapsed, err := measure(s)
+	if err != nil {
+		return Scrypt{}, err
+	}
+	// doubling N doubles the time
+	for s.N < MaxScryptN && 2*elapsed <= target {
+		s.N <<= 1
+		elapsed *= 2
+	}
+	return s, nil
+}
+
+// CalibratePBKDF2 returns PBKDF2 parameters with h whose derivations take
+// about target on this machine, at least 1000 iterations
+func CalibratePBKDF2(h crypto.Hash, target time.Duration) (PBKDF2, error) {
+	const probe = 10000
+	p := PBKDF2{Hash: h, Iterations: probe}
+	elapsed, err := measure(p)
+	if err != nil {
+		return PBKDF2{}, err
+	}
+	iterations := int64(probe) * int64(target) / int64(elapsed)
+	if iterations > MaxPBKDF2Iterations {
+		return PBKDF2{}, fmt.Errorf("kdf: target exceeds %d pbkdf2 iterations", MaxPBKDF2Iterations)
+	}
+	p.Iterations = int(max(iterations, 1000))
+	return p, nil
+}
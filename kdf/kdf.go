@@ -0,0 +1,217 @@
+// Package kdf derives keys from passwords with Argon2id of RFC 9106, scrypt
+// of RFC 7914 and PBKDF2 of RFC 8018, so every password derived key in this
+// module is stretched the same way.
+//
+// Hash and Verify store password hashes as PHC strings, Calibrate* pick
+// parameters that take a target time on the running machine, and Stretch
+// turns a KDF into the key stretching function of OPAQUE.
+package kdf
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"hash"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// MaxArgon2Memory bounds Argon2id memory, in KiB, to 4 GiB
+	MaxArgon2Memory = 4 << 20
+	// MaxScryptN bounds the scrypt cost, 4 GiB of memory with r = 8
+	MaxScryptN = 1 << 22
+	// MaxPBKDF2Iterations bounds the PBKDF2 iteration count
+	MaxPBKDF2Iterations = 1 << 26
+	// maxKeyLength bounds derived keys
+	maxKeyLength = 1 << 10
+)
+
+// KDF is a password based key derivation function with its parameters
+type KDF interface {
+	// ID returns the PHC identifier of the function
+	ID() string
+	// Validate checks the parameters
+	Validate() error
+	// Key derives length bytes from password and salt
+	Key(password, salt []byte, length int) ([]byte, error)
+	// phcParams returns the parameters in PHC string syntax
+	phcParams() string
+}
+
+var (
+	// DefaultArgon2id is the second recommended option of RFC 9106, 3
+	// passes over 64 MiB
+	DefaultArgon2id = Argon2id{Time: 3, Memory: 64 * 1024, Threads: 4}
+	// DefaultScrypt is N = 2^15, r = 8 and p = 1, 32 MiB
+	DefaultScrypt = Scrypt{N: 1 << 15, R: 8, P: 1}
+	// DefaultPBKDF2 is PBKDF2-HMAC-SHA256 with 600000 iterations, the OWASP
+	// recommendation, for interoperability where memory hard functions are
+	// not available
+	DefaultPBKDF2 = PBKDF2{Hash: crypto.SHA256, Iterations: 600000}
+)
+
+func checkKeyLength(length int) error {
+	if length < 4 || length > maxKeyLength {
+		return fmt.Errorf("kdf: key length must be between 4 and %d bytes", maxKeyLength)
+	}
+	return nil
+}
+
+// Argon2id are the parameters of Argon2id version 19
+type Argon2id struct {
+	// Time is the number of passes over the memory
+	Time uint32 `json:"time"`
+	// Memory is the memory size in KiB
+	Memory uint32 `json:"memory"`
+	// Threads is the degree of parallelism
+	Threads uint8 `json:"threads"`
+}
+
+// ID returns argon2id
+func (a Argon2id) ID() string {
+	return "argon2id"
+}
+
+// Validate checks the parameters
+func (a Argon2id) Validate() error {
+	if a.Time == 0 || a.Threads == 0 || a.Memory < 8*uint32(a.Threads) || a.Memory > MaxArgon2Memory {
+		return fmt.Errorf("kdf: invalid argon2id parameters")
+	}
+	return nil
+}
+
+// Key derives length bytes from password and salt
+func (a Argon2id) Key(password, salt []byte, length int) ([]byte, error) {
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+	if err := checkKeyLength(length); err != nil {
+		return nil, err
+	}
+	if len(salt) < 8 {
+		return nil, fmt.Errorf("kdf: argon2id salt must be at least 8 bytes")
+	}
+	return argon2.IDKey(password, salt, a.Time, a.Memory, a.Threads, uint32(length)), nil
+}
+
+func (a Argon2id) phcParams() string {
+	return fmt.Sprintf("v=%d$m=%d,t=%d,p=%d", argon2.Version, a.Memory, a.Time, a.Threads)
+}
+
+// Scrypt are the parameters of scrypt
+type Scrypt struct {
+	// N is the CPU and memory cost, a power of two
+	N int `json:"n"`
+	// R is the block size
+	R int `json:"r"`
+	// P is the parallelism
+	P int `json:"p"`
+}
+
+// ID returns scrypt
+func (s Scrypt) ID() string {
+	return "scrypt"
+}
+
+// Validate checks the parameters
+func (s Scrypt) Validate() error {
+	if s.N <= 1 || s.N&(s.N-1) != 0 || s.N > MaxScryptN || s.R <= 0 || s.P <= 0 || s.R*s.P >= 1<<30 {
+		return fmt.Errorf("kdf: invalid scrypt parameters")
+	}
+	return nil
+}
+
+// Key derives length bytes from password and salt
+func (s Scrypt) Key(password, salt []byte, length int) ([]byte, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	if err := checkKeyLength(length); err != nil {
+		return nil, err
+	}
+	return scrypt.Key(password, salt, s.N, s.R, s.P, length)
+}
+
+// logN returns log2 of N
+func (s Scrypt) logN() int {
+	n := 0
+	for v := s.N; v > 1; v >>= 1 {
+		n++
+	}
+	return n
+}
+
+func (s Scrypt) phcParams() string {
+	return fmt.Sprintf("ln=%d,r=%d,p=%d", s.logN(), s.R, s.P)
+}
+
+// PBKDF2 are the parameters of PBKDF2 with HMAC
+type PBKDF2 struct {
+	// Hash is crypto.SHA256 or crypto.SHA512
+	Hash crypto.Hash `json:"hash"`
+	// Iterations is the iteration count
+	Iterations int `json:"iterations"`
+}
+
+// ID returns pbkdf2-sha256 or pbkdf2-sha512
+func (p PBKDF2) ID() string {
+	switch p.Hash {
+	case crypto.SHA256:
+		return "pbkdf2-sha256"
+	case crypto.SHA512:
+		return "pbkdf2-sha512"
+	default:
+		return "pbkdf2"
+	}
+}
+
+func (p PBKDF2) hash() func() hash.Hash {
+	switch p.Hash {
+	case crypto.SHA256:
+		return sha256.New
+	case crypto.SHA512:
+		return sha512.New
+	default:
+		return nil
+	}
+}
+
+// Validate checks the parameters
+func (p PBKDF2) Validate() error {
+	if p.hash() == nil || p.Iterations <= 0 || p.Iterations > MaxPBKDF2Iterations {
+		return fmt.Errorf("kdf: invalid pbkdf2 parameters")
+	}
+	return nil
+}
+
+// Key derives length bytes from password and salt
+func (p PBKDF2) Key(password, salt []byte, length int) ([]byte, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	if err := checkKeyLength(length); err != nil {
+		return nil, err
+	}
+	return pbkdf2.Key(password, salt, p.Iterations, length, p.hash()), nil
+}
+
+func (p PBKDF2) phcParams() string {
+	return fmt.Sprintf("i=%d", p.Iterations)
+}
+
+// Stretch returns the key stretching function of k for OPAQUE, with the
+// all zero 16 byte salt of RFC 9807 and length bytes of output, the output
+// size of the OPAQUE hash
+func Stretch(k KDF, length int) func(msg []byte) ([]byte, error) {
+	salt := make([]byte, 16)
+	return func(msg []byte) ([]byte, error) {
+		if k == nil {
+			return nil, fmt.Errorf("kdf: kdf is required")
+		}
+		return k.Key(msg, salt, length)
+	}
+}
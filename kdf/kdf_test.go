@@ -0,0 +1,100 @@
+package kdf
+
+import (
+	"crypto"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testArgon2id keeps Argon2id cheap in tests
+var testArgon2id = Argon2id{Time: 1, Memory: 64, Threads: 1}
+
+func TestVectors(t *testing.T) {
+	// RFC 7914 section 11 and 12
+	key, err := PBKDF2{Hash: crypto.SHA256, Iterations: 1}.Key([]byte("passwd"), []byte("salt"), 64)
+	require.NoError(t, err)
+	require.Equal(t, "55ac046e56e3089fec1691c22544b605f94185216dde0465e68b9d57c20dacbc49ca9cccf179b645991664b39d77ef317c71b845b1e30bd509112041d3a19783", hex.EncodeToString(key))
+
+	key, err = Scrypt{N: 1024, R: 8, P: 16}.Key([]byte("password"), []byte("NaCl"), 64)
+	require.NoError(t, err)
+	require.Equal(t, "fdbabe1c9d3472007856e7190d01e9fe7c6ad7cbc8237830e77376634b3731622eaf30d92e22a3886ff109279d9830dac727afb94a83ee6d8360cbdfa2cc0640", hex.EncodeToString(key))
+
+	// the reference implementation, argon2 somesalt -id -t 2 -m 16 -p 1
+	ok, err := Verify([]byte("password"), "$argon2id$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc")
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestHashVerify(t *testing.T) {
+	for _, k := range []KDF{
+		testArgon2id,
+		Scrypt{N: 1 << 10, R: 8, P: 1},
+		PBKDF2{Hash: crypto.SHA256, Iterations: 1000},
+		PBKDF2{Hash: crypto.SHA512, Iterations: 1000},
+	} {
+		t.Run(k.ID(), func(t *testing.T) {
+			phc, err := Hash([]byte("correct horse"), k)
+			require.NoError(t, err)
+			require.True(t, strings.HasPrefix(phc, "$"+k.ID()+"$"))
+
+			ok, err := Verify([]byte("correct horse"), phc)
+			require.NoError(t, err)
+			require.True(t, ok)
+			ok, err = Verify([]byte("battery staple"), phc)
+			require.NoError(t, err)
+			require.False(t, ok)
+
+			parsed, err := ParsePHC(phc)
+			require.NoError(t, err)
+			require.Equal(t, k, parsed.KDF)
+			require.Equal(t, phc, parsed.String())
+		})
+	}
+}
+
+func TestParsePHCErrors(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"argon2id$v=19$m=64,t=1,p=1$c2FsdHNhbHQ$aGFzaGhhc2g",
+		"$argon2id$v=16$m=64,t=1,p=1$c2FsdHNhbHQ$aGFzaGhhc2g",
+		"$argon2id$v=19$m=64,t=0,p=1$c2FsdHNhbHQ$aGFzaGhhc2g",
+		"$argon2id$v=19$m=1099511627776,t=1,p=1$c2FsdHNhbHQ$aGFzaGhhc2g",
+		"$scrypt$ln=40,r=8,p=1$c2FsdHNhbHQ$aGFzaGhhc2g",
+		"$pbkdf2-sha256$i=1,i=2$c2FsdHNhbHQ$aGFzaGhhc2g",
+		"$pbkdf2-md5$i=1000$c2FsdHNhbHQ$aGFzaGhhc2g",
+		"$pbkdf2-sha256$i=1000$c2FsdHNhbHQ$",
+	} {
+		_, err := ParsePHC(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestCalibrate(t *testing.T) {
+	a, err := CalibrateArgon2id(64, 1, 5*time.Millisecond)
+	require.NoError(t, err)
+	require.NoError(t, a.Validate())
+	require.GreaterOrEqual(t, a.Time, uint32(1))
+
+	s, err := CalibrateScrypt(8, 1, 5*time.Millisecond)
+	require.NoError(t, err)
+	require.NoError(t, s.Validate())
+
+	p, err := CalibratePBKDF2(crypto.SHA256, 5*time.Millisecond)
+	require.NoError(t, err)
+	require.NoError(t, p.Validate())
+	require.GreaterOrEqual(t, p.Iterations, 1000)
+}
+
+func TestStretch(t *testing.T) {
+	ksf := Stretch(testArgon2id, 64)
+	a, err := ksf([]byte("oprf output"))
+	require.NoError(t, err)
+	require.Len(t, a, 64)
+	b, err := ksf([]byte("oprf output"))
+	require.NoError(t, err)
+	require.Equal(t, a, b)
+}
@@ -0,0 +1,159 @@
+package kdf
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	// SaltSize is the size of the salts Hash draws
+	SaltSize = 16
+	// HashSize is the size of the hashes Hash derives
+	HashSize = 32
+)
+
+// b64 is the base64 of PHC strings, standard alphabet without padding
+var b64 = base64.RawStdEncoding
+
+// PHC is a password hash in the PHC string format,
+// $<id>[$v=<version>]$<params>$<salt>$<hash>
+type PHC struct {
+	KDF  KDF
+	Salt []byte
+	Hash []byte
+}
+
+// String returns the PHC string
+func (p *PHC) String() string {
+	return "$" + p.KDF.ID() + "$" + p.KDF.phcParams() + "$" + b64.EncodeToString(p.Salt) + "$" + b64.EncodeToString(p.Hash)
+}
+
+// ParsePHC parses a PHC string of Argon2id, scrypt or PBKDF2
+func ParsePHC(s string) (*PHC, error) {
+	fields := strings.Split(s, "$")
+	if len(fields) < 5 || fields[0] != "" {
+		return nil, fmt.Errorf("kdf: invalid PHC string")
+	}
+	id, rest := fields[1], fields[2:]
+	if id == "argon2id" {
+		if len(rest) != 4 || rest[0] != "v="+strconv.Itoa(argon2.Version) {
+			return nil, fmt.Errorf("kdf: unsupported argon2id version")
+		}
+		rest = rest[1:]
+	}
+	if len(rest) != 3 {
+		return nil, fmt.Errorf("kdf: invalid PHC string")
+	}
+	params, err := parseParams(rest[0])
+	if err != nil {
+		return nil, err
+	}
+	var k KDF
+	switch id {
+	case "argon2id":
+		a := Argon2id{}
+		m, err1 := params.uint("m", 32)
+		t, err2 := params.uint("t", 32)
+		p, err3 := params.uint("p", 8)
+		if err1 != nil || err2 != nil || err3 != nil {
+			return nil, fmt.Errorf("kdf: invalid argon2id parameters")
+		}
+		a.Memory, a.Time, a.Threads = uint32(m), uint32(t), uint8(p)
+		k = a
+	case "scrypt":
+		ln, err1 := params.uint("ln", 6)
+		r, err2 := params.uint("r", 30)
+		p, err3 := params.uint("p", 30)
+		if err1 != nil || err2 != nil || err3 != nil || ln >= 63 {
+			return nil, fmt.Errorf("kdf: invalid scrypt parameters")
+		}
+		k = Scrypt{N: 1 << ln, R: int(r), P: int(p)}
+	case "pbkdf2-sha256", "pbkdf2-sha512":
+		i, err := params.uint("i", 31)
+		if err != nil {
+			return nil, fmt.Errorf("kdf: invalid pbkdf2 parameters")
+		}
+		h := crypto.SHA256
+		if id == "pbkdf2-sha512" {
+			h = crypto.SHA512
+		}
+		k = PBKDF2{Hash: h, Iterations: int(i)}
+	default:
+		return nil, fmt.Errorf("kdf: unsupported function %q", id)
+	}
+	if err := k.Validate(); err != nil {
+		return nil, err
+	}
+	salt, err := b64.DecodeString(rest[1])
+	if err != nil {
+		return nil, fmt.Errorf("kdf: invalid PHC salt")
+	}
+	hash, err := b64.DecodeString(rest[2])
+	if err != nil || checkKeyLength(len(hash)) != nil {
+		return nil, fmt.Errorf("kdf: invalid PHC hash")
+	}
+	return &PHC{KDF: k, Salt: salt, Hash: hash}, nil
+}
+
+// phcParams are the name=value parameters of a PHC string
+type phcParams map[string]string
+
+func parseParams(s string) (phcParams, error) {
+	params := phcParams{}
+	for _, kv := range strings.Split(s, ",") {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("kdf: invalid PHC parameters")
+		}
+		if _, dup := params[k]; dup {
+			return nil, fmt.Errorf("kdf: duplicate PHC parameter %s", k)
+		}
+		params[k] = v
+	}
+	return params, nil
+}
+
+func (p phcParams) uint(name string, bits int) (uint64, error) {
+	v, ok := p[name]
+	if !ok {
+		return 0, fmt.Errorf("kdf: missing PHC parameter %s", name)
+	}
+	return strconv.ParseUint(v, 10, bits)
+}
+
+// Hash hashes password with k and a random salt and returns the PHC string
+func Hash(password []byte, k KDF) (string, error) {
+	if k == nil {
+		return "", fmt.Errorf("kdf: kdf is required")
+	}
+	salt := make([]byte, SaltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return "", err
+	}
+	hash, err := k.Key(password, salt, HashSize)
+	if err != nil {
+		return "", err
+	}
+	return (&PHC{KDF: k, Salt: salt, Hash: hash}).String(), nil
+}
+
+// Verify reports whether password matches the PHC string of Hash
+func Verify(password []byte, phc string) (bool, error) {
+	p, err := ParsePHC(phc)
+	if err != nil {
+		return false, err
+	}
+	hash, err := p.KDF.Key(password, p.Salt, len(p.Hash))
+	if err != nil {
+		return false, err
+	}
+	return subtle.ConstantTimeCompare(hash, p.Hash) == 1, nil
+}
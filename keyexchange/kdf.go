@@ -0,0 +1,73 @@
+package keyexchange
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// HKDF derives length bytes from a shared secret with HKDF of RFC 5869.
+// The salt may be empty, info binds the key to its use.
+func HKDF(h func() hash.Hash, secret, salt, info []byte, length int) ([]byte, error) {
+	if h == nil || len(secret) == 0 {
+		return nil, fmt.Errorf("hash and secret are required")
+	}
+	if length <= 0 || length > 255*h().Size() {
+		return nil, fmt.Errorf("invalid key length %d", length)
+	}
+	out := make([]byte, length)
+	if _, err := io.ReadFull(hkdf.New(h, secret, salt, info), out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// X963KDF derives length bytes from a shared secret with the KDF of ANSI
+// X9.63 and SEC 1 section 3.6.1: Hash(secret || counter || sharedInfo) for
+// a 32 bit big endian counter starting at 1.
+func X963KDF(h func() hash.Hash, secret, sharedInfo []byte, length int) ([]byte, error) {
+	if h == nil || len(secret) == 0 {
+		return nil, fmt.Errorf("hash and secret are required")
+	}
+	if length <= 0 {
+		return nil, fmt.Errorf("invalid key length %d", length)
+	}
+	out := make([]byte, 0, length)
+	var counter [4]byte
+	for i := uint32(1); len(out) < length; i++ {
+		binary.BigEndian.PutUint32(counter[:], i)
+		d := h()
+		_, _ = d.Write(secret)
+		_, _ = d.Write(counter[:])
+		_, _ = d.Write(sharedInfo)
+		out = d.Sum(out)
+	}
+	return out[:length], nil
+}
+
+// ConcatKDF derives length bytes from a shared secret with the single step
+// KDF of NIST SP 800-56A section 5.8.1 as used by JOSE ECDH-ES:
+// Hash(counter || secret || otherInfo) for a 32 bit big endian counter
+// starting at 1.
+func ConcatKDF(h func() hash.Hash, secret, otherInfo []byte, length int) ([]byte, error) {
+	if h == nil || len(secret) == 0 {
+		return nil, fmt.Errorf("hash and secret are required")
+	}
+	if length <= 0 {
+		return nil, fmt.Errorf("invalid key length %d", length)
+	}
+	out := make([]byte, 0, length)
+	var counter [4]byte
+	for i := uint32(1); len(out) < length; i++ {
+		binary.BigEndian.PutUint32(counter[:], i)
+		d := h()
+		_, _ = d.Write(counter[:])
+		_, _ = d.Write(secret)
+		_, _ = d.Write(otherInfo)
+		out = d.Sum(out)
+	}
+	return out[:length], nil
+}
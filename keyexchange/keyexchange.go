@@ -0,0 +1,171 @@
+// Package keyexchange provides Diffie-Hellman key agreement: X25519 and X448
+// of RFC 7748 https://www.rfc-editor.org/rfc/rfc7748.html on byte encoded
+// keys, ECDH on curves.Point for the short Weierstrass curves, and KDFs to
+// turn a shared secret into keys.
+//
+// A static exchange uses a long term PrivateKey on both sides. An ephemeral
+// exchange draws a fresh key for one message with Ephemeral, the sender
+// transmits the ephemeral public key next to its ciphertext.
+package keyexchange
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/curve25519"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/secret"
+	"github.com/go-sonr/crypto/internal"
+)
+
+// Montgomery is one of the RFC 7748 Diffie-Hellman functions
+type Montgomery struct {
+	// Name is the RFC 7748 name of the function
+	Name string
+	// Size is the length in bytes of private keys, public keys and shared secrets
+	Size int
+
+	basepoint  []byte
+	scalarMult func(scalar, point []byte) ([]byte, error)
+}
+
+var (
+	// X25519 is the Diffie-Hellman function over curve25519
+	X25519 = &Montgomery{
+		Name:       "X25519",
+		Size:       curve25519.ScalarSize,
+		basepoint:  curve25519.Basepoint,
+		scalarMult: curve25519.X25519,
+	}
+	// X448 is the Diffie-Hellman function over curve448
+	X448 = &Montgomery{
+		Name:       "X448",
+		Size:       x448Size,
+		basepoint:  append([]byte{5}, make([]byte, x448Size-1)...),
+		scalarMult: x448,
+	}
+)
+
+// PrivateKey is an X25519 or X448 private key
+type PrivateKey struct {
+	curve  *Montgomery
+	scalar []byte
+	public []byte
+}
+
+// ScalarMult computes the raw function on a private key scalar and a peer
+// point. It fails when the result is all zeros, that is when point has low order.
+func (m *Montgomery) ScalarMult(scalar, point []byte) ([]byte, error) {
+	if len(scalar) != m.Size || len(point) != m.Size {
+		return nil, fmt.Errorf("%s inputs must be %d bytes", m.Name, m.Size)
+	}
+	return m.scalarMult(scalar, point)
+}
+
+// GenerateKey draws a private key from reader
+func (m *Montgomery) GenerateKey(reader io.Reader) (*PrivateKey, error) {
+	if reader == nil {
+		return nil, internal.ErrNilArguments
+	}
+	scalar := make([]byte, m.Size)
+	if _, err := io.ReadFull(reader, scalar); err != nil {
+		return nil, err
+	}
+	return m.NewPrivateKey(scalar)
+}
+
+// NewPrivateKey loads a private key of Size bytes
+func (m *Montgomery) NewPrivateKey(scalar []byte) (*PrivateKey, error) {
+	public, err := m.ScalarMult(scalar, m.basepoint)
+	if err != nil {
+		return nil, err
+	}
+	return &PrivateKey{curve: m, scalar: append([]byte{}, scalar...), public: public}, nil
+}
+
+// Ephemeral draws a one time key and agrees on a secret with peer. It
+// returns the ephemeral public key to send to the peer and the shared secret.
+func (m *Montgomery) Ephemeral(peer []byte, reader io.Reader) ([]byte, []byte, error) {
+	key, err := m.GenerateKey(reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	shared, err := key.ECDH(peer)
+	if err != nil {
+		return nil, nil, err
+	}
+	return key.PublicKey(), shared, nil
+}
+
+// Bytes returns the private key
+func (k *PrivateKey) Bytes() []byte {
+	return append([]byte{}, k.scalar...)
+}
+
+// Zeroize overwrites the private key scalar with zeros
+func (k *PrivateKey) Zeroize() {
+	secret.Wipe(k.scalar)
+}
+
+// PublicKey returns the public key
+func (k *PrivateKey) PublicKey() []byte {
+	return append([]byte{}, k.public...)
+}
+
+// Curve returns the function of the key
+func (k *PrivateKey) Curve() *Montgomery {
+	return k.curve
+}
+
+// ECDH computes the shared secret with the peer public key
+func (k *PrivateKey) ECDH(peer []byte) ([]byte, error) {
+	return k.curve.ScalarMult(k.scalar, peer)
+}
+
+// Equal reports whether k and other are the same key
+func (k *PrivateKey) Equal(other *PrivateKey) bool {
+	return other != nil && k.curve == other.curve && subtle.ConstantTimeCompare(k.scalar, other.scalar) == 1
+}
+
+// ECDH computes the shared secret sk * pk on a short Weierstrass curve and
+// returns its x-coordinate as in SEC 1 section 3.3.1. The scalar
+// multiplication is the constant time one of the curve implementation.
+func ECDH(sk curves.Scalar, pk curves.Point) ([]byte, error) {
+	if sk == nil || pk == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if sk.IsZero() {
+		return nil, internal.ErrZeroValue
+	}
+	if pk.IsIdentity() || !pk.IsOnCurve() {
+		return nil, internal.ErrNotOnCurve
+	}
+	shared := pk.Mul(sk)
+	if shared == nil {
+		return nil, internal.ErrPointsDistinctCurves
+	}
+	if shared.IsIdentity() {
+		return nil, fmt.Errorf("shared secret is the identity")
+	}
+	compressed := shared.ToAffineCompressed()
+	if len(compressed) != 33 {
+		return nil, fmt.Errorf("ECDH is not supported for %s", pk.CurveName())
+	}
+	return compressed[1:], nil
+}
+
+// EphemeralECDH draws a one time key on curve and agrees on a secret with
+// pk. It returns the ephemeral public key and the shared secret.
+func EphemeralECDH(curve *curves.Curve, pk curves.Point, reader io.Reader) (curves.Point, []byte, error) {
+	if curve == nil || reader == nil {
+		return nil, nil, internal.ErrNilArguments
+	}
+	sk := curve.Scalar.Random(reader)
+	shared, err := ECDH(sk, pk)
+	if err != nil {
+		return nil, nil, err
+	}
+	return curve.ScalarBaseMult(sk), shared, nil
+}
@@ -0,0 +1,184 @@
+package keyexchange
+
+import (
+	"crypto/ecdh"
+	crand "crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+)
+
+func unhex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+func TestRFC7748Vectors(t *testing.T) {
+	// RFC 7748 section 6
+	for _, test := range []struct {
+		curve                            *Montgomery
+		alice, alicePub, bob, bobPub, ss string
+	}{
+		{
+			X25519,
+			"77076d0a7318a57d3c16c17251b26645df4c2f87ebc0992ab177fba51db92c2a",
+			"8520f0098930a754748b7ddcb43ef75a0dbf3a0d26381af4eba4a98eaa9b4e6a",
+			"5dab087e624a8a4b79e17f8b83800ee66f3bb1292618b6fd1c2f8b27ff88e0eb",
+			"de9edb7d7b7dc1b4d35b61c2ece435373f8343c85b78674dadfc7e146f882b4f",
+			"4a5d9d5ba4ce2de1728e3bf480350f25e07e21c947d19e3376f09b3c1e161742",
+		},
+		{
+			X448,
+			"9a8f4925d1519f5775cf46b04b5800d4ee9ee8bae8bc5565d498c28dd9c9baf574a9419744897391006382a6f127ab1d9ac2d8c0a598726b",
+			"9b08f7cc31b7e3e67d22d5aea121074a273bd2b83de09c63faa73d2c22c5d9bbc836647241d953d40c5b12da88120d53177f80e532c41fa0",
+			"1c306a7ac2a0e2e0990b294470cba339e6453772b075811d8fad0d1d6927c120bb5ee8972b0d3e21374c9c921b09d1b0366f10b65173992d",
+			"3eb7a829b0cd20f5bcfc0b599b6feccf6da4627107bdb0d4f345b43027d8b972fc3e34fb4232a13ca706dcb57aec3dae07bdc1c67bf33609",
+			"07fff4181ac6cc95ec1c16a94a0f74d12da232ce40a77552281d282bb60c0b56fd2464c335543936521c24403085d59a449a5037514a879d",
+		},
+	} {
+		alice, err := test.curve.NewPrivateKey(unhex(t, test.alice))
+		require.NoError(t, err)
+		require.Equal(t, test.alicePub, hex.EncodeToString(alice.PublicKey()))
+		bob, err := test.curve.NewPrivateKey(unhex(t, test.bob))
+		require.NoError(t, err)
+		require.Equal(t, test.bobPub, hex.EncodeToString(bob.PublicKey()))
+
+		ss1, err := alice.ECDH(bob.PublicKey())
+		require.NoError(t, err)
+		ss2, err := bob.ECDH(alice.PublicKey())
+		require.NoError(t, err)
+		require.Equal(t, test.ss, hex.EncodeToString(ss1))
+		require.Equal(t, ss1, ss2)
+	}
+}
+
+func TestX448ScalarMult(t *testing.T) {
+	// RFC 7748 section 5.2
+	out, err := X448.ScalarMult(
+		unhex(t, "3d262fddf9ec8e88495266fea19a34d28882acef045104d0d1aae121700a779c984c24f8cdd78fbff44943eba368f54b29259a4f1c600ad3"),
+		unhex(t, "06fce640fa3487bfda5f6cf2d5263f8aad88334cbd07437f020f08f9814dc031ddbdc38c19c6da2583fa5429db94ada18aa7a7fb4ef8a086"),
+	)
+	require.NoError(t, err)
+	require.Equal(t, "ce3e4ff95a60dc6697da1db1d85e6afbdf79b50a2412d7546d5f239fe14fbaadeb445fc66a01b0779d98223961111e21766282f73dd96b6f", hex.EncodeToString(out))
+
+	// one iteration of the iterated test
+	k := append([]byte{5}, make([]byte, 55)...)
+	out, err = X448.ScalarMult(k, k)
+	require.NoError(t, err)
+	require.Equal(t, "3f482c8a9f19b01e6c46ee9711d9dc14fd4bf67af30765c2ae2b846a4d23a8cd0db897086239492caf350b51f833868b9bc2b3bca9cf4113", hex.EncodeToString(out))
+}
+
+func TestLowOrderPointsRejected(t *testing.T) {
+	for _, curve := range []*Montgomery{X25519, X448} {
+		key, err := curve.GenerateKey(crand.Reader)
+		require.NoError(t, err)
+		_, err = key.ECDH(make([]byte, curve.Size))
+		require.Error(t, err)
+		one := append([]byte{1}, make([]byte, curve.Size-1)...)
+		_, err = key.ECDH(one)
+		require.Error(t, err)
+		_, err = key.ECDH(make([]byte, curve.Size-1))
+		require.Error(t, err)
+	}
+}
+
+func TestEphemeral(t *testing.T) {
+	for _, curve := range []*Montgomery{X25519, X448} {
+		static, err := curve.GenerateKey(crand.Reader)
+		require.NoError(t, err)
+		ephemeral, shared, err := curve.Ephemeral(static.PublicKey(), crand.Reader)
+		require.NoError(t, err)
+		other, err := static.ECDH(ephemeral)
+		require.NoError(t, err)
+		require.Equal(t, shared, other)
+
+		loaded, err := curve.NewPrivateKey(static.Bytes())
+		require.NoError(t, err)
+		require.True(t, loaded.Equal(static))
+	}
+}
+
+func TestECDH(t *testing.T) {
+	for _, curve := range []*curves.Curve{curves.K256(), curves.P256()} {
+		a := curve.Scalar.Random(crand.Reader)
+		b := curve.Scalar.Random(crand.Reader)
+		s1, err := ECDH(a, curve.ScalarBaseMult(b))
+		require.NoError(t, err)
+		s2, err := ECDH(b, curve.ScalarBaseMult(a))
+		require.NoError(t, err)
+		require.Equal(t, s1, s2)
+		require.Len(t, s1, 32)
+
+		epk, shared, err := EphemeralECDH(curve, curve.ScalarBaseMult(a), crand.Reader)
+		require.NoError(t, err)
+		back, err := ECDH(a, epk)
+		require.NoError(t, err)
+		require.Equal(t, shared, back)
+
+		_, err = ECDH(a, curve.NewIdentityPoint())
+		require.Error(t, err)
+	}
+
+	// agrees with crypto/ecdh on P-256
+	key, err := ecdh.P256().GenerateKey(crand.Reader)
+	require.NoError(t, err)
+	peer, err := ecdh.P256().GenerateKey(crand.Reader)
+	require.NoError(t, err)
+	expected, err := key.ECDH(peer.PublicKey())
+	require.NoError(t, err)
+	sk, err := curves.P256().Scalar.SetBytes(key.Bytes())
+	require.NoError(t, err)
+	pk, err := curves.P256().Point.FromAffineUncompressed(peer.PublicKey().Bytes())
+	require.NoError(t, err)
+	shared, err := ECDH(sk, pk)
+	require.NoError(t, err)
+	require.Equal(t, expected, shared)
+
+	// points of another curve are rejected
+	_, err = ECDH(curves.K256().Scalar.Random(crand.Reader), pk)
+	require.Error(t, err)
+}
+
+func TestHKDF(t *testing.T) {
+	// RFC 5869 test case 1
+	okm, err := HKDF(sha256.New,
+		unhex(t, "0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b"),
+		unhex(t, "000102030405060708090a0b0c"),
+		unhex(t, "f0f1f2f3f4f5f6f7f8f9"), 42)
+	require.NoError(t, err)
+	require.Equal(t, "3cb25f25faacd57a90434f64d0362f2a2d2d0a90cf1a5a4c5db02d56ecc4c5bf34007208d5b887185865", hex.EncodeToString(okm))
+	_, err = HKDF(sha256.New, []byte("secret"), nil, nil, 255*32+1)
+	require.Error(t, err)
+}
+
+func TestX963KDF(t *testing.T) {
+	out, err := X963KDF(sha256.New, []byte("secret"), []byte("info"), 80)
+	require.NoError(t, err)
+	require.Len(t, out, 80)
+	// the first block is SHA-256(secret || 00000001 || info)
+	h := sha256.Sum256(append(append([]byte("secret"), 0, 0, 0, 1), "info"...))
+	require.Equal(t, h[:], out[:32])
+	short, err := X963KDF(sha256.New, []byte("secret"), []byte("info"), 16)
+	require.NoError(t, err)
+	require.Equal(t, out[:16], short)
+}
+
+// RFC 7518 Appendix C, the ECDH-ES key agreement computation
+func TestConcatKDF(t *testing.T) {
+	z := []byte{158, 86, 217, 29, 129, 113, 53, 211, 114, 131, 66, 131, 191, 132, 38, 156,
+		251, 49, 110, 163, 218, 128, 106, 72, 246, 218, 167, 121, 140, 254, 144, 196}
+	var otherInfo []byte
+	for _, field := range []string{"A128GCM", "Alice", "Bob"} {
+		otherInfo = append(otherInfo, 0, 0, 0, byte(len(field)))
+		otherInfo = append(otherInfo, field...)
+	}
+	otherInfo = append(otherInfo, 0, 0, 0, 128)
+	out, err := ConcatKDF(sha256.New, z, otherInfo, 16)
+	require.NoError(t, err)
+	require.Equal(t, []byte{86, 170, 141, 234, 248, 35, 109, 32, 92, 34, 40, 205, 113, 167, 16, 26}, out)
+}
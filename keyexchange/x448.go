@@ -0,0 +1,214 @@
+package keyexchange
+
+import (
+	"fmt"
+)
+
+// fe448 is an element of GF(2^448 - 2^224 - 1) in 16 limbs of 28 bits.
+// All operations run in constant time.
+type fe448 [16]uint64
+
+const (
+	x448Size  = 56
+	limbBits  = 28
+	limbMask  = 1<<limbBits - 1
+	x448Steps = 448
+	x448A24   = 39081
+)
+
+func (f *fe448) setBytes(b []byte) *fe448 {
+	// bytes are little endian, every limb is 3.5 bytes
+	for i := 0; i < 8; i++ {
+		// two limbs from 7 bytes
+		var v uint64
+		for j := 6; j >= 0; j-- {
+			v = v<<8 | uint64(b[7*i+j])
+		}
+		f[2*i] = v & limbMask
+		f[2*i+1] = v >> limbBits
+	}
+	return f
+}
+
+func (f *fe448) bytes() []byte {
+	t := *f
+	t.reduce()
+	out := make([]byte, x448Size)
+	for i := 0; i < 8; i++ {
+		v := t[2*i] | t[2*i+1]<<limbBits
+		for j := 0; j < 7; j++ {
+			out[7*i+j] = byte(v >> (8 * j))
+		}
+	}
+	return out
+}
+
+// carry propagates the limbs back below 2^28 using 2^448 = 2^224 + 1
+func (f *fe448) carry() {
+	for i := 0; i < 15; i++ {
+		f[i+1] += f[i] >> limbBits
+		f[i] &= limbMask
+	}
+	top := f[15] >> limbBits
+	f[15] &= limbMask
+	f[0] += top
+	f[8] += top
+}
+
+// reduce sets f to its canonical representative
+func (f *fe448) reduce() {
+	f.carry()
+	f.carry()
+	f.carry()
+	// f >= p iff f + 2^224 + 1 overflows 2^448
+	t := *f
+	t[0]++
+	t[8]++
+	for i := 0; i < 15; i++ {
+		t[i+1] += t[i] >> limbBits
+		t[i] &= limbMask
+	}
+	c := t[15] >> limbBits
+	t[15] &= limbMask
+	mask := -c
+	for i := range f {
+		f[i] = (t[i] & mask) | (f[i] &^ mask)
+	}
+}
+
+func (f *fe448) add(a, b *fe448) *fe448 {
+	for i := range f {
+		f[i] = a[i] + b[i]
+	}
+	f.carry()
+	return f
+}
+
+// sub computes a - b + 2p so the limbs stay positive
+func (f *fe448) sub(a, b *fe448) *fe448 {
+	for i := range f {
+		// the limbs of 2p are 2^29 - 2 except limb 8 which is 2^29 - 4
+		twoP := uint64(2 * limbMask)
+		if i == 8 {
+			twoP -= 2
+		}
+		f[i] = a[i] + twoP - b[i]
+	}
+	f.carry()
+	return f
+}
+
+func (f *fe448) mul(a, b *fe448) *fe448 {
+	var c [31]uint64
+	for i := 0; i < 16; i++ {
+		for j := 0; j < 16; j++ {
+			c[i+j] += a[i] * b[j]
+		}
+	}
+	// fold the upper half with 2^448 = 2^224 + 1
+	for k := 14; k >= 0; k-- {
+		c[k+8] += c[k+16]
+		c[k] += c[k+16]
+	}
+	copy(f[:], c[:16])
+	f.carry()
+	f.carry()
+	return f
+}
+
+func (f *fe448) square(a *fe448) *fe448 {
+	return f.mul(a, a)
+}
+
+func (f *fe448) mulSmall(a *fe448, k uint64) *fe448 {
+	for i := range f {
+		f[i] = a[i] * k
+	}
+	f.carry()
+	f.carry()
+	return f
+}
+
+// invert computes a^(p-2)
+func (f *fe448) invert(a *fe448) *fe448 {
+	// p - 2 = 2^448 - 2^224 - 3, bits 447..225 and 223..2 and 0 are set
+	var r fe448
+	r[0] = 1
+	x := *a
+	for bit := x448Steps - 1; bit >= 0; bit-- {
+		r.square(&r)
+		if bit != 224 && bit != 1 {
+			r.mul(&r, &x)
+		}
+	}
+	*f = r
+	return f
+}
+
+// cswap swaps f and g when swap is 1
+func (f *fe448) cswap(g *fe448, swap uint64) {
+	mask := -swap
+	for i := range f {
+		t := mask & (f[i] ^ g[i])
+		f[i] ^= t
+		g[i] ^= t
+	}
+}
+
+// x448 is the X448 function of RFC 7748 section 5
+func x448(scalar, point []byte) ([]byte, error) {
+	if len(scalar) != x448Size || len(point) != x448Size {
+		return nil, fmt.Errorf("x448 inputs must be %d bytes", x448Size)
+	}
+	var k [x448Size]byte
+	copy(k[:], scalar)
+	k[0] &= 252
+	k[55] |= 128
+
+	var x1, x2, z2, x3, z3 fe448
+	x1.setBytes(point)
+	x2[0] = 1
+	x3 = x1
+	z3[0] = 1
+	var swap uint64
+	var a, aa, b, bb, e, c, d, da, cb fe448
+	for t := x448Steps - 1; t >= 0; t-- {
+		kt := uint64(k[t/8]>>(t%8)) & 1
+		swap ^= kt
+		x2.cswap(&x3, swap)
+		z2.cswap(&z3, swap)
+		swap = kt
+
+		a.add(&x2, &z2)
+		aa.square(&a)
+		b.sub(&x2, &z2)
+		bb.square(&b)
+		e.sub(&aa, &bb)
+		c.add(&x3, &z3)
+		d.sub(&x3, &z3)
+		da.mul(&d, &a)
+		cb.mul(&c, &b)
+		x3.add(&da, &cb)
+		x3.square(&x3)
+		z3.sub(&da, &cb)
+		z3.square(&z3)
+		z3.mul(&z3, &x1)
+		x2.mul(&aa, &bb)
+		z2.mulSmall(&e, x448A24)
+		z2.add(&z2, &aa)
+		z2.mul(&z2, &e)
+	}
+	x2.cswap(&x3, swap)
+	z2.cswap(&z3, swap)
+
+	z2.invert(&z2)
+	out := x2.mul(&x2, &z2).bytes()
+	var zero byte
+	for _, v := range out {
+		zero |= v
+	}
+	if zero == 0 {
+		return nil, fmt.Errorf("x448 output is zero, the point has low order")
+	}
+	return out, nil
+}
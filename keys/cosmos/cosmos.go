@@ -0,0 +1,313 @@
+// Package cosmos converts the keys of this module to and from the
+// secp256k1 and ed25519 keys of the Cosmos SDK, so that chain modules can
+// accept did:key identifiers and MPC generated keys.
+//
+// Besides the key types it covers the encodings a chain sees keys in:
+// protobuf Any, legacy amino binary and JSON, and bech32 account addresses.
+package cosmos
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/cosmos/gogoproto/proto"
+	p2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/keys/parsers"
+)
+
+// PubKeyFromDIDKey returns the Cosmos SDK public key of a secp256k1 or
+// Ed25519 did:key
+func PubKeyFromDIDKey(id parsers.DIDKey) (cryptotypes.PubKey, error) {
+	if id.PubKey == nil {
+		return nil, internal.ErrNilArguments
+	}
+	raw, err := id.Raw()
+	if err != nil {
+		return nil, err
+	}
+	switch id.Type() {
+	case p2pcrypto.Secp256k1:
+		return &secp256k1.PubKey{Key: raw}, nil
+	case p2pcrypto.Ed25519:
+		return &ed25519.PubKey{Key: raw}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type for cosmos: %s", id.Type())
+	}
+}
+
+// DIDKeyFromPubKey returns the did:key of a Cosmos SDK secp256k1 or
+// ed25519 public key
+func DIDKeyFromPubKey(pk cryptotypes.PubKey) (parsers.DIDKey, error) {
+	var (
+		pub p2pcrypto.PubKey
+		err error
+	)
+	switch pk := pk.(type) {
+	case *secp256k1.PubKey:
+		pub, err = p2pcrypto.UnmarshalSecp256k1PublicKey(pk.Key)
+	case *ed25519.PubKey:
+		pub, err = p2pcrypto.UnmarshalEd25519PublicKey(pk.Key)
+	case nil:
+		return parsers.DIDKey{}, internal.ErrNilArguments
+	default:
+		return parsers.DIDKey{}, fmt.Errorf("unsupported cosmos key type: %s", pk.Type())
+	}
+	if err != nil {
+		return parsers.DIDKey{}, err
+	}
+	return parsers.NewKeyDID(pub)
+}
+
+// PubKeyFromPoint returns the Cosmos SDK public key of a secp256k1 or
+// Ed25519 curve point, like the public key of an MPC key share
+func PubKeyFromPoint(p curves.Point) (cryptotypes.PubKey, error) {
+	if p == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if p.IsIdentity() {
+		return nil, internal.ErrZeroValue
+	}
+	switch p.CurveName() {
+	case curves.K256Name:
+		return &secp256k1.PubKey{Key: p.ToAffineCompressed()}, nil
+	case curves.ED25519Name:
+		return &ed25519.PubKey{Key: p.ToAffineCompressed()}, nil
+	default:
+		return nil, fmt.Errorf("unsupported curve for cosmos: %s", p.CurveName())
+	}
+}
+
+// PrivKeyFromScalar returns the Cosmos SDK private key of a secp256k1
+// secret scalar. Ed25519 keys are seeds rather than scalars, see
+// PrivKeyFromP2P.
+func PrivKeyFromScalar(s curves.Scalar) (cryptotypes.PrivKey, error) {
+	if s == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if _, ok := s.(*curves.ScalarK256); !ok {
+		return nil, fmt.Errorf("scalar is not a secp256k1 scalar")
+	}
+	if s.IsZero() {
+		return nil, internal.ErrZeroValue
+	}
+	return &secp256k1.PrivKey{Key: s.Bytes()}, nil
+}
+
+// PrivKeyFromP2P returns the Cosmos SDK private key of a secp256k1 or
+// Ed25519 libp2p private key
+func PrivKeyFromP2P(priv p2pcrypto.PrivKey) (cryptotypes.PrivKey, error) {
+	if priv == nil {
+		return nil, internal.ErrNilArguments
+	}
+	raw, err := priv.Raw()
+	if err != nil {
+		return nil, err
+	}
+	switch priv.Type() {
+	case p2pcrypto.Secp256k1:
+		return &secp256k1.PrivKey{Key: raw}, nil
+	case p2pcrypto.Ed25519:
+		// both are the seed followed by the public key
+		return &ed25519.PrivKey{Key: raw}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type for cosmos: %s", priv.Type())
+	}
+}
+
+// PrivKeyToP2P returns the libp2p private key of a Cosmos SDK secp256k1 or
+// ed25519 private key
+func PrivKeyToP2P(priv cryptotypes.PrivKey) (p2pcrypto.PrivKey, error) {
+	switch priv := priv.(type) {
+	case *secp256k1.PrivKey:
+		return p2pcrypto.UnmarshalSecp256k1PrivateKey(priv.Key)
+	case *ed25519.PrivKey:
+		return p2pcrypto.UnmarshalEd25519PrivateKey(priv.Key)
+	case nil:
+		return nil, internal.ErrNilArguments
+	default:
+		return nil, fmt.Errorf("unsupported cosmos key type: %s", priv.Type())
+	}
+}
+
+// PackAny wraps a public key in a protobuf Any, as in the public key of a
+// BaseAccount or a SignerInfo
+func PackAny(pk cryptotypes.PubKey) (*codectypes.Any, error) {
+	if pk == nil {
+		return nil, internal.ErrNilArguments
+	}
+	return codectypes.NewAnyWithValue(pk)
+}
+
+// UnpackAny returns the secp256k1 or ed25519 public key in a protobuf Any
+func UnpackAny(any *codectypes.Any) (cryptotypes.PubKey, error) {
+	if any == nil {
+		return nil, internal.ErrNilArguments
+	}
+	var pk cryptotypes.PubKey
+	switch any.TypeUrl {
+	case "/" + proto.MessageName(&secp256k1.PubKey{}):
+		pk = &secp256k1.PubKey{}
+	case "/" + proto.MessageName(&ed25519.PubKey{}):
+		pk = &ed25519.PubKey{}
+	default:
+		return nil, fmt.Errorf("unsupported public key type %q", any.TypeUrl)
+	}
+	if err := proto.Unmarshal(any.Value, pk); err != nil {
+		return nil, err
+	}
+	return pk, checkPubKey(pk)
+}
+
+func checkPubKey(pk cryptotypes.PubKey) error {
+	size := secp256k1.PubKeySize
+	if _, ok := pk.(*ed25519.PubKey); ok {
+		size = ed25519.PubKeySize
+	}
+	if len(pk.Bytes()) != size {
+		return fmt.Errorf("invalid %s public key size %d", pk.Type(), len(pk.Bytes()))
+	}
+	return nil
+}
+
+// aminoName is the registered amino name of a public key type
+func aminoName(pk cryptotypes.PubKey) (string, error) {
+	switch pk.(type) {
+	case *secp256k1.PubKey:
+		return secp256k1.PubKeyName, nil
+	case *ed25519.PubKey:
+		return ed25519.PubKeyName, nil
+	default:
+		return "", fmt.Errorf("unsupported cosmos key type: %s", pk.Type())
+	}
+}
+
+// aminoPrefix is the 4 byte prefix amino writes before a registered
+// concrete type: SHA-256 of the name without its leading zero bytes, and
+// then without the 3 byte disambiguation
+func aminoPrefix(name string) []byte {
+	h := sha256.Sum256([]byte(name))
+	b := h[:]
+	for b[0] == 0 {
+		b = b[1:]
+	}
+	b = b[3:]
+	for b[0] == 0 {
+		b = b[1:]
+	}
+	return b[:4]
+}
+
+// MarshalAmino returns the legacy amino binary encoding of a public key,
+// the prefix of its type followed by its length prefixed bytes
+func MarshalAmino(pk cryptotypes.PubKey) ([]byte, error) {
+	if pk == nil {
+		return nil, internal.ErrNilArguments
+	}
+	name, err := aminoName(pk)
+	if err != nil {
+		return nil, err
+	}
+	key := pk.Bytes()
+	out := append(aminoPrefix(name), byte(len(key)))
+	return append(out, key...), nil
+}
+
+// UnmarshalAmino decodes the legacy amino binary encoding of a secp256k1
+// or ed25519 public key
+func UnmarshalAmino(data []byte) (cryptotypes.PubKey, error) {
+	for _, pk := range []cryptotypes.PubKey{&secp256k1.PubKey{}, &ed25519.PubKey{}} {
+		name, _ := aminoName(pk)
+		prefix := aminoPrefix(name)
+		if !bytes.HasPrefix(data, prefix) {
+			continue
+		}
+		rest := data[len(prefix):]
+		if len(rest) == 0 || int(rest[0]) != len(rest)-1 {
+			return nil, fmt.Errorf("malformed amino public key")
+		}
+		key := bytes.Clone(rest[1:])
+		switch pk := pk.(type) {
+		case *secp256k1.PubKey:
+			pk.Key = key
+		case *ed25519.PubKey:
+			pk.Key = key
+		}
+		return pk, checkPubKey(pk)
+	}
+	return nil, fmt.Errorf("unknown amino public key type")
+}
+
+type aminoJSON struct {
+	Type  string `json:"type"`
+	Value []byte `json:"value"`
+}
+
+// MarshalAminoJSON returns the legacy amino JSON encoding of a public key,
+// its type name and its base64 bytes
+func MarshalAminoJSON(pk cryptotypes.PubKey) ([]byte, error) {
+	if pk == nil {
+		return nil, internal.ErrNilArguments
+	}
+	name, err := aminoName(pk)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(aminoJSON{Type: name, Value: pk.Bytes()})
+}
+
+// UnmarshalAminoJSON decodes the legacy amino JSON encoding of a secp256k1
+// or ed25519 public key
+func UnmarshalAminoJSON(data []byte) (cryptotypes.PubKey, error) {
+	var v aminoJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	var pk cryptotypes.PubKey
+	switch v.Type {
+	case secp256k1.PubKeyName:
+		pk = &secp256k1.PubKey{Key: v.Value}
+	case ed25519.PubKeyName:
+		pk = &ed25519.PubKey{Key: v.Value}
+	default:
+		return nil, fmt.Errorf("unsupported amino public key type %q", v.Type)
+	}
+	return pk, checkPubKey(pk)
+}
+
+// Bech32Address is the bech32 account address of a public key with the
+// human readable part of a chain, like cosmos
+func Bech32Address(hrp string, pk cryptotypes.PubKey) (string, error) {
+	if pk == nil {
+		return "", internal.ErrNilArguments
+	}
+	if err := checkPubKey(pk); err != nil {
+		return "", err
+	}
+	return bech32.ConvertAndEncode(hrp, pk.Address())
+}
+
+// ParseBech32Address returns the bytes of a bech32 account address with
+// the human readable part hrp
+func ParseBech32Address(hrp, addr string) ([]byte, error) {
+	got, data, err := bech32.DecodeAndConvert(addr)
+	if err != nil {
+		return nil, err
+	}
+	if got != hrp {
+		return nil, fmt.Errorf("address has prefix %q, not %q", got, hrp)
+	}
+	if len(data) != 20 && len(data) != 32 {
+		return nil, fmt.Errorf("invalid address length %d", len(data))
+	}
+	return data, nil
+}
@@ -0,0 +1,141 @@
+package cosmos
+
+import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	p2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/keys/parsers"
+)
+
+func TestAminoPrefix(t *testing.T) {
+	require.Equal(t, "eb5ae987", hex.EncodeToString(aminoPrefix(secp256k1.PubKeyName)))
+	require.Equal(t, "1624de64", hex.EncodeToString(aminoPrefix(ed25519.PubKeyName)))
+}
+
+func TestDIDKeyRoundTrip(t *testing.T) {
+	for _, typ := range []int{p2pcrypto.Secp256k1, p2pcrypto.Ed25519} {
+		priv, pub, err := p2pcrypto.GenerateKeyPairWithReader(typ, 0, crand.Reader)
+		require.NoError(t, err)
+		id, err := parsers.NewKeyDID(pub)
+		require.NoError(t, err)
+
+		pk, err := PubKeyFromDIDKey(id)
+		require.NoError(t, err)
+		back, err := DIDKeyFromPubKey(pk)
+		require.NoError(t, err)
+		require.Equal(t, id.String(), back.String())
+
+		// the converted private key signs for the converted public key
+		sk, err := PrivKeyFromP2P(priv)
+		require.NoError(t, err)
+		require.True(t, sk.PubKey().Equals(pk))
+		sig, err := sk.Sign([]byte("msg"))
+		require.NoError(t, err)
+		require.True(t, pk.VerifySignature([]byte("msg"), sig))
+
+		p2p, err := PrivKeyToP2P(sk)
+		require.NoError(t, err)
+		require.True(t, p2p.Equals(priv))
+	}
+
+	_, pub, err := p2pcrypto.GenerateKeyPairWithReader(p2pcrypto.RSA, 2048, crand.Reader)
+	require.NoError(t, err)
+	id, err := parsers.NewKeyDID(pub)
+	require.NoError(t, err)
+	_, err = PubKeyFromDIDKey(id)
+	require.Error(t, err)
+}
+
+func TestPointAndScalar(t *testing.T) {
+	k256 := curves.K256()
+	secret := k256.Scalar.Random(crand.Reader)
+	sk, err := PrivKeyFromScalar(secret)
+	require.NoError(t, err)
+	pk, err := PubKeyFromPoint(k256.ScalarBaseMult(secret))
+	require.NoError(t, err)
+	require.True(t, sk.PubKey().Equals(pk))
+
+	edPriv, edPub, err := p2pcrypto.GenerateEd25519Key(crand.Reader)
+	require.NoError(t, err)
+	raw, err := edPub.Raw()
+	require.NoError(t, err)
+	point, err := curves.ED25519().Point.FromAffineCompressed(raw)
+	require.NoError(t, err)
+	pk, err = PubKeyFromPoint(point)
+	require.NoError(t, err)
+	sk, err = PrivKeyFromP2P(edPriv)
+	require.NoError(t, err)
+	require.True(t, sk.PubKey().Equals(pk))
+
+	_, err = PrivKeyFromScalar(curves.P256().Scalar.Random(crand.Reader))
+	require.Error(t, err)
+	_, err = PubKeyFromPoint(curves.P256().Point.Generator())
+	require.Error(t, err)
+	_, err = PubKeyFromPoint(k256.Point.Identity())
+	require.Error(t, err)
+}
+
+func TestEncodings(t *testing.T) {
+	for _, tc := range []struct {
+		pk      cryptotypes.PubKey
+		typeURL string
+		prefix  string
+	}{
+		{secp256k1.GenPrivKey().PubKey(), "/cosmos.crypto.secp256k1.PubKey", "eb5ae98721"},
+		{ed25519.GenPrivKey().PubKey(), "/cosmos.crypto.ed25519.PubKey", "1624de6420"},
+	} {
+		any, err := PackAny(tc.pk)
+		require.NoError(t, err)
+		require.Equal(t, tc.typeURL, any.TypeUrl)
+		got, err := UnpackAny(any)
+		require.NoError(t, err)
+		require.True(t, got.Equals(tc.pk))
+
+		amino, err := MarshalAmino(tc.pk)
+		require.NoError(t, err)
+		require.Equal(t, tc.prefix, hex.EncodeToString(amino[:5]))
+		got, err = UnmarshalAmino(amino)
+		require.NoError(t, err)
+		require.True(t, got.Equals(tc.pk))
+		_, err = UnmarshalAmino(amino[:len(amino)-1])
+		require.Error(t, err)
+
+		js, err := MarshalAminoJSON(tc.pk)
+		require.NoError(t, err)
+		got, err = UnmarshalAminoJSON(js)
+		require.NoError(t, err)
+		require.True(t, got.Equals(tc.pk))
+
+		addr, err := Bech32Address("cosmos", tc.pk)
+		require.NoError(t, err)
+		raw, err := ParseBech32Address("cosmos", addr)
+		require.NoError(t, err)
+		require.Equal(t, []byte(tc.pk.Address()), raw)
+		_, err = ParseBech32Address("osmo", addr)
+		require.Error(t, err)
+	}
+}
+
+func TestFundraiserVector(t *testing.T) {
+	// the first atom fundraiser vector of the cosmos-sdk hd tests
+	priv, _ := hex.DecodeString("91bba8805845210665d7a9c5aff63ef69f7604fbeddb485706d31f04458f572c")
+	secret, err := curves.K256().Scalar.SetBytes(priv)
+	require.NoError(t, err)
+	sk, err := PrivKeyFromScalar(secret)
+	require.NoError(t, err)
+	require.Equal(t, "0210ddc89abc90bbcbec8c63f5a4ebb016b58063b9d1a77854502042bdfcac5e51", hex.EncodeToString(sk.PubKey().Bytes()))
+
+	addr, err := Bech32Address("cosmos", sk.PubKey())
+	require.NoError(t, err)
+	raw, err := ParseBech32Address("cosmos", addr)
+	require.NoError(t, err)
+	require.Equal(t, "72e7d6e9cfa899043a0783752a4876423f8effb8", hex.EncodeToString(raw))
+}
@@ -0,0 +1,168 @@
+package parsers
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+
+	mb "github.com/multiformats/go-multibase"
+)
+
+const (
+	base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	// arenaChunk is the size of the slabs holding decoded key material
+	arenaChunk = 16 * 1024
+)
+
+var base58Index = func() (idx [256]int8) {
+	for i := range idx {
+		idx[i] = -1
+	}
+	for i := 0; i < len(base58Alphabet); i++ {
+		idx[base58Alphabet[i]] = int8(i)
+	}
+	return idx
+}()
+
+// BatchParser parses did:key identifiers in bulk. It reuses a single scratch
+// buffer for base58btc decoding, carves the decoded key material out of shared
+// slabs instead of allocating it per key and parses repeated identifiers once.
+// A BatchParser is not safe for concurrent use, see ParseBatchConcurrent.
+type BatchParser struct {
+	scratch []byte
+	arena   []byte
+}
+
+// NewBatchParser returns a BatchParser with empty buffers
+func NewBatchParser() *BatchParser {
+	return &BatchParser{}
+}
+
+// ParseBatch parses every entry of keystrs. The returned slices have the same
+// length as keystrs; errs[i] is nil when keys[i] parsed successfully.
+func ParseBatch(keystrs []string) ([]DIDKey, []error) {
+	return NewBatchParser().ParseBatch(keystrs)
+}
+
+// ParseBatchConcurrent is ParseBatch split across a pool of workers each with
+// its own BatchParser. A non-positive workers uses runtime.GOMAXPROCS.
+func ParseBatchConcurrent(keystrs []string, workers int) ([]DIDKey, []error) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(keystrs) {
+		workers = len(keystrs)
+	}
+	keys := make([]DIDKey, len(keystrs))
+	errs := make([]error, len(keystrs))
+	if workers == 0 {
+		return keys, errs
+	}
+
+	chunk := (len(keystrs) + workers - 1) / workers
+	var wg sync.WaitGroup
+	for start := 0; start < len(keystrs); start += chunk {
+		end := min(start+chunk, len(keystrs))
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			p := NewBatchParser()
+			p.parseInto(keystrs[start:end], keys[start:end], errs[start:end])
+		}(start, end)
+	}
+	wg.Wait()
+	return keys, errs
+}
+
+// ParseBatch parses every entry of keystrs, see the package level ParseBatch
+func (p *BatchParser) ParseBatch(keystrs []string) ([]DIDKey, []error) {
+	keys := make([]DIDKey, len(keystrs))
+	errs := make([]error, len(keystrs))
+	p.parseInto(keystrs, keys, errs)
+	return keys, errs
+}
+
+func (p *BatchParser) parseInto(keystrs []string, keys []DIDKey, errs []error) {
+	seen := make(map[string]int, len(keystrs))
+	for i, keystr := range keystrs {
+		if j, ok := seen[keystr]; ok {
+			keys[i], errs[i] = keys[j], errs[j]
+			continue
+		}
+		seen[keystr] = i
+		keys[i], errs[i] = p.Parse(keystr)
+	}
+}
+
+// Parse turns a string into a key method ID, like the package level Parse
+func (p *BatchParser) Parse(keystr string) (DIDKey, error) {
+	var id DIDKey
+	if !strings.HasPrefix(keystr, KeyPrefix+":") {
+		return id, fmt.Errorf("decentralized identifier is not a 'key' type")
+	}
+	keystr = keystr[len(KeyPrefix)+1:]
+
+	var data []byte
+	if len(keystr) > 0 && mb.Encoding(keystr[0]) == mb.Base58BTC {
+		decoded, err := p.decodeBase58(keystr[1:])
+		if err != nil {
+			return id, fmt.Errorf("decoding multibase: %w", err)
+		}
+		data = p.alloc(decoded)
+	} else {
+		var err error
+		_, data, err = mb.Decode(keystr)
+		if err != nil {
+			return id, fmt.Errorf("decoding multibase: %w", err)
+		}
+	}
+	return parseMulticodec(data)
+}
+
+// alloc copies b into the arena and returns the copy capped to its length so
+// keys referencing it can never observe each other
+func (p *BatchParser) alloc(b []byte) []byte {
+	if cap(p.arena)-len(p.arena) < len(b) {
+		p.arena = make([]byte, 0, max(arenaChunk, len(b)))
+	}
+	start := len(p.arena)
+	p.arena = append(p.arena, b...)
+	return p.arena[start:len(p.arena):len(p.arena)]
+}
+
+// decodeBase58 decodes s into the scratch buffer, which is only valid until the next call
+func (p *BatchParser) decodeBase58(s string) ([]byte, error) {
+	zeros := 0
+	for zeros < len(s) && s[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+
+	// every leading '1' is a zero byte, the rest needs log(58) / log(256) ~= 0.733 bytes per digit
+	size := zeros + (len(s)-zeros)*733/1000 + 1
+	if cap(p.scratch) < size {
+		p.scratch = make([]byte, size)
+	}
+	out := p.scratch[:size]
+	clear(out)
+
+	// out holds a big endian number, length tracks its used low-order bytes
+	length := 0
+	for i := zeros; i < len(s); i++ {
+		digit := base58Index[s[i]]
+		if digit < 0 {
+			return nil, fmt.Errorf("invalid base58 character %q", s[i])
+		}
+		carry := int(digit)
+		j := 0
+		for k := size - 1; (carry != 0 || j < length) && k >= 0; k-- {
+			carry += 58 * int(out[k])
+			out[k] = byte(carry)
+			carry >>= 8
+			j++
+		}
+		length = j
+	}
+
+	return out[size-length-zeros:], nil
+}
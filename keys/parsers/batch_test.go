@@ -0,0 +1,96 @@
+package parsers
+
+import (
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	p2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/mr-tron/base58"
+	mb "github.com/multiformats/go-multibase"
+	"github.com/stretchr/testify/require"
+)
+
+func batchFixture(t testing.TB, n int) []string {
+	out := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		keyType := []int{p2pcrypto.Ed25519, p2pcrypto.Secp256k1, p2pcrypto.ECDSA}[i%3]
+		_, pub, err := p2pcrypto.GenerateKeyPairWithReader(keyType, 0, rand.Reader)
+		require.NoError(t, err)
+		id, err := NewKeyDID(pub)
+		require.NoError(t, err)
+		out = append(out, id.String())
+	}
+	return out
+}
+
+func TestParseBatchMatchesParse(t *testing.T) {
+	dids := batchFixture(t, 30)
+	b64, err := Parse(dids[0])
+	require.NoError(t, err)
+	dids = append(dids,
+		dids[1],
+		b64.StringWithEncoding(mb.Base64url),
+		"did:web:example.com",
+		"did:key:z",
+		"did:key:z0OIl",
+		"did:key:zQ3shokFTS3brHcDQrn82RUDfCZESWL1ZdCEJwekUDPQiYBme",
+	)
+
+	keys, errs := ParseBatch(dids)
+	require.Len(t, keys, len(dids))
+	require.Len(t, errs, len(dids))
+	for i, did := range dids {
+		expected, expectedErr := Parse(did)
+		if expectedErr != nil {
+			require.Error(t, errs[i], did)
+			continue
+		}
+		require.NoError(t, errs[i], did)
+		require.True(t, expected.Equals(keys[i].PubKey), did)
+	}
+
+	concurrentKeys, concurrentErrs := ParseBatchConcurrent(dids, 4)
+	for i := range dids {
+		require.Equal(t, errs[i] == nil, concurrentErrs[i] == nil)
+		if errs[i] == nil {
+			require.Equal(t, keys[i].String(), concurrentKeys[i].String())
+		}
+	}
+}
+
+func TestParseBatchEmpty(t *testing.T) {
+	keys, errs := ParseBatchConcurrent(nil, 0)
+	require.Empty(t, keys)
+	require.Empty(t, errs)
+}
+
+func TestBatchParserDecodeBase58(t *testing.T) {
+	p := NewBatchParser()
+	for _, input := range [][]byte{{}, {0}, {0, 0, 1}, {0xff, 0xfe}, []byte("hello world"), make([]byte, 40)} {
+		encoded := base58.Encode(input)
+		decoded, err := p.decodeBase58(encoded)
+		require.NoError(t, err)
+		require.Equal(t, fmt.Sprintf("%x", input), fmt.Sprintf("%x", decoded))
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	dids := batchFixture(b, 256)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, did := range dids {
+			_, _ = Parse(did)
+		}
+	}
+}
+
+func BenchmarkParseBatch(b *testing.B) {
+	dids := batchFixture(b, 256)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = ParseBatch(dids)
+	}
+}
@@ -0,0 +1,105 @@
+package parsers
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/crypto/pb"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/signatures/bls/bls_sig"
+)
+
+const (
+	// KeyTypeBLS12381G1 marks a BLS12-381 public key in G1. libp2p has no
+	// BLS key types so the multicodec value is reused as the key type.
+	KeyTypeBLS12381G1 = pb.KeyType(MulticodecKindBLS12381G1PubKey)
+	// KeyTypeBLS12381G2 marks a BLS12-381 public key in G2
+	KeyTypeBLS12381G2 = pb.KeyType(MulticodecKindBLS12381G2PubKey)
+)
+
+// BLS12381PublicKey is a BLS12-381 public key in either G1 or G2
+// that satisfies the libp2p crypto.PubKey interface.
+// Signatures are verified with the proof of possession ciphersuite,
+// minimal-pubkey-size for G1 keys and minimal-signature-size for G2 keys.
+type BLS12381PublicKey struct {
+	point curves.PairingPoint
+}
+
+// UnmarshalBLS12381G1PublicKey decodes a compressed G1 point into a public key
+func UnmarshalBLS12381G1PublicKey(data []byte) (crypto.PubKey, error) {
+	return unmarshalBLS12381PublicKey(curves.BLS12381G1(), data)
+}
+
+// UnmarshalBLS12381G2PublicKey decodes a compressed G2 point into a public key
+func UnmarshalBLS12381G2PublicKey(data []byte) (crypto.PubKey, error) {
+	return unmarshalBLS12381PublicKey(curves.BLS12381G2(), data)
+}
+
+func unmarshalBLS12381PublicKey(curve *curves.Curve, data []byte) (crypto.PubKey, error) {
+	pt, err := curve.Point.FromAffineCompressed(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s public key: %w", curve.Name, err)
+	}
+	if pt.IsIdentity() {
+		return nil, fmt.Errorf("public keys cannot be zero")
+	}
+	return &BLS12381PublicKey{point: pt.(curves.PairingPoint)}, nil
+}
+
+// Point returns the backing curve point, one of
+// *curves.PointBls12381G1 or *curves.PointBls12381G2
+func (k *BLS12381PublicKey) Point() curves.PairingPoint {
+	return k.point
+}
+
+// Type returns KeyTypeBLS12381G1 or KeyTypeBLS12381G2
+func (k *BLS12381PublicKey) Type() pb.KeyType {
+	if _, ok := k.point.(*curves.PointBls12381G2); ok {
+		return KeyTypeBLS12381G2
+	}
+	return KeyTypeBLS12381G1
+}
+
+// Raw returns the compressed point
+func (k *BLS12381PublicKey) Raw() ([]byte, error) {
+	return k.point.ToAffineCompressed(), nil
+}
+
+// Equals checks whether two keys are the same
+func (k *BLS12381PublicKey) Equals(o crypto.Key) bool {
+	if o == nil || o.Type() != k.Type() {
+		return false
+	}
+	raw, err := o.Raw()
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(raw, k.point.ToAffineCompressed())
+}
+
+// Verify checks a compressed BLS signature over data
+func (k *BLS12381PublicKey) Verify(data, sigBytes []byte) (bool, error) {
+	raw := k.point.ToAffineCompressed()
+	if k.Type() == KeyTypeBLS12381G2 {
+		pk := new(bls_sig.PublicKeyVt)
+		if err := pk.UnmarshalBinary(raw); err != nil {
+			return false, err
+		}
+		sig := new(bls_sig.SignatureVt)
+		if err := sig.UnmarshalBinary(sigBytes); err != nil {
+			return false, err
+		}
+		return bls_sig.NewSigPopVt().Verify(pk, data, sig)
+	}
+	pk := new(bls_sig.PublicKey)
+	if err := pk.UnmarshalBinary(raw); err != nil {
+		return false, err
+	}
+	sig := new(bls_sig.Signature)
+	if err := sig.UnmarshalBinary(sigBytes); err != nil {
+		return false, err
+	}
+	return bls_sig.NewSigPop().Verify(pk, data, sig)
+}
@@ -0,0 +1,114 @@
+package parsers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	mb "github.com/multiformats/go-multibase"
+)
+
+const (
+	// MethodKey is the did:key method name
+	MethodKey = "key"
+	// MethodWeb is the did:web method name
+	MethodWeb = "web"
+	// MethodPeer is the did:peer method name
+	MethodPeer = "peer"
+)
+
+// MethodHandler resolves the identifiers of a single DID method
+type MethodHandler interface {
+	// Method returns the method name, e.g. "key" for did:key
+	Method() string
+	// Resolve turns a full DID string into its DID Document
+	Resolve(ctx context.Context, did string) (*Document, error)
+}
+
+// Registry dispatches DIDs to the MethodHandler of their method
+type Registry struct {
+	mu       sync.RWMutex
+	handlers map[string]MethodHandler
+}
+
+// NewRegistry returns a Registry with the given handlers registered
+func NewRegistry(handlers ...MethodHandler) *Registry {
+	r := &Registry{handlers: make(map[string]MethodHandler, len(handlers))}
+	for _, h := range handlers {
+		r.Register(h)
+	}
+	return r
+}
+
+// Register adds h to the registry, replacing any handler of the same method
+func (r *Registry) Register(h MethodHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[h.Method()] = h
+}
+
+// Handler returns the handler registered for method
+func (r *Registry) Handler(method string) (MethodHandler, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	h, ok := r.handlers[method]
+	return h, ok
+}
+
+// ParseDID resolves did with the handler registered for its method
+func (r *Registry) ParseDID(ctx context.Context, did string) (*Document, error) {
+	method, _, err := SplitDID(did)
+	if err != nil {
+		return nil, err
+	}
+	h, ok := r.Handler(method)
+	if !ok {
+		return nil, fmt.Errorf("unsupported DID method: %s", method)
+	}
+	return h.Resolve(ctx, did)
+}
+
+// DefaultRegistry handles did:key, did:web and did:peer
+var DefaultRegistry = NewRegistry(KeyMethod{}, &WebMethod{}, PeerMethod{})
+
+// ParseDID resolves did with the DefaultRegistry
+func ParseDID(ctx context.Context, did string) (*Document, error) {
+	return DefaultRegistry.ParseDID(ctx, did)
+}
+
+// SplitDID returns the method and method specific identifier of did
+func SplitDID(did string) (method, id string, err error) {
+	parts := strings.SplitN(did, ":", 3)
+	if len(parts) != 3 || parts[0] != "did" || parts[1] == "" || parts[2] == "" {
+		return "", "", fmt.Errorf("invalid decentralized identifier: %q", did)
+	}
+	for _, c := range parts[1] {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') {
+			return "", "", fmt.Errorf("invalid DID method name: %q", parts[1])
+		}
+	}
+	return parts[1], parts[2], nil
+}
+
+// KeyMethod resolves did:key identifiers
+type KeyMethod struct{}
+
+// Method returns "key"
+func (KeyMethod) Method() string {
+	return MethodKey
+}
+
+// Resolve parses did and expands it into its DID Document. The did:key spec
+// only allows base58btc, so other multibase encodings are rejected here even
+// though Parse accepts them: the document would not match the given DID.
+func (KeyMethod) Resolve(_ context.Context, did string) (*Document, error) {
+	if !strings.HasPrefix(did, KeyPrefix+":"+string(rune(mb.Base58BTC))) {
+		return nil, fmt.Errorf("did:key identifier is not base58btc encoded: %q", did)
+	}
+	id, err := Parse(did)
+	if err != nil {
+		return nil, err
+	}
+	return id.Resolve()
+}
@@ -0,0 +1,185 @@
+package parsers
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	mb "github.com/multiformats/go-multibase"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitDID(t *testing.T) {
+	method, id, err := SplitDID("did:web:example.com:user:alice")
+	require.NoError(t, err)
+	require.Equal(t, MethodWeb, method)
+	require.Equal(t, "example.com:user:alice", id)
+
+	for _, bad := range []string{"", "did:", "did:key", "did::abc", "dod:key:abc", "did:KEY:abc"} {
+		_, _, err := SplitDID(bad)
+		require.Error(t, err, bad)
+	}
+}
+
+func TestParseDIDKey(t *testing.T) {
+	did := "did:key:z6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK"
+	doc, err := ParseDID(context.Background(), did)
+	require.NoError(t, err)
+	require.Equal(t, did, doc.ID)
+	require.Len(t, doc.KeyAgreement, 1)
+
+	// the same key in another multibase is not a valid did:key
+	id, err := Parse(did)
+	require.NoError(t, err)
+	_, err = ParseDID(context.Background(), id.StringWithEncoding(mb.Base64url))
+	require.Error(t, err)
+}
+
+func TestParseDIDUnsupportedMethod(t *testing.T) {
+	_, err := ParseDID(context.Background(), "did:example:123")
+	require.Error(t, err)
+}
+
+type staticMethod struct{ doc *Document }
+
+func (staticMethod) Method() string { return "example" }
+
+func (m staticMethod) Resolve(context.Context, string) (*Document, error) { return m.doc, nil }
+
+func TestRegistryRegister(t *testing.T) {
+	doc := &Document{ID: "did:example:123"}
+	r := NewRegistry(KeyMethod{})
+	r.Register(staticMethod{doc: doc})
+
+	out, err := r.ParseDID(context.Background(), "did:example:123")
+	require.NoError(t, err)
+	require.Same(t, doc, out)
+	_, ok := r.Handler(MethodPeer)
+	require.False(t, ok)
+}
+
+func TestWebDocumentURL(t *testing.T) {
+	cases := map[string]string{
+		"did:web:w3c-ccg.github.io":                  "https://w3c-ccg.github.io/.well-known/did.json",
+		"did:web:w3c-ccg.github.io:user:alice":       "https://w3c-ccg.github.io/user/alice/did.json",
+		"did:web:example.com%3A3000:user:alice":      "https://example.com:3000/user/alice/did.json",
+		"did:web:example.com:path%20with%20space:id": "https://example.com/path%20with%20space/id/did.json",
+	}
+	for did, expected := range cases {
+		u, err := WebDocumentURL(did)
+		require.NoError(t, err, did)
+		require.Equal(t, expected, u)
+	}
+	for _, bad := range []string{"did:key:z6Mk", "did:web:example.com::alice", "did:web:evil.com%2F..", "did:web:a%40b.com"} {
+		_, err := WebDocumentURL(bad)
+		require.Error(t, err, bad)
+	}
+}
+
+func TestWebMethodResolve(t *testing.T) {
+	var did string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/user/alice/did.json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/did+json")
+		_, _ = w.Write([]byte(`{
+			"@context": "https://www.w3.org/ns/did/v1",
+			"id": "` + did + `",
+			"verificationMethod": [{
+				"id": "` + did + `#key-0",
+				"type": "JsonWebKey2020",
+				"controller": "` + did + `",
+				"publicKeyJwk": {"kty": "OKP", "crv": "Ed25519", "x": "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}
+			}],
+			"authentication": ["#key-0", {
+				"id": "` + did + `#key-1",
+				"type": "Multikey",
+				"controller": "` + did + `",
+				"publicKeyMultibase": "z6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK"
+			}],
+			"service": [{"id": "#hub", "type": "LinkedDomains", "serviceEndpoint": "https://example.com"}]
+		}`))
+	}))
+	defer server.Close()
+
+	host := strings.TrimPrefix(server.URL, "https://")
+	did = "did:web:" + strings.ReplaceAll(host, ":", "%3A") + ":user:alice"
+
+	web := &WebMethod{Client: server.Client()}
+	doc, err := NewRegistry(web).ParseDID(context.Background(), did)
+	require.NoError(t, err)
+	require.Equal(t, []string{ContextDIDv1}, doc.Context)
+	require.Len(t, doc.VerificationMethod, 2)
+	require.Equal(t, []string{"#key-0", did + "#key-1"}, doc.Authentication)
+
+	vm, ok := doc.VerificationMethodByID("#key-0")
+	require.True(t, ok)
+	id, err := FromJWK(vm.PublicKeyJwk)
+	require.NoError(t, err)
+	require.NotEmpty(t, id.String())
+
+	_, err = web.Resolve(context.Background(), strings.TrimSuffix(did, ":alice")+":bob")
+	require.Error(t, err)
+
+	// the default client does not trust the test server certificate
+	_, err = (&WebMethod{}).Resolve(context.Background(), did)
+	require.Error(t, err)
+}
+
+func TestPeerNumalgo0(t *testing.T) {
+	did := "did:peer:0z6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK"
+	doc, err := ParseDID(context.Background(), did)
+	require.NoError(t, err)
+	require.Equal(t, did, doc.ID)
+	require.Equal(t, []string{did + "#z6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK"}, doc.Authentication)
+	require.Equal(t, []string{did + "#z6LSj72tK8brWgZja8NLRwPigth2T9QRiG1uH9oKZuKjdh9p"}, doc.KeyAgreement)
+	for _, vm := range doc.VerificationMethod {
+		require.Equal(t, did, vm.Controller)
+	}
+}
+
+func TestPeerNumalgo2(t *testing.T) {
+	service := base64.RawURLEncoding.EncodeToString([]byte(
+		`{"t":"dm","s":{"uri":"https://example.com/endpoint","r":["did:example:somemediator#somekey"],"a":["didcomm/v2"]}}`,
+	))
+	did := "did:peer:2" +
+		".Ez6LSbysY2xFMRpGMhb7tFTLMpeuPRaqaWM1yECx2AtzE3KCc" +
+		".Vz6MkqRYqQiSgvZQdnBytw86Qbs2ZWUkGv22od935YF4s8M7V" +
+		".Vz6MkgoLTnTypo3tDRwCkZXSccTPHRLhF4ZnjhueYAFpEX6vg" +
+		".S" + service
+
+	doc, err := ParseDID(context.Background(), did)
+	require.NoError(t, err)
+	require.Len(t, doc.VerificationMethod, 3)
+	require.Equal(t, []string{did + "#key-1"}, doc.KeyAgreement)
+	require.Equal(t, []string{did + "#key-2", did + "#key-3"}, doc.Authentication)
+
+	require.Len(t, doc.Service, 1)
+	svc := doc.Service[0]
+	require.Equal(t, did+"#service", svc.ID)
+	require.Equal(t, "DIDCommMessaging", svc.Type)
+	endpoint, ok := svc.ServiceEndpoint.(map[string]any)
+	require.True(t, ok)
+	require.Equal(t, "https://example.com/endpoint", endpoint["uri"])
+	require.Equal(t, []any{"did:example:somemediator#somekey"}, endpoint["routingKeys"])
+	require.Equal(t, []any{"didcomm/v2"}, endpoint["accept"])
+}
+
+func TestPeerInvalid(t *testing.T) {
+	for _, bad := range []string{
+		"did:peer:1zQmZMygzYqNwU6Uhmewx5Xepf2VLp5S4HLSwwgf2aiKZuwa",
+		"did:peer:2",
+		"did:peer:2.X",
+		"did:peer:2.Qz6MkqRYqQiSgvZQdnBytw86Qbs2ZWUkGv22od935YF4s8M7V",
+		"did:peer:2.Vnotmultibase!",
+		"did:peer:2.S!!!",
+	} {
+		_, err := ParseDID(context.Background(), bad)
+		require.Error(t, err, bad)
+	}
+}
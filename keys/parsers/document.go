@@ -0,0 +1,187 @@
+package parsers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"filippo.io/edwards25519"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	mb "github.com/multiformats/go-multibase"
+)
+
+const (
+	// ContextDIDv1 is the base JSON-LD context of every DID Document
+	ContextDIDv1 = "https://www.w3.org/ns/did/v1"
+	// ContextMultikeyV1 defines the Multikey verification method type
+	ContextMultikeyV1 = "https://w3id.org/security/multikey/v1"
+	// VerificationMethodMultikey is the verification method type emitted for did:key documents
+	VerificationMethodMultikey = "Multikey"
+)
+
+// Document is a W3C DID Document as produced by the did:key method.
+// Verification relationships reference verification methods by id.
+type Document struct {
+	Context              []string             `json:"@context"`
+	ID                   string               `json:"id"`
+	VerificationMethod   []VerificationMethod `json:"verificationMethod"`
+	Authentication       []string             `json:"authentication,omitempty"`
+	AssertionMethod      []string             `json:"assertionMethod,omitempty"`
+	CapabilityDelegation []string             `json:"capabilityDelegation,omitempty"`
+	CapabilityInvocation []string             `json:"capabilityInvocation,omitempty"`
+	KeyAgreement         []string             `json:"keyAgreement,omitempty"`
+	Service              []Service            `json:"service,omitempty"`
+}
+
+// VerificationMethod is a public key entry of a DID Document
+type VerificationMethod struct {
+	ID                 string `json:"id"`
+	Type               string `json:"type"`
+	Controller         string `json:"controller"`
+	PublicKeyMultibase string `json:"publicKeyMultibase,omitempty"`
+	PublicKeyJwk       *JWK   `json:"publicKeyJwk,omitempty"`
+}
+
+// Service is a service endpoint entry of a DID Document
+type Service struct {
+	ID              string `json:"id"`
+	Type            string `json:"type"`
+	ServiceEndpoint any    `json:"serviceEndpoint"`
+}
+
+// UnmarshalJSON decodes a DID Document, accepting a single string @context and
+// verification methods embedded in verification relationships. Embedded methods
+// are moved into VerificationMethod and referenced by id.
+func (doc *Document) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Context              json.RawMessage      `json:"@context"`
+		ID                   string               `json:"id"`
+		VerificationMethod   []VerificationMethod `json:"verificationMethod"`
+		Authentication       []json.RawMessage    `json:"authentication"`
+		AssertionMethod      []json.RawMessage    `json:"assertionMethod"`
+		CapabilityDelegation []json.RawMessage    `json:"capabilityDelegation"`
+		CapabilityInvocation []json.RawMessage    `json:"capabilityInvocation"`
+		KeyAgreement         []json.RawMessage    `json:"keyAgreement"`
+		Service              []Service            `json:"service"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	out := Document{ID: aux.ID, VerificationMethod: aux.VerificationMethod, Service: aux.Service}
+	if len(aux.Context) > 0 {
+		var single string
+		if err := json.Unmarshal(aux.Context, &single); err == nil {
+			out.Context = []string{single}
+		} else if err := json.Unmarshal(aux.Context, &out.Context); err != nil {
+			return fmt.Errorf("invalid @context: %w", err)
+		}
+	}
+
+	relationships := []struct {
+		raw []json.RawMessage
+		dst *[]string
+	}{
+		{aux.Authentication, &out.Authentication},
+		{aux.AssertionMethod, &out.AssertionMethod},
+		{aux.CapabilityDelegation, &out.CapabilityDelegation},
+		{aux.CapabilityInvocation, &out.CapabilityInvocation},
+		{aux.KeyAgreement, &out.KeyAgreement},
+	}
+	for _, rel := range relationships {
+		for _, entry := range rel.raw {
+			var ref string
+			if err := json.Unmarshal(entry, &ref); err == nil {
+				*rel.dst = append(*rel.dst, ref)
+				continue
+			}
+			var vm VerificationMethod
+			if err := json.Unmarshal(entry, &vm); err != nil {
+				return fmt.Errorf("invalid verification relationship: %w", err)
+			}
+			out.VerificationMethod = append(out.VerificationMethod, vm)
+			*rel.dst = append(*rel.dst, vm.ID)
+		}
+	}
+	*doc = out
+	return nil
+}
+
+// VerificationMethodByID returns the verification method with the given id, which
+// may be either the full DID URL or just the fragment
+func (doc *Document) VerificationMethodByID(id string) (VerificationMethod, bool) {
+	if strings.HasPrefix(id, "#") {
+		id = doc.ID + id
+	}
+	for _, vm := range doc.VerificationMethod {
+		if vm.ID == id || (strings.HasPrefix(vm.ID, "#") && doc.ID+vm.ID == id) {
+			return vm, true
+		}
+	}
+	return VerificationMethod{}, false
+}
+
+// Resolve expands this did:key into its DID Document following
+// https://w3c-ccg.github.io/did-method-key/#document-creation-algorithm.
+// Ed25519 keys additionally get a derived X25519 key agreement method,
+// ECDSA curve keys are listed for key agreement directly.
+func (id DIDKey) Resolve() (*Document, error) {
+	did := id.String()
+	if did == "" {
+		return nil, fmt.Errorf("unable to encode did:key")
+	}
+	fingerprint := strings.TrimPrefix(did, KeyPrefix+":")
+	signing := VerificationMethod{
+		ID:                 did + "#" + fingerprint,
+		Type:               VerificationMethodMultikey,
+		Controller:         did,
+		PublicKeyMultibase: fingerprint,
+	}
+
+	doc := &Document{
+		Context:              []string{ContextDIDv1, ContextMultikeyV1},
+		ID:                   did,
+		VerificationMethod:   []VerificationMethod{signing},
+		Authentication:       []string{signing.ID},
+		AssertionMethod:      []string{signing.ID},
+		CapabilityDelegation: []string{signing.ID},
+		CapabilityInvocation: []string{signing.ID},
+	}
+
+	switch id.Type() {
+	case crypto.Ed25519:
+		raw, err := id.Raw()
+		if err != nil {
+			return nil, err
+		}
+		xpub, err := Ed25519ToX25519PublicKey(raw)
+		if err != nil {
+			return nil, err
+		}
+		xfingerprint, err := encodeMulticodec(mb.Base58BTC, MulticodecKindX25519PubKey, xpub)
+		if err != nil {
+			return nil, err
+		}
+		agreement := VerificationMethod{
+			ID:                 did + "#" + xfingerprint,
+			Type:               VerificationMethodMultikey,
+			Controller:         did,
+			PublicKeyMultibase: xfingerprint,
+		}
+		doc.VerificationMethod = append(doc.VerificationMethod, agreement)
+		doc.KeyAgreement = []string{agreement.ID}
+	case crypto.Secp256k1, crypto.ECDSA:
+		doc.KeyAgreement = []string{signing.ID}
+	}
+	return doc, nil
+}
+
+// Ed25519ToX25519PublicKey maps an Ed25519 public key to the X25519 public key
+// on the birationally equivalent Montgomery curve
+func Ed25519ToX25519PublicKey(pub []byte) ([]byte, error) {
+	p, err := new(edwards25519.Point).SetBytes(pub)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Ed25519 public key: %w", err)
+	}
+	return p.BytesMontgomery(), nil
+}
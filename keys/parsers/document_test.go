@@ -0,0 +1,74 @@
+package parsers
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/json"
+	"testing"
+
+	p2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/curve25519"
+)
+
+func TestResolveEd25519Vector(t *testing.T) {
+	did := "did:key:z6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK"
+	id, err := Parse(did)
+	require.NoError(t, err)
+
+	doc, err := id.Resolve()
+	require.NoError(t, err)
+	require.Equal(t, did, doc.ID)
+	require.Len(t, doc.VerificationMethod, 2)
+
+	signing := did + "#z6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK"
+	agreement := did + "#z6LSj72tK8brWgZja8NLRwPigth2T9QRiG1uH9oKZuKjdh9p"
+	require.Equal(t, []string{signing}, doc.Authentication)
+	require.Equal(t, []string{signing}, doc.AssertionMethod)
+	require.Equal(t, []string{agreement}, doc.KeyAgreement)
+
+	vm, ok := doc.VerificationMethodByID("#z6LSj72tK8brWgZja8NLRwPigth2T9QRiG1uH9oKZuKjdh9p")
+	require.True(t, ok)
+	require.Equal(t, did, vm.Controller)
+	require.Equal(t, VerificationMethodMultikey, vm.Type)
+
+	out, err := json.Marshal(doc)
+	require.NoError(t, err)
+	var decoded Document
+	require.NoError(t, json.Unmarshal(out, &decoded))
+	require.Equal(t, *doc, decoded)
+}
+
+func TestEd25519ToX25519MatchesPrivateKey(t *testing.T) {
+	pk, sk, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	// X25519 clamps the scalar itself, so the hashed seed is usable as is
+	h := sha512.Sum512(sk.Seed())
+	expected, err := curve25519.X25519(h[:32], curve25519.Basepoint)
+	require.NoError(t, err)
+
+	xpub, err := Ed25519ToX25519PublicKey(pk)
+	require.NoError(t, err)
+	require.Equal(t, expected, xpub)
+}
+
+func TestResolveKeyAgreementByType(t *testing.T) {
+	_, secp, err := p2pcrypto.GenerateSecp256k1Key(rand.Reader)
+	require.NoError(t, err)
+	id, err := NewKeyDID(secp)
+	require.NoError(t, err)
+	doc, err := id.Resolve()
+	require.NoError(t, err)
+	require.Len(t, doc.VerificationMethod, 1)
+	require.Equal(t, doc.Authentication, doc.KeyAgreement)
+
+	_, rsaPub, err := p2pcrypto.GenerateRSAKeyPair(2048, rand.Reader)
+	require.NoError(t, err)
+	id, err = NewKeyDID(rsaPub)
+	require.NoError(t, err)
+	doc, err = id.Resolve()
+	require.NoError(t, err)
+	require.Empty(t, doc.KeyAgreement)
+}
@@ -0,0 +1,167 @@
+package parsers
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/libp2p/go-libp2p/core/crypto"
+)
+
+// JWK is an RFC 7517 JSON Web Key holding a public key
+type JWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Kid string `json:"kid,omitempty"`
+}
+
+const (
+	jwkKtyOKP = "OKP"
+	jwkKtyEC  = "EC"
+	jwkKtyRSA = "RSA"
+
+	jwkCrvEd25519   = "Ed25519"
+	jwkCrvSecp256k1 = "secp256k1"
+	jwkCrvP256      = "P-256"
+	jwkCrvP384      = "P-384"
+)
+
+var b64 = base64.RawURLEncoding
+
+// ToJWK converts this did:key into a JSON Web Key. Ed25519 keys map to OKP,
+// Secp256k1, P-256 and P-384 keys map to EC and RSA keys map to RSA.
+// The key id is set to the did:key verification method id.
+func (id DIDKey) ToJWK() (*JWK, error) {
+	vk, err := id.VerifyKey()
+	if err != nil {
+		return nil, err
+	}
+	did := id.String()
+	jwk := &JWK{Kid: did + "#" + did[len(KeyPrefix)+1:]}
+	switch key := vk.(type) {
+	case []byte:
+		pub, err := btcec.ParsePubKey(key)
+		if err != nil {
+			return nil, err
+		}
+		jwk.Kty, jwk.Crv = jwkKtyEC, jwkCrvSecp256k1
+		jwk.X = b64.EncodeToString(pub.X().FillBytes(make([]byte, 32)))
+		jwk.Y = b64.EncodeToString(pub.Y().FillBytes(make([]byte, 32)))
+	case *ecdsa.PublicKey:
+		size := (key.Curve.Params().BitSize + 7) / 8
+		jwk.Kty, jwk.Crv = jwkKtyEC, key.Curve.Params().Name
+		jwk.X = b64.EncodeToString(key.X.FillBytes(make([]byte, size)))
+		jwk.Y = b64.EncodeToString(key.Y.FillBytes(make([]byte, size)))
+	case *rsa.PublicKey:
+		jwk.Kty = jwkKtyRSA
+		jwk.N = b64.EncodeToString(key.N.Bytes())
+		jwk.E = b64.EncodeToString(big.NewInt(int64(key.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.Kty, jwk.Crv = jwkKtyOKP, jwkCrvEd25519
+		jwk.X = b64.EncodeToString(key)
+	default:
+		return nil, fmt.Errorf("unsupported JWK key type: %s", id.Type())
+	}
+	return jwk, nil
+}
+
+// FromJWK converts a JSON Web Key into a did:key. Private key members are ignored.
+func FromJWK(jwk *JWK) (DIDKey, error) {
+	if jwk == nil {
+		return DIDKey{}, fmt.Errorf("jwk cannot be nil")
+	}
+	var (
+		pub crypto.PubKey
+		err error
+	)
+	switch jwk.Kty {
+	case jwkKtyOKP:
+		if jwk.Crv != jwkCrvEd25519 {
+			return DIDKey{}, fmt.Errorf("unsupported OKP curve: %s", jwk.Crv)
+		}
+		x, err := b64.DecodeString(jwk.X)
+		if err != nil {
+			return DIDKey{}, fmt.Errorf("decoding x: %w", err)
+		}
+		pub, err = crypto.UnmarshalEd25519PublicKey(x)
+		if err != nil {
+			return DIDKey{}, err
+		}
+	case jwkKtyEC:
+		pub, err = ecPubKeyFromJWK(jwk)
+		if err != nil {
+			return DIDKey{}, err
+		}
+	case jwkKtyRSA:
+		n, err := b64.DecodeString(jwk.N)
+		if err != nil {
+			return DIDKey{}, fmt.Errorf("decoding n: %w", err)
+		}
+		e, err := b64.DecodeString(jwk.E)
+		if err != nil {
+			return DIDKey{}, fmt.Errorf("decoding e: %w", err)
+		}
+		exp := new(big.Int).SetBytes(e)
+		if !exp.IsInt64() || exp.Int64() > 1<<31-1 {
+			return DIDKey{}, fmt.Errorf("rsa public exponent too large")
+		}
+		der, err := x509.MarshalPKIXPublicKey(&rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exp.Int64())})
+		if err != nil {
+			return DIDKey{}, err
+		}
+		pub, err = crypto.UnmarshalRsaPublicKey(der)
+		if err != nil {
+			return DIDKey{}, err
+		}
+	default:
+		return DIDKey{}, fmt.Errorf("unsupported JWK key type: %s", jwk.Kty)
+	}
+	return NewKeyDID(pub)
+}
+
+func ecPubKeyFromJWK(jwk *JWK) (crypto.PubKey, error) {
+	x, err := b64.DecodeString(jwk.X)
+	if err != nil {
+		return nil, fmt.Errorf("decoding x: %w", err)
+	}
+	y, err := b64.DecodeString(jwk.Y)
+	if err != nil {
+		return nil, fmt.Errorf("decoding y: %w", err)
+	}
+
+	var curve elliptic.Curve
+	switch jwk.Crv {
+	case jwkCrvSecp256k1:
+		if len(x) != 32 || len(y) != 32 {
+			return nil, fmt.Errorf("invalid secp256k1 coordinate length")
+		}
+		uncompressed := append(append([]byte{0x04}, x...), y...)
+		return crypto.UnmarshalSecp256k1PublicKey(uncompressed)
+	case jwkCrvP256:
+		curve = elliptic.P256()
+	case jwkCrvP384:
+		curve = elliptic.P384()
+	default:
+		return nil, fmt.Errorf("unsupported EC curve: %s", jwk.Crv)
+	}
+
+	size := (curve.Params().BitSize + 7) / 8
+	if len(x) != size || len(y) != size {
+		return nil, fmt.Errorf("invalid %s coordinate length", jwk.Crv)
+	}
+	pub := ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+	if !curve.IsOnCurve(pub.X, pub.Y) {
+		return nil, fmt.Errorf("point is not on curve %s", jwk.Crv)
+	}
+	return crypto.ECDSAPublicKeyFromPubKey(pub)
+}
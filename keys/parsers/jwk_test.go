@@ -0,0 +1,75 @@
+package parsers
+
+import (
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+
+	p2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWKRoundTrip(t *testing.T) {
+	pubs := make([]p2pcrypto.PubKey, 0, 5)
+	for _, keyType := range []int{p2pcrypto.Ed25519, p2pcrypto.Secp256k1, p2pcrypto.RSA} {
+		_, pub, err := p2pcrypto.GenerateKeyPairWithReader(keyType, 2048, rand.Reader)
+		require.NoError(t, err)
+		pubs = append(pubs, pub)
+	}
+	for _, curve := range []elliptic.Curve{elliptic.P256(), elliptic.P384()} {
+		_, pub, err := p2pcrypto.GenerateECDSAKeyPairWithCurve(curve, rand.Reader)
+		require.NoError(t, err)
+		pubs = append(pubs, pub)
+	}
+
+	for _, pub := range pubs {
+		id, err := NewKeyDID(pub)
+		require.NoError(t, err)
+		jwk, err := id.ToJWK()
+		require.NoError(t, err)
+
+		bz, err := json.Marshal(jwk)
+		require.NoError(t, err)
+		var decoded JWK
+		require.NoError(t, json.Unmarshal(bz, &decoded))
+
+		parsed, err := FromJWK(&decoded)
+		require.NoError(t, err)
+		require.Equal(t, id.String(), parsed.String())
+	}
+}
+
+func TestFromJWKVectors(t *testing.T) {
+	// RFC 8037 appendix A.2
+	okp := &JWK{Kty: "OKP", Crv: "Ed25519", X: "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}
+	id, err := FromJWK(okp)
+	require.NoError(t, err)
+	require.Equal(t, p2pcrypto.Ed25519, int(id.Type()))
+
+	// RFC 7517 appendix A.1
+	ec := &JWK{
+		Kty: "EC",
+		Crv: "P-256",
+		X:   "MKBCTNIcKUSDii11ySs3526iDZ8AiTo7Tu6KPAqv7D4",
+		Y:   "4Etl6SRW2YiLUrN5vfvVHuhp7x8PxltmWWlbbM4IFyM",
+	}
+	id, err = FromJWK(ec)
+	require.NoError(t, err)
+	require.Equal(t, uint64(MulticodecKindP256PubKey), multicodecOf(t, id))
+	out, err := id.ToJWK()
+	require.NoError(t, err)
+	require.Equal(t, ec.X, out.X)
+	require.Equal(t, ec.Y, out.Y)
+}
+
+func TestFromJWKInvalid(t *testing.T) {
+	_, err := FromJWK(&JWK{Kty: "oct"})
+	require.Error(t, err)
+	_, err = FromJWK(&JWK{Kty: "OKP", Crv: "X25519", X: "AA"})
+	require.Error(t, err)
+	_, err = FromJWK(&JWK{Kty: "EC", Crv: "P-256", X: "AA", Y: "AA"})
+	require.Error(t, err)
+	_, err = FromJWK(nil)
+	require.Error(t, err)
+}
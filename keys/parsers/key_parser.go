@@ -1,7 +1,9 @@
 package parsers
 
 import (
+	"crypto/ecdsa"
 	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rsa"
 	"crypto/x509"
 	"fmt"
@@ -21,6 +23,16 @@ const (
 	MulticodecKindEd25519PubKey = 0xed
 	// MulticodecKindSecp256k1PubKey secp256k1-pub
 	MulticodecKindSecp256k1PubKey = 0x1206
+	// MulticodecKindP256PubKey p256-pub
+	MulticodecKindP256PubKey = 0x1200
+	// MulticodecKindP384PubKey p384-pub
+	MulticodecKindP384PubKey = 0x1201
+	// MulticodecKindBLS12381G1PubKey bls12_381-g1-pub
+	MulticodecKindBLS12381G1PubKey = 0xea
+	// MulticodecKindBLS12381G2PubKey bls12_381-g2-pub
+	MulticodecKindBLS12381G2PubKey = 0xeb
+	// MulticodecKindX25519PubKey x25519-pub
+	MulticodecKindX25519PubKey = 0xec
 )
 
 // DIDKey is a DID:key identifier
@@ -33,48 +45,104 @@ func NewKeyDID(pub crypto.PubKey) (DIDKey, error) {
 	switch pub.Type() {
 	case crypto.Ed25519, crypto.RSA, crypto.Secp256k1:
 		return DIDKey{PubKey: pub}, nil
+	case crypto.ECDSA:
+		if _, err := ecdsaMulticodec(pub); err != nil {
+			return DIDKey{}, err
+		}
+		return DIDKey{PubKey: pub}, nil
+	case KeyTypeBLS12381G1, KeyTypeBLS12381G2:
+		if _, ok := pub.(*BLS12381PublicKey); !ok {
+			return DIDKey{}, fmt.Errorf("unexpected BLS12-381 key implementation: %T", pub)
+		}
+		return DIDKey{PubKey: pub}, nil
+	}
+	switch pub.(type) {
+	case *MLDSAPublicKey, *SLHDSAPublicKey, *CompositePublicKey:
+		return DIDKey{PubKey: pub}, nil
 	default:
 		return DIDKey{}, fmt.Errorf("unsupported key type: %s", pub.Type())
 	}
 }
 
-// MulticodecType indicates the type for this multicodec
-func (id DIDKey) MulticodecType() uint64 {
+// MulticodecType indicates the type for this multicodec. It returns an error
+// for a key type or ECDSA curve that has no did:key multicodec.
+func (id DIDKey) MulticodecType() (uint64, error) {
+	if id.PubKey == nil {
+		return 0, fmt.Errorf("missing public key")
+	}
 	switch id.Type() {
 	case crypto.RSA:
-		return MulticodecKindRSAPubKey
+		return MulticodecKindRSAPubKey, nil
 	case crypto.Ed25519:
-		return MulticodecKindEd25519PubKey
+		return MulticodecKindEd25519PubKey, nil
 	case crypto.Secp256k1:
-		return MulticodecKindSecp256k1PubKey
-	default:
-		panic("unexpected crypto type")
+		return MulticodecKindSecp256k1PubKey, nil
+	case crypto.ECDSA:
+		return ecdsaMulticodec(id.PubKey)
+	case KeyTypeBLS12381G1:
+		return MulticodecKindBLS12381G1PubKey, nil
+	case KeyTypeBLS12381G2:
+		return MulticodecKindBLS12381G2PubKey, nil
+	}
+	if k, ok := id.PubKey.(multicodecKey); ok {
+		return k.multicodec(), nil
+	}
+	return 0, fmt.Errorf("unsupported key type: %s", id.Type())
+}
+
+// multicodecRaw returns the key bytes that follow the multicodec prefix.
+// NIST curve keys are stored as compressed points rather than PKIX DER.
+func (id DIDKey) multicodecRaw() ([]byte, error) {
+	if id.Type() != crypto.ECDSA {
+		return id.Raw()
+	}
+	pub, err := ecdsaPublicKey(id.PubKey)
+	if err != nil {
+		return nil, err
 	}
+	return elliptic.MarshalCompressed(pub.Curve, pub.X, pub.Y), nil
 }
 
 // String returns this did:key formatted as a string
 func (id DIDKey) String() string {
-	raw, err := id.Raw()
+	return id.StringWithEncoding(mb.Base58BTC)
+}
+
+// StringWithEncoding returns this did:key formatted as a string with the
+// key material encoded in the given multibase. The did:key spec only
+// mandates base58btc, use String unless the consumer expects otherwise.
+func (id DIDKey) StringWithEncoding(enc mb.Encoding) string {
+	t, err := id.MulticodecType()
+	if err != nil {
+		return ""
+	}
+	raw, err := id.multicodecRaw()
 	if err != nil {
 		return ""
 	}
 
-	t := id.MulticodecType()
+	keyStr, err := encodeMulticodec(enc, t, raw)
+	if err != nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%s:%s", KeyPrefix, keyStr)
+}
+
+// encodeMulticodec prefixes raw with the multicodec t and encodes it as multibase
+func encodeMulticodec(enc mb.Encoding, t uint64, raw []byte) (string, error) {
 	size := varint.UvarintSize(t)
 	data := make([]byte, size+len(raw))
 	n := varint.PutUvarint(data, t)
 	copy(data[n:], raw)
 
-	b58BKeyStr, err := mb.Encode(mb.Base58BTC, data)
-	if err != nil {
-		return ""
-	}
-
-	return fmt.Sprintf("%s:%s", KeyPrefix, b58BKeyStr)
+	return mb.Encode(enc, data)
 }
 
 // VerifyKey returns the backing implementation for a public key, one of:
-// *rsa.PublicKey, ed25519.PublicKey
+// *rsa.PublicKey, ed25519.PublicKey, *ecdsa.PublicKey, the raw Secp256k1 bytes,
+// *curves.PointBls12381G1, *curves.PointBls12381G2, *mldsa.PublicKey,
+// *slhdsa.PublicKey or *mldsa.CompositePublicKey
 func (id DIDKey) VerifyKey() (interface{}, error) {
 	rawPubBytes, err := id.PubKey.Raw()
 	if err != nil {
@@ -99,6 +167,22 @@ func (id DIDKey) VerifyKey() (interface{}, error) {
 			return rawPubBytes, nil
 		}
 		return nil, fmt.Errorf("invalid Secp256k1 public key length: %d", len(rawPubBytes))
+	case crypto.ECDSA:
+		return ecdsaPublicKey(id.PubKey)
+	case KeyTypeBLS12381G1, KeyTypeBLS12381G2:
+		blsKey, ok := id.PubKey.(*BLS12381PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("public key is not a BLS12-381 key. got type: %T", id.PubKey)
+		}
+		return blsKey.Point(), nil
+	}
+	switch key := id.PubKey.(type) {
+	case *MLDSAPublicKey:
+		return key.Key(), nil
+	case *SLHDSAPublicKey:
+		return key.Key(), nil
+	case *CompositePublicKey:
+		return key.Key(), nil
 	default:
 		return nil, fmt.Errorf("unrecognized Public Key type: %s", id.Type())
 	}
@@ -113,15 +197,19 @@ func Parse(keystr string) (DIDKey, error) {
 
 	keystr = strings.TrimPrefix(keystr, KeyPrefix+":")
 
-	enc, data, err := mb.Decode(keystr)
+	// any multibase is accepted, String always emits base58btc
+	_, data, err := mb.Decode(keystr)
 	if err != nil {
 		return id, fmt.Errorf("decoding multibase: %w", err)
 	}
 
-	if enc != mb.Base58BTC {
-		return id, fmt.Errorf("unexpected multibase encoding: %s", mb.EncodingToStr[enc])
-	}
+	return parseMulticodec(data)
+}
 
+// parseMulticodec turns multicodec prefixed key bytes into a key method ID.
+// The returned key may reference data.
+func parseMulticodec(data []byte) (DIDKey, error) {
+	var id DIDKey
 	keyType, n, err := varint.FromUvarint(data)
 	if err != nil {
 		return id, err
@@ -151,7 +239,90 @@ func Parse(keystr string) (DIDKey, error) {
 			return id, fmt.Errorf("failed to unmarshal Secp256k1 key: %w", err)
 		}
 		return DIDKey{pub}, nil
+	case MulticodecKindP256PubKey:
+		return parseECDSAKey(elliptic.P256(), data[n:])
+	case MulticodecKindP384PubKey:
+		return parseECDSAKey(elliptic.P384(), data[n:])
+	case MulticodecKindBLS12381G1PubKey:
+		pub, err := UnmarshalBLS12381G1PublicKey(data[n:])
+		if err != nil {
+			return id, err
+		}
+		return DIDKey{pub}, nil
+	case MulticodecKindBLS12381G2PubKey:
+		pub, err := UnmarshalBLS12381G2PublicKey(data[n:])
+		if err != nil {
+			return id, err
+		}
+		return DIDKey{pub}, nil
+	}
+	if _, ok := mldsaMulticodecs[keyType]; ok {
+		pub, err := UnmarshalMLDSAPublicKey(keyType, data[n:])
+		if err != nil {
+			return id, err
+		}
+		return DIDKey{pub}, nil
+	}
+	if _, ok := slhdsaMulticodecs[keyType]; ok {
+		pub, err := UnmarshalSLHDSAPublicKey(keyType, data[n:])
+		if err != nil {
+			return id, err
+		}
+		return DIDKey{pub}, nil
+	}
+	if _, ok := compositeMulticodecs[keyType]; ok {
+		pub, err := UnmarshalCompositePublicKey(keyType, data[n:])
+		if err != nil {
+			return id, err
+		}
+		return DIDKey{pub}, nil
 	}
 
 	return id, fmt.Errorf("unrecognized key type multicodec prefix: %x", data[0])
 }
+
+// parseECDSAKey decodes a compressed NIST curve point into a DIDKey
+func parseECDSAKey(curve elliptic.Curve, keyData []byte) (DIDKey, error) {
+	x, y := elliptic.UnmarshalCompressed(curve, keyData)
+	if x == nil {
+		return DIDKey{}, fmt.Errorf("invalid %s public key", curve.Params().Name)
+	}
+	pub, err := crypto.ECDSAPublicKeyFromPubKey(ecdsa.PublicKey{Curve: curve, X: x, Y: y})
+	if err != nil {
+		return DIDKey{}, fmt.Errorf("failed to unmarshal %s key: %w", curve.Params().Name, err)
+	}
+	return DIDKey{pub}, nil
+}
+
+// ecdsaPublicKey extracts the standard library key from a libp2p ECDSA key
+func ecdsaPublicKey(pub crypto.PubKey) (*ecdsa.PublicKey, error) {
+	raw, err := pub.Raw()
+	if err != nil {
+		return nil, err
+	}
+	keyiface, err := x509.ParsePKIXPublicKey(raw)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := keyiface.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("public key is not an ECDSA key. got type: %T", keyiface)
+	}
+	return key, nil
+}
+
+// ecdsaMulticodec returns the multicodec for the curve of an ECDSA key
+func ecdsaMulticodec(pub crypto.PubKey) (uint64, error) {
+	key, err := ecdsaPublicKey(pub)
+	if err != nil {
+		return 0, err
+	}
+	switch key.Curve {
+	case elliptic.P256():
+		return MulticodecKindP256PubKey, nil
+	case elliptic.P384():
+		return MulticodecKindP384PubKey, nil
+	default:
+		return 0, fmt.Errorf("unsupported ECDSA curve: %s", key.Curve.Params().Name)
+	}
+}
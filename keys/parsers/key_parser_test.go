@@ -0,0 +1,261 @@
+package parsers
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	mb "github.com/multiformats/go-multibase"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/pqc/mldsa"
+	"github.com/go-sonr/crypto/pqc/slhdsa"
+	"github.com/go-sonr/crypto/signatures/bls/bls_sig"
+)
+
+func TestParseNistCurveVectors(t *testing.T) {
+	vectors := []struct {
+		did   string
+		curve elliptic.Curve
+	}{
+		{"did:key:zDnaerDaTF5BXEavCrfRZEk316dpbLsfPDZ3WJ5hRTPFU2169", elliptic.P256()},
+		{"did:key:zDnaerx9CtbPJ1q36T5Ln5wYt3MQYeGRG5ehnPAmxcf5mDZpv", elliptic.P256()},
+		{"did:key:z82Lm1MpAkeJcix9K8TMiLd5NMAhnwkjjCBeWHXyu3U4oT2MVJJKXkcVBgjGhnLBn2Kaau9", elliptic.P384()},
+		{"did:key:z82LkvCwHNreneWpsgPEbV3gu1C6NFJEBg4srfJ5gdxEsMGRJUz2sG9FE42shbn2xkZJh54", elliptic.P384()},
+	}
+	for _, v := range vectors {
+		id, err := Parse(v.did)
+		require.NoError(t, err)
+		require.Equal(t, v.did, id.String())
+
+		vk, err := id.VerifyKey()
+		require.NoError(t, err)
+		pub, ok := vk.(*ecdsa.PublicKey)
+		require.True(t, ok)
+		require.Equal(t, v.curve, pub.Curve)
+	}
+}
+
+func TestNistCurveRoundTrip(t *testing.T) {
+	for _, curve := range []elliptic.Curve{elliptic.P256(), elliptic.P384()} {
+		_, pub, err := crypto.GenerateECDSAKeyPairWithCurve(curve, rand.Reader)
+		require.NoError(t, err)
+
+		id, err := NewKeyDID(pub)
+		require.NoError(t, err)
+
+		parsed, err := Parse(id.String())
+		require.NoError(t, err)
+		require.True(t, parsed.Equals(pub))
+		require.Equal(t, multicodecOf(t, id), multicodecOf(t, parsed))
+	}
+	require.Equal(t, uint64(MulticodecKindP256PubKey), multicodecOf(t, mustDIDKey(t, elliptic.P256())))
+	require.Equal(t, uint64(MulticodecKindP384PubKey), multicodecOf(t, mustDIDKey(t, elliptic.P384())))
+}
+
+func TestNewKeyDIDUnsupportedCurve(t *testing.T) {
+	_, pub, err := crypto.GenerateECDSAKeyPairWithCurve(elliptic.P521(), rand.Reader)
+	require.NoError(t, err)
+	_, err = NewKeyDID(pub)
+	require.Error(t, err)
+
+	// a key built without NewKeyDID is rejected rather than panicking
+	id := DIDKey{PubKey: pub}
+	_, err = id.MulticodecType()
+	require.Error(t, err)
+	require.Empty(t, id.String())
+	_, err = DIDKey{}.MulticodecType()
+	require.Error(t, err)
+	require.Empty(t, DIDKey{}.String())
+}
+
+func TestParseInvalidNistPoint(t *testing.T) {
+	// a p256-pub prefix followed by a truncated point
+	_, err := Parse("did:key:zDnaerDaTF5BXEavCrfRZEk316dpbLsfPDZ3WJ5hRTPFU21")
+	require.Error(t, err)
+}
+
+func mustDIDKey(t *testing.T, curve elliptic.Curve) DIDKey {
+	_, pub, err := crypto.GenerateECDSAKeyPairWithCurve(curve, rand.Reader)
+	require.NoError(t, err)
+	id, err := NewKeyDID(pub)
+	require.NoError(t, err)
+	return id
+}
+
+func TestBLS12381G1RoundTrip(t *testing.T) {
+	scheme := bls_sig.NewSigPop()
+	pk, sk, err := scheme.Keygen()
+	require.NoError(t, err)
+	raw, err := pk.MarshalBinary()
+	require.NoError(t, err)
+
+	pub, err := UnmarshalBLS12381G1PublicKey(raw)
+	require.NoError(t, err)
+	id, err := NewKeyDID(pub)
+	require.NoError(t, err)
+	require.Equal(t, uint64(MulticodecKindBLS12381G1PubKey), multicodecOf(t, id))
+
+	parsed, err := Parse(id.String())
+	require.NoError(t, err)
+	require.True(t, parsed.Equals(pub))
+	vk, err := parsed.VerifyKey()
+	require.NoError(t, err)
+	_, ok := vk.(*curves.PointBls12381G1)
+	require.True(t, ok)
+
+	msg := []byte("did:key bls12_381-g1-pub")
+	sig, err := scheme.Sign(sk, msg)
+	require.NoError(t, err)
+	sigBytes, err := sig.MarshalBinary()
+	require.NoError(t, err)
+	valid, err := parsed.PubKey.Verify(msg, sigBytes)
+	require.NoError(t, err)
+	require.True(t, valid)
+}
+
+func TestBLS12381G2RoundTrip(t *testing.T) {
+	scheme := bls_sig.NewSigPopVt()
+	pk, sk, err := scheme.Keygen()
+	require.NoError(t, err)
+	raw, err := pk.MarshalBinary()
+	require.NoError(t, err)
+
+	pub, err := UnmarshalBLS12381G2PublicKey(raw)
+	require.NoError(t, err)
+	id, err := NewKeyDID(pub)
+	require.NoError(t, err)
+	require.Equal(t, uint64(MulticodecKindBLS12381G2PubKey), multicodecOf(t, id))
+
+	parsed, err := Parse(id.String())
+	require.NoError(t, err)
+	require.True(t, parsed.Equals(pub))
+	vk, err := parsed.VerifyKey()
+	require.NoError(t, err)
+	_, ok := vk.(*curves.PointBls12381G2)
+	require.True(t, ok)
+
+	msg := []byte("did:key bls12_381-g2-pub")
+	sig, err := scheme.Sign(sk, msg)
+	require.NoError(t, err)
+	sigBytes, err := sig.MarshalBinary()
+	require.NoError(t, err)
+	valid, err := parsed.PubKey.Verify(msg, sigBytes)
+	require.NoError(t, err)
+	require.True(t, valid)
+}
+
+func TestParseInvalidBLS12381Point(t *testing.T) {
+	_, err := UnmarshalBLS12381G1PublicKey(make([]byte, 47))
+	require.Error(t, err)
+	_, err = UnmarshalBLS12381G2PublicKey(make([]byte, 96))
+	require.Error(t, err)
+}
+
+func TestPostQuantumRoundTrip(t *testing.T) {
+	for _, p := range []*mldsa.ParameterSet{mldsa.MLDSA44, mldsa.MLDSA65, mldsa.MLDSA87} {
+		sk, err := p.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		pub := NewMLDSAPublicKey(sk.PublicKey())
+		id, err := NewKeyDID(pub)
+		require.NoError(t, err)
+
+		parsed, err := Parse(id.String())
+		require.NoError(t, err)
+		require.True(t, parsed.Equals(pub))
+		require.Equal(t, multicodecOf(t, id), multicodecOf(t, parsed))
+		vk, err := parsed.VerifyKey()
+		require.NoError(t, err)
+		require.True(t, vk.(*mldsa.PublicKey).Equal(sk.PublicKey()))
+	}
+	require.Equal(t, uint64(MulticodecKindMLDSA65PubKey), multicodecOf(t, mustMLDSADIDKey(t, mldsa.MLDSA65)))
+
+	for _, p := range []*slhdsa.ParameterSet{slhdsa.SLHDSASHA2128f, slhdsa.SLHDSASHAKE256f} {
+		sk, err := p.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		pub := NewSLHDSAPublicKey(sk.PublicKey())
+		id, err := NewKeyDID(pub)
+		require.NoError(t, err)
+
+		parsed, err := Parse(id.String())
+		require.NoError(t, err)
+		require.True(t, parsed.Equals(pub))
+		require.Equal(t, multicodecOf(t, id), multicodecOf(t, parsed))
+		vk, err := parsed.VerifyKey()
+		require.NoError(t, err)
+		require.True(t, vk.(*slhdsa.PublicKey).Equal(sk.PublicKey()))
+	}
+}
+
+func TestCompositeRoundTrip(t *testing.T) {
+	for _, c := range []*mldsa.Composite{mldsa.MLDSA44Ed25519, mldsa.MLDSA65Ed25519} {
+		sk, err := c.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		pub := NewCompositePublicKey(sk.PublicKey())
+		id, err := NewKeyDID(pub)
+		require.NoError(t, err)
+
+		parsed, err := Parse(id.String())
+		require.NoError(t, err)
+		require.True(t, parsed.Equals(pub))
+		require.Equal(t, multicodecOf(t, id), multicodecOf(t, parsed))
+		vk, err := parsed.VerifyKey()
+		require.NoError(t, err)
+		require.True(t, vk.(*mldsa.CompositePublicKey).Equal(sk.PublicKey()))
+
+		doc, err := parsed.Resolve()
+		require.NoError(t, err)
+		require.Empty(t, doc.KeyAgreement)
+	}
+}
+
+func TestParseInvalidPostQuantumKey(t *testing.T) {
+	_, err := UnmarshalMLDSAPublicKey(MulticodecKindMLDSA44PubKey, make([]byte, 1311))
+	require.Error(t, err)
+	_, err = UnmarshalMLDSAPublicKey(MulticodecKindSLHDSASHA2128sPubKey, make([]byte, 1312))
+	require.Error(t, err)
+	_, err = UnmarshalSLHDSAPublicKey(MulticodecKindSLHDSASHAKE128fPubKey, make([]byte, 31))
+	require.Error(t, err)
+	_, err = UnmarshalCompositePublicKey(MulticodecKindMLDSA44Ed25519PubKey, make([]byte, 1312))
+	require.Error(t, err)
+}
+
+func multicodecOf(t *testing.T, id DIDKey) uint64 {
+	codec, err := id.MulticodecType()
+	require.NoError(t, err)
+	return codec
+}
+
+func mustMLDSADIDKey(t *testing.T, p *mldsa.ParameterSet) DIDKey {
+	sk, err := p.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	id, err := NewKeyDID(NewMLDSAPublicKey(sk.PublicKey()))
+	require.NoError(t, err)
+	return id
+}
+
+func TestStringWithEncoding(t *testing.T) {
+	did := "did:key:z6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK"
+	id, err := Parse(did)
+	require.NoError(t, err)
+
+	for _, enc := range []mb.Encoding{mb.Base64url, mb.Base32, mb.Base16, mb.Base58BTC} {
+		encoded := id.StringWithEncoding(enc)
+		require.NotEmpty(t, encoded)
+
+		parsed, err := Parse(encoded)
+		require.NoError(t, err)
+		require.True(t, parsed.Equals(id.PubKey))
+		require.Equal(t, did, parsed.String())
+	}
+	require.Equal(t, did, id.StringWithEncoding(mb.Base58BTC))
+	require.Equal(t, byte('u'), id.StringWithEncoding(mb.Base64url)[len(KeyPrefix)+1])
+}
+
+func TestParseInvalidMultibase(t *testing.T) {
+	_, err := Parse("did:key:!notmultibase")
+	require.Error(t, err)
+}
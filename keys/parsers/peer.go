@@ -0,0 +1,226 @@
+package parsers
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	mb "github.com/multiformats/go-multibase"
+	varint "github.com/multiformats/go-varint"
+)
+
+// PeerPrefix indicates a decentralized identifier that uses the peer method
+const PeerPrefix = "did:peer"
+
+// did:peer numalgo 2 element purposes
+const (
+	peerPurposeAssertion            = 'A'
+	peerPurposeKeyAgreement         = 'E'
+	peerPurposeAuthentication       = 'V'
+	peerPurposeCapabilityInvocation = 'I'
+	peerPurposeCapabilityDelegation = 'D'
+	peerPurposeService              = 'S'
+)
+
+// peerServiceAbbreviations expands the abbreviated keys and values of numalgo 2 services
+var peerServiceAbbreviations = map[string]string{
+	"t":  "type",
+	"s":  "serviceEndpoint",
+	"r":  "routingKeys",
+	"a":  "accept",
+	"dm": "DIDCommMessaging",
+}
+
+// PeerMethod resolves did:peer identifiers with numalgo 0 and 2 as described in
+// https://identity.foundation/peer-did-method-spec/
+type PeerMethod struct{}
+
+// Method returns "peer"
+func (PeerMethod) Method() string {
+	return MethodPeer
+}
+
+// Resolve turns a did:peer into its DID Document
+func (PeerMethod) Resolve(_ context.Context, did string) (*Document, error) {
+	if !strings.HasPrefix(did, PeerPrefix+":") || len(did) < len(PeerPrefix)+2 {
+		return nil, fmt.Errorf("decentralized identifier is not a 'peer' type")
+	}
+	id := did[len(PeerPrefix)+1:]
+	switch id[0] {
+	case '0':
+		return resolvePeer0(did, id[1:])
+	case '2':
+		return resolvePeer2(did, id[1:])
+	default:
+		return nil, fmt.Errorf("unsupported did:peer numalgo: %c", id[0])
+	}
+}
+
+// resolvePeer0 builds the document of an inception key, which matches its did:key document
+func resolvePeer0(did, key string) (*Document, error) {
+	id, err := Parse(KeyPrefix + ":" + key)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := id.Resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	keyDID := doc.ID
+	rebase := func(s string) string {
+		return did + strings.TrimPrefix(s, keyDID)
+	}
+	doc.ID = did
+	for i := range doc.VerificationMethod {
+		doc.VerificationMethod[i].ID = rebase(doc.VerificationMethod[i].ID)
+		doc.VerificationMethod[i].Controller = did
+	}
+	for _, rel := range []*[]string{
+		&doc.Authentication, &doc.AssertionMethod, &doc.CapabilityDelegation,
+		&doc.CapabilityInvocation, &doc.KeyAgreement,
+	} {
+		for i := range *rel {
+			(*rel)[i] = rebase((*rel)[i])
+		}
+	}
+	return doc, nil
+}
+
+// resolvePeer2 builds the document of a list of purpose prefixed keys and services
+func resolvePeer2(did, elements string) (*Document, error) {
+	if !strings.HasPrefix(elements, ".") {
+		return nil, fmt.Errorf("invalid did:peer:2 identifier")
+	}
+	doc := &Document{
+		Context: []string{ContextDIDv1, ContextMultikeyV1},
+		ID:      did,
+	}
+	for _, element := range strings.Split(elements[1:], ".") {
+		if len(element) < 2 {
+			return nil, fmt.Errorf("invalid did:peer:2 element %q", element)
+		}
+		purpose, value := element[0], element[1:]
+		if purpose == peerPurposeService {
+			svc, err := decodePeerService(value)
+			if err != nil {
+				return nil, err
+			}
+			if svc.ID == "" {
+				svc.ID = did + "#service"
+				if n := len(doc.Service); n > 0 {
+					svc.ID = fmt.Sprintf("%s#service-%d", did, n)
+				}
+			}
+			doc.Service = append(doc.Service, svc)
+			continue
+		}
+
+		if err := validatePeerKey(value); err != nil {
+			return nil, err
+		}
+		vm := VerificationMethod{
+			ID:                 fmt.Sprintf("%s#key-%d", did, len(doc.VerificationMethod)+1),
+			Type:               VerificationMethodMultikey,
+			Controller:         did,
+			PublicKeyMultibase: value,
+		}
+		doc.VerificationMethod = append(doc.VerificationMethod, vm)
+
+		switch purpose {
+		case peerPurposeAssertion:
+			doc.AssertionMethod = append(doc.AssertionMethod, vm.ID)
+		case peerPurposeKeyAgreement:
+			doc.KeyAgreement = append(doc.KeyAgreement, vm.ID)
+		case peerPurposeAuthentication:
+			doc.Authentication = append(doc.Authentication, vm.ID)
+		case peerPurposeCapabilityInvocation:
+			doc.CapabilityInvocation = append(doc.CapabilityInvocation, vm.ID)
+		case peerPurposeCapabilityDelegation:
+			doc.CapabilityDelegation = append(doc.CapabilityDelegation, vm.ID)
+		default:
+			return nil, fmt.Errorf("unknown did:peer:2 purpose: %c", purpose)
+		}
+	}
+	return doc, nil
+}
+
+// validatePeerKey checks that value is a multibase encoded multicodec key
+func validatePeerKey(value string) error {
+	enc, data, err := mb.Decode(value)
+	if err != nil {
+		return fmt.Errorf("decoding multibase: %w", err)
+	}
+	if enc != mb.Base58BTC {
+		return fmt.Errorf("unexpected multibase encoding: %s", mb.EncodingToStr[enc])
+	}
+	if _, _, err := varint.FromUvarint(data); err != nil {
+		return err
+	}
+	return nil
+}
+
+// decodePeerService decodes a base64url encoded abbreviated service
+func decodePeerService(value string) (Service, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	if err != nil {
+		return Service{}, fmt.Errorf("decoding did:peer:2 service: %w", err)
+	}
+	var abbreviated map[string]any
+	if err := json.Unmarshal(raw, &abbreviated); err != nil {
+		return Service{}, fmt.Errorf("decoding did:peer:2 service: %w", err)
+	}
+	expanded, ok := expandPeerService(abbreviated).(map[string]any)
+	if !ok {
+		return Service{}, fmt.Errorf("invalid did:peer:2 service")
+	}
+
+	var svc Service
+	if id, ok := expanded["id"].(string); ok {
+		svc.ID = id
+	}
+	if t, ok := expanded["type"].(string); ok {
+		svc.Type = t
+	}
+	svc.ServiceEndpoint = expanded["serviceEndpoint"]
+	// legacy encodings keep routing keys and accept next to the endpoint
+	if endpoint, ok := svc.ServiceEndpoint.(string); ok {
+		if routing, hasRouting := expanded["routingKeys"]; hasRouting {
+			object := map[string]any{"uri": endpoint, "routingKeys": routing}
+			if accept, hasAccept := expanded["accept"]; hasAccept {
+				object["accept"] = accept
+			}
+			svc.ServiceEndpoint = object
+		}
+	}
+	return svc, nil
+}
+
+func expandPeerService(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(t))
+		for k, val := range t {
+			if full, ok := peerServiceAbbreviations[k]; ok {
+				k = full
+			}
+			out[k] = expandPeerService(val)
+		}
+		return out
+	case []any:
+		for i := range t {
+			t[i] = expandPeerService(t[i])
+		}
+		return t
+	case string:
+		// "dm" is the only abbreviated value, other strings are kept verbatim
+		if t == "dm" {
+			return peerServiceAbbreviations[t]
+		}
+		return t
+	default:
+		return v
+	}
+}
@@ -0,0 +1,282 @@
+package parsers
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/crypto/pb"
+
+	"github.com/go-sonr/crypto/pqc/mldsa"
+	"github.com/go-sonr/crypto/pqc/slhdsa"
+)
+
+// Post-quantum public key multicodecs, draft entries of the multicodec table.
+// As with BLS12-381, libp2p has no matching key types so the multicodec value
+// is reused as the key type.
+const (
+	// MulticodecKindMLDSA44PubKey mldsa-44-pub
+	MulticodecKindMLDSA44PubKey = 0x1210
+	// MulticodecKindMLDSA65PubKey mldsa-65-pub
+	MulticodecKindMLDSA65PubKey = 0x1211
+	// MulticodecKindMLDSA87PubKey mldsa-87-pub
+	MulticodecKindMLDSA87PubKey = 0x1212
+	// MulticodecKindSLHDSASHA2128sPubKey slhdsa-sha2-128s-pub
+	MulticodecKindSLHDSASHA2128sPubKey = 0x1220
+	// MulticodecKindSLHDSASHA2128fPubKey slhdsa-sha2-128f-pub
+	MulticodecKindSLHDSASHA2128fPubKey = 0x1221
+	// MulticodecKindSLHDSASHA2192sPubKey slhdsa-sha2-192s-pub
+	MulticodecKindSLHDSASHA2192sPubKey = 0x1222
+	// MulticodecKindSLHDSASHA2192fPubKey slhdsa-sha2-192f-pub
+	MulticodecKindSLHDSASHA2192fPubKey = 0x1223
+	// MulticodecKindSLHDSASHA2256sPubKey slhdsa-sha2-256s-pub
+	MulticodecKindSLHDSASHA2256sPubKey = 0x1224
+	// MulticodecKindSLHDSASHA2256fPubKey slhdsa-sha2-256f-pub
+	MulticodecKindSLHDSASHA2256fPubKey = 0x1225
+	// MulticodecKindSLHDSASHAKE128sPubKey slhdsa-shake-128s-pub
+	MulticodecKindSLHDSASHAKE128sPubKey = 0x1226
+	// MulticodecKindSLHDSASHAKE128fPubKey slhdsa-shake-128f-pub
+	MulticodecKindSLHDSASHAKE128fPubKey = 0x1227
+	// MulticodecKindSLHDSASHAKE192sPubKey slhdsa-shake-192s-pub
+	MulticodecKindSLHDSASHAKE192sPubKey = 0x1228
+	// MulticodecKindSLHDSASHAKE192fPubKey slhdsa-shake-192f-pub
+	MulticodecKindSLHDSASHAKE192fPubKey = 0x1229
+	// MulticodecKindSLHDSASHAKE256sPubKey slhdsa-shake-256s-pub
+	MulticodecKindSLHDSASHAKE256sPubKey = 0x122a
+	// MulticodecKindSLHDSASHAKE256fPubKey slhdsa-shake-256f-pub
+	MulticodecKindSLHDSASHAKE256fPubKey = 0x122b
+
+	// Composite keys have no multicodec yet, these codes are taken from the
+	// private use range 0x300000 - 0x3fffff and may change once one is assigned.
+
+	// MulticodecKindMLDSA44Ed25519PubKey is a composite ML-DSA-44 and Ed25519 key
+	MulticodecKindMLDSA44Ed25519PubKey = 0x301210
+	// MulticodecKindMLDSA65Ed25519PubKey is a composite ML-DSA-65 and Ed25519 key
+	MulticodecKindMLDSA65Ed25519PubKey = 0x301211
+)
+
+var mldsaMulticodecs = map[uint64]*mldsa.ParameterSet{
+	MulticodecKindMLDSA44PubKey: mldsa.MLDSA44,
+	MulticodecKindMLDSA65PubKey: mldsa.MLDSA65,
+	MulticodecKindMLDSA87PubKey: mldsa.MLDSA87,
+}
+
+var slhdsaMulticodecs = map[uint64]*slhdsa.ParameterSet{
+	MulticodecKindSLHDSASHA2128sPubKey:  slhdsa.SLHDSASHA2128s,
+	MulticodecKindSLHDSASHA2128fPubKey:  slhdsa.SLHDSASHA2128f,
+	MulticodecKindSLHDSASHA2192sPubKey:  slhdsa.SLHDSASHA2192s,
+	MulticodecKindSLHDSASHA2192fPubKey:  slhdsa.SLHDSASHA2192f,
+	MulticodecKindSLHDSASHA2256sPubKey:  slhdsa.SLHDSASHA2256s,
+	MulticodecKindSLHDSASHA2256fPubKey:  slhdsa.SLHDSASHA2256f,
+	MulticodecKindSLHDSASHAKE128sPubKey: slhdsa.SLHDSASHAKE128s,
+	MulticodecKindSLHDSASHAKE128fPubKey: slhdsa.SLHDSASHAKE128f,
+	MulticodecKindSLHDSASHAKE192sPubKey: slhdsa.SLHDSASHAKE192s,
+	MulticodecKindSLHDSASHAKE192fPubKey: slhdsa.SLHDSASHAKE192f,
+	MulticodecKindSLHDSASHAKE256sPubKey: slhdsa.SLHDSASHAKE256s,
+	MulticodecKindSLHDSASHAKE256fPubKey: slhdsa.SLHDSASHAKE256f,
+}
+
+var compositeMulticodecs = map[uint64]*mldsa.Composite{
+	MulticodecKindMLDSA44Ed25519PubKey: mldsa.MLDSA44Ed25519,
+	MulticodecKindMLDSA65Ed25519PubKey: mldsa.MLDSA65Ed25519,
+}
+
+// multicodecKey is implemented by keys whose libp2p key type is a multicodec
+type multicodecKey interface {
+	crypto.PubKey
+	multicodec() uint64
+}
+
+// MLDSAPublicKey is an ML-DSA public key that satisfies the libp2p
+// crypto.PubKey interface. Signatures are verified with an empty context.
+type MLDSAPublicKey struct {
+	key   *mldsa.PublicKey
+	codec uint64
+}
+
+// NewMLDSAPublicKey wraps key for use in a did:key
+func NewMLDSAPublicKey(key *mldsa.PublicKey) *MLDSAPublicKey {
+	for codec, p := range mldsaMulticodecs {
+		if p == key.ParameterSet() {
+			return &MLDSAPublicKey{key: key, codec: codec}
+		}
+	}
+	panic("unexpected ML-DSA parameter set")
+}
+
+// UnmarshalMLDSAPublicKey decodes an encoded ML-DSA public key of the
+// parameter set identified by multicodec
+func UnmarshalMLDSAPublicKey(multicodec uint64, data []byte) (crypto.PubKey, error) {
+	p, ok := mldsaMulticodecs[multicodec]
+	if !ok {
+		return nil, fmt.Errorf("not an ML-DSA multicodec: %x", multicodec)
+	}
+	key, err := p.NewPublicKey(data)
+	if err != nil {
+		return nil, err
+	}
+	return &MLDSAPublicKey{key: key, codec: multicodec}, nil
+}
+
+// Key returns the backing ML-DSA key
+func (k *MLDSAPublicKey) Key() *mldsa.PublicKey {
+	return k.key
+}
+
+func (k *MLDSAPublicKey) multicodec() uint64 {
+	return k.codec
+}
+
+// Type returns the multicodec of the parameter set as a key type
+func (k *MLDSAPublicKey) Type() pb.KeyType {
+	return pb.KeyType(k.codec)
+}
+
+// Raw returns the encoded public key
+func (k *MLDSAPublicKey) Raw() ([]byte, error) {
+	return k.key.Bytes(), nil
+}
+
+// Equals checks whether two keys are the same
+func (k *MLDSAPublicKey) Equals(o crypto.Key) bool {
+	return equalRaw(k, o)
+}
+
+// Verify checks an ML-DSA signature over data with an empty context
+func (k *MLDSAPublicKey) Verify(data, sigBytes []byte) (bool, error) {
+	return k.key.Verify(data, sigBytes, nil) == nil, nil
+}
+
+// SLHDSAPublicKey is an SLH-DSA public key that satisfies the libp2p
+// crypto.PubKey interface. Signatures are verified with an empty context.
+type SLHDSAPublicKey struct {
+	key   *slhdsa.PublicKey
+	codec uint64
+}
+
+// NewSLHDSAPublicKey wraps key for use in a did:key
+func NewSLHDSAPublicKey(key *slhdsa.PublicKey) *SLHDSAPublicKey {
+	for codec, p := range slhdsaMulticodecs {
+		if p == key.ParameterSet() {
+			return &SLHDSAPublicKey{key: key, codec: codec}
+		}
+	}
+	panic("unexpected SLH-DSA parameter set")
+}
+
+// UnmarshalSLHDSAPublicKey decodes an encoded SLH-DSA public key of the
+// parameter set identified by multicodec
+func UnmarshalSLHDSAPublicKey(multicodec uint64, data []byte) (crypto.PubKey, error) {
+	p, ok := slhdsaMulticodecs[multicodec]
+	if !ok {
+		return nil, fmt.Errorf("not an SLH-DSA multicodec: %x", multicodec)
+	}
+	key, err := p.NewPublicKey(data)
+	if err != nil {
+		return nil, err
+	}
+	return &SLHDSAPublicKey{key: key, codec: multicodec}, nil
+}
+
+// Key returns the backing SLH-DSA key
+func (k *SLHDSAPublicKey) Key() *slhdsa.PublicKey {
+	return k.key
+}
+
+func (k *SLHDSAPublicKey) multicodec() uint64 {
+	return k.codec
+}
+
+// Type returns the multicodec of the parameter set as a key type
+func (k *SLHDSAPublicKey) Type() pb.KeyType {
+	return pb.KeyType(k.codec)
+}
+
+// Raw returns the encoded public key PK.seed || PK.root
+func (k *SLHDSAPublicKey) Raw() ([]byte, error) {
+	return k.key.Bytes(), nil
+}
+
+// Equals checks whether two keys are the same
+func (k *SLHDSAPublicKey) Equals(o crypto.Key) bool {
+	return equalRaw(k, o)
+}
+
+// Verify checks an SLH-DSA signature over data with an empty context
+func (k *SLHDSAPublicKey) Verify(data, sigBytes []byte) (bool, error) {
+	return k.key.Verify(data, sigBytes, nil) == nil, nil
+}
+
+// CompositePublicKey is a composite ML-DSA and Ed25519 public key that
+// satisfies the libp2p crypto.PubKey interface. Signatures are verified with
+// an empty context.
+type CompositePublicKey struct {
+	key   *mldsa.CompositePublicKey
+	codec uint64
+}
+
+// NewCompositePublicKey wraps key for use in a did:key
+func NewCompositePublicKey(key *mldsa.CompositePublicKey) *CompositePublicKey {
+	for codec, c := range compositeMulticodecs {
+		if c == key.Composite() {
+			return &CompositePublicKey{key: key, codec: codec}
+		}
+	}
+	panic("unexpected composite scheme")
+}
+
+// UnmarshalCompositePublicKey decodes an encoded composite public key of the
+// scheme identified by multicodec
+func UnmarshalCompositePublicKey(multicodec uint64, data []byte) (crypto.PubKey, error) {
+	c, ok := compositeMulticodecs[multicodec]
+	if !ok {
+		return nil, fmt.Errorf("not a composite multicodec: %x", multicodec)
+	}
+	key, err := c.NewPublicKey(data)
+	if err != nil {
+		return nil, err
+	}
+	return &CompositePublicKey{key: key, codec: multicodec}, nil
+}
+
+// Key returns the backing composite key
+func (k *CompositePublicKey) Key() *mldsa.CompositePublicKey {
+	return k.key
+}
+
+func (k *CompositePublicKey) multicodec() uint64 {
+	return k.codec
+}
+
+// Type returns the multicodec of the scheme as a key type
+func (k *CompositePublicKey) Type() pb.KeyType {
+	return pb.KeyType(k.codec)
+}
+
+// Raw returns the encoded public key pk_M || pk_E
+func (k *CompositePublicKey) Raw() ([]byte, error) {
+	return k.key.Bytes(), nil
+}
+
+// Equals checks whether two keys are the same
+func (k *CompositePublicKey) Equals(o crypto.Key) bool {
+	return equalRaw(k, o)
+}
+
+// Verify checks a composite signature over data with an empty context
+func (k *CompositePublicKey) Verify(data, sigBytes []byte) (bool, error) {
+	return k.key.Verify(data, sigBytes, nil) == nil, nil
+}
+
+// equalRaw compares the key types and raw encodings of two keys
+func equalRaw(k, o crypto.Key) bool {
+	if o == nil || o.Type() != k.Type() {
+		return false
+	}
+	raw, err := o.Raw()
+	if err != nil {
+		return false
+	}
+	kRaw, _ := k.Raw()
+	return bytes.Equal(raw, kRaw)
+}
@@ -0,0 +1,115 @@
+package parsers
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/pqc/mldsa"
+	"github.com/go-sonr/crypto/pqc/slhdsa"
+)
+
+// ErrInvalidSignature is returned when a signature does not verify against a did:key
+var ErrInvalidSignature = fmt.Errorf("invalid signature")
+
+// Verify checks signature over message using the verification primitive for the key type:
+//   - RSA: RSASSA-PSS or PKCS#1 v1.5 over SHA-256
+//   - Ed25519: pure Ed25519
+//   - Secp256k1: ECDSA over SHA-256, DER or 64 byte R || S encoded
+//   - P-256 / P-384: ECDSA over SHA-256 / SHA-384, DER or R || S encoded
+//   - BLS12-381: proof of possession ciphersuite, see BLS12381PublicKey
+//   - ML-DSA / SLH-DSA: pure signatures with an empty context
+//   - ML-DSA + Ed25519: composite signatures with an empty context
+func (id DIDKey) Verify(message, signature []byte) error {
+	vk, err := id.VerifyKey()
+	if err != nil {
+		return err
+	}
+	var valid bool
+	switch key := vk.(type) {
+	case *rsa.PublicKey:
+		valid = verifyRSA(key, message, signature)
+	case ed25519.PublicKey:
+		valid = ed25519.Verify(key, message, signature)
+	case *ecdsa.PublicKey:
+		valid = verifyECDSA(key, message, signature)
+	case []byte:
+		valid, err = verifySecp256k1(key, message, signature)
+	case *curves.PointBls12381G1, *curves.PointBls12381G2:
+		valid, err = id.PubKey.Verify(message, signature)
+	case *mldsa.PublicKey:
+		valid = key.Verify(message, signature, nil) == nil
+	case *slhdsa.PublicKey:
+		valid = key.Verify(message, signature, nil) == nil
+	case *mldsa.CompositePublicKey:
+		valid = key.Verify(message, signature, nil) == nil
+	default:
+		return fmt.Errorf("unsupported verification key type: %T", vk)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	if !valid {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func verifyRSA(pub *rsa.PublicKey, message, signature []byte) bool {
+	digest := sha256.Sum256(message)
+	if rsa.VerifyPSS(pub, crypto.SHA256, digest[:], signature, nil) == nil {
+		return true
+	}
+	return rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], signature) == nil
+}
+
+func verifyECDSA(pub *ecdsa.PublicKey, message, signature []byte) bool {
+	var digest []byte
+	switch pub.Curve {
+	case elliptic.P384():
+		h := sha512.Sum384(message)
+		digest = h[:]
+	default:
+		h := sha256.Sum256(message)
+		digest = h[:]
+	}
+	size := (pub.Curve.Params().BitSize + 7) / 8
+	if len(signature) == 2*size {
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		return ecdsa.Verify(pub, digest, r, s)
+	}
+	return ecdsa.VerifyASN1(pub, digest, signature)
+}
+
+func verifySecp256k1(rawPub, message, signature []byte) (bool, error) {
+	pub, err := btcec.ParsePubKey(rawPub)
+	if err != nil {
+		return false, err
+	}
+	var sig *btcecdsa.Signature
+	if len(signature) == 64 {
+		var r, s btcec.ModNScalar
+		if r.SetByteSlice(signature[:32]) || s.SetByteSlice(signature[32:]) {
+			return false, fmt.Errorf("signature component overflows the group order")
+		}
+		sig = btcecdsa.NewSignature(&r, &s)
+	} else {
+		sig, err = btcecdsa.ParseDERSignature(signature)
+		if err != nil {
+			return false, err
+		}
+	}
+	digest := sha256.Sum256(message)
+	return sig.Verify(digest[:], pub), nil
+}
@@ -0,0 +1,150 @@
+package parsers
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	p2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/pqc/mldsa"
+	"github.com/go-sonr/crypto/pqc/slhdsa"
+	"github.com/go-sonr/crypto/signatures/bls/bls_sig"
+)
+
+var verifyMsg = []byte("did:key verify")
+
+func TestVerifyLibp2pSignatures(t *testing.T) {
+	for _, keyType := range []int{p2pcrypto.Ed25519, p2pcrypto.Secp256k1, p2pcrypto.RSA, p2pcrypto.ECDSA} {
+		priv, pub, err := p2pcrypto.GenerateKeyPairWithReader(keyType, 2048, rand.Reader)
+		require.NoError(t, err)
+		id, err := NewKeyDID(pub)
+		require.NoError(t, err)
+
+		sig, err := priv.Sign(verifyMsg)
+		require.NoError(t, err)
+		require.NoError(t, id.Verify(verifyMsg, sig))
+		require.ErrorIs(t, id.Verify([]byte("tampered"), sig), ErrInvalidSignature)
+	}
+}
+
+func TestVerifyRSAPSS(t *testing.T) {
+	sk, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, pub, err := p2pcrypto.KeyPairFromStdKey(sk)
+	require.NoError(t, err)
+	id, err := NewKeyDID(pub)
+	require.NoError(t, err)
+
+	digest := sha256.Sum256(verifyMsg)
+	sig, err := rsa.SignPSS(rand.Reader, sk, crypto.SHA256, digest[:], nil)
+	require.NoError(t, err)
+	require.NoError(t, id.Verify(verifyMsg, sig))
+}
+
+func TestVerifySecp256k1Compact(t *testing.T) {
+	sk, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	pub, err := p2pcrypto.UnmarshalSecp256k1PublicKey(sk.PubKey().SerializeCompressed())
+	require.NoError(t, err)
+	id, err := NewKeyDID(pub)
+	require.NoError(t, err)
+
+	digest := sha256.Sum256(verifyMsg)
+	compact := btcecdsa.SignCompact(sk, digest[:], true)
+	// drop the recovery byte to get R || S
+	require.NoError(t, id.Verify(verifyMsg, compact[1:]))
+	compact[10] ^= 1
+	require.Error(t, id.Verify(verifyMsg, compact[1:]))
+}
+
+func TestVerifyNistCurveRawSignature(t *testing.T) {
+	sk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	pub, err := p2pcrypto.ECDSAPublicKeyFromPubKey(sk.PublicKey)
+	require.NoError(t, err)
+	id, err := NewKeyDID(pub)
+	require.NoError(t, err)
+
+	digest := sha256.Sum256(verifyMsg)
+	r, s, err := ecdsa.Sign(rand.Reader, sk, digest[:])
+	require.NoError(t, err)
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+	require.NoError(t, id.Verify(verifyMsg, sig))
+}
+
+func TestVerifyEd25519Tampered(t *testing.T) {
+	pk, sk, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	pub, err := p2pcrypto.UnmarshalEd25519PublicKey(pk)
+	require.NoError(t, err)
+	id, err := NewKeyDID(pub)
+	require.NoError(t, err)
+
+	sig := ed25519.Sign(sk, verifyMsg)
+	require.NoError(t, id.Verify(verifyMsg, sig))
+	sig[0] ^= 1
+	require.ErrorIs(t, id.Verify(verifyMsg, sig), ErrInvalidSignature)
+}
+
+func TestVerifyBLS12381(t *testing.T) {
+	scheme := bls_sig.NewSigPop()
+	pk, sk, err := scheme.Keygen()
+	require.NoError(t, err)
+	raw, err := pk.MarshalBinary()
+	require.NoError(t, err)
+	pub, err := UnmarshalBLS12381G1PublicKey(raw)
+	require.NoError(t, err)
+	id, err := NewKeyDID(pub)
+	require.NoError(t, err)
+
+	sig, err := scheme.Sign(sk, verifyMsg)
+	require.NoError(t, err)
+	sigBytes, err := sig.MarshalBinary()
+	require.NoError(t, err)
+	require.NoError(t, id.Verify(verifyMsg, sigBytes))
+	require.ErrorIs(t, id.Verify([]byte("tampered"), sigBytes), ErrInvalidSignature)
+}
+
+func TestVerifyPostQuantum(t *testing.T) {
+	mlSk, err := mldsa.MLDSA44.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	id, err := NewKeyDID(NewMLDSAPublicKey(mlSk.PublicKey()))
+	require.NoError(t, err)
+	sig, err := mlSk.Sign(rand.Reader, verifyMsg, nil)
+	require.NoError(t, err)
+	require.NoError(t, id.Verify(verifyMsg, sig))
+	require.ErrorIs(t, id.Verify([]byte("tampered"), sig), ErrInvalidSignature)
+	// a context separates signatures from did:key verification
+	sig, err = mlSk.Sign(rand.Reader, verifyMsg, []byte("ctx"))
+	require.NoError(t, err)
+	require.ErrorIs(t, id.Verify(verifyMsg, sig), ErrInvalidSignature)
+
+	slhSk, err := slhdsa.SLHDSASHAKE128f.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	id, err = NewKeyDID(NewSLHDSAPublicKey(slhSk.PublicKey()))
+	require.NoError(t, err)
+	sig, err = slhSk.Sign(rand.Reader, verifyMsg, nil)
+	require.NoError(t, err)
+	require.NoError(t, id.Verify(verifyMsg, sig))
+	require.ErrorIs(t, id.Verify([]byte("tampered"), sig), ErrInvalidSignature)
+
+	compSk, err := mldsa.MLDSA65Ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	id, err = NewKeyDID(NewCompositePublicKey(compSk.PublicKey()))
+	require.NoError(t, err)
+	sig, err = compSk.Sign(rand.Reader, verifyMsg, nil)
+	require.NoError(t, err)
+	require.NoError(t, id.Verify(verifyMsg, sig))
+	require.ErrorIs(t, id.Verify([]byte("tampered"), sig), ErrInvalidSignature)
+}
@@ -0,0 +1,104 @@
+package parsers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// maxWebDocumentSize bounds the size of a fetched did:web document
+const maxWebDocumentSize = 1 << 20
+
+// WebMethod resolves did:web identifiers by fetching the DID Document over HTTPS
+// as described in https://w3c-ccg.github.io/did-method-web/#read-resolve
+type WebMethod struct {
+	// Client performs the document request, http.DefaultClient when nil.
+	// Set its Transport to control how documents are fetched.
+	Client *http.Client
+}
+
+// Method returns "web"
+func (*WebMethod) Method() string {
+	return MethodWeb
+}
+
+// Resolve fetches and decodes the DID Document of did
+func (m *WebMethod) Resolve(ctx context.Context, did string) (*Document, error) {
+	docURL, err := WebDocumentURL(did)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, docURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/did+json, application/json")
+
+	client := m.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s: %w", docURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %s: unexpected status %s", docURL, resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxWebDocumentSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", docURL, err)
+	}
+	if len(body) > maxWebDocumentSize {
+		return nil, fmt.Errorf("did:web document exceeds %d bytes", maxWebDocumentSize)
+	}
+
+	doc := new(Document)
+	if err := json.Unmarshal(body, doc); err != nil {
+		return nil, fmt.Errorf("decoding did:web document: %w", err)
+	}
+	if doc.ID != did {
+		return nil, fmt.Errorf("did:web document id %q does not match %q", doc.ID, did)
+	}
+	return doc, nil
+}
+
+// WebDocumentURL returns the HTTPS location of the DID Document for a did:web
+func WebDocumentURL(did string) (string, error) {
+	method, id, err := SplitDID(did)
+	if err != nil {
+		return "", err
+	}
+	if method != MethodWeb {
+		return "", fmt.Errorf("decentralized identifier is not a 'web' type")
+	}
+
+	segments := strings.Split(id, ":")
+	for i, seg := range segments {
+		decoded, err := url.PathUnescape(seg)
+		if err != nil {
+			return "", fmt.Errorf("invalid did:web segment %q: %w", seg, err)
+		}
+		if decoded == "" || (i > 0 && strings.ContainsAny(decoded, "/?#")) {
+			return "", fmt.Errorf("invalid did:web segment %q", seg)
+		}
+		segments[i] = decoded
+	}
+
+	host := segments[0]
+	if strings.ContainsAny(host, "/?#@") {
+		return "", fmt.Errorf("invalid did:web host %q", host)
+	}
+	path := "/.well-known"
+	if len(segments) > 1 {
+		path = "/" + strings.Join(segments[1:], "/")
+	}
+	u := url.URL{Scheme: "https", Host: host, Path: path + "/did.json"}
+	return u.String(), nil
+}
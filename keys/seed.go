@@ -0,0 +1,70 @@
+package keys
+
+import (
+	"crypto/sha512"
+	"fmt"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/secret"
+	"github.com/go-sonr/crypto/derive"
+)
+
+const (
+	// SeedSize is the size of Ed25519 seeds, RFC 8032 section 5.1.5
+	SeedSize = 32
+	// MinSeedSize is the minimum size of seeds of other curves
+	MinSeedSize = 16
+
+	seedDST = "sonr-keys-seed-v1-"
+)
+
+// PrivKey is a private scalar of a curve
+type PrivKey struct {
+	Curve  *curves.Curve
+	Scalar curves.Scalar
+}
+
+// NewKeyFromSeed derives the private key of curve from seed. Ed25519 keys
+// are the clamped SHA-512 hash of a 32 byte seed as in RFC 8032, and match
+// crypto/ed25519.NewKeyFromSeed. Keys of other curves are hash_to_field of
+// the seed with a domain separation tag naming the curve, so one seed gives
+// unrelated keys on different curves.
+func NewKeyFromSeed(curve *curves.Curve, seed []byte) (*PrivKey, error) {
+	if curve == nil {
+		return nil, fmt.Errorf("curve is required")
+	}
+	var s curves.Scalar
+	if curve.Name == curves.ED25519Name {
+		if len(seed) != SeedSize {
+			return nil, fmt.Errorf("ed25519 seed must be %d bytes", SeedSize)
+		}
+		h := sha512.Sum512(seed)
+		defer secret.Wipe(h[:])
+		var err error
+		if s, err = new(curves.ScalarEd25519).SetBytesClamping(h[:32]); err != nil {
+			return nil, err
+		}
+	} else {
+		if len(seed) < MinSeedSize {
+			return nil, fmt.Errorf("seed must be at least %d bytes", MinSeedSize)
+		}
+		var err error
+		if s, err = derive.HashToScalar(curve, seed, []byte(seedDST+curve.Name)); err != nil {
+			return nil, err
+		}
+	}
+	if s.IsZero() {
+		return nil, fmt.Errorf("seed derives the zero scalar")
+	}
+	return &PrivKey{Curve: curve, Scalar: s}, nil
+}
+
+// PublicPoint returns the public key of k, the scalar times the generator
+func (k *PrivKey) PublicPoint() curves.Point {
+	return k.Curve.ScalarBaseMult(k.Scalar)
+}
+
+// PubKey returns the public key of k
+func (k *PrivKey) PubKey() PubKey {
+	return NewPubKey(k.PublicPoint())
+}
@@ -0,0 +1,47 @@
+package keys
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+)
+
+func TestNewKeyFromSeed(t *testing.T) {
+	seed, err := hex.DecodeString("9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60")
+	require.NoError(t, err)
+
+	// RFC 8032 section 7.1 test 1
+	k, err := NewKeyFromSeed(curves.ED25519(), seed)
+	require.NoError(t, err)
+	require.Equal(t, "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a", hex.EncodeToString(k.PublicPoint().ToAffineCompressed()))
+	require.Equal(t, []byte(ed25519.NewKeyFromSeed(seed).Public().(ed25519.PublicKey)), k.PublicPoint().ToAffineCompressed())
+	_, err = NewKeyFromSeed(curves.ED25519(), seed[:16])
+	require.Error(t, err)
+
+	seen := map[string]bool{}
+	for _, curve := range []*curves.Curve{
+		curves.K256(), curves.P256(), curves.PALLAS(), curves.VESTA(),
+		curves.RISTRETTO255(), curves.BLS12381G1(), curves.BLS12381G2(),
+		curves.BLS12377G1(), curves.BLS12377G2(),
+	} {
+		a, err := NewKeyFromSeed(curve, seed)
+		require.NoError(t, err, curve.Name)
+		b, err := NewKeyFromSeed(curve, seed)
+		require.NoError(t, err, curve.Name)
+		require.Equal(t, a.Scalar.Bytes(), b.Scalar.Bytes(), curve.Name)
+		require.False(t, seen[hex.EncodeToString(a.Scalar.Bytes())], curve.Name)
+		seen[hex.EncodeToString(a.Scalar.Bytes())] = true
+		require.True(t, a.PublicPoint().Equal(curve.ScalarBaseMult(b.Scalar)), curve.Name)
+	}
+
+	// keys are reproducible across platforms and releases
+	k, err = NewKeyFromSeed(curves.K256(), seed)
+	require.NoError(t, err)
+	require.Equal(t, "a2276c8a8e1ea91b88052206b55f8504fae3ab9b042228b17332512a577c0e4a", hex.EncodeToString(k.Scalar.Bytes()))
+	_, err = NewKeyFromSeed(curves.K256(), seed[:MinSeedSize-1])
+	require.Error(t, err)
+}
@@ -0,0 +1,188 @@
+package keyservice
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/rsa"
+	"crypto/x509"
+	"io"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/go-sonr/crypto/internal"
+)
+
+// DefaultTimeout bounds the calls RemoteKey makes without a context
+const DefaultTimeout = 10 * time.Second
+
+// tokenCredentials sends a bearer token with every call
+type tokenCredentials struct {
+	token    string
+	insecure bool
+}
+
+// TokenCredentials returns per-call credentials carrying token for a
+// TokenAuthenticator. Unless insecure is set, calls require a secure
+// transport.
+func TokenCredentials(token string, insecure bool) credentials.PerRPCCredentials {
+	return tokenCredentials{token: token, insecure: insecure}
+}
+
+func (c tokenCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + c.token}, nil
+}
+
+func (c tokenCredentials) RequireTransportSecurity() bool {
+	return !c.insecure
+}
+
+// Client calls a key service
+type Client struct {
+	conn grpc.ClientConnInterface
+	opts []grpc.CallOption
+}
+
+// NewClient returns a client of the key service on conn. The call options
+// are added to every call, such as grpc.PerRPCCredentials with
+// TokenCredentials.
+func NewClient(conn grpc.ClientConnInterface, opts ...grpc.CallOption) (*Client, error) {
+	if conn == nil {
+		return nil, internal.ErrNilArguments
+	}
+	return &Client{conn: conn, opts: append([]grpc.CallOption{grpc.CallContentSubtype(codecName)}, opts...)}, nil
+}
+
+func (c *Client) invoke(ctx context.Context, method string, in, out any) error {
+	return c.conn.Invoke(ctx, fullMethod(method), in, out, c.opts...)
+}
+
+// PublicKey returns the public key of the key id
+func (c *Client) PublicKey(ctx context.Context, id string) (crypto.PublicKey, error) {
+	out := new(PublicKeyResponse)
+	if err := c.invoke(ctx, methodPublicKey, &PublicKeyRequest{KeyID: id}, out); err != nil {
+		return nil, err
+	}
+	return x509.ParsePKIXPublicKey(out.PublicKey)
+}
+
+// Sign signs digest with the key id, as crypto.Signer does
+func (c *Client) Sign(ctx context.Context, id string, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	out := new(SignResponse)
+	if err := c.invoke(ctx, methodSign, newSignRequest(id, digest, opts), out); err != nil {
+		return nil, err
+	}
+	return out.Signature, nil
+}
+
+// Verify reports whether sig is a signature of digest by the key id
+func (c *Client) Verify(ctx context.Context, id string, digest, sig []byte, opts crypto.SignerOpts) (bool, error) {
+	out := new(VerifyResponse)
+	req := &VerifyRequest{SignRequest: *newSignRequest(id, digest, opts), Signature: sig}
+	if err := c.invoke(ctx, methodVerify, req, out); err != nil {
+		return false, err
+	}
+	return out.Valid, nil
+}
+
+// ECDH returns the shared secret of the key id and peer
+func (c *Client) ECDH(ctx context.Context, id string, peer *ecdh.PublicKey) ([]byte, error) {
+	if peer == nil {
+		return nil, internal.ErrNilArguments
+	}
+	out := new(ECDHResponse)
+	if err := c.invoke(ctx, methodECDH, &ECDHRequest{KeyID: id, PublicKey: peer.Bytes()}, out); err != nil {
+		return nil, err
+	}
+	return out.Secret, nil
+}
+
+// Derive returns length bytes derived with HKDF-SHA256 from the shared
+// secret of the key id and peer, the secret itself stays on the server
+func (c *Client) Derive(ctx context.Context, id string, peer *ecdh.PublicKey, salt, info []byte, length int) ([]byte, error) {
+	if peer == nil {
+		return nil, internal.ErrNilArguments
+	}
+	out := new(DeriveResponse)
+	req := &DeriveRequest{
+		ECDHRequest: ECDHRequest{KeyID: id, PublicKey: peer.Bytes()},
+		Salt:        salt,
+		Info:        info,
+		Length:      length,
+	}
+	if err := c.invoke(ctx, methodDerive, req, out); err != nil {
+		return nil, err
+	}
+	return out.Key, nil
+}
+
+// Key returns the key id as a RemoteKey
+func (c *Client) Key(ctx context.Context, id string) (*RemoteKey, error) {
+	pub, err := c.PublicKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &RemoteKey{client: c, id: id, pub: pub, Timeout: DefaultTimeout}, nil
+}
+
+func newSignRequest(id string, digest []byte, opts crypto.SignerOpts) *SignRequest {
+	req := &SignRequest{KeyID: id, Digest: digest}
+	if opts != nil {
+		req.Hash = uint(opts.HashFunc())
+	}
+	if pss, ok := opts.(*rsa.PSSOptions); ok {
+		req.PSS, req.PSSSaltLength = true, pss.SaltLength
+	}
+	return req
+}
+
+// RemoteKey is a key of a key service. It implements hardware.Key, so
+// hardware.PrivKey turns it into a libp2p private key for the jose, cose
+// and ucan packages.
+type RemoteKey struct {
+	client *Client
+	id     string
+	pub    crypto.PublicKey
+
+	// Timeout bounds the calls of Sign and ECDH, DefaultTimeout when zero
+	Timeout time.Duration
+}
+
+func (k *RemoteKey) context() (context.Context, context.CancelFunc) {
+	timeout := k.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return context.WithTimeout(context.Background(), timeout)
+}
+
+// ID returns the key id
+func (k *RemoteKey) ID() string {
+	return k.id
+}
+
+// Public returns the public key
+func (k *RemoteKey) Public() crypto.PublicKey {
+	return k.pub
+}
+
+// Sign signs digest on the key service
+func (k *RemoteKey) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	ctx, cancel := k.context()
+	defer cancel()
+	return k.client.Sign(ctx, k.id, digest, opts)
+}
+
+// ECDH computes the shared secret with peer on the key service
+func (k *RemoteKey) ECDH(peer *ecdh.PublicKey) ([]byte, error) {
+	ctx, cancel := k.context()
+	defer cancel()
+	return k.client.ECDH(ctx, k.id, peer)
+}
+
+// Close does nothing, the connection belongs to the caller of NewClient
+func (k *RemoteKey) Close() error {
+	return nil
+}
@@ -0,0 +1,210 @@
+// Package keyservice runs key operations in a remote signer daemon over
+// gRPC. A Server holds crypto.Signer keys by id, local or hardware backed,
+// and signs, verifies, computes ECDH shared secrets and derives keys from
+// them for authenticated callers. A Client reaches those keys as RemoteKeys,
+// which implement the interfaces of the hardware package, so validators and
+// wallets sign with a remote key the way they sign with a local one.
+//
+// Messages are encoded as JSON with the keyservice-json content subtype, the
+// service needs no generated code.
+package keyservice
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+// ServiceName is the fully qualified gRPC service name
+const ServiceName = "sonr.keyservice.v1.KeyService"
+
+const (
+	methodPublicKey = "PublicKey"
+	methodSign      = "Sign"
+	methodVerify    = "Verify"
+	methodECDH      = "ECDH"
+	methodDerive    = "Derive"
+)
+
+// codecName is the content subtype of the JSON codec
+const codecName = "keyservice-json"
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
+
+// jsonCodec encodes the messages of the service as JSON
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return codecName
+}
+
+// PublicKeyRequest asks for the public key of a key
+type PublicKeyRequest struct {
+	KeyID string `json:"keyId"`
+}
+
+// PublicKeyResponse carries a PKIX DER public key
+type PublicKeyResponse struct {
+	PublicKey []byte `json:"publicKey"`
+}
+
+// SignRequest asks for a signature of a digest. Hash is the crypto.Hash of
+// the digest, zero for keys such as Ed25519 that sign the message itself.
+// PSS selects RSA-PSS with PSSSaltLength.
+type SignRequest struct {
+	KeyID         string `json:"keyId"`
+	Digest        []byte `json:"digest"`
+	Hash          uint   `json:"hash"`
+	PSS           bool   `json:"pss,omitempty"`
+	PSSSaltLength int    `json:"pssSaltLength,omitempty"`
+}
+
+// SignResponse carries a signature as crypto.Signer returns it
+type SignResponse struct {
+	Signature []byte `json:"signature"`
+}
+
+// VerifyRequest asks whether Signature is a signature of Digest by a key
+type VerifyRequest struct {
+	SignRequest
+	Signature []byte `json:"signature"`
+}
+
+// VerifyResponse carries the result of a verification
+type VerifyResponse struct {
+	Valid bool `json:"valid"`
+}
+
+// ECDHRequest asks for the shared secret of a key and a peer public key,
+// encoded as crypto/ecdh encodes keys of the curve of the key
+type ECDHRequest struct {
+	KeyID     string `json:"keyId"`
+	PublicKey []byte `json:"publicKey"`
+}
+
+// ECDHResponse carries a shared secret
+type ECDHResponse struct {
+	Secret []byte `json:"secret"`
+}
+
+// DeriveRequest asks for Length bytes derived from the shared secret of a
+// key and a peer with HKDF-SHA256
+type DeriveRequest struct {
+	ECDHRequest
+	Salt   []byte `json:"salt,omitempty"`
+	Info   []byte `json:"info,omitempty"`
+	Length int    `json:"length"`
+}
+
+// DeriveResponse carries a derived key
+type DeriveResponse struct {
+	Key []byte `json:"key"`
+}
+
+// keyService is the interface the service descriptor dispatches to
+type keyService interface {
+	PublicKey(context.Context, *PublicKeyRequest) (*PublicKeyResponse, error)
+	Sign(context.Context, *SignRequest) (*SignResponse, error)
+	Verify(context.Context, *VerifyRequest) (*VerifyResponse, error)
+	ECDH(context.Context, *ECDHRequest) (*ECDHResponse, error)
+	Derive(context.Context, *DeriveRequest) (*DeriveResponse, error)
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*keyService)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: methodPublicKey, Handler: publicKeyHandler},
+		{MethodName: methodSign, Handler: signHandler},
+		{MethodName: methodVerify, Handler: verifyHandler},
+		{MethodName: methodECDH, Handler: ecdhHandler},
+		{MethodName: methodDerive, Handler: deriveHandler},
+	},
+	Metadata: "keyservice",
+}
+
+func fullMethod(method string) string {
+	return "/" + ServiceName + "/" + method
+}
+
+func publicKeyHandler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(PublicKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(keyService).PublicKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: fullMethod(methodPublicKey)}
+	return interceptor(ctx, in, info, func(ctx context.Context, req any) (any, error) {
+		return srv.(keyService).PublicKey(ctx, req.(*PublicKeyRequest))
+	})
+}
+
+func signHandler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(SignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(keyService).Sign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: fullMethod(methodSign)}
+	return interceptor(ctx, in, info, func(ctx context.Context, req any) (any, error) {
+		return srv.(keyService).Sign(ctx, req.(*SignRequest))
+	})
+}
+
+func verifyHandler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(VerifyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(keyService).Verify(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: fullMethod(methodVerify)}
+	return interceptor(ctx, in, info, func(ctx context.Context, req any) (any, error) {
+		return srv.(keyService).Verify(ctx, req.(*VerifyRequest))
+	})
+}
+
+func ecdhHandler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(ECDHRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(keyService).ECDH(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: fullMethod(methodECDH)}
+	return interceptor(ctx, in, info, func(ctx context.Context, req any) (any, error) {
+		return srv.(keyService).ECDH(ctx, req.(*ECDHRequest))
+	})
+}
+
+func deriveHandler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(DeriveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(keyService).Derive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: fullMethod(methodDerive)}
+	return interceptor(ctx, in, info, func(ctx context.Context, req any) (any, error) {
+		return srv.(keyService).Derive(ctx, req.(*DeriveRequest))
+	})
+}
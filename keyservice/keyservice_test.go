@@ -0,0 +1,154 @@
+package keyservice
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	crand "crypto/rand"
+	"crypto/sha256"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/go-sonr/crypto/hardware"
+	"github.com/go-sonr/crypto/jose"
+	"github.com/go-sonr/crypto/keyexchange"
+)
+
+var _ hardware.Key = (*RemoteKey)(nil)
+
+// newTestService serves a P-256 key as "validator" and an Ed25519 key as
+// "wallet" to the tokens "admin", granted every key, "wallet", granted
+// only the wallet key, and "empty", granted nothing
+func newTestService(t *testing.T) (*ecdsa.PrivateKey, func(token string) *Client) {
+	srv, err := NewServer(TokenAuthenticator(map[string][]string{
+		"admin":  {AllKeys},
+		"wallet": {"wallet"},
+		"empty":  nil,
+	}))
+	require.NoError(t, err)
+	validator, err := ecdsa.GenerateKey(elliptic.P256(), crand.Reader)
+	require.NoError(t, err)
+	require.NoError(t, srv.AddKey("validator", validator))
+	_, wallet, err := ed25519.GenerateKey(crand.Reader)
+	require.NoError(t, err)
+	require.NoError(t, srv.AddKey("wallet", wallet))
+	require.Error(t, srv.AddKey("wallet", wallet))
+
+	lis := bufconn.Listen(1 << 20)
+	gs := grpc.NewServer()
+	srv.Register(gs)
+	go func() { _ = gs.Serve(lis) }()
+	t.Cleanup(gs.Stop)
+
+	return validator, func(token string) *Client {
+		conn, err := grpc.NewClient("passthrough:///bufnet",
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+				return lis.DialContext(ctx)
+			}),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = conn.Close() })
+		c, err := NewClient(conn, grpc.PerRPCCredentials(TokenCredentials(token, true)))
+		require.NoError(t, err)
+		return c
+	}
+}
+
+func TestRemoteKey(t *testing.T) {
+	ctx := context.Background()
+	validator, dial := newTestService(t)
+	c := dial("admin")
+
+	k, err := c.Key(ctx, "validator")
+	require.NoError(t, err)
+	require.True(t, validator.PublicKey.Equal(k.Public()))
+
+	digest := sha256.Sum256([]byte("block"))
+	sig, err := k.Sign(nil, digest[:], crypto.SHA256)
+	require.NoError(t, err)
+	require.True(t, ecdsa.VerifyASN1(&validator.PublicKey, digest[:], sig))
+	ok, err := c.Verify(ctx, "validator", digest[:], sig, crypto.SHA256)
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = c.Verify(ctx, "validator", digest[:], sig[:len(sig)-1], crypto.SHA256)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// a digest of the wrong size is rejected
+	_, err = k.Sign(nil, digest[:16], crypto.SHA256)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	peer, err := ecdh.P256().GenerateKey(crand.Reader)
+	require.NoError(t, err)
+	own, err := validator.PublicKey.ECDH()
+	require.NoError(t, err)
+	expected, err := peer.ECDH(own)
+	require.NoError(t, err)
+	secret, err := k.ECDH(peer.PublicKey())
+	require.NoError(t, err)
+	require.Equal(t, expected, secret)
+
+	derived, err := c.Derive(ctx, "validator", peer.PublicKey(), []byte("salt"), []byte("info"), 48)
+	require.NoError(t, err)
+	want, err := keyexchange.HKDF(sha256.New, expected, []byte("salt"), []byte("info"), 48)
+	require.NoError(t, err)
+	require.Equal(t, want, derived)
+
+	// the remote key signs JWS through the hardware interfaces
+	priv, err := hardware.PrivKey(k)
+	require.NoError(t, err)
+	signer, err := jose.NewSigner(priv)
+	require.NoError(t, err)
+	token, err := jose.Sign([]byte("payload"), signer, nil)
+	require.NoError(t, err)
+	id, err := hardware.DIDKey(k)
+	require.NoError(t, err)
+	verifier, err := jose.NewVerifier(id)
+	require.NoError(t, err)
+	_, _, err = jose.Verify(token, verifier)
+	require.NoError(t, err)
+}
+
+func TestEd25519Key(t *testing.T) {
+	ctx := context.Background()
+	_, dial := newTestService(t)
+	c := dial("wallet")
+
+	k, err := c.Key(ctx, "wallet")
+	require.NoError(t, err)
+	pub, ok := k.Public().(ed25519.PublicKey)
+	require.True(t, ok)
+	sig, err := k.Sign(nil, []byte("message"), crypto.Hash(0))
+	require.NoError(t, err)
+	require.True(t, ed25519.Verify(pub, []byte("message"), sig))
+
+	peer, err := ecdh.P256().GenerateKey(crand.Reader)
+	require.NoError(t, err)
+	_, err = c.ECDH(ctx, "wallet", peer.PublicKey())
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestAuthentication(t *testing.T) {
+	ctx := context.Background()
+	_, dial := newTestService(t)
+
+	_, err := dial("wrong").Key(ctx, "validator")
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = dial("wallet").Key(ctx, "validator")
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = dial("admin").Key(ctx, "missing")
+	require.Equal(t, codes.NotFound, status.Code(err))
+	_, err = dial("empty").Key(ctx, "wallet")
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
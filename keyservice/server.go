@@ -0,0 +1,271 @@
+package keyservice
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"fmt"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/go-sonr/crypto/hardware"
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/keyexchange"
+)
+
+// maxDeriveLength bounds the output of Derive, the limit of HKDF-SHA256
+const maxDeriveLength = 255 * sha256.Size
+
+// Authenticator authorizes a call of method on the key keyID, returning an
+// error to reject it
+type Authenticator func(ctx context.Context, method, keyID string) error
+
+// AllKeys is the grant of every key
+const AllKeys = "*"
+
+// TokenAuthenticator authorizes calls that carry a bearer token of grants in
+// their authorization metadata, for the key ids granted to the token. Only
+// tokens granted AllKeys may use every key, and a token granted nothing may
+// use none.
+func TokenAuthenticator(grants map[string][]string) Authenticator {
+	return func(ctx context.Context, _, keyID string) error {
+		md, _ := metadata.FromIncomingContext(ctx)
+		var token string
+		for _, v := range md.Get("authorization") {
+			if t, ok := strings.CutPrefix(v, "Bearer "); ok {
+				token = t
+			}
+		}
+		if token == "" {
+			return status.Error(codes.Unauthenticated, "keyservice: bearer token required")
+		}
+		var (
+			ids   []string
+			found bool
+		)
+		for t, keys := range grants {
+			if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+				ids, found = keys, true
+			}
+		}
+		if !found {
+			return status.Error(codes.Unauthenticated, "keyservice: invalid bearer token")
+		}
+		for _, id := range ids {
+			if id == keyID || id == AllKeys {
+				return nil
+			}
+		}
+		return status.Errorf(codes.PermissionDenied, "keyservice: key %q is not granted", keyID)
+	}
+}
+
+// Server serves the keys it holds to callers its Authenticator accepts
+type Server struct {
+	auth Authenticator
+
+	mu   sync.RWMutex
+	keys map[string]crypto.Signer
+}
+
+// NewServer returns a server without keys that authorizes every call with
+// auth
+func NewServer(auth Authenticator) (*Server, error) {
+	if auth == nil {
+		return nil, internal.ErrNilArguments
+	}
+	return &Server{auth: auth, keys: make(map[string]crypto.Signer)}, nil
+}
+
+// Register registers the service on a gRPC server
+func (s *Server) Register(r grpc.ServiceRegistrar) {
+	r.RegisterService(&serviceDesc, s)
+}
+
+// AddKey serves key as id. Keys that implement hardware.Decrypter, and
+// *ecdsa.PrivateKey, also serve ECDH and Derive.
+func (s *Server) AddKey(id string, key crypto.Signer) error {
+	if id == "" || key == nil {
+		return internal.ErrNilArguments
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.keys[id]; ok {
+		return fmt.Errorf("keyservice: key %q already exists", id)
+	}
+	s.keys[id] = key
+	return nil
+}
+
+// RemoveKey stops serving the key id
+func (s *Server) RemoveKey(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.keys, id)
+}
+
+// key authorizes method on id and returns the key
+func (s *Server) key(ctx context.Context, method, id string) (crypto.Signer, error) {
+	if err := s.auth(ctx, method, id); err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	s.mu.RLock()
+	key, ok := s.keys[id]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "keyservice: key %q not found", id)
+	}
+	return key, nil
+}
+
+// PublicKey returns the PKIX encoding of the public key of a key
+func (s *Server) PublicKey(ctx context.Context, req *PublicKeyRequest) (*PublicKeyResponse, error) {
+	key, err := s.key(ctx, methodPublicKey, req.KeyID)
+	if err != nil {
+		return nil, err
+	}
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &PublicKeyResponse{PublicKey: der}, nil
+}
+
+// Sign signs a digest with a key
+func (s *Server) Sign(ctx context.Context, req *SignRequest) (*SignResponse, error) {
+	key, err := s.key(ctx, methodSign, req.KeyID)
+	if err != nil {
+		return nil, err
+	}
+	opts, err := req.signerOpts()
+	if err != nil {
+		return nil, err
+	}
+	sig, err := key.Sign(rand.Reader, req.Digest, opts)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &SignResponse{Signature: sig}, nil
+}
+
+// Verify checks a signature of a digest against the public key of a key
+func (s *Server) Verify(ctx context.Context, req *VerifyRequest) (*VerifyResponse, error) {
+	key, err := s.key(ctx, methodVerify, req.KeyID)
+	if err != nil {
+		return nil, err
+	}
+	opts, err := req.signerOpts()
+	if err != nil {
+		return nil, err
+	}
+	return &VerifyResponse{Valid: verify(key.Public(), req.Digest, req.Signature, opts)}, nil
+}
+
+// ECDH computes the shared secret of a key and a peer
+func (s *Server) ECDH(ctx context.Context, req *ECDHRequest) (*ECDHResponse, error) {
+	secret, err := s.ecdh(ctx, methodECDH, req)
+	if err != nil {
+		return nil, err
+	}
+	return &ECDHResponse{Secret: secret}, nil
+}
+
+// Derive derives a key from the shared secret of a key and a peer
+func (s *Server) Derive(ctx context.Context, req *DeriveRequest) (*DeriveResponse, error) {
+	if req.Length <= 0 || req.Length > maxDeriveLength {
+		return nil, status.Errorf(codes.InvalidArgument, "keyservice: derived length must be between 1 and %d", maxDeriveLength)
+	}
+	secret, err := s.ecdh(ctx, methodDerive, &req.ECDHRequest)
+	if err != nil {
+		return nil, err
+	}
+	out, err := keyexchange.HKDF(sha256.New, secret, req.Salt, req.Info, req.Length)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &DeriveResponse{Key: out}, nil
+}
+
+func (s *Server) ecdh(ctx context.Context, method string, req *ECDHRequest) ([]byte, error) {
+	key, err := s.key(ctx, method, req.KeyID)
+	if err != nil {
+		return nil, err
+	}
+	pub, ok := key.Public().(*ecdsa.PublicKey)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "keyservice: key %q does not support ECDH", req.KeyID)
+	}
+	own, err := pub.ECDH()
+	if err != nil {
+		return nil, status.Error(codes.Unimplemented, err.Error())
+	}
+	peer, err := own.Curve().NewPublicKey(req.PublicKey)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	var secret []byte
+	switch k := key.(type) {
+	case hardware.Decrypter:
+		secret, err = k.ECDH(peer)
+	case *ecdsa.PrivateKey:
+		var priv *ecdh.PrivateKey
+		if priv, err = k.ECDH(); err == nil {
+			secret, err = priv.ECDH(peer)
+		}
+	default:
+		return nil, status.Errorf(codes.Unimplemented, "keyservice: key %q does not support ECDH", req.KeyID)
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return secret, nil
+}
+
+// signerOpts returns the crypto.SignerOpts of a request
+func (r *SignRequest) signerOpts() (crypto.SignerOpts, error) {
+	h := crypto.Hash(r.Hash)
+	if h != 0 {
+		if !h.Available() {
+			return nil, status.Errorf(codes.InvalidArgument, "keyservice: unavailable hash %d", r.Hash)
+		}
+		if len(r.Digest) != h.Size() {
+			return nil, status.Errorf(codes.InvalidArgument, "keyservice: digest must be %d bytes", h.Size())
+		}
+	}
+	if r.PSS {
+		return &rsa.PSSOptions{SaltLength: r.PSSSaltLength, Hash: h}, nil
+	}
+	return h, nil
+}
+
+// verify checks a signature as crypto.Signer produces it for opts
+func verify(pub crypto.PublicKey, digest, sig []byte, opts crypto.SignerOpts) bool {
+	switch pub := pub.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(pub, digest, sig)
+	case ed25519.PublicKey:
+		return opts.HashFunc() == 0 && ed25519.Verify(pub, digest, sig)
+	case *rsa.PublicKey:
+		if pss, ok := opts.(*rsa.PSSOptions); ok {
+			return rsa.VerifyPSS(pub, pss.Hash, digest, sig, pss) == nil
+		}
+		return rsa.VerifyPKCS1v15(pub, opts.HashFunc(), digest, sig) == nil
+	default:
+		return false
+	}
+}
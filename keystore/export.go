@@ -0,0 +1,101 @@
+package keystore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-sonr/crypto/core/secret"
+)
+
+const (
+	bundleVersion = 1
+	bundleAD      = "sonr-keystore-export-v1"
+)
+
+// bundle is the JSON encoding of an exported keystore
+type bundle struct {
+	Version int `json:"version"`
+	sealed
+}
+
+// Export encrypts the entries ids of ks, every entry when ids is empty,
+// into a bundle under passphrase
+func Export(ctx context.Context, ks Keystore, passphrase []byte, params Argon2Params, ids ...string) ([]byte, error) {
+	if ks == nil {
+		return nil, fmt.Errorf("keystore: keystore is required")
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		infos, err := ks.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, info := range infos {
+			ids = append(ids, info.ID)
+		}
+	}
+	entries := make([]*Entry, 0, len(ids))
+	defer func() {
+		for _, e := range entries {
+			e.Wipe()
+		}
+	}()
+	for _, id := range ids {
+		e, err := ks.Get(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("keystore: export %q: %w", id, err)
+		}
+		entries = append(entries, e)
+	}
+	plaintext, err := json.Marshal(entries)
+	if err != nil {
+		return nil, err
+	}
+	defer secret.Wipe(plaintext)
+	s, err := seal(passphrase, plaintext, []byte(bundleAD), params)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(&bundle{Version: bundleVersion, sealed: *s})
+}
+
+// Import decrypts a bundle of Export with passphrase and puts its entries
+// in ks, returning their ids. Entries already in ks fail the import with
+// ErrExists, entries put before the failure are kept.
+func Import(ctx context.Context, ks Keystore, data, passphrase []byte) ([]string, error) {
+	if ks == nil {
+		return nil, fmt.Errorf("keystore: keystore is required")
+	}
+	b := &bundle{}
+	if err := json.Unmarshal(data, b); err != nil {
+		return nil, fmt.Errorf("keystore: invalid bundle: %w", err)
+	}
+	if b.Version != bundleVersion {
+		return nil, fmt.Errorf("keystore: unsupported bundle version %d", b.Version)
+	}
+	plaintext, err := b.open(passphrase, []byte(bundleAD))
+	if err != nil {
+		return nil, err
+	}
+	defer secret.Wipe(plaintext)
+	var entries []*Entry
+	if err := json.Unmarshal(plaintext, &entries); err != nil {
+		return nil, fmt.Errorf("keystore: invalid bundle: %w", err)
+	}
+	defer func() {
+		for _, e := range entries {
+			e.Wipe()
+		}
+	}()
+	ids := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if err := ks.Put(ctx, e); err != nil {
+			return ids, fmt.Errorf("keystore: import %q: %w", e.ID, err)
+		}
+		ids = append(ids, e.ID)
+	}
+	return ids, nil
+}
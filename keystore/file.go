@@ -0,0 +1,193 @@
+package keystore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	fileVersion = 1
+	fileSuffix  = ".json"
+)
+
+// fileEntry is the JSON file of an entry. The info is stored in the clear
+// so List needs no passphrase, and authenticated as additional data.
+type fileEntry struct {
+	Version int `json:"version"`
+	Info
+	sealed
+}
+
+// additionalData binds the version and info of a file to its ciphertext
+func (f *fileEntry) additionalData() ([]byte, error) {
+	return json.Marshal(struct {
+		Version int `json:"version"`
+		Info
+	}{f.Version, f.Info})
+}
+
+type fileKeystore struct {
+	dir        string
+	passphrase []byte
+	params     Argon2Params
+}
+
+var _ Keystore = (*fileKeystore)(nil)
+
+// NewFileKeystore returns a keystore of one file per entry in dir, created
+// if missing, with secrets encrypted under passphrase. Keys are derived from
+// the passphrase and a random salt per file with Argon2id and params.
+func NewFileKeystore(dir string, passphrase []byte, params Argon2Params) (Keystore, error) {
+	if dir == "" || len(passphrase) == 0 {
+		return nil, fmt.Errorf("keystore: directory and passphrase are required")
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &fileKeystore{dir: dir, passphrase: append([]byte(nil), passphrase...), params: params}, nil
+}
+
+func (ks *fileKeystore) path(id string) (string, error) {
+	if !idPattern.MatchString(id) {
+		return "", fmt.Errorf("keystore: invalid id %q", id)
+	}
+	return filepath.Join(ks.dir, id+fileSuffix), nil
+}
+
+func (ks *fileKeystore) Put(_ context.Context, e *Entry) error {
+	if err := e.validate(); err != nil {
+		return err
+	}
+	path, err := ks.path(e.ID)
+	if err != nil {
+		return err
+	}
+	f := &fileEntry{Version: fileVersion, Info: e.clone().Info}
+	if f.CreatedAt.IsZero() {
+		f.CreatedAt = time.Now().UTC()
+	}
+	ad, err := f.additionalData()
+	if err != nil {
+		return err
+	}
+	s, err := seal(ks.passphrase, e.Secret, ad, ks.params)
+	if err != nil {
+		return err
+	}
+	f.sealed = *s
+	data, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+
+	// write a temporary file and link it in place, which fails rather than
+	// replacing an existing entry
+	tmp, err := os.CreateTemp(ks.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(data); err == nil {
+		err = tmp.Sync()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.Link(tmp.Name(), path); err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			return ErrExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (ks *fileKeystore) read(path string) (*fileEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	f := &fileEntry{}
+	if err := json.Unmarshal(data, f); err != nil {
+		return nil, fmt.Errorf("keystore: invalid entry file %s: %w", filepath.Base(path), err)
+	}
+	if f.Version != fileVersion {
+		return nil, fmt.Errorf("keystore: unsupported entry version %d", f.Version)
+	}
+	return f, nil
+}
+
+func (ks *fileKeystore) Get(_ context.Context, id string) (*Entry, error) {
+	path, err := ks.path(id)
+	if err != nil {
+		return nil, err
+	}
+	f, err := ks.read(path)
+	if err != nil {
+		return nil, err
+	}
+	if f.ID != id {
+		return nil, fmt.Errorf("keystore: entry file %s holds id %q", filepath.Base(path), f.ID)
+	}
+	ad, err := f.additionalData()
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := f.open(ks.passphrase, ad)
+	if err != nil {
+		return nil, err
+	}
+	return &Entry{Info: f.Info, Secret: plaintext}, nil
+}
+
+func (ks *fileKeystore) List(context.Context) ([]Info, error) {
+	files, err := os.ReadDir(ks.dir)
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]Info, 0, len(files))
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() || strings.HasPrefix(name, ".") || !strings.HasSuffix(name, fileSuffix) {
+			continue
+		}
+		f, err := ks.read(filepath.Join(ks.dir, name))
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, f.Info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].ID < infos[j].ID })
+	return infos, nil
+}
+
+func (ks *fileKeystore) Delete(_ context.Context, id string) error {
+	path, err := ks.path(id)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+// Package keystore stores private keys and MPC key shares. A Keystore puts,
+// gets, lists and deletes entries by id. The file keystore encrypts every
+// entry at rest with a key derived from a passphrase with Argon2id and a
+// key committing XChaCha20-Poly1305, and Export and Import move entries
+// between keystores as a single encrypted bundle.
+package keystore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	p2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
+
+	"github.com/go-sonr/crypto/core/secret"
+	"github.com/go-sonr/crypto/mpc"
+)
+
+var (
+	// ErrNotFound is returned for ids without an entry
+	ErrNotFound = errors.New("keystore: entry not found")
+	// ErrExists is returned when putting an id that already has an entry
+	ErrExists = errors.New("keystore: entry already exists")
+	// ErrDecrypt is returned when an entry or bundle cannot be decrypted,
+	// usually because of a wrong passphrase
+	ErrDecrypt = errors.New("keystore: decryption failed")
+)
+
+// Kind is the kind of secret of an entry
+type Kind string
+
+const (
+	// KindPrivateKey is a libp2p protobuf encoded private key
+	KindPrivateKey Kind = "private-key"
+	// KindMPCShare is an MPC key share, such as a JSON encoded mpc enclave
+	KindMPCShare Kind = "mpc-share"
+)
+
+// idPattern restricts ids to names that are safe as file names
+var idPattern = regexp.MustCompile(`^[A-Za-z0-9_-][A-Za-z0-9._-]{0,127}$`)
+
+// Info describes an entry without its secret
+type Info struct {
+	ID        string            `json:"id"`
+	Kind      Kind              `json:"kind"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	CreatedAt time.Time         `json:"created"`
+}
+
+// Entry is a secret and its description
+type Entry struct {
+	Info
+	Secret []byte `json:"secret"`
+}
+
+// Wipe overwrites the secret of the entry
+func (e *Entry) Wipe() {
+	secret.Wipe(e.Secret)
+}
+
+func (e *Entry) validate() error {
+	if e == nil || len(e.Secret) == 0 {
+		return fmt.Errorf("keystore: entry secret is required")
+	}
+	if !idPattern.MatchString(e.ID) {
+		return fmt.Errorf("keystore: invalid id %q", e.ID)
+	}
+	if e.Kind == "" {
+		return fmt.Errorf("keystore: entry kind is required")
+	}
+	return nil
+}
+
+// clone deep copies an entry
+func (e *Entry) clone() *Entry {
+	c := &Entry{Info: e.Info, Secret: append([]byte(nil), e.Secret...)}
+	if e.Metadata != nil {
+		c.Metadata = make(map[string]string, len(e.Metadata))
+		for k, v := range e.Metadata {
+			c.Metadata[k] = v
+		}
+	}
+	return c
+}
+
+// Keystore stores secrets by id. Get returns a copy the caller should Wipe
+// when done with it.
+type Keystore interface {
+	// Put stores a new entry, ErrExists if its id is taken. A zero
+	// CreatedAt is set to the current time.
+	Put(ctx context.Context, e *Entry) error
+	// Get returns the entry of id, ErrNotFound if there is none
+	Get(ctx context.Context, id string) (*Entry, error)
+	// List describes every entry, sorted by id
+	List(ctx context.Context) ([]Info, error)
+	// Delete removes the entry of id, ErrNotFound if there is none
+	Delete(ctx context.Context, id string) error
+}
+
+type memKeystore struct {
+	mu      sync.Mutex
+	entries map[string]*Entry
+}
+
+var _ Keystore = (*memKeystore)(nil)
+
+// NewMemKeystore returns a keystore that holds entries in memory, for tests
+// and short lived processes
+func NewMemKeystore() Keystore {
+	return &memKeystore{entries: map[string]*Entry{}}
+}
+
+func (ks *memKeystore) Put(_ context.Context, e *Entry) error {
+	if err := e.validate(); err != nil {
+		return err
+	}
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	if _, ok := ks.entries[e.ID]; ok {
+		return ErrExists
+	}
+	c := e.clone()
+	if c.CreatedAt.IsZero() {
+		c.CreatedAt = time.Now().UTC()
+	}
+	ks.entries[e.ID] = c
+	return nil
+}
+
+func (ks *memKeystore) Get(_ context.Context, id string) (*Entry, error) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	e, ok := ks.entries[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return e.clone(), nil
+}
+
+func (ks *memKeystore) List(context.Context) ([]Info, error) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	infos := make([]Info, 0, len(ks.entries))
+	for _, e := range ks.entries {
+		infos = append(infos, e.clone().Info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].ID < infos[j].ID })
+	return infos, nil
+}
+
+func (ks *memKeystore) Delete(_ context.Context, id string) error {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	e, ok := ks.entries[id]
+	if !ok {
+		return ErrNotFound
+	}
+	e.Wipe()
+	delete(ks.entries, id)
+	return nil
+}
+
+// PutPrivateKey stores a libp2p private key as id
+func PutPrivateKey(ctx context.Context, ks Keystore, id string, priv p2pcrypto.PrivKey, metadata map[string]string) error {
+	if ks == nil || priv == nil {
+		return fmt.Errorf("keystore: keystore and private key are required")
+	}
+	raw, err := p2pcrypto.MarshalPrivateKey(priv)
+	if err != nil {
+		return err
+	}
+	e := &Entry{Info: Info{ID: id, Kind: KindPrivateKey, Metadata: metadata}, Secret: raw}
+	defer e.Wipe()
+	return ks.Put(ctx, e)
+}
+
+// GetPrivateKey returns the libp2p private key stored as id
+func GetPrivateKey(ctx context.Context, ks Keystore, id string) (p2pcrypto.PrivKey, error) {
+	e, err := getKind(ctx, ks, id, KindPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	defer e.Wipe()
+	return p2pcrypto.UnmarshalPrivateKey(e.Secret)
+}
+
+// PutEnclave stores the key shares of an MPC enclave as id
+func PutEnclave(ctx context.Context, ks Keystore, id string, enclave mpc.Enclave, metadata map[string]string) error {
+	if ks == nil || enclave == nil {
+		return fmt.Errorf("keystore: keystore and enclave are required")
+	}
+	raw, err := enclave.Marshal()
+	if err != nil {
+		return err
+	}
+	e := &Entry{Info: Info{ID: id, Kind: KindMPCShare, Metadata: metadata}, Secret: raw}
+	defer e.Wipe()
+	return ks.Put(ctx, e)
+}
+
+// GetEnclave returns the MPC enclave stored as id
+func GetEnclave(ctx context.Context, ks Keystore, id string) (mpc.Enclave, error) {
+	e, err := getKind(ctx, ks, id, KindMPCShare)
+	if err != nil {
+		return nil, err
+	}
+	defer e.Wipe()
+	data := &mpc.EnclaveData{}
+	if err := data.Unmarshal(e.Secret); err != nil {
+		return nil, fmt.Errorf("keystore: invalid enclave: %w", err)
+	}
+	return mpc.RestoreEnclaveFromData(data)
+}
+
+func getKind(ctx context.Context, ks Keystore, id string, kind Kind) (*Entry, error) {
+	if ks == nil {
+		return nil, fmt.Errorf("keystore: keystore is required")
+	}
+	e, err := ks.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if e.Kind != kind {
+		e.Wipe()
+		return nil, fmt.Errorf("keystore: entry %q is a %s, not a %s", id, e.Kind, kind)
+	}
+	return e, nil
+}
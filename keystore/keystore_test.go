@@ -0,0 +1,138 @@
+package keystore
+
+import (
+	"context"
+	crand "crypto/rand"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	p2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/mpc"
+)
+
+// testParams keeps Argon2id cheap in tests
+var testParams = Argon2Params{Time: 1, Memory: 64, Threads: 1}
+
+func testKeystore(t *testing.T, ks Keystore) {
+	ctx := context.Background()
+	priv, _, err := p2pcrypto.GenerateEd25519Key(crand.Reader)
+	require.NoError(t, err)
+
+	require.NoError(t, PutPrivateKey(ctx, ks, "alice", priv, map[string]string{"chain": "sonr"}))
+	require.ErrorIs(t, PutPrivateKey(ctx, ks, "alice", priv, nil), ErrExists)
+	require.NoError(t, ks.Put(ctx, &Entry{Info: Info{ID: "bob", Kind: KindMPCShare}, Secret: []byte("share")}))
+	require.Error(t, ks.Put(ctx, &Entry{Info: Info{ID: "../escape", Kind: KindMPCShare}, Secret: []byte("share")}))
+	require.Error(t, ks.Put(ctx, &Entry{Info: Info{ID: "empty", Kind: KindMPCShare}}))
+
+	got, err := GetPrivateKey(ctx, ks, "alice")
+	require.NoError(t, err)
+	require.True(t, priv.Equals(got))
+	_, err = GetPrivateKey(ctx, ks, "bob")
+	require.Error(t, err)
+	_, err = ks.Get(ctx, "carol")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	infos, err := ks.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, infos, 2)
+	require.Equal(t, "alice", infos[0].ID)
+	require.Equal(t, KindPrivateKey, infos[0].Kind)
+	require.Equal(t, "sonr", infos[0].Metadata["chain"])
+	require.False(t, infos[0].CreatedAt.IsZero())
+	require.Equal(t, "bob", infos[1].ID)
+
+	require.NoError(t, ks.Delete(ctx, "bob"))
+	require.ErrorIs(t, ks.Delete(ctx, "bob"), ErrNotFound)
+	infos, err = ks.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+}
+
+func TestMemKeystore(t *testing.T) {
+	testKeystore(t, NewMemKeystore())
+}
+
+func TestFileKeystore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	ks, err := NewFileKeystore(dir, []byte("correct horse"), testParams)
+	require.NoError(t, err)
+	testKeystore(t, ks)
+
+	// secrets are not stored in the clear
+	raw, err := os.ReadFile(filepath.Join(dir, "alice.json"))
+	require.NoError(t, err)
+	e, err := ks.Get(ctx, "alice")
+	require.NoError(t, err)
+	require.NotContains(t, string(raw), string(e.Secret))
+
+	// a wrong passphrase cannot decrypt entries but can list them
+	wrong, err := NewFileKeystore(dir, []byte("battery staple"), testParams)
+	require.NoError(t, err)
+	_, err = wrong.Get(ctx, "alice")
+	require.ErrorIs(t, err, ErrDecrypt)
+	infos, err := wrong.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+
+	// the clear info is authenticated
+	var f map[string]any
+	require.NoError(t, json.Unmarshal(raw, &f))
+	f["kind"] = string(KindMPCShare)
+	tampered, err := json.Marshal(f)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "alice.json"), tampered, 0o600))
+	_, err = ks.Get(ctx, "alice")
+	require.ErrorIs(t, err, ErrDecrypt)
+
+	_, err = NewFileKeystore(dir, nil, testParams)
+	require.Error(t, err)
+	_, err = NewFileKeystore(dir, []byte("pass"), Argon2Params{})
+	require.Error(t, err)
+}
+
+func TestExportImport(t *testing.T) {
+	ctx := context.Background()
+	src, err := NewFileKeystore(t.TempDir(), []byte("source"), testParams)
+	require.NoError(t, err)
+	priv, _, err := p2pcrypto.GenerateSecp256k1Key(crand.Reader)
+	require.NoError(t, err)
+	require.NoError(t, PutPrivateKey(ctx, src, "validator", priv, map[string]string{"role": "validator"}))
+	enclave := &mpc.EnclaveData{PubHex: "02aa", Curve: mpc.K256Name}
+	require.NoError(t, PutEnclave(ctx, src, "wallet", enclave, nil))
+
+	bundle, err := Export(ctx, src, []byte("export"), testParams)
+	require.NoError(t, err)
+
+	dst := NewMemKeystore()
+	_, err = Import(ctx, dst, bundle, []byte("wrong"))
+	require.ErrorIs(t, err, ErrDecrypt)
+	ids, err := Import(ctx, dst, bundle, []byte("export"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"validator", "wallet"}, ids)
+
+	got, err := GetPrivateKey(ctx, dst, "validator")
+	require.NoError(t, err)
+	require.True(t, priv.Equals(got))
+	restored, err := GetEnclave(ctx, dst, "wallet")
+	require.NoError(t, err)
+	require.Equal(t, "02aa", restored.PubKeyHex())
+	infos, err := dst.List(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "validator", infos[0].Metadata["role"])
+
+	// importing again collides with the existing entries
+	_, err = Import(ctx, dst, bundle, []byte("export"))
+	require.ErrorIs(t, err, ErrExists)
+
+	// a single entry can be exported
+	bundle, err = Export(ctx, src, []byte("export"), testParams, "wallet")
+	require.NoError(t, err)
+	ids, err = Import(ctx, NewMemKeystore(), bundle, []byte("export"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"wallet"}, ids)
+}
@@ -0,0 +1,88 @@
+package keystore
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+
+	"github.com/go-sonr/crypto/aead"
+	"github.com/go-sonr/crypto/core/secret"
+	"github.com/go-sonr/crypto/kdf"
+)
+
+const (
+	kdfArgon2id = "argon2id"
+	keyInfo     = "sonr-keystore-v1"
+	saltSize    = 16
+)
+
+// cipherAlgorithm encrypts entries and bundles
+const cipherAlgorithm = aead.XChaCha20Poly1305
+
+// Argon2Params are the Argon2id parameters of the passphrase KDF
+type Argon2Params = kdf.Argon2id
+
+// DefaultArgon2Params are the second recommended option of RFC 9106, 3
+// passes over 64 MiB
+var DefaultArgon2Params = kdf.DefaultArgon2id
+
+// kdfInfo records how the key of a sealed secret is derived
+type kdfInfo struct {
+	Name string `json:"name"`
+	Salt []byte `json:"salt"`
+	Argon2Params
+}
+
+// sealed is a secret encrypted under a passphrase
+type sealed struct {
+	KDF        kdfInfo `json:"kdf"`
+	Cipher     string  `json:"cipher"`
+	Ciphertext []byte  `json:"ciphertext"`
+}
+
+func (k *kdfInfo) key(passphrase []byte) (*aead.Key, error) {
+	if k.Name != kdfArgon2id || len(k.Salt) < saltSize {
+		return nil, fmt.Errorf("keystore: unsupported kdf %q", k.Name)
+	}
+	ikm, err := k.Argon2Params.Key(passphrase, k.Salt, cipherAlgorithm.KeySize())
+	if err != nil {
+		return nil, err
+	}
+	defer secret.Wipe(ikm)
+	return aead.NewKey(cipherAlgorithm, ikm, nil, []byte(keyInfo))
+}
+
+// seal encrypts plaintext under passphrase, authenticating ad
+func seal(passphrase, plaintext, ad []byte, params Argon2Params) (*sealed, error) {
+	if len(passphrase) == 0 {
+		return nil, fmt.Errorf("keystore: passphrase is required")
+	}
+	s := &sealed{KDF: kdfInfo{Name: kdfArgon2id, Salt: make([]byte, saltSize), Argon2Params: params}, Cipher: cipherAlgorithm.String()}
+	if _, err := io.ReadFull(rand.Reader, s.KDF.Salt); err != nil {
+		return nil, err
+	}
+	key, err := s.KDF.key(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if s.Ciphertext, err = key.Seal(plaintext, ad); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// open decrypts a sealed secret, authenticating ad
+func (s *sealed) open(passphrase, ad []byte) ([]byte, error) {
+	if s.Cipher != cipherAlgorithm.String() {
+		return nil, fmt.Errorf("keystore: unsupported cipher %q", s.Cipher)
+	}
+	key, err := s.KDF.key(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := key.Open(s.Ciphertext, ad)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,152 @@
+// Package mnemonic implements BIP39 mnemonic codes,
+// https://github.com/bitcoin/bips/blob/master/bip-0039.mediawiki
+//
+// The English wordlist is built in, wordlists of other languages can be added with RegisterWordlist. Mnemonics and
+// passphrases are used as given: BIP39 requires them to be NFKD normalized, which callers must do for non-ASCII
+// input.
+package mnemonic
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/hd"
+)
+
+const (
+	seedIterations = 2048
+	// SeedSize is the size in bytes of a BIP39 seed.
+	SeedSize = 64
+)
+
+// NewEntropy returns bits of entropy read from reader. bits must be a multiple of 32 between 128 and 256.
+func NewEntropy(bits int, reader io.Reader) ([]byte, error) {
+	if err := checkEntropyBits(bits); err != nil {
+		return nil, err
+	}
+	entropy := make([]byte, bits/8)
+	if _, err := io.ReadFull(reader, entropy); err != nil {
+		return nil, err
+	}
+	return entropy, nil
+}
+
+// New returns a mnemonic of bits of entropy read from reader, in the language of wordlist.
+func New(bits int, reader io.Reader, wordlist *Wordlist) (string, error) {
+	entropy, err := NewEntropy(bits, reader)
+	if err != nil {
+		return "", err
+	}
+	return FromEntropy(entropy, wordlist)
+}
+
+// FromEntropy encodes entropy and its checksum, the first len(entropy)/4 bits of its SHA-256, as a mnemonic.
+func FromEntropy(entropy []byte, wordlist *Wordlist) (string, error) {
+	if wordlist == nil {
+		return "", fmt.Errorf("invalid wordlist")
+	}
+	bits := len(entropy) * 8
+	if err := checkEntropyBits(bits); err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(entropy)
+	data := append(append([]byte{}, entropy...), hash[0])
+	count := (bits + bits/32) / 11
+	words := make([]string, count)
+	for i := range words {
+		words[i] = wordlist.words[readBits(data, i*11)]
+	}
+	return strings.Join(words, wordlist.separator), nil
+}
+
+// ToEntropy decodes a mnemonic in the language of wordlist and checks its checksum.
+func ToEntropy(mnemonic string, wordlist *Wordlist) ([]byte, error) {
+	if wordlist == nil {
+		return nil, fmt.Errorf("invalid wordlist")
+	}
+	words := strings.Fields(mnemonic)
+	if len(words)%3 != 0 || len(words) < 12 || len(words) > 24 {
+		return nil, fmt.Errorf("mnemonic must have 12, 15, 18, 21 or 24 words, got %d", len(words))
+	}
+	total := len(words) * 11
+	checksumBits := total / 33
+	data := make([]byte, (total+7)/8)
+	for i, w := range words {
+		index, ok := wordlist.Index(w)
+		if !ok {
+			return nil, fmt.Errorf("word %q is not in the %s wordlist", w, wordlist.language)
+		}
+		writeBits(data, i*11, index)
+	}
+	entropy := data[:(total-checksumBits)/8]
+	hash := sha256.Sum256(entropy)
+	mask := byte(0xff << (8 - checksumBits))
+	if hash[0]&mask != data[len(entropy)]&mask {
+		return nil, fmt.Errorf("invalid mnemonic checksum")
+	}
+	return entropy, nil
+}
+
+// Validate returns nil if mnemonic is a valid mnemonic in the language of wordlist.
+func Validate(mnemonic string, wordlist *Wordlist) error {
+	_, err := ToEntropy(mnemonic, wordlist)
+	return err
+}
+
+// NewSeed returns the 64 byte seed of mnemonic and passphrase, PBKDF2-HMAC-SHA512 with 2048 iterations and salt
+// "mnemonic" || passphrase. It does not validate the mnemonic, use NewSeedWithChecksum for that.
+func NewSeed(mnemonic, passphrase string) []byte {
+	words := strings.Fields(mnemonic)
+	normalized := strings.Join(words, " ")
+	return pbkdf2.Key([]byte(normalized), []byte("mnemonic"+passphrase), seedIterations, SeedSize, sha512.New)
+}
+
+// NewSeedWithChecksum validates mnemonic in the language of wordlist and returns its seed.
+func NewSeedWithChecksum(mnemonic, passphrase string, wordlist *Wordlist) ([]byte, error) {
+	if err := Validate(mnemonic, wordlist); err != nil {
+		return nil, err
+	}
+	return NewSeed(mnemonic, passphrase), nil
+}
+
+// NewMasterKey validates mnemonic in the language of wordlist and returns the hd master key of its seed for curve.
+func NewMasterKey(mnemonic, passphrase string, wordlist *Wordlist, curve *curves.Curve) (*hd.ExtendedKey, error) {
+	seed, err := NewSeedWithChecksum(mnemonic, passphrase, wordlist)
+	if err != nil {
+		return nil, err
+	}
+	return hd.NewMasterKey(seed, curve)
+}
+
+func checkEntropyBits(bits int) error {
+	if bits%32 != 0 || bits < 128 || bits > 256 {
+		return fmt.Errorf("entropy must be a multiple of 32 bits between 128 and 256, got %d", bits)
+	}
+	return nil
+}
+
+// readBits returns the 11 bits of data starting at bit offset.
+func readBits(data []byte, offset int) int {
+	v := 0
+	for i := 0; i < 11; i++ {
+		bit := offset + i
+		v = v<<1 | int(data[bit/8]>>(7-bit%8)&1)
+	}
+	return v
+}
+
+// writeBits writes the 11 bits of v into data starting at bit offset.
+func writeBits(data []byte, offset, v int) {
+	for i := 0; i < 11; i++ {
+		if v>>(10-i)&1 == 1 {
+			bit := offset + i
+			data[bit/8] |= 1 << (7 - bit%8)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package mnemonic
+
+import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/hd"
+)
+
+func english(t *testing.T) *Wordlist {
+	w, err := GetWordlist(English)
+	require.NoError(t, err)
+	return w
+}
+
+// Test vectors from https://github.com/trezor/python-mnemonic/blob/master/vectors.json
+func TestVectors(t *testing.T) {
+	vectors := []struct {
+		entropy, mnemonic, seed string
+	}{
+		{
+			"00000000000000000000000000000000",
+			"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		},
+		{
+			"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+			"legal winner thank year wave sausage worth useful legal winner thank yellow",
+			"2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+		},
+		{
+			"80808080808080808080808080808080",
+			"letter advice cage absurd amount doctor acoustic avoid letter advice cage above",
+			"d71de856f81a8acc65e6fc851a38d4d7ec216fd0796d0a6827a3ad6ed5511a30fa280f12eb2e47ed2ac03b5c462a0358d18d69fe4f985ec81778c1b370b652a8",
+		},
+		{
+			"ffffffffffffffffffffffffffffffff",
+			"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo wrong",
+			"ac27495480225222079d7be181583751e86f571027b0497b5b5d11218e0a8a13332572917f0f8e5a589620c6f15b11c61dee327651a14c34e18231052e48c069",
+		},
+		{
+			"0000000000000000000000000000000000000000000000000000000000000000",
+			"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+			"bda85446c68413707090a52022edd26a1c9462295029f2e60cd7c4f2bbd3097170af7a4d73245cafa9c3cca8d561a7c3de6f5d4a10be8ed2a5e608d68f92fcc8",
+		},
+	}
+	wl := english(t)
+	for _, v := range vectors {
+		entropy, _ := hex.DecodeString(v.entropy)
+		m, err := FromEntropy(entropy, wl)
+		require.NoError(t, err)
+		require.Equal(t, v.mnemonic, m)
+		decoded, err := ToEntropy(m, wl)
+		require.NoError(t, err)
+		require.Equal(t, entropy, decoded)
+		seed, err := NewSeedWithChecksum(m, "TREZOR", wl)
+		require.NoError(t, err)
+		require.Equal(t, v.seed, hex.EncodeToString(seed))
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	wl := english(t)
+	for bits := 128; bits <= 256; bits += 32 {
+		m, err := New(bits, crand.Reader, wl)
+		require.NoError(t, err)
+		require.Len(t, strings.Fields(m), (bits+bits/32)/11)
+		require.NoError(t, Validate(m, wl))
+	}
+}
+
+func TestInvalidMnemonics(t *testing.T) {
+	wl := english(t)
+	for _, m := range []string{
+		// checksum
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon",
+		// word count
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		// unknown word
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abou",
+		"",
+	} {
+		require.Error(t, Validate(m, wl), m)
+	}
+	_, err := NewEntropy(129, crand.Reader)
+	require.Error(t, err)
+	_, err = FromEntropy(make([]byte, 8), wl)
+	require.Error(t, err)
+	_, err = FromEntropy(make([]byte, 16), nil)
+	require.Error(t, err)
+}
+
+func TestWordlists(t *testing.T) {
+	wl := english(t)
+	w, err := wl.Word(2047)
+	require.NoError(t, err)
+	require.Equal(t, "zoo", w)
+	i, ok := wl.Index("abandon")
+	require.True(t, ok)
+	require.Equal(t, 0, i)
+	_, err = GetWordlist("klingon")
+	require.Error(t, err)
+
+	// A custom language round trips through the registry
+	words := make([]string, 2048)
+	for i := range words {
+		words[i] = "w" + strings.Repeat("x", i%7) + hex.EncodeToString([]byte{byte(i >> 8), byte(i)})
+	}
+	custom, err := NewWordlist("test", words, "　")
+	require.NoError(t, err)
+	require.NoError(t, RegisterWordlist(custom))
+	require.Contains(t, Languages(), "test")
+	registered, err := GetWordlist("test")
+	require.NoError(t, err)
+	m, err := New(160, crand.Reader, registered)
+	require.NoError(t, err)
+	require.Contains(t, m, "　")
+	require.NoError(t, Validate(m, registered))
+	require.Error(t, Validate(m, wl))
+
+	_, err = NewWordlist("short", words[:2047], " ")
+	require.Error(t, err)
+	words[1] = words[0]
+	_, err = NewWordlist("duplicate", words, " ")
+	require.Error(t, err)
+}
+
+func TestMasterKey(t *testing.T) {
+	wl := english(t)
+	m := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	key, err := NewMasterKey(m, "TREZOR", wl, curves.K256())
+	require.NoError(t, err)
+	seed := NewSeed(m, "TREZOR")
+	expected, err := hd.NewMasterKey(seed, curves.K256())
+	require.NoError(t, err)
+	require.True(t, expected.Equal(key))
+
+	_, err = NewMasterKey(strings.Replace(m, "about", "abandon", 1), "", wl, curves.K256())
+	require.Error(t, err)
+}
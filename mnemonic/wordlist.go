@@ -0,0 +1,126 @@
+package mnemonic
+
+import (
+	_ "embed"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// English is the language of the built-in BIP39 wordlist.
+const English = "english"
+
+//go:embed wordlists/english.txt
+var englishWords string
+
+// Wordlist is a list of 2048 words, each encoding 11 bits of a mnemonic.
+type Wordlist struct {
+	language  string
+	words     []string
+	index     map[string]int
+	separator string
+}
+
+// NewWordlist checks words and returns the wordlist of language. Mnemonics are joined with separator, which is a
+// space for most languages and an ideographic space for Japanese. Words must be NFKD normalized.
+func NewWordlist(language string, words []string, separator string) (*Wordlist, error) {
+	if language == "" {
+		return nil, fmt.Errorf("invalid language")
+	}
+	if separator == "" {
+		separator = " "
+	}
+	if len(words) != 2048 {
+		return nil, fmt.Errorf("wordlist must have 2048 words, got %d", len(words))
+	}
+	index := make(map[string]int, len(words))
+	for i, w := range words {
+		if w == "" || strings.ContainsAny(w, " \t\n　") {
+			return nil, fmt.Errorf("invalid word at index %d", i)
+		}
+		if _, ok := index[w]; ok {
+			return nil, fmt.Errorf("duplicate word %q", w)
+		}
+		index[w] = i
+	}
+	return &Wordlist{
+		language:  language,
+		words:     append([]string{}, words...),
+		index:     index,
+		separator: separator,
+	}, nil
+}
+
+// Language returns the language of the wordlist.
+func (w *Wordlist) Language() string {
+	return w.language
+}
+
+// Separator returns the separator mnemonics of the wordlist are joined with.
+func (w *Wordlist) Separator() string {
+	return w.separator
+}
+
+// Word returns the word at index i.
+func (w *Wordlist) Word(i int) (string, error) {
+	if i < 0 || i >= len(w.words) {
+		return "", fmt.Errorf("invalid word index %d", i)
+	}
+	return w.words[i], nil
+}
+
+// Index returns the index of word, or false if it is not in the wordlist.
+func (w *Wordlist) Index(word string) (int, bool) {
+	i, ok := w.index[word]
+	return i, ok
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]*Wordlist{}
+)
+
+func init() {
+	words := strings.Fields(englishWords)
+	wl, err := NewWordlist(English, words, " ")
+	if err != nil {
+		panic(err)
+	}
+	registry[English] = wl
+}
+
+// RegisterWordlist makes a wordlist available to GetWordlist under its language, replacing any wordlist already
+// registered for it.
+func RegisterWordlist(w *Wordlist) error {
+	if w == nil {
+		return fmt.Errorf("invalid wordlist")
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[w.language] = w
+	return nil
+}
+
+// GetWordlist returns the wordlist registered for language.
+func GetWordlist(language string) (*Wordlist, error) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	w, ok := registry[language]
+	if !ok {
+		return nil, fmt.Errorf("no wordlist for language %q", language)
+	}
+	return w, nil
+}
+
+// Languages returns the registered languages in order.
+func Languages() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	out := make([]string, 0, len(registry))
+	for l := range registry {
+		out = append(out, l)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,189 @@
+package opaque
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"io"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/oprf"
+)
+
+// Client runs the client side of registration or of one login for a password
+type Client struct {
+	conf     *Config
+	password []byte
+	oprf     *oprf.Client
+	blinded  *oprf.Blinded
+	// login state
+	ke1          *KE1
+	clientSecret curves.Scalar
+}
+
+// NewClient creates a client for password
+func NewClient(conf *Config, password []byte) (*Client, error) {
+	if err := conf.check(); err != nil {
+		return nil, err
+	}
+	if err := checkLength(password); err != nil {
+		return nil, err
+	}
+	o, err := oprf.NewClient(conf.Suite, oprf.ModeOPRF, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{conf: conf, password: append([]byte{}, password...), oprf: o}, nil
+}
+
+// RegistrationRequest blinds the password, see RFC 9807 section 5.2.1
+func (c *Client) RegistrationRequest(reader io.Reader) (*RegistrationRequest, error) {
+	blinded, err := c.oprf.Blind(c.password, nil, reader)
+	if err != nil {
+		return nil, err
+	}
+	c.blinded = blinded
+	return &RegistrationRequest{BlindedMessage: blinded.Element}, nil
+}
+
+// FinalizeRegistration seals the client key in an envelope and returns the
+// record to upload and the export key, see RFC 9807 section 5.2.3. Empty
+// identities default to the public keys as in the RFC.
+func (c *Client) FinalizeRegistration(resp *RegistrationResponse, serverIdentity, clientIdentity []byte, reader io.Reader) (*RegistrationRecord, []byte, error) {
+	if c.blinded == nil {
+		return nil, nil, internal.ErrInvalidRound
+	}
+	if resp == nil || resp.EvaluatedMessage == nil || resp.ServerPublicKey == nil {
+		return nil, nil, internal.ErrNilArguments
+	}
+	if err := checkIdentities(serverIdentity, clientIdentity); err != nil {
+		return nil, nil, err
+	}
+	randomizedPassword, err := c.finalizeOprf(resp.EvaluatedMessage)
+	if err != nil {
+		return nil, nil, err
+	}
+	conf := c.conf
+
+	nonce, err := random(reader, nonceLength)
+	if err != nil {
+		return nil, nil, err
+	}
+	maskingKey := conf.expand(randomizedPassword, []byte("MaskingKey"), conf.hashLength())
+	authKey, exportKey, _, clientPublicKey, err := conf.envelopeKeys(randomizedPassword, nonce)
+	if err != nil {
+		return nil, nil, err
+	}
+	credentials, _, _ := conf.cleartextCredentials(resp.ServerPublicKey, clientPublicKey, serverIdentity, clientIdentity)
+	envelope := append(nonce, conf.mac(authKey, nonce, credentials)...)
+	return &RegistrationRecord{
+		ClientPublicKey: clientPublicKey,
+		MaskingKey:      maskingKey,
+		Envelope:        envelope,
+	}, exportKey, nil
+}
+
+// GenerateKE1 starts a login, see RFC 9807 section 6.2.1
+func (c *Client) GenerateKE1(reader io.Reader) (*KE1, error) {
+	if _, err := c.RegistrationRequest(reader); err != nil {
+		return nil, err
+	}
+	return c.generateKE1(reader)
+}
+
+// generateKE1 builds KE1 around the blinded password
+func (c *Client) generateKE1(reader io.Reader) (*KE1, error) {
+	nonce, err := random(reader, nonceLength)
+	if err != nil {
+		return nil, err
+	}
+	seed, err := random(reader, seedLength)
+	if err != nil {
+		return nil, err
+	}
+	secret, keyshare, err := c.conf.deriveDiffieHellmanKeyPair(seed)
+	if err != nil {
+		return nil, err
+	}
+	c.clientSecret = secret
+	c.ke1 = &KE1{
+		BlindedMessage:       c.blinded.Element,
+		ClientNonce:          nonce,
+		ClientPublicKeyshare: keyshare,
+	}
+	return c.ke1, nil
+}
+
+// GenerateKE3 recovers the client key from ke2, authenticates the server
+// and returns the final message with the session key and the export key,
+// see RFC 9807 section 6.2.4. The identities must match the ones used at
+// registration.
+func (c *Client) GenerateKE3(ke2 *KE2, serverIdentity, clientIdentity []byte) (*KE3, []byte, []byte, error) {
+	if c.ke1 == nil || c.clientSecret == nil {
+		return nil, nil, nil, internal.ErrInvalidRound
+	}
+	if ke2 == nil || ke2.EvaluatedMessage == nil || ke2.ServerPublicKeyshare == nil {
+		return nil, nil, nil, internal.ErrNilArguments
+	}
+	if err := checkIdentities(serverIdentity, clientIdentity); err != nil {
+		return nil, nil, nil, err
+	}
+	conf := c.conf
+	if len(ke2.MaskingNonce) != nonceLength || len(ke2.MaskedResponse) != conf.elementLength()+conf.envelopeLength() {
+		return nil, nil, nil, fmt.Errorf("invalid message length")
+	}
+	randomizedPassword, err := c.finalizeOprf(ke2.EvaluatedMessage)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// unmask the server public key and the envelope
+	maskingKey := conf.expand(randomizedPassword, []byte("MaskingKey"), conf.hashLength())
+	pad := conf.expand(maskingKey, append(append([]byte{}, ke2.MaskingNonce...), "CredentialResponsePad"...), len(ke2.MaskedResponse))
+	plain := xor(pad, ke2.MaskedResponse)
+	serverPublicKey, err := conf.Suite.DeserializeElement(plain[:conf.elementLength()])
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("envelope recovery failed")
+	}
+	envelope := plain[conf.elementLength():]
+
+	// open the envelope
+	nonce := envelope[:nonceLength]
+	authKey, exportKey, clientPrivateKey, clientPublicKey, err := conf.envelopeKeys(randomizedPassword, nonce)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	credentials, serverIdentity, clientIdentity := conf.cleartextCredentials(serverPublicKey, clientPublicKey, serverIdentity, clientIdentity)
+	if subtle.ConstantTimeCompare(conf.mac(authKey, nonce, credentials), envelope[nonceLength:]) != 1 {
+		return nil, nil, nil, fmt.Errorf("envelope recovery failed")
+	}
+
+	// 3DH
+	ikm := dh(c.clientSecret, ke2.ServerPublicKeyshare, serverPublicKey)
+	ikm = append(ikm, dh(clientPrivateKey, ke2.ServerPublicKeyshare)...)
+	preamble := conf.preamble(clientIdentity, c.ke1, serverIdentity, ke2)
+	km2, km3, sessionKey := conf.deriveKeys(ikm, preamble)
+	serverMAC := conf.mac(km2, conf.hash(preamble))
+	if subtle.ConstantTimeCompare(serverMAC, ke2.ServerMAC) != 1 {
+		return nil, nil, nil, fmt.Errorf("server authentication failed")
+	}
+	ke3 := &KE3{ClientMAC: conf.mac(km3, conf.hash(preamble, serverMAC))}
+
+	c.ke1, c.clientSecret, c.blinded = nil, nil, nil
+	return ke3, sessionKey, exportKey, nil
+}
+
+func (c *Client) finalizeOprf(evaluated curves.Point) ([]byte, error) {
+	outputs, err := c.oprf.Finalize([]*oprf.Blinded{c.blinded}, &oprf.Evaluation{Elements: []curves.Point{evaluated}})
+	if err != nil {
+		return nil, err
+	}
+	return c.conf.randomizedPassword(outputs[0])
+}
+
+func checkIdentities(serverIdentity, clientIdentity []byte) error {
+	if err := checkLength(serverIdentity); err != nil {
+		return err
+	}
+	return checkLength(clientIdentity)
+}
@@ -0,0 +1,187 @@
+package opaque
+
+import (
+	"fmt"
+
+	"github.com/go-sonr/crypto/core/curves"
+)
+
+// RegistrationRequest is the first registration message, sent by the client
+type RegistrationRequest struct {
+	BlindedMessage curves.Point
+}
+
+// RegistrationResponse is the server answer to a RegistrationRequest
+type RegistrationResponse struct {
+	EvaluatedMessage curves.Point
+	ServerPublicKey  curves.Point
+}
+
+// RegistrationRecord is what the server stores for a client
+type RegistrationRecord struct {
+	ClientPublicKey curves.Point
+	MaskingKey      []byte
+	Envelope        []byte
+}
+
+// KE1 is the first login message, sent by the client
+type KE1 struct {
+	BlindedMessage       curves.Point
+	ClientNonce          []byte
+	ClientPublicKeyshare curves.Point
+}
+
+// KE2 is the server answer to KE1
+type KE2 struct {
+	EvaluatedMessage     curves.Point
+	MaskingNonce         []byte
+	MaskedResponse       []byte
+	ServerNonce          []byte
+	ServerPublicKeyshare curves.Point
+	ServerMAC            []byte
+}
+
+// KE3 is the last login message, it authenticates the client
+type KE3 struct {
+	ClientMAC []byte
+}
+
+// Bytes serializes the request
+func (m *RegistrationRequest) Bytes() []byte {
+	return m.BlindedMessage.ToAffineCompressed()
+}
+
+// Bytes serializes the response
+func (m *RegistrationResponse) Bytes() []byte {
+	return append(m.EvaluatedMessage.ToAffineCompressed(), m.ServerPublicKey.ToAffineCompressed()...)
+}
+
+// Bytes serializes the record
+func (m *RegistrationRecord) Bytes() []byte {
+	out := m.ClientPublicKey.ToAffineCompressed()
+	out = append(out, m.MaskingKey...)
+	return append(out, m.Envelope...)
+}
+
+// Bytes serializes KE1
+func (m *KE1) Bytes() []byte {
+	out := m.BlindedMessage.ToAffineCompressed()
+	out = append(out, m.ClientNonce...)
+	return append(out, m.ClientPublicKeyshare.ToAffineCompressed()...)
+}
+
+// credentialResponse is the CredentialResponse part of KE2
+func (m *KE2) credentialResponse() []byte {
+	out := m.EvaluatedMessage.ToAffineCompressed()
+	out = append(out, m.MaskingNonce...)
+	return append(out, m.MaskedResponse...)
+}
+
+// Bytes serializes KE2
+func (m *KE2) Bytes() []byte {
+	out := m.credentialResponse()
+	out = append(out, m.ServerNonce...)
+	out = append(out, m.ServerPublicKeyshare.ToAffineCompressed()...)
+	return append(out, m.ServerMAC...)
+}
+
+// Bytes serializes KE3
+func (m *KE3) Bytes() []byte {
+	return append([]byte{}, m.ClientMAC...)
+}
+
+// reader splits a message into its fields
+type reader struct {
+	conf *Config
+	data []byte
+	err  error
+}
+
+func (r *reader) bytes(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if len(r.data) < n {
+		r.err = fmt.Errorf("message is too short")
+		return nil
+	}
+	out := append([]byte{}, r.data[:n]...)
+	r.data = r.data[n:]
+	return out
+}
+
+func (r *reader) element() curves.Point {
+	b := r.bytes(r.conf.elementLength())
+	if r.err != nil {
+		return nil
+	}
+	p, err := r.conf.Suite.DeserializeElement(b)
+	if err != nil {
+		r.err = err
+	}
+	return p
+}
+
+func (r *reader) done() error {
+	if r.err == nil && len(r.data) != 0 {
+		r.err = fmt.Errorf("message is too long")
+	}
+	return r.err
+}
+
+// ParseRegistrationRequest decodes a RegistrationRequest
+func (c *Config) ParseRegistrationRequest(b []byte) (*RegistrationRequest, error) {
+	r := &reader{conf: c, data: b}
+	m := &RegistrationRequest{BlindedMessage: r.element()}
+	return m, r.done()
+}
+
+// ParseRegistrationResponse decodes a RegistrationResponse
+func (c *Config) ParseRegistrationResponse(b []byte) (*RegistrationResponse, error) {
+	r := &reader{conf: c, data: b}
+	m := &RegistrationResponse{EvaluatedMessage: r.element(), ServerPublicKey: r.element()}
+	return m, r.done()
+}
+
+// ParseRegistrationRecord decodes a RegistrationRecord
+func (c *Config) ParseRegistrationRecord(b []byte) (*RegistrationRecord, error) {
+	r := &reader{conf: c, data: b}
+	m := &RegistrationRecord{
+		ClientPublicKey: r.element(),
+		MaskingKey:      r.bytes(c.hashLength()),
+		Envelope:        r.bytes(c.envelopeLength()),
+	}
+	return m, r.done()
+}
+
+// ParseKE1 decodes KE1
+func (c *Config) ParseKE1(b []byte) (*KE1, error) {
+	r := &reader{conf: c, data: b}
+	m := &KE1{
+		BlindedMessage:       r.element(),
+		ClientNonce:          r.bytes(nonceLength),
+		ClientPublicKeyshare: r.element(),
+	}
+	return m, r.done()
+}
+
+// ParseKE2 decodes KE2
+func (c *Config) ParseKE2(b []byte) (*KE2, error) {
+	r := &reader{conf: c, data: b}
+	m := &KE2{
+		EvaluatedMessage:     r.element(),
+		MaskingNonce:         r.bytes(nonceLength),
+		MaskedResponse:       r.bytes(c.elementLength() + c.envelopeLength()),
+		ServerNonce:          r.bytes(nonceLength),
+		ServerPublicKeyshare: r.element(),
+		ServerMAC:            r.bytes(c.hashLength()),
+	}
+	return m, r.done()
+}
+
+// ParseKE3 decodes KE3
+func (c *Config) ParseKE3(b []byte) (*KE3, error) {
+	r := &reader{conf: c, data: b}
+	m := &KE3{ClientMAC: r.bytes(c.hashLength())}
+	return m, r.done()
+}
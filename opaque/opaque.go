@@ -0,0 +1,251 @@
+// Package opaque implements the OPAQUE augmented password authenticated key
+// exchange of RFC 9807 https://www.rfc-editor.org/rfc/rfc9807.html with the
+// 3DH authenticated key exchange, on top of the oprf package.
+//
+// Registration has one round trip:
+//  1. the client calls Client.RegistrationRequest
+//  2. the server answers with Server.RegistrationResponse
+//  3. the client calls Client.FinalizeRegistration and uploads the record
+//
+// Login takes three messages:
+//  1. the client calls Client.GenerateKE1
+//  2. the server loads the record and answers with Server.GenerateKE2
+//  3. the client checks the server with Client.GenerateKE3 and the server
+//     checks the client with ServerLogin.Finish
+//
+// Both sides end with the same session key. The password never leaves the client.
+package opaque
+
+import (
+	"crypto/hmac"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/oprf"
+)
+
+const (
+	nonceLength = 32
+	seedLength  = 32
+)
+
+// Config selects the OPAQUE configuration of RFC 9807 section 7
+type Config struct {
+	// Suite is the OPRF and 3DH group and the hash used by HKDF and HMAC,
+	// oprf.Ristretto255Sha512 or oprf.P256Sha256
+	Suite *oprf.Suite
+	// KSF is the key stretching function applied to the OPRF output. A nil
+	// KSF is the identity, use a memory hard function such as
+	// kdf.Stretch(kdf.DefaultArgon2id, 64) in production.
+	KSF func(msg []byte) ([]byte, error)
+	// Context is bound into the key exchange transcript
+	Context []byte
+}
+
+// DefaultConfig is ristretto255-SHA512 with the identity KSF
+func DefaultConfig() *Config {
+	return &Config{Suite: oprf.Ristretto255Sha512}
+}
+
+func (c *Config) hashLength() int {
+	return c.Suite.Hash().Size()
+}
+
+func (c *Config) elementLength() int {
+	return len(c.Suite.Curve().Point.Generator().ToAffineCompressed())
+}
+
+func (c *Config) envelopeLength() int {
+	return nonceLength + c.hashLength()
+}
+
+func (c *Config) check() error {
+	if c == nil || c.Suite == nil {
+		return internal.ErrNilArguments
+	}
+	return nil
+}
+
+func (c *Config) extract(ikm []byte) []byte {
+	return hkdf.Extract(c.Suite.Hash, ikm, nil)
+}
+
+func (c *Config) expand(prk, info []byte, n int) []byte {
+	out := make([]byte, n)
+	_, _ = io.ReadFull(hkdf.Expand(c.Suite.Hash, prk, info), out)
+	return out
+}
+
+func (c *Config) mac(key []byte, msg ...[]byte) []byte {
+	m := hmac.New(c.Suite.Hash, key)
+	for _, b := range msg {
+		_, _ = m.Write(b)
+	}
+	return m.Sum(nil)
+}
+
+func (c *Config) hash(msg ...[]byte) []byte {
+	h := c.Suite.Hash()
+	for _, b := range msg {
+		_, _ = h.Write(b)
+	}
+	return h.Sum(nil)
+}
+
+// expandLabel is Expand-Label of RFC 9807 section 6.4.2
+func (c *Config) expandLabel(secret []byte, label string, context []byte, n int) []byte {
+	label = "OPAQUE-" + label
+	info := []byte{byte(n >> 8), byte(n), byte(len(label))}
+	info = append(info, label...)
+	info = append(info, byte(len(context)))
+	info = append(info, context...)
+	return c.expand(secret, info, n)
+}
+
+func (c *Config) deriveSecret(secret []byte, label string, transcriptHash []byte) []byte {
+	return c.expandLabel(secret, label, transcriptHash, c.hashLength())
+}
+
+// deriveDiffieHellmanKeyPair is DeriveDiffieHellmanKeyPair of RFC 9807 section 6.4.1
+func (c *Config) deriveDiffieHellmanKeyPair(seed []byte) (curves.Scalar, curves.Point, error) {
+	return c.Suite.DeriveKeyPair(oprf.ModeOPRF, seed, []byte("OPAQUE-DeriveDiffieHellmanKeyPair"))
+}
+
+// GenerateKeyPair draws a server long term key pair from reader
+func (c *Config) GenerateKeyPair(reader io.Reader) (curves.Scalar, curves.Point, error) {
+	if err := c.check(); err != nil {
+		return nil, nil, err
+	}
+	seed, err := random(reader, seedLength)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.deriveDiffieHellmanKeyPair(seed)
+}
+
+// FakeRecord returns a record for a client that is not registered. The server
+// answers KE1 of unknown clients with it so they are indistinguishable from
+// registered clients, see RFC 9807 section 10.9.
+func (c *Config) FakeRecord(reader io.Reader) (*RegistrationRecord, error) {
+	_, pk, err := c.GenerateKeyPair(reader)
+	if err != nil {
+		return nil, err
+	}
+	maskingKey, err := random(reader, c.hashLength())
+	if err != nil {
+		return nil, err
+	}
+	return &RegistrationRecord{
+		ClientPublicKey: pk,
+		MaskingKey:      maskingKey,
+		Envelope:        make([]byte, c.envelopeLength()),
+	}, nil
+}
+
+// cleartextCredentials is CreateCleartextCredentials of RFC 9807 section 4.1.2.
+// It returns the encoded credentials and the identities with their defaults applied.
+func (c *Config) cleartextCredentials(serverPublicKey, clientPublicKey curves.Point, serverIdentity, clientIdentity []byte) ([]byte, []byte, []byte) {
+	if len(serverIdentity) == 0 {
+		serverIdentity = serverPublicKey.ToAffineCompressed()
+	}
+	if len(clientIdentity) == 0 {
+		clientIdentity = clientPublicKey.ToAffineCompressed()
+	}
+	out := serverPublicKey.ToAffineCompressed()
+	out = append(out, lengthPrefixed(serverIdentity)...)
+	out = append(out, lengthPrefixed(clientIdentity)...)
+	return out, serverIdentity, clientIdentity
+}
+
+// envelopeKeys derives the envelope keys of RFC 9807 section 4.1.3
+func (c *Config) envelopeKeys(randomizedPassword, nonce []byte) (authKey, exportKey []byte, sk curves.Scalar, pk curves.Point, err error) {
+	authKey = c.expand(randomizedPassword, append(append([]byte{}, nonce...), "AuthKey"...), c.hashLength())
+	exportKey = c.expand(randomizedPassword, append(append([]byte{}, nonce...), "ExportKey"...), c.hashLength())
+	seed := c.expand(randomizedPassword, append(append([]byte{}, nonce...), "PrivateKey"...), seedLength)
+	sk, pk, err = c.deriveDiffieHellmanKeyPair(seed)
+	return authKey, exportKey, sk, pk, err
+}
+
+// randomizedPassword is Extract("", oprf_output || Stretch(oprf_output))
+func (c *Config) randomizedPassword(oprfOutput []byte) ([]byte, error) {
+	stretched := oprfOutput
+	if c.KSF != nil {
+		var err error
+		if stretched, err = c.KSF(oprfOutput); err != nil {
+			return nil, err
+		}
+	}
+	return c.extract(append(append([]byte{}, oprfOutput...), stretched...)), nil
+}
+
+// oprfKey derives the per client OPRF key of RFC 9807 section 5.2.2
+func (c *Config) oprfKey(oprfSeed, credentialIdentifier []byte) (curves.Scalar, error) {
+	seed := c.expand(oprfSeed, append(append([]byte{}, credentialIdentifier...), "OprfKey"...), seedLength)
+	sk, _, err := c.Suite.DeriveKeyPair(oprf.ModeOPRF, seed, []byte("OPAQUE-DeriveKeyPair"))
+	return sk, err
+}
+
+// preamble is the transcript of RFC 9807 section 6.4.3
+func (c *Config) preamble(clientIdentity []byte, ke1 *KE1, serverIdentity []byte, ke2 *KE2) []byte {
+	out := append([]byte("OPAQUEv1-"), lengthPrefixed(c.Context)...)
+	out = append(out, lengthPrefixed(clientIdentity)...)
+	out = append(out, ke1.Bytes()...)
+	out = append(out, lengthPrefixed(serverIdentity)...)
+	out = append(out, ke2.credentialResponse()...)
+	out = append(out, ke2.ServerNonce...)
+	return append(out, ke2.ServerPublicKeyshare.ToAffineCompressed()...)
+}
+
+// deriveKeys is DeriveKeys of RFC 9807 section 6.4.2
+func (c *Config) deriveKeys(ikm, preamble []byte) (km2, km3, sessionKey []byte) {
+	prk := c.extract(ikm)
+	transcript := c.hash(preamble)
+	handshakeSecret := c.deriveSecret(prk, "HandshakeSecret", transcript)
+	sessionKey = c.deriveSecret(prk, "SessionKey", transcript)
+	km2 = c.deriveSecret(handshakeSecret, "ServerMAC", nil)
+	km3 = c.deriveSecret(handshakeSecret, "ClientMAC", nil)
+	return km2, km3, sessionKey
+}
+
+func dh(sk curves.Scalar, pk ...curves.Point) []byte {
+	var out []byte
+	for _, p := range pk {
+		out = append(out, p.Mul(sk).ToAffineCompressed()...)
+	}
+	return out
+}
+
+func random(reader io.Reader, n int) ([]byte, error) {
+	if reader == nil {
+		return nil, internal.ErrNilArguments
+	}
+	out := make([]byte, n)
+	if _, err := io.ReadFull(reader, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func xor(a, b []byte) []byte {
+	out := make([]byte, len(a))
+	for i := range a {
+		out[i] = a[i] ^ b[i]
+	}
+	return out
+}
+
+// lengthPrefixed is I2OSP(len(b), 2) || b
+func lengthPrefixed(b []byte) []byte {
+	return append([]byte{byte(len(b) >> 8), byte(len(b))}, b...)
+}
+
+func checkLength(b []byte) error {
+	if len(b) > 0xffff {
+		return fmt.Errorf("value is too long")
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+package opaque
+
+import (
+	"bytes"
+	crand "crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/oprf"
+)
+
+type setup struct {
+	conf   *Config
+	server *Server
+	record *RegistrationRecord
+	export []byte
+}
+
+var credentialIdentifier = []byte("user-1234")
+
+func register(t *testing.T, conf *Config, password, serverIdentity, clientIdentity []byte) *setup {
+	sk, _, err := conf.GenerateKeyPair(crand.Reader)
+	require.NoError(t, err)
+	seed := make([]byte, 64)
+	_, err = crand.Read(seed)
+	require.NoError(t, err)
+	server, err := NewServer(conf, sk, seed, serverIdentity)
+	require.NoError(t, err)
+
+	client, err := NewClient(conf, password)
+	require.NoError(t, err)
+	req, err := client.RegistrationRequest(crand.Reader)
+	require.NoError(t, err)
+	req, err = conf.ParseRegistrationRequest(req.Bytes())
+	require.NoError(t, err)
+	resp, err := server.RegistrationResponse(req, credentialIdentifier)
+	require.NoError(t, err)
+	resp, err = conf.ParseRegistrationResponse(resp.Bytes())
+	require.NoError(t, err)
+	record, export, err := client.FinalizeRegistration(resp, serverIdentity, clientIdentity, crand.Reader)
+	require.NoError(t, err)
+	record, err = conf.ParseRegistrationRecord(record.Bytes())
+	require.NoError(t, err)
+	return &setup{conf: conf, server: server, record: record, export: export}
+}
+
+// login runs the login flow through the wire encoding and returns the client error
+func (s *setup) login(t *testing.T, password, serverIdentity, clientIdentity []byte) ([]byte, []byte, *ServerLogin, *KE3, error) {
+	client, err := NewClient(s.conf, password)
+	require.NoError(t, err)
+	ke1, err := client.GenerateKE1(crand.Reader)
+	require.NoError(t, err)
+	ke1, err = s.conf.ParseKE1(ke1.Bytes())
+	require.NoError(t, err)
+	ke2, state, err := s.server.GenerateKE2(ke1, s.record, credentialIdentifier, clientIdentity, crand.Reader)
+	require.NoError(t, err)
+	ke2, err = s.conf.ParseKE2(ke2.Bytes())
+	require.NoError(t, err)
+	ke3, sessionKey, export, err := client.GenerateKE3(ke2, serverIdentity, clientIdentity)
+	return sessionKey, export, state, ke3, err
+}
+
+func TestRegistrationAndLogin(t *testing.T) {
+	for _, conf := range []*Config{DefaultConfig(), {Suite: oprf.P256Sha256, Context: []byte("sonr")}} {
+		password := []byte("CorrectHorseBatteryStaple")
+		s := register(t, conf, password, nil, nil)
+
+		sessionKey, export, state, ke3, err := s.login(t, password, nil, nil)
+		require.NoError(t, err)
+		require.Equal(t, s.export, export)
+		ke3, err = conf.ParseKE3(ke3.Bytes())
+		require.NoError(t, err)
+		serverKey, err := state.Finish(ke3)
+		require.NoError(t, err)
+		require.Equal(t, sessionKey, serverKey)
+		require.Len(t, sessionKey, conf.hashLength())
+
+		// the login state is single use
+		_, err = state.Finish(ke3)
+		require.Error(t, err)
+
+		// each login has a fresh session key
+		again, _, _, _, err := s.login(t, password, nil, nil)
+		require.NoError(t, err)
+		require.NotEqual(t, sessionKey, again)
+	}
+}
+
+func TestIdentities(t *testing.T) {
+	conf := DefaultConfig()
+	password := []byte("password")
+	s := register(t, conf, password, []byte("example.com"), []byte("alice"))
+
+	_, _, state, ke3, err := s.login(t, password, []byte("example.com"), []byte("alice"))
+	require.NoError(t, err)
+	_, err = state.Finish(ke3)
+	require.NoError(t, err)
+
+	_, _, _, _, err = s.login(t, password, []byte("example.com"), []byte("bob"))
+	require.Error(t, err)
+	_, _, _, _, err = s.login(t, password, nil, []byte("alice"))
+	require.Error(t, err)
+}
+
+func TestWrongPassword(t *testing.T) {
+	conf := DefaultConfig()
+	s := register(t, conf, []byte("right"), nil, nil)
+	_, _, _, _, err := s.login(t, []byte("wrong"), nil, nil)
+	require.Error(t, err)
+}
+
+func TestFakeRecord(t *testing.T) {
+	conf := DefaultConfig()
+	s := register(t, conf, []byte("right"), nil, nil)
+	fake, err := conf.FakeRecord(crand.Reader)
+	require.NoError(t, err)
+	require.Len(t, fake.Bytes(), len(s.record.Bytes()))
+	s.record = fake
+	_, _, _, _, err = s.login(t, []byte("right"), nil, nil)
+	require.Error(t, err)
+}
+
+func TestClientMACRejected(t *testing.T) {
+	conf := DefaultConfig()
+	password := []byte("password")
+	s := register(t, conf, password, nil, nil)
+	_, _, state, ke3, err := s.login(t, password, nil, nil)
+	require.NoError(t, err)
+	ke3.ClientMAC[0] ^= 1
+	_, err = state.Finish(ke3)
+	require.Error(t, err)
+}
+
+func TestParseRejectsBadLengths(t *testing.T) {
+	conf := DefaultConfig()
+	s := register(t, conf, []byte("password"), nil, nil)
+	b := s.record.Bytes()
+	_, err := conf.ParseRegistrationRecord(b[1:])
+	require.Error(t, err)
+	_, err = conf.ParseRegistrationRecord(append(b, 0))
+	require.Error(t, err)
+	_, err = conf.ParseKE3(nil)
+	require.Error(t, err)
+}
+
+func unhex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+// blindWith blinds the password of c with a fixed blind instead of a random one
+func blindWith(t *testing.T, c *Client, blind string) {
+	suite := c.conf.Suite
+	r, err := suite.DeserializeScalar(unhex(t, blind))
+	require.NoError(t, err)
+	p, err := suite.Curve().HashToPoint(c.password, []byte("HashToGroup-OPRFV1-\x00-ristretto255-SHA512"))
+	require.NoError(t, err)
+	c.blinded = &oprf.Blinded{Input: c.password, Blind: r, Element: p.Mul(r)}
+}
+
+// RFC 9807 appendix C.1.1, OPAQUE-3DH Real Test Vector 1
+func TestRFC9807Vector(t *testing.T) {
+	conf := &Config{Suite: oprf.Ristretto255Sha512, Context: []byte("OPAQUE-POC")}
+	password := []byte("CorrectHorseBatteryStaple")
+	credentialIdentifier := []byte("1234")
+
+	sk, err := conf.Suite.DeserializeScalar(unhex(t, "47451a85372f8b3537e249d7b54188091fb18edde78094b43e2ba42b5eb89f0d"))
+	require.NoError(t, err)
+	oprfSeed := unhex(t, "f433d0227b0b9dd54f7c4422b600e764e47fb503f1f9a0f0a47c6606b054a7fdc65347f1a08f277e22358bbabe26f823fca82c7848e9a75661f4ec5d5c1989ef")
+	server, err := NewServer(conf, sk, oprfSeed, nil)
+	require.NoError(t, err)
+	require.Equal(t, unhex(t, "b2fe7af9f48cc502d016729d2fe25cdd433f2c4bc904660b2a382c9b79df1a78"), server.PublicKey().ToAffineCompressed())
+
+	// registration
+	client, err := NewClient(conf, password)
+	require.NoError(t, err)
+	blindWith(t, client, "76cfbfe758db884bebb33582331ba9f159720ca8784a2a070a265d9c2d6abe01")
+	req := &RegistrationRequest{BlindedMessage: client.blinded.Element}
+	require.Equal(t, unhex(t, "5059ff249eb1551b7ce4991f3336205bde44a105a032e747d21bf382e75f7a71"), req.Bytes())
+	resp, err := server.RegistrationResponse(req, credentialIdentifier)
+	require.NoError(t, err)
+	require.Equal(t, unhex(t, "7408a268083e03abc7097fc05b587834539065e86fb0c7b6342fcf5e01e5b019"+
+		"b2fe7af9f48cc502d016729d2fe25cdd433f2c4bc904660b2a382c9b79df1a78"), resp.Bytes())
+	envelopeNonce := unhex(t, "ac13171b2f17bc2c74997f0fce1e1f35bec6b91fe2e12dbd323d23ba7a38dfec")
+	record, export, err := client.FinalizeRegistration(resp, nil, nil, bytes.NewReader(envelopeNonce))
+	require.NoError(t, err)
+	require.Equal(t, unhex(t, "76a845464c68a5d2f7e442436bb1424953b17d3e2e289ccbaccafb57ac5c3675"+
+		"1ac5844383c7708077dea41cbefe2fa15724f449e535dd7dd562e66f5ecfb95864eadddec9db5874959905117dad40a4524111849799281fefe3c51fa82785c5"+
+		"ac13171b2f17bc2c74997f0fce1e1f35bec6b91fe2e12dbd323d23ba7a38dfec634b0f5b96109c198a8027da51854c35bee90d1e1c781806d07d49b76de6a28b8d9e9b6c93b9f8b64d16dddd9c5bfb5fea48ee8fd2f75012a8b308605cdd8ba5"), record.Bytes())
+	expectedExport := unhex(t, "1ef15b4fa99e8a852412450ab78713aad30d21fa6966c9b8c9fb3262a970dc62950d4dd4ed62598229b1b72794fc0335199d9f7fcc6eaedde92cc04870e63f16")
+	require.Equal(t, expectedExport, export)
+
+	// login
+	client, err = NewClient(conf, password)
+	require.NoError(t, err)
+	blindWith(t, client, "6ecc102d2e7a7cf49617aad7bbe188556792d4acd60a1a8a8d2b65d4b0790308")
+	clientNonce := unhex(t, "da7e07376d6d6f034cfa9bb537d11b8c6b4238c334333d1f0aebb380cae6a6cc")
+	clientKeyshareSeed := unhex(t, "82850a697b42a505f5b68fcdafce8c31f0af2b581f063cf1091933541936304b")
+	ke1, err := client.generateKE1(bytes.NewReader(append(clientNonce, clientKeyshareSeed...)))
+	require.NoError(t, err)
+	require.Equal(t, unhex(t, "c4dedb0ba6ed5d965d6f250fbe554cd45cba5dfcce3ce836e4aee778aa3cd44d"+
+		"da7e07376d6d6f034cfa9bb537d11b8c6b4238c334333d1f0aebb380cae6a6cc"+
+		"6e29bee50701498605b2c085d7b241ca15ba5c32027dd21ba420b94ce60da326"), ke1.Bytes())
+
+	maskingNonce := unhex(t, "38fe59af0df2c79f57b8780278f5ae47355fe1f817119041951c80f612fdfc6d")
+	serverNonce := unhex(t, "71cd9960ecef2fe0d0f7494986fa3d8b2bb01963537e60efb13981e138e3d4a1")
+	serverKeyshareSeed := unhex(t, "05a4f54206eef1ba2f615bc0aa285cb22f26d1153b5b40a1e85ff80da12f982f")
+	ke2, state, err := server.GenerateKE2(ke1, record, credentialIdentifier, nil,
+		bytes.NewReader(append(append(maskingNonce, serverNonce...), serverKeyshareSeed...)))
+	require.NoError(t, err)
+	ke3, sessionKey, export, err := client.GenerateKE3(ke2, nil, nil)
+	require.NoError(t, err)
+	expectedSessionKey := unhex(t, "42afde6f5aca0cfa5c163763fbad55e73a41db6b41bc87b8e7b62214a8eedc6731fa3cb857d657ab9b3764b89a84e91ebcb4785166fbb02cedfcbdfda215b96f")
+	require.Equal(t, expectedSessionKey, sessionKey)
+	require.Equal(t, expectedExport, export)
+	serverKey, err := state.Finish(ke3)
+	require.NoError(t, err)
+	require.Equal(t, expectedSessionKey, serverKey)
+}
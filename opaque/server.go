@@ -0,0 +1,167 @@
+package opaque
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"io"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/oprf"
+)
+
+// Server holds the long term server keys
+type Server struct {
+	conf       *Config
+	privateKey curves.Scalar
+	publicKey  curves.Point
+	oprfSeed   []byte
+	identity   []byte
+}
+
+// ServerLogin is the server state of one login between GenerateKE2 and Finish
+type ServerLogin struct {
+	expectedClientMAC []byte
+	sessionKey        []byte
+}
+
+// NewServer creates a server with its key pair, a secret seed of at least 32
+// bytes for the per client OPRF keys and an optional identity that
+// defaults to the public key
+func NewServer(conf *Config, privateKey curves.Scalar, oprfSeed, identity []byte) (*Server, error) {
+	if err := conf.check(); err != nil {
+		return nil, err
+	}
+	if privateKey == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if privateKey.IsZero() {
+		return nil, internal.ErrZeroValue
+	}
+	if len(oprfSeed) < seedLength {
+		return nil, fmt.Errorf("oprf seed must be at least %d bytes", seedLength)
+	}
+	if err := checkLength(identity); err != nil {
+		return nil, err
+	}
+	return &Server{
+		conf:       conf,
+		privateKey: privateKey,
+		publicKey:  conf.Suite.Curve().ScalarBaseMult(privateKey),
+		oprfSeed:   append([]byte{}, oprfSeed...),
+		identity:   append([]byte{}, identity...),
+	}, nil
+}
+
+// PublicKey returns the server public key
+func (s *Server) PublicKey() curves.Point {
+	return s.publicKey
+}
+
+// RegistrationResponse evaluates the blinded password of req, see RFC 9807
+// section 5.2.2. The credential identifier is a unique, stable identifier
+// of the client account.
+func (s *Server) RegistrationResponse(req *RegistrationRequest, credentialIdentifier []byte) (*RegistrationResponse, error) {
+	if req == nil || req.BlindedMessage == nil {
+		return nil, internal.ErrNilArguments
+	}
+	evaluated, err := s.evaluate(req.BlindedMessage, credentialIdentifier)
+	if err != nil {
+		return nil, err
+	}
+	return &RegistrationResponse{EvaluatedMessage: evaluated, ServerPublicKey: s.publicKey}, nil
+}
+
+// GenerateKE2 answers ke1 with the stored record of the client, see RFC 9807
+// section 6.2.2. Unknown clients get a record from Config.FakeRecord. The
+// client identity must match the one used at registration.
+func (s *Server) GenerateKE2(ke1 *KE1, record *RegistrationRecord, credentialIdentifier, clientIdentity []byte, reader io.Reader) (*KE2, *ServerLogin, error) {
+	if ke1 == nil || ke1.BlindedMessage == nil || ke1.ClientPublicKeyshare == nil || record == nil || record.ClientPublicKey == nil {
+		return nil, nil, internal.ErrNilArguments
+	}
+	conf := s.conf
+	if len(ke1.ClientNonce) != nonceLength || len(record.MaskingKey) != conf.hashLength() || len(record.Envelope) != conf.envelopeLength() {
+		return nil, nil, fmt.Errorf("invalid message length")
+	}
+	if err := checkLength(clientIdentity); err != nil {
+		return nil, nil, err
+	}
+
+	// credential response
+	evaluated, err := s.evaluate(ke1.BlindedMessage, credentialIdentifier)
+	if err != nil {
+		return nil, nil, err
+	}
+	maskingNonce, err := random(reader, nonceLength)
+	if err != nil {
+		return nil, nil, err
+	}
+	plain := append(s.publicKey.ToAffineCompressed(), record.Envelope...)
+	pad := conf.expand(record.MaskingKey, append(append([]byte{}, maskingNonce...), "CredentialResponsePad"...), len(plain))
+
+	// auth response
+	serverNonce, err := random(reader, nonceLength)
+	if err != nil {
+		return nil, nil, err
+	}
+	seed, err := random(reader, seedLength)
+	if err != nil {
+		return nil, nil, err
+	}
+	secret, keyshare, err := conf.deriveDiffieHellmanKeyPair(seed)
+	if err != nil {
+		return nil, nil, err
+	}
+	ke2 := &KE2{
+		EvaluatedMessage:     evaluated,
+		MaskingNonce:         maskingNonce,
+		MaskedResponse:       xor(pad, plain),
+		ServerNonce:          serverNonce,
+		ServerPublicKeyshare: keyshare,
+	}
+
+	_, serverIdentity, clientIdentity := conf.cleartextCredentials(s.publicKey, record.ClientPublicKey, s.identity, clientIdentity)
+	preamble := conf.preamble(clientIdentity, ke1, serverIdentity, ke2)
+	ikm := dh(secret, ke1.ClientPublicKeyshare)
+	ikm = append(ikm, dh(s.privateKey, ke1.ClientPublicKeyshare)...)
+	ikm = append(ikm, dh(secret, record.ClientPublicKey)...)
+	km2, km3, sessionKey := conf.deriveKeys(ikm, preamble)
+	ke2.ServerMAC = conf.mac(km2, conf.hash(preamble))
+
+	return ke2, &ServerLogin{
+		expectedClientMAC: conf.mac(km3, conf.hash(preamble, ke2.ServerMAC)),
+		sessionKey:        sessionKey,
+	}, nil
+}
+
+// Finish authenticates the client with ke3 and returns the session key, see RFC 9807 section 6.2.3
+func (l *ServerLogin) Finish(ke3 *KE3) ([]byte, error) {
+	if ke3 == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if l.sessionKey == nil {
+		return nil, internal.ErrInvalidRound
+	}
+	expected, key := l.expectedClientMAC, l.sessionKey
+	l.expectedClientMAC, l.sessionKey = nil, nil
+	if subtle.ConstantTimeCompare(expected, ke3.ClientMAC) != 1 {
+		return nil, fmt.Errorf("client authentication failed")
+	}
+	return key, nil
+}
+
+func (s *Server) evaluate(blinded curves.Point, credentialIdentifier []byte) (curves.Point, error) {
+	key, err := s.conf.oprfKey(s.oprfSeed, credentialIdentifier)
+	if err != nil {
+		return nil, err
+	}
+	server, err := oprf.NewServer(s.conf.Suite, oprf.ModeOPRF, key)
+	if err != nil {
+		return nil, err
+	}
+	eval, err := server.BlindEvaluate([]curves.Point{blinded}, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return eval.Elements[0], nil
+}
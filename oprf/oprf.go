@@ -0,0 +1,287 @@
+// Package oprf implements the oblivious pseudorandom functions of RFC 9497
+// https://www.rfc-editor.org/rfc/rfc9497.html over ristretto255 and P-256.
+//
+// A Client blinds its input and sends the blinded element to the Server, which
+// evaluates it with its secret key without learning the input. The client
+// unblinds the result with Finalize. Three modes are supported:
+//   - ModeOPRF: the base protocol
+//   - ModeVOPRF: the server proves the evaluation used the key behind its public key
+//   - ModePOPRF: like ModeVOPRF with a public info string bound into the output
+//
+// Several inputs can be evaluated in one BlindEvaluate call with a single proof.
+package oprf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/internal"
+)
+
+// Blinded is the client state for one input between Blind and Finalize.
+// Only Element is sent to the server.
+type Blinded struct {
+	Input   []byte
+	Info    []byte
+	Blind   curves.Scalar
+	Element curves.Point
+	// tweakedKey is the POPRF key pkS + m*G the proof is checked against
+	tweakedKey curves.Point
+}
+
+// Evaluation is the server response to BlindEvaluate
+type Evaluation struct {
+	Elements []curves.Point
+	// Proof is nil in ModeOPRF
+	Proof *Proof
+}
+
+// Client runs the client side of the protocol
+type Client struct {
+	suite *Suite
+	mode  Mode
+	pk    curves.Point
+}
+
+// Server runs the server side of the protocol
+type Server struct {
+	suite *Suite
+	mode  Mode
+	sk    curves.Scalar
+	pk    curves.Point
+}
+
+func checkMode(mode Mode) error {
+	if mode != ModeOPRF && mode != ModeVOPRF && mode != ModePOPRF {
+		return fmt.Errorf("unknown mode %d", mode)
+	}
+	return nil
+}
+
+// NewClient creates a client. The server public key pk is required in
+// ModeVOPRF and ModePOPRF and ignored in ModeOPRF.
+func NewClient(suite *Suite, mode Mode, pk curves.Point) (*Client, error) {
+	if suite == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if err := checkMode(mode); err != nil {
+		return nil, err
+	}
+	if mode != ModeOPRF && (pk == nil || pk.IsIdentity()) {
+		return nil, fmt.Errorf("a valid server public key is required in verifiable modes")
+	}
+	return &Client{suite: suite, mode: mode, pk: pk}, nil
+}
+
+// NewServer creates a server with secret key sk
+func NewServer(suite *Suite, mode Mode, sk curves.Scalar) (*Server, error) {
+	if suite == nil || sk == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if err := checkMode(mode); err != nil {
+		return nil, err
+	}
+	if sk.IsZero() {
+		return nil, internal.ErrZeroValue
+	}
+	return &Server{suite: suite, mode: mode, sk: sk, pk: suite.curve.ScalarBaseMult(sk)}, nil
+}
+
+// PublicKey returns the server public key
+func (s *Server) PublicKey() curves.Point {
+	return s.pk
+}
+
+// Blind blinds input with a random scalar drawn from reader. The public
+// info is only used in ModePOPRF and must be empty otherwise.
+func (c *Client) Blind(input, info []byte, reader io.Reader) (*Blinded, error) {
+	blind, err := c.suite.randomScalar(reader)
+	if err != nil {
+		return nil, err
+	}
+	return c.blind(input, info, blind)
+}
+
+func (c *Client) blind(input, info []byte, blind curves.Scalar) (*Blinded, error) {
+	if len(input) > 0xffff || len(info) > 0xffff {
+		return nil, fmt.Errorf("input is too long")
+	}
+	if c.mode != ModePOPRF && len(info) > 0 {
+		return nil, fmt.Errorf("info is only used in POPRF mode")
+	}
+	result := &Blinded{
+		Input: append([]byte{}, input...),
+		Info:  append([]byte{}, info...),
+		Blind: blind,
+	}
+	if c.mode == ModePOPRF {
+		m := c.suite.hashToScalar(c.mode, framedInfo(info), nil)
+		result.tweakedKey = c.suite.curve.ScalarBaseMult(m).Add(c.pk)
+		if result.tweakedKey.IsIdentity() {
+			return nil, fmt.Errorf("invalid input")
+		}
+	}
+	element, err := c.suite.hashToGroup(c.mode, input)
+	if err != nil {
+		return nil, err
+	}
+	if element.IsIdentity() {
+		return nil, fmt.Errorf("invalid input")
+	}
+	result.Element = element.Mul(blind)
+	return result, nil
+}
+
+// Finalize verifies the proof in verifiable modes, unblinds the evaluated
+// elements and returns one output per blinded input, see RFC 9497 section 3.3
+func (c *Client) Finalize(blinded []*Blinded, eval *Evaluation) ([][]byte, error) {
+	if eval == nil || len(blinded) == 0 {
+		return nil, internal.ErrNilArguments
+	}
+	if len(blinded) != len(eval.Elements) {
+		return nil, internal.ErrIncorrectCount
+	}
+	blindedElements := make([]curves.Point, len(blinded))
+	for i, b := range blinded {
+		if b == nil || b.Blind == nil || b.Element == nil || eval.Elements[i] == nil {
+			return nil, internal.ErrNilArguments
+		}
+		if c.mode == ModePOPRF && !bytes.Equal(b.Info, blinded[0].Info) {
+			return nil, fmt.Errorf("all inputs of a batch must use the same info")
+		}
+		blindedElements[i] = b.Element
+	}
+
+	switch c.mode {
+	case ModeVOPRF:
+		if err := c.suite.verifyProof(c.mode, c.pk, blindedElements, eval.Elements, eval.Proof); err != nil {
+			return nil, err
+		}
+	case ModePOPRF:
+		if err := c.suite.verifyProof(c.mode, blinded[0].tweakedKey, eval.Elements, blindedElements, eval.Proof); err != nil {
+			return nil, err
+		}
+	}
+
+	outputs := make([][]byte, len(blinded))
+	for i, b := range blinded {
+		inv, err := b.Blind.Invert()
+		if err != nil {
+			return nil, err
+		}
+		outputs[i] = c.suite.finalizeHash(c.mode, b.Input, b.Info, eval.Elements[i].Mul(inv))
+	}
+	return outputs, nil
+}
+
+// BlindEvaluate evaluates the blinded elements, see RFC 9497 section 3.3.
+// The proof randomness is drawn from reader, info is only used in ModePOPRF.
+func (s *Server) BlindEvaluate(blinded []curves.Point, info []byte, reader io.Reader) (*Evaluation, error) {
+	var r curves.Scalar
+	if s.mode != ModeOPRF {
+		var err error
+		if r, err = s.suite.randomScalar(reader); err != nil {
+			return nil, err
+		}
+	}
+	return s.blindEvaluate(blinded, info, r)
+}
+
+func (s *Server) blindEvaluate(blinded []curves.Point, info []byte, r curves.Scalar) (*Evaluation, error) {
+	if len(blinded) == 0 {
+		return nil, internal.ErrNilArguments
+	}
+	for _, b := range blinded {
+		if b == nil || b.IsIdentity() {
+			return nil, fmt.Errorf("invalid blinded element")
+		}
+	}
+	if s.mode != ModePOPRF && len(info) > 0 {
+		return nil, fmt.Errorf("info is only used in POPRF mode")
+	}
+
+	eval := &Evaluation{Elements: make([]curves.Point, len(blinded))}
+	switch s.mode {
+	case ModeOPRF, ModeVOPRF:
+		for i, b := range blinded {
+			eval.Elements[i] = b.Mul(s.sk)
+		}
+		if s.mode == ModeVOPRF {
+			eval.Proof = s.suite.generateProof(s.mode, s.sk, s.pk, blinded, eval.Elements, r)
+		}
+	case ModePOPRF:
+		t, err := s.tweakedKey(info)
+		if err != nil {
+			return nil, err
+		}
+		tInv, err := t.Invert()
+		if err != nil {
+			return nil, err
+		}
+		for i, b := range blinded {
+			eval.Elements[i] = b.Mul(tInv)
+		}
+		eval.Proof = s.suite.generateProof(s.mode, t, s.suite.curve.ScalarBaseMult(t), eval.Elements, blinded, r)
+	}
+	return eval, nil
+}
+
+// Evaluate computes the output for input directly, as Finalize would for the
+// same input after a protocol run, see RFC 9497 section 3.3
+func (s *Server) Evaluate(input, info []byte) ([]byte, error) {
+	if len(input) > 0xffff || len(info) > 0xffff {
+		return nil, fmt.Errorf("input is too long")
+	}
+	if s.mode != ModePOPRF && len(info) > 0 {
+		return nil, fmt.Errorf("info is only used in POPRF mode")
+	}
+	element, err := s.suite.hashToGroup(s.mode, input)
+	if err != nil {
+		return nil, err
+	}
+	if element.IsIdentity() {
+		return nil, fmt.Errorf("invalid input")
+	}
+	k := s.sk
+	if s.mode == ModePOPRF {
+		t, err := s.tweakedKey(info)
+		if err != nil {
+			return nil, err
+		}
+		if k, err = t.Invert(); err != nil {
+			return nil, err
+		}
+	}
+	return s.suite.finalizeHash(s.mode, input, info, element.Mul(k)), nil
+}
+
+// tweakedKey is t = skS + HashToScalar(framedInfo)
+func (s *Server) tweakedKey(info []byte) (curves.Scalar, error) {
+	if len(info) > 0xffff {
+		return nil, fmt.Errorf("info is too long")
+	}
+	t := s.sk.Add(s.suite.hashToScalar(s.mode, framedInfo(info), nil))
+	if t.IsZero() {
+		return nil, fmt.Errorf("inverse error")
+	}
+	return t, nil
+}
+
+// finalizeHash hashes the unblinded element into the protocol output
+func (s *Suite) finalizeHash(mode Mode, input, info []byte, element curves.Point) []byte {
+	h := s.hash()
+	_, _ = h.Write(lengthPrefixed(input))
+	if mode == ModePOPRF {
+		_, _ = h.Write(lengthPrefixed(info))
+	}
+	_, _ = h.Write(lengthPrefixed(s.SerializeElement(element)))
+	_, _ = h.Write([]byte("Finalize"))
+	return h.Sum(nil)
+}
+
+// framedInfo is "Info" || I2OSP(len(info), 2) || info
+func framedInfo(info []byte) []byte {
+	return append([]byte("Info"), lengthPrefixed(info)...)
+}
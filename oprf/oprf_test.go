@@ -0,0 +1,173 @@
+package oprf
+
+import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+)
+
+func unhex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+func TestDeriveKeyPair(t *testing.T) {
+	// RFC 9497 appendix A
+	seed := unhex(t, "a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3")
+	info := unhex(t, "74657374206b6579")
+	for _, test := range []struct {
+		suite *Suite
+		mode  Mode
+		sk    string
+	}{
+		{Ristretto255Sha512, ModeOPRF, "5ebcea5ee37023ccb9fc2d2019f9d7737be85591ae8652ffa9ef0f4d37063b0e"},
+		{Ristretto255Sha512, ModeVOPRF, "e6f73f344b79b379f1a0dd37e07ff62e38d9f71345ce62ae3a9bc60b04ccd909"},
+		{Ristretto255Sha512, ModePOPRF, "145c79c108538421ac164ecbe131942136d5570b16d8bf41a24d4337da981e07"},
+		{P256Sha256, ModeOPRF, "159749d750713afe245d2d39ccfaae8381c53ce92d098a9375ee70739c7ac0bf"},
+	} {
+		sk, pk, err := test.suite.DeriveKeyPair(test.mode, seed, info)
+		require.NoError(t, err)
+		require.Equal(t, test.sk, hex.EncodeToString(test.suite.SerializeScalar(sk)))
+		require.True(t, test.suite.Curve().ScalarBaseMult(sk).Equal(pk))
+	}
+}
+
+func TestOPRFVector(t *testing.T) {
+	// RFC 9497 appendix A.1.1 test vector 1
+	suite := Ristretto255Sha512
+	sk, err := suite.DeserializeScalar(unhex(t, "5ebcea5ee37023ccb9fc2d2019f9d7737be85591ae8652ffa9ef0f4d37063b0e"))
+	require.NoError(t, err)
+	blind, err := suite.DeserializeScalar(unhex(t, "64d37aed22a27f5191de1c1d69fadb899d8862b58eb4220029e036ec4c1f6706"))
+	require.NoError(t, err)
+
+	client, err := NewClient(suite, ModeOPRF, nil)
+	require.NoError(t, err)
+	server, err := NewServer(suite, ModeOPRF, sk)
+	require.NoError(t, err)
+
+	b, err := client.blind([]byte{0x00}, nil, blind)
+	require.NoError(t, err)
+	require.Equal(t, "609a0ae68c15a3cf6903766461307e5c8bb2f95e7e6550e1ffa2dc99e412803c", hex.EncodeToString(suite.SerializeElement(b.Element)))
+	eval, err := server.BlindEvaluate([]curves.Point{b.Element}, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, "7ec6578ae5120958eb2db1745758ff379e77cb64fe77b0b2d8cc917ea0869c7e", hex.EncodeToString(suite.SerializeElement(eval.Elements[0])))
+	out, err := client.Finalize([]*Blinded{b}, eval)
+	require.NoError(t, err)
+	require.Equal(t, "527759c3d9366f277d8c6020418d96bb393ba2afb20ff90df23fb7708264e2f3ab9135e3bd69955851de4b1f9fe8a0973396719b7912ba9ee8aa7d0b5e24bcf6", hex.EncodeToString(out[0]))
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, suite := range []*Suite{Ristretto255Sha512, P256Sha256} {
+		for _, mode := range []Mode{ModeOPRF, ModeVOPRF, ModePOPRF} {
+			sk := suite.Curve().Scalar.Random(crand.Reader)
+			server, err := NewServer(suite, mode, sk)
+			require.NoError(t, err)
+			client, err := NewClient(suite, mode, server.PublicKey())
+			require.NoError(t, err)
+
+			var info []byte
+			if mode == ModePOPRF {
+				info = []byte("public info")
+			}
+			inputs := [][]byte{[]byte("alice@example.com"), []byte("bob@example.com")}
+			blinded := make([]*Blinded, len(inputs))
+			elements := make([]curves.Point, len(inputs))
+			for i, input := range inputs {
+				blinded[i], err = client.Blind(input, info, crand.Reader)
+				require.NoError(t, err)
+				elements[i] = blinded[i].Element
+			}
+			eval, err := server.BlindEvaluate(elements, info, crand.Reader)
+			require.NoError(t, err)
+			outputs, err := client.Finalize(blinded, eval)
+			require.NoError(t, err)
+			for i, input := range inputs {
+				direct, err := server.Evaluate(input, info)
+				require.NoError(t, err)
+				require.Equal(t, direct, outputs[i])
+			}
+			require.NotEqual(t, outputs[0], outputs[1])
+
+			if mode == ModeOPRF {
+				continue
+			}
+			// a proof for another key is rejected
+			other, err := NewServer(suite, mode, suite.Curve().Scalar.Random(crand.Reader))
+			require.NoError(t, err)
+			forged, err := other.BlindEvaluate(elements, info, crand.Reader)
+			require.NoError(t, err)
+			_, err = client.Finalize(blinded, forged)
+			require.Error(t, err)
+
+			// the proof covers every element of the batch
+			eval.Elements[0], eval.Elements[1] = eval.Elements[1], eval.Elements[0]
+			_, err = client.Finalize(blinded, eval)
+			require.Error(t, err)
+
+			proof, err := suite.ProofFromBytes(forged.Proof.Bytes())
+			require.NoError(t, err)
+			require.Equal(t, 0, proof.C.Cmp(forged.Proof.C))
+			require.Equal(t, 0, proof.S.Cmp(forged.Proof.S))
+		}
+	}
+}
+
+func TestVOPRFVector(t *testing.T) {
+	// RFC 9497 appendix A.1.2 test vector 1
+	suite := Ristretto255Sha512
+	sk, err := suite.DeserializeScalar(unhex(t, "e6f73f344b79b379f1a0dd37e07ff62e38d9f71345ce62ae3a9bc60b04ccd909"))
+	require.NoError(t, err)
+	blind, err := suite.DeserializeScalar(unhex(t, "64d37aed22a27f5191de1c1d69fadb899d8862b58eb4220029e036ec4c1f6706"))
+	require.NoError(t, err)
+	r, err := suite.DeserializeScalar(unhex(t, "222a5e897cf59db8145db8d16e597e8facb80ae7d4e26d9881aa6f61d645fc0e"))
+	require.NoError(t, err)
+
+	server, err := NewServer(suite, ModeVOPRF, sk)
+	require.NoError(t, err)
+	client, err := NewClient(suite, ModeVOPRF, server.PublicKey())
+	require.NoError(t, err)
+
+	b, err := client.blind([]byte{0x00}, nil, blind)
+	require.NoError(t, err)
+	require.Equal(t, "863f330cc1a1259ed5a5998a23acfd37fb4351a793a5b3c090b642ddc439b945", hex.EncodeToString(suite.SerializeElement(b.Element)))
+	eval, err := server.blindEvaluate([]curves.Point{b.Element}, nil, r)
+	require.NoError(t, err)
+	require.Equal(t, "aa8fa048764d5623868679402ff6108d2521884fa138cd7f9c7669a9a014267e", hex.EncodeToString(suite.SerializeElement(eval.Elements[0])))
+	require.Equal(t, "ddef93772692e535d1a53903db24367355cc2cc78de93b3be5a8ffcc6985dd066d4346421d17bf5117a2a1ff0fcb2a759f58a539dfbe857a40bce4cf49ec600d", hex.EncodeToString(eval.Proof.Bytes()))
+	out, err := client.Finalize([]*Blinded{b}, eval)
+	require.NoError(t, err)
+	require.Equal(t, "b58cfbe118e0cb94d79b5fd6a6dafb98764dff49c14e1770b566e42402da1a7da4d8527693914139caee5bd03903af43a491351d23b430948dd50cde10d32b3c", hex.EncodeToString(out[0]))
+}
+
+func TestPOPRFVector(t *testing.T) {
+	// RFC 9497 appendix A.1.3 test vector 1
+	suite := Ristretto255Sha512
+	sk, err := suite.DeserializeScalar(unhex(t, "145c79c108538421ac164ecbe131942136d5570b16d8bf41a24d4337da981e07"))
+	require.NoError(t, err)
+	blind, err := suite.DeserializeScalar(unhex(t, "64d37aed22a27f5191de1c1d69fadb899d8862b58eb4220029e036ec4c1f6706"))
+	require.NoError(t, err)
+	r, err := suite.DeserializeScalar(unhex(t, "222a5e897cf59db8145db8d16e597e8facb80ae7d4e26d9881aa6f61d645fc0e"))
+	require.NoError(t, err)
+	info := unhex(t, "7465737420696e666f")
+
+	server, err := NewServer(suite, ModePOPRF, sk)
+	require.NoError(t, err)
+	client, err := NewClient(suite, ModePOPRF, server.PublicKey())
+	require.NoError(t, err)
+
+	b, err := client.blind([]byte{0x00}, info, blind)
+	require.NoError(t, err)
+	require.Equal(t, "c8713aa89241d6989ac142f22dba30596db635c772cbf25021fdd8f3d461f715", hex.EncodeToString(suite.SerializeElement(b.Element)))
+	eval, err := server.blindEvaluate([]curves.Point{b.Element}, info, r)
+	require.NoError(t, err)
+	require.Equal(t, "1a4b860d808ff19624731e67b5eff20ceb2df3c3c03b906f5693e2078450d874", hex.EncodeToString(suite.SerializeElement(eval.Elements[0])))
+	require.Equal(t, "41ad1a291aa02c80b0915fbfbb0c0afa15a57e2970067a602ddb9e8fd6b7100de32e1ecff943a36f0b10e3dae6bd266cdeb8adf825d86ef27dbc6c0e30c52206", hex.EncodeToString(eval.Proof.Bytes()))
+	out, err := client.Finalize([]*Blinded{b}, eval)
+	require.NoError(t, err)
+	require.Equal(t, "ca688351e88afb1d841fde4401c79efebb2eb75e7998fa9737bd5a82a152406d38bd29f680504e54fd4587eddcf2f37a2617ac2fbd2993f7bdf45442ace7d221", hex.EncodeToString(out[0]))
+}
@@ -0,0 +1,108 @@
+package oprf
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/internal"
+)
+
+// Proof is the discrete log equivalence proof of RFC 9497 section 2.2
+type Proof struct {
+	C curves.Scalar
+	S curves.Scalar
+}
+
+// Bytes serializes the proof as SerializeScalar(c) || SerializeScalar(s)
+func (p *Proof) Bytes() []byte {
+	return append(p.C.Bytes(), p.S.Bytes()...)
+}
+
+// ProofFromBytes decodes a proof serialized with Bytes
+func (s *Suite) ProofFromBytes(b []byte) (*Proof, error) {
+	n := s.scalarLength()
+	if len(b) != 2*n {
+		return nil, fmt.Errorf("invalid proof length")
+	}
+	c, err := s.DeserializeScalar(b[:n])
+	if err != nil {
+		return nil, fmt.Errorf("invalid proof: %w", err)
+	}
+	sc, err := s.DeserializeScalar(b[n:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid proof: %w", err)
+	}
+	return &Proof{C: c, S: sc}, nil
+}
+
+// generateProof proves that k is the discrete log of B with respect to the
+// generator and of every D[i] with respect to C[i], see RFC 9497 section 2.2.1
+func (s *Suite) generateProof(mode Mode, k curves.Scalar, b curves.Point, c, d []curves.Point, r curves.Scalar) *Proof {
+	m, z := s.computeComposites(mode, k, b, c, d)
+	t2 := s.curve.ScalarBaseMult(r)
+	t3 := m.Mul(r)
+	ch := s.challenge(mode, b, m, z, t2, t3)
+	return &Proof{C: ch, S: r.Sub(ch.Mul(k))}
+}
+
+// verifyProof is VerifyProof of RFC 9497 section 2.2.2
+func (s *Suite) verifyProof(mode Mode, b curves.Point, c, d []curves.Point, proof *Proof) error {
+	if proof == nil || proof.C == nil || proof.S == nil {
+		return fmt.Errorf("missing proof")
+	}
+	m, z := s.computeComposites(mode, nil, b, c, d)
+	t2 := s.curve.ScalarBaseMult(proof.S).Add(b.Mul(proof.C))
+	t3 := m.Mul(proof.S).Add(z.Mul(proof.C))
+	if s.challenge(mode, b, m, z, t2, t3).Cmp(proof.C) != 0 {
+		return fmt.Errorf("invalid proof")
+	}
+	return nil
+}
+
+func (s *Suite) challenge(mode Mode, points ...curves.Point) curves.Scalar {
+	var transcript []byte
+	for _, p := range points {
+		transcript = append(transcript, lengthPrefixed(s.SerializeElement(p))...)
+	}
+	return s.hashToScalar(mode, append(transcript, "Challenge"...), nil)
+}
+
+// computeComposites is ComputeCompositesFast of RFC 9497 section 2.2.1 when
+// k is set, and ComputeComposites of section 2.2.2 otherwise
+func (s *Suite) computeComposites(mode Mode, k curves.Scalar, b curves.Point, c, d []curves.Point) (curves.Point, curves.Point) {
+	seedDST := append([]byte("Seed-"), s.contextString(mode)...)
+	h := s.hash()
+	_, _ = h.Write(lengthPrefixed(s.SerializeElement(b)))
+	_, _ = h.Write(lengthPrefixed(seedDST))
+	seed := lengthPrefixed(h.Sum(nil))
+
+	m := s.curve.NewIdentityPoint()
+	z := s.curve.NewIdentityPoint()
+	for i := range c {
+		transcript := append([]byte{}, seed...)
+		transcript = append(transcript, byte(i>>8), byte(i))
+		transcript = append(transcript, lengthPrefixed(s.SerializeElement(c[i]))...)
+		transcript = append(transcript, lengthPrefixed(s.SerializeElement(d[i]))...)
+		di := s.hashToScalar(mode, append(transcript, "Composite"...), nil)
+		m = c[i].Mul(di).Add(m)
+		if k == nil {
+			z = d[i].Mul(di).Add(z)
+		}
+	}
+	if k != nil {
+		z = m.Mul(k)
+	}
+	return m, z
+}
+
+func (s *Suite) randomScalar(reader io.Reader) (curves.Scalar, error) {
+	if reader == nil {
+		return nil, internal.ErrNilArguments
+	}
+	k := s.curve.Scalar.Random(reader)
+	if k == nil || k.IsZero() {
+		return nil, internal.ErrZeroValue
+	}
+	return k, nil
+}
@@ -0,0 +1,149 @@
+package oprf
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"hash"
+	"math/big"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/curves/native"
+)
+
+// Mode is the protocol variant of RFC 9497 section 3
+type Mode byte
+
+const (
+	// ModeOPRF is the base mode without proofs
+	ModeOPRF Mode = 0x00
+	// ModeVOPRF lets the client verify the output was computed with the server's public key
+	ModeVOPRF Mode = 0x01
+	// ModePOPRF is VOPRF with a public input shared by client and server
+	ModePOPRF Mode = 0x02
+)
+
+// Suite is a prime order group and hash function of RFC 9497 section 4
+type Suite struct {
+	// Identifier is the RFC 9497 name of the suite
+	Identifier string
+
+	curve        *curves.Curve
+	hash         func() hash.Hash
+	expander     func() *native.EllipticPointHasher
+	littleEndian bool // scalars are encoded little endian
+}
+
+var (
+	// Ristretto255Sha512 is ristretto255-SHA512 of RFC 9497 section 4.1
+	Ristretto255Sha512 = &Suite{
+		Identifier:   "ristretto255-SHA512",
+		curve:        curves.RISTRETTO255(),
+		hash:         sha512.New,
+		expander:     native.EllipticPointHasherSha512,
+		littleEndian: true,
+	}
+	// P256Sha256 is P256-SHA256 of RFC 9497 section 4.3
+	P256Sha256 = &Suite{
+		Identifier: "P256-SHA256",
+		curve:      curves.P256(),
+		hash:       sha256.New,
+		expander:   native.EllipticPointHasherSha256,
+	}
+)
+
+// Curve returns the group of the suite
+func (s *Suite) Curve() *curves.Curve {
+	return s.curve
+}
+
+// Hash returns a new instance of the suite hash function
+func (s *Suite) Hash() hash.Hash {
+	return s.hash()
+}
+
+// contextString is "OPRFV1-" || I2OSP(mode, 1) || "-" || identifier
+func (s *Suite) contextString(mode Mode) []byte {
+	ctx := append([]byte("OPRFV1-"), byte(mode), '-')
+	return append(ctx, s.Identifier...)
+}
+
+// SerializeElement encodes a group element
+func (s *Suite) SerializeElement(p curves.Point) []byte {
+	return p.ToAffineCompressed()
+}
+
+// DeserializeElement decodes a group element and rejects the identity, see RFC 9497 section 2.1
+func (s *Suite) DeserializeElement(b []byte) (curves.Point, error) {
+	p, err := s.curve.Point.FromAffineCompressed(b)
+	if err != nil {
+		return nil, fmt.Errorf("invalid element: %w", err)
+	}
+	if p.IsIdentity() {
+		return nil, fmt.Errorf("invalid element: identity")
+	}
+	return p, nil
+}
+
+// SerializeScalar encodes a scalar
+func (s *Suite) SerializeScalar(k curves.Scalar) []byte {
+	return k.Bytes()
+}
+
+// DeserializeScalar decodes a canonical scalar
+func (s *Suite) DeserializeScalar(b []byte) (curves.Scalar, error) {
+	return s.curve.Scalar.SetBytes(b)
+}
+
+func (s *Suite) elementLength() int {
+	return len(s.curve.Point.Generator().ToAffineCompressed())
+}
+
+func (s *Suite) scalarLength() int {
+	return len(s.curve.Scalar.One().Bytes())
+}
+
+func (s *Suite) hashToGroup(mode Mode, msg []byte) (curves.Point, error) {
+	return s.curve.HashToPoint(msg, append([]byte("HashToGroup-"), s.contextString(mode)...))
+}
+
+// hashToScalar is HashToScalar of RFC 9497 section 4. A nil dst uses "HashToScalar-" || contextString.
+func (s *Suite) hashToScalar(mode Mode, msg, dst []byte) curves.Scalar {
+	if dst == nil {
+		dst = append([]byte("HashToScalar-"), s.contextString(mode)...)
+	}
+	if s.littleEndian {
+		// 64 uniform bytes interpreted little endian
+		u := native.ExpandMsgXmd(s.expander(), msg, dst, 64)
+		k, _ := s.curve.Scalar.SetBytesWide(u)
+		return k
+	}
+	// hash_to_field with L = 48 interpreted big endian
+	u := native.ExpandMsgXmd(s.expander(), msg, dst, 48)
+	k, _ := s.curve.Scalar.SetBigInt(new(big.Int).SetBytes(u))
+	return k
+}
+
+// DeriveKeyPair deterministically derives a key from seed and info, see RFC 9497 section 3.2.1
+func (s *Suite) DeriveKeyPair(mode Mode, seed, info []byte) (curves.Scalar, curves.Point, error) {
+	if len(seed) != 32 {
+		return nil, nil, fmt.Errorf("seed must be 32 bytes")
+	}
+	if len(info) > 0xffff {
+		return nil, nil, fmt.Errorf("info is too long")
+	}
+	input := append(append([]byte{}, seed...), lengthPrefixed(info)...)
+	dst := append([]byte("DeriveKeyPair"), s.contextString(mode)...)
+	for counter := 0; counter < 256; counter++ {
+		sk := s.hashToScalar(mode, append(input, byte(counter)), dst)
+		if !sk.IsZero() {
+			return sk, s.curve.ScalarBaseMult(sk), nil
+		}
+	}
+	return nil, nil, fmt.Errorf("unable to derive key pair")
+}
+
+// lengthPrefixed is I2OSP(len(b), 2) || b
+func lengthPrefixed(b []byte) []byte {
+	return append([]byte{byte(len(b) >> 8), byte(len(b))}, b...)
+}
@@ -0,0 +1,155 @@
+package simplest
+
+import (
+	"bytes"
+	"encoding/gob"
+
+	"github.com/pkg/errors"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
+)
+
+// senderState is the serialized state of a Sender. Scalars and points are
+// encoded on Curve and empty until set by their round.
+type senderState struct {
+	Output     *SenderOutput
+	Curve      string
+	SecretKey  []byte
+	PublicKey  []byte
+	BatchSize  int
+	Transcript []byte
+}
+
+// receiverState is the serialized state of a Receiver
+type receiverState struct {
+	Output          *ReceiverOutput
+	Curve           string
+	SenderPublicKey []byte
+	SenderChallenge []OtChallenge
+	BatchSize       int
+	Transcript      []byte
+}
+
+// MarshalBinary serializes the state of the sender between rounds, including
+// its secret key. The result must be protected as the sender itself.
+func (sender *Sender) MarshalBinary() ([]byte, error) {
+	tr, err := sender.transcript.MarshalBinary()
+	if err != nil {
+		return nil, errors.Wrap(err, "serializing sender transcript")
+	}
+	state := &senderState{
+		Output:     sender.Output,
+		Curve:      sender.curve.Name,
+		BatchSize:  sender.batchSize,
+		Transcript: tr,
+	}
+	if sender.secretKey != nil {
+		state.SecretKey = sender.secretKey.Bytes()
+		state.PublicKey = sender.publicKey.ToAffineCompressed()
+	}
+	buf := new(bytes.Buffer)
+	if err := gob.NewEncoder(buf).Encode(state); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary restores a sender serialized by MarshalBinary
+func (sender *Sender) UnmarshalBinary(data []byte) error {
+	state := new(senderState)
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(state); err != nil {
+		return errors.Wrap(err, "decoding sender state")
+	}
+	curve := curves.GetCurveByName(state.Curve)
+	if curve == nil {
+		return errors.Errorf("unknown curve %s", state.Curve)
+	}
+	if state.BatchSize&0x07 != 0 {
+		return errors.New("batch size should be a multiple of 8")
+	}
+	restored := &Sender{
+		Output:     state.Output,
+		curve:      curve,
+		batchSize:  state.BatchSize,
+		transcript: new(transcript.Transcript),
+	}
+	if restored.Output == nil {
+		restored.Output = &SenderOutput{}
+	}
+	if err := restored.transcript.UnmarshalBinary(state.Transcript); err != nil {
+		return errors.Wrap(err, "decoding sender transcript")
+	}
+	if state.SecretKey != nil {
+		var err error
+		if restored.secretKey, err = curve.Scalar.SetBytes(state.SecretKey); err != nil {
+			return errors.Wrap(err, "decoding sender secret key")
+		}
+		if restored.publicKey, err = curve.Point.FromAffineCompressed(state.PublicKey); err != nil {
+			return errors.Wrap(err, "decoding sender public key")
+		}
+	}
+	*sender = *restored
+	return nil
+}
+
+// MarshalBinary serializes the state of the receiver between rounds,
+// including its choice bits and keys. The result must be protected as the
+// receiver itself.
+func (receiver *Receiver) MarshalBinary() ([]byte, error) {
+	tr, err := receiver.transcript.MarshalBinary()
+	if err != nil {
+		return nil, errors.Wrap(err, "serializing receiver transcript")
+	}
+	state := &receiverState{
+		Output:          receiver.Output,
+		Curve:           receiver.curve.Name,
+		SenderChallenge: receiver.senderChallenge,
+		BatchSize:       receiver.batchSize,
+		Transcript:      tr,
+	}
+	if receiver.senderPublicKey != nil {
+		state.SenderPublicKey = receiver.senderPublicKey.ToAffineCompressed()
+	}
+	buf := new(bytes.Buffer)
+	if err := gob.NewEncoder(buf).Encode(state); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary restores a receiver serialized by MarshalBinary
+func (receiver *Receiver) UnmarshalBinary(data []byte) error {
+	state := new(receiverState)
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(state); err != nil {
+		return errors.Wrap(err, "decoding receiver state")
+	}
+	curve := curves.GetCurveByName(state.Curve)
+	if curve == nil {
+		return errors.Errorf("unknown curve %s", state.Curve)
+	}
+	if state.BatchSize&0x07 != 0 {
+		return errors.New("batch size should be a multiple of 8")
+	}
+	if state.Output == nil || len(state.Output.PackedRandomChoiceBits) != state.BatchSize>>3 {
+		return errors.New("receiver state has no choice bits for its batch size")
+	}
+	restored := &Receiver{
+		Output:          state.Output,
+		curve:           curve,
+		senderChallenge: state.SenderChallenge,
+		batchSize:       state.BatchSize,
+		transcript:      new(transcript.Transcript),
+	}
+	if err := restored.transcript.UnmarshalBinary(state.Transcript); err != nil {
+		return errors.Wrap(err, "decoding receiver transcript")
+	}
+	if state.SenderPublicKey != nil {
+		var err error
+		if restored.senderPublicKey, err = curve.Point.FromAffineCompressed(state.SenderPublicKey); err != nil {
+			return errors.Wrap(err, "decoding sender public key")
+		}
+	}
+	*receiver = *restored
+	return nil
+}
@@ -0,0 +1,267 @@
+package ot
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/binary"
+	"fmt"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/go-sonr/crypto/core/protocol/messages"
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/ot/base/simplest"
+	"github.com/go-sonr/crypto/ot/extension/kos"
+)
+
+// MaxCount is the largest number of OTs of one extension
+const MaxCount = 1 << 24
+
+const (
+	statistical     = 80
+	expandDomain    = "go-sonr ot extension v1"
+	challengeDomain = "go-sonr ot extension challenge v1"
+	padDomain       = "go-sonr ot extension pad v1"
+	extensionType   = "ot/extension"
+)
+
+// Extension is the message of the receiver extending the base OTs: the
+// columns U of the KOS15 matrix and the values of the consistency check
+type Extension struct {
+	Count  uint32
+	U      [][]byte
+	WPrime [kos.KappaBytes]byte
+	VPrime [kos.KappaBytes]byte
+}
+
+// SenderOutput is the sender side of a batch of random OTs: two random pads
+// for each OT
+type SenderOutput struct {
+	Pads [][2][PadSize]byte
+	used bool
+}
+
+// ReceiverOutput is the receiver side of a batch of random OTs: its choice
+// bits and the pad of its choice for each OT
+type ReceiverOutput struct {
+	Choices []bool
+	Pads    [][PadSize]byte
+	used    bool
+}
+
+// Extend starts an extension of len(choices) random OTs with the choice bits
+// of the receiver, returning its output and the message for the sender. The
+// session id must be unique for the base OTs.
+func (r *Receiver) Extend(sessionId []byte, choices []bool) (*ReceiverOutput, *Extension, error) {
+	if len(sessionId) == 0 {
+		return nil, nil, fmt.Errorf("session id cannot be empty")
+	}
+	if len(choices) == 0 || len(choices) > MaxCount {
+		return nil, nil, fmt.Errorf("invalid number of OTs %d", len(choices))
+	}
+	n := extendedCount(len(choices))
+
+	// the choices, padded with random bits that hide them in the check
+	w := make([]byte, n>>3)
+	if _, err := rand.Read(w); err != nil {
+		return nil, nil, err
+	}
+	for j, c := range choices {
+		bit := boolToByte(c)
+		w[j>>3] = w[j>>3]&^(1<<(j&0x07)) | bit<<(j&0x07)
+	}
+
+	ext := &Extension{Count: uint32(len(choices)), U: make([][]byte, Kappa)}
+	t := make([][]byte, Kappa)
+	for i := 0; i < Kappa; i++ {
+		t[i] = expand(sessionId, r.base.OneTimePadEncryptionKeys[i][0], n)
+		t1 := expand(sessionId, r.base.OneTimePadEncryptionKeys[i][1], n)
+		ext.U[i] = make([]byte, n>>3)
+		for k := range t1 {
+			ext.U[i][k] = t[i][k] ^ t1[k] ^ w[k]
+		}
+	}
+	rows := transpose(t, n)
+	digest := matrixDigest(sessionId, ext)
+	for j := 0; j < n; j++ {
+		chi := challenge(digest, j)
+		mask := -simplest.ExtractBitFromByteVector(w, j)
+		tChi := kos.BinaryFieldMul(rows[j][:], chi)
+		for k := 0; k < kos.KappaBytes; k++ {
+			ext.WPrime[k] ^= mask & chi[k]
+			ext.VPrime[k] ^= tChi[k]
+		}
+	}
+
+	out := &ReceiverOutput{Choices: append([]bool(nil), choices...), Pads: make([][PadSize]byte, len(choices))}
+	for j := range out.Pads {
+		out.Pads[j] = pad(sessionId, j, rows[j][:])
+	}
+	return out, ext, nil
+}
+
+// Extend finishes the extension of the receiver, checking its consistency,
+// and returns the random OTs of the sender
+func (s *Sender) Extend(sessionId []byte, ext *Extension) (*SenderOutput, error) {
+	if ext == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if len(sessionId) == 0 {
+		return nil, fmt.Errorf("session id cannot be empty")
+	}
+	if ext.Count == 0 || ext.Count > MaxCount {
+		return nil, fmt.Errorf("invalid number of OTs %d", ext.Count)
+	}
+	n := extendedCount(int(ext.Count))
+	if len(ext.U) != Kappa {
+		return nil, fmt.Errorf("extension has %d columns, expected %d", len(ext.U), Kappa)
+	}
+	q := make([][]byte, Kappa)
+	for i := 0; i < Kappa; i++ {
+		if len(ext.U[i]) != n>>3 {
+			return nil, fmt.Errorf("invalid length of column %d", i)
+		}
+		q[i] = expand(sessionId, s.base.OneTimePadDecryptionKey[i], n)
+		mask := -byte(s.base.RandomChoiceBits[i] & 0x01)
+		for k := range q[i] {
+			q[i][k] ^= mask & ext.U[i][k]
+		}
+	}
+	rows := transpose(q, n)
+	digest := matrixDigest(sessionId, ext)
+	var qPrime [kos.KappaBytes]byte
+	for j := 0; j < n; j++ {
+		qChi := kos.BinaryFieldMul(rows[j][:], challenge(digest, j))
+		for k := 0; k < kos.KappaBytes; k++ {
+			qPrime[k] ^= qChi[k]
+		}
+	}
+	var rhs [kos.KappaBytes]byte
+	sw := kos.BinaryFieldMul(s.base.PackedRandomChoiceBits, ext.WPrime[:])
+	for k := 0; k < kos.KappaBytes; k++ {
+		rhs[k] = ext.VPrime[k] ^ sw[k]
+	}
+	if subtle.ConstantTimeCompare(qPrime[:], rhs[:]) != 1 {
+		return nil, fmt.Errorf("consistency check of the extension failed; this may be an attack; do NOT reuse the base OTs")
+	}
+
+	out := &SenderOutput{Pads: make([][2][PadSize]byte, ext.Count)}
+	for j := range out.Pads {
+		out.Pads[j][0] = pad(sessionId, j, rows[j][:])
+		for k := 0; k < kos.KappaBytes; k++ {
+			rows[j][k] ^= s.base.PackedRandomChoiceBits[k]
+		}
+		out.Pads[j][1] = pad(sessionId, j, rows[j][:])
+	}
+	return out, nil
+}
+
+// extendedCount returns the number of bits of the columns for count OTs,
+// with the random rows of the consistency check
+func extendedCount(count int) int {
+	return (count+7)&^0x07 + Kappa + statistical
+}
+
+// expand stretches a base OT seed into n bits of a column
+func expand(sessionId []byte, seed [simplest.DigestSize]byte, n int) []byte {
+	out := make([]byte, n>>3)
+	shake := sha3.NewCShake256([]byte(expandDomain), sessionId)
+	_, _ = shake.Write(seed[:])
+	_, _ = shake.Read(out)
+	return out
+}
+
+// transpose returns the n rows of Kappa bits of the Kappa columns of n bits
+func transpose(columns [][]byte, n int) [][kos.KappaBytes]byte {
+	rows := make([][kos.KappaBytes]byte, n)
+	for i, column := range columns {
+		for j := 0; j < n; j++ {
+			rows[j][i>>3] |= simplest.ExtractBitFromByteVector(column, j) << (i & 0x07)
+		}
+	}
+	return rows
+}
+
+// matrixDigest hashes the session and the matrix U, which the challenges of
+// the consistency check derive from
+func matrixDigest(sessionId []byte, ext *Extension) []byte {
+	h := sha3.New256()
+	_, _ = h.Write([]byte(challengeDomain))
+	_, _ = h.Write(binary.BigEndian.AppendUint32(nil, uint32(len(sessionId))))
+	_, _ = h.Write(sessionId)
+	_, _ = h.Write(binary.BigEndian.AppendUint32(nil, ext.Count))
+	for _, u := range ext.U {
+		_, _ = h.Write(u)
+	}
+	return h.Sum(nil)
+}
+
+// challenge returns the challenge χ_j of row j
+func challenge(digest []byte, j int) []byte {
+	h := sha3.New256()
+	_, _ = h.Write(binary.BigEndian.AppendUint32(nil, uint32(j)))
+	_, _ = h.Write(digest)
+	return h.Sum(nil)
+}
+
+// pad hashes row j into the pad of the OT j
+func pad(sessionId []byte, j int, row []byte) [PadSize]byte {
+	var out [PadSize]byte
+	shake := sha3.NewCShake256([]byte(padDomain), sessionId)
+	_, _ = shake.Write(binary.BigEndian.AppendUint32(nil, uint32(j)))
+	_, _ = shake.Write(row)
+	_, _ = shake.Read(out[:])
+	return out
+}
+
+func boolToByte(b bool) byte {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// MarshalBinary encodes the extension in the canonical messages format.
+func (e *Extension) MarshalBinary() ([]byte, error) {
+	if e == nil {
+		return nil, internal.ErrNilArguments
+	}
+	enc := messages.NewEncoder(extensionType, nil)
+	enc.WriteUint32(e.Count)
+	enc.WriteUint32(uint32(len(e.U)))
+	for _, u := range e.U {
+		enc.WriteBytes(u)
+	}
+	enc.WriteBytes(e.WPrime[:])
+	enc.WriteBytes(e.VPrime[:])
+	return enc.Finish()
+}
+
+// UnmarshalBinary decodes an extension encoded by MarshalBinary.
+func (e *Extension) UnmarshalBinary(data []byte) error {
+	dec, err := messages.NewDecoder(data, extensionType)
+	if err != nil {
+		return err
+	}
+	count := dec.ReadUint32()
+	columns := dec.ReadUint32()
+	if columns != Kappa {
+		return fmt.Errorf("extension has %d columns, expected %d", columns, Kappa)
+	}
+	u := make([][]byte, columns)
+	for i := range u {
+		u[i] = dec.ReadBytes()
+	}
+	wPrime := dec.ReadBytes()
+	vPrime := dec.ReadBytes()
+	if err = dec.Finish(); err != nil {
+		return err
+	}
+	if len(wPrime) != kos.KappaBytes || len(vPrime) != kos.KappaBytes {
+		return fmt.Errorf("invalid consistency check of the extension")
+	}
+	e.Count, e.U = count, u
+	copy(e.WPrime[:], wPrime)
+	copy(e.VPrime[:], vPrime)
+	return nil
+}
@@ -75,7 +75,8 @@ type Sender struct {
 	curve *curves.Curve
 }
 
-func binaryFieldMul(A []byte, B []byte) []byte {
+// BinaryFieldMul multiplies the 32 byte elements A and B of the field of order 2^256.
+func BinaryFieldMul(A []byte, B []byte) []byte {
 	// multiplies `A` and `B` in the finite field of order 2^256.
 	// The reference is Hankerson, Vanstone and Menezes, Guide to Elliptic Curve Cryptography. https://link.springer.com/book/10.1007/b97644
 	// `A` and `B` are both assumed to be 32-bytes slices. here we view them as little-endian coordinate representations of degree-255 polynomials.
@@ -252,7 +253,7 @@ func (receiver *Receiver) Round1Initialize(uniqueSessionId [simplest.DigestSize]
 		}
 		chiJ := hash.Sum(nil)
 		wJ := convertBitToBitmask(simplest.ExtractBitFromByteVector(receiver.extendedPackedChoices[:], j)) // extract j^th bit from vector of bytes w.
-		psiJTimesChiJ := binaryFieldMul(receiver.psi[j][:], chiJ)
+		psiJTimesChiJ := BinaryFieldMul(receiver.psi[j][:], chiJ)
 		for k := 0; k < KappaBytes; k++ {
 			result.WPrime[k] ^= wJ & chiJ[k]
 			result.VPrime[k] ^= psiJTimesChiJ[k]
@@ -302,13 +303,13 @@ func (sender *Sender) Round2Transfer(uniqueSessionId [simplest.DigestSize]byte,
 			return nil, errors.Wrap(err, "writing input digest into hash while computing chiJ in cOT sender round 2 transfer")
 		}
 		chiJ := hash.Sum(nil)
-		zetaJTimesChiJ := binaryFieldMul(zeta[j][:], chiJ)
+		zetaJTimesChiJ := BinaryFieldMul(zeta[j][:], chiJ)
 		for k := 0; k < KappaBytes; k++ {
 			zPrime[k] ^= zetaJTimesChiJ[k]
 		}
 	}
 	rhs := [simplest.DigestSize]byte{}
-	nablaTimesWPrime := binaryFieldMul(sender.seedOtResults.PackedRandomChoiceBits, round1Output.WPrime[:])
+	nablaTimesWPrime := BinaryFieldMul(sender.seedOtResults.PackedRandomChoiceBits, round1Output.WPrime[:])
 	for i := 0; i < KappaBytes; i++ {
 		rhs[i] = round1Output.VPrime[i] ^ nablaTimesWPrime[i]
 	}
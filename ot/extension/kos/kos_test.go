@@ -24,7 +24,7 @@ func TestBinaryMult(t *testing.T) {
 		// thus raising any element to the |F|th power should yield that element itself.
 		// this is a good test because it relies on subtle facts about the field structure, and will fail if anything goes wrong.
 		for j := 0; j < 256; j++ {
-			expected = binaryFieldMul(expected, expected)
+			expected = BinaryFieldMul(expected, expected)
 		}
 		require.Equal(t, temp, expected)
 	}
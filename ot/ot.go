@@ -0,0 +1,109 @@
+// Package ot is oblivious transfer for protocols that need many OTs, such
+// as private set intersection and garbled circuits, on top of the OT used by
+// the DKLs18 threshold ECDSA.
+//
+// A pair of parties first runs Kappa base OTs of ot/base/simplest, with the
+// roles reversed: the receiver of the extension is the base OT sender.
+// SetupSender and SetupReceiver run them over a stream, and NewSender and
+// NewReceiver take the outputs of the rounds run by hand. From then on the
+// maliciously secure KOS15 extension, https://eprint.iacr.org/2015/546.pdf,
+// turns the base OTs into any number of OTs at the cost of symmetric
+// operations, once per session id:
+//
+//   - random OT: Extend gives the sender two random pads per OT and the
+//     receiver the pad of its choice bit,
+//   - chosen-message OT: Transfer and Receive use the pads to send one of two
+//     messages,
+//   - correlated OT: Correlate turns the pads into additive shares of
+//     choice·Δ for scalars Δ of the sender, as ot/extension/kos does for the
+//     fixed batches of DKLs18.
+//
+// A failed consistency check of an extension is an attack, and the base OTs
+// must not be used again.
+package ot
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/ot/base/simplest"
+	"github.com/go-sonr/crypto/ot/extension/kos"
+)
+
+const (
+	// Kappa is the number of base OTs and the computational security
+	// parameter of the extension
+	Kappa = kos.Kappa
+	// PadSize is the size of the pads of a random OT
+	PadSize = simplest.DigestSize
+)
+
+// Sender is the sender of OT extensions, holding the output of the base OTs
+// it received
+type Sender struct {
+	base *simplest.ReceiverOutput
+}
+
+// Receiver is the receiver of OT extensions, holding the output of the base
+// OTs it sent
+type Receiver struct {
+	base *simplest.SenderOutput
+}
+
+// NewSender returns the extension sender of the output of Kappa base OTs run
+// as the base OT receiver
+func NewSender(base *simplest.ReceiverOutput) (*Sender, error) {
+	if base == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if len(base.OneTimePadDecryptionKey) != Kappa || len(base.RandomChoiceBits) != Kappa || len(base.PackedRandomChoiceBits) != kos.KappaBytes {
+		return nil, fmt.Errorf("extension needs %d base OTs", Kappa)
+	}
+	return &Sender{base: base}, nil
+}
+
+// NewReceiver returns the extension receiver of the output of Kappa base OTs
+// run as the base OT sender
+func NewReceiver(base *simplest.SenderOutput) (*Receiver, error) {
+	if base == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if len(base.OneTimePadEncryptionKeys) != Kappa {
+		return nil, fmt.Errorf("extension needs %d base OTs", Kappa)
+	}
+	return &Receiver{base: base}, nil
+}
+
+// SetupSender runs the base OTs with the receiver over rw and returns the
+// extension sender
+func SetupSender(curve *curves.Curve, sessionId [simplest.DigestSize]byte, rw io.ReadWriter) (*Sender, error) {
+	if curve == nil || rw == nil {
+		return nil, internal.ErrNilArguments
+	}
+	receiver, err := simplest.NewReceiver(curve, Kappa, sessionId)
+	if err != nil {
+		return nil, err
+	}
+	if err = simplest.ReceiverStreamOTRun(receiver, rw); err != nil {
+		return nil, err
+	}
+	return NewSender(receiver.Output)
+}
+
+// SetupReceiver runs the base OTs with the sender over rw and returns the
+// extension receiver
+func SetupReceiver(curve *curves.Curve, sessionId [simplest.DigestSize]byte, rw io.ReadWriter) (*Receiver, error) {
+	if curve == nil || rw == nil {
+		return nil, internal.ErrNilArguments
+	}
+	sender, err := simplest.NewSender(curve, Kappa, sessionId)
+	if err != nil {
+		return nil, err
+	}
+	if err = simplest.SenderStreamOTRun(sender, rw); err != nil {
+		return nil, err
+	}
+	return NewReceiver(sender.Output)
+}
@@ -0,0 +1,183 @@
+package ot
+
+import (
+	crand "crypto/rand"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/ot/base/simplest"
+	"github.com/go-sonr/crypto/ot/ottest"
+)
+
+func setup(t *testing.T) (*Sender, *Receiver) {
+	var sid [simplest.DigestSize]byte
+	_, err := crand.Read(sid[:])
+	require.NoError(t, err)
+	baseSender, baseReceiver, err := ottest.RunSimplestOT(curves.K256(), Kappa, sid)
+	require.NoError(t, err)
+	sender, err := NewSender(baseReceiver)
+	require.NoError(t, err)
+	receiver, err := NewReceiver(baseSender)
+	require.NoError(t, err)
+	return sender, receiver
+}
+
+func randomChoices(t *testing.T, n int) []bool {
+	b := make([]byte, n)
+	_, err := crand.Read(b)
+	require.NoError(t, err)
+	choices := make([]bool, n)
+	for i := range b {
+		choices[i] = b[i]&1 == 1
+	}
+	return choices
+}
+
+func TestRandomOT(t *testing.T) {
+	sender, receiver := setup(t)
+	for _, n := range []int{1, 8, 13, 1000} {
+		sid := []byte{byte(n), byte(n >> 8)}
+		choices := randomChoices(t, n)
+		rOut, ext, err := receiver.Extend(sid, choices)
+		require.NoError(t, err)
+		sOut, err := sender.Extend(sid, ext)
+		require.NoError(t, err)
+		require.Len(t, sOut.Pads, n)
+		for j := 0; j < n; j++ {
+			require.NotEqual(t, sOut.Pads[j][0], sOut.Pads[j][1])
+			if choices[j] {
+				require.Equal(t, sOut.Pads[j][1], rOut.Pads[j])
+			} else {
+				require.Equal(t, sOut.Pads[j][0], rOut.Pads[j])
+			}
+		}
+	}
+}
+
+func TestChosenMessageOT(t *testing.T) {
+	sender, receiver := setup(t)
+	sid := []byte("chosen")
+	choices := randomChoices(t, 100)
+	rOut, ext, err := receiver.Extend(sid, choices)
+	require.NoError(t, err)
+	sOut, err := sender.Extend(sid, ext)
+	require.NoError(t, err)
+
+	msgs := make([][2][]byte, len(choices))
+	for j := range msgs {
+		msgs[j] = [2][]byte{{byte(j), 0, 1}, {byte(j), 1, 0}}
+	}
+	ciphertexts, err := sOut.Transfer(msgs)
+	require.NoError(t, err)
+	got, err := rOut.Receive(ciphertexts)
+	require.NoError(t, err)
+	for j, c := range choices {
+		if c {
+			require.Equal(t, msgs[j][1], got[j])
+		} else {
+			require.Equal(t, msgs[j][0], got[j])
+		}
+	}
+
+	_, err = sOut.Transfer(msgs)
+	require.Error(t, err)
+	_, err = rOut.Receive(ciphertexts)
+	require.Error(t, err)
+}
+
+func TestCorrelatedOT(t *testing.T) {
+	curve := curves.P256()
+	sender, receiver := setup(t)
+	sid := []byte("correlated")
+	choices := randomChoices(t, 64)
+	rOut, ext, err := receiver.Extend(sid, choices)
+	require.NoError(t, err)
+	sOut, err := sender.Extend(sid, ext)
+	require.NoError(t, err)
+
+	deltas := make([]curves.Scalar, len(choices))
+	for j := range deltas {
+		deltas[j] = curve.Scalar.Random(crand.Reader)
+	}
+	_, _, err = sOut.Correlate(curve, deltas[1:])
+	require.Error(t, err)
+	senderShares, corrections, err := sOut.Correlate(curve, deltas)
+	require.NoError(t, err)
+	receiverShares, err := rOut.Correlate(curve, corrections)
+	require.NoError(t, err)
+	for j, c := range choices {
+		sum := senderShares[j].Add(receiverShares[j])
+		if c {
+			require.Equal(t, 0, sum.Cmp(deltas[j]))
+		} else {
+			require.True(t, sum.IsZero())
+		}
+	}
+}
+
+func TestExtensionConsistencyCheck(t *testing.T) {
+	sender, receiver := setup(t)
+	sid := []byte("check")
+	_, ext, err := receiver.Extend(sid, randomChoices(t, 40))
+	require.NoError(t, err)
+
+	_, err = sender.Extend([]byte("other"), ext)
+	require.Error(t, err)
+
+	ext.VPrime[0] ^= 1
+	_, err = sender.Extend(sid, ext)
+	require.Error(t, err)
+	ext.VPrime[0] ^= 1
+
+	ext.U[3] = ext.U[3][1:]
+	_, err = sender.Extend(sid, ext)
+	require.Error(t, err)
+}
+
+func TestExtensionMarshalBinary(t *testing.T) {
+	sender, receiver := setup(t)
+	sid := []byte("wire")
+	choices := randomChoices(t, 20)
+	rOut, ext, err := receiver.Extend(sid, choices)
+	require.NoError(t, err)
+
+	data, err := ext.MarshalBinary()
+	require.NoError(t, err)
+	decoded := new(Extension)
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	require.Error(t, decoded.UnmarshalBinary(data[:len(data)-1]))
+	sOut, err := sender.Extend(sid, decoded)
+	require.NoError(t, err)
+	require.Equal(t, sOut.Pads[5][boolToByte(choices[5])], rOut.Pads[5])
+}
+
+func TestSetupOverStream(t *testing.T) {
+	curve := curves.K256()
+	var sid [simplest.DigestSize]byte
+	a, b := net.Pipe()
+	defer a.Close()
+	defer b.Close()
+
+	errs := make(chan error, 1)
+	var receiver *Receiver
+	go func() {
+		var err error
+		receiver, err = SetupReceiver(curve, sid, b)
+		errs <- err
+	}()
+	sender, err := SetupSender(curve, sid, a)
+	require.NoError(t, err)
+	require.NoError(t, <-errs)
+
+	choices := randomChoices(t, 16)
+	rOut, ext, err := receiver.Extend([]byte("stream"), choices)
+	require.NoError(t, err)
+	sOut, err := sender.Extend([]byte("stream"), ext)
+	require.NoError(t, err)
+	for j, c := range choices {
+		require.Equal(t, sOut.Pads[j][boolToByte(c)], rOut.Pads[j])
+	}
+}
@@ -0,0 +1,141 @@
+package ot
+
+import (
+	"crypto/subtle"
+	"fmt"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/internal"
+)
+
+const (
+	messageDomain     = "go-sonr ot message v1"
+	correlationDomain = "go-sonr ot correlation v1"
+)
+
+// Transfer encrypts the two messages of each OT under its pads, for the
+// receiver to decrypt the one of its choice with Receive. Both messages of
+// an OT must have the same length. The pads can be used once.
+func (o *SenderOutput) Transfer(msgs [][2][]byte) ([][2][]byte, error) {
+	if err := o.use(len(msgs)); err != nil {
+		return nil, err
+	}
+	ciphertexts := make([][2][]byte, len(msgs))
+	for j, m := range msgs {
+		if len(m[0]) != len(m[1]) {
+			return nil, fmt.Errorf("messages of OT %d have different lengths", j)
+		}
+		for b := 0; b < 2; b++ {
+			ciphertexts[j][b] = xorStream(o.Pads[j][b], m[b])
+		}
+	}
+	return ciphertexts, nil
+}
+
+// Receive decrypts the message of its choice of each OT
+func (o *ReceiverOutput) Receive(ciphertexts [][2][]byte) ([][]byte, error) {
+	if err := o.use(len(ciphertexts)); err != nil {
+		return nil, err
+	}
+	msgs := make([][]byte, len(ciphertexts))
+	for j, c := range ciphertexts {
+		if len(c[0]) != len(c[1]) {
+			return nil, fmt.Errorf("ciphertexts of OT %d have different lengths", j)
+		}
+		chosen := append([]byte(nil), c[0]...)
+		subtle.ConstantTimeCopy(int(boolToByte(o.Choices[j])), chosen, c[1])
+		msgs[j] = xorStream(o.Pads[j], chosen)
+	}
+	return msgs, nil
+}
+
+// Correlate turns the OTs into correlated OTs of the scalars deltas: the
+// sender keeps the returned shares and sends the corrections, and the
+// receiver's shares from them add to choice·delta. The pads can be used
+// once.
+func (o *SenderOutput) Correlate(curve *curves.Curve, deltas []curves.Scalar) ([]curves.Scalar, []curves.Scalar, error) {
+	if curve == nil {
+		return nil, nil, internal.ErrNilArguments
+	}
+	if err := o.use(len(deltas)); err != nil {
+		return nil, nil, err
+	}
+	shares := make([]curves.Scalar, len(deltas))
+	corrections := make([]curves.Scalar, len(deltas))
+	for j, delta := range deltas {
+		if delta == nil {
+			return nil, nil, internal.ErrNilArguments
+		}
+		h0 := correlation(curve, o.Pads[j][0])
+		h1 := correlation(curve, o.Pads[j][1])
+		shares[j] = h0.Neg()
+		corrections[j] = delta.Sub(h1).Add(h0)
+	}
+	return shares, corrections, nil
+}
+
+// Correlate returns the shares of the receiver of correlated OTs from the
+// corrections of the sender
+func (o *ReceiverOutput) Correlate(curve *curves.Curve, corrections []curves.Scalar) ([]curves.Scalar, error) {
+	if curve == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if err := o.use(len(corrections)); err != nil {
+		return nil, err
+	}
+	shares := make([]curves.Scalar, len(corrections))
+	for j, c := range corrections {
+		if c == nil {
+			return nil, internal.ErrNilArguments
+		}
+		h := correlation(curve, o.Pads[j])
+		share := h.Bytes()
+		subtle.ConstantTimeCopy(int(boolToByte(o.Choices[j])), share, h.Add(c).Bytes())
+		var err error
+		if shares[j], err = curve.Scalar.SetBytes(share); err != nil {
+			return nil, err
+		}
+	}
+	return shares, nil
+}
+
+func (o *SenderOutput) use(count int) error {
+	if o.used {
+		return fmt.Errorf("pads were already used")
+	}
+	if count != len(o.Pads) {
+		return fmt.Errorf("%d inputs for %d OTs", count, len(o.Pads))
+	}
+	o.used = true
+	return nil
+}
+
+func (o *ReceiverOutput) use(count int) error {
+	if o.used {
+		return fmt.Errorf("pads were already used")
+	}
+	if count != len(o.Pads) {
+		return fmt.Errorf("%d inputs for %d OTs", count, len(o.Pads))
+	}
+	o.used = true
+	return nil
+}
+
+// xorStream encrypts or decrypts data with the key stream of a pad
+func xorStream(p [PadSize]byte, data []byte) []byte {
+	out := make([]byte, len(data))
+	shake := sha3.NewCShake256([]byte(messageDomain), nil)
+	_, _ = shake.Write(p[:])
+	_, _ = shake.Read(out)
+	for i := range out {
+		out[i] ^= data[i]
+	}
+	return out
+}
+
+// correlation hashes a pad to a scalar
+func correlation(curve *curves.Curve, p [PadSize]byte) curves.Scalar {
+	return curve.Scalar.Hash(append([]byte(correlationDomain), p[:]...))
+}
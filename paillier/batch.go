@@ -0,0 +1,215 @@
+// This file contains batched operations, which run on all CPUs, and ciphertext packing.
+
+package paillier
+
+import (
+	"fmt"
+	"math/big"
+	"runtime"
+	"sync"
+
+	"github.com/go-sonr/crypto/internal"
+)
+
+// EncryptBatch encrypts every message and returns the ciphertexts and nonces in the same order.
+func (pk *PublicKey) EncryptBatch(msgs []*big.Int) ([]Ciphertext, []*big.Int, error) {
+	cts := make([]Ciphertext, len(msgs))
+	nonces := make([]*big.Int, len(msgs))
+	err := parallel(len(msgs), func(i int) error {
+		var err error
+		cts[i], nonces[i], err = pk.Encrypt(msgs[i])
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return cts, nonces, nil
+}
+
+// AddBatch adds c[i] and d[i] for every i.
+func (pk *PublicKey) AddBatch(c, d []Ciphertext) ([]Ciphertext, error) {
+	if len(c) != len(d) {
+		return nil, fmt.Errorf("batches have different lengths: %d and %d", len(c), len(d))
+	}
+	out := make([]Ciphertext, len(c))
+	err := parallel(len(c), func(i int) error {
+		var err error
+		out[i], err = pk.Add(c[i], d[i])
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MulBatch multiplies the plaintext of c[i] by a[i] for every i.
+func (pk *PublicKey) MulBatch(a []*big.Int, c []Ciphertext) ([]Ciphertext, error) {
+	if len(a) != len(c) {
+		return nil, fmt.Errorf("batches have different lengths: %d and %d", len(a), len(c))
+	}
+	out := make([]Ciphertext, len(c))
+	err := parallel(len(c), func(i int) error {
+		var err error
+		out[i], err = pk.Mul(a[i], c[i])
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DecryptBatch decrypts every ciphertext.
+func (sk *SecretKey) DecryptBatch(cts []Ciphertext) ([]*big.Int, error) {
+	msgs := make([]*big.Int, len(cts))
+	err := parallel(len(cts), func(i int) error {
+		var err error
+		msgs[i], err = sk.Decrypt(cts[i])
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return msgs, nil
+}
+
+// parallel runs f for 0 <= i < n on one goroutine per CPU and returns the error of the lowest failing i.
+func parallel(n int, f func(i int) error) error {
+	errs := make([]error, n)
+	workers := runtime.NumCPU()
+	if workers > n {
+		workers = n
+	}
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				errs[i] = f(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Packer packs several small non-negative plaintexts into the slots of one Paillier plaintext,
+// m = v_0 + v_1·2^SlotBits + v_2·2^(2·SlotBits) + ..., so that one ciphertext carries Slots() values and one
+// homomorphic Add adds all slots at once.
+//
+// Slots do not wrap: a slot that exceeds 2^SlotBits carries into the next one. Callers must leave headroom for the
+// operations they run, e.g. adding k ciphertexts of values below 2^b needs SlotBits >= b + ⌈log2 k⌉.
+type Packer struct {
+	pk       *PublicKey
+	slotBits uint
+	slots    int
+}
+
+// NewPacker returns a packer with slots of slotBits bits for the public key pk.
+func NewPacker(pk *PublicKey, slotBits uint) (*Packer, error) {
+	if pk == nil || pk.N == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if slotBits == 0 {
+		return nil, fmt.Errorf("slot size must be positive")
+	}
+	// every packed plaintext stays below 2^(N.BitLen()-1) < N
+	slots := (pk.N.BitLen() - 1) / int(slotBits)
+	if slots < 1 {
+		return nil, fmt.Errorf("slot size %d does not fit in the modulus", slotBits)
+	}
+	return &Packer{pk: pk, slotBits: slotBits, slots: slots}, nil
+}
+
+// Slots returns the number of values that fit in one ciphertext.
+func (p *Packer) Slots() int {
+	return p.slots
+}
+
+// Pack returns the plaintext that holds values, which must be in [0, 2^SlotBits).
+func (p *Packer) Pack(values []*big.Int) (*big.Int, error) {
+	if len(values) > p.slots {
+		return nil, fmt.Errorf("%d values do not fit in %d slots", len(values), p.slots)
+	}
+	m := new(big.Int)
+	for i := len(values) - 1; i >= 0; i-- {
+		v := values[i]
+		if v == nil {
+			return nil, internal.ErrNilArguments
+		}
+		if v.Sign() < 0 || uint(v.BitLen()) > p.slotBits {
+			return nil, fmt.Errorf("value %d does not fit in a slot of %d bits", i, p.slotBits)
+		}
+		m.Lsh(m, p.slotBits)
+		m.Add(m, v)
+	}
+	return m, nil
+}
+
+// Unpack splits a plaintext into count slot values.
+func (p *Packer) Unpack(m *big.Int, count int) ([]*big.Int, error) {
+	if m == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if count < 0 || count > p.slots {
+		return nil, fmt.Errorf("cannot unpack %d of %d slots", count, p.slots)
+	}
+	mask := new(big.Int).Lsh(big.NewInt(1), p.slotBits)
+	mask.Sub(mask, big.NewInt(1))
+	rest := new(big.Int).Set(m)
+	values := make([]*big.Int, count)
+	for i := range values {
+		values[i] = new(big.Int).And(rest, mask)
+		rest.Rsh(rest, p.slotBits)
+	}
+	return values, nil
+}
+
+// Encrypt packs values and encrypts them into one ciphertext.
+func (p *Packer) Encrypt(values []*big.Int) (Ciphertext, *big.Int, error) {
+	m, err := p.Pack(values)
+	if err != nil {
+		return nil, nil, err
+	}
+	return p.pk.Encrypt(m)
+}
+
+// Add adds two packed ciphertexts slot by slot.
+func (p *Packer) Add(c, d Ciphertext) (Ciphertext, error) {
+	return p.pk.Add(c, d)
+}
+
+// MulConst multiplies every slot of a packed ciphertext by the non-negative constant a.
+func (p *Packer) MulConst(a *big.Int, c Ciphertext) (Ciphertext, error) {
+	if a == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if a.Sign() < 0 {
+		return nil, fmt.Errorf("packed ciphertexts can only be multiplied by non-negative constants")
+	}
+	return p.pk.Mul(a, c)
+}
+
+// Decrypt decrypts a packed ciphertext into count slot values.
+func (p *Packer) Decrypt(sk *SecretKey, c Ciphertext, count int) ([]*big.Int, error) {
+	if sk == nil {
+		return nil, internal.ErrNilArguments
+	}
+	m, err := sk.Decrypt(c)
+	if err != nil {
+		return nil, err
+	}
+	return p.Unpack(m, count)
+}
@@ -0,0 +1,84 @@
+package paillier
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchOperations(t *testing.T) {
+	sk, err := NewSecretKey(testPrimes[0], testPrimes[1])
+	require.NoError(t, err)
+	pk := &sk.PublicKey
+
+	msgs := []*big.Int{big.NewInt(0), big.NewInt(7), big.NewInt(1000), new(big.Int).Sub(pk.N, big.NewInt(1))}
+	cts, nonces, err := pk.EncryptBatch(msgs)
+	require.NoError(t, err)
+	require.Len(t, cts, len(msgs))
+	require.Len(t, nonces, len(msgs))
+
+	sums, err := pk.AddBatch(cts, cts)
+	require.NoError(t, err)
+	factors := []*big.Int{big.NewInt(3), big.NewInt(3), big.NewInt(3), big.NewInt(3)}
+	products, err := pk.MulBatch(factors, sums)
+	require.NoError(t, err)
+
+	decrypted, err := sk.DecryptBatch(products)
+	require.NoError(t, err)
+	for i, m := range msgs {
+		want := new(big.Int).Mul(m, big.NewInt(6))
+		want.Mod(want, pk.N)
+		require.Equal(t, want, decrypted[i])
+	}
+
+	_, err = pk.AddBatch(cts, cts[1:])
+	require.Error(t, err)
+	_, err = pk.MulBatch(factors[1:], cts)
+	require.Error(t, err)
+	_, _, err = pk.EncryptBatch([]*big.Int{big.NewInt(1), pk.N})
+	require.Error(t, err)
+}
+
+func TestPacker(t *testing.T) {
+	sk, err := NewSecretKey(testPrimes[0], testPrimes[1])
+	require.NoError(t, err)
+	packer, err := NewPacker(&sk.PublicKey, 64)
+	require.NoError(t, err)
+	require.Equal(t, (sk.N.BitLen()-1)/64, packer.Slots())
+
+	a := make([]*big.Int, packer.Slots())
+	b := make([]*big.Int, packer.Slots())
+	for i := range a {
+		a[i] = big.NewInt(int64(i) << 40)
+		b[i] = big.NewInt(int64(i) + 1)
+	}
+	ca, _, err := packer.Encrypt(a)
+	require.NoError(t, err)
+	cb, _, err := packer.Encrypt(b)
+	require.NoError(t, err)
+	sum, err := packer.Add(ca, cb)
+	require.NoError(t, err)
+	sum, err = packer.MulConst(big.NewInt(5), sum)
+	require.NoError(t, err)
+
+	values, err := packer.Decrypt(sk, sum, packer.Slots())
+	require.NoError(t, err)
+	for i := range values {
+		want := new(big.Int).Add(a[i], b[i])
+		want.Mul(want, big.NewInt(5))
+		require.Equal(t, want, values[i])
+	}
+
+	// values must fit in their slot
+	_, _, err = packer.Encrypt([]*big.Int{new(big.Int).Lsh(big.NewInt(1), 64)})
+	require.Error(t, err)
+	_, _, err = packer.Encrypt([]*big.Int{big.NewInt(-1)})
+	require.Error(t, err)
+	_, _, err = packer.Encrypt(make([]*big.Int, packer.Slots()+1))
+	require.Error(t, err)
+	_, err = packer.MulConst(big.NewInt(-1), ca)
+	require.Error(t, err)
+	_, err = NewPacker(&sk.PublicKey, uint(sk.N.BitLen()))
+	require.Error(t, err)
+}
@@ -0,0 +1,109 @@
+// This file contains decryption with the Chinese remainder theorem: [P99] §7 Decryption using Chinese-remaindering.
+
+package paillier
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/go-sonr/crypto/core"
+	"github.com/go-sonr/crypto/internal"
+)
+
+// crtParams are the values precomputed from the factors of N for decryption modulo P² and Q².
+type crtParams struct {
+	p, q   *big.Int
+	pm1    *big.Int // P - 1
+	qm1    *big.Int // Q - 1
+	hp, hq *big.Int // hp = L_P((N+1)^(P-1) mod P²)^{-1} mod P, likewise hq
+	qInv   *big.Int // Q^{-1} mod P
+	pp, qq *core.MontgomeryModulus
+}
+
+// Precompute derives the CRT parameters from N and the totient, for secret keys that were not created by
+// NewSecretKey or UnmarshalJSON. Without them Decrypt uses the slower exponentiation modulo N².
+func (sk *SecretKey) Precompute() error {
+	if sk.N == nil || sk.Totient == nil {
+		return internal.ErrNilArguments
+	}
+	p, q, err := factor(sk.N, sk.Totient)
+	if err != nil {
+		return err
+	}
+	sk.crt, err = newCrtParams(p, q)
+	return err
+}
+
+func newCrtParams(p, q *big.Int) (*crtParams, error) {
+	pp, err := core.NewMontgomeryModulus(new(big.Int).Mul(p, p))
+	if err != nil {
+		return nil, err
+	}
+	qq, err := core.NewMontgomeryModulus(new(big.Int).Mul(q, q))
+	if err != nil {
+		return nil, err
+	}
+	crt := &crtParams{
+		p:    p,
+		q:    q,
+		pm1:  new(big.Int).Sub(p, core.One),
+		qm1:  new(big.Int).Sub(q, core.One),
+		qInv: new(big.Int).ModInverse(q, p),
+		pp:   pp,
+		qq:   qq,
+	}
+	if crt.qInv == nil {
+		return nil, errors.New("paillier primes are not coprime")
+	}
+	g := new(big.Int).Mul(p, q)
+	g.Add(g, core.One) // N + 1
+	if crt.hp, err = crt.h(g, pp, p, crt.pm1); err != nil {
+		return nil, err
+	}
+	if crt.hq, err = crt.h(g, qq, q, crt.qm1); err != nil {
+		return nil, err
+	}
+	return crt, nil
+}
+
+// h returns L_r((N+1)^(r-1) mod r²)^{-1} mod r for the prime r.
+func (crt *crtParams) h(g *big.Int, rr *core.MontgomeryModulus, r, rm1 *big.Int) (*big.Int, error) {
+	x, err := rr.Exp(g, rm1)
+	if err != nil {
+		return nil, err
+	}
+	h := new(big.Int).ModInverse(lr(x, r), r)
+	if h == nil {
+		return nil, errors.New("paillier prime does not yield a valid decryption key")
+	}
+	return h, nil
+}
+
+// decrypt computes m_P = L_P(c^(P-1) mod P²)·h_P mod P and m_Q likewise, and combines them with the CRT.
+func (crt *crtParams) decrypt(c *big.Int) (*big.Int, error) {
+	xp, err := crt.pp.Exp(c, crt.pm1)
+	if err != nil {
+		return nil, err
+	}
+	xq, err := crt.qq.Exp(c, crt.qm1)
+	if err != nil {
+		return nil, err
+	}
+	mp := lr(xp, crt.p)
+	mp.Mul(mp, crt.hp).Mod(mp, crt.p)
+	mq := lr(xq, crt.q)
+	mq.Mul(mq, crt.hq).Mod(mq, crt.q)
+
+	// m = m_Q + Q·((m_P - m_Q)·Q^{-1} mod P)
+	m := new(big.Int).Sub(mp, mq)
+	m.Mul(m, crt.qInv).Mod(m, crt.p)
+	m.Mul(m, crt.q)
+	return m.Add(m, mq), nil
+}
+
+// lr is L_r(x) = (x - 1) / r.
+func lr(x, r *big.Int) *big.Int {
+	l := new(big.Int).Sub(x, core.One)
+	return l.Div(l, r)
+}
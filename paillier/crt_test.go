@@ -0,0 +1,72 @@
+package paillier
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	crypto "github.com/go-sonr/crypto/core"
+)
+
+func TestCrtDecryptMatches(t *testing.T) {
+	sk, err := NewSecretKey(testPrimes[0], testPrimes[1])
+	require.NoError(t, err)
+	require.NotNil(t, sk.crt)
+	for _, msg := range []*big.Int{crypto.Zero, crypto.One, big.NewInt(123456789), new(big.Int).Sub(sk.N, crypto.One)} {
+		c, _, err := sk.Encrypt(msg)
+		require.NoError(t, err)
+		fast, err := sk.Decrypt(c)
+		require.NoError(t, err)
+		slow, err := sk.decrypt(c)
+		require.NoError(t, err)
+		require.Equal(t, msg, fast)
+		require.Equal(t, slow, fast)
+	}
+}
+
+func TestCrtPrecompute(t *testing.T) {
+	sk, err := NewSecretKey(testPrimes[2], testPrimes[3])
+	require.NoError(t, err)
+	bytes, err := json.Marshal(sk)
+	require.NoError(t, err)
+	decoded := new(SecretKey)
+	require.NoError(t, json.Unmarshal(bytes, decoded))
+	require.NotNil(t, decoded.crt)
+
+	// a key assembled by hand decrypts without the CRT until precomputed
+	plain := &SecretKey{PublicKey: sk.PublicKey, Lambda: sk.Lambda, Totient: sk.Totient, U: sk.U}
+	msg := big.NewInt(42)
+	c, _, err := sk.Encrypt(msg)
+	require.NoError(t, err)
+	m, err := plain.Decrypt(c)
+	require.NoError(t, err)
+	require.Equal(t, msg, m)
+	require.NoError(t, plain.Precompute())
+	m, err = plain.Decrypt(c)
+	require.NoError(t, err)
+	require.Equal(t, msg, m)
+
+	plain.Totient = new(big.Int).Add(plain.Totient, crypto.Two)
+	require.Error(t, plain.Precompute())
+	require.Error(t, (&SecretKey{}).Precompute())
+}
+
+func BenchmarkDecrypt(b *testing.B) {
+	sk, err := NewSecretKey(testPrimes[0], testPrimes[1])
+	require.NoError(b, err)
+	c, _, err := sk.Encrypt(big.NewInt(123456789))
+	require.NoError(b, err)
+
+	b.Run("crt", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = sk.Decrypt(c)
+		}
+	})
+	b.Run("n2", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = sk.decrypt(c)
+		}
+	})
+}
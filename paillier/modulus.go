@@ -0,0 +1,316 @@
+// This file contains proofs that Paillier moduli are well-formed: the Paillier-Blum modulus proof of
+// [CGGMP21] https://eprint.iacr.org/2021/060 fig 16, made non-interactive with Fiat-Shamir.
+
+package paillier
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	crypto "github.com/go-sonr/crypto/core"
+	"github.com/go-sonr/crypto/internal"
+)
+
+// ModulusProofLength is the number of challenges of a ModulusProof, for a soundness error of 2^-80.
+const ModulusProofLength = 80
+
+// smallPrimeBound bounds the primes that VerifyModulus rules out as factors of N.
+const smallPrimeBound = 1 << 16
+
+// ModulusProofParams contains the inputs to Prove
+type ModulusProofParams struct {
+	SecretKey *SecretKey
+	// Context binds the proof to a session, e.g. the session id and the prover's identity.
+	Context []byte
+}
+
+// ModulusVerifyParams contains the inputs to Verify
+type ModulusVerifyParams struct {
+	PublicKey *PublicKey
+	Context   []byte
+}
+
+// ModulusProof proves that a Paillier modulus N is a Blum integer N = PQ, P ≡ Q ≡ 3 mod 4, with gcd(N, 𝝋(N)) = 1.
+// This implies that N is square-free and has exactly two prime factors. Safe-prime moduli always satisfy it.
+type ModulusProof struct {
+	// W is a random value of Jacobi symbol -1 modulo N.
+	W *big.Int
+	// X are fourth roots of (-1)^A w^B y modulo N for the challenges y.
+	X []*big.Int
+	// A and B select the sign and the power of W for each challenge.
+	A, B []bool
+	// Z are the N-th roots of the challenges modulo N.
+	Z []*big.Int
+}
+
+// Prove that a Paillier modulus is a well-formed Blum integer
+// [CGGMP21] fig 16
+func (p *ModulusProofParams) Prove() (*ModulusProof, error) {
+	if p == nil || p.SecretKey == nil || p.SecretKey.N == nil || p.SecretKey.Totient == nil {
+		return nil, internal.ErrNilArguments
+	}
+	n := p.SecretKey.N
+	primeP, primeQ, err := factor(n, p.SecretKey.Totient)
+	if err != nil {
+		return nil, err
+	}
+	if primeP.Bit(0) != 1 || primeP.Bit(1) != 1 || primeQ.Bit(0) != 1 || primeQ.Bit(1) != 1 {
+		return nil, fmt.Errorf("paillier modulus is not a blum integer")
+	}
+
+	// 1. sample w with Jacobi symbol (w|N) = -1
+	var w *big.Int
+	for {
+		w, err = crypto.Rand(n)
+		if err != nil {
+			return nil, err
+		}
+		if big.Jacobi(w, n) == -1 {
+			break
+		}
+	}
+
+	// 2. y_i <- challenges; M = N^{-1} mod 𝝋(N)
+	y, err := modulusChallenges(n, w, p.Context)
+	if err != nil {
+		return nil, err
+	}
+	m, err := crypto.Inv(n, p.SecretKey.Totient)
+	if err != nil {
+		return nil, err
+	}
+
+	minusOne := new(big.Int).Sub(n, crypto.One)
+	proof := &ModulusProof{
+		W: w,
+		X: make([]*big.Int, ModulusProofLength),
+		A: make([]bool, ModulusProofLength),
+		B: make([]bool, ModulusProofLength),
+		Z: make([]*big.Int, ModulusProofLength),
+	}
+	for i, yi := range y {
+		// 3. z_i = y_i^M mod N
+		if proof.Z[i], err = crypto.Exp(yi, m, n); err != nil {
+			return nil, err
+		}
+
+		// 4. pick a_i, b_i so that y'_i = (-1)^a_i w^b_i y_i is a quadratic residue mod N
+		found := false
+		for _, a := range []bool{false, true} {
+			for _, b := range []bool{false, true} {
+				yPrime := new(big.Int).Set(yi)
+				if a {
+					yPrime.Mul(yPrime, minusOne).Mod(yPrime, n)
+				}
+				if b {
+					yPrime.Mul(yPrime, w).Mod(yPrime, n)
+				}
+				if big.Jacobi(yPrime, primeP) != 1 || big.Jacobi(yPrime, primeQ) != 1 {
+					continue
+				}
+				// 5. x_i = y'_i^{1/4} mod N
+				proof.X[i] = fourthRoot(yPrime, primeP, primeQ)
+				proof.A[i], proof.B[i] = a, b
+				found = true
+				break
+			}
+			if found {
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("challenge %d has no fourth root", i)
+		}
+	}
+	return proof, nil
+}
+
+// Verify that a Paillier modulus is a well-formed Blum integer without small factors
+// [CGGMP21] fig 16
+func (p *ModulusProof) Verify(params *ModulusVerifyParams) error {
+	if p == nil || params == nil || params.PublicKey == nil || params.PublicKey.N == nil || p.W == nil {
+		return internal.ErrNilArguments
+	}
+	if len(p.X) != ModulusProofLength || len(p.A) != ModulusProofLength ||
+		len(p.B) != ModulusProofLength || len(p.Z) != ModulusProofLength {
+		return fmt.Errorf("modulus proof is not correct length: want=%v", ModulusProofLength)
+	}
+	n := params.PublicKey.N
+	if err := VerifyModulus(n); err != nil {
+		return err
+	}
+	if err := inUnits(p.W, n); err != nil {
+		return fmt.Errorf("invalid w: %w", err)
+	}
+	if big.Jacobi(p.W, n) != -1 {
+		return fmt.Errorf("w has jacobi symbol 1")
+	}
+
+	y, err := modulusChallenges(n, p.W, params.Context)
+	if err != nil {
+		return err
+	}
+	minusOne := new(big.Int).Sub(n, crypto.One)
+	four := big.NewInt(4)
+	for i, yi := range y {
+		if p.X[i] == nil || p.Z[i] == nil {
+			return internal.ErrNilArguments
+		}
+		// z_i^N = y_i mod N
+		lhs, err := crypto.Exp(p.Z[i], n, n)
+		if err != nil {
+			return err
+		}
+		if lhs.Cmp(yi) != 0 {
+			return fmt.Errorf("z is not an n-th root at %d", i)
+		}
+		// x_i^4 = (-1)^a_i w^b_i y_i mod N
+		rhs := new(big.Int).Set(yi)
+		if p.A[i] {
+			rhs.Mul(rhs, minusOne).Mod(rhs, n)
+		}
+		if p.B[i] {
+			rhs.Mul(rhs, p.W).Mod(rhs, n)
+		}
+		lhs, err = crypto.Exp(p.X[i], four, n)
+		if err != nil {
+			return err
+		}
+		if lhs.Cmp(rhs) != 0 {
+			return fmt.Errorf("x is not a fourth root at %d", i)
+		}
+	}
+	return nil
+}
+
+// VerifyModulus runs the public checks on a Paillier modulus: N must be odd, composite and have no prime factor
+// below 2^16. It does not rule out larger small factors; that needs a ModulusProof from the owner of the key and,
+// where factors must be close to √N, a proof of factor size.
+func VerifyModulus(n *big.Int) error {
+	if n == nil {
+		return internal.ErrNilArguments
+	}
+	if n.Sign() <= 0 || n.Bit(0) == 0 {
+		return fmt.Errorf("paillier modulus must be odd and positive")
+	}
+	if n.ProbablyPrime(20) {
+		return fmt.Errorf("paillier modulus is prime")
+	}
+	if new(big.Int).GCD(nil, nil, n, smallPrimeProduct()).Cmp(crypto.One) != 0 {
+		return fmt.Errorf("paillier modulus has a factor below %d", smallPrimeBound)
+	}
+	return nil
+}
+
+// factor recovers P and Q from N and 𝝋(N): P + Q = N - 𝝋(N) + 1 and P - Q = √((P + Q)² - 4N).
+func factor(n, totient *big.Int) (*big.Int, *big.Int, error) {
+	sum := new(big.Int).Sub(n, totient)
+	sum.Add(sum, crypto.One)
+	disc := new(big.Int).Mul(sum, sum)
+	disc.Sub(disc, new(big.Int).Lsh(n, 2))
+	if disc.Sign() < 0 {
+		return nil, nil, fmt.Errorf("totient does not match the modulus")
+	}
+	diff := new(big.Int).Sqrt(disc)
+	if new(big.Int).Mul(diff, diff).Cmp(disc) != 0 {
+		return nil, nil, fmt.Errorf("totient does not match the modulus")
+	}
+	p := new(big.Int).Add(sum, diff)
+	p.Rsh(p, 1)
+	q := new(big.Int).Sub(sum, diff)
+	q.Rsh(q, 1)
+	if new(big.Int).Mul(p, q).Cmp(n) != 0 {
+		return nil, nil, fmt.Errorf("totient does not match the modulus")
+	}
+	return p, q, nil
+}
+
+// fourthRoot returns a fourth root of a quadratic residue x mod N = PQ for P ≡ Q ≡ 3 mod 4.
+// Modulo such a prime x^((p+1)/4) is the square root of x that is itself a quadratic residue, so applying it twice
+// gives a fourth root; the roots mod P and mod Q are combined with the CRT.
+func fourthRoot(x, p, q *big.Int) *big.Int {
+	xp := new(big.Int).Exp(x, fourthRootExponent(p), p)
+	xq := new(big.Int).Exp(x, fourthRootExponent(q), q)
+	// x = xq + Q * ((xp - xq) * Q^{-1} mod P)
+	qInv := new(big.Int).ModInverse(q, p)
+	t := new(big.Int).Sub(xp, xq)
+	t.Mul(t, qInv)
+	t.Mod(t, p)
+	t.Mul(t, q)
+	return t.Add(t, xq)
+}
+
+// fourthRootExponent returns ((p+1)/4)² mod (p-1).
+func fourthRootExponent(p *big.Int) *big.Int {
+	d := new(big.Int).Add(p, crypto.One)
+	d.Rsh(d, 2)
+	d.Mul(d, d)
+	return d.Mod(d, new(big.Int).Sub(p, crypto.One))
+}
+
+// modulusChallenges derives ModulusProofLength challenges in Z_N* from N, w and the context.
+func modulusChallenges(n, w *big.Int, context []byte) ([]*big.Int, error) {
+	b := n.BitLen()
+	if b < 8 {
+		return nil, internal.ErrNilArguments
+	}
+	ctx := new(big.Int).SetBytes(append([]byte{1}, context...))
+	const h = 256
+	s := int64((b + h - 1) / h)
+	y := make([]*big.Int, ModulusProofLength)
+	m := big.NewInt(0)
+	for j := int64(0); j < ModulusProofLength; {
+		var ej []byte
+		for k := int64(1); k <= s; k++ {
+			res, err := crypto.FiatShamir(n, w, ctx, big.NewInt(j), big.NewInt(k), m)
+			if err != nil {
+				return nil, err
+			}
+			ej = append(ej, res...)
+		}
+		yj := new(big.Int).SetBytes(ej[:b/8])
+		if inUnits(yj, n) == nil {
+			y[j] = yj
+			j++
+			m = big.NewInt(0)
+		} else {
+			m.Add(m, crypto.One)
+		}
+	}
+	return y, nil
+}
+
+// inUnits checks that 0 < x < n and gcd(x, n) = 1.
+func inUnits(x, n *big.Int) error {
+	if x.Sign() <= 0 || x.Cmp(n) >= 0 {
+		return fmt.Errorf("value is not in the range (0, N)")
+	}
+	if new(big.Int).GCD(nil, nil, x, n).Cmp(crypto.One) != 0 {
+		return fmt.Errorf("value is not coprime to N")
+	}
+	return nil
+}
+
+var (
+	smallPrimesOnce    sync.Once
+	smallPrimesProduct *big.Int
+)
+
+// smallPrimeProduct returns the product of the odd primes below smallPrimeBound.
+func smallPrimeProduct() *big.Int {
+	smallPrimesOnce.Do(func() {
+		composite := make([]bool, smallPrimeBound)
+		smallPrimesProduct = big.NewInt(1)
+		for i := 3; i < smallPrimeBound; i += 2 {
+			if composite[i] {
+				continue
+			}
+			smallPrimesProduct.Mul(smallPrimesProduct, big.NewInt(int64(i)))
+			for j := i * i; j < smallPrimeBound; j += 2 * i {
+				composite[j] = true
+			}
+		}
+	})
+	return smallPrimesProduct
+}
@@ -0,0 +1,73 @@
+package paillier
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	crypto "github.com/go-sonr/crypto/core"
+)
+
+func TestModulusProofWorks(t *testing.T) {
+	sk, err := NewSecretKey(testPrimes[0], testPrimes[1])
+	require.NoError(t, err)
+	context := []byte("session")
+	proof, err := (&ModulusProofParams{SecretKey: sk, Context: context}).Prove()
+	require.NoError(t, err)
+	require.NoError(t, proof.Verify(&ModulusVerifyParams{PublicKey: &sk.PublicKey, Context: context}))
+
+	// the proof is bound to its context and modulus
+	require.Error(t, proof.Verify(&ModulusVerifyParams{PublicKey: &sk.PublicKey, Context: []byte("other")}))
+	other, err := NewSecretKey(testPrimes[2], testPrimes[3])
+	require.NoError(t, err)
+	require.Error(t, proof.Verify(&ModulusVerifyParams{PublicKey: &other.PublicKey, Context: context}))
+
+	// tampered roots are rejected
+	proof.X[3] = new(big.Int).Add(proof.X[3], crypto.One)
+	require.Error(t, proof.Verify(&ModulusVerifyParams{PublicKey: &sk.PublicKey, Context: context}))
+	proof.X = proof.X[1:]
+	require.Error(t, proof.Verify(&ModulusVerifyParams{PublicKey: &sk.PublicKey, Context: context}))
+}
+
+func TestModulusProofBadInputs(t *testing.T) {
+	_, err := (&ModulusProofParams{}).Prove()
+	require.Error(t, err)
+	require.Error(t, (*ModulusProof)(nil).Verify(nil))
+
+	// a totient that does not belong to N
+	sk, err := NewSecretKey(testPrimes[0], testPrimes[1])
+	require.NoError(t, err)
+	sk.Totient = new(big.Int).Sub(sk.Totient, crypto.Two)
+	_, err = (&ModulusProofParams{SecretKey: sk}).Prove()
+	require.Error(t, err)
+
+	// P ≡ 1 mod 4 is not a blum integer
+	p := big.NewInt(65537)
+	sk, err = NewSecretKey(p, testPrimes[1])
+	require.NoError(t, err)
+	_, err = (&ModulusProofParams{SecretKey: sk}).Prove()
+	require.Error(t, err)
+}
+
+func TestVerifyModulus(t *testing.T) {
+	require.NoError(t, VerifyModulus(new(big.Int).Mul(testPrimes[0], testPrimes[1])))
+	require.Error(t, VerifyModulus(nil))
+	require.Error(t, VerifyModulus(new(big.Int).Lsh(testPrimes[0], 1)))
+	require.Error(t, VerifyModulus(testPrimes[0]))
+	require.Error(t, VerifyModulus(new(big.Int).Mul(testPrimes[0], big.NewInt(65521))))
+}
+
+func TestNewKeysWithBits(t *testing.T) {
+	_, _, err := NewKeysWithBits(1024)
+	require.Error(t, err)
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+	pk, sk, err := NewKeysWithBits(ModulusBits2048)
+	require.NoError(t, err)
+	require.Equal(t, ModulusBits2048, pk.N.BitLen())
+	proof, err := (&ModulusProofParams{SecretKey: sk}).Prove()
+	require.NoError(t, err)
+	require.NoError(t, proof.Verify(&ModulusVerifyParams{PublicKey: pk}))
+}
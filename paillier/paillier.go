@@ -11,7 +11,7 @@
 //
 // This module provides APIs for:
 //
-//   - generating a safe keypair,
+//   - generating a safe keypair and proving that its modulus is well-formed,
 //   - encryption and decryption,
 //   - adding two encrypted values, Enc(a) and Enc(b), and obtaining Enc(a + b), and
 //   - multiplying a plain value, a, and an encrypted value Enc(b), and obtaining Enc(a * b).
@@ -34,6 +34,13 @@ import (
 // PaillierPrimeBits is the number of bits used to generate Paillier Safe Primes.
 const PaillierPrimeBits = 1024
 
+// Supported modulus sizes for NewKeysWithBits.
+const (
+	ModulusBits2048 = 2048
+	ModulusBits3072 = 3072
+	ModulusBits4096 = 4096
+)
+
 type (
 	// PublicKey is a Paillier public key: N = P*Q; for safe primes P,Q.
 	PublicKey struct {
@@ -54,6 +61,8 @@ type (
 		Lambda  *big.Int // lcm(P - 1, Q - 1)
 		Totient *big.Int // Euler's totient: (P - 1) * (Q - 1)
 		U       *big.Int // L((N + 1)^λ(N) mod N²)−1 mod N
+
+		crt *crtParams // precomputed from P and Q for Decrypt, nil when unknown
 	}
 
 	// SecretKeyJson encapsulates the data that is serialized to JSON.
@@ -74,6 +83,20 @@ func NewKeys() (*PublicKey, *SecretKey, error) {
 	return keyGenerator(core.GenerateSafePrime, PaillierPrimeBits)
 }
 
+// NewKeysWithBits generates Paillier keys whose modulus N = PQ has exactly `modulusBits` bits, for safe primes P, Q
+// of `modulusBits`/2 bits. The safe primes are searched for in parallel on all CPUs.
+// `modulusBits` must be one of ModulusBits2048, ModulusBits3072 or ModulusBits4096.
+func NewKeysWithBits(modulusBits uint) (*PublicKey, *SecretKey, error) {
+	switch modulusBits {
+	case ModulusBits2048, ModulusBits3072, ModulusBits4096:
+	default:
+		return nil, nil, fmt.Errorf("unsupported paillier modulus size %d", modulusBits)
+	}
+	return keyGenerator(func(bits uint) (*big.Int, error) {
+		return core.GenerateSafePrimeParallel(bits, 0)
+	}, modulusBits/2)
+}
+
 // keyGenerator generates Paillier keys with `bits` sized safe primes using function
 // `genSafePrime` to generate the safe primes.
 func keyGenerator(genSafePrime func(uint) (*big.Int, error), bits uint) (*PublicKey, *SecretKey, error) {
@@ -82,7 +105,7 @@ func keyGenerator(genSafePrime func(uint) (*big.Int, error), bits uint) (*Public
 
 	var p, q *big.Int
 
-	for p == q {
+	for p == nil || p.Cmp(q) == 0 {
 		for range []int{1, 2} {
 			go func() {
 				value, err := genSafePrime(bits)
@@ -141,7 +164,10 @@ func NewSecretKey(p, q *big.Int) (*SecretKey, error) {
 	// L((N+1)^λ(N) mod N²)^-1 mod N
 	u.ModInverse(u, n)
 
-	return &SecretKey{pk, lambda, totient, u}, nil
+	sk := &SecretKey{PublicKey: pk, Lambda: lambda, Totient: totient, U: u}
+	// p and q that are not distinct odd primes still decrypt, only without the CRT
+	sk.crt, _ = newCrtParams(p, q)
+	return sk, nil
 }
 
 // MarshalJSON converts the public key into json format.
@@ -329,6 +355,14 @@ func (sk *SecretKey) Decrypt(c Ciphertext) (*big.Int, error) {
 		return nil, err
 	}
 
+	if sk.crt != nil {
+		return sk.crt.decrypt(c)
+	}
+	return sk.decrypt(c)
+}
+
+// decrypt is Decrypt without the CRT.
+func (sk *SecretKey) decrypt(c Ciphertext) (*big.Int, error) {
 	// Compute the msg in components
 	// ɑ ≡ c^{λ(N)}		mod N²
 	ɑ := new(big.Int).Exp(c, sk.Lambda, sk.N2)
@@ -372,5 +406,10 @@ func (sk *SecretKey) UnmarshalJSON(bytes []byte) error {
 	sk.U = data.U
 	sk.Totient = data.Totient
 	sk.Lambda = data.Lambda
+	sk.crt = nil
+	if sk.N != nil && sk.Totient != nil {
+		// a key that does not factor still decrypts, only without the CRT
+		_ = sk.Precompute()
+	}
 	return nil
 }
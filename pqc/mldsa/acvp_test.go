@@ -0,0 +1,158 @@
+package mldsa
+
+import (
+	"compress/gzip"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+)
+
+// acvpVectors are vectors of the ML-DSA FIPS204 vector sets of the NIST
+// ACVP server, https://github.com/usnistgov/ACVP-Server, a few of each
+// parameter set. The signing and verification vectors are of the internal
+// functions, whose message is M' itself.
+type acvpVectors struct {
+	KeyGen []struct {
+		ParameterSet string
+		Seed, Pk, Sk string
+	}
+	SigGen []struct {
+		ParameterSet                string
+		Sk, Message, Rnd, Signature string
+	}
+	SigVer []struct {
+		ParameterSet string
+		Pk           string
+		Tests        []struct {
+			Message, Signature string
+			TestPassed         bool
+		}
+	}
+}
+
+func loadACVP(t *testing.T) *acvpVectors {
+	f, err := os.Open("testdata/acvp.json.gz")
+	require.NoError(t, err)
+	defer f.Close()
+	r, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	vectors := new(acvpVectors)
+	require.NoError(t, json.NewDecoder(r).Decode(vectors))
+	return vectors
+}
+
+func parameterSetByName(t *testing.T, name string) *ParameterSet {
+	for _, p := range parameterSets {
+		if p.Name == name {
+			return p
+		}
+	}
+	t.Fatalf("unknown parameter set %s", name)
+	return nil
+}
+
+func unhex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+// internalMu is mu of ML-DSA.Sign_internal for the message M'
+func internalMu(tr []byte, message []byte) [64]byte {
+	var mu [64]byte
+	h := sha3.NewShake256()
+	_, _ = h.Write(tr)
+	_, _ = h.Write(message)
+	_, _ = h.Read(mu[:])
+	return mu
+}
+
+func (p *ParameterSet) etaBits() int {
+	if p.eta == 2 {
+		return 3
+	}
+	return 4
+}
+
+// encodeExpanded is skEncode of FIPS 204 algorithm 24
+func (sk *PrivateKey) encodeExpanded() []byte {
+	p := sk.pk.params
+	b := append([]byte(nil), sk.pk.rho[:]...)
+	b = append(b, sk.k[:]...)
+	b = append(b, sk.pk.tr[:]...)
+	for _, s := range append(append([]ringElement(nil), sk.s1...), sk.s2...) {
+		s = inverseNTT(s)
+		b = bitPack(b, &s, p.etaBits(), int32(p.eta))
+	}
+	for _, t0 := range sk.t0 {
+		t0 = inverseNTT(t0)
+		b = bitPack(b, &t0, d, 1<<(d-1))
+	}
+	return b
+}
+
+// decodeExpanded is skDecode of FIPS 204 algorithm 25, for the signing
+// vectors that give the expanded private key instead of its seed
+func (p *ParameterSet) decodeExpanded(b []byte) *PrivateKey {
+	pk := &PublicKey{params: p}
+	sk := &PrivateKey{pk: pk}
+	copy(pk.rho[:], b[:32])
+	copy(sk.k[:], b[32:64])
+	copy(pk.tr[:], b[64:128])
+	b = b[128:]
+	size := n * p.etaBits() / 8
+	for i := 0; i < p.l+p.k; i++ {
+		s := ntt(bitUnpack(b[:size], p.etaBits(), int32(p.eta)))
+		if i < p.l {
+			sk.s1 = append(sk.s1, s)
+		} else {
+			sk.s2 = append(sk.s2, s)
+		}
+		b = b[size:]
+	}
+	size = n * d / 8
+	for i := 0; i < p.k; i++ {
+		sk.t0 = append(sk.t0, ntt(bitUnpack(b[:size], d, 1<<(d-1))))
+		b = b[size:]
+	}
+	sk.a = p.expandA(pk.rho[:])
+	return sk
+}
+
+func TestACVP(t *testing.T) {
+	vectors := loadACVP(t)
+	require.NotEmpty(t, vectors.KeyGen)
+	require.NotEmpty(t, vectors.SigGen)
+	require.NotEmpty(t, vectors.SigVer)
+
+	for _, v := range vectors.KeyGen {
+		p := parameterSetByName(t, v.ParameterSet)
+		sk, err := p.NewPrivateKey(unhex(t, v.Seed))
+		require.NoError(t, err)
+		require.Equal(t, unhex(t, v.Pk), sk.PublicKey().Bytes(), p.Name)
+		require.Equal(t, unhex(t, v.Sk), sk.encodeExpanded(), p.Name)
+	}
+
+	for _, v := range vectors.SigGen {
+		p := parameterSetByName(t, v.ParameterSet)
+		sk := p.decodeExpanded(unhex(t, v.Sk))
+		var rnd [32]byte
+		copy(rnd[:], unhex(t, v.Rnd))
+		sig := sk.signInternal(internalMu(sk.pk.tr[:], unhex(t, v.Message)), &rnd)
+		require.Equal(t, unhex(t, v.Signature), sig, p.Name)
+	}
+
+	for _, g := range vectors.SigVer {
+		p := parameterSetByName(t, g.ParameterSet)
+		pk, err := p.NewPublicKey(unhex(t, g.Pk))
+		require.NoError(t, err)
+		for _, v := range g.Tests {
+			ok := pk.verifyInternal(internalMu(pk.tr[:], unhex(t, v.Message)), unhex(t, v.Signature))
+			require.Equal(t, v.TestPassed, ok, p.Name)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package mldsa
+
+import (
+	"crypto/ed25519"
+	"crypto/sha512"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/go-sonr/crypto/core/secret"
+	"github.com/go-sonr/crypto/internal"
+)
+
+// Composite is a signature scheme combining ML-DSA with Ed25519, a signature
+// only verifies when both components do, so it stays unforgeable as long as
+// one of the two is unbroken. It follows the construction of
+// draft-ietf-lamps-pq-composite-sigs: both components sign
+//
+//	M' = Prefix || Label || len(ctx) || ctx || SHA-512(M)
+//
+// ML-DSA with Label as its context, which binds the components together so
+// neither signature can be stripped and verified on its own. Public keys are
+// pk_M || pk_E and signatures sig_M || sig_E. As for the hybrid KEMs of
+// pqc/mlkem both private keys are expanded from one 32 byte seed with SHAKE256.
+type Composite struct {
+	// Name is the name of the composite scheme
+	Name string
+	// PublicKeySize is the size of pk_M || pk_E
+	PublicKeySize int
+	// SignatureSize is the size of sig_M || sig_E
+	SignatureSize int
+
+	dsa   *ParameterSet
+	label []byte
+}
+
+// CompositeSeedSize is the size of a composite private key seed
+const CompositeSeedSize = 32
+
+var compositePrefix = []byte("CompositeAlgorithmSignatures2025")
+
+func newComposite(name, label string, dsa *ParameterSet) *Composite {
+	return &Composite{
+		Name:          name,
+		PublicKeySize: dsa.PublicKeySize + ed25519.PublicKeySize,
+		SignatureSize: dsa.SignatureSize + ed25519.SignatureSize,
+		dsa:           dsa,
+		label:         []byte(label),
+	}
+}
+
+var (
+	// MLDSA44Ed25519 combines ML-DSA-44 and Ed25519
+	MLDSA44Ed25519 = newComposite("ML-DSA-44-Ed25519", "COMPSIG-MLDSA44-Ed25519-SHA512", MLDSA44)
+	// MLDSA65Ed25519 combines ML-DSA-65 and Ed25519
+	MLDSA65Ed25519 = newComposite("ML-DSA-65-Ed25519", "COMPSIG-MLDSA65-Ed25519-SHA512", MLDSA65)
+)
+
+// CompositePublicKey is the public key of a composite scheme
+type CompositePublicKey struct {
+	composite *Composite
+	mldsa     *PublicKey
+	ed25519   ed25519.PublicKey
+}
+
+// CompositePrivateKey is the private key of a composite scheme
+type CompositePrivateKey struct {
+	seed    [CompositeSeedSize]byte
+	mldsa   *PrivateKey
+	ed25519 ed25519.PrivateKey
+	pk      *CompositePublicKey
+}
+
+// GenerateKey draws a private key seed from reader
+func (c *Composite) GenerateKey(reader io.Reader) (*CompositePrivateKey, error) {
+	if reader == nil {
+		return nil, internal.ErrNilArguments
+	}
+	var seed [CompositeSeedSize]byte
+	if _, err := io.ReadFull(reader, seed[:]); err != nil {
+		return nil, err
+	}
+	defer secret.Wipe(seed[:])
+	return c.NewPrivateKey(seed[:])
+}
+
+// NewPrivateKey expands the ML-DSA and the Ed25519 seeds from seed
+func (c *Composite) NewPrivateKey(seed []byte) (*CompositePrivateKey, error) {
+	if len(seed) != CompositeSeedSize {
+		return nil, fmt.Errorf("%s seed must be %d bytes", c.Name, CompositeSeedSize)
+	}
+	var expanded [SeedSize + ed25519.SeedSize]byte
+	defer secret.Wipe(expanded[:])
+	sha3.ShakeSum256(expanded[:], seed)
+
+	skM, err := c.dsa.NewPrivateKey(expanded[:SeedSize])
+	if err != nil {
+		return nil, err
+	}
+	skE := ed25519.NewKeyFromSeed(expanded[SeedSize:])
+	sk := &CompositePrivateKey{mldsa: skM, ed25519: skE}
+	copy(sk.seed[:], seed)
+	sk.pk = &CompositePublicKey{
+		composite: c,
+		mldsa:     skM.PublicKey(),
+		ed25519:   skE.Public().(ed25519.PublicKey),
+	}
+	return sk, nil
+}
+
+// NewPublicKey decodes pk_M || pk_E
+func (c *Composite) NewPublicKey(b []byte) (*CompositePublicKey, error) {
+	if len(b) != c.PublicKeySize {
+		return nil, fmt.Errorf("%s public key must be %d bytes", c.Name, c.PublicKeySize)
+	}
+	pkM, err := c.dsa.NewPublicKey(b[:c.dsa.PublicKeySize])
+	if err != nil {
+		return nil, err
+	}
+	pkE := append(ed25519.PublicKey{}, b[c.dsa.PublicKeySize:]...)
+	return &CompositePublicKey{composite: c, mldsa: pkM, ed25519: pkE}, nil
+}
+
+// message computes M' = Prefix || Label || len(ctx) || ctx || SHA-512(M)
+func (c *Composite) message(message, context []byte) ([]byte, error) {
+	if len(context) > MaxContextSize {
+		return nil, fmt.Errorf("context must be at most %d bytes", MaxContextSize)
+	}
+	digest := sha512.Sum512(message)
+	m := make([]byte, 0, len(compositePrefix)+len(c.label)+1+len(context)+len(digest))
+	m = append(m, compositePrefix...)
+	m = append(m, c.label...)
+	m = append(m, byte(len(context)))
+	m = append(m, context...)
+	return append(m, digest[:]...), nil
+}
+
+// Composite returns the scheme of the key
+func (pk *CompositePublicKey) Composite() *Composite {
+	return pk.composite
+}
+
+// Bytes returns pk_M || pk_E
+func (pk *CompositePublicKey) Bytes() []byte {
+	return append(pk.mldsa.Bytes(), pk.ed25519...)
+}
+
+// MLDSA returns the ML-DSA component of the key
+func (pk *CompositePublicKey) MLDSA() *PublicKey {
+	return pk.mldsa
+}
+
+// Ed25519 returns the Ed25519 component of the key
+func (pk *CompositePublicKey) Ed25519() ed25519.PublicKey {
+	return pk.ed25519
+}
+
+// Equal reports whether pk and other are the same key
+func (pk *CompositePublicKey) Equal(other *CompositePublicKey) bool {
+	return pk.composite == other.composite && pk.mldsa.Equal(other.mldsa) && pk.ed25519.Equal(other.ed25519)
+}
+
+// Verify checks a composite signature of message under context, both
+// components are always checked
+func (pk *CompositePublicKey) Verify(message, signature, context []byte) error {
+	c := pk.composite
+	if len(signature) != c.SignatureSize {
+		return fmt.Errorf("%s signature must be %d bytes", c.Name, c.SignatureSize)
+	}
+	m, err := c.message(message, context)
+	if err != nil {
+		return err
+	}
+	errM := pk.mldsa.Verify(m, signature[:c.dsa.SignatureSize], c.label)
+	validE := ed25519.Verify(pk.ed25519, m, signature[c.dsa.SignatureSize:])
+	if errM != nil || !validE {
+		return fmt.Errorf("invalid %s signature", c.Name)
+	}
+	return nil
+}
+
+// Composite returns the scheme of the key
+func (sk *CompositePrivateKey) Composite() *Composite {
+	return sk.pk.composite
+}
+
+// Bytes returns the seed of the key
+func (sk *CompositePrivateKey) Bytes() []byte {
+	return append([]byte{}, sk.seed[:]...)
+}
+
+// PublicKey returns the public key of sk
+func (sk *CompositePrivateKey) PublicKey() *CompositePublicKey {
+	return sk.pk
+}
+
+// Zeroize overwrites the seed and both component keys with zeros
+func (sk *CompositePrivateKey) Zeroize() {
+	secret.Wipe(sk.seed[:])
+	secret.Wipe(sk.ed25519)
+	sk.mldsa.Zeroize()
+}
+
+// Sign returns sig_M || sig_E over message under context, the ML-DSA component
+// is hedged with randomness drawn from reader
+func (sk *CompositePrivateKey) Sign(reader io.Reader, message, context []byte) ([]byte, error) {
+	c := sk.pk.composite
+	m, err := c.message(message, context)
+	if err != nil {
+		return nil, err
+	}
+	sigM, err := sk.mldsa.Sign(reader, m, c.label)
+	if err != nil {
+		return nil, err
+	}
+	return append(sigM, ed25519.Sign(sk.ed25519, m)...), nil
+}
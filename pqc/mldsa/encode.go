@@ -0,0 +1,102 @@
+package mldsa
+
+// Bit packing of FIPS 204 section 7.1, coefficients are packed little endian
+// with the first coefficient in the lowest bits.
+
+// simpleBitPack is algorithm 16 of FIPS 204 for coefficients in [0, 2^bits)
+func simpleBitPack(dst []byte, w []uint32, bits int) []byte {
+	var acc uint64
+	var accBits int
+	for _, c := range w {
+		acc |= uint64(c) << accBits
+		accBits += bits
+		for accBits >= 8 {
+			dst = append(dst, byte(acc))
+			acc >>= 8
+			accBits -= 8
+		}
+	}
+	return dst
+}
+
+// simpleBitUnpack is algorithm 18 of FIPS 204
+func simpleBitUnpack(b []byte, bits int) [n]uint32 {
+	var w [n]uint32
+	var acc uint64
+	var accBits int
+	mask := uint64(1)<<bits - 1
+	i := 0
+	for _, x := range b {
+		acc |= uint64(x) << accBits
+		accBits += 8
+		for accBits >= bits && i < n {
+			w[i] = uint32(acc & mask)
+			acc >>= bits
+			accBits -= bits
+			i++
+		}
+	}
+	return w
+}
+
+// bitPack is algorithm 17 of FIPS 204, it packs the coefficients of f in
+// [-a, b] as b - f_i
+func bitPack(dst []byte, f *ringElement, bits int, b int32) []byte {
+	var w [n]uint32
+	for i, c := range f {
+		w[i] = uint32(b - centered(c))
+	}
+	return simpleBitPack(dst, w[:], bits)
+}
+
+// bitUnpack is algorithm 19 of FIPS 204
+func bitUnpack(v []byte, bits int, b int32) ringElement {
+	w := simpleBitUnpack(v, bits)
+	var f ringElement
+	for i := range f {
+		f[i] = fieldFromInt(b - int32(w[i]))
+	}
+	return f
+}
+
+// hintBitPack is algorithm 20 of FIPS 204
+func (p *ParameterSet) hintBitPack(dst []byte, h [][n]byte) []byte {
+	y := make([]byte, p.omega+p.k)
+	index := 0
+	for i := range h {
+		for j, bit := range h[i] {
+			if bit != 0 {
+				y[index] = byte(j)
+				index++
+			}
+		}
+		y[p.omega+i] = byte(index)
+	}
+	return append(dst, y...)
+}
+
+// hintBitUnpack is algorithm 21 of FIPS 204, it rejects every non canonical
+// encoding so that signatures are not malleable
+func (p *ParameterSet) hintBitUnpack(y []byte) ([][n]byte, bool) {
+	h := make([][n]byte, p.k)
+	index := 0
+	for i := range h {
+		end := int(y[p.omega+i])
+		if end < index || end > p.omega {
+			return nil, false
+		}
+		first := index
+		for ; index < end; index++ {
+			if index > first && y[index-1] >= y[index] {
+				return nil, false
+			}
+			h[i][y[index]] = 1
+		}
+	}
+	for ; index < p.omega; index++ {
+		if y[index] != 0 {
+			return nil, false
+		}
+	}
+	return h, true
+}
@@ -0,0 +1,204 @@
+package mldsa
+
+// Arithmetic in Z_q and in the ring R_q = Z_q[X]/(X^256 + 1) of FIPS 204
+// section 7.5. Coefficients are kept reduced in [0, q).
+
+const (
+	q = 8380417
+	n = 256
+	d = 13
+
+	// invN is 256^-1 mod q, the scale factor of the inverse NTT
+	invN = 8347681
+	// zeta is the 512-th root of unity of FIPS 204
+	zeta = 1753
+)
+
+type fieldElement uint32
+
+// ringElement is a polynomial of R_q, or its NTT representation in T_q
+type ringElement [n]fieldElement
+
+// fieldReduceOnce maps a in [0, 2q) to [0, q)
+func fieldReduceOnce(a uint32) fieldElement {
+	x := a - q
+	// x underflowed iff a < q, in which case its top bit is set
+	x += q & uint32(int32(x)>>31)
+	return fieldElement(x)
+}
+
+func fieldAdd(a, b fieldElement) fieldElement {
+	return fieldReduceOnce(uint32(a + b))
+}
+
+func fieldSub(a, b fieldElement) fieldElement {
+	return fieldReduceOnce(uint32(a - b + q))
+}
+
+func fieldMul(a, b fieldElement) fieldElement {
+	// division by the constant q compiles to a multiplication
+	return fieldElement(uint64(a) * uint64(b) % q)
+}
+
+// fieldFromInt maps a in (-q, q) to Z_q
+func fieldFromInt(a int32) fieldElement {
+	return fieldElement(a + q&(a>>31))
+}
+
+// centered returns the representative of a in (-(q-1)/2, (q-1)/2]
+func centered(a fieldElement) int32 {
+	x := int32(a)
+	return x - q&(((q-1)/2-x)>>31)
+}
+
+// infinityNorm returns |a| of the centered representative
+func infinityNorm(a fieldElement) uint32 {
+	x := centered(a)
+	mask := x >> 31
+	return uint32((x ^ mask) - mask)
+}
+
+// polyInfinityNormAtLeast reports whether a coefficient of f has norm >= bound
+func polyInfinityNormAtLeast(f *ringElement, bound uint32) bool {
+	var over uint32
+	for _, c := range f {
+		over |= (bound - 1 - infinityNorm(c)) >> 31
+	}
+	return over == 1
+}
+
+var zetas [n]fieldElement
+
+func init() {
+	var powers [n]fieldElement
+	powers[0] = 1
+	for i := 1; i < n; i++ {
+		powers[i] = fieldMul(powers[i-1], zeta)
+	}
+	for i := range zetas {
+		zetas[i] = powers[bitRev8(uint8(i))]
+	}
+}
+
+func bitRev8(x uint8) uint8 {
+	var r uint8
+	for i := 0; i < 8; i++ {
+		r = r<<1 | x>>i&1
+	}
+	return r
+}
+
+func polyAdd(a, b *ringElement) ringElement {
+	var out ringElement
+	for i := range out {
+		out[i] = fieldAdd(a[i], b[i])
+	}
+	return out
+}
+
+func polySub(a, b *ringElement) ringElement {
+	var out ringElement
+	for i := range out {
+		out[i] = fieldSub(a[i], b[i])
+	}
+	return out
+}
+
+// nttMulAdd sets acc += f * g in T_q
+func nttMulAdd(acc, f, g *ringElement) {
+	for i := range acc {
+		acc[i] = fieldAdd(acc[i], fieldMul(f[i], g[i]))
+	}
+}
+
+func nttMul(f, g *ringElement) ringElement {
+	var out ringElement
+	nttMulAdd(&out, f, g)
+	return out
+}
+
+// ntt is algorithm 41 of FIPS 204
+func ntt(w ringElement) ringElement {
+	m := 0
+	for length := 128; length >= 1; length /= 2 {
+		for start := 0; start < n; start += 2 * length {
+			m++
+			z := zetas[m]
+			for j := start; j < start+length; j++ {
+				t := fieldMul(z, w[j+length])
+				w[j+length] = fieldSub(w[j], t)
+				w[j] = fieldAdd(w[j], t)
+			}
+		}
+	}
+	return w
+}
+
+// inverseNTT is algorithm 42 of FIPS 204
+func inverseNTT(w ringElement) ringElement {
+	m := n
+	for length := 1; length < n; length *= 2 {
+		for start := 0; start < n; start += 2 * length {
+			m--
+			z := q - zetas[m]
+			for j := start; j < start+length; j++ {
+				t := w[j]
+				w[j] = fieldAdd(t, w[j+length])
+				w[j+length] = fieldMul(z, fieldSub(t, w[j+length]))
+			}
+		}
+	}
+	for j := range w {
+		w[j] = fieldMul(w[j], invN)
+	}
+	return w
+}
+
+// power2Round is algorithm 35 of FIPS 204, r = r1 * 2^d + r0 with r0 in (-2^(d-1), 2^(d-1)]
+func power2Round(r fieldElement) (uint32, fieldElement) {
+	x := int32(r)
+	r1 := (x + 1<<(d-1) - 1) >> d
+	return uint32(r1), fieldFromInt(x - r1<<d)
+}
+
+// decompose is algorithm 36 of FIPS 204, r = r1 * 2*gamma2 + r0 with r0 in
+// (-gamma2, gamma2], following the branch free reference implementation
+func decompose(r fieldElement, gamma2 uint32) (uint32, int32) {
+	x := int32(r)
+	r1 := (x + 127) >> 7
+	if gamma2 == (q-1)/32 {
+		r1 = (r1*1025 + 1<<21) >> 22
+		r1 &= 15
+	} else {
+		r1 = (r1*11275 + 1<<23) >> 24
+		r1 ^= ((43 - r1) >> 31) & r1
+	}
+	r0 := x - r1*2*int32(gamma2)
+	r0 -= ((q-1)/2 - r0) >> 31 & q
+	return uint32(r1), r0
+}
+
+// highBits is algorithm 37 of FIPS 204
+func highBits(r fieldElement, gamma2 uint32) uint32 {
+	r1, _ := decompose(r, gamma2)
+	return r1
+}
+
+// lowBits is algorithm 38 of FIPS 204
+func lowBits(r fieldElement, gamma2 uint32) int32 {
+	_, r0 := decompose(r, gamma2)
+	return r0
+}
+
+// useHint is algorithm 40 of FIPS 204, it runs on public data only
+func useHint(h byte, r fieldElement, gamma2 uint32) uint32 {
+	r1, r0 := decompose(r, gamma2)
+	if h == 0 {
+		return r1
+	}
+	m := (q - 1) / (2 * gamma2)
+	if r0 > 0 {
+		return (r1 + 1) % m
+	}
+	return (r1 + m - 1) % m
+}
@@ -0,0 +1,444 @@
+// Package mldsa implements ML-DSA, the module-lattice-based digital signature
+// algorithm of FIPS 204 https://doi.org/10.6028/NIST.FIPS.204, with the
+// ML-DSA-44, ML-DSA-65 and ML-DSA-87 parameter sets, and composite signatures
+// combining ML-DSA with Ed25519.
+//
+// Only pure ML-DSA with an optional context string is provided, HashML-DSA is
+// not. Private keys are stored as the 32 byte seed xi of FIPS 204 algorithm 6,
+// the same encoding as the crypto/mldsa package of the standard library.
+package mldsa
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/go-sonr/crypto/core/secret"
+	"github.com/go-sonr/crypto/internal"
+)
+
+const (
+	// SeedSize is the size of a private key seed
+	SeedSize = 32
+	// MaxContextSize is the largest context string accepted by Sign and Verify
+	MaxContextSize = 255
+
+	// t1Bits is the width of the packed coefficients of t1
+	t1Bits = 23 - d
+	// trSize is the size of the public key hash tr
+	trSize = 64
+)
+
+// ParameterSet is one of the ML-DSA parameter sets of FIPS 204 section 4
+type ParameterSet struct {
+	// Name is the FIPS 204 name of the parameter set
+	Name string
+	// PublicKeySize is the size of an encoded public key
+	PublicKeySize int
+	// SignatureSize is the size of a signature
+	SignatureSize int
+
+	k, l       int
+	eta        int
+	tau        int
+	beta       int32
+	gamma1Bits int
+	gamma2     uint32
+	omega      int
+	cTildeSize int
+}
+
+func newParameterSet(name string, k, l, eta, tau, gamma1Bits int, gamma2 uint32, omega, lambda int) *ParameterSet {
+	return &ParameterSet{
+		Name:          name,
+		PublicKeySize: 32 + k*n*t1Bits/8,
+		SignatureSize: lambda/4 + l*n*(gamma1Bits+1)/8 + omega + k,
+		k:             k,
+		l:             l,
+		eta:           eta,
+		tau:           tau,
+		beta:          int32(tau * eta),
+		gamma1Bits:    gamma1Bits,
+		gamma2:        gamma2,
+		omega:         omega,
+		cTildeSize:    lambda / 4,
+	}
+}
+
+var (
+	// MLDSA44 is ML-DSA-44, security category 2
+	MLDSA44 = newParameterSet("ML-DSA-44", 4, 4, 2, 39, 17, (q-1)/88, 80, 128)
+	// MLDSA65 is ML-DSA-65, security category 3
+	MLDSA65 = newParameterSet("ML-DSA-65", 6, 5, 4, 49, 19, (q-1)/32, 55, 192)
+	// MLDSA87 is ML-DSA-87, security category 5
+	MLDSA87 = newParameterSet("ML-DSA-87", 8, 7, 2, 60, 19, (q-1)/32, 75, 256)
+)
+
+// PublicKey is an ML-DSA public key
+type PublicKey struct {
+	params *ParameterSet
+	rho    [32]byte
+	t1     []ringElement // NTT(t1 * 2^d)
+	tr     [trSize]byte
+	raw    []byte
+}
+
+// PrivateKey is an ML-DSA private key
+type PrivateKey struct {
+	seed [SeedSize]byte
+	k    [32]byte
+	s1   []ringElement // NTT(s1)
+	s2   []ringElement // NTT(s2)
+	t0   []ringElement // NTT(t0)
+	a    [][]ringElement
+	pk   *PublicKey
+}
+
+// GenerateKey draws a private key seed from reader
+func (p *ParameterSet) GenerateKey(reader io.Reader) (*PrivateKey, error) {
+	if reader == nil {
+		return nil, internal.ErrNilArguments
+	}
+	var seed [SeedSize]byte
+	if _, err := io.ReadFull(reader, seed[:]); err != nil {
+		return nil, err
+	}
+	defer secret.Wipe(seed[:])
+	return p.NewPrivateKey(seed[:])
+}
+
+// NewPrivateKey derives the key pair of seed, ML-DSA.KeyGen_internal of
+// FIPS 204 algorithm 6
+func (p *ParameterSet) NewPrivateKey(seed []byte) (*PrivateKey, error) {
+	if len(seed) != SeedSize {
+		return nil, fmt.Errorf("%s seed must be %d bytes", p.Name, SeedSize)
+	}
+	sk := &PrivateKey{t0: make([]ringElement, p.k)}
+	copy(sk.seed[:], seed)
+	pk := &PublicKey{params: p, t1: make([]ringElement, p.k)}
+
+	var expanded [128]byte
+	h := sha3.NewShake256()
+	_, _ = h.Write(seed)
+	_, _ = h.Write([]byte{byte(p.k), byte(p.l)})
+	_, _ = h.Read(expanded[:])
+	defer secret.Wipe(expanded[:])
+	rho, rhoPrime := expanded[:32], expanded[32:96]
+	copy(pk.rho[:], rho)
+	copy(sk.k[:], expanded[96:])
+
+	sk.a = p.expandA(rho)
+	s1, s2 := p.expandS(rhoPrime)
+	for i := range s1 {
+		s1[i] = ntt(s1[i])
+	}
+	sk.s1 = s1
+
+	pk.raw = make([]byte, 0, p.PublicKeySize)
+	pk.raw = append(pk.raw, rho...)
+	for i := 0; i < p.k; i++ {
+		var t ringElement
+		for j := range s1 {
+			nttMulAdd(&t, &sk.a[i][j], &s1[j])
+		}
+		t = inverseNTT(t)
+		t = polyAdd(&t, &s2[i])
+
+		var t1 [n]uint32
+		var t0 ringElement
+		for j := range t {
+			t1[j], t0[j] = power2Round(t[j])
+		}
+		pk.raw = simpleBitPack(pk.raw, t1[:], t1Bits)
+		pk.t1[i] = ntt(scaleT1(&t1))
+		sk.t0[i] = ntt(t0)
+		s2[i] = ntt(s2[i])
+	}
+	sk.s2 = s2
+
+	h = sha3.NewShake256()
+	_, _ = h.Write(pk.raw)
+	_, _ = h.Read(pk.tr[:])
+	sk.pk = pk
+	return sk, nil
+}
+
+// scaleT1 returns t1 * 2^d as a polynomial
+func scaleT1(t1 *[n]uint32) ringElement {
+	var f ringElement
+	for i, c := range t1 {
+		f[i] = fieldElement(c << d)
+	}
+	return f
+}
+
+// NewPublicKey decodes a public key, pkDecode of FIPS 204 algorithm 23
+func (p *ParameterSet) NewPublicKey(b []byte) (*PublicKey, error) {
+	if len(b) != p.PublicKeySize {
+		return nil, fmt.Errorf("%s public key must be %d bytes", p.Name, p.PublicKeySize)
+	}
+	pk := &PublicKey{params: p, t1: make([]ringElement, p.k), raw: append([]byte{}, b...)}
+	copy(pk.rho[:], b)
+	size := n * t1Bits / 8
+	for i := range pk.t1 {
+		t1 := simpleBitUnpack(b[32+i*size:32+(i+1)*size], t1Bits)
+		pk.t1[i] = ntt(scaleT1(&t1))
+	}
+	h := sha3.NewShake256()
+	_, _ = h.Write(pk.raw)
+	_, _ = h.Read(pk.tr[:])
+	return pk, nil
+}
+
+// ParameterSet returns the parameter set of the key
+func (pk *PublicKey) ParameterSet() *ParameterSet {
+	return pk.params
+}
+
+// Bytes returns the encoded public key
+func (pk *PublicKey) Bytes() []byte {
+	return append([]byte{}, pk.raw...)
+}
+
+// Equal reports whether pk and other are the same key
+func (pk *PublicKey) Equal(other *PublicKey) bool {
+	return pk.params == other.params && subtle.ConstantTimeCompare(pk.raw, other.raw) == 1
+}
+
+// ParameterSet returns the parameter set of the key
+func (sk *PrivateKey) ParameterSet() *ParameterSet {
+	return sk.pk.params
+}
+
+// Bytes returns the seed of the key
+func (sk *PrivateKey) Bytes() []byte {
+	return append([]byte{}, sk.seed[:]...)
+}
+
+// PublicKey returns the public key of sk
+func (sk *PrivateKey) PublicKey() *PublicKey {
+	return sk.pk
+}
+
+// Zeroize overwrites the seed and the secret vectors with zeros
+func (sk *PrivateKey) Zeroize() {
+	secret.Wipe(sk.seed[:])
+	secret.Wipe(sk.k[:])
+	for _, v := range [][]ringElement{sk.s1, sk.s2, sk.t0} {
+		for i := range v {
+			clear(v[i][:])
+		}
+	}
+}
+
+// Sign returns the hedged signature of message under context, drawing the
+// 32 bytes of per signature randomness from reader
+func (sk *PrivateKey) Sign(reader io.Reader, message, context []byte) ([]byte, error) {
+	if reader == nil {
+		return nil, internal.ErrNilArguments
+	}
+	var rnd [32]byte
+	if _, err := io.ReadFull(reader, rnd[:]); err != nil {
+		return nil, err
+	}
+	return sk.sign(message, context, &rnd)
+}
+
+// SignDeterministic returns the deterministic signature of message under
+// context, the variant of FIPS 204 section 3.4 with rnd = {0}^32
+func (sk *PrivateKey) SignDeterministic(message, context []byte) ([]byte, error) {
+	var rnd [32]byte
+	return sk.sign(message, context, &rnd)
+}
+
+func (sk *PrivateKey) sign(message, context []byte, rnd *[32]byte) ([]byte, error) {
+	if len(context) > MaxContextSize {
+		return nil, fmt.Errorf("context must be at most %d bytes", MaxContextSize)
+	}
+	return sk.signInternal(sk.pk.mu(message, context), rnd), nil
+}
+
+// mu computes H(tr || M', 64) with M' = 0 || |ctx| || ctx || M the message
+// encoding of pure ML-DSA, FIPS 204 algorithms 2 and 3
+func (pk *PublicKey) mu(message, context []byte) [64]byte {
+	var mu [64]byte
+	h := sha3.NewShake256()
+	_, _ = h.Write(pk.tr[:])
+	_, _ = h.Write([]byte{0, byte(len(context))})
+	_, _ = h.Write(context)
+	_, _ = h.Write(message)
+	_, _ = h.Read(mu[:])
+	return mu
+}
+
+// signInternal is ML-DSA.Sign_internal of FIPS 204 algorithm 7
+func (sk *PrivateKey) signInternal(mu [64]byte, rnd *[32]byte) []byte {
+	p := sk.pk.params
+	gamma1 := int32(1) << p.gamma1Bits
+
+	var rhoPrime [64]byte
+	h := sha3.NewShake256()
+	_, _ = h.Write(sk.k[:])
+	_, _ = h.Write(rnd[:])
+	_, _ = h.Write(mu[:])
+	_, _ = h.Read(rhoPrime[:])
+	defer secret.Wipe(rhoPrime[:])
+
+	w1Buf := make([]byte, 0, p.k*n*p.w1Bits()/8)
+	cTilde := make([]byte, p.cTildeSize)
+	z := make([]ringElement, p.l)
+	hint := make([][n]byte, p.k)
+	for kappa := 0; ; kappa += p.l {
+		y := p.expandMask(rhoPrime[:], kappa)
+		yHat := make([]ringElement, p.l)
+		for i := range y {
+			yHat[i] = ntt(y[i])
+		}
+		w := make([]ringElement, p.k)
+		w1Buf = w1Buf[:0]
+		for i := range w {
+			for j := range yHat {
+				nttMulAdd(&w[i], &sk.a[i][j], &yHat[j])
+			}
+			w[i] = inverseNTT(w[i])
+			var w1 [n]uint32
+			for j, c := range w[i] {
+				w1[j] = highBits(c, p.gamma2)
+			}
+			w1Buf = simpleBitPack(w1Buf, w1[:], p.w1Bits())
+		}
+
+		h = sha3.NewShake256()
+		_, _ = h.Write(mu[:])
+		_, _ = h.Write(w1Buf)
+		_, _ = h.Read(cTilde)
+		c := sampleInBall(cTilde, p.tau)
+		cHat := ntt(c)
+
+		// rejections only reveal that an attempt failed, FIPS 204 section 3.4
+		reject := false
+		for i := range z {
+			cs1 := nttMul(&cHat, &sk.s1[i])
+			cs1 = inverseNTT(cs1)
+			z[i] = polyAdd(&y[i], &cs1)
+			reject = reject || polyInfinityNormAtLeast(&z[i], uint32(gamma1-p.beta))
+		}
+		if reject {
+			continue
+		}
+
+		hints := 0
+		for i := range w {
+			cs2 := nttMul(&cHat, &sk.s2[i])
+			cs2 = inverseNTT(cs2)
+			r := polySub(&w[i], &cs2)
+			var r0 ringElement
+			for j, c := range r {
+				r0[j] = fieldFromInt(lowBits(c, p.gamma2))
+			}
+			if polyInfinityNormAtLeast(&r0, p.gamma2-uint32(p.beta)) {
+				reject = true
+				break
+			}
+			ct0 := nttMul(&cHat, &sk.t0[i])
+			ct0 = inverseNTT(ct0)
+			if polyInfinityNormAtLeast(&ct0, p.gamma2) {
+				reject = true
+				break
+			}
+			// makeHint, algorithm 39, of -ct0 and w - cs2 + ct0
+			r1 := polyAdd(&r, &ct0)
+			for j := range r {
+				bit := highBits(r1[j], p.gamma2) ^ highBits(r[j], p.gamma2)
+				bit = (bit | -bit) >> 31
+				hint[i][j] = byte(bit)
+				hints += int(bit)
+			}
+		}
+		if reject || hints > p.omega {
+			continue
+		}
+
+		// sigEncode, algorithm 26
+		sig := make([]byte, 0, p.SignatureSize)
+		sig = append(sig, cTilde...)
+		for i := range z {
+			sig = bitPack(sig, &z[i], p.gamma1Bits+1, gamma1)
+		}
+		return p.hintBitPack(sig, hint)
+	}
+}
+
+// w1Bits is the width of the packed coefficients of w1
+func (p *ParameterSet) w1Bits() int {
+	if p.gamma2 == (q-1)/88 {
+		return 6
+	}
+	return 4
+}
+
+// Verify checks signature of message under context, ML-DSA.Verify of FIPS 204
+// algorithm 3
+func (pk *PublicKey) Verify(message, signature, context []byte) error {
+	if len(context) > MaxContextSize {
+		return fmt.Errorf("context must be at most %d bytes", MaxContextSize)
+	}
+	if !pk.verifyInternal(pk.mu(message, context), signature) {
+		return fmt.Errorf("invalid %s signature", pk.params.Name)
+	}
+	return nil
+}
+
+// verifyInternal is ML-DSA.Verify_internal of FIPS 204 algorithm 8
+func (pk *PublicKey) verifyInternal(mu [64]byte, sig []byte) bool {
+	p := pk.params
+	if len(sig) != p.SignatureSize {
+		return false
+	}
+	gamma1 := int32(1) << p.gamma1Bits
+
+	// sigDecode, algorithm 27
+	cTilde := sig[:p.cTildeSize]
+	zSize := n * (p.gamma1Bits + 1) / 8
+	z := make([]ringElement, p.l)
+	for i := range z {
+		off := p.cTildeSize + i*zSize
+		z[i] = bitUnpack(sig[off:off+zSize], p.gamma1Bits+1, gamma1)
+		if polyInfinityNormAtLeast(&z[i], uint32(gamma1-p.beta)) {
+			return false
+		}
+		z[i] = ntt(z[i])
+	}
+	hint, ok := p.hintBitUnpack(sig[p.cTildeSize+p.l*zSize:])
+	if !ok {
+		return false
+	}
+
+	a := p.expandA(pk.rho[:])
+	c := sampleInBall(cTilde, p.tau)
+	cHat := ntt(c)
+	w1Buf := make([]byte, 0, p.k*n*p.w1Bits()/8)
+	for i := 0; i < p.k; i++ {
+		var w ringElement
+		for j := range z {
+			nttMulAdd(&w, &a[i][j], &z[j])
+		}
+		ct1 := nttMul(&cHat, &pk.t1[i])
+		w = polySub(&w, &ct1)
+		w = inverseNTT(w)
+		var w1 [n]uint32
+		for j, c := range w {
+			w1[j] = useHint(hint[i][j], c, p.gamma2)
+		}
+		w1Buf = simpleBitPack(w1Buf, w1[:], p.w1Bits())
+	}
+
+	expected := make([]byte, p.cTildeSize)
+	h := sha3.NewShake256()
+	_, _ = h.Write(mu[:])
+	_, _ = h.Write(w1Buf)
+	_, _ = h.Read(expected)
+	return subtle.ConstantTimeCompare(cTilde, expected) == 1
+}
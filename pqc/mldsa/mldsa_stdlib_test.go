@@ -0,0 +1,49 @@
+//go:build go1.27
+
+package mldsa
+
+import (
+	"crypto/mldsa"
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestStandardLibrary checks keys and deterministic signatures against the
+// crypto/mldsa package added in Go 1.27
+func TestStandardLibrary(t *testing.T) {
+	std := map[*ParameterSet]mldsa.Parameters{
+		MLDSA44: mldsa.MLDSA44(),
+		MLDSA65: mldsa.MLDSA65(),
+		MLDSA87: mldsa.MLDSA87(),
+	}
+	msg := []byte("interoperability")
+	for p, params := range std {
+		t.Run(p.Name, func(t *testing.T) {
+			for i := 0; i < 5; i++ {
+				seed := make([]byte, SeedSize)
+				_, _ = crand.Read(seed)
+				sk, err := p.NewPrivateKey(seed)
+				require.NoError(t, err)
+				stdSk, err := mldsa.NewPrivateKey(params, seed)
+				require.NoError(t, err)
+				require.Equal(t, stdSk.PublicKey().Bytes(), sk.PublicKey().Bytes())
+
+				opts := &mldsa.Options{Context: "ctx"}
+				sig, err := sk.SignDeterministic(msg, []byte(opts.Context))
+				require.NoError(t, err)
+				stdSig, err := stdSk.SignDeterministic(msg, opts)
+				require.NoError(t, err)
+				require.Equal(t, stdSig, sig)
+
+				sig, err = sk.Sign(crand.Reader, msg, []byte(opts.Context))
+				require.NoError(t, err)
+				require.NoError(t, mldsa.Verify(stdSk.PublicKey(), msg, sig, opts))
+				stdSig, err = stdSk.Sign(nil, msg, opts)
+				require.NoError(t, err)
+				require.NoError(t, sk.PublicKey().Verify(msg, stdSig, []byte(opts.Context)))
+			}
+		})
+	}
+}
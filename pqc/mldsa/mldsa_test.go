@@ -0,0 +1,136 @@
+package mldsa
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var parameterSets = []*ParameterSet{MLDSA44, MLDSA65, MLDSA87}
+
+func TestSizes(t *testing.T) {
+	require.Equal(t, 1312, MLDSA44.PublicKeySize)
+	require.Equal(t, 2420, MLDSA44.SignatureSize)
+	require.Equal(t, 1952, MLDSA65.PublicKeySize)
+	require.Equal(t, 3309, MLDSA65.SignatureSize)
+	require.Equal(t, 2592, MLDSA87.PublicKeySize)
+	require.Equal(t, 4627, MLDSA87.SignatureSize)
+}
+
+func TestSignVerify(t *testing.T) {
+	msg := []byte("post-quantum")
+	ctx := []byte("did:key")
+	for _, p := range parameterSets {
+		t.Run(p.Name, func(t *testing.T) {
+			sk, err := p.GenerateKey(crand.Reader)
+			require.NoError(t, err)
+			pk, err := p.NewPublicKey(sk.PublicKey().Bytes())
+			require.NoError(t, err)
+			require.True(t, pk.Equal(sk.PublicKey()))
+
+			sig, err := sk.Sign(crand.Reader, msg, ctx)
+			require.NoError(t, err)
+			require.Len(t, sig, p.SignatureSize)
+			require.NoError(t, pk.Verify(msg, sig, ctx))
+			require.Error(t, pk.Verify([]byte("post-classical"), sig, ctx))
+			require.Error(t, pk.Verify(msg, sig, nil))
+			require.Error(t, pk.Verify(msg, sig[1:], ctx))
+
+			tampered := append([]byte{}, sig...)
+			tampered[len(tampered)/2] ^= 1
+			require.Error(t, pk.Verify(msg, tampered, ctx))
+
+			// the hint of a canonical signature ends with zero padding
+			tampered = append([]byte{}, sig...)
+			tampered[len(tampered)-p.k-1] ^= 1
+			require.Error(t, pk.Verify(msg, tampered, ctx))
+
+			sig1, err := sk.SignDeterministic(msg, ctx)
+			require.NoError(t, err)
+			sig2, err := sk.SignDeterministic(msg, ctx)
+			require.NoError(t, err)
+			require.Equal(t, sig1, sig2)
+			require.NoError(t, pk.Verify(msg, sig1, ctx))
+
+			_, err = sk.Sign(crand.Reader, msg, make([]byte, MaxContextSize+1))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestNewPrivateKey(t *testing.T) {
+	seed := make([]byte, SeedSize)
+	_, _ = crand.Read(seed)
+	sk1, err := MLDSA65.NewPrivateKey(seed)
+	require.NoError(t, err)
+	sk2, err := MLDSA65.NewPrivateKey(sk1.Bytes())
+	require.NoError(t, err)
+	require.True(t, sk1.PublicKey().Equal(sk2.PublicKey()))
+
+	_, err = MLDSA65.NewPrivateKey(seed[1:])
+	require.Error(t, err)
+	_, err = MLDSA65.NewPublicKey(sk1.PublicKey().Bytes()[1:])
+	require.Error(t, err)
+
+	sk1.Zeroize()
+	require.Equal(t, make([]byte, SeedSize), sk1.Bytes())
+}
+
+func TestComposite(t *testing.T) {
+	msg := []byte("post-quantum")
+	ctx := []byte("did:key")
+	for _, c := range []*Composite{MLDSA44Ed25519, MLDSA65Ed25519} {
+		t.Run(c.Name, func(t *testing.T) {
+			sk, err := c.GenerateKey(crand.Reader)
+			require.NoError(t, err)
+			pk, err := c.NewPublicKey(sk.PublicKey().Bytes())
+			require.NoError(t, err)
+			require.True(t, pk.Equal(sk.PublicKey()))
+			require.Len(t, pk.Bytes(), c.PublicKeySize)
+
+			sig, err := sk.Sign(crand.Reader, msg, ctx)
+			require.NoError(t, err)
+			require.Len(t, sig, c.SignatureSize)
+			require.NoError(t, pk.Verify(msg, sig, ctx))
+			require.Error(t, pk.Verify([]byte("post-classical"), sig, ctx))
+			require.Error(t, pk.Verify(msg, sig, nil))
+			require.Error(t, pk.Verify(msg, sig[1:], ctx))
+
+			// either component failing rejects the signature
+			tampered := append([]byte{}, sig...)
+			tampered[0] ^= 1
+			require.Error(t, pk.Verify(msg, tampered, ctx))
+			tampered = append([]byte{}, sig...)
+			tampered[len(tampered)-1] ^= 1
+			require.Error(t, pk.Verify(msg, tampered, ctx))
+
+			// the ML-DSA component does not verify on its own
+			require.Error(t, pk.MLDSA().Verify(msg, sig[:c.dsa.SignatureSize], ctx))
+
+			sk2, err := c.NewPrivateKey(sk.Bytes())
+			require.NoError(t, err)
+			require.True(t, sk2.PublicKey().Equal(pk))
+		})
+	}
+}
+
+func BenchmarkSign(b *testing.B) {
+	sk, _ := MLDSA65.GenerateKey(crand.Reader)
+	msg := []byte("benchmark")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = sk.Sign(crand.Reader, msg, nil)
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	sk, _ := MLDSA65.GenerateKey(crand.Reader)
+	msg := []byte("benchmark")
+	sig, _ := sk.Sign(crand.Reader, msg, nil)
+	pk := sk.PublicKey()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = pk.Verify(msg, sig, nil)
+	}
+}
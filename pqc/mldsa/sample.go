@@ -0,0 +1,140 @@
+package mldsa
+
+import (
+	"golang.org/x/crypto/sha3"
+)
+
+// rejNTTPoly is algorithm 30 of FIPS 204, it samples an element of T_q
+// uniformly from SHAKE128(rho || s || r)
+func rejNTTPoly(rho []byte, s, r byte) ringElement {
+	xof := sha3.NewShake128()
+	_, _ = xof.Write(rho)
+	_, _ = xof.Write([]byte{s, r})
+
+	var a ringElement
+	var buf [168]byte
+	off := len(buf)
+	for j := 0; j < n; {
+		if off >= len(buf) {
+			_, _ = xof.Read(buf[:])
+			off = 0
+		}
+		// coeffFromThreeBytes, rejection sampling on public data only
+		z := uint32(buf[off]) | uint32(buf[off+1])<<8 | uint32(buf[off+2]&0x7F)<<16
+		off += 3
+		if z < q {
+			a[j] = fieldElement(z)
+			j++
+		}
+	}
+	return a
+}
+
+// rejBoundedPoly is algorithm 31 of FIPS 204, it samples a polynomial with
+// coefficients in [-eta, eta] from SHAKE256(rho || r)
+func rejBoundedPoly(rho []byte, r uint16, eta int) ringElement {
+	xof := sha3.NewShake256()
+	_, _ = xof.Write(rho)
+	_, _ = xof.Write([]byte{byte(r), byte(r >> 8)})
+
+	var a ringElement
+	var buf [136]byte
+	off := len(buf)
+	j := 0
+	// coeffFromHalfByte
+	sample := func(b byte) {
+		switch {
+		case eta == 2 && b < 15:
+			a[j] = fieldFromInt(2 - int32(b%5))
+			j++
+		case eta == 4 && b < 9:
+			a[j] = fieldFromInt(4 - int32(b))
+			j++
+		}
+	}
+	for j < n {
+		if off >= len(buf) {
+			_, _ = xof.Read(buf[:])
+			off = 0
+		}
+		z := buf[off]
+		off++
+		sample(z & 0x0F)
+		if j < n {
+			sample(z >> 4)
+		}
+	}
+	return a
+}
+
+// expandA is algorithm 32 of FIPS 204, the k x l matrix in NTT form
+func (p *ParameterSet) expandA(rho []byte) [][]ringElement {
+	a := make([][]ringElement, p.k)
+	for r := range a {
+		a[r] = make([]ringElement, p.l)
+		for s := range a[r] {
+			a[r][s] = rejNTTPoly(rho, byte(s), byte(r))
+		}
+	}
+	return a
+}
+
+// expandS is algorithm 33 of FIPS 204
+func (p *ParameterSet) expandS(rho []byte) (s1, s2 []ringElement) {
+	s1 = make([]ringElement, p.l)
+	s2 = make([]ringElement, p.k)
+	for r := range s1 {
+		s1[r] = rejBoundedPoly(rho, uint16(r), p.eta)
+	}
+	for r := range s2 {
+		s2[r] = rejBoundedPoly(rho, uint16(r+p.l), p.eta)
+	}
+	return s1, s2
+}
+
+// expandMask is algorithm 34 of FIPS 204
+func (p *ParameterSet) expandMask(rho []byte, mu int) []ringElement {
+	bits := p.gamma1Bits + 1
+	buf := make([]byte, n*bits/8)
+	y := make([]ringElement, p.l)
+	for r := range y {
+		xof := sha3.NewShake256()
+		_, _ = xof.Write(rho)
+		_, _ = xof.Write([]byte{byte(mu + r), byte((mu + r) >> 8)})
+		_, _ = xof.Read(buf)
+		y[r] = bitUnpack(buf, bits, 1<<p.gamma1Bits)
+	}
+	return y
+}
+
+// sampleInBall is algorithm 29 of FIPS 204, it samples a polynomial with tau
+// coefficients in {-1, 1} and the others 0
+func sampleInBall(rho []byte, tau int) ringElement {
+	xof := sha3.NewShake256()
+	_, _ = xof.Write(rho)
+	var s [8]byte
+	_, _ = xof.Read(s[:])
+	signs := uint64(0)
+	for i := 7; i >= 0; i-- {
+		signs = signs<<8 | uint64(s[i])
+	}
+
+	var c ringElement
+	var b [1]byte
+	for i := n - tau; i < n; i++ {
+		for {
+			_, _ = xof.Read(b[:])
+			if int(b[0]) <= i {
+				break
+			}
+		}
+		j := b[0]
+		c[i] = c[j]
+		c[j] = 1
+		if signs&1 == 1 {
+			c[j] = q - 1
+		}
+		signs >>= 1
+	}
+	return c
+}
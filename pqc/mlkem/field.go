@@ -0,0 +1,227 @@
+package mlkem
+
+// Arithmetic in Z_q and in the ring R_q = Z_q[X]/(X^256 + 1) of FIPS 203
+// section 4.3. Coefficients are kept reduced in [0, q) and every operation on
+// them runs in constant time.
+
+const (
+	q = 3329
+	n = 256
+
+	// barrettMultiplier is floor(2^24 / q)
+	barrettMultiplier = 5039
+	barrettShift      = 24
+
+	// invN is 128^-1 mod q, the scale factor of the inverse NTT
+	invN = 3303
+)
+
+type fieldElement uint16
+
+// ringElement is a polynomial of R_q, or its NTT representation in T_q
+type ringElement [n]fieldElement
+
+// fieldReduceOnce maps a in [0, 2q) to [0, q)
+func fieldReduceOnce(a uint16) fieldElement {
+	x := a - q
+	// x underflowed iff a < q, in which case its top bit is set
+	x += (x >> 15) * q
+	return fieldElement(x)
+}
+
+func fieldAdd(a, b fieldElement) fieldElement {
+	return fieldReduceOnce(uint16(a + b))
+}
+
+func fieldSub(a, b fieldElement) fieldElement {
+	return fieldReduceOnce(uint16(a - b + q))
+}
+
+// fieldReduce maps a < q^2 to [0, q) with a Barrett reduction
+func fieldReduce(a uint32) fieldElement {
+	quotient := uint32((uint64(a) * barrettMultiplier) >> barrettShift)
+	return fieldReduceOnce(uint16(a - quotient*q))
+}
+
+func fieldMul(a, b fieldElement) fieldElement {
+	return fieldReduce(uint32(a) * uint32(b))
+}
+
+// zetas[i] is 17^BitRev7(i) mod q and gammas[i] is 17^(2*BitRev7(i)+1) mod q,
+// FIPS 203 appendix A
+var zetas, gammas [128]fieldElement
+
+func init() {
+	var powers [256]fieldElement
+	powers[0] = 1
+	for i := 1; i < len(powers); i++ {
+		powers[i] = fieldMul(powers[i-1], 17)
+	}
+	for i := range zetas {
+		r := bitRev7(uint8(i))
+		zetas[i] = powers[r]
+		gammas[i] = powers[2*int(r)+1]
+	}
+}
+
+func bitRev7(x uint8) uint8 {
+	var r uint8
+	for i := 0; i < 7; i++ {
+		r = r<<1 | x>>i&1
+	}
+	return r
+}
+
+func polyAdd(a, b *ringElement) ringElement {
+	var out ringElement
+	for i := range out {
+		out[i] = fieldAdd(a[i], b[i])
+	}
+	return out
+}
+
+func polySub(a, b *ringElement) ringElement {
+	var out ringElement
+	for i := range out {
+		out[i] = fieldSub(a[i], b[i])
+	}
+	return out
+}
+
+// ntt is algorithm 9 of FIPS 203
+func ntt(f ringElement) ringElement {
+	i := 1
+	for length := 128; length >= 2; length /= 2 {
+		for start := 0; start < n; start += 2 * length {
+			zeta := zetas[i]
+			i++
+			for j := start; j < start+length; j++ {
+				t := fieldMul(zeta, f[j+length])
+				f[j+length] = fieldSub(f[j], t)
+				f[j] = fieldAdd(f[j], t)
+			}
+		}
+	}
+	return f
+}
+
+// inverseNTT is algorithm 10 of FIPS 203
+func inverseNTT(f ringElement) ringElement {
+	i := 127
+	for length := 2; length <= 128; length *= 2 {
+		for start := 0; start < n; start += 2 * length {
+			zeta := zetas[i]
+			i--
+			for j := start; j < start+length; j++ {
+				t := f[j]
+				f[j] = fieldAdd(t, f[j+length])
+				f[j+length] = fieldMul(zeta, fieldSub(f[j+length], t))
+			}
+		}
+	}
+	for j := range f {
+		f[j] = fieldMul(f[j], invN)
+	}
+	return f
+}
+
+// nttMulAdd sets acc += f * g in T_q, algorithms 11 and 12 of FIPS 203
+func nttMulAdd(acc, f, g *ringElement) {
+	for i := 0; i < 128; i++ {
+		a0, a1 := f[2*i], f[2*i+1]
+		b0, b1 := g[2*i], g[2*i+1]
+		c0 := fieldAdd(fieldMul(a0, b0), fieldMul(fieldMul(a1, b1), gammas[i]))
+		c1 := fieldAdd(fieldMul(a0, b1), fieldMul(a1, b0))
+		acc[2*i] = fieldAdd(acc[2*i], c0)
+		acc[2*i+1] = fieldAdd(acc[2*i+1], c1)
+	}
+}
+
+// compress is Compress_d of FIPS 203 section 4.2.1, round(2^d / q * x) mod 2^d
+func compress(x fieldElement, d uint) uint16 {
+	// division by the constant q compiles to a multiplication
+	return uint16((uint32(x)<<d + q/2) / q & (1<<d - 1))
+}
+
+// decompress is Decompress_d of FIPS 203 section 4.2.1, round(q / 2^d * y)
+func decompress(y uint16, d uint) fieldElement {
+	return fieldElement((uint32(y)*q + 1<<(d-1)) >> d)
+}
+
+// byteEncode is algorithm 5 of FIPS 203, it packs the d low bits of every
+// coefficient, least significant bit first
+func byteEncode(out []byte, f []uint16, d uint) []byte {
+	var acc uint32
+	var bits uint
+	for _, c := range f {
+		acc |= uint32(c) << bits
+		bits += d
+		for bits >= 8 {
+			out = append(out, byte(acc))
+			acc >>= 8
+			bits -= 8
+		}
+	}
+	return out
+}
+
+// byteDecode is algorithm 6 of FIPS 203 for d < 12, it unpacks len(f)
+// coefficients of d bits from b
+func byteDecode(f []uint16, b []byte, d uint) {
+	var acc uint32
+	var bits uint
+	for i := range f {
+		for bits < d {
+			acc |= uint32(b[0]) << bits
+			b = b[1:]
+			bits += 8
+		}
+		f[i] = uint16(acc & (1<<d - 1))
+		acc >>= d
+		bits -= d
+	}
+}
+
+// polyEncode12 is ByteEncode_12 of a reduced polynomial
+func polyEncode12(out []byte, f *ringElement) []byte {
+	var c [n]uint16
+	for i := range f {
+		c[i] = uint16(f[i])
+	}
+	return byteEncode(out, c[:], 12)
+}
+
+// polyDecode12 is ByteDecode_12, it fails for coefficients that are not
+// reduced, which is the modulus check of FIPS 203 section 7.2
+func polyDecode12(b []byte) (ringElement, bool) {
+	var c [n]uint16
+	byteDecode(c[:], b, 12)
+	var f ringElement
+	for i := range f {
+		if c[i] >= q {
+			return f, false
+		}
+		f[i] = fieldElement(c[i])
+	}
+	return f, true
+}
+
+// polyCompressEncode is ByteEncode_d(Compress_d(f))
+func polyCompressEncode(out []byte, f *ringElement, d uint) []byte {
+	var c [n]uint16
+	for i := range f {
+		c[i] = compress(f[i], d)
+	}
+	return byteEncode(out, c[:], d)
+}
+
+// polyDecodeDecompress is Decompress_d(ByteDecode_d(b))
+func polyDecodeDecompress(b []byte, d uint) ringElement {
+	var c [n]uint16
+	byteDecode(c[:], b, d)
+	var f ringElement
+	for i := range f {
+		f[i] = decompress(c[i], d)
+	}
+	return f
+}
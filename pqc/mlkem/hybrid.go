@@ -0,0 +1,195 @@
+package mlkem
+
+import (
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/go-sonr/crypto/core/secret"
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/keyexchange"
+)
+
+// Hybrid is a KEM combining ML-KEM with X25519, the shared key stays secret
+// as long as one of the two is unbroken. It follows the construction of X-Wing,
+// draft-connolly-cfrg-xwing-kem: both key pairs are expanded from one 32 byte
+// seed with SHAKE256 and the shared key is
+//
+//	SHA3-256(ss_M || ss_X || ct_X || pk_X || "\.//^\")
+//
+// With ML-KEM-768 this is X-Wing, the other parameter sets reuse its combiner.
+type Hybrid struct {
+	// Name is the name of the hybrid KEM
+	Name string
+	// EncapsulationKeySize is the size of pk_M || pk_X
+	EncapsulationKeySize int
+	// CiphertextSize is the size of ct_M || ct_X
+	CiphertextSize int
+
+	kem *ParameterSet
+}
+
+const (
+	// HybridSeedSize is the size of a hybrid decapsulation key seed
+	HybridSeedSize = 32
+
+	x25519Size = 32
+)
+
+var hybridLabel = []byte(`\.//^\`)
+
+func newHybrid(name string, kem *ParameterSet) *Hybrid {
+	return &Hybrid{
+		Name:                 name,
+		EncapsulationKeySize: kem.EncapsulationKeySize + x25519Size,
+		CiphertextSize:       kem.CiphertextSize + x25519Size,
+		kem:                  kem,
+	}
+}
+
+var (
+	// X25519MLKEM512 combines ML-KEM-512 and X25519
+	X25519MLKEM512 = newHybrid("X25519-ML-KEM-512", MLKEM512)
+	// X25519MLKEM768 combines ML-KEM-768 and X25519, it is X-Wing
+	X25519MLKEM768 = newHybrid("X25519-ML-KEM-768", MLKEM768)
+	// X25519MLKEM1024 combines ML-KEM-1024 and X25519
+	X25519MLKEM1024 = newHybrid("X25519-ML-KEM-1024", MLKEM1024)
+)
+
+// HybridEncapsulationKey is the public key of a hybrid KEM
+type HybridEncapsulationKey struct {
+	hybrid *Hybrid
+	mlkem  *EncapsulationKey
+	x25519 []byte
+}
+
+// HybridDecapsulationKey is the private key of a hybrid KEM
+type HybridDecapsulationKey struct {
+	hybrid *Hybrid
+	seed   [HybridSeedSize]byte
+	mlkem  *DecapsulationKey
+	x25519 *keyexchange.PrivateKey
+	ek     *HybridEncapsulationKey
+}
+
+// GenerateKey draws a decapsulation key seed from reader
+func (h *Hybrid) GenerateKey(reader io.Reader) (*HybridDecapsulationKey, error) {
+	if reader == nil {
+		return nil, internal.ErrNilArguments
+	}
+	var seed [HybridSeedSize]byte
+	if _, err := io.ReadFull(reader, seed[:]); err != nil {
+		return nil, err
+	}
+	defer secret.Wipe(seed[:])
+	return h.NewDecapsulationKey(seed[:])
+}
+
+// NewDecapsulationKey expands the ML-KEM seed and the X25519 private key from seed
+func (h *Hybrid) NewDecapsulationKey(seed []byte) (*HybridDecapsulationKey, error) {
+	if len(seed) != HybridSeedSize {
+		return nil, fmt.Errorf("%s seed must be %d bytes", h.Name, HybridSeedSize)
+	}
+	var expanded [SeedSize + x25519Size]byte
+	defer secret.Wipe(expanded[:])
+	sha3.ShakeSum256(expanded[:], seed)
+
+	dkM, err := h.kem.NewDecapsulationKey(expanded[:SeedSize])
+	if err != nil {
+		return nil, err
+	}
+	dkX, err := keyexchange.X25519.NewPrivateKey(expanded[SeedSize:])
+	if err != nil {
+		return nil, err
+	}
+	dk := &HybridDecapsulationKey{hybrid: h, mlkem: dkM, x25519: dkX}
+	copy(dk.seed[:], seed)
+	dk.ek = &HybridEncapsulationKey{hybrid: h, mlkem: dkM.EncapsulationKey(), x25519: dkX.PublicKey()}
+	return dk, nil
+}
+
+// NewEncapsulationKey decodes pk_M || pk_X
+func (h *Hybrid) NewEncapsulationKey(b []byte) (*HybridEncapsulationKey, error) {
+	if len(b) != h.EncapsulationKeySize {
+		return nil, fmt.Errorf("%s encapsulation key must be %d bytes", h.Name, h.EncapsulationKeySize)
+	}
+	ekM, err := h.kem.NewEncapsulationKey(b[:h.kem.EncapsulationKeySize])
+	if err != nil {
+		return nil, err
+	}
+	return &HybridEncapsulationKey{
+		hybrid: h,
+		mlkem:  ekM,
+		x25519: append([]byte{}, b[h.kem.EncapsulationKeySize:]...),
+	}, nil
+}
+
+// combine derives the hybrid shared key
+func combine(ssM, ssX, ctX, pkX []byte) []byte {
+	hash := sha3.New256()
+	_, _ = hash.Write(ssM)
+	_, _ = hash.Write(ssX)
+	_, _ = hash.Write(ctX)
+	_, _ = hash.Write(pkX)
+	_, _ = hash.Write(hybridLabel)
+	return hash.Sum(nil)
+}
+
+// Bytes returns pk_M || pk_X
+func (ek *HybridEncapsulationKey) Bytes() []byte {
+	return append(ek.mlkem.Bytes(), ek.x25519...)
+}
+
+// Encapsulate draws the ML-KEM message and an ephemeral X25519 key from reader
+// and returns the shared key and the ciphertext ct_M || ct_X
+func (ek *HybridEncapsulationKey) Encapsulate(reader io.Reader) (sharedKey, ciphertext []byte, err error) {
+	ssM, ctM, err := ek.mlkem.Encapsulate(reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer secret.Wipe(ssM)
+	ctX, ssX, err := keyexchange.X25519.Ephemeral(ek.x25519, reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer secret.Wipe(ssX)
+	return combine(ssM, ssX, ctX, ek.x25519), append(ctM, ctX...), nil
+}
+
+// Bytes returns the seed of the key
+func (dk *HybridDecapsulationKey) Bytes() []byte {
+	return append([]byte{}, dk.seed[:]...)
+}
+
+// EncapsulationKey returns the public key of dk
+func (dk *HybridDecapsulationKey) EncapsulationKey() *HybridEncapsulationKey {
+	return dk.ek
+}
+
+// Zeroize overwrites the seed and both private keys with zeros
+func (dk *HybridDecapsulationKey) Zeroize() {
+	secret.Wipe(dk.seed[:])
+	dk.mlkem.Zeroize()
+	dk.x25519.Zeroize()
+}
+
+// Decapsulate returns the shared key of ct_M || ct_X
+func (dk *HybridDecapsulationKey) Decapsulate(ciphertext []byte) ([]byte, error) {
+	h := dk.hybrid
+	if len(ciphertext) != h.CiphertextSize {
+		return nil, fmt.Errorf("%s ciphertext must be %d bytes", h.Name, h.CiphertextSize)
+	}
+	ctM, ctX := ciphertext[:h.kem.CiphertextSize], ciphertext[h.kem.CiphertextSize:]
+	ssM, err := dk.mlkem.Decapsulate(ctM)
+	if err != nil {
+		return nil, err
+	}
+	defer secret.Wipe(ssM)
+	ssX, err := dk.x25519.ECDH(ctX)
+	if err != nil {
+		return nil, err
+	}
+	defer secret.Wipe(ssX)
+	return combine(ssM, ssX, ctX, dk.ek.x25519), nil
+}
@@ -0,0 +1,291 @@
+// Package mlkem implements ML-KEM, the module-lattice-based key encapsulation
+// mechanism of FIPS 203 https://doi.org/10.6028/NIST.FIPS.203, with the
+// ML-KEM-512, ML-KEM-768 and ML-KEM-1024 parameter sets, and hybrid KEMs
+// combining ML-KEM with X25519.
+//
+// Decapsulation keys are stored as the 64 byte seed d || z of FIPS 203
+// section 7.1, the same encoding as the crypto/mlkem package of the standard
+// library, which only provides ML-KEM-768 and ML-KEM-1024.
+package mlkem
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/go-sonr/crypto/core/secret"
+	"github.com/go-sonr/crypto/internal"
+)
+
+const (
+	// SeedSize is the size of a decapsulation key seed d || z
+	SeedSize = 64
+	// SharedKeySize is the size of the shared keys of every parameter set
+	SharedKeySize = 32
+
+	// encodingSize12 is the size of a ByteEncode_12 polynomial
+	encodingSize12 = n * 12 / 8
+)
+
+// ParameterSet is one of the ML-KEM parameter sets of FIPS 203 section 8
+type ParameterSet struct {
+	// Name is the FIPS 203 name of the parameter set
+	Name string
+	// EncapsulationKeySize is the size of an encoded encapsulation key
+	EncapsulationKeySize int
+	// CiphertextSize is the size of a ciphertext
+	CiphertextSize int
+
+	k      int
+	eta1   int
+	eta2   int
+	du, dv uint
+}
+
+func newParameterSet(name string, k, eta1 int, du, dv uint) *ParameterSet {
+	return &ParameterSet{
+		Name:                 name,
+		EncapsulationKeySize: encodingSize12*k + 32,
+		CiphertextSize:       32 * (int(du)*k + int(dv)),
+		k:                    k,
+		eta1:                 eta1,
+		eta2:                 2,
+		du:                   du,
+		dv:                   dv,
+	}
+}
+
+var (
+	// MLKEM512 is ML-KEM-512, security category 1
+	MLKEM512 = newParameterSet("ML-KEM-512", 2, 3, 10, 4)
+	// MLKEM768 is ML-KEM-768, security category 3
+	MLKEM768 = newParameterSet("ML-KEM-768", 3, 2, 10, 4)
+	// MLKEM1024 is ML-KEM-1024, security category 5
+	MLKEM1024 = newParameterSet("ML-KEM-1024", 4, 2, 11, 5)
+)
+
+// EncapsulationKey is the public key of ML-KEM
+type EncapsulationKey struct {
+	params *ParameterSet
+	t      []ringElement // NTT(t) of K-PKE
+	rho    [32]byte
+	raw    []byte
+	h      [32]byte // H(ek)
+}
+
+// DecapsulationKey is the private key of ML-KEM
+type DecapsulationKey struct {
+	seed [SeedSize]byte
+	s    []ringElement // NTT(s) of K-PKE
+	ek   *EncapsulationKey
+}
+
+// GenerateKey draws a decapsulation key seed from reader
+func (p *ParameterSet) GenerateKey(reader io.Reader) (*DecapsulationKey, error) {
+	if reader == nil {
+		return nil, internal.ErrNilArguments
+	}
+	var seed [SeedSize]byte
+	if _, err := io.ReadFull(reader, seed[:]); err != nil {
+		return nil, err
+	}
+	defer secret.Wipe(seed[:])
+	return p.NewDecapsulationKey(seed[:])
+}
+
+// NewDecapsulationKey derives the key pair of the seed d || z,
+// ML-KEM.KeyGen_internal of FIPS 203 algorithm 16
+func (p *ParameterSet) NewDecapsulationKey(seed []byte) (*DecapsulationKey, error) {
+	if len(seed) != SeedSize {
+		return nil, fmt.Errorf("%s seed must be %d bytes", p.Name, SeedSize)
+	}
+	dk := &DecapsulationKey{s: make([]ringElement, p.k)}
+	copy(dk.seed[:], seed)
+	ek := &EncapsulationKey{params: p, t: make([]ringElement, p.k)}
+
+	// K-PKE.KeyGen, algorithm 13
+	g := sha3.Sum512(append(append([]byte{}, seed[:32]...), byte(p.k)))
+	rho, sigma := g[:32], g[32:]
+	defer secret.Wipe(g[:])
+	copy(ek.rho[:], rho)
+
+	var nonce byte
+	for i := range dk.s {
+		dk.s[i] = ntt(samplePolyCBD(sigma, nonce, p.eta1))
+		nonce++
+	}
+	for i := range ek.t {
+		e := ntt(samplePolyCBD(sigma, nonce, p.eta1))
+		nonce++
+		for j := range dk.s {
+			a := sampleNTT(rho, byte(j), byte(i))
+			nttMulAdd(&e, &a, &dk.s[j])
+		}
+		ek.t[i] = e
+	}
+
+	ek.raw = make([]byte, 0, p.EncapsulationKeySize)
+	for i := range ek.t {
+		ek.raw = polyEncode12(ek.raw, &ek.t[i])
+	}
+	ek.raw = append(ek.raw, rho...)
+	ek.h = sha3.Sum256(ek.raw)
+	dk.ek = ek
+	return dk, nil
+}
+
+// NewEncapsulationKey decodes an encapsulation key and runs the modulus check
+// of FIPS 203 section 7.2
+func (p *ParameterSet) NewEncapsulationKey(b []byte) (*EncapsulationKey, error) {
+	if len(b) != p.EncapsulationKeySize {
+		return nil, fmt.Errorf("%s encapsulation key must be %d bytes", p.Name, p.EncapsulationKeySize)
+	}
+	ek := &EncapsulationKey{params: p, t: make([]ringElement, p.k), raw: append([]byte{}, b...)}
+	for i := range ek.t {
+		t, ok := polyDecode12(b[i*encodingSize12 : (i+1)*encodingSize12])
+		if !ok {
+			return nil, fmt.Errorf("invalid %s encapsulation key", p.Name)
+		}
+		ek.t[i] = t
+	}
+	copy(ek.rho[:], b[p.k*encodingSize12:])
+	ek.h = sha3.Sum256(ek.raw)
+	return ek, nil
+}
+
+// ParameterSet returns the parameter set of the key
+func (ek *EncapsulationKey) ParameterSet() *ParameterSet {
+	return ek.params
+}
+
+// Bytes returns the encoded encapsulation key
+func (ek *EncapsulationKey) Bytes() []byte {
+	return append([]byte{}, ek.raw...)
+}
+
+// Encapsulate draws a message from reader and returns the shared key and the
+// ciphertext to send to the owner of the decapsulation key
+func (ek *EncapsulationKey) Encapsulate(reader io.Reader) (sharedKey, ciphertext []byte, err error) {
+	if reader == nil {
+		return nil, nil, internal.ErrNilArguments
+	}
+	var m [32]byte
+	if _, err = io.ReadFull(reader, m[:]); err != nil {
+		return nil, nil, err
+	}
+	defer secret.Wipe(m[:])
+	sharedKey, ciphertext = ek.encapsulate(&m)
+	return sharedKey, ciphertext, nil
+}
+
+// encapsulate is ML-KEM.Encaps_internal, FIPS 203 algorithm 17
+func (ek *EncapsulationKey) encapsulate(m *[32]byte) (sharedKey, ciphertext []byte) {
+	g := sha3.Sum512(append(m[:], ek.h[:]...))
+	defer secret.Wipe(g[:])
+	ciphertext = ek.encrypt(m, g[32:])
+	return append([]byte{}, g[:32]...), ciphertext
+}
+
+// encrypt is K-PKE.Encrypt, FIPS 203 algorithm 14
+func (ek *EncapsulationKey) encrypt(m *[32]byte, r []byte) []byte {
+	p := ek.params
+	var nonce byte
+	y := make([]ringElement, p.k)
+	for i := range y {
+		y[i] = ntt(samplePolyCBD(r, nonce, p.eta1))
+		nonce++
+	}
+
+	c := make([]byte, 0, p.CiphertextSize)
+	for i := 0; i < p.k; i++ {
+		var u ringElement
+		for j := range y {
+			// A transposed
+			a := sampleNTT(ek.rho[:], byte(i), byte(j))
+			nttMulAdd(&u, &a, &y[j])
+		}
+		u = inverseNTT(u)
+		e1 := samplePolyCBD(r, nonce, p.eta2)
+		nonce++
+		u = polyAdd(&u, &e1)
+		c = polyCompressEncode(c, &u, p.du)
+	}
+
+	var v ringElement
+	for i := range y {
+		nttMulAdd(&v, &ek.t[i], &y[i])
+	}
+	v = inverseNTT(v)
+	e2 := samplePolyCBD(r, nonce, p.eta2)
+	v = polyAdd(&v, &e2)
+	mu := polyDecodeDecompress(m[:], 1)
+	v = polyAdd(&v, &mu)
+	return polyCompressEncode(c, &v, p.dv)
+}
+
+// ParameterSet returns the parameter set of the key
+func (dk *DecapsulationKey) ParameterSet() *ParameterSet {
+	return dk.ek.params
+}
+
+// Bytes returns the seed d || z of the key
+func (dk *DecapsulationKey) Bytes() []byte {
+	return append([]byte{}, dk.seed[:]...)
+}
+
+// EncapsulationKey returns the public key of dk
+func (dk *DecapsulationKey) EncapsulationKey() *EncapsulationKey {
+	return dk.ek
+}
+
+// Zeroize overwrites the seed and the secret vector with zeros
+func (dk *DecapsulationKey) Zeroize() {
+	secret.Wipe(dk.seed[:])
+	for i := range dk.s {
+		clear(dk.s[i][:])
+	}
+}
+
+// Decapsulate returns the shared key of ciphertext, ML-KEM.Decaps_internal of
+// FIPS 203 algorithm 18. An invalid ciphertext yields an unrelated pseudorandom
+// key rather than an error, the implicit rejection of ML-KEM.
+func (dk *DecapsulationKey) Decapsulate(ciphertext []byte) ([]byte, error) {
+	p := dk.ek.params
+	if len(ciphertext) != p.CiphertextSize {
+		return nil, fmt.Errorf("%s ciphertext must be %d bytes", p.Name, p.CiphertextSize)
+	}
+	m := dk.decrypt(ciphertext)
+	defer secret.Wipe(m[:])
+	g := sha3.Sum512(append(m[:], dk.ek.h[:]...))
+	defer secret.Wipe(g[:])
+
+	rejected := make([]byte, SharedKeySize)
+	j := sha3.NewShake256()
+	_, _ = j.Write(dk.seed[32:])
+	_, _ = j.Write(ciphertext)
+	_, _ = j.Read(rejected)
+
+	c := dk.ek.encrypt(&m, g[32:])
+	subtle.ConstantTimeCopy(subtle.ConstantTimeCompare(c, ciphertext), rejected, g[:32])
+	return rejected, nil
+}
+
+// decrypt is K-PKE.Decrypt, FIPS 203 algorithm 15
+func (dk *DecapsulationKey) decrypt(ciphertext []byte) [32]byte {
+	p := dk.ek.params
+	uSize := n * int(p.du) / 8
+	var w ringElement
+	for i := range dk.s {
+		u := ntt(polyDecodeDecompress(ciphertext[i*uSize:(i+1)*uSize], p.du))
+		nttMulAdd(&w, &dk.s[i], &u)
+	}
+	w = inverseNTT(w)
+	v := polyDecodeDecompress(ciphertext[p.k*uSize:], p.dv)
+	w = polySub(&v, &w)
+
+	var m [32]byte
+	polyCompressEncode(m[:0], &w, 1)
+	return m
+}
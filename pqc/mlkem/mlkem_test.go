@@ -0,0 +1,297 @@
+package mlkem
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/mlkem"
+	crand "crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+)
+
+var parameterSets = []*ParameterSet{MLKEM512, MLKEM768, MLKEM1024}
+
+func TestSizes(t *testing.T) {
+	require.Equal(t, 800, MLKEM512.EncapsulationKeySize)
+	require.Equal(t, 768, MLKEM512.CiphertextSize)
+	require.Equal(t, mlkem.EncapsulationKeySize768, MLKEM768.EncapsulationKeySize)
+	require.Equal(t, mlkem.CiphertextSize768, MLKEM768.CiphertextSize)
+	require.Equal(t, mlkem.EncapsulationKeySize1024, MLKEM1024.EncapsulationKeySize)
+	require.Equal(t, mlkem.CiphertextSize1024, MLKEM1024.CiphertextSize)
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, p := range parameterSets {
+		t.Run(p.Name, func(t *testing.T) {
+			dk, err := p.GenerateKey(crand.Reader)
+			require.NoError(t, err)
+			ek, err := p.NewEncapsulationKey(dk.EncapsulationKey().Bytes())
+			require.NoError(t, err)
+			require.Equal(t, p, ek.ParameterSet())
+
+			key, ct, err := ek.Encapsulate(crand.Reader)
+			require.NoError(t, err)
+			require.Len(t, key, SharedKeySize)
+			require.Len(t, ct, p.CiphertextSize)
+			decapsulated, err := dk.Decapsulate(ct)
+			require.NoError(t, err)
+			require.Equal(t, key, decapsulated)
+
+			// implicit rejection returns J(z || c)
+			ct[0] ^= 1
+			rejected, err := dk.Decapsulate(ct)
+			require.NoError(t, err)
+			require.NotEqual(t, key, rejected)
+			expected := make([]byte, SharedKeySize)
+			sha3.ShakeSum256(expected, append(dk.Bytes()[32:], ct...))
+			require.Equal(t, expected, rejected)
+
+			_, err = dk.Decapsulate(ct[1:])
+			require.Error(t, err)
+
+			restored, err := p.NewDecapsulationKey(dk.Bytes())
+			require.NoError(t, err)
+			require.Equal(t, dk.EncapsulationKey().Bytes(), restored.EncapsulationKey().Bytes())
+		})
+	}
+}
+
+func TestModulusCheck(t *testing.T) {
+	for _, p := range parameterSets {
+		dk, err := p.GenerateKey(crand.Reader)
+		require.NoError(t, err)
+		raw := dk.EncapsulationKey().Bytes()
+		// the first coefficient becomes 0xFFF >= q
+		raw[0] = 0xFF
+		raw[1] |= 0x0F
+		_, err = p.NewEncapsulationKey(raw)
+		require.Error(t, err)
+		_, err = p.NewEncapsulationKey(raw[1:])
+		require.Error(t, err)
+	}
+}
+
+// The standard library implements ML-KEM-768 and ML-KEM-1024 from the same seeds
+func TestStandardLibraryInterop(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		seed := make([]byte, SeedSize)
+		_, _ = crand.Read(seed)
+
+		dk, err := MLKEM768.NewDecapsulationKey(seed)
+		require.NoError(t, err)
+		std, err := mlkem.NewDecapsulationKey768(seed)
+		require.NoError(t, err)
+		require.True(t, bytes.Equal(std.EncapsulationKey().Bytes(), dk.EncapsulationKey().Bytes()))
+
+		key, ct := std.EncapsulationKey().Encapsulate()
+		decapsulated, err := dk.Decapsulate(ct)
+		require.NoError(t, err)
+		require.Equal(t, key, decapsulated)
+		key, ct, err = dk.EncapsulationKey().Encapsulate(crand.Reader)
+		require.NoError(t, err)
+		decapsulated, err = std.Decapsulate(ct)
+		require.NoError(t, err)
+		require.Equal(t, key, decapsulated)
+
+		dk1024, err := MLKEM1024.NewDecapsulationKey(seed)
+		require.NoError(t, err)
+		std1024, err := mlkem.NewDecapsulationKey1024(seed)
+		require.NoError(t, err)
+		require.True(t, bytes.Equal(std1024.EncapsulationKey().Bytes(), dk1024.EncapsulationKey().Bytes()))
+		key, ct = std1024.EncapsulationKey().Encapsulate()
+		decapsulated, err = dk1024.Decapsulate(ct)
+		require.NoError(t, err)
+		require.Equal(t, key, decapsulated)
+	}
+}
+
+// acvpVectors are vectors of the ML-KEM FIPS203 vector sets of the NIST ACVP
+// server, https://github.com/usnistgov/ACVP-Server, a few of each parameter
+// set. The decapsulation vectors are left out, their keys are in the expanded
+// encoding of FIPS 203 rather than the seed this package stores.
+type acvpVectors struct {
+	KeyGen []struct {
+		ParameterSet string
+		D, Z, Ek, Dk string
+	}
+	Encapsulation []struct {
+		ParameterSet string
+		Ek, M, C, K  string
+	}
+}
+
+func loadACVP(t *testing.T) *acvpVectors {
+	f, err := os.Open("testdata/acvp.json.gz")
+	require.NoError(t, err)
+	defer f.Close()
+	r, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	vectors := new(acvpVectors)
+	require.NoError(t, json.NewDecoder(r).Decode(vectors))
+	return vectors
+}
+
+func parameterSetByName(t *testing.T, name string) *ParameterSet {
+	for _, p := range parameterSets {
+		if p.Name == name {
+			return p
+		}
+	}
+	t.Fatalf("unknown parameter set %s", name)
+	return nil
+}
+
+func unhex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+// expandedKey is the decapsulation key encoding of FIPS 203 algorithm 16,
+// dk_PKE || ek || H(ek) || z
+func expandedKey(dk *DecapsulationKey) []byte {
+	var out []byte
+	for i := range dk.s {
+		out = polyEncode12(out, &dk.s[i])
+	}
+	out = append(out, dk.ek.raw...)
+	out = append(out, dk.ek.h[:]...)
+	return append(out, dk.seed[32:]...)
+}
+
+func TestACVPKeyGen(t *testing.T) {
+	vectors := loadACVP(t)
+	require.NotEmpty(t, vectors.KeyGen)
+	for _, v := range vectors.KeyGen {
+		p := parameterSetByName(t, v.ParameterSet)
+		dk, err := p.NewDecapsulationKey(append(unhex(t, v.D), unhex(t, v.Z)...))
+		require.NoError(t, err)
+		require.Equal(t, unhex(t, v.Ek), dk.EncapsulationKey().Bytes(), v.ParameterSet)
+		require.Equal(t, unhex(t, v.Dk), expandedKey(dk), v.ParameterSet)
+	}
+}
+
+func TestACVPEncapsulation(t *testing.T) {
+	vectors := loadACVP(t)
+	require.NotEmpty(t, vectors.Encapsulation)
+	for _, v := range vectors.Encapsulation {
+		p := parameterSetByName(t, v.ParameterSet)
+		ek, err := p.NewEncapsulationKey(unhex(t, v.Ek))
+		require.NoError(t, err)
+		key, ct, err := ek.Encapsulate(bytes.NewReader(unhex(t, v.M)))
+		require.NoError(t, err)
+		require.Equal(t, unhex(t, v.C), ct, v.ParameterSet)
+		require.Equal(t, unhex(t, v.K), key, v.ParameterSet)
+	}
+}
+
+func TestHybridRoundTrip(t *testing.T) {
+	for _, h := range []*Hybrid{X25519MLKEM512, X25519MLKEM768, X25519MLKEM1024} {
+		t.Run(h.Name, func(t *testing.T) {
+			dk, err := h.GenerateKey(crand.Reader)
+			require.NoError(t, err)
+			ek, err := h.NewEncapsulationKey(dk.EncapsulationKey().Bytes())
+			require.NoError(t, err)
+
+			key, ct, err := ek.Encapsulate(crand.Reader)
+			require.NoError(t, err)
+			require.Len(t, ct, h.CiphertextSize)
+			decapsulated, err := dk.Decapsulate(ct)
+			require.NoError(t, err)
+			require.Equal(t, key, decapsulated)
+
+			// tampering with either half changes the key
+			ct[0] ^= 1
+			other, err := dk.Decapsulate(ct)
+			require.NoError(t, err)
+			require.NotEqual(t, key, other)
+			ct[0] ^= 1
+			ct[len(ct)-1] ^= 1
+			other, err = dk.Decapsulate(ct)
+			require.NoError(t, err)
+			require.NotEqual(t, key, other)
+
+			restored, err := h.NewDecapsulationKey(dk.Bytes())
+			require.NoError(t, err)
+			require.Equal(t, dk.EncapsulationKey().Bytes(), restored.EncapsulationKey().Bytes())
+
+			dk.Zeroize()
+			require.Equal(t, make([]byte, HybridSeedSize), dk.Bytes())
+		})
+	}
+}
+
+// TestXWingVectors checks X25519MLKEM768 against the test vectors of
+// draft-connolly-cfrg-xwing-kem: it derives them from a SHAKE128 stream, prints
+// them in the format of spec/test-vectors.txt of the draft and compares the
+// SHAKE128 digest of the text with the digest of that file.
+func TestXWingVectors(t *testing.T) {
+	var w bytes.Buffer
+	writeHex := func(prefix string, val []byte) {
+		h := hex.EncodeToString(val)
+		if len(prefix)+len(h)+5 < 74 {
+			_, _ = fmt.Fprintf(&w, "%s     %s\n", prefix, h)
+			return
+		}
+		_, _ = fmt.Fprintf(&w, "%s\n", prefix)
+		for len(h) > 72 {
+			_, _ = fmt.Fprintf(&w, "  %s\n", h[:72])
+			h = h[72:]
+		}
+		_, _ = fmt.Fprintf(&w, "  %s\n", h)
+	}
+
+	drbg := sha3.NewShake128()
+	for i := 0; i < 3; i++ {
+		seed := make([]byte, HybridSeedSize)
+		_, _ = drbg.Read(seed)
+		eseed := make([]byte, 64)
+		writeHex("seed", seed)
+		dk, err := X25519MLKEM768.NewDecapsulationKey(seed)
+		require.NoError(t, err)
+		writeHex("sk", dk.Bytes())
+		writeHex("pk", dk.EncapsulationKey().Bytes())
+		_, _ = drbg.Read(eseed)
+		writeHex("eseed", eseed)
+		key, ct, err := dk.EncapsulationKey().Encapsulate(bytes.NewReader(eseed))
+		require.NoError(t, err)
+		writeHex("ct", ct)
+		writeHex("ss", key)
+		w.WriteString("\n")
+
+		decapsulated, err := dk.Decapsulate(ct)
+		require.NoError(t, err)
+		require.Equal(t, key, decapsulated)
+	}
+
+	digest := make([]byte, 32)
+	sha3.ShakeSum128(digest, w.Bytes())
+	require.Equal(t, "1bcd0057d861d6b866239936cadcaeee1ec0164dedc181c386e9e54fe46156fe", hex.EncodeToString(digest))
+}
+
+func BenchmarkMLKEM768(b *testing.B) {
+	dk, _ := MLKEM768.GenerateKey(crand.Reader)
+	ek := dk.EncapsulationKey()
+	_, ct, _ := ek.Encapsulate(crand.Reader)
+	b.Run("KeyGen", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = MLKEM768.GenerateKey(crand.Reader)
+		}
+	})
+	b.Run("Encapsulate", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _, _ = ek.Encapsulate(crand.Reader)
+		}
+	})
+	b.Run("Decapsulate", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = dk.Decapsulate(ct)
+		}
+	})
+}
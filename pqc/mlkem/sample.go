@@ -0,0 +1,60 @@
+package mlkem
+
+import (
+	"golang.org/x/crypto/sha3"
+)
+
+// sampleNTT is algorithm 7 of FIPS 203, it samples an element of T_q
+// uniformly from SHAKE128(rho || j || i)
+func sampleNTT(rho []byte, j, i byte) ringElement {
+	xof := sha3.NewShake128()
+	_, _ = xof.Write(rho)
+	_, _ = xof.Write([]byte{j, i})
+
+	var a ringElement
+	var buf [168]byte
+	off := len(buf)
+	for k := 0; k < n; {
+		if off >= len(buf) {
+			_, _ = xof.Read(buf[:])
+			off = 0
+		}
+		d1 := uint16(buf[off]) | uint16(buf[off+1]&0x0F)<<8
+		d2 := uint16(buf[off+1])>>4 | uint16(buf[off+2])<<4
+		off += 3
+		// rejection sampling on public data only
+		if d1 < q {
+			a[k] = fieldElement(d1)
+			k++
+		}
+		if d2 < q && k < n {
+			a[k] = fieldElement(d2)
+			k++
+		}
+	}
+	return a
+}
+
+// samplePolyCBD is algorithm 8 of FIPS 203 on PRF_eta(s, b) = SHAKE256(s || b),
+// it samples a polynomial with centered binomial coefficients of parameter eta
+func samplePolyCBD(s []byte, b byte, eta int) ringElement {
+	buf := make([]byte, 64*eta)
+	prf := sha3.NewShake256()
+	_, _ = prf.Write(s)
+	_, _ = prf.Write([]byte{b})
+	_, _ = prf.Read(buf)
+
+	bit := func(i int) fieldElement {
+		return fieldElement(buf[i>>3] >> (i & 7) & 1)
+	}
+	var f ringElement
+	for i := range f {
+		var x, y fieldElement
+		for j := 0; j < eta; j++ {
+			x += bit(2*i*eta + j)
+			y += bit(2*i*eta + eta + j)
+		}
+		f[i] = fieldSub(x, y)
+	}
+	return f
+}
@@ -0,0 +1,114 @@
+package slhdsa
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// acvpVectors are vectors of the SLH-DSA FIPS205 vector sets of the NIST
+// ACVP server, https://github.com/usnistgov/ACVP-Server: a key of each
+// parameter set, and signatures of pure SLH-DSA and of the internal functions
+// of the 128 bit sets. Signatures without additional randomness are
+// deterministic.
+type acvpVectors struct {
+	KeyGen []struct {
+		ParameterSet          string
+		SkSeed, SkPrf, PkSeed string
+		Sk, Pk                string
+	}
+	SigGen []struct {
+		ParameterSet, Interface string
+		Sk, Message, Context    string
+		AdditionalRandomness    string
+		Signature               string
+	}
+	SigVer []struct {
+		ParameterSet, Interface string
+		Pk, Message, Context    string
+		Signature               string
+		TestPassed              bool
+	}
+}
+
+func loadACVP(t *testing.T) *acvpVectors {
+	f, err := os.Open("testdata/acvp.json.gz")
+	require.NoError(t, err)
+	defer f.Close()
+	r, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	vectors := new(acvpVectors)
+	require.NoError(t, json.NewDecoder(r).Decode(vectors))
+	return vectors
+}
+
+func parameterSetByName(t *testing.T, name string) *ParameterSet {
+	for _, p := range []*ParameterSet{
+		SLHDSASHA2128s, SLHDSASHAKE128s, SLHDSASHA2128f, SLHDSASHAKE128f,
+		SLHDSASHA2192s, SLHDSASHAKE192s, SLHDSASHA2192f, SLHDSASHAKE192f,
+		SLHDSASHA2256s, SLHDSASHAKE256s, SLHDSASHA2256f, SLHDSASHAKE256f,
+	} {
+		if p.Name == name {
+			return p
+		}
+	}
+	t.Fatalf("unknown parameter set %s", name)
+	return nil
+}
+
+func unhex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+func TestACVP(t *testing.T) {
+	vectors := loadACVP(t)
+	require.NotEmpty(t, vectors.KeyGen)
+	require.NotEmpty(t, vectors.SigGen)
+	require.NotEmpty(t, vectors.SigVer)
+
+	for _, v := range vectors.KeyGen {
+		p := parameterSetByName(t, v.ParameterSet)
+		seeds := append(append(unhex(t, v.SkSeed), unhex(t, v.SkPrf)...), unhex(t, v.PkSeed)...)
+		sk, err := p.GenerateKey(bytes.NewReader(seeds))
+		require.NoError(t, err)
+		require.Equal(t, unhex(t, v.Sk), sk.Bytes(), p.Name)
+		require.Equal(t, unhex(t, v.Pk), sk.PublicKey().Bytes(), p.Name)
+	}
+
+	for _, v := range vectors.SigGen {
+		p := parameterSetByName(t, v.ParameterSet)
+		sk, err := p.NewPrivateKey(unhex(t, v.Sk))
+		require.NoError(t, err)
+		optRand := sk.pk.seed()
+		if v.AdditionalRandomness != "" {
+			optRand = unhex(t, v.AdditionalRandomness)
+		}
+		var sig []byte
+		if v.Interface == "internal" {
+			sig = sk.signInternal(unhex(t, v.Message), optRand)
+		} else {
+			sig, err = sk.sign(unhex(t, v.Message), unhex(t, v.Context), optRand)
+			require.NoError(t, err)
+		}
+		require.Equal(t, unhex(t, v.Signature), sig, p.Name)
+	}
+
+	for _, v := range vectors.SigVer {
+		p := parameterSetByName(t, v.ParameterSet)
+		pk, err := p.NewPublicKey(unhex(t, v.Pk))
+		require.NoError(t, err)
+		if v.Interface == "internal" {
+			require.Equal(t, v.TestPassed, pk.verifyInternal(unhex(t, v.Message), unhex(t, v.Signature)), p.Name)
+			continue
+		}
+		err = pk.Verify(unhex(t, v.Message), unhex(t, v.Signature), unhex(t, v.Context))
+		require.Equal(t, v.TestPassed, err == nil, p.Name)
+	}
+}
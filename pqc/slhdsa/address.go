@@ -0,0 +1,73 @@
+package slhdsa
+
+import (
+	"encoding/binary"
+)
+
+// address is the 32 byte ADRS of FIPS 205 section 4.2
+type address [32]byte
+
+// address types of FIPS 205 section 4.2
+const (
+	wotsHash  = 0
+	wotsPK    = 1
+	tree      = 2
+	forsTree  = 3
+	forsRoots = 4
+	wotsPRF   = 5
+	forsPRF   = 6
+)
+
+func (a *address) setLayerAddress(l uint32) {
+	binary.BigEndian.PutUint32(a[0:4], l)
+}
+
+// setTreeAddress sets the 12 byte tree address, tree indices fit in 64 bits
+func (a *address) setTreeAddress(t uint64) {
+	clear(a[4:8])
+	binary.BigEndian.PutUint64(a[8:16], t)
+}
+
+// setTypeAndClear sets the type and clears the last 12 bytes
+func (a *address) setTypeAndClear(y uint32) {
+	binary.BigEndian.PutUint32(a[16:20], y)
+	clear(a[20:32])
+}
+
+func (a *address) setKeyPairAddress(i uint32) {
+	binary.BigEndian.PutUint32(a[20:24], i)
+}
+
+func (a *address) keyPairAddress() uint32 {
+	return binary.BigEndian.Uint32(a[20:24])
+}
+
+func (a *address) setChainAddress(i uint32) {
+	binary.BigEndian.PutUint32(a[24:28], i)
+}
+
+func (a *address) setTreeHeight(z uint32) {
+	binary.BigEndian.PutUint32(a[24:28], z)
+}
+
+func (a *address) setHashAddress(i uint32) {
+	binary.BigEndian.PutUint32(a[28:32], i)
+}
+
+func (a *address) setTreeIndex(i uint32) {
+	binary.BigEndian.PutUint32(a[28:32], i)
+}
+
+func (a *address) treeIndex() uint32 {
+	return binary.BigEndian.Uint32(a[28:32])
+}
+
+// compressed returns the 22 byte ADRSc of FIPS 205 section 11.2 used by the
+// SHA2 parameter sets
+func (a *address) compressed() []byte {
+	c := make([]byte, 0, 22)
+	c = append(c, a[3])
+	c = append(c, a[8:16]...)
+	c = append(c, a[19])
+	return append(c, a[20:32]...)
+}
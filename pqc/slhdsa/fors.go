@@ -0,0 +1,60 @@
+package slhdsa
+
+// FORS few-time signatures of FIPS 205 section 8
+
+// forsSKGen is algorithm 14 of FIPS 205
+func (h *hasher) forsSKGen(skSeed []byte, adrs *address, idx uint32) []byte {
+	skAdrs := *adrs
+	skAdrs.setTypeAndClear(forsPRF)
+	skAdrs.setKeyPairAddress(adrs.keyPairAddress())
+	skAdrs.setTreeIndex(idx)
+	return h.prf(&skAdrs, skSeed)
+}
+
+// forsNode is algorithm 15 of FIPS 205
+func (h *hasher) forsNode(skSeed []byte, i, z uint32, adrs *address) []byte {
+	if z == 0 {
+		sk := h.forsSKGen(skSeed, adrs, i)
+		adrs.setTreeHeight(0)
+		adrs.setTreeIndex(i)
+		return h.f(adrs, sk)
+	}
+	left := h.forsNode(skSeed, 2*i, z-1, adrs)
+	right := h.forsNode(skSeed, 2*i+1, z-1, adrs)
+	adrs.setTreeHeight(z)
+	adrs.setTreeIndex(i)
+	return h.h(adrs, left, right)
+}
+
+// forsSign is algorithm 16 of FIPS 205
+func (h *hasher) forsSign(md, skSeed []byte, adrs *address) []byte {
+	a, k := h.p.a, h.p.k
+	indices := base2b(md, a, k)
+	sig := make([]byte, 0, k*(a+1)*h.p.n)
+	for i, idx := range indices {
+		sig = append(sig, h.forsSKGen(skSeed, adrs, uint32(i)<<a+idx)...)
+		for j := 0; j < a; j++ {
+			s := idx>>j ^ 1
+			sig = append(sig, h.forsNode(skSeed, uint32(i)<<(a-j)+s, uint32(j), adrs)...)
+		}
+	}
+	return sig
+}
+
+// forsPKFromSig is algorithm 17 of FIPS 205
+func (h *hasher) forsPKFromSig(sig, md []byte, adrs *address) []byte {
+	n, a, k := h.p.n, h.p.a, h.p.k
+	indices := base2b(md, a, k)
+	roots := make([][]byte, k)
+	for i, idx := range indices {
+		tree := sig[i*(a+1)*n : (i+1)*(a+1)*n]
+		adrs.setTreeHeight(0)
+		adrs.setTreeIndex(uint32(i)<<a + idx)
+		node := h.f(adrs, tree[:n])
+		roots[i] = h.climb(node, tree[n:], idx, uint32(i)<<a, a, adrs)
+	}
+	pkAdrs := *adrs
+	pkAdrs.setTypeAndClear(forsRoots)
+	pkAdrs.setKeyPairAddress(adrs.keyPairAddress())
+	return h.h(&pkAdrs, roots...)
+}
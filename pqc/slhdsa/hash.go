@@ -0,0 +1,124 @@
+package slhdsa
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"hash"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// hasher holds the public seed and implements the tweakable hash functions
+// and PRFs of FIPS 205 sections 11.1 (SHAKE) and 11.2 (SHA2)
+type hasher struct {
+	p      *ParameterSet
+	pkSeed []byte
+}
+
+func (p *ParameterSet) newHasher(pkSeed []byte) *hasher {
+	return &hasher{p: p, pkSeed: pkSeed}
+}
+
+// tweak computes Hash(PK.seed || pad || ADRS || M) truncated to n bytes, with
+// the block padding and compressed address of the SHA2 parameter sets
+func (h *hasher) tweak(newHash func() hash.Hash, blockSize int, adrs *address, msgs ...[]byte) []byte {
+	if !h.p.sha2 {
+		xof := sha3.NewShake256()
+		_, _ = xof.Write(h.pkSeed)
+		_, _ = xof.Write(adrs[:])
+		for _, m := range msgs {
+			_, _ = xof.Write(m)
+		}
+		out := make([]byte, h.p.n)
+		_, _ = xof.Read(out)
+		return out
+	}
+	md := newHash()
+	_, _ = md.Write(h.pkSeed)
+	_, _ = md.Write(make([]byte, blockSize-h.p.n))
+	_, _ = md.Write(adrs.compressed())
+	for _, m := range msgs {
+		_, _ = md.Write(m)
+	}
+	return md.Sum(nil)[:h.p.n]
+}
+
+// f is the tweakable hash F of a single n byte block
+func (h *hasher) f(adrs *address, m []byte) []byte {
+	return h.tweak(sha256.New, sha256.BlockSize, adrs, m)
+}
+
+// h is the tweakable hash H of two blocks, and T_l of l blocks
+func (h *hasher) h(adrs *address, msgs ...[]byte) []byte {
+	if h.p.n > 16 {
+		return h.tweak(sha512.New, sha512.BlockSize, adrs, msgs...)
+	}
+	return h.tweak(sha256.New, sha256.BlockSize, adrs, msgs...)
+}
+
+// prf derives the secret values of WOTS+ and FORS from SK.seed
+func (h *hasher) prf(adrs *address, skSeed []byte) []byte {
+	return h.tweak(sha256.New, sha256.BlockSize, adrs, skSeed)
+}
+
+// prfMsg derives the signature randomizer R
+func (h *hasher) prfMsg(skPrf, optRand, msg []byte) []byte {
+	if !h.p.sha2 {
+		xof := sha3.NewShake256()
+		_, _ = xof.Write(skPrf)
+		_, _ = xof.Write(optRand)
+		_, _ = xof.Write(msg)
+		out := make([]byte, h.p.n)
+		_, _ = xof.Read(out)
+		return out
+	}
+	newHash := sha256.New
+	if h.p.n > 16 {
+		newHash = sha512.New
+	}
+	mac := hmac.New(newHash, skPrf)
+	_, _ = mac.Write(optRand)
+	_, _ = mac.Write(msg)
+	return mac.Sum(nil)[:h.p.n]
+}
+
+// hMsg computes the m byte message digest
+func (h *hasher) hMsg(r, pkRoot, msg []byte) []byte {
+	out := make([]byte, h.p.m)
+	if !h.p.sha2 {
+		xof := sha3.NewShake256()
+		_, _ = xof.Write(r)
+		_, _ = xof.Write(h.pkSeed)
+		_, _ = xof.Write(pkRoot)
+		_, _ = xof.Write(msg)
+		_, _ = xof.Read(out)
+		return out
+	}
+	newHash := sha256.New
+	if h.p.n > 16 {
+		newHash = sha512.New
+	}
+	md := newHash()
+	_, _ = md.Write(r)
+	_, _ = md.Write(h.pkSeed)
+	_, _ = md.Write(pkRoot)
+	_, _ = md.Write(msg)
+	seed := append(append(append([]byte{}, r...), h.pkSeed...), md.Sum(nil)...)
+	return mgf1(newHash, seed, out)
+}
+
+// mgf1 fills out with MGF1 of RFC 8017 appendix B.2.1
+func mgf1(newHash func() hash.Hash, seed, out []byte) []byte {
+	var counter [4]byte
+	md := newHash()
+	for i, off := uint32(0), 0; off < len(out); i++ {
+		binary.BigEndian.PutUint32(counter[:], i)
+		md.Reset()
+		_, _ = md.Write(seed)
+		_, _ = md.Write(counter[:])
+		off += copy(out[off:], md.Sum(nil))
+	}
+	return out
+}
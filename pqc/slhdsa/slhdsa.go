@@ -0,0 +1,295 @@
+// Package slhdsa implements SLH-DSA, the stateless hash-based digital
+// signature algorithm of FIPS 205 https://doi.org/10.6028/NIST.FIPS.205, with
+// the twelve SHA2 and SHAKE parameter sets.
+//
+// Only pure SLH-DSA with an optional context string is provided, HashSLH-DSA
+// is not. The s parameter sets have small signatures and slow signing, the f
+// parameter sets sign quickly with larger signatures.
+package slhdsa
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"io"
+
+	"github.com/go-sonr/crypto/core/secret"
+	"github.com/go-sonr/crypto/internal"
+)
+
+// MaxContextSize is the largest context string accepted by Sign and Verify
+const MaxContextSize = 255
+
+// ParameterSet is one of the SLH-DSA parameter sets of FIPS 205 section 11
+type ParameterSet struct {
+	// Name is the FIPS 205 name of the parameter set
+	Name string
+	// PublicKeySize is the size of an encoded public key PK.seed || PK.root
+	PublicKeySize int
+	// PrivateKeySize is the size of an encoded private key
+	// SK.seed || SK.prf || PK.seed || PK.root
+	PrivateKeySize int
+	// SignatureSize is the size of a signature
+	SignatureSize int
+
+	sha2 bool
+	n    int
+	h    int
+	d    int
+	hp   int // h' = h / d, the height of an XMSS tree
+	a    int
+	k    int
+	m    int
+}
+
+func newParameterSet(name string, sha2 bool, n, h, d, a, k int) *ParameterSet {
+	hp := h / d
+	p := &ParameterSet{
+		Name:           name,
+		PublicKeySize:  2 * n,
+		PrivateKeySize: 4 * n,
+		sha2:           sha2,
+		n:              n,
+		h:              h,
+		d:              d,
+		hp:             hp,
+		a:              a,
+		k:              k,
+		m:              (k*a+7)/8 + (h-hp+7)/8 + (hp+7)/8,
+	}
+	p.SignatureSize = n + k*(1+a)*n + (h+d*p.wotsLen())*n
+	return p
+}
+
+var (
+	// SLHDSASHA2128s is SLH-DSA-SHA2-128s, security category 1
+	SLHDSASHA2128s = newParameterSet("SLH-DSA-SHA2-128s", true, 16, 63, 7, 12, 14)
+	// SLHDSASHAKE128s is SLH-DSA-SHAKE-128s, security category 1
+	SLHDSASHAKE128s = newParameterSet("SLH-DSA-SHAKE-128s", false, 16, 63, 7, 12, 14)
+	// SLHDSASHA2128f is SLH-DSA-SHA2-128f, security category 1
+	SLHDSASHA2128f = newParameterSet("SLH-DSA-SHA2-128f", true, 16, 66, 22, 6, 33)
+	// SLHDSASHAKE128f is SLH-DSA-SHAKE-128f, security category 1
+	SLHDSASHAKE128f = newParameterSet("SLH-DSA-SHAKE-128f", false, 16, 66, 22, 6, 33)
+	// SLHDSASHA2192s is SLH-DSA-SHA2-192s, security category 3
+	SLHDSASHA2192s = newParameterSet("SLH-DSA-SHA2-192s", true, 24, 63, 7, 14, 17)
+	// SLHDSASHAKE192s is SLH-DSA-SHAKE-192s, security category 3
+	SLHDSASHAKE192s = newParameterSet("SLH-DSA-SHAKE-192s", false, 24, 63, 7, 14, 17)
+	// SLHDSASHA2192f is SLH-DSA-SHA2-192f, security category 3
+	SLHDSASHA2192f = newParameterSet("SLH-DSA-SHA2-192f", true, 24, 66, 22, 8, 33)
+	// SLHDSASHAKE192f is SLH-DSA-SHAKE-192f, security category 3
+	SLHDSASHAKE192f = newParameterSet("SLH-DSA-SHAKE-192f", false, 24, 66, 22, 8, 33)
+	// SLHDSASHA2256s is SLH-DSA-SHA2-256s, security category 5
+	SLHDSASHA2256s = newParameterSet("SLH-DSA-SHA2-256s", true, 32, 64, 8, 14, 22)
+	// SLHDSASHAKE256s is SLH-DSA-SHAKE-256s, security category 5
+	SLHDSASHAKE256s = newParameterSet("SLH-DSA-SHAKE-256s", false, 32, 64, 8, 14, 22)
+	// SLHDSASHA2256f is SLH-DSA-SHA2-256f, security category 5
+	SLHDSASHA2256f = newParameterSet("SLH-DSA-SHA2-256f", true, 32, 68, 17, 9, 35)
+	// SLHDSASHAKE256f is SLH-DSA-SHAKE-256f, security category 5
+	SLHDSASHAKE256f = newParameterSet("SLH-DSA-SHAKE-256f", false, 32, 68, 17, 9, 35)
+)
+
+// PublicKey is an SLH-DSA public key
+type PublicKey struct {
+	params *ParameterSet
+	raw    []byte // PK.seed || PK.root
+}
+
+// PrivateKey is an SLH-DSA private key
+type PrivateKey struct {
+	raw []byte // SK.seed || SK.prf
+	pk  *PublicKey
+}
+
+// GenerateKey draws SK.seed, SK.prf and PK.seed from reader and computes
+// PK.root, slh_keygen_internal of FIPS 205 algorithm 18
+func (p *ParameterSet) GenerateKey(reader io.Reader) (*PrivateKey, error) {
+	if reader == nil {
+		return nil, internal.ErrNilArguments
+	}
+	seeds := make([]byte, 3*p.n)
+	if _, err := io.ReadFull(reader, seeds); err != nil {
+		return nil, err
+	}
+	defer secret.Wipe(seeds)
+	skSeed, pkSeed := seeds[:p.n], seeds[2*p.n:]
+
+	var adrs address
+	adrs.setLayerAddress(uint32(p.d - 1))
+	root := p.newHasher(pkSeed).xmssNode(skSeed, 0, uint32(p.hp), &adrs)
+	return p.NewPrivateKey(append(append([]byte{}, seeds...), root...))
+}
+
+// NewPrivateKey decodes a private key SK.seed || SK.prf || PK.seed || PK.root
+func (p *ParameterSet) NewPrivateKey(b []byte) (*PrivateKey, error) {
+	if len(b) != p.PrivateKeySize {
+		return nil, fmt.Errorf("%s private key must be %d bytes", p.Name, p.PrivateKeySize)
+	}
+	return &PrivateKey{
+		raw: append([]byte{}, b[:2*p.n]...),
+		pk:  &PublicKey{params: p, raw: append([]byte{}, b[2*p.n:]...)},
+	}, nil
+}
+
+// NewPublicKey decodes a public key PK.seed || PK.root
+func (p *ParameterSet) NewPublicKey(b []byte) (*PublicKey, error) {
+	if len(b) != p.PublicKeySize {
+		return nil, fmt.Errorf("%s public key must be %d bytes", p.Name, p.PublicKeySize)
+	}
+	return &PublicKey{params: p, raw: append([]byte{}, b...)}, nil
+}
+
+// ParameterSet returns the parameter set of the key
+func (pk *PublicKey) ParameterSet() *ParameterSet {
+	return pk.params
+}
+
+// Bytes returns the encoded public key
+func (pk *PublicKey) Bytes() []byte {
+	return append([]byte{}, pk.raw...)
+}
+
+// Equal reports whether pk and other are the same key
+func (pk *PublicKey) Equal(other *PublicKey) bool {
+	return pk.params == other.params && subtle.ConstantTimeCompare(pk.raw, other.raw) == 1
+}
+
+func (pk *PublicKey) seed() []byte {
+	return pk.raw[:pk.params.n]
+}
+
+func (pk *PublicKey) root() []byte {
+	return pk.raw[pk.params.n:]
+}
+
+// ParameterSet returns the parameter set of the key
+func (sk *PrivateKey) ParameterSet() *ParameterSet {
+	return sk.pk.params
+}
+
+// Bytes returns the encoded private key
+func (sk *PrivateKey) Bytes() []byte {
+	return append(append([]byte{}, sk.raw...), sk.pk.raw...)
+}
+
+// PublicKey returns the public key of sk
+func (sk *PrivateKey) PublicKey() *PublicKey {
+	return sk.pk
+}
+
+// Zeroize overwrites SK.seed and SK.prf with zeros
+func (sk *PrivateKey) Zeroize() {
+	secret.Wipe(sk.raw)
+}
+
+// Sign returns the hedged signature of message under context, drawing the
+// n bytes of opt_rand from reader
+func (sk *PrivateKey) Sign(reader io.Reader, message, context []byte) ([]byte, error) {
+	if reader == nil {
+		return nil, internal.ErrNilArguments
+	}
+	optRand := make([]byte, sk.pk.params.n)
+	if _, err := io.ReadFull(reader, optRand); err != nil {
+		return nil, err
+	}
+	return sk.sign(message, context, optRand)
+}
+
+// SignDeterministic returns the deterministic signature of message under
+// context, the variant of FIPS 205 section 9.2 with opt_rand = PK.seed
+func (sk *PrivateKey) SignDeterministic(message, context []byte) ([]byte, error) {
+	return sk.sign(message, context, sk.pk.seed())
+}
+
+func (sk *PrivateKey) sign(message, context, optRand []byte) ([]byte, error) {
+	msg, err := encodeMessage(message, context)
+	if err != nil {
+		return nil, err
+	}
+	return sk.signInternal(msg, optRand), nil
+}
+
+// encodeMessage returns M' = 0 || |ctx| || ctx || M, the message encoding of
+// pure SLH-DSA, FIPS 205 algorithms 22 and 24
+func encodeMessage(message, context []byte) ([]byte, error) {
+	if len(context) > MaxContextSize {
+		return nil, fmt.Errorf("context must be at most %d bytes", MaxContextSize)
+	}
+	msg := make([]byte, 0, 2+len(context)+len(message))
+	msg = append(msg, 0, byte(len(context)))
+	msg = append(msg, context...)
+	return append(msg, message...), nil
+}
+
+// splitDigest returns md, idx_tree and idx_leaf of a message digest
+func (p *ParameterSet) splitDigest(digest []byte) ([]byte, uint64, uint32) {
+	mdSize := (p.k*p.a + 7) / 8
+	treeSize := (p.h - p.hp + 7) / 8
+	md := digest[:mdSize]
+
+	var idxTree uint64
+	for _, b := range digest[mdSize : mdSize+treeSize] {
+		idxTree = idxTree<<8 | uint64(b)
+	}
+	if bits := p.h - p.hp; bits < 64 {
+		idxTree &= 1<<bits - 1
+	}
+	var idxLeaf uint32
+	for _, b := range digest[mdSize+treeSize:] {
+		idxLeaf = idxLeaf<<8 | uint32(b)
+	}
+	idxLeaf &= 1<<p.hp - 1
+	return md, idxTree, idxLeaf
+}
+
+// signInternal is slh_sign_internal of FIPS 205 algorithm 19
+func (sk *PrivateKey) signInternal(msg, optRand []byte) []byte {
+	p := sk.pk.params
+	skSeed, skPrf := sk.raw[:p.n], sk.raw[p.n:]
+	h := p.newHasher(sk.pk.seed())
+
+	r := h.prfMsg(skPrf, optRand, msg)
+	md, idxTree, idxLeaf := p.splitDigest(h.hMsg(r, sk.pk.root(), msg))
+
+	var adrs address
+	adrs.setTreeAddress(idxTree)
+	adrs.setTypeAndClear(forsTree)
+	adrs.setKeyPairAddress(idxLeaf)
+	forsSig := h.forsSign(md, skSeed, &adrs)
+	forsPK := h.forsPKFromSig(forsSig, md, &adrs)
+
+	sig := make([]byte, 0, p.SignatureSize)
+	sig = append(sig, r...)
+	sig = append(sig, forsSig...)
+	return append(sig, h.htSign(forsPK, skSeed, idxTree, idxLeaf)...)
+}
+
+// Verify checks signature of message under context, slh_verify of FIPS 205
+// algorithm 24
+func (pk *PublicKey) Verify(message, signature, context []byte) error {
+	msg, err := encodeMessage(message, context)
+	if err != nil {
+		return err
+	}
+	if !pk.verifyInternal(msg, signature) {
+		return fmt.Errorf("invalid %s signature", pk.params.Name)
+	}
+	return nil
+}
+
+// verifyInternal is slh_verify_internal of FIPS 205 algorithm 20
+func (pk *PublicKey) verifyInternal(msg, sig []byte) bool {
+	p := pk.params
+	if len(sig) != p.SignatureSize {
+		return false
+	}
+	h := p.newHasher(pk.seed())
+	forsSize := p.k * (1 + p.a) * p.n
+	r, forsSig, htSig := sig[:p.n], sig[p.n:p.n+forsSize], sig[p.n+forsSize:]
+	md, idxTree, idxLeaf := p.splitDigest(h.hMsg(r, pk.root(), msg))
+
+	var adrs address
+	adrs.setTreeAddress(idxTree)
+	adrs.setTypeAndClear(forsTree)
+	adrs.setKeyPairAddress(idxLeaf)
+	forsPK := h.forsPKFromSig(forsSig, md, &adrs)
+	return h.htVerify(forsPK, htSig, pk.root(), idxTree, idxLeaf)
+}
@@ -0,0 +1,119 @@
+package slhdsa
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSizes(t *testing.T) {
+	sizes := map[*ParameterSet]int{
+		SLHDSASHA2128s:  7856,
+		SLHDSASHAKE128s: 7856,
+		SLHDSASHA2128f:  17088,
+		SLHDSASHAKE128f: 17088,
+		SLHDSASHA2192s:  16224,
+		SLHDSASHAKE192s: 16224,
+		SLHDSASHA2192f:  35664,
+		SLHDSASHAKE192f: 35664,
+		SLHDSASHA2256s:  29792,
+		SLHDSASHAKE256s: 29792,
+		SLHDSASHA2256f:  49856,
+		SLHDSASHAKE256f: 49856,
+	}
+	for p, size := range sizes {
+		require.Equal(t, size, p.SignatureSize, p.Name)
+		require.Equal(t, 2*p.n, p.PublicKeySize, p.Name)
+	}
+	require.Equal(t, 30, SLHDSASHA2128s.m)
+	require.Equal(t, 34, SLHDSASHA2128f.m)
+	require.Equal(t, 39, SLHDSASHA2192s.m)
+	require.Equal(t, 42, SLHDSASHA2192f.m)
+	require.Equal(t, 47, SLHDSASHA2256s.m)
+	require.Equal(t, 49, SLHDSASHA2256f.m)
+}
+
+func TestBase2b(t *testing.T) {
+	require.Equal(t, []uint32{0xA, 0xB, 0xC, 0xD}, base2b([]byte{0xAB, 0xCD}, 4, 4))
+	require.Equal(t, []uint32{0xABC, 0xDEF}, base2b([]byte{0xAB, 0xCD, 0xEF}, 12, 2))
+	require.Equal(t, []uint32{0x2A, 0x3C}, base2b([]byte{0xAB, 0xCD}, 6, 2))
+}
+
+func TestSignVerify(t *testing.T) {
+	sets := []*ParameterSet{
+		SLHDSASHA2128f, SLHDSASHAKE128f,
+		SLHDSASHA2192f, SLHDSASHAKE192f,
+		SLHDSASHA2256f, SLHDSASHAKE256f,
+	}
+	if !testing.Short() {
+		sets = append(sets, SLHDSASHA2128s, SLHDSASHAKE128s)
+	}
+	msg := []byte("post-quantum")
+	ctx := []byte("did:key")
+	for _, p := range sets {
+		t.Run(p.Name, func(t *testing.T) {
+			sk, err := p.GenerateKey(crand.Reader)
+			require.NoError(t, err)
+			pk, err := p.NewPublicKey(sk.PublicKey().Bytes())
+			require.NoError(t, err)
+			require.True(t, pk.Equal(sk.PublicKey()))
+
+			sig, err := sk.Sign(crand.Reader, msg, ctx)
+			require.NoError(t, err)
+			require.Len(t, sig, p.SignatureSize)
+			require.NoError(t, pk.Verify(msg, sig, ctx))
+			require.Error(t, pk.Verify([]byte("post-classical"), sig, ctx))
+			require.Error(t, pk.Verify(msg, sig, nil))
+			require.Error(t, pk.Verify(msg, sig[1:], ctx))
+			for _, i := range []int{0, p.n, len(sig) / 2, len(sig) - 1} {
+				tampered := append([]byte{}, sig...)
+				tampered[i] ^= 1
+				require.Error(t, pk.Verify(msg, tampered, ctx))
+			}
+
+			sig1, err := sk.SignDeterministic(msg, ctx)
+			require.NoError(t, err)
+			sig2, err := sk.SignDeterministic(msg, ctx)
+			require.NoError(t, err)
+			require.Equal(t, sig1, sig2)
+			require.NoError(t, pk.Verify(msg, sig1, ctx))
+
+			sk2, err := p.NewPrivateKey(sk.Bytes())
+			require.NoError(t, err)
+			sig3, err := sk2.SignDeterministic(msg, ctx)
+			require.NoError(t, err)
+			require.Equal(t, sig1, sig3)
+
+			_, err = sk.Sign(crand.Reader, msg, make([]byte, MaxContextSize+1))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestZeroize(t *testing.T) {
+	sk, err := SLHDSASHAKE128f.GenerateKey(crand.Reader)
+	require.NoError(t, err)
+	sk.Zeroize()
+	require.Equal(t, make([]byte, 2*SLHDSASHAKE128f.n), sk.Bytes()[:2*SLHDSASHAKE128f.n])
+}
+
+func BenchmarkSign(b *testing.B) {
+	sk, _ := SLHDSASHA2128f.GenerateKey(crand.Reader)
+	msg := []byte("benchmark")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = sk.Sign(crand.Reader, msg, nil)
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	sk, _ := SLHDSASHA2128f.GenerateKey(crand.Reader)
+	msg := []byte("benchmark")
+	sig, _ := sk.Sign(crand.Reader, msg, nil)
+	pk := sk.PublicKey()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = pk.Verify(msg, sig, nil)
+	}
+}
@@ -0,0 +1,106 @@
+package slhdsa
+
+// WOTS+ one-time signatures of FIPS 205 section 5, with lg_w = 4
+
+const (
+	lgW = 4
+	w   = 1 << lgW
+	// len2 is the number of checksum digits for every parameter set
+	len2 = 3
+)
+
+// wotsLen is len = len1 + len2 with len1 = 2n
+func (p *ParameterSet) wotsLen() int {
+	return 2*p.n + len2
+}
+
+// base2b is algorithm 4 of FIPS 205, it splits x into outLen big endian
+// digits of b bits
+func base2b(x []byte, b, outLen int) []uint32 {
+	out := make([]uint32, outLen)
+	in, bits := 0, 0
+	var total uint64
+	for i := range out {
+		for bits < b {
+			total = total<<8 | uint64(x[in])
+			in++
+			bits += 8
+		}
+		bits -= b
+		out[i] = uint32(total>>bits) & (1<<b - 1)
+	}
+	return out
+}
+
+// wotsDigits returns the message digits followed by the checksum digits
+func (p *ParameterSet) wotsDigits(m []byte) []uint32 {
+	msg := base2b(m, lgW, 2*p.n)
+	var csum uint32
+	for _, v := range msg {
+		csum += w - 1 - v
+	}
+	// shift the 12 bit checksum into two bytes
+	csum <<= 4
+	return append(msg, base2b([]byte{byte(csum >> 8), byte(csum)}, lgW, len2)...)
+}
+
+// chain is algorithm 5 of FIPS 205
+func (h *hasher) chain(x []byte, i, s uint32, adrs *address) []byte {
+	tmp := x
+	for j := i; j < i+s; j++ {
+		adrs.setHashAddress(j)
+		tmp = h.f(adrs, tmp)
+	}
+	return tmp
+}
+
+// wotsSecret derives the secret value of chain i
+func (h *hasher) wotsSecret(skSeed []byte, adrs *address, i uint32) []byte {
+	skAdrs := *adrs
+	skAdrs.setTypeAndClear(wotsPRF)
+	skAdrs.setKeyPairAddress(adrs.keyPairAddress())
+	skAdrs.setChainAddress(i)
+	return h.prf(&skAdrs, skSeed)
+}
+
+// wotsCompress hashes the chain ends into the WOTS+ public key
+func (h *hasher) wotsCompress(adrs *address, ends [][]byte) []byte {
+	pkAdrs := *adrs
+	pkAdrs.setTypeAndClear(wotsPK)
+	pkAdrs.setKeyPairAddress(adrs.keyPairAddress())
+	return h.h(&pkAdrs, ends...)
+}
+
+// wotsPKGen is algorithm 6 of FIPS 205
+func (h *hasher) wotsPKGen(skSeed []byte, adrs *address) []byte {
+	ends := make([][]byte, h.p.wotsLen())
+	for i := range ends {
+		sk := h.wotsSecret(skSeed, adrs, uint32(i))
+		adrs.setChainAddress(uint32(i))
+		ends[i] = h.chain(sk, 0, w-1, adrs)
+	}
+	return h.wotsCompress(adrs, ends)
+}
+
+// wotsSign is algorithm 7 of FIPS 205
+func (h *hasher) wotsSign(m, skSeed []byte, adrs *address) []byte {
+	digits := h.p.wotsDigits(m)
+	sig := make([]byte, 0, len(digits)*h.p.n)
+	for i, v := range digits {
+		sk := h.wotsSecret(skSeed, adrs, uint32(i))
+		adrs.setChainAddress(uint32(i))
+		sig = append(sig, h.chain(sk, 0, v, adrs)...)
+	}
+	return sig
+}
+
+// wotsPKFromSig is algorithm 8 of FIPS 205
+func (h *hasher) wotsPKFromSig(sig, m []byte, adrs *address) []byte {
+	digits := h.p.wotsDigits(m)
+	ends := make([][]byte, len(digits))
+	for i, v := range digits {
+		adrs.setChainAddress(uint32(i))
+		ends[i] = h.chain(sig[i*h.p.n:(i+1)*h.p.n], v, w-1-v, adrs)
+	}
+	return h.wotsCompress(adrs, ends)
+}
@@ -0,0 +1,100 @@
+package slhdsa
+
+import (
+	"bytes"
+)
+
+// XMSS trees and the hypertree of FIPS 205 sections 6 and 7
+
+// xmssNode is algorithm 9 of FIPS 205
+func (h *hasher) xmssNode(skSeed []byte, i, z uint32, adrs *address) []byte {
+	if z == 0 {
+		adrs.setTypeAndClear(wotsHash)
+		adrs.setKeyPairAddress(i)
+		return h.wotsPKGen(skSeed, adrs)
+	}
+	left := h.xmssNode(skSeed, 2*i, z-1, adrs)
+	right := h.xmssNode(skSeed, 2*i+1, z-1, adrs)
+	adrs.setTypeAndClear(tree)
+	adrs.setTreeHeight(z)
+	adrs.setTreeIndex(i)
+	return h.h(adrs, left, right)
+}
+
+// xmssSign is algorithm 10 of FIPS 205
+func (h *hasher) xmssSign(m, skSeed []byte, idx uint32, adrs *address) []byte {
+	auth := make([]byte, 0, h.p.hp*h.p.n)
+	for j := 0; j < h.p.hp; j++ {
+		k := idx>>j ^ 1
+		auth = append(auth, h.xmssNode(skSeed, k, uint32(j), adrs)...)
+	}
+	adrs.setTypeAndClear(wotsHash)
+	adrs.setKeyPairAddress(idx)
+	return append(h.wotsSign(m, skSeed, adrs), auth...)
+}
+
+// xmssPKFromSig is algorithm 11 of FIPS 205
+func (h *hasher) xmssPKFromSig(idx uint32, sig, m []byte, adrs *address) []byte {
+	n := h.p.n
+	wotsSize := h.p.wotsLen() * n
+	adrs.setTypeAndClear(wotsHash)
+	adrs.setKeyPairAddress(idx)
+	node := h.wotsPKFromSig(sig[:wotsSize], m, adrs)
+	adrs.setTypeAndClear(tree)
+	return h.climb(node, sig[wotsSize:], idx, 0, h.p.hp, adrs)
+}
+
+// climb hashes node with its authentication path up to the root of the tree,
+// the loop shared by xmss_PKFromSig and fors_pkFromSig
+func (h *hasher) climb(node, auth []byte, leaf, offset uint32, height int, adrs *address) []byte {
+	n := h.p.n
+	adrs.setTreeIndex(offset + leaf)
+	for k := 0; k < height; k++ {
+		adrs.setTreeHeight(uint32(k + 1))
+		sibling := auth[k*n : (k+1)*n]
+		if leaf>>k&1 == 0 {
+			adrs.setTreeIndex(adrs.treeIndex() / 2)
+			node = h.h(adrs, node, sibling)
+		} else {
+			adrs.setTreeIndex((adrs.treeIndex() - 1) / 2)
+			node = h.h(adrs, sibling, node)
+		}
+	}
+	return node
+}
+
+// htSign is algorithm 12 of FIPS 205
+func (h *hasher) htSign(m, skSeed []byte, idxTree uint64, idxLeaf uint32) []byte {
+	var adrs address
+	adrs.setTreeAddress(idxTree)
+	sig := h.xmssSign(m, skSeed, idxLeaf, &adrs)
+	root := h.xmssPKFromSig(idxLeaf, sig, m, &adrs)
+	for j := 1; j < h.p.d; j++ {
+		idxLeaf = uint32(idxTree & (1<<h.p.hp - 1))
+		idxTree >>= h.p.hp
+		adrs.setLayerAddress(uint32(j))
+		adrs.setTreeAddress(idxTree)
+		layer := h.xmssSign(root, skSeed, idxLeaf, &adrs)
+		sig = append(sig, layer...)
+		if j < h.p.d-1 {
+			root = h.xmssPKFromSig(idxLeaf, layer, root, &adrs)
+		}
+	}
+	return sig
+}
+
+// htVerify is algorithm 13 of FIPS 205
+func (h *hasher) htVerify(m, sig, pkRoot []byte, idxTree uint64, idxLeaf uint32) bool {
+	xmssSize := (h.p.wotsLen() + h.p.hp) * h.p.n
+	var adrs address
+	adrs.setTreeAddress(idxTree)
+	node := h.xmssPKFromSig(idxLeaf, sig[:xmssSize], m, &adrs)
+	for j := 1; j < h.p.d; j++ {
+		idxLeaf = uint32(idxTree & (1<<h.p.hp - 1))
+		idxTree >>= h.p.hp
+		adrs.setLayerAddress(uint32(j))
+		adrs.setTreeAddress(idxTree)
+		node = h.xmssPKFromSig(idxLeaf, sig[j*xmssSize:(j+1)*xmssSize], node, &adrs)
+	}
+	return bytes.Equal(node, pkRoot)
+}
@@ -0,0 +1,448 @@
+package proofs
+
+import (
+	"bytes"
+	crand "crypto/rand"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	mb "github.com/multiformats/go-multibase"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/keys/parsers"
+	"github.com/go-sonr/crypto/signatures/bbs"
+)
+
+// BBS2023 is the cryptosuite identifier of BBS proofs
+const BBS2023 = "sonr-bbs-2023"
+
+// bbsCurve signs with BBS+ keys in G2, the keys of a BLS12-381 G2 did:key
+var bbsCurve = curves.BLS12381(&curves.PointBls12381G2{})
+
+// BBSKey is a BBS+ issuer key
+type BBSKey struct {
+	sk *bbs.SecretKey
+	pk *bbs.PublicKey
+	id parsers.DIDKey
+}
+
+// GenerateBBSKey returns a random BBS+ issuer key
+func GenerateBBSKey() (*BBSKey, error) {
+	sk, err := bbs.NewSecretKey(bbsCurve)
+	if err != nil {
+		return nil, err
+	}
+	return NewBBSKey(sk)
+}
+
+// NewBBSKey wraps a BBS+ secret key on BLS12-381 with public keys in G2
+func NewBBSKey(sk *bbs.SecretKey) (*BBSKey, error) {
+	if sk == nil {
+		return nil, fmt.Errorf("secret key is required")
+	}
+	pk := sk.PublicKey()
+	raw, err := pk.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	pub, err := parsers.UnmarshalBLS12381G2PublicKey(raw)
+	if err != nil {
+		return nil, err
+	}
+	id, err := parsers.NewKeyDID(pub)
+	if err != nil {
+		return nil, err
+	}
+	return &BBSKey{sk: sk, pk: pk, id: id}, nil
+}
+
+// DIDKey is the did:key of the public key
+func (k *BBSKey) DIDKey() parsers.DIDKey {
+	return k.id
+}
+
+// Zeroize clears the secret key
+func (k *BBSKey) Zeroize() {
+	k.sk.Zeroize()
+}
+
+// bbsBaseProof is the proof value of a base proof
+type bbsBaseProof struct {
+	Signature []byte   `json:"signature"`
+	Mandatory []string `json:"mandatory"`
+}
+
+// bbsDerivedProof is the proof value of a derived proof
+type bbsDerivedProof struct {
+	Proof              []byte   `json:"proof"`
+	Challenge          []byte   `json:"challenge"`
+	Mandatory          []string `json:"mandatory"`
+	Messages           int      `json:"messages"`
+	Revealed           []int    `json:"revealed"`
+	PresentationHeader []byte   `json:"presentationHeader,omitempty"`
+}
+
+// BBSSign returns a copy of doc with a base proof by key. The statements at
+// the mandatory JSON pointers are disclosed in every derived proof.
+//
+// A base proof is for the holder, who derives the proofs that verifiers
+// check with BBSDerive.
+func BBSSign(doc map[string]any, key *BBSKey, mandatory []string, opts Options) (map[string]any, error) {
+	if key == nil {
+		return nil, fmt.Errorf("key is required")
+	}
+	if _, ok := doc["proof"]; ok {
+		return nil, fmt.Errorf("document already has a proof")
+	}
+	stmts, err := statements(doc)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range mandatory {
+		if len(selectStatements(stmts, p)) == 0 {
+			return nil, fmt.Errorf("mandatory pointer %q selects nothing", p)
+		}
+	}
+	proof := opts.proof(BBS2023, key.id)
+	msgs, err := bbsMessages(doc, proof, mandatory, stmts)
+	if err != nil {
+		return nil, err
+	}
+	generators, err := new(bbs.MessageGenerators).Init(key.pk, len(msgs))
+	if err != nil {
+		return nil, err
+	}
+	sig, err := key.sk.Sign(generators, msgs)
+	if err != nil {
+		return nil, err
+	}
+	sigBytes, err := sig.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	proof.ProofValue, err = encodeProofValue(bbsBaseProof{Signature: sigBytes, Mandatory: mandatory})
+	if err != nil {
+		return nil, err
+	}
+	return withProof(doc, proof)
+}
+
+// BBSDerive checks the base proof of doc and returns the document reduced
+// to the mandatory statements and those at the selective JSON pointers, with
+// an unlinkable proof bound to the presentation header
+func BBSDerive(doc map[string]any, selective []string, presentationHeader []byte) (map[string]any, error) {
+	unsecured, proof, err := splitProof(doc)
+	if err != nil {
+		return nil, err
+	}
+	if proof.Cryptosuite != BBS2023 {
+		return nil, fmt.Errorf("proof cryptosuite %q is not %s", proof.Cryptosuite, BBS2023)
+	}
+	var base bbsBaseProof
+	if err := decodeProofValue(proof.ProofValue, &base); err != nil {
+		return nil, err
+	}
+	pk, err := bbsPublicKey(proof.VerificationMethod)
+	if err != nil {
+		return nil, err
+	}
+	sig := new(bbs.Signature).Init(bbsCurve)
+	if err := sig.UnmarshalBinary(base.Signature); err != nil {
+		return nil, fmt.Errorf("malformed base proof: %w", err)
+	}
+	stmts, err := statements(unsecured)
+	if err != nil {
+		return nil, err
+	}
+	msgs, err := bbsMessages(unsecured, proof, base.Mandatory, stmts)
+	if err != nil {
+		return nil, err
+	}
+	generators, err := new(bbs.MessageGenerators).Init(pk, len(msgs))
+	if err != nil {
+		return nil, err
+	}
+	if err := pk.Verify(sig, generators, msgs); err != nil {
+		return nil, fmt.Errorf("invalid base proof: %w", err)
+	}
+
+	selected := make(map[int]bool)
+	for _, p := range append(slices.Clone(base.Mandatory), selective...) {
+		idx := selectStatements(stmts, p)
+		if len(idx) == 0 {
+			return nil, fmt.Errorf("pointer %q selects nothing", p)
+		}
+		for _, i := range idx {
+			selected[i] = true
+		}
+	}
+	reduced := make(map[string]any)
+	if ctx, ok := unsecured["@context"]; ok {
+		reduced["@context"] = ctx
+	}
+	// message 0 binds the proof configuration and is always revealed
+	revealed := []int{0}
+	for i, s := range stmts {
+		if selected[i] {
+			if err := setPointer(reduced, s.pointer, s.value); err != nil {
+				return nil, err
+			}
+			revealed = append(revealed, i+1)
+		}
+	}
+
+	nonce := bbsCurve.Scalar.Hash(presentationHeader)
+	pok, challenge, err := bbs.CreateSelectiveDisclosureProof(sig, generators, msgs, revealed, nonce, crand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	pokBytes, err := pok.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	derived := *proof
+	derived.ProofValue, err = encodeProofValue(bbsDerivedProof{
+		Proof:              pokBytes,
+		Challenge:          challenge.Bytes(),
+		Mandatory:          base.Mandatory,
+		Messages:           len(msgs),
+		Revealed:           revealed[1:],
+		PresentationHeader: presentationHeader,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return withProof(reduced, &derived)
+}
+
+// BBSVerify checks a derived proof of doc against the did:key of its
+// verification method
+func BBSVerify(doc map[string]any, opts VerifyOptions) error {
+	unsecured, proof, err := splitProof(doc)
+	if err != nil {
+		return err
+	}
+	if proof.Cryptosuite != BBS2023 {
+		return fmt.Errorf("proof cryptosuite %q is not %s", proof.Cryptosuite, BBS2023)
+	}
+	if err := opts.check(proof); err != nil {
+		return err
+	}
+	var derived bbsDerivedProof
+	if err := decodeProofValue(proof.ProofValue, &derived); err != nil {
+		return err
+	}
+	if opts.PresentationHeader != nil && !bytes.Equal(opts.PresentationHeader, derived.PresentationHeader) {
+		return fmt.Errorf("presentation header does not match")
+	}
+	pk, err := bbsPublicKey(proof.VerificationMethod)
+	if err != nil {
+		return err
+	}
+	stmts, err := statements(unsecured)
+	if err != nil {
+		return err
+	}
+	if len(stmts) != len(derived.Revealed) {
+		return fmt.Errorf("proof reveals %d statements, the document has %d", len(derived.Revealed), len(stmts))
+	}
+	for _, p := range derived.Mandatory {
+		if len(selectStatements(stmts, p)) == 0 {
+			return fmt.Errorf("mandatory pointer %q is not disclosed", p)
+		}
+	}
+	m0, err := bbsMandatoryMessage(unsecured, proof, derived.Mandatory)
+	if err != nil {
+		return err
+	}
+	revealed := map[int]curves.Scalar{0: m0}
+	last := 0
+	for i, idx := range derived.Revealed {
+		if idx <= last || idx >= derived.Messages {
+			return fmt.Errorf("malformed revealed indexes")
+		}
+		last = idx
+		revealed[idx] = bbsCurve.Scalar.Hash(stmts[i].bytes)
+	}
+
+	generators, err := new(bbs.MessageGenerators).Init(pk, derived.Messages)
+	if err != nil {
+		return err
+	}
+	pok := new(bbs.PokSignatureProof).Init(bbsCurve)
+	if err := pok.UnmarshalBinary(derived.Proof); err != nil {
+		return fmt.Errorf("malformed derived proof: %w", err)
+	}
+	challenge, err := bbsCurve.Scalar.SetBytes(derived.Challenge)
+	if err != nil {
+		return fmt.Errorf("malformed derived proof: %w", err)
+	}
+	nonce := bbsCurve.Scalar.Hash(derived.PresentationHeader)
+	return bbs.VerifySelectiveDisclosureProof(pok, challenge, pk, generators, revealed, nonce)
+}
+
+// bbsMessages are the mandatory message followed by one message per
+// statement
+func bbsMessages(doc map[string]any, proof *Proof, mandatory []string, stmts []statement) ([]curves.Scalar, error) {
+	m0, err := bbsMandatoryMessage(doc, proof, mandatory)
+	if err != nil {
+		return nil, err
+	}
+	raw := make([][]byte, len(stmts))
+	for i, s := range stmts {
+		raw[i] = s.bytes
+	}
+	return append([]curves.Scalar{m0}, bbs.MessagesFromBytes(bbsCurve, raw)...), nil
+}
+
+// bbsMandatoryMessage binds the proof configuration, with the context of
+// the document, and the mandatory pointers
+func bbsMandatoryMessage(doc map[string]any, proof *Proof, mandatory []string) (curves.Scalar, error) {
+	config, err := proofConfig(doc, proof)
+	if err != nil {
+		return nil, err
+	}
+	pointers := make([]any, len(mandatory))
+	for i, p := range mandatory {
+		pointers[i] = p
+	}
+	config["mandatoryPointers"] = pointers
+	data, err := JCS.Canonicalize(config)
+	if err != nil {
+		return nil, err
+	}
+	return bbsCurve.Scalar.Hash(data), nil
+}
+
+func bbsPublicKey(vm string) (*bbs.PublicKey, error) {
+	id, err := resolveVerificationMethod(vm)
+	if err != nil {
+		return nil, err
+	}
+	if id.Type() != parsers.KeyTypeBLS12381G2 {
+		return nil, fmt.Errorf("%s requires a BLS12-381 G2 key", BBS2023)
+	}
+	raw, err := id.Raw()
+	if err != nil {
+		return nil, err
+	}
+	pk := new(bbs.PublicKey).Init(bbsCurve)
+	if err := pk.UnmarshalBinary(raw); err != nil {
+		return nil, err
+	}
+	return pk, nil
+}
+
+func encodeProofValue(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return mb.Encode(mb.Base64url, data)
+}
+
+func decodeProofValue(s string, v any) error {
+	enc, data, err := mb.Decode(s)
+	if err != nil {
+		return fmt.Errorf("malformed proof value: %w", err)
+	}
+	if enc != mb.Base64url {
+		return fmt.Errorf("proof value is not base64url")
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("malformed proof value: %w", err)
+	}
+	return nil
+}
+
+// statement is a leaf of a document, arrays and empty objects are leaves
+type statement struct {
+	pointer string
+	value   any
+	// bytes is the JSON pointer and the JCS of the value
+	bytes []byte
+}
+
+// statements returns the leaves of doc in byte order, without the context,
+// which is bound by the mandatory message
+func statements(doc map[string]any) ([]statement, error) {
+	var out []statement
+	var walk func(prefix string, v any) error
+	walk = func(prefix string, v any) error {
+		if obj, ok := v.(map[string]any); ok && len(obj) > 0 {
+			for k, child := range obj {
+				if err := walk(prefix+"/"+escapePointer(k), child); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		var buf bytes.Buffer
+		buf.WriteString(prefix)
+		buf.WriteByte(' ')
+		if err := writeJCS(&buf, v); err != nil {
+			return err
+		}
+		out = append(out, statement{pointer: prefix, value: v, bytes: buf.Bytes()})
+		return nil
+	}
+	for k, v := range doc {
+		if k == "@context" || k == "proof" {
+			continue
+		}
+		if err := walk("/"+escapePointer(k), v); err != nil {
+			return nil, err
+		}
+	}
+	slices.SortFunc(out, func(a, b statement) int {
+		return bytes.Compare(a.bytes, b.bytes)
+	})
+	return out, nil
+}
+
+// selectStatements returns the indexes of the statements at or below the
+// JSON pointer p
+func selectStatements(stmts []statement, p string) []int {
+	var idx []int
+	for i, s := range stmts {
+		if p == "" || s.pointer == p || strings.HasPrefix(s.pointer, p+"/") {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
+
+// setPointer sets the value at the JSON pointer p, creating the objects on
+// the way
+func setPointer(doc map[string]any, p string, v any) error {
+	tokens := strings.Split(p, "/")[1:]
+	obj := doc
+	for i, tok := range tokens {
+		key := unescapePointer(tok)
+		if i == len(tokens)-1 {
+			obj[key] = v
+			return nil
+		}
+		next, ok := obj[key].(map[string]any)
+		if !ok {
+			if _, exists := obj[key]; exists {
+				return fmt.Errorf("pointer %s crosses a leaf", strconv.Quote(p))
+			}
+			next = make(map[string]any)
+			obj[key] = next
+		}
+		obj = next
+	}
+	return fmt.Errorf("invalid pointer %s", strconv.Quote(p))
+}
+
+func escapePointer(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
+
+func unescapePointer(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~1", "/"), "~0", "~")
+}
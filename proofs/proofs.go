@@ -0,0 +1,213 @@
+// Package proofs signs and verifies W3C Verifiable Credential Data
+// Integrity proofs https://www.w3.org/TR/vc-data-integrity/ with the keys of
+// this module.
+//
+// The eddsa-2022 and ecdsa-2019 cryptosuites are provided in their rdfc and
+// jcs variants. The jcs variants canonicalize with the built-in JCS of RFC
+// 8785. The rdfc variants take the RDF Dataset Canonicalization of the
+// caller as a Canonicalizer hook, as this module has no JSON-LD processor;
+// the hook returns the canonical N-Quads of a document.
+//
+// The BBS suite provides selective disclosure with the BBS+ signatures of
+// signatures/bbs over one statement per JSON pointer of the credential. It
+// follows the base and derived proof flow of bbs-2023, but neither its
+// statements nor the BBS+ ciphersuite are those of the W3C specification,
+// so it is identified as sonr-bbs-2023 and only interoperates with itself.
+//
+// Verification methods are did:key verification method ids, which are
+// resolved through keys/parsers.
+package proofs
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-sonr/crypto/keys/parsers"
+)
+
+const (
+	// ProofType is the type of every Data Integrity proof
+	ProofType = "DataIntegrityProof"
+	// PurposeAssertion is the proof purpose of credentials
+	PurposeAssertion = "assertionMethod"
+	// PurposeAuthentication is the proof purpose of presentations
+	PurposeAuthentication = "authentication"
+)
+
+// Canonicalizer turns a JSON document into the bytes that are hashed
+type Canonicalizer interface {
+	Canonicalize(doc map[string]any) ([]byte, error)
+}
+
+// CanonicalizerFunc adapts a function to a Canonicalizer
+type CanonicalizerFunc func(doc map[string]any) ([]byte, error)
+
+// Canonicalize calls f
+func (f CanonicalizerFunc) Canonicalize(doc map[string]any) ([]byte, error) {
+	return f(doc)
+}
+
+// Proof is a Data Integrity proof
+type Proof struct {
+	Type               string `json:"type"`
+	Cryptosuite        string `json:"cryptosuite"`
+	Created            string `json:"created,omitempty"`
+	VerificationMethod string `json:"verificationMethod"`
+	ProofPurpose       string `json:"proofPurpose"`
+	Challenge          string `json:"challenge,omitempty"`
+	Domain             string `json:"domain,omitempty"`
+	ProofValue         string `json:"proofValue,omitempty"`
+}
+
+// Options are the proof options of a new proof
+type Options struct {
+	// VerificationMethod defaults to the did:key verification method of
+	// the signing key
+	VerificationMethod string
+	// ProofPurpose defaults to PurposeAssertion
+	ProofPurpose string
+	// Created defaults to the current time
+	Created   time.Time
+	Challenge string
+	Domain    string
+}
+
+// VerifyOptions are the checks on a proof beyond its signature
+type VerifyOptions struct {
+	// ProofPurpose defaults to PurposeAssertion
+	ProofPurpose string
+	// Challenge and Domain, when set, must match the proof
+	Challenge string
+	Domain    string
+	// PresentationHeader, when set, must match a BBS derived proof
+	PresentationHeader []byte
+}
+
+func (o Options) proof(cryptosuite string, id parsers.DIDKey) *Proof {
+	p := &Proof{
+		Type:               ProofType,
+		Cryptosuite:        cryptosuite,
+		VerificationMethod: o.VerificationMethod,
+		ProofPurpose:       o.ProofPurpose,
+		Challenge:          o.Challenge,
+		Domain:             o.Domain,
+	}
+	if p.VerificationMethod == "" {
+		did := id.String()
+		p.VerificationMethod = did + "#" + strings.TrimPrefix(did, parsers.KeyPrefix+":")
+	}
+	if p.ProofPurpose == "" {
+		p.ProofPurpose = PurposeAssertion
+	}
+	created := o.Created
+	if created.IsZero() {
+		created = time.Now()
+	}
+	p.Created = created.UTC().Format(time.RFC3339)
+	return p
+}
+
+func (o VerifyOptions) check(p *Proof) error {
+	purpose := o.ProofPurpose
+	if purpose == "" {
+		purpose = PurposeAssertion
+	}
+	if p.ProofPurpose != purpose {
+		return fmt.Errorf("proof purpose %q is not %q", p.ProofPurpose, purpose)
+	}
+	if o.Challenge != "" && p.Challenge != o.Challenge {
+		return fmt.Errorf("proof challenge does not match")
+	}
+	if o.Domain != "" && p.Domain != o.Domain {
+		return fmt.Errorf("proof domain does not match")
+	}
+	if p.Created != "" {
+		if _, err := time.Parse(time.RFC3339, p.Created); err != nil {
+			return fmt.Errorf("invalid proof created time: %w", err)
+		}
+	}
+	return nil
+}
+
+// splitProof returns a copy of the document without its proof, and the
+// proof
+func splitProof(doc map[string]any) (map[string]any, *Proof, error) {
+	raw, ok := doc["proof"]
+	if !ok {
+		return nil, nil, fmt.Errorf("document has no proof")
+	}
+	if _, ok := raw.(map[string]any); !ok {
+		return nil, nil, fmt.Errorf("only documents with a single proof are supported")
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	proof := new(Proof)
+	if err := json.Unmarshal(data, proof); err != nil {
+		return nil, nil, fmt.Errorf("malformed proof: %w", err)
+	}
+	if proof.Type != ProofType {
+		return nil, nil, fmt.Errorf("unsupported proof type %q", proof.Type)
+	}
+	return withoutProof(doc), proof, nil
+}
+
+func withoutProof(doc map[string]any) map[string]any {
+	out := make(map[string]any, len(doc))
+	for k, v := range doc {
+		if k != "proof" {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// withProof returns a copy of the document with proof attached
+func withProof(doc map[string]any, proof *Proof) (map[string]any, error) {
+	data, err := json.Marshal(proof)
+	if err != nil {
+		return nil, err
+	}
+	var p map[string]any
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, err
+	}
+	out := withoutProof(doc)
+	out["proof"] = p
+	return out, nil
+}
+
+// proofConfig is the proof without its value, with the context of the
+// document, as in section 3.3.5 of the eddsa and ecdsa cryptosuites
+func proofConfig(doc map[string]any, proof *Proof) (map[string]any, error) {
+	p := *proof
+	p.ProofValue = ""
+	data, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	var config map[string]any
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+	if ctx, ok := doc["@context"]; ok {
+		config["@context"] = ctx
+	}
+	return config, nil
+}
+
+// resolveVerificationMethod resolves a did:key verification method id
+func resolveVerificationMethod(vm string) (parsers.DIDKey, error) {
+	did, fragment, _ := strings.Cut(vm, "#")
+	id, err := parsers.Parse(did)
+	if err != nil {
+		return parsers.DIDKey{}, fmt.Errorf("resolving verification method %q: %w", vm, err)
+	}
+	if fragment != "" && fragment != strings.TrimPrefix(did, parsers.KeyPrefix+":") {
+		return parsers.DIDKey{}, fmt.Errorf("verification method %q is not the key of its did:key", vm)
+	}
+	return id, nil
+}
@@ -0,0 +1,188 @@
+package proofs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	crand "crypto/rand"
+	"encoding/json"
+	"testing"
+	"time"
+
+	p2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func testCredential(t *testing.T) map[string]any {
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"@context": ["https://www.w3.org/ns/credentials/v2"],
+		"type": ["VerifiableCredential"],
+		"issuer": "did:example:issuer",
+		"validFrom": "2024-01-01T00:00:00Z",
+		"credentialSubject": {
+			"id": "did:example:subject",
+			"name": "Alice",
+			"birthDate": "1990-01-01",
+			"address": {"country": "CH", "city": "Zurich"},
+			"score": 1.5e3
+		}
+	}`), &doc))
+	return doc
+}
+
+func TestJCS(t *testing.T) {
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"numbers": [333333333.33333329, 1E30, 4.50, 2e-3, 0.000000000000000000000000001, 1e21, 1e-7, 0.000001, -0, 100],
+		"string": "\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/",
+		"literals": [null, true, false],
+		"\u20ac": 1, "\r": 2, "\ud83d\ude00": 3, "\u0080": 4, "1": 5, "\ufb33": 6
+	}`), &doc))
+	out, err := JCS.Canonicalize(doc)
+	require.NoError(t, err)
+	require.Equal(t, "{\"\\r\":2,\"1\":5,\"literals\":[null,true,false],"+
+		"\"numbers\":[333333333.3333333,1e+30,4.5,0.002,1e-27,1e+21,1e-7,0.000001,0,100],"+
+		"\"string\":\"€$\\u000f\\nA'B\\\"\\\\\\\\\\\"/\",\"\u0080\":4,\"€\":1,\"😀\":3,\"\ufb33\":6}", string(out))
+}
+
+func TestEdDSAJCS2022(t *testing.T) {
+	priv, _, err := p2pcrypto.GenerateEd25519Key(crand.Reader)
+	require.NoError(t, err)
+	suite := EdDSAJCS2022()
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	signed, err := suite.Sign(testCredential(t), priv, Options{Created: created})
+	require.NoError(t, err)
+	require.NoError(t, suite.Verify(signed, VerifyOptions{}))
+
+	proof := signed["proof"].(map[string]any)
+	require.Equal(t, ProofType, proof["type"])
+	require.Equal(t, "eddsa-jcs-2022", proof["cryptosuite"])
+	require.Equal(t, "2024-01-01T00:00:00Z", proof["created"])
+	require.Equal(t, PurposeAssertion, proof["proofPurpose"])
+	require.Equal(t, byte('z'), proof["proofValue"].(string)[0])
+
+	// a signed document survives a JSON round trip
+	data, err := json.Marshal(signed)
+	require.NoError(t, err)
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.NoError(t, suite.Verify(decoded, VerifyOptions{}))
+
+	// tampering with the document or the proof options fails
+	decoded["credentialSubject"].(map[string]any)["name"] = "Mallory"
+	require.Error(t, suite.Verify(decoded, VerifyOptions{}))
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	decoded["proof"].(map[string]any)["created"] = "2025-01-01T00:00:00Z"
+	require.Error(t, suite.Verify(decoded, VerifyOptions{}))
+
+	require.Error(t, suite.Verify(signed, VerifyOptions{ProofPurpose: PurposeAuthentication}))
+	require.Error(t, ECDSAJCS2019().Verify(signed, VerifyOptions{}))
+	_, err = suite.Sign(signed, priv, Options{})
+	require.Error(t, err)
+}
+
+func TestChallengeAndDomain(t *testing.T) {
+	priv, _, err := p2pcrypto.GenerateEd25519Key(crand.Reader)
+	require.NoError(t, err)
+	suite := EdDSAJCS2022()
+	signed, err := suite.Sign(testCredential(t), priv, Options{
+		ProofPurpose: PurposeAuthentication,
+		Challenge:    "abc",
+		Domain:       "example.com",
+	})
+	require.NoError(t, err)
+	opts := VerifyOptions{ProofPurpose: PurposeAuthentication, Challenge: "abc", Domain: "example.com"}
+	require.NoError(t, suite.Verify(signed, opts))
+	opts.Challenge = "abd"
+	require.Error(t, suite.Verify(signed, opts))
+}
+
+func TestECDSAJCS2019(t *testing.T) {
+	for _, curve := range []elliptic.Curve{elliptic.P256(), elliptic.P384()} {
+		key, err := ecdsa.GenerateKey(curve, crand.Reader)
+		require.NoError(t, err)
+		priv, _, err := p2pcrypto.ECDSAKeyPairFromKey(key)
+		require.NoError(t, err)
+		suite := ECDSAJCS2019()
+		signed, err := suite.Sign(testCredential(t), priv, Options{})
+		require.NoError(t, err)
+		require.NoError(t, suite.Verify(signed, VerifyOptions{}))
+
+		signed["issuer"] = "did:example:other"
+		require.Error(t, suite.Verify(signed, VerifyOptions{}))
+	}
+
+	priv, _, err := p2pcrypto.GenerateEd25519Key(crand.Reader)
+	require.NoError(t, err)
+	_, err = ECDSAJCS2019().Sign(testCredential(t), priv, Options{})
+	require.Error(t, err)
+}
+
+func TestRDFCCanonicalizerHook(t *testing.T) {
+	priv, _, err := p2pcrypto.GenerateEd25519Key(crand.Reader)
+	require.NoError(t, err)
+	var calls int
+	canon := CanonicalizerFunc(func(doc map[string]any) ([]byte, error) {
+		calls++
+		return JCS.Canonicalize(doc)
+	})
+	suite := EdDSARDFC2022(canon)
+	require.Equal(t, "eddsa-rdfc-2022", suite.Name())
+	signed, err := suite.Sign(testCredential(t), priv, Options{})
+	require.NoError(t, err)
+	require.Equal(t, 2, calls)
+	require.NoError(t, suite.Verify(signed, VerifyOptions{}))
+	require.Equal(t, 4, calls)
+
+	// the jcs suite does not accept rdfc proofs
+	require.Error(t, EdDSAJCS2022().Verify(signed, VerifyOptions{}))
+	_, err = EdDSARDFC2022(nil).Sign(testCredential(t), priv, Options{})
+	require.Error(t, err)
+}
+
+func TestBBS2023(t *testing.T) {
+	key, err := GenerateBBSKey()
+	require.NoError(t, err)
+	base, err := BBSSign(testCredential(t), key, []string{"/issuer", "/type"}, Options{})
+	require.NoError(t, err)
+
+	ph := []byte("verifier nonce")
+	derived, err := BBSDerive(base, []string{"/credentialSubject/address"}, ph)
+	require.NoError(t, err)
+	require.NoError(t, BBSVerify(derived, VerifyOptions{PresentationHeader: ph}))
+
+	subject := derived["credentialSubject"].(map[string]any)
+	require.NotContains(t, subject, "name")
+	require.NotContains(t, subject, "birthDate")
+	require.Equal(t, "CH", subject["address"].(map[string]any)["country"])
+	require.Equal(t, "did:example:issuer", derived["issuer"])
+	require.NotContains(t, derived, "validFrom")
+
+	// derived documents survive a JSON round trip
+	data, err := json.Marshal(derived)
+	require.NoError(t, err)
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.NoError(t, BBSVerify(decoded, VerifyOptions{}))
+
+	// tampering or a different presentation header fails
+	require.Error(t, BBSVerify(derived, VerifyOptions{PresentationHeader: []byte("other")}))
+	decoded["credentialSubject"].(map[string]any)["address"].(map[string]any)["city"] = "Geneva"
+	require.Error(t, BBSVerify(decoded, VerifyOptions{}))
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	delete(decoded, "issuer")
+	require.Error(t, BBSVerify(decoded, VerifyOptions{}))
+
+	// two derivations of the same credential are unlinkable
+	again, err := BBSDerive(base, []string{"/credentialSubject/address"}, ph)
+	require.NoError(t, err)
+	require.NotEqual(t, derived["proof"].(map[string]any)["proofValue"], again["proof"].(map[string]any)["proofValue"])
+
+	// a tampered base proof cannot be derived from
+	base["validFrom"] = "2000-01-01T00:00:00Z"
+	_, err = BBSDerive(base, nil, ph)
+	require.Error(t, err)
+
+	_, err = BBSSign(testCredential(t), key, []string{"/missing"}, Options{})
+	require.Error(t, err)
+}
@@ -0,0 +1,161 @@
+package proofs
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"hash"
+
+	p2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
+	mb "github.com/multiformats/go-multibase"
+
+	"github.com/go-sonr/crypto/jose"
+	"github.com/go-sonr/crypto/keys/parsers"
+)
+
+// Suite is an eddsa-2022 or ecdsa-2019 cryptosuite
+type Suite struct {
+	name  string
+	canon Canonicalizer
+	algs  []jose.Algorithm
+}
+
+// EdDSARDFC2022 is the eddsa-rdfc-2022 cryptosuite with Ed25519 keys, canon
+// is the RDF Dataset Canonicalization of the caller
+func EdDSARDFC2022(canon Canonicalizer) *Suite {
+	return &Suite{name: "eddsa-rdfc-2022", canon: canon, algs: []jose.Algorithm{jose.EdDSA}}
+}
+
+// EdDSAJCS2022 is the eddsa-jcs-2022 cryptosuite with Ed25519 keys
+func EdDSAJCS2022() *Suite {
+	return &Suite{name: "eddsa-jcs-2022", canon: JCS, algs: []jose.Algorithm{jose.EdDSA}}
+}
+
+// ECDSARDFC2019 is the ecdsa-rdfc-2019 cryptosuite with P-256 and P-384
+// keys, canon is the RDF Dataset Canonicalization of the caller
+func ECDSARDFC2019(canon Canonicalizer) *Suite {
+	return &Suite{name: "ecdsa-rdfc-2019", canon: canon, algs: []jose.Algorithm{jose.ES256, jose.ES384}}
+}
+
+// ECDSAJCS2019 is the ecdsa-jcs-2019 cryptosuite with P-256 and P-384 keys
+func ECDSAJCS2019() *Suite {
+	return &Suite{name: "ecdsa-jcs-2019", canon: JCS, algs: []jose.Algorithm{jose.ES256, jose.ES384}}
+}
+
+// Name is the cryptosuite identifier of the proofs
+func (s *Suite) Name() string {
+	return s.name
+}
+
+func (s *Suite) supports(alg jose.Algorithm) error {
+	for _, a := range s.algs {
+		if a == alg {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s does not support %s keys", s.name, alg)
+}
+
+// Sign returns a copy of doc with a proof by priv
+func (s *Suite) Sign(doc map[string]any, priv p2pcrypto.PrivKey, opts Options) (map[string]any, error) {
+	if s.canon == nil {
+		return nil, fmt.Errorf("%s requires a canonicalizer", s.name)
+	}
+	if _, ok := doc["proof"]; ok {
+		return nil, fmt.Errorf("document already has a proof")
+	}
+	signer, err := jose.NewSigner(priv)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.supports(signer.Algorithm()); err != nil {
+		return nil, err
+	}
+	id, err := parsers.NewKeyDID(priv.GetPublic())
+	if err != nil {
+		return nil, err
+	}
+	proof := opts.proof(s.name, id)
+	data, err := s.hashData(doc, proof, signer.Algorithm())
+	if err != nil {
+		return nil, err
+	}
+	sig, err := signer.Sign(data)
+	if err != nil {
+		return nil, err
+	}
+	proof.ProofValue, err = mb.Encode(mb.Base58BTC, sig)
+	if err != nil {
+		return nil, err
+	}
+	return withProof(doc, proof)
+}
+
+// Verify checks the proof of doc against the did:key of its verification
+// method
+func (s *Suite) Verify(doc map[string]any, opts VerifyOptions) error {
+	if s.canon == nil {
+		return fmt.Errorf("%s requires a canonicalizer", s.name)
+	}
+	unsecured, proof, err := splitProof(doc)
+	if err != nil {
+		return err
+	}
+	if proof.Cryptosuite != s.name {
+		return fmt.Errorf("proof cryptosuite %q is not %s", proof.Cryptosuite, s.name)
+	}
+	if err := opts.check(proof); err != nil {
+		return err
+	}
+	id, err := resolveVerificationMethod(proof.VerificationMethod)
+	if err != nil {
+		return err
+	}
+	verifier, err := jose.NewVerifier(id)
+	if err != nil {
+		return err
+	}
+	if err := s.supports(verifier.Algorithm()); err != nil {
+		return err
+	}
+	enc, sig, err := mb.Decode(proof.ProofValue)
+	if err != nil {
+		return fmt.Errorf("malformed proof value: %w", err)
+	}
+	if enc != mb.Base58BTC {
+		return fmt.Errorf("proof value is not base58btc")
+	}
+	data, err := s.hashData(unsecured, proof, verifier.Algorithm())
+	if err != nil {
+		return err
+	}
+	return verifier.Verify(data, sig)
+}
+
+// hashData is the hash of the canonical proof configuration followed by
+// the hash of the canonical document
+func (s *Suite) hashData(doc map[string]any, proof *Proof, alg jose.Algorithm) ([]byte, error) {
+	config, err := proofConfig(doc, proof)
+	if err != nil {
+		return nil, err
+	}
+	canonConfig, err := s.canon.Canonicalize(config)
+	if err != nil {
+		return nil, fmt.Errorf("canonicalizing proof configuration: %w", err)
+	}
+	canonDoc, err := s.canon.Canonicalize(doc)
+	if err != nil {
+		return nil, fmt.Errorf("canonicalizing document: %w", err)
+	}
+	newHash := sha256.New
+	if alg == jose.ES384 {
+		newHash = sha512.New384
+	}
+	return append(digest(newHash, canonConfig), digest(newHash, canonDoc)...), nil
+}
+
+func digest(newHash func() hash.Hash, data []byte) []byte {
+	h := newHash()
+	h.Write(data)
+	return h.Sum(nil)
+}
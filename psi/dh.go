@@ -0,0 +1,134 @@
+package psi
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/internal"
+)
+
+const (
+	dhHashDomain = "go-sonr psi dh hash v1"
+	dhTagDomain  = "go-sonr psi dh tag v1"
+)
+
+// DHClient is the client of DH-PSI
+type DHClient struct {
+	matcher
+	curve   *curves.Curve
+	a       curves.Scalar
+	aInv    curves.Scalar
+	pending [][][]byte
+}
+
+// DHServer is the server of DH-PSI
+type DHServer struct {
+	curve *curves.Curve
+	b     curves.Scalar
+}
+
+// NewDHClient returns a client with a fresh key on curve, which should be
+// of prime order such as ristretto255 or P-256
+func NewDHClient(curve *curves.Curve) (*DHClient, error) {
+	if curve == nil {
+		return nil, internal.ErrNilArguments
+	}
+	a := curve.Scalar.Random(rand.Reader)
+	aInv, err := a.Invert()
+	if err != nil {
+		return nil, err
+	}
+	return &DHClient{matcher: newMatcher(), curve: curve, a: a, aInv: aInv}, nil
+}
+
+// NewDHServer returns a server with a fresh key on curve
+func NewDHServer(curve *curves.Curve) (*DHServer, error) {
+	if curve == nil {
+		return nil, internal.ErrNilArguments
+	}
+	return &DHServer{curve: curve, b: curve.Scalar.Random(rand.Reader)}, nil
+}
+
+func hashItem(curve *curves.Curve, item []byte) curves.Point {
+	return curve.Point.Hash(append([]byte(dhHashDomain), item...))
+}
+
+// Blind returns the blinded items a·H(x) of a batch, in order, to send to
+// the server
+func (c *DHClient) Blind(items [][]byte) ([]curves.Point, error) {
+	if len(items) == 0 {
+		return nil, internal.ErrNilArguments
+	}
+	blinded := make([]curves.Point, len(items))
+	batch := make([][]byte, len(items))
+	for i, item := range items {
+		batch[i] = append([]byte(nil), item...)
+		blinded[i] = hashItem(c.curve, item).Mul(c.a)
+	}
+	c.pending = append(c.pending, batch)
+	return blinded, nil
+}
+
+// Unblind takes the evaluation of the oldest blinded batch not unblinded
+// yet and records the tags of its items
+func (c *DHClient) Unblind(evaluated []curves.Point) error {
+	if len(c.pending) == 0 {
+		return fmt.Errorf("no blinded batch is pending")
+	}
+	batch := c.pending[0]
+	if len(evaluated) != len(batch) {
+		return internal.ErrIncorrectCount
+	}
+	for _, p := range evaluated {
+		if p == nil || p.CurveName() != c.curve.Name || p.IsIdentity() || !p.IsOnCurve() {
+			return fmt.Errorf("invalid evaluated element")
+		}
+	}
+	for i, p := range evaluated {
+		c.add(tag(dhTagDomain, p.Mul(c.aInv).ToAffineCompressed()), batch[i])
+	}
+	c.pending = c.pending[1:]
+	return nil
+}
+
+// Match takes a batch of tags of the server and returns the items of the
+// client they match, each item once across batches. Items are matched
+// only once their batch is unblinded.
+func (c *DHClient) Match(tags [][]byte) [][]byte {
+	return c.match(tags)
+}
+
+// Intersection returns every item matched so far
+func (c *DHClient) Intersection() [][]byte {
+	return append([][]byte(nil), c.found...)
+}
+
+// Evaluate raises the blinded items of the client to the key of the
+// server, in order
+func (s *DHServer) Evaluate(blinded []curves.Point) ([]curves.Point, error) {
+	if len(blinded) == 0 {
+		return nil, internal.ErrNilArguments
+	}
+	out := make([]curves.Point, len(blinded))
+	for i, p := range blinded {
+		if p == nil || p.CurveName() != s.curve.Name || p.IsIdentity() || !p.IsOnCurve() {
+			return nil, fmt.Errorf("invalid blinded element")
+		}
+		out[i] = p.Mul(s.b)
+	}
+	return out, nil
+}
+
+// Tags returns the tags of a batch of items of the server, shuffled, to
+// send to the client
+func (s *DHServer) Tags(items [][]byte) ([][]byte, error) {
+	tags := make([][]byte, len(items))
+	for i, item := range items {
+		tags[i] = tag(dhTagDomain, hashItem(s.curve, item).Mul(s.b).ToAffineCompressed())
+	}
+	if err := shuffle(tags); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
@@ -0,0 +1,128 @@
+package psi
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/oprf"
+)
+
+const oprfTagDomain = "go-sonr psi oprf tag v1"
+
+// OPRFClient is the client of OPRF-PSI
+type OPRFClient struct {
+	matcher
+	client  *oprf.Client
+	pending [][]*oprf.Blinded
+}
+
+// OPRFServer is the server of OPRF-PSI
+type OPRFServer struct {
+	server *oprf.Server
+}
+
+// NewOPRFClient returns a client of a server with public key pk. mode is
+// oprf.ModeOPRF, which ignores pk, or oprf.ModeVOPRF.
+func NewOPRFClient(suite *oprf.Suite, mode oprf.Mode, pk curves.Point) (*OPRFClient, error) {
+	if mode == oprf.ModePOPRF {
+		return nil, fmt.Errorf("psi does not use POPRF mode")
+	}
+	client, err := oprf.NewClient(suite, mode, pk)
+	if err != nil {
+		return nil, err
+	}
+	return &OPRFClient{matcher: newMatcher(), client: client}, nil
+}
+
+// NewOPRFServer returns a server with the secret key sk. mode is
+// oprf.ModeOPRF or oprf.ModeVOPRF, as for its clients.
+func NewOPRFServer(suite *oprf.Suite, mode oprf.Mode, sk curves.Scalar) (*OPRFServer, error) {
+	if mode == oprf.ModePOPRF {
+		return nil, fmt.Errorf("psi does not use POPRF mode")
+	}
+	server, err := oprf.NewServer(suite, mode, sk)
+	if err != nil {
+		return nil, err
+	}
+	return &OPRFServer{server: server}, nil
+}
+
+// PublicKey returns the public key of the server for verifiable mode
+func (s *OPRFServer) PublicKey() curves.Point {
+	return s.server.PublicKey()
+}
+
+// Blind returns the blinded elements of a batch of items, in order, to
+// send to the server
+func (c *OPRFClient) Blind(items [][]byte) ([]curves.Point, error) {
+	if len(items) == 0 {
+		return nil, internal.ErrNilArguments
+	}
+	batch := make([]*oprf.Blinded, len(items))
+	elements := make([]curves.Point, len(items))
+	for i, item := range items {
+		b, err := c.client.Blind(item, nil, rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		batch[i], elements[i] = b, b.Element
+	}
+	c.pending = append(c.pending, batch)
+	return elements, nil
+}
+
+// Finalize takes the evaluation of the oldest blinded batch not finalized
+// yet, verifying its proof in verifiable mode, and records the tags of its
+// items
+func (c *OPRFClient) Finalize(eval *oprf.Evaluation) error {
+	if len(c.pending) == 0 {
+		return fmt.Errorf("no blinded batch is pending")
+	}
+	batch := c.pending[0]
+	outputs, err := c.client.Finalize(batch, eval)
+	if err != nil {
+		return err
+	}
+	for i, out := range outputs {
+		c.add(tag(oprfTagDomain, out), batch[i].Input)
+	}
+	c.pending = c.pending[1:]
+	return nil
+}
+
+// Match takes a batch of tags of the server and returns the items of the
+// client they match, each item once across batches. Items are matched
+// only once their batch is finalized.
+func (c *OPRFClient) Match(tags [][]byte) [][]byte {
+	return c.match(tags)
+}
+
+// Intersection returns every item matched so far
+func (c *OPRFClient) Intersection() [][]byte {
+	return append([][]byte(nil), c.found...)
+}
+
+// Evaluate evaluates the blinded elements of a batch of the client
+func (s *OPRFServer) Evaluate(blinded []curves.Point) (*oprf.Evaluation, error) {
+	return s.server.BlindEvaluate(blinded, nil, rand.Reader)
+}
+
+// Tags returns the tags of a batch of items of the server, shuffled, to
+// send to the client. They only depend on the key, so a server with a
+// long lived key can compute them once for all its clients.
+func (s *OPRFServer) Tags(items [][]byte) ([][]byte, error) {
+	tags := make([][]byte, len(items))
+	for i, item := range items {
+		out, err := s.server.Evaluate(item, nil)
+		if err != nil {
+			return nil, err
+		}
+		tags[i] = tag(oprfTagDomain, out)
+	}
+	if err := shuffle(tags); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
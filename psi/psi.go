@@ -0,0 +1,89 @@
+// Package psi implements two-party private set intersection, in which a
+// client learns which of its items a server also holds, and nothing else
+// about the set of the server, while the server learns nothing but the
+// number of items of the client.
+//
+// Both variants map every item to a tag under a secret key of the server,
+// tags the client obtains for its own items obliviously, and then compares
+// with the tags the server sends for its items:
+//   - DH-PSI, after Huberman, Franklin and Hogg, blinds the hash H(x) of an
+//     item as a·H(x) with a key of the client, the server raises it to its
+//     key b, and the client strips a to get the tag of b·H(x). Both keys
+//     are fresh for every run.
+//   - OPRF-PSI evaluates an oblivious PRF of RFC 9497 with the oprf
+//     package. The key of the server can be long lived, so it can compute
+//     the tags of a large set once and serve many clients, as in contact
+//     discovery, and in verifiable mode the client checks every evaluation
+//     used the same key.
+//
+// The protocols are secure against a semi-honest server and client. Every
+// step works on batches, so sets larger than memory allows to send at once
+// are streamed: the client blinds and finalizes its items batch by batch,
+// and matches the tags of the server as they arrive.
+package psi
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"math/big"
+)
+
+// TagSize is the length of the tags the server sends for its items
+const TagSize = 16
+
+// matcher holds the tags of the items of the client and the items matched
+type matcher struct {
+	items   map[string][]byte
+	matched map[string]bool
+	found   [][]byte
+}
+
+func newMatcher() matcher {
+	return matcher{items: map[string][]byte{}, matched: map[string]bool{}}
+}
+
+func (m *matcher) add(tag, item []byte) {
+	m.items[string(tag)] = item
+}
+
+// match returns the items of the client whose tag is in tags and were not
+// matched before
+func (m *matcher) match(tags [][]byte) [][]byte {
+	var found [][]byte
+	for _, tag := range tags {
+		item, ok := m.items[string(tag)]
+		if !ok || m.matched[string(tag)] {
+			continue
+		}
+		m.matched[string(tag)] = true
+		found = append(found, item)
+	}
+	m.found = append(m.found, found...)
+	return found
+}
+
+// tag truncates the hash of the element of an item under the key
+func tag(domain string, element []byte) []byte {
+	h := sha256.New()
+	_, _ = h.Write([]byte(domain))
+	var l [8]byte
+	binary.BigEndian.PutUint64(l[:], uint64(len(element)))
+	_, _ = h.Write(l[:])
+	_, _ = h.Write(element)
+	return h.Sum(nil)[:TagSize]
+}
+
+// shuffle permutes tags at random, so that their order tells the client
+// nothing about the order of the items of the server
+func shuffle(tags [][]byte) error {
+	for i := len(tags) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return err
+		}
+		k := j.Int64()
+		tags[i], tags[k] = tags[k], tags[i]
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package psi
+
+import (
+	crand "crypto/rand"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/oprf"
+)
+
+func items(prefix string, from, to int) [][]byte {
+	var out [][]byte
+	for i := from; i < to; i++ {
+		out = append(out, []byte(fmt.Sprintf("%s%d", prefix, i)))
+	}
+	return out
+}
+
+func sorted(items [][]byte) []string {
+	out := make([]string, len(items))
+	for i, item := range items {
+		out[i] = string(item)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestDHPSI(t *testing.T) {
+	for _, curve := range []*curves.Curve{curves.P256(), curves.K256()} {
+		t.Run(curve.Name, func(t *testing.T) {
+			client, err := NewDHClient(curve)
+			require.NoError(t, err)
+			server, err := NewDHServer(curve)
+			require.NoError(t, err)
+
+			// contacts 0 to 19 of the client, 10 to 39 of the server
+			blinded, err := client.Blind(items("contact", 0, 20))
+			require.NoError(t, err)
+			evaluated, err := server.Evaluate(blinded)
+			require.NoError(t, err)
+			require.NoError(t, client.Unblind(evaluated))
+
+			tags, err := server.Tags(items("contact", 10, 40))
+			require.NoError(t, err)
+			require.Len(t, tags[0], TagSize)
+			require.Equal(t, sorted(items("contact", 10, 20)), sorted(client.Match(tags)))
+			require.Empty(t, client.Match(tags))
+			require.Len(t, client.Intersection(), 10)
+		})
+	}
+}
+
+func TestDHPSIStreaming(t *testing.T) {
+	curve := curves.P256()
+	client, err := NewDHClient(curve)
+	require.NoError(t, err)
+	server, err := NewDHServer(curve)
+	require.NoError(t, err)
+
+	// two batches in flight at once, evaluated in order
+	b1, err := client.Blind(items("x", 0, 5))
+	require.NoError(t, err)
+	b2, err := client.Blind(items("x", 5, 10))
+	require.NoError(t, err)
+	e1, err := server.Evaluate(b1)
+	require.NoError(t, err)
+	e2, err := server.Evaluate(b2)
+	require.NoError(t, err)
+	require.Error(t, client.Unblind(e1[:4]))
+	require.NoError(t, client.Unblind(e1))
+
+	// server tags stream in batches
+	var found [][]byte
+	for from := 0; from < 12; from += 4 {
+		tags, err := server.Tags(items("x", from, from+4))
+		require.NoError(t, err)
+		found = append(found, client.Match(tags)...)
+	}
+	require.Equal(t, sorted(items("x", 0, 5)), sorted(found))
+
+	// the second batch matches once unblinded
+	require.NoError(t, client.Unblind(e2))
+	tags, err := server.Tags(items("x", 0, 12))
+	require.NoError(t, err)
+	require.Equal(t, sorted(items("x", 5, 10)), sorted(client.Match(tags)))
+	require.Error(t, client.Unblind(e2))
+}
+
+func TestDHPSIOtherServerKey(t *testing.T) {
+	curve := curves.P256()
+	client, err := NewDHClient(curve)
+	require.NoError(t, err)
+	server, err := NewDHServer(curve)
+	require.NoError(t, err)
+	other, err := NewDHServer(curve)
+	require.NoError(t, err)
+
+	blinded, err := client.Blind(items("a", 0, 3))
+	require.NoError(t, err)
+	evaluated, err := server.Evaluate(blinded)
+	require.NoError(t, err)
+	require.NoError(t, client.Unblind(evaluated))
+	tags, err := other.Tags(items("a", 0, 3))
+	require.NoError(t, err)
+	require.Empty(t, client.Match(tags))
+
+	_, err = server.Evaluate([]curves.Point{curve.Point.Identity()})
+	require.Error(t, err)
+}
+
+func TestOPRFPSI(t *testing.T) {
+	for _, mode := range []oprf.Mode{oprf.ModeOPRF, oprf.ModeVOPRF} {
+		t.Run(fmt.Sprintf("mode %d", mode), func(t *testing.T) {
+			suite := oprf.Ristretto255Sha512
+			sk := suite.Curve().Scalar.Random(crand.Reader)
+			server, err := NewOPRFServer(suite, mode, sk)
+			require.NoError(t, err)
+
+			// tags of a long lived key are computed once for every client
+			tags, err := server.Tags(items("user", 0, 50))
+			require.NoError(t, err)
+
+			for c := 0; c < 2; c++ {
+				client, err := NewOPRFClient(suite, mode, server.PublicKey())
+				require.NoError(t, err)
+				for from := 40; from < 60; from += 10 {
+					blinded, err := client.Blind(items("user", from, from+10))
+					require.NoError(t, err)
+					eval, err := server.Evaluate(blinded)
+					require.NoError(t, err)
+					require.NoError(t, client.Finalize(eval))
+				}
+				require.Equal(t, sorted(items("user", 40, 50)), sorted(client.Match(tags)))
+			}
+		})
+	}
+}
+
+func TestOPRFPSIRejectsOtherKey(t *testing.T) {
+	suite := oprf.P256Sha256
+	server, err := NewOPRFServer(suite, oprf.ModeVOPRF, suite.Curve().Scalar.Random(crand.Reader))
+	require.NoError(t, err)
+	other, err := NewOPRFServer(suite, oprf.ModeVOPRF, suite.Curve().Scalar.Random(crand.Reader))
+	require.NoError(t, err)
+
+	client, err := NewOPRFClient(suite, oprf.ModeVOPRF, server.PublicKey())
+	require.NoError(t, err)
+	blinded, err := client.Blind(items("user", 0, 3))
+	require.NoError(t, err)
+	eval, err := other.Evaluate(blinded)
+	require.NoError(t, err)
+	require.Error(t, client.Finalize(eval))
+
+	_, err = NewOPRFClient(suite, oprf.ModePOPRF, server.PublicKey())
+	require.Error(t, err)
+}
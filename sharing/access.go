@@ -0,0 +1,227 @@
+package sharing
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-sonr/crypto/core/curves"
+)
+
+// Access structures as threshold trees, https://link.springer.com/content/pdf/10.1007/0-387-34799-2_3.pdf
+//
+// Every inner node is a k-of-n gate over its children and every leaf is a participant. The secret of a node is
+// Shamir shared among its children with threshold k, recursively, so a set of participants recovers the secret
+// exactly when it satisfies the tree. For example "2 executives OR 1 executive and 3 managers" is
+//
+//	Or(Threshold(2, execs...), And(Threshold(1, execs...), Threshold(3, managers...)))
+//
+// A participant that appears in several leaves receives one share per leaf.
+
+// AccessNode is a node of an access structure: a participant if Children is empty, else a Threshold-of-Children gate.
+type AccessNode struct {
+	Participant uint32
+	Threshold   uint32
+	Children    []*AccessNode
+}
+
+// TreeShare is the share of the leaf at Path, the 1-based child indices from the root, held by participant Id.
+type TreeShare struct {
+	Id    uint32   `json:"identifier"`
+	Path  []uint32 `json:"path"`
+	Value []byte   `json:"value"`
+}
+
+// Participant returns the leaf of participant id.
+func Participant(id uint32) *AccessNode {
+	return &AccessNode{Participant: id}
+}
+
+// Participants returns the leaves of the participants.
+func Participants(ids ...uint32) []*AccessNode {
+	out := make([]*AccessNode, len(ids))
+	for i, id := range ids {
+		out[i] = Participant(id)
+	}
+	return out
+}
+
+// Threshold returns the gate satisfied by any k of the children.
+func Threshold(k uint32, children ...*AccessNode) *AccessNode {
+	return &AccessNode{Threshold: k, Children: children}
+}
+
+// And returns the gate satisfied by all of the children.
+func And(children ...*AccessNode) *AccessNode {
+	return Threshold(uint32(len(children)), children...)
+}
+
+// Or returns the gate satisfied by any of the children.
+func Or(children ...*AccessNode) *AccessNode {
+	return Threshold(1, children...)
+}
+
+// AccessStructure shares secrets according to a threshold tree.
+type AccessStructure struct {
+	root  *AccessNode
+	curve *curves.Curve
+}
+
+// NewAccessStructure checks the tree and returns its sharing scheme.
+func NewAccessStructure(root *AccessNode, curve *curves.Curve) (*AccessStructure, error) {
+	if curve == nil {
+		return nil, fmt.Errorf("invalid curve")
+	}
+	if err := root.validate(); err != nil {
+		return nil, err
+	}
+	return &AccessStructure{root: root, curve: curve}, nil
+}
+
+func (n *AccessNode) validate() error {
+	if n == nil {
+		return fmt.Errorf("invalid access structure")
+	}
+	if len(n.Children) == 0 {
+		if n.Participant == 0 || n.Threshold != 0 {
+			return fmt.Errorf("invalid participant")
+		}
+		return nil
+	}
+	if n.Participant != 0 {
+		return fmt.Errorf("gates cannot be participants")
+	}
+	if n.Threshold < 1 || n.Threshold > uint32(len(n.Children)) {
+		return fmt.Errorf("threshold must be between 1 and %d", len(n.Children))
+	}
+	if len(n.Children) > 255 {
+		return fmt.Errorf("cannot exceed 255 children")
+	}
+	for _, c := range n.Children {
+		if err := c.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Split splits secret and returns the shares by participant.
+func (a AccessStructure) Split(secret curves.Scalar, reader io.Reader) (map[uint32][]*TreeShare, error) {
+	if secret == nil || secret.IsZero() {
+		return nil, fmt.Errorf("invalid secret")
+	}
+	out := make(map[uint32][]*TreeShare)
+	a.split(a.root, secret, nil, reader, out)
+	return out, nil
+}
+
+func (a AccessStructure) split(n *AccessNode, secret curves.Scalar, path []uint32, reader io.Reader, out map[uint32][]*TreeShare) {
+	if len(n.Children) == 0 {
+		out[n.Participant] = append(out[n.Participant], &TreeShare{
+			Id:    n.Participant,
+			Path:  append([]uint32{}, path...),
+			Value: secret.Bytes(),
+		})
+		return
+	}
+	poly := new(Polynomial).Init(secret, n.Threshold, reader)
+	for i, c := range n.Children {
+		x := a.curve.Scalar.New(i + 1)
+		a.split(c, poly.Evaluate(x), append(path, uint32(i+1)), reader, out)
+	}
+}
+
+// Authorized returns true if the participants satisfy the access structure.
+func (a AccessStructure) Authorized(ids ...uint32) bool {
+	set := make(map[uint32]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return a.root.satisfied(set)
+}
+
+func (n *AccessNode) satisfied(set map[uint32]bool) bool {
+	if len(n.Children) == 0 {
+		return set[n.Participant]
+	}
+	count := uint32(0)
+	for _, c := range n.Children {
+		if c.satisfied(set) {
+			count++
+		}
+	}
+	return count >= n.Threshold
+}
+
+// Combine recovers the secret from shares that satisfy the access structure.
+func (a AccessStructure) Combine(shares ...*TreeShare) (curves.Scalar, error) {
+	leaves := make(map[string]curves.Scalar, len(shares))
+	for _, s := range shares {
+		if s == nil {
+			return nil, fmt.Errorf("invalid share")
+		}
+		leaf, err := a.root.leaf(s.Path)
+		if err != nil {
+			return nil, err
+		}
+		if leaf.Participant != s.Id {
+			return nil, fmt.Errorf("share at %v does not belong to participant %d", s.Path, s.Id)
+		}
+		value, err := a.curve.Scalar.SetBytes(s.Value)
+		if err != nil {
+			return nil, err
+		}
+		leaves[fmt.Sprint(s.Path)] = value
+	}
+	secret, ok, err := a.combine(a.root, nil, leaves)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("shares do not satisfy the access structure")
+	}
+	return secret, nil
+}
+
+func (a AccessStructure) combine(n *AccessNode, path []uint32, leaves map[string]curves.Scalar) (curves.Scalar, bool, error) {
+	if len(n.Children) == 0 {
+		value, ok := leaves[fmt.Sprint(path)]
+		return value, ok, nil
+	}
+	var xs, ys []curves.Scalar
+	for i, c := range n.Children {
+		value, ok, err := a.combine(c, append(append([]uint32{}, path...), uint32(i+1)), leaves)
+		if err != nil {
+			return nil, false, err
+		}
+		if ok {
+			xs = append(xs, a.curve.Scalar.New(i+1))
+			ys = append(ys, value)
+			if uint32(len(xs)) == n.Threshold {
+				break
+			}
+		}
+	}
+	if uint32(len(xs)) < n.Threshold {
+		return nil, false, nil
+	}
+	shamir := Shamir{curve: a.curve}
+	value, err := shamir.interpolate(xs, ys)
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+func (n *AccessNode) leaf(path []uint32) (*AccessNode, error) {
+	node := n
+	for _, i := range path {
+		if i == 0 || i > uint32(len(node.Children)) {
+			return nil, fmt.Errorf("invalid share path %v", path)
+		}
+		node = node.Children[i-1]
+	}
+	if len(node.Children) != 0 {
+		return nil, fmt.Errorf("invalid share path %v", path)
+	}
+	return node, nil
+}
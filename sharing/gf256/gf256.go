@@ -0,0 +1,239 @@
+// Package gf256 is Shamir secret sharing of byte strings over GF(2^8), the
+// field of AES with the polynomial x^8 + x^4 + x^3 + x + 1, as in SLIP-0039.
+//
+// Every byte of the secret is shared with its own random polynomial, so
+// seeds, symmetric keys and other secrets of any length split directly into
+// shares of the same length, without encoding them as scalars. At most 255
+// shares can be dealt.
+package gf256
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"io"
+
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/sharing"
+)
+
+const (
+	digestSize  = 4
+	digestIndex = 254
+	secretIndex = 255
+)
+
+// Shamir is a threshold sharing of byte strings
+type Shamir struct {
+	threshold, limit uint32
+}
+
+// NewShamir returns the sharing of limit shares any threshold of which
+// recover the secret
+func NewShamir(threshold, limit uint32) (*Shamir, error) {
+	if limit < threshold {
+		return nil, fmt.Errorf("limit cannot be less than threshold")
+	}
+	if threshold < 2 {
+		return nil, fmt.Errorf("threshold cannot be less than 2")
+	}
+	if limit > 255 {
+		return nil, fmt.Errorf("cannot exceed 255 shares")
+	}
+	return &Shamir{threshold: threshold, limit: limit}, nil
+}
+
+// Split shares secret into shares with identifiers 1 to limit, with the
+// coefficients of the polynomials read from reader
+func (s Shamir) Split(secret []byte, reader io.Reader) ([]*sharing.ShamirShare, error) {
+	if len(secret) == 0 {
+		return nil, fmt.Errorf("invalid secret")
+	}
+	if reader == nil {
+		return nil, internal.ErrNilArguments
+	}
+	// coefficients[k][i] is the coefficient of x^(k+1) of the polynomial of
+	// byte i
+	coefficients := make([][]byte, s.threshold-1)
+	for k := range coefficients {
+		coefficients[k] = make([]byte, len(secret))
+		if _, err := io.ReadFull(reader, coefficients[k]); err != nil {
+			return nil, err
+		}
+	}
+	shares := make([]*sharing.ShamirShare, s.limit)
+	for j := range shares {
+		x := byte(j + 1)
+		value := make([]byte, len(secret))
+		for i := range secret {
+			// Horner's rule
+			y := byte(0)
+			for k := len(coefficients) - 1; k >= 0; k-- {
+				y = Mul(y, x) ^ coefficients[k][i]
+			}
+			value[i] = Mul(y, x) ^ secret[i]
+		}
+		shares[j] = &sharing.ShamirShare{Id: uint32(x), Value: value}
+	}
+	return shares, nil
+}
+
+// Combine recovers the secret from at least threshold shares
+func (s Shamir) Combine(shares ...*sharing.ShamirShare) ([]byte, error) {
+	if len(shares) < int(s.threshold) {
+		return nil, fmt.Errorf("invalid number of shares")
+	}
+	xs := make([]byte, len(shares))
+	ys := make([][]byte, len(shares))
+	for i, share := range shares {
+		if share == nil {
+			return nil, internal.ErrNilArguments
+		}
+		if share.Id == 0 || share.Id > s.limit {
+			return nil, fmt.Errorf("invalid share identifier %d", share.Id)
+		}
+		xs[i], ys[i] = byte(share.Id), share.Value
+	}
+	return Interpolate(xs, ys, 0)
+}
+
+// SplitSecret is the sharing of SLIP-0039 and SSKR: it shares secret among
+// count shares at x = 0, ..., count-1 on a polynomial that also passes
+// through the secret at 255 and a digest of it at 254, so recovery from a
+// wrong set of shares is detected. The secret must be longer than the
+// digest.
+func SplitSecret(threshold, count int, secret []byte, reader io.Reader) ([][]byte, error) {
+	if threshold < 1 || threshold > count || count > 255 {
+		return nil, fmt.Errorf("invalid threshold %d of %d", threshold, count)
+	}
+	if len(secret) <= digestSize {
+		return nil, fmt.Errorf("invalid secret")
+	}
+	if reader == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if threshold == 1 {
+		shares := make([][]byte, count)
+		for i := range shares {
+			shares[i] = append([]byte(nil), secret...)
+		}
+		return shares, nil
+	}
+	xs := make([]byte, 0, threshold)
+	ys := make([][]byte, 0, threshold)
+	for i := 0; i < threshold-2; i++ {
+		y := make([]byte, len(secret))
+		if _, err := io.ReadFull(reader, y); err != nil {
+			return nil, err
+		}
+		xs, ys = append(xs, byte(i)), append(ys, y)
+	}
+	random := make([]byte, len(secret)-digestSize)
+	if _, err := io.ReadFull(reader, random); err != nil {
+		return nil, err
+	}
+	xs = append(xs, digestIndex, secretIndex)
+	ys = append(ys, append(digest(random, secret), random...), secret)
+
+	shares := make([][]byte, count)
+	copy(shares, ys[:threshold-2])
+	for i := threshold - 2; i < count; i++ {
+		y, err := Interpolate(xs, ys, byte(i))
+		if err != nil {
+			return nil, err
+		}
+		shares[i] = y
+	}
+	return shares, nil
+}
+
+// RecoverSecret interpolates the secret of threshold shares of SplitSecret
+// and checks its digest
+func RecoverSecret(threshold int, xs []byte, ys [][]byte) ([]byte, error) {
+	if threshold < 1 || len(xs) != threshold || len(ys) != threshold {
+		return nil, fmt.Errorf("invalid number of shares")
+	}
+	if threshold == 1 {
+		return ys[0], nil
+	}
+	secret, err := Interpolate(xs, ys, secretIndex)
+	if err != nil {
+		return nil, err
+	}
+	digestShare, err := Interpolate(xs, ys, digestIndex)
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal(digestShare[:digestSize], digest(digestShare[digestSize:], secret)) {
+		return nil, fmt.Errorf("invalid digest of the shared secret")
+	}
+	return secret, nil
+}
+
+func digest(random, secret []byte) []byte {
+	mac := hmac.New(sha256.New, random)
+	_, _ = mac.Write(secret)
+	return mac.Sum(nil)[:digestSize]
+}
+
+// Interpolate evaluates at x the polynomials through the points (xs[i],
+// ys[i][k]) of each byte k. The xs must be distinct and the ys of the same
+// length.
+func Interpolate(xs []byte, ys [][]byte, x byte) ([]byte, error) {
+	if len(xs) == 0 || len(xs) != len(ys) {
+		return nil, fmt.Errorf("invalid number of points")
+	}
+	n := len(ys[0])
+	seen := make(map[byte]bool, len(xs))
+	for i, xi := range xs {
+		if seen[xi] {
+			return nil, fmt.Errorf("duplicate share")
+		}
+		seen[xi] = true
+		if len(ys[i]) != n {
+			return nil, fmt.Errorf("shares have different lengths")
+		}
+	}
+	result := make([]byte, n)
+	for i, xi := range xs {
+		// the Lagrange basis polynomial of xi at x, subtraction being xor
+		basis := byte(1)
+		for j, xj := range xs {
+			if j != i {
+				basis = Mul(basis, Div(x^xj, xi^xj))
+			}
+		}
+		for k, y := range ys[i] {
+			result[k] ^= Mul(basis, y)
+		}
+	}
+	return result, nil
+}
+
+// Mul multiplies a and b in constant time
+func Mul(a, b byte) byte {
+	var p byte
+	for i := 0; i < 8; i++ {
+		p ^= -(b & 1) & a
+		b >>= 1
+		// multiply a by x modulo x^8 + x^4 + x^3 + x + 1
+		a = a<<1 ^ -(a>>7)&0x1b
+	}
+	return p
+}
+
+// Inv returns the inverse a^254 of a, and 0 for 0
+func Inv(a byte) byte {
+	// a^254 = a^(2+4+8+16+32+64+128)
+	r := byte(1)
+	for i := 0; i < 7; i++ {
+		a = Mul(a, a)
+		r = Mul(r, a)
+	}
+	return r
+}
+
+// Div divides a by b, which must not be 0
+func Div(a, b byte) byte {
+	return Mul(a, Inv(b))
+}
@@ -0,0 +1,82 @@
+package gf256
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestField(t *testing.T) {
+	// the example multiplication of FIPS-197
+	require.Equal(t, byte(0xc1), Mul(0x57, 0x83))
+	require.Equal(t, byte(0xfe), Mul(0x57, 0x13))
+	for a := 1; a < 256; a++ {
+		require.Equal(t, byte(1), Mul(byte(a), Inv(byte(a))))
+		require.Equal(t, byte(a), Div(Mul(byte(a), 0x35), 0x35))
+	}
+	require.Equal(t, byte(0), Inv(0))
+}
+
+func TestShamir(t *testing.T) {
+	scheme, err := NewShamir(3, 5)
+	require.NoError(t, err)
+	secret := []byte("a seed or symmetric key of any length")
+	shares, err := scheme.Split(secret, crand.Reader)
+	require.NoError(t, err)
+	require.Len(t, shares, 5)
+	for _, s := range shares {
+		require.Len(t, s.Value, len(secret))
+	}
+
+	got, err := scheme.Combine(shares[4], shares[1], shares[2])
+	require.NoError(t, err)
+	require.Equal(t, secret, got)
+	got, err = scheme.Combine(shares...)
+	require.NoError(t, err)
+	require.Equal(t, secret, got)
+
+	got, err = scheme.Combine(shares[0], shares[1], shares[3])
+	require.NoError(t, err)
+	require.Equal(t, secret, got)
+
+	_, err = scheme.Combine(shares[0], shares[1])
+	require.Error(t, err)
+	_, err = scheme.Combine(shares[0], shares[1], shares[1])
+	require.Error(t, err)
+}
+
+func TestNewShamir(t *testing.T) {
+	_, err := NewShamir(1, 3)
+	require.Error(t, err)
+	_, err = NewShamir(4, 3)
+	require.Error(t, err)
+	_, err = NewShamir(2, 256)
+	require.Error(t, err)
+}
+
+func TestSplitSecret(t *testing.T) {
+	secret := []byte("sixteen byte key")
+	shares, err := SplitSecret(3, 5, secret, crand.Reader)
+	require.NoError(t, err)
+	require.Len(t, shares, 5)
+
+	got, err := RecoverSecret(3, []byte{4, 0, 2}, [][]byte{shares[4], shares[0], shares[2]})
+	require.NoError(t, err)
+	require.Equal(t, secret, got)
+
+	shares[2][0] ^= 1
+	_, err = RecoverSecret(3, []byte{4, 0, 2}, [][]byte{shares[4], shares[0], shares[2]})
+	require.Error(t, err)
+	_, err = RecoverSecret(3, []byte{4, 0}, [][]byte{shares[4], shares[0]})
+	require.Error(t, err)
+
+	shares, err = SplitSecret(1, 2, secret, crand.Reader)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{secret, secret}, shares)
+
+	_, err = SplitSecret(3, 2, secret, crand.Reader)
+	require.Error(t, err)
+	_, err = SplitSecret(2, 3, secret[:digestSize], crand.Reader)
+	require.Error(t, err)
+}
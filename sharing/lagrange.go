@@ -0,0 +1,240 @@
+package sharing
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-sonr/crypto/core/curves"
+)
+
+// lagrangeAtZero returns the Lagrange coefficients at zero of the points xs.
+// The denominators are inverted together, one field inversion in all.
+func lagrangeAtZero(curve *curves.Curve, xs []curves.Scalar) ([]curves.Scalar, error) {
+	nums := make([]curves.Scalar, len(xs))
+	dens := make([]curves.Scalar, len(xs))
+	for i, xi := range xs {
+		nums[i] = curve.Scalar.One()
+		dens[i] = curve.Scalar.One()
+		for j, xj := range xs {
+			if i == j {
+				continue
+			}
+			nums[i] = nums[i].Mul(xj)
+			dens[i] = dens[i].Mul(xj.Sub(xi))
+		}
+	}
+	// prefix[i] is the product of dens[:i]
+	prefix := make([]curves.Scalar, len(xs)+1)
+	prefix[0] = curve.Scalar.One()
+	for i, d := range dens {
+		prefix[i+1] = prefix[i].Mul(d)
+	}
+	if prefix[len(xs)].IsZero() {
+		return nil, fmt.Errorf("divide by zero")
+	}
+	inv, err := prefix[len(xs)].Invert()
+	if err != nil {
+		return nil, err
+	}
+	coeffs := make([]curves.Scalar, len(xs))
+	for i := len(xs) - 1; i >= 0; i-- {
+		// inv is the inverse of the product of dens[:i+1]
+		coeffs[i] = nums[i].Mul(inv.Mul(prefix[i]))
+		inv = inv.Mul(dens[i])
+	}
+	return coeffs, nil
+}
+
+// Interpolator holds the Lagrange coefficients of a fixed set of
+// participants, so that reconstructing many secrets shared among the same
+// participants computes them once. Combining is constant time in the share
+// values: it only multiplies and adds, and never branches on them.
+type Interpolator struct {
+	curve  *curves.Curve
+	ids    []uint32
+	coeffs []curves.Scalar
+	index  map[uint32]int
+}
+
+// Interpolator returns the interpolator of the participants identities,
+// which must be at least threshold distinct valid identifiers
+func (s Shamir) Interpolator(identities ...uint32) (*Interpolator, error) {
+	if len(identities) < int(s.threshold) {
+		return nil, fmt.Errorf("invalid number of shares")
+	}
+	ids := append([]uint32(nil), identities...)
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	index := make(map[uint32]int, len(ids))
+	xs := make([]curves.Scalar, len(ids))
+	for i, id := range ids {
+		if id == 0 || id > s.limit {
+			return nil, fmt.Errorf("invalid share identifier")
+		}
+		if _, in := index[id]; in {
+			return nil, fmt.Errorf("duplicate share")
+		}
+		index[id] = i
+		xs[i] = s.curve.Scalar.New(int(id))
+	}
+	coeffs, err := lagrangeAtZero(s.curve, xs)
+	if err != nil {
+		return nil, err
+	}
+	return &Interpolator{curve: s.curve, ids: ids, coeffs: coeffs, index: index}, nil
+}
+
+// Identities returns the participants of the interpolator in increasing order
+func (in *Interpolator) Identities() []uint32 {
+	return append([]uint32(nil), in.ids...)
+}
+
+// Coefficients returns the Lagrange coefficient at zero of every participant
+func (in *Interpolator) Coefficients() map[uint32]curves.Scalar {
+	result := make(map[uint32]curves.Scalar, len(in.ids))
+	for i, id := range in.ids {
+		result[id] = in.coeffs[i].Clone()
+	}
+	return result
+}
+
+// order returns the share of every participant in the order of the
+// interpolator, checking that shares are exactly one per participant
+func (in *Interpolator) order(shares []*ShamirShare) ([]*ShamirShare, error) {
+	if len(shares) != len(in.ids) {
+		return nil, fmt.Errorf("expected %d shares, got %d", len(in.ids), len(shares))
+	}
+	ordered := make([]*ShamirShare, len(shares))
+	for _, share := range shares {
+		if share == nil {
+			return nil, fmt.Errorf("nil share")
+		}
+		i, ok := in.index[share.Id]
+		if !ok {
+			return nil, fmt.Errorf("share %d is not of this participant set", share.Id)
+		}
+		if ordered[i] != nil {
+			return nil, fmt.Errorf("duplicate share")
+		}
+		ordered[i] = share
+	}
+	return ordered, nil
+}
+
+// Combine reconstructs the secret from one share of every participant, in
+// any order
+func (in *Interpolator) Combine(shares ...*ShamirShare) (curves.Scalar, error) {
+	ordered, err := in.order(shares)
+	if err != nil {
+		return nil, err
+	}
+	result := in.curve.Scalar.Zero()
+	for i, share := range ordered {
+		y, err := in.curve.Scalar.SetBytes(share.Value)
+		if err != nil {
+			curves.Zeroize(result)
+			return nil, err
+		}
+		term := y.Mul(in.coeffs[i])
+		next := result.Add(term)
+		curves.Zeroize(y, term, result)
+		result = next
+	}
+	return result, nil
+}
+
+// CombineBatch reconstructs a secret from each set of shares, every set
+// holding one share of every participant
+func (in *Interpolator) CombineBatch(sets ...[]*ShamirShare) ([]curves.Scalar, error) {
+	secrets := make([]curves.Scalar, len(sets))
+	for i, shares := range sets {
+		secret, err := in.Combine(shares...)
+		if err != nil {
+			curves.Zeroize(secrets[:i]...)
+			return nil, fmt.Errorf("set %d: %w", i, err)
+		}
+		secrets[i] = secret
+	}
+	return secrets, nil
+}
+
+// CombinePoints interpolates points in the exponent, such as public key
+// shares or partial signatures, given one point of every participant
+func (in *Interpolator) CombinePoints(points map[uint32]curves.Point) (curves.Point, error) {
+	if len(points) != len(in.ids) {
+		return nil, fmt.Errorf("expected %d points, got %d", len(in.ids), len(points))
+	}
+	ps := make([]curves.Point, len(in.ids))
+	for i, id := range in.ids {
+		p, ok := points[id]
+		if !ok || p == nil {
+			return nil, fmt.Errorf("no point for participant %d", id)
+		}
+		ps[i] = p
+	}
+	return in.curve.SumOfProducts(ps, in.coeffs), nil
+}
+
+// maxCachedInterpolators bounds an InterpolatorCache, which is emptied when
+// full
+const maxCachedInterpolators = 256
+
+// InterpolatorCache keeps the interpolators of the participant sets that
+// combined shares, for services that reconstruct for a few recurring
+// quorums. It is safe for concurrent use.
+type InterpolatorCache struct {
+	shamir *Shamir
+	mu     sync.Mutex
+	sets   map[string]*Interpolator
+}
+
+// NewInterpolatorCache returns an empty cache of interpolators of shamir
+func NewInterpolatorCache(shamir *Shamir) *InterpolatorCache {
+	return &InterpolatorCache{shamir: shamir, sets: make(map[string]*Interpolator)}
+}
+
+// Get returns the interpolator of the participants identities, computing
+// it on first use
+func (c *InterpolatorCache) Get(identities ...uint32) (*Interpolator, error) {
+	ids := append([]uint32(nil), identities...)
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	var key strings.Builder
+	for _, id := range ids {
+		key.WriteString(strconv.FormatUint(uint64(id), 10))
+		key.WriteByte(',')
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if in, ok := c.sets[key.String()]; ok {
+		return in, nil
+	}
+	in, err := c.shamir.Interpolator(ids...)
+	if err != nil {
+		return nil, err
+	}
+	if len(c.sets) >= maxCachedInterpolators {
+		c.sets = make(map[string]*Interpolator)
+	}
+	c.sets[key.String()] = in
+	return in, nil
+}
+
+// Combine reconstructs the secret from shares with the cached interpolator
+// of their participants
+func (c *InterpolatorCache) Combine(shares ...*ShamirShare) (curves.Scalar, error) {
+	ids := make([]uint32, len(shares))
+	for i, share := range shares {
+		if share == nil {
+			return nil, fmt.Errorf("nil share")
+		}
+		ids[i] = share.Id
+	}
+	in, err := c.Get(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return in.Combine(shares...)
+}
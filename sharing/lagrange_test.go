@@ -0,0 +1,121 @@
+package sharing
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+)
+
+func TestInterpolator(t *testing.T) {
+	for _, curve := range []*curves.Curve{curves.K256(), curves.P256(), curves.ED25519(), curves.BLS12381G1(), curves.PALLAS()} {
+		scheme, err := NewShamir(3, 5, curve)
+		require.NoError(t, err, curve.Name)
+		in, err := scheme.Interpolator(5, 2, 4)
+		require.NoError(t, err, curve.Name)
+		require.Equal(t, []uint32{2, 4, 5}, in.Identities())
+
+		// the coefficients are those of LagrangeCoeffs
+		expected, err := scheme.LagrangeCoeffs([]uint32{2, 4, 5})
+		require.NoError(t, err)
+		for id, c := range in.Coefficients() {
+			require.Equal(t, 0, c.Cmp(expected[id]), curve.Name)
+		}
+
+		secrets := make([]curves.Scalar, 4)
+		sets := make([][]*ShamirShare, len(secrets))
+		publics := make([]map[uint32]curves.Point, len(secrets))
+		for i := range secrets {
+			secrets[i] = curve.Scalar.Random(crand.Reader)
+			shares, err := scheme.Split(secrets[i], crand.Reader)
+			require.NoError(t, err)
+			sets[i] = []*ShamirShare{shares[3], shares[1], shares[4]}
+			publics[i] = map[uint32]curves.Point{}
+			for _, share := range sets[i] {
+				s, err := curve.Scalar.SetBytes(share.Value)
+				require.NoError(t, err)
+				publics[i][share.Id] = curve.ScalarBaseMult(s)
+			}
+		}
+		combined, err := in.CombineBatch(sets...)
+		require.NoError(t, err, curve.Name)
+		for i, secret := range combined {
+			require.Equal(t, 0, secret.Cmp(secrets[i]), curve.Name)
+			viaShamir, err := scheme.Combine(sets[i]...)
+			require.NoError(t, err)
+			require.Equal(t, 0, viaShamir.Cmp(secrets[i]), curve.Name)
+			point, err := in.CombinePoints(publics[i])
+			require.NoError(t, err, curve.Name)
+			require.True(t, point.Equal(curve.ScalarBaseMult(secrets[i])), curve.Name)
+		}
+
+		// shares must be exactly one per participant
+		_, err = in.Combine(sets[0][:2]...)
+		require.Error(t, err)
+		_, err = in.Combine(sets[0][0], sets[0][0], sets[0][1])
+		require.Error(t, err)
+		_, err = in.Combine(sets[0][0], sets[0][1], &ShamirShare{Id: 1, Value: sets[0][2].Value})
+		require.Error(t, err)
+		_, err = in.CombineBatch(sets[0], sets[1][:2])
+		require.Error(t, err)
+	}
+}
+
+func TestInterpolatorInvalid(t *testing.T) {
+	scheme, err := NewShamir(3, 5, curves.K256())
+	require.NoError(t, err)
+	_, err = scheme.Interpolator(1, 2)
+	require.Error(t, err)
+	_, err = scheme.Interpolator(1, 2, 2)
+	require.Error(t, err)
+	_, err = scheme.Interpolator(0, 1, 2)
+	require.Error(t, err)
+	_, err = scheme.Interpolator(1, 2, 6)
+	require.Error(t, err)
+}
+
+func TestInterpolatorCache(t *testing.T) {
+	curve := curves.K256()
+	scheme, err := NewShamir(2, 4, curve)
+	require.NoError(t, err)
+	cache := NewInterpolatorCache(scheme)
+	secret := curve.Scalar.Random(crand.Reader)
+	shares, err := scheme.Split(secret, crand.Reader)
+	require.NoError(t, err)
+
+	first, err := cache.Get(1, 3)
+	require.NoError(t, err)
+	again, err := cache.Get(3, 1)
+	require.NoError(t, err)
+	require.Same(t, first, again)
+
+	combined, err := cache.Combine(shares[2], shares[0])
+	require.NoError(t, err)
+	require.Equal(t, 0, combined.Cmp(secret))
+	combined, err = cache.Combine(shares[1], shares[3])
+	require.NoError(t, err)
+	require.Equal(t, 0, combined.Cmp(secret))
+	_, err = cache.Combine(shares[1])
+	require.Error(t, err)
+}
+
+func BenchmarkCombine(b *testing.B) {
+	curve := curves.K256()
+	scheme, _ := NewShamir(10, 20, curve)
+	shares, _ := scheme.Split(curve.Scalar.Random(crand.Reader), crand.Reader)
+	shares = shares[:10]
+	b.Run("shamir", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = scheme.Combine(shares...)
+		}
+	})
+	b.Run("interpolator", func(b *testing.B) {
+		in, _ := scheme.Interpolator(1, 2, 3, 4, 5, 6, 7, 8, 9, 10)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_, _ = in.Combine(shares...)
+		}
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/go-sonr/crypto/commitments"
 	"github.com/go-sonr/crypto/core/curves"
 )
 
@@ -45,11 +46,13 @@ func (pv PedersenVerifier) Verify(share, blindShare *ShamirShare) error {
 
 	sc, _ := curve.Scalar.SetBytes(share.Value)
 	bsc, _ := curve.Scalar.SetBytes(blindShare.Value)
-	g := pv.Commitments[0].Generator().Mul(sc)
-	h := pv.Generator.Mul(bsc)
-	lhs := g.Add(h)
+	params := &commitments.Params{G: pv.Commitments[0].Generator(), H: pv.Generator}
+	lhs, err := params.CommitWithBlinding(sc, bsc)
+	if err != nil {
+		return err
+	}
 
-	if lhs.Equal(rhs) {
+	if lhs.Point.Equal(rhs) {
 		return nil
 	} else {
 		return fmt.Errorf("not equal")
@@ -105,12 +108,14 @@ func (pd Pedersen) Split(secret curves.Scalar, reader io.Reader) (*PedersenResul
 	verifiers := make([]curves.Point, pd.threshold)
 
 	// ({p0 * G + b0 * H}, ...,{pt * G + bt * H})
+	params := &commitments.Params{G: pd.curve.NewGeneratorPoint(), H: pd.generator}
 	for i, c := range poly.Coefficients {
-		s := pd.curve.ScalarBaseMult(c)
-		b := pd.generator.Mul(polyBlinding.Coefficients[i])
-		bv := s.Add(b)
-		blindedverifiers[i] = bv
-		verifiers[i] = s
+		bv, err := params.CommitWithBlinding(c, polyBlinding.Coefficients[i])
+		if err != nil {
+			return nil, err
+		}
+		blindedverifiers[i] = bv.Point
+		verifiers[i] = pd.curve.ScalarBaseMult(c)
 	}
 	verifier1 := &FeldmanVerifier{Commitments: verifiers}
 	verifier2 := &PedersenVerifier{Commitments: blindedverifiers, Generator: pd.generator}
@@ -0,0 +1,124 @@
+package pvss
+
+import (
+	"fmt"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/protocol/messages"
+	"github.com/go-sonr/crypto/elgamal"
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/zkp/sigma"
+)
+
+const (
+	distributionType   = "pvss/distribution"
+	decryptedShareType = "pvss/decrypted-share"
+)
+
+// MarshalBinary encodes the distribution in the canonical messages format,
+// with the shares in increasing order of participant.
+func (d *Distribution) MarshalBinary() ([]byte, error) {
+	if d == nil || len(d.Commitments) == 0 || d.Commitments[0] == nil {
+		return nil, internal.ErrNilArguments
+	}
+	curve := curves.GetCurveByName(d.Commitments[0].CurveName())
+	if curve == nil {
+		return nil, fmt.Errorf("unsupported curve %s", d.Commitments[0].CurveName())
+	}
+	enc := messages.NewEncoder(distributionType, curve)
+	enc.WritePoints(d.Commitments)
+	enc.WriteUint32(uint32(len(d.Shares)))
+	for id := uint32(1); id <= uint32(len(d.Shares)); id++ {
+		share, ok := d.Shares[id]
+		if !ok || share == nil || share.Ciphertext == nil || share.Proof == nil || share.Proof.Challenge == nil {
+			return nil, fmt.Errorf("no share for participant %d", id)
+		}
+		enc.WritePoint(share.Ciphertext.C1)
+		enc.WritePoint(share.Ciphertext.C2)
+		enc.WriteScalar(share.Proof.Challenge)
+		writeScalars(enc, share.Proof.Challenges)
+		writeScalars(enc, share.Proof.Responses)
+	}
+	return enc.Finish()
+}
+
+// UnmarshalBinary decodes a distribution encoded by MarshalBinary.
+func (d *Distribution) UnmarshalBinary(data []byte) error {
+	dec, err := messages.NewDecoder(data, distributionType)
+	if err != nil {
+		return err
+	}
+	commitments := dec.ReadPoints()
+	n := dec.ReadUint32()
+	if n > 255 {
+		return fmt.Errorf("too many shares")
+	}
+	shares := make(map[uint32]*EncryptedShare, n)
+	for id := uint32(1); id <= n; id++ {
+		share := &EncryptedShare{Id: id, Ciphertext: new(elgamal.Ciphertext), Proof: new(sigma.Proof)}
+		share.Ciphertext.C1 = dec.ReadPoint()
+		share.Ciphertext.C2 = dec.ReadPoint()
+		share.Proof.Challenge = dec.ReadScalar()
+		share.Proof.Challenges = readScalars(dec)
+		share.Proof.Responses = readScalars(dec)
+		shares[id] = share
+	}
+	if err = dec.Finish(); err != nil {
+		return err
+	}
+	d.Commitments, d.Shares = commitments, shares
+	return nil
+}
+
+// MarshalBinary encodes the share in the canonical messages format.
+func (s *DecryptedShare) MarshalBinary() ([]byte, error) {
+	if s == nil || s.Value == nil || s.Proof == nil || s.Proof.C == nil || s.Proof.S == nil {
+		return nil, internal.ErrNilArguments
+	}
+	curve := curves.GetCurveByName(s.Value.CurveName())
+	if curve == nil {
+		return nil, fmt.Errorf("unsupported curve %s", s.Value.CurveName())
+	}
+	enc := messages.NewEncoder(decryptedShareType, curve)
+	enc.WriteUint32(s.Id)
+	enc.WritePoint(s.Value)
+	enc.WriteScalar(s.Proof.C)
+	enc.WriteScalar(s.Proof.S)
+	return enc.Finish()
+}
+
+// UnmarshalBinary decodes a share encoded by MarshalBinary.
+func (s *DecryptedShare) UnmarshalBinary(data []byte) error {
+	dec, err := messages.NewDecoder(data, decryptedShareType)
+	if err != nil {
+		return err
+	}
+	id := dec.ReadUint32()
+	value := dec.ReadPoint()
+	proof := &elgamal.DecryptionProof{C: dec.ReadScalar(), S: dec.ReadScalar()}
+	if err = dec.Finish(); err != nil {
+		return err
+	}
+	s.Id, s.Value, s.Proof = id, value, proof
+	return nil
+}
+
+func writeScalars(enc *messages.Encoder, scalars []curves.Scalar) {
+	enc.WriteUint32(uint32(len(scalars)))
+	for _, s := range scalars {
+		enc.WriteScalar(s)
+	}
+}
+
+func readScalars(dec *messages.Decoder) []curves.Scalar {
+	n := dec.ReadUint32()
+	var scalars []curves.Scalar
+	for i := uint32(0); i < n; i++ {
+		s := dec.ReadScalar()
+		if s == nil {
+			return nil
+		}
+		scalars = append(scalars, s)
+	}
+	return scalars
+}
@@ -0,0 +1,328 @@
+// Package pvss implements publicly verifiable secret sharing: a dealer
+// shares a secret among participants known by their ElGamal public keys,
+// and anyone, not only the participants, can check that the encrypted
+// shares are consistent and that the shares later decrypted are correct.
+//
+// The scheme follows Schoenmakers,
+// https://www.win.tue.nl/~berry/papers/crypto99.pdf, with shares encrypted
+// under standard ElGamal of the elgamal package. The dealer commits to the
+// coefficients a_j of its polynomial p as C_j = a_j·H, for a generator H
+// independent of the base point G, encrypts the share p(i)·G of every
+// participant i under its key, and proves with the sigma package that the
+// ciphertext encrypts the share committed by X_i = Σ i^j·C_j = p(i)·H.
+// Participants decrypt their share with a Chaum-Pedersen proof, and any
+// threshold of correct shares reconstruct the secret point s·G.
+//
+// Since everything is checked without interaction, a distribution can be
+// posted on chain: a round of a randomness beacon reconstructs the secret
+// of the round and hashes it with Beacon, and an escrow encrypts to the key
+// EscrowKey derives from the secret, recoverable by a threshold of the
+// escrow agents.
+package pvss
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sort"
+
+	"golang.org/x/crypto/hkdf"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
+	"github.com/go-sonr/crypto/elgamal"
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/sharing"
+	"github.com/go-sonr/crypto/zkp/sigma"
+)
+
+const (
+	domain          = "go-sonr pvss v1"
+	generatorDomain = "go-sonr pvss generator H"
+	beaconDomain    = "go-sonr pvss beacon"
+)
+
+// PVSS is a publicly verifiable sharing of threshold out of limit
+// participants, identified 1 to limit
+type PVSS struct {
+	threshold, limit uint32
+	curve            *curves.Curve
+	shamir           *sharing.Shamir
+	h                curves.Point
+}
+
+// EncryptedShare is the share of a participant encrypted under its key,
+// with the proof that it is the share committed by the dealer
+type EncryptedShare struct {
+	Id         uint32
+	Ciphertext *elgamal.Ciphertext
+	Proof      *sigma.Proof
+}
+
+// Distribution is what a dealer publishes: the commitments to its
+// polynomial and the encrypted share of every participant
+type Distribution struct {
+	Commitments []curves.Point
+	Shares      map[uint32]*EncryptedShare
+}
+
+// DecryptedShare is the share p(i)·G of a participant with the proof that
+// it decrypts the encrypted share
+type DecryptedShare struct {
+	Id    uint32
+	Value curves.Point
+	Proof *elgamal.DecryptionProof
+}
+
+// NewPVSS returns the scheme of threshold out of limit participants on
+// curve
+func NewPVSS(threshold, limit uint32, curve *curves.Curve) (*PVSS, error) {
+	shamir, err := sharing.NewShamir(threshold, limit, curve)
+	if err != nil {
+		return nil, err
+	}
+	return &PVSS{
+		threshold: threshold,
+		limit:     limit,
+		curve:     curve,
+		shamir:    shamir,
+		h:         curve.Point.Hash([]byte(generatorDomain)),
+	}, nil
+}
+
+// Deal shares secret among the participants of keys, which must hold the
+// key of every participant. sessionId binds the distribution to its
+// context, such as the round of a beacon, and must be unique.
+func (p *PVSS) Deal(sessionId []byte, secret curves.Scalar, keys map[uint32]*elgamal.PublicKey, reader io.Reader) (*Distribution, error) {
+	if secret == nil || reader == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if secret.IsZero() {
+		return nil, fmt.Errorf("invalid secret")
+	}
+	if err := p.checkKeys(keys); err != nil {
+		return nil, err
+	}
+	poly := new(sharing.Polynomial).Init(secret, p.threshold, reader)
+	defer curves.Zeroize(poly.Coefficients...)
+	d := &Distribution{
+		Commitments: make([]curves.Point, p.threshold),
+		Shares:      make(map[uint32]*EncryptedShare, p.limit),
+	}
+	for j, a := range poly.Coefficients {
+		d.Commitments[j] = p.h.Mul(a)
+	}
+	for id := uint32(1); id <= p.limit; id++ {
+		share := poly.Evaluate(p.curve.Scalar.New(int(id)))
+		r := p.curve.Scalar.Random(reader)
+		c, err := keys[id].EncryptWithNonce(p.curve.ScalarBaseMult(share), r)
+		if err != nil {
+			return nil, err
+		}
+		statement := p.statement(keys[id], p.commitment(d.Commitments, id), c)
+		proof, err := sigma.ProveFromReader(p.transcript(sessionId, d.Commitments, id), statement, sigma.Secrets(share, r), reader)
+		curves.Zeroize(share, r)
+		if err != nil {
+			return nil, err
+		}
+		d.Shares[id] = &EncryptedShare{Id: id, Ciphertext: c, Proof: proof}
+	}
+	return d, nil
+}
+
+// VerifyDistribution checks that the distribution shares a secret among
+// the participants of keys, each of them able to decrypt a correct share
+func (p *PVSS) VerifyDistribution(sessionId []byte, keys map[uint32]*elgamal.PublicKey, d *Distribution) error {
+	if d == nil {
+		return internal.ErrNilArguments
+	}
+	if err := p.checkKeys(keys); err != nil {
+		return err
+	}
+	if uint32(len(d.Commitments)) != p.threshold {
+		return fmt.Errorf("distribution must commit to %d coefficients", p.threshold)
+	}
+	for _, c := range d.Commitments {
+		if c == nil || c.CurveName() != p.curve.Name || c.IsIdentity() || !c.IsOnCurve() {
+			return fmt.Errorf("invalid commitment")
+		}
+	}
+	if uint32(len(d.Shares)) != p.limit {
+		return fmt.Errorf("distribution must hold %d shares", p.limit)
+	}
+	for id := uint32(1); id <= p.limit; id++ {
+		if err := p.verifyEncryptedShare(sessionId, keys[id], d, id); err != nil {
+			return fmt.Errorf("share of participant %d: %w", id, err)
+		}
+	}
+	return nil
+}
+
+func (p *PVSS) verifyEncryptedShare(sessionId []byte, key *elgamal.PublicKey, d *Distribution, id uint32) error {
+	share, ok := d.Shares[id]
+	if !ok || share == nil || share.Ciphertext == nil || share.Proof == nil {
+		return fmt.Errorf("missing share")
+	}
+	if share.Id != id {
+		return fmt.Errorf("share is for participant %d", share.Id)
+	}
+	c := share.Ciphertext
+	for _, q := range []curves.Point{c.C1, c.C2} {
+		if q == nil || q.CurveName() != p.curve.Name || !q.IsOnCurve() {
+			return fmt.Errorf("invalid ciphertext")
+		}
+	}
+	statement := p.statement(key, p.commitment(d.Commitments, id), c)
+	return sigma.Verify(p.transcript(sessionId, d.Commitments, id), statement, share.Proof)
+}
+
+// DecryptShare decrypts the share of participant id with its secret key
+// and proves the decryption. The distribution should have been checked
+// with VerifyDistribution first.
+func (p *PVSS) DecryptShare(sessionId []byte, id uint32, sk *elgamal.SecretKey, d *Distribution) (*DecryptedShare, error) {
+	if sk == nil || d == nil {
+		return nil, internal.ErrNilArguments
+	}
+	share, ok := d.Shares[id]
+	if !ok || share == nil {
+		return nil, fmt.Errorf("no share for participant %d", id)
+	}
+	value, proof, err := sk.ProveDecryption(share.Ciphertext, decryptionSession(sessionId, id))
+	if err != nil {
+		return nil, err
+	}
+	return &DecryptedShare{Id: id, Value: value, Proof: proof}, nil
+}
+
+// VerifyShare checks that share is the decryption of the encrypted share
+// of its participant, whose key is key
+func (p *PVSS) VerifyShare(sessionId []byte, key *elgamal.PublicKey, d *Distribution, share *DecryptedShare) error {
+	if key == nil || d == nil || share == nil || share.Value == nil {
+		return internal.ErrNilArguments
+	}
+	encrypted, ok := d.Shares[share.Id]
+	if !ok || encrypted == nil {
+		return fmt.Errorf("no share for participant %d", share.Id)
+	}
+	if !share.Value.IsOnCurve() {
+		return fmt.Errorf("share is not on curve %s", p.curve.Name)
+	}
+	return key.VerifyDecryption(encrypted.Ciphertext, share.Value, share.Proof, decryptionSession(sessionId, share.Id))
+}
+
+// Reconstruct checks the decrypted shares, ignoring invalid and duplicate
+// ones, and interpolates the first threshold valid ones into the secret
+// point s·G. It fails when fewer than threshold shares are correct.
+func (p *PVSS) Reconstruct(sessionId []byte, keys map[uint32]*elgamal.PublicKey, d *Distribution, shares ...*DecryptedShare) (curves.Point, error) {
+	valid := make(map[uint32]curves.Point, p.threshold)
+	for _, share := range shares {
+		if share == nil || valid[share.Id] != nil || keys[share.Id] == nil {
+			continue
+		}
+		if p.VerifyShare(sessionId, keys[share.Id], d, share) != nil {
+			continue
+		}
+		valid[share.Id] = share.Value
+	}
+	if uint32(len(valid)) < p.threshold {
+		return nil, fmt.Errorf("%d valid shares, %d needed", len(valid), p.threshold)
+	}
+	ids := make([]uint32, 0, len(valid))
+	for id := range valid {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	ids = ids[:p.threshold]
+	points := make(map[uint32]curves.Point, len(ids))
+	for _, id := range ids {
+		points[id] = valid[id]
+	}
+	interpolator, err := p.shamir.Interpolator(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return interpolator.CombinePoints(points)
+}
+
+// Beacon returns the 32 byte output of a randomness beacon whose round
+// reconstructed secret
+func Beacon(secret curves.Point) []byte {
+	hash := sha3.New256()
+	hash.Write([]byte(beaconDomain))
+	hash.Write([]byte(secret.CurveName()))
+	hash.Write(secret.ToAffineCompressed())
+	return hash.Sum(nil)
+}
+
+// EscrowKey derives a symmetric key of length bytes from a secret
+// reconstructed for an escrow, with info naming its purpose
+func EscrowKey(secret curves.Point, info []byte, length int) ([]byte, error) {
+	if secret == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if secret.IsIdentity() {
+		return nil, internal.ErrZeroValue
+	}
+	key := make([]byte, length)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret.ToAffineCompressed(), []byte(domain), info), key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func (p *PVSS) checkKeys(keys map[uint32]*elgamal.PublicKey) error {
+	if uint32(len(keys)) != p.limit {
+		return fmt.Errorf("expected %d keys, got %d", p.limit, len(keys))
+	}
+	for id := uint32(1); id <= p.limit; id++ {
+		key, ok := keys[id]
+		if !ok || key == nil || key.Y == nil {
+			return fmt.Errorf("no key for participant %d", id)
+		}
+		if key.Y.CurveName() != p.curve.Name || key.Y.IsIdentity() || !key.Y.IsOnCurve() {
+			return fmt.Errorf("invalid key for participant %d", id)
+		}
+	}
+	return nil
+}
+
+// commitment returns X_i = Σ i^j·C_j, the commitment p(i)·H to the share
+// of participant id
+func (p *PVSS) commitment(commitments []curves.Point, id uint32) curves.Point {
+	x := p.curve.Scalar.New(int(id))
+	powers := make([]curves.Scalar, len(commitments))
+	powers[0] = p.curve.Scalar.One()
+	for j := 1; j < len(powers); j++ {
+		powers[j] = powers[j-1].Mul(x)
+	}
+	return p.curve.SumOfProducts(commitments, powers)
+}
+
+// statement is the statement that the ciphertext (C1, C2) = (rG, σG + rY)
+// encrypts the share σ committed as X = σH
+func (p *PVSS) statement(key *elgamal.PublicKey, x curves.Point, c *elgamal.Ciphertext) *sigma.Statement {
+	g := p.curve.Point.Generator()
+	return sigma.Linear(2,
+		sigma.Equation{Public: x, Terms: []sigma.Term{{Secret: 0, Base: p.h}}},
+		sigma.Equation{Public: c.C1, Terms: []sigma.Term{{Secret: 1, Base: g}}},
+		sigma.Equation{Public: c.C2, Terms: []sigma.Term{{Secret: 0, Base: g}, {Secret: 1, Base: key.Y}}},
+	)
+}
+
+func (p *PVSS) transcript(sessionId []byte, commitments []curves.Point, id uint32) *transcript.Transcript {
+	tr := transcript.New(domain)
+	tr.AppendMessage([]byte("session id"), sessionId)
+	tr.AppendPoints([]byte("commitments"), commitments...)
+	tr.AppendMessage([]byte("participant"), binary.BigEndian.AppendUint32(nil, id))
+	return tr
+}
+
+// decryptionSession binds the decryption proof of a participant to the
+// session and to the participant
+func decryptionSession(sessionId []byte, id uint32) []byte {
+	out := binary.BigEndian.AppendUint32([]byte(domain), uint32(len(sessionId)))
+	out = append(out, sessionId...)
+	return binary.BigEndian.AppendUint32(out, id)
+}
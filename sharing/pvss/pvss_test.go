@@ -0,0 +1,163 @@
+package pvss
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/elgamal"
+)
+
+type participants struct {
+	public map[uint32]*elgamal.PublicKey
+	secret map[uint32]*elgamal.SecretKey
+}
+
+func newParticipants(t *testing.T, curve *curves.Curve, n uint32) *participants {
+	p := &participants{public: map[uint32]*elgamal.PublicKey{}, secret: map[uint32]*elgamal.SecretKey{}}
+	for id := uint32(1); id <= n; id++ {
+		pk, sk, err := elgamal.NewKeys(curve)
+		require.NoError(t, err)
+		p.public[id], p.secret[id] = pk, sk
+	}
+	return p
+}
+
+func TestPVSSRoundTrip(t *testing.T) {
+	for _, curve := range []*curves.Curve{curves.K256(), curves.P256(), curves.ED25519()} {
+		t.Run(curve.Name, func(t *testing.T) {
+			scheme, err := NewPVSS(3, 5, curve)
+			require.NoError(t, err)
+			parties := newParticipants(t, curve, 5)
+			sid := []byte("round 1")
+			secret := curve.Scalar.Random(crand.Reader)
+
+			d, err := scheme.Deal(sid, secret, parties.public, crand.Reader)
+			require.NoError(t, err)
+			require.NoError(t, scheme.VerifyDistribution(sid, parties.public, d))
+
+			var shares []*DecryptedShare
+			for _, id := range []uint32{5, 2, 4} {
+				share, err := scheme.DecryptShare(sid, id, parties.secret[id], d)
+				require.NoError(t, err)
+				require.NoError(t, scheme.VerifyShare(sid, parties.public[id], d, share))
+				shares = append(shares, share)
+			}
+			got, err := scheme.Reconstruct(sid, parties.public, d, shares...)
+			require.NoError(t, err)
+			require.True(t, got.Equal(curve.ScalarBaseMult(secret)))
+		})
+	}
+}
+
+func TestPVSSRejectsBadDistribution(t *testing.T) {
+	curve := curves.K256()
+	scheme, err := NewPVSS(2, 3, curve)
+	require.NoError(t, err)
+	parties := newParticipants(t, curve, 3)
+	sid := []byte("session")
+	d, err := scheme.Deal(sid, curve.Scalar.Random(crand.Reader), parties.public, crand.Reader)
+	require.NoError(t, err)
+
+	// another session
+	require.Error(t, scheme.VerifyDistribution([]byte("other"), parties.public, d))
+
+	// a share encrypting another value
+	c, _, err := parties.public[2].EncryptScalar(curve.Scalar.New(7))
+	require.NoError(t, err)
+	saved := d.Shares[2].Ciphertext
+	d.Shares[2].Ciphertext = c
+	require.Error(t, scheme.VerifyDistribution(sid, parties.public, d))
+	d.Shares[2].Ciphertext = saved
+
+	// a changed commitment
+	saved0 := d.Commitments[1]
+	d.Commitments[1] = saved0.Double()
+	require.Error(t, scheme.VerifyDistribution(sid, parties.public, d))
+	d.Commitments[1] = saved0
+
+	// shares swapped between participants
+	d.Shares[1], d.Shares[3] = d.Shares[3], d.Shares[1]
+	require.Error(t, scheme.VerifyDistribution(sid, parties.public, d))
+	d.Shares[1], d.Shares[3] = d.Shares[3], d.Shares[1]
+
+	// a missing key or share
+	delete(d.Shares, 3)
+	require.Error(t, scheme.VerifyDistribution(sid, parties.public, d))
+
+	require.Error(t, scheme.VerifyDistribution(sid, map[uint32]*elgamal.PublicKey{1: parties.public[1]}, d))
+}
+
+func TestPVSSReconstructIgnoresBadShares(t *testing.T) {
+	curve := curves.P256()
+	scheme, err := NewPVSS(2, 4, curve)
+	require.NoError(t, err)
+	parties := newParticipants(t, curve, 4)
+	sid := []byte("escrow")
+	secret := curve.Scalar.Random(crand.Reader)
+	d, err := scheme.Deal(sid, secret, parties.public, crand.Reader)
+	require.NoError(t, err)
+
+	shares := make([]*DecryptedShare, 0, 4)
+	for id := uint32(1); id <= 4; id++ {
+		share, err := scheme.DecryptShare(sid, id, parties.secret[id], d)
+		require.NoError(t, err)
+		shares = append(shares, share)
+	}
+	// a wrong value, and a correct share decrypted with the key of another
+	shares[0].Value = shares[0].Value.Double()
+	require.Error(t, scheme.VerifyShare(sid, parties.public[1], d, shares[0]))
+	forged, err := scheme.DecryptShare(sid, 2, parties.secret[3], d)
+	require.NoError(t, err)
+	require.Error(t, scheme.VerifyShare(sid, parties.public[2], d, forged))
+
+	_, err = scheme.Reconstruct(sid, parties.public, d, shares[0], forged, shares[2])
+	require.Error(t, err)
+	got, err := scheme.Reconstruct(sid, parties.public, d, shares[0], forged, shares[2], shares[2], shares[3])
+	require.NoError(t, err)
+	require.True(t, got.Equal(curve.ScalarBaseMult(secret)))
+
+	key, err := EscrowKey(got, []byte("backup"), 32)
+	require.NoError(t, err)
+	again, err := EscrowKey(curve.ScalarBaseMult(secret), []byte("backup"), 32)
+	require.NoError(t, err)
+	require.Equal(t, key, again)
+	other, err := EscrowKey(got, []byte("other"), 32)
+	require.NoError(t, err)
+	require.NotEqual(t, key, other)
+}
+
+func TestBeacon(t *testing.T) {
+	curve := curves.ED25519()
+	s := curve.ScalarBaseMult(curve.Scalar.Random(crand.Reader))
+	require.Len(t, Beacon(s), 32)
+	require.Equal(t, Beacon(s), Beacon(s.Add(curve.Point.Identity())))
+	require.NotEqual(t, Beacon(s), Beacon(s.Double()))
+}
+
+func TestMarshalBinary(t *testing.T) {
+	curve := curves.K256()
+	scheme, err := NewPVSS(2, 3, curve)
+	require.NoError(t, err)
+	parties := newParticipants(t, curve, 3)
+	sid := []byte("wire")
+	d, err := scheme.Deal(sid, curve.Scalar.Random(crand.Reader), parties.public, crand.Reader)
+	require.NoError(t, err)
+
+	data, err := d.MarshalBinary()
+	require.NoError(t, err)
+	decoded := new(Distribution)
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	require.NoError(t, scheme.VerifyDistribution(sid, parties.public, decoded))
+	require.Error(t, decoded.UnmarshalBinary(data[:len(data)-1]))
+
+	share, err := scheme.DecryptShare(sid, 3, parties.secret[3], decoded)
+	require.NoError(t, err)
+	data, err = share.MarshalBinary()
+	require.NoError(t, err)
+	decodedShare := new(DecryptedShare)
+	require.NoError(t, decodedShare.UnmarshalBinary(data))
+	require.NoError(t, scheme.VerifyShare(sid, parties.public[3], d, decodedShare))
+}
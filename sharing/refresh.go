@@ -0,0 +1,98 @@
+package sharing
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-sonr/crypto/core/curves"
+)
+
+// Proactive share refresh of https://link.springer.com/content/pdf/10.1007/3-540-44750-4_27.pdf
+//
+// Every shareholder deals a Feldman verifiable sharing of zero with RefreshDeal and sends each shareholder its share.
+// Each shareholder adds the verified shares of zero to its share with RefreshShares, and everybody updates the
+// public commitments with RefreshVerifier. The new shares are a fresh sharing of the same secret, so shares leaked
+// before the refresh cannot be combined with shares leaked after it.
+
+// RefreshDeal returns the commitments and the shares of a random sharing of zero.
+func (f Feldman) RefreshDeal(reader io.Reader) (*FeldmanVerifier, []*ShamirShare, error) {
+	if f.Curve == nil || reader == nil {
+		return nil, nil, fmt.Errorf("invalid arguments")
+	}
+	shamir := &Shamir{
+		threshold: f.Threshold,
+		limit:     f.Limit,
+		curve:     f.Curve,
+	}
+	shares, poly := shamir.getPolyAndShares(f.Curve.Scalar.Zero(), reader)
+	verifier := new(FeldmanVerifier)
+	verifier.Commitments = make([]curves.Point, f.Threshold)
+	for i := range verifier.Commitments {
+		verifier.Commitments[i] = f.Curve.ScalarBaseMult(poly.Coefficients[i])
+	}
+	return verifier, shares, nil
+}
+
+// VerifyRefresh checks that the verifier commits to a sharing of zero of the right threshold and that update is
+// one of its shares.
+func (f Feldman) VerifyRefresh(verifier *FeldmanVerifier, update *ShamirShare) error {
+	if verifier == nil || update == nil {
+		return fmt.Errorf("invalid arguments")
+	}
+	if uint32(len(verifier.Commitments)) != f.Threshold {
+		return fmt.Errorf("refresh must commit to %d coefficients", f.Threshold)
+	}
+	for _, c := range verifier.Commitments {
+		if c == nil || c.CurveName() != f.Curve.Name || (!c.IsIdentity() && !c.IsOnCurve()) {
+			return fmt.Errorf("invalid commitment")
+		}
+	}
+	if !verifier.Commitments[0].IsIdentity() {
+		return fmt.Errorf("refresh does not share zero")
+	}
+	return verifier.Verify(update)
+}
+
+// RefreshShares adds the updates, one from each dealer with its verifier, to share and returns the new share.
+// Every update is checked with VerifyRefresh.
+func (f Feldman) RefreshShares(share *ShamirShare, updates []*ShamirShare, verifiers []*FeldmanVerifier) (*ShamirShare, error) {
+	if share == nil {
+		return nil, fmt.Errorf("invalid share")
+	}
+	if err := share.Validate(f.Curve); err != nil {
+		return nil, err
+	}
+	if len(updates) != len(verifiers) || len(updates) == 0 {
+		return nil, fmt.Errorf("need one verifier for each update")
+	}
+	value, _ := f.Curve.Scalar.SetBytes(share.Value)
+	for i, update := range updates {
+		if update == nil || update.Id != share.Id {
+			return nil, fmt.Errorf("update %d is not for share %d", i, share.Id)
+		}
+		if err := f.VerifyRefresh(verifiers[i], update); err != nil {
+			return nil, fmt.Errorf("update %d: %w", i, err)
+		}
+		u, _ := f.Curve.Scalar.SetBytes(update.Value)
+		value = value.Add(u)
+	}
+	return &ShamirShare{Id: share.Id, Value: value.Bytes()}, nil
+}
+
+// RefreshVerifier returns the commitments to the refreshed sharing, old plus the commitments of every update.
+func (f Feldman) RefreshVerifier(old *FeldmanVerifier, verifiers ...*FeldmanVerifier) (*FeldmanVerifier, error) {
+	if old == nil || uint32(len(old.Commitments)) != f.Threshold {
+		return nil, fmt.Errorf("invalid verifier")
+	}
+	out := &FeldmanVerifier{Commitments: make([]curves.Point, len(old.Commitments))}
+	copy(out.Commitments, old.Commitments)
+	for i, v := range verifiers {
+		if v == nil || uint32(len(v.Commitments)) != f.Threshold || !v.Commitments[0].IsIdentity() {
+			return nil, fmt.Errorf("verifier %d does not commit to a sharing of zero", i)
+		}
+		for j, c := range v.Commitments {
+			out.Commitments[j] = out.Commitments[j].Add(c)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,93 @@
+package sharing
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+)
+
+func refreshAll(t *testing.T, scheme *Feldman, verifier *FeldmanVerifier, shares []*ShamirShare) (*FeldmanVerifier, []*ShamirShare) {
+	verifiers := make([]*FeldmanVerifier, len(shares))
+	dealt := make([][]*ShamirShare, len(shares))
+	for i := range shares {
+		var err error
+		verifiers[i], dealt[i], err = scheme.RefreshDeal(crand.Reader)
+		require.NoError(t, err)
+	}
+	refreshed := make([]*ShamirShare, len(shares))
+	for i, share := range shares {
+		updates := make([]*ShamirShare, len(shares))
+		for j := range dealt {
+			updates[j] = dealt[j][i]
+		}
+		var err error
+		refreshed[i], err = scheme.RefreshShares(share, updates, verifiers)
+		require.NoError(t, err)
+	}
+	newVerifier, err := scheme.RefreshVerifier(verifier, verifiers...)
+	require.NoError(t, err)
+	return newVerifier, refreshed
+}
+
+func TestFeldmanRefreshShares(t *testing.T) {
+	for _, curve := range []*curves.Curve{curves.K256(), curves.ED25519()} {
+		scheme, err := NewFeldman(3, 5, curve)
+		require.NoError(t, err)
+		secret := curve.Scalar.Hash([]byte("test"))
+		verifier, shares, err := scheme.Split(secret, crand.Reader)
+		require.NoError(t, err)
+
+		newVerifier, refreshed := refreshAll(t, scheme, verifier, shares)
+		require.True(t, newVerifier.Commitments[0].Equal(verifier.Commitments[0]))
+		for i, share := range refreshed {
+			require.NotEqual(t, shares[i].Value, share.Value)
+			require.NoError(t, newVerifier.Verify(share))
+			require.Error(t, verifier.Verify(share))
+		}
+
+		combined, err := scheme.Combine(refreshed[0], refreshed[2], refreshed[4])
+		require.NoError(t, err)
+		require.Equal(t, secret.Bytes(), combined.Bytes())
+
+		// Old and new shares do not mix
+		combined, err = scheme.Combine(shares[0], refreshed[2], refreshed[4])
+		require.NoError(t, err)
+		require.NotEqual(t, secret.Bytes(), combined.Bytes())
+	}
+}
+
+func TestFeldmanRefreshRejectsBadUpdates(t *testing.T) {
+	curve := curves.K256()
+	scheme, err := NewFeldman(2, 3, curve)
+	require.NoError(t, err)
+	_, shares, err := scheme.Split(curve.Scalar.New(42), crand.Reader)
+	require.NoError(t, err)
+
+	// A sharing of a non-zero value would change the secret
+	bad, badShares, err := scheme.Split(curve.Scalar.New(1), crand.Reader)
+	require.NoError(t, err)
+	_, err = scheme.RefreshShares(shares[0], []*ShamirShare{badShares[0]}, []*FeldmanVerifier{bad})
+	require.Error(t, err)
+	_, err = scheme.RefreshVerifier(bad, bad)
+	require.Error(t, err)
+
+	verifier, updates, err := scheme.RefreshDeal(crand.Reader)
+	require.NoError(t, err)
+	// The update for another shareholder
+	_, err = scheme.RefreshShares(shares[0], []*ShamirShare{updates[1]}, []*FeldmanVerifier{verifier})
+	require.Error(t, err)
+	// A tampered update
+	tampered := &ShamirShare{Id: 1, Value: curve.Scalar.New(7).Bytes()}
+	_, err = scheme.RefreshShares(shares[0], []*ShamirShare{tampered}, []*FeldmanVerifier{verifier})
+	require.Error(t, err)
+	// Missing verifier
+	_, err = scheme.RefreshShares(shares[0], []*ShamirShare{updates[0]}, nil)
+	require.Error(t, err)
+
+	refreshed, err := scheme.RefreshShares(shares[0], []*ShamirShare{updates[0]}, []*FeldmanVerifier{verifier})
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), refreshed.Id)
+}
@@ -0,0 +1,353 @@
+package sharing
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/protocol/messages"
+)
+
+// Share repair, the enrollment protocol of https://eprint.iacr.org/2017/1155.pdf
+//
+// A set of threshold helpers lets a participant recover its lost share f(r) without learning the secret or the
+// shares of the helpers. Helper i splits its contribution λ_i(r)·f(i) into random pieces, one for each helper, and
+// broadcasts commitments to the pieces. Every helper sums the pieces it received and sends the sum to the target,
+// which adds the sums. The Feldman verifier of the sharing lets everybody check every step, so a helper that
+// deviates is identified by a RepairMisbehavior error.
+
+const (
+	repairRound1BcastType = "sharing/repair-round1-bcast"
+	repairRound1P2PType   = "sharing/repair-round1-p2p"
+	repairRound2Type      = "sharing/repair-round2"
+)
+
+// RepairMisbehavior identifies the helper that sent an invalid repair message.
+type RepairMisbehavior struct {
+	Id     uint32
+	Reason string
+}
+
+func (m *RepairMisbehavior) Error() string {
+	return fmt.Sprintf("helper %d misbehaved: %s", m.Id, m.Reason)
+}
+
+// RepairRound1Bcast are the commitments δ_ij·G to the pieces of helper i, in the order of the helpers.
+type RepairRound1Bcast struct {
+	Id          uint32
+	Commitments []curves.Point
+}
+
+// RepairRound1P2P is the piece δ_ij that helper i sends to helper j.
+type RepairRound1P2P struct {
+	Id    uint32
+	Piece curves.Scalar
+}
+
+// RepairRound2 is the sum σ_j = Σ_i δ_ij that helper j sends to the target.
+type RepairRound2 struct {
+	Id  uint32
+	Sum curves.Scalar
+}
+
+// RepairHelper is a shareholder that helps another participant recover its share.
+type RepairHelper struct {
+	feldman  Feldman
+	verifier *FeldmanVerifier
+	share    *ShamirShare
+	target   uint32
+	helpers  []uint32
+	round    int
+}
+
+// RepairTarget is the participant that recovers its share.
+type RepairTarget struct {
+	feldman  Feldman
+	verifier *FeldmanVerifier
+	id       uint32
+	helpers  []uint32
+}
+
+// NewRepairHelper returns a helper with share, one of helpers, for the repair of the share of target.
+// verifier are the Feldman commitments of the sharing.
+func (f Feldman) NewRepairHelper(share *ShamirShare, verifier *FeldmanVerifier, target uint32, helpers []uint32) (*RepairHelper, error) {
+	if share == nil {
+		return nil, fmt.Errorf("invalid share")
+	}
+	helpers, err := f.checkRepair(verifier, target, helpers)
+	if err != nil {
+		return nil, err
+	}
+	if err = verifier.Verify(share); err != nil {
+		return nil, err
+	}
+	if !containsId(helpers, share.Id) {
+		return nil, fmt.Errorf("participant %d is not a helper", share.Id)
+	}
+	return &RepairHelper{feldman: f, verifier: verifier, share: share, target: target, helpers: helpers, round: 1}, nil
+}
+
+// NewRepairTarget returns the target id of a repair by helpers.
+func (f Feldman) NewRepairTarget(id uint32, verifier *FeldmanVerifier, helpers []uint32) (*RepairTarget, error) {
+	helpers, err := f.checkRepair(verifier, id, helpers)
+	if err != nil {
+		return nil, err
+	}
+	return &RepairTarget{feldman: f, verifier: verifier, id: id, helpers: helpers}, nil
+}
+
+// Round1 splits λ_i(r)·f(i) into one random piece for each helper. The broadcast goes to the other helpers and the
+// target, the pieces to the helpers by id.
+func (h *RepairHelper) Round1(reader io.Reader) (*RepairRound1Bcast, map[uint32]*RepairRound1P2P, error) {
+	if h.round != 1 {
+		return nil, nil, fmt.Errorf("invalid round")
+	}
+	if reader == nil {
+		return nil, nil, fmt.Errorf("invalid reader")
+	}
+	curve := h.feldman.Curve
+	lambda, err := repairLagrange(curve, h.helpers, h.share.Id, h.target)
+	if err != nil {
+		return nil, nil, err
+	}
+	value, err := curve.Scalar.SetBytes(h.share.Value)
+	if err != nil {
+		return nil, nil, err
+	}
+	// δ_i = Σ_j δ_ij
+	rest := lambda.Mul(value)
+	bcast := &RepairRound1Bcast{Id: h.share.Id, Commitments: make([]curves.Point, len(h.helpers))}
+	p2p := make(map[uint32]*RepairRound1P2P, len(h.helpers))
+	for k, j := range h.helpers {
+		piece := rest
+		if k < len(h.helpers)-1 {
+			piece = curve.Scalar.Random(reader)
+			rest = rest.Sub(piece)
+		}
+		bcast.Commitments[k] = curve.ScalarBaseMult(piece)
+		p2p[j] = &RepairRound1P2P{Id: h.share.Id, Piece: piece}
+	}
+	h.round = 2
+	return bcast, p2p, nil
+}
+
+// Round2 checks the broadcasts and pieces of all helpers, including this one, and returns the sum of the pieces
+// for the target.
+func (h *RepairHelper) Round2(bcast map[uint32]*RepairRound1Bcast, p2p map[uint32]*RepairRound1P2P) (*RepairRound2, error) {
+	if h.round != 2 {
+		return nil, fmt.Errorf("invalid round")
+	}
+	if err := h.feldman.checkRepairBroadcasts(h.verifier, h.helpers, h.target, bcast); err != nil {
+		return nil, err
+	}
+	curve := h.feldman.Curve
+	self := indexOf(h.helpers, h.share.Id)
+	sum := curve.Scalar.Zero()
+	for _, i := range h.helpers {
+		msg := p2p[i]
+		if msg == nil || msg.Id != i || msg.Piece == nil || msg.Piece.Point().CurveName() != curve.Name {
+			return nil, &RepairMisbehavior{Id: i, Reason: "missing or invalid piece"}
+		}
+		if !curve.ScalarBaseMult(msg.Piece).Equal(bcast[i].Commitments[self]) {
+			return nil, &RepairMisbehavior{Id: i, Reason: "piece does not match its commitment"}
+		}
+		sum = sum.Add(msg.Piece)
+	}
+	h.round = 3
+	return &RepairRound2{Id: h.share.Id, Sum: sum}, nil
+}
+
+// Recover checks the broadcasts of round 1 and the sums of round 2 and returns the recovered share.
+func (t *RepairTarget) Recover(bcast map[uint32]*RepairRound1Bcast, sums map[uint32]*RepairRound2) (*ShamirShare, error) {
+	if err := t.feldman.checkRepairBroadcasts(t.verifier, t.helpers, t.id, bcast); err != nil {
+		return nil, err
+	}
+	curve := t.feldman.Curve
+	value := curve.Scalar.Zero()
+	for k, j := range t.helpers {
+		msg := sums[j]
+		if msg == nil || msg.Id != j || msg.Sum == nil || msg.Sum.Point().CurveName() != curve.Name {
+			return nil, &RepairMisbehavior{Id: j, Reason: "missing or invalid sum"}
+		}
+		// σ_j·G = Σ_i δ_ij·G
+		expected := curve.NewIdentityPoint()
+		for _, i := range t.helpers {
+			expected = expected.Add(bcast[i].Commitments[k])
+		}
+		if !curve.ScalarBaseMult(msg.Sum).Equal(expected) {
+			return nil, &RepairMisbehavior{Id: j, Reason: "sum does not match the commitments"}
+		}
+		value = value.Add(msg.Sum)
+	}
+	share := &ShamirShare{Id: t.id, Value: value.Bytes()}
+	if err := t.verifier.Verify(share); err != nil {
+		return nil, fmt.Errorf("recovered share is invalid: %w", err)
+	}
+	return share, nil
+}
+
+// checkRepairBroadcasts checks that Σ_j δ_ij·G = λ_i(r)·f(i)·G for every helper i.
+func (f Feldman) checkRepairBroadcasts(verifier *FeldmanVerifier, helpers []uint32, target uint32, bcast map[uint32]*RepairRound1Bcast) error {
+	for _, i := range helpers {
+		b := bcast[i]
+		if b == nil || b.Id != i || len(b.Commitments) != len(helpers) {
+			return &RepairMisbehavior{Id: i, Reason: "missing or invalid commitments"}
+		}
+		sum := f.Curve.NewIdentityPoint()
+		for _, c := range b.Commitments {
+			if c == nil || c.CurveName() != f.Curve.Name || !c.IsOnCurve() {
+				return &RepairMisbehavior{Id: i, Reason: "invalid commitment"}
+			}
+			sum = sum.Add(c)
+		}
+		lambda, err := repairLagrange(f.Curve, helpers, i, target)
+		if err != nil {
+			return err
+		}
+		if !sum.Equal(verifier.evaluate(f.Curve.Scalar.New(int(i))).Mul(lambda)) {
+			return &RepairMisbehavior{Id: i, Reason: "commitments do not match the share"}
+		}
+	}
+	return nil
+}
+
+func (f Feldman) checkRepair(verifier *FeldmanVerifier, target uint32, helpers []uint32) ([]uint32, error) {
+	if f.Curve == nil || verifier == nil || uint32(len(verifier.Commitments)) != f.Threshold {
+		return nil, fmt.Errorf("invalid verifier")
+	}
+	if target == 0 || target > f.Limit {
+		return nil, fmt.Errorf("invalid target")
+	}
+	if uint32(len(helpers)) != f.Threshold {
+		return nil, fmt.Errorf("repair needs exactly %d helpers", f.Threshold)
+	}
+	sorted := append([]uint32{}, helpers...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	for k, id := range sorted {
+		if id == 0 || id > f.Limit || id == target || (k > 0 && sorted[k-1] == id) {
+			return nil, fmt.Errorf("invalid helpers")
+		}
+	}
+	return sorted, nil
+}
+
+// evaluate returns f(x)·G = Σ A_k·x^k.
+func (v FeldmanVerifier) evaluate(x curves.Scalar) curves.Point {
+	out := v.Commitments[len(v.Commitments)-1]
+	for k := len(v.Commitments) - 2; k >= 0; k-- {
+		out = out.Mul(x).Add(v.Commitments[k])
+	}
+	return out
+}
+
+// repairLagrange returns λ_i(r) = Π_{j ≠ i} (r - j) / (i - j) over the helpers.
+func repairLagrange(curve *curves.Curve, helpers []uint32, i, r uint32) (curves.Scalar, error) {
+	xi := curve.Scalar.New(int(i))
+	xr := curve.Scalar.New(int(r))
+	num := curve.Scalar.One()
+	den := curve.Scalar.One()
+	for _, j := range helpers {
+		if j == i {
+			continue
+		}
+		xj := curve.Scalar.New(int(j))
+		num = num.Mul(xr.Sub(xj))
+		den = den.Mul(xi.Sub(xj))
+	}
+	if den.IsZero() {
+		return nil, fmt.Errorf("divide by zero")
+	}
+	return num.Div(den), nil
+}
+
+func containsId(ids []uint32, id uint32) bool {
+	return indexOf(ids, id) >= 0
+}
+
+func indexOf(ids []uint32, id uint32) int {
+	for k, v := range ids {
+		if v == id {
+			return k
+		}
+	}
+	return -1
+}
+
+// MarshalBinary encodes the broadcast in the canonical messages format.
+func (m *RepairRound1Bcast) MarshalBinary() ([]byte, error) {
+	if m == nil || len(m.Commitments) == 0 || m.Commitments[0] == nil {
+		return nil, fmt.Errorf("invalid message")
+	}
+	enc := messages.NewEncoder(repairRound1BcastType, curves.GetCurveByName(m.Commitments[0].CurveName()))
+	enc.WriteUint32(m.Id)
+	enc.WritePoints(m.Commitments)
+	return enc.Finish()
+}
+
+// UnmarshalBinary decodes a broadcast encoded by MarshalBinary.
+func (m *RepairRound1Bcast) UnmarshalBinary(data []byte) error {
+	dec, err := messages.NewDecoder(data, repairRound1BcastType)
+	if err != nil {
+		return err
+	}
+	id := dec.ReadUint32()
+	commitments := dec.ReadPoints()
+	if err = dec.Finish(); err != nil {
+		return err
+	}
+	m.Id, m.Commitments = id, commitments
+	return nil
+}
+
+// MarshalBinary encodes the piece in the canonical messages format.
+func (m *RepairRound1P2P) MarshalBinary() ([]byte, error) {
+	if m == nil || m.Piece == nil {
+		return nil, fmt.Errorf("invalid message")
+	}
+	enc := messages.NewEncoder(repairRound1P2PType, curves.GetCurveByName(m.Piece.Point().CurveName()))
+	enc.WriteUint32(m.Id)
+	enc.WriteScalar(m.Piece)
+	return enc.Finish()
+}
+
+// UnmarshalBinary decodes a piece encoded by MarshalBinary.
+func (m *RepairRound1P2P) UnmarshalBinary(data []byte) error {
+	dec, err := messages.NewDecoder(data, repairRound1P2PType)
+	if err != nil {
+		return err
+	}
+	id := dec.ReadUint32()
+	piece := dec.ReadScalar()
+	if err = dec.Finish(); err != nil {
+		return err
+	}
+	m.Id, m.Piece = id, piece
+	return nil
+}
+
+// MarshalBinary encodes the sum in the canonical messages format.
+func (m *RepairRound2) MarshalBinary() ([]byte, error) {
+	if m == nil || m.Sum == nil {
+		return nil, fmt.Errorf("invalid message")
+	}
+	enc := messages.NewEncoder(repairRound2Type, curves.GetCurveByName(m.Sum.Point().CurveName()))
+	enc.WriteUint32(m.Id)
+	enc.WriteScalar(m.Sum)
+	return enc.Finish()
+}
+
+// UnmarshalBinary decodes a sum encoded by MarshalBinary.
+func (m *RepairRound2) UnmarshalBinary(data []byte) error {
+	dec, err := messages.NewDecoder(data, repairRound2Type)
+	if err != nil {
+		return err
+	}
+	id := dec.ReadUint32()
+	sum := dec.ReadScalar()
+	if err = dec.Finish(); err != nil {
+		return err
+	}
+	m.Id, m.Sum = id, sum
+	return nil
+}
@@ -0,0 +1,154 @@
+package sharing
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+)
+
+type repairRun struct {
+	bcast map[uint32]*RepairRound1Bcast
+	p2p   map[uint32]map[uint32]*RepairRound1P2P
+	sums  map[uint32]*RepairRound2
+}
+
+func repairRound1(t *testing.T, scheme *Feldman, verifier *FeldmanVerifier, shares []*ShamirShare, target uint32, helpers []uint32) (map[uint32]*RepairHelper, *repairRun) {
+	run := &repairRun{
+		bcast: make(map[uint32]*RepairRound1Bcast),
+		p2p:   make(map[uint32]map[uint32]*RepairRound1P2P),
+		sums:  make(map[uint32]*RepairRound2),
+	}
+	hs := make(map[uint32]*RepairHelper)
+	for _, id := range helpers {
+		h, err := scheme.NewRepairHelper(shares[id-1], verifier, target, helpers)
+		require.NoError(t, err)
+		hs[id] = h
+		b, p2p, err := h.Round1(crand.Reader)
+		require.NoError(t, err)
+		run.bcast[id] = b
+		for to, m := range p2p {
+			if run.p2p[to] == nil {
+				run.p2p[to] = make(map[uint32]*RepairRound1P2P)
+			}
+			run.p2p[to][id] = m
+		}
+	}
+	return hs, run
+}
+
+func TestFeldmanRepair(t *testing.T) {
+	for _, curve := range []*curves.Curve{curves.K256(), curves.ED25519()} {
+		scheme, err := NewFeldman(3, 5, curve)
+		require.NoError(t, err)
+		verifier, shares, err := scheme.Split(curve.Scalar.Hash([]byte("secret")), crand.Reader)
+		require.NoError(t, err)
+
+		helpers := []uint32{5, 1, 3}
+		hs, run := repairRound1(t, scheme, verifier, shares, 2, helpers)
+		for id, h := range hs {
+			sum, err := h.Round2(run.bcast, run.p2p[id])
+			require.NoError(t, err)
+			run.sums[id] = sum
+		}
+		target, err := scheme.NewRepairTarget(2, verifier, helpers)
+		require.NoError(t, err)
+		recovered, err := target.Recover(run.bcast, run.sums)
+		require.NoError(t, err)
+		require.Equal(t, shares[1].Id, recovered.Id)
+		require.Equal(t, shares[1].Value, recovered.Value)
+	}
+}
+
+func TestFeldmanRepairIdentifiesCheater(t *testing.T) {
+	curve := curves.K256()
+	scheme, err := NewFeldman(2, 4, curve)
+	require.NoError(t, err)
+	verifier, shares, err := scheme.Split(curve.Scalar.New(99), crand.Reader)
+	require.NoError(t, err)
+	helpers := []uint32{1, 3}
+
+	// Helper 3 sends helper 1 a piece that does not match its commitments
+	hs, run := repairRound1(t, scheme, verifier, shares, 4, helpers)
+	run.p2p[1][3] = &RepairRound1P2P{Id: 3, Piece: run.p2p[1][3].Piece.Add(curve.Scalar.One())}
+	_, err = hs[1].Round2(run.bcast, run.p2p[1])
+	var misbehavior *RepairMisbehavior
+	require.ErrorAs(t, err, &misbehavior)
+	require.Equal(t, uint32(3), misbehavior.Id)
+
+	// Helper 1 commits to pieces of another share
+	hs, run = repairRound1(t, scheme, verifier, shares, 4, helpers)
+	run.bcast[1].Commitments[0] = run.bcast[1].Commitments[0].Add(curve.NewGeneratorPoint())
+	_, err = hs[3].Round2(run.bcast, run.p2p[3])
+	require.ErrorAs(t, err, &misbehavior)
+	require.Equal(t, uint32(1), misbehavior.Id)
+
+	// Helper 3 sends the target a wrong sum
+	hs, run = repairRound1(t, scheme, verifier, shares, 4, helpers)
+	for id, h := range hs {
+		run.sums[id], err = h.Round2(run.bcast, run.p2p[id])
+		require.NoError(t, err)
+	}
+	run.sums[3] = &RepairRound2{Id: 3, Sum: run.sums[3].Sum.Add(curve.Scalar.One())}
+	target, err := scheme.NewRepairTarget(4, verifier, helpers)
+	require.NoError(t, err)
+	_, err = target.Recover(run.bcast, run.sums)
+	require.ErrorAs(t, err, &misbehavior)
+	require.Equal(t, uint32(3), misbehavior.Id)
+}
+
+func TestFeldmanRepairInvalidArgs(t *testing.T) {
+	curve := curves.ED25519()
+	scheme, err := NewFeldman(2, 4, curve)
+	require.NoError(t, err)
+	verifier, shares, err := scheme.Split(curve.Scalar.New(5), crand.Reader)
+	require.NoError(t, err)
+	_, err = scheme.NewRepairHelper(shares[0], verifier, 1, []uint32{1, 2})
+	require.Error(t, err)
+	_, err = scheme.NewRepairHelper(shares[0], verifier, 3, []uint32{1})
+	require.Error(t, err)
+	_, err = scheme.NewRepairHelper(shares[0], verifier, 3, []uint32{1, 1})
+	require.Error(t, err)
+	_, err = scheme.NewRepairHelper(shares[2], verifier, 4, []uint32{1, 2})
+	require.Error(t, err)
+	_, err = scheme.NewRepairTarget(5, verifier, []uint32{1, 2})
+	require.Error(t, err)
+}
+
+func TestRepairMessagesRoundTrip(t *testing.T) {
+	curve := curves.K256()
+	scheme, err := NewFeldman(2, 3, curve)
+	require.NoError(t, err)
+	verifier, shares, err := scheme.Split(curve.Scalar.New(5), crand.Reader)
+	require.NoError(t, err)
+	h, err := scheme.NewRepairHelper(shares[0], verifier, 3, []uint32{1, 2})
+	require.NoError(t, err)
+	bcast, p2p, err := h.Round1(crand.Reader)
+	require.NoError(t, err)
+
+	data, err := bcast.MarshalBinary()
+	require.NoError(t, err)
+	var decoded RepairRound1Bcast
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	require.Equal(t, bcast.Id, decoded.Id)
+	for i := range bcast.Commitments {
+		require.True(t, bcast.Commitments[i].Equal(decoded.Commitments[i]))
+	}
+
+	data, err = p2p[2].MarshalBinary()
+	require.NoError(t, err)
+	var piece RepairRound1P2P
+	require.NoError(t, piece.UnmarshalBinary(data))
+	require.Equal(t, p2p[2].Id, piece.Id)
+	require.Equal(t, p2p[2].Piece.Bytes(), piece.Piece.Bytes())
+
+	sum := &RepairRound2{Id: 1, Sum: curve.Scalar.New(11)}
+	data, err = sum.MarshalBinary()
+	require.NoError(t, err)
+	var decodedSum RepairRound2
+	require.NoError(t, decodedSum.UnmarshalBinary(data))
+	require.Equal(t, sum.Sum.Bytes(), decodedSum.Sum.Bytes())
+	require.Error(t, piece.UnmarshalBinary(data))
+}
@@ -17,13 +17,39 @@ import (
 	"io"
 
 	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/protocol/messages"
 )
 
+const shamirShareType = "sharing/shamir-share"
+
 type ShamirShare struct {
 	Id    uint32 `json:"identifier"`
 	Value []byte `json:"value"`
 }
 
+// MarshalBinary encodes the share in the canonical messages format.
+func (ss ShamirShare) MarshalBinary() ([]byte, error) {
+	enc := messages.NewEncoder(shamirShareType, nil)
+	enc.WriteUint32(ss.Id)
+	enc.WriteBytes(ss.Value)
+	return enc.Finish()
+}
+
+// UnmarshalBinary decodes a share encoded by MarshalBinary.
+func (ss *ShamirShare) UnmarshalBinary(data []byte) error {
+	dec, err := messages.NewDecoder(data, shamirShareType)
+	if err != nil {
+		return err
+	}
+	id := dec.ReadUint32()
+	value := dec.ReadBytes()
+	if err = dec.Finish(); err != nil {
+		return err
+	}
+	ss.Id, ss.Value = id, value
+	return nil
+}
+
 func (ss ShamirShare) Validate(curve *curves.Curve) error {
 	if ss.Id == 0 {
 		return fmt.Errorf("invalid identifier")
@@ -87,27 +113,17 @@ func (s Shamir) getPolyAndShares(secret curves.Scalar, reader io.Reader) ([]*Sha
 }
 
 func (s Shamir) LagrangeCoeffs(identities []uint32) (map[uint32]curves.Scalar, error) {
-	xs := make(map[uint32]curves.Scalar, len(identities))
-	for _, xi := range identities {
-		xs[xi] = s.curve.Scalar.New(int(xi))
+	xs := make([]curves.Scalar, len(identities))
+	for i, xi := range identities {
+		xs[i] = s.curve.Scalar.New(int(xi))
+	}
+	coeffs, err := lagrangeAtZero(s.curve, xs)
+	if err != nil {
+		return nil, err
 	}
-
 	result := make(map[uint32]curves.Scalar, len(identities))
-	for i, xi := range xs {
-		num := s.curve.Scalar.One()
-		den := s.curve.Scalar.One()
-		for j, xj := range xs {
-			if i == j {
-				continue
-			}
-
-			num = num.Mul(xj)
-			den = den.Mul(xj.Sub(xi))
-		}
-		if den.IsZero() {
-			return nil, fmt.Errorf("divide by zero")
-		}
-		result[i] = num.Div(den)
+	for i, xi := range identities {
+		result[xi] = coeffs[i]
 	}
 	return result, nil
 }
@@ -166,41 +182,21 @@ func (s Shamir) CombinePoints(shares ...*ShamirShare) (curves.Point, error) {
 }
 
 func (s Shamir) interpolate(xs, ys []curves.Scalar) (curves.Scalar, error) {
+	coeffs, err := lagrangeAtZero(s.curve, xs)
+	if err != nil {
+		return nil, err
+	}
 	result := s.curve.Scalar.Zero()
-	for i, xi := range xs {
-		num := s.curve.Scalar.One()
-		den := s.curve.Scalar.One()
-		for j, xj := range xs {
-			if i == j {
-				continue
-			}
-			num = num.Mul(xj)
-			den = den.Mul(xj.Sub(xi))
-		}
-		if den.IsZero() {
-			return nil, fmt.Errorf("divide by zero")
-		}
-		result = result.Add(ys[i].Mul(num.Div(den)))
+	for i, y := range ys {
+		result = result.Add(y.Mul(coeffs[i]))
 	}
 	return result, nil
 }
 
 func (s Shamir) interpolatePoint(xs []curves.Scalar, ys []curves.Point) (curves.Point, error) {
-	result := s.curve.NewIdentityPoint()
-	for i, xi := range xs {
-		num := s.curve.Scalar.One()
-		den := s.curve.Scalar.One()
-		for j, xj := range xs {
-			if i == j {
-				continue
-			}
-			num = num.Mul(xj)
-			den = den.Mul(xj.Sub(xi))
-		}
-		if den.IsZero() {
-			return nil, fmt.Errorf("divide by zero")
-		}
-		result = result.Add(ys[i].Mul(num.Div(den)))
+	coeffs, err := lagrangeAtZero(s.curve, xs)
+	if err != nil {
+		return nil, err
 	}
-	return result, nil
+	return s.curve.SumOfProducts(ys, coeffs), nil
 }
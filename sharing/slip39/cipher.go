@@ -0,0 +1,41 @@
+package slip39
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// baseIterations is the PBKDF2 iterations of a round of the Feistel network
+// at iteration exponent 0
+const baseIterations = 2500
+
+// encrypt is the four round Feistel network of SLIP-0039 keyed by the
+// passphrase, turning the master secret into the shared secret
+func encrypt(masterSecret, passphrase []byte, e int, id uint16, extendable bool) []byte {
+	return feistel(masterSecret, passphrase, e, id, extendable, []byte{0, 1, 2, 3})
+}
+
+// decrypt inverts encrypt
+func decrypt(encrypted, passphrase []byte, e int, id uint16, extendable bool) []byte {
+	return feistel(encrypted, passphrase, e, id, extendable, []byte{3, 2, 1, 0})
+}
+
+func feistel(data, passphrase []byte, e int, id uint16, extendable bool, rounds []byte) []byte {
+	half := len(data) / 2
+	l := append([]byte(nil), data[:half]...)
+	r := append([]byte(nil), data[half:]...)
+	var salt []byte
+	if !extendable {
+		salt = binary.BigEndian.AppendUint16([]byte("shamir"), id)
+	}
+	for _, i := range rounds {
+		f := pbkdf2.Key(append([]byte{i}, passphrase...), append(append([]byte(nil), salt...), r...), baseIterations<<e, half, sha256.New)
+		for k := range f {
+			f[k] ^= l[k]
+		}
+		l, r = r, f
+	}
+	return append(r, l...)
+}
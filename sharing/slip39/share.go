@@ -0,0 +1,185 @@
+package slip39
+
+import (
+	_ "embed"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const (
+	radixBits = 10
+	radix     = 1 << radixBits
+	// the identifier, extendable flag and iteration exponent, then the
+	// group and member parameters, each two words
+	headerWords   = 4
+	checksumWords = 3
+	// MinMnemonicWords is the length of the mnemonic of a 128 bit secret
+	MinMnemonicWords = headerWords + 13 + checksumWords
+)
+
+//go:embed wordlists/english.txt
+var wordlistText string
+
+var (
+	wordlist  = strings.Fields(wordlistText)
+	wordIndex = func() map[string]int {
+		index := make(map[string]int, len(wordlist))
+		for i, w := range wordlist {
+			index[w] = i
+		}
+		return index
+	}()
+)
+
+// Share is a decoded SLIP-0039 share. Thresholds and counts are the actual
+// values, not the values minus one of the encoding.
+type Share struct {
+	Identifier        uint16
+	Extendable        bool
+	IterationExponent int
+	GroupIndex        int
+	GroupThreshold    int
+	GroupCount        int
+	MemberIndex       int
+	MemberThreshold   int
+	Value             []byte
+}
+
+// ParseShare decodes a mnemonic and checks its checksum
+func ParseShare(mnemonic string) (*Share, error) {
+	words := strings.Fields(strings.ToLower(mnemonic))
+	if len(words) < MinMnemonicWords {
+		return nil, fmt.Errorf("slip39: mnemonic must have at least %d words", MinMnemonicWords)
+	}
+	indices := make([]int, len(words))
+	for i, w := range words {
+		index, ok := wordIndex[w]
+		if !ok {
+			return nil, fmt.Errorf("slip39: invalid word %q", w)
+		}
+		indices[i] = index
+	}
+	extendable := indices[1]>>4&1 == 1
+	if rs1024Polymod(customization(extendable), indices) != 1 {
+		return nil, fmt.Errorf("slip39: invalid mnemonic checksum")
+	}
+
+	header := indices[0]<<30 | indices[1]<<20 | indices[2]<<10 | indices[3]
+	s := &Share{
+		Identifier:        uint16(header >> 25),
+		Extendable:        extendable,
+		IterationExponent: header >> 20 & 0x0f,
+		GroupIndex:        header >> 16 & 0x0f,
+		GroupThreshold:    header>>12&0x0f + 1,
+		GroupCount:        header>>8&0x0f + 1,
+		MemberIndex:       header >> 4 & 0x0f,
+		MemberThreshold:   header&0x0f + 1,
+	}
+	if s.GroupThreshold > s.GroupCount {
+		return nil, fmt.Errorf("slip39: group threshold exceeds the group count")
+	}
+
+	valueWords := indices[headerWords : len(indices)-checksumWords]
+	padding := radixBits * len(valueWords) % 16
+	if padding > 8 {
+		return nil, fmt.Errorf("slip39: invalid mnemonic length")
+	}
+	v := new(big.Int)
+	for _, w := range valueWords {
+		v.Lsh(v, radixBits).Or(v, big.NewInt(int64(w)))
+	}
+	n := (radixBits*len(valueWords) - padding) / 8
+	if v.BitLen() > 8*n {
+		return nil, fmt.Errorf("slip39: invalid mnemonic padding")
+	}
+	s.Value = v.FillBytes(make([]byte, n))
+	return s, nil
+}
+
+// Mnemonic encodes the share as words of the SLIP-0039 wordlist
+func (s *Share) Mnemonic() (string, error) {
+	if err := s.validate(); err != nil {
+		return "", err
+	}
+	ext := 0
+	if s.Extendable {
+		ext = 1
+	}
+	header := int(s.Identifier)<<25 | ext<<24 | s.IterationExponent<<20 |
+		s.GroupIndex<<16 | (s.GroupThreshold-1)<<12 | (s.GroupCount-1)<<8 |
+		s.MemberIndex<<4 | (s.MemberThreshold - 1)
+	indices := []int{header >> 30, header >> 20 & (radix - 1), header >> 10 & (radix - 1), header & (radix - 1)}
+
+	count := (8*len(s.Value) + radixBits - 1) / radixBits
+	v := new(big.Int).SetBytes(s.Value)
+	mask := big.NewInt(radix - 1)
+	for i := count - 1; i >= 0; i-- {
+		w := new(big.Int).Rsh(v, uint(radixBits*i))
+		indices = append(indices, int(w.And(w, mask).Int64()))
+	}
+	indices = append(indices, rs1024Checksum(customization(s.Extendable), indices)...)
+
+	words := make([]string, len(indices))
+	for i, index := range indices {
+		words[i] = wordlist[index]
+	}
+	return strings.Join(words, " "), nil
+}
+
+func (s *Share) validate() error {
+	switch {
+	case s.Identifier >= 1<<15:
+		return fmt.Errorf("slip39: identifier must be 15 bits")
+	case s.IterationExponent < 0 || s.IterationExponent > 15:
+		return fmt.Errorf("slip39: iteration exponent must be 4 bits")
+	case s.GroupCount < 1 || s.GroupCount > MaxShareCount:
+		return fmt.Errorf("slip39: invalid group count %d", s.GroupCount)
+	case s.GroupThreshold < 1 || s.GroupThreshold > s.GroupCount:
+		return fmt.Errorf("slip39: invalid group threshold %d", s.GroupThreshold)
+	case s.GroupIndex < 0 || s.GroupIndex >= s.GroupCount:
+		return fmt.Errorf("slip39: invalid group index %d", s.GroupIndex)
+	case s.MemberThreshold < 1 || s.MemberThreshold > MaxShareCount:
+		return fmt.Errorf("slip39: invalid member threshold %d", s.MemberThreshold)
+	case s.MemberIndex < 0 || s.MemberIndex >= MaxShareCount:
+		return fmt.Errorf("slip39: invalid member index %d", s.MemberIndex)
+	case len(s.Value) < MinSecretSize || len(s.Value)%2 != 0:
+		return fmt.Errorf("slip39: invalid share value length %d", len(s.Value))
+	}
+	return nil
+}
+
+func customization(extendable bool) string {
+	if extendable {
+		return "shamir_extendable"
+	}
+	return "shamir"
+}
+
+// rs1024Polymod is the checksum of SLIP-0039, a Reed-Solomon code over
+// GF(1024)
+func rs1024Polymod(cs string, values []int) int {
+	gen := [...]int{0xe0e040, 0x1c1c080, 0x3838100, 0x7070200, 0xe0e0009, 0x1c0c2412, 0x38086c24, 0x3090fc48, 0x21b1f890, 0x3f3f120}
+	chk := 1
+	step := func(v int) {
+		b := chk >> 20
+		chk = (chk&0xfffff)<<10 ^ v
+		for i := 0; i < 10; i++ {
+			if b>>i&1 == 1 {
+				chk ^= gen[i]
+			}
+		}
+	}
+	for _, c := range []byte(cs) {
+		step(int(c))
+	}
+	for _, v := range values {
+		step(v)
+	}
+	return chk
+}
+
+func rs1024Checksum(cs string, values []int) []int {
+	polymod := rs1024Polymod(cs, append(append([]int(nil), values...), 0, 0, 0)) ^ 1
+	return []int{polymod >> 20 & (radix - 1), polymod >> 10 & (radix - 1), polymod & (radix - 1)}
+}
@@ -0,0 +1,212 @@
+// Package slip39 implements SLIP-0039 Shamir backups of master secrets,
+// https://github.com/satoshilabs/slips/blob/master/slip-0039.md
+//
+// The master secret is encrypted with a passphrase and split in two levels:
+// into groups, a threshold of which is needed, and each group into member
+// shares, a threshold of which recovers the group's share. Shares are
+// written as mnemonics of the 1024 word SLIP-0039 wordlist with an RS1024
+// checksum, and the sharing is over GF(256) with the sharing/gf256 field.
+// Any passphrase decrypts a valid set of shares, to a different secret, so
+// a wrong passphrase cannot be detected.
+package slip39
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/sharing/gf256"
+)
+
+const (
+	// MaxShareCount is the largest number of groups and of members of a group
+	MaxShareCount = 16
+	// MinSecretSize is the size of the smallest master secret
+	MinSecretSize = 16
+)
+
+// Group is the member threshold and member count of a group
+type Group struct {
+	Threshold int
+	Count     int
+}
+
+// GenerateMnemonics splits masterSecret, encrypted with passphrase, into
+// groups of mnemonics, groupThreshold of which must each provide the
+// threshold of their members. The encryption takes 10000·2^e PBKDF2
+// iterations for the iteration exponent e. Extendable backups can be
+// extended with more groups later under the same identifier.
+func GenerateMnemonics(groupThreshold int, groups []Group, masterSecret, passphrase []byte, extendable bool, iterationExponent int, reader io.Reader) ([][]string, error) {
+	if reader == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if len(masterSecret) < MinSecretSize || len(masterSecret)%2 != 0 {
+		return nil, fmt.Errorf("slip39: master secret must be an even number of at least %d bytes", MinSecretSize)
+	}
+	if err := checkPassphrase(passphrase); err != nil {
+		return nil, err
+	}
+	if iterationExponent < 0 || iterationExponent > 15 {
+		return nil, fmt.Errorf("slip39: iteration exponent must be 4 bits")
+	}
+	if len(groups) == 0 || len(groups) > MaxShareCount {
+		return nil, fmt.Errorf("slip39: invalid number of groups %d", len(groups))
+	}
+	if groupThreshold < 1 || groupThreshold > len(groups) {
+		return nil, fmt.Errorf("slip39: invalid group threshold %d", groupThreshold)
+	}
+	for i, g := range groups {
+		if g.Threshold < 1 || g.Threshold > g.Count || g.Count > MaxShareCount {
+			return nil, fmt.Errorf("slip39: invalid threshold %d of %d of group %d", g.Threshold, g.Count, i)
+		}
+		if g.Threshold == 1 && g.Count > 1 {
+			return nil, fmt.Errorf("slip39: group %d with threshold 1 must have a single member", i)
+		}
+	}
+
+	var id [2]byte
+	if _, err := io.ReadFull(reader, id[:]); err != nil {
+		return nil, err
+	}
+	identifier := binary.BigEndian.Uint16(id[:]) & (1<<15 - 1)
+	encrypted := encrypt(masterSecret, passphrase, iterationExponent, identifier, extendable)
+
+	groupShares, err := gf256.SplitSecret(groupThreshold, len(groups), encrypted, reader)
+	if err != nil {
+		return nil, err
+	}
+	mnemonics := make([][]string, len(groups))
+	for i, g := range groups {
+		memberShares, err := gf256.SplitSecret(g.Threshold, g.Count, groupShares[i], reader)
+		if err != nil {
+			return nil, err
+		}
+		for j, value := range memberShares {
+			share := &Share{
+				Identifier:        identifier,
+				Extendable:        extendable,
+				IterationExponent: iterationExponent,
+				GroupIndex:        i,
+				GroupThreshold:    groupThreshold,
+				GroupCount:        len(groups),
+				MemberIndex:       j,
+				MemberThreshold:   g.Threshold,
+				Value:             value,
+			}
+			m, err := share.Mnemonic()
+			if err != nil {
+				return nil, err
+			}
+			mnemonics[i] = append(mnemonics[i], m)
+		}
+	}
+	return mnemonics, nil
+}
+
+// Combine recovers the master secret from mnemonics covering the group
+// threshold, and decrypts it with passphrase
+func Combine(mnemonics []string, passphrase []byte) ([]byte, error) {
+	if err := checkPassphrase(passphrase); err != nil {
+		return nil, err
+	}
+	shares := make([]*Share, len(mnemonics))
+	for i, m := range mnemonics {
+		share, err := ParseShare(m)
+		if err != nil {
+			return nil, err
+		}
+		shares[i] = share
+	}
+	return CombineShares(shares, passphrase)
+}
+
+// CombineShares recovers the master secret from decoded shares
+func CombineShares(shares []*Share, passphrase []byte) ([]byte, error) {
+	if len(shares) == 0 {
+		return nil, fmt.Errorf("slip39: no shares")
+	}
+	if err := checkPassphrase(passphrase); err != nil {
+		return nil, err
+	}
+	first := shares[0]
+	if first == nil {
+		return nil, internal.ErrNilArguments
+	}
+	// the member shares of each group, by member index
+	groups := make(map[int]map[int]*Share)
+	for _, s := range shares {
+		if s == nil {
+			return nil, internal.ErrNilArguments
+		}
+		if s.Identifier != first.Identifier || s.Extendable != first.Extendable || s.IterationExponent != first.IterationExponent {
+			return nil, fmt.Errorf("slip39: shares are not from the same backup")
+		}
+		if s.GroupThreshold != first.GroupThreshold || s.GroupCount != first.GroupCount || len(s.Value) != len(first.Value) {
+			return nil, fmt.Errorf("slip39: shares have inconsistent parameters")
+		}
+		if err := s.validate(); err != nil {
+			return nil, err
+		}
+		members, ok := groups[s.GroupIndex]
+		if !ok {
+			members = make(map[int]*Share)
+			groups[s.GroupIndex] = members
+		}
+		for _, m := range members {
+			if m.MemberThreshold != s.MemberThreshold {
+				return nil, fmt.Errorf("slip39: shares of group %d have different thresholds", s.GroupIndex)
+			}
+		}
+		if m, ok := members[s.MemberIndex]; ok && !bytes.Equal(m.Value, s.Value) {
+			return nil, fmt.Errorf("slip39: different shares with member index %d in group %d", s.MemberIndex, s.GroupIndex)
+		}
+		members[s.MemberIndex] = s
+	}
+
+	var xs []byte
+	var ys [][]byte
+	for index := 0; index < first.GroupCount && len(xs) < first.GroupThreshold; index++ {
+		members := groups[index]
+		if len(members) == 0 {
+			continue
+		}
+		var threshold int
+		var mxs []byte
+		var mys [][]byte
+		for i := 0; i < MaxShareCount; i++ {
+			if m, ok := members[i]; ok {
+				threshold = m.MemberThreshold
+				mxs, mys = append(mxs, byte(i)), append(mys, m.Value)
+			}
+		}
+		if len(mxs) < threshold {
+			continue
+		}
+		value, err := gf256.RecoverSecret(threshold, mxs[:threshold], mys[:threshold])
+		if err != nil {
+			return nil, fmt.Errorf("slip39: group %d: %w", index, err)
+		}
+		xs, ys = append(xs, byte(index)), append(ys, value)
+	}
+	if len(xs) < first.GroupThreshold {
+		return nil, fmt.Errorf("slip39: %d complete groups, %d needed", len(xs), first.GroupThreshold)
+	}
+	encrypted, err := gf256.RecoverSecret(first.GroupThreshold, xs, ys)
+	if err != nil {
+		return nil, err
+	}
+	return decrypt(encrypted, passphrase, first.IterationExponent, first.Identifier, first.Extendable), nil
+}
+
+// checkPassphrase checks the passphrase is printable ASCII, as SLIP-0039
+// requires
+func checkPassphrase(passphrase []byte) error {
+	for _, c := range passphrase {
+		if c < 32 || c > 126 {
+			return fmt.Errorf("slip39: passphrase must be printable ASCII")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package slip39
+
+import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const vector1 = "duckling enlarge academic academic agency result length solution fridge kidney coal piece deal husband erode duke ajar critical decision keyboard"
+
+func TestVector(t *testing.T) {
+	secret, err := Combine([]string{vector1}, []byte("TREZOR"))
+	require.NoError(t, err)
+	require.Equal(t, "bb54aac4b89dc868ba37d9cc21b2cece", hex.EncodeToString(secret))
+
+	share, err := ParseShare(vector1)
+	require.NoError(t, err)
+	m, err := share.Mnemonic()
+	require.NoError(t, err)
+	require.Equal(t, vector1, m)
+
+	// the last word changed breaks the checksum
+	_, err = Combine([]string{vector1[:len(vector1)-len("keyboard")] + "kidney"}, []byte("TREZOR"))
+	require.Error(t, err)
+}
+
+func TestWordlist(t *testing.T) {
+	require.Len(t, wordlist, radix)
+	require.Len(t, wordIndex, radix)
+}
+
+func TestRoundTrip(t *testing.T) {
+	secret := make([]byte, 32)
+	_, err := crand.Read(secret)
+	require.NoError(t, err)
+	groups := []Group{{1, 1}, {2, 3}, {3, 5}}
+	for _, extendable := range []bool{false, true} {
+		mnemonics, err := GenerateMnemonics(2, groups, secret, []byte("pass"), extendable, 0, crand.Reader)
+		require.NoError(t, err)
+		require.Len(t, mnemonics, 3)
+		require.Len(t, mnemonics[2], 5)
+
+		got, err := Combine([]string{mnemonics[0][0], mnemonics[2][4], mnemonics[2][0], mnemonics[2][2]}, []byte("pass"))
+		require.NoError(t, err)
+		require.Equal(t, secret, got)
+
+		got, err = Combine([]string{mnemonics[1][2], mnemonics[2][1], mnemonics[1][0], mnemonics[2][3], mnemonics[2][4]}, []byte("pass"))
+		require.NoError(t, err)
+		require.Equal(t, secret, got)
+
+		// another passphrase decrypts to another secret
+		got, err = Combine([]string{mnemonics[0][0], mnemonics[1][0], mnemonics[1][1]}, []byte("other"))
+		require.NoError(t, err)
+		require.NotEqual(t, secret, got)
+
+		// too few groups or members
+		_, err = Combine([]string{mnemonics[0][0], mnemonics[1][0]}, []byte("pass"))
+		require.Error(t, err)
+		_, err = Combine([]string{mnemonics[2][0], mnemonics[2][1], mnemonics[2][2]}, []byte("pass"))
+		require.Error(t, err)
+	}
+}
+
+func TestCombineRejectsMixedShares(t *testing.T) {
+	secret := make([]byte, 16)
+	a, err := GenerateMnemonics(1, []Group{{2, 3}}, secret, nil, false, 0, crand.Reader)
+	require.NoError(t, err)
+	b, err := GenerateMnemonics(1, []Group{{2, 3}}, secret, nil, false, 0, crand.Reader)
+	require.NoError(t, err)
+	_, err = Combine([]string{a[0][0], b[0][1]}, nil)
+	require.Error(t, err)
+
+	// a share of the same backup with a tampered value fails the digest
+	share, err := ParseShare(a[0][1])
+	require.NoError(t, err)
+	share.Value[0] ^= 1
+	first, err := ParseShare(a[0][0])
+	require.NoError(t, err)
+	_, err = CombineShares([]*Share{first, share}, nil)
+	require.Error(t, err)
+}
+
+func TestGenerateRejectsBadParameters(t *testing.T) {
+	secret := make([]byte, 16)
+	_, err := GenerateMnemonics(1, []Group{{1, 2}}, secret, nil, false, 0, crand.Reader)
+	require.Error(t, err)
+	_, err = GenerateMnemonics(2, []Group{{1, 1}}, secret, nil, false, 0, crand.Reader)
+	require.Error(t, err)
+	_, err = GenerateMnemonics(1, []Group{{2, 17}}, secret, nil, false, 0, crand.Reader)
+	require.Error(t, err)
+	_, err = GenerateMnemonics(1, []Group{{1, 1}}, secret[:15], nil, false, 0, crand.Reader)
+	require.Error(t, err)
+	_, err = GenerateMnemonics(1, []Group{{1, 1}}, secret, []byte("naïve"), false, 0, crand.Reader)
+	require.Error(t, err)
+	_, err = GenerateMnemonics(1, []Group{{1, 1}}, secret, nil, false, 16, crand.Reader)
+	require.Error(t, err)
+}
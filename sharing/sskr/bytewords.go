@@ -0,0 +1,94 @@
+package sskr
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"strings"
+)
+
+// bytewordsText is the wordlist of Blockchain Commons bytewords, a word of
+// four letters for each byte whose first and last letters are unique
+const bytewordsText = "" +
+	"able acid also apex aqua arch atom aunt away axis back bald barn belt beta bias " +
+	"blue body brag brew bulb buzz calm cash cats chef city claw code cola cook cost " +
+	"crux curl cusp cyan dark data days deli dice diet door down draw drop drum dull " +
+	"duty each easy echo edge epic even exam exit eyes fact fair fern figs film fish " +
+	"fizz flap flew flux foxy free frog fuel fund gala game gear gems gift girl glow " +
+	"good gray grim guru gush gyro half hang hard hawk heat help high hill holy hope " +
+	"horn huts iced idea idle inch inky into iris iron item jade jazz join jolt jowl " +
+	"judo jugs jump junk jury keep keno kept keys kick kiln king kite kiwi knob lamb " +
+	"lava lazy leaf legs liar limp lion list logo loud love luau luck lung main many " +
+	"math maze memo menu meow mild mint miss monk nail navy need news next noon note " +
+	"numb obey oboe omit onyx open oval owls paid part peck play plus poem pool pose " +
+	"puff puma purr quad quiz race ramp real redo rich road rock roof ruby ruin runs " +
+	"rust safe saga scar sets silk skew slot soap solo song stub surf swan taco task " +
+	"taxi tent tied time tiny toil tomb toys trip tuna twin ugly undo unit urge user " +
+	"vast very veto vial vibe view visa void vows wall wand warm wasp wave waxy webs " +
+	"what when whiz wolf work yank yawn yell yoga yurt zaps zero zest zinc zone zoom"
+
+var (
+	bytewords     = strings.Fields(bytewordsText)
+	bytewordIndex = func() map[string]byte {
+		index := make(map[string]byte, 2*len(bytewords))
+		for i, w := range bytewords {
+			index[w] = byte(i)
+			index[w[:1]+w[3:]] = byte(i)
+		}
+		return index
+	}()
+)
+
+// encodeBytewords writes data and its big endian CRC-32 as bytewords
+// joined by sep, or as their first and last letters if minimal
+func encodeBytewords(data []byte, sep string, minimal bool) string {
+	data = binary.BigEndian.AppendUint32(append([]byte(nil), data...), crc32.ChecksumIEEE(data))
+	words := make([]string, len(data))
+	for i, b := range data {
+		words[i] = bytewords[b]
+		if minimal {
+			words[i] = words[i][:1] + words[i][3:]
+		}
+	}
+	return strings.Join(words, sep)
+}
+
+// decodeBytewords inverts encodeBytewords, in any case, and checks the
+// checksum
+func decodeBytewords(s, sep string, minimal bool) ([]byte, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	var words []string
+	switch {
+	case minimal:
+		if len(s)%2 != 0 {
+			return nil, fmt.Errorf("sskr: invalid bytewords length")
+		}
+		for i := 0; i < len(s); i += 2 {
+			words = append(words, s[i:i+2])
+		}
+	case sep == " ":
+		words = strings.Fields(s)
+	default:
+		words = strings.Split(s, sep)
+	}
+	if len(words) < 5 {
+		return nil, fmt.Errorf("sskr: bytewords too short")
+	}
+	size := 4
+	if minimal {
+		size = 2
+	}
+	data := make([]byte, len(words))
+	for i, w := range words {
+		b, ok := bytewordIndex[w]
+		if !ok || len(w) != size {
+			return nil, fmt.Errorf("sskr: invalid byteword %q", w)
+		}
+		data[i] = b
+	}
+	data, checksum := data[:len(data)-4], data[len(data)-4:]
+	if binary.BigEndian.Uint32(checksum) != crc32.ChecksumIEEE(data) {
+		return nil, fmt.Errorf("sskr: invalid bytewords checksum")
+	}
+	return data, nil
+}
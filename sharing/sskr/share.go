@@ -0,0 +1,179 @@
+package sskr
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+const (
+	metadataSize = 5
+	// cborTag is the CBOR tag of SSKR shares, and legacyCBORTag its first
+	// version still written by older Gordian tools
+	cborTag       = 40309
+	legacyCBORTag = 309
+	urPrefix      = "ur:sskr/"
+)
+
+// Share is a decoded SSKR share. Thresholds and counts are the actual
+// values, not the values minus one of the encoding.
+type Share struct {
+	Identifier      uint16
+	GroupIndex      int
+	GroupThreshold  int
+	GroupCount      int
+	MemberIndex     int
+	MemberThreshold int
+	Value           []byte
+}
+
+// MarshalBinary encodes the share as its five bytes of metadata followed by
+// the share value
+func (s *Share) MarshalBinary() ([]byte, error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	data := binary.BigEndian.AppendUint16(make([]byte, 0, metadataSize+len(s.Value)), s.Identifier)
+	data = append(data,
+		byte((s.GroupThreshold-1)<<4|(s.GroupCount-1)),
+		byte(s.GroupIndex<<4|(s.MemberThreshold-1)),
+		byte(s.MemberIndex),
+	)
+	return append(data, s.Value...), nil
+}
+
+// UnmarshalBinary decodes a share of MarshalBinary
+func (s *Share) UnmarshalBinary(data []byte) error {
+	if len(data) < metadataSize {
+		return fmt.Errorf("sskr: share too short")
+	}
+	if data[4]>>4 != 0 {
+		return fmt.Errorf("sskr: reserved bits must be zero")
+	}
+	share := Share{
+		Identifier:      binary.BigEndian.Uint16(data),
+		GroupThreshold:  int(data[2]>>4) + 1,
+		GroupCount:      int(data[2]&0x0f) + 1,
+		GroupIndex:      int(data[3] >> 4),
+		MemberThreshold: int(data[3]&0x0f) + 1,
+		MemberIndex:     int(data[4]),
+		Value:           append([]byte(nil), data[metadataSize:]...),
+	}
+	if err := share.validate(); err != nil {
+		return err
+	}
+	*s = share
+	return nil
+}
+
+// ParseShare decodes a share of MarshalBinary
+func ParseShare(data []byte) (*Share, error) {
+	s := new(Share)
+	if err := s.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Bytewords encodes the share as the tagged CBOR byte string of SSKR in
+// bytewords separated by spaces, as Gordian Seed Tool prints and reads
+// shares
+func (s *Share) Bytewords() (string, error) {
+	data, err := s.MarshalBinary()
+	if err != nil {
+		return "", err
+	}
+	tagged := []byte{0xd9, cborTag >> 8, cborTag & 0xff}
+	return encodeBytewords(append(tagged, cborBytes(data)...), " ", false), nil
+}
+
+// ParseBytewords decodes a share of Bytewords, also accepting the legacy
+// CBOR tag
+func ParseBytewords(s string) (*Share, error) {
+	data, err := decodeBytewords(s, " ", false)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 3 || data[0] != 0xd9 {
+		return nil, fmt.Errorf("sskr: share is not tagged")
+	}
+	if tag := binary.BigEndian.Uint16(data[1:]); tag != cborTag && tag != legacyCBORTag {
+		return nil, fmt.Errorf("sskr: invalid CBOR tag %d", tag)
+	}
+	value, err := parseCBORBytes(data[3:])
+	if err != nil {
+		return nil, err
+	}
+	return ParseShare(value)
+}
+
+// UR encodes the share as a single part uniform resource "ur:sskr/..." of
+// minimal bytewords, for QR codes. Upper case it for the QR alphanumeric
+// mode.
+func (s *Share) UR() (string, error) {
+	data, err := s.MarshalBinary()
+	if err != nil {
+		return "", err
+	}
+	return urPrefix + encodeBytewords(cborBytes(data), "", true), nil
+}
+
+// ParseUR decodes a share of UR in either case
+func ParseUR(ur string) (*Share, error) {
+	ur = strings.ToLower(strings.TrimSpace(ur))
+	if !strings.HasPrefix(ur, urPrefix) {
+		return nil, fmt.Errorf("sskr: not an sskr uniform resource")
+	}
+	data, err := decodeBytewords(ur[len(urPrefix):], "", true)
+	if err != nil {
+		return nil, err
+	}
+	value, err := parseCBORBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	return ParseShare(value)
+}
+
+func (s *Share) validate() error {
+	switch {
+	case s.GroupCount < 1 || s.GroupCount > MaxShareCount:
+		return fmt.Errorf("sskr: invalid group count %d", s.GroupCount)
+	case s.GroupThreshold < 1 || s.GroupThreshold > s.GroupCount:
+		return fmt.Errorf("sskr: invalid group threshold %d", s.GroupThreshold)
+	case s.GroupIndex < 0 || s.GroupIndex >= s.GroupCount:
+		return fmt.Errorf("sskr: invalid group index %d", s.GroupIndex)
+	case s.MemberThreshold < 1 || s.MemberThreshold > MaxShareCount:
+		return fmt.Errorf("sskr: invalid member threshold %d", s.MemberThreshold)
+	case s.MemberIndex < 0 || s.MemberIndex >= MaxShareCount:
+		return fmt.Errorf("sskr: invalid member index %d", s.MemberIndex)
+	case len(s.Value) < MinSecretSize || len(s.Value) > MaxSecretSize || len(s.Value)%2 != 0:
+		return fmt.Errorf("sskr: invalid share value length %d", len(s.Value))
+	}
+	return nil
+}
+
+// cborBytes encodes data as a CBOR byte string, which for shares is always
+// shorter than 256 bytes
+func cborBytes(data []byte) []byte {
+	if len(data) < 24 {
+		return append([]byte{0x40 | byte(len(data))}, data...)
+	}
+	return append([]byte{0x58, byte(len(data))}, data...)
+}
+
+func parseCBORBytes(data []byte) ([]byte, error) {
+	var n, header int
+	switch {
+	case len(data) >= 1 && data[0]&0xe0 == 0x40 && data[0] < 0x58:
+		n, header = int(data[0]&0x1f), 1
+	case len(data) >= 2 && data[0] == 0x58 && data[1] >= 24:
+		n, header = int(data[1]), 2
+	default:
+		return nil, fmt.Errorf("sskr: share is not a CBOR byte string")
+	}
+	if len(data) != header+n {
+		return nil, fmt.Errorf("sskr: invalid CBOR byte string length")
+	}
+	return data[header:], nil
+}
@@ -0,0 +1,158 @@
+// Package sskr implements Sharded Secret Key Reconstruction of Blockchain
+// Commons, https://github.com/BlockchainCommons/Research/blob/master/papers/bcr-2020-011-sskr.md
+//
+// SSKR is the two level sharing of SLIP-0039 over the sharing/gf256 field
+// without its encryption: the secret is split into groups, a threshold of
+// which is needed, and each group into member shares, a threshold of which
+// recovers the group's share. Shares are exported as bytewords or as
+// "ur:sskr" uniform resources for QR codes, so they can be restored with
+// Gordian Seed Tool and other SSKR wallets. Secrets are 16 to 32 bytes, such
+// as BIP-39 entropy or the seed of a key; use package slip39 to back the
+// same secrets up as SLIP-0039 mnemonics.
+package sskr
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/sharing/gf256"
+)
+
+const (
+	// MaxShareCount is the largest number of groups and of members of a group
+	MaxShareCount = 16
+	// MinSecretSize is the size of the smallest secret
+	MinSecretSize = 16
+	// MaxSecretSize is the size of the largest secret
+	MaxSecretSize = 32
+)
+
+// Group is the member threshold and member count of a group
+type Group struct {
+	Threshold int
+	Count     int
+}
+
+// Generate splits secret into groups of shares, groupThreshold of which
+// must each provide the threshold of their members
+func Generate(groupThreshold int, groups []Group, secret []byte, reader io.Reader) ([][]*Share, error) {
+	if reader == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if len(secret) < MinSecretSize || len(secret) > MaxSecretSize || len(secret)%2 != 0 {
+		return nil, fmt.Errorf("sskr: secret must be an even number of %d to %d bytes", MinSecretSize, MaxSecretSize)
+	}
+	if len(groups) == 0 || len(groups) > MaxShareCount {
+		return nil, fmt.Errorf("sskr: invalid number of groups %d", len(groups))
+	}
+	if groupThreshold < 1 || groupThreshold > len(groups) {
+		return nil, fmt.Errorf("sskr: invalid group threshold %d", groupThreshold)
+	}
+	for i, g := range groups {
+		if g.Threshold < 1 || g.Threshold > g.Count || g.Count > MaxShareCount {
+			return nil, fmt.Errorf("sskr: invalid threshold %d of %d of group %d", g.Threshold, g.Count, i)
+		}
+	}
+
+	var id [2]byte
+	if _, err := io.ReadFull(reader, id[:]); err != nil {
+		return nil, err
+	}
+	groupShares, err := gf256.SplitSecret(groupThreshold, len(groups), secret, reader)
+	if err != nil {
+		return nil, err
+	}
+	shares := make([][]*Share, len(groups))
+	for i, g := range groups {
+		memberShares, err := gf256.SplitSecret(g.Threshold, g.Count, groupShares[i], reader)
+		if err != nil {
+			return nil, err
+		}
+		for j, value := range memberShares {
+			shares[i] = append(shares[i], &Share{
+				Identifier:      binary.BigEndian.Uint16(id[:]),
+				GroupIndex:      i,
+				GroupThreshold:  groupThreshold,
+				GroupCount:      len(groups),
+				MemberIndex:     j,
+				MemberThreshold: g.Threshold,
+				Value:           value,
+			})
+		}
+	}
+	return shares, nil
+}
+
+// Combine recovers the secret from shares covering the group threshold
+func Combine(shares []*Share) ([]byte, error) {
+	if len(shares) == 0 {
+		return nil, fmt.Errorf("sskr: no shares")
+	}
+	first := shares[0]
+	if first == nil {
+		return nil, internal.ErrNilArguments
+	}
+	// the member shares of each group, by member index
+	groups := make(map[int]map[int]*Share)
+	for _, s := range shares {
+		if s == nil {
+			return nil, internal.ErrNilArguments
+		}
+		if s.Identifier != first.Identifier {
+			return nil, fmt.Errorf("sskr: shares are not from the same split")
+		}
+		if s.GroupThreshold != first.GroupThreshold || s.GroupCount != first.GroupCount || len(s.Value) != len(first.Value) {
+			return nil, fmt.Errorf("sskr: shares have inconsistent parameters")
+		}
+		if err := s.validate(); err != nil {
+			return nil, err
+		}
+		members, ok := groups[s.GroupIndex]
+		if !ok {
+			members = make(map[int]*Share)
+			groups[s.GroupIndex] = members
+		}
+		for _, m := range members {
+			if m.MemberThreshold != s.MemberThreshold {
+				return nil, fmt.Errorf("sskr: shares of group %d have different thresholds", s.GroupIndex)
+			}
+		}
+		if m, ok := members[s.MemberIndex]; ok && !bytes.Equal(m.Value, s.Value) {
+			return nil, fmt.Errorf("sskr: different shares with member index %d in group %d", s.MemberIndex, s.GroupIndex)
+		}
+		members[s.MemberIndex] = s
+	}
+
+	var xs []byte
+	var ys [][]byte
+	for index := 0; index < first.GroupCount && len(xs) < first.GroupThreshold; index++ {
+		members := groups[index]
+		if len(members) == 0 {
+			continue
+		}
+		var threshold int
+		var mxs []byte
+		var mys [][]byte
+		for i := 0; i < MaxShareCount; i++ {
+			if m, ok := members[i]; ok {
+				threshold = m.MemberThreshold
+				mxs, mys = append(mxs, byte(i)), append(mys, m.Value)
+			}
+		}
+		if len(mxs) < threshold {
+			continue
+		}
+		value, err := gf256.RecoverSecret(threshold, mxs[:threshold], mys[:threshold])
+		if err != nil {
+			return nil, fmt.Errorf("sskr: group %d: %w", index, err)
+		}
+		xs, ys = append(xs, byte(index)), append(ys, value)
+	}
+	if len(xs) < first.GroupThreshold {
+		return nil, fmt.Errorf("sskr: %d complete groups, %d needed", len(xs), first.GroupThreshold)
+	}
+	return gf256.RecoverSecret(first.GroupThreshold, xs, ys)
+}
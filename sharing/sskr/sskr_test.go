@@ -0,0 +1,105 @@
+package sskr
+
+import (
+	crand "crypto/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Test vector of https://github.com/BlockchainCommons/bc-ur
+func TestBytewords(t *testing.T) {
+	data := []byte{0, 1, 2, 128, 255}
+	require.Equal(t, "able acid also lava zoom jade need echo taxi", encodeBytewords(data, " ", false))
+	require.Equal(t, "able-acid-also-lava-zoom-jade-need-echo-taxi", encodeBytewords(data, "-", false))
+	require.Equal(t, "aeadaolazmjendeoti", encodeBytewords(data, "", true))
+
+	got, err := decodeBytewords("ABLE ACID ALSO LAVA ZOOM JADE NEED ECHO TAXI", " ", false)
+	require.NoError(t, err)
+	require.Equal(t, data, got)
+	got, err = decodeBytewords("aeadaolazmjendeoti", "", true)
+	require.NoError(t, err)
+	require.Equal(t, data, got)
+
+	_, err = decodeBytewords("able acid also lava zoom jade need echo taco", " ", false)
+	require.Error(t, err)
+	_, err = decodeBytewords("aeadaolazmjendeo", "", true)
+	require.Error(t, err)
+}
+
+func TestGenerateCombine(t *testing.T) {
+	secret := []byte("0123456789abcdef")
+	groups := []Group{{Threshold: 2, Count: 3}, {Threshold: 1, Count: 2}, {Threshold: 3, Count: 5}}
+	shares, err := Generate(2, groups, secret, crand.Reader)
+	require.NoError(t, err)
+	require.Len(t, shares, 3)
+	for i, g := range groups {
+		require.Len(t, shares[i], g.Count)
+	}
+
+	got, err := Combine([]*Share{shares[0][2], shares[0][0], shares[1][1]})
+	require.NoError(t, err)
+	require.Equal(t, secret, got)
+	got, err = Combine([]*Share{shares[2][4], shares[2][1], shares[0][1], shares[2][3], shares[0][2]})
+	require.NoError(t, err)
+	require.Equal(t, secret, got)
+
+	_, err = Combine([]*Share{shares[0][2], shares[1][1]})
+	require.Error(t, err)
+	_, err = Combine([]*Share{shares[1][0], shares[2][0], shares[2][1]})
+	require.Error(t, err)
+
+	other, err := Generate(2, groups, secret, crand.Reader)
+	require.NoError(t, err)
+	_, err = Combine([]*Share{shares[0][0], shares[0][1], other[1][0]})
+	require.Error(t, err)
+
+	_, err = Generate(2, groups, secret[:15], crand.Reader)
+	require.Error(t, err)
+	_, err = Generate(4, groups, secret, crand.Reader)
+	require.Error(t, err)
+	_, err = Generate(1, []Group{{Threshold: 3, Count: 2}}, secret, crand.Reader)
+	require.Error(t, err)
+}
+
+func TestEncodings(t *testing.T) {
+	secret := make([]byte, MaxSecretSize)
+	_, err := crand.Read(secret)
+	require.NoError(t, err)
+	shares, err := Generate(1, []Group{{Threshold: 2, Count: 3}}, secret, crand.Reader)
+	require.NoError(t, err)
+
+	words, err := shares[0][0].Bytewords()
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(words, "tuna next keep"))
+	first, err := ParseBytewords(words)
+	require.NoError(t, err)
+	require.Equal(t, shares[0][0], first)
+
+	ur, err := shares[0][2].UR()
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(ur, "ur:sskr/"))
+	third, err := ParseUR(strings.ToUpper(ur))
+	require.NoError(t, err)
+	require.Equal(t, shares[0][2], third)
+
+	got, err := Combine([]*Share{first, third})
+	require.NoError(t, err)
+	require.Equal(t, secret, got)
+
+	// the first version of the CBOR tag
+	data, err := shares[0][1].MarshalBinary()
+	require.NoError(t, err)
+	legacy := encodeBytewords(append([]byte{0xd9, 0x01, 0x35}, cborBytes(data)...), " ", false)
+	require.True(t, strings.HasPrefix(legacy, "tuna acid epic"))
+	second, err := ParseBytewords(legacy)
+	require.NoError(t, err)
+	require.Equal(t, shares[0][1], second)
+
+	data[4] |= 0x10
+	_, err = ParseShare(data)
+	require.Error(t, err)
+	_, err = ParseUR("ur:seed/" + ur[len("ur:sskr/"):])
+	require.Error(t, err)
+}
@@ -0,0 +1,141 @@
+package sharing
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/go-sonr/crypto/core/curves"
+)
+
+// WeightedShamir is a threshold scheme where every participant holds as many Shamir shares as its weight, so a set
+// of participants can recover the secret when the sum of their weights reaches the threshold.
+type WeightedShamir struct {
+	threshold uint32
+	weights   map[uint32]uint32
+	curve     *curves.Curve
+}
+
+// WeightedShares are the shares of one participant of a weighted sharing.
+type WeightedShares struct {
+	Id     uint32
+	Shares []*ShamirShare
+}
+
+// NewWeightedShamir creates a scheme for participants with the given weights. The total weight is the number of
+// Shamir shares and cannot exceed 255.
+func NewWeightedShamir(threshold uint32, weights map[uint32]uint32, curve *curves.Curve) (*WeightedShamir, error) {
+	if curve == nil {
+		return nil, fmt.Errorf("invalid curve")
+	}
+	if threshold < 2 {
+		return nil, fmt.Errorf("threshold cannot be less than 2")
+	}
+	total := uint32(0)
+	for id, w := range weights {
+		if id == 0 {
+			return nil, fmt.Errorf("invalid identifier")
+		}
+		if w == 0 {
+			return nil, fmt.Errorf("weight of participant %d cannot be 0", id)
+		}
+		total += w
+		if total > 255 {
+			return nil, fmt.Errorf("total weight cannot exceed 255")
+		}
+	}
+	if total < threshold {
+		return nil, fmt.Errorf("total weight cannot be less than threshold")
+	}
+	copied := make(map[uint32]uint32, len(weights))
+	for id, w := range weights {
+		copied[id] = w
+	}
+	return &WeightedShamir{threshold, copied, curve}, nil
+}
+
+// Split splits secret and returns the shares of every participant, by id.
+func (w WeightedShamir) Split(secret curves.Scalar, reader io.Reader) (map[uint32]*WeightedShares, error) {
+	if secret == nil || secret.IsZero() {
+		return nil, fmt.Errorf("invalid secret")
+	}
+	shamir := w.shamir()
+	shares, _ := shamir.getPolyAndShares(secret, reader)
+	out := make(map[uint32]*WeightedShares, len(w.weights))
+	next := 0
+	for _, id := range w.ids() {
+		n := int(w.weights[id])
+		out[id] = &WeightedShares{Id: id, Shares: shares[next : next+n]}
+		next += n
+	}
+	return out, nil
+}
+
+// Authorized returns true if the weights of the participants reach the threshold.
+func (w WeightedShamir) Authorized(ids ...uint32) bool {
+	seen := make(map[uint32]bool, len(ids))
+	total := uint32(0)
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			total += w.weights[id]
+		}
+	}
+	return total >= w.threshold
+}
+
+// Combine recovers the secret from the shares of participants whose weights reach the threshold.
+func (w WeightedShamir) Combine(shares ...*WeightedShares) (curves.Scalar, error) {
+	var all []*ShamirShare
+	seen := make(map[uint32]bool, len(shares))
+	for _, s := range shares {
+		if s == nil {
+			return nil, fmt.Errorf("invalid shares")
+		}
+		if seen[s.Id] {
+			return nil, fmt.Errorf("duplicate participant %d", s.Id)
+		}
+		seen[s.Id] = true
+		if uint32(len(s.Shares)) != w.weights[s.Id] {
+			return nil, fmt.Errorf("participant %d must have %d shares", s.Id, w.weights[s.Id])
+		}
+		first, last := w.shareRange(s.Id)
+		for _, share := range s.Shares {
+			if share == nil || share.Id < first || share.Id > last {
+				return nil, fmt.Errorf("share does not belong to participant %d", s.Id)
+			}
+		}
+		all = append(all, s.Shares...)
+	}
+	shamir := w.shamir()
+	return shamir.Combine(all...)
+}
+
+func (w WeightedShamir) shamir() *Shamir {
+	total := uint32(0)
+	for _, weight := range w.weights {
+		total += weight
+	}
+	return &Shamir{threshold: w.threshold, limit: total, curve: w.curve}
+}
+
+func (w WeightedShamir) ids() []uint32 {
+	ids := make([]uint32, 0, len(w.weights))
+	for id := range w.weights {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// shareRange returns the first and last Shamir share identifiers of participant id.
+func (w WeightedShamir) shareRange(id uint32) (uint32, uint32) {
+	next := uint32(1)
+	for _, i := range w.ids() {
+		if i == id {
+			return next, next + w.weights[i] - 1
+		}
+		next += w.weights[i]
+	}
+	return 0, 0
+}
@@ -0,0 +1,117 @@
+package sharing
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+)
+
+func TestWeightedShamir(t *testing.T) {
+	curve := curves.K256()
+	// 1 has weight 3, 2 and 3 weight 2, 4 weight 1; threshold 4
+	scheme, err := NewWeightedShamir(4, map[uint32]uint32{1: 3, 2: 2, 3: 2, 4: 1}, curve)
+	require.NoError(t, err)
+	secret := curve.Scalar.New(1234)
+	shares, err := scheme.Split(secret, crand.Reader)
+	require.NoError(t, err)
+	require.Len(t, shares[1].Shares, 3)
+	require.Len(t, shares[4].Shares, 1)
+
+	for _, ids := range [][]uint32{{1, 4}, {1, 2}, {2, 3}, {2, 3, 4}, {1, 2, 3, 4}} {
+		require.True(t, scheme.Authorized(ids...))
+		var set []*WeightedShares
+		for _, id := range ids {
+			set = append(set, shares[id])
+		}
+		combined, err := scheme.Combine(set...)
+		require.NoError(t, err)
+		require.Equal(t, secret.Bytes(), combined.Bytes())
+	}
+	for _, ids := range [][]uint32{{1}, {2, 4}, {3, 4}} {
+		require.False(t, scheme.Authorized(ids...))
+		var set []*WeightedShares
+		for _, id := range ids {
+			set = append(set, shares[id])
+		}
+		_, err := scheme.Combine(set...)
+		require.Error(t, err)
+	}
+
+	// The shares of 1 cannot be claimed by 4
+	_, err = scheme.Combine(&WeightedShares{Id: 4, Shares: shares[1].Shares[:1]}, shares[1])
+	require.Error(t, err)
+	_, err = scheme.Combine(shares[1], shares[1])
+	require.Error(t, err)
+}
+
+func TestWeightedShamirInvalidArgs(t *testing.T) {
+	curve := curves.ED25519()
+	_, err := NewWeightedShamir(3, map[uint32]uint32{1: 1, 2: 1}, curve)
+	require.Error(t, err)
+	_, err = NewWeightedShamir(2, map[uint32]uint32{1: 0, 2: 2}, curve)
+	require.Error(t, err)
+	_, err = NewWeightedShamir(2, map[uint32]uint32{1: 200, 2: 100}, curve)
+	require.Error(t, err)
+	_, err = NewWeightedShamir(1, map[uint32]uint32{1: 1, 2: 1}, curve)
+	require.Error(t, err)
+}
+
+func TestAccessStructureHierarchical(t *testing.T) {
+	curve := curves.ED25519()
+	execs := []uint32{1, 2, 3}
+	managers := []uint32{4, 5, 6, 7}
+	// 2 executives OR 1 executive and 3 managers
+	tree := Or(
+		Threshold(2, Participants(execs...)...),
+		And(Threshold(1, Participants(execs...)...), Threshold(3, Participants(managers...)...)),
+	)
+	scheme, err := NewAccessStructure(tree, curve)
+	require.NoError(t, err)
+	secret := curve.Scalar.Hash([]byte("treasury"))
+	shares, err := scheme.Split(secret, crand.Reader)
+	require.NoError(t, err)
+	require.Len(t, shares[1], 2)
+	require.Len(t, shares[4], 1)
+
+	combine := func(ids ...uint32) (curves.Scalar, error) {
+		var set []*TreeShare
+		for _, id := range ids {
+			set = append(set, shares[id]...)
+		}
+		return scheme.Combine(set...)
+	}
+	for _, ids := range [][]uint32{{1, 2}, {2, 3}, {1, 4, 5, 6}, {3, 5, 6, 7}, {1, 2, 3, 4, 5, 6, 7}} {
+		require.True(t, scheme.Authorized(ids...))
+		combined, err := combine(ids...)
+		require.NoError(t, err)
+		require.Equal(t, secret.Bytes(), combined.Bytes())
+	}
+	for _, ids := range [][]uint32{{1}, {4, 5, 6, 7}, {1, 4, 5}} {
+		require.False(t, scheme.Authorized(ids...))
+		_, err := combine(ids...)
+		require.Error(t, err)
+	}
+
+	// A share claimed by the wrong participant
+	forged := *shares[1][0]
+	forged.Id = 4
+	_, err = scheme.Combine(&forged, shares[2][0])
+	require.Error(t, err)
+}
+
+func TestAccessStructureInvalid(t *testing.T) {
+	curve := curves.K256()
+	_, err := NewAccessStructure(nil, curve)
+	require.Error(t, err)
+	_, err = NewAccessStructure(Threshold(3, Participants(1, 2)...), curve)
+	require.Error(t, err)
+	_, err = NewAccessStructure(Threshold(0, Participants(1, 2)...), curve)
+	require.Error(t, err)
+	_, err = NewAccessStructure(Or(Participant(0)), curve)
+	require.Error(t, err)
+	_, err = NewAccessStructure(And(Participants(1, 2)...), nil)
+	require.Error(t, err)
+}
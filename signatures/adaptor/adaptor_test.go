@@ -0,0 +1,125 @@
+package adaptor_test
+
+import (
+	crand "crypto/rand"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/signatures/adaptor"
+	"github.com/go-sonr/crypto/signatures/schnorr/bip340"
+)
+
+func TestSchnorrAdaptor(t *testing.T) {
+	pk, sk, err := bip340.NewKeys()
+	require.NoError(t, err)
+	curve := curves.K256()
+	msg := []byte("atomic swap")
+
+	for i := 0; i < 16; i++ {
+		secret := curve.Scalar.Random(crand.Reader)
+		point := curve.ScalarBaseMult(secret)
+		pre, err := adaptor.SchnorrPreSign(sk, msg, point)
+		require.NoError(t, err)
+		require.NoError(t, adaptor.SchnorrVerify(pk, msg, point, pre))
+
+		sig, err := adaptor.SchnorrAdapt(pre, secret)
+		require.NoError(t, err)
+		require.NoError(t, pk.Verify(msg, sig))
+
+		extracted, err := adaptor.SchnorrExtract(pre, sig, point)
+		require.NoError(t, err)
+		require.Equal(t, secret.Bytes(), extracted.Bytes())
+	}
+}
+
+func TestSchnorrAdaptorInvalid(t *testing.T) {
+	pk, sk, err := bip340.NewKeys()
+	require.NoError(t, err)
+	curve := curves.K256()
+	msg := []byte("atomic swap")
+	secret := curve.Scalar.Random(crand.Reader)
+	point := curve.ScalarBaseMult(secret)
+	pre, err := adaptor.SchnorrPreSign(sk, msg, point)
+	require.NoError(t, err)
+
+	other := curve.ScalarBaseMult(curve.Scalar.Random(crand.Reader))
+	require.Error(t, adaptor.SchnorrVerify(pk, msg, other, pre))
+	require.Error(t, adaptor.SchnorrVerify(pk, []byte("other message"), point, pre))
+	_, err = adaptor.SchnorrPreSign(sk, msg, curves.P256().ScalarBaseMult(secret))
+	require.Error(t, err)
+
+	// The wrong secret does not complete the signature
+	sig, err := adaptor.SchnorrAdapt(pre, secret.Add(curve.Scalar.One()))
+	require.NoError(t, err)
+	require.Error(t, pk.Verify(msg, sig))
+	_, err = adaptor.SchnorrExtract(pre, sig, point)
+	require.Error(t, err)
+}
+
+func TestEcdsaAdaptor(t *testing.T) {
+	for _, curve := range []*curves.Curve{curves.K256(), curves.P256()} {
+		sk := curve.Scalar.Random(crand.Reader)
+		pk := curve.ScalarBaseMult(sk)
+		ecCurve, err := curve.ToEllipticCurve()
+		require.NoError(t, err)
+		ecPk, err := curves.NewScalarBaseMult(ecCurve, sk.BigInt())
+		require.NoError(t, err)
+		digest := sha256.Sum256([]byte("atomic swap"))
+
+		for i := 0; i < 8; i++ {
+			secret := curve.Scalar.Random(crand.Reader)
+			point := curve.ScalarBaseMult(secret)
+			pre, err := adaptor.EcdsaPreSign(sk, digest[:], point)
+			require.NoError(t, err)
+			require.NoError(t, adaptor.EcdsaVerify(pk, digest[:], point, pre))
+
+			sig, err := adaptor.EcdsaAdapt(pre, secret)
+			require.NoError(t, err)
+			require.True(t, curves.VerifyEcdsa(ecPk, digest[:], sig))
+
+			extracted, err := adaptor.EcdsaExtract(pre, sig, point)
+			require.NoError(t, err)
+			require.Equal(t, secret.Bytes(), extracted.Bytes())
+		}
+	}
+}
+
+func TestEcdsaAdaptorInvalid(t *testing.T) {
+	curve := curves.K256()
+	sk := curve.Scalar.Random(crand.Reader)
+	pk := curve.ScalarBaseMult(sk)
+	digest := sha256.Sum256([]byte("atomic swap"))
+	secret := curve.Scalar.Random(crand.Reader)
+	point := curve.ScalarBaseMult(secret)
+	pre, err := adaptor.EcdsaPreSign(sk, digest[:], point)
+	require.NoError(t, err)
+
+	other := curve.ScalarBaseMult(curve.Scalar.Random(crand.Reader))
+	require.Error(t, adaptor.EcdsaVerify(pk, digest[:], other, pre))
+	otherDigest := sha256.Sum256([]byte("other message"))
+	require.Error(t, adaptor.EcdsaVerify(pk, otherDigest[:], point, pre))
+
+	// R must share the nonce of RA
+	forged := *pre
+	forged.R = pre.R.Add(curve.NewGeneratorPoint())
+	require.Error(t, adaptor.EcdsaVerify(pk, digest[:], point, &forged))
+
+	sig, err := adaptor.EcdsaAdapt(pre, secret.Add(curve.Scalar.One()))
+	require.NoError(t, err)
+	_, err = adaptor.EcdsaExtract(pre, sig, point)
+	require.Error(t, err)
+}
+
+func TestDleq(t *testing.T) {
+	curve := curves.P256()
+	x := curve.Scalar.Random(crand.Reader)
+	g := curve.NewGeneratorPoint()
+	h := curve.Point.Random(crand.Reader)
+	proof, err := adaptor.ProveDleq(curve, x, g, h, crand.Reader)
+	require.NoError(t, err)
+	require.NoError(t, adaptor.VerifyDleq(curve, proof, g, h, g.Mul(x), h.Mul(x)))
+	require.Error(t, adaptor.VerifyDleq(curve, proof, g, h, g.Mul(x), h.Mul(x.Add(curve.Scalar.One()))))
+}
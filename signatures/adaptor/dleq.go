@@ -0,0 +1,53 @@
+package adaptor
+
+import (
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/internal"
+)
+
+const dleqDomain = "go-sonr adaptor DLEQ v1"
+
+// DleqProof is a non-interactive Chaum-Pedersen proof that two points have
+// the same discrete logarithm x to the bases G and H, x·G and x·H.
+type DleqProof struct {
+	C curves.Scalar
+	S curves.Scalar
+}
+
+// ProveDleq proves that x·g and x·h share the discrete logarithm x
+func ProveDleq(curve *curves.Curve, x curves.Scalar, g, h curves.Point, reader io.Reader) (*DleqProof, error) {
+	if curve == nil || x == nil || g == nil || h == nil || reader == nil {
+		return nil, internal.ErrNilArguments
+	}
+	k := curve.Scalar.Random(reader)
+	c := dleqChallenge(curve, g, h, g.Mul(x), h.Mul(x), g.Mul(k), h.Mul(k))
+	return &DleqProof{C: c, S: k.Sub(c.Mul(x))}, nil
+}
+
+// VerifyDleq checks that proof shows log_g(a) == log_h(b)
+func VerifyDleq(curve *curves.Curve, proof *DleqProof, g, h, a, b curves.Point) error {
+	if curve == nil || proof == nil || proof.C == nil || proof.S == nil || g == nil || h == nil || a == nil || b == nil {
+		return internal.ErrNilArguments
+	}
+	// k·G = s·G + c·A and k·H = s·H + c·B
+	kg := g.Mul(proof.S).Add(a.Mul(proof.C))
+	kh := h.Mul(proof.S).Add(b.Mul(proof.C))
+	if dleqChallenge(curve, g, h, a, b, kg, kh).Cmp(proof.C) != 0 {
+		return fmt.Errorf("invalid dleq proof")
+	}
+	return nil
+}
+
+func dleqChallenge(curve *curves.Curve, points ...curves.Point) curves.Scalar {
+	h := sha3.New256()
+	_, _ = h.Write([]byte(dleqDomain))
+	for _, p := range points {
+		_, _ = h.Write(p.ToAffineCompressed())
+	}
+	return curve.Scalar.Hash(h.Sum(nil))
+}
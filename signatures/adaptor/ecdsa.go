@@ -0,0 +1,196 @@
+package adaptor
+
+import (
+	crand "crypto/rand"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/internal"
+)
+
+// EcdsaPreSignature is an ECDSA signature encrypted to an adaptor point T.
+// RA = k·G and R = k·T share the nonce k, which the proof shows, and the x
+// coordinate of R is the r of the completed signature.
+type EcdsaPreSignature struct {
+	R     curves.Point
+	RA    curves.Point
+	S     curves.Scalar
+	Proof *DleqProof
+}
+
+// EcdsaPreSign creates a pre-signature over the message digest bound to adaptor
+func EcdsaPreSign(sk curves.Scalar, digest []byte, adaptor curves.Point) (*EcdsaPreSignature, error) {
+	return EcdsaPreSignFromReader(sk, digest, adaptor, crand.Reader)
+}
+
+// EcdsaPreSignFromReader creates a pre-signature over the message digest bound
+// to adaptor, drawing the nonce from reader
+func EcdsaPreSignFromReader(sk curves.Scalar, digest []byte, adaptor curves.Point, reader io.Reader) (*EcdsaPreSignature, error) {
+	if sk == nil || adaptor == nil || reader == nil {
+		return nil, internal.ErrNilArguments
+	}
+	curve, err := ecdsaCurve(adaptor)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkAdaptor(curve, adaptor); err != nil {
+		return nil, err
+	}
+	if sk.IsZero() {
+		return nil, internal.ErrZeroValue
+	}
+	k := curve.Scalar.Random(reader)
+	if k.IsZero() {
+		return nil, internal.ErrZeroValue
+	}
+	r := adaptor.Mul(k)
+	rx := xCoordinate(curve, r)
+	if rx.IsZero() {
+		return nil, fmt.Errorf("derived a zero r")
+	}
+	kInv, err := k.Invert()
+	if err != nil {
+		return nil, err
+	}
+	// s' = k^-1 (H(m) + r·x)
+	s := kInv.Mul(reduce(curve, digest).Add(rx.Mul(sk)))
+	if s.IsZero() {
+		return nil, fmt.Errorf("derived a zero s")
+	}
+	ra := curve.ScalarBaseMult(k)
+	proof, err := ProveDleq(curve, k, curve.NewGeneratorPoint(), adaptor, reader)
+	if err != nil {
+		return nil, err
+	}
+	return &EcdsaPreSignature{R: r, RA: ra, S: s, Proof: proof}, nil
+}
+
+// EcdsaVerify checks that pre is a pre-signature over the message digest by pk bound to adaptor
+func EcdsaVerify(pk curves.Point, digest []byte, adaptor curves.Point, pre *EcdsaPreSignature) error {
+	if pk == nil || adaptor == nil || pre == nil || pre.R == nil || pre.RA == nil || pre.S == nil {
+		return internal.ErrNilArguments
+	}
+	curve, err := ecdsaCurve(adaptor)
+	if err != nil {
+		return err
+	}
+	if err := checkAdaptor(curve, adaptor); err != nil {
+		return err
+	}
+	if pk.CurveName() != curve.Name || pk.IsIdentity() {
+		return fmt.Errorf("invalid public key")
+	}
+	if pre.S.IsZero() || pre.R.IsIdentity() {
+		return fmt.Errorf("invalid pre-signature")
+	}
+	if err := VerifyDleq(curve, pre.Proof, curve.NewGeneratorPoint(), adaptor, pre.RA, pre.R); err != nil {
+		return err
+	}
+	rx := xCoordinate(curve, pre.R)
+	sInv, err := pre.S.Invert()
+	if err != nil {
+		return err
+	}
+	// H(m)/s'·G + r/s'·P == RA
+	lhs := curve.ScalarBaseMult(reduce(curve, digest).Mul(sInv)).Add(pk.Mul(rx.Mul(sInv)))
+	if !lhs.Equal(pre.RA) {
+		return fmt.Errorf("invalid pre-signature")
+	}
+	return nil
+}
+
+// EcdsaAdapt completes pre with the adaptor secret into a low-s ECDSA signature
+func EcdsaAdapt(pre *EcdsaPreSignature, secret curves.Scalar) (*curves.EcdsaSignature, error) {
+	if pre == nil || pre.R == nil || pre.S == nil || secret == nil {
+		return nil, internal.ErrNilArguments
+	}
+	curve, err := ecdsaCurve(pre.R)
+	if err != nil {
+		return nil, err
+	}
+	tInv, err := secret.Invert()
+	if err != nil {
+		return nil, err
+	}
+	s := pre.S.Mul(tInv)
+	sig := &curves.EcdsaSignature{
+		R: xCoordinate(curve, pre.R).BigInt(),
+		S: s.BigInt(),
+		V: int(pre.R.ToAffineCompressed()[0] & 1),
+	}
+	if sig.S.Cmp(halfOrder(curve)) > 0 {
+		sig.S = s.Neg().BigInt()
+		sig.V ^= 1
+	}
+	return sig, nil
+}
+
+// EcdsaExtract recovers the adaptor secret from pre and the completed signature sig
+func EcdsaExtract(pre *EcdsaPreSignature, sig *curves.EcdsaSignature, adaptor curves.Point) (curves.Scalar, error) {
+	if pre == nil || pre.S == nil || sig == nil || sig.S == nil || adaptor == nil {
+		return nil, internal.ErrNilArguments
+	}
+	curve, err := ecdsaCurve(adaptor)
+	if err != nil {
+		return nil, err
+	}
+	s, err := curve.Scalar.SetBigInt(sig.S)
+	if err != nil {
+		return nil, err
+	}
+	sInv, err := s.Invert()
+	if err != nil {
+		return nil, err
+	}
+	// t = s'/s up to the sign lost by low-s normalization
+	secret := pre.S.Mul(sInv)
+	if curve.ScalarBaseMult(secret).Equal(adaptor) {
+		return secret, nil
+	}
+	if secret = secret.Neg(); curve.ScalarBaseMult(secret).Equal(adaptor) {
+		return secret, nil
+	}
+	return nil, fmt.Errorf("signature does not complete the pre-signature")
+}
+
+func ecdsaCurve(p curves.Point) (*curves.Curve, error) {
+	switch p.CurveName() {
+	case curves.K256Name:
+		return curves.K256(), nil
+	case curves.P256Name:
+		return curves.P256(), nil
+	default:
+		return nil, fmt.Errorf("unsupported curve %s", p.CurveName())
+	}
+}
+
+// xCoordinate returns the x coordinate of p reduced by the group order
+func xCoordinate(curve *curves.Curve, p curves.Point) curves.Scalar {
+	return reduce(curve, p.ToAffineCompressed()[1:])
+}
+
+// reduce converts a digest to a scalar like crypto/ecdsa: the leftmost bits of
+// the size of the group order, reduced by the order
+func reduce(curve *curves.Curve, digest []byte) curves.Scalar {
+	n := order(curve)
+	bits := n.BitLen()
+	if size := (bits + 7) / 8; len(digest) > size {
+		digest = digest[:size]
+	}
+	v := new(big.Int).SetBytes(digest)
+	if excess := len(digest)*8 - bits; excess > 0 {
+		v.Rsh(v, uint(excess))
+	}
+	s, _ := curve.Scalar.SetBigInt(v.Mod(v, n))
+	return s
+}
+
+func order(curve *curves.Curve) *big.Int {
+	return new(big.Int).Add(curve.Scalar.One().Neg().BigInt(), big.NewInt(1))
+}
+
+func halfOrder(curve *curves.Curve) *big.Int {
+	return new(big.Int).Rsh(order(curve), 1)
+}
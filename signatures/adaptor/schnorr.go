@@ -0,0 +1,153 @@
+// Package adaptor implements adaptor signatures, also known as scriptless
+// scripts, for BIP-340 Schnorr and for ECDSA.
+//
+// A pre-signature is bound to an adaptor point T = t·G. Anyone can check that
+// it is a correct pre-signature for T, but only the holder of t can turn it
+// into a valid signature, and once that signature is published the
+// pre-signature and the signature together reveal t. This makes the release
+// of a signature and the release of a secret atomic, which is the building
+// block of atomic swaps and payment channels.
+//
+// The Schnorr construction completes to ordinary BIP-340 signatures. The ECDSA
+// construction follows the one-time verifiably encrypted signatures of
+// https://github.com/LLFourn/one-time-VES and completes to ordinary ECDSA
+// signatures on secp256k1 or P-256.
+package adaptor
+
+import (
+	crand "crypto/rand"
+	"fmt"
+	"io"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/signatures/schnorr/bip340"
+)
+
+const tagSchnorrNonce = "SchnorrAdaptor/nonce"
+
+// SchnorrPreSignature is a BIP-340 signature encrypted to an adaptor point.
+// R is the nonce of the completed signature including the adaptor point, its
+// parity decides how the adaptor secret enters the response.
+type SchnorrPreSignature struct {
+	R curves.Point
+	S curves.Scalar
+}
+
+// SchnorrPreSign creates a pre-signature over msg bound to the secp256k1 adaptor point T
+func SchnorrPreSign(sk *bip340.SecretKey, msg []byte, adaptor curves.Point) (*SchnorrPreSignature, error) {
+	return SchnorrPreSignFromReader(sk, msg, adaptor, crand.Reader)
+}
+
+// SchnorrPreSignFromReader creates a pre-signature over msg bound to adaptor,
+// mixing the randomness of reader into the nonce derivation
+func SchnorrPreSignFromReader(sk *bip340.SecretKey, msg []byte, adaptor curves.Point, reader io.Reader) (*SchnorrPreSignature, error) {
+	if sk == nil || adaptor == nil || reader == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if err := checkAdaptor(curves.K256(), adaptor); err != nil {
+		return nil, err
+	}
+	var aux [32]byte
+	if _, err := io.ReadFull(reader, aux[:]); err != nil {
+		return nil, err
+	}
+	skBytes, err := sk.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	curve := curves.K256()
+	d, err := curve.Scalar.SetBytes(skBytes)
+	if err != nil {
+		return nil, err
+	}
+	p := curve.ScalarBaseMult(d)
+	if !bip340.HasEvenY(p) {
+		d = d.Neg()
+	}
+	pk := bip340.XOnly(p)
+
+	t := d.Bytes()
+	for i := range t {
+		t[i] ^= aux[i]
+	}
+	k := reduce(curve, bip340.TaggedHash(tagSchnorrNonce, t, pk, adaptor.ToAffineCompressed(), msg))
+	if k.IsZero() {
+		return nil, fmt.Errorf("derived a zero nonce")
+	}
+	r := curve.ScalarBaseMult(k).Add(adaptor)
+	if r.IsIdentity() {
+		return nil, fmt.Errorf("derived an identity nonce")
+	}
+	// With an odd nonce the completed signature uses -R, so -k is the signing nonce
+	if !bip340.HasEvenY(r) {
+		k = k.Neg()
+	}
+	e := schnorrChallenge(r, pk, msg)
+	return &SchnorrPreSignature{R: r, S: k.Add(e.Mul(d))}, nil
+}
+
+// SchnorrVerify checks that pre is a pre-signature over msg by pk bound to adaptor
+func SchnorrVerify(pk *bip340.PublicKey, msg []byte, adaptor curves.Point, pre *SchnorrPreSignature) error {
+	if pk == nil || adaptor == nil || pre == nil || pre.R == nil || pre.S == nil {
+		return internal.ErrNilArguments
+	}
+	if err := checkAdaptor(curves.K256(), adaptor); err != nil {
+		return err
+	}
+	if pre.R.IsIdentity() {
+		return fmt.Errorf("invalid pre-signature nonce")
+	}
+	e := schnorrChallenge(pre.R, bip340.XOnly(pk.Point()), msg)
+	// s'·G == ±(R - T) + e·P, the sign being that of the y coordinate of R
+	nonce := pre.R.Sub(adaptor)
+	if !bip340.HasEvenY(pre.R) {
+		nonce = nonce.Neg()
+	}
+	if !curves.K256().ScalarBaseMult(pre.S).Equal(nonce.Add(pk.Point().Mul(e))) {
+		return fmt.Errorf("invalid pre-signature")
+	}
+	return nil
+}
+
+// SchnorrAdapt completes pre with the adaptor secret into a BIP-340 signature
+func SchnorrAdapt(pre *SchnorrPreSignature, secret curves.Scalar) (*bip340.Signature, error) {
+	if pre == nil || pre.R == nil || pre.S == nil || secret == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if !bip340.HasEvenY(pre.R) {
+		secret = secret.Neg()
+	}
+	sig := &bip340.Signature{S: pre.S.Add(secret)}
+	copy(sig.R[:], bip340.XOnly(pre.R))
+	return sig, nil
+}
+
+// SchnorrExtract recovers the adaptor secret from pre and the completed signature sig
+func SchnorrExtract(pre *SchnorrPreSignature, sig *bip340.Signature, adaptor curves.Point) (curves.Scalar, error) {
+	if pre == nil || pre.R == nil || pre.S == nil || sig == nil || sig.S == nil || adaptor == nil {
+		return nil, internal.ErrNilArguments
+	}
+	secret := sig.S.Sub(pre.S)
+	if !bip340.HasEvenY(pre.R) {
+		secret = secret.Neg()
+	}
+	if !curves.K256().ScalarBaseMult(secret).Equal(adaptor) {
+		return nil, fmt.Errorf("signature does not complete the pre-signature")
+	}
+	return secret, nil
+}
+
+func schnorrChallenge(r curves.Point, pk, msg []byte) curves.Scalar {
+	return reduce(curves.K256(), bip340.TaggedHash("BIP0340/challenge", bip340.XOnly(r), pk, msg))
+}
+
+func checkAdaptor(curve *curves.Curve, adaptor curves.Point) error {
+	if !adaptor.IsOnCurve() || adaptor.IsIdentity() {
+		return internal.ErrNotOnCurve
+	}
+	if adaptor.CurveName() != curve.Name {
+		return fmt.Errorf("adaptor point is not on %s", curve.Name)
+	}
+	return nil
+}
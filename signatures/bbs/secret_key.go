@@ -86,6 +86,11 @@ func (sk *SecretKey) Init(curve *curves.PairingCurve) *SecretKey {
 	return sk
 }
 
+// Zeroize overwrites the signing key with zero
+func (sk *SecretKey) Zeroize() {
+	curves.Zeroize(sk.value)
+}
+
 func (sk SecretKey) MarshalBinary() ([]byte, error) {
 	return sk.value.Bytes(), nil
 }
@@ -0,0 +1,103 @@
+package bbs
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/gtank/merlin"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/signatures/common"
+)
+
+// selectiveDisclosureLabel is the merlin transcript label for
+// the standalone selective disclosure proofs
+const selectiveDisclosureLabel = "BBS+ selective disclosure"
+
+// MessagesFromBytes hashes arbitrary byte messages, like
+// credential claims, to scalars that can be signed
+func MessagesFromBytes(curve *curves.PairingCurve, msgs [][]byte) []curves.Scalar {
+	out := make([]curves.Scalar, len(msgs))
+	for i, m := range msgs {
+		out[i] = curve.Scalar.Hash(m)
+	}
+	return out
+}
+
+// CreateSelectiveDisclosureProof proves knowledge of `sig` over `msgs`
+// while only revealing the messages at the indices in `revealed`.
+// This wraps NewPokSignature and the Fiat-Shamir step for when the
+// proof is not combined with other proofs.
+// The returned challenge must be sent with the proof.
+func CreateSelectiveDisclosureProof(
+	sig *Signature,
+	generators *MessageGenerators,
+	msgs []curves.Scalar,
+	revealed []int,
+	nonce common.Nonce,
+	reader io.Reader,
+) (*PokSignatureProof, common.Challenge, error) {
+	if len(msgs) != generators.length {
+		return nil, nil, fmt.Errorf("mismatch messages and generators")
+	}
+	reveal := make(map[int]bool, len(revealed))
+	for _, idx := range revealed {
+		if idx < 0 || idx >= len(msgs) {
+			return nil, nil, fmt.Errorf("revealed index %d out of range", idx)
+		}
+		reveal[idx] = true
+	}
+
+	proofMsgs := make([]common.ProofMessage, len(msgs))
+	for i, m := range msgs {
+		if reveal[i] {
+			proofMsgs[i] = &common.RevealedMessage{Message: m}
+		} else {
+			proofMsgs[i] = &common.ProofSpecificMessage{Message: m}
+		}
+	}
+
+	pok, err := NewPokSignature(sig, generators, proofMsgs, reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	transcript := merlin.NewTranscript(selectiveDisclosureLabel)
+	pok.GetChallengeContribution(transcript)
+	transcript.AppendMessage([]byte("nonce"), nonce.Bytes())
+	okm := transcript.ExtractBytes([]byte("signature proof of knowledge"), 64)
+	challenge, err := nonce.SetBytesWide(okm)
+	if err != nil {
+		return nil, nil, err
+	}
+	proof, err := pok.GenerateProof(challenge)
+	if err != nil {
+		return nil, nil, err
+	}
+	return proof, challenge, nil
+}
+
+// VerifySelectiveDisclosureProof checks a proof created by
+// CreateSelectiveDisclosureProof against the revealed messages
+// indexed by their position in the signed message vector
+func VerifySelectiveDisclosureProof(
+	proof *PokSignatureProof,
+	challenge common.Challenge,
+	pk *PublicKey,
+	generators *MessageGenerators,
+	revealedMsgs map[int]curves.Scalar,
+	nonce common.Nonce,
+) error {
+	for idx := range revealedMsgs {
+		if idx < 0 || idx >= generators.length {
+			return fmt.Errorf("revealed index %d out of range", idx)
+		}
+	}
+	if len(proof.proof2) != generators.length-len(revealedMsgs)+2 {
+		return fmt.Errorf("proof does not match the number of hidden messages")
+	}
+	transcript := merlin.NewTranscript(selectiveDisclosureLabel)
+	if !proof.Verify(revealedMsgs, pk, generators, nonce, challenge, transcript) {
+		return fmt.Errorf("invalid selective disclosure proof")
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package bbs
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+)
+
+func TestSelectiveDisclosureProof(t *testing.T) {
+	curve := curves.BLS12381(&curves.PointBls12381G2{})
+	pk, sk, err := NewKeys(curve)
+	require.NoError(t, err)
+	msgs := MessagesFromBytes(curve, [][]byte{
+		[]byte("name=alice"),
+		[]byte("dob=1990-01-01"),
+		[]byte("country=CH"),
+		[]byte("id=1234"),
+	})
+	generators, err := new(MessageGenerators).Init(pk, len(msgs))
+	require.NoError(t, err)
+	sig, err := sk.Sign(generators, msgs)
+	require.NoError(t, err)
+
+	nonce := curve.Scalar.Random(crand.Reader)
+	proof, challenge, err := CreateSelectiveDisclosureProof(sig, generators, msgs, []int{0, 2}, nonce, crand.Reader)
+	require.NoError(t, err)
+
+	revealed := map[int]curves.Scalar{0: msgs[0], 2: msgs[2]}
+	require.NoError(t, VerifySelectiveDisclosureProof(proof, challenge, pk, generators, revealed, nonce))
+
+	// serialized proofs still verify
+	bin, err := proof.MarshalBinary()
+	require.NoError(t, err)
+	decoded := new(PokSignatureProof).Init(curve)
+	require.NoError(t, decoded.UnmarshalBinary(bin))
+	require.NoError(t, VerifySelectiveDisclosureProof(decoded, challenge, pk, generators, revealed, nonce))
+
+	// a different nonce, revealed value or revealed set fails
+	require.Error(t, VerifySelectiveDisclosureProof(proof, challenge, pk, generators, revealed, curve.Scalar.Random(crand.Reader)))
+	require.Error(t, VerifySelectiveDisclosureProof(proof, challenge, pk, generators, map[int]curves.Scalar{0: msgs[1], 2: msgs[2]}, nonce))
+	require.Error(t, VerifySelectiveDisclosureProof(proof, challenge, pk, generators, map[int]curves.Scalar{0: msgs[0]}, nonce))
+
+	otherPk, _, err := NewKeys(curve)
+	require.NoError(t, err)
+	require.Error(t, VerifySelectiveDisclosureProof(proof, challenge, otherPk, generators, revealed, nonce))
+}
+
+func TestSelectiveDisclosureProofBadIndex(t *testing.T) {
+	curve := curves.BLS12381(&curves.PointBls12381G2{})
+	pk, sk, err := NewKeys(curve)
+	require.NoError(t, err)
+	msgs := []curves.Scalar{curve.Scalar.New(1), curve.Scalar.New(2)}
+	generators, err := new(MessageGenerators).Init(pk, len(msgs))
+	require.NoError(t, err)
+	sig, err := sk.Sign(generators, msgs)
+	require.NoError(t, err)
+
+	_, _, err = CreateSelectiveDisclosureProof(sig, generators, msgs, []int{2}, curve.Scalar.New(7), crand.Reader)
+	require.Error(t, err)
+	_, _, err = CreateSelectiveDisclosureProof(sig, generators, msgs[:1], nil, curve.Scalar.New(7), crand.Reader)
+	require.Error(t, err)
+}
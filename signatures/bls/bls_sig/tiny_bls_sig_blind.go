@@ -0,0 +1,151 @@
+package bls_sig
+
+import (
+	"fmt"
+
+	"github.com/go-sonr/crypto/core/curves/native"
+	"github.com/go-sonr/crypto/core/curves/native/bls12381"
+)
+
+// Represents a message hashed to G1 and blinded by a BlindingFactor
+type BlindMessageVt struct {
+	value bls12381.G1
+}
+
+// Represents a signature in G1 over a BlindMessageVt
+type BlindSignatureVt struct {
+	value bls12381.G1
+}
+
+// Serialize a blinded message to a byte array in compressed form.
+func (msg *BlindMessageVt) MarshalBinary() ([]byte, error) {
+	out := msg.value.ToCompressed()
+	return out[:], nil
+}
+
+// Deserialize a blinded message from a byte array in compressed form.
+func (msg *BlindMessageVt) UnmarshalBinary(data []byte) error {
+	p1, err := g1FromCompressed(data)
+	if err != nil {
+		return err
+	}
+	msg.value = *p1
+	return nil
+}
+
+// Serialize a blind signature to a byte array in compressed form.
+func (sig *BlindSignatureVt) MarshalBinary() ([]byte, error) {
+	out := sig.value.ToCompressed()
+	return out[:], nil
+}
+
+// Deserialize a blind signature from a byte array in compressed form.
+func (sig *BlindSignatureVt) UnmarshalBinary(data []byte) error {
+	p1, err := g1FromCompressed(data)
+	if err != nil {
+		return err
+	}
+	sig.value = *p1
+	return nil
+}
+
+// Hash message to G1 and blind it with a fresh blinding factor
+func blindMessageVt(message []byte, dstVt string) (*BlindMessageVt, *BlindingFactor, error) {
+	if message == nil {
+		return nil, nil, fmt.Errorf("message cannot be nil")
+	}
+	r, err := newBlindingFactor()
+	if err != nil {
+		return nil, nil, err
+	}
+	p1 := new(bls12381.G1).Hash(native.EllipticPointHasherSha256(), message, []byte(dstVt))
+	return &BlindMessageVt{value: *new(bls12381.G1).Mul(p1, r.value)}, r, nil
+}
+
+// Compute a signature in G1 over a blinded message.
+func (sk *SecretKey) blindSignVt(msg *BlindMessageVt) (*BlindSignatureVt, error) {
+	if msg == nil {
+		return nil, fmt.Errorf("blinded message cannot be nil")
+	}
+	if sk.value.IsZero() == 1 {
+		return nil, fmt.Errorf("invalid secret key")
+	}
+	if msg.value.IsIdentity() == 1 || msg.value.InCorrectSubgroup() == 0 {
+		return nil, fmt.Errorf("blinded message is not in the correct subgroup")
+	}
+	return &BlindSignatureVt{value: *new(bls12381.G1).Mul(&msg.value, sk.value)}, nil
+}
+
+// Check the blind signature and remove the blinding factor
+func (sig *BlindSignatureVt) unblind(pk *PublicKeyVt, msg *BlindMessageVt, r *BlindingFactor) (*SignatureVt, error) {
+	if pk == nil || msg == nil || r == nil || r.value == nil {
+		return nil, fmt.Errorf("public key, blinded message and blinding factor cannot be nil")
+	}
+	if sig.value.IsIdentity() == 1 || sig.value.InCorrectSubgroup() == 0 {
+		return nil, fmt.Errorf("blind signature is not in the correct subgroup")
+	}
+	// e(M', pk) == e(s', g2)
+	engine := new(bls12381.Engine)
+	engine.AddPairInvG1(&msg.value, &pk.value)
+	engine.AddPair(&sig.value, new(bls12381.G2).Generator())
+	if !engine.Check() {
+		return nil, fmt.Errorf("invalid blind signature")
+	}
+	rInv, ok := bls12381.Bls12381FqNew().Invert(r.value)
+	if !ok {
+		return nil, fmt.Errorf("invalid blinding factor")
+	}
+	return &SignatureVt{value: *new(bls12381.G1).Mul(&sig.value, rInv)}, nil
+}
+
+func g1FromCompressed(data []byte) (*bls12381.G1, error) {
+	if len(data) != SignatureVtSize {
+		return nil, fmt.Errorf("point must be %d bytes", SignatureVtSize)
+	}
+	var blob [SignatureVtSize]byte
+	copy(blob[:], data)
+	p1, err := new(bls12381.G1).FromCompressed(&blob)
+	if err != nil {
+		return nil, err
+	}
+	if p1.IsIdentity() == 1 {
+		return nil, fmt.Errorf("point cannot be zero")
+	}
+	return p1, nil
+}
+
+// Blind hashes msg to G1 and blinds it for signing with BlindSign
+func (b SigBasicVt) Blind(msg []byte) (*BlindMessageVt, *BlindingFactor, error) {
+	return blindMessageVt(msg, b.dst)
+}
+
+// BlindSign computes a signature in G1 over a blinded message
+func (b SigBasicVt) BlindSign(sk *SecretKey, msg *BlindMessageVt) (*BlindSignatureVt, error) {
+	return sk.blindSignVt(msg)
+}
+
+// Unblind checks a blind signature under pk and returns the signature over the original message
+func (b SigBasicVt) Unblind(pk *PublicKeyVt, msg *BlindMessageVt, sig *BlindSignatureVt, r *BlindingFactor) (*SignatureVt, error) {
+	if sig == nil {
+		return nil, fmt.Errorf("blind signature cannot be nil")
+	}
+	return sig.unblind(pk, msg, r)
+}
+
+// Blind hashes msg to G1 and blinds it for signing with BlindSign
+func (b SigPopVt) Blind(msg []byte) (*BlindMessageVt, *BlindingFactor, error) {
+	return blindMessageVt(msg, b.sigDst)
+}
+
+// BlindSign computes a signature in G1 over a blinded message
+func (b SigPopVt) BlindSign(sk *SecretKey, msg *BlindMessageVt) (*BlindSignatureVt, error) {
+	return sk.blindSignVt(msg)
+}
+
+// Unblind checks a blind signature under pk and returns the signature over the original message
+func (b SigPopVt) Unblind(pk *PublicKeyVt, msg *BlindMessageVt, sig *BlindSignatureVt, r *BlindingFactor) (*SignatureVt, error) {
+	if sig == nil {
+		return nil, fmt.Errorf("blind signature cannot be nil")
+	}
+	return sig.unblind(pk, msg, r)
+}
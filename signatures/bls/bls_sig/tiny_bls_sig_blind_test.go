@@ -0,0 +1,63 @@
+package bls_sig
+
+import (
+	"testing"
+)
+
+func TestBlindSignG1Works(t *testing.T) {
+	bls := NewSigPopVt()
+	pk, sk, err := bls.Keygen()
+	if err != nil {
+		t.Fatalf("Keygen failed: %v", err)
+	}
+	msg := []byte("blind token")
+	bm, r, err := bls.Blind(msg)
+	if err != nil {
+		t.Fatalf("Blind failed: %v", err)
+	}
+	data, err := bm.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+	received := new(BlindMessageVt)
+	if err = received.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	bs, err := bls.BlindSign(sk, received)
+	if err != nil {
+		t.Fatalf("BlindSign failed: %v", err)
+	}
+	sig, err := bls.Unblind(pk, bm, bs, r)
+	if err != nil {
+		t.Fatalf("Unblind failed: %v", err)
+	}
+	if ok, err := bls.Verify(pk, msg, sig); err != nil || !ok {
+		t.Errorf("Unblinded signature failed verification")
+	}
+}
+
+func TestBlindSignG1Fail(t *testing.T) {
+	bls := NewSigBasicVt()
+	_, sk, err := bls.Keygen()
+	if err != nil {
+		t.Fatalf("Keygen failed: %v", err)
+	}
+	otherPk, _, err := bls.Keygen()
+	if err != nil {
+		t.Fatalf("Keygen failed: %v", err)
+	}
+	bm, r, err := bls.Blind([]byte("blind token"))
+	if err != nil {
+		t.Fatalf("Blind failed: %v", err)
+	}
+	bs, err := bls.BlindSign(sk, bm)
+	if err != nil {
+		t.Fatalf("BlindSign failed: %v", err)
+	}
+	if _, err = bls.Unblind(otherPk, bm, bs, r); err == nil {
+		t.Errorf("Unblind accepted a signature of another key")
+	}
+	if _, err = bls.BlindSign(sk, &BlindMessageVt{}); err == nil {
+		t.Errorf("BlindSign accepted the identity")
+	}
+}
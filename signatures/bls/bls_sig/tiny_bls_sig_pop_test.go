@@ -843,3 +843,78 @@ func TestPopVtPartialMixupShares(t *testing.T) {
 		t.Errorf("CombineSignatures expected to fail but succeeded.")
 	}
 }
+
+func TestAggregateVerifyMultiSignatureG1Works(t *testing.T) {
+	// duplicate messages are fine in the proof of possession scheme
+	messages := [][]byte{{1}, {2}, {1}}
+	pks, sigs := initAggregatedTestValuesG1(messages, t)
+	bls := NewSigPopVt()
+	asig, err := bls.AggregateSignatures(sigs...)
+	if err != nil {
+		t.Fatalf("AggregateSignatures failed: %v", err)
+	}
+	msgs := make([][]byte, len(pks))
+	for i := range msgs {
+		msgs[i] = messages[i%len(messages)]
+	}
+	if res, err := bls.AggregateVerifyMultiSignature(pks, msgs, asig); !res {
+		t.Errorf("AggregateVerifyMultiSignature failed: %v", err)
+	}
+	msgs[0] = []byte{3}
+	if res, _ := bls.AggregateVerifyMultiSignature(pks, msgs, asig); res {
+		t.Errorf("AggregateVerifyMultiSignature verified when it should've failed.")
+	}
+	if _, err := bls.AggregateVerifyMultiSignature(pks, msgs, nil); err == nil {
+		t.Errorf("AggregateVerifyMultiSignature should've failed on a nil signature")
+	}
+}
+
+func TestFastAggregateVerifyMultiSignatureG1Works(t *testing.T) {
+	message := []byte{0}
+	pks, sigs := initAggregatedTestValuesG1([][]byte{message}, t)
+	bls := NewSigPopVt()
+	asig, err := bls.AggregateSignatures(sigs...)
+	if err != nil {
+		t.Fatalf("AggregateSignatures failed: %v", err)
+	}
+	if res, err := bls.FastAggregateVerifyMultiSignature(pks, message, asig); !res {
+		t.Errorf("FastAggregateVerifyMultiSignature failed: %v", err)
+	}
+	if res, _ := bls.FastAggregateVerifyMultiSignature(pks[1:], message, asig); res {
+		t.Errorf("FastAggregateVerifyMultiSignature verified when it should've failed.")
+	}
+}
+
+func TestAggregatePublicKeysWithPopG1(t *testing.T) {
+	bls := NewSigPopVt()
+	pks := []*PublicKeyVt{}
+	pops := []*ProofOfPossessionVt{}
+	ikm := make([]byte, 32)
+	for i := 0; i < 3; i++ {
+		readRand(ikm, t)
+		sk := genRandSecretKey(ikm, t)
+		pop, err := bls.PopProve(sk)
+		if err != nil {
+			t.Fatalf("PopProve failed: %v", err)
+		}
+		pks = append(pks, genPublicKeyVt(sk, t))
+		pops = append(pops, pop)
+	}
+	apk, err := bls.AggregatePublicKeysWithPop(pks, pops)
+	if err != nil {
+		t.Fatalf("AggregatePublicKeysWithPop failed: %v", err)
+	}
+	expected, _ := bls.AggregatePublicKeys(pks...)
+	if apk.value.Equal(&expected.value) != 1 {
+		t.Errorf("AggregatePublicKeysWithPop returned the wrong key")
+	}
+
+	// swapped proofs are rejected
+	pops[0], pops[1] = pops[1], pops[0]
+	if _, err := bls.AggregatePublicKeysWithPop(pks, pops); err == nil {
+		t.Errorf("AggregatePublicKeysWithPop should've failed with mismatched proofs")
+	}
+	if _, err := bls.AggregatePublicKeysWithPop(pks, pops[:2]); err == nil {
+		t.Errorf("AggregatePublicKeysWithPop should've failed with missing proofs")
+	}
+}
@@ -427,6 +427,52 @@ func (b SigPop) FastAggregateVerifyConstituent(pks []*PublicKey, msg []byte, sig
 	return b.FastAggregateVerify(pks, msg, asig)
 }
 
+// AggregateVerifyMultiSignature checks a signature aggregated with AggregateSignatures
+// over several (PK, message) pairs. Unlike AggregateVerify the messages do not
+// have to be distinct because every key must have a verified proof of possession.
+// See section 3.3 from
+// https://tools.ietf.org/html/draft-irtf-cfrg-bls-signature-03
+func (b SigPop) AggregateVerifyMultiSignature(pks []*PublicKey, msgs [][]byte, asig *MultiSignature) (bool, error) {
+	if asig == nil {
+		return false, fmt.Errorf("signature cannot be nil")
+	}
+	s := &Signature{Value: asig.value}
+	return s.coreAggregateVerify(pks, msgs, b.sigDst)
+}
+
+// FastAggregateVerifyMultiSignature verifies a signature aggregated with AggregateSignatures
+// over the same message under the given public keys.
+// See section 3.3.4 from
+// https://tools.ietf.org/html/draft-irtf-cfrg-bls-signature-03
+func (b SigPop) FastAggregateVerifyMultiSignature(pks []*PublicKey, msg []byte, asig *MultiSignature) (bool, error) {
+	if asig == nil {
+		return false, fmt.Errorf("signature cannot be nil")
+	}
+	return b.FastAggregateVerify(pks, msg, &Signature{Value: asig.value})
+}
+
+// AggregatePublicKeysWithPop checks the proof of possession of every public key
+// before combining them, so the result is safe to use with VerifyMultiSignature.
+// pops[i] must be the proof for pks[i].
+func (b SigPop) AggregatePublicKeysWithPop(pks []*PublicKey, pops []*ProofOfPossession) (*MultiPublicKey, error) {
+	if len(pks) != len(pops) {
+		return nil, fmt.Errorf("the number of public keys does not match the number of proofs: %v != %v", len(pks), len(pops))
+	}
+	for i, pk := range pks {
+		if pk == nil || pops[i] == nil {
+			return nil, fmt.Errorf("public key and proof at %d cannot be nil", i)
+		}
+		ok, err := b.PopVerify(pk, pops[i])
+		if err != nil {
+			return nil, fmt.Errorf("proof of possession at %d: %w", i, err)
+		}
+		if !ok {
+			return nil, fmt.Errorf("invalid proof of possession at %d", i)
+		}
+	}
+	return b.AggregatePublicKeys(pks...)
+}
+
 // Create a proof of possession for the corresponding public key.
 // A proof of possession must be created for each public key to be used
 // in FastAggregateVerify or a Multipublickey to avoid rogue key attacks.
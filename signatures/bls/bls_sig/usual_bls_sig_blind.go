@@ -0,0 +1,181 @@
+package bls_sig
+
+import (
+	"fmt"
+
+	"github.com/go-sonr/crypto/core/curves/native"
+	"github.com/go-sonr/crypto/core/curves/native/bls12381"
+)
+
+// Blind signatures let a signer sign a message it never sees.
+// The user hashes the message to G2 and multiplies it by a random
+// blinding factor r, the signer multiplies the blinded message by its
+// secret key, and the user multiplies the result by r^-1 to obtain an
+// ordinary signature that verifies under the scheme's Verify.
+// The signer cannot link the final signature to the signing session.
+
+// Represents the secret blinding factor chosen by the user
+type BlindingFactor struct {
+	value *native.Field
+}
+
+// Represents a message hashed to G2 and blinded by a BlindingFactor
+type BlindMessage struct {
+	Value bls12381.G2
+}
+
+// Represents a signature in G2 over a BlindMessage
+type BlindSignature struct {
+	Value bls12381.G2
+}
+
+// Serialize a blinded message to a byte array in compressed form.
+func (msg BlindMessage) MarshalBinary() ([]byte, error) {
+	out := msg.Value.ToCompressed()
+	return out[:], nil
+}
+
+// Deserialize a blinded message from a byte array in compressed form.
+func (msg *BlindMessage) UnmarshalBinary(data []byte) error {
+	p2, err := g2FromCompressed(data)
+	if err != nil {
+		return err
+	}
+	msg.Value = *p2
+	return nil
+}
+
+// Serialize a blind signature to a byte array in compressed form.
+func (sig BlindSignature) MarshalBinary() ([]byte, error) {
+	out := sig.Value.ToCompressed()
+	return out[:], nil
+}
+
+// Deserialize a blind signature from a byte array in compressed form.
+func (sig *BlindSignature) UnmarshalBinary(data []byte) error {
+	p2, err := g2FromCompressed(data)
+	if err != nil {
+		return err
+	}
+	sig.Value = *p2
+	return nil
+}
+
+// newBlindingFactor returns a random non-zero scalar
+func newBlindingFactor() (*BlindingFactor, error) {
+	for {
+		ikm, err := generateRandBytes(native.WideFieldBytes)
+		if err != nil {
+			return nil, err
+		}
+		var wide [native.WideFieldBytes]byte
+		copy(wide[:], ikm)
+		r := bls12381.Bls12381FqNew().SetBytesWide(&wide)
+		if r.IsZero() == 0 {
+			return &BlindingFactor{value: r}, nil
+		}
+	}
+}
+
+// Hash message to G2 and blind it with a fresh blinding factor
+func blindMessage(message []byte, dst string) (*BlindMessage, *BlindingFactor, error) {
+	if message == nil {
+		return nil, nil, fmt.Errorf("message cannot be nil")
+	}
+	r, err := newBlindingFactor()
+	if err != nil {
+		return nil, nil, err
+	}
+	p2 := new(bls12381.G2).Hash(native.EllipticPointHasherSha256(), message, []byte(dst))
+	return &BlindMessage{Value: *new(bls12381.G2).Mul(p2, r.value)}, r, nil
+}
+
+// Compute a signature over a blinded message.
+// The signer learns nothing about the message, so the protocol
+// around it must decide what the signer is willing to sign.
+func (sk SecretKey) blindSign(msg *BlindMessage) (*BlindSignature, error) {
+	if msg == nil {
+		return nil, fmt.Errorf("blinded message cannot be nil")
+	}
+	if sk.value.IsZero() == 1 {
+		return nil, fmt.Errorf("invalid secret key")
+	}
+	if msg.Value.IsIdentity() == 1 || msg.Value.InCorrectSubgroup() == 0 {
+		return nil, fmt.Errorf("blinded message is not in the correct subgroup")
+	}
+	return &BlindSignature{Value: *new(bls12381.G2).Mul(&msg.Value, sk.value)}, nil
+}
+
+// Check the blind signature and remove the blinding factor
+func (sig BlindSignature) unblind(pk *PublicKey, msg *BlindMessage, r *BlindingFactor) (*Signature, error) {
+	if pk == nil || msg == nil || r == nil || r.value == nil {
+		return nil, fmt.Errorf("public key, blinded message and blinding factor cannot be nil")
+	}
+	if sig.Value.IsIdentity() == 1 || sig.Value.InCorrectSubgroup() == 0 {
+		return nil, fmt.Errorf("blind signature is not in the correct subgroup")
+	}
+	// e(pk, M') == e(g1, s')
+	engine := new(bls12381.Engine)
+	engine.AddPair(&pk.value, &msg.Value)
+	engine.AddPairInvG1(new(bls12381.G1).Generator(), &sig.Value)
+	if !engine.Check() {
+		return nil, fmt.Errorf("invalid blind signature")
+	}
+	rInv, ok := bls12381.Bls12381FqNew().Invert(r.value)
+	if !ok {
+		return nil, fmt.Errorf("invalid blinding factor")
+	}
+	return &Signature{Value: *new(bls12381.G2).Mul(&sig.Value, rInv)}, nil
+}
+
+func g2FromCompressed(data []byte) (*bls12381.G2, error) {
+	if len(data) != SignatureSize {
+		return nil, fmt.Errorf("point must be %d bytes", SignatureSize)
+	}
+	var blob [SignatureSize]byte
+	copy(blob[:], data)
+	p2, err := new(bls12381.G2).FromCompressed(&blob)
+	if err != nil {
+		return nil, err
+	}
+	if p2.IsIdentity() == 1 {
+		return nil, fmt.Errorf("point cannot be zero")
+	}
+	return p2, nil
+}
+
+// Blind hashes msg to G2 and blinds it for signing with BlindSign
+func (b SigBasic) Blind(msg []byte) (*BlindMessage, *BlindingFactor, error) {
+	return blindMessage(msg, b.dst)
+}
+
+// BlindSign computes a signature over a blinded message
+func (b SigBasic) BlindSign(sk *SecretKey, msg *BlindMessage) (*BlindSignature, error) {
+	return sk.blindSign(msg)
+}
+
+// Unblind checks a blind signature under pk and returns the signature over the original message
+func (b SigBasic) Unblind(pk *PublicKey, msg *BlindMessage, sig *BlindSignature, r *BlindingFactor) (*Signature, error) {
+	if sig == nil {
+		return nil, fmt.Errorf("blind signature cannot be nil")
+	}
+	return sig.unblind(pk, msg, r)
+}
+
+// Blind hashes msg to G2 and blinds it for signing with BlindSign
+func (b SigPop) Blind(msg []byte) (*BlindMessage, *BlindingFactor, error) {
+	return blindMessage(msg, b.sigDst)
+}
+
+// BlindSign computes a signature over a blinded message
+func (b SigPop) BlindSign(sk *SecretKey, msg *BlindMessage) (*BlindSignature, error) {
+	return sk.blindSign(msg)
+}
+
+// Unblind checks a blind signature under pk and returns the signature over the original message
+func (b SigPop) Unblind(pk *PublicKey, msg *BlindMessage, sig *BlindSignature, r *BlindingFactor) (*Signature, error) {
+	if sig == nil {
+		return nil, fmt.Errorf("blind signature cannot be nil")
+	}
+	return sig.unblind(pk, msg, r)
+}
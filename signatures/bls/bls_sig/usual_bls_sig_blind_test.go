@@ -0,0 +1,85 @@
+package bls_sig
+
+import (
+	"testing"
+)
+
+func TestBlindSignG2Works(t *testing.T) {
+	bls := NewSigPop()
+	pk, sk, err := bls.Keygen()
+	if err != nil {
+		t.Fatalf("Keygen failed: %v", err)
+	}
+	msg := []byte("blind token")
+	bm, r, err := bls.Blind(msg)
+	if err != nil {
+		t.Fatalf("Blind failed: %v", err)
+	}
+	// The blinded message travels to the signer and back
+	data, err := bm.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+	received := new(BlindMessage)
+	if err = received.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	bs, err := bls.BlindSign(sk, received)
+	if err != nil {
+		t.Fatalf("BlindSign failed: %v", err)
+	}
+	sig, err := bls.Unblind(pk, bm, bs, r)
+	if err != nil {
+		t.Fatalf("Unblind failed: %v", err)
+	}
+	if ok, err := bls.Verify(pk, msg, sig); err != nil || !ok {
+		t.Errorf("Unblinded signature failed verification")
+	}
+	// Unblinding yields the deterministic signature, the signer saw only the blinded message
+	direct, err := bls.Sign(sk, msg)
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	if direct.Value.Equal(&sig.Value) != 1 {
+		t.Errorf("Unblinded signature differs from the direct signature")
+	}
+	if bm.Value.Equal(&direct.Value) == 1 || bs.Value.Equal(&direct.Value) == 1 {
+		t.Errorf("Blinding did not hide the message")
+	}
+}
+
+func TestBlindSignG2Fail(t *testing.T) {
+	bls := NewSigBasic()
+	pk, sk, err := bls.Keygen()
+	if err != nil {
+		t.Fatalf("Keygen failed: %v", err)
+	}
+	otherPk, _, err := bls.Keygen()
+	if err != nil {
+		t.Fatalf("Keygen failed: %v", err)
+	}
+	bm, r, err := bls.Blind([]byte("blind token"))
+	if err != nil {
+		t.Fatalf("Blind failed: %v", err)
+	}
+	bs, err := bls.BlindSign(sk, bm)
+	if err != nil {
+		t.Fatalf("BlindSign failed: %v", err)
+	}
+	if _, err = bls.Unblind(otherPk, bm, bs, r); err == nil {
+		t.Errorf("Unblind accepted a signature of another key")
+	}
+	if _, _, err = bls.Blind(nil); err == nil {
+		t.Errorf("Blind accepted a nil message")
+	}
+	if _, err = bls.BlindSign(sk, &BlindMessage{}); err == nil {
+		t.Errorf("BlindSign accepted the identity")
+	}
+	sig, err := bls.Unblind(pk, bm, bs, r)
+	if err != nil {
+		t.Fatalf("Unblind failed: %v", err)
+	}
+	if ok, _ := bls.Verify(pk, []byte("other token"), sig); ok {
+		t.Errorf("Unblinded signature verified for another message")
+	}
+}
@@ -0,0 +1,39 @@
+package tbls
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/signatures/bls/bls_sig"
+)
+
+// A threshold of participants runs a randomness beacon by signing the
+// message of each round in turn. Signatures are unique, so the output of a
+// round is fixed by the group key yet unpredictable until a threshold signs.
+
+// BeaconMessage returns the message of round, SHA-256(previous || round)
+// with round as 8 big endian bytes. previous is the signature of the last
+// round, which chains the rounds, or empty for unchained beacons.
+func BeaconMessage(round uint64, previous []byte) []byte {
+	h := sha256.New()
+	h.Write(previous)
+	var r [8]byte
+	binary.BigEndian.PutUint64(r[:], round)
+	h.Write(r[:])
+	return h.Sum(nil)
+}
+
+// Randomness returns the output of a beacon round, SHA-256 of the
+// compressed signature
+func Randomness(sig *bls_sig.Signature) ([]byte, error) {
+	if sig == nil {
+		return nil, internal.ErrNilArguments
+	}
+	b, err := sig.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	out := sha256.Sum256(b)
+	return out[:], nil
+}
@@ -0,0 +1,189 @@
+// Package tbls implements threshold BLS signatures on BLS12-381, with
+// public keys in G1 and signatures in G2.
+//
+// Key shares come from a distributed key generation over
+// curves.BLS12381G1, such as dkg/pedersen, so no party ever holds the key.
+// Each share signs partially, partial signatures are verified against the
+// public share of their signer, and any threshold of them interpolate to a
+// standard BLS signature under the group public key.
+package tbls
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/dkg/pedersen"
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/sharing"
+	"github.com/go-sonr/crypto/signatures/bls/bls_sig"
+)
+
+// Scheme is the BLS signature scheme of the partial and combined
+// signatures, SigBasic or SigPop of bls_sig
+type Scheme interface {
+	PartialSign(sks *bls_sig.SecretKeyShare, msg []byte) (*bls_sig.PartialSignature, error)
+	CombineSignatures(sigs ...*bls_sig.PartialSignature) (*bls_sig.Signature, error)
+	Verify(pk *bls_sig.PublicKey, msg []byte, sig *bls_sig.Signature) (bool, error)
+}
+
+// PublicKey is the group key of a threshold and the public shares of its
+// participants, all a verifier of partial signatures needs
+type PublicKey struct {
+	Threshold    uint32
+	Key          *bls_sig.PublicKey
+	PublicShares map[uint32]*bls_sig.PublicKey
+	scheme       Scheme
+}
+
+// KeyShare is the signing share of one participant
+type KeyShare struct {
+	*PublicKey
+	ID    uint32
+	share *bls_sig.SecretKeyShare
+}
+
+// NewKeyShare returns the key share of the Shamir share of a DKG over
+// curves.BLS12381G1 with its public key and public shares. A nil scheme
+// is bls_sig.NewSigPop().
+func NewKeyShare(scheme Scheme, share *sharing.ShamirShare, threshold uint32, publicKey curves.Point, publicShares map[uint32]curves.Point) (*KeyShare, error) {
+	if share == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if share.Id == 0 || share.Id > 255 {
+		return nil, fmt.Errorf("share identifier must be between 1 and 255")
+	}
+	if len(share.Value) != bls_sig.SecretKeySize {
+		return nil, fmt.Errorf("share must be a BLS12-381 scalar")
+	}
+	pub, err := NewPublicKey(scheme, threshold, publicKey, publicShares)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := pub.PublicShares[share.Id]; !ok {
+		return nil, fmt.Errorf("no public share for participant %d", share.Id)
+	}
+	// the share is the big endian scalar followed by the identifier
+	sks := new(bls_sig.SecretKeyShare)
+	if err := sks.UnmarshalBinary(append(append([]byte(nil), share.Value...), byte(share.Id))); err != nil {
+		return nil, err
+	}
+	return &KeyShare{PublicKey: pub, ID: share.Id, share: sks}, nil
+}
+
+// FromPedersen returns the key share of a dkg/pedersen result
+func FromPedersen(scheme Scheme, result *pedersen.Result) (*KeyShare, error) {
+	if result == nil {
+		return nil, internal.ErrNilArguments
+	}
+	return NewKeyShare(scheme, result.SecretShare, result.Threshold(), result.PublicKey, result.PublicShares)
+}
+
+// NewPublicKey converts the G1 points of a DKG into the public key of a
+// threshold. A nil scheme is bls_sig.NewSigPop().
+func NewPublicKey(scheme Scheme, threshold uint32, publicKey curves.Point, publicShares map[uint32]curves.Point) (*PublicKey, error) {
+	if publicKey == nil || publicShares == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if threshold < 2 || int(threshold) > len(publicShares) {
+		return nil, fmt.Errorf("invalid threshold %d of %d", threshold, len(publicShares))
+	}
+	if scheme == nil {
+		scheme = bls_sig.NewSigPop()
+	}
+	key, err := toPublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	pub := &PublicKey{
+		Threshold:    threshold,
+		Key:          key,
+		PublicShares: make(map[uint32]*bls_sig.PublicKey, len(publicShares)),
+		scheme:       scheme,
+	}
+	for id, p := range publicShares {
+		if pub.PublicShares[id], err = toPublicKey(p); err != nil {
+			return nil, fmt.Errorf("public share of participant %d: %w", id, err)
+		}
+	}
+	return pub, nil
+}
+
+func toPublicKey(p curves.Point) (*bls_sig.PublicKey, error) {
+	if _, ok := p.(*curves.PointBls12381G1); !ok {
+		return nil, fmt.Errorf("public keys must be BLS12-381 G1 points")
+	}
+	pk := new(bls_sig.PublicKey)
+	if err := pk.UnmarshalBinary(p.ToAffineCompressed()); err != nil {
+		return nil, err
+	}
+	return pk, nil
+}
+
+// Sign returns the partial signature of msg by the share
+func (k *KeyShare) Sign(msg []byte) (*bls_sig.PartialSignature, error) {
+	return k.scheme.PartialSign(k.share, msg)
+}
+
+// VerifyPartial checks a partial signature of msg against the public share
+// of its signer
+func (p *PublicKey) VerifyPartial(msg []byte, sig *bls_sig.PartialSignature) error {
+	if sig == nil {
+		return internal.ErrNilArguments
+	}
+	pk, ok := p.PublicShares[uint32(sig.Identifier)]
+	if !ok {
+		return fmt.Errorf("unknown signer %d", sig.Identifier)
+	}
+	ok, err := p.scheme.Verify(pk, msg, &bls_sig.Signature{Value: sig.Signature})
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("invalid partial signature of signer %d", sig.Identifier)
+	}
+	return nil
+}
+
+// Combine verifies the partial signatures of msg, ignoring invalid and
+// duplicate ones, and interpolates the first threshold valid ones into the
+// BLS signature of the group key. It fails when fewer than threshold
+// signers signed correctly.
+func (p *PublicKey) Combine(msg []byte, sigs ...*bls_sig.PartialSignature) (*bls_sig.Signature, error) {
+	valid := make([]*bls_sig.PartialSignature, 0, p.Threshold)
+	seen := make(map[byte]bool, len(sigs))
+	for _, sig := range sigs {
+		if sig == nil || seen[sig.Identifier] || p.VerifyPartial(msg, sig) != nil {
+			continue
+		}
+		seen[sig.Identifier] = true
+		valid = append(valid, sig)
+	}
+	if uint32(len(valid)) < p.Threshold {
+		return nil, fmt.Errorf("%d valid partial signatures, %d needed", len(valid), p.Threshold)
+	}
+	sort.Slice(valid, func(i, j int) bool { return valid[i].Identifier < valid[j].Identifier })
+	sig, err := p.scheme.CombineSignatures(valid[:p.Threshold]...)
+	if err != nil {
+		return nil, err
+	}
+	if err = p.Verify(msg, sig); err != nil {
+		return nil, fmt.Errorf("combined signature does not verify")
+	}
+	return sig, nil
+}
+
+// Verify checks a combined signature of msg against the group key
+func (p *PublicKey) Verify(msg []byte, sig *bls_sig.Signature) error {
+	if sig == nil {
+		return internal.ErrNilArguments
+	}
+	ok, err := p.scheme.Verify(p.Key, msg, sig)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("invalid signature")
+	}
+	return nil
+}
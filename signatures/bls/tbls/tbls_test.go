@@ -0,0 +1,157 @@
+package tbls
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/dkg/pedersen"
+	"github.com/go-sonr/crypto/signatures/bls/bls_sig"
+)
+
+// runDkg runs the Pedersen DKG of limit participants over BLS12-381 G1
+func runDkg(t *testing.T, threshold, limit uint32) map[uint32]*pedersen.Result {
+	curve := curves.BLS12381G1()
+	participants := make(map[uint32]*pedersen.Participant, limit)
+	for i := uint32(1); i <= limit; i++ {
+		var others []uint32
+		for j := uint32(1); j <= limit; j++ {
+			if i != j {
+				others = append(others, j)
+			}
+		}
+		p, err := pedersen.NewParticipant(i, threshold, curve, []byte("tbls test"), others...)
+		require.NoError(t, err)
+		participants[i] = p
+	}
+
+	bcast1 := make(map[uint32]*pedersen.Round1Bcast)
+	p2p1 := make(map[uint32]pedersen.Round1P2PSend)
+	for id, p := range participants {
+		b, s, err := p.Round1(nil)
+		require.NoError(t, err)
+		bcast1[id], p2p1[id] = b, s
+	}
+	bcast2 := make(map[uint32]*pedersen.Round2Bcast)
+	for id, p := range participants {
+		in := make(map[uint32]*pedersen.Round1P2PSendPacket)
+		for from, s := range p2p1 {
+			if from != id {
+				in[from] = s[id]
+			}
+		}
+		b, err := p.Round2(bcast1, in)
+		require.NoError(t, err)
+		bcast2[id] = b
+	}
+	bcast3 := make(map[uint32]*pedersen.Round3Bcast)
+	for id, p := range participants {
+		b, err := p.Round3(bcast2)
+		require.NoError(t, err)
+		bcast3[id] = b
+	}
+	bcast4 := make(map[uint32]*pedersen.Round4Bcast)
+	for id, p := range participants {
+		b, err := p.Round4(bcast3)
+		require.NoError(t, err)
+		bcast4[id] = b
+	}
+	bcast5 := make(map[uint32]*pedersen.Round5Bcast)
+	for id, p := range participants {
+		b, err := p.Round5(bcast4)
+		require.NoError(t, err)
+		bcast5[id] = b
+	}
+	bcast6 := make(map[uint32]*pedersen.Round6Bcast)
+	for id, p := range participants {
+		b, err := p.Round6(bcast5)
+		require.NoError(t, err)
+		bcast6[id] = b
+	}
+	results := make(map[uint32]*pedersen.Result, limit)
+	for id, p := range participants {
+		r, err := p.Finalize(bcast6)
+		require.NoError(t, err)
+		results[id] = r
+	}
+	return results
+}
+
+func TestThresholdSign(t *testing.T) {
+	results := runDkg(t, 3, 5)
+	shares := make(map[uint32]*KeyShare, len(results))
+	for id, r := range results {
+		k, err := FromPedersen(nil, r)
+		require.NoError(t, err)
+		shares[id] = k
+	}
+
+	msg := []byte("round 1")
+	partials := make([]*bls_sig.PartialSignature, 0, len(shares))
+	for id := uint32(1); id <= 5; id++ {
+		sig, err := shares[id].Sign(msg)
+		require.NoError(t, err)
+		require.NoError(t, shares[1].VerifyPartial(msg, sig))
+		partials = append(partials, sig)
+	}
+
+	// any threshold of signers gives the same standard BLS signature
+	a, err := shares[1].Combine(msg, partials[0], partials[1], partials[2])
+	require.NoError(t, err)
+	b, err := shares[1].Combine(msg, partials[4], partials[2], partials[3])
+	require.NoError(t, err)
+	requireSameSignature(t, a, b)
+	ok, err := bls_sig.NewSigPop().Verify(shares[1].Key, msg, a)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// invalid and duplicate partial signatures do not count
+	other, err := shares[2].Sign([]byte("round 2"))
+	require.NoError(t, err)
+	require.Error(t, shares[1].VerifyPartial(msg, other))
+	_, err = shares[1].Combine(msg, partials[0], partials[0], other, partials[1])
+	require.Error(t, err)
+	c, err := shares[1].Combine(msg, other, partials[0], partials[0], partials[3], partials[1])
+	require.NoError(t, err)
+	requireSameSignature(t, a, c)
+}
+
+func requireSameSignature(t *testing.T, a, b *bls_sig.Signature) {
+	x, err := a.MarshalBinary()
+	require.NoError(t, err)
+	y, err := b.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, x, y)
+}
+
+func TestBeacon(t *testing.T) {
+	results := runDkg(t, 2, 3)
+	shares := make([]*KeyShare, 0, len(results))
+	for _, r := range results {
+		k, err := FromPedersen(bls_sig.NewSigBasic(), r)
+		require.NoError(t, err)
+		shares = append(shares, k)
+	}
+
+	var previous []byte
+	seen := map[string]bool{}
+	for round := uint64(1); round <= 3; round++ {
+		msg := BeaconMessage(round, previous)
+		var partials []*bls_sig.PartialSignature
+		for _, k := range shares {
+			sig, err := k.Sign(msg)
+			require.NoError(t, err)
+			partials = append(partials, sig)
+		}
+		sig, err := shares[0].Combine(msg, partials...)
+		require.NoError(t, err)
+		out, err := Randomness(sig)
+		require.NoError(t, err)
+		require.Len(t, out, 32)
+		require.False(t, seen[string(out)])
+		seen[string(out)] = true
+		previous, err = sig.MarshalBinary()
+		require.NoError(t, err)
+	}
+}
@@ -0,0 +1,124 @@
+// Package ecdsa signs and verifies ECDSA signatures on secp256k1 and P-256
+// with nonces from the nonce package, hedged RFC 6979 nonces by default.
+package ecdsa
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/signatures/nonce"
+)
+
+func checkCurve(curve *curves.Curve) error {
+	if curve == nil {
+		return internal.ErrNilArguments
+	}
+	if curve.Name != curves.K256Name && curve.Name != curves.P256Name {
+		return fmt.Errorf("ecdsa: unsupported curve %s", curve.Name)
+	}
+	return nil
+}
+
+// order returns the order of the scalar field of curve
+func order(curve *curves.Curve) *big.Int {
+	q := curve.Scalar.One().Neg().BigInt()
+	return q.Add(q, big.NewInt(1))
+}
+
+// hashToInt is the leftmost bits of digest reduced by the order, SEC 1
+// section 4.1.3 step 5
+func hashToInt(curve *curves.Curve, digest []byte) (curves.Scalar, error) {
+	q := order(curve)
+	e := new(big.Int).SetBytes(digest)
+	if n := len(digest)*8 - q.BitLen(); n > 0 {
+		e.Rsh(e, uint(n))
+	}
+	return curve.Scalar.SetBigInt(e.Mod(e, q))
+}
+
+// Sign signs digest with secret and a hedged RFC 6979 nonce
+func Sign(curve *curves.Curve, secret curves.Scalar, digest []byte) (*curves.EcdsaSignature, error) {
+	return SignWith(curve, secret, digest, nonce.Default())
+}
+
+// SignWith signs digest with secret and a nonce of nonces. V of the
+// signature is the recovery id, the parity of the y coordinate of R plus
+// two when its x coordinate overflowed the order.
+func SignWith(curve *curves.Curve, secret curves.Scalar, digest []byte, nonces nonce.Generator) (*curves.EcdsaSignature, error) {
+	if err := checkCurve(curve); err != nil {
+		return nil, err
+	}
+	if secret == nil || nonces == nil || len(digest) == 0 {
+		return nil, internal.ErrNilArguments
+	}
+	if secret.IsZero() {
+		return nil, internal.ErrZeroValue
+	}
+	e, err := hashToInt(curve, digest)
+	if err != nil {
+		return nil, err
+	}
+	k, err := nonces.Nonce(curve, secret, digest)
+	if err != nil {
+		return nil, err
+	}
+	defer curves.Zeroize(k)
+
+	R := curve.ScalarBaseMult(k).ToAffineCompressed()
+	x := new(big.Int).SetBytes(R[1:])
+	v := int(R[0] & 1)
+	if x.Cmp(order(curve)) >= 0 {
+		v |= 2
+	}
+	r, err := curve.Scalar.SetBigInt(x)
+	if err != nil {
+		return nil, err
+	}
+	kInv, err := k.Invert()
+	if err != nil {
+		return nil, err
+	}
+	defer curves.Zeroize(kInv)
+	s := kInv.Mul(e.Add(r.Mul(secret)))
+	// a zero r or s happens with negligible probability, and the same
+	// deterministic nonce would give it again
+	if r.IsZero() || s.IsZero() {
+		return nil, fmt.Errorf("ecdsa: nonce gives a zero signature")
+	}
+	return &curves.EcdsaSignature{R: r.BigInt(), S: s.BigInt(), V: v}, nil
+}
+
+// Verify reports whether sig is a valid signature of digest by pub
+func Verify(curve *curves.Curve, pub curves.Point, digest []byte, sig *curves.EcdsaSignature) bool {
+	if checkCurve(curve) != nil || pub == nil || pub.IsIdentity() || sig == nil || sig.R == nil || sig.S == nil {
+		return false
+	}
+	q := order(curve)
+	if sig.R.Sign() <= 0 || sig.S.Sign() <= 0 || sig.R.Cmp(q) >= 0 || sig.S.Cmp(q) >= 0 {
+		return false
+	}
+	e, err := hashToInt(curve, digest)
+	if err != nil {
+		return false
+	}
+	r, err := curve.Scalar.SetBigInt(sig.R)
+	if err != nil {
+		return false
+	}
+	s, err := curve.Scalar.SetBigInt(sig.S)
+	if err != nil {
+		return false
+	}
+	w, err := s.Invert()
+	if err != nil {
+		return false
+	}
+	R := curve.ScalarBaseMult(e.Mul(w)).Add(pub.Mul(r.Mul(w)))
+	if R.IsIdentity() {
+		return false
+	}
+	x := new(big.Int).SetBytes(R.ToAffineCompressed()[1:])
+	return x.Mod(x, q).Cmp(sig.R) == 0
+}
@@ -0,0 +1,60 @@
+package ecdsa
+
+import (
+	stdecdsa "crypto/ecdsa"
+	"crypto/elliptic"
+	crand "crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/signatures/nonce"
+)
+
+func TestSignVector(t *testing.T) {
+	// RFC 6979 appendix A.2.5, P-256 with SHA-256 over "sample"
+	curve := curves.P256()
+	x, _ := new(big.Int).SetString("C9AFA9D845BA75166B5C215767B1D6934E50C3DB36E89B127B8A622B120F6721", 16)
+	secret, err := curve.Scalar.SetBigInt(x)
+	require.NoError(t, err)
+	digest := sha256.Sum256([]byte("sample"))
+
+	sig, err := SignWith(curve, secret, digest[:], nonce.Deterministic(sha256.New))
+	require.NoError(t, err)
+	require.Equal(t, "efd48b2aacb6a8fd1140dd9cd45e81d69d2c877b56aaf991c34d0ea84eaf3716", hex.EncodeToString(sig.R.Bytes()))
+	require.Equal(t, "f7cb1c942d657c41d436c7a1b6e29f65f3e900dbb9aff4064dc4ab2f843acda8", hex.EncodeToString(sig.S.Bytes()))
+	require.True(t, Verify(curve, curve.ScalarBaseMult(secret), digest[:], sig))
+}
+
+func TestSignVerify(t *testing.T) {
+	digest := sha256.Sum256([]byte("message"))
+	for _, curve := range []*curves.Curve{curves.K256(), curves.P256()} {
+		secret := curve.Scalar.Random(crand.Reader)
+		pub := curve.ScalarBaseMult(secret)
+
+		sig, err := Sign(curve, secret, digest[:])
+		require.NoError(t, err)
+		require.True(t, Verify(curve, pub, digest[:], sig), curve.Name)
+		other := sha256.Sum256([]byte("other"))
+		require.False(t, Verify(curve, pub, other[:], sig), curve.Name)
+		bad := *sig
+		bad.S = new(big.Int).Add(sig.S, big.NewInt(1))
+		require.False(t, Verify(curve, pub, digest[:], &bad), curve.Name)
+	}
+
+	// P-256 signatures verify with the standard library
+	curve := curves.P256()
+	secret := curve.Scalar.Random(crand.Reader)
+	sig, err := Sign(curve, secret, digest[:])
+	require.NoError(t, err)
+	pub := curve.ScalarBaseMult(secret).ToAffineUncompressed()
+	key := &stdecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(pub[1:33]), Y: new(big.Int).SetBytes(pub[33:])}
+	require.True(t, stdecdsa.Verify(key, digest[:], sig.R, sig.S))
+
+	_, err = Sign(curves.ED25519(), curves.ED25519().Scalar.Random(crand.Reader), digest[:])
+	require.Error(t, err)
+}
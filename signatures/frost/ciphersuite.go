@@ -0,0 +1,168 @@
+package frost
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"hash"
+	"math/big"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/curves/native"
+)
+
+// Ciphersuite binds FROST to a prime order group and the hash functions H1 to H5
+// of https://www.rfc-editor.org/rfc/rfc9591.html#section-6
+type Ciphersuite interface {
+	// Curve returns the group the signatures are computed in
+	Curve() *curves.Curve
+	// H1 derives binding factors
+	H1(m []byte) curves.Scalar
+	// H2 derives the signature challenge
+	H2(m []byte) curves.Scalar
+	// H3 derives nonces
+	H3(m []byte) curves.Scalar
+	// H4 hashes the message
+	H4(m []byte) []byte
+	// H5 hashes the commitment list
+	H5(m []byte) []byte
+	// SerializeElement encodes a group element
+	SerializeElement(p curves.Point) []byte
+	// SerializeScalar encodes a scalar
+	SerializeScalar(s curves.Scalar) []byte
+}
+
+const (
+	contextEd25519   = "FROST-ED25519-SHA512-v1"
+	contextSecp256k1 = "FROST-secp256k1-SHA256-v1"
+	contextP256      = "FROST-P256-SHA256-v1"
+)
+
+// Ed25519Sha512 is FROST(Ed25519, SHA-512), its signatures verify with crypto/ed25519
+type Ed25519Sha512 struct{}
+
+func (Ed25519Sha512) Curve() *curves.Curve {
+	return curves.ED25519()
+}
+
+func (Ed25519Sha512) H1(m []byte) curves.Scalar {
+	return ed25519HashToScalar([]byte(contextEd25519+"rho"), m)
+}
+
+// H2 omits the context string so the challenge matches RFC 8032
+func (Ed25519Sha512) H2(m []byte) curves.Scalar {
+	return ed25519HashToScalar(nil, m)
+}
+
+func (Ed25519Sha512) H3(m []byte) curves.Scalar {
+	return ed25519HashToScalar([]byte(contextEd25519+"nonce"), m)
+}
+
+func (Ed25519Sha512) H4(m []byte) []byte {
+	return prefixedHash(sha512.New(), []byte(contextEd25519+"msg"), m)
+}
+
+func (Ed25519Sha512) H5(m []byte) []byte {
+	return prefixedHash(sha512.New(), []byte(contextEd25519+"com"), m)
+}
+
+func (Ed25519Sha512) SerializeElement(p curves.Point) []byte {
+	return p.ToAffineCompressed()
+}
+
+// SerializeScalar returns the 32 byte little endian scalar
+func (Ed25519Sha512) SerializeScalar(s curves.Scalar) []byte {
+	return s.Bytes()
+}
+
+// Secp256k1Sha256 is FROST(secp256k1, SHA-256)
+type Secp256k1Sha256 struct{}
+
+func (Secp256k1Sha256) Curve() *curves.Curve {
+	return curves.K256()
+}
+
+func (c Secp256k1Sha256) H1(m []byte) curves.Scalar {
+	return xmdHashToScalar(c.Curve(), m, []byte(contextSecp256k1+"rho"))
+}
+
+func (c Secp256k1Sha256) H2(m []byte) curves.Scalar {
+	return xmdHashToScalar(c.Curve(), m, []byte(contextSecp256k1+"chal"))
+}
+
+func (c Secp256k1Sha256) H3(m []byte) curves.Scalar {
+	return xmdHashToScalar(c.Curve(), m, []byte(contextSecp256k1+"nonce"))
+}
+
+func (Secp256k1Sha256) H4(m []byte) []byte {
+	return prefixedHash(sha256.New(), []byte(contextSecp256k1+"msg"), m)
+}
+
+func (Secp256k1Sha256) H5(m []byte) []byte {
+	return prefixedHash(sha256.New(), []byte(contextSecp256k1+"com"), m)
+}
+
+// SerializeElement returns the 33 byte compressed SEC1 encoding
+func (Secp256k1Sha256) SerializeElement(p curves.Point) []byte {
+	return p.ToAffineCompressed()
+}
+
+// SerializeScalar returns the 32 byte big endian scalar
+func (Secp256k1Sha256) SerializeScalar(s curves.Scalar) []byte {
+	return s.Bytes()
+}
+
+// P256Sha256 is FROST(P-256, SHA-256)
+type P256Sha256 struct{}
+
+func (P256Sha256) Curve() *curves.Curve {
+	return curves.P256()
+}
+
+func (c P256Sha256) H1(m []byte) curves.Scalar {
+	return xmdHashToScalar(c.Curve(), m, []byte(contextP256+"rho"))
+}
+
+func (c P256Sha256) H2(m []byte) curves.Scalar {
+	return xmdHashToScalar(c.Curve(), m, []byte(contextP256+"chal"))
+}
+
+func (c P256Sha256) H3(m []byte) curves.Scalar {
+	return xmdHashToScalar(c.Curve(), m, []byte(contextP256+"nonce"))
+}
+
+func (P256Sha256) H4(m []byte) []byte {
+	return prefixedHash(sha256.New(), []byte(contextP256+"msg"), m)
+}
+
+func (P256Sha256) H5(m []byte) []byte {
+	return prefixedHash(sha256.New(), []byte(contextP256+"com"), m)
+}
+
+// SerializeElement returns the 33 byte compressed SEC1 encoding
+func (P256Sha256) SerializeElement(p curves.Point) []byte {
+	return p.ToAffineCompressed()
+}
+
+// SerializeScalar returns the 32 byte big endian scalar
+func (P256Sha256) SerializeScalar(s curves.Scalar) []byte {
+	return s.Bytes()
+}
+
+func prefixedHash(h hash.Hash, prefix, m []byte) []byte {
+	_, _ = h.Write(prefix)
+	_, _ = h.Write(m)
+	return h.Sum(nil)
+}
+
+// ed25519HashToScalar reduces the little endian SHA-512 digest of prefix || m
+func ed25519HashToScalar(prefix, m []byte) curves.Scalar {
+	s, _ := new(curves.ScalarEd25519).SetBytesWide(prefixedHash(sha512.New(), prefix, m))
+	return s
+}
+
+// xmdHashToScalar is hash_to_field from RFC 9380 with expand_message_xmd(SHA-256) and L = 48
+func xmdHashToScalar(curve *curves.Curve, m, dst []byte) curves.Scalar {
+	xmd := native.ExpandMsgXmd(native.EllipticPointHasherSha256(), m, dst, 48)
+	s, _ := curve.Scalar.SetBigInt(new(big.Int).SetBytes(xmd))
+	return s
+}
@@ -0,0 +1,144 @@
+// Package frost implements the two-round FROST threshold Schnorr signing
+// protocol of RFC 9591 https://www.rfc-editor.org/rfc/rfc9591.html
+//
+// Key shares come from a trusted dealer (TrustedDealerKeyGen) or from the
+// distributed key generation in dkg/frost (KeyPackageFromDkg). Signing takes
+// two rounds between at least threshold signers and a coordinator:
+//  1. each signer calls Commit and sends its Commitment to the coordinator
+//  2. the coordinator sends the message and all commitments to the signers,
+//     each signer calls Sign and returns its signature share
+//
+// The coordinator then combines the shares with Aggregate. With the
+// Ed25519Sha512 ciphersuite the result is an RFC 8032 Ed25519 signature.
+package frost
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-sonr/crypto/core/curves"
+	dkg "github.com/go-sonr/crypto/dkg/frost"
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/sharing"
+)
+
+// KeyPackage is the long lived key material of one signer
+type KeyPackage struct {
+	Identifier     uint32
+	SecretShare    curves.Scalar
+	VerifyingShare curves.Point
+	GroupKey       curves.Point
+	Threshold      uint32
+}
+
+// PublicKeyPackage is the public key material the coordinator needs to check signature shares
+type PublicKeyPackage struct {
+	VerifyingShares map[uint32]curves.Point
+	GroupKey        curves.Point
+}
+
+// TrustedDealerKeyGen splits secret into limit shares of which threshold are
+// needed to sign. A nil secret draws a random signing key.
+func TrustedDealerKeyGen(suite Ciphersuite, secret curves.Scalar, threshold, limit uint32, reader io.Reader) ([]*KeyPackage, *PublicKeyPackage, error) {
+	if suite == nil || reader == nil {
+		return nil, nil, internal.ErrNilArguments
+	}
+	curve := suite.Curve()
+	if secret == nil {
+		secret = curve.Scalar.Random(reader)
+	}
+	if secret.IsZero() {
+		return nil, nil, internal.ErrZeroValue
+	}
+	feldman, err := sharing.NewFeldman(threshold, limit, curve)
+	if err != nil {
+		return nil, nil, err
+	}
+	verifier, shares, err := feldman.Split(secret, reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pub := &PublicKeyPackage{
+		VerifyingShares: make(map[uint32]curves.Point, len(shares)),
+		GroupKey:        verifier.Commitments[0],
+	}
+	packages := make([]*KeyPackage, len(shares))
+	for i, share := range shares {
+		if err := verifier.Verify(share); err != nil {
+			return nil, nil, err
+		}
+		sk, err := curve.Scalar.SetBytes(share.Value)
+		if err != nil {
+			return nil, nil, err
+		}
+		packages[i] = &KeyPackage{
+			Identifier:     share.Id,
+			SecretShare:    sk,
+			VerifyingShare: curve.BaseMultTable().Mul(sk),
+			GroupKey:       pub.GroupKey,
+			Threshold:      threshold,
+		}
+		pub.VerifyingShares[share.Id] = packages[i].VerifyingShare
+	}
+	return packages, pub, nil
+}
+
+// KeyPackageFromDkg returns the key package of a participant that completed both dkg/frost rounds
+func KeyPackageFromDkg(participant *dkg.DkgParticipant, threshold uint32) (*KeyPackage, error) {
+	if participant == nil || participant.SkShare == nil || participant.VkShare == nil || participant.VerificationKey == nil {
+		return nil, internal.ErrNilArguments
+	}
+	return &KeyPackage{
+		Identifier:     participant.Id,
+		SecretShare:    participant.SkShare,
+		VerifyingShare: participant.VkShare,
+		GroupKey:       participant.VerificationKey,
+		Threshold:      threshold,
+	}, nil
+}
+
+// Verify checks a signature produced by Aggregate against the group key
+func Verify(suite Ciphersuite, groupKey curves.Point, msg, sig []byte) error {
+	if suite == nil || groupKey == nil {
+		return internal.ErrNilArguments
+	}
+	r, z, err := decodeSignature(suite, sig)
+	if err != nil {
+		return err
+	}
+	c := challenge(suite, r, groupKey, msg)
+	curve := suite.Curve()
+	// z * G - c * PK == R
+	lhs := curve.SumOfProducts([]curves.Point{curve.NewGeneratorPoint(), groupKey}, []curves.Scalar{z, c.Neg()})
+	if lhs == nil || !lhs.Equal(r) {
+		return fmt.Errorf("invalid signature")
+	}
+	return nil
+}
+
+func decodeSignature(suite Ciphersuite, sig []byte) (curves.Point, curves.Scalar, error) {
+	curve := suite.Curve()
+	elementLen := len(suite.SerializeElement(curve.Point.Generator()))
+	scalarLen := len(suite.SerializeScalar(curve.Scalar.One()))
+	if len(sig) != elementLen+scalarLen {
+		return nil, nil, fmt.Errorf("invalid signature length")
+	}
+	r, err := curve.Point.FromAffineCompressed(sig[:elementLen])
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid signature commitment: %w", err)
+	}
+	if r.IsIdentity() {
+		return nil, nil, fmt.Errorf("invalid signature commitment")
+	}
+	z, err := curve.Scalar.SetBytes(sig[elementLen:])
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid signature response: %w", err)
+	}
+	return r, z, nil
+}
+
+func challenge(suite Ciphersuite, r, groupKey curves.Point, msg []byte) curves.Scalar {
+	input := append(suite.SerializeElement(r), suite.SerializeElement(groupKey)...)
+	return suite.H2(append(input, msg...))
+}
@@ -0,0 +1,302 @@
+package frost
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	crand "crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+	dkg "github.com/go-sonr/crypto/dkg/frost"
+	"github.com/go-sonr/crypto/sharing"
+)
+
+func signWith(t *testing.T, suite Ciphersuite, keys []*KeyPackage, pub *PublicKeyPackage, msg []byte) []byte {
+	signers := make([]*Signer, len(keys))
+	commitments := make([]*Commitment, len(keys))
+	for i, key := range keys {
+		var err error
+		signers[i], err = NewSigner(suite, key)
+		require.NoError(t, err)
+		commitments[i], err = signers[i].Commit(crand.Reader)
+		require.NoError(t, err)
+	}
+
+	shares := make(map[uint32]curves.Scalar, len(keys))
+	for i, signer := range signers {
+		share, err := signer.Sign(msg, commitments)
+		require.NoError(t, err)
+		require.NoError(t, VerifySignatureShare(suite, pub, keys[i].Identifier, share, msg, commitments))
+		shares[keys[i].Identifier] = share
+
+		// nonces are single use
+		_, err = signer.Sign(msg, commitments)
+		require.Error(t, err)
+	}
+
+	sig, err := Aggregate(suite, pub, msg, commitments, shares)
+	require.NoError(t, err)
+	require.NoError(t, Verify(suite, pub.GroupKey, msg, sig))
+	return sig
+}
+
+func TestEd25519TwoOfThree(t *testing.T) {
+	suite := Ed25519Sha512{}
+	keys, pub, err := TrustedDealerKeyGen(suite, nil, 2, 3, crand.Reader)
+	require.NoError(t, err)
+	require.Len(t, keys, 3)
+
+	msg := []byte("threshold ed25519")
+	for _, signers := range [][]*KeyPackage{{keys[0], keys[1]}, {keys[0], keys[2]}, {keys[2], keys[1]}} {
+		sig := signWith(t, suite, signers, pub, msg)
+		// the aggregate signature is a plain RFC 8032 signature
+		require.True(t, ed25519.Verify(pub.GroupKey.ToAffineCompressed(), msg, sig))
+		require.False(t, ed25519.Verify(pub.GroupKey.ToAffineCompressed(), []byte("other"), sig))
+	}
+}
+
+func TestOtherCiphersuites(t *testing.T) {
+	for _, suite := range []Ciphersuite{Secp256k1Sha256{}, P256Sha256{}} {
+		keys, pub, err := TrustedDealerKeyGen(suite, nil, 3, 5, crand.Reader)
+		require.NoError(t, err)
+		msg := []byte("threshold schnorr")
+		sig := signWith(t, suite, []*KeyPackage{keys[4], keys[1], keys[2]}, pub, msg)
+		require.Error(t, Verify(suite, pub.GroupKey, []byte("other"), sig))
+	}
+}
+
+func TestKeyPackageFromDkg(t *testing.T) {
+	const ctx = "frost signing test"
+	curve := curves.ED25519()
+	p1, err := dkg.NewDkgParticipant(1, 2, ctx, curve, 2)
+	require.NoError(t, err)
+	p2, err := dkg.NewDkgParticipant(2, 2, ctx, curve, 1)
+	require.NoError(t, err)
+	bcast1, p2psend1, err := p1.Round1(nil)
+	require.NoError(t, err)
+	bcast2, p2psend2, err := p2.Round1(nil)
+	require.NoError(t, err)
+	bcast := map[uint32]*dkg.Round1Bcast{1: bcast1, 2: bcast2}
+	_, err = p1.Round2(bcast, map[uint32]*sharing.ShamirShare{2: p2psend2[1]})
+	require.NoError(t, err)
+	_, err = p2.Round2(bcast, map[uint32]*sharing.ShamirShare{1: p2psend1[2]})
+	require.NoError(t, err)
+
+	k1, err := KeyPackageFromDkg(p1, 2)
+	require.NoError(t, err)
+	k2, err := KeyPackageFromDkg(p2, 2)
+	require.NoError(t, err)
+	pub := &PublicKeyPackage{
+		VerifyingShares: map[uint32]curves.Point{1: k1.VerifyingShare, 2: k2.VerifyingShare},
+		GroupKey:        k1.GroupKey,
+	}
+
+	msg := []byte("dkg keys")
+	sig := signWith(t, Ed25519Sha512{}, []*KeyPackage{k1, k2}, pub, msg)
+	require.True(t, ed25519.Verify(pub.GroupKey.ToAffineCompressed(), msg, sig))
+}
+
+func TestAggregateRejectsBadShare(t *testing.T) {
+	suite := Ed25519Sha512{}
+	keys, pub, err := TrustedDealerKeyGen(suite, nil, 2, 3, crand.Reader)
+	require.NoError(t, err)
+
+	msg := []byte("msg")
+	s1, err := NewSigner(suite, keys[0])
+	require.NoError(t, err)
+	s2, err := NewSigner(suite, keys[1])
+	require.NoError(t, err)
+	c1, err := s1.Commit(crand.Reader)
+	require.NoError(t, err)
+	c2, err := s2.Commit(crand.Reader)
+	require.NoError(t, err)
+	commitments := []*Commitment{c1, c2}
+
+	z1, err := s1.Sign(msg, commitments)
+	require.NoError(t, err)
+	z2, err := s2.Sign(msg, commitments)
+	require.NoError(t, err)
+
+	bad := z2.Add(suite.Curve().Scalar.One())
+	require.Error(t, VerifySignatureShare(suite, pub, keys[1].Identifier, bad, msg, commitments))
+	_, err = Aggregate(suite, pub, msg, commitments, map[uint32]curves.Scalar{1: z1, 2: bad})
+	require.Error(t, err)
+	_, err = Aggregate(suite, pub, msg, commitments, map[uint32]curves.Scalar{1: z1})
+	require.Error(t, err)
+}
+
+func TestSignRejectsBadCommitments(t *testing.T) {
+	suite := Ed25519Sha512{}
+	keys, _, err := TrustedDealerKeyGen(suite, nil, 2, 3, crand.Reader)
+	require.NoError(t, err)
+	s1, err := NewSigner(suite, keys[0])
+	require.NoError(t, err)
+	s2, err := NewSigner(suite, keys[1])
+	require.NoError(t, err)
+
+	// below the threshold
+	c1, err := s1.Commit(crand.Reader)
+	require.NoError(t, err)
+	_, err = s1.Sign([]byte("msg"), []*Commitment{c1})
+	require.Error(t, err)
+
+	// own commitment missing
+	c1, err = s1.Commit(crand.Reader)
+	require.NoError(t, err)
+	c2, err := s2.Commit(crand.Reader)
+	require.NoError(t, err)
+	c3 := &Commitment{Identifier: 3, Hiding: c2.Hiding, Binding: c2.Binding}
+	_, err = s1.Sign([]byte("msg"), []*Commitment{c2, c3})
+	require.Error(t, err)
+
+	// without Commit
+	_, err = s1.Sign([]byte("msg"), []*Commitment{c1, c2})
+	require.Error(t, err)
+}
+
+func unhex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+// rfc9591Vector is a 2-of-3 trusted dealer vector of RFC 9591 appendix E,
+// signed by participants 1 and 3 over the message "test"
+type rfc9591Vector struct {
+	suite             Ciphersuite
+	groupSecretKey    string
+	groupPublicKey    string
+	coefficient       string
+	shares            [3]string
+	nonceRandomness   map[uint32][2]string
+	nonces            map[uint32][2]string
+	hidingCommitments map[uint32]string
+	sigShares         map[uint32]string
+	sig               string
+}
+
+// rfc9591Keys rebuilds the dealer's key packages from the vector polynomial
+func rfc9591Keys(t *testing.T, v *rfc9591Vector) (map[uint32]*KeyPackage, *PublicKeyPackage) {
+	curve := v.suite.Curve()
+	secret, err := curve.Scalar.SetBytes(unhex(t, v.groupSecretKey))
+	require.NoError(t, err)
+	coefficient, err := curve.Scalar.SetBytes(unhex(t, v.coefficient))
+	require.NoError(t, err)
+	groupKey := curve.ScalarBaseMult(secret)
+	require.Equal(t, unhex(t, v.groupPublicKey), v.suite.SerializeElement(groupKey))
+
+	keys := make(map[uint32]*KeyPackage, len(v.shares))
+	pub := &PublicKeyPackage{VerifyingShares: make(map[uint32]curves.Point, len(v.shares)), GroupKey: groupKey}
+	for i, expected := range v.shares {
+		id := uint32(i + 1)
+		share := secret.Add(coefficient.Mul(curve.Scalar.New(int(id))))
+		require.Equal(t, unhex(t, expected), v.suite.SerializeScalar(share))
+		keys[id] = &KeyPackage{
+			Identifier:     id,
+			SecretShare:    share,
+			VerifyingShare: curve.ScalarBaseMult(share),
+			GroupKey:       groupKey,
+			Threshold:      2,
+		}
+		pub.VerifyingShares[id] = keys[id].VerifyingShare
+	}
+	return keys, pub
+}
+
+func TestRFC9591Vectors(t *testing.T) {
+	vectors := []*rfc9591Vector{
+		// appendix E.1, FROST(Ed25519, SHA-512)
+		{
+			suite:          Ed25519Sha512{},
+			groupSecretKey: "7b1c33d3f5291d85de664833beb1ad469f7fb6025a0ec78b3a790c6e13a98304",
+			groupPublicKey: "15d21ccd7ee42959562fc8aa63224c8851fb3ec85a3faf66040d380fb9738673",
+			coefficient:    "178199860edd8c62f5212ee91eff1295d0d670ab4ed4506866bae57e7030b204",
+			shares: [3]string{
+				"929dcc590407aae7d388761cddb0c0db6f5627aea8e217f4a033f2ec83d93509",
+				"a91e66e012e4364ac9aaa405fcafd370402d9859f7b6685c07eed76bf409e80d",
+				"d3cb090a075eb154e82fdb4b3cb507f110040905468bb9c46da8bdea643a9a02",
+			},
+			nonceRandomness: map[uint32][2]string{
+				1: {"0fd2e39e111cdc266f6c0f4d0fd45c947761f1f5d3cb583dfcb9bbaf8d4c9fec", "69cd85f631d5f7f2721ed5e40519b1366f340a87c2f6856363dbdcda348a7501"},
+				3: {"86d64a260059e495d0fb4fcc17ea3da7452391baa494d4b00321098ed2a0062f", "13e6b25afb2eba51716a9a7d44130c0dbae0004a9ef8d7b5550c8a0e07c61775"},
+			},
+			nonces: map[uint32][2]string{
+				1: {"812d6104142944d5a55924de6d49940956206909f2acaeedecda2b726e630407", "b1110165fc2334149750b28dd813a39244f315cff14d4e89e6142f262ed83301"},
+			},
+			hidingCommitments: map[uint32]string{
+				1: "b5aa8ab305882a6fc69cbee9327e5a45e54c08af61ae77cb8207be3d2ce13de3",
+			},
+			sigShares: map[uint32]string{
+				1: "001719ab5a53ee1a12095cd088fd149702c0720ce5fd2f29dbecf24b7281b603",
+				3: "bd86125de990acc5e1f13781d8e32c03a9bbd4c53539bbc106058bfd14326007",
+			},
+			sig: "36282629c383bb820a88b71cae937d41f2f2adfcc3d02e55507e2fb9e2dd3cbe" +
+				"bd9d2b0844e49ae0f3fa935161e1419aab7b47d21a37ebeae1f17d4987b3160b",
+		},
+		// appendix E.4, FROST(P-256, SHA-256), key generation
+		{
+			suite:          P256Sha256{},
+			groupSecretKey: "8ba9bba2e0fd8c4767154d35a0b7562244a4aaf6f36c8fb8735fa48b301bd8de",
+			groupPublicKey: "023a309ad94e9fe8a7ba45dfc58f38bf091959d3c99cfbd02b4dc00585ec45ab70",
+			coefficient:    "80f25e6c0709353e46bfbe882a11bdbb1f8097e46340eb8673b7e14556e6c3a4",
+			shares: [3]string{
+				"0c9c1a0fe806c184add50bbdcac913dda73e482daf95dcb9f35dbb0d8a9f7731",
+				"8d8e787bef0ff6c2f494ca45f4dad198c6bee01212d6c84067159c52e1863ad5",
+				"0e80d6e8f6192c003b5488ce1eec8f5429587d48cf001541e713b2d53c09d928",
+			},
+		},
+		// appendix E.5, FROST(secp256k1, SHA-256), key generation
+		{
+			suite:          Secp256k1Sha256{},
+			groupSecretKey: "0d004150d27c3bf2a42f312683d35fac7394b1e9e318249c1bfe7f0795a83114",
+			groupPublicKey: "02f37c34b66ced1fb51c34a90bdae006901f10625cc06c4f64663b0eae87d87b4f",
+			coefficient:    "fbf85eadae3058ea14f19148bb72b45e4399c0b16028acaf0395c9b03c823579",
+			shares: [3]string{
+				"08f89ffe80ac94dcb920c26f3f46140bfc7f95b493f8310f5fc1ea2b01f4254c",
+				"04f0feac2edcedc6ce1253b7fab8c86b856a797f44d83d82a385554e6e401984",
+				"00e95d59dd0d46b0e303e500b62b7ccb0e555d49f5b849f5e748c071da8c0dbc",
+			},
+		},
+	}
+	msg := []byte("test")
+	for _, v := range vectors {
+		keys, pub := rfc9591Keys(t, v)
+		if v.sig == "" {
+			continue
+		}
+
+		signers := make(map[uint32]*Signer, len(v.nonceRandomness))
+		var commitments []*Commitment
+		for _, id := range []uint32{1, 3} {
+			signer, err := NewSigner(v.suite, keys[id])
+			require.NoError(t, err)
+			randomness := v.nonceRandomness[id]
+			commitment, err := signer.Commit(bytes.NewReader(append(unhex(t, randomness[0]), unhex(t, randomness[1])...)))
+			require.NoError(t, err)
+			if nonces, ok := v.nonces[id]; ok {
+				require.Equal(t, unhex(t, nonces[0]), v.suite.SerializeScalar(signer.hiding))
+				require.Equal(t, unhex(t, nonces[1]), v.suite.SerializeScalar(signer.binding))
+			}
+			if hiding, ok := v.hidingCommitments[id]; ok {
+				require.Equal(t, unhex(t, hiding), v.suite.SerializeElement(commitment.Hiding))
+			}
+			signers[id] = signer
+			commitments = append(commitments, commitment)
+		}
+
+		shares := make(map[uint32]curves.Scalar, len(signers))
+		for id, signer := range signers {
+			share, err := signer.Sign(msg, commitments)
+			require.NoError(t, err)
+			require.Equal(t, unhex(t, v.sigShares[id]), v.suite.SerializeScalar(share))
+			shares[id] = share
+		}
+		sig, err := Aggregate(v.suite, pub, msg, commitments, shares)
+		require.NoError(t, err)
+		require.Equal(t, unhex(t, v.sig), sig)
+		require.NoError(t, Verify(v.suite, pub.GroupKey, msg, sig))
+	}
+}
@@ -0,0 +1,275 @@
+package frost
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/internal"
+)
+
+// Commitment is the pair of nonce commitments a signer publishes in round 1
+type Commitment struct {
+	Identifier uint32
+	Hiding     curves.Point
+	Binding    curves.Point
+}
+
+// Signer holds a signer's key package and the nonces of its pending signature
+type Signer struct {
+	suite   Ciphersuite
+	key     *KeyPackage
+	hiding  curves.Scalar
+	binding curves.Scalar
+	commit  *Commitment
+}
+
+// NewSigner creates a signer for key using suite
+func NewSigner(suite Ciphersuite, key *KeyPackage) (*Signer, error) {
+	if suite == nil || key == nil || key.SecretShare == nil || key.GroupKey == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if key.Identifier == 0 {
+		return nil, fmt.Errorf("invalid identifier")
+	}
+	return &Signer{suite: suite, key: key}, nil
+}
+
+// Commit draws fresh nonces and returns their commitment, see RFC 9591 section 5.1
+func (s *Signer) Commit(reader io.Reader) (*Commitment, error) {
+	if reader == nil {
+		return nil, internal.ErrNilArguments
+	}
+	hiding, err := s.nonceGenerate(reader)
+	if err != nil {
+		return nil, err
+	}
+	binding, err := s.nonceGenerate(reader)
+	if err != nil {
+		return nil, err
+	}
+	curve := s.suite.Curve()
+	s.hiding, s.binding = hiding, binding
+	s.commit = &Commitment{
+		Identifier: s.key.Identifier,
+		Hiding:     curve.BaseMultTable().Mul(hiding),
+		Binding:    curve.BaseMultTable().Mul(binding),
+	}
+	return s.commit, nil
+}
+
+// Sign returns this signer's share of the signature over msg, see RFC 9591
+// section 5.2. The nonces are erased, a new Commit is needed for the next message.
+func (s *Signer) Sign(msg []byte, commitments []*Commitment) (curves.Scalar, error) {
+	if s.hiding == nil || s.binding == nil {
+		return nil, internal.ErrInvalidRound
+	}
+	hiding, binding, own := s.hiding, s.binding, s.commit
+	s.hiding, s.binding, s.commit = nil, nil, nil
+
+	list, err := sortCommitments(commitments, s.key.Threshold)
+	if err != nil {
+		return nil, err
+	}
+	found := false
+	for _, c := range list {
+		if c.Identifier == own.Identifier {
+			if !c.Hiding.Equal(own.Hiding) || !c.Binding.Equal(own.Binding) {
+				return nil, fmt.Errorf("commitment of signer %d was altered", own.Identifier)
+			}
+			found = true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("signer %d is not in the commitment list", own.Identifier)
+	}
+
+	bindingFactors := computeBindingFactors(s.suite, s.key.GroupKey, list, msg)
+	r := groupCommitment(s.suite, list, bindingFactors)
+	lambda, err := interpolatingValue(s.suite.Curve(), list, s.key.Identifier)
+	if err != nil {
+		return nil, err
+	}
+	c := challenge(s.suite, r, s.key.GroupKey, msg)
+
+	// z_i = d_i + e_i * rho_i + lambda_i * s_i * c
+	share := hiding.Add(binding.Mul(bindingFactors[s.key.Identifier]))
+	return share.Add(lambda.Mul(s.key.SecretShare).Mul(c)), nil
+}
+
+// VerifySignatureShare checks the share of signer id against its verifying share, see RFC 9591 section 5.4
+func VerifySignatureShare(suite Ciphersuite, pub *PublicKeyPackage, id uint32, share curves.Scalar, msg []byte, commitments []*Commitment) error {
+	if suite == nil || pub == nil || share == nil {
+		return internal.ErrNilArguments
+	}
+	list, err := sortCommitments(commitments, 0)
+	if err != nil {
+		return err
+	}
+	return verifyShare(suite, pub, id, share, msg, list, computeBindingFactors(suite, pub.GroupKey, list, msg))
+}
+
+// Aggregate combines the signature shares into a signature serialized as
+// SerializeElement(R) || SerializeScalar(z). Every share is checked against
+// its verifying share first so a misbehaving signer is identified.
+func Aggregate(suite Ciphersuite, pub *PublicKeyPackage, msg []byte, commitments []*Commitment, shares map[uint32]curves.Scalar) ([]byte, error) {
+	if suite == nil || pub == nil || pub.GroupKey == nil {
+		return nil, internal.ErrNilArguments
+	}
+	list, err := sortCommitments(commitments, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(shares) != len(list) {
+		return nil, internal.ErrIncorrectCount
+	}
+	bindingFactors := computeBindingFactors(suite, pub.GroupKey, list, msg)
+	z := suite.Curve().Scalar.Zero()
+	for _, c := range list {
+		share, ok := shares[c.Identifier]
+		if !ok || share == nil {
+			return nil, fmt.Errorf("missing signature share of signer %d", c.Identifier)
+		}
+		if err := verifyShare(suite, pub, c.Identifier, share, msg, list, bindingFactors); err != nil {
+			return nil, err
+		}
+		z = z.Add(share)
+	}
+	r := groupCommitment(suite, list, bindingFactors)
+	return append(suite.SerializeElement(r), suite.SerializeScalar(z)...), nil
+}
+
+// GroupCommitment returns the commitment R of the signature over msg by the signers of commitments, see RFC 9591 section 4.5
+func GroupCommitment(suite Ciphersuite, groupKey curves.Point, msg []byte, commitments []*Commitment) (curves.Point, error) {
+	if suite == nil || groupKey == nil {
+		return nil, internal.ErrNilArguments
+	}
+	list, err := sortCommitments(commitments, 0)
+	if err != nil {
+		return nil, err
+	}
+	return groupCommitment(suite, list, computeBindingFactors(suite, groupKey, list, msg)), nil
+}
+
+func verifyShare(suite Ciphersuite, pub *PublicKeyPackage, id uint32, share curves.Scalar, msg []byte, list []*Commitment, bindingFactors map[uint32]curves.Scalar) error {
+	vk, ok := pub.VerifyingShares[id]
+	if !ok {
+		return fmt.Errorf("unknown signer %d", id)
+	}
+	var commitment *Commitment
+	for _, c := range list {
+		if c.Identifier == id {
+			commitment = c
+		}
+	}
+	if commitment == nil {
+		return fmt.Errorf("signer %d is not in the commitment list", id)
+	}
+	curve := suite.Curve()
+	lambda, err := interpolatingValue(curve, list, id)
+	if err != nil {
+		return err
+	}
+	r := groupCommitment(suite, list, bindingFactors)
+	c := challenge(suite, r, pub.GroupKey, msg)
+
+	// z_i * G == D_i + rho_i * E_i + c * lambda_i * PK_i
+	ri := curve.SumOfProducts(
+		[]curves.Point{commitment.Hiding, commitment.Binding, vk},
+		[]curves.Scalar{curve.Scalar.One(), bindingFactors[id], c.Mul(lambda)},
+	)
+	if ri == nil || !curve.ScalarBaseMult(share).Equal(ri) {
+		return fmt.Errorf("invalid signature share of signer %d", id)
+	}
+	return nil
+}
+
+// nonceGenerate is nonce_generate of RFC 9591 section 4.1
+func (s *Signer) nonceGenerate(reader io.Reader) (curves.Scalar, error) {
+	var random [32]byte
+	if _, err := io.ReadFull(reader, random[:]); err != nil {
+		return nil, err
+	}
+	k := s.suite.H3(append(random[:], s.suite.SerializeScalar(s.key.SecretShare)...))
+	if k.IsZero() {
+		return nil, internal.ErrZeroValue
+	}
+	return k, nil
+}
+
+// sortCommitments validates the commitment list and orders it by identifier
+func sortCommitments(commitments []*Commitment, threshold uint32) ([]*Commitment, error) {
+	if uint32(len(commitments)) < threshold || len(commitments) == 0 {
+		return nil, fmt.Errorf("not enough commitments")
+	}
+	list := make([]*Commitment, len(commitments))
+	copy(list, commitments)
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Identifier < list[j].Identifier
+	})
+	for i, c := range list {
+		if c == nil || c.Hiding == nil || c.Binding == nil {
+			return nil, internal.ErrNilArguments
+		}
+		if c.Identifier == 0 || (i > 0 && list[i-1].Identifier == c.Identifier) {
+			return nil, fmt.Errorf("invalid or duplicate identifier %d", c.Identifier)
+		}
+		if c.Hiding.IsIdentity() || c.Binding.IsIdentity() {
+			return nil, fmt.Errorf("commitment of signer %d is the identity", c.Identifier)
+		}
+	}
+	return list, nil
+}
+
+// computeBindingFactors is compute_binding_factors of RFC 9591 section 4.4
+func computeBindingFactors(suite Ciphersuite, groupKey curves.Point, list []*Commitment, msg []byte) map[uint32]curves.Scalar {
+	curve := suite.Curve()
+	var encoded []byte
+	for _, c := range list {
+		encoded = append(encoded, suite.SerializeScalar(curve.Scalar.New(int(c.Identifier)))...)
+		encoded = append(encoded, suite.SerializeElement(c.Hiding)...)
+		encoded = append(encoded, suite.SerializeElement(c.Binding)...)
+	}
+	prefix := suite.SerializeElement(groupKey)
+	prefix = append(prefix, suite.H4(msg)...)
+	prefix = append(prefix, suite.H5(encoded)...)
+
+	factors := make(map[uint32]curves.Scalar, len(list))
+	for _, c := range list {
+		input := append(append([]byte{}, prefix...), suite.SerializeScalar(curve.Scalar.New(int(c.Identifier)))...)
+		factors[c.Identifier] = suite.H1(input)
+	}
+	return factors
+}
+
+// groupCommitment is compute_group_commitment of RFC 9591 section 4.5
+func groupCommitment(suite Ciphersuite, list []*Commitment, bindingFactors map[uint32]curves.Scalar) curves.Point {
+	curve := suite.Curve()
+	points := make([]curves.Point, 0, 2*len(list))
+	scalars := make([]curves.Scalar, 0, 2*len(list))
+	for _, c := range list {
+		points = append(points, c.Hiding, c.Binding)
+		scalars = append(scalars, curve.Scalar.One(), bindingFactors[c.Identifier])
+	}
+	return curve.SumOfProducts(points, scalars)
+}
+
+// interpolatingValue is the Lagrange coefficient of id at zero over the signers in list
+func interpolatingValue(curve *curves.Curve, list []*Commitment, id uint32) (curves.Scalar, error) {
+	xi := curve.Scalar.New(int(id))
+	num := curve.Scalar.One()
+	den := curve.Scalar.One()
+	for _, c := range list {
+		if c.Identifier == id {
+			continue
+		}
+		xj := curve.Scalar.New(int(c.Identifier))
+		num = num.Mul(xj)
+		den = den.Mul(xj.Sub(xi))
+	}
+	if den.IsZero() {
+		return nil, fmt.Errorf("divide by zero")
+	}
+	return num.Div(den), nil
+}
@@ -0,0 +1,171 @@
+// Package musig2 is an implementation of the MuSig2 multi-signature scheme for
+// secp256k1 Schnorr signatures as specified in BIP-327
+// https://github.com/bitcoin/bips/blob/master/bip-0327.mediawiki
+//
+// The aggregate signatures are ordinary BIP-340 signatures and verify against
+// the x-only aggregate public key with any BIP-340 verifier.
+//
+// Signing takes two rounds:
+//  1. every signer runs NonceGen and broadcasts its PublicNonce
+//  2. every signer aggregates the public nonces with NonceAgg, builds a Session
+//     and broadcasts its partial signature from Sign
+//
+// Any party can then check the partial signatures with PartialSigVerify and
+// combine them with PartialSigAgg.
+package musig2
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/signatures/schnorr/bip340"
+)
+
+const (
+	tagKeyAggList        = "KeyAgg list"
+	tagKeyAggCoefficient = "KeyAgg coefficient"
+	tagAux               = "MuSig/aux"
+	tagNonce             = "MuSig/nonce"
+	tagNonceCoef         = "MuSig/noncecoef"
+	tagChallenge         = "BIP0340/challenge"
+)
+
+// KeyAggContext holds the aggregate public key and the accumulated tweaks of a signer set
+type KeyAggContext struct {
+	curve *curves.Curve
+	pks   [][]byte // compressed public keys in signing order
+	q     curves.Point
+	gacc  curves.Scalar
+	tacc  curves.Scalar
+	// key aggregation coefficient inputs
+	keysHash  []byte
+	secondKey []byte
+}
+
+// KeySort sorts compressed public keys lexicographically, which makes the
+// aggregate key independent of the order the keys were collected in
+func KeySort(pks [][]byte) [][]byte {
+	sorted := make([][]byte, len(pks))
+	copy(sorted, pks)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i], sorted[j]) < 0
+	})
+	return sorted
+}
+
+// KeyAgg aggregates the 33 byte compressed public keys of the signers.
+// The order of pks matters, see KeySort.
+func KeyAgg(pks [][]byte) (*KeyAggContext, error) {
+	if len(pks) == 0 {
+		return nil, internal.ErrNilArguments
+	}
+	curve := curves.K256()
+	ctx := &KeyAggContext{
+		curve: curve,
+		pks:   make([][]byte, len(pks)),
+		gacc:  curve.Scalar.One(),
+		tacc:  curve.Scalar.Zero(),
+	}
+	points := make([]curves.Point, len(pks))
+	for i, pk := range pks {
+		p, err := parsePoint(pk)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key of signer %d: %w", i, err)
+		}
+		points[i] = p
+		ctx.pks[i] = p.ToAffineCompressed()
+	}
+
+	ctx.keysHash = bip340.TaggedHash(tagKeyAggList, ctx.pks...)
+	for _, pk := range ctx.pks[1:] {
+		if !bytes.Equal(pk, ctx.pks[0]) {
+			ctx.secondKey = pk
+			break
+		}
+	}
+
+	coeffs := make([]curves.Scalar, len(points))
+	for i, pk := range ctx.pks {
+		coeffs[i] = ctx.coefficient(pk)
+	}
+	ctx.q = curve.Point.SumOfProducts(points, coeffs)
+	if ctx.q.IsIdentity() {
+		return nil, fmt.Errorf("aggregate public key is the point at infinity")
+	}
+	return ctx, nil
+}
+
+// PublicKey returns the aggregate public key including tweaks
+func (ctx *KeyAggContext) PublicKey() curves.Point {
+	return ctx.q
+}
+
+// XOnlyPublicKey returns the 32 byte BIP-340 encoding of the aggregate public key
+func (ctx *KeyAggContext) XOnlyPublicKey() []byte {
+	return bip340.XOnly(ctx.q)
+}
+
+// ApplyTweak adds tweak * G to the aggregate public key. An x-only tweak is
+// applied to the even y variant of the key, as done for taproot outputs.
+func (ctx *KeyAggContext) ApplyTweak(tweak []byte, xonly bool) error {
+	if len(tweak) != 32 {
+		return fmt.Errorf("invalid tweak length")
+	}
+	t, err := ctx.curve.Scalar.SetBytes(tweak)
+	if err != nil {
+		return fmt.Errorf("tweak exceeds the group order")
+	}
+	g := ctx.curve.Scalar.One()
+	if xonly && !bip340.HasEvenY(ctx.q) {
+		g = g.Neg()
+	}
+	q := ctx.q.Mul(g).Add(ctx.curve.ScalarBaseMult(t))
+	if q.IsIdentity() {
+		return fmt.Errorf("tweaked public key is the point at infinity")
+	}
+	ctx.q = q
+	ctx.gacc = g.Mul(ctx.gacc)
+	ctx.tacc = t.Add(g.Mul(ctx.tacc))
+	return nil
+}
+
+// coefficient returns the key aggregation coefficient of the compressed key pk
+func (ctx *KeyAggContext) coefficient(pk []byte) curves.Scalar {
+	if ctx.secondKey != nil && bytes.Equal(pk, ctx.secondKey) {
+		return ctx.curve.Scalar.One()
+	}
+	return hashToScalar(ctx.curve, bip340.TaggedHash(tagKeyAggCoefficient, ctx.keysHash, pk))
+}
+
+// contains reports whether the compressed key pk is one of the signers
+func (ctx *KeyAggContext) contains(pk []byte) bool {
+	for _, p := range ctx.pks {
+		if bytes.Equal(p, pk) {
+			return true
+		}
+	}
+	return false
+}
+
+// hashToScalar interprets a 32 byte big endian digest as a scalar reduced by the group order
+func hashToScalar(curve *curves.Curve, digest []byte) curves.Scalar {
+	s, _ := curve.Scalar.SetBigInt(new(big.Int).SetBytes(digest))
+	return s
+}
+
+// parsePoint decodes a compressed point, rejecting encodings that are not on the curve
+func parsePoint(b []byte) (curves.Point, error) {
+	p, err := curves.K256().Point.FromAffineCompressed(b)
+	if err != nil {
+		return nil, err
+	}
+	// points that are not on the curve decode to the identity
+	if p.IsIdentity() {
+		return nil, fmt.Errorf("point is not on the curve")
+	}
+	return p, nil
+}
@@ -0,0 +1,254 @@
+package musig2
+
+import (
+	"bytes"
+	crand "crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/signatures/schnorr/bip340"
+)
+
+func unhex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+// Test vectors from https://github.com/bitcoin/bips/blob/master/bip-0327/vectors
+var (
+	vectorPubKeys = []string{
+		"02F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9",
+		"03DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		"023590A94E768F8E1815C2F24B4D80A8E3149316C3518CE7B7AD338368D038CA66",
+		"020000000000000000000000000000000000000000000000000000000000000005",
+	}
+	signSk      = "7FB9E0E687ADA1EEBF7ECFE2F21E73EBDB51A7D450948DFE8D76D7F2D1007671"
+	signPubKeys = []string{
+		"03935F972DA013F80AE011890FA89B67A27B7BE6CCB24D3274D18B2D4067F261A9",
+		"02F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9",
+		"02DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA661",
+	}
+	signSecNonce = "508B81A611F100A6B2B6B29656590898AF488BCF2E1F55CF22E5CFB84421FE61FA27FD49B1D50085B481285E1CA205D55C82CC1B31FF5CD54A489829355901F7"
+	signAggNonce = "028465FCF0BBDBCF443AABCCE533D42B4B5A10966AC09A49655E8C42DAAB8FCD61037496A3CC86926D452CAFCFD55D25972CA1675D549310DE296BFF42F72EEEA8C9"
+	signMsg      = "F95466D086770E689964664219266FE5ED215C92AE20BAB5C9D79ADDDDF3C0CF"
+)
+
+func TestKeyAggVectors(t *testing.T) {
+	tests := []struct {
+		indices  []int
+		expected string
+	}{
+		{[]int{0, 1, 2}, "90539EEDE565F5D054F32CC0C220126889ED1E5D193BAF15AEF344FE59D4610C"},
+		{[]int{2, 1, 0}, "6204DE8B083426DC6EAF9502D27024D53FC826BF7D2012148A0575435DF54B2B"},
+		{[]int{0, 0, 0}, "B436E3BAD62B8CD409969A224731C193D051162D8C5AE8B109306127DA3AA935"},
+		{[]int{0, 0, 1, 1}, "69BC22BFA5D106306E48A20679DE1D7389386124D07571D0D872686028C26A3E"},
+	}
+	for _, test := range tests {
+		pks := make([][]byte, len(test.indices))
+		for i, idx := range test.indices {
+			pks[i] = unhex(t, vectorPubKeys[idx])
+		}
+		ctx, err := KeyAgg(pks)
+		require.NoError(t, err)
+		require.Equal(t, unhex(t, test.expected), ctx.XOnlyPublicKey())
+	}
+
+	// x = 5 is not on the curve
+	_, err := KeyAgg([][]byte{unhex(t, vectorPubKeys[0]), unhex(t, vectorPubKeys[3])})
+	require.Error(t, err)
+}
+
+func TestKeySort(t *testing.T) {
+	pks := [][]byte{unhex(t, vectorPubKeys[1]), unhex(t, vectorPubKeys[0]), unhex(t, vectorPubKeys[2])}
+	sorted := KeySort(pks)
+	require.Equal(t, unhex(t, vectorPubKeys[2]), sorted[0])
+	require.Equal(t, unhex(t, vectorPubKeys[0]), sorted[1])
+	require.Equal(t, unhex(t, vectorPubKeys[1]), sorted[2])
+	// the input is left untouched
+	require.Equal(t, unhex(t, vectorPubKeys[1]), pks[0])
+}
+
+func TestNonceGenVector(t *testing.T) {
+	curve := curves.K256()
+	sk, err := curve.Scalar.SetBytes(unhex(t, "0202020202020202020202020202020202020202020202020202020202020202"))
+	require.NoError(t, err)
+	pk := unhex(t, "024D4B6CD1361032CA9BD2AEB9D900AA4D45D9EAD80AC9423374C451A7254D0766")
+	aggpk := unhex(t, "0707070707070707070707070707070707070707070707070707070707070707")
+	msg := unhex(t, "0101010101010101010101010101010101010101010101010101010101010101")
+	extra := unhex(t, "0808080808080808080808080808080808080808080808080808080808080808")
+
+	secnonce, pubnonce, err := NonceGen(pk, sk, aggpk, msg, extra, bytes.NewReader(make([]byte, 32)))
+	require.NoError(t, err)
+	expected := unhex(t, "227243DCB40EF2A13A981DB188FA433717B506BDFA14B1AE47D5DC027C9C3B9EF2370B2AD206E724243215137C86365699361126991E6FEC816845F837BDDAC3")
+	require.Equal(t, expected[:32], secnonce.k1.Bytes())
+	require.Equal(t, expected[32:], secnonce.k2.Bytes())
+	require.Equal(t, pk, secnonce.pk)
+
+	decoded, err := PublicNonceFromBytes(pubnonce.Bytes())
+	require.NoError(t, err)
+	require.True(t, decoded.R1.Equal(pubnonce.R1))
+	require.True(t, decoded.R2.Equal(pubnonce.R2))
+}
+
+func TestSignVectors(t *testing.T) {
+	curve := curves.K256()
+	sk, err := curve.Scalar.SetBytes(unhex(t, signSk))
+	require.NoError(t, err)
+	aggnonce, err := AggregateNonceFromBytes(unhex(t, signAggNonce))
+	require.NoError(t, err)
+
+	tests := []struct {
+		indices  []int
+		expected string
+	}{
+		{[]int{0, 1, 2}, "012ABBCB52B3016AC03AD82395A1A415C48B93DEF78718E62A7A90052FE224FB"},
+		{[]int{1, 0, 2}, "9FF2F7AAA856150CC8819254218D3ADEEB0535269051897724F9DB3789513A52"},
+		{[]int{1, 2, 0}, "FA23C359F6FAC4E7796BB93BC9F0532A95468C539BA20FF86D7C76ED92227900"},
+	}
+	for _, test := range tests {
+		pks := make([][]byte, len(test.indices))
+		for i, idx := range test.indices {
+			pks[i] = unhex(t, signPubKeys[idx])
+		}
+		keyAgg, err := KeyAgg(pks)
+		require.NoError(t, err)
+		session, err := NewSession(aggnonce, keyAgg, unhex(t, signMsg))
+		require.NoError(t, err)
+
+		k := unhex(t, signSecNonce)
+		k1, err := curve.Scalar.SetBytes(k[:32])
+		require.NoError(t, err)
+		k2, err := curve.Scalar.SetBytes(k[32:])
+		require.NoError(t, err)
+		secnonce := &SecretNonce{k1: k1, k2: k2, pk: unhex(t, signPubKeys[0])}
+
+		psig, err := session.Sign(secnonce, sk)
+		require.NoError(t, err)
+		require.Equal(t, unhex(t, test.expected), psig.Bytes())
+
+		_, err = session.Sign(secnonce, sk)
+		require.Error(t, err)
+	}
+}
+
+func runSigning(t *testing.T, sks []curves.Scalar, keyAgg *KeyAggContext, msg []byte) []byte {
+	curve := curves.K256()
+	secnonces := make([]*SecretNonce, len(sks))
+	pubnonces := make([]*PublicNonce, len(sks))
+	pks := make([][]byte, len(sks))
+	for i, sk := range sks {
+		pks[i] = curve.ScalarBaseMult(sk).ToAffineCompressed()
+		var err error
+		secnonces[i], pubnonces[i], err = NonceGen(pks[i], sk, keyAgg.XOnlyPublicKey(), msg, nil, crand.Reader)
+		require.NoError(t, err)
+	}
+	aggnonce, err := NonceAgg(pubnonces)
+	require.NoError(t, err)
+
+	psigs := make([]curves.Scalar, len(sks))
+	for i, sk := range sks {
+		session, err := NewSession(aggnonce, keyAgg, msg)
+		require.NoError(t, err)
+		psigs[i], err = session.Sign(secnonces[i], sk)
+		require.NoError(t, err)
+	}
+
+	session, err := NewSession(aggnonce, keyAgg, msg)
+	require.NoError(t, err)
+	for i := range psigs {
+		require.NoError(t, session.PartialSigVerify(psigs[i], pubnonces[i], pks[i]))
+	}
+	// a partial signature does not verify for another signer
+	require.Error(t, session.PartialSigVerify(psigs[0], pubnonces[1], pks[1]))
+
+	sig, err := session.PartialSigAgg(psigs)
+	require.NoError(t, err)
+	require.Len(t, sig, SignatureSize)
+	return sig
+}
+
+func sha256Sum(b []byte) []byte {
+	h := sha256.Sum256(b)
+	return h[:]
+}
+
+func verifyBIP340(t *testing.T, sig, msg, xonly []byte) bool {
+	pk, err := schnorr.ParsePubKey(xonly)
+	require.NoError(t, err)
+	parsed, err := schnorr.ParseSignature(sig)
+	require.NoError(t, err)
+	return parsed.Verify(msg, pk)
+}
+
+func TestSigningRoundTrip(t *testing.T) {
+	curve := curves.K256()
+	sks := make([]curves.Scalar, 3)
+	pks := make([][]byte, 3)
+	for i := range sks {
+		sks[i] = curve.Scalar.Random(crand.Reader)
+		pks[i] = curve.ScalarBaseMult(sks[i]).ToAffineCompressed()
+	}
+	keyAgg, err := KeyAgg(KeySort(pks))
+	require.NoError(t, err)
+
+	msg := bytes.Repeat([]byte{0x42}, 32)
+	sig := runSigning(t, sks, keyAgg, msg)
+	require.True(t, verifyBIP340(t, sig, msg, keyAgg.XOnlyPublicKey()))
+
+	pk, err := bip340.ParsePublicKey(keyAgg.XOnlyPublicKey())
+	require.NoError(t, err)
+	parsed := new(bip340.Signature)
+	require.NoError(t, parsed.UnmarshalBinary(sig))
+	require.NoError(t, pk.Verify(msg, parsed))
+
+	msg[0] ^= 1
+	require.False(t, verifyBIP340(t, sig, msg, keyAgg.XOnlyPublicKey()))
+}
+
+func TestSigningWithTweaks(t *testing.T) {
+	curve := curves.K256()
+	sks := make([]curves.Scalar, 2)
+	pks := make([][]byte, 2)
+	for i := range sks {
+		sks[i] = curve.Scalar.Random(crand.Reader)
+		pks[i] = curve.ScalarBaseMult(sks[i]).ToAffineCompressed()
+	}
+	keyAgg, err := KeyAgg(pks)
+	require.NoError(t, err)
+	require.NoError(t, keyAgg.ApplyTweak(curve.Scalar.Random(crand.Reader).Bytes(), false))
+	require.NoError(t, keyAgg.ApplyTweak(curve.Scalar.Random(crand.Reader).Bytes(), true))
+	require.Error(t, keyAgg.ApplyTweak(bytes.Repeat([]byte{0xff}, 32), true))
+
+	// BIP-340 verifiers in the wild only accept 32 byte messages
+	msg := sha256Sum([]byte("taproot key path spend"))
+	sig := runSigning(t, sks, keyAgg, msg)
+	require.True(t, verifyBIP340(t, sig, msg, keyAgg.XOnlyPublicKey()))
+}
+
+func TestSignRejectsForeignKey(t *testing.T) {
+	curve := curves.K256()
+	sks := []curves.Scalar{curve.Scalar.Random(crand.Reader), curve.Scalar.Random(crand.Reader)}
+	pks := [][]byte{
+		curve.ScalarBaseMult(sks[0]).ToAffineCompressed(),
+		curve.ScalarBaseMult(sks[1]).ToAffineCompressed(),
+	}
+	keyAgg, err := KeyAgg(pks)
+	require.NoError(t, err)
+
+	outsider := curve.Scalar.Random(crand.Reader)
+	outsiderPk := curve.ScalarBaseMult(outsider).ToAffineCompressed()
+	secnonce, pubnonce, err := NonceGen(outsiderPk, outsider, nil, nil, nil, nil)
+	require.NoError(t, err)
+	aggnonce, err := NonceAgg([]*PublicNonce{pubnonce, pubnonce})
+	require.NoError(t, err)
+	session, err := NewSession(aggnonce, keyAgg, []byte("msg"))
+	require.NoError(t, err)
+	_, err = session.Sign(secnonce, outsider)
+	require.Error(t, err)
+}
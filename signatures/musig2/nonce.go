@@ -0,0 +1,182 @@
+package musig2
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/signatures/schnorr/bip340"
+)
+
+const (
+	// PublicNonceSize is the size of a serialized PublicNonce or AggregateNonce
+	PublicNonceSize = 66
+	// pointSize is the size of a compressed secp256k1 point
+	pointSize = 33
+)
+
+// SecretNonce is the secret part of a signer's nonce. It is erased by Sign so
+// that it can never be used for a second signature.
+type SecretNonce struct {
+	k1, k2 curves.Scalar
+	pk     []byte // compressed public key of the signer
+}
+
+// PublicNonce is the nonce commitment a signer broadcasts in the first round
+type PublicNonce struct {
+	R1, R2 curves.Point
+}
+
+// AggregateNonce is the sum of the public nonces of all signers. Unlike a
+// PublicNonce its points may be the identity.
+type AggregateNonce struct {
+	R1, R2 curves.Point
+}
+
+// NonceGen creates a fresh signing nonce for the signer with public key pk.
+// sk, aggpk, msg and extra are optional and only strengthen the derivation
+// against a faulty reader. reader defaults to crypto/rand.
+func NonceGen(pk []byte, sk curves.Scalar, aggpk, msg, extra []byte, reader io.Reader) (*SecretNonce, *PublicNonce, error) {
+	if _, err := parsePoint(pk); err != nil {
+		return nil, nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	if len(aggpk) != 0 && len(aggpk) != 32 {
+		return nil, nil, fmt.Errorf("invalid aggregate public key length")
+	}
+	if reader == nil {
+		reader = crand.Reader
+	}
+	var seed [32]byte
+	if _, err := io.ReadFull(reader, seed[:]); err != nil {
+		return nil, nil, err
+	}
+
+	rand := seed[:]
+	if sk != nil {
+		aux := bip340.TaggedHash(tagAux, seed[:])
+		rand = sk.Bytes()
+		for i := range rand {
+			rand[i] ^= aux[i]
+		}
+	}
+
+	var msgPrefixed []byte
+	if msg == nil {
+		msgPrefixed = []byte{0}
+	} else {
+		msgPrefixed = make([]byte, 9, 9+len(msg))
+		msgPrefixed[0] = 1
+		binary.BigEndian.PutUint64(msgPrefixed[1:], uint64(len(msg)))
+		msgPrefixed = append(msgPrefixed, msg...)
+	}
+	var extraLen [4]byte
+	binary.BigEndian.PutUint32(extraLen[:], uint32(len(extra)))
+
+	curve := curves.K256()
+	k := make([]curves.Scalar, 2)
+	for i := range k {
+		digest := bip340.TaggedHash(tagNonce,
+			rand,
+			[]byte{byte(len(pk))}, pk,
+			[]byte{byte(len(aggpk))}, aggpk,
+			msgPrefixed,
+			extraLen[:], extra,
+			[]byte{byte(i)},
+		)
+		k[i] = hashToScalar(curve, digest)
+		if k[i].IsZero() {
+			return nil, nil, fmt.Errorf("derived a zero nonce")
+		}
+	}
+
+	secnonce := &SecretNonce{k1: k[0], k2: k[1], pk: append([]byte{}, pk...)}
+	pubnonce := &PublicNonce{
+		R1: curve.ScalarBaseMult(k[0]),
+		R2: curve.ScalarBaseMult(k[1]),
+	}
+	return secnonce, pubnonce, nil
+}
+
+// Bytes serializes the nonce as two compressed points
+func (n *PublicNonce) Bytes() []byte {
+	out := make([]byte, 0, PublicNonceSize)
+	out = append(out, n.R1.ToAffineCompressed()...)
+	return append(out, n.R2.ToAffineCompressed()...)
+}
+
+// PublicNonceFromBytes decodes a PublicNonce created with PublicNonce.Bytes
+func PublicNonceFromBytes(b []byte) (*PublicNonce, error) {
+	if len(b) != PublicNonceSize {
+		return nil, fmt.Errorf("invalid public nonce length")
+	}
+	r1, err := parsePoint(b[:pointSize])
+	if err != nil {
+		return nil, fmt.Errorf("invalid public nonce: %w", err)
+	}
+	r2, err := parsePoint(b[pointSize:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid public nonce: %w", err)
+	}
+	return &PublicNonce{R1: r1, R2: r2}, nil
+}
+
+// NonceAgg sums the public nonces of all signers
+func NonceAgg(pubnonces []*PublicNonce) (*AggregateNonce, error) {
+	if len(pubnonces) == 0 {
+		return nil, fmt.Errorf("no public nonces to aggregate")
+	}
+	curve := curves.K256()
+	agg := &AggregateNonce{
+		R1: curve.NewIdentityPoint(),
+		R2: curve.NewIdentityPoint(),
+	}
+	for i, n := range pubnonces {
+		if n == nil || n.R1 == nil || n.R2 == nil {
+			return nil, fmt.Errorf("missing public nonce of signer %d", i)
+		}
+		agg.R1 = agg.R1.Add(n.R1)
+		agg.R2 = agg.R2.Add(n.R2)
+	}
+	return agg, nil
+}
+
+// Bytes serializes the nonce as two compressed points, encoding the identity as 33 zero bytes
+func (n *AggregateNonce) Bytes() []byte {
+	out := make([]byte, 0, PublicNonceSize)
+	out = append(out, encodePointOrInfinity(n.R1)...)
+	return append(out, encodePointOrInfinity(n.R2)...)
+}
+
+// AggregateNonceFromBytes decodes an AggregateNonce created with AggregateNonce.Bytes
+func AggregateNonceFromBytes(b []byte) (*AggregateNonce, error) {
+	if len(b) != PublicNonceSize {
+		return nil, fmt.Errorf("invalid aggregate nonce length")
+	}
+	r1, err := decodePointOrInfinity(b[:pointSize])
+	if err != nil {
+		return nil, fmt.Errorf("invalid aggregate nonce: %w", err)
+	}
+	r2, err := decodePointOrInfinity(b[pointSize:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid aggregate nonce: %w", err)
+	}
+	return &AggregateNonce{R1: r1, R2: r2}, nil
+}
+
+func encodePointOrInfinity(p curves.Point) []byte {
+	if p.IsIdentity() {
+		return make([]byte, pointSize)
+	}
+	return p.ToAffineCompressed()
+}
+
+func decodePointOrInfinity(b []byte) (curves.Point, error) {
+	for _, c := range b {
+		if c != 0 {
+			return parsePoint(b)
+		}
+	}
+	return curves.K256().NewIdentityPoint(), nil
+}
@@ -0,0 +1,153 @@
+package musig2
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/signatures/schnorr/bip340"
+)
+
+// SignatureSize is the size of a BIP-340 signature
+const SignatureSize = 64
+
+// Session holds the values shared by all signers of one message after the nonce exchange
+type Session struct {
+	keyAgg *KeyAggContext
+	b      curves.Scalar // nonce coefficient
+	r      curves.Point  // final nonce
+	e      curves.Scalar // BIP-340 challenge
+}
+
+// NewSession derives the signing session of msg from the aggregate nonce of all signers
+func NewSession(aggnonce *AggregateNonce, keyAgg *KeyAggContext, msg []byte) (*Session, error) {
+	if aggnonce == nil || keyAgg == nil {
+		return nil, internal.ErrNilArguments
+	}
+	curve := keyAgg.curve
+	qx := keyAgg.XOnlyPublicKey()
+	b := hashToScalar(curve, bip340.TaggedHash(tagNonceCoef, aggnonce.Bytes(), qx, msg))
+
+	r := aggnonce.R1.Add(aggnonce.R2.Mul(b))
+	// a malicious signer can force the nonce to infinity, the generator keeps the protocol running
+	if r.IsIdentity() {
+		r = curve.NewGeneratorPoint()
+	}
+	e := hashToScalar(curve, bip340.TaggedHash(tagChallenge, bip340.XOnly(r), qx, msg))
+	return &Session{
+		keyAgg: keyAgg,
+		b:      b,
+		r:      r,
+		e:      e,
+	}, nil
+}
+
+// Sign creates the partial signature of the signer with secret key sk. The
+// secret nonce is erased, calling Sign twice with the same nonce fails.
+func (s *Session) Sign(secnonce *SecretNonce, sk curves.Scalar) (curves.Scalar, error) {
+	if secnonce == nil || sk == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if secnonce.k1 == nil || secnonce.k2 == nil {
+		return nil, fmt.Errorf("secret nonce has already been used")
+	}
+	k1, k2 := secnonce.k1, secnonce.k2
+	secnonce.k1, secnonce.k2 = nil, nil
+	defer curves.Zeroize(k1, k2)
+
+	curve := s.keyAgg.curve
+	if sk.IsZero() {
+		return nil, internal.ErrZeroValue
+	}
+	pk := curve.ScalarBaseMult(sk).ToAffineCompressed()
+	if !bytes.Equal(pk, secnonce.pk) {
+		return nil, fmt.Errorf("secret key does not match the nonce's public key")
+	}
+	if !s.keyAgg.contains(pk) {
+		return nil, fmt.Errorf("signer is not part of the key aggregation")
+	}
+
+	// the public nonce lets the signature be checked before it is released
+	r1, r2 := curve.ScalarBaseMult(k1), curve.ScalarBaseMult(k2)
+	if !bip340.HasEvenY(s.r) {
+		k1, k2 = k1.Neg(), k2.Neg()
+		defer curves.Zeroize(k1, k2)
+	}
+	a := s.keyAgg.coefficient(pk)
+	d := s.keyGParity().Mul(sk)
+
+	// s = k1 + b * k2 + e * a * d
+	psig := k1.Add(s.b.Mul(k2)).Add(s.e.Mul(a).Mul(d))
+
+	pubnonce := &PublicNonce{R1: r1, R2: r2}
+	if err := s.PartialSigVerify(psig, pubnonce, pk); err != nil {
+		return nil, err
+	}
+	return psig, nil
+}
+
+// PartialSigVerify checks the partial signature of the signer with public nonce
+// pubnonce and compressed public key pk
+func (s *Session) PartialSigVerify(psig curves.Scalar, pubnonce *PublicNonce, pk []byte) error {
+	if psig == nil || pubnonce == nil {
+		return internal.ErrNilArguments
+	}
+	p, err := parsePoint(pk)
+	if err != nil {
+		return fmt.Errorf("invalid public key: %w", err)
+	}
+	if !s.keyAgg.contains(p.ToAffineCompressed()) {
+		return fmt.Errorf("signer is not part of the key aggregation")
+	}
+	curve := s.keyAgg.curve
+
+	re := pubnonce.R1.Add(pubnonce.R2.Mul(s.b))
+	if !bip340.HasEvenY(s.r) {
+		re = re.Neg()
+	}
+	a := s.keyAgg.coefficient(p.ToAffineCompressed())
+	g := s.keyGParity()
+
+	// s * G == Re + e * a * g * P
+	lhs := curve.ScalarBaseMult(psig)
+	rhs := re.Add(p.Mul(s.e.Mul(a).Mul(g)))
+	if !lhs.Equal(rhs) {
+		return fmt.Errorf("invalid partial signature")
+	}
+	return nil
+}
+
+// PartialSigAgg combines the partial signatures of all signers into a BIP-340 signature
+func (s *Session) PartialSigAgg(psigs []curves.Scalar) ([]byte, error) {
+	if len(psigs) == 0 {
+		return nil, internal.ErrNilArguments
+	}
+	curve := s.keyAgg.curve
+	sum := curve.Scalar.Zero()
+	for i, psig := range psigs {
+		if psig == nil {
+			return nil, fmt.Errorf("missing partial signature of signer %d", i)
+		}
+		sum = sum.Add(psig)
+	}
+	g := curve.Scalar.One()
+	if !bip340.HasEvenY(s.keyAgg.q) {
+		g = g.Neg()
+	}
+	sum = sum.Add(s.e.Mul(g).Mul(s.keyAgg.tacc))
+
+	sig := make([]byte, 0, SignatureSize)
+	sig = append(sig, bip340.XOnly(s.r)...)
+	return append(sig, sum.Bytes()...), nil
+}
+
+// keyGParity returns g * gacc, the sign applied to the secret keys so the
+// signature verifies against the even y aggregate key
+func (s *Session) keyGParity() curves.Scalar {
+	g := s.keyAgg.gacc
+	if !bip340.HasEvenY(s.keyAgg.q) {
+		g = g.Neg()
+	}
+	return g
+}
@@ -0,0 +1,148 @@
+// Package nonce generates the secret nonces of ECDSA, Schnorr and EdDSA
+// signatures.
+//
+// A nonce that repeats, or is biased, reveals the signing key. Deterministic
+// nonces of RFC 6979 depend only on the key and the message, so a broken
+// random number generator cannot leak the key, and hedged nonces add fresh
+// randomness as the additional data of RFC 6979 section 3.6, so they stay
+// safe under a broken generator while resisting fault attacks that exploit
+// fully deterministic signing. Hedged is the default of every signer that
+// takes a Generator.
+package nonce
+
+import (
+	"crypto/hmac"
+	crand "crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"io"
+	"math/big"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/secret"
+	"github.com/go-sonr/crypto/internal"
+)
+
+// hedgeSize is the size of the fresh randomness of hedged nonces
+const hedgeSize = 32
+
+// Generator produces the nonce of a signature by secret over digest, the
+// hash of the message or the signature's own binding of it
+type Generator interface {
+	Nonce(curve *curves.Curve, secret curves.Scalar, digest []byte) (curves.Scalar, error)
+}
+
+// Default returns the hedged generator with SHA-256 and crypto/rand
+func Default() Generator {
+	return Hedged(sha256.New, crand.Reader)
+}
+
+type random struct {
+	reader io.Reader
+}
+
+// Random returns a generator of uniformly random nonces from reader, safe
+// only while reader is
+func Random(reader io.Reader) Generator {
+	return &random{reader: reader}
+}
+
+func (g *random) Nonce(curve *curves.Curve, secret curves.Scalar, digest []byte) (curves.Scalar, error) {
+	if curve == nil || g.reader == nil {
+		return nil, internal.ErrNilArguments
+	}
+	k := curve.Scalar.Random(g.reader)
+	if k == nil || k.IsZero() {
+		return nil, fmt.Errorf("nonce: failed to draw a nonce")
+	}
+	return k, nil
+}
+
+type deterministic struct {
+	hash   func() hash.Hash
+	reader io.Reader
+}
+
+// Deterministic returns the generator of RFC 6979 with HMAC over h
+func Deterministic(h func() hash.Hash) Generator {
+	return &deterministic{hash: h}
+}
+
+// Hedged returns the generator of RFC 6979 with HMAC over h and 32 bytes
+// from reader as additional data
+func Hedged(h func() hash.Hash, reader io.Reader) Generator {
+	return &deterministic{hash: h, reader: reader}
+}
+
+func (g *deterministic) Nonce(curve *curves.Curve, secret curves.Scalar, digest []byte) (curves.Scalar, error) {
+	if g.reader == nil {
+		return RFC6979(curve, g.hash, secret, digest, nil)
+	}
+	var extra [hedgeSize]byte
+	if _, err := io.ReadFull(g.reader, extra[:]); err != nil {
+		return nil, err
+	}
+	return RFC6979(curve, g.hash, secret, digest, extra[:])
+}
+
+// RFC6979 returns the nonce of RFC 6979 section 3.2 for secret and digest,
+// with extra as the additional data k' of section 3.6 when not empty
+func RFC6979(curve *curves.Curve, h func() hash.Hash, x curves.Scalar, digest, extra []byte) (curves.Scalar, error) {
+	if curve == nil || h == nil || x == nil || len(digest) == 0 {
+		return nil, internal.ErrNilArguments
+	}
+	if x.IsZero() {
+		return nil, internal.ErrZeroValue
+	}
+	q := curve.Scalar.One().Neg().BigInt()
+	q.Add(q, big.NewInt(1))
+	qlen := q.BitLen()
+	rlen := (qlen + 7) / 8
+
+	// bits2int keeps the leftmost qlen bits
+	bits2int := func(b []byte) *big.Int {
+		v := new(big.Int).SetBytes(b)
+		if n := len(b)*8 - qlen; n > 0 {
+			v.Rsh(v, uint(n))
+		}
+		return v
+	}
+	xOctets := x.BigInt().FillBytes(make([]byte, rlen))
+	defer secret.Wipe(xOctets)
+	z := bits2int(digest)
+	hOctets := z.Mod(z, q).FillBytes(make([]byte, rlen))
+
+	size := h().Size()
+	v := make([]byte, size)
+	k := make([]byte, size)
+	defer secret.Wipe(k)
+	for i := range v {
+		v[i] = 1
+	}
+	mac := func(key []byte, data ...[]byte) []byte {
+		m := hmac.New(h, key)
+		for _, d := range data {
+			_, _ = m.Write(d)
+		}
+		return m.Sum(nil)
+	}
+	k = mac(k, v, []byte{0}, xOctets, hOctets, extra)
+	v = mac(k, v)
+	k = mac(k, v, []byte{1}, xOctets, hOctets, extra)
+	v = mac(k, v)
+	for {
+		var t []byte
+		for len(t) < rlen {
+			v = mac(k, v)
+			t = append(t, v...)
+		}
+		candidate := bits2int(t[:rlen])
+		secret.Wipe(t)
+		if candidate.Sign() > 0 && candidate.Cmp(q) < 0 {
+			return curve.Scalar.SetBigInt(candidate)
+		}
+		k = mac(k, v, []byte{0})
+		v = mac(k, v)
+	}
+}
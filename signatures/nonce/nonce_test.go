@@ -0,0 +1,70 @@
+package nonce
+
+import (
+	"bytes"
+	crand "crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+)
+
+func TestRFC6979Vectors(t *testing.T) {
+	// RFC 6979 appendix A.2.5
+	curve := curves.P256()
+	x, ok := new(big.Int).SetString("C9AFA9D845BA75166B5C215767B1D6934E50C3DB36E89B127B8A622B120F6721", 16)
+	require.True(t, ok)
+	secret, err := curve.Scalar.SetBigInt(x)
+	require.NoError(t, err)
+
+	sample256 := sha256.Sum256([]byte("sample"))
+	test256 := sha256.Sum256([]byte("test"))
+	sample512 := sha512.Sum512([]byte("sample"))
+	for _, tc := range []struct {
+		gen    Generator
+		digest []byte
+		k      string
+	}{
+		{Deterministic(sha256.New), sample256[:], "a6e3c57dd01abe90086538398355dd4c3b17aa873382b0f24d6129493d8aad60"},
+		{Deterministic(sha256.New), test256[:], "d16b6ae827f17175e040871a1c7ec3500192c4c92677336ec2537acaee0008e0"},
+		{Deterministic(sha512.New), sample512[:], "5fa81c63109badb88c1f367b47da606da28cad69aa22c4fe6ad7df73a7173aa5"},
+	} {
+		k, err := tc.gen.Nonce(curve, secret, tc.digest)
+		require.NoError(t, err)
+		require.Equal(t, tc.k, hex.EncodeToString(k.Bytes()))
+	}
+}
+
+func TestHedged(t *testing.T) {
+	digest := sha256.Sum256([]byte("message"))
+	for _, curve := range []*curves.Curve{curves.K256(), curves.P256(), curves.ED25519(), curves.PALLAS(), curves.BLS12381G1()} {
+		secret := curve.Scalar.Random(crand.Reader)
+
+		a, err := Default().Nonce(curve, secret, digest[:])
+		require.NoError(t, err)
+		b, err := Default().Nonce(curve, secret, digest[:])
+		require.NoError(t, err)
+		require.NotEqual(t, a.Bytes(), b.Bytes(), curve.Name)
+
+		// a stuck random number generator still gives distinct nonces for
+		// distinct messages
+		stuck := Hedged(sha256.New, bytes.NewReader(make([]byte, 64)))
+		c, err := stuck.Nonce(curve, secret, digest[:])
+		require.NoError(t, err)
+		other := sha256.Sum256([]byte("other"))
+		d, err := stuck.Nonce(curve, secret, other[:])
+		require.NoError(t, err)
+		require.NotEqual(t, c.Bytes(), d.Bytes(), curve.Name)
+
+		// and fails rather than drop the randomness when it runs dry
+		_, err = Hedged(sha256.New, bytes.NewReader(nil)).Nonce(curve, secret, digest[:])
+		require.Error(t, err)
+	}
+	_, err := Deterministic(sha256.New).Nonce(curves.K256(), curves.K256().Scalar.Zero(), digest[:])
+	require.Error(t, err)
+}
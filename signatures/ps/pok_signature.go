@@ -0,0 +1,116 @@
+package ps
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/gtank/merlin"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/signatures/common"
+)
+
+// PokSignature a.k.a. Proof of Knowledge of a Signature
+// is used by the prover to convince a verifier
+// that they possess a valid signature and
+// can selectively disclose a set of signed messages
+type PokSignature struct {
+	// These values correspond to section 6.2 in <https://eprint.iacr.org/2015/525.pdf>
+	// σ' = (r·σ1, r·(σ2 + t·σ1)) is the randomized signature and
+	// j = t·g̃ + Σ m_i·Ỹ_i for all undisclosed messages m_i
+	sigma1, sigma2, j curves.PairingPoint
+	// proof for the opening of j
+	proof *common.ProofCommittedBuilder
+	// secrets are t followed by the undisclosed messages
+	secrets []curves.Scalar
+}
+
+// NewPokSignature creates the initial proof data before a Fiat-Shamir calculation
+func NewPokSignature(sig *Signature,
+	pk *PublicKey,
+	msgs []common.ProofMessage,
+	reader io.Reader,
+) (*PokSignature, error) {
+	if sig == nil || pk == nil {
+		return nil, fmt.Errorf("signature and public key cannot be nil")
+	}
+	if len(msgs) != len(pk.y) {
+		return nil, fmt.Errorf("mismatch messages and public key")
+	}
+	if sig.sigma1.IsIdentity() {
+		return nil, fmt.Errorf("invalid signature")
+	}
+
+	r := getNonZeroScalar(sig.sigma1.Scalar(), reader)
+	t := getNonZeroScalar(sig.sigma1.Scalar(), reader)
+
+	sigma1, ok := sig.sigma1.Mul(r).(curves.PairingPoint)
+	if !ok {
+		return nil, fmt.Errorf("invalid point")
+	}
+	// σ'2 = r·σ2 + t·σ'1
+	sigma2, ok := sigma1.SumOfProducts([]curves.Point{sig.sigma2, sigma1}, []curves.Scalar{r, t}).(curves.PairingPoint)
+	if !ok {
+		return nil, fmt.Errorf("invalid point")
+	}
+
+	curve := curves.Curve{
+		Scalar: t.Zero(),
+		Point:  pk.x.Identity(),
+	}
+	proof := common.NewProofCommittedBuilder(&curve)
+	// For g̃ * t
+	err := proof.CommitRandom(pk.x.Generator(), reader)
+	if err != nil {
+		return nil, err
+	}
+	points := make([]curves.Point, 1, len(msgs)+1)
+	secrets := make([]curves.Scalar, 1, len(msgs)+1)
+	points[0] = pk.x.Generator()
+	secrets[0] = t
+	for i, m := range msgs {
+		if m.IsHidden() {
+			err = proof.Commit(pk.y[i], m.GetBlinding(reader))
+			if err != nil {
+				return nil, err
+			}
+			points = append(points, pk.y[i])
+			secrets = append(secrets, m.GetMessage())
+		}
+	}
+	j, ok := pk.x.SumOfProducts(points, secrets).(curves.PairingPoint)
+	if !ok {
+		return nil, fmt.Errorf("invalid point")
+	}
+
+	return &PokSignature{
+		sigma1,
+		sigma2,
+		j,
+		proof,
+		secrets,
+	}, nil
+}
+
+// GetChallengeContribution returns the bytes that should be added to
+// a sigma protocol transcript for generating the challenge
+func (pok *PokSignature) GetChallengeContribution(transcript *merlin.Transcript) {
+	transcript.AppendMessage([]byte("sigma1'"), pok.sigma1.ToAffineCompressed())
+	transcript.AppendMessage([]byte("sigma2'"), pok.sigma2.ToAffineCompressed())
+	transcript.AppendMessage([]byte("J"), pok.j.ToAffineCompressed())
+	transcript.AppendMessage([]byte("Proof"), pok.proof.GetChallengeContribution())
+}
+
+// GenerateProof converts the blinding factors and secrets into Schnorr proofs
+func (pok *PokSignature) GenerateProof(challenge curves.Scalar) (*PokSignatureProof, error) {
+	proof, err := pok.proof.GenerateProof(challenge, pok.secrets)
+	if err != nil {
+		return nil, err
+	}
+	return &PokSignatureProof{
+		sigma1: pok.sigma1,
+		sigma2: pok.sigma2,
+		j:      pok.j,
+		proof:  proof,
+	}, nil
+}
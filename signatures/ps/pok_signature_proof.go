@@ -0,0 +1,158 @@
+package ps
+
+import (
+	"fmt"
+
+	"github.com/gtank/merlin"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/signatures/common"
+)
+
+// PokSignatureProof is the actual proof sent from a prover
+// to a verifier that contains a proof of knowledge of a signature
+// and the selective disclosure proof
+type PokSignatureProof struct {
+	sigma1, sigma2, j curves.PairingPoint
+	proof             []curves.Scalar
+}
+
+// Init creates an empty proof to a specific curve
+// which should be followed by UnmarshalBinary
+func (pok *PokSignatureProof) Init(curve *curves.PairingCurve) *PokSignatureProof {
+	pok.sigma1 = curve.NewG1IdentityPoint()
+	pok.sigma2 = pok.sigma1
+	pok.j = curve.NewG2IdentityPoint()
+	pok.proof = make([]curves.Scalar, 0)
+	return pok
+}
+
+func (pok *PokSignatureProof) MarshalBinary() ([]byte, error) {
+	data := append(pok.sigma1.ToAffineCompressed(), pok.sigma2.ToAffineCompressed()...)
+	data = append(data, pok.j.ToAffineCompressed()...)
+	for _, p := range pok.proof {
+		data = append(data, p.Bytes()...)
+	}
+	return data, nil
+}
+
+func (pok *PokSignatureProof) UnmarshalBinary(in []byte) error {
+	sc := pok.sigma1.Scalar()
+	scSize := len(sc.Bytes())
+	g1Size := len(pok.sigma1.ToAffineCompressed())
+	g2Size := len(pok.j.ToAffineCompressed())
+	ptSize := 2*g1Size + g2Size
+	inSize := len(in)
+	if inSize < ptSize+scSize || (inSize-ptSize)%scSize != 0 {
+		return fmt.Errorf("invalid byte sequence")
+	}
+	sigma1, err := pairingPoint(pok.sigma1, in[:g1Size])
+	if err != nil {
+		return err
+	}
+	sigma2, err := pairingPoint(pok.sigma2, in[g1Size:2*g1Size])
+	if err != nil {
+		return err
+	}
+	j, err := pairingPoint(pok.j, in[2*g1Size:ptSize])
+	if err != nil {
+		return err
+	}
+	proof := make([]curves.Scalar, (inSize-ptSize)/scSize)
+	for i := range proof {
+		offset := ptSize + i*scSize
+		proof[i], err = sc.SetBytes(in[offset : offset+scSize])
+		if err != nil {
+			return err
+		}
+	}
+	pok.sigma1 = sigma1
+	pok.sigma2 = sigma2
+	pok.j = j
+	pok.proof = proof
+	return nil
+}
+
+// GetChallengeContribution converts the committed values to bytes
+// for the Fiat-Shamir challenge
+func (pok PokSignatureProof) GetChallengeContribution(
+	pk *PublicKey,
+	revealedMessages map[int]curves.Scalar,
+	challenge common.Challenge,
+	transcript *merlin.Transcript,
+) {
+	transcript.AppendMessage([]byte("sigma1'"), pok.sigma1.ToAffineCompressed())
+	transcript.AppendMessage([]byte("sigma2'"), pok.sigma2.ToAffineCompressed())
+	transcript.AppendMessage([]byte("J"), pok.j.ToAffineCompressed())
+
+	pts := 2 + len(pk.y) - len(revealedMessages)
+	points := make([]curves.Point, 2, pts)
+	scalars := make([]curves.Scalar, 2, pts)
+
+	// J * -c
+	points[0] = pok.j
+	scalars[0] = challenge.Neg()
+
+	// g̃ * tHat
+	points[1] = pk.x.Generator()
+	scalars[1] = pok.proof[0]
+
+	j := 1
+	for i := range pk.y {
+		if _, contains := revealedMessages[i]; contains {
+			continue
+		}
+		points = append(points, pk.y[i])
+		scalars = append(scalars, pok.proof[j])
+		j++
+	}
+	commitment := pok.j.SumOfProducts(points, scalars)
+	transcript.AppendMessage([]byte("Proof"), commitment.ToAffineCompressed())
+}
+
+// VerifySigPok only validates the signature proof,
+// the selective disclosure proof is checked by
+// verifying
+// pok.challenge == computedChallenge
+func (pok PokSignatureProof) VerifySigPok(pk *PublicKey, revealedMessages map[int]curves.Scalar) bool {
+	if pk.check() != nil || pok.sigma1.IsIdentity() || len(pok.proof) == 0 {
+		return false
+	}
+	for idx := range revealedMessages {
+		if idx < 0 || idx >= len(pk.y) {
+			return false
+		}
+	}
+	points := make([]curves.Point, 2, len(revealedMessages)+2)
+	scalars := make([]curves.Scalar, 2, len(revealedMessages)+2)
+	points[0], points[1] = pk.x, pok.j
+	scalars[0], scalars[1] = pok.proof[0].One(), pok.proof[0].One()
+	for idx, msg := range revealedMessages {
+		points = append(points, pk.y[idx])
+		scalars = append(scalars, msg)
+	}
+	// e(σ'1, X̃ + Σ m_i·Ỹ_i + J) == e(σ'2, g̃)
+	a := pk.x.SumOfProducts(points, scalars).(curves.PairingPoint)
+	return pok.sigma1.MultiPairing(pok.sigma1, a, pok.sigma2, pk.x.Generator().Neg().(curves.PairingPoint)).IsOne()
+}
+
+// Verify checks a signature proof of knowledge and selective disclosure proof
+func (pok PokSignatureProof) Verify(
+	revealedMsgs map[int]curves.Scalar,
+	pk *PublicKey,
+	nonce common.Nonce,
+	challenge common.Challenge,
+	transcript *merlin.Transcript,
+) bool {
+	if len(pok.proof) != 1+len(pk.y)-len(revealedMsgs) {
+		return false
+	}
+	pok.GetChallengeContribution(pk, revealedMsgs, challenge, transcript)
+	transcript.AppendMessage([]byte("nonce"), nonce.Bytes())
+	okm := transcript.ExtractBytes([]byte("signature proof of knowledge"), 64)
+	vChallenge, err := pok.proof[0].SetBytesWide(okm)
+	if err != nil {
+		return false
+	}
+	return pok.VerifySigPok(pk, revealedMsgs) && challenge.Cmp(vChallenge) == 0
+}
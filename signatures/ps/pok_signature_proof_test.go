@@ -0,0 +1,150 @@
+package ps
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/gtank/merlin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/signatures/common"
+)
+
+func createProof(t *testing.T, curve *curves.PairingCurve, sig *Signature, pk *PublicKey, proofMsgs []common.ProofMessage, nonce common.Nonce) (*PokSignatureProof, common.Challenge) {
+	pok, err := NewPokSignature(sig, pk, proofMsgs, crand.Reader)
+	require.NoError(t, err)
+	transcript := merlin.NewTranscript("TestPokSignatureProofWorks")
+	pok.GetChallengeContribution(transcript)
+	transcript.AppendMessage([]byte("nonce"), nonce.Bytes())
+	okm := transcript.ExtractBytes([]byte("signature proof of knowledge"), 64)
+	challenge, err := curve.Scalar.SetBytesWide(okm)
+	require.NoError(t, err)
+	proof, err := pok.GenerateProof(challenge)
+	require.NoError(t, err)
+	return proof, challenge
+}
+
+func TestPokSignatureProofSomeMessagesRevealed(t *testing.T) {
+	curve := curves.BLS12381(&curves.PointBls12381G2{})
+	pk, sk, err := NewKeys(curve, 4)
+	require.NoError(t, err)
+	msgs := testMessages(curve)
+	sig, err := sk.Sign(msgs)
+	require.NoError(t, err)
+
+	proofMsgs := []common.ProofMessage{
+		&common.ProofSpecificMessage{
+			Message: msgs[0],
+		},
+		&common.ProofSpecificMessage{
+			Message: msgs[1],
+		},
+		&common.RevealedMessage{
+			Message: msgs[2],
+		},
+		&common.RevealedMessage{
+			Message: msgs[3],
+		},
+	}
+	nonce := curve.Scalar.Random(crand.Reader)
+	proof, challenge := createProof(t, curve, sig, pk, proofMsgs, nonce)
+
+	revealedMsgs := map[int]curves.Scalar{
+		2: msgs[2],
+		3: msgs[3],
+	}
+	require.True(t, proof.VerifySigPok(pk, revealedMsgs))
+	transcript := merlin.NewTranscript("TestPokSignatureProofWorks")
+	require.True(t, proof.Verify(revealedMsgs, pk, nonce, challenge, transcript))
+
+	// A wrong disclosed message fails
+	revealedMsgs[3] = curve.Scalar.New(6)
+	transcript = merlin.NewTranscript("TestPokSignatureProofWorks")
+	require.False(t, proof.Verify(revealedMsgs, pk, nonce, challenge, transcript))
+
+	// A different nonce fails
+	revealedMsgs[3] = msgs[3]
+	transcript = merlin.NewTranscript("TestPokSignatureProofWorks")
+	require.False(t, proof.Verify(revealedMsgs, pk, curve.Scalar.Random(crand.Reader), challenge, transcript))
+}
+
+func TestPokSignatureProofAllMessagesHidden(t *testing.T) {
+	curve := curves.BLS12381(&curves.PointBls12381G2{})
+	pk, sk, err := NewKeys(curve, 4)
+	require.NoError(t, err)
+	msgs := testMessages(curve)
+	sig, err := sk.Sign(msgs)
+	require.NoError(t, err)
+
+	proofMsgs := make([]common.ProofMessage, len(msgs))
+	for i, m := range msgs {
+		proofMsgs[i] = &common.ProofSpecificMessage{Message: m}
+	}
+	nonce := curve.Scalar.Random(crand.Reader)
+	proof, challenge := createProof(t, curve, sig, pk, proofMsgs, nonce)
+	transcript := merlin.NewTranscript("TestPokSignatureProofWorks")
+	require.True(t, proof.Verify(map[int]curves.Scalar{}, pk, nonce, challenge, transcript))
+
+	// Two proofs of the same signature share no values
+	proof2, _ := createProof(t, curve, sig, pk, proofMsgs, nonce)
+	require.False(t, proof.sigma1.Equal(proof2.sigma1))
+	require.False(t, proof.j.Equal(proof2.j))
+}
+
+func TestPokSignatureProofForgedSignature(t *testing.T) {
+	curve := curves.BLS12381(&curves.PointBls12381G2{})
+	pk, sk, err := NewKeys(curve, 4)
+	require.NoError(t, err)
+	msgs := testMessages(curve)
+	sig, err := sk.Sign(msgs)
+	require.NoError(t, err)
+	sig.sigma2 = sig.sigma2.Add(sig.sigma1).(curves.PairingPoint)
+
+	proofMsgs := make([]common.ProofMessage, len(msgs))
+	for i, m := range msgs {
+		proofMsgs[i] = &common.ProofSpecificMessage{Message: m}
+	}
+	nonce := curve.Scalar.Random(crand.Reader)
+	proof, challenge := createProof(t, curve, sig, pk, proofMsgs, nonce)
+	transcript := merlin.NewTranscript("TestPokSignatureProofWorks")
+	require.False(t, proof.Verify(map[int]curves.Scalar{}, pk, nonce, challenge, transcript))
+}
+
+func TestPokSignatureProofMarshalBinary(t *testing.T) {
+	curve := curves.BLS12381(&curves.PointBls12381G2{})
+	pk, sk, err := NewKeys(curve, 4)
+	require.NoError(t, err)
+	msgs := testMessages(curve)
+	sig, err := sk.Sign(msgs)
+	require.NoError(t, err)
+
+	proofMsgs := []common.ProofMessage{
+		&common.ProofSpecificMessage{
+			Message: msgs[0],
+		},
+		&common.RevealedMessage{
+			Message: msgs[1],
+		},
+		&common.ProofSpecificMessage{
+			Message: msgs[2],
+		},
+		&common.RevealedMessage{
+			Message: msgs[3],
+		},
+	}
+	nonce := curve.Scalar.Random(crand.Reader)
+	proof, challenge := createProof(t, curve, sig, pk, proofMsgs, nonce)
+	data, err := proof.MarshalBinary()
+	require.NoError(t, err)
+	proof2 := new(PokSignatureProof).Init(curve)
+	require.NoError(t, proof2.UnmarshalBinary(data))
+	require.Len(t, proof2.proof, 3)
+
+	revealedMsgs := map[int]curves.Scalar{
+		1: msgs[1],
+		3: msgs[3],
+	}
+	transcript := merlin.NewTranscript("TestPokSignatureProofWorks")
+	require.True(t, proof2.Verify(revealedMsgs, pk, nonce, challenge, transcript))
+}
@@ -0,0 +1,101 @@
+package ps
+
+import (
+	"fmt"
+
+	"github.com/go-sonr/crypto/core/curves"
+)
+
+// PublicKey is a Pointcheval-Sanders verification key,
+// X̃ = x·g̃ and Ỹ_i = y_i·g̃ in G2
+type PublicKey struct {
+	x curves.PairingPoint
+	y []curves.PairingPoint
+}
+
+// Init creates an empty public key to a specific curve
+// which should be followed by UnmarshalBinary
+func (pk *PublicKey) Init(curve *curves.PairingCurve) *PublicKey {
+	pk.x = curve.NewG2IdentityPoint()
+	pk.y = nil
+	return pk
+}
+
+// MessageCount returns the number of messages signed under this key
+func (pk PublicKey) MessageCount() int {
+	return len(pk.y)
+}
+
+func (pk PublicKey) MarshalBinary() ([]byte, error) {
+	data := pk.x.ToAffineCompressed()
+	for _, y := range pk.y {
+		data = append(data, y.ToAffineCompressed()...)
+	}
+	return data, nil
+}
+
+func (pk *PublicKey) UnmarshalBinary(in []byte) error {
+	ptSize := len(pk.x.ToAffineCompressed())
+	if len(in) < 2*ptSize || len(in)%ptSize != 0 {
+		return fmt.Errorf("invalid byte sequence")
+	}
+	values := make([]curves.PairingPoint, len(in)/ptSize)
+	for i := range values {
+		value, err := pairingPoint(pk.x, in[i*ptSize:(i+1)*ptSize])
+		if err != nil {
+			return err
+		}
+		values[i] = value
+	}
+	pk.x = values[0]
+	pk.y = values[1:]
+	return nil
+}
+
+// Verify checks a signature where all messages are known to the verifier
+func (pk PublicKey) Verify(signature *Signature, msgs []curves.Scalar) error {
+	if len(msgs) != len(pk.y) {
+		return fmt.Errorf("expected %d messages, got %d", len(pk.y), len(msgs))
+	}
+	if err := pk.check(); err != nil {
+		return err
+	}
+	// Identity σ1 will always return true which is not what we want
+	if signature == nil || signature.sigma1 == nil || signature.sigma2 == nil || signature.sigma1.IsIdentity() {
+		return fmt.Errorf("invalid signature")
+	}
+	points := make([]curves.Point, len(msgs)+1)
+	scalars := make([]curves.Scalar, len(msgs)+1)
+	points[0] = pk.x
+	scalars[0] = msgs[0].One()
+	for i, m := range msgs {
+		points[i+1] = pk.y[i]
+		scalars[i+1] = m
+	}
+	// e(σ1, X̃ + Σ m_i·Ỹ_i) == e(σ2, g̃)
+	a, ok := pk.x.SumOfProducts(points, scalars).(curves.PairingPoint)
+	if !ok {
+		return fmt.Errorf("not a valid point")
+	}
+	b, ok := pk.x.Generator().Neg().(curves.PairingPoint)
+	if !ok {
+		return fmt.Errorf("not a valid point")
+	}
+	if !a.MultiPairing(signature.sigma1, a, signature.sigma2, b).IsOne() {
+		return fmt.Errorf("invalid result")
+	}
+	return nil
+}
+
+func (pk PublicKey) check() error {
+	// Identity points would make every signature valid
+	if pk.x == nil || pk.x.IsIdentity() {
+		return fmt.Errorf("invalid public key")
+	}
+	for _, y := range pk.y {
+		if y == nil || y.IsIdentity() {
+			return fmt.Errorf("invalid public key")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package ps
+
+import (
+	crand "crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/go-sonr/crypto/core/curves"
+)
+
+// SecretKey is a Pointcheval-Sanders signing key for a fixed number of messages
+type SecretKey struct {
+	x curves.PairingScalar
+	y []curves.PairingScalar
+}
+
+// NewSecretKey creates a signing key for vectors of count messages
+func NewSecretKey(curve *curves.PairingCurve, count int) (*SecretKey, error) {
+	return newSecretKey(curve, count, crand.Reader)
+}
+
+func newSecretKey(curve *curves.PairingCurve, count int, reader io.Reader) (*SecretKey, error) {
+	if count < 1 {
+		return nil, fmt.Errorf("invalid message count")
+	}
+	x, err := toPairingScalar(getNonZeroScalar(curve.Scalar, reader), curve)
+	if err != nil {
+		return nil, err
+	}
+	y := make([]curves.PairingScalar, count)
+	for i := range y {
+		y[i], err = toPairingScalar(getNonZeroScalar(curve.Scalar, reader), curve)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &SecretKey{x: x, y: y}, nil
+}
+
+// NewKeys creates a key pair for vectors of count messages
+func NewKeys(curve *curves.PairingCurve, count int) (*PublicKey, *SecretKey, error) {
+	sk, err := NewSecretKey(curve, count)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sk.PublicKey(), sk, nil
+}
+
+// Init creates an empty secret key to a specific curve
+// which should be followed by UnmarshalBinary
+func (sk *SecretKey) Init(curve *curves.PairingCurve) *SecretKey {
+	sk.x = curve.NewScalar().SetPoint(curve.PointG2)
+	sk.y = nil
+	return sk
+}
+
+// Zeroize overwrites x and every y_i with zero
+func (sk *SecretKey) Zeroize() {
+	curves.Zeroize(sk.x)
+	for _, y := range sk.y {
+		curves.Zeroize(y)
+	}
+}
+
+func (sk SecretKey) MarshalBinary() ([]byte, error) {
+	data := sk.x.Bytes()
+	for _, y := range sk.y {
+		data = append(data, y.Bytes()...)
+	}
+	return data, nil
+}
+
+func (sk *SecretKey) UnmarshalBinary(in []byte) error {
+	scSize := len(sk.x.Bytes())
+	if len(in) < 2*scSize || len(in)%scSize != 0 {
+		return fmt.Errorf("invalid byte sequence")
+	}
+	values := make([]curves.PairingScalar, len(in)/scSize)
+	for i := range values {
+		value, err := sk.x.SetBytes(in[i*scSize : (i+1)*scSize])
+		if err != nil {
+			return err
+		}
+		var ok bool
+		values[i], ok = value.(curves.PairingScalar)
+		if !ok {
+			return errors.New("incorrect type conversion")
+		}
+	}
+	sk.x = values[0]
+	sk.y = values[1:]
+	return nil
+}
+
+// Sign generates a new signature over msgs, which must hold
+// exactly one message per key element
+func (sk *SecretKey) Sign(msgs []curves.Scalar) (*Signature, error) {
+	if len(msgs) != len(sk.y) {
+		return nil, fmt.Errorf("expected %d messages, got %d", len(sk.y), len(msgs))
+	}
+	if sk.x.IsZero() {
+		return nil, fmt.Errorf("invalid secret key")
+	}
+
+	// σ1 is derived from the key and the messages so signing needs no randomness
+	drbg := sha3.NewShake256()
+	_, _ = drbg.Write(sk.x.Bytes())
+	for _, m := range msgs {
+		_, _ = drbg.Write(m.Bytes())
+	}
+	h := getNonZeroScalar(sk.x, drbg)
+	sigma1, ok := g1Generator(sk.x).Mul(h).(curves.PairingPoint)
+	if !ok {
+		return nil, errors.New("incorrect type conversion")
+	}
+	sigma2, ok := sigma1.Mul(sk.exponent(msgs)).(curves.PairingPoint)
+	if !ok {
+		return nil, errors.New("incorrect type conversion")
+	}
+	return &Signature{sigma1: sigma1, sigma2: sigma2}, nil
+}
+
+// PublicKey returns the corresponding public key
+func (sk *SecretKey) PublicKey() *PublicKey {
+	g2 := sk.x.Point().Generator()
+	y := make([]curves.PairingPoint, len(sk.y))
+	for i, yi := range sk.y {
+		y[i] = g2.Mul(yi).(curves.PairingPoint)
+	}
+	return &PublicKey{
+		x: g2.Mul(sk.x).(curves.PairingPoint),
+		y: y,
+	}
+}
+
+// exponent computes x + y_1·m_1 + y_2·m_2 ...
+func (sk *SecretKey) exponent(msgs []curves.Scalar) curves.Scalar {
+	var e curves.Scalar = sk.x
+	for i, m := range msgs {
+		e = sk.y[i].MulAdd(m, e)
+	}
+	return e
+}
+
+func g1Generator(sc curves.PairingScalar) curves.Point {
+	return sc.Point().(curves.PairingPoint).OtherGroup().Generator()
+}
+
+func toPairingScalar(sc curves.Scalar, curve *curves.PairingCurve) (curves.PairingScalar, error) {
+	value, ok := sc.(curves.PairingScalar)
+	if !ok {
+		return nil, fmt.Errorf("invalid scalar")
+	}
+	return value.SetPoint(curve.PointG2), nil
+}
+
+func getNonZeroScalar(sc curves.Scalar, reader io.Reader) curves.Scalar {
+	// Very small likelihood of being zero
+	e := sc.Random(reader)
+	for e.IsZero() {
+		e = sc.Random(reader)
+	}
+	return e
+}
@@ -0,0 +1,82 @@
+// Package ps is an implementation of the Pointcheval-Sanders signature of
+// https://eprint.iacr.org/2015/525.pdf on BLS12-381, with signatures in G1
+// and public keys in G2.
+//
+// A signature over a vector of messages is a pair of G1 points that anyone
+// can re-randomize into an unlinkable signature over the same messages, and
+// its holder can prove knowledge of it while disclosing only some of the
+// messages, which makes it a building block for anonymous credentials next
+// to BBS+.
+package ps
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/go-sonr/crypto/core/curves"
+)
+
+// Signature is a Pointcheval-Sanders signature (σ1, σ2) with σ2 = (x + Σ y_i·m_i)·σ1
+type Signature struct {
+	sigma1, sigma2 curves.PairingPoint
+}
+
+// Init creates an empty signature to a specific curve
+// which should be followed by UnmarshalBinary
+func (sig *Signature) Init(curve *curves.PairingCurve) *Signature {
+	sig.sigma1 = curve.NewG1IdentityPoint()
+	sig.sigma2 = curve.NewG1IdentityPoint()
+	return sig
+}
+
+func (sig Signature) MarshalBinary() ([]byte, error) {
+	return append(sig.sigma1.ToAffineCompressed(), sig.sigma2.ToAffineCompressed()...), nil
+}
+
+func (sig *Signature) UnmarshalBinary(data []byte) error {
+	pointLength := len(sig.sigma1.ToAffineCompressed())
+	if len(data) != 2*pointLength {
+		return fmt.Errorf("invalid byte sequence")
+	}
+	sigma1, err := pairingPoint(sig.sigma1, data[:pointLength])
+	if err != nil {
+		return err
+	}
+	sigma2, err := pairingPoint(sig.sigma2, data[pointLength:])
+	if err != nil {
+		return err
+	}
+	sig.sigma1, sig.sigma2 = sigma1, sigma2
+	return nil
+}
+
+// Randomize returns a new signature over the same messages that cannot be
+// linked to sig: (t·σ1, t·σ2) for a random non-zero t
+func (sig Signature) Randomize(reader io.Reader) (*Signature, error) {
+	if sig.sigma1 == nil || sig.sigma2 == nil {
+		return nil, fmt.Errorf("invalid signature")
+	}
+	t := getNonZeroScalar(sig.sigma1.Scalar(), reader)
+	sigma1, ok := sig.sigma1.Mul(t).(curves.PairingPoint)
+	if !ok {
+		return nil, errors.New("incorrect type conversion")
+	}
+	sigma2, ok := sig.sigma2.Mul(t).(curves.PairingPoint)
+	if !ok {
+		return nil, errors.New("incorrect type conversion")
+	}
+	return &Signature{sigma1: sigma1, sigma2: sigma2}, nil
+}
+
+func pairingPoint(template curves.PairingPoint, data []byte) (curves.PairingPoint, error) {
+	p, err := template.FromAffineCompressed(data)
+	if err != nil {
+		return nil, err
+	}
+	value, ok := p.(curves.PairingPoint)
+	if !ok {
+		return nil, errors.New("incorrect type conversion")
+	}
+	return value, nil
+}
@@ -0,0 +1,103 @@
+package ps
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+)
+
+func testMessages(curve *curves.PairingCurve) []curves.Scalar {
+	return []curves.Scalar{
+		curve.Scalar.New(2),
+		curve.Scalar.New(3),
+		curve.Scalar.New(4),
+		curve.Scalar.New(5),
+	}
+}
+
+func TestSignatureWorks(t *testing.T) {
+	curve := curves.BLS12381(&curves.PointBls12381G2{})
+	pk, sk, err := NewKeys(curve, 4)
+	require.NoError(t, err)
+	require.Equal(t, 4, pk.MessageCount())
+	_, ok := pk.x.(*curves.PointBls12381G2)
+	require.True(t, ok)
+	msgs := testMessages(curve)
+
+	sig, err := sk.Sign(msgs)
+	require.NoError(t, err)
+	_, ok = sig.sigma1.(*curves.PointBls12381G1)
+	require.True(t, ok)
+	require.NoError(t, pk.Verify(sig, msgs))
+
+	// Signing is deterministic
+	sig2, err := sk.Sign(msgs)
+	require.NoError(t, err)
+	require.True(t, sig.sigma1.Equal(sig2.sigma1))
+	require.True(t, sig.sigma2.Equal(sig2.sigma2))
+
+	msgs[1] = curve.Scalar.New(6)
+	require.Error(t, pk.Verify(sig, msgs))
+	require.Error(t, pk.Verify(sig, msgs[:3]))
+	_, err = sk.Sign(msgs[:3])
+	require.Error(t, err)
+}
+
+func TestSignatureRandomize(t *testing.T) {
+	curve := curves.BLS12381(&curves.PointBls12381G2{})
+	pk, sk, err := NewKeys(curve, 4)
+	require.NoError(t, err)
+	msgs := testMessages(curve)
+	sig, err := sk.Sign(msgs)
+	require.NoError(t, err)
+
+	randomized, err := sig.Randomize(crand.Reader)
+	require.NoError(t, err)
+	require.False(t, sig.sigma1.Equal(randomized.sigma1))
+	require.False(t, sig.sigma2.Equal(randomized.sigma2))
+	require.NoError(t, pk.Verify(randomized, msgs))
+}
+
+func TestSignatureRejectsIdentity(t *testing.T) {
+	curve := curves.BLS12381(&curves.PointBls12381G2{})
+	pk, _, err := NewKeys(curve, 4)
+	require.NoError(t, err)
+	sig := new(Signature).Init(curve)
+	require.Error(t, pk.Verify(sig, testMessages(curve)))
+}
+
+func TestSignatureMarshalBinary(t *testing.T) {
+	curve := curves.BLS12381(&curves.PointBls12381G2{})
+	pk, sk, err := NewKeys(curve, 4)
+	require.NoError(t, err)
+	msgs := testMessages(curve)
+	sig, err := sk.Sign(msgs)
+	require.NoError(t, err)
+
+	data, err := sig.MarshalBinary()
+	require.NoError(t, err)
+	sig2 := new(Signature).Init(curve)
+	require.NoError(t, sig2.UnmarshalBinary(data))
+	require.True(t, sig.sigma1.Equal(sig2.sigma1))
+	require.True(t, sig.sigma2.Equal(sig2.sigma2))
+	require.Error(t, sig2.UnmarshalBinary(data[1:]))
+
+	data, err = pk.MarshalBinary()
+	require.NoError(t, err)
+	pk2 := new(PublicKey).Init(curve)
+	require.NoError(t, pk2.UnmarshalBinary(data))
+	require.Equal(t, 4, pk2.MessageCount())
+	require.NoError(t, pk2.Verify(sig2, msgs))
+
+	data, err = sk.MarshalBinary()
+	require.NoError(t, err)
+	sk2 := new(SecretKey).Init(curve)
+	require.NoError(t, sk2.UnmarshalBinary(data))
+	sig3, err := sk2.Sign(msgs)
+	require.NoError(t, err)
+	require.True(t, sig.sigma2.Equal(sig3.sigma2))
+	require.True(t, sk2.PublicKey().x.Equal(pk.x))
+}
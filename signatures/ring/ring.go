@@ -0,0 +1,318 @@
+// Package ring implements linkable ring signatures over any prime order group
+// of curves.Curve.
+//
+// A ring signature proves that one member of a set of public keys, the ring,
+// signed a message without revealing which. The signatures here are linkable:
+// each carries the key image I = x·Hp(P) of the signing key, which is the same
+// for every signature by that key and reveals nothing else, so a verifier can
+// tell when one member signs twice while the member stays anonymous.
+//
+// Sign and Verify implement LSAG (Liu, Wei and Wong,
+// https://eprint.iacr.org/2004/027) in the back-linked form used by Monero.
+// SignMLSAG and VerifyMLSAG implement MLSAG, where every ring member is a
+// vector of keys and the signer proves knowledge of all secret keys of one
+// vector at the same index.
+package ring
+
+import (
+	crand "crypto/rand"
+	"fmt"
+	"io"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/internal"
+)
+
+const (
+	challengeDomain = "go-sonr ring signature v1"
+	keyImageDomain  = "go-sonr ring key image v1"
+)
+
+// Signature is an LSAG signature, the key image of the signer, the first
+// challenge of the ring and one response per ring member
+type Signature struct {
+	KeyImage curves.Point
+	C        curves.Scalar
+	S        []curves.Scalar
+}
+
+// MLSAGSignature is an MLSAG signature, one key image per key of the signing
+// vector, the first challenge and one response per key of every ring member
+type MLSAGSignature struct {
+	KeyImages []curves.Point
+	C         curves.Scalar
+	S         [][]curves.Scalar
+}
+
+// KeyImage returns the key image x·Hp(x·G) of the secret key sk
+func KeyImage(curve *curves.Curve, sk curves.Scalar) curves.Point {
+	return hashToPoint(curve, curve.ScalarBaseMult(sk)).Mul(sk)
+}
+
+// Sign signs msg as an anonymous member of ring with the secret key sk, whose public key must be in ring
+func Sign(curve *curves.Curve, msg []byte, ring []curves.Point, sk curves.Scalar) (*Signature, error) {
+	return SignFromReader(curve, msg, ring, sk, crand.Reader)
+}
+
+// SignFromReader signs msg as an anonymous member of ring, drawing randomness from reader
+func SignFromReader(curve *curves.Curve, msg []byte, ring []curves.Point, sk curves.Scalar, reader io.Reader) (*Signature, error) {
+	if sk == nil {
+		return nil, internal.ErrNilArguments
+	}
+	sig, err := SignMLSAGFromReader(curve, msg, column(ring), []curves.Scalar{sk}, reader)
+	if err != nil {
+		return nil, err
+	}
+	s := make([]curves.Scalar, len(sig.S))
+	for i := range sig.S {
+		s[i] = sig.S[i][0]
+	}
+	return &Signature{KeyImage: sig.KeyImages[0], C: sig.C, S: s}, nil
+}
+
+// Verify checks that sig is a signature over msg by a member of ring
+func Verify(curve *curves.Curve, msg []byte, ring []curves.Point, sig *Signature) error {
+	if sig == nil || sig.KeyImage == nil {
+		return internal.ErrNilArguments
+	}
+	s := make([][]curves.Scalar, len(sig.S))
+	for i := range sig.S {
+		s[i] = []curves.Scalar{sig.S[i]}
+	}
+	return VerifyMLSAG(curve, msg, column(ring), &MLSAGSignature{
+		KeyImages: []curves.Point{sig.KeyImage},
+		C:         sig.C,
+		S:         s,
+	})
+}
+
+// Linked reports whether a and b were made with the same secret key
+func Linked(a, b *Signature) bool {
+	if a == nil || b == nil || a.KeyImage == nil || b.KeyImage == nil {
+		return false
+	}
+	return a.KeyImage.Equal(b.KeyImage)
+}
+
+// SignMLSAG signs msg as an anonymous member of ring, a list of key vectors
+// of the same length, with the secret keys sks of one of the vectors
+func SignMLSAG(curve *curves.Curve, msg []byte, ring [][]curves.Point, sks []curves.Scalar) (*MLSAGSignature, error) {
+	return SignMLSAGFromReader(curve, msg, ring, sks, crand.Reader)
+}
+
+// SignMLSAGFromReader signs msg as an anonymous member of ring, drawing randomness from reader
+func SignMLSAGFromReader(curve *curves.Curve, msg []byte, ring [][]curves.Point, sks []curves.Scalar, reader io.Reader) (*MLSAGSignature, error) {
+	if curve == nil || reader == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if err := checkRing(curve, ring); err != nil {
+		return nil, err
+	}
+	width := len(ring[0])
+	if len(sks) != width {
+		return nil, fmt.Errorf("expected %d secret keys, got %d", width, len(sks))
+	}
+	pks := make([]curves.Point, width)
+	for j, sk := range sks {
+		if sk == nil || sk.IsZero() {
+			return nil, internal.ErrZeroValue
+		}
+		pks[j] = curve.ScalarBaseMult(sk)
+	}
+	signer := -1
+	for i, member := range ring {
+		if equalKeys(member, pks) {
+			signer = i
+			break
+		}
+	}
+	if signer < 0 {
+		return nil, fmt.Errorf("public key of the signer is not in the ring")
+	}
+
+	n := len(ring)
+	hashes := hashRing(curve, ring)
+	images := make([]curves.Point, width)
+	for j, sk := range sks {
+		images[j] = hashes[signer][j].Mul(sk)
+	}
+	prefix := challengePrefix(curve, msg, ring, images)
+
+	// c_{π+1} = H(msg, α·G, α·Hp(P_π))
+	alpha := make([]curves.Scalar, width)
+	l := make([]curves.Point, width)
+	r := make([]curves.Point, width)
+	for j := range alpha {
+		alpha[j] = curve.Scalar.Random(reader)
+		l[j] = curve.ScalarBaseMult(alpha[j])
+		r[j] = hashes[signer][j].Mul(alpha[j])
+	}
+	c := make([]curves.Scalar, n)
+	s := make([][]curves.Scalar, n)
+	c[(signer+1)%n] = challenge(curve, prefix, l, r)
+
+	// Close the ring with random responses for every other member
+	for k := 1; k < n; k++ {
+		i := (signer + k) % n
+		s[i] = make([]curves.Scalar, width)
+		for j := range s[i] {
+			s[i][j] = curve.Scalar.Random(reader)
+		}
+		l, r = commitments(curve, ring[i], hashes[i], images, s[i], c[i])
+		c[(i+1)%n] = challenge(curve, prefix, l, r)
+	}
+
+	// s_π = α - c_π·x
+	s[signer] = make([]curves.Scalar, width)
+	for j, sk := range sks {
+		s[signer][j] = alpha[j].Sub(c[signer].Mul(sk))
+	}
+	return &MLSAGSignature{KeyImages: images, C: c[0], S: s}, nil
+}
+
+// VerifyMLSAG checks that sig is a signature over msg by a member of ring
+func VerifyMLSAG(curve *curves.Curve, msg []byte, ring [][]curves.Point, sig *MLSAGSignature) error {
+	if curve == nil || sig == nil || sig.C == nil {
+		return internal.ErrNilArguments
+	}
+	if err := checkRing(curve, ring); err != nil {
+		return err
+	}
+	width := len(ring[0])
+	if len(sig.KeyImages) != width || len(sig.S) != len(ring) {
+		return fmt.Errorf("signature does not match the ring")
+	}
+	for _, image := range sig.KeyImages {
+		if err := checkKeyImage(curve, image); err != nil {
+			return err
+		}
+	}
+	hashes := hashRing(curve, ring)
+	prefix := challengePrefix(curve, msg, ring, sig.KeyImages)
+	c := sig.C
+	for i := range ring {
+		if len(sig.S[i]) != width {
+			return fmt.Errorf("signature does not match the ring")
+		}
+		for _, s := range sig.S[i] {
+			if s == nil {
+				return internal.ErrNilArguments
+			}
+		}
+		l, r := commitments(curve, ring[i], hashes[i], sig.KeyImages, sig.S[i], c)
+		c = challenge(curve, prefix, l, r)
+	}
+	if c.Cmp(sig.C) != 0 {
+		return fmt.Errorf("invalid ring signature")
+	}
+	return nil
+}
+
+// LinkedMLSAG reports whether a and b were made with the same secret key at any key index
+func LinkedMLSAG(a, b *MLSAGSignature) bool {
+	if a == nil || b == nil || len(a.KeyImages) != len(b.KeyImages) {
+		return false
+	}
+	for j := range a.KeyImages {
+		if a.KeyImages[j] != nil && b.KeyImages[j] != nil && a.KeyImages[j].Equal(b.KeyImages[j]) {
+			return true
+		}
+	}
+	return false
+}
+
+// commitments returns L_j = s_j·G + c·P_j and R_j = s_j·Hp(P_j) + c·I_j
+func commitments(curve *curves.Curve, keys, hashes, images []curves.Point, s []curves.Scalar, c curves.Scalar) ([]curves.Point, []curves.Point) {
+	l := make([]curves.Point, len(keys))
+	r := make([]curves.Point, len(keys))
+	for j := range keys {
+		l[j] = curve.ScalarBaseMult(s[j]).Add(keys[j].Mul(c))
+		r[j] = hashes[j].Mul(s[j]).Add(images[j].Mul(c))
+	}
+	return l, r
+}
+
+// challengePrefix binds the challenges to the message, the whole ring and the key images
+func challengePrefix(curve *curves.Curve, msg []byte, ring [][]curves.Point, images []curves.Point) []byte {
+	prefix := append([]byte(challengeDomain), curve.Name...)
+	for _, member := range ring {
+		for _, p := range member {
+			prefix = append(prefix, p.ToAffineCompressed()...)
+		}
+	}
+	for _, image := range images {
+		prefix = append(prefix, image.ToAffineCompressed()...)
+	}
+	return append(prefix, msg...)
+}
+
+func challenge(curve *curves.Curve, prefix []byte, l, r []curves.Point) curves.Scalar {
+	input := append([]byte{}, prefix...)
+	for j := range l {
+		input = append(input, l[j].ToAffineCompressed()...)
+		input = append(input, r[j].ToAffineCompressed()...)
+	}
+	return curve.Scalar.Hash(input)
+}
+
+func hashToPoint(curve *curves.Curve, p curves.Point) curves.Point {
+	return curve.Point.Hash(append([]byte(keyImageDomain), p.ToAffineCompressed()...))
+}
+
+func hashRing(curve *curves.Curve, ring [][]curves.Point) [][]curves.Point {
+	hashes := make([][]curves.Point, len(ring))
+	for i, member := range ring {
+		hashes[i] = make([]curves.Point, len(member))
+		for j, p := range member {
+			hashes[i][j] = hashToPoint(curve, p)
+		}
+	}
+	return hashes
+}
+
+func checkRing(curve *curves.Curve, ring [][]curves.Point) error {
+	if len(ring) == 0 || len(ring[0]) == 0 {
+		return fmt.Errorf("ring must be non-empty")
+	}
+	for _, member := range ring {
+		if len(member) != len(ring[0]) {
+			return fmt.Errorf("ring members must have the same number of keys")
+		}
+		for _, p := range member {
+			if p == nil || p.CurveName() != curve.Name || !p.IsOnCurve() || p.IsIdentity() {
+				return internal.ErrNotOnCurve
+			}
+		}
+	}
+	return nil
+}
+
+// checkKeyImage rejects key images outside the prime order subgroup, whose
+// torsion component would let a signer produce unlinked images of one key
+func checkKeyImage(curve *curves.Curve, image curves.Point) error {
+	if image == nil || image.CurveName() != curve.Name || !image.IsOnCurve() || image.IsIdentity() {
+		return fmt.Errorf("invalid key image")
+	}
+	// (q-1)·I + I = q·I is the identity only in the subgroup of order q
+	if !image.Mul(curve.Scalar.One().Neg()).Add(image).IsIdentity() {
+		return fmt.Errorf("key image is not in the prime order subgroup")
+	}
+	return nil
+}
+
+func column(ring []curves.Point) [][]curves.Point {
+	out := make([][]curves.Point, len(ring))
+	for i, p := range ring {
+		out[i] = []curves.Point{p}
+	}
+	return out
+}
+
+func equalKeys(a, b []curves.Point) bool {
+	for j := range a {
+		if a[j] == nil || !a[j].Equal(b[j]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,123 @@
+package ring_test
+
+import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/signatures/ring"
+)
+
+func newRing(curve *curves.Curve, size int) ([]curves.Point, []curves.Scalar) {
+	pks := make([]curves.Point, size)
+	sks := make([]curves.Scalar, size)
+	for i := range pks {
+		sks[i] = curve.Scalar.Random(crand.Reader)
+		pks[i] = curve.ScalarBaseMult(sks[i])
+	}
+	return pks, sks
+}
+
+func TestLSAG(t *testing.T) {
+	for _, curve := range []*curves.Curve{curves.K256(), curves.P256(), curves.ED25519()} {
+		pks, sks := newRing(curve, 5)
+		msg := []byte("attestation")
+		for signer := range pks {
+			sig, err := ring.Sign(curve, msg, pks, sks[signer])
+			require.NoError(t, err)
+			require.NoError(t, ring.Verify(curve, msg, pks, sig))
+			require.Error(t, ring.Verify(curve, []byte("other attestation"), pks, sig))
+			require.True(t, sig.KeyImage.Equal(ring.KeyImage(curve, sks[signer])))
+		}
+		// A ring of one is an ordinary signature
+		sig, err := ring.Sign(curve, msg, pks[:1], sks[0])
+		require.NoError(t, err)
+		require.NoError(t, ring.Verify(curve, msg, pks[:1], sig))
+	}
+}
+
+func TestLSAGLinkability(t *testing.T) {
+	curve := curves.K256()
+	pks, sks := newRing(curve, 4)
+	sig1, err := ring.Sign(curve, []byte("vote 1"), pks, sks[2])
+	require.NoError(t, err)
+	// The same key in another ring still links
+	sig2, err := ring.Sign(curve, []byte("vote 2"), []curves.Point{pks[3], pks[2]}, sks[2])
+	require.NoError(t, err)
+	sig3, err := ring.Sign(curve, []byte("vote 1"), pks, sks[1])
+	require.NoError(t, err)
+	require.True(t, ring.Linked(sig1, sig2))
+	require.False(t, ring.Linked(sig1, sig3))
+}
+
+func TestLSAGInvalid(t *testing.T) {
+	curve := curves.P256()
+	pks, sks := newRing(curve, 3)
+	msg := []byte("attestation")
+
+	_, err := ring.Sign(curve, msg, pks, curve.Scalar.Random(crand.Reader))
+	require.Error(t, err)
+	_, err = ring.Sign(curve, msg, nil, sks[0])
+	require.Error(t, err)
+
+	sig, err := ring.Sign(curve, msg, pks, sks[0])
+	require.NoError(t, err)
+	// Another ring, a swapped key image or a changed response do not verify
+	require.Error(t, ring.Verify(curve, msg, []curves.Point{pks[1], pks[0], pks[2]}, sig))
+	require.Error(t, ring.Verify(curve, msg, pks[:2], sig))
+	forged := *sig
+	forged.KeyImage = ring.KeyImage(curve, sks[1])
+	require.Error(t, ring.Verify(curve, msg, pks, &forged))
+	forged = *sig
+	forged.S = append([]curves.Scalar{sig.S[0].Add(curve.Scalar.One())}, sig.S[1:]...)
+	require.Error(t, ring.Verify(curve, msg, pks, &forged))
+}
+
+func TestLSAGRejectsTorsionKeyImage(t *testing.T) {
+	curve := curves.ED25519()
+	pks, sks := newRing(curve, 3)
+	msg := []byte("attestation")
+	sig, err := ring.Sign(curve, msg, pks, sks[0])
+	require.NoError(t, err)
+
+	// (0, -1) has order 2
+	encoded, err := hex.DecodeString("ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f")
+	require.NoError(t, err)
+	torsion, err := curve.Point.FromAffineCompressed(encoded)
+	require.NoError(t, err)
+	forged := *sig
+	forged.KeyImage = sig.KeyImage.Add(torsion)
+	require.Error(t, ring.Verify(curve, msg, pks, &forged))
+}
+
+func TestMLSAG(t *testing.T) {
+	curve := curves.K256()
+	const size, width = 4, 3
+	keys := make([][]curves.Point, size)
+	secrets := make([][]curves.Scalar, size)
+	for i := range keys {
+		keys[i], secrets[i] = newRing(curve, width)
+	}
+	msg := []byte("attestation")
+	sig, err := ring.SignMLSAG(curve, msg, keys, secrets[1])
+	require.NoError(t, err)
+	require.NoError(t, ring.VerifyMLSAG(curve, msg, keys, sig))
+	require.Error(t, ring.VerifyMLSAG(curve, []byte("other attestation"), keys, sig))
+
+	other, err := ring.SignMLSAG(curve, []byte("second"), keys, secrets[1])
+	require.NoError(t, err)
+	require.True(t, ring.LinkedMLSAG(sig, other))
+	other, err = ring.SignMLSAG(curve, []byte("second"), keys, secrets[2])
+	require.NoError(t, err)
+	require.False(t, ring.LinkedMLSAG(sig, other))
+
+	// Keys of different members do not sign together
+	mixed := []curves.Scalar{secrets[0][0], secrets[1][1], secrets[0][2]}
+	_, err = ring.SignMLSAG(curve, msg, keys, mixed)
+	require.Error(t, err)
+	_, err = ring.SignMLSAG(curve, msg, keys, secrets[1][:2])
+	require.Error(t, err)
+}
@@ -0,0 +1,336 @@
+// Package bip340 implements secp256k1 Schnorr signatures with x-only public
+// keys as specified in BIP-340, the signature scheme used by taproot
+// https://github.com/bitcoin/bips/blob/master/bip-0340.mediawiki
+package bip340
+
+import (
+	"bytes"
+	crand "crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/secret"
+	"github.com/go-sonr/crypto/internal"
+)
+
+const (
+	// PublicKeySize is the size of an x-only public key
+	PublicKeySize = 32
+	// SecretKeySize is the size of a serialized secret key
+	SecretKeySize = 32
+	// SignatureSize is the size of a serialized signature
+	SignatureSize = 64
+
+	tagAux       = "BIP0340/aux"
+	tagNonce     = "BIP0340/nonce"
+	tagChallenge = "BIP0340/challenge"
+)
+
+// PublicKey is an x-only verification key, its point always has an even y coordinate
+type PublicKey struct {
+	value curves.Point
+}
+
+// SecretKey is the signing key
+type SecretKey struct {
+	value curves.Scalar
+}
+
+// Signature is a BIP-340 signature, the x coordinate of the nonce and the response
+type Signature struct {
+	R [32]byte
+	S curves.Scalar
+}
+
+// NewKeys creates a new keypair using a CSPRNG
+func NewKeys() (*PublicKey, *SecretKey, error) {
+	return NewKeysFromReader(crand.Reader)
+}
+
+// NewKeysFromReader creates a new keypair using the specified reader
+func NewKeysFromReader(reader io.Reader) (*PublicKey, *SecretKey, error) {
+	if reader == nil {
+		return nil, nil, internal.ErrNilArguments
+	}
+	sk := curves.K256().Scalar.Random(reader)
+	if sk.IsZero() {
+		return nil, nil, internal.ErrZeroValue
+	}
+	secretKey := &SecretKey{value: sk}
+	return secretKey.PublicKey(), secretKey, nil
+}
+
+// SecretKeyFromScalar wraps a non-zero secp256k1 scalar
+func SecretKeyFromScalar(sk curves.Scalar) (*SecretKey, error) {
+	if sk == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if _, ok := sk.(*curves.ScalarK256); !ok {
+		return nil, fmt.Errorf("secret key is not a secp256k1 scalar")
+	}
+	if sk.IsZero() {
+		return nil, internal.ErrZeroValue
+	}
+	return &SecretKey{value: sk.Clone()}, nil
+}
+
+// PublicKey returns the x-only verification key of sk
+func (sk *SecretKey) PublicKey() *PublicKey {
+	return &PublicKey{value: lift(curves.K256().ScalarBaseMult(sk.value))}
+}
+
+// Zeroize overwrites the secret key with zero, sk cannot sign afterwards
+func (sk *SecretKey) Zeroize() {
+	curves.Zeroize(sk.value)
+}
+
+// MarshalBinary returns the 32 byte big endian secret key
+func (sk SecretKey) MarshalBinary() ([]byte, error) {
+	return sk.value.Bytes(), nil
+}
+
+// UnmarshalBinary decodes a 32 byte big endian secret key
+func (sk *SecretKey) UnmarshalBinary(input []byte) error {
+	if len(input) != SecretKeySize {
+		return fmt.Errorf("invalid secret key length")
+	}
+	value, err := curves.K256().Scalar.SetBytes(input)
+	if err != nil {
+		return err
+	}
+	if value.IsZero() {
+		return internal.ErrZeroValue
+	}
+	sk.value = value
+	return nil
+}
+
+// Sign signs msg with fresh auxiliary randomness from crypto/rand
+func (sk *SecretKey) Sign(msg []byte) (*Signature, error) {
+	var aux [32]byte
+	if _, err := crand.Read(aux[:]); err != nil {
+		return nil, err
+	}
+	return sk.SignWithAuxRand(msg, aux[:])
+}
+
+// SignWithAuxRand signs msg using the 32 bytes of auxiliary randomness aux.
+// Signing stays secure with a fixed aux, which makes the signature deterministic.
+func (sk *SecretKey) SignWithAuxRand(msg, aux []byte) (*Signature, error) {
+	if len(aux) != 32 {
+		return nil, fmt.Errorf("auxiliary randomness must be 32 bytes")
+	}
+	curve := curves.K256()
+	p := curve.ScalarBaseMult(sk.value)
+	d := sk.value
+	if !HasEvenY(p) {
+		d = d.Neg()
+	}
+	pk := XOnly(p)
+
+	t := d.Bytes()
+	auxHash := TaggedHash(tagAux, aux)
+	for i := range t {
+		t[i] ^= auxHash[i]
+	}
+	k := hashToScalar(TaggedHash(tagNonce, t, pk, msg))
+	secret.Wipe(t)
+	defer curves.Zeroize(k)
+	if k.IsZero() {
+		return nil, fmt.Errorf("derived a zero nonce")
+	}
+	r := curve.ScalarBaseMult(k)
+	if !HasEvenY(r) {
+		k = k.Neg()
+		defer curves.Zeroize(k)
+	}
+	rx := XOnly(r)
+	e := hashToScalar(TaggedHash(tagChallenge, rx, pk, msg))
+
+	sig := &Signature{S: k.Add(e.Mul(d))}
+	copy(sig.R[:], rx)
+	// guard against faults leaking the key through an invalid signature
+	if err := (&PublicKey{value: lift(p)}).Verify(msg, sig); err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
+
+// ParsePublicKey decodes a 32 byte x-only public key
+func ParsePublicKey(input []byte) (*PublicKey, error) {
+	p, err := LiftX(input)
+	if err != nil {
+		return nil, err
+	}
+	return &PublicKey{value: p}, nil
+}
+
+// Point returns the even y point of the public key
+func (pk PublicKey) Point() curves.Point {
+	return pk.value
+}
+
+// MarshalBinary returns the 32 byte x-only encoding of the public key
+func (pk PublicKey) MarshalBinary() ([]byte, error) {
+	return XOnly(pk.value), nil
+}
+
+// UnmarshalBinary decodes a 32 byte x-only public key
+func (pk *PublicKey) UnmarshalBinary(input []byte) error {
+	p, err := LiftX(input)
+	if err != nil {
+		return err
+	}
+	pk.value = p
+	return nil
+}
+
+// Verify checks that sig is a valid signature over msg for this public key
+func (pk *PublicKey) Verify(msg []byte, sig *Signature) error {
+	if sig == nil || sig.S == nil || pk.value == nil {
+		return internal.ErrNilArguments
+	}
+	curve := curves.K256()
+	if new(big.Int).SetBytes(sig.R[:]).Cmp(curves.K256Curve().Params().P) >= 0 {
+		return fmt.Errorf("signature r is not a field element")
+	}
+	e := hashToScalar(TaggedHash(tagChallenge, sig.R[:], XOnly(pk.value), msg))
+
+	// R = s * G - e * P
+	r := curve.ScalarBaseMult(sig.S).Sub(pk.value.Mul(e))
+	if r.IsIdentity() || !HasEvenY(r) || !bytes.Equal(XOnly(r), sig.R[:]) {
+		return fmt.Errorf("invalid signature")
+	}
+	return nil
+}
+
+// BatchVerify checks that every sigs[i] is a valid signature over msgs[i] for
+// publicKeys[i] with the batch verification algorithm of BIP-340: the
+// verification equations are combined with random coefficients a_1 = 1 and
+// a_i read from reader, and
+//
+//	(sum a_i*s_i) * G == sum a_i*R_i + sum a_i*e_i*P_i
+//
+// is checked with one multi-scalar multiplication. If reader is nil,
+// crypto/rand.Reader will be used.
+func BatchVerify(reader io.Reader, publicKeys []*PublicKey, msgs [][]byte, sigs []*Signature) error {
+	if reader == nil {
+		reader = crand.Reader
+	}
+	if len(publicKeys) != len(msgs) || len(publicKeys) != len(sigs) {
+		return fmt.Errorf("batch length mismatch")
+	}
+	curve := curves.K256()
+	points := make([]curves.Point, 0, 2*len(sigs)+1)
+	scalars := make([]curves.Scalar, 0, 2*len(sigs)+1)
+	points = append(points, curve.NewGeneratorPoint())
+	scalars = append(scalars, curve.NewScalar())
+
+	for i, sig := range sigs {
+		pk := publicKeys[i]
+		if sig == nil || sig.S == nil || pk == nil || pk.value == nil {
+			return internal.ErrNilArguments
+		}
+		r, err := LiftX(sig.R[:])
+		if err != nil {
+			return fmt.Errorf("invalid signature")
+		}
+		e := hashToScalar(TaggedHash(tagChallenge, sig.R[:], XOnly(pk.value), msgs[i]))
+
+		a := curve.Scalar.One()
+		if i > 0 {
+			a = curve.Scalar.Random(reader)
+		}
+		scalars[0] = scalars[0].Add(a.Mul(sig.S))
+		points = append(points, r.Neg(), pk.value.Neg())
+		scalars = append(scalars, a, a.Mul(e))
+	}
+
+	sum := curve.SumOfProducts(points, scalars)
+	if sum == nil || !sum.IsIdentity() {
+		return fmt.Errorf("invalid signature")
+	}
+	return nil
+}
+
+// MarshalBinary returns the 64 byte r || s encoding of the signature
+func (sig Signature) MarshalBinary() ([]byte, error) {
+	if sig.S == nil {
+		return nil, internal.ErrNilArguments
+	}
+	out := make([]byte, 0, SignatureSize)
+	out = append(out, sig.R[:]...)
+	return append(out, sig.S.Bytes()...), nil
+}
+
+// UnmarshalBinary decodes a 64 byte r || s signature
+func (sig *Signature) UnmarshalBinary(input []byte) error {
+	if len(input) != SignatureSize {
+		return fmt.Errorf("invalid signature length")
+	}
+	s, err := curves.K256().Scalar.SetBytes(input[32:])
+	if err != nil {
+		return fmt.Errorf("signature s exceeds the group order")
+	}
+	copy(sig.R[:], input[:32])
+	sig.S = s
+	return nil
+}
+
+// TaggedHash computes SHA256(SHA256(tag) || SHA256(tag) || msg...)
+func TaggedHash(tag string, msg ...[]byte) []byte {
+	tagHash := sha256.Sum256([]byte(tag))
+	h := sha256.New()
+	_, _ = h.Write(tagHash[:])
+	_, _ = h.Write(tagHash[:])
+	for _, m := range msg {
+		_, _ = h.Write(m)
+	}
+	return h.Sum(nil)
+}
+
+// LiftX returns the point with x coordinate x and an even y coordinate
+func LiftX(x []byte) (curves.Point, error) {
+	if len(x) != PublicKeySize {
+		return nil, fmt.Errorf("invalid x-only public key length")
+	}
+	compressed := make([]byte, 33)
+	compressed[0] = 2
+	copy(compressed[1:], x)
+	p, err := curves.K256().Point.FromAffineCompressed(compressed)
+	if err != nil {
+		return nil, err
+	}
+	// x coordinates without a point on the curve decode to the identity
+	if p.IsIdentity() {
+		return nil, internal.ErrNotOnCurve
+	}
+	return p, nil
+}
+
+// XOnly returns the 32 byte big endian x coordinate of p
+func XOnly(p curves.Point) []byte {
+	return p.ToAffineCompressed()[1:]
+}
+
+// HasEvenY reports whether the affine y coordinate of p is even
+func HasEvenY(p curves.Point) bool {
+	return p.ToAffineCompressed()[0] == 2
+}
+
+// lift returns the even y variant of p
+func lift(p curves.Point) curves.Point {
+	if HasEvenY(p) {
+		return p
+	}
+	return p.Neg()
+}
+
+// hashToScalar interprets a 32 byte big endian digest as a scalar reduced by the group order
+func hashToScalar(digest []byte) curves.Scalar {
+	s, _ := curves.K256().Scalar.SetBigInt(new(big.Int).SetBytes(digest))
+	return s
+}
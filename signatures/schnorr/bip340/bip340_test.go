@@ -0,0 +1,259 @@
+package bip340
+
+import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Test vectors from https://github.com/bitcoin/bips/blob/master/bip-0340/test-vectors.csv
+var testVectors = []struct {
+	secretKey string
+	publicKey string
+	auxRand   string
+	message   string
+	signature string
+	valid     bool
+}{
+	{
+		secretKey: "0000000000000000000000000000000000000000000000000000000000000003",
+		publicKey: "F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9",
+		auxRand:   "0000000000000000000000000000000000000000000000000000000000000000",
+		message:   "0000000000000000000000000000000000000000000000000000000000000000",
+		signature: "E907831F80848D1069A5371B402410364BDF1C5F8307B0084C55F1CE2DCA821525F66A4A85EA8B71E482A74F382D2CE5EBEEE8FDB2172F477DF4900D310536C0",
+		valid:     true,
+	},
+	{
+		secretKey: "B7E151628AED2A6ABF7158809CF4F3C762E7160F38B4DA56A784D9045190CFEF",
+		publicKey: "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		auxRand:   "0000000000000000000000000000000000000000000000000000000000000001",
+		message:   "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		signature: "6896BD60EEAE296DB48A229FF71DFE071BDE413E6D43F917DC8DCF8C78DE33418906D11AC976ABCCB20B091292BFF4EA897EFCB639EA871CFA95F6DE339E4B0A",
+		valid:     true,
+	},
+	{
+		secretKey: "C90FDAA22168C234C4C6628B80DC1CD129024E088A67CC74020BBEA63B14E5C9",
+		publicKey: "DD308AFEC5777E13121FA72B9CC1B7CC0139715309B086C960E18FD969774EB8",
+		auxRand:   "C87AA53824B4D7AE2EB035A2B5BBBCCC080E76CDC6D1692C4B0B62D798E6D906",
+		message:   "7E2D58D8B3BCDF1ABADEC7829054F90DDA9805AAB56C77333024B9D0A508B75C",
+		signature: "5831AAEED7B44BB74E5EAB94BA9D4294C49BCF2A60728D8B4C200F50DD313C1BAB745879A5AD954A72C45A91C3A51D3C7ADEA98D82F8481E0E1E03674A6F3FB7",
+		valid:     true,
+	},
+	{
+		secretKey: "0B432B2677937381AEF05BB02A66ECD012773062CF3FA2549E44F58ED2401710",
+		publicKey: "25D1DFF95105F5253C4022F628A996AD3A0D95FBF21D468A1B33F8C160D8F517",
+		auxRand:   "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF",
+		message:   "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF",
+		signature: "7EB0509757E246F19449885651611CB965ECC1A187DD51B64FDA1EDC9637D5EC97582B9CB13DB3933705B32BA982AF5AF25FD78881EBB32771FC5922EFC66EA3",
+		valid:     true,
+	},
+	{
+		publicKey: "D69C3509BB99E412E68B0FE8544E72837DFA30746D8BE2AA65975F29D22DC7B9",
+		message:   "4DF3C3F68FCC83B27E9D42C90431A72499F17875C81A599B566C9889B9696703",
+		signature: "00000000000000000000003B78CE563F89A0ED9414F5AA28AD0D96D6795F9C6376AFB1548AF603B3EB45C9F8207DEE1060CB71C04E80F593060B07D28308D7F4",
+		valid:     true,
+	},
+	{
+		// public key not on the curve
+		publicKey: "EEFDEA4CDB677750A420FEE807EACF21EB9898AE79B9768766E4FAA04A2D4A34",
+		message:   "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		signature: "6CFF5C3BA86C69EA4B7376F31A9BCB4F74C1976089B2D9963DA2E5543E17776969E89B4C5564D00349106B8497785DD7D1D713A8AE82B32FA79D5F7FC407D39B",
+	},
+	{
+		// has_even_y(R) is false
+		publicKey: "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		message:   "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		signature: "FFF97BD5755EEEA420453A14355235D382F6472F8568A18B2F057A14602975563CC27944640AC607CD107AE10923D9EF7A73C643E166BE5EBEAFA34B1AC553E2",
+	},
+	{
+		// negated message
+		publicKey: "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		message:   "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		signature: "1FA62E331EDBC21C394792D2AB1100A7B432B013DF3F6FF4F99FCB33E0E1515F28890B3EDB6E7189B630448B515CE4F8622A954CFE545735AAEA5134FCCDB2BD",
+	},
+	{
+		// negated s value
+		publicKey: "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		message:   "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		signature: "6CFF5C3BA86C69EA4B7376F31A9BCB4F74C1976089B2D9963DA2E5543E177769961764B3AA9B2FFCB6EF947B6887A226E8D7C93E00C5ED0C1834FF0D0C2E6DA6",
+	},
+	{
+		// sG - eP is infinite
+		publicKey: "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		message:   "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		signature: "0000000000000000000000000000000000000000000000000000000000000000123DDA8328AF9C23A94C1FEECFD123BA4FB73476F0D594DCB65C6425BD186051",
+	},
+	{
+		// sG - eP is infinite
+		publicKey: "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		message:   "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		signature: "00000000000000000000000000000000000000000000000000000000000000017615FBAF5AE28864013C099742DEADB4DBA87F11AC6754F93780D5A1837CF197",
+	},
+	{
+		// sig[0:32] is not an x coordinate on the curve
+		publicKey: "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		message:   "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		signature: "4A298DACAE57395A15D0795DDBFD1DCB564DA82B0F269BC70A74F8220429BA1D69E89B4C5564D00349106B8497785DD7D1D713A8AE82B32FA79D5F7FC407D39B",
+	},
+	{
+		// sig[0:32] is equal to the field size
+		publicKey: "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		message:   "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		signature: "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC2F69E89B4C5564D00349106B8497785DD7D1D713A8AE82B32FA79D5F7FC407D39B",
+	},
+	{
+		// sig[32:64] is equal to the curve order
+		publicKey: "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		message:   "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		signature: "6CFF5C3BA86C69EA4B7376F31A9BCB4F74C1976089B2D9963DA2E5543E177769FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141",
+	},
+	{
+		// public key exceeds the field size
+		publicKey: "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC30",
+		message:   "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		signature: "6CFF5C3BA86C69EA4B7376F31A9BCB4F74C1976089B2D9963DA2E5543E17776969E89B4C5564D00349106B8497785DD7D1D713A8AE82B32FA79D5F7FC407D39B",
+	},
+}
+
+func unhex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+func verifyVector(t *testing.T, pkHex, msgHex, sigHex string) error {
+	pk, err := ParsePublicKey(unhex(t, pkHex))
+	if err != nil {
+		return err
+	}
+	sig := new(Signature)
+	if err := sig.UnmarshalBinary(unhex(t, sigHex)); err != nil {
+		return err
+	}
+	return pk.Verify(unhex(t, msgHex), sig)
+}
+
+func TestSignVectors(t *testing.T) {
+	for i, test := range testVectors {
+		if test.secretKey == "" {
+			continue
+		}
+		sk := new(SecretKey)
+		require.NoError(t, sk.UnmarshalBinary(unhex(t, test.secretKey)), "vector %d", i)
+		pk, err := sk.PublicKey().MarshalBinary()
+		require.NoError(t, err)
+		require.Equal(t, unhex(t, test.publicKey), pk, "vector %d", i)
+
+		sig, err := sk.SignWithAuxRand(unhex(t, test.message), unhex(t, test.auxRand))
+		require.NoError(t, err, "vector %d", i)
+		out, err := sig.MarshalBinary()
+		require.NoError(t, err)
+		require.Equal(t, unhex(t, test.signature), out, "vector %d", i)
+	}
+}
+
+func TestVerifyVectors(t *testing.T) {
+	for i, test := range testVectors {
+		err := verifyVector(t, test.publicKey, test.message, test.signature)
+		if test.valid {
+			require.NoError(t, err, "vector %d", i)
+		} else {
+			require.Error(t, err, "vector %d", i)
+		}
+	}
+}
+
+func TestBatchVerifyVectors(t *testing.T) {
+	var pks []*PublicKey
+	var msgs [][]byte
+	var sigs []*Signature
+	type entry struct {
+		pk  *PublicKey
+		msg []byte
+		sig *Signature
+	}
+	var invalid []entry
+	for _, test := range testVectors {
+		pk, err := ParsePublicKey(unhex(t, test.publicKey))
+		if err != nil {
+			continue
+		}
+		sig := new(Signature)
+		if err := sig.UnmarshalBinary(unhex(t, test.signature)); err != nil {
+			continue
+		}
+		if test.valid {
+			pks = append(pks, pk)
+			msgs = append(msgs, unhex(t, test.message))
+			sigs = append(sigs, sig)
+		} else {
+			invalid = append(invalid, entry{pk, unhex(t, test.message), sig})
+		}
+	}
+	require.NoError(t, BatchVerify(crand.Reader, pks, msgs, sigs))
+	require.NoError(t, BatchVerify(crand.Reader, nil, nil, nil))
+	for i, e := range invalid {
+		err := BatchVerify(crand.Reader,
+			append(pks[:len(pks):len(pks)], e.pk),
+			append(msgs[:len(msgs):len(msgs)], e.msg),
+			append(sigs[:len(sigs):len(sigs)], e.sig))
+		require.Error(t, err, "invalid vector %d", i)
+	}
+	require.Error(t, BatchVerify(crand.Reader, pks[1:], msgs, sigs))
+	require.NoError(t, BatchVerify(nil, pks, msgs, sigs))
+}
+
+func TestBatchVerifyRoundTrip(t *testing.T) {
+	const n = 20
+	pks := make([]*PublicKey, n)
+	msgs := make([][]byte, n)
+	sigs := make([]*Signature, n)
+	for i := range pks {
+		pk, sk, err := NewKeys()
+		require.NoError(t, err)
+		pks[i] = pk
+		msgs[i] = []byte{byte(i)}
+		sigs[i], err = sk.Sign(msgs[i])
+		require.NoError(t, err)
+	}
+	require.NoError(t, BatchVerify(crand.Reader, pks, msgs, sigs))
+	pks[0], pks[1] = pks[1], pks[0]
+	require.Error(t, BatchVerify(crand.Reader, pks, msgs, sigs))
+}
+
+func TestSignVerifyRoundTrip(t *testing.T) {
+	pk, sk, err := NewKeys()
+	require.NoError(t, err)
+	// BIP-340 signs messages of any length
+	for _, msg := range [][]byte{nil, []byte("a"), make([]byte, 100)} {
+		sig, err := sk.Sign(msg)
+		require.NoError(t, err)
+		require.NoError(t, pk.Verify(msg, sig))
+
+		other, _, err := NewKeys()
+		require.NoError(t, err)
+		require.Error(t, other.Verify(msg, sig))
+
+		raw, err := sig.MarshalBinary()
+		require.NoError(t, err)
+		decoded := new(Signature)
+		require.NoError(t, decoded.UnmarshalBinary(raw))
+		require.NoError(t, pk.Verify(msg, decoded))
+	}
+}
+
+func TestPublicKeyIsXOnly(t *testing.T) {
+	pk, _, err := NewKeys()
+	require.NoError(t, err)
+	require.True(t, HasEvenY(pk.Point()))
+	raw, err := pk.MarshalBinary()
+	require.NoError(t, err)
+	require.Len(t, raw, PublicKeySize)
+
+	decoded := new(PublicKey)
+	require.NoError(t, decoded.UnmarshalBinary(raw))
+	require.True(t, decoded.Point().Equal(pk.Point()))
+	require.Error(t, decoded.UnmarshalBinary(raw[1:]))
+}
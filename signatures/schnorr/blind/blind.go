@@ -0,0 +1,232 @@
+// Package blind implements the clause blind Schnorr signature of Fuchsbauer,
+// Plouviez and Seurin, https://eprint.iacr.org/2019/877
+//
+// In a blind signature the signer signs a message it never sees and cannot
+// link the final signature to the session that issued it, which is the core
+// of privacy preserving token issuance.
+//
+// The plain blind Schnorr protocol is broken by the ROS attack once a signer
+// runs a few hundred sessions concurrently. The clause construction runs two
+// sessions side by side: the signer commits to two nonces, the user blinds
+// both, and the signer answers only one of them chosen at random. Forging
+// then requires solving the modified ROS problem, for which no efficient
+// attack is known, so sessions may run concurrently.
+//
+// Signing takes three moves:
+//
+//	signer: Commit       -> Commitment
+//	user:   Challenge    -> Challenge
+//	signer: Respond      -> Response
+//	user:   Unblind      -> Signature
+//
+// Signatures are Schnorr signatures (R, s) with s·G = R + H(R, X, m)·X and
+// verify with Verify.
+package blind
+
+import (
+	crand "crypto/rand"
+	"fmt"
+	"io"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/internal"
+)
+
+const challengeDomain = "go-sonr blind schnorr v1"
+
+// Commitment is the signer's first message, the commitments to its two nonces
+type Commitment struct {
+	R [2]curves.Point
+}
+
+// Challenge is the user's message, the blinded challenges of both clauses
+type Challenge struct {
+	C [2]curves.Scalar
+}
+
+// Response is the signer's answer to one of the two clauses
+type Response struct {
+	Clause uint8
+	S      curves.Scalar
+}
+
+// Signature is a Schnorr signature
+type Signature struct {
+	R curves.Point
+	S curves.Scalar
+}
+
+// Signer holds the secret key and the nonces of one signing session
+type Signer struct {
+	curve  *curves.Curve
+	sk     curves.Scalar
+	nonces [2]curves.Scalar
+	round  int
+}
+
+// User holds the blinding values of one signing session
+type User struct {
+	curve      *curves.Curve
+	pk         curves.Point
+	msg        []byte
+	alpha      [2]curves.Scalar
+	blindedR   [2]curves.Point
+	challenges [2]curves.Scalar
+	round      int
+}
+
+// NewSigner creates the signer of one session. A Signer answers exactly one
+// challenge, every session needs a new one.
+func NewSigner(curve *curves.Curve, sk curves.Scalar) (*Signer, error) {
+	if curve == nil || sk == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if sk.IsZero() {
+		return nil, internal.ErrZeroValue
+	}
+	return &Signer{curve: curve, sk: sk, round: 1}, nil
+}
+
+// Commit draws the two nonces of the session from crypto/rand
+func (s *Signer) Commit() (*Commitment, error) {
+	return s.CommitFromReader(crand.Reader)
+}
+
+// CommitFromReader draws the two nonces of the session from reader
+func (s *Signer) CommitFromReader(reader io.Reader) (*Commitment, error) {
+	if reader == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if s.round != 1 {
+		return nil, internal.ErrInvalidRound
+	}
+	commitment := &Commitment{}
+	for i := range s.nonces {
+		s.nonces[i] = s.curve.Scalar.Random(reader)
+		commitment.R[i] = s.curve.ScalarBaseMult(s.nonces[i])
+	}
+	s.round = 2
+	return commitment, nil
+}
+
+// Respond answers one randomly chosen clause of challenge drawn from crypto/rand
+func (s *Signer) Respond(challenge *Challenge) (*Response, error) {
+	return s.RespondFromReader(challenge, crand.Reader)
+}
+
+// RespondFromReader answers one clause of challenge chosen with a random bit
+// from reader. The nonces are erased so the signer never answers twice.
+func (s *Signer) RespondFromReader(challenge *Challenge, reader io.Reader) (*Response, error) {
+	if challenge == nil || challenge.C[0] == nil || challenge.C[1] == nil || reader == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if s.round != 2 {
+		return nil, internal.ErrInvalidRound
+	}
+	var bit [1]byte
+	if _, err := io.ReadFull(reader, bit[:]); err != nil {
+		return nil, err
+	}
+	clause := bit[0] & 1
+	// s = r_b + c_b·x
+	response := &Response{Clause: clause, S: s.nonces[clause].Add(challenge.C[clause].Mul(s.sk))}
+	s.nonces = [2]curves.Scalar{}
+	s.round = 3
+	return response, nil
+}
+
+// NewUser creates the user of one session asking for a signature over msg under pk
+func NewUser(pk curves.Point, msg []byte) (*User, error) {
+	if pk == nil || msg == nil {
+		return nil, internal.ErrNilArguments
+	}
+	curve := curves.GetCurveByName(pk.CurveName())
+	if curve == nil {
+		return nil, fmt.Errorf("unsupported curve %s", pk.CurveName())
+	}
+	if !pk.IsOnCurve() || pk.IsIdentity() {
+		return nil, internal.ErrNotOnCurve
+	}
+	return &User{curve: curve, pk: pk, msg: msg, round: 1}, nil
+}
+
+// Challenge blinds both nonces of commitment with randomness from crypto/rand
+func (u *User) Challenge(commitment *Commitment) (*Challenge, error) {
+	return u.ChallengeFromReader(commitment, crand.Reader)
+}
+
+// ChallengeFromReader blinds both nonces of commitment with randomness from reader
+func (u *User) ChallengeFromReader(commitment *Commitment, reader io.Reader) (*Challenge, error) {
+	if commitment == nil || commitment.R[0] == nil || commitment.R[1] == nil || reader == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if u.round != 1 {
+		return nil, internal.ErrInvalidRound
+	}
+	challenge := &Challenge{}
+	for i, r := range commitment.R {
+		if r.CurveName() != u.curve.Name || !r.IsOnCurve() || r.IsIdentity() {
+			return nil, fmt.Errorf("invalid nonce commitment %d", i)
+		}
+		alpha := u.curve.Scalar.Random(reader)
+		beta := u.curve.Scalar.Random(reader)
+		// R' = R + α·G + β·X, c = H(R', X, m) + β
+		blinded := r.Add(u.curve.ScalarBaseMult(alpha)).Add(u.pk.Mul(beta))
+		if blinded.IsIdentity() {
+			return nil, fmt.Errorf("blinded nonce is the identity")
+		}
+		u.alpha[i], u.blindedR[i] = alpha, blinded
+		u.challenges[i] = challengeHash(u.curve, blinded, u.pk, u.msg)
+		challenge.C[i] = u.challenges[i].Add(beta)
+	}
+	u.round = 2
+	return challenge, nil
+}
+
+// Unblind checks the signer's response and returns the signature over the message
+func (u *User) Unblind(response *Response) (*Signature, error) {
+	if response == nil || response.S == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if u.round != 2 {
+		return nil, internal.ErrInvalidRound
+	}
+	if response.Clause > 1 {
+		return nil, fmt.Errorf("invalid clause %d", response.Clause)
+	}
+	b := response.Clause
+	// s·G == R_b + c_b·X with c_b = H(R'_b, X, m) + β_b
+	//      == R'_b - α_b·G + H(R'_b, X, m)·X
+	expected := u.blindedR[b].Sub(u.curve.ScalarBaseMult(u.alpha[b])).Add(u.pk.Mul(u.challenges[b]))
+	if !u.curve.ScalarBaseMult(response.S).Equal(expected) {
+		return nil, fmt.Errorf("invalid signer response")
+	}
+	u.round = 3
+	return &Signature{R: u.blindedR[b], S: response.S.Add(u.alpha[b])}, nil
+}
+
+// Verify checks that sig is a signature over msg under pk
+func Verify(pk curves.Point, msg []byte, sig *Signature) error {
+	if pk == nil || sig == nil || sig.R == nil || sig.S == nil {
+		return internal.ErrNilArguments
+	}
+	curve := curves.GetCurveByName(pk.CurveName())
+	if curve == nil {
+		return fmt.Errorf("unsupported curve %s", pk.CurveName())
+	}
+	if sig.R.CurveName() != curve.Name || sig.R.IsIdentity() || pk.IsIdentity() {
+		return fmt.Errorf("invalid signature")
+	}
+	// s·G == R + H(R, X, m)·X
+	c := challengeHash(curve, sig.R, pk, msg)
+	if !curve.ScalarBaseMult(sig.S).Equal(sig.R.Add(pk.Mul(c))) {
+		return fmt.Errorf("invalid signature")
+	}
+	return nil
+}
+
+func challengeHash(curve *curves.Curve, r, pk curves.Point, msg []byte) curves.Scalar {
+	input := append([]byte(challengeDomain), r.ToAffineCompressed()...)
+	input = append(input, pk.ToAffineCompressed()...)
+	return curve.Scalar.Hash(append(input, msg...))
+}
@@ -0,0 +1,91 @@
+package blind_test
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/signatures/schnorr/blind"
+)
+
+func runSession(t *testing.T, curve *curves.Curve, sk curves.Scalar, pk curves.Point, msg []byte) (*blind.Signature, *blind.Commitment, *blind.Challenge, *blind.Response) {
+	t.Helper()
+	signer, err := blind.NewSigner(curve, sk)
+	require.NoError(t, err)
+	user, err := blind.NewUser(pk, msg)
+	require.NoError(t, err)
+
+	commitment, err := signer.Commit()
+	require.NoError(t, err)
+	challenge, err := user.Challenge(commitment)
+	require.NoError(t, err)
+	response, err := signer.Respond(challenge)
+	require.NoError(t, err)
+	sig, err := user.Unblind(response)
+	require.NoError(t, err)
+	return sig, commitment, challenge, response
+}
+
+func TestBlindSchnorr(t *testing.T) {
+	for _, curve := range []*curves.Curve{curves.K256(), curves.P256(), curves.ED25519()} {
+		sk := curve.Scalar.Random(crand.Reader)
+		pk := curve.ScalarBaseMult(sk)
+		msg := []byte("token serial")
+		for i := 0; i < 8; i++ {
+			sig, commitment, _, response := runSession(t, curve, sk, pk, msg)
+			require.NoError(t, blind.Verify(pk, msg, sig))
+			require.Error(t, blind.Verify(pk, []byte("other serial"), sig))
+			// The signature nonce is unlinkable to the signer's view of the session
+			require.False(t, sig.R.Equal(commitment.R[response.Clause]))
+		}
+	}
+}
+
+func TestBlindSchnorrSignerAnswersOnce(t *testing.T) {
+	curve := curves.K256()
+	sk := curve.Scalar.Random(crand.Reader)
+	signer, err := blind.NewSigner(curve, sk)
+	require.NoError(t, err)
+	user, err := blind.NewUser(curve.ScalarBaseMult(sk), []byte("token serial"))
+	require.NoError(t, err)
+
+	commitment, err := signer.Commit()
+	require.NoError(t, err)
+	_, err = signer.Commit()
+	require.Error(t, err)
+	challenge, err := user.Challenge(commitment)
+	require.NoError(t, err)
+	_, err = signer.Respond(challenge)
+	require.NoError(t, err)
+	_, err = signer.Respond(challenge)
+	require.Error(t, err)
+}
+
+func TestBlindSchnorrInvalidResponse(t *testing.T) {
+	curve := curves.P256()
+	sk := curve.Scalar.Random(crand.Reader)
+	pk := curve.ScalarBaseMult(sk)
+	signer, err := blind.NewSigner(curve, sk)
+	require.NoError(t, err)
+	user, err := blind.NewUser(pk, []byte("token serial"))
+	require.NoError(t, err)
+
+	commitment, err := signer.Commit()
+	require.NoError(t, err)
+	challenge, err := user.Challenge(commitment)
+	require.NoError(t, err)
+	response, err := signer.Respond(challenge)
+	require.NoError(t, err)
+
+	response.S = response.S.Add(curve.Scalar.One())
+	_, err = user.Unblind(response)
+	require.Error(t, err)
+	response.Clause = 2
+	_, err = user.Unblind(response)
+	require.Error(t, err)
+
+	_, err = blind.NewUser(curves.P256().NewIdentityPoint(), []byte("token serial"))
+	require.Error(t, err)
+}
@@ -0,0 +1,173 @@
+// Package signcryption signs and encrypts a message in one pass over any
+// curve in core/curves, so a message layer cannot get the binding between
+// the two steps wrong.
+//
+// The sender draws an ephemeral key E = eG and derives the encryption key
+// from both eR and sR, the ephemeral and static Diffie-Hellman secrets with
+// the recipient key R, so only the sender and the recipient can produce or
+// read a ciphertext. The plaintext is encrypted in chunks with the STREAM
+// construction over ChaCha20-Poly1305, and the final chunk carries a Schnorr
+// signature by the sender over the sender and recipient keys, E, the
+// associated data and the SHA-512 digest of the plaintext. Signing the
+// recipient and E stops a recipient from forwarding the signed message to a
+// third party as if it were addressed to them, and keying the encryption with
+// sR stops anyone but the sender from replacing the signature.
+package signcryption
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/internal"
+)
+
+const label = "sonr-signcryption-v1"
+
+// SenderKey is the static key pair a sender signcrypts with
+type SenderKey struct {
+	keyPair
+}
+
+// RecipientKey is the static key pair a recipient unsigncrypts with
+type RecipientKey struct {
+	keyPair
+}
+
+type keyPair struct {
+	curve *curves.Curve
+	sk    curves.Scalar
+	pk    curves.Point
+}
+
+func newKeyPair(sk curves.Scalar) (keyPair, error) {
+	if sk == nil {
+		return keyPair{}, internal.ErrNilArguments
+	}
+	if sk.IsZero() {
+		return keyPair{}, internal.ErrZeroValue
+	}
+	pk := sk.Point().Generator().Mul(sk)
+	curve := curves.GetCurveByName(pk.CurveName())
+	if curve == nil {
+		return keyPair{}, fmt.Errorf("unsupported curve %s", pk.CurveName())
+	}
+	return keyPair{curve: curve, sk: sk, pk: pk}, nil
+}
+
+// NewSenderKey returns the sender key with secret sk
+func NewSenderKey(sk curves.Scalar) (*SenderKey, error) {
+	kp, err := newKeyPair(sk)
+	if err != nil {
+		return nil, err
+	}
+	return &SenderKey{kp}, nil
+}
+
+// GenerateSenderKey draws a sender key on curve from crypto/rand
+func GenerateSenderKey(curve *curves.Curve) (*SenderKey, error) {
+	if curve == nil {
+		return nil, internal.ErrNilArguments
+	}
+	return NewSenderKey(curve.Scalar.Random(rand.Reader))
+}
+
+// NewRecipientKey returns the recipient key with secret sk
+func NewRecipientKey(sk curves.Scalar) (*RecipientKey, error) {
+	kp, err := newKeyPair(sk)
+	if err != nil {
+		return nil, err
+	}
+	return &RecipientKey{kp}, nil
+}
+
+// GenerateRecipientKey draws a recipient key on curve from crypto/rand
+func GenerateRecipientKey(curve *curves.Curve) (*RecipientKey, error) {
+	if curve == nil {
+		return nil, internal.ErrNilArguments
+	}
+	return NewRecipientKey(curve.Scalar.Random(rand.Reader))
+}
+
+// PublicKey returns the public key of the key pair
+func (k *keyPair) PublicKey() curves.Point {
+	return k.pk
+}
+
+// Zeroize overwrites the secret key with zero
+func (k *keyPair) Zeroize() {
+	curves.Zeroize(k.sk)
+}
+
+// checkPeer validates the public key of the other party
+func (k *keyPair) checkPeer(pk curves.Point) error {
+	if pk == nil {
+		return internal.ErrNilArguments
+	}
+	if pk.CurveName() != k.curve.Name {
+		return fmt.Errorf("peer key is on %s, key is on %s", pk.CurveName(), k.curve.Name)
+	}
+	if pk.IsIdentity() || !pk.IsOnCurve() {
+		return internal.ErrNotOnCurve
+	}
+	return nil
+}
+
+// Signcrypt signs and encrypts msg to recipient, binding ad
+func (k *SenderKey) Signcrypt(recipient curves.Point, msg, ad []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := k.NewWriter(&buf, recipient, ad)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = w.Write(msg); err != nil {
+		return nil, err
+	}
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Unsigncrypt decrypts a ciphertext of Signcrypt from sender and checks its
+// signature and ad
+func (k *RecipientKey) Unsigncrypt(sender curves.Point, ciphertext, ad []byte) ([]byte, error) {
+	r, err := k.NewReader(bytes.NewReader(ciphertext), sender, ad)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(r)
+}
+
+// transcript binds the curve, both static keys, the ephemeral key and the
+// associated data, each prefixed with its length
+func transcript(curve *curves.Curve, sender, recipient, ephemeral curves.Point, ad []byte) []byte {
+	var out []byte
+	for _, b := range [][]byte{
+		[]byte(label),
+		[]byte(curve.Name),
+		sender.ToAffineCompressed(),
+		recipient.ToAffineCompressed(),
+		ephemeral.ToAffineCompressed(),
+		ad,
+	} {
+		out = binary.BigEndian.AppendUint32(out, uint32(len(b)))
+		out = append(out, b...)
+	}
+	return out
+}
+
+// challenge is the Schnorr challenge over the transcript, the signature
+// nonce and the digest of the plaintext
+func challenge(curve *curves.Curve, transcript []byte, nonce curves.Point, digest []byte) curves.Scalar {
+	msg := append(append(append([]byte{}, transcript...), nonce.ToAffineCompressed()...), digest...)
+	return curve.Scalar.Hash(msg)
+}
+
+// signatureSize is a compressed nonce point and a scalar
+func signatureSize(curve *curves.Curve) int {
+	return len(curve.Point.Generator().ToAffineCompressed()) + len(curve.Scalar.Zero().Bytes())
+}
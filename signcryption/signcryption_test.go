@@ -0,0 +1,110 @@
+package signcryption
+
+import (
+	"bytes"
+	crand "crypto/rand"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+)
+
+func TestSigncrypt(t *testing.T) {
+	for _, curve := range []*curves.Curve{curves.K256(), curves.P256(), curves.ED25519(), curves.PALLAS()} {
+		t.Run(curve.Name, func(t *testing.T) {
+			alice, err := GenerateSenderKey(curve)
+			require.NoError(t, err)
+			bob, err := GenerateRecipientKey(curve)
+			require.NoError(t, err)
+
+			ct, err := alice.Signcrypt(bob.PublicKey(), []byte("hello bob"), []byte("ad"))
+			require.NoError(t, err)
+			pt, err := bob.Unsigncrypt(alice.PublicKey(), ct, []byte("ad"))
+			require.NoError(t, err)
+			require.Equal(t, []byte("hello bob"), pt)
+
+			_, err = bob.Unsigncrypt(alice.PublicKey(), ct, []byte("other ad"))
+			require.Error(t, err)
+
+			mallory, err := GenerateSenderKey(curve)
+			require.NoError(t, err)
+			_, err = bob.Unsigncrypt(mallory.PublicKey(), ct, []byte("ad"))
+			require.Error(t, err)
+
+			eve, err := GenerateRecipientKey(curve)
+			require.NoError(t, err)
+			_, err = eve.Unsigncrypt(alice.PublicKey(), ct, []byte("ad"))
+			require.Error(t, err)
+
+			for i := range ct {
+				tampered := append([]byte{}, ct...)
+				tampered[i] ^= 0x01
+				_, err = bob.Unsigncrypt(alice.PublicKey(), tampered, []byte("ad"))
+				require.Error(t, err)
+			}
+			_, err = bob.Unsigncrypt(alice.PublicKey(), ct[:len(ct)-1], []byte("ad"))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestSigncryptStream(t *testing.T) {
+	curve := curves.K256()
+	alice, err := GenerateSenderKey(curve)
+	require.NoError(t, err)
+	bob, err := GenerateRecipientKey(curve)
+	require.NoError(t, err)
+	sigSize := signatureSize(curve)
+
+	for _, size := range []int{0, 1, ChunkSize - sigSize, ChunkSize - 1, ChunkSize, ChunkSize + 1, 3*ChunkSize - sigSize - 1, 3 * ChunkSize} {
+		msg := make([]byte, size)
+		_, _ = crand.Read(msg)
+
+		var buf bytes.Buffer
+		w, err := alice.NewWriter(&buf, bob.PublicKey(), nil)
+		require.NoError(t, err)
+		for i := 0; i < len(msg); i += 1000 {
+			_, err = w.Write(msg[i:min(i+1000, len(msg))])
+			require.NoError(t, err)
+		}
+		require.NoError(t, w.Close())
+		ct := buf.Bytes()
+
+		r, err := bob.NewReader(bytes.NewReader(ct), alice.PublicKey(), nil)
+		require.NoError(t, err)
+		got, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.True(t, bytes.Equal(msg, got))
+
+		pt, err := bob.Unsigncrypt(alice.PublicKey(), ct, nil)
+		require.NoError(t, err)
+		require.True(t, bytes.Equal(msg, pt))
+
+		// truncated at a chunk boundary
+		header := len(bob.PublicKey().ToAffineCompressed())
+		if size > ChunkSize {
+			_, err = bob.Unsigncrypt(alice.PublicKey(), ct[:header+encChunkSize], nil)
+			require.Error(t, err)
+		}
+		_, err = bob.Unsigncrypt(alice.PublicKey(), append(append([]byte{}, ct...), 0), nil)
+		require.Error(t, err)
+	}
+}
+
+func TestSigncryptInvalid(t *testing.T) {
+	_, err := GenerateSenderKey(nil)
+	require.Error(t, err)
+	_, err = NewRecipientKey(curves.K256().Scalar.Zero())
+	require.Error(t, err)
+
+	alice, err := GenerateSenderKey(curves.K256())
+	require.NoError(t, err)
+	_, err = alice.Signcrypt(curves.P256().Point.Random(crand.Reader), []byte("x"), nil)
+	require.Error(t, err)
+	_, err = alice.Signcrypt(curves.K256().NewIdentityPoint(), []byte("x"), nil)
+	require.Error(t, err)
+	_, err = alice.Signcrypt(nil, []byte("x"), nil)
+	require.Error(t, err)
+}
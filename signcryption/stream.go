@@ -0,0 +1,309 @@
+package signcryption
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"io"
+	"math"
+
+	"golang.org/x/crypto/chacha20poly1305"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/secret"
+	"github.com/go-sonr/crypto/keyexchange"
+)
+
+// ChunkSize is the plaintext size of every chunk but the last
+const ChunkSize = 64 * 1024
+
+const encChunkSize = ChunkSize + chacha20poly1305.Overhead
+
+// stream is ChaCha20-Poly1305 with a 64 bit big endian chunk counter and a
+// last chunk flag as the nonce
+type stream struct {
+	aead    cipher.AEAD
+	counter uint64
+	nonce   [chacha20poly1305.NonceSize]byte
+}
+
+// newStream derives the key from the ephemeral and static shared secrets
+func newStream(ephemeralShared, staticShared curves.Point, transcript []byte) (*stream, error) {
+	ikm := append(ephemeralShared.ToAffineCompressed(), staticShared.ToAffineCompressed()...)
+	defer secret.Wipe(ikm)
+	key, err := keyexchange.HKDF(sha256.New, ikm, transcript, []byte(label+" key"), chacha20poly1305.KeySize)
+	if err != nil {
+		return nil, err
+	}
+	defer secret.Wipe(key)
+	a, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, err
+	}
+	return &stream{aead: a}, nil
+}
+
+func (s *stream) next(last bool) ([]byte, error) {
+	if s.counter == math.MaxUint64 {
+		return nil, fmt.Errorf("message has too many chunks")
+	}
+	binary.BigEndian.PutUint64(s.nonce[3:11], s.counter)
+	s.nonce[11] = 0
+	if last {
+		s.nonce[11] = 1
+	}
+	s.counter++
+	return s.nonce[:], nil
+}
+
+type writer struct {
+	dst        io.Writer
+	sender     *SenderKey
+	transcript []byte
+	stream     *stream
+	digest     hash.Hash
+	buf        []byte
+	closed     bool
+}
+
+// NewWriter returns a writer that signcrypts to recipient, binding ad. The
+// ephemeral key is written to dst at once, Close signs the message and
+// writes the final chunk.
+func (k *SenderKey) NewWriter(dst io.Writer, recipient curves.Point, ad []byte) (io.WriteCloser, error) {
+	if dst == nil {
+		return nil, fmt.Errorf("destination is required")
+	}
+	if err := k.checkPeer(recipient); err != nil {
+		return nil, err
+	}
+	e := k.curve.Scalar.Random(rand.Reader)
+	defer curves.Zeroize(e)
+	ephemeral := k.curve.ScalarBaseMult(e)
+	t := transcript(k.curve, k.pk, recipient, ephemeral, ad)
+	s, err := newStream(recipient.Mul(e), recipient.Mul(k.sk), t)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dst.Write(ephemeral.ToAffineCompressed()); err != nil {
+		return nil, err
+	}
+	return &writer{
+		dst:        dst,
+		sender:     k,
+		transcript: t,
+		stream:     s,
+		digest:     sha512.New(),
+		buf:        make([]byte, 0, encChunkSize+signatureSize(k.curve)),
+	}, nil
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, fmt.Errorf("write to closed signcryption writer")
+	}
+	_, _ = w.digest.Write(p)
+	written := 0
+	for len(p) > 0 {
+		// a full chunk is only sealed once more data arrives, so the final
+		// chunk holds less than ChunkSize bytes of the message
+		if len(w.buf) == ChunkSize {
+			if err := w.flush(false); err != nil {
+				return written, err
+			}
+		}
+		n := copy(w.buf[len(w.buf):ChunkSize], p)
+		w.buf = w.buf[:len(w.buf)+n]
+		p = p[n:]
+		written += n
+	}
+	return written, nil
+}
+
+func (w *writer) flush(last bool) error {
+	nonce, err := w.stream.next(last)
+	if err != nil {
+		return err
+	}
+	chunk := w.stream.aead.Seal(w.buf[:0], nonce, w.buf, nil)
+	if _, err := w.dst.Write(chunk); err != nil {
+		return err
+	}
+	w.buf = w.buf[:0]
+	return nil
+}
+
+// Close signs the message and seals it into the final chunk, it does not
+// close the destination
+func (w *writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	if len(w.buf) == ChunkSize {
+		if err := w.flush(false); err != nil {
+			return err
+		}
+	}
+	sig, err := w.sign()
+	if err != nil {
+		return err
+	}
+	w.buf = append(w.buf, sig...)
+	return w.flush(true)
+}
+
+// sign is a Schnorr signature with a nonce hedged by fresh randomness and
+// derived from the secret key and the message
+func (w *writer) sign() ([]byte, error) {
+	curve := w.sender.curve
+	digest := w.digest.Sum(nil)
+	var aux [32]byte
+	if _, err := rand.Read(aux[:]); err != nil {
+		return nil, err
+	}
+	sk := w.sender.sk.Bytes()
+	defer secret.Wipe(sk)
+	seed := append(append(append(append([]byte{}, aux[:]...), sk...), w.transcript...), digest...)
+	defer secret.Wipe(seed)
+	k := curve.Scalar.Hash(seed)
+	defer curves.Zeroize(k)
+	if k.IsZero() {
+		return nil, fmt.Errorf("derived a zero nonce")
+	}
+	nonce := curve.ScalarBaseMult(k)
+	c := challenge(curve, w.transcript, nonce, digest)
+	z := k.Add(c.Mul(w.sender.sk))
+	return append(nonce.ToAffineCompressed(), z.Bytes()...), nil
+}
+
+type reader struct {
+	src        io.Reader
+	curve      *curves.Curve
+	sender     curves.Point
+	transcript []byte
+	stream     *stream
+	digest     hash.Hash
+	buf        []byte
+	pending    int
+	out        []byte
+	done       bool
+}
+
+// NewReader returns a reader of a message signcrypted by sender, binding
+// ad. Every chunk is authenticated as it is read, but the signature is only
+// checked at the end: the message is signed once Read returns io.EOF.
+func (k *RecipientKey) NewReader(src io.Reader, sender curves.Point, ad []byte) (io.Reader, error) {
+	if src == nil {
+		return nil, fmt.Errorf("source is required")
+	}
+	if err := k.checkPeer(sender); err != nil {
+		return nil, err
+	}
+	enc := make([]byte, len(k.pk.ToAffineCompressed()))
+	if _, err := io.ReadFull(src, enc); err != nil {
+		return nil, fmt.Errorf("reading ephemeral key: %w", err)
+	}
+	ephemeral, err := k.curve.Point.FromAffineCompressed(enc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ephemeral key: %w", err)
+	}
+	if ephemeral.IsIdentity() {
+		return nil, fmt.Errorf("invalid ephemeral key")
+	}
+	t := transcript(k.curve, sender, k.pk, ephemeral, ad)
+	s, err := newStream(ephemeral.Mul(k.sk), sender.Mul(k.sk), t)
+	if err != nil {
+		return nil, err
+	}
+	return &reader{
+		src:        src,
+		curve:      k.curve,
+		sender:     sender,
+		transcript: t,
+		stream:     s,
+		digest:     sha512.New(),
+		buf:        make([]byte, encChunkSize+signatureSize(k.curve)),
+	}, nil
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	for len(r.out) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		if err := r.readChunk(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.out)
+	r.out = r.out[n:]
+	return n, nil
+}
+
+// readChunk keeps a signature worth of ciphertext beyond a full chunk
+// buffered: the final chunk is shorter than that, so a full buffer starts
+// with a chunk that is not the last
+func (r *reader) readChunk() error {
+	n, err := io.ReadFull(r.src, r.buf[r.pending:])
+	r.pending += n
+	last := false
+	switch err {
+	case nil:
+	case io.EOF, io.ErrUnexpectedEOF:
+		last = true
+	default:
+		return err
+	}
+
+	size := encChunkSize
+	if last {
+		size = r.pending
+		if size < signatureSize(r.curve)+chacha20poly1305.Overhead {
+			return fmt.Errorf("message is truncated")
+		}
+	}
+	nonce, err := r.stream.next(last)
+	if err != nil {
+		return err
+	}
+	out, err := r.stream.aead.Open(nil, nonce, r.buf[:size], nil)
+	if err != nil {
+		return fmt.Errorf("decrypting chunk: %w", err)
+	}
+	r.pending = copy(r.buf, r.buf[size:r.pending])
+
+	if last {
+		msgLen := len(out) - signatureSize(r.curve)
+		_, _ = r.digest.Write(out[:msgLen])
+		if err := r.verify(out[msgLen:]); err != nil {
+			return err
+		}
+		out = out[:msgLen]
+		r.done = true
+	} else {
+		_, _ = r.digest.Write(out)
+	}
+	r.out = out
+	return nil
+}
+
+func (r *reader) verify(sig []byte) error {
+	pointSize := len(r.curve.Point.Generator().ToAffineCompressed())
+	nonce, err := r.curve.Point.FromAffineCompressed(sig[:pointSize])
+	if err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+	z, err := r.curve.Scalar.SetBytes(sig[pointSize:])
+	if err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+	c := challenge(r.curve, r.transcript, nonce, r.digest.Sum(nil))
+	if !r.curve.ScalarBaseMult(z).Equal(nonce.Add(r.sender.Mul(c))) {
+		return fmt.Errorf("invalid signature")
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package simulator
+
+import (
+	"io"
+	"math/big"
+)
+
+// Fault sees every message before delivery and returns the messages
+// delivered in its place: none to drop it, a changed copy to corrupt it,
+// several to duplicate it
+type Fault func(m *Message) []*Message
+
+// Match selects the messages a fault applies to
+type Match func(m *Message) bool
+
+// Any matches every message
+func Any(*Message) bool { return true }
+
+// From matches the messages sent by a party
+func From(id uint32) Match {
+	return func(m *Message) bool { return m.From == id }
+}
+
+// To matches the messages delivered to a party
+func To(id uint32) Match {
+	return func(m *Message) bool { return m.To == id }
+}
+
+// InRound matches the messages sent in round r
+func InRound(r int) Match {
+	return func(m *Message) bool { return m.Round == r }
+}
+
+// All matches the messages every match matches
+func All(matches ...Match) Match {
+	return func(m *Message) bool {
+		for _, match := range matches {
+			if !match(m) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Drop drops the matching messages
+func Drop(match Match) Fault {
+	return func(m *Message) []*Message {
+		if match(m) {
+			return nil
+		}
+		return []*Message{m}
+	}
+}
+
+// Corrupt flips one bit, at a position drawn from reader, of the payload of
+// the matching messages
+func Corrupt(match Match, reader io.Reader) Fault {
+	return func(m *Message) []*Message {
+		if !match(m) || len(m.Payload) == 0 {
+			return []*Message{m}
+		}
+		c := m.clone()
+		bit := 0
+		var b [8]byte
+		if _, err := io.ReadFull(reader, b[:]); err == nil {
+			n := new(big.Int).SetBytes(b[:])
+			bit = int(n.Mod(n, big.NewInt(int64(len(c.Payload)*8))).Int64())
+		}
+		c.Payload[bit/8] ^= 1 << (bit % 8)
+		return []*Message{c}
+	}
+}
+
+// Duplicate delivers the matching messages twice
+func Duplicate(match Match) Fault {
+	return func(m *Message) []*Message {
+		if match(m) {
+			return []*Message{m, m.clone()}
+		}
+		return []*Message{m}
+	}
+}
+
+// Rewrite replaces the payload of the matching messages with the result of
+// f, for targeted attacks such as a bad share sent to one party
+func Rewrite(match Match, f func(m *Message) []byte) Fault {
+	return func(m *Message) []*Message {
+		if !match(m) {
+			return []*Message{m}
+		}
+		c := m.clone()
+		c.Payload = f(m)
+		return []*Message{c}
+	}
+}
+
+type byzantine struct {
+	Party
+	tamper func(r int, out []*Message) []*Message
+}
+
+// Byzantine wraps p so that tamper rewrites the messages it sends in every
+// round, before they reach the network. Unlike faults, tamper sees the
+// messages before broadcasts are expanded.
+func Byzantine(p Party, tamper func(r int, out []*Message) []*Message) Party {
+	return &byzantine{Party: p, tamper: tamper}
+}
+
+func (b *byzantine) Round(r int, in []*Message) ([]*Message, bool, error) {
+	out, done, err := b.Party.Round(r, in)
+	if err != nil {
+		return nil, false, err
+	}
+	return b.tamper(r, out), done, nil
+}
@@ -0,0 +1,195 @@
+package simulator
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/protocol"
+	"github.com/go-sonr/crypto/dkg/frost"
+	"github.com/go-sonr/crypto/sharing"
+)
+
+// tags of FROST DKG payloads, which share a round
+const (
+	tagBroadcast = 'b'
+	tagDirect    = 'p'
+)
+
+// FrostDKG is a party of the FROST DKG of dkg/frost. Round 1 deals the
+// shares, round 2 computes the key, and round 3 checks that every party
+// computed the same verification key.
+type FrostDKG struct {
+	*frost.DkgParticipant
+	round1 *frost.Round1Bcast
+}
+
+// NewFrostDKGParties returns the limit parties, identified 1 to limit, of
+// a threshold FROST DKG over curve
+func NewFrostDKGParties(curve *curves.Curve, threshold, limit uint32, ctx string) ([]*FrostDKG, error) {
+	parties := make([]*FrostDKG, 0, limit)
+	for i := uint32(1); i <= limit; i++ {
+		var others []uint32
+		for j := uint32(1); j <= limit; j++ {
+			if j != i {
+				others = append(others, j)
+			}
+		}
+		p, err := frost.NewDkgParticipant(i, threshold, ctx, curve, others...)
+		if err != nil {
+			return nil, err
+		}
+		parties = append(parties, &FrostDKG{DkgParticipant: p})
+	}
+	return parties, nil
+}
+
+// ID returns the identifier of the participant
+func (p *FrostDKG) ID() uint32 {
+	return p.Id
+}
+
+// Round runs the DKG round r
+func (p *FrostDKG) Round(r int, in []*Message) ([]*Message, bool, error) {
+	switch r {
+	case 1:
+		bcast, p2p, err := p.Round1(nil)
+		if err != nil {
+			return nil, false, err
+		}
+		p.round1 = bcast
+		data, err := bcast.MarshalBinary()
+		if err != nil {
+			return nil, false, err
+		}
+		out := []*Message{{To: Broadcast, Payload: append([]byte{tagBroadcast}, data...)}}
+		for id, share := range p2p {
+			data, err := share.MarshalBinary()
+			if err != nil {
+				return nil, false, err
+			}
+			out = append(out, &Message{To: id, Payload: append([]byte{tagDirect}, data...)})
+		}
+		return out, false, nil
+
+	case 2:
+		bcast := map[uint32]*frost.Round1Bcast{p.Id: p.round1}
+		p2p := map[uint32]*sharing.ShamirShare{}
+		for _, m := range in {
+			if len(m.Payload) == 0 {
+				return nil, false, fmt.Errorf("empty message from party %d", m.From)
+			}
+			var err error
+			switch m.Payload[0] {
+			case tagBroadcast:
+				if bcast[m.From] != nil {
+					return nil, false, fmt.Errorf("two broadcasts from party %d", m.From)
+				}
+				bcast[m.From] = new(frost.Round1Bcast)
+				err = bcast[m.From].UnmarshalBinary(m.Payload[1:])
+			case tagDirect:
+				if p2p[m.From] != nil {
+					return nil, false, fmt.Errorf("two shares from party %d", m.From)
+				}
+				p2p[m.From] = new(sharing.ShamirShare)
+				err = p2p[m.From].UnmarshalBinary(m.Payload[1:])
+			default:
+				err = fmt.Errorf("unknown message")
+			}
+			if err != nil {
+				return nil, false, fmt.Errorf("message from party %d: %w", m.From, err)
+			}
+		}
+		// a dealer counts only with both its broadcast and its share
+		for id := range bcast {
+			if id != p.Id && p2p[id] == nil {
+				delete(bcast, id)
+			}
+		}
+		for id := range p2p {
+			if bcast[id] == nil {
+				delete(p2p, id)
+			}
+		}
+		out, err := p.Round2(bcast, p2p)
+		if err != nil {
+			return nil, false, err
+		}
+		data, err := out.MarshalBinary()
+		if err != nil {
+			return nil, false, err
+		}
+		return []*Message{{To: Broadcast, Payload: data}}, false, nil
+
+	case 3:
+		for _, m := range in {
+			bcast := new(frost.Round2Bcast)
+			if err := bcast.UnmarshalBinary(m.Payload); err != nil {
+				return nil, false, fmt.Errorf("message from party %d: %w", m.From, err)
+			}
+			if !bcast.VerificationKey.Equal(p.VerificationKey) {
+				return nil, false, fmt.Errorf("party %d computed another verification key", m.From)
+			}
+		}
+		return nil, true, nil
+
+	default:
+		return nil, false, fmt.Errorf("no round %d", r)
+	}
+}
+
+// Iterator is a party of a two-party protocol.Iterator protocol, such as
+// the DKG and signing protocols of tecdsa/dklsv1, exchanging JSON encoded
+// messages with its peer. As when cranking iterators by hand, a step
+// without output still sends an empty message, so that the peer calls Next
+// again and learns the protocol has finished.
+type Iterator struct {
+	id, peer  uint32
+	iterator  protocol.Iterator
+	initiator bool
+}
+
+// NewIterator returns the party id running iterator with peer. The
+// initiator sends the first message.
+func NewIterator(id, peer uint32, iterator protocol.Iterator, initiator bool) *Iterator {
+	return &Iterator{id: id, peer: peer, iterator: iterator, initiator: initiator}
+}
+
+// ID returns the identifier of the party
+func (p *Iterator) ID() uint32 {
+	return p.id
+}
+
+// Result returns the result of the protocol once finished
+func (p *Iterator) Result(version uint) (*protocol.Message, error) {
+	return p.iterator.Result(version)
+}
+
+// Round runs the next step of the iterator on the message of the peer, or
+// waits for one
+func (p *Iterator) Round(r int, in []*Message) ([]*Message, bool, error) {
+	var input *protocol.Message
+	switch {
+	case r == 1 && !p.initiator, r > 1 && len(in) == 0:
+		return nil, false, nil
+	case len(in) > 1:
+		return nil, false, fmt.Errorf("%d messages in one round", len(in))
+	case len(in) == 1 && len(in[0].Payload) > 0:
+		input = new(protocol.Message)
+		if err := json.Unmarshal(in[0].Payload, input); err != nil {
+			return nil, false, fmt.Errorf("message from party %d: %w", in[0].From, err)
+		}
+	}
+	output, err := p.iterator.Next(input)
+	done := err == protocol.ErrProtocolFinished
+	if err != nil && !done {
+		return nil, false, err
+	}
+	var data []byte
+	if output != nil {
+		if data, err = json.Marshal(output); err != nil {
+			return nil, false, err
+		}
+	}
+	return []*Message{{To: p.peer, Payload: data}}, done, nil
+}
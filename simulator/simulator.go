@@ -0,0 +1,213 @@
+// Package simulator runs multi-party protocols in process, round by round,
+// across simulated parties connected by a network that can drop, corrupt
+// or rewrite messages.
+//
+// A protocol joins the simulator through the Party interface, and adapters
+// are provided for the FROST DKG and for two-party protocol.Iterator
+// protocols such as DKLs18. Faults are injected on the network, and
+// byzantine parties are parties that rewrite their own messages, so
+// protocol changes can be checked against misbehavior without standing up
+// real parties.
+package simulator
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/go-sonr/crypto/internal"
+)
+
+// Broadcast is the recipient of messages to every other party
+const Broadcast = 0
+
+// DefaultMaxRounds bounds the rounds of a run when MaxRounds is zero
+const DefaultMaxRounds = 100
+
+// ErrStalled is returned by Run when parties have not finished but no
+// message is in flight, usually because the network dropped one
+var ErrStalled = errors.New("simulator: protocol stalled")
+
+// Message is a message of a round. To is a party, or Broadcast.
+type Message struct {
+	From    uint32
+	To      uint32
+	Round   int
+	Payload []byte
+}
+
+func (m *Message) clone() *Message {
+	c := *m
+	c.Payload = append([]byte(nil), m.Payload...)
+	return &c
+}
+
+// Party is a participant of a round based protocol
+type Party interface {
+	// ID returns the non-zero identifier of the party
+	ID() uint32
+	// Round runs round r on the messages delivered to the party at the end
+	// of round r-1, and returns the messages it sends and whether it has
+	// finished. A finished party is not called again.
+	Round(r int, in []*Message) (out []*Message, done bool, err error)
+}
+
+// Report is the outcome of a run
+type Report struct {
+	// Rounds is the number of rounds run
+	Rounds int
+	// Sent counts messages sent, a broadcast once per recipient
+	Sent int
+	// Delivered counts messages delivered after faults
+	Delivered int
+	// Finished are the parties that finished, in order
+	Finished []uint32
+	// Errors are the errors of the parties that failed
+	Errors map[uint32]error
+	// Transcript is every delivered message in order
+	Transcript []*Message
+}
+
+// Simulator runs parties over a faulty network
+type Simulator struct {
+	// MaxRounds bounds the rounds of a run, DefaultMaxRounds if zero
+	MaxRounds int
+
+	parties map[uint32]Party
+	ids     []uint32
+	faults  []Fault
+}
+
+// New returns a simulator of parties, which must have distinct non-zero
+// identifiers
+func New(parties ...Party) (*Simulator, error) {
+	if len(parties) < 2 {
+		return nil, fmt.Errorf("simulator: at least two parties are required")
+	}
+	s := &Simulator{parties: make(map[uint32]Party, len(parties))}
+	for _, p := range parties {
+		if p == nil {
+			return nil, internal.ErrNilArguments
+		}
+		id := p.ID()
+		if id == Broadcast {
+			return nil, fmt.Errorf("simulator: party identifiers must be non-zero")
+		}
+		if _, ok := s.parties[id]; ok {
+			return nil, fmt.Errorf("simulator: duplicate party %d", id)
+		}
+		s.parties[id] = p
+		s.ids = append(s.ids, id)
+	}
+	sort.Slice(s.ids, func(i, j int) bool { return s.ids[i] < s.ids[j] })
+	return s, nil
+}
+
+// Inject adds faults to the network, applied in order to every message
+func (s *Simulator) Inject(faults ...Fault) {
+	s.faults = append(s.faults, faults...)
+}
+
+// deliver expands broadcasts and applies the faults to a message
+func (s *Simulator) deliver(m *Message, report *Report) []*Message {
+	var out []*Message
+	for _, id := range s.ids {
+		if id == m.From || (m.To != Broadcast && m.To != id) {
+			continue
+		}
+		c := m.clone()
+		c.To = id
+		report.Sent++
+		msgs := []*Message{c}
+		for _, f := range s.faults {
+			var next []*Message
+			for _, x := range msgs {
+				next = append(next, f(x)...)
+			}
+			msgs = next
+		}
+		out = append(out, msgs...)
+	}
+	return out
+}
+
+// Run runs the parties until every party has finished or failed. It
+// returns the errors of failed parties joined, or ErrStalled, along with
+// the report, which is complete either way.
+func (s *Simulator) Run() (*Report, error) {
+	maxRounds := s.MaxRounds
+	if maxRounds == 0 {
+		maxRounds = DefaultMaxRounds
+	}
+	report := &Report{Errors: map[uint32]error{}}
+	finished := map[uint32]bool{}
+	inboxes := map[uint32][]*Message{}
+	for r := 1; ; r++ {
+		if r > maxRounds {
+			return report, fmt.Errorf("simulator: no result after %d rounds", maxRounds)
+		}
+		report.Rounds = r
+		var sent []*Message
+		for _, id := range s.ids {
+			if finished[id] || report.Errors[id] != nil {
+				continue
+			}
+			out, done, err := s.parties[id].Round(r, inboxes[id])
+			if err != nil {
+				report.Errors[id] = err
+				continue
+			}
+			for _, m := range out {
+				if m == nil {
+					continue
+				}
+				c := m.clone()
+				c.From, c.Round = id, r
+				sent = append(sent, c)
+			}
+			if done {
+				finished[id] = true
+				report.Finished = append(report.Finished, id)
+			}
+		}
+
+		inboxes = map[uint32][]*Message{}
+		inFlight := 0
+		for _, m := range sent {
+			for _, d := range s.deliver(m, report) {
+				report.Delivered++
+				report.Transcript = append(report.Transcript, d)
+				if finished[d.To] || report.Errors[d.To] != nil {
+					continue
+				}
+				inboxes[d.To] = append(inboxes[d.To], d)
+				inFlight++
+			}
+		}
+
+		active := 0
+		for _, id := range s.ids {
+			if !finished[id] && report.Errors[id] == nil {
+				active++
+			}
+		}
+		if active == 0 {
+			break
+		}
+		// nobody sent or receives anything, so no party can make progress
+		if inFlight == 0 && len(sent) == 0 {
+			return report, s.result(report, ErrStalled)
+		}
+	}
+	return report, s.result(report, nil)
+}
+
+func (s *Simulator) result(report *Report, err error) error {
+	errs := []error{err}
+	for _, id := range s.ids {
+		if e := report.Errors[id]; e != nil {
+			errs = append(errs, fmt.Errorf("party %d: %w", id, e))
+		}
+	}
+	return errors.Join(errs...)
+}
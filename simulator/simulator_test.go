@@ -0,0 +1,137 @@
+package simulator
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/protocol"
+	"github.com/go-sonr/crypto/sharing"
+	"github.com/go-sonr/crypto/tecdsa/dklsv1"
+)
+
+func newFrost(t *testing.T, threshold, limit uint32) (*Simulator, []*FrostDKG) {
+	parties, err := NewFrostDKGParties(curves.ED25519(), threshold, limit, "simulator test")
+	require.NoError(t, err)
+	ps := make([]Party, len(parties))
+	for i, p := range parties {
+		ps[i] = p
+	}
+	sim, err := New(ps...)
+	require.NoError(t, err)
+	return sim, parties
+}
+
+func TestFrostDKG(t *testing.T) {
+	sim, parties := newFrost(t, 3, 5)
+	report, err := sim.Run()
+	require.NoError(t, err)
+	require.Equal(t, 3, report.Rounds)
+	require.Equal(t, []uint32{1, 2, 3, 4, 5}, report.Finished)
+	// 5 broadcasts and 20 shares in round 1, 5 broadcasts in round 2
+	require.Equal(t, 20+20+20, report.Sent)
+	require.Equal(t, report.Sent, report.Delivered)
+
+	// any threshold of shares reconstructs the verification key
+	shamir, err := sharing.NewShamir(3, 5, curves.ED25519())
+	require.NoError(t, err)
+	secret, err := shamir.Combine(
+		&sharing.ShamirShare{Id: 1, Value: parties[0].SkShare.Bytes()},
+		&sharing.ShamirShare{Id: 3, Value: parties[2].SkShare.Bytes()},
+		&sharing.ShamirShare{Id: 5, Value: parties[4].SkShare.Bytes()},
+	)
+	require.NoError(t, err)
+	require.True(t, curves.ED25519().ScalarBaseMult(secret).Equal(parties[0].VerificationKey))
+}
+
+func TestFrostDKGFaults(t *testing.T) {
+	// a corrupted share fails its recipient only
+	sim, _ := newFrost(t, 3, 5)
+	sim.Inject(Corrupt(All(From(2), To(3), InRound(1)), crand.Reader))
+	report, err := sim.Run()
+	require.Error(t, err)
+	require.Len(t, report.Errors, 1)
+	require.Error(t, report.Errors[3])
+
+	// a byzantine dealer sending a share off its polynomial is caught
+	sim, parties := newFrost(t, 3, 5)
+	ps := []Party{Byzantine(parties[0], func(r int, out []*Message) []*Message {
+		for _, m := range out {
+			if r == 1 && m.To == 4 {
+				share := new(sharing.ShamirShare)
+				require.NoError(t, share.UnmarshalBinary(m.Payload[1:]))
+				s, err := curves.ED25519().Scalar.SetBytes(share.Value)
+				require.NoError(t, err)
+				share.Value = s.Double().Bytes()
+				data, err := share.MarshalBinary()
+				require.NoError(t, err)
+				m.Payload = append([]byte{tagDirect}, data...)
+			}
+		}
+		return out
+	})}
+	for _, p := range parties[1:] {
+		ps = append(ps, p)
+	}
+	sim, err = New(ps...)
+	require.NoError(t, err)
+	report, err = sim.Run()
+	require.Error(t, err)
+	require.ErrorContains(t, report.Errors[4], "participant with id 1")
+
+	// a silent party leaves the others with a different key, which round
+	// 3 detects
+	sim, _ = newFrost(t, 3, 5)
+	sim.Inject(Drop(From(5)))
+	report, err = sim.Run()
+	require.Error(t, err)
+	require.Error(t, report.Errors[5])
+
+	// duplicated messages are rejected
+	sim, _ = newFrost(t, 3, 5)
+	sim.Inject(Duplicate(All(From(1), To(2), InRound(1))))
+	report, err = sim.Run()
+	require.Error(t, err)
+	require.Error(t, report.Errors[2])
+}
+
+func dkls(t *testing.T) (*Iterator, *Iterator) {
+	curve := curves.K256()
+	alice := NewIterator(1, 2, dklsv1.NewAliceDkg(curve, protocol.Version1), false)
+	bob := NewIterator(2, 1, dklsv1.NewBobDkg(curve, protocol.Version1), true)
+	return alice, bob
+}
+
+func TestIterator(t *testing.T) {
+	alice, bob := dkls(t)
+	sim, err := New(alice, bob)
+	require.NoError(t, err)
+	report, err := sim.Run()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []uint32{1, 2}, report.Finished)
+	a, err := alice.Result(protocol.Version1)
+	require.NoError(t, err)
+	require.NotNil(t, a)
+	b, err := bob.Result(protocol.Version1)
+	require.NoError(t, err)
+	require.NotNil(t, b)
+
+	// a dropped message stalls the protocol
+	alice, bob = dkls(t)
+	sim, err = New(alice, bob)
+	require.NoError(t, err)
+	sim.Inject(Drop(All(From(1), InRound(2))))
+	_, err = sim.Run()
+	require.ErrorIs(t, err, ErrStalled)
+
+	// and a corrupted one fails its recipient
+	alice, bob = dkls(t)
+	sim, err = New(alice, bob)
+	require.NoError(t, err)
+	sim.Inject(Rewrite(All(From(2), InRound(1)), func(*Message) []byte { return []byte("{}") }))
+	report, err = sim.Run()
+	require.Error(t, err)
+	require.Error(t, report.Errors[1])
+}
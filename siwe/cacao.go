@@ -0,0 +1,167 @@
+package siwe
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-sonr/crypto/eth"
+)
+
+const (
+	// CACAOHeaderType is the header type of CACAOs from EIP-4361 messages
+	CACAOHeaderType = "eip4361"
+	// CACAOSignatureType is the signature type of personal_sign signatures
+	CACAOSignatureType = "eip191"
+
+	didPKHPrefix = "did:pkh:eip155:"
+)
+
+// CACAO is a chain agnostic capability object (CAIP-74) carrying a signed
+// EIP-4361 message, in its JSON representation
+type CACAO struct {
+	Header    CACAOHeader    `json:"h"`
+	Payload   CACAOPayload   `json:"p"`
+	Signature CACAOSignature `json:"s"`
+}
+
+// CACAOHeader is the header of a CACAO
+type CACAOHeader struct {
+	Type string `json:"t"`
+}
+
+// CACAOPayload is the payload of a CACAO, the fields of a message with the
+// address and chain as a did:pkh issuer
+type CACAOPayload struct {
+	Domain    string   `json:"domain"`
+	Issuer    string   `json:"iss"`
+	Audience  string   `json:"aud"`
+	Version   string   `json:"version"`
+	Nonce     string   `json:"nonce"`
+	IssuedAt  string   `json:"iat"`
+	NotBefore string   `json:"nbf,omitempty"`
+	Expires   string   `json:"exp,omitempty"`
+	Statement string   `json:"statement,omitempty"`
+	RequestID string   `json:"requestId,omitempty"`
+	Resources []string `json:"resources,omitempty"`
+}
+
+// CACAOSignature is the signature of a CACAO, hex with a 0x prefix
+type CACAOSignature struct {
+	Type      string `json:"t"`
+	Signature string `json:"s"`
+}
+
+// DIDPKH is the did:pkh of an account on an EIP-155 chain
+func DIDPKH(chainID uint64, addr eth.Address) string {
+	return didPKHPrefix + strconv.FormatUint(chainID, 10) + ":" + addr.String()
+}
+
+// ParseDIDPKH returns the chain and account of an EIP-155 did:pkh
+func ParseDIDPKH(did string) (uint64, eth.Address, error) {
+	rest, ok := strings.CutPrefix(did, didPKHPrefix)
+	if !ok {
+		return 0, eth.Address{}, fmt.Errorf("%q is not an eip155 did:pkh", did)
+	}
+	chain, account, ok := strings.Cut(rest, ":")
+	if !ok {
+		return 0, eth.Address{}, fmt.Errorf("did:pkh has no account")
+	}
+	chainID, err := strconv.ParseUint(chain, 10, 64)
+	if err != nil {
+		return 0, eth.Address{}, fmt.Errorf("invalid did:pkh chain: %w", err)
+	}
+	addr, err := eth.ParseAddress(account)
+	if err != nil {
+		return 0, eth.Address{}, err
+	}
+	return chainID, addr, nil
+}
+
+// NewCACAO wraps a message and its personal_sign signature. The message
+// must have no scheme, which CACAOs do not carry.
+func NewCACAO(m *Message, sig []byte) (*CACAO, error) {
+	if m.Scheme != "" {
+		return nil, fmt.Errorf("CACAOs cannot carry the scheme of a message")
+	}
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	if len(sig) != eth.SignatureSize {
+		return nil, fmt.Errorf("signature must be %d bytes", eth.SignatureSize)
+	}
+	return &CACAO{
+		Header: CACAOHeader{Type: CACAOHeaderType},
+		Payload: CACAOPayload{
+			Domain:    m.Domain,
+			Issuer:    DIDPKH(m.ChainID, m.Address),
+			Audience:  m.URI,
+			Version:   m.Version,
+			Nonce:     m.Nonce,
+			IssuedAt:  m.IssuedAt,
+			NotBefore: m.NotBefore,
+			Expires:   m.ExpirationTime,
+			Statement: m.Statement,
+			RequestID: m.RequestID,
+			Resources: m.Resources,
+		},
+		Signature: CACAOSignature{Type: CACAOSignatureType, Signature: "0x" + hex.EncodeToString(sig)},
+	}, nil
+}
+
+// ParseCACAO decodes a CACAO from JSON
+func ParseCACAO(data []byte) (*CACAO, error) {
+	c := new(CACAO)
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("malformed CACAO: %w", err)
+	}
+	return c, nil
+}
+
+// Message returns the EIP-4361 message the CACAO signs
+func (c *CACAO) Message() (*Message, error) {
+	if c.Header.Type != CACAOHeaderType {
+		return nil, fmt.Errorf("unsupported CACAO header type %q", c.Header.Type)
+	}
+	chainID, addr, err := ParseDIDPKH(c.Payload.Issuer)
+	if err != nil {
+		return nil, err
+	}
+	return &Message{
+		Domain:         c.Payload.Domain,
+		Address:        addr,
+		Statement:      c.Payload.Statement,
+		URI:            c.Payload.Audience,
+		Version:        c.Payload.Version,
+		ChainID:        chainID,
+		Nonce:          c.Payload.Nonce,
+		IssuedAt:       c.Payload.IssuedAt,
+		ExpirationTime: c.Payload.Expires,
+		NotBefore:      c.Payload.NotBefore,
+		RequestID:      c.Payload.RequestID,
+		Resources:      c.Payload.Resources,
+	}, nil
+}
+
+// Verify checks the signature of the CACAO by its issuer and the time
+// bounds of its message
+func (c *CACAO) Verify(opts VerifyOptions) error {
+	if c.Signature.Type != CACAOSignatureType {
+		return fmt.Errorf("unsupported CACAO signature type %q", c.Signature.Type)
+	}
+	sigHex, ok := strings.CutPrefix(c.Signature.Signature, "0x")
+	if !ok {
+		return fmt.Errorf("CACAO signature must be 0x prefixed hex")
+	}
+	sig, err := hex.DecodeString(sigHex)
+	if err != nil {
+		return fmt.Errorf("malformed CACAO signature: %w", err)
+	}
+	m, err := c.Message()
+	if err != nil {
+		return err
+	}
+	return m.Verify(sig, opts)
+}
@@ -0,0 +1,294 @@
+// Package siwe implements Sign-In with Ethereum (EIP-4361) messages and
+// their CACAO (CAIP-74) capability objects.
+//
+// Signatures are EIP-191 personal_sign signatures of externally owned
+// accounts, recovered with the eth package. Contract
+// wallets (EIP-1271) need chain access and are not supported.
+package siwe
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/eth"
+	"github.com/go-sonr/crypto/internal"
+)
+
+const (
+	// Version is the only message version of EIP-4361
+	Version = "1"
+
+	preamble     = " wants you to sign in with your Ethereum account:"
+	minNonceSize = 8
+)
+
+// Message is an EIP-4361 message. Times are RFC 3339 strings, kept as
+// they appear in the signed text.
+type Message struct {
+	// Scheme is the optional scheme of the origin, like https
+	Scheme    string
+	Domain    string
+	Address   eth.Address
+	Statement string
+	URI       string
+	Version   string
+	ChainID   uint64
+	Nonce     string
+	IssuedAt  string
+	// ExpirationTime, NotBefore and RequestID are optional
+	ExpirationTime string
+	NotBefore      string
+	RequestID      string
+	Resources      []string
+}
+
+// String returns the text that is signed
+func (m *Message) String() string {
+	var b strings.Builder
+	if m.Scheme != "" {
+		b.WriteString(m.Scheme + "://")
+	}
+	b.WriteString(m.Domain + preamble + "\n")
+	b.WriteString(m.Address.String() + "\n\n")
+	if m.Statement != "" {
+		b.WriteString(m.Statement + "\n")
+	}
+	b.WriteString("\n")
+	b.WriteString("URI: " + m.URI + "\n")
+	b.WriteString("Version: " + m.Version + "\n")
+	b.WriteString("Chain ID: " + strconv.FormatUint(m.ChainID, 10) + "\n")
+	b.WriteString("Nonce: " + m.Nonce + "\n")
+	b.WriteString("Issued At: " + m.IssuedAt)
+	if m.ExpirationTime != "" {
+		b.WriteString("\nExpiration Time: " + m.ExpirationTime)
+	}
+	if m.NotBefore != "" {
+		b.WriteString("\nNot Before: " + m.NotBefore)
+	}
+	if m.RequestID != "" {
+		b.WriteString("\nRequest ID: " + m.RequestID)
+	}
+	if len(m.Resources) > 0 {
+		b.WriteString("\nResources:")
+		for _, r := range m.Resources {
+			b.WriteString("\n- " + r)
+		}
+	}
+	return b.String()
+}
+
+// Validate checks the fields of the message against the grammar of
+// EIP-4361
+func (m *Message) Validate() error {
+	if m.Domain == "" || strings.ContainsAny(m.Domain, " \n/") {
+		return fmt.Errorf("invalid domain %q", m.Domain)
+	}
+	if strings.Contains(m.Statement, "\n") {
+		return fmt.Errorf("statement cannot contain a newline")
+	}
+	if u, err := url.Parse(m.URI); err != nil || u.Scheme == "" {
+		return fmt.Errorf("invalid URI %q", m.URI)
+	}
+	if m.Version != Version {
+		return fmt.Errorf("unsupported version %q", m.Version)
+	}
+	if len(m.Nonce) < minNonceSize {
+		return fmt.Errorf("nonce must be at least %d characters", minNonceSize)
+	}
+	for _, c := range m.Nonce {
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9') {
+			return fmt.Errorf("nonce must be alphanumeric")
+		}
+	}
+	if _, err := time.Parse(time.RFC3339, m.IssuedAt); err != nil {
+		return fmt.Errorf("invalid issued at time: %w", err)
+	}
+	for _, t := range []string{m.ExpirationTime, m.NotBefore} {
+		if t == "" {
+			continue
+		}
+		if _, err := time.Parse(time.RFC3339, t); err != nil {
+			return fmt.Errorf("invalid time: %w", err)
+		}
+	}
+	for _, r := range m.Resources {
+		if u, err := url.Parse(r); err != nil || u.Scheme == "" {
+			return fmt.Errorf("invalid resource %q", r)
+		}
+	}
+	return nil
+}
+
+// ParseMessage parses the text of an EIP-4361 message. The text must be
+// exactly the String of the result so that its signature verifies.
+func ParseMessage(text string) (*Message, error) {
+	lines := strings.Split(text, "\n")
+	next := func() (string, bool) {
+		if len(lines) == 0 {
+			return "", false
+		}
+		l := lines[0]
+		lines = lines[1:]
+		return l, true
+	}
+	m := &Message{}
+	l, _ := next()
+	origin, ok := strings.CutSuffix(l, preamble)
+	if !ok {
+		return nil, fmt.Errorf("message does not start with the sign in preamble")
+	}
+	if scheme, domain, ok := strings.Cut(origin, "://"); ok {
+		m.Scheme, m.Domain = scheme, domain
+	} else {
+		m.Domain = origin
+	}
+	l, _ = next()
+	var err error
+	if m.Address, err = eth.ParseAddress(l); err != nil {
+		return nil, err
+	}
+	if l, _ = next(); l != "" {
+		return nil, fmt.Errorf("expected an empty line after the address")
+	}
+	l, ok = next()
+	if ok && l != "" {
+		m.Statement = l
+		if l, _ = next(); l != "" {
+			return nil, fmt.Errorf("expected an empty line after the statement")
+		}
+	}
+
+	field := func(name string, optional bool) (string, error) {
+		if len(lines) == 0 || !strings.HasPrefix(lines[0], name+": ") {
+			if optional {
+				return "", nil
+			}
+			return "", fmt.Errorf("missing %s", name)
+		}
+		l, _ := next()
+		return strings.TrimPrefix(l, name+": "), nil
+	}
+	if m.URI, err = field("URI", false); err != nil {
+		return nil, err
+	}
+	if m.Version, err = field("Version", false); err != nil {
+		return nil, err
+	}
+	chainID, err := field("Chain ID", false)
+	if err != nil {
+		return nil, err
+	}
+	if m.ChainID, err = strconv.ParseUint(chainID, 10, 64); err != nil || chainID != strconv.FormatUint(m.ChainID, 10) {
+		return nil, fmt.Errorf("invalid chain ID %q", chainID)
+	}
+	if m.Nonce, err = field("Nonce", false); err != nil {
+		return nil, err
+	}
+	if m.IssuedAt, err = field("Issued At", false); err != nil {
+		return nil, err
+	}
+	if m.ExpirationTime, err = field("Expiration Time", true); err != nil {
+		return nil, err
+	}
+	if m.NotBefore, err = field("Not Before", true); err != nil {
+		return nil, err
+	}
+	if m.RequestID, err = field("Request ID", true); err != nil {
+		return nil, err
+	}
+	if len(lines) > 0 && lines[0] == "Resources:" {
+		next()
+		for len(lines) > 0 && strings.HasPrefix(lines[0], "- ") {
+			l, _ := next()
+			m.Resources = append(m.Resources, strings.TrimPrefix(l, "- "))
+		}
+		if len(m.Resources) == 0 {
+			return nil, fmt.Errorf("resources must not be empty")
+		}
+	}
+	if len(lines) > 0 {
+		return nil, fmt.Errorf("unexpected line %q", lines[0])
+	}
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	if m.String() != text {
+		return nil, fmt.Errorf("message is not in the canonical EIP-4361 form")
+	}
+	return m, nil
+}
+
+// VerifyOptions are the checks on a message beyond its signature
+type VerifyOptions struct {
+	// Domain and Nonce, when set, must match the message
+	Domain string
+	Nonce  string
+	// Now is the time the message is checked at, time.Now when zero
+	Now time.Time
+}
+
+// Sign signs the message with personal_sign by the secp256k1 secret key of
+// its address
+func (m *Message) Sign(secret curves.Scalar) ([]byte, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	if secret == nil {
+		return nil, internal.ErrNilArguments
+	}
+	d, err := curves.K256().Scalar.SetBigInt(secret.BigInt())
+	if err != nil {
+		return nil, err
+	}
+	defer curves.Zeroize(d)
+	addr, err := eth.AddressFromPublicKey(curves.K256().ScalarBaseMult(d))
+	if err != nil {
+		return nil, err
+	}
+	if addr != m.Address {
+		return nil, fmt.Errorf("secret key is not the key of %s", m.Address)
+	}
+	return eth.SignPersonal(secret, []byte(m.String()))
+}
+
+// Verify checks that sig is the personal_sign signature of the message by
+// its address, and that the message is valid at the time of the options
+func (m *Message) Verify(sig []byte, opts VerifyOptions) error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+	if opts.Domain != "" && m.Domain != opts.Domain {
+		return fmt.Errorf("domain %q does not match", m.Domain)
+	}
+	if opts.Nonce != "" && m.Nonce != opts.Nonce {
+		return fmt.Errorf("nonce does not match")
+	}
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	if m.ExpirationTime != "" {
+		exp, _ := time.Parse(time.RFC3339, m.ExpirationTime)
+		if !now.Before(exp) {
+			return fmt.Errorf("message expired at %s", m.ExpirationTime)
+		}
+	}
+	if m.NotBefore != "" {
+		nbf, _ := time.Parse(time.RFC3339, m.NotBefore)
+		if now.Before(nbf) {
+			return fmt.Errorf("message is not valid before %s", m.NotBefore)
+		}
+	}
+	signer, err := eth.RecoverPersonal([]byte(m.String()), sig)
+	if err != nil {
+		return err
+	}
+	if signer != m.Address {
+		return fmt.Errorf("message is signed by %s, not %s", signer, m.Address)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package siwe
+
+import (
+	crand "crypto/rand"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/eth"
+)
+
+const exampleMessage = `service.invalid wants you to sign in with your Ethereum account:
+0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2
+
+I accept the ServiceOrg Terms of Service: https://service.invalid/tos
+
+URI: https://service.invalid/login
+Version: 1
+Chain ID: 1
+Nonce: 32891756
+Issued At: 2021-09-30T16:25:24Z
+Resources:
+- ipfs://bafybeiemxf5abjwjbikoz4mc3a3dla6ual3jsgpdr4cjr3oz3evfyavhwq/
+- https://example.com/my-web2-claim.json`
+
+func TestParseMessage(t *testing.T) {
+	m, err := ParseMessage(exampleMessage)
+	require.NoError(t, err)
+	require.Equal(t, "service.invalid", m.Domain)
+	require.Equal(t, "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2", m.Address.String())
+	require.Equal(t, "I accept the ServiceOrg Terms of Service: https://service.invalid/tos", m.Statement)
+	require.Equal(t, uint64(1), m.ChainID)
+	require.Equal(t, "32891756", m.Nonce)
+	require.Len(t, m.Resources, 2)
+	require.Equal(t, exampleMessage, m.String())
+
+	// without a statement there are two empty lines
+	m.Statement = ""
+	m.Scheme = "https"
+	m.RequestID = "req-1"
+	parsed, err := ParseMessage(m.String())
+	require.NoError(t, err)
+	require.Equal(t, m, parsed)
+
+	for _, bad := range []string{
+		"",
+		exampleMessage[:len(exampleMessage)-5] + "\nextra",
+		"service.invalid wants you to sign in with your Ethereum account:\n0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2\n\nURI: https://service.invalid/login",
+	} {
+		_, err := ParseMessage(bad)
+		require.Error(t, err)
+	}
+}
+
+func testMessage(t *testing.T) (*Message, []byte) {
+	secret := curves.K256().Scalar.Random(crand.Reader)
+	addr, err := eth.AddressFromPublicKey(curves.K256().ScalarBaseMult(secret))
+	require.NoError(t, err)
+	now := time.Now().UTC()
+	m := &Message{
+		Domain:         "example.com",
+		Address:        addr,
+		Statement:      "Sign in to example",
+		URI:            "https://example.com/login",
+		Version:        Version,
+		ChainID:        1,
+		Nonce:          "abcdef0123",
+		IssuedAt:       now.Format(time.RFC3339),
+		ExpirationTime: now.Add(time.Hour).Format(time.RFC3339),
+		Resources:      []string{"https://example.com/profile"},
+	}
+	sig, err := m.Sign(secret)
+	require.NoError(t, err)
+	_, err = m.Sign(curves.K256().Scalar.Random(crand.Reader))
+	require.Error(t, err)
+	return m, sig
+}
+
+func TestMessageVerify(t *testing.T) {
+	m, sig := testMessage(t)
+	require.NoError(t, m.Verify(sig, VerifyOptions{Domain: "example.com", Nonce: "abcdef0123"}))
+	require.Error(t, m.Verify(sig, VerifyOptions{Domain: "evil.com"}))
+	require.Error(t, m.Verify(sig, VerifyOptions{Nonce: "0123456789"}))
+	require.Error(t, m.Verify(sig, VerifyOptions{Now: time.Now().Add(2 * time.Hour)}))
+
+	m.Statement = "Sign in to evil"
+	require.Error(t, m.Verify(sig, VerifyOptions{}))
+}
+
+func TestCACAO(t *testing.T) {
+	m, sig := testMessage(t)
+	c, err := NewCACAO(m, sig)
+	require.NoError(t, err)
+	require.Equal(t, DIDPKH(1, m.Address), c.Payload.Issuer)
+	require.NoError(t, c.Verify(VerifyOptions{Domain: "example.com"}))
+
+	data, err := json.Marshal(c)
+	require.NoError(t, err)
+	parsed, err := ParseCACAO(data)
+	require.NoError(t, err)
+	require.NoError(t, parsed.Verify(VerifyOptions{}))
+	back, err := parsed.Message()
+	require.NoError(t, err)
+	require.Equal(t, m.String(), back.String())
+
+	// a different chain or audience breaks the signature
+	parsed.Payload.Issuer = DIDPKH(5, m.Address)
+	require.Error(t, parsed.Verify(VerifyOptions{}))
+	parsed, _ = ParseCACAO(data)
+	parsed.Payload.Audience = "https://evil.com"
+	require.Error(t, parsed.Verify(VerifyOptions{}))
+
+	m.Scheme = "https"
+	_, err = NewCACAO(m, sig)
+	require.Error(t, err)
+}
@@ -0,0 +1,313 @@
+// Package tecdh implements threshold ECDH: the secret key x of a group is
+// shared among participants by a distributed key generation, such as
+// dkg/pedersen, and any threshold of them compute x·R for a point R without
+// anyone learning x.
+//
+// This is threshold decryption of ElGamal ciphertexts (C1, C2), whose
+// plaintext is C2 - x·C1, and of ECIES ciphertexts of the ecies package,
+// whose key derives from x·R for the ephemeral key R. Each participant
+// publishes the partial result x_i·R with a Chaum-Pedersen proof that it
+// used the share committed by its public share x_i·G, so a combiner can
+// drop wrong contributions and interpolate the result from any threshold
+// correct ones. Data encrypted to the group key thus needs the consent of
+// a threshold of participants to be read.
+package tecdh
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sort"
+
+	eciesgo "github.com/ecies/go/v2"
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
+	"github.com/go-sonr/crypto/dkg/pedersen"
+	"github.com/go-sonr/crypto/ecies"
+	"github.com/go-sonr/crypto/elgamal"
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/sharing"
+	"github.com/go-sonr/crypto/zkp/sigma"
+)
+
+const domain = "go-sonr threshold ecdh v1"
+
+// PublicKey is the group key of a threshold and the public shares of its
+// participants, all a verifier of partial results needs
+type PublicKey struct {
+	Threshold    uint32
+	Key          curves.Point
+	PublicShares map[uint32]curves.Point
+	curve        *curves.Curve
+	shamir       *sharing.Shamir
+}
+
+// KeyShare is the share of the secret key of one participant
+type KeyShare struct {
+	*PublicKey
+	ID    uint32
+	share curves.Scalar
+}
+
+// Partial is the contribution x_i·R of participant ID to the ECDH of R,
+// with the proof it used the share of its public share
+type Partial struct {
+	ID    uint32
+	Point curves.Point
+	Proof *sigma.Proof
+}
+
+// NewPublicKey returns the public key of a threshold with the public
+// shares of its participants
+func NewPublicKey(threshold uint32, key curves.Point, publicShares map[uint32]curves.Point) (*PublicKey, error) {
+	if key == nil || publicShares == nil {
+		return nil, internal.ErrNilArguments
+	}
+	curve := curves.GetCurveByName(key.CurveName())
+	if curve == nil {
+		return nil, fmt.Errorf("unsupported curve %s", key.CurveName())
+	}
+	limit := uint32(0)
+	for id, p := range publicShares {
+		if p == nil || p.CurveName() != curve.Name || p.IsIdentity() || !p.IsOnCurve() {
+			return nil, fmt.Errorf("invalid public share of participant %d", id)
+		}
+		if id > limit {
+			limit = id
+		}
+	}
+	if key.IsIdentity() || !key.IsOnCurve() {
+		return nil, fmt.Errorf("invalid public key")
+	}
+	shamir, err := sharing.NewShamir(threshold, limit, curve)
+	if err != nil {
+		return nil, err
+	}
+	if int(threshold) > len(publicShares) {
+		return nil, fmt.Errorf("invalid threshold %d of %d", threshold, len(publicShares))
+	}
+	return &PublicKey{
+		Threshold:    threshold,
+		Key:          key,
+		PublicShares: publicShares,
+		curve:        curve,
+		shamir:       shamir,
+	}, nil
+}
+
+// NewKeyShare returns the key share of the Shamir share of a DKG with its
+// public key and public shares
+func NewKeyShare(share *sharing.ShamirShare, threshold uint32, key curves.Point, publicShares map[uint32]curves.Point) (*KeyShare, error) {
+	if share == nil {
+		return nil, internal.ErrNilArguments
+	}
+	pub, err := NewPublicKey(threshold, key, publicShares)
+	if err != nil {
+		return nil, err
+	}
+	x, err := pub.curve.Scalar.SetBytes(share.Value)
+	if err != nil {
+		return nil, err
+	}
+	public, ok := pub.PublicShares[share.Id]
+	if !ok {
+		return nil, fmt.Errorf("no public share for participant %d", share.Id)
+	}
+	if !pub.curve.ScalarBaseMult(x).Equal(public) {
+		return nil, fmt.Errorf("share does not match the public share of participant %d", share.Id)
+	}
+	return &KeyShare{PublicKey: pub, ID: share.Id, share: x}, nil
+}
+
+// FromPedersen returns the key share of a dkg/pedersen result
+func FromPedersen(result *pedersen.Result) (*KeyShare, error) {
+	if result == nil {
+		return nil, internal.ErrNilArguments
+	}
+	return NewKeyShare(result.SecretShare, result.Threshold(), result.PublicKey, result.PublicShares)
+}
+
+// ECDH returns the partial ECDH x_i·point of the share. sessionId binds
+// the proof to the request, such as the consent being given.
+func (k *KeyShare) ECDH(point curves.Point, sessionId []byte) (*Partial, error) {
+	if err := k.checkPoint(point); err != nil {
+		return nil, err
+	}
+	d := point.Mul(k.share)
+	statement := sigma.DLEQ(k.curve.Point.Generator(), k.PublicShares[k.ID], point, d)
+	proof, err := sigma.Prove(k.transcript(sessionId, k.ID), statement, sigma.Secrets(k.share))
+	if err != nil {
+		return nil, err
+	}
+	return &Partial{ID: k.ID, Point: d, Proof: proof}, nil
+}
+
+// VerifyPartial checks a partial ECDH of point against the public share of
+// its participant
+func (p *PublicKey) VerifyPartial(point curves.Point, sessionId []byte, partial *Partial) error {
+	if partial == nil || partial.Point == nil || partial.Proof == nil {
+		return internal.ErrNilArguments
+	}
+	if err := p.checkPoint(point); err != nil {
+		return err
+	}
+	public, ok := p.PublicShares[partial.ID]
+	if !ok {
+		return fmt.Errorf("unknown participant %d", partial.ID)
+	}
+	if partial.Point.CurveName() != p.curve.Name || !partial.Point.IsOnCurve() {
+		return fmt.Errorf("partial result of participant %d is not on curve %s", partial.ID, p.curve.Name)
+	}
+	statement := sigma.DLEQ(p.curve.Point.Generator(), public, point, partial.Point)
+	if err := sigma.Verify(p.transcript(sessionId, partial.ID), statement, partial.Proof); err != nil {
+		return fmt.Errorf("invalid partial result of participant %d: %w", partial.ID, err)
+	}
+	return nil
+}
+
+// Combine verifies the partial ECDHs of point, ignoring invalid and
+// duplicate ones, and interpolates the first threshold valid ones into
+// x·point. It fails when fewer than threshold participants contributed
+// correctly.
+func (p *PublicKey) Combine(point curves.Point, sessionId []byte, partials ...*Partial) (curves.Point, error) {
+	valid := make(map[uint32]curves.Point, p.Threshold)
+	for _, partial := range partials {
+		if partial == nil || valid[partial.ID] != nil || p.VerifyPartial(point, sessionId, partial) != nil {
+			continue
+		}
+		valid[partial.ID] = partial.Point
+	}
+	if uint32(len(valid)) < p.Threshold {
+		return nil, fmt.Errorf("%d valid partial results, %d needed", len(valid), p.Threshold)
+	}
+	ids := make([]uint32, 0, len(valid))
+	for id := range valid {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	ids = ids[:p.Threshold]
+	points := make(map[uint32]curves.Point, len(ids))
+	for _, id := range ids {
+		points[id] = valid[id]
+	}
+	interpolator, err := p.shamir.Interpolator(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return interpolator.CombinePoints(points)
+}
+
+// ElGamal returns the group key as an ElGamal public key to encrypt to
+func (p *PublicKey) ElGamal() *elgamal.PublicKey {
+	return &elgamal.PublicKey{Y: p.Key}
+}
+
+// PartialDecrypt returns the partial decryption of an ElGamal ciphertext
+func (k *KeyShare) PartialDecrypt(c *elgamal.Ciphertext, sessionId []byte) (*Partial, error) {
+	if c == nil {
+		return nil, internal.ErrNilArguments
+	}
+	return k.ECDH(c.C1, sessionId)
+}
+
+// Decrypt combines the partial decryptions of an ElGamal ciphertext into
+// its plaintext
+func (p *PublicKey) Decrypt(c *elgamal.Ciphertext, sessionId []byte, partials ...*Partial) (curves.Point, error) {
+	if c == nil || c.C2 == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if c.C2.CurveName() != p.curve.Name || !c.C2.IsOnCurve() {
+		return nil, fmt.Errorf("ciphertext is not on curve %s", p.curve.Name)
+	}
+	d, err := p.Combine(c.C1, sessionId, partials...)
+	if err != nil {
+		return nil, err
+	}
+	return c.C2.Sub(d), nil
+}
+
+// ECIES returns the group key as an ECIES public key to encrypt to with
+// ecies.Encrypt. The group key must be on secp256k1.
+func (p *PublicKey) ECIES() (*ecies.PublicKey, error) {
+	if p.curve.Name != curves.K256Name {
+		return nil, fmt.Errorf("ecies needs a secp256k1 key, not %s", p.curve.Name)
+	}
+	return eciesgo.NewPublicKeyFromBytes(p.Key.ToAffineUncompressed())
+}
+
+// PartialDecryptECIES returns the partial decryption of an ECIES ciphertext
+func (k *KeyShare) PartialDecryptECIES(ciphertext []byte, sessionId []byte) (*Partial, error) {
+	r, err := k.ephemeralKey(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	return k.ECDH(r, sessionId)
+}
+
+// DecryptECIES combines the partial decryptions of an ECIES ciphertext and
+// decrypts it
+func (p *PublicKey) DecryptECIES(ciphertext []byte, sessionId []byte, partials ...*Partial) ([]byte, error) {
+	r, err := p.ephemeralKey(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	shared, err := p.Combine(r, sessionId, partials...)
+	if err != nil {
+		return nil, err
+	}
+
+	// the layout and key derivation of ecies.Encrypt: the ephemeral key,
+	// a 16 byte nonce, the tag and the AES-GCM ciphertext, under the key
+	// HKDF-SHA256(R || x·R) of uncompressed points
+	key := make([]byte, 32)
+	secret := append(r.ToAffineUncompressed(), shared.ToAffineUncompressed()...)
+	if _, err = io.ReadFull(hkdf.New(sha256.New, secret, nil, nil), key); err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCMWithNonceSize(block, 16)
+	if err != nil {
+		return nil, err
+	}
+	body := ciphertext[65:]
+	nonce, tag := body[:16], body[16:32]
+	return gcm.Open(nil, nonce, bytes.Join([][]byte{body[32:], tag}, nil), nil)
+}
+
+// ephemeralKey returns the ephemeral key R of an ECIES ciphertext
+func (p *PublicKey) ephemeralKey(ciphertext []byte) (curves.Point, error) {
+	if p.curve.Name != curves.K256Name {
+		return nil, fmt.Errorf("ecies needs a secp256k1 key, not %s", p.curve.Name)
+	}
+	if len(ciphertext) <= 65+16+16 {
+		return nil, fmt.Errorf("invalid length of ciphertext")
+	}
+	return p.curve.Point.FromAffineUncompressed(ciphertext[:65])
+}
+
+func (p *PublicKey) checkPoint(point curves.Point) error {
+	if point == nil {
+		return internal.ErrNilArguments
+	}
+	if point.CurveName() != p.curve.Name || point.IsIdentity() || !point.IsOnCurve() {
+		return fmt.Errorf("point is not on curve %s", p.curve.Name)
+	}
+	return nil
+}
+
+func (p *PublicKey) transcript(sessionId []byte, id uint32) *transcript.Transcript {
+	tr := transcript.New(domain)
+	tr.AppendMessage([]byte("session id"), sessionId)
+	tr.AppendPoint([]byte("public key"), p.Key)
+	tr.AppendMessage([]byte("participant"), binary.BigEndian.AppendUint32(nil, id))
+	return tr
+}
@@ -0,0 +1,143 @@
+package tecdh
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/ecies"
+	"github.com/go-sonr/crypto/sharing"
+)
+
+// deal shares a random key among limit participants with a trusted dealer
+func deal(t *testing.T, curve *curves.Curve, threshold, limit uint32) (curves.Scalar, []*KeyShare) {
+	feldman, err := sharing.NewFeldman(threshold, limit, curve)
+	require.NoError(t, err)
+	secret := curve.Scalar.Random(crand.Reader)
+	verifier, shares, err := feldman.Split(secret, crand.Reader)
+	require.NoError(t, err)
+	publicShares := make(map[uint32]curves.Point, limit)
+	for _, s := range shares {
+		x, err := curve.Scalar.SetBytes(s.Value)
+		require.NoError(t, err)
+		publicShares[s.Id] = curve.ScalarBaseMult(x)
+	}
+	keys := make([]*KeyShare, 0, limit)
+	for _, s := range shares {
+		k, err := NewKeyShare(s, threshold, verifier.Commitments[0], publicShares)
+		require.NoError(t, err)
+		keys = append(keys, k)
+	}
+	return secret, keys
+}
+
+func TestECDH(t *testing.T) {
+	for _, curve := range []*curves.Curve{curves.K256(), curves.P256(), curves.ED25519()} {
+		t.Run(curve.Name, func(t *testing.T) {
+			secret, keys := deal(t, curve, 3, 5)
+			r := curve.ScalarBaseMult(curve.Scalar.Random(crand.Reader))
+			sid := []byte("request 1")
+
+			var partials []*Partial
+			for _, k := range keys {
+				p, err := k.ECDH(r, sid)
+				require.NoError(t, err)
+				require.NoError(t, keys[0].VerifyPartial(r, sid, p))
+				partials = append(partials, p)
+			}
+			a, err := keys[0].Combine(r, sid, partials[4], partials[1], partials[2])
+			require.NoError(t, err)
+			require.True(t, a.Equal(r.Mul(secret)))
+			b, err := keys[0].Combine(r, sid, partials[:3]...)
+			require.NoError(t, err)
+			require.True(t, a.Equal(b))
+		})
+	}
+}
+
+func TestCombineIgnoresBadPartials(t *testing.T) {
+	curve := curves.K256()
+	secret, keys := deal(t, curve, 2, 3)
+	r := curve.ScalarBaseMult(curve.Scalar.Random(crand.Reader))
+	sid := []byte("request")
+
+	good, err := keys[0].ECDH(r, sid)
+	require.NoError(t, err)
+	wrongPoint := &Partial{ID: good.ID, Point: good.Point.Double(), Proof: good.Proof}
+	require.Error(t, keys[1].VerifyPartial(r, sid, wrongPoint))
+	otherSession, err := keys[1].ECDH(r, []byte("another request"))
+	require.NoError(t, err)
+	require.Error(t, keys[1].VerifyPartial(r, sid, otherSession))
+	impostor := &Partial{ID: 3, Point: good.Point, Proof: good.Proof}
+	require.Error(t, keys[1].VerifyPartial(r, sid, impostor))
+
+	_, err = keys[0].Combine(r, sid, good, good, wrongPoint, otherSession, impostor)
+	require.Error(t, err)
+	third, err := keys[2].ECDH(r, sid)
+	require.NoError(t, err)
+	d, err := keys[0].Combine(r, sid, wrongPoint, good, otherSession, third)
+	require.NoError(t, err)
+	require.True(t, d.Equal(r.Mul(secret)))
+}
+
+func TestThresholdElGamal(t *testing.T) {
+	curve := curves.P256()
+	_, keys := deal(t, curve, 2, 3)
+	msg := curve.Point.Hash([]byte("escrowed"))
+	c, _, err := keys[0].ElGamal().Encrypt(msg)
+	require.NoError(t, err)
+
+	sid := []byte("consent 7")
+	p1, err := keys[2].PartialDecrypt(c, sid)
+	require.NoError(t, err)
+	p2, err := keys[0].PartialDecrypt(c, sid)
+	require.NoError(t, err)
+	got, err := keys[1].Decrypt(c, sid, p1, p2)
+	require.NoError(t, err)
+	require.True(t, got.Equal(msg))
+
+	_, err = keys[1].Decrypt(c, sid, p1)
+	require.Error(t, err)
+}
+
+func TestThresholdECIES(t *testing.T) {
+	curve := curves.K256()
+	_, keys := deal(t, curve, 3, 4)
+	pk, err := keys[0].ECIES()
+	require.NoError(t, err)
+	ciphertext, err := ecies.Encrypt(pk, []byte("read with 3 of 4"))
+	require.NoError(t, err)
+
+	sid := []byte("consent")
+	var partials []*Partial
+	for _, k := range keys[1:] {
+		p, err := k.PartialDecryptECIES(ciphertext, sid)
+		require.NoError(t, err)
+		partials = append(partials, p)
+	}
+	plaintext, err := keys[0].DecryptECIES(ciphertext, sid, partials...)
+	require.NoError(t, err)
+	require.Equal(t, []byte("read with 3 of 4"), plaintext)
+
+	_, err = keys[0].DecryptECIES(ciphertext, sid, partials[:2]...)
+	require.Error(t, err)
+	ciphertext[len(ciphertext)-1] ^= 1
+	_, err = keys[0].DecryptECIES(ciphertext, sid, partials...)
+	require.Error(t, err)
+
+	_, p256 := deal(t, curves.P256(), 2, 2)
+	_, err = p256[0].ECIES()
+	require.Error(t, err)
+}
+
+func TestNewKeyShareRejectsWrongShare(t *testing.T) {
+	curve := curves.K256()
+	_, keys := deal(t, curve, 2, 3)
+	share := &sharing.ShamirShare{Id: 1, Value: keys[1].share.Bytes()}
+	_, err := NewKeyShare(share, 2, keys[0].Key, keys[0].PublicShares)
+	require.Error(t, err)
+	_, err = NewKeyShare(share, 4, keys[0].Key, keys[0].PublicShares)
+	require.Error(t, err)
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/go-sonr/crypto/core/curves"
 	"github.com/go-sonr/crypto/core/protocol"
+	"github.com/go-sonr/crypto/tecdsa/dklsv1/derive"
 	"github.com/go-sonr/crypto/tecdsa/dklsv1/dkg"
 	"github.com/go-sonr/crypto/tecdsa/dklsv1/refresh"
 	"github.com/go-sonr/crypto/tecdsa/dklsv1/sign"
@@ -28,12 +29,14 @@ type BobDkg struct {
 type AliceSign struct {
 	protoStepper
 	*sign.Alice
+	inputs *signInputs
 }
 
 // BobSign DKLS sign implementation that satisfies the protocol iterator interface.
 type BobSign struct {
 	protoStepper
 	*sign.Bob
+	inputs *signInputs
 }
 
 // AliceRefresh DKLS refresh implementation that satisfies the protocol iterator interface.
@@ -48,6 +51,46 @@ type BobRefresh struct {
 	*refresh.Bob
 }
 
+// AliceReshare DKLS reshare implementation that satisfies the protocol iterator interface.
+type AliceReshare struct {
+	protoStepper
+	*refresh.Alice
+}
+
+// BobReshare DKLS reshare implementation that satisfies the protocol iterator interface.
+type BobReshare struct {
+	protoStepper
+	*refresh.Bob
+}
+
+// AlicePresign DKLS presign implementation that satisfies the protocol iterator interface.
+type AlicePresign struct {
+	protoStepper
+	*sign.Alice
+	inputs       *signInputs
+	presignature *sign.AlicePresignature
+}
+
+// BobPresign DKLS presign implementation that satisfies the protocol iterator interface.
+type BobPresign struct {
+	protoStepper
+	*sign.Bob
+	inputs       *signInputs
+	presignature *sign.BobPresignature
+}
+
+// AliceDerive DKLS non-hardened child derivation that satisfies the protocol iterator interface.
+type AliceDerive struct {
+	protoStepper
+	*derive.Alice
+}
+
+// BobDerive DKLS non-hardened child derivation that satisfies the protocol iterator interface.
+type BobDerive struct {
+	protoStepper
+	*derive.Bob
+}
+
 var (
 	// Static type assertions
 	_ protocol.Iterator = &AliceDkg{}
@@ -56,16 +99,29 @@ var (
 	_ protocol.Iterator = &BobSign{}
 	_ protocol.Iterator = &AliceRefresh{}
 	_ protocol.Iterator = &BobRefresh{}
+	_ protocol.Iterator = &AliceReshare{}
+	_ protocol.Iterator = &BobReshare{}
+	_ protocol.Iterator = &AlicePresign{}
+	_ protocol.Iterator = &BobPresign{}
+	_ protocol.Iterator = &AliceDerive{}
+	_ protocol.Iterator = &BobDerive{}
 )
 
 // NewAliceDkg creates a new protocol that can compute a DKG as Alice
 func NewAliceDkg(curve *curves.Curve, version uint) *AliceDkg {
-	a := &AliceDkg{Alice: dkg.NewAlice(curve)}
+	return newAliceDkg(dkg.NewAlice(curve), version)
+}
+
+// newAliceDkg runs the DKG from the state of alice, new or restored
+func newAliceDkg(alice *dkg.Alice, version uint) *AliceDkg {
+	a := &AliceDkg{Alice: alice}
+	a.counterparty, a.name, a.version = PartyBob, protocol.Dkls18Dkg, version
+	a.transcript = alice.MarshalTranscript
 	a.steps = []func(*protocol.Message) (*protocol.Message, error){
 		func(input *protocol.Message) (*protocol.Message, error) {
 			bobSeed, err := decodeDkgRound2Input(input)
 			if err != nil {
-				return nil, errors.WithStack(err)
+				return nil, reject(errors.WithStack(err))
 			}
 			roundOutput, err := a.Round2CommitToProof(bobSeed)
 			if err != nil {
@@ -76,43 +132,43 @@ func NewAliceDkg(curve *curves.Curve, version uint) *AliceDkg {
 		func(input *protocol.Message) (*protocol.Message, error) {
 			proof, err := decodeDkgRound4Input(input)
 			if err != nil {
-				return nil, errors.WithStack(err)
+				return nil, reject(errors.WithStack(err))
 			}
 			aliceProof, err := a.Round4VerifyAndReveal(proof)
 			if err != nil {
-				return nil, err
+				return nil, reject(err)
 			}
 			return encodeDkgRound4Output(aliceProof, version)
 		},
 		func(input *protocol.Message) (*protocol.Message, error) {
 			proof, err := decodeDkgRound6Input(input)
 			if err != nil {
-				return nil, errors.WithStack(err)
+				return nil, reject(errors.WithStack(err))
 			}
 			choices, err := a.Round6DkgRound2Ot(proof)
 			if err != nil {
-				return nil, err
+				return nil, reject(err)
 			}
 			return encodeDkgRound6Output(choices, version)
 		},
 		func(input *protocol.Message) (*protocol.Message, error) {
 			challenge, err := decodeDkgRound8Input(input)
 			if err != nil {
-				return nil, errors.WithStack(err)
+				return nil, reject(errors.WithStack(err))
 			}
 			responses, err := a.Round8DkgRound4Ot(challenge)
 			if err != nil {
-				return nil, err
+				return nil, reject(err)
 			}
 			return encodeDkgRound8Output(responses, version)
 		},
 		func(input *protocol.Message) (*protocol.Message, error) {
 			opening, err := decodeDkgRound10Input(input)
 			if err != nil {
-				return nil, errors.WithStack(err)
+				return nil, reject(errors.WithStack(err))
 			}
 			if err := a.Round10DkgRound6Ot(opening); err != nil {
-				return nil, err
+				return nil, reject(err)
 			}
 			return nil, nil
 		},
@@ -136,7 +192,14 @@ func (a *AliceDkg) Result(version uint) (*protocol.Message, error) {
 
 // NewBobDkg Creates a new protocol that can compute a DKG as Bob.
 func NewBobDkg(curve *curves.Curve, version uint) *BobDkg {
-	b := &BobDkg{Bob: dkg.NewBob(curve)}
+	return newBobDkg(dkg.NewBob(curve), version)
+}
+
+// newBobDkg runs the DKG from the state of bob, new or restored
+func newBobDkg(bob *dkg.Bob, version uint) *BobDkg {
+	b := &BobDkg{Bob: bob}
+	b.counterparty, b.name, b.version = PartyAlice, protocol.Dkls18Dkg, version
+	b.transcript = bob.MarshalTranscript
 	b.steps = []func(message *protocol.Message) (*protocol.Message, error){
 		func(*protocol.Message) (*protocol.Message, error) {
 			commitment, err := b.Round1GenerateRandomSeed()
@@ -148,7 +211,7 @@ func NewBobDkg(curve *curves.Curve, version uint) *BobDkg {
 		func(input *protocol.Message) (*protocol.Message, error) {
 			round3Input, err := decodeDkgRound3Input(input)
 			if err != nil {
-				return nil, errors.WithStack(err)
+				return nil, reject(errors.WithStack(err))
 			}
 			bobProof, err := b.Round3SchnorrProve(round3Input)
 			if err != nil {
@@ -159,33 +222,33 @@ func NewBobDkg(curve *curves.Curve, version uint) *BobDkg {
 		func(input *protocol.Message) (*protocol.Message, error) {
 			proof, err := decodeDkgRound5Input(input)
 			if err != nil {
-				return nil, errors.WithStack(err)
+				return nil, reject(errors.WithStack(err))
 			}
 			bobProof, err := b.Round5DecommitmentAndStartOt(proof)
 			if err != nil {
-				return nil, err
+				return nil, reject(err)
 			}
 			return encodeDkgRound5Output(bobProof, version)
 		},
 		func(input *protocol.Message) (*protocol.Message, error) {
 			choices, err := decodeDkgRound7Input(input)
 			if err != nil {
-				return nil, errors.WithStack(err)
+				return nil, reject(errors.WithStack(err))
 			}
 			challenge, err := b.Round7DkgRound3Ot(choices)
 			if err != nil {
-				return nil, err
+				return nil, reject(err)
 			}
 			return encodeDkgRound7Output(challenge, version)
 		},
 		func(input *protocol.Message) (*protocol.Message, error) {
 			responses, err := decodeDkgRound9Input(input)
 			if err != nil {
-				return nil, errors.WithStack(err)
+				return nil, reject(errors.WithStack(err))
 			}
 			opening, err := b.Round9DkgRound5Ot(responses)
 			if err != nil {
-				return nil, err
+				return nil, reject(err)
 			}
 			return encodeDkgRound9Output(opening, version)
 		},
@@ -215,6 +278,8 @@ func NewAliceSign(curve *curves.Curve, hash hash.Hash, message []byte, dkgResult
 		return nil, errors.WithStack(err)
 	}
 	a := &AliceSign{Alice: sign.NewAlice(curve, hash, dkgResult)}
+	a.counterparty, a.name, a.version = PartyBob, protocol.Dkls18Sign, version
+	a.inputs = &signInputs{curve: curve, message: message, dkgResult: dkgResultMessage}
 	a.steps = []func(message *protocol.Message) (*protocol.Message, error){
 		func(*protocol.Message) (*protocol.Message, error) {
 			aliceCommitment, err := a.Round1GenerateRandomSeed()
@@ -226,11 +291,11 @@ func NewAliceSign(curve *curves.Curve, hash hash.Hash, message []byte, dkgResult
 		func(input *protocol.Message) (*protocol.Message, error) {
 			round2Output, err := decodeSignRound3Input(input)
 			if err != nil {
-				return nil, errors.WithStack(err)
+				return nil, reject(errors.WithStack(err))
 			}
 			round3Output, err := a.Round3Sign(message, round2Output)
 			if err != nil {
-				return nil, errors.WithStack(err)
+				return nil, reject(errors.WithStack(err))
 			}
 			return encodeSignRound3Output(round3Output, version)
 		},
@@ -246,11 +311,13 @@ func NewBobSign(curve *curves.Curve, hash hash.Hash, message []byte, dkgResultMe
 		return nil, errors.WithStack(err)
 	}
 	b := &BobSign{Bob: sign.NewBob(curve, hash, dkgResult)}
+	b.counterparty, b.name, b.version = PartyAlice, protocol.Dkls18Sign, version
+	b.inputs = &signInputs{curve: curve, message: message, dkgResult: dkgResultMessage}
 	b.steps = []func(message *protocol.Message) (*protocol.Message, error){
 		func(input *protocol.Message) (*protocol.Message, error) {
 			commitment, err := decodeSignRound2Input(input)
 			if err != nil {
-				return nil, errors.WithStack(err)
+				return nil, reject(errors.WithStack(err))
 			}
 			round2Output, err := b.Round2Initialize(commitment)
 			if err != nil {
@@ -261,11 +328,11 @@ func NewBobSign(curve *curves.Curve, hash hash.Hash, message []byte, dkgResultMe
 		func(input *protocol.Message) (*protocol.Message, error) {
 			round4Input, err := decodeSignRound4Input(input)
 			if err != nil {
-				return nil, errors.WithStack(err)
+				return nil, reject(errors.WithStack(err))
 			}
 
 			if err = b.Round4Final(message, round4Input); err != nil {
-				return nil, errors.WithStack(err)
+				return nil, reject(errors.WithStack(err))
 			}
 			return nil, nil
 		},
@@ -298,8 +365,14 @@ func NewAliceRefresh(curve *curves.Curve, dkgResultMessage *protocol.Message, ve
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
+	return newAliceRefresh(refresh.NewAlice(curve, dkgResult), version), nil
+}
 
-	a := &AliceRefresh{Alice: refresh.NewAlice(curve, dkgResult)}
+// newAliceRefresh runs the refresh from the state of alice, new or restored
+func newAliceRefresh(alice *refresh.Alice, version uint) *AliceRefresh {
+	a := &AliceRefresh{Alice: alice}
+	a.counterparty, a.name, a.version = PartyBob, protocol.Dkls18Refresh, version
+	a.transcript = alice.MarshalTranscript
 	a.steps = []func(*protocol.Message) (*protocol.Message, error){
 		func(_ *protocol.Message) (*protocol.Message, error) {
 			aliceSeed := a.Round1RefreshGenerateSeed()
@@ -308,37 +381,37 @@ func NewAliceRefresh(curve *curves.Curve, dkgResultMessage *protocol.Message, ve
 		func(input *protocol.Message) (*protocol.Message, error) {
 			round3Input, err := decodeRefreshRound3Input(input)
 			if err != nil {
-				return nil, errors.WithStack(err)
+				return nil, reject(errors.WithStack(err))
 			}
 			round3Output, err := a.Round3RefreshMultiplyRound2Ot(round3Input)
 			if err != nil {
-				return nil, err
+				return nil, reject(err)
 			}
 			return encodeRefreshRound3Output(round3Output, version)
 		},
 		func(input *protocol.Message) (*protocol.Message, error) {
 			round5Input, err := decodeRefreshRound5Input(input)
 			if err != nil {
-				return nil, errors.WithStack(err)
+				return nil, reject(errors.WithStack(err))
 			}
 			round5Output, err := a.Round5RefreshRound4Ot(round5Input)
 			if err != nil {
-				return nil, err
+				return nil, reject(err)
 			}
 			return encodeRefreshRound5Output(round5Output, version)
 		},
 		func(input *protocol.Message) (*protocol.Message, error) {
 			round7Input, err := decodeRefreshRound7Input(input)
 			if err != nil {
-				return nil, errors.WithStack(err)
+				return nil, reject(errors.WithStack(err))
 			}
 			if err := a.Round7DkgRound6Ot(round7Input); err != nil {
-				return nil, err
+				return nil, reject(err)
 			}
 			return nil, nil
 		},
 	}
-	return a, nil
+	return a
 }
 
 // Result Returns an encoded version of Alice as sequence of bytes that can be used to initialize an AliceSign protocol.
@@ -361,13 +434,19 @@ func NewBobRefresh(curve *curves.Curve, dkgResultMessage *protocol.Message, vers
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
+	return newBobRefresh(refresh.NewBob(curve, dkgResult), version), nil
+}
 
-	b := &BobRefresh{Bob: refresh.NewBob(curve, dkgResult)}
+// newBobRefresh runs the refresh from the state of bob, new or restored
+func newBobRefresh(bob *refresh.Bob, version uint) *BobRefresh {
+	b := &BobRefresh{Bob: bob}
+	b.counterparty, b.name, b.version = PartyAlice, protocol.Dkls18Refresh, version
+	b.transcript = bob.MarshalTranscript
 	b.steps = []func(message *protocol.Message) (*protocol.Message, error){
 		func(input *protocol.Message) (*protocol.Message, error) {
 			round2Input, err := decodeRefreshRound2Input(input)
 			if err != nil {
-				return nil, errors.WithStack(err)
+				return nil, reject(errors.WithStack(err))
 			}
 			round2Output, err := b.Round2RefreshProduceSeedAndMultiplyAndStartOT(round2Input)
 			if err != nil {
@@ -378,27 +457,27 @@ func NewBobRefresh(curve *curves.Curve, dkgResultMessage *protocol.Message, vers
 		func(input *protocol.Message) (*protocol.Message, error) {
 			round4Input, err := decodeRefreshRound4Input(input)
 			if err != nil {
-				return nil, errors.WithStack(err)
+				return nil, reject(errors.WithStack(err))
 			}
 			round4Output, err := b.Round4RefreshRound3Ot(round4Input)
 			if err != nil {
-				return nil, err
+				return nil, reject(err)
 			}
 			return encodeRefreshRound4Output(round4Output, version)
 		},
 		func(input *protocol.Message) (*protocol.Message, error) {
 			round6Input, err := decodeRefreshRound6Input(input)
 			if err != nil {
-				return nil, errors.WithStack(err)
+				return nil, reject(errors.WithStack(err))
 			}
 			round6Output, err := b.Round6RefreshRound5Ot(round6Input)
 			if err != nil {
-				return nil, err
+				return nil, reject(err)
 			}
 			return encodeRefreshRound6Output(round6Output, version)
 		},
 	}
-	return b, nil
+	return b
 }
 
 // Result returns an encoded version of Bob as sequence of bytes that can be used to  initialize an BobSign protocol.
@@ -414,3 +493,437 @@ func (b *BobRefresh) Result(version uint) (*protocol.Message, error) {
 	result := b.Output()
 	return EncodeBobDkgOutput(result, version)
 }
+
+// SplitAliceShare splits the key share of Alice in a DKG result into handoffs for the new Alice and the new Bob of a
+// reshare. Each handoff must reach its party over a private channel, and the old share must be deleted.
+func SplitAliceShare(curve *curves.Curve, dkgResultMessage *protocol.Message, version uint) (toNewAlice, toNewBob *protocol.Message, err error) {
+	dkgResult, err := DecodeAliceDkgResult(dkgResultMessage)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	same, other, err := refresh.SplitShare(curve, dkgResult.SecretKeyShare, dkgResult.PublicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return encodeHandoffs(same, other, PartyAlice, version)
+}
+
+// SplitBobShare splits the key share of Bob in a DKG result into handoffs for the new Alice and the new Bob of a
+// reshare. Each handoff must reach its party over a private channel, and the old share must be deleted.
+func SplitBobShare(curve *curves.Curve, dkgResultMessage *protocol.Message, version uint) (toNewAlice, toNewBob *protocol.Message, err error) {
+	dkgResult, err := DecodeBobDkgResult(dkgResultMessage)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	same, other, err := refresh.SplitShare(curve, dkgResult.SecretKeyShare, dkgResult.PublicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return encodeHandoffs(other, same, PartyBob, version)
+}
+
+func encodeHandoffs(toAlice, toBob *refresh.Handoff, from Party, version uint) (toNewAlice, toNewBob *protocol.Message, err error) {
+	if toNewAlice, err = EncodeReshareHandoff(toAlice, from, PartyAlice, version); err != nil {
+		return nil, nil, err
+	}
+	if toNewBob, err = EncodeReshareHandoff(toBob, from, PartyBob, version); err != nil {
+		return nil, nil, err
+	}
+	return toNewAlice, toNewBob, nil
+}
+
+// NewAliceReshare creates a new protocol that takes over the key as the new Alice, from her handoffs of the old Alice
+// and the old Bob. The result decodes like a DKG output with the same public key.
+func NewAliceReshare(curve *curves.Curve, fromAlice, fromBob *protocol.Message, version uint) (*AliceReshare, error) {
+	fromAliceHandoff, err := DecodeReshareHandoff(fromAlice, PartyAlice, PartyAlice)
+	if err != nil {
+		return nil, err
+	}
+	fromBobHandoff, err := DecodeReshareHandoff(fromBob, PartyBob, PartyAlice)
+	if err != nil {
+		return nil, err
+	}
+	alice, err := refresh.NewAliceReshare(curve, fromAliceHandoff, fromBobHandoff)
+	if err != nil {
+		return nil, err
+	}
+	return newAliceReshare(alice, version), nil
+}
+
+// newAliceReshare runs the reshare from the state of alice, new or restored
+func newAliceReshare(alice *refresh.Alice, version uint) *AliceReshare {
+	a := &AliceReshare{Alice: alice}
+	a.counterparty, a.name, a.version = PartyBob, protocol.Dkls18Reshare, version
+	a.transcript = alice.MarshalTranscript
+	a.steps = []func(*protocol.Message) (*protocol.Message, error){
+		func(_ *protocol.Message) (*protocol.Message, error) {
+			aliceSeed := a.Round1RefreshGenerateSeed()
+			return encodeReshareRound1Output(aliceSeed, version)
+		},
+		func(input *protocol.Message) (*protocol.Message, error) {
+			round3Input, err := decodeReshareRound3Input(input)
+			if err != nil {
+				return nil, reject(errors.WithStack(err))
+			}
+			round3Output, err := a.Round3ReshareVerifyAndProve(round3Input)
+			if err != nil {
+				return nil, reject(err)
+			}
+			return encodeReshareRound3Output(round3Output, version)
+		},
+		func(input *protocol.Message) (*protocol.Message, error) {
+			round5Input, err := decodeReshareRound5Input(input)
+			if err != nil {
+				return nil, reject(errors.WithStack(err))
+			}
+			round5Output, err := a.Round5RefreshRound4Ot(round5Input)
+			if err != nil {
+				return nil, reject(err)
+			}
+			return encodeReshareRound5Output(round5Output, version)
+		},
+		func(input *protocol.Message) (*protocol.Message, error) {
+			round7Input, err := decodeReshareRound7Input(input)
+			if err != nil {
+				return nil, reject(errors.WithStack(err))
+			}
+			if err := a.Round7DkgRound6Ot(round7Input); err != nil {
+				return nil, reject(err)
+			}
+			return nil, nil
+		},
+	}
+	return a
+}
+
+// Result returns the encoded output of the new Alice, which can be used to initialize an AliceSign protocol.
+func (a *AliceReshare) Result(version uint) (*protocol.Message, error) {
+	// Sanity check
+	if !a.complete() {
+		return nil, nil
+	}
+	if a.Alice == nil {
+		return nil, protocol.ErrNotInitialized
+	}
+
+	result := a.Output()
+	return EncodeAliceDkgOutput(result, version)
+}
+
+// NewBobReshare creates a new protocol that takes over the key as the new Bob, from his handoffs of the old Alice and
+// the old Bob. The result decodes like a DKG output with the same public key.
+func NewBobReshare(curve *curves.Curve, fromAlice, fromBob *protocol.Message, version uint) (*BobReshare, error) {
+	fromAliceHandoff, err := DecodeReshareHandoff(fromAlice, PartyAlice, PartyBob)
+	if err != nil {
+		return nil, err
+	}
+	fromBobHandoff, err := DecodeReshareHandoff(fromBob, PartyBob, PartyBob)
+	if err != nil {
+		return nil, err
+	}
+	bob, err := refresh.NewBobReshare(curve, fromAliceHandoff, fromBobHandoff)
+	if err != nil {
+		return nil, err
+	}
+	return newBobReshare(bob, version), nil
+}
+
+// newBobReshare runs the reshare from the state of bob, new or restored
+func newBobReshare(bob *refresh.Bob, version uint) *BobReshare {
+	b := &BobReshare{Bob: bob}
+	b.counterparty, b.name, b.version = PartyAlice, protocol.Dkls18Reshare, version
+	b.transcript = bob.MarshalTranscript
+	b.steps = []func(message *protocol.Message) (*protocol.Message, error){
+		func(input *protocol.Message) (*protocol.Message, error) {
+			round2Input, err := decodeReshareRound2Input(input)
+			if err != nil {
+				return nil, reject(errors.WithStack(err))
+			}
+			round2Output, err := b.Round2ReshareProduceSeedAndProve(round2Input)
+			if err != nil {
+				return nil, err
+			}
+			return encodeReshareRound2Output(round2Output, version)
+		},
+		func(input *protocol.Message) (*protocol.Message, error) {
+			round4Input, err := decodeReshareRound4Input(input)
+			if err != nil {
+				return nil, reject(errors.WithStack(err))
+			}
+			round4Output, err := b.Round4ReshareVerify(round4Input)
+			if err != nil {
+				return nil, reject(err)
+			}
+			return encodeReshareRound4Output(round4Output, version)
+		},
+		func(input *protocol.Message) (*protocol.Message, error) {
+			round6Input, err := decodeReshareRound6Input(input)
+			if err != nil {
+				return nil, reject(errors.WithStack(err))
+			}
+			round6Output, err := b.Round6RefreshRound5Ot(round6Input)
+			if err != nil {
+				return nil, reject(err)
+			}
+			return encodeReshareRound6Output(round6Output, version)
+		},
+	}
+	return b
+}
+
+// Result returns the encoded output of the new Bob, which can be used to initialize a BobSign protocol.
+func (b *BobReshare) Result(version uint) (*protocol.Message, error) {
+	// Sanity check
+	if !b.complete() {
+		return nil, nil
+	}
+	if b.Bob == nil {
+		return nil, protocol.ErrNotInitialized
+	}
+
+	result := b.Output()
+	return EncodeBobDkgOutput(result, version)
+}
+
+// NewAlicePresign creates a new protocol that computes a presignature as Alice, before the message is known.
+// Requires dkg state that was produced at the end of DKG.Output().
+func NewAlicePresign(curve *curves.Curve, dkgResultMessage *protocol.Message, version uint) (*AlicePresign, error) {
+	dkgResult, err := DecodeAliceDkgResult(dkgResultMessage)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	a := &AlicePresign{Alice: sign.NewAlice(curve, nil, dkgResult)}
+	a.counterparty, a.name, a.version = PartyBob, protocol.Dkls18Presign, version
+	a.inputs = &signInputs{curve: curve, dkgResult: dkgResultMessage}
+	a.steps = []func(message *protocol.Message) (*protocol.Message, error){
+		func(*protocol.Message) (*protocol.Message, error) {
+			aliceCommitment, err := a.Round1GenerateRandomSeed()
+			if err != nil {
+				return nil, err
+			}
+			return encodeSignRound1Output(aliceCommitment, version)
+		},
+		func(input *protocol.Message) (*protocol.Message, error) {
+			round2Output, err := decodeSignRound3Input(input)
+			if err != nil {
+				return nil, reject(errors.WithStack(err))
+			}
+			round3Output, presignature, err := a.Round3Presign(round2Output)
+			if err != nil {
+				return nil, reject(errors.WithStack(err))
+			}
+			a.presignature = presignature
+			return encodePresignRound3Output(round3Output, version)
+		},
+	}
+	return a, nil
+}
+
+// NewBobPresign creates a new protocol that computes a presignature as Bob, before the message is known.
+// Requires dkg state that was produced at the end of DKG.Output().
+func NewBobPresign(curve *curves.Curve, dkgResultMessage *protocol.Message, version uint) (*BobPresign, error) {
+	dkgResult, err := DecodeBobDkgResult(dkgResultMessage)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	b := &BobPresign{Bob: sign.NewBob(curve, nil, dkgResult)}
+	b.counterparty, b.name, b.version = PartyAlice, protocol.Dkls18Presign, version
+	b.inputs = &signInputs{curve: curve, dkgResult: dkgResultMessage}
+	b.steps = []func(message *protocol.Message) (*protocol.Message, error){
+		func(input *protocol.Message) (*protocol.Message, error) {
+			commitment, err := decodeSignRound2Input(input)
+			if err != nil {
+				return nil, reject(errors.WithStack(err))
+			}
+			round2Output, err := b.Round2Initialize(commitment)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			return encodeSignRound2Output(round2Output, version)
+		},
+		func(input *protocol.Message) (*protocol.Message, error) {
+			round4Input, err := decodePresignRound4Input(input)
+			if err != nil {
+				return nil, reject(errors.WithStack(err))
+			}
+			if b.presignature, err = b.Round4Presign(round4Input); err != nil {
+				return nil, reject(errors.WithStack(err))
+			}
+			return nil, nil
+		},
+	}
+	return b, nil
+}
+
+// Result returns Alice's encoded presignature once the presign protocol completed.
+func (a *AlicePresign) Result(version uint) (*protocol.Message, error) {
+	if !a.complete() {
+		return nil, nil
+	}
+	if a.presignature == nil {
+		return nil, protocol.ErrNotInitialized
+	}
+	return EncodeAlicePresignature(a.presignature, version)
+}
+
+// Result returns Bob's encoded presignature once the presign protocol completed.
+func (b *BobPresign) Result(version uint) (*protocol.Message, error) {
+	if !b.complete() {
+		return nil, nil
+	}
+	if b.presignature == nil {
+		return nil, protocol.ErrNotInitialized
+	}
+	return EncodeBobPresignature(b.presignature, version)
+}
+
+// SignWithAlicePresignature computes Alice's only message of the online phase for message.
+// The presignature is spent; its stored copy must be deleted.
+func SignWithAlicePresignature(presignature *sign.AlicePresignature, hash hash.Hash, message []byte, version uint) (*protocol.Message, error) {
+	if presignature == nil {
+		return nil, protocol.ErrNotInitialized
+	}
+	output, err := presignature.Sign(hash, message)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return encodeOnlineSignOutput(output, version)
+}
+
+// FinalizeWithBobPresignature completes the signature on message from Alice's online message and returns it
+// encoded like the result of BobSign. The presignature is spent; its stored copy must be deleted.
+func FinalizeWithBobPresignature(presignature *sign.BobPresignature, hash hash.Hash, message []byte, onlineMessage *protocol.Message, version uint) (*protocol.Message, error) {
+	if presignature == nil {
+		return nil, protocol.ErrNotInitialized
+	}
+	if presignature.Used {
+		return nil, errors.New("presignature has already been used")
+	}
+	output, err := decodeOnlineSignInput(onlineMessage)
+	if errors.Is(err, errUnexpectedMessage) {
+		return nil, err
+	}
+	var signature *curves.EcdsaSignature
+	if err == nil {
+		signature, err = presignature.Finalize(hash, message, output)
+	}
+	if err != nil {
+		return nil, &AbortError{
+			Culprit:  PartyAlice,
+			Protocol: protocol.Dkls18Presign,
+			Round:    1,
+			Err:      err,
+			Evidence: &Evidence{Message: onlineMessage},
+		}
+	}
+	return encodeSignature(signature, version)
+}
+
+// NewAliceDerive creates a new protocol that derives the shares of the non-hardened child index of the key of
+// dkgResultMessage, whose chain code is chainCode, as Alice. The chain code of the child is available from
+// ChainCode once the protocol completed.
+func NewAliceDerive(curve *curves.Curve, dkgResultMessage *protocol.Message, chainCode []byte, index uint32, version uint) (*AliceDerive, error) {
+	dkgResult, err := DecodeAliceDkgResult(dkgResultMessage)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	alice, err := derive.NewAlice(curve, dkgResult, chainCode, index)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	a := &AliceDerive{Alice: alice}
+	a.counterparty, a.name = PartyBob, protocol.Dkls18Derive
+	a.steps = []func(*protocol.Message) (*protocol.Message, error){
+		func(*protocol.Message) (*protocol.Message, error) {
+			seed, err := a.Round1GenerateRandomSeed()
+			if err != nil {
+				return nil, err
+			}
+			return encodeDeriveRound1Output(seed, version)
+		},
+		func(input *protocol.Message) (*protocol.Message, error) {
+			round3Input, err := decodeDeriveRound3Input(input)
+			if err != nil {
+				return nil, reject(errors.WithStack(err))
+			}
+			round3Output, err := a.Round3Multiply(round3Input)
+			if err != nil {
+				return nil, reject(err)
+			}
+			return encodeDeriveRound3Output(round3Output, version)
+		},
+		func(input *protocol.Message) (*protocol.Message, error) {
+			round5Input, err := decodeDeriveRound5Input(input)
+			if err != nil {
+				return nil, reject(errors.WithStack(err))
+			}
+			if err := a.Round5Verify(round5Input); err != nil {
+				return nil, reject(err)
+			}
+			return nil, nil
+		},
+	}
+	return a, nil
+}
+
+// Result returns Alice's encoded share of the child key, which can be used to initialize an AliceSign protocol.
+func (a *AliceDerive) Result(version uint) (*protocol.Message, error) {
+	if !a.complete() {
+		return nil, nil
+	}
+	if a.Alice == nil {
+		return nil, protocol.ErrNotInitialized
+	}
+	return EncodeAliceDkgOutput(a.Output(), version)
+}
+
+// NewBobDerive creates a new protocol that derives the shares of the non-hardened child index of the key of
+// dkgResultMessage, whose chain code is chainCode, as Bob.
+func NewBobDerive(curve *curves.Curve, dkgResultMessage *protocol.Message, chainCode []byte, index uint32, version uint) (*BobDerive, error) {
+	dkgResult, err := DecodeBobDkgResult(dkgResultMessage)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	bob, err := derive.NewBob(curve, dkgResult, chainCode, index)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	b := &BobDerive{Bob: bob}
+	b.counterparty, b.name = PartyAlice, protocol.Dkls18Derive
+	b.steps = []func(*protocol.Message) (*protocol.Message, error){
+		func(input *protocol.Message) (*protocol.Message, error) {
+			seed, err := decodeDeriveRound2Input(input)
+			if err != nil {
+				return nil, reject(errors.WithStack(err))
+			}
+			round2Output, err := b.Round2Initialize(seed)
+			if err != nil {
+				return nil, err
+			}
+			return encodeDeriveRound2Output(round2Output, version)
+		},
+		func(input *protocol.Message) (*protocol.Message, error) {
+			round4Input, err := decodeDeriveRound4Input(input)
+			if err != nil {
+				return nil, reject(errors.WithStack(err))
+			}
+			proof, err := b.Round4Verify(round4Input)
+			if err != nil {
+				return nil, reject(err)
+			}
+			return encodeDeriveRound4Output(proof, version)
+		},
+	}
+	return b, nil
+}
+
+// Result returns Bob's encoded share of the child key, which can be used to initialize a BobSign protocol.
+func (b *BobDerive) Result(version uint) (*protocol.Message, error) {
+	if !b.complete() {
+		return nil, nil
+	}
+	if b.Bob == nil {
+		return nil, protocol.ErrNotInitialized
+	}
+	return EncodeBobDkgOutput(b.Output(), version)
+}
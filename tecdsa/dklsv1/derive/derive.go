@@ -0,0 +1,290 @@
+// Package derive implements non-hardened BIP32 child derivation on the key shares of [DKLs18](https://eprint.iacr.org/2018/499.pdf).
+// Alice and Bob hold multiplicative shares sk = sk_A * sk_B of the parent key and both know its chain code, so both
+// compute the public BIP32 tweak t of the child, sk' = sk + t. The tweak cannot be added to a multiplicative share
+// without leaking it, instead the parties re-share the child key with one multiplication:
+//  1. alice and bob agree on a session id as in the signing protocol.
+//  2. alice samples sk'_A <-- F_q and runs the multiplication with input sk_A / sk'_A, bob with input sk_B; they obtain
+//     additive shares alpha + beta = sk_A * sk_B / sk'_A.
+//  3. alice sends m = alpha + t / sk'_A and a proof of knowledge of sk'_A. bob sets sk'_B = beta + m, so that
+//     sk'_A * sk'_B = sk + t, and checks sk'_B * (sk'_A . G) = pk + t . G.
+//  4. bob sends a proof of knowledge of sk'_B and alice checks sk'_A * (sk'_B . G) = pk + t . G.
+//
+// The child shares reuse the seed OT of the parent.
+package derive
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
+	"github.com/go-sonr/crypto/hd"
+	"github.com/go-sonr/crypto/ot/base/simplest"
+	"github.com/go-sonr/crypto/ot/extension/kos"
+	"github.com/go-sonr/crypto/tecdsa/dklsv1/dkg"
+	"github.com/go-sonr/crypto/tecdsa/dklsv1/sign"
+	"github.com/go-sonr/crypto/zkp/schnorr"
+)
+
+// Alice struct encoding Alice's state during one execution of the derivation protocol.
+type Alice struct {
+	seedOtResults  *simplest.ReceiverOutput
+	secretKeyShare curves.Scalar
+	publicKey      curves.Point
+
+	// tweak and chainCode are the BIP32 tweak and the chain code of the child.
+	tweak     curves.Scalar
+	chainCode []byte
+
+	childSecretKeyShare curves.Scalar
+	childPublicKey      curves.Point
+
+	curve      *curves.Curve
+	transcript *transcript.Transcript
+}
+
+// Bob struct encoding Bob's state during one execution of the derivation protocol.
+type Bob struct {
+	seedOtResults  *simplest.SenderOutput
+	secretKeyShare curves.Scalar
+	publicKey      curves.Point
+
+	// tweak and chainCode are the BIP32 tweak and the chain code of the child.
+	tweak     curves.Scalar
+	chainCode []byte
+
+	multiplyReceiver    *sign.MultiplyReceiver
+	childSecretKeyShare curves.Scalar
+	childPublicKey      curves.Point
+
+	curve      *curves.Curve
+	transcript *transcript.Transcript
+}
+
+// Round2Output is the output of Bob's first round.
+type Round2Output struct {
+	// Seed is the random value used to derive the joint unique session id.
+	Seed [simplest.DigestSize]byte
+
+	// KosRound1Output is the first message of the multiplication.
+	KosRound1Output *kos.Round1Output
+}
+
+// Round3Output is the output of Alice's second round.
+type Round3Output struct {
+	// MultiplyRound2Output is the second message of the multiplication.
+	MultiplyRound2Output *sign.MultiplyRound2Output
+
+	// Mask is m = alpha + t / sk'_A, Bob's child share minus his output of the multiplication.
+	Mask curves.Scalar
+
+	// Proof is the proof of knowledge of Alice's child share.
+	Proof *schnorr.Proof
+}
+
+// NewAlice creates a party that derives the non-hardened child index of Alice's key share with parent chain code
+// chainCode.
+func NewAlice(curve *curves.Curve, dkgOutput *dkg.AliceOutput, chainCode []byte, index uint32) (*Alice, error) {
+	tweak, childChainCode, err := childTweak(curve, dkgOutput.PublicKey, chainCode, index)
+	if err != nil {
+		return nil, err
+	}
+	return &Alice{
+		seedOtResults:  dkgOutput.SeedOtResult,
+		secretKeyShare: dkgOutput.SecretKeyShare,
+		publicKey:      dkgOutput.PublicKey,
+		tweak:          tweak,
+		chainCode:      childChainCode,
+		curve:          curve,
+		transcript:     newTranscript(dkgOutput.PublicKey, chainCode, index),
+	}, nil
+}
+
+// NewBob creates a party that derives the non-hardened child index of Bob's key share with parent chain code
+// chainCode.
+func NewBob(curve *curves.Curve, dkgOutput *dkg.BobOutput, chainCode []byte, index uint32) (*Bob, error) {
+	tweak, childChainCode, err := childTweak(curve, dkgOutput.PublicKey, chainCode, index)
+	if err != nil {
+		return nil, err
+	}
+	return &Bob{
+		seedOtResults:  dkgOutput.SeedOtResult,
+		secretKeyShare: dkgOutput.SecretKeyShare,
+		publicKey:      dkgOutput.PublicKey,
+		tweak:          tweak,
+		chainCode:      childChainCode,
+		curve:          curve,
+		transcript:     newTranscript(dkgOutput.PublicKey, chainCode, index),
+	}, nil
+}
+
+func childTweak(curve *curves.Curve, publicKey curves.Point, chainCode []byte, index uint32) (curves.Scalar, []byte, error) {
+	if publicKey == nil {
+		return nil, nil, errors.New("missing public key")
+	}
+	tweak, childChainCode, err := hd.ChildTweak(curve, publicKey.ToAffineCompressed(), chainCode, index)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "computing child tweak")
+	}
+	return tweak, childChainCode, nil
+}
+
+func newTranscript(publicKey curves.Point, chainCode []byte, index uint32) *transcript.Transcript {
+	tr := transcript.New("Coinbase_DKLs_Derive")
+	tr.AppendPoint([]byte("public key"), publicKey)
+	tr.AppendMessage([]byte("chain code"), chainCode)
+	tr.AppendMessage([]byte("index"), binary.BigEndian.AppendUint32(nil, index))
+	return tr
+}
+
+// Round1GenerateRandomSeed Alice samples her half of the session id, as in the signing protocol.
+func (alice *Alice) Round1GenerateRandomSeed() ([simplest.DigestSize]byte, error) {
+	aliceSeed := [simplest.DigestSize]byte{}
+	if _, err := rand.Read(aliceSeed[:]); err != nil {
+		return [simplest.DigestSize]byte{}, errors.Wrap(err, "generating random bytes in alice round 1 derive")
+	}
+	alice.transcript.AppendMessage([]byte("session_id_alice"), aliceSeed[:])
+	return aliceSeed, nil
+}
+
+// Round2Initialize Bob samples his half of the session id and starts the multiplication with his key share as input.
+func (bob *Bob) Round2Initialize(aliceSeed [simplest.DigestSize]byte) (*Round2Output, error) {
+	bobSeed := [simplest.DigestSize]byte{}
+	if _, err := rand.Read(bobSeed[:]); err != nil {
+		return nil, errors.Wrap(err, "generating random bytes in bob round 2 derive")
+	}
+	bob.transcript.AppendMessage([]byte("session_id_alice"), aliceSeed[:])
+	bob.transcript.AppendMessage([]byte("session_id_bob"), bobSeed[:])
+
+	var err error
+	uniqueSessionId := [simplest.DigestSize]byte{}
+	copy(uniqueSessionId[:], bob.transcript.ExtractBytes([]byte("multiply receiver id"), simplest.DigestSize))
+	if bob.multiplyReceiver, err = sign.NewMultiplyReceiver(bob.seedOtResults, bob.curve, uniqueSessionId); err != nil {
+		return nil, errors.Wrap(err, "creating multiply receiver in bob round 2 derive")
+	}
+	kosRound1Output, err := bob.multiplyReceiver.Round1Initialize(bob.secretKeyShare)
+	if err != nil {
+		return nil, errors.Wrap(err, "multiply round 1 initialize in bob round 2 derive")
+	}
+	return &Round2Output{
+		Seed:            bobSeed,
+		KosRound1Output: kosRound1Output,
+	}, nil
+}
+
+// Round3Multiply Alice samples her child share, completes the multiplication and masks Bob's child share.
+func (alice *Alice) Round3Multiply(round2Output *Round2Output) (*Round3Output, error) {
+	if round2Output == nil || round2Output.KosRound1Output == nil {
+		return nil, errors.New("invalid round 2 output")
+	}
+	alice.transcript.AppendMessage([]byte("session_id_bob"), round2Output.Seed[:])
+
+	uniqueSessionId := [simplest.DigestSize]byte{}
+	copy(uniqueSessionId[:], alice.transcript.ExtractBytes([]byte("multiply receiver id"), simplest.DigestSize))
+	multiplySender, err := sign.NewMultiplySender(alice.seedOtResults, alice.curve, uniqueSessionId)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating multiply sender in alice round 3 derive")
+	}
+
+	alice.childSecretKeyShare = alice.curve.Scalar.Random(rand.Reader)
+	childInverse, err := alice.childSecretKeyShare.Invert()
+	if err != nil {
+		return nil, errors.Wrap(err, "inverting alice's child key share")
+	}
+	multiplyOutput, err := multiplySender.Round2Multiply(alice.secretKeyShare.Mul(childInverse), round2Output.KosRound1Output)
+	if err != nil {
+		return nil, errors.Wrap(err, "multiply round 2 in alice round 3 derive")
+	}
+	mask := multiplySender.OutputAdditiveShare().Add(alice.tweak.Mul(childInverse))
+
+	copy(uniqueSessionId[:], alice.transcript.ExtractBytes([]byte("schnorr proof for alice child share"), simplest.DigestSize))
+	prover := schnorr.NewProver(alice.curve, nil, uniqueSessionId[:])
+	proof, err := prover.Prove(alice.childSecretKeyShare)
+	if err != nil {
+		return nil, errors.Wrap(err, "proving alice's child share")
+	}
+	alice.childPublicKey = alice.publicKey.Add(alice.curve.ScalarBaseMult(alice.tweak))
+	return &Round3Output{
+		MultiplyRound2Output: multiplyOutput,
+		Mask:                 mask,
+		Proof:                proof,
+	}, nil
+}
+
+// Round4Verify Bob completes the multiplication, computes his child share and checks it against Alice's.
+func (bob *Bob) Round4Verify(round3Output *Round3Output) (*schnorr.Proof, error) {
+	if round3Output == nil || round3Output.MultiplyRound2Output == nil || round3Output.Mask == nil || !validProof(round3Output.Proof) {
+		return nil, errors.New("invalid round 3 output")
+	}
+	if err := bob.multiplyReceiver.Round3Multiply(round3Output.MultiplyRound2Output); err != nil {
+		return nil, errors.Wrap(err, "multiply round 3 in bob round 4 derive")
+	}
+	uniqueSessionId := [simplest.DigestSize]byte{}
+	copy(uniqueSessionId[:], bob.transcript.ExtractBytes([]byte("schnorr proof for alice child share"), simplest.DigestSize))
+	if err := schnorr.Verify(round3Output.Proof, bob.curve, nil, uniqueSessionId[:]); err != nil {
+		return nil, errors.Wrap(err, "verifying alice's child share proof")
+	}
+	bob.childSecretKeyShare = bob.multiplyReceiver.OutputAdditiveShare().Add(round3Output.Mask)
+	bob.childPublicKey = bob.publicKey.Add(bob.curve.ScalarBaseMult(bob.tweak))
+	if !round3Output.Proof.Statement.Mul(bob.childSecretKeyShare).Equal(bob.childPublicKey) {
+		return nil, errors.New("child shares do not match the child public key")
+	}
+
+	copy(uniqueSessionId[:], bob.transcript.ExtractBytes([]byte("schnorr proof for bob child share"), simplest.DigestSize))
+	prover := schnorr.NewProver(bob.curve, nil, uniqueSessionId[:])
+	proof, err := prover.Prove(bob.childSecretKeyShare)
+	if err != nil {
+		return nil, errors.Wrap(err, "proving bob's child share")
+	}
+	return proof, nil
+}
+
+// Round5Verify Alice checks Bob's child share against hers.
+func (alice *Alice) Round5Verify(proof *schnorr.Proof) error {
+	if !validProof(proof) {
+		return errors.New("invalid round 4 output")
+	}
+	uniqueSessionId := [simplest.DigestSize]byte{}
+	copy(uniqueSessionId[:], alice.transcript.ExtractBytes([]byte("schnorr proof for bob child share"), simplest.DigestSize))
+	if err := schnorr.Verify(proof, alice.curve, nil, uniqueSessionId[:]); err != nil {
+		return errors.Wrap(err, "verifying bob's child share proof")
+	}
+	if !proof.Statement.Mul(alice.childSecretKeyShare).Equal(alice.childPublicKey) {
+		return errors.New("child shares do not match the child public key")
+	}
+	return nil
+}
+
+func validProof(proof *schnorr.Proof) bool {
+	return proof != nil && proof.C != nil && proof.S != nil && proof.Statement != nil
+}
+
+// Output returns Alice's share of the child key.
+func (alice *Alice) Output() *dkg.AliceOutput {
+	return &dkg.AliceOutput{
+		PublicKey:      alice.childPublicKey,
+		SecretKeyShare: alice.childSecretKeyShare,
+		SeedOtResult:   alice.seedOtResults,
+	}
+}
+
+// Output returns Bob's share of the child key.
+func (bob *Bob) Output() *dkg.BobOutput {
+	return &dkg.BobOutput{
+		PublicKey:      bob.childPublicKey,
+		SecretKeyShare: bob.childSecretKeyShare,
+		SeedOtResult:   bob.seedOtResults,
+	}
+}
+
+// ChainCode returns the chain code of the child.
+func (alice *Alice) ChainCode() []byte {
+	return append([]byte{}, alice.chainCode...)
+}
+
+// ChainCode returns the chain code of the child.
+func (bob *Bob) ChainCode() []byte {
+	return append([]byte{}, bob.chainCode...)
+}
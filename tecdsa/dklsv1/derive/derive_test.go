@@ -0,0 +1,159 @@
+package derive_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/hd"
+	"github.com/go-sonr/crypto/tecdsa/dklsv1/derive"
+	"github.com/go-sonr/crypto/tecdsa/dklsv1/dkg"
+	"github.com/go-sonr/crypto/tecdsa/dklsv1/sign"
+)
+
+func performDKG(t *testing.T, curve *curves.Curve) (*dkg.AliceOutput, *dkg.BobOutput) {
+	t.Helper()
+
+	alice := dkg.NewAlice(curve)
+	bob := dkg.NewBob(curve)
+
+	seed, err := bob.Round1GenerateRandomSeed()
+	require.NoError(t, err)
+	round3Output, err := alice.Round2CommitToProof(seed)
+	require.NoError(t, err)
+	proof, err := bob.Round3SchnorrProve(round3Output)
+	require.NoError(t, err)
+	proof, err = alice.Round4VerifyAndReveal(proof)
+	require.NoError(t, err)
+	proof, err = bob.Round5DecommitmentAndStartOt(proof)
+	require.NoError(t, err)
+	compressedReceiversMaskedChoice, err := alice.Round6DkgRound2Ot(proof)
+	require.NoError(t, err)
+	challenge, err := bob.Round7DkgRound3Ot(compressedReceiversMaskedChoice)
+	require.NoError(t, err)
+	challengeResponse, err := alice.Round8DkgRound4Ot(challenge)
+	require.NoError(t, err)
+	challengeOpenings, err := bob.Round9DkgRound5Ot(challengeResponse)
+	require.NoError(t, err)
+	err = alice.Round10DkgRound6Ot(challengeOpenings)
+	require.NoError(t, err)
+
+	return alice.Output(), bob.Output()
+}
+
+func newParties(t *testing.T, curve *curves.Curve, aliceOutput *dkg.AliceOutput, bobOutput *dkg.BobOutput, chainCode []byte, index uint32) (*derive.Alice, *derive.Bob) {
+	t.Helper()
+	alice, err := derive.NewAlice(curve, aliceOutput, chainCode, index)
+	require.NoError(t, err)
+	bob, err := derive.NewBob(curve, bobOutput, chainCode, index)
+	require.NoError(t, err)
+	return alice, bob
+}
+
+func performDerive(t *testing.T, curve *curves.Curve, aliceOutput *dkg.AliceOutput, bobOutput *dkg.BobOutput, chainCode []byte, index uint32) (*derive.Alice, *derive.Bob) {
+	t.Helper()
+	alice, bob := newParties(t, curve, aliceOutput, bobOutput, chainCode, index)
+	seed, err := alice.Round1GenerateRandomSeed()
+	require.NoError(t, err)
+	round2Output, err := bob.Round2Initialize(seed)
+	require.NoError(t, err)
+	round3Output, err := alice.Round3Multiply(round2Output)
+	require.NoError(t, err)
+	proof, err := bob.Round4Verify(round3Output)
+	require.NoError(t, err)
+	require.NoError(t, alice.Round5Verify(proof))
+	return alice, bob
+}
+
+func Test_DeriveMatchesPublicDerivation(t *testing.T) {
+	t.Parallel()
+	curveInstances := []*curves.Curve{
+		curves.K256(),
+		curves.P256(),
+	}
+	for _, curve := range curveInstances {
+		boundCurve := curve
+		t.Run(fmt.Sprintf("testing derive for curve %s", boundCurve.Name), func(tt *testing.T) {
+			tt.Parallel()
+			aliceOutput, bobOutput := performDKG(tt, boundCurve)
+			chainCode := bytes.Repeat([]byte{0x42}, 32)
+
+			// m/0/5 of the shared key
+			alice, bob := performDerive(tt, boundCurve, aliceOutput, bobOutput, chainCode, 0)
+			alice, bob = performDerive(tt, boundCurve, alice.Output(), bob.Output(), alice.ChainCode(), 5)
+			require.Equal(tt, alice.ChainCode(), bob.ChainCode())
+
+			tweak0, chainCode0, err := hd.ChildTweak(boundCurve, aliceOutput.PublicKey.ToAffineCompressed(), chainCode, 0)
+			require.NoError(tt, err)
+			child0 := aliceOutput.PublicKey.Add(boundCurve.ScalarBaseMult(tweak0))
+			tweak5, chainCode5, err := hd.ChildTweak(boundCurve, child0.ToAffineCompressed(), chainCode0, 5)
+			require.NoError(tt, err)
+			child5 := child0.Add(boundCurve.ScalarBaseMult(tweak5))
+			require.Equal(tt, chainCode5, alice.ChainCode())
+			require.True(tt, child5.Equal(alice.Output().PublicKey))
+			require.True(tt, child5.Equal(bob.Output().PublicKey))
+
+			// The child shares multiply to the parent key plus the tweaks
+			parentKey := aliceOutput.SecretKeyShare.Mul(bobOutput.SecretKeyShare)
+			childKey := alice.Output().SecretKeyShare.Mul(bob.Output().SecretKeyShare)
+			require.Equal(tt, parentKey.Add(tweak0).Add(tweak5).Bytes(), childKey.Bytes())
+		})
+	}
+}
+
+func Test_CanSignWithChildKey(t *testing.T) {
+	t.Parallel()
+	curve := curves.K256()
+	aliceOutput, bobOutput := performDKG(t, curve)
+	alice, bob := performDerive(t, curve, aliceOutput, bobOutput, bytes.Repeat([]byte{7}, 32), 44)
+
+	aliceSign := sign.NewAlice(curve, sha3.New256(), alice.Output())
+	bobSign := sign.NewBob(curve, sha3.New256(), bob.Output())
+	message := []byte("A message.")
+	seed, err := aliceSign.Round1GenerateRandomSeed()
+	require.NoError(t, err)
+	round3Output, err := bobSign.Round2Initialize(seed)
+	require.NoError(t, err)
+	round4Output, err := aliceSign.Round3Sign(message, round3Output)
+	require.NoError(t, err)
+	require.NoError(t, bobSign.Round4Final(message, round4Output))
+}
+
+func Test_DeriveDetectsCheating(t *testing.T) {
+	t.Parallel()
+	curve := curves.K256()
+	aliceOutput, bobOutput := performDKG(t, curve)
+	chainCode := bytes.Repeat([]byte{1}, 32)
+
+	// Alice masks Bob's share with a wrong tweak
+	alice, bob := newParties(t, curve, aliceOutput, bobOutput, chainCode, 3)
+	seed, err := alice.Round1GenerateRandomSeed()
+	require.NoError(t, err)
+	round2Output, err := bob.Round2Initialize(seed)
+	require.NoError(t, err)
+	round3Output, err := alice.Round3Multiply(round2Output)
+	require.NoError(t, err)
+	round3Output.Mask = round3Output.Mask.Add(curve.Scalar.One())
+	_, err = bob.Round4Verify(round3Output)
+	require.Error(t, err)
+
+	// The parties disagree on the child index, so on the session id of the multiplication
+	alice, _ = newParties(t, curve, aliceOutput, bobOutput, chainCode, 3)
+	_, bob = newParties(t, curve, aliceOutput, bobOutput, chainCode, 4)
+	seed, err = alice.Round1GenerateRandomSeed()
+	require.NoError(t, err)
+	round2Output, err = bob.Round2Initialize(seed)
+	require.NoError(t, err)
+	_, err = alice.Round3Multiply(round2Output)
+	require.Error(t, err)
+
+	// Hardened children need the key
+	_, err = derive.NewAlice(curve, aliceOutput, chainCode, hd.HardenedOffset)
+	require.Error(t, err)
+	_, err = derive.NewBob(curve, bobOutput, chainCode[:31], 0)
+	require.Error(t, err)
+}
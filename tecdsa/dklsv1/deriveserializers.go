@@ -0,0 +1,130 @@
+package dklsv1
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/go-sonr/crypto/core/protocol"
+	"github.com/go-sonr/crypto/core/protocol/messages"
+	"github.com/go-sonr/crypto/ot/base/simplest"
+	"github.com/go-sonr/crypto/tecdsa/dklsv1/derive"
+	"github.com/go-sonr/crypto/zkp/schnorr"
+)
+
+const (
+	deriveRound1Type = "dkls18-derive/round1"
+	deriveRound2Type = "dkls18-derive/round2"
+	deriveRound3Type = "dkls18-derive/round3"
+	deriveRound4Type = "dkls18-derive/round4"
+)
+
+func newDeriveProtocolMessage(payload []byte, round string, version uint) *protocol.Message {
+	return &protocol.Message{
+		Protocol: protocol.Dkls18Derive,
+		Version:  version,
+		Payloads: map[string][]byte{payloadKey: payload},
+		Metadata: map[string]string{"round": round},
+	}
+}
+
+func encodeDeriveRound1Output(seed [simplest.DigestSize]byte, version uint) (*protocol.Message, error) {
+	if version != protocol.Version1 {
+		return nil, errors.New("only version 1 is supported")
+	}
+	payload, err := encodeDigest(deriveRound1Type, seed)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return newDeriveProtocolMessage(payload, "1", version), nil
+}
+
+func decodeDeriveRound2Input(m *protocol.Message) ([simplest.DigestSize]byte, error) {
+	return decodeDigest(m, protocol.Dkls18Derive, "1", deriveRound1Type)
+}
+
+func encodeDeriveRound2Output(output *derive.Round2Output, version uint) (*protocol.Message, error) {
+	if version != protocol.Version1 {
+		return nil, errors.New("only version 1 is supported")
+	}
+	enc := messages.NewEncoder(deriveRound2Type, nil)
+	enc.WriteBytes(output.Seed[:])
+	if err := writeKosRound1Output(enc, output.KosRound1Output); err != nil {
+		return nil, err
+	}
+	payload, err := enc.Finish()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return newDeriveProtocolMessage(payload, "2", version), nil
+}
+
+func decodeDeriveRound3Input(m *protocol.Message) (*derive.Round2Output, error) {
+	dec, err := decodePayload(m, protocol.Dkls18Derive, "2", deriveRound2Type)
+	if err != nil {
+		return nil, err
+	}
+	seed, err := readFixed(dec, simplest.DigestSize)
+	if err != nil {
+		return nil, err
+	}
+	decoded := new(derive.Round2Output)
+	if decoded.KosRound1Output, err = readKosRound1Output(dec); err != nil {
+		return nil, err
+	}
+	if err = dec.Finish(); err != nil {
+		return nil, err
+	}
+	copy(decoded.Seed[:], seed)
+	return decoded, nil
+}
+
+func encodeDeriveRound3Output(output *derive.Round3Output, version uint) (*protocol.Message, error) {
+	if version != protocol.Version1 {
+		return nil, errors.New("only version 1 is supported")
+	}
+	curve, err := scalarCurve(output.Mask)
+	if err != nil {
+		return nil, err
+	}
+	enc := messages.NewEncoder(deriveRound3Type, curve)
+	if err = writeMultiplyRound2Output(enc, output.MultiplyRound2Output); err != nil {
+		return nil, err
+	}
+	enc.WriteScalar(output.Mask)
+	writeProof(enc, output.Proof)
+	payload, err := enc.Finish()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return newDeriveProtocolMessage(payload, "3", version), nil
+}
+
+func decodeDeriveRound4Input(m *protocol.Message) (*derive.Round3Output, error) {
+	dec, err := decodePayload(m, protocol.Dkls18Derive, "3", deriveRound3Type)
+	if err != nil {
+		return nil, err
+	}
+	decoded := &derive.Round3Output{
+		MultiplyRound2Output: readMultiplyRound2Output(dec),
+		Mask:                 dec.ReadScalar(),
+		Proof:                readProof(dec),
+	}
+	if err = dec.Finish(); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+func encodeDeriveRound4Output(proof *schnorr.Proof, version uint) (*protocol.Message, error) {
+	if version != protocol.Version1 {
+		return nil, errors.New("only version 1 is supported")
+	}
+	payload, err := encodeProof(deriveRound4Type, proof)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return newDeriveProtocolMessage(payload, "4", version), nil
+}
+
+func decodeDeriveRound5Input(m *protocol.Message) (*schnorr.Proof, error) {
+	return decodeProof(m, protocol.Dkls18Derive, "4", deriveRound4Type)
+}
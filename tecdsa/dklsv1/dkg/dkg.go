@@ -12,10 +12,10 @@ package dkg
 import (
 	"crypto/rand"
 
-	"github.com/gtank/merlin"
 	"github.com/pkg/errors"
 
 	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
 	"github.com/go-sonr/crypto/ot/base/simplest"
 	"github.com/go-sonr/crypto/ot/extension/kos"
 	"github.com/go-sonr/crypto/zkp/schnorr"
@@ -75,7 +75,7 @@ type Alice struct {
 
 	curve *curves.Curve
 
-	transcript *merlin.Transcript
+	transcript *transcript.Transcript
 }
 
 // Bob struct encoding Bob's state during one execution of the overall signing algorithm.
@@ -100,7 +100,7 @@ type Bob struct {
 
 	curve *curves.Curve
 
-	transcript *merlin.Transcript
+	transcript *transcript.Transcript
 }
 
 // Round2Output contains the output of the 2nd round of DKG.
@@ -116,7 +116,7 @@ type Round2Output struct {
 func NewAlice(curve *curves.Curve) *Alice {
 	return &Alice{
 		curve:      curve,
-		transcript: merlin.NewTranscript("Coinbase_DKLs_DKG"),
+		transcript: transcript.NewRecorded("Coinbase_DKLs_DKG"),
 	}
 }
 
@@ -125,7 +125,7 @@ func NewAlice(curve *curves.Curve) *Alice {
 func NewBob(curve *curves.Curve) *Bob {
 	return &Bob{
 		curve:      curve,
-		transcript: merlin.NewTranscript("Coinbase_DKLs_DKG"),
+		transcript: transcript.NewRecorded("Coinbase_DKLs_DKG"),
 	}
 }
 
@@ -202,16 +202,20 @@ func (bob *Bob) Round3SchnorrProve(round2Output *Round2Output) (*schnorr.Proof,
 
 // Round4VerifyAndReveal step 4 of protocol 2 on page 7.
 func (alice *Alice) Round4VerifyAndReveal(proof *schnorr.Proof) (*schnorr.Proof, error) {
-	var err error
-	uniqueSessionId := [simplest.DigestSize]byte{}
-	copy(uniqueSessionId[:], alice.transcript.ExtractBytes([]byte("salt for bob schnorr"), simplest.DigestSize))
-	if err = schnorr.Verify(proof, alice.curve, nil, uniqueSessionId[:]); err != nil {
+	if err := CheckBobProof(alice.curve, alice.transcript, proof); err != nil {
 		return nil, errors.Wrap(err, "alice's verification of Bob's schnorr proof failed in DKG round 3")
 	}
 	alice.publicKey = proof.Statement.Mul(alice.secretKeyShare)
 	return alice.proof, nil
 }
 
+// CheckBobProof verifies the schnorr proof of Bob against tr, the transcript of Alice before round 4. Alice runs it in
+// round 4, and a third party on the evidence of an abort.
+func CheckBobProof(curve *curves.Curve, tr *transcript.Transcript, proof *schnorr.Proof) error {
+	uniqueSessionId := tr.ExtractBytes([]byte("salt for bob schnorr"), simplest.DigestSize)
+	return schnorr.Verify(proof, curve, nil, uniqueSessionId)
+}
+
 // Round5DecommitmentAndStartOt step 5 of protocol 2 on page 7.
 func (bob *Bob) Round5DecommitmentAndStartOt(proof *schnorr.Proof) (*schnorr.Proof, error) {
 	var err error
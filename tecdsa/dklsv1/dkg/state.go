@@ -0,0 +1,223 @@
+package dkg
+
+import (
+	"bytes"
+	"encoding/gob"
+
+	"github.com/pkg/errors"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
+	"github.com/go-sonr/crypto/ot/base/simplest"
+	"github.com/go-sonr/crypto/zkp/schnorr"
+)
+
+// aliceState is the serialized state of Alice between rounds. Nested states
+// are encoded with their own MarshalBinary, and fields are empty until set
+// by their round.
+type aliceState struct {
+	Curve          string
+	Prover         []byte
+	Proof          [3][]byte
+	Receiver       []byte
+	SecretKeyShare []byte
+	PublicKey      []byte
+	Transcript     []byte
+}
+
+// bobState is the serialized state of Bob between rounds
+type bobState struct {
+	Curve           string
+	Prover          []byte
+	Sender          []byte
+	SecretKeyShare  []byte
+	PublicKey       []byte
+	AliceCommitment schnorr.Commitment
+	AliceSalt       [simplest.DigestSize]byte
+	Transcript      []byte
+}
+
+// MarshalBinary serializes the state of Alice between rounds, including her
+// secret key share. The result must be protected as the key share itself.
+func (alice *Alice) MarshalBinary() ([]byte, error) {
+	var err error
+	state := &aliceState{Curve: alice.curve.Name}
+	if state.Transcript, err = alice.transcript.MarshalBinary(); err != nil {
+		return nil, err
+	}
+	if alice.prover != nil {
+		if state.Prover, err = alice.prover.MarshalBinary(); err != nil {
+			return nil, err
+		}
+	}
+	if alice.proof != nil {
+		state.Proof = [3][]byte{alice.proof.C.Bytes(), alice.proof.S.Bytes(), alice.proof.Statement.ToAffineCompressed()}
+	}
+	if alice.receiver != nil {
+		if state.Receiver, err = alice.receiver.MarshalBinary(); err != nil {
+			return nil, err
+		}
+	}
+	if alice.secretKeyShare != nil {
+		state.SecretKeyShare = alice.secretKeyShare.Bytes()
+	}
+	if alice.publicKey != nil {
+		state.PublicKey = alice.publicKey.ToAffineCompressed()
+	}
+	return encodeState(state)
+}
+
+// UnmarshalBinary restores Alice serialized by MarshalBinary
+func (alice *Alice) UnmarshalBinary(data []byte) error {
+	state := new(aliceState)
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(state); err != nil {
+		return errors.Wrap(err, "decoding alice dkg state")
+	}
+	curve := curves.GetCurveByName(state.Curve)
+	if curve == nil {
+		return errors.Errorf("unknown curve %s", state.Curve)
+	}
+	var err error
+	restored := &Alice{curve: curve, transcript: new(transcript.Transcript)}
+	if err = restored.transcript.UnmarshalBinary(state.Transcript); err != nil {
+		return errors.Wrap(err, "decoding alice dkg transcript")
+	}
+	if state.Prover != nil {
+		restored.prover = new(schnorr.Prover)
+		if err = restored.prover.UnmarshalBinary(state.Prover); err != nil {
+			return err
+		}
+	}
+	if state.Proof[0] != nil {
+		restored.proof = new(schnorr.Proof)
+		if restored.proof.C, err = curve.Scalar.SetBytes(state.Proof[0]); err != nil {
+			return errors.Wrap(err, "decoding alice proof")
+		}
+		if restored.proof.S, err = curve.Scalar.SetBytes(state.Proof[1]); err != nil {
+			return errors.Wrap(err, "decoding alice proof")
+		}
+		if restored.proof.Statement, err = curve.Point.FromAffineCompressed(state.Proof[2]); err != nil {
+			return errors.Wrap(err, "decoding alice proof")
+		}
+	}
+	if state.Receiver != nil {
+		restored.receiver = new(simplest.Receiver)
+		if err = restored.receiver.UnmarshalBinary(state.Receiver); err != nil {
+			return err
+		}
+	}
+	if restored.secretKeyShare, restored.publicKey, err = decodeKeys(curve, state.SecretKeyShare, state.PublicKey); err != nil {
+		return err
+	}
+	*alice = *restored
+	return nil
+}
+
+// MarshalBinary serializes the state of Bob between rounds, including his
+// secret key share. The result must be protected as the key share itself.
+func (bob *Bob) MarshalBinary() ([]byte, error) {
+	var err error
+	state := &bobState{
+		Curve:           bob.curve.Name,
+		AliceCommitment: bob.aliceCommitment,
+		AliceSalt:       bob.aliceSalt,
+	}
+	if state.Transcript, err = bob.transcript.MarshalBinary(); err != nil {
+		return nil, err
+	}
+	if bob.prover != nil {
+		if state.Prover, err = bob.prover.MarshalBinary(); err != nil {
+			return nil, err
+		}
+	}
+	if bob.sender != nil {
+		if state.Sender, err = bob.sender.MarshalBinary(); err != nil {
+			return nil, err
+		}
+	}
+	if bob.secretKeyShare != nil {
+		state.SecretKeyShare = bob.secretKeyShare.Bytes()
+	}
+	if bob.publicKey != nil {
+		state.PublicKey = bob.publicKey.ToAffineCompressed()
+	}
+	return encodeState(state)
+}
+
+// UnmarshalBinary restores Bob serialized by MarshalBinary
+func (bob *Bob) UnmarshalBinary(data []byte) error {
+	state := new(bobState)
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(state); err != nil {
+		return errors.Wrap(err, "decoding bob dkg state")
+	}
+	curve := curves.GetCurveByName(state.Curve)
+	if curve == nil {
+		return errors.Errorf("unknown curve %s", state.Curve)
+	}
+	var err error
+	restored := &Bob{
+		curve:           curve,
+		aliceCommitment: state.AliceCommitment,
+		aliceSalt:       state.AliceSalt,
+		transcript:      new(transcript.Transcript),
+	}
+	if err = restored.transcript.UnmarshalBinary(state.Transcript); err != nil {
+		return errors.Wrap(err, "decoding bob dkg transcript")
+	}
+	if state.Prover != nil {
+		restored.prover = new(schnorr.Prover)
+		if err = restored.prover.UnmarshalBinary(state.Prover); err != nil {
+			return err
+		}
+	}
+	if state.Sender != nil {
+		restored.sender = new(simplest.Sender)
+		if err = restored.sender.UnmarshalBinary(state.Sender); err != nil {
+			return err
+		}
+	}
+	if restored.secretKeyShare, restored.publicKey, err = decodeKeys(curve, state.SecretKeyShare, state.PublicKey); err != nil {
+		return err
+	}
+	*bob = *restored
+	return nil
+}
+
+func encodeState(state interface{}) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := gob.NewEncoder(buf).Encode(state); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeKeys decodes a secret key share and joint public key, either of
+// which may not be set yet
+func decodeKeys(curve *curves.Curve, secretKeyShare, publicKey []byte) (curves.Scalar, curves.Point, error) {
+	var (
+		sk  curves.Scalar
+		pk  curves.Point
+		err error
+	)
+	if secretKeyShare != nil {
+		if sk, err = curve.Scalar.SetBytes(secretKeyShare); err != nil {
+			return nil, nil, errors.Wrap(err, "decoding secret key share")
+		}
+	}
+	if publicKey != nil {
+		if pk, err = curve.Point.FromAffineCompressed(publicKey); err != nil {
+			return nil, nil, errors.Wrap(err, "decoding public key")
+		}
+	}
+	return sk, pk, nil
+}
+
+// MarshalTranscript serializes the transcript of Alice, which holds only public messages, as evidence of an abort
+func (alice *Alice) MarshalTranscript() ([]byte, error) {
+	return alice.transcript.MarshalBinary()
+}
+
+// MarshalTranscript serializes the transcript of Bob, which holds only public messages, as evidence of an abort
+func (bob *Bob) MarshalTranscript() ([]byte, error) {
+	return bob.transcript.MarshalBinary()
+}
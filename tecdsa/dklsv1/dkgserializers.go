@@ -8,12 +8,25 @@ import (
 
 	"github.com/go-sonr/crypto/core/curves"
 	"github.com/go-sonr/crypto/core/protocol"
+	"github.com/go-sonr/crypto/core/protocol/messages"
 	"github.com/go-sonr/crypto/ot/base/simplest"
 	"github.com/go-sonr/crypto/tecdsa/dklsv1/dkg"
 	"github.com/go-sonr/crypto/zkp/schnorr"
 )
 
-const payloadKey = "direct"
+const (
+	dkgRound1Type     = "dkls18-dkg/round1"
+	dkgRound2Type     = "dkls18-dkg/round2"
+	dkgRound3Type     = "dkls18-dkg/round3"
+	dkgRound4Type     = "dkls18-dkg/round4"
+	dkgRound5Type     = "dkls18-dkg/round5"
+	dkgRound6Type     = "dkls18-dkg/round6"
+	dkgRound7Type     = "dkls18-dkg/round7"
+	dkgRound8Type     = "dkls18-dkg/round8"
+	dkgRound9Type     = "dkls18-dkg/round9"
+	aliceKeyShareType = "dkls18/alice-key-share"
+	bobKeyShareType   = "dkls18/bob-key-share"
+)
 
 func newDkgProtocolMessage(payload []byte, round string, version uint) *protocol.Message {
 	return &protocol.Message{
@@ -24,6 +37,7 @@ func newDkgProtocolMessage(payload []byte, round string, version uint) *protocol
 	}
 }
 
+// registerTypes registers the curves of the key shares that earlier releases encoded with gob
 func registerTypes() {
 	gob.Register(&curves.ScalarK256{})
 	gob.Register(&curves.PointK256{})
@@ -35,50 +49,45 @@ func encodeDkgRound1Output(commitment [32]byte, version uint) (*protocol.Message
 	if version != protocol.Version1 {
 		return nil, errors.New("only version 1 is supported")
 	}
-	registerTypes()
-	buf := bytes.NewBuffer([]byte{})
-	enc := gob.NewEncoder(buf)
-	if err := enc.Encode(&commitment); err != nil {
+	payload, err := encodeDigest(dkgRound1Type, commitment)
+	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return newDkgProtocolMessage(buf.Bytes(), "1", version), nil
+	return newDkgProtocolMessage(payload, "1", version), nil
 }
 
 func decodeDkgRound2Input(m *protocol.Message) ([32]byte, error) {
-	if m.Version != protocol.Version1 {
-		return [32]byte{}, errors.New("only version 1 is supported")
-	}
-	buf := bytes.NewBuffer(m.Payloads[payloadKey])
-	dec := gob.NewDecoder(buf)
-	decoded := [32]byte{}
-	if err := dec.Decode(&decoded); err != nil {
-		return [32]byte{}, errors.WithStack(err)
-	}
-	return decoded, nil
+	return decodeDigest(m, protocol.Dkls18Dkg, "1", dkgRound1Type)
 }
 
 func encodeDkgRound2Output(output *dkg.Round2Output, version uint) (*protocol.Message, error) {
 	if version != protocol.Version1 {
 		return nil, errors.New("only version 1 is supported")
 	}
-	buf := bytes.NewBuffer([]byte{})
-	enc := gob.NewEncoder(buf)
-	if err := enc.Encode(output); err != nil {
+	enc := messages.NewEncoder(dkgRound2Type, nil)
+	enc.WriteBytes(output.Seed[:])
+	enc.WriteBytes(output.Commitment)
+	payload, err := enc.Finish()
+	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return newDkgProtocolMessage(buf.Bytes(), "2", version), nil
+	return newDkgProtocolMessage(payload, "2", version), nil
 }
 
 func decodeDkgRound3Input(m *protocol.Message) (*dkg.Round2Output, error) {
-	if m.Version != protocol.Version1 {
-		return nil, errors.New("only version 1 is supported")
+	dec, err := decodePayload(m, protocol.Dkls18Dkg, "2", dkgRound2Type)
+	if err != nil {
+		return nil, err
 	}
-	buf := bytes.NewBuffer(m.Payloads[payloadKey])
-	dec := gob.NewDecoder(buf)
-	decoded := new(dkg.Round2Output)
-	if err := dec.Decode(decoded); err != nil {
-		return nil, errors.WithStack(err)
+	seed, err := readFixed(dec, simplest.DigestSize)
+	if err != nil {
+		return nil, err
+	}
+	decoded := &dkg.Round2Output{Commitment: dec.ReadBytes()}
+	if err = dec.Finish(); err != nil {
+		return nil, err
 	}
+	copy(decoded.Seed[:], seed)
 	return decoded, nil
 }
 
@@ -86,175 +95,255 @@ func encodeDkgRound3Output(proof *schnorr.Proof, version uint) (*protocol.Messag
 	if version != protocol.Version1 {
 		return nil, errors.New("only version 1 is supported")
 	}
-	buf := bytes.NewBuffer([]byte{})
-	enc := gob.NewEncoder(buf)
-	if err := enc.Encode(proof); err != nil {
+	payload, err := encodeProof(dkgRound3Type, proof)
+	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return newDkgProtocolMessage(buf.Bytes(), "3", version), nil
+	return newDkgProtocolMessage(payload, "3", version), nil
 }
 
 func decodeDkgRound4Input(m *protocol.Message) (*schnorr.Proof, error) {
-	if m.Version != protocol.Version1 {
-		return nil, errors.New("only version 1 is supported")
-	}
-	buf := bytes.NewBuffer(m.Payloads[payloadKey])
-	dec := gob.NewDecoder(buf)
-	decoded := new(schnorr.Proof)
-	if err := dec.Decode(decoded); err != nil {
-		return nil, errors.WithStack(err)
-	}
-	return decoded, nil
+	return decodeProof(m, protocol.Dkls18Dkg, "3", dkgRound3Type)
 }
 
 func encodeDkgRound4Output(proof *schnorr.Proof, version uint) (*protocol.Message, error) {
 	if version != protocol.Version1 {
 		return nil, errors.New("only version 1 is supported")
 	}
-	buf := bytes.NewBuffer([]byte{})
-	enc := gob.NewEncoder(buf)
-	if err := enc.Encode(proof); err != nil {
+	payload, err := encodeProof(dkgRound4Type, proof)
+	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return newDkgProtocolMessage(buf.Bytes(), "4", version), nil
+	return newDkgProtocolMessage(payload, "4", version), nil
 }
 
 func decodeDkgRound5Input(m *protocol.Message) (*schnorr.Proof, error) {
-	if m.Version != protocol.Version1 {
-		return nil, errors.New("only version 1 is supported")
-	}
-	buf := bytes.NewBuffer(m.Payloads[payloadKey])
-	dec := gob.NewDecoder(buf)
-	decoded := new(schnorr.Proof)
-	if err := dec.Decode(decoded); err != nil {
-		return nil, errors.WithStack(err)
-	}
-	return decoded, nil
+	return decodeProof(m, protocol.Dkls18Dkg, "4", dkgRound4Type)
 }
 
 func encodeDkgRound5Output(proof *schnorr.Proof, version uint) (*protocol.Message, error) {
 	if version != protocol.Version1 {
 		return nil, errors.New("only version 1 is supported")
 	}
-	buf := bytes.NewBuffer([]byte{})
-	enc := gob.NewEncoder(buf)
-	if err := enc.Encode(proof); err != nil {
+	payload, err := encodeProof(dkgRound5Type, proof)
+	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return newDkgProtocolMessage(buf.Bytes(), "5", version), nil
+	return newDkgProtocolMessage(payload, "5", version), nil
 }
 
 func decodeDkgRound6Input(m *protocol.Message) (*schnorr.Proof, error) {
-	if m.Version != protocol.Version1 {
-		return nil, errors.New("only version 1 is supported")
-	}
-	buf := bytes.NewBuffer(m.Payloads[payloadKey])
-	dec := gob.NewDecoder(buf)
-	decoded := new(schnorr.Proof)
-	if err := dec.Decode(decoded); err != nil {
-		return nil, errors.WithStack(err)
-	}
-	return decoded, nil
+	return decodeProof(m, protocol.Dkls18Dkg, "5", dkgRound5Type)
 }
 
 func encodeDkgRound6Output(choices []simplest.ReceiversMaskedChoices, version uint) (*protocol.Message, error) {
 	if version != protocol.Version1 {
 		return nil, errors.New("only version 1 is supported")
 	}
-	buf := bytes.NewBuffer([]byte{})
-	enc := gob.NewEncoder(buf)
-	if err := enc.Encode(choices); err != nil {
+	enc := messages.NewEncoder(dkgRound6Type, nil)
+	writeChoices(enc, choices)
+	payload, err := enc.Finish()
+	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return newDkgProtocolMessage(buf.Bytes(), "6", version), nil
+	return newDkgProtocolMessage(payload, "6", version), nil
 }
 
 func decodeDkgRound7Input(m *protocol.Message) ([]simplest.ReceiversMaskedChoices, error) {
-	if m.Version != protocol.Version1 {
-		return nil, errors.New("only version 1 is supported")
+	dec, err := decodePayload(m, protocol.Dkls18Dkg, "6", dkgRound6Type)
+	if err != nil {
+		return nil, err
 	}
-	buf := bytes.NewBuffer(m.Payloads[payloadKey])
-	dec := gob.NewDecoder(buf)
-	decoded := []simplest.ReceiversMaskedChoices{}
-	if err := dec.Decode(&decoded); err != nil {
-		return nil, errors.WithStack(err)
+	choices := readChoices(dec)
+	if err = dec.Finish(); err != nil {
+		return nil, err
 	}
-	return decoded, nil
+	return choices, nil
 }
 
 func encodeDkgRound7Output(challenge []simplest.OtChallenge, version uint) (*protocol.Message, error) {
 	if version != protocol.Version1 {
 		return nil, errors.New("only version 1 is supported")
 	}
-	buf := bytes.NewBuffer([]byte{})
-	enc := gob.NewEncoder(buf)
-	if err := enc.Encode(challenge); err != nil {
+	enc := messages.NewEncoder(dkgRound7Type, nil)
+	writeChallenges(enc, challenge)
+	payload, err := enc.Finish()
+	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return newDkgProtocolMessage(buf.Bytes(), "7", version), nil
+	return newDkgProtocolMessage(payload, "7", version), nil
 }
 
 func decodeDkgRound8Input(m *protocol.Message) ([]simplest.OtChallenge, error) {
-	if m.Version != protocol.Version1 {
-		return nil, errors.New("only version 1 is supported")
+	dec, err := decodePayload(m, protocol.Dkls18Dkg, "7", dkgRound7Type)
+	if err != nil {
+		return nil, err
 	}
-	buf := bytes.NewBuffer(m.Payloads[payloadKey])
-	dec := gob.NewDecoder(buf)
-	decoded := []simplest.OtChallenge{}
-	if err := dec.Decode(&decoded); err != nil {
-		return nil, errors.WithStack(err)
+	challenges, err := readChallenges(dec)
+	if err != nil {
+		return nil, err
 	}
-	return decoded, nil
+	if err = dec.Finish(); err != nil {
+		return nil, err
+	}
+	return challenges, nil
 }
 
 func encodeDkgRound8Output(responses []simplest.OtChallengeResponse, version uint) (*protocol.Message, error) {
 	if version != protocol.Version1 {
 		return nil, errors.New("only version 1 is supported")
 	}
-	buf := bytes.NewBuffer([]byte{})
-	enc := gob.NewEncoder(buf)
-	if err := enc.Encode(responses); err != nil {
+	enc := messages.NewEncoder(dkgRound8Type, nil)
+	writeChallenges(enc, responses)
+	payload, err := enc.Finish()
+	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return newDkgProtocolMessage(buf.Bytes(), "8", version), nil
+	return newDkgProtocolMessage(payload, "8", version), nil
 }
 
 func decodeDkgRound9Input(m *protocol.Message) ([]simplest.OtChallengeResponse, error) {
-	if m.Version != protocol.Version1 {
-		return nil, errors.New("only version 1 is supported")
+	dec, err := decodePayload(m, protocol.Dkls18Dkg, "8", dkgRound8Type)
+	if err != nil {
+		return nil, err
 	}
-	buf := bytes.NewBuffer(m.Payloads[payloadKey])
-	dec := gob.NewDecoder(buf)
-	decoded := []simplest.OtChallengeResponse{}
-	if err := dec.Decode(&decoded); err != nil {
-		return nil, errors.WithStack(err)
+	responses, err := readChallenges(dec)
+	if err != nil {
+		return nil, err
 	}
-	return decoded, nil
+	if err = dec.Finish(); err != nil {
+		return nil, err
+	}
+	return responses, nil
 }
 
 func encodeDkgRound9Output(opening []simplest.ChallengeOpening, version uint) (*protocol.Message, error) {
 	if version != protocol.Version1 {
 		return nil, errors.New("only version 1 is supported")
 	}
-	buf := bytes.NewBuffer([]byte{})
-	enc := gob.NewEncoder(buf)
-	if err := enc.Encode(opening); err != nil {
+	enc := messages.NewEncoder(dkgRound9Type, nil)
+	writeDigestPairs(enc, opening)
+	payload, err := enc.Finish()
+	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return newDkgProtocolMessage(buf.Bytes(), "9", version), nil
+	return newDkgProtocolMessage(payload, "9", version), nil
 }
 
 func decodeDkgRound10Input(m *protocol.Message) ([]simplest.ChallengeOpening, error) {
-	if m.Version != protocol.Version1 {
-		return nil, errors.New("only version 1 is supported")
+	dec, err := decodePayload(m, protocol.Dkls18Dkg, "9", dkgRound9Type)
+	if err != nil {
+		return nil, err
 	}
-	buf := bytes.NewBuffer(m.Payloads[payloadKey])
-	dec := gob.NewDecoder(buf)
-	decoded := []simplest.ChallengeOpening{}
-	if err := dec.Decode(&decoded); err != nil {
-		return nil, errors.WithStack(err)
+	openings, err := readDigestPairs(dec)
+	if err != nil {
+		return nil, err
 	}
-	return decoded, nil
+	if err = dec.Finish(); err != nil {
+		return nil, err
+	}
+	return openings, nil
+}
+
+// encodeAliceKeyShare encodes the key share of Alice after a DKG, refresh or reshare. Her choice bits are encoded
+// packed.
+func encodeAliceKeyShare(result *dkg.AliceOutput) ([]byte, error) {
+	if result == nil || result.SeedOtResult == nil {
+		return nil, errors.New("missing alice key share")
+	}
+	curve, err := scalarCurve(result.SecretKeyShare)
+	if err != nil {
+		return nil, err
+	}
+	enc := messages.NewEncoder(aliceKeyShareType, curve)
+	enc.WritePoint(result.PublicKey)
+	enc.WriteScalar(result.SecretKeyShare)
+	enc.WriteBytes(result.SeedOtResult.PackedRandomChoiceBits)
+	keys := make([][]byte, len(result.SeedOtResult.OneTimePadDecryptionKey))
+	for i := range result.SeedOtResult.OneTimePadDecryptionKey {
+		keys[i] = result.SeedOtResult.OneTimePadDecryptionKey[i][:]
+	}
+	writeByteStrings(enc, keys)
+	return enc.Finish()
+}
+
+// decodeAliceKeyShare decodes the key share of Alice. Key shares that earlier releases encoded with gob still decode.
+func decodeAliceKeyShare(payload []byte) (*dkg.AliceOutput, error) {
+	dec, err := messages.NewDecoder(payload, aliceKeyShareType)
+	if err != nil {
+		legacy := new(dkg.AliceOutput)
+		if decodeLegacyKeyShare(payload, &legacy) == nil {
+			return legacy, nil
+		}
+		return nil, err
+	}
+	result := &dkg.AliceOutput{
+		PublicKey:      dec.ReadPoint(),
+		SecretKeyShare: dec.ReadScalar(),
+		SeedOtResult:   &simplest.ReceiverOutput{PackedRandomChoiceBits: dec.ReadBytes()},
+	}
+	keys, err := readDigests(dec, simplest.DigestSize)
+	if err != nil {
+		return nil, err
+	}
+	if err = dec.Finish(); err != nil {
+		return nil, err
+	}
+	packed := result.SeedOtResult.PackedRandomChoiceBits
+	if len(keys) != 8*len(packed) {
+		return nil, errors.Errorf("%d seed OT keys for %d choice bits", len(keys), 8*len(packed))
+	}
+	result.SeedOtResult.RandomChoiceBits = make([]int, len(keys))
+	result.SeedOtResult.OneTimePadDecryptionKey = make([]simplest.OneTimePadDecryptionKey, len(keys))
+	for i := range keys {
+		result.SeedOtResult.RandomChoiceBits[i] = int(simplest.ExtractBitFromByteVector(packed, i))
+		copy(result.SeedOtResult.OneTimePadDecryptionKey[i][:], keys[i])
+	}
+	return result, nil
+}
+
+// encodeBobKeyShare encodes the key share of Bob after a DKG, refresh or reshare.
+func encodeBobKeyShare(result *dkg.BobOutput) ([]byte, error) {
+	if result == nil || result.SeedOtResult == nil {
+		return nil, errors.New("missing bob key share")
+	}
+	curve, err := scalarCurve(result.SecretKeyShare)
+	if err != nil {
+		return nil, err
+	}
+	enc := messages.NewEncoder(bobKeyShareType, curve)
+	enc.WritePoint(result.PublicKey)
+	enc.WriteScalar(result.SecretKeyShare)
+	writeDigestPairs(enc, result.SeedOtResult.OneTimePadEncryptionKeys)
+	return enc.Finish()
+}
+
+// decodeBobKeyShare decodes the key share of Bob. Key shares that earlier releases encoded with gob still decode.
+func decodeBobKeyShare(payload []byte) (*dkg.BobOutput, error) {
+	dec, err := messages.NewDecoder(payload, bobKeyShareType)
+	if err != nil {
+		legacy := new(dkg.BobOutput)
+		if decodeLegacyKeyShare(payload, &legacy) == nil {
+			return legacy, nil
+		}
+		return nil, err
+	}
+	result := &dkg.BobOutput{PublicKey: dec.ReadPoint(), SecretKeyShare: dec.ReadScalar()}
+	keys, err := readDigestPairs(dec)
+	if err != nil {
+		return nil, err
+	}
+	if err = dec.Finish(); err != nil {
+		return nil, err
+	}
+	result.SeedOtResult = &simplest.SenderOutput{OneTimePadEncryptionKeys: keys}
+	return result, nil
+}
+
+// decodeLegacyKeyShare decodes a key share that an earlier release encoded with gob
+func decodeLegacyKeyShare(payload []byte, value interface{}) error {
+	registerTypes()
+	return gob.NewDecoder(bytes.NewBuffer(payload)).Decode(value)
 }
 
 // EncodeAliceDkgOutput serializes Alice DKG output based on the protocol version.
@@ -262,13 +351,11 @@ func EncodeAliceDkgOutput(result *dkg.AliceOutput, version uint) (*protocol.Mess
 	if version != protocol.Version1 {
 		return nil, errors.New("only version 1 is supported")
 	}
-	registerTypes()
-	buf := bytes.NewBuffer([]byte{})
-	enc := gob.NewEncoder(buf)
-	if err := enc.Encode(result); err != nil {
+	payload, err := encodeAliceKeyShare(result)
+	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return newDkgProtocolMessage(buf.Bytes(), "alice-output", version), nil
+	return newDkgProtocolMessage(payload, "alice-output", version), nil
 }
 
 // DecodeAliceDkgResult deserializes Alice DKG output.
@@ -276,11 +363,8 @@ func DecodeAliceDkgResult(m *protocol.Message) (*dkg.AliceOutput, error) {
 	if m.Version != protocol.Version1 {
 		return nil, errors.New("only version 1 is supported")
 	}
-	registerTypes()
-	buf := bytes.NewBuffer(m.Payloads[payloadKey])
-	dec := gob.NewDecoder(buf)
-	decoded := new(dkg.AliceOutput)
-	if err := dec.Decode(&decoded); err != nil {
+	decoded, err := decodeAliceKeyShare(m.Payloads[payloadKey])
+	if err != nil {
 		return nil, errors.WithStack(err)
 	}
 	return decoded, nil
@@ -291,13 +375,11 @@ func EncodeBobDkgOutput(result *dkg.BobOutput, version uint) (*protocol.Message,
 	if version != protocol.Version1 {
 		return nil, errors.New("only version 1 is supported")
 	}
-	registerTypes()
-	buf := bytes.NewBuffer([]byte{})
-	enc := gob.NewEncoder(buf)
-	if err := enc.Encode(result); err != nil {
+	payload, err := encodeBobKeyShare(result)
+	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return newDkgProtocolMessage(buf.Bytes(), "bob-output", version), nil
+	return newDkgProtocolMessage(payload, "bob-output", version), nil
 }
 
 // DecodeBobDkgResult deserializes Bob DKG output.
@@ -305,10 +387,8 @@ func DecodeBobDkgResult(m *protocol.Message) (*dkg.BobOutput, error) {
 	if m.Version != protocol.Version1 {
 		return nil, errors.New("only version 1 is supported")
 	}
-	buf := bytes.NewBuffer(m.Payloads[payloadKey])
-	dec := gob.NewDecoder(buf)
-	decoded := new(dkg.BobOutput)
-	if err := dec.Decode(&decoded); err != nil {
+	decoded, err := decodeBobKeyShare(m.Payloads[payloadKey])
+	if err != nil {
 		return nil, errors.WithStack(err)
 	}
 	return decoded, nil
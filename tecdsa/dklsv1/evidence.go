@@ -0,0 +1,142 @@
+package dklsv1
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/protocol"
+	"github.com/go-sonr/crypto/core/transcript"
+	"github.com/go-sonr/crypto/tecdsa/dklsv1/dkg"
+	"github.com/go-sonr/crypto/tecdsa/dklsv1/refresh"
+)
+
+// Evidence is what a party held when it rejected a message of the counterparty. It convinces a third party only when
+// the message is authenticated by its sender, for example signed by the transport.
+type Evidence struct {
+	// Message is the rejected message.
+	Message *protocol.Message
+	// Transcript is the public transcript of the aborting party before the step, from which the session ids of the
+	// proofs it checks derive. It is empty for signing, presigning and derivation, whose transcripts are not recorded.
+	Transcript []byte
+}
+
+// ErrPrivateCheck is returned by VerifyEvidence when the rejected message decodes and passes the public checks of its
+// step. The check that failed depends on the secret state of the aborting party, such as the seed OT, the
+// multiplications or the signature, and a third party cannot rerun it.
+var ErrPrivateCheck = errors.New("the failed check depends on the secret state of the aborting party")
+
+// evidenceCheck decodes a rejected message and reruns the public checks of the step that received it. fault is the
+// failed check that shows the fault of the sender, err a failure to rerun the checks.
+type evidenceCheck func(curve *curves.Curve, m *protocol.Message, tr *transcript.Transcript) (fault, err error)
+
+// decodes is the check of the steps whose public check is decoding the message
+func decodes[T any](decode func(*protocol.Message) (T, error)) evidenceCheck {
+	return func(_ *curves.Curve, m *protocol.Message, _ *transcript.Transcript) (error, error) {
+		_, fault := decode(m)
+		return fault, nil
+	}
+}
+
+// evidenceChecks are the checks of the rejected messages by protocol and round
+var evidenceChecks = map[string]map[string]evidenceCheck{
+	protocol.Dkls18Dkg: {
+		"1": decodes(decodeDkgRound2Input),
+		"2": decodes(decodeDkgRound3Input),
+		"3": func(curve *curves.Curve, m *protocol.Message, tr *transcript.Transcript) (error, error) {
+			proof, fault := decodeDkgRound4Input(m)
+			if fault != nil {
+				return fault, nil
+			}
+			if tr == nil {
+				return nil, errors.New("evidence has no transcript")
+			}
+			return dkg.CheckBobProof(curve, tr, proof), nil
+		},
+		"4": decodes(decodeDkgRound5Input),
+		"5": decodes(decodeDkgRound6Input),
+		"6": decodes(decodeDkgRound7Input),
+		"7": decodes(decodeDkgRound8Input),
+		"8": decodes(decodeDkgRound9Input),
+		"9": decodes(decodeDkgRound10Input),
+	},
+	protocol.Dkls18Sign: {
+		"1": decodes(decodeSignRound2Input),
+		"2": decodes(decodeSignRound3Input),
+		"3": decodes(decodeSignRound4Input),
+	},
+	protocol.Dkls18Refresh: {
+		"1": decodes(decodeRefreshRound2Input),
+		"2": decodes(decodeRefreshRound3Input),
+		"3": decodes(decodeRefreshRound4Input),
+		"4": decodes(decodeRefreshRound5Input),
+		"5": decodes(decodeRefreshRound6Input),
+		"6": decodes(decodeRefreshRound7Input),
+	},
+	protocol.Dkls18Reshare: {
+		"1": decodes(decodeReshareRound2Input),
+		"2": func(curve *curves.Curve, m *protocol.Message, tr *transcript.Transcript) (error, error) {
+			output, fault := decodeReshareRound3Input(m)
+			if fault != nil {
+				return fault, nil
+			}
+			if tr == nil {
+				return nil, errors.New("evidence has no transcript")
+			}
+			return refresh.CheckBobReshareProof(curve, tr, output), nil
+		},
+		"3": func(curve *curves.Curve, m *protocol.Message, tr *transcript.Transcript) (error, error) {
+			output, fault := decodeReshareRound4Input(m)
+			if fault != nil {
+				return fault, nil
+			}
+			if tr == nil {
+				return nil, errors.New("evidence has no transcript")
+			}
+			return refresh.CheckAliceReshareProof(curve, tr, output), nil
+		},
+		"4": decodes(decodeReshareRound5Input),
+		"5": decodes(decodeReshareRound6Input),
+		"6": decodes(decodeReshareRound7Input),
+	},
+	protocol.Dkls18Presign: {
+		"3":      decodes(decodePresignRound4Input),
+		"online": decodes(decodeOnlineSignInput),
+	},
+	protocol.Dkls18Derive: {
+		"1": decodes(decodeDeriveRound2Input),
+		"2": decodes(decodeDeriveRound3Input),
+		"3": decodes(decodeDeriveRound4Input),
+		"4": decodes(decodeDeriveRound5Input),
+	},
+}
+
+// VerifyEvidence reruns the public checks of the step that aborted on the rejected message. It returns nil when the
+// message fails them, which shows the fault of the culprit, and ErrPrivateCheck when it passes them.
+func VerifyEvidence(curve *curves.Curve, abort *AbortError) error {
+	if abort == nil || abort.Evidence == nil || abort.Evidence.Message == nil {
+		return errors.New("abort has no evidence")
+	}
+	m := abort.Evidence.Message
+	check, ok := evidenceChecks[m.Protocol][m.Metadata["round"]]
+	if !ok {
+		return errors.Errorf("no evidence check for %s message %s", m.Protocol, m.Metadata["round"])
+	}
+	var tr *transcript.Transcript
+	if abort.Evidence.Transcript != nil {
+		tr = new(transcript.Transcript)
+		if err := tr.UnmarshalBinary(abort.Evidence.Transcript); err != nil {
+			return errors.Wrap(err, "decoding evidence transcript")
+		}
+	}
+	fault, err := check(curve, m, tr)
+	if err != nil {
+		return err
+	}
+	if fault == nil {
+		return ErrPrivateCheck
+	}
+	if errors.Is(fault, errUnexpectedMessage) {
+		return errors.Wrap(fault, "evidence is not a message of the protocol")
+	}
+	return nil
+}
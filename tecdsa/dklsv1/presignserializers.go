@@ -0,0 +1,207 @@
+package dklsv1
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/protocol"
+	"github.com/go-sonr/crypto/core/protocol/messages"
+	"github.com/go-sonr/crypto/ot/base/simplest"
+	"github.com/go-sonr/crypto/tecdsa/dklsv1/sign"
+)
+
+const (
+	presignRound3Type     = "dkls18-presign/round3"
+	onlineSignType        = "dkls18-presign/online"
+	alicePresignatureType = "dkls18-presign/alice-presignature"
+	bobPresignatureType   = "dkls18-presign/bob-presignature"
+)
+
+func newPresignProtocolMessage(payload []byte, round string, version uint) *protocol.Message {
+	return &protocol.Message{
+		Protocol: protocol.Dkls18Presign,
+		Version:  version,
+		Payloads: map[string][]byte{payloadKey: payload},
+		Metadata: map[string]string{"round": round},
+	}
+}
+
+// encodePresignPayload encodes a presign message of type msgType on the curve of s, whose fields write writes
+func encodePresignPayload(msgType string, s curves.Scalar, write func(*messages.Encoder) error, round string, version uint) (*protocol.Message, error) {
+	if version != protocol.Version1 {
+		return nil, errors.New("only version 1 is supported")
+	}
+	curve, err := scalarCurve(s)
+	if err != nil {
+		return nil, err
+	}
+	enc := messages.NewEncoder(msgType, curve)
+	if err = write(enc); err != nil {
+		return nil, err
+	}
+	payload, err := enc.Finish()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return newPresignProtocolMessage(payload, round, version), nil
+}
+
+// decodePresignPayload decodes a presign message of type msgType whose fields read reads
+func decodePresignPayload(m *protocol.Message, round, msgType string, read func(*messages.Decoder) error) error {
+	dec, err := decodePayload(m, protocol.Dkls18Presign, round, msgType)
+	if err != nil {
+		return err
+	}
+	if err = read(dec); err != nil {
+		return err
+	}
+	return dec.Finish()
+}
+
+func encodePresignRound3Output(output *sign.PresignRound3Output, version uint) (*protocol.Message, error) {
+	return encodePresignPayload(presignRound3Type, output.EtaPhi, func(enc *messages.Encoder) error {
+		for _, multiplyOutput := range output.MultiplyRound2Outputs {
+			if err := writeMultiplyRound2Output(enc, multiplyOutput); err != nil {
+				return err
+			}
+		}
+		writeProof(enc, output.RSchnorrProof)
+		enc.WritePoint(output.RPrime)
+		enc.WriteScalar(output.EtaPhi)
+		return nil
+	}, "3", version)
+}
+
+func decodePresignRound4Input(m *protocol.Message) (*sign.PresignRound3Output, error) {
+	decoded := new(sign.PresignRound3Output)
+	if err := decodePresignPayload(m, "3", presignRound3Type, func(dec *messages.Decoder) error {
+		for i := range decoded.MultiplyRound2Outputs {
+			decoded.MultiplyRound2Outputs[i] = readMultiplyRound2Output(dec)
+		}
+		decoded.RSchnorrProof = readProof(dec)
+		decoded.RPrime = dec.ReadPoint()
+		decoded.EtaPhi = dec.ReadScalar()
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+func encodeOnlineSignOutput(output *sign.OnlineSignOutput, version uint) (*protocol.Message, error) {
+	return encodePresignPayload(onlineSignType, output.EtaSig, func(enc *messages.Encoder) error {
+		enc.WriteBytes(output.PresignatureID[:])
+		enc.WriteScalar(output.EtaSig)
+		return nil
+	}, "online", version)
+}
+
+func decodeOnlineSignInput(m *protocol.Message) (*sign.OnlineSignOutput, error) {
+	decoded := new(sign.OnlineSignOutput)
+	if err := decodePresignPayload(m, "online", onlineSignType, func(dec *messages.Decoder) error {
+		id, err := readFixed(dec, simplest.DigestSize)
+		if err != nil {
+			return err
+		}
+		copy(decoded.PresignatureID[:], id)
+		decoded.EtaSig = dec.ReadScalar()
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+// writeUsed writes the used flag of a presignature
+func writeUsed(enc *messages.Encoder, used bool) {
+	if used {
+		enc.WriteUint32(1)
+	} else {
+		enc.WriteUint32(0)
+	}
+}
+
+func readUsed(dec *messages.Decoder) (bool, error) {
+	switch dec.ReadUint32() {
+	case 0:
+		return false, nil
+	case 1:
+		return true, nil
+	default:
+		return false, errors.New("invalid used flag")
+	}
+}
+
+// EncodeAlicePresignature serializes Alice's presignature for storage. Storage must hand out every
+// presignature at most once.
+func EncodeAlicePresignature(presignature *sign.AlicePresignature, version uint) (*protocol.Message, error) {
+	return encodePresignPayload(alicePresignatureType, presignature.SecretShare, func(enc *messages.Encoder) error {
+		enc.WriteBytes(presignature.ID[:])
+		enc.WritePoint(presignature.PublicKey)
+		enc.WriteScalar(presignature.RX)
+		enc.WriteScalar(presignature.PhiShare)
+		enc.WriteScalar(presignature.SecretShare)
+		enc.WriteScalar(presignature.Gamma2Hash)
+		writeUsed(enc, presignature.Used)
+		return nil
+	}, "alice-presignature", version)
+}
+
+// DecodeAlicePresignature deserializes Alice's presignature.
+func DecodeAlicePresignature(m *protocol.Message) (*sign.AlicePresignature, error) {
+	decoded := new(sign.AlicePresignature)
+	if err := decodePresignPayload(m, "alice-presignature", alicePresignatureType, func(dec *messages.Decoder) error {
+		id, err := readFixed(dec, simplest.DigestSize)
+		if err != nil {
+			return err
+		}
+		copy(decoded.ID[:], id)
+		decoded.PublicKey = dec.ReadPoint()
+		decoded.RX = dec.ReadScalar()
+		decoded.PhiShare = dec.ReadScalar()
+		decoded.SecretShare = dec.ReadScalar()
+		decoded.Gamma2Hash = dec.ReadScalar()
+		decoded.Used, err = readUsed(dec)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+// EncodeBobPresignature serializes Bob's presignature for storage. Storage must hand out every
+// presignature at most once.
+func EncodeBobPresignature(presignature *sign.BobPresignature, version uint) (*protocol.Message, error) {
+	return encodePresignPayload(bobPresignatureType, presignature.SecretShare, func(enc *messages.Encoder) error {
+		enc.WriteBytes(presignature.ID[:])
+		enc.WritePoint(presignature.PublicKey)
+		enc.WritePoint(presignature.R)
+		enc.WriteScalar(presignature.Theta)
+		enc.WriteScalar(presignature.SecretShare)
+		enc.WriteScalar(presignature.Gamma2Hash)
+		writeUsed(enc, presignature.Used)
+		return nil
+	}, "bob-presignature", version)
+}
+
+// DecodeBobPresignature deserializes Bob's presignature.
+func DecodeBobPresignature(m *protocol.Message) (*sign.BobPresignature, error) {
+	decoded := new(sign.BobPresignature)
+	if err := decodePresignPayload(m, "bob-presignature", bobPresignatureType, func(dec *messages.Decoder) error {
+		id, err := readFixed(dec, simplest.DigestSize)
+		if err != nil {
+			return err
+		}
+		copy(decoded.ID[:], id)
+		decoded.PublicKey = dec.ReadPoint()
+		decoded.R = dec.ReadPoint()
+		decoded.Theta = dec.ReadScalar()
+		decoded.SecretShare = dec.ReadScalar()
+		decoded.Gamma2Hash = dec.ReadScalar()
+		decoded.Used, err = readUsed(dec)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
@@ -3,13 +3,103 @@
 package dklsv1
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/go-sonr/crypto/core/protocol"
 )
 
+// Party is the index of a participant of the two party protocols.
+type Party int
+
+const (
+	// PartyAlice is the index of Alice
+	PartyAlice Party = 1
+	// PartyBob is the index of Bob
+	PartyBob Party = 2
+)
+
+// String returns the name of the party
+func (p Party) String() string {
+	switch p {
+	case PartyAlice:
+		return "alice"
+	case PartyBob:
+		return "bob"
+	default:
+		return fmt.Sprintf("party %d", int(p))
+	}
+}
+
+// AbortError reports that a party aborted because a message of the counterparty failed to decode or verify.
+// In the two party setting an honest party that aborts on such a message identifies the counterparty as
+// the culprit; a corrupted transport produces the same error. Local failures, such as randomness, encoding or a
+// message of another protocol or round, are returned as plain errors.
+type AbortError struct {
+	// Culprit is the party whose message was rejected.
+	Culprit Party
+	// Protocol is the protocol of the rejected message.
+	Protocol string
+	// Round is the step of the aborting party that rejected the message, starting at 1.
+	Round int
+	// Err is the failed check.
+	Err error
+	// Evidence lets a third party rerun the public checks of the step with VerifyEvidence.
+	Evidence *Evidence
+}
+
+func (e *AbortError) Error() string {
+	return fmt.Sprintf("%s aborted in step %d: message of %s rejected: %v", e.Protocol, e.Round, e.Culprit, e.Err)
+}
+
+func (e *AbortError) Unwrap() error { return e.Err }
+
+// Blame returns the misbehaving party when err is an abort on a message of the counterparty.
+func Blame(err error) (Party, bool) {
+	var abort *AbortError
+	if errors.As(err, &abort) {
+		return abort.Culprit, true
+	}
+	return 0, false
+}
+
+// errUnexpectedMessage reports a message of another protocol, version or round. It is an error of the caller, who
+// delivered the message, rather than of the counterparty.
+var errUnexpectedMessage = errors.New("unexpected message")
+
+// rejectedError marks an error of a step on the message of the counterparty, which Next reports as an AbortError.
+type rejectedError struct {
+	err error
+}
+
+func (e *rejectedError) Error() string { return e.err.Error() }
+
+func (e *rejectedError) Unwrap() error { return e.err }
+
+// reject marks err, returned while decoding or verifying the received message, as a fault of the counterparty.
+// Unexpected messages stay local errors.
+func reject(err error) error {
+	if err == nil || errors.Is(err, errUnexpectedMessage) {
+		return err
+	}
+	return &rejectedError{err: err}
+}
+
 // Basic protocol interface implementation that calls the next step func in a pre-defined list
 type protoStepper struct {
 	steps []func(input *protocol.Message) (*protocol.Message, error)
 	step  int
+
+	// counterparty and name attribute failures on received messages, see AbortError
+	counterparty Party
+	name         string
+
+	// version is the version of the messages, and of the serialized state
+	version uint
+
+	// transcript returns the public transcript of the party for the evidence of an abort, nil when the party does
+	// not record it
+	transcript func() ([]byte, error)
 }
 
 // Next runs the next step in the protocol and reports errors or increments the step index
@@ -18,10 +108,32 @@ func (p *protoStepper) Next(input *protocol.Message) (*protocol.Message, error)
 		return nil, protocol.ErrProtocolFinished
 	}
 
+	// The transcript before the step is the evidence of an abort in the step
+	var tr []byte
+	if input != nil && p.transcript != nil {
+		var err error
+		if tr, err = p.transcript(); err != nil {
+			return nil, err
+		}
+	}
+
 	// Run the current protocol step and report any errors
 	output, err := p.steps[p.step](input)
 	if err != nil {
-		return nil, err
+		var rejected *rejectedError
+		if !errors.As(err, &rejected) {
+			return nil, err
+		}
+		if input == nil || p.counterparty == 0 {
+			return nil, rejected.err
+		}
+		return nil, &AbortError{
+			Culprit:  p.counterparty,
+			Protocol: p.name,
+			Round:    p.step + 1,
+			Err:      rejected.err,
+			Evidence: &Evidence{Message: input, Transcript: tr},
+		}
 	}
 
 	// Increment the step index and report success
@@ -31,3 +143,6 @@ func (p *protoStepper) Next(input *protocol.Message) (*protocol.Message, error)
 
 // Reports true if the step index exceeds the number of steps
 func (p *protoStepper) complete() bool { return p.step >= len(p.steps) /**/ }
+
+// Finished reports whether the party has run all its steps, see protocol.Finisher
+func (p *protoStepper) Finished() bool { return p.complete() }
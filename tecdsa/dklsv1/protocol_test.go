@@ -7,8 +7,14 @@
 package dklsv1
 
 import (
+	"bytes"
+	"context"
+	crand "crypto/rand"
+	"encoding/gob"
+	"fmt"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/sha3"
@@ -17,6 +23,7 @@ import (
 	"github.com/go-sonr/crypto/core/protocol"
 	"github.com/go-sonr/crypto/ot/extension/kos"
 	"github.com/go-sonr/crypto/tecdsa/dklsv1/dkg"
+	"github.com/go-sonr/crypto/tecdsa/dklsv1/refresh"
 )
 
 // For DKG bob starts first. For refresh and sign, Alice starts first.
@@ -97,39 +104,212 @@ func TestDkgProto(t *testing.T) {
 	}
 }
 
-//
-// // DKG > Refresh > Sign
-// func TestRefreshProto(t *testing.T) {
-// 	t.Parallel()
-// 	curveInstances := []*curves.Curve{
-// 		curves.K256(),
-// 		curves.P256(),
-// 	}
-// 	for _, curve := range curveInstances {
-// 		boundCurve := curve
-// 		t.Run(fmt.Sprintf("testing refresh for curve %s", boundCurve.Name), func(tt *testing.T) {
-// 			tt.Parallel()
-// 			// DKG
-// 			aliceDkg := NewAliceDkg(boundCurve, protocol.Version1)
-// 			bobDkg := NewBobDkg(boundCurve, protocol.Version1)
-//
-// 			aDkgErr, bDkgErr := runIteratedProtocol(bobDkg, aliceDkg)
-// 			require.ErrorIs(tt, aDkgErr, protocol.ErrProtocolFinished)
-// 			require.ErrorIs(tt, bDkgErr, protocol.ErrProtocolFinished)
-//
-// 			aliceDkgResultMessage, err := aliceDkg.Result(protocol.Version1)
-// 			require.NoError(tt, err)
-// 			bobDkgResultMessage, err := bobDkg.Result(protocol.Version1)
-// 			require.NoError(tt, err)
-//
-// 			// Refresh
-// 			aliceRefreshResultMessage, bobRefreshResultMessage := refreshV1(t, boundCurve, aliceDkgResultMessage, bobDkgResultMessage)
-//
-// 			// sign
-// 			signV1(t, boundCurve, aliceRefreshResultMessage, bobRefreshResultMessage)
-// 		})
-// 	}
-// }
+func TestKeyShareEncoding(t *testing.T) {
+	curve := curves.K256()
+	alice := NewAliceDkg(curve, protocol.Version1)
+	bob := NewBobDkg(curve, protocol.Version1)
+	aErr, bErr := runIteratedProtocol(bob, alice)
+	require.ErrorIs(t, aErr, protocol.ErrProtocolFinished)
+	require.ErrorIs(t, bErr, protocol.ErrProtocolFinished)
+	aliceMessage, err := alice.Result(protocol.Version1)
+	require.NoError(t, err)
+	bobMessage, err := bob.Result(protocol.Version1)
+	require.NoError(t, err)
+
+	aliceOutput, err := DecodeAliceDkgResult(aliceMessage)
+	require.NoError(t, err)
+	require.Equal(t, alice.Alice.Output().SeedOtResult, aliceOutput.SeedOtResult)
+	require.True(t, alice.Alice.Output().SecretKeyShare.Cmp(aliceOutput.SecretKeyShare) == 0)
+	bobOutput, err := DecodeBobDkgResult(bobMessage)
+	require.NoError(t, err)
+	require.Equal(t, bob.Bob.Output().SeedOtResult, bobOutput.SeedOtResult)
+
+	// the encoding is canonical
+	reencoded, err := EncodeAliceDkgOutput(aliceOutput, protocol.Version1)
+	require.NoError(t, err)
+	require.Equal(t, aliceMessage.Payloads[payloadKey], reencoded.Payloads[payloadKey])
+
+	t.Run("key shares of earlier releases still decode", func(t *testing.T) {
+		registerTypes()
+		buf := new(bytes.Buffer)
+		require.NoError(t, gob.NewEncoder(buf).Encode(alice.Alice.Output()))
+		legacyAlice, err := DecodeAliceDkgResult(&protocol.Message{Version: protocol.Version1, Payloads: map[string][]byte{payloadKey: buf.Bytes()}})
+		require.NoError(t, err)
+		require.Equal(t, aliceOutput.SeedOtResult, legacyAlice.SeedOtResult)
+		require.True(t, aliceOutput.PublicKey.Equal(legacyAlice.PublicKey))
+
+		buf.Reset()
+		require.NoError(t, gob.NewEncoder(buf).Encode(bob.Bob.Output()))
+		legacyBob, err := DecodeBobDkgResult(&protocol.Message{Version: protocol.Version1, Payloads: map[string][]byte{payloadKey: buf.Bytes()}})
+		require.NoError(t, err)
+		require.Equal(t, bobOutput.SeedOtResult, legacyBob.SeedOtResult)
+	})
+
+	t.Run("truncated key shares are rejected", func(t *testing.T) {
+		payload := aliceMessage.Payloads[payloadKey]
+		_, err := DecodeAliceDkgResult(&protocol.Message{Version: protocol.Version1, Payloads: map[string][]byte{payloadKey: payload[:len(payload)-1]}})
+		require.Error(t, err)
+		_, err = DecodeBobDkgResult(aliceMessage)
+		require.Error(t, err)
+	})
+}
+
+// DKG > Derive > Sign
+func TestDeriveProto(t *testing.T) {
+	t.Parallel()
+	curve := curves.K256()
+	aliceDkg := NewAliceDkg(curve, protocol.Version1)
+	bobDkg := NewBobDkg(curve, protocol.Version1)
+	aErr, bErr := runIteratedProtocol(bobDkg, aliceDkg)
+	require.ErrorIs(t, aErr, protocol.ErrProtocolFinished)
+	require.ErrorIs(t, bErr, protocol.ErrProtocolFinished)
+	aliceDkgResultMessage, err := aliceDkg.Result(protocol.Version1)
+	require.NoError(t, err)
+	bobDkgResultMessage, err := bobDkg.Result(protocol.Version1)
+	require.NoError(t, err)
+
+	chainCode := make([]byte, 32)
+	aliceDerive, err := NewAliceDerive(curve, aliceDkgResultMessage, chainCode, 1, protocol.Version1)
+	require.NoError(t, err)
+	bobDerive, err := NewBobDerive(curve, bobDkgResultMessage, chainCode, 1, protocol.Version1)
+	require.NoError(t, err)
+	aErr, bErr = runIteratedProtocol(aliceDerive, bobDerive)
+	require.ErrorIs(t, aErr, protocol.ErrProtocolFinished)
+	require.ErrorIs(t, bErr, protocol.ErrProtocolFinished)
+	require.Equal(t, aliceDerive.ChainCode(), bobDerive.ChainCode())
+
+	aliceChildMessage, err := aliceDerive.Result(protocol.Version1)
+	require.NoError(t, err)
+	bobChildMessage, err := bobDerive.Result(protocol.Version1)
+	require.NoError(t, err)
+	aliceChild, err := DecodeAliceDkgResult(aliceChildMessage)
+	require.NoError(t, err)
+	parent, err := DecodeAliceDkgResult(aliceDkgResultMessage)
+	require.NoError(t, err)
+	require.False(t, aliceChild.PublicKey.Equal(parent.PublicKey))
+
+	signV1(t, curve, aliceChildMessage, bobChildMessage)
+}
+
+// DKG > Refresh > Sign
+func TestRefreshProto(t *testing.T) {
+	t.Parallel()
+	curveInstances := []*curves.Curve{
+		curves.K256(),
+		curves.P256(),
+	}
+	for _, curve := range curveInstances {
+		boundCurve := curve
+		t.Run(fmt.Sprintf("testing refresh for curve %s", boundCurve.Name), func(tt *testing.T) {
+			tt.Parallel()
+			// DKG
+			aliceDkg := NewAliceDkg(boundCurve, protocol.Version1)
+			bobDkg := NewBobDkg(boundCurve, protocol.Version1)
+
+			aDkgErr, bDkgErr := runIteratedProtocol(bobDkg, aliceDkg)
+			require.ErrorIs(tt, aDkgErr, protocol.ErrProtocolFinished)
+			require.ErrorIs(tt, bDkgErr, protocol.ErrProtocolFinished)
+
+			aliceDkgResultMessage, err := aliceDkg.Result(protocol.Version1)
+			require.NoError(tt, err)
+			bobDkgResultMessage, err := bobDkg.Result(protocol.Version1)
+			require.NoError(tt, err)
+
+			// Refresh
+			aliceRefreshResultMessage, bobRefreshResultMessage := refreshV1(t, boundCurve, aliceDkgResultMessage, bobDkgResultMessage)
+
+			// sign
+			signV1(t, boundCurve, aliceRefreshResultMessage, bobRefreshResultMessage)
+		})
+	}
+}
+
+func TestReshareProto(t *testing.T) {
+	t.Parallel()
+	for _, curve := range []*curves.Curve{curves.K256(), curves.P256()} {
+		boundCurve := curve
+		t.Run(fmt.Sprintf("testing reshare for curve %s", boundCurve.Name), func(tt *testing.T) {
+			tt.Parallel()
+			aliceDkg := NewAliceDkg(boundCurve, protocol.Version1)
+			bobDkg := NewBobDkg(boundCurve, protocol.Version1)
+			aDkgErr, bDkgErr := runIteratedProtocol(bobDkg, aliceDkg)
+			require.ErrorIs(tt, aDkgErr, protocol.ErrProtocolFinished)
+			require.ErrorIs(tt, bDkgErr, protocol.ErrProtocolFinished)
+			aliceDkgResultMessage, err := aliceDkg.Result(protocol.Version1)
+			require.NoError(tt, err)
+			bobDkgResultMessage, err := bobDkg.Result(protocol.Version1)
+			require.NoError(tt, err)
+
+			// the old parties hand their shares to a new pair
+			aliceToAlice, aliceToBob, err := SplitAliceShare(boundCurve, aliceDkgResultMessage, protocol.Version1)
+			require.NoError(tt, err)
+			bobToAlice, bobToBob, err := SplitBobShare(boundCurve, bobDkgResultMessage, protocol.Version1)
+			require.NoError(tt, err)
+
+			aliceReshare, err := NewAliceReshare(boundCurve, aliceToAlice, bobToAlice, protocol.Version1)
+			require.NoError(tt, err)
+			bobReshare, err := NewBobReshare(boundCurve, aliceToBob, bobToBob, protocol.Version1)
+			require.NoError(tt, err)
+			aErr, bErr := runIteratedProtocol(aliceReshare, bobReshare)
+			require.ErrorIs(tt, aErr, protocol.ErrProtocolFinished)
+			require.ErrorIs(tt, bErr, protocol.ErrProtocolFinished)
+
+			aliceResultMessage, err := aliceReshare.Result(protocol.Version1)
+			require.NoError(tt, err)
+			bobResultMessage, err := bobReshare.Result(protocol.Version1)
+			require.NoError(tt, err)
+
+			oldAlice, err := DecodeAliceDkgResult(aliceDkgResultMessage)
+			require.NoError(tt, err)
+			oldBob, err := DecodeBobDkgResult(bobDkgResultMessage)
+			require.NoError(tt, err)
+			newAlice, err := DecodeAliceDkgResult(aliceResultMessage)
+			require.NoError(tt, err)
+			newBob, err := DecodeBobDkgResult(bobResultMessage)
+			require.NoError(tt, err)
+			require.True(tt, newAlice.PublicKey.Equal(oldAlice.PublicKey))
+			require.True(tt, newBob.PublicKey.Equal(oldAlice.PublicKey))
+			require.NotEqual(tt, oldAlice.SecretKeyShare.Bytes(), newAlice.SecretKeyShare.Bytes())
+			require.NotEqual(tt, oldBob.SecretKeyShare.Bytes(), newBob.SecretKeyShare.Bytes())
+
+			signV1(tt, boundCurve, aliceResultMessage, bobResultMessage)
+
+			// handoffs of another key are rejected
+			otherAliceDkg := NewAliceDkg(boundCurve, protocol.Version1)
+			otherBobDkg := NewBobDkg(boundCurve, protocol.Version1)
+			_, _ = runIteratedProtocol(otherBobDkg, otherAliceDkg)
+			otherBobDkgResultMessage, err := otherBobDkg.Result(protocol.Version1)
+			require.NoError(tt, err)
+			otherBobToAlice, _, err := SplitBobShare(boundCurve, otherBobDkgResultMessage, protocol.Version1)
+			require.NoError(tt, err)
+			_, err = NewAliceReshare(boundCurve, aliceToAlice, otherBobToAlice, protocol.Version1)
+			require.Error(tt, err)
+			_, err = NewAliceReshare(boundCurve, aliceToBob, bobToAlice, protocol.Version1)
+			require.Error(tt, err)
+
+			// a new party whose handoff was replaced is caught by its counterparty
+			forged, err := EncodeReshareHandoff(&refresh.Handoff{
+				PublicKey: oldAlice.PublicKey,
+				Factor:    boundCurve.Scalar.Random(crand.Reader),
+			}, PartyBob, PartyAlice, protocol.Version1)
+			require.NoError(tt, err)
+			aliceReshare, err = NewAliceReshare(boundCurve, aliceToAlice, forged, protocol.Version1)
+			require.NoError(tt, err)
+			bobReshare, err = NewBobReshare(boundCurve, aliceToBob, bobToBob, protocol.Version1)
+			require.NoError(tt, err)
+			// alice runs first, so her error comes second
+			bErr, aErr = runIteratedProtocol(aliceReshare, bobReshare)
+			require.NoError(tt, bErr)
+			culprit, ok := Blame(aErr)
+			require.True(tt, ok)
+			require.Equal(tt, PartyBob, culprit)
+			// bob's proof is valid, only alice's share shows the mismatch
+			var abort *AbortError
+			require.ErrorAs(tt, aErr, &abort)
+			require.ErrorIs(tt, VerifyEvidence(boundCurve, abort), ErrPrivateCheck)
+		})
+	}
+}
 
 // DKG > Output > NewDklsSign > Sign > Output
 func TestDkgSignProto(t *testing.T) {
@@ -277,93 +457,93 @@ func TestDkgSignProto(t *testing.T) {
 // 		require.Equal(t, bob.Output().SecretKeyShare, decodedBob.SecretKeyShare)
 // 	})
 // }
-//
-// func signV1(t *testing.T, curve *curves.Curve, aliceDkgResultMessage *protocol.Message, bobDkgResultMessage *protocol.Message) {
-// 	t.Helper()
-// 	// New DklsSign
-// 	msg := []byte("As soon as you trust yourself, you will know how to live.")
-// 	aliceSign, err := NewAliceSign(curve, sha3.New256(), msg, aliceDkgResultMessage, protocol.Version1)
-// 	require.NoError(t, err)
-// 	bobSign, err := NewBobSign(curve, sha3.New256(), msg, bobDkgResultMessage, protocol.Version1)
-// 	require.NoError(t, err)
-//
-// 	// Sign
-// 	var aErr error
-// 	var bErr error
-// 	t.Run("sign", func(t *testing.T) {
-// 		aErr, bErr = runIteratedProtocol(aliceSign, bobSign)
-// 		require.ErrorIs(t, aErr, protocol.ErrProtocolFinished)
-// 		require.ErrorIs(t, bErr, protocol.ErrProtocolFinished)
-// 	})
-// 	// Don't continue to verifying results if sign didn't run to completion.
-// 	require.ErrorIs(t, aErr, protocol.ErrProtocolFinished)
-// 	require.ErrorIs(t, bErr, protocol.ErrProtocolFinished)
-//
-// 	// Output
-// 	var result *curves.EcdsaSignature
-// 	t.Run("bob produces result of correct type", func(t *testing.T) {
-// 		resultMessage, err := bobSign.Result(protocol.Version1)
-// 		require.NoError(t, err)
-// 		result, err = DecodeSignature(resultMessage)
-// 		require.NoError(t, err)
-// 	})
-// 	require.NotNil(t, result)
-//
-// 	aliceDkg, err := DecodeAliceDkgResult(aliceDkgResultMessage)
-// 	require.NoError(t, err)
-//
-// 	t.Run("valid signature", func(t *testing.T) {
-// 		hash := sha3.New256()
-// 		_, err = hash.Write(msg)
-// 		require.NoError(t, err)
-// 		digest := hash.Sum(nil)
-// 		unCompressedAffinePublicKey := aliceDkg.PublicKey.ToAffineUncompressed()
-// 		require.Equal(t, 65, len(unCompressedAffinePublicKey))
-// 		x := new(big.Int).SetBytes(unCompressedAffinePublicKey[1:33])
-// 		y := new(big.Int).SetBytes(unCompressedAffinePublicKey[33:])
-// 		ecCurve, err := curve.ToEllipticCurve()
-// 		require.NoError(t, err)
-// 		publicKey := &curves.EcPoint{
-// 			Curve: ecCurve,
-// 			X:     x,
-// 			Y:     y,
-// 		}
-// 		require.True(t,
-// 			curves.VerifyEcdsa(publicKey,
-// 				digest[:],
-// 				result,
-// 			),
-// 			"signature failed verification",
-// 		)
-// 	})
-// }
-//
-// func refreshV1(t *testing.T, curve *curves.Curve, aliceDkgResultMessage, bobDkgResultMessage *protocol.Message) (aliceRefreshResultMessage, bobRefreshResultMessage *protocol.Message) {
-// 	t.Helper()
-// 	aliceRefresh, err := NewAliceRefresh(curve, aliceDkgResultMessage, protocol.Version1)
-// 	require.NoError(t, err)
-// 	bobRefresh, err := NewBobRefresh(curve, bobDkgResultMessage, protocol.Version1)
-// 	require.NoError(t, err)
-//
-// 	aErr, bErr := runIteratedProtocol(aliceRefresh, bobRefresh)
-// 	require.ErrorIs(t, aErr, protocol.ErrProtocolFinished)
-// 	require.ErrorIs(t, bErr, protocol.ErrProtocolFinished)
-//
-// 	aliceRefreshResultMessage, err = aliceRefresh.Result(protocol.Version1)
-// 	require.NoError(t, err)
-// 	require.NotNil(t, aliceRefreshResultMessage)
-// 	_, err = DecodeAliceRefreshResult(aliceRefreshResultMessage)
-// 	require.NoError(t, err)
-//
-// 	bobRefreshResultMessage, err = bobRefresh.Result(protocol.Version1)
-// 	require.NoError(t, err)
-// 	require.NotNil(t, bobRefreshResultMessage)
-// 	_, err = DecodeBobRefreshResult(bobRefreshResultMessage)
-// 	require.NoError(t, err)
-//
-// 	return aliceRefreshResultMessage, bobRefreshResultMessage
-// }
-//
+
+func signV1(t *testing.T, curve *curves.Curve, aliceDkgResultMessage *protocol.Message, bobDkgResultMessage *protocol.Message) {
+	t.Helper()
+	// New DklsSign
+	msg := []byte("As soon as you trust yourself, you will know how to live.")
+	aliceSign, err := NewAliceSign(curve, sha3.New256(), msg, aliceDkgResultMessage, protocol.Version1)
+	require.NoError(t, err)
+	bobSign, err := NewBobSign(curve, sha3.New256(), msg, bobDkgResultMessage, protocol.Version1)
+	require.NoError(t, err)
+
+	// Sign
+	var aErr error
+	var bErr error
+	t.Run("sign", func(t *testing.T) {
+		aErr, bErr = runIteratedProtocol(aliceSign, bobSign)
+		require.ErrorIs(t, aErr, protocol.ErrProtocolFinished)
+		require.ErrorIs(t, bErr, protocol.ErrProtocolFinished)
+	})
+	// Don't continue to verifying results if sign didn't run to completion.
+	require.ErrorIs(t, aErr, protocol.ErrProtocolFinished)
+	require.ErrorIs(t, bErr, protocol.ErrProtocolFinished)
+
+	// Output
+	var result *curves.EcdsaSignature
+	t.Run("bob produces result of correct type", func(t *testing.T) {
+		resultMessage, err := bobSign.Result(protocol.Version1)
+		require.NoError(t, err)
+		result, err = DecodeSignature(resultMessage)
+		require.NoError(t, err)
+	})
+	require.NotNil(t, result)
+
+	aliceDkg, err := DecodeAliceDkgResult(aliceDkgResultMessage)
+	require.NoError(t, err)
+
+	t.Run("valid signature", func(t *testing.T) {
+		hash := sha3.New256()
+		_, err = hash.Write(msg)
+		require.NoError(t, err)
+		digest := hash.Sum(nil)
+		unCompressedAffinePublicKey := aliceDkg.PublicKey.ToAffineUncompressed()
+		require.Equal(t, 65, len(unCompressedAffinePublicKey))
+		x := new(big.Int).SetBytes(unCompressedAffinePublicKey[1:33])
+		y := new(big.Int).SetBytes(unCompressedAffinePublicKey[33:])
+		ecCurve, err := curve.ToEllipticCurve()
+		require.NoError(t, err)
+		publicKey := &curves.EcPoint{
+			Curve: ecCurve,
+			X:     x,
+			Y:     y,
+		}
+		require.True(t,
+			curves.VerifyEcdsa(publicKey,
+				digest[:],
+				result,
+			),
+			"signature failed verification",
+		)
+	})
+}
+
+func refreshV1(t *testing.T, curve *curves.Curve, aliceDkgResultMessage, bobDkgResultMessage *protocol.Message) (aliceRefreshResultMessage, bobRefreshResultMessage *protocol.Message) {
+	t.Helper()
+	aliceRefresh, err := NewAliceRefresh(curve, aliceDkgResultMessage, protocol.Version1)
+	require.NoError(t, err)
+	bobRefresh, err := NewBobRefresh(curve, bobDkgResultMessage, protocol.Version1)
+	require.NoError(t, err)
+
+	aErr, bErr := runIteratedProtocol(aliceRefresh, bobRefresh)
+	require.ErrorIs(t, aErr, protocol.ErrProtocolFinished)
+	require.ErrorIs(t, bErr, protocol.ErrProtocolFinished)
+
+	aliceRefreshResultMessage, err = aliceRefresh.Result(protocol.Version1)
+	require.NoError(t, err)
+	require.NotNil(t, aliceRefreshResultMessage)
+	_, err = DecodeAliceRefreshResult(aliceRefreshResultMessage)
+	require.NoError(t, err)
+
+	bobRefreshResultMessage, err = bobRefresh.Result(protocol.Version1)
+	require.NoError(t, err)
+	require.NotNil(t, bobRefreshResultMessage)
+	_, err = DecodeBobRefreshResult(bobRefreshResultMessage)
+	require.NoError(t, err)
+
+	return aliceRefreshResultMessage, bobRefreshResultMessage
+}
+
 // func BenchmarkDKGProto(b *testing.B) {
 // 	curveInstances := []*curves.Curve{
 // 		curves.K256(),
@@ -417,3 +597,200 @@ func TestDkgSignProto(t *testing.T) {
 // 		})
 // 	}
 // }
+
+// DKG > Presign > Encode/Decode presignatures > Online sign
+func TestPresignProto(t *testing.T) {
+	for _, curve := range []*curves.Curve{curves.K256(), curves.P256()} {
+		aliceDkg := NewAliceDkg(curve, protocol.Version1)
+		bobDkg := NewBobDkg(curve, protocol.Version1)
+		aDkgErr, bDkgErr := runIteratedProtocol(bobDkg, aliceDkg)
+		require.ErrorIs(t, aDkgErr, protocol.ErrProtocolFinished)
+		require.ErrorIs(t, bDkgErr, protocol.ErrProtocolFinished)
+		aliceDkgResultMessage, err := aliceDkg.Result(protocol.Version1)
+		require.NoError(t, err)
+		bobDkgResultMessage, err := bobDkg.Result(protocol.Version1)
+		require.NoError(t, err)
+
+		alicePresign, err := NewAlicePresign(curve, aliceDkgResultMessage, protocol.Version1)
+		require.NoError(t, err)
+		bobPresign, err := NewBobPresign(curve, bobDkgResultMessage, protocol.Version1)
+		require.NoError(t, err)
+		aErr, bErr := runIteratedProtocol(alicePresign, bobPresign)
+		require.ErrorIs(t, aErr, protocol.ErrProtocolFinished)
+		require.ErrorIs(t, bErr, protocol.ErrProtocolFinished)
+
+		alicePresignatureMessage, err := alicePresign.Result(protocol.Version1)
+		require.NoError(t, err)
+		bobPresignatureMessage, err := bobPresign.Result(protocol.Version1)
+		require.NoError(t, err)
+		alicePresignature, err := DecodeAlicePresignature(alicePresignatureMessage)
+		require.NoError(t, err)
+		bobPresignature, err := DecodeBobPresignature(bobPresignatureMessage)
+		require.NoError(t, err)
+		_, err = DecodeAlicePresignature(bobPresignatureMessage)
+		require.Error(t, err)
+
+		msg := []byte("As soon as you trust yourself, you will know how to live.")
+		onlineMessage, err := SignWithAlicePresignature(alicePresignature, sha3.New256(), msg, protocol.Version1)
+		require.NoError(t, err)
+		signatureMessage, err := FinalizeWithBobPresignature(bobPresignature, sha3.New256(), msg, onlineMessage, protocol.Version1)
+		require.NoError(t, err)
+		signature, err := DecodeSignature(signatureMessage)
+		require.NoError(t, err)
+
+		digest := sha3.Sum256(msg)
+		uncompressed := alicePresignature.PublicKey.ToAffineUncompressed()
+		ecCurve, err := curve.ToEllipticCurve()
+		require.NoError(t, err)
+		publicKey := &curves.EcPoint{
+			Curve: ecCurve,
+			X:     new(big.Int).SetBytes(uncompressed[1:33]),
+			Y:     new(big.Int).SetBytes(uncompressed[33:]),
+		}
+		require.True(t, curves.VerifyEcdsa(publicKey, digest[:], signature))
+
+		_, err = SignWithAlicePresignature(alicePresignature, sha3.New256(), msg, protocol.Version1)
+		require.Error(t, err)
+	}
+}
+
+func TestIdentifiableAbort(t *testing.T) {
+	curve := curves.K256()
+	aliceDkg := NewAliceDkg(curve, protocol.Version1)
+	bobDkg := NewBobDkg(curve, protocol.Version1)
+	aDkgErr, bDkgErr := runIteratedProtocol(bobDkg, aliceDkg)
+	require.ErrorIs(t, aDkgErr, protocol.ErrProtocolFinished)
+	require.ErrorIs(t, bDkgErr, protocol.ErrProtocolFinished)
+	aliceDkgResultMessage, err := aliceDkg.Result(protocol.Version1)
+	require.NoError(t, err)
+	bobDkgResultMessage, err := bobDkg.Result(protocol.Version1)
+	require.NoError(t, err)
+
+	t.Run("alice tampers with eta sig", func(t *testing.T) {
+		msg := []byte("message")
+		aliceSign, err := NewAliceSign(curve, sha3.New256(), msg, aliceDkgResultMessage, protocol.Version1)
+		require.NoError(t, err)
+		bobSign, err := NewBobSign(curve, sha3.New256(), msg, bobDkgResultMessage, protocol.Version1)
+		require.NoError(t, err)
+		m1, err := aliceSign.Next(nil)
+		require.NoError(t, err)
+		m2, err := bobSign.Next(m1)
+		require.NoError(t, err)
+		m3, err := aliceSign.Next(m2)
+		require.NoError(t, err)
+
+		round3, err := decodeSignRound4Input(m3)
+		require.NoError(t, err)
+		round3.EtaSig = round3.EtaSig.Add(curve.Scalar.One())
+		m3, err = encodeSignRound3Output(round3, protocol.Version1)
+		require.NoError(t, err)
+		_, err = bobSign.Next(m3)
+		require.Error(t, err)
+		culprit, ok := Blame(err)
+		require.True(t, ok)
+		require.Equal(t, PartyAlice, culprit)
+		// the signature check depends on the secret state of bob
+		var abort *AbortError
+		require.ErrorAs(t, err, &abort)
+		require.ErrorIs(t, VerifyEvidence(curve, abort), ErrPrivateCheck)
+	})
+
+	t.Run("bob sends a malformed message", func(t *testing.T) {
+		aliceRefresh, err := NewAliceRefresh(curve, aliceDkgResultMessage, protocol.Version1)
+		require.NoError(t, err)
+		_, err = aliceRefresh.Next(nil)
+		require.NoError(t, err)
+		_, err = aliceRefresh.Next(&protocol.Message{
+			Protocol: protocol.Dkls18Refresh,
+			Version:  protocol.Version1,
+			Payloads: map[string][]byte{payloadKey: {1, 2, 3}},
+			Metadata: map[string]string{"round": "2"},
+		})
+		culprit, ok := Blame(err)
+		require.True(t, ok)
+		require.Equal(t, PartyBob, culprit)
+		require.Contains(t, err.Error(), protocol.Dkls18Refresh)
+		var abort *AbortError
+		require.ErrorAs(t, err, &abort)
+		require.NotEmpty(t, abort.Evidence.Transcript)
+		require.NoError(t, VerifyEvidence(curve, abort))
+	})
+
+	t.Run("a message of another round is not blamed", func(t *testing.T) {
+		aliceRefresh, err := NewAliceRefresh(curve, aliceDkgResultMessage, protocol.Version1)
+		require.NoError(t, err)
+		m1, err := aliceRefresh.Next(nil)
+		require.NoError(t, err)
+		_, err = aliceRefresh.Next(m1)
+		require.Error(t, err)
+		_, ok := Blame(err)
+		require.False(t, ok)
+	})
+
+	t.Run("bob sends a bad dkg proof", func(t *testing.T) {
+		aliceDkg := NewAliceDkg(curve, protocol.Version1)
+		bobDkg := NewBobDkg(curve, protocol.Version1)
+		m1, err := bobDkg.Next(nil)
+		require.NoError(t, err)
+		m2, err := aliceDkg.Next(m1)
+		require.NoError(t, err)
+		m3, err := bobDkg.Next(m2)
+		require.NoError(t, err)
+
+		proof, err := decodeDkgRound4Input(m3)
+		require.NoError(t, err)
+		proof.S = proof.S.Add(curve.Scalar.One())
+		m3, err = encodeDkgRound3Output(proof, protocol.Version1)
+		require.NoError(t, err)
+		_, err = aliceDkg.Next(m3)
+		culprit, ok := Blame(err)
+		require.True(t, ok)
+		require.Equal(t, PartyBob, culprit)
+		var abort *AbortError
+		require.ErrorAs(t, err, &abort)
+		require.NoError(t, VerifyEvidence(curve, abort))
+
+		// the proof cannot be checked without the transcript of alice
+		abort.Evidence.Transcript = nil
+		require.Error(t, VerifyEvidence(curve, abort))
+	})
+
+	_, ok := Blame(protocol.ErrProtocolFinished)
+	require.False(t, ok)
+}
+
+func TestRunnerProto(t *testing.T) {
+	curve := curves.K256()
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	run := func(alice, bob protocol.Iterator, bobFirst bool) (*protocol.Message, *protocol.Message) {
+		aliceTransport, bobTransport := protocol.Pipe("alice", "bob")
+		aliceRunner := &protocol.Runner{Self: "alice", Peer: "bob", Transport: aliceTransport, Timeout: 10 * time.Second}
+		bobRunner := &protocol.Runner{Self: "bob", Peer: "alice", Transport: bobTransport, Timeout: 10 * time.Second}
+		aliceDone := make(chan error, 1)
+		go func() { aliceDone <- aliceRunner.Run(ctx, alice, !bobFirst) }()
+		require.NoError(t, bobRunner.Run(ctx, bob, bobFirst))
+		require.NoError(t, <-aliceDone)
+		aliceResult, aErr := alice.Result(protocol.Version1)
+		bobResult, err := bob.Result(protocol.Version1)
+		require.NoError(t, err)
+		if aErr != nil {
+			return nil, bobResult
+		}
+		return aliceResult, bobResult
+	}
+
+	aliceDkgResult, bobDkgResult := run(NewAliceDkg(curve, protocol.Version1), NewBobDkg(curve, protocol.Version1), true)
+	require.NotNil(t, aliceDkgResult)
+	require.NotNil(t, bobDkgResult)
+
+	message := []byte("signed over a transport")
+	aliceSign, err := NewAliceSign(curve, sha3.New256(), message, aliceDkgResult, protocol.Version1)
+	require.NoError(t, err)
+	bobSign, err := NewBobSign(curve, sha3.New256(), message, bobDkgResult, protocol.Version1)
+	require.NoError(t, err)
+	_, signatureMessage := run(aliceSign, bobSign, false)
+	signature, err := DecodeSignature(signatureMessage)
+	require.NoError(t, err)
+	require.NotNil(t, signature)
+}
@@ -16,10 +16,10 @@ package refresh
 import (
 	"crypto/rand"
 
-	"github.com/gtank/merlin"
 	"github.com/pkg/errors"
 
 	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
 	"github.com/go-sonr/crypto/ot/base/simplest"
 	"github.com/go-sonr/crypto/ot/extension/kos"
 	"github.com/go-sonr/crypto/tecdsa/dklsv1/dkg"
@@ -40,7 +40,7 @@ type Alice struct {
 
 	curve *curves.Curve
 
-	transcript *merlin.Transcript
+	transcript *transcript.Transcript
 }
 
 // Bob struct encoding Bob's state during one execution of the overall signing algorithm.
@@ -57,7 +57,7 @@ type Bob struct {
 
 	curve *curves.Curve
 
-	transcript *merlin.Transcript
+	transcript *transcript.Transcript
 }
 
 type RefreshRound2Output struct {
@@ -71,7 +71,7 @@ func NewAlice(curve *curves.Curve, dkgOutput *dkg.AliceOutput) *Alice {
 		curve:          curve,
 		secretKeyShare: dkgOutput.SecretKeyShare,
 		publicKey:      dkgOutput.PublicKey,
-		transcript:     merlin.NewTranscript("Coinbase_DKLs_Refresh"),
+		transcript:     transcript.NewRecorded("Coinbase_DKLs_Refresh"),
 	}
 }
 
@@ -81,30 +81,25 @@ func NewBob(curve *curves.Curve, dkgOutput *dkg.BobOutput) *Bob {
 		curve:          curve,
 		secretKeyShare: dkgOutput.SecretKeyShare,
 		publicKey:      dkgOutput.PublicKey,
-		transcript:     merlin.NewTranscript("Coinbase_DKLs_Refresh"),
+		transcript:     transcript.NewRecorded("Coinbase_DKLs_Refresh"),
 	}
 }
 
 func (alice *Alice) Round1RefreshGenerateSeed() curves.Scalar {
 	refreshSeed := alice.curve.Scalar.Random(rand.Reader)
-	alice.transcript.AppendMessage([]byte("alice refresh seed"), refreshSeed.Bytes())
+	alice.transcript.AppendScalar([]byte("alice refresh seed"), refreshSeed)
 	return refreshSeed
 }
 
 func (bob *Bob) Round2RefreshProduceSeedAndMultiplyAndStartOT(aliceSeed curves.Scalar) (*RefreshRound2Output, error) {
-	bob.transcript.AppendMessage([]byte("alice refresh seed"), aliceSeed.Bytes())
+	bob.transcript.AppendScalar([]byte("alice refresh seed"), aliceSeed)
 	bobSeed := bob.curve.Scalar.Random(rand.Reader)
-	bob.transcript.AppendMessage([]byte("bob refresh seed"), bobSeed.Bytes())
-	k, err := bob.curve.NewScalar().SetBytes(
-		bob.transcript.ExtractBytes([]byte("secret key share multiplier"), simplest.DigestSize),
-	)
+	k, uniqueSessionId, err := multiplierAndSalt(bob.curve, bob.transcript, bobSeed)
 	if err != nil {
 		return nil, errors.Wrap(err, "couldn't produce bob's secret key share multiplier")
 	}
 	bob.secretKeyShare = bob.secretKeyShare.Mul(k)
 
-	uniqueSessionId := [simplest.DigestSize]byte{} // note: will use and re-use this below for sub-session IDs.
-	copy(uniqueSessionId[:], bob.transcript.ExtractBytes([]byte("salt for simplest OT"), simplest.DigestSize))
 	bob.sender, err = simplest.NewSender(bob.curve, kos.Kappa, uniqueSessionId)
 	if err != nil {
 		return nil, errors.Wrap(err, "bob constructing new OT sender in refresh round 2")
@@ -121,10 +116,7 @@ func (bob *Bob) Round2RefreshProduceSeedAndMultiplyAndStartOT(aliceSeed curves.S
 }
 
 func (alice *Alice) Round3RefreshMultiplyRound2Ot(input *RefreshRound2Output) ([]simplest.ReceiversMaskedChoices, error) {
-	alice.transcript.AppendMessage([]byte("bob refresh seed"), input.BobMultiplier.Bytes())
-	k, err := alice.curve.NewScalar().SetBytes(
-		alice.transcript.ExtractBytes([]byte("secret key share multiplier"), simplest.DigestSize),
-	)
+	k, uniqueSessionId, err := multiplierAndSalt(alice.curve, alice.transcript, input.BobMultiplier)
 	if err != nil {
 		return nil, errors.Wrap(err, "couldn't produce bob's secret key share multiplier")
 	}
@@ -134,8 +126,6 @@ func (alice *Alice) Round3RefreshMultiplyRound2Ot(input *RefreshRound2Output) ([
 	}
 	alice.secretKeyShare = alice.secretKeyShare.Mul(kInverse)
 
-	uniqueSessionId := [simplest.DigestSize]byte{} // note: will use and re-use this below for sub-session IDs.
-	copy(uniqueSessionId[:], alice.transcript.ExtractBytes([]byte("salt for simplest OT"), simplest.DigestSize))
 	alice.receiver, err = simplest.NewReceiver(alice.curve, kos.Kappa, uniqueSessionId)
 	if err != nil {
 		return nil, errors.Wrap(err, "couldn't construct OT receiver")
@@ -144,6 +134,19 @@ func (alice *Alice) Round3RefreshMultiplyRound2Ot(input *RefreshRound2Output) ([
 	return alice.receiver.Round2VerifySchnorrAndPadTransfer(input.SeedOTRound1Output)
 }
 
+// multiplierAndSalt appends the seed of Bob to the transcript and extracts the secret key share multiplier and the
+// session id of the seed OT.
+func multiplierAndSalt(curve *curves.Curve, tr *transcript.Transcript, bobSeed curves.Scalar) (curves.Scalar, [simplest.DigestSize]byte, error) {
+	uniqueSessionId := [simplest.DigestSize]byte{} // note: will use and re-use this below for sub-session IDs.
+	tr.AppendScalar([]byte("bob refresh seed"), bobSeed)
+	k, err := curve.NewScalar().SetBytes(tr.ExtractBytes([]byte("secret key share multiplier"), simplest.DigestSize))
+	if err != nil {
+		return nil, uniqueSessionId, err
+	}
+	copy(uniqueSessionId[:], tr.ExtractBytes([]byte("salt for simplest OT"), simplest.DigestSize))
+	return k, uniqueSessionId, nil
+}
+
 func (bob *Bob) Round4RefreshRound3Ot(compressedReceiversMaskedChoice []simplest.ReceiversMaskedChoices) ([]simplest.OtChallenge, error) {
 	return bob.sender.Round3PadTransfer(compressedReceiversMaskedChoice)
 }
@@ -0,0 +1,185 @@
+// This file implements resharing the joint key to a new pair of parties. Shares are multiplicative, sk = sk_A * sk_B,
+// so each old party splits its share into two factors, one for each new party:
+//  1. Handoff:
+//     1.1. the old alice samples u <-- F_q, sends sk_A * u to the new alice and u^{-1} to the new bob.
+//     1.2. the old bob samples w <-- F_q, sends sk_B * w to the new bob and w^{-1} to the new alice.
+//     1.3. the new alice holds sk_A * u * w^{-1} and the new bob sk_B * w * u^{-1}, whose product is sk.
+//  2. Refresh: the new pair runs the refresh protocol on these shares, and each party proves its refreshed public
+//     key share, which the counterparty checks against the joint public key with its own share.
+//
+// The old parties must delete their shares after the handoff, and the handoffs travel over private channels.
+// DKLs18 is a 2-of-2 protocol, so the new participant set is again one Alice and one Bob.
+
+package refresh
+
+import (
+	"crypto/rand"
+
+	"github.com/pkg/errors"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
+	"github.com/go-sonr/crypto/ot/base/simplest"
+	"github.com/go-sonr/crypto/zkp/schnorr"
+)
+
+var (
+	aliceProofLabel = []byte("salt for alice reshare proof")
+	bobProofLabel   = []byte("salt for bob reshare proof")
+)
+
+// Handoff is a factor of the key share of an old party, sent to one party of the new pair.
+type Handoff struct {
+	PublicKey curves.Point
+	Factor    curves.Scalar
+}
+
+// ReshareRound2Output is the refresh round 2 output of Bob with a proof of his refreshed public key share.
+type ReshareRound2Output struct {
+	Refresh *RefreshRound2Output
+	Proof   *schnorr.Proof
+}
+
+// ReshareRound3Output is the refresh round 3 output of Alice with a proof of her refreshed public key share.
+type ReshareRound3Output struct {
+	Choices []simplest.ReceiversMaskedChoices
+	Proof   *schnorr.Proof
+}
+
+// SplitShare splits the key share of an old party. The new party of the same role gets share * u and the other
+// new party u^{-1}, for a random u.
+func SplitShare(curve *curves.Curve, share curves.Scalar, publicKey curves.Point) (same, other *Handoff, err error) {
+	if share == nil || share.IsZero() || publicKey == nil || publicKey.IsIdentity() {
+		return nil, nil, errors.New("invalid key share")
+	}
+	u := curve.Scalar.Random(rand.Reader)
+	uInverse, err := u.Invert()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "couldn't invert share factor")
+	}
+	return &Handoff{PublicKey: publicKey, Factor: share.Mul(u)}, &Handoff{PublicKey: publicKey, Factor: uInverse}, nil
+}
+
+// combineHandoffs returns the key share of a new party from the handoffs of the old Alice and the old Bob.
+func combineHandoffs(fromAlice, fromBob *Handoff) (curves.Scalar, curves.Point, error) {
+	for _, h := range []*Handoff{fromAlice, fromBob} {
+		if h == nil || h.PublicKey == nil || h.PublicKey.IsIdentity() || h.Factor == nil || h.Factor.IsZero() {
+			return nil, nil, errors.New("invalid handoff")
+		}
+	}
+	if !fromAlice.PublicKey.Equal(fromBob.PublicKey) {
+		return nil, nil, errors.New("handoffs are for different public keys")
+	}
+	return fromAlice.Factor.Mul(fromBob.Factor), fromAlice.PublicKey, nil
+}
+
+// NewAliceReshare creates the new Alice of a reshare from her handoffs of the old Alice and the old Bob.
+func NewAliceReshare(curve *curves.Curve, fromAlice, fromBob *Handoff) (*Alice, error) {
+	share, publicKey, err := combineHandoffs(fromAlice, fromBob)
+	if err != nil {
+		return nil, err
+	}
+	return &Alice{
+		curve:          curve,
+		secretKeyShare: share,
+		publicKey:      publicKey,
+		transcript:     transcript.NewRecorded("go-sonr dkls reshare v1"),
+	}, nil
+}
+
+// NewBobReshare creates the new Bob of a reshare from his handoffs of the old Alice and the old Bob.
+func NewBobReshare(curve *curves.Curve, fromAlice, fromBob *Handoff) (*Bob, error) {
+	share, publicKey, err := combineHandoffs(fromAlice, fromBob)
+	if err != nil {
+		return nil, err
+	}
+	return &Bob{
+		curve:          curve,
+		secretKeyShare: share,
+		publicKey:      publicKey,
+		transcript:     transcript.NewRecorded("go-sonr dkls reshare v1"),
+	}, nil
+}
+
+// Round2ReshareProduceSeedAndProve runs refresh round 2 and proves the refreshed public key share of Bob.
+func (bob *Bob) Round2ReshareProduceSeedAndProve(aliceSeed curves.Scalar) (*ReshareRound2Output, error) {
+	output, err := bob.Round2RefreshProduceSeedAndMultiplyAndStartOT(aliceSeed)
+	if err != nil {
+		return nil, err
+	}
+	salt := bob.transcript.ExtractBytes(bobProofLabel, simplest.DigestSize)
+	proof, err := schnorr.NewProver(bob.curve, nil, salt).Prove(bob.secretKeyShare)
+	if err != nil {
+		return nil, errors.Wrap(err, "bob proving his public key share in reshare round 2")
+	}
+	return &ReshareRound2Output{Refresh: output, Proof: proof}, nil
+}
+
+// Round3ReshareVerifyAndProve runs refresh round 3, checks the public key share of Bob and proves the refreshed
+// public key share of Alice.
+func (alice *Alice) Round3ReshareVerifyAndProve(input *ReshareRound2Output) (*ReshareRound3Output, error) {
+	if input == nil || input.Refresh == nil {
+		return nil, errors.New("missing reshare round 2 output")
+	}
+	choices, err := alice.Round3RefreshMultiplyRound2Ot(input.Refresh)
+	if err != nil {
+		return nil, err
+	}
+	if err = verifyShare(alice.curve, alice.transcript, bobProofLabel, input.Proof, alice.secretKeyShare, alice.publicKey); err != nil {
+		return nil, errors.Wrap(err, "alice checking bob's public key share in reshare round 3")
+	}
+	aliceSalt := alice.transcript.ExtractBytes(aliceProofLabel, simplest.DigestSize)
+	proof, err := schnorr.NewProver(alice.curve, nil, aliceSalt).Prove(alice.secretKeyShare)
+	if err != nil {
+		return nil, errors.Wrap(err, "alice proving her public key share in reshare round 3")
+	}
+	return &ReshareRound3Output{Choices: choices, Proof: proof}, nil
+}
+
+// Round4ReshareVerify checks the public key share of Alice and runs refresh round 4.
+func (bob *Bob) Round4ReshareVerify(input *ReshareRound3Output) ([]simplest.OtChallenge, error) {
+	if input == nil {
+		return nil, errors.New("missing reshare round 3 output")
+	}
+	if err := verifyShare(bob.curve, bob.transcript, aliceProofLabel, input.Proof, bob.secretKeyShare, bob.publicKey); err != nil {
+		return nil, errors.Wrap(err, "bob checking alice's public key share in reshare round 4")
+	}
+	return bob.Round4RefreshRound3Ot(input.Choices)
+}
+
+// verifyShare checks the proof of the public key share of the counterparty, and that share times it is the joint
+// public key.
+func verifyShare(curve *curves.Curve, tr *transcript.Transcript, label []byte, proof *schnorr.Proof, share curves.Scalar, publicKey curves.Point) error {
+	if err := verifyProof(curve, tr, label, proof); err != nil {
+		return err
+	}
+	if !proof.Statement.Mul(share).Equal(publicKey) {
+		return errors.New("public key shares don't match the public key")
+	}
+	return nil
+}
+
+func verifyProof(curve *curves.Curve, tr *transcript.Transcript, label []byte, proof *schnorr.Proof) error {
+	return schnorr.Verify(proof, curve, nil, tr.ExtractBytes(label, simplest.DigestSize))
+}
+
+// CheckBobReshareProof checks the proof of Bob in a reshare round 2 output against tr, the transcript of Alice before
+// her round 3, for a third party that holds the evidence of an abort.
+func CheckBobReshareProof(curve *curves.Curve, tr *transcript.Transcript, input *ReshareRound2Output) error {
+	if input == nil || input.Refresh == nil {
+		return errors.New("missing reshare round 2 output")
+	}
+	if _, _, err := multiplierAndSalt(curve, tr, input.Refresh.BobMultiplier); err != nil {
+		return err
+	}
+	return verifyProof(curve, tr, bobProofLabel, input.Proof)
+}
+
+// CheckAliceReshareProof checks the proof of Alice in a reshare round 3 output against tr, the transcript of Bob
+// before his round 4, for a third party that holds the evidence of an abort.
+func CheckAliceReshareProof(curve *curves.Curve, tr *transcript.Transcript, input *ReshareRound3Output) error {
+	if input == nil {
+		return errors.New("missing reshare round 3 output")
+	}
+	return verifyProof(curve, tr, aliceProofLabel, input.Proof)
+}
@@ -0,0 +1,131 @@
+package refresh
+
+import (
+	"bytes"
+	"encoding/gob"
+
+	"github.com/pkg/errors"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
+	"github.com/go-sonr/crypto/ot/base/simplest"
+)
+
+// partyState is the serialized state of Alice or Bob between rounds. OT is
+// the receiver of Alice or the sender of Bob, empty until the OT starts.
+type partyState struct {
+	Curve          string
+	OT             []byte
+	SecretKeyShare []byte
+	PublicKey      []byte
+	Transcript     []byte
+}
+
+func (s *partyState) encode(curve *curves.Curve, ot interface{ MarshalBinary() ([]byte, error) }, sk curves.Scalar, pk curves.Point, tr *transcript.Transcript) ([]byte, error) {
+	var err error
+	s.Curve = curve.Name
+	s.SecretKeyShare = sk.Bytes()
+	s.PublicKey = pk.ToAffineCompressed()
+	if s.Transcript, err = tr.MarshalBinary(); err != nil {
+		return nil, err
+	}
+	if ot != nil {
+		if s.OT, err = ot.MarshalBinary(); err != nil {
+			return nil, err
+		}
+	}
+	buf := new(bytes.Buffer)
+	if err := gob.NewEncoder(buf).Encode(s); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (s *partyState) decode(data []byte) (*curves.Curve, curves.Scalar, curves.Point, *transcript.Transcript, error) {
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(s); err != nil {
+		return nil, nil, nil, nil, errors.Wrap(err, "decoding refresh state")
+	}
+	curve := curves.GetCurveByName(s.Curve)
+	if curve == nil {
+		return nil, nil, nil, nil, errors.Errorf("unknown curve %s", s.Curve)
+	}
+	sk, err := curve.Scalar.SetBytes(s.SecretKeyShare)
+	if err != nil {
+		return nil, nil, nil, nil, errors.Wrap(err, "decoding secret key share")
+	}
+	pk, err := curve.Point.FromAffineCompressed(s.PublicKey)
+	if err != nil {
+		return nil, nil, nil, nil, errors.Wrap(err, "decoding public key")
+	}
+	tr := new(transcript.Transcript)
+	if err := tr.UnmarshalBinary(s.Transcript); err != nil {
+		return nil, nil, nil, nil, errors.Wrap(err, "decoding refresh transcript")
+	}
+	return curve, sk, pk, tr, nil
+}
+
+// MarshalBinary serializes the state of Alice between rounds, including her
+// secret key share. The result must be protected as the key share itself.
+func (alice *Alice) MarshalBinary() ([]byte, error) {
+	var ot interface{ MarshalBinary() ([]byte, error) }
+	if alice.receiver != nil {
+		ot = alice.receiver
+	}
+	return new(partyState).encode(alice.curve, ot, alice.secretKeyShare, alice.publicKey, alice.transcript)
+}
+
+// UnmarshalBinary restores Alice serialized by MarshalBinary
+func (alice *Alice) UnmarshalBinary(data []byte) error {
+	state := new(partyState)
+	curve, sk, pk, tr, err := state.decode(data)
+	if err != nil {
+		return err
+	}
+	restored := &Alice{curve: curve, secretKeyShare: sk, publicKey: pk, transcript: tr}
+	if state.OT != nil {
+		restored.receiver = new(simplest.Receiver)
+		if err := restored.receiver.UnmarshalBinary(state.OT); err != nil {
+			return err
+		}
+	}
+	*alice = *restored
+	return nil
+}
+
+// MarshalBinary serializes the state of Bob between rounds, including his
+// secret key share. The result must be protected as the key share itself.
+func (bob *Bob) MarshalBinary() ([]byte, error) {
+	var ot interface{ MarshalBinary() ([]byte, error) }
+	if bob.sender != nil {
+		ot = bob.sender
+	}
+	return new(partyState).encode(bob.curve, ot, bob.secretKeyShare, bob.publicKey, bob.transcript)
+}
+
+// UnmarshalBinary restores Bob serialized by MarshalBinary
+func (bob *Bob) UnmarshalBinary(data []byte) error {
+	state := new(partyState)
+	curve, sk, pk, tr, err := state.decode(data)
+	if err != nil {
+		return err
+	}
+	restored := &Bob{curve: curve, secretKeyShare: sk, publicKey: pk, transcript: tr}
+	if state.OT != nil {
+		restored.sender = new(simplest.Sender)
+		if err := restored.sender.UnmarshalBinary(state.OT); err != nil {
+			return err
+		}
+	}
+	*bob = *restored
+	return nil
+}
+
+// MarshalTranscript serializes the transcript of Alice, which holds only public messages, as evidence of an abort
+func (alice *Alice) MarshalTranscript() ([]byte, error) {
+	return alice.transcript.MarshalBinary()
+}
+
+// MarshalTranscript serializes the transcript of Bob, which holds only public messages, as evidence of an abort
+func (bob *Bob) MarshalTranscript() ([]byte, error) {
+	return bob.transcript.MarshalBinary()
+}
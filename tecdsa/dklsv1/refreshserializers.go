@@ -1,18 +1,25 @@
 package dklsv1
 
 import (
-	"bytes"
-	"encoding/gob"
-
 	"github.com/pkg/errors"
 
 	"github.com/go-sonr/crypto/core/curves"
 	"github.com/go-sonr/crypto/core/protocol"
+	"github.com/go-sonr/crypto/core/protocol/messages"
 	"github.com/go-sonr/crypto/ot/base/simplest"
 	"github.com/go-sonr/crypto/tecdsa/dklsv1/dkg"
 	"github.com/go-sonr/crypto/tecdsa/dklsv1/refresh"
 )
 
+const (
+	refreshRound1Type = "dkls18-refresh/round1"
+	refreshRound2Type = "dkls18-refresh/round2"
+	refreshRound3Type = "dkls18-refresh/round3"
+	refreshRound4Type = "dkls18-refresh/round4"
+	refreshRound5Type = "dkls18-refresh/round5"
+	refreshRound6Type = "dkls18-refresh/round6"
+)
+
 func newRefreshProtocolMessage(payload []byte, round string, version uint) *protocol.Message {
 	return &protocol.Message{
 		Protocol: protocol.Dkls18Refresh,
@@ -33,49 +40,57 @@ func encodeRefreshRound1Output(seed curves.Scalar, version uint) (*protocol.Mess
 	if err := versionIsSupported(version); err != nil {
 		return nil, errors.Wrap(err, "version error")
 	}
-	registerTypes()
-	buf := bytes.NewBuffer([]byte{})
-	enc := gob.NewEncoder(buf)
-	if err := enc.Encode(&seed); err != nil {
+	curve, err := scalarCurve(seed)
+	if err != nil {
+		return nil, err
+	}
+	enc := messages.NewEncoder(refreshRound1Type, curve)
+	enc.WriteScalar(seed)
+	payload, err := enc.Finish()
+	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return newRefreshProtocolMessage(buf.Bytes(), "1", version), nil
+	return newRefreshProtocolMessage(payload, "1", version), nil
 }
 
 func decodeRefreshRound2Input(m *protocol.Message) (curves.Scalar, error) {
-	if err := versionIsSupported(m.Version); err != nil {
-		return nil, errors.Wrap(err, "version error")
+	dec, err := decodePayload(m, protocol.Dkls18Refresh, "1", refreshRound1Type)
+	if err != nil {
+		return nil, err
 	}
-	buf := bytes.NewBuffer(m.Payloads[payloadKey])
-	dec := gob.NewDecoder(buf)
-	decoded := new(curves.Scalar)
-	if err := dec.Decode(&decoded); err != nil {
-		return nil, errors.WithStack(err)
+	seed := dec.ReadScalar()
+	if err = dec.Finish(); err != nil {
+		return nil, err
 	}
-	return *decoded, nil
+	return seed, nil
 }
 
 func encodeRefreshRound2Output(output *refresh.RefreshRound2Output, version uint) (*protocol.Message, error) {
 	if err := versionIsSupported(version); err != nil {
 		return nil, errors.Wrap(err, "version error")
 	}
-	buf := bytes.NewBuffer([]byte{})
-	enc := gob.NewEncoder(buf)
-	if err := enc.Encode(output); err != nil {
+	curve, err := scalarCurve(output.BobMultiplier)
+	if err != nil {
+		return nil, err
+	}
+	enc := messages.NewEncoder(refreshRound2Type, curve)
+	writeProof(enc, output.SeedOTRound1Output)
+	enc.WriteScalar(output.BobMultiplier)
+	payload, err := enc.Finish()
+	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return newRefreshProtocolMessage(buf.Bytes(), "2", version), nil
+	return newRefreshProtocolMessage(payload, "2", version), nil
 }
 
 func decodeRefreshRound3Input(m *protocol.Message) (*refresh.RefreshRound2Output, error) {
-	if err := versionIsSupported(m.Version); err != nil {
-		return nil, errors.Wrap(err, "version error")
+	dec, err := decodePayload(m, protocol.Dkls18Refresh, "2", refreshRound2Type)
+	if err != nil {
+		return nil, err
 	}
-	buf := bytes.NewBuffer(m.Payloads[payloadKey])
-	dec := gob.NewDecoder(buf)
-	decoded := new(refresh.RefreshRound2Output)
-	if err := dec.Decode(decoded); err != nil {
-		return nil, errors.WithStack(err)
+	decoded := &refresh.RefreshRound2Output{SeedOTRound1Output: readProof(dec), BobMultiplier: dec.ReadScalar()}
+	if err = dec.Finish(); err != nil {
+		return nil, err
 	}
 	return decoded, nil
 }
@@ -84,100 +99,109 @@ func encodeRefreshRound3Output(choices []simplest.ReceiversMaskedChoices, versio
 	if err := versionIsSupported(version); err != nil {
 		return nil, errors.Wrap(err, "version error")
 	}
-	buf := bytes.NewBuffer([]byte{})
-	enc := gob.NewEncoder(buf)
-	if err := enc.Encode(choices); err != nil {
+	enc := messages.NewEncoder(refreshRound3Type, nil)
+	writeChoices(enc, choices)
+	payload, err := enc.Finish()
+	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return newRefreshProtocolMessage(buf.Bytes(), "3", version), nil
+	return newRefreshProtocolMessage(payload, "3", version), nil
 }
 
 func decodeRefreshRound4Input(m *protocol.Message) ([]simplest.ReceiversMaskedChoices, error) {
-	if err := versionIsSupported(m.Version); err != nil {
-		return nil, errors.Wrap(err, "version error")
+	dec, err := decodePayload(m, protocol.Dkls18Refresh, "3", refreshRound3Type)
+	if err != nil {
+		return nil, err
 	}
-	buf := bytes.NewBuffer(m.Payloads[payloadKey])
-	dec := gob.NewDecoder(buf)
-	decoded := []simplest.ReceiversMaskedChoices{}
-	if err := dec.Decode(&decoded); err != nil {
-		return nil, errors.WithStack(err)
+	choices := readChoices(dec)
+	if err = dec.Finish(); err != nil {
+		return nil, err
 	}
-	return decoded, nil
+	return choices, nil
 }
 
 func encodeRefreshRound4Output(challenge []simplest.OtChallenge, version uint) (*protocol.Message, error) {
 	if err := versionIsSupported(version); err != nil {
 		return nil, errors.Wrap(err, "version error")
 	}
-	buf := bytes.NewBuffer([]byte{})
-	enc := gob.NewEncoder(buf)
-	if err := enc.Encode(challenge); err != nil {
+	enc := messages.NewEncoder(refreshRound4Type, nil)
+	writeChallenges(enc, challenge)
+	payload, err := enc.Finish()
+	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return newRefreshProtocolMessage(buf.Bytes(), "4", version), nil
+	return newRefreshProtocolMessage(payload, "4", version), nil
 }
 
 func decodeRefreshRound5Input(m *protocol.Message) ([]simplest.OtChallenge, error) {
-	if err := versionIsSupported(m.Version); err != nil {
-		return nil, errors.Wrap(err, "version error")
+	dec, err := decodePayload(m, protocol.Dkls18Refresh, "4", refreshRound4Type)
+	if err != nil {
+		return nil, err
 	}
-	buf := bytes.NewBuffer(m.Payloads[payloadKey])
-	dec := gob.NewDecoder(buf)
-	decoded := []simplest.OtChallenge{}
-	if err := dec.Decode(&decoded); err != nil {
-		return nil, errors.WithStack(err)
+	challenges, err := readChallenges(dec)
+	if err != nil {
+		return nil, err
 	}
-	return decoded, nil
+	if err = dec.Finish(); err != nil {
+		return nil, err
+	}
+	return challenges, nil
 }
 
 func encodeRefreshRound5Output(responses []simplest.OtChallengeResponse, version uint) (*protocol.Message, error) {
 	if err := versionIsSupported(version); err != nil {
 		return nil, errors.Wrap(err, "version error")
 	}
-	buf := bytes.NewBuffer([]byte{})
-	enc := gob.NewEncoder(buf)
-	if err := enc.Encode(responses); err != nil {
+	enc := messages.NewEncoder(refreshRound5Type, nil)
+	writeChallenges(enc, responses)
+	payload, err := enc.Finish()
+	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return newRefreshProtocolMessage(buf.Bytes(), "5", version), nil
+	return newRefreshProtocolMessage(payload, "5", version), nil
 }
 
 func decodeRefreshRound6Input(m *protocol.Message) ([]simplest.OtChallengeResponse, error) {
-	if err := versionIsSupported(m.Version); err != nil {
-		return nil, errors.Wrap(err, "version error")
+	dec, err := decodePayload(m, protocol.Dkls18Refresh, "5", refreshRound5Type)
+	if err != nil {
+		return nil, err
 	}
-	buf := bytes.NewBuffer(m.Payloads[payloadKey])
-	dec := gob.NewDecoder(buf)
-	decoded := []simplest.OtChallengeResponse{}
-	if err := dec.Decode(&decoded); err != nil {
-		return nil, errors.WithStack(err)
+	responses, err := readChallenges(dec)
+	if err != nil {
+		return nil, err
 	}
-	return decoded, nil
+	if err = dec.Finish(); err != nil {
+		return nil, err
+	}
+	return responses, nil
 }
 
 func encodeRefreshRound6Output(opening []simplest.ChallengeOpening, version uint) (*protocol.Message, error) {
 	if err := versionIsSupported(version); err != nil {
 		return nil, errors.Wrap(err, "version error")
 	}
-	buf := bytes.NewBuffer([]byte{})
-	enc := gob.NewEncoder(buf)
-	if err := enc.Encode(opening); err != nil {
+	enc := messages.NewEncoder(refreshRound6Type, nil)
+	writeDigestPairs(enc, opening)
+	payload, err := enc.Finish()
+	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return newRefreshProtocolMessage(buf.Bytes(), "6", version), nil
+	return newRefreshProtocolMessage(payload, "6", version), nil
 }
 
 func decodeRefreshRound7Input(m *protocol.Message) ([]simplest.ChallengeOpening, error) {
-	if err := versionIsSupported(m.Version); err != nil {
-		return nil, errors.Wrap(err, "version error")
+	dec, err := decodePayload(m, protocol.Dkls18Refresh, "6", refreshRound6Type)
+	if err != nil {
+		return nil, err
 	}
-	buf := bytes.NewBuffer(m.Payloads[payloadKey])
-	dec := gob.NewDecoder(buf)
-	decoded := []simplest.ChallengeOpening{}
-	if err := dec.Decode(&decoded); err != nil {
-		return nil, errors.WithStack(err)
+	openings, err := readDigestPairs(dec)
+	if err != nil {
+		return nil, err
 	}
-	return decoded, nil
+	if err = dec.Finish(); err != nil {
+		return nil, err
+	}
+	return openings, nil
 }
 
 // EncodeAliceRefreshOutput serializes Alice Refresh output based on the protocol version.
@@ -185,13 +209,11 @@ func EncodeAliceRefreshOutput(result *dkg.AliceOutput, version uint) (*protocol.
 	if err := versionIsSupported(version); err != nil {
 		return nil, errors.Wrap(err, "version error")
 	}
-	registerTypes()
-	buf := bytes.NewBuffer([]byte{})
-	enc := gob.NewEncoder(buf)
-	if err := enc.Encode(result); err != nil {
+	payload, err := encodeAliceKeyShare(result)
+	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return newRefreshProtocolMessage(buf.Bytes(), "alice-output", version), nil
+	return newRefreshProtocolMessage(payload, "alice-output", version), nil
 }
 
 // DecodeAliceRefreshResult deserializes Alice refresh output.
@@ -199,11 +221,8 @@ func DecodeAliceRefreshResult(m *protocol.Message) (*dkg.AliceOutput, error) {
 	if err := versionIsSupported(m.Version); err != nil {
 		return nil, errors.Wrap(err, "version error")
 	}
-	registerTypes()
-	buf := bytes.NewBuffer(m.Payloads[payloadKey])
-	dec := gob.NewDecoder(buf)
-	decoded := new(dkg.AliceOutput)
-	if err := dec.Decode(&decoded); err != nil {
+	decoded, err := decodeAliceKeyShare(m.Payloads[payloadKey])
+	if err != nil {
 		return nil, errors.WithStack(err)
 	}
 	return decoded, nil
@@ -214,13 +233,11 @@ func EncodeBobRefreshOutput(result *dkg.BobOutput, version uint) (*protocol.Mess
 	if err := versionIsSupported(version); err != nil {
 		return nil, errors.Wrap(err, "version error")
 	}
-	registerTypes()
-	buf := bytes.NewBuffer([]byte{})
-	enc := gob.NewEncoder(buf)
-	if err := enc.Encode(result); err != nil {
+	payload, err := encodeBobKeyShare(result)
+	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return newRefreshProtocolMessage(buf.Bytes(), "bob-output", version), nil
+	return newRefreshProtocolMessage(payload, "bob-output", version), nil
 }
 
 // DecodeBobRefreshResult deserializes Bob refhresh output.
@@ -228,10 +245,8 @@ func DecodeBobRefreshResult(m *protocol.Message) (*dkg.BobOutput, error) {
 	if err := versionIsSupported(m.Version); err != nil {
 		return nil, errors.Wrap(err, "version error")
 	}
-	buf := bytes.NewBuffer(m.Payloads[payloadKey])
-	dec := gob.NewDecoder(buf)
-	decoded := new(dkg.BobOutput)
-	if err := dec.Decode(&decoded); err != nil {
+	decoded, err := decodeBobKeyShare(m.Payloads[payloadKey])
+	if err != nil {
 		return nil, errors.WithStack(err)
 	}
 	return decoded, nil
@@ -0,0 +1,245 @@
+package dklsv1
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/protocol"
+	"github.com/go-sonr/crypto/core/protocol/messages"
+	"github.com/go-sonr/crypto/ot/base/simplest"
+	"github.com/go-sonr/crypto/tecdsa/dklsv1/refresh"
+)
+
+// Reshare payloads, one message type per round.
+const (
+	reshareHandoffType = "dkls18-reshare/handoff"
+	reshareRound1Type  = "dkls18-reshare/round1"
+	reshareRound2Type  = "dkls18-reshare/round2"
+	reshareRound3Type  = "dkls18-reshare/round3"
+	reshareRound4Type  = "dkls18-reshare/round4"
+	reshareRound5Type  = "dkls18-reshare/round5"
+	reshareRound6Type  = "dkls18-reshare/round6"
+)
+
+func newReshareProtocolMessage(payload []byte, round string, version uint) *protocol.Message {
+	return &protocol.Message{
+		Protocol: protocol.Dkls18Reshare,
+		Version:  version,
+		Payloads: map[string][]byte{payloadKey: payload},
+		Metadata: map[string]string{"round": round},
+	}
+}
+
+// decodeReshareMessage checks the envelope of a reshare message of the given round and starts decoding its payload
+func decodeReshareMessage(m *protocol.Message, round, msgType string) (*messages.Decoder, error) {
+	return decodePayload(m, protocol.Dkls18Reshare, round, msgType)
+}
+
+// EncodeReshareHandoff serializes a handoff of the old party from to the new party to.
+func EncodeReshareHandoff(handoff *refresh.Handoff, from, to Party, version uint) (*protocol.Message, error) {
+	if err := versionIsSupported(version); err != nil {
+		return nil, errors.Wrap(err, "version error")
+	}
+	curve, err := scalarCurve(handoff.Factor)
+	if err != nil {
+		return nil, errors.Wrap(err, "encoding reshare handoff")
+	}
+	enc := messages.NewEncoder(reshareHandoffType, curve)
+	enc.WritePoint(handoff.PublicKey)
+	enc.WriteScalar(handoff.Factor)
+	payload, err := enc.Finish()
+	if err != nil {
+		return nil, errors.Wrap(err, "encoding reshare handoff")
+	}
+	return newReshareProtocolMessage(payload, "handoff-"+from.String()+"-to-"+to.String(), version), nil
+}
+
+// DecodeReshareHandoff deserializes a handoff of the old party from to the new party to.
+func DecodeReshareHandoff(m *protocol.Message, from, to Party) (*refresh.Handoff, error) {
+	dec, err := decodeReshareMessage(m, "handoff-"+from.String()+"-to-"+to.String(), reshareHandoffType)
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding reshare handoff")
+	}
+	handoff := &refresh.Handoff{PublicKey: dec.ReadPoint(), Factor: dec.ReadScalar()}
+	if err = dec.Finish(); err != nil {
+		return nil, errors.Wrap(err, "decoding reshare handoff")
+	}
+	return handoff, nil
+}
+
+func encodeReshareRound1Output(seed curves.Scalar, version uint) (*protocol.Message, error) {
+	if err := versionIsSupported(version); err != nil {
+		return nil, errors.Wrap(err, "version error")
+	}
+	curve, err := scalarCurve(seed)
+	if err != nil {
+		return nil, err
+	}
+	enc := messages.NewEncoder(reshareRound1Type, curve)
+	enc.WriteScalar(seed)
+	payload, err := enc.Finish()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return newReshareProtocolMessage(payload, "1", version), nil
+}
+
+func decodeReshareRound2Input(m *protocol.Message) (curves.Scalar, error) {
+	dec, err := decodeReshareMessage(m, "1", reshareRound1Type)
+	if err != nil {
+		return nil, err
+	}
+	seed := dec.ReadScalar()
+	if err = dec.Finish(); err != nil {
+		return nil, err
+	}
+	return seed, nil
+}
+
+func encodeReshareRound2Output(output *refresh.ReshareRound2Output, version uint) (*protocol.Message, error) {
+	if err := versionIsSupported(version); err != nil {
+		return nil, errors.Wrap(err, "version error")
+	}
+	curve, err := scalarCurve(output.Refresh.BobMultiplier)
+	if err != nil {
+		return nil, err
+	}
+	enc := messages.NewEncoder(reshareRound2Type, curve)
+	writeProof(enc, output.Refresh.SeedOTRound1Output)
+	enc.WriteScalar(output.Refresh.BobMultiplier)
+	writeProof(enc, output.Proof)
+	payload, err := enc.Finish()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return newReshareProtocolMessage(payload, "2", version), nil
+}
+
+func decodeReshareRound3Input(m *protocol.Message) (*refresh.ReshareRound2Output, error) {
+	dec, err := decodeReshareMessage(m, "2", reshareRound2Type)
+	if err != nil {
+		return nil, err
+	}
+	output := &refresh.ReshareRound2Output{Refresh: &refresh.RefreshRound2Output{}}
+	output.Refresh.SeedOTRound1Output = readProof(dec)
+	output.Refresh.BobMultiplier = dec.ReadScalar()
+	output.Proof = readProof(dec)
+	if err = dec.Finish(); err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+func encodeReshareRound3Output(output *refresh.ReshareRound3Output, version uint) (*protocol.Message, error) {
+	if err := versionIsSupported(version); err != nil {
+		return nil, errors.Wrap(err, "version error")
+	}
+	curve, err := scalarCurve(output.Proof.C)
+	if err != nil {
+		return nil, err
+	}
+	enc := messages.NewEncoder(reshareRound3Type, curve)
+	writeChoices(enc, output.Choices)
+	writeProof(enc, output.Proof)
+	payload, err := enc.Finish()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return newReshareProtocolMessage(payload, "3", version), nil
+}
+
+func decodeReshareRound4Input(m *protocol.Message) (*refresh.ReshareRound3Output, error) {
+	dec, err := decodeReshareMessage(m, "3", reshareRound3Type)
+	if err != nil {
+		return nil, err
+	}
+	output := &refresh.ReshareRound3Output{Choices: readChoices(dec), Proof: readProof(dec)}
+	if err = dec.Finish(); err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+func encodeReshareRound4Output(challenges []simplest.OtChallenge, version uint) (*protocol.Message, error) {
+	if err := versionIsSupported(version); err != nil {
+		return nil, errors.Wrap(err, "version error")
+	}
+	enc := messages.NewEncoder(reshareRound4Type, nil)
+	writeChallenges(enc, challenges)
+	payload, err := enc.Finish()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return newReshareProtocolMessage(payload, "4", version), nil
+}
+
+func decodeReshareRound5Input(m *protocol.Message) ([]simplest.OtChallenge, error) {
+	dec, err := decodeReshareMessage(m, "4", reshareRound4Type)
+	if err != nil {
+		return nil, err
+	}
+	challenges, err := readChallenges(dec)
+	if err != nil {
+		return nil, err
+	}
+	if err = dec.Finish(); err != nil {
+		return nil, err
+	}
+	return challenges, nil
+}
+
+func encodeReshareRound5Output(responses []simplest.OtChallengeResponse, version uint) (*protocol.Message, error) {
+	if err := versionIsSupported(version); err != nil {
+		return nil, errors.Wrap(err, "version error")
+	}
+	enc := messages.NewEncoder(reshareRound5Type, nil)
+	writeChallenges(enc, responses)
+	payload, err := enc.Finish()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return newReshareProtocolMessage(payload, "5", version), nil
+}
+
+func decodeReshareRound6Input(m *protocol.Message) ([]simplest.OtChallengeResponse, error) {
+	dec, err := decodeReshareMessage(m, "5", reshareRound5Type)
+	if err != nil {
+		return nil, err
+	}
+	responses, err := readChallenges(dec)
+	if err != nil {
+		return nil, err
+	}
+	if err = dec.Finish(); err != nil {
+		return nil, err
+	}
+	return responses, nil
+}
+
+func encodeReshareRound6Output(openings []simplest.ChallengeOpening, version uint) (*protocol.Message, error) {
+	if err := versionIsSupported(version); err != nil {
+		return nil, errors.Wrap(err, "version error")
+	}
+	enc := messages.NewEncoder(reshareRound6Type, nil)
+	writeDigestPairs(enc, openings)
+	payload, err := enc.Finish()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return newReshareProtocolMessage(payload, "6", version), nil
+}
+
+func decodeReshareRound7Input(m *protocol.Message) ([]simplest.ChallengeOpening, error) {
+	dec, err := decodeReshareMessage(m, "6", reshareRound6Type)
+	if err != nil {
+		return nil, err
+	}
+	openings, err := readDigestPairs(dec)
+	if err != nil {
+		return nil, err
+	}
+	if err = dec.Finish(); err != nil {
+		return nil, err
+	}
+	return openings, nil
+}
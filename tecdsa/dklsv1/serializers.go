@@ -0,0 +1,289 @@
+package dklsv1
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/protocol"
+	"github.com/go-sonr/crypto/core/protocol/messages"
+	"github.com/go-sonr/crypto/ot/base/simplest"
+	"github.com/go-sonr/crypto/ot/extension/kos"
+	"github.com/go-sonr/crypto/tecdsa/dklsv1/sign"
+	"github.com/go-sonr/crypto/zkp/schnorr"
+)
+
+// Payloads of the DKLs18 messages use the canonical encoding of the messages package, with one message type per
+// round. The protocol.Message envelope carries the protocol, the version and the round.
+
+const payloadKey = "direct"
+
+// checkMessage checks the envelope of a received message. Its errors are local: the caller delivered a message of
+// another protocol, version or round.
+func checkMessage(m *protocol.Message, name, round string) error {
+	if m == nil {
+		return errors.Wrap(errUnexpectedMessage, "message is required")
+	}
+	if err := versionIsSupported(m.Version); err != nil {
+		return errors.Wrap(errUnexpectedMessage, err.Error())
+	}
+	if m.Protocol != name || m.Metadata["round"] != round {
+		return errors.Wrapf(errUnexpectedMessage, "expected %s message %s, got %s message %s", name, round, m.Protocol, m.Metadata["round"])
+	}
+	return nil
+}
+
+// decodePayload checks the envelope of a message of the given protocol and round and starts decoding its payload
+func decodePayload(m *protocol.Message, name, round, msgType string) (*messages.Decoder, error) {
+	if err := checkMessage(m, name, round); err != nil {
+		return nil, err
+	}
+	return messages.NewDecoder(m.Payloads[payloadKey], msgType)
+}
+
+// scalarCurve returns the curve of s, to encode the curve elements of a message
+func scalarCurve(s curves.Scalar) (*curves.Curve, error) {
+	if s == nil {
+		return nil, errors.New("nil scalar")
+	}
+	curve := curves.GetCurveByName(s.Point().CurveName())
+	if curve == nil {
+		return nil, errors.Errorf("unsupported curve %s", s.Point().CurveName())
+	}
+	return curve, nil
+}
+
+func writeProof(enc *messages.Encoder, proof *schnorr.Proof) {
+	if proof == nil {
+		proof = new(schnorr.Proof)
+	}
+	enc.WriteScalar(proof.C)
+	enc.WriteScalar(proof.S)
+	enc.WritePoint(proof.Statement)
+}
+
+func readProof(dec *messages.Decoder) *schnorr.Proof {
+	return &schnorr.Proof{C: dec.ReadScalar(), S: dec.ReadScalar(), Statement: dec.ReadPoint()}
+}
+
+// readFixed reads a byte string and checks that it has size bytes
+func readFixed(dec *messages.Decoder, size int) ([]byte, error) {
+	b := dec.ReadBytes()
+	if b != nil && len(b) != size {
+		return nil, errors.Errorf("field of %d bytes, expected %d", len(b), size)
+	}
+	return b, nil
+}
+
+// writeByteStrings writes the number of byte strings followed by each string
+func writeByteStrings(enc *messages.Encoder, strings [][]byte) {
+	enc.WriteUint32(uint32(len(strings)))
+	for _, s := range strings {
+		enc.WriteBytes(s)
+	}
+}
+
+// readByteStrings reads byte strings written by writeByteStrings
+func readByteStrings(dec *messages.Decoder) [][]byte {
+	n := dec.ReadUint32()
+	var strings [][]byte
+	for i := uint32(0); i < n; i++ {
+		s := dec.ReadBytes()
+		if s == nil {
+			// the decoder failed, Finish reports why
+			break
+		}
+		strings = append(strings, s)
+	}
+	return strings
+}
+
+// readDigests reads byte strings written by writeByteStrings and checks that each has size bytes
+func readDigests(dec *messages.Decoder, size int) ([][]byte, error) {
+	digests := readByteStrings(dec)
+	for _, d := range digests {
+		if len(d) != size {
+			return nil, errors.Errorf("digest of %d bytes, expected %d", len(d), size)
+		}
+	}
+	return digests, nil
+}
+
+// writeChoices writes the masked choices of the seed OT receiver, which the sender checks as points
+func writeChoices(enc *messages.Encoder, choices []simplest.ReceiversMaskedChoices) {
+	strings := make([][]byte, len(choices))
+	for i, c := range choices {
+		strings[i] = c
+	}
+	writeByteStrings(enc, strings)
+}
+
+func readChoices(dec *messages.Decoder) []simplest.ReceiversMaskedChoices {
+	var choices []simplest.ReceiversMaskedChoices
+	for _, c := range readByteStrings(dec) {
+		choices = append(choices, c)
+	}
+	return choices
+}
+
+func writeChallenges(enc *messages.Encoder, challenges []simplest.OtChallenge) {
+	digests := make([][]byte, len(challenges))
+	for i := range challenges {
+		digests[i] = challenges[i][:]
+	}
+	writeByteStrings(enc, digests)
+}
+
+func readChallenges(dec *messages.Decoder) ([]simplest.OtChallenge, error) {
+	digests, err := readDigests(dec, simplest.DigestSize)
+	if err != nil {
+		return nil, err
+	}
+	challenges := make([]simplest.OtChallenge, len(digests))
+	for i, d := range digests {
+		copy(challenges[i][:], d)
+	}
+	return challenges, nil
+}
+
+// writeDigestPairs writes pairs of digests, the challenge openings and the keys of the seed OT sender, as one list
+func writeDigestPairs(enc *messages.Encoder, pairs [][2][simplest.DigestSize]byte) {
+	digests := make([][]byte, 0, 2*len(pairs))
+	for i := range pairs {
+		for j := range pairs[i] {
+			digests = append(digests, pairs[i][j][:])
+		}
+	}
+	writeByteStrings(enc, digests)
+}
+
+func readDigestPairs(dec *messages.Decoder) ([][2][simplest.DigestSize]byte, error) {
+	digests, err := readDigests(dec, simplest.DigestSize)
+	if err != nil {
+		return nil, err
+	}
+	if len(digests)%2 != 0 {
+		return nil, errors.New("incomplete pair of digests")
+	}
+	pairs := make([][2][simplest.DigestSize]byte, len(digests)/2)
+	for i := range pairs {
+		for j := range pairs[i] {
+			copy(pairs[i][j][:], digests[2*i+j])
+		}
+	}
+	return pairs, nil
+}
+
+// writeKosRound1Output writes the first message of the cOT extension, which has no curve elements
+func writeKosRound1Output(enc *messages.Encoder, output *kos.Round1Output) error {
+	if output == nil {
+		return errors.New("missing cOT round 1 output")
+	}
+	for i := range output.U {
+		enc.WriteBytes(output.U[i][:])
+	}
+	enc.WriteBytes(output.WPrime[:])
+	enc.WriteBytes(output.VPrime[:])
+	return nil
+}
+
+func readKosRound1Output(dec *messages.Decoder) (*kos.Round1Output, error) {
+	output := new(kos.Round1Output)
+	for i := range output.U {
+		row, err := readFixed(dec, len(output.U[i]))
+		if err != nil {
+			return nil, err
+		}
+		copy(output.U[i][:], row)
+	}
+	for _, field := range [][]byte{output.WPrime[:], output.VPrime[:]} {
+		b, err := readFixed(dec, simplest.DigestSize)
+		if err != nil {
+			return nil, err
+		}
+		copy(field, b)
+	}
+	return output, nil
+}
+
+// writeMultiplyRound2Output writes the second message of a multiplication
+func writeMultiplyRound2Output(enc *messages.Encoder, output *sign.MultiplyRound2Output) error {
+	if output == nil || output.COTRound2Output == nil {
+		return errors.New("missing multiplication round 2 output")
+	}
+	for i := range output.COTRound2Output.Tau {
+		for _, tau := range output.COTRound2Output.Tau[i] {
+			enc.WriteScalar(tau)
+		}
+	}
+	for _, r := range output.R {
+		enc.WriteScalar(r)
+	}
+	enc.WriteScalar(output.U)
+	return nil
+}
+
+func readMultiplyRound2Output(dec *messages.Decoder) *sign.MultiplyRound2Output {
+	output := &sign.MultiplyRound2Output{COTRound2Output: new(kos.Round2Output)}
+	for i := range output.COTRound2Output.Tau {
+		for j := range output.COTRound2Output.Tau[i] {
+			output.COTRound2Output.Tau[i][j] = dec.ReadScalar()
+		}
+	}
+	for i := range output.R {
+		output.R[i] = dec.ReadScalar()
+	}
+	output.U = dec.ReadScalar()
+	return output
+}
+
+// encodeProof encodes a message of type msgType that carries a single proof
+func encodeProof(msgType string, proof *schnorr.Proof) ([]byte, error) {
+	if proof == nil {
+		return nil, errors.New("missing proof")
+	}
+	curve, err := scalarCurve(proof.C)
+	if err != nil {
+		return nil, err
+	}
+	enc := messages.NewEncoder(msgType, curve)
+	writeProof(enc, proof)
+	return enc.Finish()
+}
+
+// decodeProof decodes a message of type msgType encoded by encodeProof
+func decodeProof(m *protocol.Message, name, round, msgType string) (*schnorr.Proof, error) {
+	dec, err := decodePayload(m, name, round, msgType)
+	if err != nil {
+		return nil, err
+	}
+	proof := readProof(dec)
+	if err = dec.Finish(); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// encodeDigest encodes a message of type msgType that carries a single digest
+func encodeDigest(msgType string, digest [simplest.DigestSize]byte) ([]byte, error) {
+	enc := messages.NewEncoder(msgType, nil)
+	enc.WriteBytes(digest[:])
+	return enc.Finish()
+}
+
+// decodeDigest decodes a message of type msgType encoded by encodeDigest
+func decodeDigest(m *protocol.Message, name, round, msgType string) ([simplest.DigestSize]byte, error) {
+	var digest [simplest.DigestSize]byte
+	dec, err := decodePayload(m, name, round, msgType)
+	if err != nil {
+		return digest, err
+	}
+	b, err := readFixed(dec, simplest.DigestSize)
+	if err != nil {
+		return digest, err
+	}
+	if err = dec.Finish(); err != nil {
+		return digest, err
+	}
+	copy(digest[:], b)
+	return digest, nil
+}
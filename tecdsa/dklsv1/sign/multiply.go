@@ -12,11 +12,11 @@ import (
 	"fmt"
 	"math/big"
 
-	"github.com/gtank/merlin"
 	"github.com/pkg/errors"
 	"golang.org/x/crypto/sha3"
 
 	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
 	"github.com/go-sonr/crypto/internal"
 	"github.com/go-sonr/crypto/ot/base/simplest"
 	"github.com/go-sonr/crypto/ot/extension/kos"
@@ -33,7 +33,7 @@ type MultiplySender struct {
 	outputAdditiveShare curves.Scalar // ultimate output share of mult.
 	gadget              [kos.L]curves.Scalar
 	curve               *curves.Curve
-	transcript          *merlin.Transcript
+	transcript          *transcript.Transcript
 	uniqueSessionId     [simplest.DigestSize]byte
 }
 
@@ -44,7 +44,7 @@ type MultiplyReceiver struct {
 	omega               [kos.COtBlockSizeBytes]byte // this is used as an intermediate result during the course of mult.
 	gadget              [kos.L]curves.Scalar
 	curve               *curves.Curve
-	transcript          *merlin.Transcript
+	transcript          *transcript.Transcript
 	uniqueSessionId     [simplest.DigestSize]byte
 }
 
@@ -82,12 +82,12 @@ func NewMultiplySender(seedOtResults *simplest.ReceiverOutput, curve *curves.Cur
 		return nil, errors.Wrap(err, "error generating gadget vector in new multiply sender")
 	}
 
-	transcript := merlin.NewTranscript("Coinbase_DKLs_Multiply")
-	transcript.AppendMessage([]byte("session_id"), uniqueSessionId[:])
+	tr := transcript.New("Coinbase_DKLs_Multiply")
+	tr.AppendMessage([]byte("session_id"), uniqueSessionId[:])
 	return &MultiplySender{
 		cOtSender:       sender,
 		curve:           curve,
-		transcript:      transcript,
+		transcript:      tr,
 		uniqueSessionId: uniqueSessionId,
 		gadget:          gadget,
 	}, nil
@@ -102,17 +102,27 @@ func NewMultiplyReceiver(seedOtResults *simplest.SenderOutput, curve *curves.Cur
 	if err != nil {
 		return nil, errors.Wrap(err, "error generating gadget vector in new multiply receiver")
 	}
-	transcript := merlin.NewTranscript("Coinbase_DKLs_Multiply")
-	transcript.AppendMessage([]byte("session_id"), uniqueSessionId[:])
+	tr := transcript.New("Coinbase_DKLs_Multiply")
+	tr.AppendMessage([]byte("session_id"), uniqueSessionId[:])
 	return &MultiplyReceiver{
 		cOtReceiver:     receiver,
 		curve:           curve,
-		transcript:      transcript,
+		transcript:      tr,
 		uniqueSessionId: uniqueSessionId,
 		gadget:          gadget,
 	}, nil
 }
 
+// OutputAdditiveShare returns the sender's additive share of the product, available after Round2Multiply.
+func (sender *MultiplySender) OutputAdditiveShare() curves.Scalar {
+	return sender.outputAdditiveShare
+}
+
+// OutputAdditiveShare returns the receiver's additive share of the product, available after Round3Multiply.
+func (receiver *MultiplyReceiver) OutputAdditiveShare() curves.Scalar {
+	return receiver.outputAdditiveShare
+}
+
 // MultiplyRound2Output is the output of the second round of the multiplication protocol.
 type MultiplyRound2Output struct {
 	COTRound2Output *kos.Round2Output
@@ -197,7 +207,7 @@ func (sender *MultiplySender) Round2Multiply(alpha curves.Scalar, round1Output *
 	for i := 0; i < kos.Kappa; i++ {
 		for k := 0; k < chiWidth; k++ {
 			label := []byte(fmt.Sprintf("row %d of Tau", i))
-			sender.transcript.AppendMessage(label, round2Output.COTRound2Output.Tau[i][k].Bytes())
+			sender.transcript.AppendScalar(label, round2Output.COTRound2Output.Tau[i][k])
 		}
 	}
 	chi := make([]curves.Scalar, chiWidth)
@@ -230,7 +240,7 @@ func (receiver *MultiplyReceiver) Round3Multiply(round2Output *MultiplyRound2Out
 	for i := 0; i < kos.Kappa; i++ {
 		for k := 0; k < chiWidth; k++ {
 			label := []byte(fmt.Sprintf("row %d of Tau", i))
-			receiver.transcript.AppendMessage(label, round2Output.COTRound2Output.Tau[i][k].Bytes())
+			receiver.transcript.AppendScalar(label, round2Output.COTRound2Output.Tau[i][k])
 		}
 	}
 	if err := receiver.cOtReceiver.Round3Transfer(round2Output.COTRound2Output); err != nil {
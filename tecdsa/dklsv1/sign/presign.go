@@ -0,0 +1,227 @@
+package sign
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"hash"
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/ot/base/simplest"
+	"github.com/go-sonr/crypto/zkp/schnorr"
+)
+
+// This file splits the signing protocol into an offline presigning phase and an online phase.
+// Everything Alice sends in Round3Sign except EtaSig is independent of the message, so the presigning phase runs
+// Round1GenerateRandomSeed, Round2Initialize, Round3Presign and Round4Presign ahead of time. Once the message is known,
+// Alice sends a single OnlineSignOutput and Bob completes the signature without further interaction.
+//
+// A presignature must never be used twice: two signatures with the same nonce reveal the secret key.
+// Sign and Finalize mark the presignature as used and erase its shares, but a persisted copy is still live, so
+// storage must delete (or record the ID of) every presignature it hands out.
+
+// PresignRound3Output is Alice's message independent reply to Bob's first message.
+type PresignRound3Output struct {
+	// MultiplyRound2Outputs is the output of the second round of multiply sub-protocol.
+	MultiplyRound2Outputs [multiplicationCount]*MultiplyRound2Output
+
+	// RSchnorrProof is ZKP for the value R = k_{A} . D_{B} from the paper.
+	RSchnorrProof *schnorr.Proof
+
+	// RPrime is R' = k'_{A} . D_{B} from the paper.
+	RPrime curves.Point
+
+	// EtaPhi is the Eta_{Phi} from the paper.
+	EtaPhi curves.Scalar
+}
+
+// AlicePresignature is Alice's state for one online signature.
+type AlicePresignature struct {
+	// ID is derived from the presigning transcript and is the same for Alice and Bob.
+	ID [simplest.DigestSize]byte
+
+	PublicKey   curves.Point
+	RX          curves.Scalar
+	PhiShare    curves.Scalar
+	SecretShare curves.Scalar
+	Gamma2Hash  curves.Scalar
+
+	// Used is set once the presignature produced a signature.
+	Used bool
+}
+
+// BobPresignature is Bob's state for one online signature.
+type BobPresignature struct {
+	// ID is derived from the presigning transcript and is the same for Alice and Bob.
+	ID [simplest.DigestSize]byte
+
+	PublicKey   curves.Point
+	R           curves.Point
+	Theta       curves.Scalar
+	SecretShare curves.Scalar
+	Gamma2Hash  curves.Scalar
+
+	// Used is set once the presignature produced a signature.
+	Used bool
+}
+
+// OnlineSignOutput is Alice's only message of the online phase.
+type OnlineSignOutput struct {
+	// PresignatureID identifies the presignature Alice used.
+	PresignatureID [simplest.DigestSize]byte
+
+	// EtaSig is the Eta_{Sig} from the paper.
+	EtaSig curves.Scalar
+}
+
+// Round3Presign is Round3Sign without the message. It returns the message for Bob and Alice's presignature.
+func (alice *Alice) Round3Presign(round2Output *SignRound2Output) (*PresignRound3Output, *AlicePresignature, error) {
+	return alice.presign(round2Output)
+}
+
+// Round4Presign is Round4Final without the message. It verifies Alice's message and returns Bob's presignature.
+func (bob *Bob) Round4Presign(round3Output *PresignRound3Output) (*BobPresignature, error) {
+	return bob.presign(round3Output)
+}
+
+// Sign computes Alice's online message for message. The hash is reset before use.
+func (p *AlicePresignature) Sign(h hash.Hash, message []byte) (*OnlineSignOutput, error) {
+	if err := p.usable(); err != nil {
+		return nil, err
+	}
+	curve, err := presignatureCurve(p.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	h.Reset()
+	digest, err := messageDigest(curve, h, message)
+	if err != nil {
+		return nil, errors.Wrap(err, "hashing message in alice online sign")
+	}
+	output := &OnlineSignOutput{
+		PresignatureID: p.ID,
+		EtaSig:         p.etaSig(digest),
+	}
+	p.Used = true
+	p.PhiShare = curve.Scalar.Zero()
+	p.SecretShare = curve.Scalar.Zero()
+	return output, nil
+}
+
+// Finalize completes and verifies the signature on message from Alice's online message. The hash is reset before use.
+func (p *BobPresignature) Finalize(h hash.Hash, message []byte, output *OnlineSignOutput) (*curves.EcdsaSignature, error) {
+	if output == nil || output.EtaSig == nil {
+		return nil, errors.New("online sign output is required")
+	}
+	if err := p.usable(); err != nil {
+		return nil, err
+	}
+	if output.PresignatureID != p.ID {
+		return nil, errors.New("online sign output is for another presignature")
+	}
+	curve, err := presignatureCurve(p.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	// the presignature is spent even when the signature fails, Alice may have revealed EtaSig for another message
+	defer func() {
+		p.Used = true
+		p.Theta = curve.Scalar.Zero()
+		p.SecretShare = curve.Scalar.Zero()
+	}()
+	h.Reset()
+	return p.finalize(h, message, output.EtaSig)
+}
+
+func (p *AlicePresignature) usable() error {
+	if p.Used || p.PhiShare == nil || p.SecretShare == nil {
+		return errors.New("presignature has already been used")
+	}
+	return nil
+}
+
+func (p *BobPresignature) usable() error {
+	if p.Used || p.Theta == nil || p.SecretShare == nil {
+		return errors.New("presignature has already been used")
+	}
+	return nil
+}
+
+// etaSig is Eta_{Sig} for the message digest.
+func (p *AlicePresignature) etaSig(digest curves.Scalar) curves.Scalar {
+	sigA := digest.Mul(p.PhiShare).Add(p.RX.Mul(p.SecretShare))
+	return p.Gamma2Hash.Add(sigA)
+}
+
+// finalize is the message dependent part of Round4Final.
+func (p *BobPresignature) finalize(h hash.Hash, message []byte, etaSig curves.Scalar) (*curves.EcdsaSignature, error) {
+	curve, err := presignatureCurve(p.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	zero := curve.Scalar.Zero()
+	affineCompressedForm := p.R.ToAffineCompressed()
+	if len(affineCompressedForm) != 33 {
+		return nil, errors.New("the compressed form must be exactly 33 bytes")
+	}
+	rY := affineCompressedForm[0] & 0x1 // this is bit(0) of Y coordinate
+	rX, err := curve.Scalar.SetBytes(affineCompressedForm[1:])
+	if err != nil {
+		return nil, errors.Wrap(err, "setting rX scalar from bytes")
+	}
+	signature := &curves.EcdsaSignature{
+		R: rX.Add(zero).BigInt(), // slight trick here; add it to 0 just to mod it by q (now it's mod p!)
+		V: int(rY),
+	}
+	digest, err := messageDigest(curve, h, message)
+	if err != nil {
+		return nil, errors.Wrap(err, "hashing message in Bob sign round 5 final")
+	}
+	capitalR, err := curve.Scalar.SetBigInt(signature.R)
+	if err != nil {
+		return nil, errors.Wrap(err, "setting capitalR scalar from big int")
+	}
+	sigB := digest.Mul(p.Theta).Add(capitalR.Mul(p.SecretShare))
+	scalarS := sigB.Add(etaSig.Sub(p.Gamma2Hash))
+	signature.S = scalarS.BigInt()
+	if signature.S.Bit(255) == 1 {
+		signature.S = scalarS.Neg().BigInt()
+		signature.V ^= 1
+	}
+	// now verify the signature
+	unCompressedAffinePublicKey := p.PublicKey.ToAffineUncompressed()
+	if len(unCompressedAffinePublicKey) != 65 {
+		return nil, errors.New("the uncompressed form must have exactly 65 bytes")
+	}
+	x := new(big.Int).SetBytes(unCompressedAffinePublicKey[1:33])
+	y := new(big.Int).SetBytes(unCompressedAffinePublicKey[33:])
+	ellipticCurve, err := curve.ToEllipticCurve()
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid curve")
+	}
+	if !ecdsa.Verify(&ecdsa.PublicKey{Curve: ellipticCurve, X: x, Y: y}, digest.Bytes(), signature.R, signature.S) {
+		return nil, fmt.Errorf("final signature failed to verify")
+	}
+	return signature, nil
+}
+
+// messageDigest writes message to h and returns the digest as a scalar.
+func messageDigest(curve *curves.Curve, h hash.Hash, message []byte) (curves.Scalar, error) {
+	if _, err := h.Write(message); err != nil {
+		return nil, errors.Wrap(err, "writing message to hash")
+	}
+	return curve.Scalar.SetBytes(h.Sum(nil))
+}
+
+func presignatureCurve(publicKey curves.Point) (*curves.Curve, error) {
+	if publicKey == nil {
+		return nil, errors.New("presignature has no public key")
+	}
+	curve := curves.GetCurveByName(publicKey.CurveName())
+	if curve == nil {
+		return nil, errors.Errorf("unsupported curve %s", publicKey.CurveName())
+	}
+	return curve, nil
+}
@@ -0,0 +1,87 @@
+package sign
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/ot/base/simplest"
+	"github.com/go-sonr/crypto/ot/extension/kos"
+	"github.com/go-sonr/crypto/ot/ottest"
+	"github.com/go-sonr/crypto/tecdsa/dklsv1/dkg"
+)
+
+func presignParties(t *testing.T, curve *curves.Curve) (*Alice, *Bob) {
+	t.Helper()
+	hashKeySeed := [simplest.DigestSize]byte{}
+	_, err := rand.Read(hashKeySeed[:])
+	require.NoError(t, err)
+	baseOtSenderOutput, baseOtReceiverOutput, err := ottest.RunSimplestOT(curve, kos.Kappa, hashKeySeed)
+	require.NoError(t, err)
+
+	secretKeyShareA := curve.Scalar.Random(rand.Reader)
+	secretKeyShareB := curve.Scalar.Random(rand.Reader)
+	publicKey := curve.ScalarBaseMult(secretKeyShareA.Mul(secretKeyShareB))
+	alice := NewAlice(curve, sha3.New256(), &dkg.AliceOutput{SeedOtResult: baseOtReceiverOutput, SecretKeyShare: secretKeyShareA, PublicKey: publicKey})
+	bob := NewBob(curve, sha3.New256(), &dkg.BobOutput{SeedOtResult: baseOtSenderOutput, SecretKeyShare: secretKeyShareB, PublicKey: publicKey})
+	return alice, bob
+}
+
+func runPresign(t *testing.T, alice *Alice, bob *Bob) (*AlicePresignature, *BobPresignature) {
+	t.Helper()
+	seed, err := alice.Round1GenerateRandomSeed()
+	require.NoError(t, err)
+	round2Output, err := bob.Round2Initialize(seed)
+	require.NoError(t, err)
+	round3Output, alicePresignature, err := alice.Round3Presign(round2Output)
+	require.NoError(t, err)
+	bobPresignature, err := bob.Round4Presign(round3Output)
+	require.NoError(t, err)
+	require.Equal(t, alicePresignature.ID, bobPresignature.ID)
+	return alicePresignature, bobPresignature
+}
+
+func TestPresign(t *testing.T) {
+	for _, curve := range []*curves.Curve{curves.K256(), curves.P256()} {
+		alice, bob := presignParties(t, curve)
+		alicePresignature, bobPresignature := runPresign(t, alice, bob)
+
+		message := []byte("A message known only after presigning.")
+		online, err := alicePresignature.Sign(sha3.New256(), message)
+		require.NoError(t, err)
+		signature, err := bobPresignature.Finalize(sha3.New256(), message, online)
+		require.NoError(t, err, "curve: %s", curve.Name)
+		require.NotNil(t, signature)
+
+		// both halves are single use
+		_, err = alicePresignature.Sign(sha3.New256(), []byte("another message"))
+		require.Error(t, err)
+		_, err = bobPresignature.Finalize(sha3.New256(), message, online)
+		require.Error(t, err)
+	}
+}
+
+func TestPresignMismatch(t *testing.T) {
+	curve := curves.K256()
+	alice, bob := presignParties(t, curve)
+	alicePresignature, bobPresignature := runPresign(t, alice, bob)
+
+	// Bob refuses the online message of another presignature
+	otherAlice, otherBob := presignParties(t, curve)
+	otherPresignature, _ := runPresign(t, otherAlice, otherBob)
+	online, err := otherPresignature.Sign(sha3.New256(), []byte("message"))
+	require.NoError(t, err)
+	_, err = bobPresignature.Finalize(sha3.New256(), []byte("message"), online)
+	require.Error(t, err)
+
+	// a signature over a different message fails and spends Bob's presignature
+	online, err = alicePresignature.Sign(sha3.New256(), []byte("message"))
+	require.NoError(t, err)
+	_, err = bobPresignature.Finalize(sha3.New256(), []byte("other message"), online)
+	require.Error(t, err)
+	_, err = bobPresignature.Finalize(sha3.New256(), []byte("message"), online)
+	require.Error(t, err)
+}
@@ -10,17 +10,14 @@
 package sign
 
 import (
-	"crypto/ecdsa"
 	"crypto/rand"
-	"fmt"
 	"hash"
-	"math/big"
 
-	"github.com/gtank/merlin"
 	"github.com/pkg/errors"
 	"golang.org/x/crypto/sha3"
 
 	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
 	"github.com/go-sonr/crypto/ot/base/simplest"
 	"github.com/go-sonr/crypto/ot/extension/kos"
 	"github.com/go-sonr/crypto/tecdsa/dklsv1/dkg"
@@ -37,7 +34,7 @@ type Alice struct {
 	secretKeyShare curves.Scalar // the witness
 	publicKey      curves.Point
 	curve          *curves.Curve
-	transcript     *merlin.Transcript
+	transcript     *transcript.Transcript
 }
 
 // Bob struct encoding Bob's state during one execution of the overall signing algorithm.
@@ -50,7 +47,7 @@ type Bob struct {
 	seedOtResults  *simplest.SenderOutput
 	secretKeyShare curves.Scalar
 	publicKey      curves.Point
-	transcript     *merlin.Transcript
+	transcript     *transcript.Transcript
 	// multiplyReceivers are 2 receivers that are used to perform the two multiplications needed:
 	// 1. (phi + 1/kA) * (1/kB)
 	// 2. skA/KA * skB/kB
@@ -68,7 +65,7 @@ func NewAlice(curve *curves.Curve, hash hash.Hash, dkgOutput *dkg.AliceOutput) *
 		curve:          curve,
 		secretKeyShare: dkgOutput.SecretKeyShare,
 		publicKey:      dkgOutput.PublicKey,
-		transcript:     merlin.NewTranscript("Coinbase_DKLs_Sign"),
+		transcript:     transcript.New("Coinbase_DKLs_Sign"),
 	}
 }
 
@@ -81,7 +78,7 @@ func NewBob(curve *curves.Curve, hash hash.Hash, dkgOutput *dkg.BobOutput) *Bob
 		curve:          curve,
 		secretKeyShare: dkgOutput.SecretKeyShare,
 		publicKey:      dkgOutput.PublicKey,
-		transcript:     merlin.NewTranscript("Coinbase_DKLs_Sign"),
+		transcript:     transcript.New("Coinbase_DKLs_Sign"),
 	}
 }
 
@@ -182,6 +179,26 @@ func (bob *Bob) Round2Initialize(aliceSeed [simplest.DigestSize]byte) (*SignRoun
 // then to use the _output_ of the multiplication (which she already possesses as of the end of her computation),
 // and use that to compute some final values which will help Bob compute the final signature.
 func (alice *Alice) Round3Sign(message []byte, round2Output *SignRound2Output) (*SignRound3Output, error) {
+	presignOutput, presignature, err := alice.presign(round2Output)
+	if err != nil {
+		return nil, err
+	}
+	digest, err := messageDigest(alice.curve, alice.hash, message)
+	if err != nil {
+		return nil, errors.Wrap(err, "hashing message in alice round 4 sign")
+	}
+	return &SignRound3Output{
+		MultiplyRound2Outputs: presignOutput.MultiplyRound2Outputs,
+		RSchnorrProof:         presignOutput.RSchnorrProof,
+		RPrime:                presignOutput.RPrime,
+		EtaPhi:                presignOutput.EtaPhi,
+		EtaSig:                presignature.etaSig(digest),
+	}, nil
+}
+
+// presign is the message independent part of Round3Sign. It returns the message for Bob and the state
+// Alice needs to later compute EtaSig for any message.
+func (alice *Alice) presign(round2Output *SignRound2Output) (*PresignRound3Output, *AlicePresignature, error) {
 	alice.transcript.AppendMessage([]byte("session_id_bob"), round2Output.Seed[:])
 
 	multiplySenders := [multiplicationCount]*MultiplySender{}
@@ -189,26 +206,26 @@ func (alice *Alice) Round3Sign(message []byte, round2Output *SignRound2Output) (
 	uniqueSessionId := [simplest.DigestSize]byte{} // will use and _re-use_ this throughout, for sub-session IDs
 	copy(uniqueSessionId[:], alice.transcript.ExtractBytes([]byte("multiply receiver id 0"), simplest.DigestSize))
 	if multiplySenders[0], err = NewMultiplySender(alice.seedOtResults, alice.curve, uniqueSessionId); err != nil {
-		return nil, errors.Wrap(err, "creating multiply sender 0 in Alice round 4 sign")
+		return nil, nil, errors.Wrap(err, "creating multiply sender 0 in Alice round 4 sign")
 	}
 	copy(uniqueSessionId[:], alice.transcript.ExtractBytes([]byte("multiply receiver id 1"), simplest.DigestSize))
 	if multiplySenders[1], err = NewMultiplySender(alice.seedOtResults, alice.curve, uniqueSessionId); err != nil {
-		return nil, errors.Wrap(err, "creating multiply sender 1 in Alice round 4 sign")
+		return nil, nil, errors.Wrap(err, "creating multiply sender 1 in Alice round 4 sign")
 	}
-	round3Output := &SignRound3Output{}
+	round3Output := &PresignRound3Output{}
 	kPrimeA := alice.curve.Scalar.Random(rand.Reader)
 	round3Output.RPrime = round2Output.DB.Mul(kPrimeA)
 	hashRPrimeBytes := sha3.Sum256(round3Output.RPrime.ToAffineCompressed())
 	hashRPrime, err := alice.curve.Scalar.SetBytes(hashRPrimeBytes[:])
 	if err != nil {
-		return nil, errors.Wrap(err, "setting hashRPrime scalar from bytes")
+		return nil, nil, errors.Wrap(err, "setting hashRPrime scalar from bytes")
 	}
 	kA := hashRPrime.Add(kPrimeA)
 	copy(uniqueSessionId[:], alice.transcript.ExtractBytes([]byte("schnorr proof for R"), simplest.DigestSize))
 	rSchnorrProver := schnorr.NewProver(alice.curve, round2Output.DB, uniqueSessionId[:])
 	round3Output.RSchnorrProof, err = rSchnorrProver.Prove(kA)
 	if err != nil {
-		return nil, errors.Wrap(err, "generating schnorr proof for R = kA * DB in alice round 4 sign")
+		return nil, nil, errors.Wrap(err, "generating schnorr proof for R = kA * DB in alice round 4 sign")
 	}
 	// reassign / stash the below value here just for notational clarity.
 	// this is _the_ key public point R in the ECDSA signature. we'll use its coordinate X in various places.
@@ -217,10 +234,10 @@ func (alice *Alice) Round3Sign(message []byte, round2Output *SignRound2Output) (
 	kAInv := alice.curve.Scalar.One().Div(kA)
 
 	if round3Output.MultiplyRound2Outputs[0], err = multiplySenders[0].Round2Multiply(phi.Add(kAInv), round2Output.KosRound1Outputs[0]); err != nil {
-		return nil, errors.Wrap(err, "error in round 2 multiply 0 within alice round 4 sign")
+		return nil, nil, errors.Wrap(err, "error in round 2 multiply 0 within alice round 4 sign")
 	}
 	if round3Output.MultiplyRound2Outputs[1], err = multiplySenders[1].Round2Multiply(alice.secretKeyShare.Mul(kAInv), round2Output.KosRound1Outputs[1]); err != nil {
-		return nil, errors.Wrap(err, "error in round 2 multiply 1 within alice round 4 sign")
+		return nil, nil, errors.Wrap(err, "error in round 2 multiply 1 within alice round 4 sign")
 	}
 
 	one := alice.curve.Scalar.One()
@@ -230,38 +247,36 @@ func (alice *Alice) Round3Sign(message []byte, round2Output *SignRound2Output) (
 	hashGamma1Bytes := sha3.Sum256(gamma1.ToAffineCompressed())
 	hashGamma1, err := alice.curve.Scalar.SetBytes(hashGamma1Bytes[:])
 	if err != nil {
-		return nil, errors.Wrap(err, "setting hashGamma1 scalar from bytes")
+		return nil, nil, errors.Wrap(err, "setting hashGamma1 scalar from bytes")
 	}
 	round3Output.EtaPhi = hashGamma1.Add(phi)
-	if _, err = alice.hash.Write(message); err != nil {
-		return nil, errors.Wrap(err, "writing message to hash in alice round 4 sign")
-	}
-	digest := alice.hash.Sum(nil)
-	hOfMAsInteger, err := alice.curve.Scalar.SetBytes(digest)
-	if err != nil {
-		return nil, errors.Wrap(err, "setting hOfMAsInteger scalar from bytes")
-	}
 	affineCompressedForm := r.ToAffineCompressed()
 	if len(affineCompressedForm) != 33 {
-		return nil, errors.New("the compressed form must be exactly 33 bytes")
+		return nil, nil, errors.New("the compressed form must be exactly 33 bytes")
 	}
 	// Discard the leading byte and parse the rest as the X coordinate.
 	rX, err := alice.curve.Scalar.SetBytes(affineCompressedForm[1:])
 	if err != nil {
-		return nil, errors.Wrap(err, "setting rX scalar from bytes")
+		return nil, nil, errors.Wrap(err, "setting rX scalar from bytes")
 	}
 
-	sigA := hOfMAsInteger.Mul(multiplySenders[0].outputAdditiveShare).Add(rX.Mul(multiplySenders[1].outputAdditiveShare))
 	gamma2 := alice.publicKey.Mul(multiplySenders[0].outputAdditiveShare)
 	other = alice.curve.ScalarBaseMult(multiplySenders[1].outputAdditiveShare.Neg())
 	gamma2 = gamma2.Add(other)
 	hashGamma2Bytes := sha3.Sum256(gamma2.ToAffineCompressed())
 	hashGamma2, err := alice.curve.Scalar.SetBytes(hashGamma2Bytes[:])
 	if err != nil {
-		return nil, errors.Wrap(err, "setting hashGamma2 scalar from bytes")
+		return nil, nil, errors.Wrap(err, "setting hashGamma2 scalar from bytes")
+	}
+	presignature := &AlicePresignature{
+		PublicKey:   alice.publicKey,
+		RX:          rX,
+		PhiShare:    multiplySenders[0].outputAdditiveShare,
+		SecretShare: multiplySenders[1].outputAdditiveShare,
+		Gamma2Hash:  hashGamma2,
 	}
-	round3Output.EtaSig = hashGamma2.Add(sigA)
-	return round3Output, nil
+	copy(presignature.ID[:], alice.transcript.ExtractBytes([]byte("presignature id"), simplest.DigestSize))
+	return round3Output, presignature, nil
 }
 
 // Round4Final this is Bob's last portion of the signature computation, and ultimately results in the complete signature
@@ -270,16 +285,32 @@ func (alice *Alice) Round3Sign(message []byte, round2Output *SignRound2Output) (
 // Bob then move's onto the remainder of Alice's message, which contains extraneous data used to finish the signature.
 // Using this data, Bob completes the signature, which gets stored in `Bob.Sig`. Bob also verifies it.
 func (bob *Bob) Round4Final(message []byte, round3Output *SignRound3Output) error {
+	presignature, err := bob.presign(&PresignRound3Output{
+		MultiplyRound2Outputs: round3Output.MultiplyRound2Outputs,
+		RSchnorrProof:         round3Output.RSchnorrProof,
+		RPrime:                round3Output.RPrime,
+		EtaPhi:                round3Output.EtaPhi,
+	})
+	if err != nil {
+		return err
+	}
+	bob.Signature, err = presignature.finalize(bob.hash, message, round3Output.EtaSig)
+	return err
+}
+
+// presign is the message independent part of Round4Final. It finishes the multiplications and verifies R,
+// returning the state Bob needs to complete a signature from Alice's EtaSig.
+func (bob *Bob) presign(round3Output *PresignRound3Output) (*BobPresignature, error) {
 	if err := bob.multiplyReceivers[0].Round3Multiply(round3Output.MultiplyRound2Outputs[0]); err != nil {
-		return errors.Wrap(err, "error in round 3 multiply 0 within sign round 5")
+		return nil, errors.Wrap(err, "error in round 3 multiply 0 within sign round 5")
 	}
 	if err := bob.multiplyReceivers[1].Round3Multiply(round3Output.MultiplyRound2Outputs[1]); err != nil {
-		return errors.Wrap(err, "error in round 3 multiply 1 within sign round 5")
+		return nil, errors.Wrap(err, "error in round 3 multiply 1 within sign round 5")
 	}
 	rPrimeHashedBytes := sha3.Sum256(round3Output.RPrime.ToAffineCompressed())
 	rPrimeHashed, err := bob.curve.Scalar.SetBytes(rPrimeHashedBytes[:])
 	if err != nil {
-		return errors.Wrap(err, "setting rPrimeHashed scalar from bytes")
+		return nil, errors.Wrap(err, "setting rPrimeHashed scalar from bytes")
 	}
 	r := bob.dB.Mul(rPrimeHashed)
 	r = r.Add(round3Output.RPrime)
@@ -289,70 +320,31 @@ func (bob *Bob) Round4Final(message []byte, round3Output *SignRound3Output) erro
 	uniqueSessionId := [simplest.DigestSize]byte{}
 	copy(uniqueSessionId[:], bob.transcript.ExtractBytes([]byte("schnorr proof for R"), simplest.DigestSize))
 	if err = schnorr.Verify(round3Output.RSchnorrProof, bob.curve, bob.dB, uniqueSessionId[:]); err != nil {
-		return errors.Wrap(err, "bob's verification of alice's schnorr proof re: r failed")
-	}
-	zero := bob.curve.Scalar.Zero()
-	affineCompressedForm := r.ToAffineCompressed()
-	if len(affineCompressedForm) != 33 {
-		return errors.New("the compressed form must be exactly 33 bytes")
-	}
-	rY := affineCompressedForm[0] & 0x1 // this is bit(0) of Y coordinate
-	rX, err := bob.curve.Scalar.SetBytes(affineCompressedForm[1:])
-	if err != nil {
-		return errors.Wrap(err, "setting rX scalar from bytes")
-	}
-	bob.Signature = &curves.EcdsaSignature{
-		R: rX.Add(zero).BigInt(), // slight trick here; add it to 0 just to mod it by q (now it's mod p!)
-		V: int(rY),
+		return nil, errors.Wrap(err, "bob's verification of alice's schnorr proof re: r failed")
 	}
 	gamma1 := r.Mul(bob.multiplyReceivers[0].outputAdditiveShare)
 	gamma1HashedBytes := sha3.Sum256(gamma1.ToAffineCompressed())
 	gamma1Hashed, err := bob.curve.Scalar.SetBytes(gamma1HashedBytes[:])
 	if err != nil {
-		return errors.Wrap(err, "setting gamma1Hashed scalar from bytes")
+		return nil, errors.Wrap(err, "setting gamma1Hashed scalar from bytes")
 	}
 	phi := round3Output.EtaPhi.Sub(gamma1Hashed)
 	theta := bob.multiplyReceivers[0].outputAdditiveShare.Sub(phi.Div(bob.kB))
-	if _, err = bob.hash.Write(message); err != nil {
-		return errors.Wrap(err, "writing message to hash in Bob sign round 5 final")
-	}
-	digestBytes := bob.hash.Sum(nil)
-	digest, err := bob.curve.Scalar.SetBytes(digestBytes)
-	if err != nil {
-		return errors.Wrap(err, "setting digest scalar from bytes")
-	}
-	capitalR, err := bob.curve.Scalar.SetBigInt(bob.Signature.R)
-	if err != nil {
-		return errors.Wrap(err, "setting capitalR scalar from big int")
-	}
-	sigB := digest.Mul(theta).Add(capitalR.Mul(bob.multiplyReceivers[1].outputAdditiveShare))
 	gamma2 := bob.curve.ScalarBaseMult(bob.multiplyReceivers[1].outputAdditiveShare)
 	other := bob.publicKey.Mul(theta.Neg())
 	gamma2 = gamma2.Add(other)
 	gamma2HashedBytes := sha3.Sum256(gamma2.ToAffineCompressed())
 	gamma2Hashed, err := bob.curve.Scalar.SetBytes(gamma2HashedBytes[:])
 	if err != nil {
-		return errors.Wrap(err, "setting gamma2Hashed scalar from bytes")
-	}
-	scalarS := sigB.Add(round3Output.EtaSig.Sub(gamma2Hashed))
-	bob.Signature.S = scalarS.BigInt()
-	if bob.Signature.S.Bit(255) == 1 {
-		bob.Signature.S = scalarS.Neg().BigInt()
-		bob.Signature.V ^= 1
-	}
-	// now verify the signature
-	unCompressedAffinePublicKey := bob.publicKey.ToAffineUncompressed()
-	if len(unCompressedAffinePublicKey) != 65 {
-		return errors.New("the uncompressed form must have exactly 65 bytes")
-	}
-	x := new(big.Int).SetBytes(unCompressedAffinePublicKey[1:33])
-	y := new(big.Int).SetBytes(unCompressedAffinePublicKey[33:])
-	ellipticCurve, err := bob.curve.ToEllipticCurve()
-	if err != nil {
-		return errors.Wrap(err, "invalid curve")
+		return nil, errors.Wrap(err, "setting gamma2Hashed scalar from bytes")
 	}
-	if !ecdsa.Verify(&ecdsa.PublicKey{Curve: ellipticCurve, X: x, Y: y}, digestBytes, bob.Signature.R, bob.Signature.S) {
-		return fmt.Errorf("final signature failed to verify")
+	presignature := &BobPresignature{
+		PublicKey:   bob.publicKey,
+		R:           r,
+		Theta:       theta,
+		SecretShare: bob.multiplyReceivers[1].outputAdditiveShare,
+		Gamma2Hash:  gamma2Hashed,
 	}
-	return nil
+	copy(presignature.ID[:], bob.transcript.ExtractBytes([]byte("presignature id"), simplest.DigestSize))
+	return presignature, nil
 }
@@ -1,16 +1,23 @@
 package dklsv1
 
 import (
-	"bytes"
-	"encoding/gob"
+	"math/big"
 
 	"github.com/pkg/errors"
 
 	"github.com/go-sonr/crypto/core/curves"
 	"github.com/go-sonr/crypto/core/protocol"
+	"github.com/go-sonr/crypto/core/protocol/messages"
 	"github.com/go-sonr/crypto/tecdsa/dklsv1/sign"
 )
 
+const (
+	signRound1Type = "dkls18-sign/round1"
+	signRound2Type = "dkls18-sign/round2"
+	signRound3Type = "dkls18-sign/round3"
+	signatureType  = "dkls18-sign/signature"
+)
+
 func newSignProtocolMessage(payload []byte, round string, version uint) *protocol.Message {
 	return &protocol.Message{
 		Protocol: protocol.Dkls18Sign,
@@ -24,49 +31,63 @@ func encodeSignRound1Output(commitment [32]byte, version uint) (*protocol.Messag
 	if version != protocol.Version1 {
 		return nil, errors.New("only version 1 is supported")
 	}
-	buf := bytes.NewBuffer([]byte{})
-	enc := gob.NewEncoder(buf)
-	if err := enc.Encode(&commitment); err != nil {
+	payload, err := encodeDigest(signRound1Type, commitment)
+	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return newSignProtocolMessage(buf.Bytes(), "1", version), nil
+	return newSignProtocolMessage(payload, "1", version), nil
 }
 
 func decodeSignRound2Input(m *protocol.Message) ([32]byte, error) {
-	if m.Version != protocol.Version1 {
-		return [32]byte{}, errors.New("only version 1 is supported")
-	}
-	buf := bytes.NewBuffer(m.Payloads[payloadKey])
-	dec := gob.NewDecoder(buf)
-	decoded := [32]byte{}
-	if err := dec.Decode(&decoded); err != nil {
-		return [32]byte{}, errors.WithStack(err)
-	}
-	return decoded, nil
+	return decodeDigest(m, protocol.Dkls18Sign, "1", signRound1Type)
 }
 
 func encodeSignRound2Output(output *sign.SignRound2Output, version uint) (*protocol.Message, error) {
 	if version != protocol.Version1 {
 		return nil, errors.New("only version 1 is supported")
 	}
-	buf := bytes.NewBuffer([]byte{})
-	enc := gob.NewEncoder(buf)
-	if err := enc.Encode(output); err != nil {
+	if output.DB == nil {
+		return nil, errors.New("missing sign round 2 output")
+	}
+	curve := curves.GetCurveByName(output.DB.CurveName())
+	if curve == nil {
+		return nil, errors.Errorf("unsupported curve %s", output.DB.CurveName())
+	}
+	enc := messages.NewEncoder(signRound2Type, curve)
+	for _, kosOutput := range output.KosRound1Outputs {
+		if err := writeKosRound1Output(enc, kosOutput); err != nil {
+			return nil, err
+		}
+	}
+	enc.WritePoint(output.DB)
+	enc.WriteBytes(output.Seed[:])
+	payload, err := enc.Finish()
+	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return newSignProtocolMessage(buf.Bytes(), "2", version), nil
+	return newSignProtocolMessage(payload, "2", version), nil
 }
 
 func decodeSignRound3Input(m *protocol.Message) (*sign.SignRound2Output, error) {
-	if m.Version != protocol.Version1 {
-		return nil, errors.New("only version 1 is supported")
+	dec, err := decodePayload(m, protocol.Dkls18Sign, "2", signRound2Type)
+	if err != nil {
+		return nil, err
 	}
-	buf := bytes.NewBuffer(m.Payloads[payloadKey])
-	dec := gob.NewDecoder(buf)
-	decoded := &sign.SignRound2Output{}
-	if err := dec.Decode(&decoded); err != nil {
-		return nil, errors.WithStack(err)
+	decoded := new(sign.SignRound2Output)
+	for i := range decoded.KosRound1Outputs {
+		if decoded.KosRound1Outputs[i], err = readKosRound1Output(dec); err != nil {
+			return nil, err
+		}
 	}
+	decoded.DB = dec.ReadPoint()
+	seed, err := readFixed(dec, len(decoded.Seed))
+	if err != nil {
+		return nil, err
+	}
+	if err = dec.Finish(); err != nil {
+		return nil, err
+	}
+	copy(decoded.Seed[:], seed)
 	return decoded, nil
 }
 
@@ -74,23 +95,42 @@ func encodeSignRound3Output(output *sign.SignRound3Output, version uint) (*proto
 	if version != protocol.Version1 {
 		return nil, errors.New("only version 1 is supported")
 	}
-	buf := bytes.NewBuffer([]byte{})
-	enc := gob.NewEncoder(buf)
-	if err := enc.Encode(output); err != nil {
+	curve, err := scalarCurve(output.EtaSig)
+	if err != nil {
+		return nil, err
+	}
+	enc := messages.NewEncoder(signRound3Type, curve)
+	for _, multiplyOutput := range output.MultiplyRound2Outputs {
+		if err = writeMultiplyRound2Output(enc, multiplyOutput); err != nil {
+			return nil, err
+		}
+	}
+	writeProof(enc, output.RSchnorrProof)
+	enc.WritePoint(output.RPrime)
+	enc.WriteScalar(output.EtaPhi)
+	enc.WriteScalar(output.EtaSig)
+	payload, err := enc.Finish()
+	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return newSignProtocolMessage(buf.Bytes(), "3", version), nil
+	return newSignProtocolMessage(payload, "3", version), nil
 }
 
 func decodeSignRound4Input(m *protocol.Message) (*sign.SignRound3Output, error) {
-	if m.Version != protocol.Version1 {
-		return nil, errors.New("only version 1 is supported")
+	dec, err := decodePayload(m, protocol.Dkls18Sign, "3", signRound3Type)
+	if err != nil {
+		return nil, err
 	}
-	buf := bytes.NewBuffer(m.Payloads[payloadKey])
-	dec := gob.NewDecoder(buf)
-	decoded := &sign.SignRound3Output{}
-	if err := dec.Decode(&decoded); err != nil {
-		return nil, errors.WithStack(err)
+	decoded := new(sign.SignRound3Output)
+	for i := range decoded.MultiplyRound2Outputs {
+		decoded.MultiplyRound2Outputs[i] = readMultiplyRound2Output(dec)
+	}
+	decoded.RSchnorrProof = readProof(dec)
+	decoded.RPrime = dec.ReadPoint()
+	decoded.EtaPhi = dec.ReadScalar()
+	decoded.EtaSig = dec.ReadScalar()
+	if err = dec.Finish(); err != nil {
+		return nil, err
 	}
 	return decoded, nil
 }
@@ -99,12 +139,18 @@ func encodeSignature(signature *curves.EcdsaSignature, version uint) (*protocol.
 	if version != protocol.Version1 {
 		return nil, errors.New("only version 1 is supported")
 	}
-	buf := bytes.NewBuffer([]byte{})
-	enc := gob.NewEncoder(buf)
-	if err := enc.Encode(signature); err != nil {
+	if signature == nil || signature.R == nil || signature.S == nil || signature.V < 0 {
+		return nil, errors.New("invalid signature")
+	}
+	enc := messages.NewEncoder(signatureType, nil)
+	enc.WriteBytes(signature.R.Bytes())
+	enc.WriteBytes(signature.S.Bytes())
+	enc.WriteUint32(uint32(signature.V))
+	payload, err := enc.Finish()
+	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return newSignProtocolMessage(buf.Bytes(), "signature", version), nil
+	return newSignProtocolMessage(payload, "signature", version), nil
 }
 
 // DecodeSignature serializes the signature.
@@ -112,11 +158,18 @@ func DecodeSignature(m *protocol.Message) (*curves.EcdsaSignature, error) {
 	if m.Version != protocol.Version1 {
 		return nil, errors.New("only version 1 is supported")
 	}
-	buf := bytes.NewBuffer(m.Payloads[payloadKey])
-	dec := gob.NewDecoder(buf)
-	decoded := &curves.EcdsaSignature{}
-	if err := dec.Decode(decoded); err != nil {
+	dec, err := messages.NewDecoder(m.Payloads[payloadKey], signatureType)
+	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	return decoded, nil
+	r, s := dec.ReadBytes(), dec.ReadBytes()
+	v := dec.ReadUint32()
+	if err = dec.Finish(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	// R and S are minimal big endian integers, so that the encoding is unique
+	if len(r) > 0 && r[0] == 0 || len(s) > 0 && s[0] == 0 {
+		return nil, errors.New("signature is not canonical")
+	}
+	return &curves.EcdsaSignature{R: new(big.Int).SetBytes(r), S: new(big.Int).SetBytes(s), V: int(v)}, nil
 }
@@ -0,0 +1,324 @@
+package dklsv1
+
+import (
+	"hash"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/protocol"
+	"github.com/go-sonr/crypto/tecdsa/dklsv1/dkg"
+	"github.com/go-sonr/crypto/tecdsa/dklsv1/refresh"
+)
+
+// The state of a party is serialized as a message of its protocol, whose metadata name the party and the step it
+// runs next, so that a party that crashed between steps resumes where it stopped. State must be persisted after
+// each step and before its output is sent: a party restored from an older state runs a step again with fresh
+// randomness, and its counterparty must not see both runs.
+//
+// Serialized state holds secret key shares and must be protected like them.
+const (
+	stateKey   = "state"
+	messageKey = "message"
+	dkgKey     = "dkg"
+)
+
+// ErrResumeAfterNonce is returned when serializing or restoring a signing party that has run a step. The first step
+// samples the signing nonce, and a restored party could complete a second signature with it, leaking its share.
+var ErrResumeAfterNonce = errors.New("signing state cannot be resumed after the nonce was sampled")
+
+// signInputs are the arguments of a signing party, its whole state before the first step
+type signInputs struct {
+	curve     *curves.Curve
+	message   []byte
+	dkgResult *protocol.Message
+}
+
+// party returns the party running the protocol
+func (p *protoStepper) party() Party {
+	if p.counterparty == PartyAlice {
+		return PartyBob
+	}
+	return PartyAlice
+}
+
+func (p *protoStepper) serialize(payloads map[string][]byte, metadata map[string]string) (*protocol.Message, error) {
+	if p.version != protocol.Version1 {
+		return nil, errors.New("only version 1 is supported")
+	}
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+	metadata["party"] = p.party().String()
+	metadata["step"] = strconv.Itoa(p.step)
+	return &protocol.Message{
+		Protocol: p.name,
+		Version:  p.version,
+		Payloads: payloads,
+		Metadata: metadata,
+	}, nil
+}
+
+func (p *protoStepper) serializeState(state interface{ MarshalBinary() ([]byte, error) }) (*protocol.Message, error) {
+	data, err := state.MarshalBinary()
+	if err != nil {
+		return nil, errors.Wrap(err, "serializing party state")
+	}
+	return p.serialize(map[string][]byte{stateKey: data}, nil)
+}
+
+func (p *protoStepper) serializeInputs(inputs *signInputs) (*protocol.Message, error) {
+	if p.step > 0 {
+		return nil, ErrResumeAfterNonce
+	}
+	if inputs == nil || inputs.dkgResult == nil {
+		return nil, protocol.ErrNotInitialized
+	}
+	payloads := map[string][]byte{dkgKey: inputs.dkgResult.Payloads[payloadKey]}
+	if inputs.message != nil {
+		payloads[messageKey] = inputs.message
+	}
+	return p.serialize(payloads, map[string]string{"curve": inputs.curve.Name})
+}
+
+// checkState checks that m is a serialized state of party in protocol name and returns the step it resumes at
+func checkState(m *protocol.Message, name string, party Party) (int, error) {
+	if m == nil {
+		return 0, protocol.ErrNotInitialized
+	}
+	if m.Protocol != name {
+		return 0, errors.Errorf("state of protocol %s, expected %s", m.Protocol, name)
+	}
+	if m.Version != protocol.Version1 {
+		return 0, errors.New("only version 1 is supported")
+	}
+	if m.Metadata["party"] != party.String() {
+		return 0, errors.Errorf("state of %s, expected %s", m.Metadata["party"], party)
+	}
+	step, err := strconv.Atoi(m.Metadata["step"])
+	if err != nil || step < 0 {
+		return 0, errors.Errorf("invalid step %q", m.Metadata["step"])
+	}
+	return step, nil
+}
+
+// resume sets the step of a restored party
+func (p *protoStepper) resume(step int) error {
+	if step > len(p.steps) {
+		return errors.Errorf("step %d of a protocol of %d steps", step, len(p.steps))
+	}
+	p.step = step
+	return nil
+}
+
+// checkInputs checks a serialized signing party and returns its arguments
+func checkInputs(m *protocol.Message, name string, party Party) (*curves.Curve, *protocol.Message, error) {
+	step, err := checkState(m, name, party)
+	if err != nil {
+		return nil, nil, err
+	}
+	if step != 0 {
+		return nil, nil, ErrResumeAfterNonce
+	}
+	curve := curves.GetCurveByName(m.Metadata["curve"])
+	if curve == nil {
+		return nil, nil, errors.Errorf("unknown curve %s", m.Metadata["curve"])
+	}
+	if m.Payloads[dkgKey] == nil {
+		return nil, nil, errors.New("state has no dkg result")
+	}
+	return curve, newDkgProtocolMessage(m.Payloads[dkgKey], party.String()+"-output", m.Version), nil
+}
+
+// Serialize returns the state of Alice, to resume the DKG with RestoreAliceDkg.
+func (a *AliceDkg) Serialize() (*protocol.Message, error) {
+	return a.serializeState(a.Alice)
+}
+
+// RestoreAliceDkg resumes the DKG of Alice from her serialized state.
+func RestoreAliceDkg(m *protocol.Message) (*AliceDkg, error) {
+	step, err := checkState(m, protocol.Dkls18Dkg, PartyAlice)
+	if err != nil {
+		return nil, err
+	}
+	alice := new(dkg.Alice)
+	if err := alice.UnmarshalBinary(m.Payloads[stateKey]); err != nil {
+		return nil, err
+	}
+	a := newAliceDkg(alice, m.Version)
+	if err := a.resume(step); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Serialize returns the state of Bob, to resume the DKG with RestoreBobDkg.
+func (b *BobDkg) Serialize() (*protocol.Message, error) {
+	return b.serializeState(b.Bob)
+}
+
+// RestoreBobDkg resumes the DKG of Bob from his serialized state.
+func RestoreBobDkg(m *protocol.Message) (*BobDkg, error) {
+	step, err := checkState(m, protocol.Dkls18Dkg, PartyBob)
+	if err != nil {
+		return nil, err
+	}
+	bob := new(dkg.Bob)
+	if err := bob.UnmarshalBinary(m.Payloads[stateKey]); err != nil {
+		return nil, err
+	}
+	b := newBobDkg(bob, m.Version)
+	if err := b.resume(step); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Serialize returns the state of Alice, to resume the refresh with RestoreAliceRefresh.
+func (a *AliceRefresh) Serialize() (*protocol.Message, error) {
+	return a.serializeState(a.Alice)
+}
+
+// RestoreAliceRefresh resumes the refresh of Alice from her serialized state.
+func RestoreAliceRefresh(m *protocol.Message) (*AliceRefresh, error) {
+	step, err := checkState(m, protocol.Dkls18Refresh, PartyAlice)
+	if err != nil {
+		return nil, err
+	}
+	alice := new(refresh.Alice)
+	if err := alice.UnmarshalBinary(m.Payloads[stateKey]); err != nil {
+		return nil, err
+	}
+	a := newAliceRefresh(alice, m.Version)
+	if err := a.resume(step); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Serialize returns the state of Bob, to resume the refresh with RestoreBobRefresh.
+func (b *BobRefresh) Serialize() (*protocol.Message, error) {
+	return b.serializeState(b.Bob)
+}
+
+// RestoreBobRefresh resumes the refresh of Bob from his serialized state.
+func RestoreBobRefresh(m *protocol.Message) (*BobRefresh, error) {
+	step, err := checkState(m, protocol.Dkls18Refresh, PartyBob)
+	if err != nil {
+		return nil, err
+	}
+	bob := new(refresh.Bob)
+	if err := bob.UnmarshalBinary(m.Payloads[stateKey]); err != nil {
+		return nil, err
+	}
+	b := newBobRefresh(bob, m.Version)
+	if err := b.resume(step); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Serialize returns the state of Alice, to resume the reshare with RestoreAliceReshare.
+func (a *AliceReshare) Serialize() (*protocol.Message, error) {
+	return a.serializeState(a.Alice)
+}
+
+// RestoreAliceReshare resumes the reshare of the new Alice from her serialized state.
+func RestoreAliceReshare(m *protocol.Message) (*AliceReshare, error) {
+	step, err := checkState(m, protocol.Dkls18Reshare, PartyAlice)
+	if err != nil {
+		return nil, err
+	}
+	alice := new(refresh.Alice)
+	if err := alice.UnmarshalBinary(m.Payloads[stateKey]); err != nil {
+		return nil, err
+	}
+	a := newAliceReshare(alice, m.Version)
+	if err := a.resume(step); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Serialize returns the state of Bob, to resume the reshare with RestoreBobReshare.
+func (b *BobReshare) Serialize() (*protocol.Message, error) {
+	return b.serializeState(b.Bob)
+}
+
+// RestoreBobReshare resumes the reshare of the new Bob from his serialized state.
+func RestoreBobReshare(m *protocol.Message) (*BobReshare, error) {
+	step, err := checkState(m, protocol.Dkls18Reshare, PartyBob)
+	if err != nil {
+		return nil, err
+	}
+	bob := new(refresh.Bob)
+	if err := bob.UnmarshalBinary(m.Payloads[stateKey]); err != nil {
+		return nil, err
+	}
+	b := newBobReshare(bob, m.Version)
+	if err := b.resume(step); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Serialize returns the state of Alice before her first step, to start signing later with RestoreAliceSign. It
+// returns ErrResumeAfterNonce once signing started.
+func (a *AliceSign) Serialize() (*protocol.Message, error) {
+	return a.serializeInputs(a.inputs)
+}
+
+// RestoreAliceSign restores Alice serialized before her first step. The hash is not serialized.
+func RestoreAliceSign(hash hash.Hash, m *protocol.Message) (*AliceSign, error) {
+	curve, dkgResult, err := checkInputs(m, protocol.Dkls18Sign, PartyAlice)
+	if err != nil {
+		return nil, err
+	}
+	return NewAliceSign(curve, hash, m.Payloads[messageKey], dkgResult, m.Version)
+}
+
+// Serialize returns the state of Bob before his first step, to start signing later with RestoreBobSign. It
+// returns ErrResumeAfterNonce once signing started.
+func (b *BobSign) Serialize() (*protocol.Message, error) {
+	return b.serializeInputs(b.inputs)
+}
+
+// RestoreBobSign restores Bob serialized before his first step. The hash is not serialized.
+func RestoreBobSign(hash hash.Hash, m *protocol.Message) (*BobSign, error) {
+	curve, dkgResult, err := checkInputs(m, protocol.Dkls18Sign, PartyBob)
+	if err != nil {
+		return nil, err
+	}
+	return NewBobSign(curve, hash, m.Payloads[messageKey], dkgResult, m.Version)
+}
+
+// Serialize returns the state of Alice before her first step, to start presigning later with RestoreAlicePresign.
+// It returns ErrResumeAfterNonce once presigning started.
+func (a *AlicePresign) Serialize() (*protocol.Message, error) {
+	return a.serializeInputs(a.inputs)
+}
+
+// RestoreAlicePresign restores Alice serialized before her first step.
+func RestoreAlicePresign(m *protocol.Message) (*AlicePresign, error) {
+	curve, dkgResult, err := checkInputs(m, protocol.Dkls18Presign, PartyAlice)
+	if err != nil {
+		return nil, err
+	}
+	return NewAlicePresign(curve, dkgResult, m.Version)
+}
+
+// Serialize returns the state of Bob before his first step, to start presigning later with RestoreBobPresign. It
+// returns ErrResumeAfterNonce once presigning started.
+func (b *BobPresign) Serialize() (*protocol.Message, error) {
+	return b.serializeInputs(b.inputs)
+}
+
+// RestoreBobPresign restores Bob serialized before his first step.
+func RestoreBobPresign(m *protocol.Message) (*BobPresign, error) {
+	curve, dkgResult, err := checkInputs(m, protocol.Dkls18Presign, PartyBob)
+	if err != nil {
+		return nil, err
+	}
+	return NewBobPresign(curve, dkgResult, m.Version)
+}
@@ -0,0 +1,175 @@
+package dklsv1
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/protocol"
+)
+
+type resumable interface {
+	protocol.Iterator
+	Serialize() (*protocol.Message, error)
+}
+
+// persist serializes a party through JSON, as it would be stored, and restores it
+func persist(t *testing.T, p resumable, restore func(*protocol.Message) (resumable, error)) resumable {
+	m, err := p.Serialize()
+	require.NoError(t, err)
+	data, err := json.Marshal(m)
+	require.NoError(t, err)
+	stored := new(protocol.Message)
+	require.NoError(t, json.Unmarshal(data, stored))
+	restored, err := restore(stored)
+	require.NoError(t, err)
+	return restored
+}
+
+// runResumedProtocol is runIteratedProtocol restoring both parties from their serialized state after every step
+func runResumedProtocol(t *testing.T, first resumable, restoreFirst func(*protocol.Message) (resumable, error), second resumable, restoreSecond func(*protocol.Message) (resumable, error)) (resumable, resumable) {
+	var (
+		message    *protocol.Message
+		aErr, bErr error
+	)
+	for aErr != protocol.ErrProtocolFinished || bErr != protocol.ErrProtocolFinished {
+		first = persist(t, first, restoreFirst)
+		message, bErr = first.Next(message)
+		if bErr != protocol.ErrProtocolFinished {
+			require.NoError(t, bErr)
+		}
+		second = persist(t, second, restoreSecond)
+		message, aErr = second.Next(message)
+		if aErr != protocol.ErrProtocolFinished {
+			require.NoError(t, aErr)
+		}
+	}
+	return first, second
+}
+
+func TestResumeDkgAndRefresh(t *testing.T) {
+	t.Parallel()
+	for _, curve := range []*curves.Curve{curves.K256(), curves.P256()} {
+		bob, alice := runResumedProtocol(t,
+			NewBobDkg(curve, protocol.Version1), func(m *protocol.Message) (resumable, error) { return RestoreBobDkg(m) },
+			NewAliceDkg(curve, protocol.Version1), func(m *protocol.Message) (resumable, error) { return RestoreAliceDkg(m) })
+		aliceDkgResultMessage, err := alice.Result(protocol.Version1)
+		require.NoError(t, err)
+		bobDkgResultMessage, err := bob.Result(protocol.Version1)
+		require.NoError(t, err)
+
+		aliceRefresh, err := NewAliceRefresh(curve, aliceDkgResultMessage, protocol.Version1)
+		require.NoError(t, err)
+		bobRefresh, err := NewBobRefresh(curve, bobDkgResultMessage, protocol.Version1)
+		require.NoError(t, err)
+		aliceResumed, bobResumed := runResumedProtocol(t,
+			aliceRefresh, func(m *protocol.Message) (resumable, error) { return RestoreAliceRefresh(m) },
+			bobRefresh, func(m *protocol.Message) (resumable, error) { return RestoreBobRefresh(m) })
+		aliceRefreshResultMessage, err := aliceResumed.Result(protocol.Version1)
+		require.NoError(t, err)
+		bobRefreshResultMessage, err := bobResumed.Result(protocol.Version1)
+		require.NoError(t, err)
+
+		signV1(t, curve, aliceRefreshResultMessage, bobRefreshResultMessage)
+
+		aliceToAlice, aliceToBob, err := SplitAliceShare(curve, aliceRefreshResultMessage, protocol.Version1)
+		require.NoError(t, err)
+		bobToAlice, bobToBob, err := SplitBobShare(curve, bobRefreshResultMessage, protocol.Version1)
+		require.NoError(t, err)
+		aliceReshare, err := NewAliceReshare(curve, aliceToAlice, bobToAlice, protocol.Version1)
+		require.NoError(t, err)
+		bobReshare, err := NewBobReshare(curve, aliceToBob, bobToBob, protocol.Version1)
+		require.NoError(t, err)
+		aliceResumed, bobResumed = runResumedProtocol(t,
+			aliceReshare, func(m *protocol.Message) (resumable, error) { return RestoreAliceReshare(m) },
+			bobReshare, func(m *protocol.Message) (resumable, error) { return RestoreBobReshare(m) })
+		aliceReshareResultMessage, err := aliceResumed.Result(protocol.Version1)
+		require.NoError(t, err)
+		bobReshareResultMessage, err := bobResumed.Result(protocol.Version1)
+		require.NoError(t, err)
+
+		signV1(t, curve, aliceReshareResultMessage, bobReshareResultMessage)
+	}
+}
+
+func TestResumeSign(t *testing.T) {
+	t.Parallel()
+	curve := curves.K256()
+	aliceDkg := NewAliceDkg(curve, protocol.Version1)
+	bobDkg := NewBobDkg(curve, protocol.Version1)
+	aErr, bErr := runIteratedProtocol(bobDkg, aliceDkg)
+	require.ErrorIs(t, aErr, protocol.ErrProtocolFinished)
+	require.ErrorIs(t, bErr, protocol.ErrProtocolFinished)
+	aliceDkgResultMessage, err := aliceDkg.Result(protocol.Version1)
+	require.NoError(t, err)
+	bobDkgResultMessage, err := bobDkg.Result(protocol.Version1)
+	require.NoError(t, err)
+
+	message := []byte("resumed signing")
+	aliceSign, err := NewAliceSign(curve, sha3.New256(), message, aliceDkgResultMessage, protocol.Version1)
+	require.NoError(t, err)
+	bobSign, err := NewBobSign(curve, sha3.New256(), message, bobDkgResultMessage, protocol.Version1)
+	require.NoError(t, err)
+
+	// before the first step the state is the arguments
+	aliceSign = persist(t, aliceSign, func(m *protocol.Message) (resumable, error) { return RestoreAliceSign(sha3.New256(), m) }).(*AliceSign)
+	bobSign = persist(t, bobSign, func(m *protocol.Message) (resumable, error) { return RestoreBobSign(sha3.New256(), m) }).(*BobSign)
+
+	// after it, the nonce is sampled and the state is refused
+	commitment, err := aliceSign.Next(nil)
+	require.NoError(t, err)
+	_, err = aliceSign.Serialize()
+	require.ErrorIs(t, err, ErrResumeAfterNonce)
+	m, err := bobSign.Serialize()
+	require.NoError(t, err)
+	m.Metadata["step"] = "1"
+	_, err = RestoreBobSign(sha3.New256(), m)
+	require.ErrorIs(t, err, ErrResumeAfterNonce)
+
+	round2, err := bobSign.Next(commitment)
+	require.NoError(t, err)
+	round3, err := aliceSign.Next(round2)
+	require.NoError(t, err)
+	_, err = bobSign.Next(round3)
+	require.NoError(t, err)
+	sig, err := bobSign.Result(protocol.Version1)
+	require.NoError(t, err)
+	require.NotNil(t, sig)
+
+	// presigning follows the same rule
+	alicePresign, err := NewAlicePresign(curve, aliceDkgResultMessage, protocol.Version1)
+	require.NoError(t, err)
+	alicePresign = persist(t, alicePresign, func(m *protocol.Message) (resumable, error) { return RestoreAlicePresign(m) }).(*AlicePresign)
+	_, err = alicePresign.Next(nil)
+	require.NoError(t, err)
+	_, err = alicePresign.Serialize()
+	require.ErrorIs(t, err, ErrResumeAfterNonce)
+}
+
+func TestRestoreChecks(t *testing.T) {
+	t.Parallel()
+	curve := curves.K256()
+	alice := NewAliceDkg(curve, protocol.Version1)
+	m, err := alice.Serialize()
+	require.NoError(t, err)
+
+	_, err = RestoreBobDkg(m)
+	require.Error(t, err)
+	_, err = RestoreAliceRefresh(m)
+	require.Error(t, err)
+
+	m.Metadata["step"] = "99"
+	_, err = RestoreAliceDkg(m)
+	require.Error(t, err)
+	m.Metadata["step"] = "0"
+	m.Version = 2
+	_, err = RestoreAliceDkg(m)
+	require.Error(t, err)
+	m.Version = protocol.Version1
+	m.Payloads[stateKey] = []byte("garbage")
+	_, err = RestoreAliceDkg(m)
+	require.Error(t, err)
+}
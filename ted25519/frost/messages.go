@@ -0,0 +1,148 @@
+package frost
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/protocol/messages"
+	"github.com/go-sonr/crypto/internal"
+)
+
+const (
+	round1BcastType = "ted25519-frost/round1-bcast"
+	round2BcastType = "ted25519-frost/round2-bcast"
+	round3BcastType = "ted25519-frost/round3-bcast"
+	signatureType   = "ted25519-frost/signature"
+)
+
+// MarshalBinary encodes the broadcast in the canonical messages format.
+func (result *Round1Bcast) MarshalBinary() ([]byte, error) {
+	if result == nil || result.Di == nil || result.Ei == nil {
+		return nil, internal.ErrNilArguments
+	}
+	curve, err := curveOf(result.Di.CurveName())
+	if err != nil {
+		return nil, err
+	}
+	enc := messages.NewEncoder(round1BcastType, curve)
+	enc.WritePoint(result.Di)
+	enc.WritePoint(result.Ei)
+	return enc.Finish()
+}
+
+// UnmarshalBinary decodes a broadcast encoded by MarshalBinary.
+func (result *Round1Bcast) UnmarshalBinary(data []byte) error {
+	dec, err := messages.NewDecoder(data, round1BcastType)
+	if err != nil {
+		return errors.Wrap(err, "couldn't decode round 1 broadcast")
+	}
+	di := dec.ReadPoint()
+	ei := dec.ReadPoint()
+	if err = dec.Finish(); err != nil {
+		return errors.Wrap(err, "couldn't decode round 1 broadcast")
+	}
+	result.Di, result.Ei = di, ei
+	return nil
+}
+
+// MarshalBinary encodes the broadcast in the canonical messages format.
+func (result *Round2Bcast) MarshalBinary() ([]byte, error) {
+	if result == nil || result.Zi == nil || result.Vki == nil {
+		return nil, internal.ErrNilArguments
+	}
+	curve, err := curveOf(result.Vki.CurveName())
+	if err != nil {
+		return nil, err
+	}
+	enc := messages.NewEncoder(round2BcastType, curve)
+	enc.WriteScalar(result.Zi)
+	enc.WritePoint(result.Vki)
+	return enc.Finish()
+}
+
+// UnmarshalBinary decodes a broadcast encoded by MarshalBinary.
+func (result *Round2Bcast) UnmarshalBinary(data []byte) error {
+	dec, err := messages.NewDecoder(data, round2BcastType)
+	if err != nil {
+		return errors.Wrap(err, "couldn't decode round 2 broadcast")
+	}
+	zi := dec.ReadScalar()
+	vki := dec.ReadPoint()
+	if err = dec.Finish(); err != nil {
+		return errors.Wrap(err, "couldn't decode round 2 broadcast")
+	}
+	result.Zi, result.Vki = zi, vki
+	return nil
+}
+
+// MarshalBinary encodes the broadcast, including the signed message, in the canonical messages format.
+func (result *Round3Bcast) MarshalBinary() ([]byte, error) {
+	if result == nil || result.R == nil || result.Z == nil || result.C == nil {
+		return nil, internal.ErrNilArguments
+	}
+	curve, err := curveOf(result.R.CurveName())
+	if err != nil {
+		return nil, err
+	}
+	enc := messages.NewEncoder(round3BcastType, curve)
+	enc.WritePoint(result.R)
+	enc.WriteScalar(result.Z)
+	enc.WriteScalar(result.C)
+	enc.WriteBytes(result.msg)
+	return enc.Finish()
+}
+
+// UnmarshalBinary decodes a broadcast encoded by MarshalBinary.
+func (result *Round3Bcast) UnmarshalBinary(data []byte) error {
+	dec, err := messages.NewDecoder(data, round3BcastType)
+	if err != nil {
+		return errors.Wrap(err, "couldn't decode round 3 broadcast")
+	}
+	r := dec.ReadPoint()
+	z := dec.ReadScalar()
+	c := dec.ReadScalar()
+	msg := dec.ReadBytes()
+	if err = dec.Finish(); err != nil {
+		return errors.Wrap(err, "couldn't decode round 3 broadcast")
+	}
+	result.R, result.Z, result.C, result.msg = r, z, c, msg
+	return nil
+}
+
+// MarshalBinary encodes the signature in the canonical messages format.
+func (sig *Signature) MarshalBinary() ([]byte, error) {
+	if sig == nil || sig.Z == nil || sig.C == nil {
+		return nil, internal.ErrNilArguments
+	}
+	curve, err := curveOf(sig.Z.Point().CurveName())
+	if err != nil {
+		return nil, err
+	}
+	enc := messages.NewEncoder(signatureType, curve)
+	enc.WriteScalar(sig.Z)
+	enc.WriteScalar(sig.C)
+	return enc.Finish()
+}
+
+// UnmarshalBinary decodes a signature encoded by MarshalBinary.
+func (sig *Signature) UnmarshalBinary(data []byte) error {
+	dec, err := messages.NewDecoder(data, signatureType)
+	if err != nil {
+		return errors.Wrap(err, "couldn't decode signature")
+	}
+	z := dec.ReadScalar()
+	c := dec.ReadScalar()
+	if err = dec.Finish(); err != nil {
+		return errors.Wrap(err, "couldn't decode signature")
+	}
+	sig.Z, sig.C = z, c
+	return nil
+}
+
+func curveOf(name string) (*curves.Curve, error) {
+	curve := curves.GetCurveByName(name)
+	if curve == nil {
+		return nil, errors.Errorf("unsupported curve %s", name)
+	}
+	return curve, nil
+}
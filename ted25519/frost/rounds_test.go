@@ -402,3 +402,77 @@ func TestFullRoundsWorks(t *testing.T) {
 	require.Equal(t, result[1].C, result[3].C)
 	// require.Equal(t, c, result[3].C)
 }
+
+func TestSignRoundMessagesMarshal(t *testing.T) {
+	signer1, _, round3Input := PrepareRound3Input(t)
+	round2Out := round3Input[signer1.id]
+	data, err := round2Out.MarshalBinary()
+	require.NoError(t, err)
+	decoded2 := &Round2Bcast{}
+	require.NoError(t, decoded2.UnmarshalBinary(data))
+	require.Equal(t, 0, round2Out.Zi.Cmp(decoded2.Zi))
+	require.True(t, round2Out.Vki.Equal(decoded2.Vki))
+	require.Error(t, (&Round1Bcast{}).UnmarshalBinary(data))
+
+	round3Out, err := signer1.SignRound3(round3Input)
+	require.NoError(t, err)
+	data, err = round3Out.MarshalBinary()
+	require.NoError(t, err)
+	decoded3 := &Round3Bcast{}
+	require.NoError(t, decoded3.UnmarshalBinary(data))
+	require.True(t, round3Out.R.Equal(decoded3.R))
+	require.Equal(t, 0, round3Out.Z.Cmp(decoded3.Z))
+	require.Equal(t, 0, round3Out.C.Cmp(decoded3.C))
+	require.Equal(t, round3Out.msg, decoded3.msg)
+
+	signature := &Signature{Z: round3Out.Z, C: round3Out.C}
+	data, err = signature.MarshalBinary()
+	require.NoError(t, err)
+	decodedSig := &Signature{}
+	require.NoError(t, decodedSig.UnmarshalBinary(data))
+	ok, err := Verify(signer1.curve, signer1.challengeDeriver, signer1.verificationKey, []byte("message"), decodedSig)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	round1Out := &Round1Bcast{Di: testCurve.Point.Generator(), Ei: testCurve.Point.Generator().Double()}
+	data, err = round1Out.MarshalBinary()
+	require.NoError(t, err)
+	decoded1 := &Round1Bcast{}
+	require.NoError(t, decoded1.UnmarshalBinary(data))
+	require.True(t, round1Out.Di.Equal(decoded1.Di))
+	require.True(t, round1Out.Ei.Equal(decoded1.Ei))
+}
+
+func TestSignerStateResume(t *testing.T) {
+	signer1, signer2 := PrepareNewSigners(t)
+	data, err := signer1.MarshalBinary()
+	require.NoError(t, err)
+	signer1, err = RestoreSigner(data, &Ed25519ChallengeDeriver{})
+	require.NoError(t, err)
+
+	round2Input := make(map[uint32]*Round1Bcast, 2)
+	for _, s := range []*Signer{signer1, signer2} {
+		round2Input[s.id], err = s.SignRound1()
+		require.NoError(t, err)
+	}
+
+	// the nonces of round 1 are not part of any state
+	_, err = signer1.MarshalBinary()
+	require.ErrorIs(t, err, ErrResumeAfterNonce)
+
+	msg := []byte("message")
+	round3Input := make(map[uint32]*Round2Bcast, 2)
+	for _, s := range []*Signer{signer1, signer2} {
+		round3Input[s.id], err = s.SignRound2(msg, round2Input)
+		require.NoError(t, err)
+	}
+	for _, s := range []*Signer{signer1, signer2} {
+		_, err = s.SignRound3(round3Input)
+		require.NoError(t, err)
+	}
+
+	_, err = RestoreSigner(data[:len(data)-1], &Ed25519ChallengeDeriver{})
+	require.Error(t, err)
+	_, err = RestoreSigner(data, nil)
+	require.Error(t, err)
+}
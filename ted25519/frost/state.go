@@ -0,0 +1,95 @@
+package frost
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/protocol/messages"
+	"github.com/go-sonr/crypto/internal"
+)
+
+// The state of a signer is serialized in the canonical messages format, whose header carries the version of the
+// encoding. Only a signer that hasn't run round 1 has a state: round 1 samples the nonces, and a signer restored after
+// it could answer two different round 2 inputs with them, which reveals its share. A signer that crashed after round 1
+// starts over with a new signer.
+//
+// Serialized state holds the secret share of the signer and must be protected like it.
+const signerStateType = "ted25519-frost/signer-state"
+
+// ErrResumeAfterNonce is returned when serializing a signer that has run round 1, which sampled its nonces.
+var ErrResumeAfterNonce = errors.New("signing state cannot be resumed after the nonce was sampled")
+
+// MarshalBinary encodes the state of a signer that hasn't run round 1.
+func (signer *Signer) MarshalBinary() ([]byte, error) {
+	if signer == nil || signer.curve == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if signer.round != 1 {
+		return nil, ErrResumeAfterNonce
+	}
+	enc := messages.NewEncoder(signerStateType, signer.curve)
+	enc.WriteUint32(signer.id)
+	enc.WriteUint32(signer.threshold)
+	enc.WriteUint32s(signer.cosigners)
+	for _, id := range signer.cosigners {
+		enc.WriteScalar(signer.lCoeffs[id])
+	}
+	enc.WriteScalar(signer.skShare)
+	enc.WritePoint(signer.vkShare)
+	enc.WritePoint(signer.verificationKey)
+	data, err := enc.Finish()
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't encode signer state")
+	}
+	return data, nil
+}
+
+// RestoreSigner restores a signer encoded by MarshalBinary. The challenge deriver is not part of the state.
+func RestoreSigner(data []byte, challengeDeriver ChallengeDerive) (*Signer, error) {
+	if challengeDeriver == nil {
+		return nil, internal.ErrNilArguments
+	}
+	dec, err := messages.NewDecoder(data, signerStateType)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't decode signer state")
+	}
+	curve := dec.Curve()
+	if curve == nil {
+		return nil, errors.New("signer state has no curve")
+	}
+	id := dec.ReadUint32()
+	threshold := dec.ReadUint32()
+	cosigners := dec.ReadUint32s()
+	lCoeffs := make(map[uint32]curves.Scalar, len(cosigners))
+	for _, cosigner := range cosigners {
+		lCoeffs[cosigner] = dec.ReadScalar()
+	}
+	skShare := dec.ReadScalar()
+	vkShare := dec.ReadPoint()
+	verificationKey := dec.ReadPoint()
+	if err = dec.Finish(); err != nil {
+		return nil, errors.Wrap(err, "couldn't decode signer state")
+	}
+	if len(cosigners) == 0 || len(lCoeffs) != len(cosigners) {
+		return nil, errors.New("invalid cosigners")
+	}
+	if threshold > uint32(len(cosigners)) {
+		return nil, errors.New("threshold is higher than number of signers")
+	}
+	if !curve.ScalarBaseMult(skShare).Equal(vkShare) {
+		return nil, errors.New("secret share doesn't match the verification key share")
+	}
+	return &Signer{
+		skShare:          skShare,
+		vkShare:          vkShare,
+		verificationKey:  verificationKey,
+		id:               id,
+		threshold:        threshold,
+		curve:            curve,
+		round:            1,
+		lCoeffs:          lCoeffs,
+		cosigners:        cosigners,
+		state:            &state{},
+		challengeDeriver: challengeDeriver,
+	}, nil
+}
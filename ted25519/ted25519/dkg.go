@@ -0,0 +1,85 @@
+package ted25519
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/dkg/pedersen"
+)
+
+// DkgResult is the key material of one signer after the distributed key generation.
+type DkgResult struct {
+	KeyShare  *KeyShare
+	PublicKey PublicKey
+	// VerificationShares are the public keys of the key shares of all signers,
+	// used to verify their partial signatures.
+	VerificationShares map[byte]PublicKey
+	Config             *ShareConfiguration
+}
+
+// NewDkgParticipant creates a participant of the Pedersen DKG over Ed25519 so
+// the key is generated without a dealer. The participant runs the rounds of
+// dkg/pedersen and NewDkgResult converts its result to the types of this package.
+func NewDkgParticipant(id byte, config *ShareConfiguration, ctx []byte, others ...byte) (*pedersen.Participant, error) {
+	if config == nil {
+		return nil, fmt.Errorf("ted25519: config must be non-nil")
+	}
+	if config.N != len(others)+1 {
+		return nil, fmt.Errorf("ted25519: expected %d other participants, got %d", config.N-1, len(others))
+	}
+	if config.T < 1 || config.T > config.N {
+		return nil, fmt.Errorf("ted25519: invalid threshold %d", config.T)
+	}
+	ids := make([]uint32, len(others))
+	for i, other := range others {
+		ids[i] = uint32(other)
+	}
+	return pedersen.NewParticipant(uint32(id), uint32(config.T), curves.ED25519(), ctx, ids...)
+}
+
+// NewDkgResult converts the output of a participant created by NewDkgParticipant.
+func NewDkgResult(result *pedersen.Result, config *ShareConfiguration) (*DkgResult, error) {
+	if result == nil || result.SecretShare == nil || result.PublicKey == nil || config == nil {
+		return nil, fmt.Errorf("ted25519: dkg result must be non-nil")
+	}
+	if result.SecretShare.Id == 0 || result.SecretShare.Id > math.MaxUint8 {
+		return nil, fmt.Errorf("ted25519: invalid share identifier %d", result.SecretShare.Id)
+	}
+	if _, err := curves.ED25519().Scalar.SetBytes(result.SecretShare.Value); err != nil {
+		return nil, fmt.Errorf("ted25519: invalid secret share: %w", err)
+	}
+
+	shares := make(map[byte]PublicKey, len(result.PublicShares))
+	for id, share := range result.PublicShares {
+		if id == 0 || id > math.MaxUint8 {
+			return nil, fmt.Errorf("ted25519: invalid share identifier %d", id)
+		}
+		shares[byte(id)] = share.ToAffineCompressed()
+	}
+	return &DkgResult{
+		// The key share is a big-endian field element while the DKG outputs little-endian scalars
+		KeyShare:           NewKeyShare(byte(result.SecretShare.Id), reverseBytes(result.SecretShare.Value)),
+		PublicKey:          result.PublicKey.ToAffineCompressed(),
+		VerificationShares: shares,
+		Config:             config,
+	}, nil
+}
+
+// VerificationShare returns the public key of the share of signer id under the
+// commitments of a dealer, the counterpart of VerifyVSS for partial signatures.
+func (commitments Commitments) VerificationShare(id byte) (PublicKey, error) {
+	if len(commitments) == 0 {
+		return nil, fmt.Errorf("ted25519: commitments must be non-empty")
+	}
+	if id == 0 {
+		return nil, fmt.Errorf("ted25519: invalid share identifier 0")
+	}
+	x := curves.ED25519().Scalar.New(int(id))
+	// c_0 + x*(c_1 + x*(c_2 + ...))
+	rhs := commitments[len(commitments)-1]
+	for j := len(commitments) - 2; j >= 0; j-- {
+		rhs = rhs.Mul(x).Add(commitments[j])
+	}
+	return rhs.ToAffineCompressed(), nil
+}
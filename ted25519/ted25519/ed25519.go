@@ -20,9 +20,10 @@ import (
 	"crypto/sha512"
 	"fmt"
 	"io"
-	"strconv"
 
 	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/secret"
+	"github.com/go-sonr/crypto/signatures/nonce"
 )
 
 const (
@@ -54,6 +55,11 @@ func (priv PrivateKey) Public() crypto.PublicKey {
 	return PublicKey(publicKey)
 }
 
+// Zeroize overwrites the private key with zeros
+func (priv PrivateKey) Zeroize() {
+	secret.Wipe(priv)
+}
+
 // Seed returns the private key seed corresponding to priv. It is provided for
 // interoperability with RFC 8032. RFC 8032's private keys correspond to seeds
 // in this package.
@@ -117,7 +123,7 @@ func NewKeyFromSeed(seed []byte) (PrivateKey, error) {
 
 func newKeyFromSeed(privateKey, seed []byte) error {
 	if l := len(seed); l != SeedSize {
-		return fmt.Errorf("ed25519: bad seed length: " + strconv.Itoa(l))
+		return fmt.Errorf("ed25519: bad seed length: %d", l)
 	}
 
 	digest := sha512.Sum512(seed)
@@ -147,16 +153,31 @@ func Sign(privateKey PrivateKey, message []byte) ([]byte, error) {
 	// Outline the function body so that the returned signature can be
 	// stack-allocated.
 	signature := make([]byte, SignatureSize)
-	err := sign(signature, privateKey, message)
+	err := sign(signature, privateKey, message, nil)
 	if err != nil {
 		return nil, err
 	}
 	return signature, nil
 }
 
-func sign(signature, privateKey, message []byte) error {
+// SignWith signs the message with privateKey and a nonce of nonces rather
+// than the deterministic nonce of RFC 8032. The generator gets the hash of
+// the nonce prefix and message as its digest, so hedged nonces stay bound
+// to the key and message while adding fresh randomness.
+func SignWith(privateKey PrivateKey, message []byte, nonces nonce.Generator) ([]byte, error) {
+	if nonces == nil {
+		return nil, fmt.Errorf("ed25519: nonce generator is required")
+	}
+	signature := make([]byte, SignatureSize)
+	if err := sign(signature, privateKey, message, nonces); err != nil {
+		return nil, err
+	}
+	return signature, nil
+}
+
+func sign(signature, privateKey, message []byte, nonces nonce.Generator) error {
 	if l := len(privateKey); l != PrivateKeySize {
-		return fmt.Errorf("ed25519: bad private key length: " + strconv.Itoa(l))
+		return fmt.Errorf("ed25519: bad private key length: %d", l)
 	}
 
 	var err error
@@ -185,7 +206,17 @@ func sign(signature, privateKey, message []byte) error {
 	}
 	_ = h.Sum(messageDigest[:0])
 
-	r, err := new(curves.ScalarEd25519).SetBytesWide(messageDigest[:])
+	s, err := new(curves.ScalarEd25519).SetBytesClamping(expandedSecretKey[:])
+	if err != nil {
+		return err
+	}
+
+	var r curves.Scalar
+	if nonces == nil {
+		r, err = new(curves.ScalarEd25519).SetBytesWide(messageDigest[:])
+	} else {
+		r, err = nonces.Nonce(curves.ED25519(), s, messageDigest[:])
+	}
 	if err != nil {
 		return err
 	}
@@ -215,11 +246,6 @@ func sign(signature, privateKey, message []byte) error {
 		return err
 	}
 
-	s, err := new(curves.ScalarEd25519).SetBytesClamping(expandedSecretKey[:])
-	if err != nil {
-		return err
-	}
-
 	// S = k*s + r
 	S := k.MulAdd(s, r)
 	copy(signature[:], encodedR[:])
@@ -232,11 +258,11 @@ func sign(signature, privateKey, message []byte) error {
 // Previously publicKey is of type PublicKey
 func Verify(publicKey PublicKey, message, sig []byte) (bool, error) {
 	if l := len(publicKey); l != PublicKeySize {
-		return false, fmt.Errorf("ed25519: bad public key length: " + strconv.Itoa(l))
+		return false, fmt.Errorf("ed25519: bad public key length: %d", l)
 	}
 
 	if len(sig) != SignatureSize || sig[63]&224 != 0 {
-		return false, fmt.Errorf("ed25519: bad signature size: " + strconv.Itoa(len(sig)))
+		return false, fmt.Errorf("ed25519: bad signature size: %d", len(sig))
 	}
 
 	var publicKeyBytes [32]byte
@@ -286,3 +312,78 @@ func Verify(publicKey PublicKey, message, sig []byte) (bool, error) {
 	// Check R == R'
 	return bytes.Equal(sig[:32], R.ToAffineCompressed()), nil
 }
+
+// BatchVerify reports whether every sigs[i] is a valid signature of messages[i]
+// by publicKeys[i]. The signatures are checked together with the random linear
+// combination
+//
+//	8 * (sum z_i*s_i * B - sum z_i*R_i - sum z_i*h_i*A_i) == 0
+//
+// for 128-bit z_i read from rand, which costs one multi-scalar multiplication
+// instead of one double scalar multiplication per signature. The equation is
+// the cofactored one, so a signature whose R or A has a small order component
+// can pass BatchVerify and fail Verify. If rand is nil, crypto/rand.Reader will be used.
+func BatchVerify(rand io.Reader, publicKeys []PublicKey, messages, sigs [][]byte) (bool, error) {
+	if len(publicKeys) != len(messages) || len(publicKeys) != len(sigs) {
+		return false, fmt.Errorf("ed25519: batch length mismatch")
+	}
+	if rand == nil {
+		rand = cryptorand.Reader
+	}
+	curve := curves.ED25519()
+	points := make([]curves.Point, 0, 2*len(sigs)+1)
+	scalars := make([]curves.Scalar, 0, 2*len(sigs)+1)
+	points = append(points, curve.NewGeneratorPoint())
+	scalars = append(scalars, curve.NewScalar())
+
+	var zBytes [32]byte
+	for i, sig := range sigs {
+		if l := len(publicKeys[i]); l != PublicKeySize {
+			return false, fmt.Errorf("ed25519: bad public key length: %d", l)
+		}
+		if len(sig) != SignatureSize || sig[63]&224 != 0 {
+			return false, fmt.Errorf("ed25519: bad signature size: %d", len(sig))
+		}
+		A, err := new(curves.PointEd25519).FromAffineCompressed(publicKeys[i])
+		if err != nil {
+			return false, err
+		}
+		// Verify recomputes R, so it only accepts its canonical encoding
+		R, err := new(curves.PointEd25519).FromAffineCompressed(sig[:32])
+		if err != nil || !bytes.Equal(sig[:32], R.ToAffineCompressed()) {
+			return false, nil
+		}
+		s, err := new(curves.ScalarEd25519).SetBytesCanonical(sig[32:])
+		if err != nil {
+			return false, err
+		}
+
+		h := sha512.New()
+		_, _ = h.Write(sig[:32])
+		_, _ = h.Write(publicKeys[i])
+		_, _ = h.Write(messages[i])
+		hReduced, err := new(curves.ScalarEd25519).SetBytesWide(h.Sum(nil))
+		if err != nil {
+			return false, err
+		}
+
+		if _, err = io.ReadFull(rand, zBytes[:16]); err != nil {
+			return false, err
+		}
+		z, err := curve.Scalar.SetBytes(zBytes[:])
+		if err != nil {
+			return false, err
+		}
+
+		scalars[0] = scalars[0].Add(z.Mul(s))
+		points = append(points, R.Neg(), A.Neg())
+		scalars = append(scalars, z, z.Mul(hReduced))
+	}
+
+	// Only public values enter the combination
+	sum := new(curves.PointEd25519).VarTimeSumOfProducts(points, scalars)
+	if sum == nil {
+		return false, fmt.Errorf("ed25519: batch multi-scalar multiplication failed")
+	}
+	return sum.Double().Double().Double().IsIdentity(), nil
+}
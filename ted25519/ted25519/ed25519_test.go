@@ -17,6 +17,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/signatures/nonce"
 )
 
 // sign.input.gz is a selection of test cases from
@@ -49,6 +50,25 @@ func TestUnmarshalMarshal(t *testing.T) {
 	}
 }
 
+func TestSignWithHedgedNonces(t *testing.T) {
+	public, private, err := GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	message := []byte("test message")
+
+	a, err := SignWith(private, message, nonce.Default())
+	require.NoError(t, err)
+	b, err := SignWith(private, message, nonce.Default())
+	require.NoError(t, err)
+	require.NotEqual(t, a, b)
+	for _, sig := range [][]byte{a, b} {
+		ok, err := Verify(public, message, sig)
+		require.NoError(t, err)
+		require.True(t, ok)
+	}
+	_, err = SignWith(private, message, nil)
+	require.Error(t, err)
+}
+
 func TestSignVerify(t *testing.T) {
 	var zero zeroReader
 	public, private, err := GenerateKey(zero)
@@ -163,3 +183,58 @@ func BenchmarkVerification(b *testing.B) {
 		_, _ = Verify(pub, message, signature)
 	}
 }
+
+func TestBatchVerify(t *testing.T) {
+	const n = 16
+	publicKeys := make([]PublicKey, n)
+	messages := make([][]byte, n)
+	sigs := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		pub, priv, err := GenerateKey(nil)
+		require.NoError(t, err)
+		publicKeys[i] = pub
+		messages[i] = []byte{byte(i), 'm', 's', 'g'}
+		sigs[i], err = Sign(priv, messages[i])
+		require.NoError(t, err)
+	}
+	ok, err := BatchVerify(nil, publicKeys, messages, sigs)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = BatchVerify(nil, nil, nil, nil)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// A single bad signature fails the whole batch
+	messages[3] = []byte("other")
+	ok, err = BatchVerify(nil, publicKeys, messages, sigs)
+	require.NoError(t, err)
+	require.False(t, ok)
+	messages[3] = []byte{3, 'm', 's', 'g'}
+
+	sigs[5] = append([]byte{}, sigs[5]...)
+	sigs[5][40] ^= 1
+	ok, err = BatchVerify(nil, publicKeys, messages, sigs)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, err = BatchVerify(nil, publicKeys[1:], messages, sigs)
+	require.Error(t, err)
+}
+
+func BenchmarkBatchVerification(b *testing.B) {
+	const n = 64
+	publicKeys := make([]PublicKey, n)
+	messages := make([][]byte, n)
+	sigs := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		pub, priv, _ := GenerateKey(nil)
+		publicKeys[i] = pub
+		messages[i] = []byte("Hello, world!")
+		sigs[i], _ = Sign(priv, messages[i])
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = BatchVerify(nil, publicKeys, messages, sigs)
+	}
+}
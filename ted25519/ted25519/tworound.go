@@ -0,0 +1,237 @@
+package ted25519
+
+import (
+	"bytes"
+	crand "crypto/rand"
+	"fmt"
+	"math"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/signatures/frost"
+)
+
+// suite is the ciphersuite of two-round signing, its signatures verify as plain Ed25519.
+var suite = frost.Ed25519Sha512{}
+
+// NonceCommitment is the round 1 message of two-round signing, the commitments
+// to the hiding and binding nonces of one signer.
+type NonceCommitment struct {
+	ShareIdentifier byte
+	Hiding          PublicKey
+	Binding         PublicKey
+}
+
+// Signer produces partial signatures with one key share in two rounds: Commit
+// publishes fresh nonce commitments, Sign answers the commitments of all
+// signers. Unlike GenerateSharedNonce this needs no dealing of nonce shares,
+// and every nonce is bound to the message and to the commitments of the other
+// signers (FROST, RFC 9591), so a signer cannot be tricked into reusing one.
+type Signer struct {
+	id     byte
+	signer *frost.Signer
+	key    *frost.KeyPackage
+}
+
+// NewSigner creates a two-round signer for key under the public key pub.
+func NewSigner(key *KeyShare, pub PublicKey, config *ShareConfiguration) (*Signer, error) {
+	if key == nil || key.ShamirShare == nil || config == nil {
+		return nil, fmt.Errorf("ted25519: key share and config must be non-nil")
+	}
+	if key.Identifier == 0 || key.Identifier > math.MaxUint8 {
+		return nil, fmt.Errorf("ted25519: invalid share identifier %d", key.Identifier)
+	}
+	groupKey, err := pointFromPublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+	// The field element is big-endian without leading zeros, the scalar 32 bytes little-endian
+	var le [32]byte
+	copy(le[:], reverseBytes(key.Value.Bytes()))
+	secret, err := new(curves.ScalarEd25519).SetBytesCanonical(le[:])
+	if err != nil {
+		return nil, fmt.Errorf("ted25519: invalid key share: %w", err)
+	}
+	pkg := &frost.KeyPackage{
+		Identifier:     key.Identifier,
+		SecretShare:    secret,
+		VerifyingShare: curves.ED25519().ScalarBaseMult(secret),
+		GroupKey:       groupKey,
+		Threshold:      uint32(config.T),
+	}
+	signer, err := frost.NewSigner(suite, pkg)
+	if err != nil {
+		return nil, err
+	}
+	return &Signer{id: byte(key.Identifier), signer: signer, key: pkg}, nil
+}
+
+// NewSignerFromDkg creates a two-round signer for the key share of a DKG result.
+func NewSignerFromDkg(result *DkgResult) (*Signer, error) {
+	if result == nil {
+		return nil, fmt.Errorf("ted25519: dkg result must be non-nil")
+	}
+	return NewSigner(result.KeyShare, result.PublicKey, result.Config)
+}
+
+// Commit runs round 1: it draws fresh nonces, discarding any pending ones, and
+// returns their commitments for broadcast to the other signers.
+func (s *Signer) Commit() (*NonceCommitment, error) {
+	c, err := s.signer.Commit(crand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return &NonceCommitment{
+		ShareIdentifier: s.id,
+		Hiding:          c.Hiding.ToAffineCompressed(),
+		Binding:         c.Binding.ToAffineCompressed(),
+	}, nil
+}
+
+// Sign runs round 2: it returns the partial signature over message given the
+// commitments of every signer, including this one. The nonces are erased, so
+// each call needs a new Commit.
+func (s *Signer) Sign(message Message, commitments []*NonceCommitment) (*PartialSignature, error) {
+	list, err := toFrostCommitments(commitments)
+	if err != nil {
+		return nil, err
+	}
+	z, err := s.signer.Sign(message, list)
+	if err != nil {
+		return nil, err
+	}
+	r, err := frost.GroupCommitment(suite, s.key.GroupKey, message, list)
+	if err != nil {
+		return nil, err
+	}
+	return NewPartialSignature(s.id, append(r.ToAffineCompressed(), z.Bytes()...)), nil
+}
+
+// VerifyPartialSignature checks the partial signature of one signer against its
+// verification share, so a signer sending a bad share is identified.
+func VerifyPartialSignature(
+	sig *PartialSignature, message Message, pub PublicKey,
+	verificationShares map[byte]PublicKey, commitments []*NonceCommitment,
+) error {
+	if sig == nil || len(sig.Sig) != signatureLength {
+		return fmt.Errorf("ted25519: invalid partial signature")
+	}
+	pkg, err := publicKeyPackage(pub, verificationShares)
+	if err != nil {
+		return err
+	}
+	list, err := toFrostCommitments(commitments)
+	if err != nil {
+		return err
+	}
+	r, err := frost.GroupCommitment(suite, pkg.GroupKey, message, list)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(sig.R(), r.ToAffineCompressed()) {
+		return fmt.Errorf("ted25519: unexpected nonce pubkey of signer %d", sig.ShareIdentifier)
+	}
+	z, err := new(curves.ScalarEd25519).SetBytesCanonical(sig.S())
+	if err != nil {
+		return fmt.Errorf("ted25519: invalid partial signature of signer %d: %w", sig.ShareIdentifier, err)
+	}
+	return frost.VerifySignatureShare(suite, pkg, uint32(sig.ShareIdentifier), z, message, list)
+}
+
+// AggregateVerified combines the partial signatures of two-round signing into
+// an Ed25519 signature. Every partial signature is verified against its
+// verification share first and the result is checked with standard Ed25519
+// verification, so the returned signature verifies with crypto/ed25519.
+func AggregateVerified(
+	sigs []*PartialSignature, message Message, pub PublicKey,
+	verificationShares map[byte]PublicKey, commitments []*NonceCommitment,
+) (Signature, error) {
+	if len(sigs) == 0 {
+		return nil, fmt.Errorf("ted25519: sigs must be non-empty")
+	}
+	pkg, err := publicKeyPackage(pub, verificationShares)
+	if err != nil {
+		return nil, err
+	}
+	list, err := toFrostCommitments(commitments)
+	if err != nil {
+		return nil, err
+	}
+	shares := make(map[uint32]curves.Scalar, len(sigs))
+	for _, sig := range sigs {
+		if sig == nil || len(sig.Sig) != signatureLength {
+			return nil, fmt.Errorf("ted25519: invalid partial signature")
+		}
+		if _, ok := shares[uint32(sig.ShareIdentifier)]; ok {
+			return nil, fmt.Errorf("ted25519: duplicate partial signature of signer %d", sig.ShareIdentifier)
+		}
+		z, err := new(curves.ScalarEd25519).SetBytesCanonical(sig.S())
+		if err != nil {
+			return nil, fmt.Errorf("ted25519: invalid partial signature of signer %d: %w", sig.ShareIdentifier, err)
+		}
+		shares[uint32(sig.ShareIdentifier)] = z
+	}
+	sig, err := frost.Aggregate(suite, pkg, message, list, shares)
+	if err != nil {
+		return nil, err
+	}
+	ok, err := Verify(pub, message, sig)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("ted25519: aggregated signature does not verify")
+	}
+	return sig, nil
+}
+
+func pointFromPublicKey(pub PublicKey) (curves.Point, error) {
+	if len(pub) != PublicKeySize {
+		return nil, fmt.Errorf("ted25519: invalid public key size: %d", len(pub))
+	}
+	p, err := new(curves.PointEd25519).FromAffineCompressed(pub)
+	if err != nil {
+		return nil, fmt.Errorf("ted25519: invalid public key: %w", err)
+	}
+	if p.IsIdentity() {
+		return nil, fmt.Errorf("ted25519: invalid public key")
+	}
+	return p, nil
+}
+
+func publicKeyPackage(pub PublicKey, verificationShares map[byte]PublicKey) (*frost.PublicKeyPackage, error) {
+	groupKey, err := pointFromPublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+	pkg := &frost.PublicKeyPackage{
+		VerifyingShares: make(map[uint32]curves.Point, len(verificationShares)),
+		GroupKey:        groupKey,
+	}
+	for id, share := range verificationShares {
+		p, err := pointFromPublicKey(share)
+		if err != nil {
+			return nil, fmt.Errorf("ted25519: verification share of signer %d: %w", id, err)
+		}
+		pkg.VerifyingShares[uint32(id)] = p
+	}
+	return pkg, nil
+}
+
+func toFrostCommitments(commitments []*NonceCommitment) ([]*frost.Commitment, error) {
+	list := make([]*frost.Commitment, len(commitments))
+	for i, c := range commitments {
+		if c == nil {
+			return nil, fmt.Errorf("ted25519: nonce commitment must be non-nil")
+		}
+		hiding, err := pointFromPublicKey(c.Hiding)
+		if err != nil {
+			return nil, fmt.Errorf("ted25519: hiding commitment of signer %d: %w", c.ShareIdentifier, err)
+		}
+		binding, err := pointFromPublicKey(c.Binding)
+		if err != nil {
+			return nil, fmt.Errorf("ted25519: binding commitment of signer %d: %w", c.ShareIdentifier, err)
+		}
+		list[i] = &frost.Commitment{Identifier: uint32(c.ShareIdentifier), Hiding: hiding, Binding: binding}
+	}
+	return list, nil
+}
@@ -0,0 +1,193 @@
+package ted25519
+
+import (
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/dkg/pedersen"
+)
+
+func runPedersenDkg(t *testing.T, config *ShareConfiguration) map[byte]*DkgResult {
+	t.Helper()
+	participants := make(map[uint32]*pedersen.Participant, config.N)
+	for i := 1; i <= config.N; i++ {
+		var others []byte
+		for j := 1; j <= config.N; j++ {
+			if i != j {
+				others = append(others, byte(j))
+			}
+		}
+		p, err := NewDkgParticipant(byte(i), config, []byte("ted25519 test"), others...)
+		require.NoError(t, err)
+		participants[uint32(i)] = p
+	}
+
+	bcast1 := make(map[uint32]*pedersen.Round1Bcast)
+	p2p1 := make(map[uint32]pedersen.Round1P2PSend)
+	for id, p := range participants {
+		b, s, err := p.Round1(nil)
+		require.NoError(t, err)
+		bcast1[id], p2p1[id] = b, s
+	}
+	bcast2 := make(map[uint32]*pedersen.Round2Bcast)
+	for id, p := range participants {
+		in := make(map[uint32]*pedersen.Round1P2PSendPacket)
+		for from, s := range p2p1 {
+			if from != id {
+				in[from] = s[id]
+			}
+		}
+		b, err := p.Round2(bcast1, in)
+		require.NoError(t, err)
+		bcast2[id] = b
+	}
+	bcast3 := make(map[uint32]*pedersen.Round3Bcast)
+	for id, p := range participants {
+		b, err := p.Round3(bcast2)
+		require.NoError(t, err)
+		bcast3[id] = b
+	}
+	bcast4 := make(map[uint32]*pedersen.Round4Bcast)
+	for id, p := range participants {
+		b, err := p.Round4(bcast3)
+		require.NoError(t, err)
+		bcast4[id] = b
+	}
+	bcast5 := make(map[uint32]*pedersen.Round5Bcast)
+	for id, p := range participants {
+		b, err := p.Round5(bcast4)
+		require.NoError(t, err)
+		bcast5[id] = b
+	}
+	bcast6 := make(map[uint32]*pedersen.Round6Bcast)
+	for id, p := range participants {
+		b, err := p.Round6(bcast5)
+		require.NoError(t, err)
+		bcast6[id] = b
+	}
+
+	results := make(map[byte]*DkgResult, config.N)
+	for id, p := range participants {
+		r, err := p.Finalize(bcast6)
+		require.NoError(t, err)
+		results[byte(id)], err = NewDkgResult(r, config)
+		require.NoError(t, err)
+	}
+	return results
+}
+
+func twoRoundSign(t *testing.T, signers []*Signer, message Message) ([]*NonceCommitment, []*PartialSignature) {
+	t.Helper()
+	commitments := make([]*NonceCommitment, len(signers))
+	for i, s := range signers {
+		c, err := s.Commit()
+		require.NoError(t, err)
+		commitments[i] = c
+	}
+	sigs := make([]*PartialSignature, len(signers))
+	for i, s := range signers {
+		sig, err := s.Sign(message, commitments)
+		require.NoError(t, err)
+		sigs[i] = sig
+	}
+	return commitments, sigs
+}
+
+func TestTwoRoundSignWithDkg(t *testing.T) {
+	config := &ShareConfiguration{T: 2, N: 3}
+	results := runPedersenDkg(t, config)
+	pub := results[1].PublicKey
+	for _, r := range results {
+		require.Equal(t, pub, r.PublicKey)
+	}
+
+	message := Message("test message")
+	signers := make([]*Signer, 0, config.T)
+	for _, id := range []byte{1, 3} {
+		signer, err := NewSignerFromDkg(results[id])
+		require.NoError(t, err)
+		signers = append(signers, signer)
+	}
+	commitments, sigs := twoRoundSign(t, signers, message)
+	for _, sig := range sigs {
+		require.NoError(t, VerifyPartialSignature(sig, message, pub, results[1].VerificationShares, commitments))
+	}
+	sig, err := AggregateVerified(sigs, message, pub, results[1].VerificationShares, commitments)
+	require.NoError(t, err)
+	require.True(t, ed25519.Verify(ed25519.PublicKey(pub), message, sig))
+}
+
+func TestTwoRoundSignWithDealer(t *testing.T) {
+	config := &ShareConfiguration{T: 3, N: 5}
+	pub, shares, commitments, err := GenerateSharedKey(config)
+	require.NoError(t, err)
+	verificationShares := make(map[byte]PublicKey, config.N)
+	for _, share := range shares {
+		id := byte(share.Identifier)
+		verificationShares[id], err = commitments.VerificationShare(id)
+		require.NoError(t, err)
+	}
+
+	message := Message("test message")
+	var signers []*Signer
+	for _, share := range shares[1:4] {
+		signer, err := NewSigner(share, pub, config)
+		require.NoError(t, err)
+		signers = append(signers, signer)
+	}
+	nonces, sigs := twoRoundSign(t, signers, message)
+	sig, err := AggregateVerified(sigs, message, pub, verificationShares, nonces)
+	require.NoError(t, err)
+	require.True(t, ed25519.Verify(ed25519.PublicKey(pub), message, sig))
+}
+
+func TestTwoRoundSignDetectsBadShare(t *testing.T) {
+	config := &ShareConfiguration{T: 2, N: 2}
+	results := runPedersenDkg(t, config)
+	pub, shares := results[1].PublicKey, results[1].VerificationShares
+	signers := make([]*Signer, 0, config.N)
+	for _, id := range []byte{1, 2} {
+		signer, err := NewSignerFromDkg(results[id])
+		require.NoError(t, err)
+		signers = append(signers, signer)
+	}
+
+	message := Message("test message")
+	commitments, sigs := twoRoundSign(t, signers, message)
+	sigs[1].Sig[40] ^= 1
+	require.Error(t, VerifyPartialSignature(sigs[1], message, pub, shares, commitments))
+	_, err := AggregateVerified(sigs, message, pub, shares, commitments)
+	require.Error(t, err)
+
+	// A share over another message does not aggregate
+	commitments, sigs = twoRoundSign(t, signers, message)
+	_, err = AggregateVerified(sigs, Message("other message"), pub, shares, commitments)
+	require.Error(t, err)
+}
+
+func TestTwoRoundSignRejectsNonceReuse(t *testing.T) {
+	config := &ShareConfiguration{T: 2, N: 2}
+	results := runPedersenDkg(t, config)
+	signers := make([]*Signer, 0, config.N)
+	for _, id := range []byte{1, 2} {
+		signer, err := NewSignerFromDkg(results[id])
+		require.NoError(t, err)
+		signers = append(signers, signer)
+	}
+
+	commitments, _ := twoRoundSign(t, signers, Message("first"))
+	// The nonces were erased by the first signature
+	_, err := signers[0].Sign(Message("second"), commitments)
+	require.Error(t, err)
+
+	// A signer refuses a commitment list that alters its own commitment
+	c0, err := signers[0].Commit()
+	require.NoError(t, err)
+	c1, err := signers[1].Commit()
+	require.NoError(t, err)
+	forged := &NonceCommitment{ShareIdentifier: c0.ShareIdentifier, Hiding: c1.Hiding, Binding: c0.Binding}
+	_, err = signers[0].Sign(Message("second"), []*NonceCommitment{forged, c1})
+	require.Error(t, err)
+}
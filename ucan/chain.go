@@ -0,0 +1,188 @@
+package ucan
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// maxChainDepth bounds the length of the proof chains a Verifier follows
+const maxChainDepth = 16
+
+// Verifier checks delegations and their proof chains
+type Verifier struct {
+	// Proofs resolves the CIDs of proofs to raw delegations
+	Proofs CIDBytesResolver
+	// Now is the time delegations are checked at, time.Now when nil
+	Now func() time.Time
+	// Owns reports whether a DID owns a resource and can delegate it
+	// without proof. When nil a DID owns itself and the resources below it.
+	Owns func(did, resource string) bool
+}
+
+// NewVerifier returns a verifier resolving proofs with proofs
+func NewVerifier(proofs CIDBytesResolver) *Verifier {
+	return &Verifier{Proofs: proofs}
+}
+
+// Verify parses a raw delegation to audience and checks its signature,
+// time bounds and that every capability is either owned by its issuer or
+// attenuated from a valid proof chain
+func (v *Verifier) Verify(ctx context.Context, raw, audience string) (*Delegation, error) {
+	d, err := ParseDelegation(raw)
+	if err != nil {
+		return nil, err
+	}
+	if audience != "" && d.Audience != audience {
+		return nil, fmt.Errorf("%w: delegated to %s, not %s", ErrInvalidToken, d.Audience, audience)
+	}
+	if err := v.verifyChain(ctx, d, 0); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (v *Verifier) verifyChain(ctx context.Context, d *Delegation, depth int) error {
+	if depth > maxChainDepth {
+		return fmt.Errorf("%w: proof chain is too long", ErrInvalidToken)
+	}
+	now := time.Now()
+	if v.Now != nil {
+		now = v.Now()
+	}
+	if !d.NotBefore.IsZero() && now.Before(d.NotBefore) {
+		return fmt.Errorf("%w: not valid before %s", ErrInvalidToken, d.NotBefore)
+	}
+	if !d.ExpiresAt.IsZero() && !now.Before(d.ExpiresAt) {
+		return fmt.Errorf("%w: expired at %s", ErrInvalidToken, d.ExpiresAt)
+	}
+
+	var proofs []*Delegation
+	for _, id := range d.Proofs {
+		if v.Proofs == nil {
+			return fmt.Errorf("%w: no resolver for proof %s", ErrInvalidToken, id)
+		}
+		raw, err := v.Proofs.ResolveCIDBytes(ctx, id)
+		if err != nil {
+			return fmt.Errorf("resolving proof %s: %w", id, err)
+		}
+		prf, err := ParseDelegation(string(raw))
+		if err != nil {
+			return err
+		}
+		if got, err := prf.CID(); err != nil || !got.Equals(id) {
+			return fmt.Errorf("%w: proof does not match its CID %s", ErrInvalidToken, id)
+		}
+		if prf.Audience != d.Issuer {
+			return fmt.Errorf("%w: proof %s is delegated to %s, not the issuer", ErrInvalidToken, id, prf.Audience)
+		}
+		// a delegation cannot outlive its proofs
+		if !prf.ExpiresAt.IsZero() && (d.ExpiresAt.IsZero() || d.ExpiresAt.After(prf.ExpiresAt)) {
+			return fmt.Errorf("%w: expires after proof %s", ErrInvalidToken, id)
+		}
+		if !prf.NotBefore.IsZero() && d.NotBefore.Before(prf.NotBefore) {
+			return fmt.Errorf("%w: valid before proof %s", ErrInvalidToken, id)
+		}
+		if err := v.verifyChain(ctx, prf, depth+1); err != nil {
+			return err
+		}
+		proofs = append(proofs, prf)
+	}
+
+	owns := v.Owns
+	if owns == nil {
+		owns = ownsResource
+	}
+	for _, c := range d.Capabilities {
+		if !owns(d.Issuer, c.Resource) && !provenBy(c, proofs) {
+			return fmt.Errorf("%w: capability %s is not delegated to %s", ErrInvalidToken, c, d.Issuer)
+		}
+	}
+	return nil
+}
+
+// ownsResource is true for the DID itself and the resources below it
+func ownsResource(did, resource string) bool {
+	return resource == did || strings.HasPrefix(resource, did+"/")
+}
+
+// provenBy reports whether a proof has a capability c attenuates
+func provenBy(c Cap, proofs []*Delegation) bool {
+	for _, prf := range proofs {
+		for _, pc := range prf.Capabilities {
+			if pc.Contains(c) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Contains reports whether b is an attenuation of c: its resource is c's
+// or below a c resource ending in /*, its ability is c's or below a c
+// ability ending in /* or *, and it is at least as restricted by caveats
+func (c Cap) Contains(b Cap) bool {
+	return matchesPattern(c.Resource, b.Resource) &&
+		matchesPattern(c.Ability, b.Ability) &&
+		caveatsContain(c.Caveats, b.Caveats)
+}
+
+func matchesPattern(pattern, s string) bool {
+	switch {
+	case pattern == s, pattern == "*":
+		return true
+	case strings.HasSuffix(pattern, "/*"):
+		return strings.HasPrefix(s, strings.TrimSuffix(pattern, "*"))
+	default:
+		return false
+	}
+}
+
+// caveatsContain reports whether every caveat of b is at least as strict
+// as one of a, that is it has all the fields of that caveat with the same
+// values. No caveats and the empty caveat are unrestricted.
+func caveatsContain(a, b []map[string]any) bool {
+	if len(b) == 0 {
+		b = []map[string]any{{}}
+	}
+	if len(a) == 0 {
+		return true
+	}
+NEXT:
+	for _, bc := range b {
+		for _, ac := range a {
+			if caveatContains(ac, bc) {
+				continue NEXT
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func caveatContains(a, b map[string]any) bool {
+	for k, av := range a {
+		bv, ok := b[k]
+		if !ok || !reflect.DeepEqual(normalizeCaveat(av), normalizeCaveat(bv)) {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeCaveat makes numbers from a builder and a parsed delegation
+// comparable
+func normalizeCaveat(v any) any {
+	switch n := v.(type) {
+	case int:
+		return float64(n)
+	case int64:
+		return float64(n)
+	case uint64:
+		return float64(n)
+	default:
+		return v
+	}
+}
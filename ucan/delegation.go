@@ -0,0 +1,292 @@
+package ucan
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p/core/crypto"
+
+	"github.com/go-sonr/crypto/jose"
+	"github.com/go-sonr/crypto/keys/parsers"
+)
+
+// DelegationVersion is the UCAN spec version of delegations, the last
+// version of the spec with JWT encoded tokens
+const DelegationVersion = "0.10.0"
+
+// Cap is a capability of a delegation, the ability to perform Ability on
+// Resource within the limits of its caveats
+type Cap struct {
+	Resource string
+	Ability  string
+	// Caveats restrict the capability, a capability without caveats is
+	// unrestricted
+	Caveats []map[string]any
+}
+
+// String formats a capability as resource ability
+func (c Cap) String() string {
+	return c.Resource + " " + c.Ability
+}
+
+// Delegation is a UCAN delegating capabilities from its issuer to its
+// audience, signed by the issuer
+type Delegation struct {
+	// Entire UCAN as a compact JWS
+	Raw          string
+	Issuer       string
+	Audience     string
+	Capabilities []Cap
+	// Proofs are the CIDs of the delegations to the issuer this one
+	// attenuates
+	Proofs    []cid.Cid
+	NotBefore time.Time
+	ExpiresAt time.Time
+	Nonce     string
+	Facts     map[string]any
+}
+
+// CID is the CID of the raw delegation, the reference used in the proofs
+// of delegations that attenuate it
+func (d *Delegation) CID() (cid.Cid, error) {
+	return (&Token{Raw: d.Raw}).CID()
+}
+
+// delegationPayload is the JWT payload of a delegation
+type delegationPayload struct {
+	Version  string                                 `json:"ucv"`
+	Issuer   string                                 `json:"iss"`
+	Audience string                                 `json:"aud"`
+	Caps     map[string]map[string][]map[string]any `json:"cap"`
+	Proofs   []string                               `json:"prf,omitempty"`
+	Nbf      int64                                  `json:"nbf,omitempty"`
+	// exp is null for delegations that never expire
+	Exp   *int64         `json:"exp"`
+	Nonce string         `json:"nnc,omitempty"`
+	Facts map[string]any `json:"fct,omitempty"`
+}
+
+// Builder issues delegations. Each method sets one field and returns the
+// builder, errors are reported by Build.
+type Builder struct {
+	signer jose.Signer
+	issuer string
+	d      Delegation
+	proofs []*Delegation
+	err    error
+}
+
+// NewBuilder returns a builder of delegations issued by the did:key of
+// priv. Ed25519, P-256, P-384, secp256k1 and RSA keys are supported.
+func NewBuilder(priv crypto.PrivKey) *Builder {
+	b := &Builder{}
+	if b.signer, b.err = jose.NewSigner(priv); b.err != nil {
+		return b
+	}
+	var id parsers.DIDKey
+	if id, b.err = parsers.NewKeyDID(priv.GetPublic()); b.err != nil {
+		return b
+	}
+	b.issuer = id.String()
+	return b
+}
+
+// ToAudience sets the DID the capabilities are delegated to
+func (b *Builder) ToAudience(did string) *Builder {
+	b.d.Audience = did
+	return b
+}
+
+// Claim adds the ability to perform ability on resource, restricted by
+// caveats
+func (b *Builder) Claim(resource, ability string, caveats ...map[string]any) *Builder {
+	b.d.Capabilities = append(b.d.Capabilities, Cap{Resource: resource, Ability: ability, Caveats: caveats})
+	return b
+}
+
+// WithProof adds a delegation to the issuer that the capabilities attenuate
+func (b *Builder) WithProof(proof *Delegation) *Builder {
+	b.proofs = append(b.proofs, proof)
+	return b
+}
+
+// NotBefore sets the time the delegation becomes valid
+func (b *Builder) NotBefore(t time.Time) *Builder {
+	b.d.NotBefore = t
+	return b
+}
+
+// ExpiresAt sets the time the delegation expires, delegations without an
+// expiry never expire
+func (b *Builder) ExpiresAt(t time.Time) *Builder {
+	b.d.ExpiresAt = t
+	return b
+}
+
+// ExpiresIn expires the delegation ttl from now
+func (b *Builder) ExpiresIn(ttl time.Duration) *Builder {
+	return b.ExpiresAt(time.Now().Add(ttl))
+}
+
+// WithNonce sets the nonce, Build sets a random one when it is empty
+func (b *Builder) WithNonce(nonce string) *Builder {
+	b.d.Nonce = nonce
+	return b
+}
+
+// WithFact adds a fact
+func (b *Builder) WithFact(key string, value any) *Builder {
+	if b.d.Facts == nil {
+		b.d.Facts = make(map[string]any)
+	}
+	b.d.Facts[key] = value
+	return b
+}
+
+// Build signs the delegation. Capabilities must be attenuations of the
+// capabilities of the proofs unless the issuer owns their resource.
+func (b *Builder) Build() (*Delegation, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	d := b.d
+	d.Issuer = b.issuer
+	if _, err := parsers.Parse(d.Audience); err != nil {
+		return nil, fmt.Errorf("invalid audience: %w", err)
+	}
+	if len(d.Capabilities) == 0 {
+		return nil, fmt.Errorf("delegation has no capabilities")
+	}
+	if !d.ExpiresAt.IsZero() && d.ExpiresAt.Before(d.NotBefore) {
+		return nil, fmt.Errorf("delegation expires before it is valid")
+	}
+	for _, prf := range b.proofs {
+		if prf.Audience != d.Issuer {
+			return nil, fmt.Errorf("proof is delegated to %s, not the issuer", prf.Audience)
+		}
+		id, err := prf.CID()
+		if err != nil {
+			return nil, err
+		}
+		d.Proofs = append(d.Proofs, id)
+	}
+	for _, c := range d.Capabilities {
+		if !ownsResource(d.Issuer, c.Resource) && !provenBy(c, b.proofs) {
+			return nil, fmt.Errorf("capability %s is not delegated to the issuer", c)
+		}
+	}
+	if d.Nonce == "" {
+		var nonce [12]byte
+		if _, err := rand.Read(nonce[:]); err != nil {
+			return nil, err
+		}
+		d.Nonce = base64.RawURLEncoding.EncodeToString(nonce[:])
+	}
+	payload, err := json.Marshal(d.payload())
+	if err != nil {
+		return nil, err
+	}
+	d.Raw, err = jose.Sign(payload, b.signer, &jose.Header{Type: "JWT"})
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (d *Delegation) payload() *delegationPayload {
+	p := &delegationPayload{
+		Version:  DelegationVersion,
+		Issuer:   d.Issuer,
+		Audience: d.Audience,
+		Caps:     make(map[string]map[string][]map[string]any),
+		Nonce:    d.Nonce,
+		Facts:    d.Facts,
+	}
+	for _, c := range d.Capabilities {
+		abilities, ok := p.Caps[c.Resource]
+		if !ok {
+			abilities = make(map[string][]map[string]any)
+			p.Caps[c.Resource] = abilities
+		}
+		caveats := c.Caveats
+		if len(caveats) == 0 {
+			caveats = []map[string]any{{}}
+		}
+		abilities[c.Ability] = append(abilities[c.Ability], caveats...)
+	}
+	for _, prf := range d.Proofs {
+		p.Proofs = append(p.Proofs, prf.String())
+	}
+	if !d.NotBefore.IsZero() {
+		p.Nbf = d.NotBefore.Unix()
+	}
+	if !d.ExpiresAt.IsZero() {
+		exp := d.ExpiresAt.Unix()
+		p.Exp = &exp
+	}
+	return p
+}
+
+// ParseDelegation verifies the signature of a raw delegation against the
+// did:key of its issuer. Time bounds and proofs are checked by a Verifier.
+func ParseDelegation(raw string) (*Delegation, error) {
+	jws, err := jose.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidToken, err)
+	}
+	var p delegationPayload
+	if err := json.Unmarshal(jws.Payload, &p); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidToken, err)
+	}
+	if p.Version != DelegationVersion {
+		return nil, fmt.Errorf("%w: unsupported version %q", ErrInvalidToken, p.Version)
+	}
+	if !strings.HasPrefix(p.Issuer, parsers.KeyPrefix+":") {
+		return nil, fmt.Errorf("%w: issuer is not a did:key", ErrInvalidToken)
+	}
+	verifier, err := jose.ResolveDIDKey(p.Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidToken, err)
+	}
+	if err := jws.Verify(verifier); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidToken, err)
+	}
+	if _, err := parsers.Parse(p.Audience); err != nil {
+		return nil, fmt.Errorf("%w: invalid audience: %s", ErrInvalidToken, err)
+	}
+
+	d := &Delegation{
+		Raw:      raw,
+		Issuer:   p.Issuer,
+		Audience: p.Audience,
+		Nonce:    p.Nonce,
+		Facts:    p.Facts,
+	}
+	for resource, abilities := range p.Caps {
+		for ability, caveats := range abilities {
+			if len(caveats) == 0 {
+				return nil, fmt.Errorf("%w: capability %s %s has no caveats", ErrInvalidToken, resource, ability)
+			}
+			d.Capabilities = append(d.Capabilities, Cap{Resource: resource, Ability: ability, Caveats: caveats})
+		}
+	}
+	for _, s := range p.Proofs {
+		id, err := cid.Decode(s)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid proof: %s", ErrInvalidToken, err)
+		}
+		d.Proofs = append(d.Proofs, id)
+	}
+	if p.Nbf != 0 {
+		d.NotBefore = time.Unix(p.Nbf, 0)
+	}
+	if p.Exp != nil {
+		d.ExpiresAt = time.Unix(*p.Exp, 0)
+	}
+	return d, nil
+}
@@ -0,0 +1,150 @@
+package ucan
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	crand "crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/keys/parsers"
+)
+
+func newTestKey(t *testing.T) (crypto.PrivKey, string) {
+	priv, _, err := crypto.GenerateEd25519Key(crand.Reader)
+	require.NoError(t, err)
+	id, err := parsers.NewKeyDID(priv.GetPublic())
+	require.NoError(t, err)
+	return priv, id.String()
+}
+
+func putDelegation(t *testing.T, store TokenStore, d *Delegation) {
+	id, err := d.CID()
+	require.NoError(t, err)
+	require.NoError(t, store.PutToken(context.Background(), id.String(), d.Raw))
+}
+
+func TestDelegationRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	alice, aliceDID := newTestKey(t)
+	_, bobDID := newTestKey(t)
+
+	d, err := NewBuilder(alice).
+		ToAudience(bobDID).
+		Claim(aliceDID+"/photos", "crud/*").
+		Claim(aliceDID+"/mail", "msg/send", map[string]any{"max": 10}).
+		ExpiresIn(time.Hour).
+		WithFact("note", "hello").
+		Build()
+	require.NoError(t, err)
+	require.NotEmpty(t, d.Nonce)
+
+	parsed, err := NewVerifier(nil).Verify(ctx, d.Raw, bobDID)
+	require.NoError(t, err)
+	require.Equal(t, aliceDID, parsed.Issuer)
+	require.Equal(t, bobDID, parsed.Audience)
+	require.Len(t, parsed.Capabilities, 2)
+	require.Equal(t, d.Nonce, parsed.Nonce)
+	require.Equal(t, "hello", parsed.Facts["note"])
+	require.Equal(t, d.ExpiresAt.Unix(), parsed.ExpiresAt.Unix())
+
+	// wrong audience, expired or tampered delegations fail
+	_, err = NewVerifier(nil).Verify(ctx, d.Raw, aliceDID)
+	require.ErrorIs(t, err, ErrInvalidToken)
+	late := &Verifier{Now: func() time.Time { return time.Now().Add(2 * time.Hour) }}
+	_, err = late.Verify(ctx, d.Raw, bobDID)
+	require.ErrorIs(t, err, ErrInvalidToken)
+	_, err = ParseDelegation(d.Raw[:len(d.Raw)-4] + "AAAA")
+	require.ErrorIs(t, err, ErrInvalidToken)
+
+	// resources of other DIDs need a proof
+	_, err = NewBuilder(alice).ToAudience(bobDID).Claim(bobDID+"/photos", "crud/read").Build()
+	require.Error(t, err)
+	_, err = NewBuilder(alice).ToAudience("bob").Claim(aliceDID, "*").Build()
+	require.Error(t, err)
+}
+
+func TestDelegationChain(t *testing.T) {
+	ctx := context.Background()
+	alice, aliceDID := newTestKey(t)
+	bob, bobDID := newTestKey(t)
+	carolKey, err := ecdsa.GenerateKey(elliptic.P256(), crand.Reader)
+	require.NoError(t, err)
+	carol, _, err := crypto.ECDSAKeyPairFromKey(carolKey)
+	require.NoError(t, err)
+	carolID, err := parsers.NewKeyDID(carol.GetPublic())
+	require.NoError(t, err)
+	carolDID := carolID.String()
+	_, daveDID := newTestKey(t)
+
+	store := NewMemTokenStore()
+	verifier := NewVerifier(store.(CIDBytesResolver))
+
+	root, err := NewBuilder(alice).
+		ToAudience(bobDID).
+		Claim(aliceDID+"/photos/*", "crud/*", map[string]any{"album": "holiday"}).
+		ExpiresIn(time.Hour).
+		Build()
+	require.NoError(t, err)
+	putDelegation(t, store, root)
+
+	// bob attenuates the resource, the ability and the caveats
+	mid, err := NewBuilder(bob).
+		ToAudience(carolDID).
+		Claim(aliceDID+"/photos/beach", "crud/read", map[string]any{"album": "holiday", "size": "small"}).
+		WithProof(root).
+		ExpiresIn(30 * time.Minute).
+		Build()
+	require.NoError(t, err)
+	putDelegation(t, store, mid)
+
+	leaf, err := NewBuilder(carol).
+		ToAudience(daveDID).
+		Claim(aliceDID+"/photos/beach", "crud/read", map[string]any{"album": "holiday", "size": "small"}).
+		WithProof(mid).
+		ExpiresIn(10 * time.Minute).
+		Build()
+	require.NoError(t, err)
+	_, err = verifier.Verify(ctx, leaf.Raw, daveDID)
+	require.NoError(t, err)
+
+	// escalation of the ability or dropping caveats is refused
+	_, err = NewBuilder(bob).ToAudience(carolDID).Claim(aliceDID+"/photos/beach", "admin").WithProof(root).ExpiresIn(time.Minute).Build()
+	require.Error(t, err)
+	_, err = NewBuilder(carol).ToAudience(daveDID).Claim(aliceDID+"/photos/beach", "crud/read").WithProof(mid).ExpiresIn(time.Minute).Build()
+	require.Error(t, err)
+	// proofs delegated to someone else cannot be used
+	_, err = NewBuilder(carol).ToAudience(daveDID).Claim(aliceDID+"/photos/beach", "crud/read").WithProof(root).Build()
+	require.Error(t, err)
+
+	// a delegation cannot outlive its proof
+	long, err := NewBuilder(carol).
+		ToAudience(daveDID).
+		Claim(aliceDID+"/photos/beach", "crud/read", map[string]any{"album": "holiday", "size": "small"}).
+		WithProof(mid).
+		ExpiresIn(2 * time.Hour).
+		Build()
+	require.NoError(t, err)
+	_, err = verifier.Verify(ctx, long.Raw, daveDID)
+	require.ErrorIs(t, err, ErrInvalidToken)
+
+	// chains with unresolvable proofs fail
+	_, err = NewVerifier(NewMemTokenStore().(CIDBytesResolver)).Verify(ctx, leaf.Raw, daveDID)
+	require.Error(t, err)
+}
+
+func TestCapContains(t *testing.T) {
+	all := Cap{Resource: "https://example.com/*", Ability: "*"}
+	require.True(t, all.Contains(Cap{Resource: "https://example.com/a/b", Ability: "crud/read"}))
+	require.False(t, all.Contains(Cap{Resource: "https://example.org/a", Ability: "crud/read"}))
+
+	read := Cap{Resource: "r", Ability: "crud/read", Caveats: []map[string]any{{"max": 10}}}
+	require.True(t, read.Contains(Cap{Resource: "r", Ability: "crud/read", Caveats: []map[string]any{{"max": 10.0, "day": "mon"}}}))
+	require.False(t, read.Contains(Cap{Resource: "r", Ability: "crud/read"}))
+	require.False(t, read.Contains(Cap{Resource: "r", Ability: "crud/read", Caveats: []map[string]any{{"max": 11}}}))
+	require.False(t, read.Contains(Cap{Resource: "r", Ability: "crud/write", Caveats: []map[string]any{{"max": 10}}}))
+}
@@ -0,0 +1,163 @@
+package vdf
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"git.sr.ht/~sircmpwn/go-bare"
+	"golang.org/x/crypto/chacha20poly1305"
+
+	crypto "github.com/go-sonr/crypto/core"
+	"github.com/go-sonr/crypto/internal"
+)
+
+const puzzleDomain = "go-sonr vdf time-lock key v1"
+
+// Puzzle is a time-lock puzzle: a message encrypted under a key derived
+// from the output of the function after T squarings. Its creator knows the
+// factorization of the modulus and computes the output with one short
+// exponentiation, while anyone else must spend the T squarings to open it.
+// The output the solver finds comes with its proof, so a solver can show
+// everybody else the puzzle opened to the message.
+type Puzzle struct {
+	params     *Params
+	t          uint64
+	seed       []byte
+	ciphertext []byte
+}
+
+type puzzleMarshal struct {
+	N          []byte `bare:"n"`
+	T          uint64 `bare:"t"`
+	Seed       []byte `bare:"seed"`
+	Ciphertext []byte `bare:"ciphertext"`
+}
+
+// NewPuzzle locks message for t squarings modulo a fresh modulus of bits
+// bits
+func NewPuzzle(message []byte, t uint64, bits uint) (*Puzzle, error) {
+	if t == 0 {
+		return nil, fmt.Errorf("delay must be positive")
+	}
+	if bits < MinModulusBits {
+		return nil, fmt.Errorf("modulus must have at least %d bits", MinModulusBits)
+	}
+	var p, q, n *big.Int
+	for {
+		var err error
+		if p, err = rand.Prime(rand.Reader, int(bits/2)); err != nil {
+			return nil, err
+		}
+		if q, err = rand.Prime(rand.Reader, int(bits-bits/2)); err != nil {
+			return nil, err
+		}
+		n = new(big.Int).Mul(p, q)
+		if p.Cmp(q) != 0 && n.BitLen() == int(bits) {
+			break
+		}
+	}
+	params := &Params{n: n}
+	z := &Puzzle{params: params, t: t, seed: make([]byte, 32)}
+	if _, err := rand.Read(z.seed); err != nil {
+		return nil, err
+	}
+
+	// g^(2^t) = g^(2^t mod φ(N)) since g is a unit
+	phi := new(big.Int).Mul(new(big.Int).Sub(p, crypto.One), new(big.Int).Sub(q, crypto.One))
+	e := new(big.Int).Exp(big.NewInt(2), new(big.Int).SetUint64(t), phi)
+	g := params.element(z.seed)
+	y := params.abs(new(big.Int).Exp(g, e, n))
+	p.SetInt64(0)
+	q.SetInt64(0)
+	phi.SetInt64(0)
+
+	aead, err := z.aead(y)
+	if err != nil {
+		return nil, err
+	}
+	z.ciphertext = aead.Seal(nil, make([]byte, chacha20poly1305.NonceSize), message, z.header())
+	return z, nil
+}
+
+// Params returns the parameters of the puzzle
+func (z *Puzzle) Params() *Params {
+	return z.params
+}
+
+// Delay returns the number of squarings t the puzzle is locked for
+func (z *Puzzle) Delay() uint64 {
+	return z.t
+}
+
+// Solve spends the t squarings to open the puzzle, and returns the message
+// and the output of the function, which proves the opening to others
+func (z *Puzzle) Solve() ([]byte, *Output, error) {
+	out, err := z.params.Evaluate(z.seed, z.t)
+	if err != nil {
+		return nil, nil, err
+	}
+	message, err := z.Open(out)
+	if err != nil {
+		return nil, nil, err
+	}
+	return message, out, nil
+}
+
+// Open verifies the output of a solver and decrypts the message with it
+func (z *Puzzle) Open(out *Output) ([]byte, error) {
+	if err := z.params.Verify(z.seed, z.t, out); err != nil {
+		return nil, err
+	}
+	aead, err := z.aead(out.y)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Open(nil, make([]byte, chacha20poly1305.NonceSize), z.ciphertext, z.header())
+}
+
+// aead returns the cipher keyed by the output y. Every puzzle has its own
+// seed, so the key is used once and the nonce can be fixed.
+func (z *Puzzle) aead(y *big.Int) (cipher.AEAD, error) {
+	h := sha256.New()
+	_, _ = h.Write([]byte(puzzleDomain))
+	writeLengthPrefixed(h, z.header())
+	writeLengthPrefixed(h, y.Bytes())
+	return chacha20poly1305.New(h.Sum(nil))
+}
+
+// header binds the ciphertext to the modulus, delay and seed
+func (z *Puzzle) header() []byte {
+	out := binary.BigEndian.AppendUint64(nil, z.t)
+	out = binary.BigEndian.AppendUint64(out, uint64(len(z.seed)))
+	out = append(out, z.seed...)
+	return append(out, z.params.n.Bytes()...)
+}
+
+// MarshalBinary converts Puzzle to bytes
+func (z Puzzle) MarshalBinary() ([]byte, error) {
+	if z.params == nil || z.params.n == nil {
+		return nil, fmt.Errorf("puzzle cannot be nil")
+	}
+	return bare.Marshal(&puzzleMarshal{N: z.params.n.Bytes(), T: z.t, Seed: z.seed, Ciphertext: z.ciphertext})
+}
+
+// UnmarshalBinary sets Puzzle from bytes
+func (z *Puzzle) UnmarshalBinary(data []byte) error {
+	tv := new(puzzleMarshal)
+	if err := bare.Unmarshal(data, tv); err != nil {
+		return err
+	}
+	if tv.T == 0 || len(tv.Seed) == 0 {
+		return internal.ErrNilArguments
+	}
+	params, err := NewParams(new(big.Int).SetBytes(tv.N))
+	if err != nil {
+		return err
+	}
+	z.params, z.t, z.seed, z.ciphertext = params, tv.T, tv.Seed, tv.Ciphertext
+	return nil
+}
@@ -0,0 +1,38 @@
+package vdf
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPuzzle(t *testing.T) {
+	z, err := NewPuzzle([]byte("sealed bid"), 2000, MinModulusBits)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2000), z.Delay())
+
+	data, err := z.MarshalBinary()
+	require.NoError(t, err)
+	solver := new(Puzzle)
+	require.NoError(t, solver.UnmarshalBinary(data))
+
+	msg, out, err := solver.Solve()
+	require.NoError(t, err)
+	require.Equal(t, []byte("sealed bid"), msg)
+
+	// anyone opens the puzzle with the output of the solver
+	msg, err = z.Open(out)
+	require.NoError(t, err)
+	require.Equal(t, []byte("sealed bid"), msg)
+
+	// the output of another puzzle does not open it
+	other, err := NewPuzzle([]byte("other"), 2000, MinModulusBits)
+	require.NoError(t, err)
+	_, err = other.Open(out)
+	require.Error(t, err)
+
+	// nor does a tampered ciphertext
+	solver.ciphertext[0] ^= 1
+	_, err = solver.Open(out)
+	require.Error(t, err)
+}
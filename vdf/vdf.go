@@ -0,0 +1,277 @@
+// Package vdf implements the verifiable delay function of Wesolowski,
+// https://eprint.iacr.org/2018/623.pdf, in an RSA group, and time-lock
+// puzzles of Rivest, Shamir and Wagner on top of it.
+//
+// Evaluating the function on an input takes T sequential squarings of the
+// element the input hashes to, y = g^(2^T) mod N, which cannot be sped up
+// by parallelism without the factorization of N. The proof π = g^⌊2^T/l⌋
+// for a prime challenge l lets anyone check the output with two short
+// exponentiations, π^l · g^(2^T mod l) = y.
+//
+// Nobody may know the factorization of the modulus. GenerateParams is a
+// trusted setup that forgets the primes; a modulus of unknown
+// factorization, such as an RSA factoring challenge or the output of a
+// multi-party ceremony, can be used with NewParams instead. Elements are
+// taken up to sign, in the group of signed quadratic residues, so the
+// element -1 of known order cannot be used to forge proofs.
+package vdf
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/big"
+
+	"git.sr.ht/~sircmpwn/go-bare"
+	"golang.org/x/crypto/sha3"
+
+	crypto "github.com/go-sonr/crypto/core"
+	"github.com/go-sonr/crypto/internal"
+)
+
+// MinModulusBits is the smallest modulus accepted
+const MinModulusBits = 2048
+
+const (
+	elementDomain   = "go-sonr vdf element v1"
+	challengeDomain = "go-sonr vdf challenge v1"
+	outputDomain    = "go-sonr vdf output v1"
+	primeBits       = 256
+)
+
+// Params is the RSA modulus the function is evaluated in
+type Params struct {
+	n *big.Int
+}
+
+// Output is the result y of an evaluation with its proof
+type Output struct {
+	y, proof *big.Int
+}
+
+type paramsMarshal struct {
+	N []byte `bare:"n"`
+}
+
+type outputMarshal struct {
+	Y     []byte `bare:"y"`
+	Proof []byte `bare:"proof"`
+}
+
+// GenerateParams creates a modulus of bits bits from two random primes and
+// forgets them. Whoever runs it could have kept the primes, so it is only
+// for settings where the party running the setup is trusted.
+func GenerateParams(bits uint) (*Params, error) {
+	if bits < MinModulusBits {
+		return nil, fmt.Errorf("modulus must have at least %d bits", MinModulusBits)
+	}
+	for {
+		p, err := rand.Prime(rand.Reader, int(bits/2))
+		if err != nil {
+			return nil, err
+		}
+		q, err := rand.Prime(rand.Reader, int(bits-bits/2))
+		if err != nil {
+			return nil, err
+		}
+		n := new(big.Int).Mul(p, q)
+		if p.Cmp(q) != 0 && n.BitLen() == int(bits) {
+			return &Params{n: n}, nil
+		}
+	}
+}
+
+// NewParams returns the parameters of a modulus of unknown factorization
+func NewParams(n *big.Int) (*Params, error) {
+	if n == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if n.BitLen() < MinModulusBits {
+		return nil, fmt.Errorf("modulus must have at least %d bits", MinModulusBits)
+	}
+	if n.Bit(0) == 0 || n.ProbablyPrime(20) {
+		return nil, fmt.Errorf("modulus must be an odd composite")
+	}
+	return &Params{n: new(big.Int).Set(n)}, nil
+}
+
+// Modulus returns the modulus N
+func (p *Params) Modulus() *big.Int {
+	return new(big.Int).Set(p.n)
+}
+
+// element hashes input to g = h^2 mod N, a signed quadratic residue
+func (p *Params) element(input []byte) *big.Int {
+	buf := make([]byte, (p.n.BitLen()+7)/8+16)
+	h := sha3.NewShake256()
+	_, _ = h.Write([]byte(elementDomain))
+	writeLengthPrefixed(h, p.n.Bytes())
+	writeLengthPrefixed(h, input)
+	_, _ = h.Read(buf)
+	g := new(big.Int).SetBytes(buf)
+	g.Mod(g, p.n)
+	g.Exp(g, big.NewInt(2), p.n)
+	return p.abs(g)
+}
+
+// abs returns the representative of ±x in [0, N/2]
+func (p *Params) abs(x *big.Int) *big.Int {
+	neg := new(big.Int).Sub(p.n, x)
+	if neg.Cmp(x) < 0 {
+		return neg
+	}
+	return x
+}
+
+// Evaluate computes y = g^(2^t) mod N for the element g input hashes to,
+// with its proof. It takes about 2t squarings.
+func (p *Params) Evaluate(input []byte, t uint64) (*Output, error) {
+	if t == 0 {
+		return nil, fmt.Errorf("delay must be positive")
+	}
+	g := p.element(input)
+	if g.Sign() == 0 || new(big.Int).GCD(nil, nil, g, p.n).Cmp(crypto.One) != 0 {
+		return nil, fmt.Errorf("input hashes to a non-unit")
+	}
+	y := new(big.Int).Set(g)
+	for i := uint64(0); i < t; i++ {
+		y.Mul(y, y).Mod(y, p.n)
+	}
+	y = p.abs(y)
+	l := p.challenge(g, y, t)
+
+	// π = g^⌊2^t/l⌋ by long division of 2^t by l, one bit of the quotient
+	// at a time
+	proof := big.NewInt(1)
+	r := big.NewInt(1)
+	for i := uint64(0); i < t; i++ {
+		r.Lsh(r, 1)
+		proof.Mul(proof, proof).Mod(proof, p.n)
+		if r.Cmp(l) >= 0 {
+			r.Sub(r, l)
+			proof.Mul(proof, g).Mod(proof, p.n)
+		}
+	}
+	return &Output{y: y, proof: p.abs(proof)}, nil
+}
+
+// Verify checks that out is the output of the function on input after t
+// squarings
+func (p *Params) Verify(input []byte, t uint64, out *Output) error {
+	if out == nil || crypto.AnyNil(out.y, out.proof) {
+		return internal.ErrNilArguments
+	}
+	if t == 0 {
+		return fmt.Errorf("delay must be positive")
+	}
+	for _, v := range []*big.Int{out.y, out.proof} {
+		if v.Sign() <= 0 || v.Cmp(p.abs(new(big.Int).Set(v))) != 0 {
+			return fmt.Errorf("output is not a canonical element")
+		}
+		if new(big.Int).GCD(nil, nil, v, p.n).Cmp(crypto.One) != 0 {
+			return fmt.Errorf("output is not a unit modulo N")
+		}
+	}
+	g := p.element(input)
+	l := p.challenge(g, out.y, t)
+	r := new(big.Int).Exp(big.NewInt(2), new(big.Int).SetUint64(t), l)
+	lhs := new(big.Int).Exp(out.proof, l, p.n)
+	lhs.Mul(lhs, new(big.Int).Exp(g, r, p.n)).Mod(lhs, p.n)
+	if p.abs(lhs).Cmp(out.y) != 0 {
+		return fmt.Errorf("invalid delay proof")
+	}
+	return nil
+}
+
+// challenge returns the prime l = H(N, g, y, t)
+func (p *Params) challenge(g, y *big.Int, t uint64) *big.Int {
+	var tb [8]byte
+	binary.BigEndian.PutUint64(tb[:], t)
+	return hashToPrime(challengeDomain, p.n.Bytes(), g.Bytes(), y.Bytes(), tb[:])
+}
+
+// Randomness returns 32 bytes derived from the output y, for using a
+// delayed value as unbiased randomness
+func (o *Output) Randomness() []byte {
+	h := sha256.New()
+	_, _ = h.Write([]byte(outputDomain))
+	writeLengthPrefixed(h, o.y.Bytes())
+	return h.Sum(nil)
+}
+
+// Y returns the output y
+func (o *Output) Y() *big.Int {
+	return new(big.Int).Set(o.y)
+}
+
+// MarshalBinary converts Output to bytes
+func (o Output) MarshalBinary() ([]byte, error) {
+	if crypto.AnyNil(o.y, o.proof) {
+		return nil, fmt.Errorf("output cannot be nil")
+	}
+	return bare.Marshal(&outputMarshal{Y: o.y.Bytes(), Proof: o.proof.Bytes()})
+}
+
+// UnmarshalBinary sets Output from bytes
+func (o *Output) UnmarshalBinary(data []byte) error {
+	tv := new(outputMarshal)
+	if err := bare.Unmarshal(data, tv); err != nil {
+		return err
+	}
+	o.y = new(big.Int).SetBytes(tv.Y)
+	o.proof = new(big.Int).SetBytes(tv.Proof)
+	return nil
+}
+
+// MarshalBinary converts Params to bytes
+func (p Params) MarshalBinary() ([]byte, error) {
+	if p.n == nil {
+		return nil, fmt.Errorf("modulus cannot be nil")
+	}
+	return bare.Marshal(&paramsMarshal{N: p.n.Bytes()})
+}
+
+// UnmarshalBinary sets Params from bytes
+func (p *Params) UnmarshalBinary(data []byte) error {
+	tv := new(paramsMarshal)
+	if err := bare.Unmarshal(data, tv); err != nil {
+		return err
+	}
+	params, err := NewParams(new(big.Int).SetBytes(tv.N))
+	if err != nil {
+		return err
+	}
+	p.n = params.n
+	return nil
+}
+
+func writeLengthPrefixed(w io.Writer, data []byte) {
+	var l [8]byte
+	binary.BigEndian.PutUint64(l[:], uint64(len(data)))
+	_, _ = w.Write(l[:])
+	_, _ = w.Write(data)
+}
+
+// hashToPrime hashes data with an increasing counter until the result with
+// its top and bottom bits set is a probable prime
+func hashToPrime(domain string, data ...[]byte) *big.Int {
+	h := sha256.New()
+	var counter [8]byte
+	for i := uint64(0); ; i++ {
+		h.Reset()
+		_, _ = h.Write([]byte(domain))
+		binary.BigEndian.PutUint64(counter[:], i)
+		_, _ = h.Write(counter[:])
+		for _, d := range data {
+			writeLengthPrefixed(h, d)
+		}
+		x := new(big.Int).SetBytes(h.Sum(nil))
+		x.SetBit(x, primeBits-1, 1)
+		x.SetBit(x, 0, 1)
+		if x.ProbablyPrime(20) {
+			return x
+		}
+	}
+}
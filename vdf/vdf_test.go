@@ -0,0 +1,97 @@
+package vdf
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testParamsOnce sync.Once
+	testParams     *Params
+)
+
+func params(t *testing.T) *Params {
+	testParamsOnce.Do(func() {
+		p, err := GenerateParams(MinModulusBits)
+		require.NoError(t, err)
+		testParams = p
+	})
+	return testParams
+}
+
+func TestEvaluateVerify(t *testing.T) {
+	p := params(t)
+	out, err := p.Evaluate([]byte("block 1"), 1000)
+	require.NoError(t, err)
+	require.NoError(t, p.Verify([]byte("block 1"), 1000, out))
+	require.Len(t, out.Randomness(), 32)
+
+	// the output is deterministic
+	again, err := p.Evaluate([]byte("block 1"), 1000)
+	require.NoError(t, err)
+	require.Equal(t, out.Y(), again.Y())
+
+	require.Error(t, p.Verify([]byte("block 2"), 1000, out))
+	require.Error(t, p.Verify([]byte("block 1"), 999, out))
+	require.Error(t, p.Verify([]byte("block 1"), 0, out))
+
+	// the output is g^(2^t)
+	g := p.element([]byte("block 1"))
+	y := new(big.Int).Exp(g, new(big.Int).Lsh(big.NewInt(1), 1000), p.n)
+	require.Equal(t, p.abs(y), out.Y())
+}
+
+func TestVerifyRejectsForgeries(t *testing.T) {
+	p := params(t)
+	input := []byte("forge")
+	out, err := p.Evaluate(input, 64)
+	require.NoError(t, err)
+
+	// -y and -π are the same signed elements, but not canonical
+	neg := &Output{y: new(big.Int).Sub(p.n, out.y), proof: out.proof}
+	require.Error(t, p.Verify(input, 64, neg))
+	neg = &Output{y: out.y, proof: new(big.Int).Sub(p.n, out.proof)}
+	require.Error(t, p.Verify(input, 64, neg))
+
+	bad := &Output{y: out.y, proof: new(big.Int).Add(out.proof, big.NewInt(1))}
+	require.Error(t, p.Verify(input, 64, bad))
+	bad = &Output{y: big.NewInt(1), proof: big.NewInt(1)}
+	require.Error(t, p.Verify(input, 64, bad))
+	bad = &Output{y: new(big.Int), proof: out.proof}
+	require.Error(t, p.Verify(input, 64, bad))
+	require.Error(t, p.Verify(input, 64, &Output{}))
+}
+
+func TestMarshalBinary(t *testing.T) {
+	p := params(t)
+	out, err := p.Evaluate([]byte("wire"), 100)
+	require.NoError(t, err)
+
+	data, err := p.MarshalBinary()
+	require.NoError(t, err)
+	decoded := new(Params)
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	require.Equal(t, p.Modulus(), decoded.Modulus())
+
+	data, err = out.MarshalBinary()
+	require.NoError(t, err)
+	decodedOut := new(Output)
+	require.NoError(t, decodedOut.UnmarshalBinary(data))
+	require.NoError(t, decoded.Verify([]byte("wire"), 100, decodedOut))
+}
+
+func TestNewParams(t *testing.T) {
+	_, err := NewParams(big.NewInt(15))
+	require.Error(t, err)
+	even := new(big.Int).Lsh(big.NewInt(1), MinModulusBits)
+	_, err = NewParams(even)
+	require.Error(t, err)
+	_, err = GenerateParams(1024)
+	require.Error(t, err)
+	p, err := NewParams(params(t).Modulus())
+	require.NoError(t, err)
+	require.Equal(t, params(t).Modulus(), p.Modulus())
+}
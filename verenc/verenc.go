@@ -0,0 +1,200 @@
+// Package verenc implements verifiable encryption of discrete logs: a prover encrypts the secret x of a public point
+// X = x·G to a recovery service's Paillier key, and proves in zero knowledge that the ciphertext decrypts to x.
+// It is meant for escrowing ECDSA and Schnorr key shares that anyone can check without being able to open them.
+//
+// This follows the approach of Camenisch and Shoup, https://eprint.iacr.org/2002/161, with the Paillier encryption
+// and the proof Π^log* of Canetti, et al. https://eprint.iacr.org/2021/060 (fig 25). The range of the proof is
+// proven against ring-Pedersen parameters on the service's modulus, which the service proves well-formed together
+// with the modulus itself.
+package verenc
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/paillier"
+	"github.com/go-sonr/crypto/zkp/paillierrange"
+)
+
+// PublicKey is the public key of a recovery service, with the proofs that it is well-formed.
+type PublicKey struct {
+	Paillier     *paillier.PublicKey
+	Pedersen     *paillierrange.PedersenParams
+	ModulusProof *paillier.ModulusProof
+	ParamsProof  *paillierrange.ParamsProof
+}
+
+// SecretKey is the secret key of a recovery service.
+type SecretKey struct {
+	PublicKey
+	paillier *paillier.SecretKey
+}
+
+// Ciphertext is a verifiable encryption of the discrete log of Point.
+type Ciphertext struct {
+	Ciphertext paillier.Ciphertext
+	Point      curves.Point
+	Proof      *paillierrange.LogProof
+}
+
+// NewKeys generates a recovery service key with a 2048 bit Paillier modulus.
+func NewKeys() (*PublicKey, *SecretKey, error) {
+	_, psk, err := paillier.NewKeysWithBits(paillier.ModulusBits2048)
+	if err != nil {
+		return nil, nil, err
+	}
+	sk, err := NewSecretKey(psk)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &sk.PublicKey, sk, nil
+}
+
+// NewSecretKey derives a recovery service key from a Paillier secret key and proves the public key well-formed.
+func NewSecretKey(psk *paillier.SecretKey) (*SecretKey, error) {
+	if psk == nil || psk.N == nil {
+		return nil, internal.ErrNilArguments
+	}
+	ped, err := paillierrange.NewPedersen(psk)
+	if err != nil {
+		return nil, err
+	}
+	modulusProof, err := (&paillier.ModulusProofParams{SecretKey: psk, Context: keyContext(psk.N)}).Prove()
+	if err != nil {
+		return nil, err
+	}
+	paramsProof, err := ped.Prove(keyTranscript(psk.N))
+	if err != nil {
+		return nil, err
+	}
+	return &SecretKey{
+		PublicKey: PublicKey{
+			Paillier:     &psk.PublicKey,
+			Pedersen:     ped.Params,
+			ModulusProof: modulusProof,
+			ParamsProof:  paramsProof,
+		},
+		paillier: psk,
+	}, nil
+}
+
+// Validate checks the proofs of the public key. Provers must validate a key before encrypting to it.
+func (pk *PublicKey) Validate() error {
+	if pk == nil || pk.Paillier == nil || pk.Paillier.N == nil || pk.Pedersen == nil || pk.Pedersen.N == nil ||
+		pk.ModulusProof == nil || pk.ParamsProof == nil {
+		return internal.ErrNilArguments
+	}
+	if pk.Pedersen.N.Cmp(pk.Paillier.N) != 0 {
+		return fmt.Errorf("ring-pedersen parameters are not on the paillier modulus")
+	}
+	if err := pk.ModulusProof.Verify(&paillier.ModulusVerifyParams{
+		PublicKey: pk.Paillier,
+		Context:   keyContext(pk.Paillier.N),
+	}); err != nil {
+		return err
+	}
+	return pk.ParamsProof.Verify(keyTranscript(pk.Paillier.N), pk.Pedersen)
+}
+
+// EncryptAndProve encrypts x and proves that the ciphertext holds the discrete log of x·G.
+// uniqueSessionId binds the proof to its context, e.g. the identity of the key share.
+func EncryptAndProve(pk *PublicKey, x curves.Scalar, uniqueSessionId []byte) (*Ciphertext, error) {
+	if pk == nil || pk.Paillier == nil || x == nil {
+		return nil, internal.ErrNilArguments
+	}
+	k := x.BigInt()
+	c, rho, err := pk.Paillier.Encrypt(k)
+	if err != nil {
+		return nil, err
+	}
+	ct := &Ciphertext{
+		Ciphertext: c,
+		Point:      x.Point().Generator().Mul(x),
+	}
+	statement, err := ct.statement(pk)
+	if err != nil {
+		return nil, err
+	}
+	if ct.Proof, err = paillierrange.ProveLog(sessionTranscript(uniqueSessionId), pk.Pedersen, statement, k, rho); err != nil {
+		return nil, err
+	}
+	return ct, nil
+}
+
+// Verify checks that the ciphertext encrypts the discrete log of its point under pk.
+func Verify(pk *PublicKey, ct *Ciphertext, uniqueSessionId []byte) error {
+	if ct == nil || ct.Proof == nil {
+		return internal.ErrNilArguments
+	}
+	statement, err := ct.statement(pk)
+	if err != nil {
+		return err
+	}
+	return ct.Proof.Verify(sessionTranscript(uniqueSessionId), pk.Pedersen, statement)
+}
+
+// VerifyAndDecrypt verifies the ciphertext and returns the discrete log of its point.
+func VerifyAndDecrypt(sk *SecretKey, ct *Ciphertext, uniqueSessionId []byte) (curves.Scalar, error) {
+	if sk == nil || sk.paillier == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if err := Verify(&sk.PublicKey, ct, uniqueSessionId); err != nil {
+		return nil, err
+	}
+	m, err := sk.paillier.Decrypt(ct.Ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	// the proof bounds the plaintext as a signed integer, which only matters modulo the group order
+	if m.Cmp(new(big.Int).Rsh(sk.Paillier.N, 1)) > 0 {
+		m.Sub(m, sk.Paillier.N)
+	}
+	curve := curves.GetCurveByName(ct.Point.CurveName())
+	q := curve.Scalar.One().Neg().BigInt()
+	q.Add(q, big.NewInt(1))
+	x, err := curve.Scalar.SetBigInt(m.Mod(m, q))
+	if err != nil {
+		return nil, err
+	}
+	if !curve.ScalarBaseMult(x).Equal(ct.Point) {
+		return nil, fmt.Errorf("decrypted value does not match the point")
+	}
+	return x, nil
+}
+
+func (ct *Ciphertext) statement(pk *PublicKey) (*paillierrange.LogStatement, error) {
+	if pk == nil || pk.Paillier == nil || ct.Ciphertext == nil || ct.Point == nil {
+		return nil, internal.ErrNilArguments
+	}
+	curve := curves.GetCurveByName(ct.Point.CurveName())
+	if curve == nil {
+		return nil, fmt.Errorf("unsupported curve %s", ct.Point.CurveName())
+	}
+	return &paillierrange.LogStatement{
+		Statement: paillierrange.Statement{
+			PublicKey:  pk.Paillier,
+			Ciphertext: ct.Ciphertext,
+			Bits:       uint(curve.Scalar.One().Neg().BigInt().BitLen()),
+		},
+		X: ct.Point,
+	}, nil
+}
+
+func keyContext(n *big.Int) []byte {
+	return append([]byte("verenc recovery key"), n.Bytes()...)
+}
+
+func keyTranscript(n *big.Int) *transcript.Transcript {
+	tr := transcript.New("verenc recovery key")
+	tr.AppendMessage([]byte("N"), n.Bytes())
+	return tr
+}
+
+func sessionTranscript(uniqueSessionId []byte) *transcript.Transcript {
+	tr := transcript.New("verenc")
+	tr.AppendMessage([]byte("session id"), uniqueSessionId)
+	return tr
+}
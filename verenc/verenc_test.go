@@ -0,0 +1,91 @@
+package verenc
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/paillier"
+)
+
+var testPrimes = []*big.Int{
+	internal.B10("186141419611617071752010179586510154515933389116254425631491755419216243670159714804545944298892950871169229878325987039840135057969555324774918895952900547869933648175107076399993833724447909579697857041081987997463765989497319509683575289675966710007879762972723174353568113668226442698275449371212397561567"),
+	internal.B10("94210786053667323206442523040419729883258172350738703980637961803118626748668924192069593010365236618255120977661397310932923345291377692570649198560048403943687994859423283474169530971418656709749020402756179383990602363122039939937953514870699284906666247063852187255623958659551404494107714695311474384687"),
+}
+
+func newTestKey(t *testing.T) *SecretKey {
+	psk, err := paillier.NewSecretKey(testPrimes[0], testPrimes[1])
+	require.NoError(t, err)
+	sk, err := NewSecretKey(psk)
+	require.NoError(t, err)
+	return sk
+}
+
+func TestEncryptAndDecrypt(t *testing.T) {
+	sk := newTestKey(t)
+	pk := &sk.PublicKey
+	require.NoError(t, pk.Validate())
+	sid := []byte("key share 1")
+	for _, curve := range []*curves.Curve{curves.K256(), curves.P256(), curves.ED25519()} {
+		for _, x := range []curves.Scalar{curve.Scalar.Random(rand.Reader), curve.Scalar.One(), curve.Scalar.One().Neg()} {
+			ct, err := EncryptAndProve(pk, x, sid)
+			require.NoError(t, err)
+			require.True(t, ct.Point.Equal(curve.ScalarBaseMult(x)))
+			require.NoError(t, Verify(pk, ct, sid))
+			got, err := VerifyAndDecrypt(sk, ct, sid)
+			require.NoError(t, err)
+			require.Zero(t, x.Cmp(got), curve.Name)
+		}
+	}
+}
+
+func TestVerifyFails(t *testing.T) {
+	sk := newTestKey(t)
+	pk := &sk.PublicKey
+	curve := curves.K256()
+	sid := []byte("key share 1")
+	ct, err := EncryptAndProve(pk, curve.Scalar.Random(rand.Reader), sid)
+	require.NoError(t, err)
+
+	require.Error(t, Verify(pk, ct, []byte("key share 2")))
+
+	wrongPoint := *ct
+	wrongPoint.Point = curve.Point.Random(rand.Reader)
+	require.Error(t, Verify(pk, &wrongPoint, sid))
+
+	other, err := EncryptAndProve(pk, curve.Scalar.Random(rand.Reader), sid)
+	require.NoError(t, err)
+	swapped := *ct
+	swapped.Ciphertext = other.Ciphertext
+	require.Error(t, Verify(pk, &swapped, sid))
+	_, err = VerifyAndDecrypt(sk, &swapped, sid)
+	require.Error(t, err)
+
+	_, err = VerifyAndDecrypt(sk, &Ciphertext{}, sid)
+	require.ErrorIs(t, err, internal.ErrNilArguments)
+}
+
+func TestValidateFails(t *testing.T) {
+	sk := newTestKey(t)
+	pk := sk.PublicKey
+
+	bad := pk
+	params := *pk.Pedersen
+	params.S = new(big.Int).Add(params.S, big.NewInt(1))
+	bad.Pedersen = &params
+	require.Error(t, bad.Validate())
+
+	other, err := paillier.NewSecretKey(testPrimes[0], testPrimes[0])
+	require.NoError(t, err)
+	bad = pk
+	bad.Paillier = &other.PublicKey
+	require.Error(t, bad.Validate())
+
+	bad = pk
+	bad.ParamsProof = nil
+	require.ErrorIs(t, bad.Validate(), internal.ErrNilArguments)
+}
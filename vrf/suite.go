@@ -0,0 +1,152 @@
+package vrf
+
+import (
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"hash"
+	"math/big"
+
+	"github.com/go-sonr/crypto/core/curves"
+)
+
+// Suite is an ECVRF ciphersuite of RFC 9381 section 5.5
+type Suite struct {
+	// Name is the RFC 9381 name of the suite
+	Name string
+	// ID is the suite_string
+	ID byte
+
+	curve        *curves.Curve
+	hash         func() hash.Hash
+	ptLen        int // length of an encoded point
+	cLen         int // length of an encoded challenge
+	qLen         int // length of an encoded scalar
+	cofactor     int
+	order        *big.Int // group order q, used by rfc6979Nonce
+	littleEndian bool     // integers are encoded little endian
+	// secretScalar derives the secret scalar x from the secret key bytes
+	secretScalar func(sk []byte) (curves.Scalar, error)
+	// nonce is ECVRF_nonce_generation
+	nonce func(s *Suite, sk []byte, x curves.Scalar, hString []byte) curves.Scalar
+	// hashToPoint is interpret_hash_value_as_a_point
+	hashToPoint func(s *Suite, h []byte) (curves.Point, error)
+}
+
+var (
+	// Edwards25519Sha512TAI is ECVRF-EDWARDS25519-SHA512-TAI. Secret keys are 32 byte Ed25519 seeds.
+	Edwards25519Sha512TAI = &Suite{
+		Name:         "ECVRF-EDWARDS25519-SHA512-TAI",
+		ID:           0x03,
+		curve:        curves.ED25519(),
+		hash:         sha512.New,
+		ptLen:        32,
+		cLen:         16,
+		qLen:         32,
+		cofactor:     8,
+		littleEndian: true,
+		secretScalar: ed25519SecretScalar,
+		nonce:        ed25519Nonce,
+		hashToPoint: func(s *Suite, h []byte) (curves.Point, error) {
+			return s.stringToPoint(h[:s.ptLen])
+		},
+	}
+
+	// P256Sha256TAI is ECVRF-P256-SHA256-TAI. Secret keys are 32 byte big endian scalars.
+	P256Sha256TAI = &Suite{
+		Name:         "ECVRF-P256-SHA256-TAI",
+		ID:           0x01,
+		curve:        curves.P256(),
+		hash:         sha256.New,
+		ptLen:        33,
+		cLen:         16,
+		qLen:         32,
+		cofactor:     1,
+		order:        elliptic.P256().Params().N,
+		littleEndian: false,
+		secretScalar: func(sk []byte) (curves.Scalar, error) {
+			return curves.P256().Scalar.SetBytes(sk)
+		},
+		nonce: rfc6979Nonce,
+		hashToPoint: func(s *Suite, h []byte) (curves.Point, error) {
+			return s.stringToPoint(append([]byte{0x02}, h...))
+		},
+	}
+)
+
+// ed25519SecretScalar is the clamped first half of SHA-512(sk) as in RFC 8032 section 5.1.5
+func ed25519SecretScalar(sk []byte) (curves.Scalar, error) {
+	h := sha512.Sum512(sk)
+	return new(curves.ScalarEd25519).SetBytesClamping(h[:32])
+}
+
+// ed25519Nonce is the nonce generation of RFC 9381 section 5.4.2.2
+func ed25519Nonce(s *Suite, sk []byte, _ curves.Scalar, hString []byte) curves.Scalar {
+	h := sha512.Sum512(sk)
+	kh := sha512.New()
+	_, _ = kh.Write(h[32:])
+	_, _ = kh.Write(hString)
+	k, _ := new(curves.ScalarEd25519).SetBytesWide(kh.Sum(nil))
+	return k
+}
+
+// rfc6979Nonce is the deterministic nonce of RFC 6979 section 3.2 over the
+// hash of hString, see RFC 9381 section 5.4.2.1
+func rfc6979Nonce(s *Suite, _ []byte, x curves.Scalar, hString []byte) curves.Scalar {
+	q := s.order
+	qLen := s.qLen
+	h1 := s.hash()
+	_, _ = h1.Write(hString)
+	digest := h1.Sum(nil)
+
+	bits2int := func(b []byte) *big.Int {
+		v := new(big.Int).SetBytes(b)
+		if excess := len(b)*8 - q.BitLen(); excess > 0 {
+			v.Rsh(v, uint(excess))
+		}
+		return v
+	}
+	int2octets := func(v *big.Int) []byte {
+		out := make([]byte, qLen)
+		v.FillBytes(out)
+		return out
+	}
+	z := bits2int(digest)
+	if z.Cmp(q) >= 0 {
+		z.Sub(z, q)
+	}
+	seed := append(int2octets(x.BigInt()), int2octets(z)...)
+
+	size := s.hash().Size()
+	v := make([]byte, size)
+	k := make([]byte, size)
+	for i := range v {
+		v[i] = 0x01
+	}
+	mac := func(key []byte, data ...[]byte) []byte {
+		m := hmac.New(s.hash, key)
+		for _, d := range data {
+			_, _ = m.Write(d)
+		}
+		return m.Sum(nil)
+	}
+	k = mac(k, v, []byte{0x00}, seed)
+	v = mac(k, v)
+	k = mac(k, v, []byte{0x01}, seed)
+	v = mac(k, v)
+	for {
+		var t []byte
+		for len(t) < qLen {
+			v = mac(k, v)
+			t = append(t, v...)
+		}
+		candidate := bits2int(t[:qLen])
+		if candidate.Sign() > 0 && candidate.Cmp(q) < 0 {
+			nonce, _ := s.curve.Scalar.SetBigInt(candidate)
+			return nonce
+		}
+		k = mac(k, v, []byte{0x00})
+		v = mac(k, v)
+	}
+}
@@ -0,0 +1,276 @@
+// Package vrf implements the elliptic curve verifiable random functions of
+// RFC 9381 https://www.rfc-editor.org/rfc/rfc9381.html for the suites
+// ECVRF-EDWARDS25519-SHA512-TAI and ECVRF-P256-SHA256-TAI.
+//
+// The holder of a PrivateKey computes a proof pi for an input alpha with
+// Prove. Anyone with the PublicKey checks the proof with Verify, which returns
+// the VRF output beta. The output is unique for the key and input and is
+// indistinguishable from random to anyone without the secret key.
+package vrf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/secret"
+	"github.com/go-sonr/crypto/internal"
+)
+
+// domain separators of RFC 9381 section 5.4
+const (
+	encodeToCurveFront = 0x01
+	challengeFront     = 0x02
+	proofToHashFront   = 0x03
+	back               = 0x00
+)
+
+// PrivateKey is a VRF secret key
+type PrivateKey struct {
+	suite *Suite
+	sk    []byte
+	x     curves.Scalar
+	pk    *PublicKey
+}
+
+// PublicKey is a VRF public key
+type PublicKey struct {
+	suite *Suite
+	y     curves.Point
+	raw   []byte
+}
+
+// GenerateKey draws a fresh secret key from reader
+func (s *Suite) GenerateKey(reader io.Reader) (*PrivateKey, error) {
+	if reader == nil {
+		return nil, internal.ErrNilArguments
+	}
+	for {
+		sk := make([]byte, s.qLen)
+		if _, err := io.ReadFull(reader, sk); err != nil {
+			return nil, err
+		}
+		key, err := s.NewPrivateKey(sk)
+		// P-256 secret keys outside [1, n) are drawn again
+		if err == nil {
+			return key, nil
+		}
+		if s.littleEndian {
+			return nil, err
+		}
+	}
+}
+
+// NewPrivateKey parses a secret key. For ECVRF-EDWARDS25519-SHA512-TAI it is
+// a 32 byte Ed25519 seed, for ECVRF-P256-SHA256-TAI a 32 byte big endian scalar.
+func (s *Suite) NewPrivateKey(sk []byte) (*PrivateKey, error) {
+	if len(sk) != s.qLen {
+		return nil, fmt.Errorf("invalid secret key length")
+	}
+	x, err := s.secretScalar(sk)
+	if err != nil {
+		return nil, err
+	}
+	if x.IsZero() {
+		return nil, internal.ErrZeroValue
+	}
+	y := s.curve.ScalarBaseMult(x)
+	return &PrivateKey{
+		suite: s,
+		sk:    append([]byte{}, sk...),
+		x:     x,
+		pk:    &PublicKey{suite: s, y: y, raw: y.ToAffineCompressed()},
+	}, nil
+}
+
+// NewPublicKey parses and validates a public key, see RFC 9381 section 5.4.5.
+// Keys of small order are rejected.
+func (s *Suite) NewPublicKey(pk []byte) (*PublicKey, error) {
+	y, err := s.stringToPoint(pk)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	if y.Mul(s.curve.Scalar.New(s.cofactor)).IsIdentity() {
+		return nil, fmt.Errorf("invalid public key: small order point")
+	}
+	return &PublicKey{suite: s, y: y, raw: append([]byte{}, pk...)}, nil
+}
+
+// Bytes returns the secret key
+func (k *PrivateKey) Bytes() []byte {
+	return append([]byte{}, k.sk...)
+}
+
+// Zeroize overwrites the secret key with zeros
+func (k *PrivateKey) Zeroize() {
+	secret.Wipe(k.sk)
+	curves.Zeroize(k.x)
+}
+
+// Public returns the public key of k
+func (k *PrivateKey) Public() *PublicKey {
+	return k.pk
+}
+
+// Bytes returns the encoded public key
+func (k *PublicKey) Bytes() []byte {
+	return append([]byte{}, k.raw...)
+}
+
+// Prove computes the proof pi for alpha, see RFC 9381 section 5.1
+func (k *PrivateKey) Prove(alpha []byte) ([]byte, error) {
+	s := k.suite
+	h, err := s.encodeToCurve(k.pk.raw, alpha)
+	if err != nil {
+		return nil, err
+	}
+	hString := h.ToAffineCompressed()
+	gamma := h.Mul(k.x)
+	nonce := s.nonce(s, k.sk, k.x, hString)
+	cString, c, err := s.challenge(k.pk.y, h, gamma, s.curve.ScalarBaseMult(nonce), h.Mul(nonce))
+	if err != nil {
+		return nil, err
+	}
+	sc := nonce.Add(c.Mul(k.x))
+
+	pi := gamma.ToAffineCompressed()
+	pi = append(pi, cString...)
+	return append(pi, sc.Bytes()...), nil
+}
+
+// Verify checks the proof pi for alpha and returns the VRF output beta, see RFC 9381 section 5.3
+func (k *PublicKey) Verify(alpha, pi []byte) ([]byte, error) {
+	s := k.suite
+	gamma, c, sc, err := s.decodeProof(pi)
+	if err != nil {
+		return nil, err
+	}
+	h, err := s.encodeToCurve(k.raw, alpha)
+	if err != nil {
+		return nil, err
+	}
+	// U = s*B - c*Y, V = s*H - c*Gamma
+	u := s.curve.ScalarBaseMult(sc).Sub(k.y.Mul(c))
+	v := h.Mul(sc).Sub(gamma.Mul(c))
+	expected, _, err := s.challenge(k.y, h, gamma, u, v)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(expected, pi[s.ptLen:s.ptLen+s.cLen]) {
+		return nil, fmt.Errorf("invalid proof")
+	}
+	return s.gammaToHash(gamma), nil
+}
+
+// ProofToHash returns the VRF output beta of pi without checking the proof,
+// see RFC 9381 section 5.2. Only call it on proofs that passed Verify.
+func (s *Suite) ProofToHash(pi []byte) ([]byte, error) {
+	gamma, _, _, err := s.decodeProof(pi)
+	if err != nil {
+		return nil, err
+	}
+	return s.gammaToHash(gamma), nil
+}
+
+// ProofLength is the length of a proof in bytes
+func (s *Suite) ProofLength() int {
+	return s.ptLen + s.cLen + s.qLen
+}
+
+func (s *Suite) gammaToHash(gamma curves.Point) []byte {
+	h := s.hash()
+	_, _ = h.Write([]byte{s.ID, proofToHashFront})
+	_, _ = h.Write(gamma.Mul(s.curve.Scalar.New(s.cofactor)).ToAffineCompressed())
+	_, _ = h.Write([]byte{back})
+	return h.Sum(nil)
+}
+
+// encodeToCurve is ECVRF_encode_to_curve_try_and_increment of RFC 9381 section 5.4.1.1
+func (s *Suite) encodeToCurve(salt, alpha []byte) (curves.Point, error) {
+	cofactor := s.curve.Scalar.New(s.cofactor)
+	for ctr := 0; ctr < 256; ctr++ {
+		h := s.hash()
+		_, _ = h.Write([]byte{s.ID, encodeToCurveFront})
+		_, _ = h.Write(salt)
+		_, _ = h.Write(alpha)
+		_, _ = h.Write([]byte{byte(ctr), back})
+		p, err := s.hashToPoint(s, h.Sum(nil))
+		if err != nil {
+			continue
+		}
+		if s.cofactor > 1 {
+			p = p.Mul(cofactor)
+		}
+		if !p.IsIdentity() {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("unable to encode to curve")
+}
+
+// challenge is ECVRF_challenge_generation of RFC 9381 section 5.4.3.
+// It returns the truncated challenge string and its value as a scalar.
+func (s *Suite) challenge(points ...curves.Point) ([]byte, curves.Scalar, error) {
+	h := s.hash()
+	_, _ = h.Write([]byte{s.ID, challengeFront})
+	for _, p := range points {
+		_, _ = h.Write(p.ToAffineCompressed())
+	}
+	_, _ = h.Write([]byte{back})
+	cString := h.Sum(nil)[:s.cLen]
+
+	padded := make([]byte, s.qLen)
+	if s.littleEndian {
+		copy(padded, cString)
+	} else {
+		copy(padded[s.qLen-s.cLen:], cString)
+	}
+	c, err := s.curve.Scalar.SetBytes(padded)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cString, c, nil
+}
+
+// decodeProof is ECVRF_decode_proof of RFC 9381 section 5.4.4
+func (s *Suite) decodeProof(pi []byte) (curves.Point, curves.Scalar, curves.Scalar, error) {
+	if len(pi) != s.ProofLength() {
+		return nil, nil, nil, fmt.Errorf("invalid proof length")
+	}
+	gamma, err := s.stringToPoint(pi[:s.ptLen])
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid proof: %w", err)
+	}
+	padded := make([]byte, s.qLen)
+	if s.littleEndian {
+		copy(padded, pi[s.ptLen:s.ptLen+s.cLen])
+	} else {
+		copy(padded[s.qLen-s.cLen:], pi[s.ptLen:s.ptLen+s.cLen])
+	}
+	c, err := s.curve.Scalar.SetBytes(padded)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid proof: %w", err)
+	}
+	// SetBytes rejects s >= q
+	sc, err := s.curve.Scalar.SetBytes(pi[s.ptLen+s.cLen:])
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid proof: %w", err)
+	}
+	return gamma, c, sc, nil
+}
+
+// stringToPoint decodes a compressed point and rejects non-canonical encodings and the identity
+func (s *Suite) stringToPoint(b []byte) (curves.Point, error) {
+	if len(b) != s.ptLen {
+		return nil, fmt.Errorf("invalid point length")
+	}
+	p, err := s.curve.Point.FromAffineCompressed(b)
+	if err != nil {
+		return nil, err
+	}
+	if p.IsIdentity() || !bytes.Equal(p.ToAffineCompressed(), b) {
+		return nil, fmt.Errorf("invalid point encoding")
+	}
+	return p, nil
+}
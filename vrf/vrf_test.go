@@ -0,0 +1,117 @@
+package vrf
+
+import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func unhex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+func TestEdwards25519Vector(t *testing.T) {
+	// RFC 9381 appendix B.3
+	sk, err := Edwards25519Sha512TAI.NewPrivateKey(unhex(t, "9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60"))
+	require.NoError(t, err)
+	require.Equal(t, "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a", hex.EncodeToString(sk.Public().Bytes()))
+
+	pi, err := sk.Prove(nil)
+	require.NoError(t, err)
+	require.Equal(t, "8657106690b5526245a92b003bb079ccd1a92130477671f6fc01ad16f26f723f26f8a57ccaed74ee1b190bed1f479d9727d2d0f9b005a6e456a35d4fb0daab1268a1b0db10836d9826a528ca76567805", hex.EncodeToString(pi))
+
+	beta, err := sk.Public().Verify(nil, pi)
+	require.NoError(t, err)
+	require.Equal(t, "90cf1df3b703cce59e2a35b925d411164068269d7b2d29f3301c03dd757876ff66b71dda49d2de59d03450451af026798e8f81cd2e333de5cdf4f3e140fdd8ae", hex.EncodeToString(beta))
+}
+
+func TestProveVerify(t *testing.T) {
+	for _, suite := range []*Suite{Edwards25519Sha512TAI, P256Sha256TAI} {
+		t.Run(suite.Name, func(t *testing.T) {
+			sk, err := suite.GenerateKey(crand.Reader)
+			require.NoError(t, err)
+			pk, err := suite.NewPublicKey(sk.Public().Bytes())
+			require.NoError(t, err)
+
+			alpha := []byte("leader election round 7")
+			pi, err := sk.Prove(alpha)
+			require.NoError(t, err)
+			require.Len(t, pi, suite.ProofLength())
+
+			// proofs are deterministic
+			again, err := sk.Prove(alpha)
+			require.NoError(t, err)
+			require.Equal(t, pi, again)
+
+			beta, err := pk.Verify(alpha, pi)
+			require.NoError(t, err)
+			hash, err := suite.ProofToHash(pi)
+			require.NoError(t, err)
+			require.Equal(t, beta, hash)
+
+			_, err = pk.Verify([]byte("leader election round 8"), pi)
+			require.Error(t, err)
+
+			other, err := suite.GenerateKey(crand.Reader)
+			require.NoError(t, err)
+			_, err = other.Public().Verify(alpha, pi)
+			require.Error(t, err)
+
+			for _, i := range []int{0, suite.ptLen, suite.ProofLength() - 1} {
+				bad := append([]byte{}, pi...)
+				bad[i] ^= 1
+				_, err = pk.Verify(alpha, bad)
+				require.Error(t, err)
+			}
+			_, err = pk.Verify(alpha, pi[1:])
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestRejectsLargeResponse(t *testing.T) {
+	sk, err := Edwards25519Sha512TAI.GenerateKey(crand.Reader)
+	require.NoError(t, err)
+	pi, err := sk.Prove([]byte("alpha"))
+	require.NoError(t, err)
+	// s + L encodes the same residue but is not canonical
+	l := unhex(t, "edd3f55c1a631258d69cf7a2def9de1400000000000000000000000000000010")
+	var carry uint16
+	for i := 0; i < 32; i++ {
+		v := uint16(pi[48+i]) + uint16(l[i]) + carry
+		pi[48+i] = byte(v)
+		carry = v >> 8
+	}
+	require.Zero(t, carry)
+	_, err = sk.Public().Verify([]byte("alpha"), pi)
+	require.Error(t, err)
+}
+
+func TestRejectsSmallOrderKey(t *testing.T) {
+	// a point of order 8
+	_, err := Edwards25519Sha512TAI.NewPublicKey(unhex(t, "c7176a703d4dd84fba3c0b760d10670f2a2053fa2c39ccc64ec7fd7792ac037a"))
+	require.Error(t, err)
+	_, err = Edwards25519Sha512TAI.NewPublicKey(unhex(t, "0100000000000000000000000000000000000000000000000000000000000000"))
+	require.Error(t, err)
+	_, err = P256Sha256TAI.NewPrivateKey(make([]byte, 32))
+	require.Error(t, err)
+}
+
+func TestP256Vector(t *testing.T) {
+	// RFC 9381 appendix B.1
+	sk, err := P256Sha256TAI.NewPrivateKey(unhex(t, "c9afa9d845ba75166b5c215767b1d6934e50c3db36e89b127b8a622b120f6721"))
+	require.NoError(t, err)
+	require.Equal(t, "0360fed4ba255a9d31c961eb74c6356d68c049b8923b61fa6ce669622e60f29fb6", hex.EncodeToString(sk.Public().Bytes()))
+
+	pi, err := sk.Prove([]byte("sample"))
+	require.NoError(t, err)
+	require.Equal(t, "035b5c726e8c0e2c488a107c600578ee75cb702343c153cb1eb8dec77f4b5071b4a53f0a46f018bc2c56e58d383f2305e0975972c26feea0eb122fe7893c15af376b33edf7de17c6ea056d4d82de6bc02f", hex.EncodeToString(pi))
+
+	beta, err := sk.Public().Verify([]byte("sample"), pi)
+	require.NoError(t, err)
+	require.Equal(t, "a3ad7b0ef73d8fc6655053ea22f9bede8c743f08bbed3d38821f0e16474b505e", hex.EncodeToString(beta))
+}
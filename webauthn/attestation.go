@@ -0,0 +1,450 @@
+package webauthn
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/asn1"
+	"fmt"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+
+	"github.com/go-sonr/crypto/cose"
+	"github.com/go-sonr/crypto/keys/parsers"
+)
+
+// Attestation statement formats of the WebAuthn registry
+const (
+	FormatNone       = "none"
+	FormatPacked     = "packed"
+	FormatApple      = "apple"
+	FormatAndroidKey = "android-key"
+)
+
+// AttestationType is the kind of attestation a statement provides
+type AttestationType string
+
+const (
+	// AttestationNone carries no attestation
+	AttestationNone AttestationType = "None"
+	// AttestationSelf is signed by the credential private key itself
+	AttestationSelf AttestationType = "Self"
+	// AttestationBasic is signed by an attestation certificate
+	AttestationBasic AttestationType = "Basic"
+	// AttestationAnonCA is an anonymized certificate issued for the credential
+	AttestationAnonCA AttestationType = "AnonCA"
+)
+
+// Attestation is the verified attestation of a registration. Trusted is
+// set when the certificate chain verified against the configured roots.
+type Attestation struct {
+	Format       string
+	Type         AttestationType
+	Certificates []*x509.Certificate
+	Trusted      bool
+}
+
+var (
+	oidFIDOAAGUID         = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 45724, 1, 1, 4}
+	oidAppleNonce         = asn1.ObjectIdentifier{1, 2, 840, 113635, 100, 8, 2}
+	oidAndroidAttestation = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 1, 17}
+)
+
+// attestationObject is the CBOR attestation object of a registration
+type attestationObject struct {
+	format   string
+	stmt     map[any]any
+	authData []byte
+}
+
+func parseAttestationObject(data []byte) (*attestationObject, error) {
+	v, err := cose.Unmarshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("attestation object: %w", err)
+	}
+	m, ok := v.(map[any]any)
+	if !ok {
+		return nil, fmt.Errorf("attestation object is not a map")
+	}
+	obj := &attestationObject{}
+	if obj.format, ok = m["fmt"].(string); !ok {
+		return nil, fmt.Errorf("attestation object has no format")
+	}
+	if obj.stmt, ok = m["attStmt"].(map[any]any); !ok {
+		return nil, fmt.Errorf("attestation object has no statement")
+	}
+	if obj.authData, ok = m["authData"].([]byte); !ok {
+		return nil, fmt.Errorf("attestation object has no authenticator data")
+	}
+	return obj, nil
+}
+
+// verifyStatement checks the attestation statement of obj over the
+// authenticator data and the client data hash
+func (obj *attestationObject) verifyStatement(ad *AuthenticatorData, clientDataHash []byte, roots *x509.CertPool, now time.Time) (*Attestation, error) {
+	signed := append(append([]byte{}, obj.authData...), clientDataHash...)
+	var (
+		att *Attestation
+		err error
+	)
+	switch obj.format {
+	case FormatNone:
+		if len(obj.stmt) != 0 {
+			return nil, fmt.Errorf("none attestation statement is not empty")
+		}
+		return &Attestation{Format: FormatNone, Type: AttestationNone}, nil
+	case FormatPacked:
+		att, err = verifyPacked(obj.stmt, ad, signed)
+	case FormatApple:
+		att, err = verifyApple(obj.stmt, ad, signed)
+	case FormatAndroidKey:
+		att, err = verifyAndroidKey(obj.stmt, ad, signed, clientDataHash)
+	default:
+		return nil, fmt.Errorf("unsupported attestation format %q", obj.format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s attestation: %w", obj.format, err)
+	}
+	att.Format = obj.format
+	if len(att.Certificates) != 0 && roots != nil {
+		if err := verifyChain(att.Certificates, roots, now); err != nil {
+			return nil, fmt.Errorf("%s attestation: %w", obj.format, err)
+		}
+		att.Trusted = true
+	}
+	return att, nil
+}
+
+// statementSignature returns the alg and sig members of a statement
+func statementSignature(stmt map[any]any) (cose.Algorithm, []byte, error) {
+	alg, ok := stmt["alg"].(int64)
+	if !ok {
+		return 0, nil, fmt.Errorf("statement has no algorithm")
+	}
+	sig, ok := stmt["sig"].([]byte)
+	if !ok {
+		return 0, nil, fmt.Errorf("statement has no signature")
+	}
+	return cose.Algorithm(alg), sig, nil
+}
+
+// statementCertificates parses the x5c member of a statement, the
+// attestation certificate first
+func statementCertificates(stmt map[any]any) ([]*x509.Certificate, error) {
+	x5c, ok := stmt["x5c"].([]any)
+	if !ok || len(x5c) == 0 {
+		return nil, fmt.Errorf("statement has no certificates")
+	}
+	certs := make([]*x509.Certificate, len(x5c))
+	for i, v := range x5c {
+		der, ok := v.([]byte)
+		if !ok {
+			return nil, fmt.Errorf("certificate %d is not a byte string", i)
+		}
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, fmt.Errorf("certificate %d: %w", i, err)
+		}
+		certs[i] = cert
+	}
+	return certs, nil
+}
+
+func verifyPacked(stmt map[any]any, ad *AuthenticatorData, signed []byte) (*Attestation, error) {
+	alg, sig, err := statementSignature(stmt)
+	if err != nil {
+		return nil, err
+	}
+	cred := ad.Credential
+	if _, ok := stmt["x5c"]; !ok {
+		// self attestation with the credential key
+		if alg != cred.PublicKey.Algorithm {
+			return nil, fmt.Errorf("algorithm %s does not match the credential key", alg)
+		}
+		vk, err := cred.PublicKey.DIDKey.VerifyKey()
+		if err != nil {
+			return nil, err
+		}
+		if err := verifySignature(vk, alg, signed, sig); err != nil {
+			return nil, err
+		}
+		return &Attestation{Type: AttestationSelf}, nil
+	}
+
+	certs, err := statementCertificates(stmt)
+	if err != nil {
+		return nil, err
+	}
+	cert := certs[0]
+	if err := verifySignature(cert.PublicKey, alg, signed, sig); err != nil {
+		return nil, err
+	}
+	if cert.Version != 3 {
+		return nil, fmt.Errorf("attestation certificate is not version 3")
+	}
+	subject := cert.Subject
+	if len(subject.Country) == 0 || len(subject.Organization) == 0 || subject.CommonName == "" ||
+		len(subject.OrganizationalUnit) != 1 || subject.OrganizationalUnit[0] != "Authenticator Attestation" {
+		return nil, fmt.Errorf("attestation certificate subject is invalid")
+	}
+	if !cert.BasicConstraintsValid || cert.IsCA {
+		return nil, fmt.Errorf("attestation certificate is a CA")
+	}
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(oidFIDOAAGUID) {
+			continue
+		}
+		var aaguid []byte
+		if rest, err := asn1.Unmarshal(ext.Value, &aaguid); err != nil || len(rest) != 0 {
+			return nil, fmt.Errorf("invalid aaguid extension")
+		}
+		if ext.Critical || !bytes.Equal(aaguid, cred.AAGUID[:]) {
+			return nil, fmt.Errorf("aaguid extension does not match the authenticator data")
+		}
+	}
+	return &Attestation{Type: AttestationBasic, Certificates: certs}, nil
+}
+
+func verifyApple(stmt map[any]any, ad *AuthenticatorData, signed []byte) (*Attestation, error) {
+	certs, err := statementCertificates(stmt)
+	if err != nil {
+		return nil, err
+	}
+	cert := certs[0]
+	nonce := sha256.Sum256(signed)
+	var ext struct {
+		Nonce []byte `asn1:"tag:1,explicit"`
+	}
+	found := false
+	for _, e := range cert.Extensions {
+		if !e.Id.Equal(oidAppleNonce) {
+			continue
+		}
+		if rest, err := asn1.Unmarshal(e.Value, &ext); err != nil || len(rest) != 0 {
+			return nil, fmt.Errorf("invalid nonce extension")
+		}
+		found = true
+	}
+	if !found || !bytes.Equal(ext.Nonce, nonce[:]) {
+		return nil, fmt.Errorf("nonce does not match")
+	}
+	if err := matchCredentialKey(cert, ad.Credential.PublicKey); err != nil {
+		return nil, err
+	}
+	return &Attestation{Type: AttestationAnonCA, Certificates: certs}, nil
+}
+
+// keyDescription is the Android key attestation extension, KeyMint and
+// Keymaster use the same layout for the fields checked here
+type keyDescription struct {
+	AttestationVersion       int
+	AttestationSecurityLevel asn1.Enumerated
+	KeymasterVersion         int
+	KeymasterSecurityLevel   asn1.Enumerated
+	AttestationChallenge     []byte
+	UniqueID                 []byte
+	SoftwareEnforced         asn1.RawValue
+	TeeEnforced              asn1.RawValue
+}
+
+// Android authorization list tags and values
+const (
+	kmTagPurpose         = 1
+	kmTagAllApplications = 600
+	kmTagOrigin          = 702
+
+	kmPurposeSign     = 2
+	kmOriginGenerated = 0
+)
+
+// authorizationList holds the checked members of an AuthorizationList
+type authorizationList struct {
+	purposes        []int
+	origin          int
+	hasOrigin       bool
+	allApplications bool
+}
+
+func parseAuthorizationList(raw asn1.RawValue) (*authorizationList, error) {
+	list := &authorizationList{}
+	rest := raw.Bytes
+	for len(rest) > 0 {
+		var el asn1.RawValue
+		var err error
+		if rest, err = asn1.Unmarshal(rest, &el); err != nil {
+			return nil, err
+		}
+		if el.Class != asn1.ClassContextSpecific {
+			continue
+		}
+		switch el.Tag {
+		case kmTagPurpose:
+			if _, err := asn1.UnmarshalWithParams(el.Bytes, &list.purposes, "set"); err != nil {
+				return nil, fmt.Errorf("purpose: %w", err)
+			}
+		case kmTagAllApplications:
+			list.allApplications = true
+		case kmTagOrigin:
+			if _, err := asn1.Unmarshal(el.Bytes, &list.origin); err != nil {
+				return nil, fmt.Errorf("origin: %w", err)
+			}
+			list.hasOrigin = true
+		}
+	}
+	return list, nil
+}
+
+func verifyAndroidKey(stmt map[any]any, ad *AuthenticatorData, signed, clientDataHash []byte) (*Attestation, error) {
+	alg, sig, err := statementSignature(stmt)
+	if err != nil {
+		return nil, err
+	}
+	certs, err := statementCertificates(stmt)
+	if err != nil {
+		return nil, err
+	}
+	cert := certs[0]
+	if err := verifySignature(cert.PublicKey, alg, signed, sig); err != nil {
+		return nil, err
+	}
+	if err := matchCredentialKey(cert, ad.Credential.PublicKey); err != nil {
+		return nil, err
+	}
+
+	var desc *keyDescription
+	for _, e := range cert.Extensions {
+		if !e.Id.Equal(oidAndroidAttestation) {
+			continue
+		}
+		desc = new(keyDescription)
+		if _, err := asn1.Unmarshal(e.Value, desc); err != nil {
+			return nil, fmt.Errorf("invalid key description: %w", err)
+		}
+	}
+	if desc == nil {
+		return nil, fmt.Errorf("attestation certificate has no key description")
+	}
+	if !bytes.Equal(desc.AttestationChallenge, clientDataHash) {
+		return nil, fmt.Errorf("attestation challenge does not match the client data hash")
+	}
+	software, err := parseAuthorizationList(desc.SoftwareEnforced)
+	if err != nil {
+		return nil, err
+	}
+	tee, err := parseAuthorizationList(desc.TeeEnforced)
+	if err != nil {
+		return nil, err
+	}
+	if software.allApplications || tee.allApplications {
+		return nil, fmt.Errorf("key is scoped to all applications")
+	}
+	list := tee
+	if !list.hasOrigin {
+		list = software
+	}
+	if !list.hasOrigin || list.origin != kmOriginGenerated {
+		return nil, fmt.Errorf("key was not generated in the keystore")
+	}
+	signing := false
+	for _, p := range list.purposes {
+		signing = signing || p == kmPurposeSign
+	}
+	if !signing {
+		return nil, fmt.Errorf("key purpose is not signing")
+	}
+	return &Attestation{Type: AttestationBasic, Certificates: certs}, nil
+}
+
+// matchCredentialKey checks that the attestation certificate certifies the
+// credential public key
+func matchCredentialKey(cert *x509.Certificate, key *cose.Key) error {
+	vk, err := key.DIDKey.VerifyKey()
+	if err != nil {
+		return err
+	}
+	type equaler interface{ Equal(x crypto.PublicKey) bool }
+	if pub, ok := cert.PublicKey.(equaler); ok && pub.Equal(vk) {
+		return nil
+	}
+	return fmt.Errorf("certificate public key does not match the credential")
+}
+
+func verifyChain(certs []*x509.Certificate, roots *x509.CertPool, now time.Time) error {
+	intermediates := x509.NewCertPool()
+	for _, c := range certs[1:] {
+		intermediates.AddCert(c)
+	}
+	if now.IsZero() {
+		now = time.Now()
+	}
+	_, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	return err
+}
+
+// verifySignature checks a WebAuthn signature, ECDSA signatures are DER
+// encoded
+func verifySignature(pub any, alg cose.Algorithm, msg, sig []byte) error {
+	valid := false
+	switch key := pub.(type) {
+	case *ecdsa.PublicKey:
+		switch {
+		case alg == cose.AlgES256 && key.Curve == elliptic.P256():
+			digest := sha256.Sum256(msg)
+			valid = ecdsa.VerifyASN1(key, digest[:], sig)
+		case alg == cose.AlgES384 && key.Curve == elliptic.P384():
+			digest := sha512.Sum384(msg)
+			valid = ecdsa.VerifyASN1(key, digest[:], sig)
+		default:
+			return fmt.Errorf("algorithm %s does not match the %s key", alg, key.Curve.Params().Name)
+		}
+	case ed25519.PublicKey:
+		if alg != cose.AlgEdDSA {
+			return fmt.Errorf("algorithm %s does not match the Ed25519 key", alg)
+		}
+		valid = ed25519.Verify(key, msg, sig)
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(msg)
+		switch alg {
+		case cose.AlgRS256:
+			valid = rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig) == nil
+		case cose.AlgPS256:
+			valid = rsa.VerifyPSS(key, crypto.SHA256, digest[:], sig, nil) == nil
+		default:
+			return fmt.Errorf("algorithm %s does not match the RSA key", alg)
+		}
+	case []byte:
+		// secp256k1 keys of did:keys
+		if alg != cose.AlgES256K {
+			return fmt.Errorf("algorithm %s does not match the secp256k1 key", alg)
+		}
+		pk, err := btcec.ParsePubKey(key)
+		if err != nil {
+			return err
+		}
+		parsed, err := btcecdsa.ParseDERSignature(sig)
+		if err != nil {
+			return parsers.ErrInvalidSignature
+		}
+		digest := sha256.Sum256(msg)
+		valid = parsed.Verify(digest[:], pk)
+	default:
+		return fmt.Errorf("unsupported public key type %T", pub)
+	}
+	if !valid {
+		return parsers.ErrInvalidSignature
+	}
+	return nil
+}
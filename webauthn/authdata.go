@@ -0,0 +1,117 @@
+package webauthn
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/go-sonr/crypto/cose"
+)
+
+// Authenticator data flags of WebAuthn section 6.1
+const (
+	FlagUserPresent      byte = 1 << 0
+	FlagUserVerified     byte = 1 << 2
+	FlagBackupEligible   byte = 1 << 3
+	FlagBackupState      byte = 1 << 4
+	FlagAttestedCredData byte = 1 << 6
+	FlagExtensionData    byte = 1 << 7
+)
+
+// minAuthDataSize is the size of the RP ID hash, flags and sign count
+const minAuthDataSize = 37
+
+// AttestedCredential is the attested credential data of a registration
+type AttestedCredential struct {
+	AAGUID    [16]byte
+	ID        []byte
+	PublicKey *cose.Key
+}
+
+// AuthenticatorData is the parsed authenticator data of a registration or
+// an assertion. Raw holds the bytes that were signed.
+type AuthenticatorData struct {
+	Raw        []byte
+	RPIDHash   [32]byte
+	Flags      byte
+	SignCount  uint32
+	Credential *AttestedCredential
+	Extensions any
+}
+
+// ParseAuthenticatorData decodes authenticator data, the attested
+// credential and extensions are present when their flags are set
+func ParseAuthenticatorData(data []byte) (*AuthenticatorData, error) {
+	if len(data) < minAuthDataSize {
+		return nil, fmt.Errorf("authenticator data is too short")
+	}
+	ad := &AuthenticatorData{
+		Raw:       append([]byte{}, data...),
+		Flags:     data[32],
+		SignCount: binary.BigEndian.Uint32(data[33:37]),
+	}
+	copy(ad.RPIDHash[:], data[:32])
+	rest := data[minAuthDataSize:]
+
+	if ad.Flags&FlagAttestedCredData != 0 {
+		if len(rest) < 18 {
+			return nil, fmt.Errorf("attested credential data is too short")
+		}
+		cred := &AttestedCredential{}
+		copy(cred.AAGUID[:], rest[:16])
+		n := int(binary.BigEndian.Uint16(rest[16:18]))
+		rest = rest[18:]
+		if n > len(rest) {
+			return nil, fmt.Errorf("credential id exceeds the authenticator data")
+		}
+		cred.ID = append([]byte{}, rest[:n]...)
+		var err error
+		if cred.PublicKey, rest, err = cose.ParseKeyPrefix(rest[n:]); err != nil {
+			return nil, fmt.Errorf("credential public key: %w", err)
+		}
+		ad.Credential = cred
+	}
+	if ad.Flags&FlagExtensionData != 0 {
+		var err error
+		if ad.Extensions, rest, err = cose.UnmarshalPrefix(rest); err != nil {
+			return nil, fmt.Errorf("extensions: %w", err)
+		}
+		if _, ok := ad.Extensions.(map[any]any); !ok {
+			return nil, fmt.Errorf("extensions are not a map")
+		}
+	}
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("trailing bytes in authenticator data")
+	}
+	return ad, nil
+}
+
+// UserPresent reports whether the UP flag is set
+func (ad *AuthenticatorData) UserPresent() bool {
+	return ad.Flags&FlagUserPresent != 0
+}
+
+// UserVerified reports whether the UV flag is set
+func (ad *AuthenticatorData) UserVerified() bool {
+	return ad.Flags&FlagUserVerified != 0
+}
+
+// verify checks the RP ID hash and the user presence and verification
+// flags
+func (ad *AuthenticatorData) verify(rpID string, requireUV bool) error {
+	h := sha256.Sum256([]byte(rpID))
+	if subtle.ConstantTimeCompare(h[:], ad.RPIDHash[:]) != 1 {
+		return fmt.Errorf("rp id hash mismatch")
+	}
+	if !ad.UserPresent() {
+		return fmt.Errorf("user was not present")
+	}
+	if requireUV && !ad.UserVerified() {
+		return fmt.Errorf("user was not verified")
+	}
+	if ad.Flags&FlagBackupState != 0 && ad.Flags&FlagBackupEligible == 0 {
+		return fmt.Errorf("backed up credential is not backup eligible")
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package webauthn
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// Client data types of WebAuthn section 5.8.1
+const (
+	TypeCreate = "webauthn.create"
+	TypeGet    = "webauthn.get"
+)
+
+// ClientData is the collected client data a browser passes to the
+// authenticator as the hash of its JSON serialization
+type ClientData struct {
+	Type        string `json:"type"`
+	Challenge   string `json:"challenge"`
+	Origin      string `json:"origin"`
+	CrossOrigin bool   `json:"crossOrigin,omitempty"`
+	TopOrigin   string `json:"topOrigin,omitempty"`
+}
+
+// ParseClientData decodes the JSON serialization of collected client data
+func ParseClientData(data []byte) (*ClientData, error) {
+	var cd ClientData
+	if err := json.Unmarshal(data, &cd); err != nil {
+		return nil, fmt.Errorf("client data: %w", err)
+	}
+	return &cd, nil
+}
+
+// verify checks the type, challenge and origin of the client data
+func (cd *ClientData) verify(typ string, challenge []byte, origins []string, allowCrossOrigin bool) error {
+	if cd.Type != typ {
+		return fmt.Errorf("client data type %q, expected %q", cd.Type, typ)
+	}
+	got, err := base64.RawURLEncoding.DecodeString(cd.Challenge)
+	if err != nil {
+		return fmt.Errorf("client data challenge: %w", err)
+	}
+	if len(challenge) == 0 || subtle.ConstantTimeCompare(got, challenge) != 1 {
+		return fmt.Errorf("challenge mismatch")
+	}
+	if !slices.Contains(origins, cd.Origin) {
+		return fmt.Errorf("origin %q is not allowed", cd.Origin)
+	}
+	if cd.CrossOrigin && !allowCrossOrigin {
+		return fmt.Errorf("cross origin ceremonies are not allowed")
+	}
+	return nil
+}
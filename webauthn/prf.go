@@ -0,0 +1,104 @@
+package webauthn
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/secret"
+	"github.com/go-sonr/crypto/derive"
+	"github.com/go-sonr/crypto/envelope"
+	"github.com/go-sonr/crypto/hpke"
+	"github.com/go-sonr/crypto/keyexchange"
+)
+
+// The PRF extension evaluates a per credential HMAC inside the
+// authenticator, so its outputs are secrets that only the passkey holder
+// can reproduce. They are key material rather than keys: every key below
+// is expanded from an output with HKDF-SHA256, salted with the credential
+// id and bound to a purpose string, so one output never serves two
+// purposes or two credentials.
+
+const (
+	// PRFOutputSize is the size of a PRF extension output
+	PRFOutputSize = 32
+
+	prfContext = "sonr-webauthn-prf-v1"
+)
+
+// PRFInput returns the extension input to evaluate for purpose, the value
+// of eval.first in the credential request
+func PRFInput(purpose string) []byte {
+	h := sha256.Sum256([]byte(prfContext + " input " + purpose))
+	return h[:]
+}
+
+// PRFSalt maps a PRF extension input to the salt of the CTAP2
+// hmac-secret extension, SHA-256("WebAuthn PRF" || 0x00 || input), for
+// clients that talk to authenticators directly
+func PRFSalt(input []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte("WebAuthn PRF"))
+	h.Write([]byte{0})
+	h.Write(input)
+	return h.Sum(nil)
+}
+
+// DerivePRFKey expands a PRF output of a credential into length bytes of
+// key material for purpose, for wrapping keys and other symmetric keys
+func DerivePRFKey(output, credentialID []byte, purpose string, length int) ([]byte, error) {
+	if len(output) != PRFOutputSize {
+		return nil, fmt.Errorf("prf output must be %d bytes", PRFOutputSize)
+	}
+	if len(credentialID) == 0 {
+		return nil, fmt.Errorf("credential id is required")
+	}
+	if purpose == "" {
+		return nil, fmt.Errorf("purpose is required")
+	}
+	info := fmt.Appendf(nil, "%s %s", prfContext, purpose)
+	return keyexchange.HKDF(sha256.New, output, credentialID, info, length)
+}
+
+// DerivePRFScalar derives a non-zero scalar of curve from a PRF output,
+// with the hash_to_field of the derive package
+func DerivePRFScalar(curve *curves.Curve, output, credentialID []byte, purpose string) (curves.Scalar, error) {
+	if curve == nil {
+		return nil, fmt.Errorf("curve is required")
+	}
+	ikm, err := DerivePRFKey(output, credentialID, purpose+" scalar", derive.KeySize)
+	if err != nil {
+		return nil, err
+	}
+	defer secret.Wipe(ikm)
+	root, err := derive.New(ikm)
+	if err != nil {
+		return nil, err
+	}
+	defer root.Wipe()
+	return root.Scalar(curve, purpose)
+}
+
+// DerivePRFIdentity derives the envelope identity of an X25519 or P-256
+// HPKE key pair from a PRF output, with the DeriveKeyPair of RFC 9180, so
+// envelopes sealed to its recipient open only with the passkey
+func DerivePRFIdentity(kem hpke.KEM, output, credentialID []byte, purpose string) (*envelope.Identity, error) {
+	ikm, err := DerivePRFKey(output, credentialID, fmt.Sprintf("%s hpke %d", purpose, kem), 32)
+	if err != nil {
+		return nil, err
+	}
+	defer secret.Wipe(ikm)
+	sk, _, err := hpke.Suite{KEM: kem, KDF: hpke.KDFHKDFSHA256, AEAD: hpke.AEADChaCha20Poly1305}.DeriveKeyPair(ikm)
+	if err != nil {
+		return nil, err
+	}
+	defer secret.Wipe(sk)
+	switch kem {
+	case hpke.KEMX25519HKDFSHA256:
+		return envelope.NewX25519Identity(sk)
+	case hpke.KEMP256HKDFSHA256:
+		return envelope.NewP256Identity(sk)
+	default:
+		return nil, fmt.Errorf("unsupported KEM for envelope identities: %d", kem)
+	}
+}
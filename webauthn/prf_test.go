@@ -0,0 +1,90 @@
+package webauthn
+
+import (
+	"bytes"
+	crand "crypto/rand"
+	"encoding/hex"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/envelope"
+	"github.com/go-sonr/crypto/hpke"
+)
+
+func TestPRFSalt(t *testing.T) {
+	// the salt of an empty input, SHA-256("WebAuthn PRF" || 0x00)
+	require.Equal(t, "6a7e64b2aa34c92736143a062fa149aff1bd8bb3f7ee6f346885481f9414a3d3", hex.EncodeToString(PRFSalt(nil)))
+	require.Len(t, PRFInput("vault"), 32)
+	require.NotEqual(t, PRFInput("vault"), PRFInput("signing"))
+}
+
+func TestDerivePRFKey(t *testing.T) {
+	output := make([]byte, PRFOutputSize)
+	_, _ = crand.Read(output)
+	id := []byte("credential")
+
+	k1, err := DerivePRFKey(output, id, "wrap", 32)
+	require.NoError(t, err)
+	k2, err := DerivePRFKey(output, id, "wrap", 32)
+	require.NoError(t, err)
+	require.Equal(t, k1, k2)
+
+	k3, err := DerivePRFKey(output, id, "other", 32)
+	require.NoError(t, err)
+	require.NotEqual(t, k1, k3)
+	k4, err := DerivePRFKey(output, []byte("other credential"), "wrap", 32)
+	require.NoError(t, err)
+	require.NotEqual(t, k1, k4)
+
+	_, err = DerivePRFKey(output[:16], id, "wrap", 32)
+	require.Error(t, err)
+	_, err = DerivePRFKey(output, nil, "wrap", 32)
+	require.Error(t, err)
+	_, err = DerivePRFKey(output, id, "", 32)
+	require.Error(t, err)
+
+	for _, curve := range []*curves.Curve{curves.K256(), curves.P256(), curves.ED25519()} {
+		s1, err := DerivePRFScalar(curve, output, id, "signing")
+		require.NoError(t, err)
+		s2, err := DerivePRFScalar(curve, output, id, "signing")
+		require.NoError(t, err)
+		require.Equal(t, 0, s1.Cmp(s2))
+	}
+}
+
+func TestDerivePRFIdentity(t *testing.T) {
+	output := make([]byte, PRFOutputSize)
+	_, _ = crand.Read(output)
+	id := []byte("credential")
+	msg := []byte("passkey gated payload")
+
+	for _, kem := range []hpke.KEM{hpke.KEMX25519HKDFSHA256, hpke.KEMP256HKDFSHA256} {
+		sender, err := DerivePRFIdentity(kem, output, id, "vault")
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		w, err := envelope.Encrypt(&buf, []envelope.Recipient{sender.Recipient()})
+		require.NoError(t, err)
+		_, err = w.Write(msg)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+
+		// the identity is derived again from the same output when decrypting
+		receiver, err := DerivePRFIdentity(kem, output, id, "vault")
+		require.NoError(t, err)
+		require.Equal(t, sender.Recipient().KeyID(), receiver.Recipient().KeyID())
+		r, _, err := envelope.Decrypt(bytes.NewReader(buf.Bytes()), receiver)
+		require.NoError(t, err)
+		got, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, msg, got)
+
+		other, err := DerivePRFIdentity(kem, output, id, "other")
+		require.NoError(t, err)
+		_, _, err = envelope.Decrypt(bytes.NewReader(buf.Bytes()), other)
+		require.Error(t, err)
+	}
+}
@@ -0,0 +1,161 @@
+// Package webauthn verifies WebAuthn Level 3 registrations and assertions
+// https://www.w3.org/TR/webauthn-3/ for passkey based identities.
+//
+// Registration parses the attestation object, checks the client data and
+// authenticator data against the relying party and verifies the none,
+// packed, apple or android-key attestation statement. The credential
+// public key is a COSE_Key mapped into a did:key, so the rest of this
+// module can address and verify it like any other key. Attestation
+// certificate chains are checked only when trust anchors are configured,
+// otherwise the attestation is reported as untrusted.
+//
+// Outputs of the PRF extension derive wrapping keys, curve scalars and
+// envelope identities, so data can be encrypted to a passkey.
+package webauthn
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/go-sonr/crypto/cose"
+	"github.com/go-sonr/crypto/keys/parsers"
+)
+
+// RegistrationOptions are the relying party expectations of a registration
+type RegistrationOptions struct {
+	// RPID is the relying party identifier, usually its effective domain
+	RPID string
+	// Origins are the allowed origins of the client data
+	Origins []string
+	// Challenge is the challenge issued for the ceremony
+	Challenge []byte
+	// RequireUserVerification rejects credentials created without UV
+	RequireUserVerification bool
+	// AllowCrossOrigin accepts ceremonies run in cross origin iframes
+	AllowCrossOrigin bool
+	// Algorithms restricts the credential algorithms, any supported
+	// algorithm when empty
+	Algorithms []cose.Algorithm
+	// Roots are the trust anchors of attestation certificates
+	Roots *x509.CertPool
+	// Now is the time certificates are checked at, the current time when
+	// zero
+	Now time.Time
+}
+
+// AssertionOptions are the relying party expectations of an assertion
+type AssertionOptions struct {
+	RPID                    string
+	Origins                 []string
+	Challenge               []byte
+	RequireUserVerification bool
+	AllowCrossOrigin        bool
+}
+
+// Credential is a registered public key credential
+type Credential struct {
+	ID             []byte
+	PublicKey      *cose.Key
+	AAGUID         [16]byte
+	SignCount      uint32
+	BackupEligible bool
+	Attestation    *Attestation
+}
+
+// DIDKey returns the did:key of the credential public key
+func (c *Credential) DIDKey() parsers.DIDKey {
+	return c.PublicKey.DIDKey
+}
+
+// VerifyRegistration verifies the client data JSON and attestation object
+// of a navigator.credentials.create() response and returns the new
+// credential
+func VerifyRegistration(clientDataJSON, attestationObject []byte, opts RegistrationOptions) (*Credential, error) {
+	cd, err := ParseClientData(clientDataJSON)
+	if err != nil {
+		return nil, err
+	}
+	if err := cd.verify(TypeCreate, opts.Challenge, opts.Origins, opts.AllowCrossOrigin); err != nil {
+		return nil, err
+	}
+	obj, err := parseAttestationObject(attestationObject)
+	if err != nil {
+		return nil, err
+	}
+	ad, err := ParseAuthenticatorData(obj.authData)
+	if err != nil {
+		return nil, err
+	}
+	if err := ad.verify(opts.RPID, opts.RequireUserVerification); err != nil {
+		return nil, err
+	}
+	if ad.Credential == nil {
+		return nil, fmt.Errorf("authenticator data has no attested credential")
+	}
+	if len(ad.Credential.ID) > 1023 {
+		return nil, fmt.Errorf("credential id is too long")
+	}
+	if len(opts.Algorithms) != 0 && !slices.Contains(opts.Algorithms, ad.Credential.PublicKey.Algorithm) {
+		return nil, fmt.Errorf("credential algorithm %s is not allowed", ad.Credential.PublicKey.Algorithm)
+	}
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	att, err := obj.verifyStatement(ad, clientDataHash[:], opts.Roots, opts.Now)
+	if err != nil {
+		return nil, err
+	}
+	return &Credential{
+		ID:             ad.Credential.ID,
+		PublicKey:      ad.Credential.PublicKey,
+		AAGUID:         ad.Credential.AAGUID,
+		SignCount:      ad.SignCount,
+		BackupEligible: ad.Flags&FlagBackupEligible != 0,
+		Attestation:    att,
+	}, nil
+}
+
+// VerifyAssertion verifies the client data JSON, authenticator data and
+// signature of a navigator.credentials.get() response made with cred. On
+// success the sign count of cred is advanced, a count that does not
+// increase signals a cloned authenticator and is rejected.
+func VerifyAssertion(cred *Credential, clientDataJSON, authenticatorData, signature []byte, opts AssertionOptions) (*AuthenticatorData, error) {
+	if cred == nil || cred.PublicKey == nil {
+		return nil, fmt.Errorf("credential is required")
+	}
+	cd, err := ParseClientData(clientDataJSON)
+	if err != nil {
+		return nil, err
+	}
+	if err := cd.verify(TypeGet, opts.Challenge, opts.Origins, opts.AllowCrossOrigin); err != nil {
+		return nil, err
+	}
+	ad, err := ParseAuthenticatorData(authenticatorData)
+	if err != nil {
+		return nil, err
+	}
+	if err := ad.verify(opts.RPID, opts.RequireUserVerification); err != nil {
+		return nil, err
+	}
+	if ad.Flags&FlagBackupEligible != 0 != cred.BackupEligible {
+		return nil, fmt.Errorf("backup eligibility changed")
+	}
+
+	vk, err := cred.PublicKey.DIDKey.VerifyKey()
+	if err != nil {
+		return nil, err
+	}
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := append(append([]byte{}, authenticatorData...), clientDataHash[:]...)
+	if err := verifySignature(vk, cred.PublicKey.Algorithm, signed, signature); err != nil {
+		return nil, err
+	}
+
+	if (ad.SignCount != 0 || cred.SignCount != 0) && ad.SignCount <= cred.SignCount {
+		return nil, fmt.Errorf("sign count %d did not increase from %d", ad.SignCount, cred.SignCount)
+	}
+	cred.SignCount = ad.SignCount
+	return ad, nil
+}
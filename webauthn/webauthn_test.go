@@ -0,0 +1,429 @@
+package webauthn
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	crand "crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/cose"
+	"github.com/go-sonr/crypto/keys/parsers"
+)
+
+const (
+	testRPID   = "sonr.id"
+	testOrigin = "https://sonr.id"
+)
+
+var testAAGUID = [16]byte{0xad, 0xce, 0x00, 0x02, 0x35, 0xbc, 0xc6, 0x0a, 0x64, 0x8b, 0x0b, 0x25, 0xf1, 0xf0, 0x55, 0x03}
+
+// authenticator is a software authenticator with a single credential
+type authenticator struct {
+	id    []byte
+	key   *ecdsa.PrivateKey
+	ed    ed25519.PrivateKey
+	count uint32
+}
+
+func newAuthenticator(t *testing.T) *authenticator {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), crand.Reader)
+	require.NoError(t, err)
+	id := make([]byte, 16)
+	_, _ = crand.Read(id)
+	return &authenticator{id: id, key: key}
+}
+
+func (a *authenticator) coseKey(t *testing.T) *cose.Key {
+	var (
+		pub crypto.PubKey
+		err error
+	)
+	if a.ed != nil {
+		pub, err = crypto.UnmarshalEd25519PublicKey(a.ed.Public().(ed25519.PublicKey))
+	} else {
+		pub, err = crypto.ECDSAPublicKeyFromPubKey(a.key.PublicKey)
+	}
+	require.NoError(t, err)
+	id, err := parsers.NewKeyDID(pub)
+	require.NoError(t, err)
+	k, err := cose.NewKey(id)
+	require.NoError(t, err)
+	return k
+}
+
+func (a *authenticator) sign(t *testing.T, msg []byte) []byte {
+	if a.ed != nil {
+		return ed25519.Sign(a.ed, msg)
+	}
+	digest := sha256.Sum256(msg)
+	sig, err := ecdsa.SignASN1(crand.Reader, a.key, digest[:])
+	require.NoError(t, err)
+	return sig
+}
+
+func (a *authenticator) authData(t *testing.T, flags byte, attested bool) []byte {
+	h := sha256.Sum256([]byte(testRPID))
+	out := append(h[:], flags)
+	a.count++
+	out = binary.BigEndian.AppendUint32(out, a.count)
+	if attested {
+		key, err := a.coseKey(t).MarshalCBOR()
+		require.NoError(t, err)
+		out = append(out, testAAGUID[:]...)
+		out = binary.BigEndian.AppendUint16(out, uint16(len(a.id)))
+		out = append(append(out, a.id...), key...)
+	}
+	return out
+}
+
+func clientData(t *testing.T, typ string, challenge []byte) []byte {
+	b, err := json.Marshal(ClientData{
+		Type:      typ,
+		Challenge: base64.RawURLEncoding.EncodeToString(challenge),
+		Origin:    testOrigin,
+	})
+	require.NoError(t, err)
+	return b
+}
+
+func attestationObjectBytes(t *testing.T, format string, stmt map[any]any, authData []byte) []byte {
+	b, err := cose.Marshal(map[any]any{"fmt": format, "attStmt": stmt, "authData": authData})
+	require.NoError(t, err)
+	return b
+}
+
+func challenge() []byte {
+	c := make([]byte, 32)
+	_, _ = crand.Read(c)
+	return c
+}
+
+func registrationOptions(c []byte) RegistrationOptions {
+	return RegistrationOptions{RPID: testRPID, Origins: []string{testOrigin}, Challenge: c}
+}
+
+func assertionOptions(c []byte) AssertionOptions {
+	return AssertionOptions{RPID: testRPID, Origins: []string{testOrigin}, Challenge: c}
+}
+
+// assert produces and verifies an assertion of a with cred
+func assert(t *testing.T, a *authenticator, cred *Credential) error {
+	c := challenge()
+	cd := clientData(t, TypeGet, c)
+	ad := a.authData(t, FlagUserPresent|FlagUserVerified, false)
+	h := sha256.Sum256(cd)
+	sig := a.sign(t, append(append([]byte{}, ad...), h[:]...))
+	_, err := VerifyAssertion(cred, cd, ad, sig, assertionOptions(c))
+	return err
+}
+
+func TestNoneRegistrationAndAssertion(t *testing.T) {
+	a := newAuthenticator(t)
+	c := challenge()
+	cd := clientData(t, TypeCreate, c)
+	obj := attestationObjectBytes(t, FormatNone, map[any]any{}, a.authData(t, FlagUserPresent|FlagAttestedCredData, true))
+
+	cred, err := VerifyRegistration(cd, obj, registrationOptions(c))
+	require.NoError(t, err)
+	require.Equal(t, a.id, cred.ID)
+	require.Equal(t, testAAGUID, cred.AAGUID)
+	require.Equal(t, cose.AlgES256, cred.PublicKey.Algorithm)
+	require.Equal(t, AttestationNone, cred.Attestation.Type)
+	require.True(t, strings.HasPrefix(cred.DIDKey().String(), "did:key:zDn"))
+
+	require.NoError(t, assert(t, a, cred))
+	require.Equal(t, a.count, cred.SignCount)
+
+	// a cloned authenticator replays an old counter
+	a.count -= 2
+	require.Error(t, assert(t, a, cred))
+
+	// wrong challenge, origin, rp id and ceremony type
+	opts := registrationOptions(challenge())
+	_, err = VerifyRegistration(cd, obj, opts)
+	require.Error(t, err)
+	opts = registrationOptions(c)
+	opts.Origins = []string{"https://evil.example"}
+	_, err = VerifyRegistration(cd, obj, opts)
+	require.Error(t, err)
+	opts = registrationOptions(c)
+	opts.RPID = "evil.example"
+	_, err = VerifyRegistration(cd, obj, opts)
+	require.Error(t, err)
+	_, err = VerifyRegistration(clientData(t, TypeGet, c), obj, registrationOptions(c))
+	require.Error(t, err)
+
+	opts = registrationOptions(c)
+	opts.RequireUserVerification = true
+	_, err = VerifyRegistration(cd, obj, opts)
+	require.Error(t, err)
+	opts = registrationOptions(c)
+	opts.Algorithms = []cose.Algorithm{cose.AlgEdDSA}
+	_, err = VerifyRegistration(cd, obj, opts)
+	require.Error(t, err)
+}
+
+func TestEd25519Credential(t *testing.T) {
+	a := newAuthenticator(t)
+	_, a.ed, _ = ed25519.GenerateKey(crand.Reader)
+	c := challenge()
+	cd := clientData(t, TypeCreate, c)
+	authData := a.authData(t, FlagUserPresent|FlagUserVerified|FlagAttestedCredData, true)
+	h := sha256.Sum256(cd)
+	sig := a.sign(t, append(append([]byte{}, authData...), h[:]...))
+	obj := attestationObjectBytes(t, FormatPacked, map[any]any{"alg": int64(cose.AlgEdDSA), "sig": sig}, authData)
+
+	cred, err := VerifyRegistration(cd, obj, registrationOptions(c))
+	require.NoError(t, err)
+	require.Equal(t, AttestationSelf, cred.Attestation.Type)
+	require.True(t, strings.HasPrefix(cred.DIDKey().String(), "did:key:z6Mk"))
+	require.NoError(t, assert(t, a, cred))
+}
+
+func TestPackedSelfAttestation(t *testing.T) {
+	a := newAuthenticator(t)
+	c := challenge()
+	cd := clientData(t, TypeCreate, c)
+	authData := a.authData(t, FlagUserPresent|FlagAttestedCredData, true)
+	h := sha256.Sum256(cd)
+	sig := a.sign(t, append(append([]byte{}, authData...), h[:]...))
+
+	obj := attestationObjectBytes(t, FormatPacked, map[any]any{"alg": int64(cose.AlgES256), "sig": sig}, authData)
+	cred, err := VerifyRegistration(cd, obj, registrationOptions(c))
+	require.NoError(t, err)
+	require.Equal(t, AttestationSelf, cred.Attestation.Type)
+
+	obj = attestationObjectBytes(t, FormatPacked, map[any]any{"alg": int64(cose.AlgES256), "sig": sig}, a.authData(t, FlagUserPresent|FlagAttestedCredData, true))
+	_, err = VerifyRegistration(cd, obj, registrationOptions(c))
+	require.Error(t, err)
+}
+
+// testCA issues attestation certificates
+type testCA struct {
+	key  *ecdsa.PrivateKey
+	cert *x509.Certificate
+	pool *x509.CertPool
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), crand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test Attestation Root"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(crand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	return &testCA{key: key, cert: cert, pool: pool}
+}
+
+func (ca *testCA) issue(t *testing.T, pub *ecdsa.PublicKey, subject pkix.Name, exts ...pkix.Extension) []byte {
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(2),
+		Subject:               subject,
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		ExtraExtensions:       exts,
+	}
+	der, err := x509.CreateCertificate(crand.Reader, tmpl, ca.cert, pub, ca.key)
+	require.NoError(t, err)
+	return der
+}
+
+func TestPackedBasicAttestation(t *testing.T) {
+	ca := newTestCA(t)
+	attKey, err := ecdsa.GenerateKey(elliptic.P256(), crand.Reader)
+	require.NoError(t, err)
+	subject := pkix.Name{
+		Country:            []string{"US"},
+		Organization:       []string{"Sonr"},
+		OrganizationalUnit: []string{"Authenticator Attestation"},
+		CommonName:         "Sonr Test Authenticator",
+	}
+	aaguidExt := func(aaguid []byte) pkix.Extension {
+		v, err := asn1.Marshal(aaguid)
+		require.NoError(t, err)
+		return pkix.Extension{Id: oidFIDOAAGUID, Value: v}
+	}
+
+	a := newAuthenticator(t)
+	register := func(der []byte, opts RegistrationOptions) (*Credential, error) {
+		cd := clientData(t, TypeCreate, opts.Challenge)
+		authData := a.authData(t, FlagUserPresent|FlagAttestedCredData, true)
+		h := sha256.Sum256(cd)
+		digest := sha256.Sum256(append(append([]byte{}, authData...), h[:]...))
+		sig, err := ecdsa.SignASN1(crand.Reader, attKey, digest[:])
+		require.NoError(t, err)
+		stmt := map[any]any{"alg": int64(cose.AlgES256), "sig": sig, "x5c": []any{der}}
+		return VerifyRegistration(cd, attestationObjectBytes(t, FormatPacked, stmt, authData), opts)
+	}
+
+	der := ca.issue(t, &attKey.PublicKey, subject, aaguidExt(testAAGUID[:]))
+	cred, err := register(der, registrationOptions(challenge()))
+	require.NoError(t, err)
+	require.Equal(t, AttestationBasic, cred.Attestation.Type)
+	require.False(t, cred.Attestation.Trusted)
+
+	opts := registrationOptions(challenge())
+	opts.Roots = ca.pool
+	cred, err = register(der, opts)
+	require.NoError(t, err)
+	require.True(t, cred.Attestation.Trusted)
+
+	opts.Roots = newTestCA(t).pool
+	_, err = register(der, opts)
+	require.Error(t, err)
+
+	_, err = register(ca.issue(t, &attKey.PublicKey, subject, aaguidExt(make([]byte, 16))), registrationOptions(challenge()))
+	require.Error(t, err)
+	subject.OrganizationalUnit = []string{"Other"}
+	_, err = register(ca.issue(t, &attKey.PublicKey, subject), registrationOptions(challenge()))
+	require.Error(t, err)
+}
+
+func TestAppleAttestation(t *testing.T) {
+	ca := newTestCA(t)
+	a := newAuthenticator(t)
+	c := challenge()
+	cd := clientData(t, TypeCreate, c)
+	authData := a.authData(t, FlagUserPresent|FlagUserVerified|FlagAttestedCredData, true)
+	h := sha256.Sum256(cd)
+	nonce := sha256.Sum256(append(append([]byte{}, authData...), h[:]...))
+
+	nonceExt := func(nonce []byte) pkix.Extension {
+		v, err := asn1.Marshal(struct {
+			Nonce []byte `asn1:"tag:1,explicit"`
+		}{nonce})
+		require.NoError(t, err)
+		return pkix.Extension{Id: oidAppleNonce, Value: v}
+	}
+	der := ca.issue(t, &a.key.PublicKey, pkix.Name{CommonName: "credential"}, nonceExt(nonce[:]))
+	opts := registrationOptions(c)
+	opts.Roots = ca.pool
+	cred, err := VerifyRegistration(cd, attestationObjectBytes(t, FormatApple, map[any]any{"x5c": []any{der}}, authData), opts)
+	require.NoError(t, err)
+	require.Equal(t, AttestationAnonCA, cred.Attestation.Type)
+	require.True(t, cred.Attestation.Trusted)
+
+	der = ca.issue(t, &a.key.PublicKey, pkix.Name{CommonName: "credential"}, nonceExt(make([]byte, 32)))
+	_, err = VerifyRegistration(cd, attestationObjectBytes(t, FormatApple, map[any]any{"x5c": []any{der}}, authData), opts)
+	require.Error(t, err)
+
+	other, err := ecdsa.GenerateKey(elliptic.P256(), crand.Reader)
+	require.NoError(t, err)
+	der = ca.issue(t, &other.PublicKey, pkix.Name{CommonName: "credential"}, nonceExt(nonce[:]))
+	_, err = VerifyRegistration(cd, attestationObjectBytes(t, FormatApple, map[any]any{"x5c": []any{der}}, authData), opts)
+	require.Error(t, err)
+}
+
+func TestAndroidKeyAttestation(t *testing.T) {
+	type authList struct {
+		Purpose []int `asn1:"explicit,tag:1,set"`
+		Origin  int   `asn1:"explicit,tag:702"`
+	}
+	keyDescriptionExt := func(challenge []byte, tee any) pkix.Extension {
+		software, err := asn1.Marshal(struct{}{})
+		require.NoError(t, err)
+		hardware, err := asn1.Marshal(tee)
+		require.NoError(t, err)
+		v, err := asn1.Marshal(keyDescription{
+			AttestationVersion:       200,
+			AttestationSecurityLevel: 1,
+			KeymasterVersion:         200,
+			KeymasterSecurityLevel:   1,
+			AttestationChallenge:     challenge,
+			UniqueID:                 []byte{},
+			SoftwareEnforced:         asn1.RawValue{FullBytes: software},
+			TeeEnforced:              asn1.RawValue{FullBytes: hardware},
+		})
+		require.NoError(t, err)
+		return pkix.Extension{Id: oidAndroidAttestation, Value: v}
+	}
+
+	ca := newTestCA(t)
+	a := newAuthenticator(t)
+	register := func(ext func(clientDataHash []byte) pkix.Extension) (*Credential, error) {
+		c := challenge()
+		cd := clientData(t, TypeCreate, c)
+		authData := a.authData(t, FlagUserPresent|FlagAttestedCredData, true)
+		h := sha256.Sum256(cd)
+		sig := a.sign(t, append(append([]byte{}, authData...), h[:]...))
+		der := ca.issue(t, &a.key.PublicKey, pkix.Name{CommonName: "Android Keystore Key"}, ext(h[:]))
+		stmt := map[any]any{"alg": int64(cose.AlgES256), "sig": sig, "x5c": []any{der}}
+		opts := registrationOptions(c)
+		opts.Roots = ca.pool
+		return VerifyRegistration(cd, attestationObjectBytes(t, FormatAndroidKey, stmt, authData), opts)
+	}
+
+	cred, err := register(func(h []byte) pkix.Extension {
+		return keyDescriptionExt(h, authList{Purpose: []int{kmPurposeSign}, Origin: kmOriginGenerated})
+	})
+	require.NoError(t, err)
+	require.Equal(t, AttestationBasic, cred.Attestation.Type)
+	require.True(t, cred.Attestation.Trusted)
+	require.NoError(t, assert(t, a, cred))
+
+	_, err = register(func(h []byte) pkix.Extension {
+		return keyDescriptionExt(make([]byte, 32), authList{Purpose: []int{kmPurposeSign}, Origin: kmOriginGenerated})
+	})
+	require.Error(t, err)
+	_, err = register(func(h []byte) pkix.Extension {
+		// an imported key
+		return keyDescriptionExt(h, authList{Purpose: []int{kmPurposeSign}, Origin: 2})
+	})
+	require.Error(t, err)
+	_, err = register(func(h []byte) pkix.Extension {
+		return keyDescriptionExt(h, struct {
+			Purpose         []int     `asn1:"explicit,tag:1,set"`
+			AllApplications asn1.Flag `asn1:"explicit,tag:600"`
+			Origin          int       `asn1:"explicit,tag:702"`
+		}{[]int{kmPurposeSign}, true, kmOriginGenerated})
+	})
+	require.Error(t, err)
+}
+
+func TestParseAuthenticatorData(t *testing.T) {
+	a := newAuthenticator(t)
+	raw := a.authData(t, FlagUserPresent|FlagAttestedCredData, true)
+	ad, err := ParseAuthenticatorData(raw)
+	require.NoError(t, err)
+	require.True(t, ad.UserPresent())
+	require.False(t, ad.UserVerified())
+	require.Equal(t, a.id, ad.Credential.ID)
+
+	ext, err := cose.Marshal(map[any]any{"credProps": map[any]any{"rk": true}})
+	require.NoError(t, err)
+	raw[32] |= FlagExtensionData
+	ad, err = ParseAuthenticatorData(append(raw, ext...))
+	require.NoError(t, err)
+	require.NotNil(t, ad.Extensions)
+
+	_, err = ParseAuthenticatorData(append(append(raw, ext...), 0))
+	require.Error(t, err)
+	_, err = ParseAuthenticatorData(raw[:40])
+	require.Error(t, err)
+}
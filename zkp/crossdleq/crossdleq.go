@@ -0,0 +1,309 @@
+// Package crossdleq proves that two points on different curves, X1 = x·G1 and
+// X2 = x·G2, have the same discrete log x, following the cross-group
+// discrete log equality proof of Noether, https://www.getmonero.org/resources/research-lab/pubs/MRL-0010.pdf.
+//
+// The groups have different orders, so x is handled as an integer smaller
+// than both orders. The prover splits x into bits b_i and commits to every bit
+// on both curves, C1_i = b_i·G1 + r_i·H1 and C2_i = b_i·G2 + s_i·H2, with
+// blinding factors that cancel in Σ 2^i·C1_i = X1 and Σ 2^i·C2_i = X2. A
+// Sigma OR proof per bit shows that both commitments open to 0 or both open to
+// 1, which is also a range proof that x < 2^Bits. All bit proofs share one
+// Fiat-Shamir challenge of ChallengeBits bits, split per bit as integers modulo
+// 2^ChallengeBits so that the same challenge holds on both curves.
+package crossdleq
+
+import (
+	crand "crypto/rand"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
+	"github.com/go-sonr/crypto/internal"
+)
+
+// ChallengeBits is the size of the challenges, smaller than the order of any supported curve
+const ChallengeBits = 128
+
+const generatorDomain = "go-sonr cross-curve dleq generator v1"
+
+var challengeModulus = new(big.Int).Lsh(big.NewInt(1), ChallengeBits)
+
+// BitProof commits to one bit of the secret on both curves and proves that
+// both commitments open to the same bit
+type BitProof struct {
+	C1, C2 curves.Point
+	// E0 is the challenge of the zero branch, the one branch gets the rest
+	E0 *big.Int
+	// Z1 and Z2 are the responses of both branches on each curve
+	Z1, Z2 [2]curves.Scalar
+}
+
+// Proof is a proof that two points share their discrete log
+type Proof struct {
+	Challenge *big.Int
+	Bits      []BitProof
+}
+
+// Bits returns the number of bits of the secrets that can be proven for curve1 and curve2,
+// one less than the size of the smaller group order
+func Bits(curve1, curve2 *curves.Curve) int {
+	n1 := curve1.Scalar.One().Neg().BigInt().BitLen()
+	n2 := curve2.Scalar.One().Neg().BigInt().BitLen()
+	if n2 < n1 {
+		n1 = n2
+	}
+	return n1 - 1
+}
+
+// Prove proves that x·G1 on curve1 and x·G2 on curve2 have the same discrete log x,
+// which must be smaller than 2^Bits(curve1, curve2)
+func Prove(transcript *transcript.Transcript, curve1, curve2 *curves.Curve, x *big.Int) (*Proof, error) {
+	return ProveFromReader(transcript, curve1, curve2, x, crand.Reader)
+}
+
+// ProveFromReader proves that x·G1 and x·G2 share x, drawing randomness from reader
+func ProveFromReader(transcript *transcript.Transcript, curve1, curve2 *curves.Curve, x *big.Int, reader io.Reader) (*Proof, error) {
+	if transcript == nil || curve1 == nil || curve2 == nil || x == nil || reader == nil {
+		return nil, internal.ErrNilArguments
+	}
+	bits := Bits(curve1, curve2)
+	if x.Sign() < 0 || x.BitLen() > bits {
+		return nil, fmt.Errorf("secret must be smaller than 2^%d", bits)
+	}
+	g1, g2 := group(curve1), group(curve2)
+	s1, err := g1.scalar(x)
+	if err != nil {
+		return nil, err
+	}
+	s2, err := g2.scalar(x)
+	if err != nil {
+		return nil, err
+	}
+	x1, x2 := g1.curve.ScalarBaseMult(s1), g2.curve.ScalarBaseMult(s2)
+
+	r1, err := g1.blindings(bits, reader)
+	if err != nil {
+		return nil, err
+	}
+	r2, err := g2.blindings(bits, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	proof := &Proof{Bits: make([]BitProof, bits)}
+	k1 := make([]curves.Scalar, bits)
+	k2 := make([]curves.Scalar, bits)
+	fake := make([]*big.Int, bits)
+	a := make([][4]curves.Point, bits)
+	for i := range proof.Bits {
+		b := int(x.Bit(i))
+		bp := &proof.Bits[i]
+		bp.C1 = g1.commit(b, r1[i])
+		bp.C2 = g2.commit(b, r2[i])
+
+		// The branch of the bit is answered honestly, the other one is simulated
+		k1[i] = g1.curve.Scalar.Random(reader)
+		k2[i] = g2.curve.Scalar.Random(reader)
+		a[i][2*b] = g1.h.Mul(k1[i])
+		a[i][2*b+1] = g2.h.Mul(k2[i])
+
+		if fake[i], err = randomChallenge(reader); err != nil {
+			return nil, err
+		}
+		bp.Z1[1-b] = g1.curve.Scalar.Random(reader)
+		bp.Z2[1-b] = g2.curve.Scalar.Random(reader)
+		if a[i][2*(1-b)], err = g1.commitment(bp.C1, 1-b, bp.Z1[1-b], fake[i]); err != nil {
+			return nil, err
+		}
+		if a[i][2*(1-b)+1], err = g2.commitment(bp.C2, 1-b, bp.Z2[1-b], fake[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	proof.Challenge = challenge(transcript, x1, x2, proof.Bits, a)
+	for i := range proof.Bits {
+		b := int(x.Bit(i))
+		bp := &proof.Bits[i]
+		e := new(big.Int).Sub(proof.Challenge, fake[i])
+		e.Mod(e, challengeModulus)
+		if b == 0 {
+			bp.E0 = e
+		} else {
+			bp.E0 = fake[i]
+		}
+		// z = k + e·r
+		e1, err := g1.scalar(e)
+		if err != nil {
+			return nil, err
+		}
+		e2, err := g2.scalar(e)
+		if err != nil {
+			return nil, err
+		}
+		bp.Z1[b] = k1[i].Add(e1.Mul(r1[i]))
+		bp.Z2[b] = k2[i].Add(e2.Mul(r2[i]))
+	}
+	return proof, nil
+}
+
+// Verify checks that proof proves that x1 and x2, points on two curves, have the same discrete log
+func Verify(transcript *transcript.Transcript, x1, x2 curves.Point, proof *Proof) error {
+	if transcript == nil || x1 == nil || x2 == nil || proof == nil || proof.Challenge == nil {
+		return internal.ErrNilArguments
+	}
+	curve1, curve2 := curves.GetCurveByName(x1.CurveName()), curves.GetCurveByName(x2.CurveName())
+	if curve1 == nil || curve2 == nil {
+		return fmt.Errorf("unsupported curve")
+	}
+	g1, g2 := group(curve1), group(curve2)
+	if err := g1.check(x1); err != nil {
+		return err
+	}
+	if err := g2.check(x2); err != nil {
+		return err
+	}
+	bits := Bits(curve1, curve2)
+	if len(proof.Bits) != bits {
+		return fmt.Errorf("expected %d bit proofs, got %d", bits, len(proof.Bits))
+	}
+	if !inChallengeRange(proof.Challenge) {
+		return fmt.Errorf("invalid challenge")
+	}
+
+	a := make([][4]curves.Point, bits)
+	for i, bp := range proof.Bits {
+		if err := g1.check(bp.C1); err != nil {
+			return err
+		}
+		if err := g2.check(bp.C2); err != nil {
+			return err
+		}
+		if bp.E0 == nil || !inChallengeRange(bp.E0) {
+			return fmt.Errorf("invalid challenge of bit %d", i)
+		}
+		e1 := new(big.Int).Sub(proof.Challenge, bp.E0)
+		e1.Mod(e1, challengeModulus)
+		for b, e := range []*big.Int{bp.E0, e1} {
+			if bp.Z1[b] == nil || bp.Z2[b] == nil {
+				return internal.ErrNilArguments
+			}
+			var err error
+			if a[i][2*b], err = g1.commitment(bp.C1, b, bp.Z1[b], e); err != nil {
+				return err
+			}
+			if a[i][2*b+1], err = g2.commitment(bp.C2, b, bp.Z2[b], e); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Σ 2^i·C_i opens to the public point because the blinding factors cancel
+	sum1, sum2 := proof.Bits[bits-1].C1, proof.Bits[bits-1].C2
+	for i := bits - 2; i >= 0; i-- {
+		sum1 = sum1.Double().Add(proof.Bits[i].C1)
+		sum2 = sum2.Double().Add(proof.Bits[i].C2)
+	}
+	if !sum1.Equal(x1) || !sum2.Equal(x2) {
+		return fmt.Errorf("bit commitments do not add up to the public points")
+	}
+
+	if challenge(transcript, x1, x2, proof.Bits, a).Cmp(proof.Challenge) != 0 {
+		return fmt.Errorf("invalid proof")
+	}
+	return nil
+}
+
+// pedersen holds the generators G and H of one curve
+type pedersen struct {
+	curve *curves.Curve
+	h     curves.Point
+}
+
+func group(curve *curves.Curve) *pedersen {
+	return &pedersen{curve: curve, h: curve.Point.Hash([]byte(generatorDomain))}
+}
+
+func (g *pedersen) scalar(v *big.Int) (curves.Scalar, error) {
+	return g.curve.Scalar.SetBigInt(v)
+}
+
+// blindings returns random r_i with Σ 2^i·r_i = 0
+func (g *pedersen) blindings(bits int, reader io.Reader) ([]curves.Scalar, error) {
+	r := make([]curves.Scalar, bits)
+	sum := g.curve.Scalar.Zero()
+	weight := g.curve.Scalar.One()
+	for i := 0; i < bits-1; i++ {
+		r[i] = g.curve.Scalar.Random(reader)
+		sum = sum.Add(r[i].Mul(weight))
+		weight = weight.Double()
+	}
+	inv, err := weight.Invert()
+	if err != nil {
+		return nil, err
+	}
+	r[bits-1] = sum.Neg().Mul(inv)
+	return r, nil
+}
+
+// commit returns b·G + r·H
+func (g *pedersen) commit(b int, r curves.Scalar) curves.Point {
+	c := g.h.Mul(r)
+	if b == 1 {
+		c = c.Add(g.curve.NewGeneratorPoint())
+	}
+	return c
+}
+
+// commitment returns z·H - e·(C - b·G), the nonce commitment of branch b
+func (g *pedersen) commitment(c curves.Point, b int, z curves.Scalar, e *big.Int) (curves.Point, error) {
+	es, err := g.scalar(e)
+	if err != nil {
+		return nil, err
+	}
+	if b == 1 {
+		c = c.Sub(g.curve.NewGeneratorPoint())
+	}
+	return g.h.Mul(z).Sub(c.Mul(es)), nil
+}
+
+// check checks that p is in the prime order subgroup, as (q-1)·p + p is the
+// identity only then. On curves with a cofactor, such as ed25519, a point
+// with a small order component would otherwise prove the log only modulo the
+// cofactor.
+func (g *pedersen) check(p curves.Point) error {
+	if p == nil || p.CurveName() != g.curve.Name || !p.IsOnCurve() {
+		return internal.ErrNotOnCurve
+	}
+	if !p.Mul(g.curve.Scalar.One().Neg()).Add(p).IsIdentity() {
+		return fmt.Errorf("point is not in the prime order subgroup")
+	}
+	return nil
+}
+
+func challenge(transcript *transcript.Transcript, x1, x2 curves.Point, bits []BitProof, a [][4]curves.Point) *big.Int {
+	transcript.AppendMessage([]byte("curves"), []byte(x1.CurveName()+","+x2.CurveName()))
+	transcript.AppendPoint([]byte("x1"), x1)
+	transcript.AppendPoint([]byte("x2"), x2)
+	for i, bp := range bits {
+		transcript.AppendPoint([]byte("c1"), bp.C1)
+		transcript.AppendPoint([]byte("c2"), bp.C2)
+		for _, p := range a[i] {
+			transcript.AppendPoint([]byte("commitment"), p)
+		}
+	}
+	return new(big.Int).SetBytes(transcript.ExtractBytes([]byte("challenge"), ChallengeBits/8))
+}
+
+func randomChallenge(reader io.Reader) (*big.Int, error) {
+	var buf [ChallengeBits / 8]byte
+	if _, err := io.ReadFull(reader, buf[:]); err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(buf[:]), nil
+}
+
+func inChallengeRange(e *big.Int) bool {
+	return e.Sign() >= 0 && e.Cmp(challengeModulus) < 0
+}
@@ -0,0 +1,123 @@
+package crossdleq
+
+import (
+	crand "crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
+)
+
+func randomSecret(t *testing.T, bits int) *big.Int {
+	x, err := crand.Int(crand.Reader, new(big.Int).Lsh(big.NewInt(1), uint(bits)))
+	require.NoError(t, err)
+	return x
+}
+
+func publicPoints(t *testing.T, curve1, curve2 *curves.Curve, x *big.Int) (curves.Point, curves.Point) {
+	s1, err := curve1.Scalar.SetBigInt(x)
+	require.NoError(t, err)
+	s2, err := curve2.Scalar.SetBigInt(x)
+	require.NoError(t, err)
+	return curve1.ScalarBaseMult(s1), curve2.ScalarBaseMult(s2)
+}
+
+func TestCrossCurveDLEQ(t *testing.T) {
+	for _, pair := range [][2]*curves.Curve{
+		{curves.K256(), curves.ED25519()},
+		{curves.ED25519(), curves.BLS12381G1()},
+		{curves.P256(), curves.K256()},
+	} {
+		curve1, curve2 := pair[0], pair[1]
+		bits := Bits(curve1, curve2)
+		x := randomSecret(t, bits)
+		x1, x2 := publicPoints(t, curve1, curve2, x)
+
+		proof, err := Prove(transcript.New("test"), curve1, curve2, x)
+		require.NoError(t, err)
+		require.Len(t, proof.Bits, bits)
+		require.NoError(t, Verify(transcript.New("test"), x1, x2, proof), curve1.Name+" "+curve2.Name)
+		require.Error(t, Verify(transcript.New("other"), x1, x2, proof))
+
+		// A different log on the second curve
+		_, y2 := publicPoints(t, curve1, curve2, new(big.Int).Add(x, big.NewInt(1)))
+		require.Error(t, Verify(transcript.New("test"), x1, y2, proof))
+	}
+}
+
+func TestCrossCurveDLEQRange(t *testing.T) {
+	curve1, curve2 := curves.K256(), curves.ED25519()
+	bits := Bits(curve1, curve2)
+	require.Equal(t, 252, bits)
+
+	_, err := Prove(transcript.New("test"), curve1, curve2, new(big.Int).Lsh(big.NewInt(1), uint(bits)))
+	require.Error(t, err)
+	_, err = Prove(transcript.New("test"), curve1, curve2, big.NewInt(-1))
+	require.Error(t, err)
+
+	// The edges of the range
+	for _, x := range []*big.Int{big.NewInt(1), new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(bits)), big.NewInt(1))} {
+		x1, x2 := publicPoints(t, curve1, curve2, x)
+		proof, err := Prove(transcript.New("test"), curve1, curve2, x)
+		require.NoError(t, err)
+		require.NoError(t, Verify(transcript.New("test"), x1, x2, proof))
+	}
+}
+
+func TestCrossCurveDLEQTampered(t *testing.T) {
+	curve1, curve2 := curves.K256(), curves.ED25519()
+	x := randomSecret(t, Bits(curve1, curve2))
+	x1, x2 := publicPoints(t, curve1, curve2, x)
+	proof, err := Prove(transcript.New("test"), curve1, curve2, x)
+	require.NoError(t, err)
+
+	tampered := *proof
+	tampered.Bits = append([]BitProof{}, proof.Bits...)
+	tampered.Bits[3].Z1[0] = tampered.Bits[3].Z1[0].Add(curve1.Scalar.One())
+	require.Error(t, Verify(transcript.New("test"), x1, x2, &tampered))
+
+	tampered.Bits = append([]BitProof{}, proof.Bits...)
+	tampered.Bits[5].E0 = new(big.Int).Add(proof.Bits[5].E0, big.NewInt(1))
+	require.Error(t, Verify(transcript.New("test"), x1, x2, &tampered))
+
+	// Swapping two bits keeps the bit proofs valid but not the sum
+	tampered.Bits = append([]BitProof{}, proof.Bits...)
+	tampered.Bits[0], tampered.Bits[1] = tampered.Bits[1], tampered.Bits[0]
+	require.Error(t, Verify(transcript.New("test"), x1, x2, &tampered))
+
+	tampered.Bits = proof.Bits[1:]
+	require.Error(t, Verify(transcript.New("test"), x1, x2, &tampered))
+	require.Error(t, Verify(transcript.New("test"), x2, x1, proof))
+}
+
+func TestCrossCurveDLEQTorsion(t *testing.T) {
+	curve1, curve2 := curves.K256(), curves.ED25519()
+	x := randomSecret(t, Bits(curve1, curve2))
+	x1, x2 := publicPoints(t, curve1, curve2, x)
+	proof, err := Prove(transcript.New("test"), curve1, curve2, x)
+	require.NoError(t, err)
+
+	// (0, -1), the point of order 2 of ed25519
+	encoded := make([]byte, 32)
+	for i := range encoded {
+		encoded[i] = 0xff
+	}
+	encoded[0], encoded[31] = 0xec, 0x7f
+	torsion, err := curve2.Point.FromAffineCompressed(encoded)
+	require.NoError(t, err)
+	require.False(t, torsion.IsIdentity())
+	require.True(t, torsion.Double().IsIdentity())
+
+	g := group(curve2)
+	require.NoError(t, g.check(x2))
+	require.Error(t, g.check(x2.Add(torsion)))
+	require.Error(t, Verify(transcript.New("test"), x1, x2.Add(torsion), proof))
+
+	tampered := *proof
+	tampered.Bits = append([]BitProof{}, proof.Bits...)
+	tampered.Bits[0].C2 = tampered.Bits[0].C2.Add(torsion)
+	require.Error(t, Verify(transcript.New("test"), x1, x2.Add(torsion), &tampered))
+}
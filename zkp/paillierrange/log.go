@@ -0,0 +1,203 @@
+package paillierrange
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/go-sonr/crypto/core"
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
+	"github.com/go-sonr/crypto/internal"
+)
+
+// LogStatement is the public input of a discrete log proof: the range statement for C together with the point
+// X = x·G, where x is the integer encrypted by C.
+type LogStatement struct {
+	Statement
+	X curves.Point
+}
+
+// LogProof is Π^log*, a proof that a Paillier ciphertext encrypts the discrete log of a curve point. The field C of
+// the embedded proof holds the commitment D of the paper.
+type LogProof struct {
+	Proof
+	Y curves.Point
+}
+
+// ProveLog proves that the ciphertext of the statement encrypts x with nonce rho, that X = x·G and that
+// |x| < 2^(Bits+SlackBits). params are the verifier's ring-Pedersen parameters.
+// [CGGMP21] fig 25
+func ProveLog(transcript *transcript.Transcript, params *PedersenParams, statement *LogStatement, x, rho *big.Int) (*LogProof, error) {
+	if transcript == nil || statement == nil || x == nil || rho == nil {
+		return nil, internal.ErrNilArguments
+	}
+	curve, err := statement.curve()
+	if err != nil {
+		return nil, err
+	}
+	if err = params.validate(); err != nil {
+		return nil, err
+	}
+	if err = statement.validate(); err != nil {
+		return nil, err
+	}
+	if uint(x.BitLen()) > statement.Bits {
+		return nil, fmt.Errorf("witness is out of range")
+	}
+	n0 := statement.PublicKey.N
+
+	bound := new(big.Int).Lsh(core.One, statement.Bits+SlackBits)
+	alpha, err := randomSigned(bound)
+	if err != nil {
+		return nil, err
+	}
+	mu, err := randomSigned(new(big.Int).Lsh(params.N, statement.Bits))
+	if err != nil {
+		return nil, err
+	}
+	r, err := randomUnit(n0)
+	if err != nil {
+		return nil, err
+	}
+	gamma, err := randomSigned(new(big.Int).Mul(bound, params.N))
+	if err != nil {
+		return nil, err
+	}
+
+	proof := &LogProof{}
+	// S = s^x t^μ mod N̂, A = (1+N0)^α r^N0 mod N0², Y = α·G, D = s^α t^γ mod N̂
+	if proof.S, err = params.commit(x, mu); err != nil {
+		return nil, err
+	}
+	if proof.A, err = encryptSigned(statement.PublicKey, alpha, r); err != nil {
+		return nil, err
+	}
+	a, err := toScalar(curve, alpha)
+	if err != nil {
+		return nil, err
+	}
+	proof.Y = curve.ScalarBaseMult(a)
+	if proof.C, err = params.commit(alpha, gamma); err != nil {
+		return nil, err
+	}
+
+	e := logChallenge(transcript, params, statement, proof)
+	proof.Z1 = new(big.Int).Mul(e, x)
+	proof.Z1.Add(proof.Z1, alpha)
+	proof.Z2 = new(big.Int).Exp(rho, e, n0)
+	proof.Z2.Mul(proof.Z2, r).Mod(proof.Z2, n0)
+	proof.Z3 = new(big.Int).Mul(e, mu)
+	proof.Z3.Add(proof.Z3, gamma)
+	return proof, nil
+}
+
+// Verify checks that the ciphertext of the statement encrypts the discrete log x of X with |x| < 2^(Bits+SlackBits).
+// [CGGMP21] fig 25
+func (proof *LogProof) Verify(transcript *transcript.Transcript, params *PedersenParams, statement *LogStatement) error {
+	if proof == nil || proof.Y == nil || transcript == nil || statement == nil ||
+		core.AnyNil(proof.S, proof.A, proof.C, proof.Z1, proof.Z2, proof.Z3) {
+		return internal.ErrNilArguments
+	}
+	curve, err := statement.curve()
+	if err != nil {
+		return err
+	}
+	if proof.Y.CurveName() != curve.Name || !proof.Y.IsOnCurve() {
+		return fmt.Errorf("invalid Y")
+	}
+	if err = params.validate(); err != nil {
+		return err
+	}
+	if err = statement.validate(); err != nil {
+		return err
+	}
+	if err = inUnits(proof.S, params.N); err != nil {
+		return fmt.Errorf("invalid S: %w", err)
+	}
+	if err = inUnits(proof.C, params.N); err != nil {
+		return fmt.Errorf("invalid D: %w", err)
+	}
+	if err = inUnits(proof.A, statement.PublicKey.N2); err != nil {
+		return fmt.Errorf("invalid A: %w", err)
+	}
+	if err = inUnits(proof.Z2, statement.PublicKey.N); err != nil {
+		return fmt.Errorf("invalid z2: %w", err)
+	}
+	if uint(proof.Z1.BitLen()) > statement.Bits+SlackBits {
+		return fmt.Errorf("z1 is out of range")
+	}
+
+	e := logChallenge(transcript, params, statement, proof)
+
+	// (1+N0)^z1 z2^N0 = A C^e mod N0²
+	lhs, err := encryptSigned(statement.PublicKey, proof.Z1, proof.Z2)
+	if err != nil {
+		return err
+	}
+	rhs := new(big.Int).Exp(statement.Ciphertext, e, statement.PublicKey.N2)
+	rhs.Mul(rhs, proof.A).Mod(rhs, statement.PublicKey.N2)
+	if lhs.Cmp(rhs) != 0 {
+		return fmt.Errorf("paillier log proof failed")
+	}
+
+	// z1·G = Y + e·X
+	z1, err := toScalar(curve, proof.Z1)
+	if err != nil {
+		return err
+	}
+	es, err := toScalar(curve, e)
+	if err != nil {
+		return err
+	}
+	if !curve.ScalarBaseMult(z1).Equal(proof.Y.Add(statement.X.Mul(es))) {
+		return fmt.Errorf("paillier log proof group equation failed")
+	}
+
+	// s^z1 t^z3 = D S^e mod N̂
+	lhs, err = params.commit(proof.Z1, proof.Z3)
+	if err != nil {
+		return err
+	}
+	rhs = new(big.Int).Exp(proof.S, e, params.N)
+	rhs.Mul(rhs, proof.C).Mod(rhs, params.N)
+	if lhs.Cmp(rhs) != 0 {
+		return fmt.Errorf("paillier log proof commitment failed")
+	}
+	return nil
+}
+
+func (statement *LogStatement) curve() (*curves.Curve, error) {
+	if statement.X == nil {
+		return nil, internal.ErrNilArguments
+	}
+	curve := curves.GetCurveByName(statement.X.CurveName())
+	if curve == nil {
+		return nil, fmt.Errorf("unsupported curve %s", statement.X.CurveName())
+	}
+	if !statement.X.IsOnCurve() {
+		return nil, fmt.Errorf("invalid X")
+	}
+	return curve, nil
+}
+
+// toScalar reduces a signed integer modulo the group order of the curve.
+func toScalar(curve *curves.Curve, k *big.Int) (curves.Scalar, error) {
+	q := curve.Scalar.One().Neg().BigInt()
+	q.Add(q, core.One)
+	return curve.Scalar.SetBigInt(new(big.Int).Mod(k, q))
+}
+
+// logChallenge derives the challenge e ∈ [0, 2^ChallengeBits) of Π^log*.
+func logChallenge(transcript *transcript.Transcript, params *PedersenParams, statement *LogStatement, proof *LogProof) *big.Int {
+	transcript.AppendMessage([]byte("protocol"), []byte("paillier log with range"))
+	params.appendTo(transcript)
+	transcript.AppendMessage([]byte("N0"), statement.PublicKey.N.Bytes())
+	transcript.AppendMessage([]byte("C"), (*big.Int)(statement.Ciphertext).Bytes())
+	transcript.AppendPoint([]byte("X"), statement.X)
+	transcript.AppendMessage([]byte("bits"), big.NewInt(int64(statement.Bits)).Bytes())
+	transcript.AppendMessage([]byte("S"), proof.S.Bytes())
+	transcript.AppendMessage([]byte("A"), proof.A.Bytes())
+	transcript.AppendPoint([]byte("Y"), proof.Y)
+	transcript.AppendMessage([]byte("D"), proof.C.Bytes())
+	return new(big.Int).SetBytes(transcript.ExtractBytes([]byte("challenge"), ChallengeBits/8))
+}
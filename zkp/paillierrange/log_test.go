@@ -0,0 +1,59 @@
+package paillierrange
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
+)
+
+func TestLogProof(t *testing.T) {
+	sk, ped := newTestKeys(t)
+	pk := &sk.PublicKey
+	for _, curve := range []*curves.Curve{curves.K256(), curves.P256(), curves.ED25519()} {
+		x := curve.Scalar.Random(rand.Reader)
+		c, rho, err := pk.Encrypt(x.BigInt())
+		require.NoError(t, err)
+		statement := &LogStatement{
+			Statement: Statement{PublicKey: pk, Ciphertext: c, Bits: 256},
+			X:         curve.ScalarBaseMult(x),
+		}
+		proof, err := ProveLog(transcript.New("test"), ped.Params, statement, x.BigInt(), rho)
+		require.NoError(t, err)
+		require.NoError(t, proof.Verify(transcript.New("test"), ped.Params, statement))
+		require.Error(t, proof.Verify(transcript.New("other"), ped.Params, statement))
+
+		// the same ciphertext with another point
+		wrong := *statement
+		wrong.X = curve.Point.Random(rand.Reader)
+		require.Error(t, proof.Verify(transcript.New("test"), ped.Params, &wrong))
+
+		tampered := *proof
+		tampered.Y = proof.Y.Add(curve.NewGeneratorPoint())
+		require.Error(t, tampered.Verify(transcript.New("test"), ped.Params, statement))
+	}
+}
+
+func TestLogProofWrongWitness(t *testing.T) {
+	sk, ped := newTestKeys(t)
+	pk := &sk.PublicKey
+	curve := curves.K256()
+	x := curve.Scalar.Random(rand.Reader)
+	c, rho, err := pk.Encrypt(x.BigInt())
+	require.NoError(t, err)
+	// the ciphertext does not encrypt the discrete log of X
+	statement := &LogStatement{
+		Statement: Statement{PublicKey: pk, Ciphertext: c, Bits: 256},
+		X:         curve.ScalarBaseMult(x.Add(curve.Scalar.One())),
+	}
+	proof, err := ProveLog(transcript.New("test"), ped.Params, statement, x.BigInt(), rho)
+	require.NoError(t, err)
+	require.Error(t, proof.Verify(transcript.New("test"), ped.Params, statement))
+
+	_, err = ProveLog(transcript.New("test"), ped.Params, statement, new(big.Int).Lsh(big.NewInt(1), 256), rho)
+	require.Error(t, err)
+}
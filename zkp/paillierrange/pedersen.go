@@ -0,0 +1,227 @@
+// Package paillierrange implements zero-knowledge range proofs for Paillier ciphertexts, as described in
+// Canetti, et al. https://eprint.iacr.org/2021/060 (CGGMP21): the ring-Pedersen parameters of §3.3 with their proof
+// of correctness Π^prm (fig 17), and the Paillier encryption in range proof Π^enc (fig 14).
+//
+// The proofs are made non-interactive with Fiat-Shamir over a merlin transcript, which callers bind to their
+// session before proving or verifying.
+package paillierrange
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+
+	"github.com/go-sonr/crypto/core"
+	"github.com/go-sonr/crypto/core/transcript"
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/paillier"
+)
+
+// ParamsProofLength is the number of repetitions of Π^prm, for a soundness error of 2^-80.
+const ParamsProofLength = 80
+
+// PedersenParams are ring-Pedersen parameters: an RSA modulus N with s, t ∈ Z_N* where s = t^λ.
+// The verifier of a range proof generates them and proves that s is in the group generated by t.
+type PedersenParams struct {
+	N, S, T *big.Int
+}
+
+// Pedersen holds ring-Pedersen parameters together with the trapdoor λ.
+type Pedersen struct {
+	Params *PedersenParams
+	lambda *big.Int
+	phi    *big.Int
+}
+
+// NewPedersen derives ring-Pedersen parameters from a Paillier secret key: t is a random square modulo N and s = t^λ
+// for a random λ ∈ Z_𝝋(N).
+func NewPedersen(sk *paillier.SecretKey) (*Pedersen, error) {
+	if sk == nil || sk.N == nil || sk.Totient == nil {
+		return nil, internal.ErrNilArguments
+	}
+	n := sk.N
+	r, err := randomUnit(n)
+	if err != nil {
+		return nil, err
+	}
+	t := new(big.Int).Mul(r, r)
+	t.Mod(t, n)
+	lambda, err := core.Rand(sk.Totient)
+	if err != nil {
+		return nil, err
+	}
+	return &Pedersen{
+		Params: &PedersenParams{N: new(big.Int).Set(n), S: new(big.Int).Exp(t, lambda, n), T: t},
+		lambda: lambda,
+		phi:    new(big.Int).Set(sk.Totient),
+	}, nil
+}
+
+// ParamsProof is Π^prm, a proof that s is in the group generated by t.
+type ParamsProof struct {
+	A, Z []*big.Int
+}
+
+// Prove proves that the parameters are well-formed.
+// [CGGMP21] fig 17
+func (p *Pedersen) Prove(transcript *transcript.Transcript) (*ParamsProof, error) {
+	if p == nil || p.Params == nil || transcript == nil {
+		return nil, internal.ErrNilArguments
+	}
+	params := p.Params
+	alpha := make([]*big.Int, ParamsProofLength)
+	proof := &ParamsProof{A: make([]*big.Int, ParamsProofLength), Z: make([]*big.Int, ParamsProofLength)}
+	for i := range alpha {
+		var err error
+		if alpha[i], err = core.Rand(p.phi); err != nil {
+			return nil, err
+		}
+		// A_i = t^a_i mod N
+		proof.A[i] = new(big.Int).Exp(params.T, alpha[i], params.N)
+	}
+	e := paramsChallenge(transcript, params, proof.A)
+	for i := range alpha {
+		// z_i = a_i + e_i λ mod 𝝋(N)
+		z := new(big.Int).Set(alpha[i])
+		if e[i] {
+			z.Add(z, p.lambda)
+		}
+		proof.Z[i] = z.Mod(z, p.phi)
+	}
+	return proof, nil
+}
+
+// Verify checks Π^prm for the parameters.
+// [CGGMP21] fig 17
+func (proof *ParamsProof) Verify(transcript *transcript.Transcript, params *PedersenParams) error {
+	if proof == nil || transcript == nil {
+		return internal.ErrNilArguments
+	}
+	if err := params.validate(); err != nil {
+		return err
+	}
+	if len(proof.A) != ParamsProofLength || len(proof.Z) != ParamsProofLength {
+		return fmt.Errorf("ring-pedersen proof is not correct length: want=%v", ParamsProofLength)
+	}
+	for i := range proof.A {
+		if err := inUnits(proof.A[i], params.N); err != nil {
+			return fmt.Errorf("invalid commitment %d: %w", i, err)
+		}
+		if proof.Z[i] == nil || proof.Z[i].Sign() < 0 {
+			return fmt.Errorf("invalid response %d", i)
+		}
+	}
+	e := paramsChallenge(transcript, params, proof.A)
+	for i := range proof.A {
+		// t^z_i = A_i s^e_i mod N
+		lhs := new(big.Int).Exp(params.T, proof.Z[i], params.N)
+		rhs := new(big.Int).Set(proof.A[i])
+		if e[i] {
+			rhs.Mul(rhs, params.S).Mod(rhs, params.N)
+		}
+		if lhs.Cmp(rhs) != 0 {
+			return fmt.Errorf("ring-pedersen proof failed at %d", i)
+		}
+	}
+	return nil
+}
+
+// commit returns s^x t^y mod N for signed exponents.
+func (params *PedersenParams) commit(x, y *big.Int) (*big.Int, error) {
+	sx, err := expSigned(params.S, x, params.N)
+	if err != nil {
+		return nil, err
+	}
+	ty, err := expSigned(params.T, y, params.N)
+	if err != nil {
+		return nil, err
+	}
+	return sx.Mul(sx, ty).Mod(sx, params.N), nil
+}
+
+func (params *PedersenParams) validate() error {
+	if params == nil || params.N == nil || params.S == nil || params.T == nil {
+		return internal.ErrNilArguments
+	}
+	if params.N.Sign() <= 0 || params.N.Bit(0) == 0 {
+		return fmt.Errorf("ring-pedersen modulus must be odd and positive")
+	}
+	if err := inUnits(params.S, params.N); err != nil {
+		return fmt.Errorf("invalid s: %w", err)
+	}
+	if err := inUnits(params.T, params.N); err != nil {
+		return fmt.Errorf("invalid t: %w", err)
+	}
+	return nil
+}
+
+func (params *PedersenParams) appendTo(transcript *transcript.Transcript) {
+	transcript.AppendMessage([]byte("ring-pedersen N"), params.N.Bytes())
+	transcript.AppendMessage([]byte("ring-pedersen s"), params.S.Bytes())
+	transcript.AppendMessage([]byte("ring-pedersen t"), params.T.Bytes())
+}
+
+// paramsChallenge derives the challenge bits of Π^prm.
+func paramsChallenge(transcript *transcript.Transcript, params *PedersenParams, a []*big.Int) []bool {
+	transcript.AppendMessage([]byte("protocol"), []byte("ring-pedersen parameters"))
+	params.appendTo(transcript)
+	for _, ai := range a {
+		transcript.AppendMessage([]byte("A"), ai.Bytes())
+	}
+	bz := transcript.ExtractBytes([]byte("challenge"), (ParamsProofLength+7)/8)
+	e := make([]bool, len(a))
+	for i := range e {
+		e[i] = bz[i/8]>>(i%8)&1 == 1
+	}
+	return e
+}
+
+// expSigned returns x^e mod m, inverting x for negative e.
+func expSigned(x, e, m *big.Int) (*big.Int, error) {
+	if e.Sign() >= 0 {
+		return new(big.Int).Exp(x, e, m), nil
+	}
+	inv := new(big.Int).ModInverse(x, m)
+	if inv == nil {
+		return nil, fmt.Errorf("value is not invertible")
+	}
+	return inv.Exp(inv, new(big.Int).Neg(e), m), nil
+}
+
+// randomUnit samples a uniform element of Z_m*.
+func randomUnit(m *big.Int) (*big.Int, error) {
+	for {
+		r, err := core.Rand(m)
+		if err != nil {
+			return nil, err
+		}
+		if inUnits(r, m) == nil {
+			return r, nil
+		}
+	}
+}
+
+// randomSigned samples a uniform integer in [-bound, bound].
+func randomSigned(bound *big.Int) (*big.Int, error) {
+	width := new(big.Int).Lsh(bound, 1)
+	width.Add(width, core.One)
+	r, err := rand.Int(rand.Reader, width)
+	if err != nil {
+		return nil, err
+	}
+	return r.Sub(r, bound), nil
+}
+
+// inUnits checks that 0 < x < m and gcd(x, m) = 1.
+func inUnits(x, m *big.Int) error {
+	if x == nil {
+		return internal.ErrNilArguments
+	}
+	if x.Sign() <= 0 || x.Cmp(m) >= 0 {
+		return fmt.Errorf("value is not in the range (0, N)")
+	}
+	if new(big.Int).GCD(nil, nil, x, m).Cmp(core.One) != 0 {
+		return fmt.Errorf("value is not coprime to N")
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package paillierrange
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/go-sonr/crypto/core"
+	"github.com/go-sonr/crypto/core/transcript"
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/paillier"
+)
+
+const (
+	// ChallengeBits is the size of the Fiat-Shamir challenge of a range proof.
+	ChallengeBits = 256
+	// SlackBits is the slack ε of a range proof: an honest prover with |k| < 2^ℓ convinces the verifier that
+	// |k| < 2^(ℓ+ε). It covers the challenge and 80 bits of statistical hiding and matches ε = 2ℓ of CGGMP21 for
+	// ℓ = 256.
+	SlackBits = 2 * ChallengeBits
+)
+
+// Statement is the public input of a range proof: the ciphertext C of the prover's Paillier key encrypts an integer k
+// with |k| < 2^Bits, where negative k are encrypted as k mod N.
+type Statement struct {
+	PublicKey  *paillier.PublicKey
+	Ciphertext paillier.Ciphertext
+	Bits       uint
+}
+
+// Proof is Π^enc.
+type Proof struct {
+	S, A, C    *big.Int
+	Z1, Z2, Z3 *big.Int
+}
+
+// Prove proves that the ciphertext of the statement encrypts k with nonce rho, i.e. C = (1+N)^k rho^N mod N², and that
+// |k| < 2^(Bits+SlackBits). params are the verifier's ring-Pedersen parameters.
+// [CGGMP21] fig 14
+func Prove(transcript *transcript.Transcript, params *PedersenParams, statement *Statement, k, rho *big.Int) (*Proof, error) {
+	if transcript == nil || k == nil || rho == nil {
+		return nil, internal.ErrNilArguments
+	}
+	if err := params.validate(); err != nil {
+		return nil, err
+	}
+	if err := statement.validate(); err != nil {
+		return nil, err
+	}
+	if uint(k.BitLen()) > statement.Bits {
+		return nil, fmt.Errorf("witness is out of range")
+	}
+	n0 := statement.PublicKey.N
+
+	// α ∈ ±2^(ℓ+ε), μ ∈ ±2^ℓ·N̂, r ∈ Z_N0*, γ ∈ ±2^(ℓ+ε)·N̂
+	bound := new(big.Int).Lsh(core.One, statement.Bits+SlackBits)
+	alpha, err := randomSigned(bound)
+	if err != nil {
+		return nil, err
+	}
+	mu, err := randomSigned(new(big.Int).Lsh(params.N, statement.Bits))
+	if err != nil {
+		return nil, err
+	}
+	r, err := randomUnit(n0)
+	if err != nil {
+		return nil, err
+	}
+	gamma, err := randomSigned(new(big.Int).Mul(bound, params.N))
+	if err != nil {
+		return nil, err
+	}
+
+	proof := &Proof{}
+	// S = s^k t^μ mod N̂, A = (1+N0)^α r^N0 mod N0², C = s^α t^γ mod N̂
+	if proof.S, err = params.commit(k, mu); err != nil {
+		return nil, err
+	}
+	if proof.A, err = encryptSigned(statement.PublicKey, alpha, r); err != nil {
+		return nil, err
+	}
+	if proof.C, err = params.commit(alpha, gamma); err != nil {
+		return nil, err
+	}
+
+	e := rangeChallenge(transcript, params, statement, proof)
+	// z1 = α + ek, z2 = r·rho^e mod N0, z3 = γ + eμ
+	proof.Z1 = new(big.Int).Mul(e, k)
+	proof.Z1.Add(proof.Z1, alpha)
+	proof.Z2 = new(big.Int).Exp(rho, e, n0)
+	proof.Z2.Mul(proof.Z2, r).Mod(proof.Z2, n0)
+	proof.Z3 = new(big.Int).Mul(e, mu)
+	proof.Z3.Add(proof.Z3, gamma)
+	return proof, nil
+}
+
+// Verify checks that the ciphertext of the statement encrypts an integer k with |k| < 2^(Bits+SlackBits).
+// [CGGMP21] fig 14
+func (proof *Proof) Verify(transcript *transcript.Transcript, params *PedersenParams, statement *Statement) error {
+	if proof == nil || transcript == nil ||
+		core.AnyNil(proof.S, proof.A, proof.C, proof.Z1, proof.Z2, proof.Z3) {
+		return internal.ErrNilArguments
+	}
+	if err := params.validate(); err != nil {
+		return err
+	}
+	if err := statement.validate(); err != nil {
+		return err
+	}
+	n0 := statement.PublicKey.N
+	if err := inUnits(proof.S, params.N); err != nil {
+		return fmt.Errorf("invalid S: %w", err)
+	}
+	if err := inUnits(proof.C, params.N); err != nil {
+		return fmt.Errorf("invalid C: %w", err)
+	}
+	if err := inUnits(proof.A, statement.PublicKey.N2); err != nil {
+		return fmt.Errorf("invalid A: %w", err)
+	}
+	if err := inUnits(proof.Z2, n0); err != nil {
+		return fmt.Errorf("invalid z2: %w", err)
+	}
+	// z1 ∈ ±2^(ℓ+ε)
+	if uint(proof.Z1.BitLen()) > statement.Bits+SlackBits {
+		return fmt.Errorf("z1 is out of range")
+	}
+
+	e := rangeChallenge(transcript, params, statement, proof)
+
+	// (1+N0)^z1 z2^N0 = A K^e mod N0²
+	lhs, err := encryptSigned(statement.PublicKey, proof.Z1, proof.Z2)
+	if err != nil {
+		return err
+	}
+	rhs := new(big.Int).Exp(statement.Ciphertext, e, statement.PublicKey.N2)
+	rhs.Mul(rhs, proof.A).Mod(rhs, statement.PublicKey.N2)
+	if lhs.Cmp(rhs) != 0 {
+		return fmt.Errorf("paillier range proof failed")
+	}
+
+	// s^z1 t^z3 = C S^e mod N̂
+	lhs, err = params.commit(proof.Z1, proof.Z3)
+	if err != nil {
+		return err
+	}
+	rhs = new(big.Int).Exp(proof.S, e, params.N)
+	rhs.Mul(rhs, proof.C).Mod(rhs, params.N)
+	if lhs.Cmp(rhs) != 0 {
+		return fmt.Errorf("paillier range proof commitment failed")
+	}
+	return nil
+}
+
+func (statement *Statement) validate() error {
+	if statement == nil || statement.PublicKey == nil || statement.PublicKey.N == nil ||
+		statement.PublicKey.N2 == nil || statement.Ciphertext == nil {
+		return internal.ErrNilArguments
+	}
+	if statement.Bits == 0 {
+		return fmt.Errorf("range must be positive")
+	}
+	// the proven bound must stay far below N/2 so that it describes an integer and not a residue
+	if statement.Bits+SlackBits+1 >= uint(statement.PublicKey.N.BitLen()) {
+		return fmt.Errorf("paillier modulus is too small for a %d bit range", statement.Bits)
+	}
+	if err := inUnits(statement.Ciphertext, statement.PublicKey.N2); err != nil {
+		return fmt.Errorf("invalid ciphertext: %w", err)
+	}
+	return nil
+}
+
+// encryptSigned returns (1+N)^m r^N mod N² for a signed m.
+func encryptSigned(pk *paillier.PublicKey, m, r *big.Int) (*big.Int, error) {
+	// (1+N)^m = 1 + mN mod N²
+	g := new(big.Int).Mul(m, pk.N)
+	g.Add(g, core.One)
+	g.Mod(g, pk.N2)
+	rn := new(big.Int).Exp(r, pk.N, pk.N2)
+	return g.Mul(g, rn).Mod(g, pk.N2), nil
+}
+
+// rangeChallenge derives the challenge e ∈ [0, 2^ChallengeBits) of Π^enc.
+func rangeChallenge(transcript *transcript.Transcript, params *PedersenParams, statement *Statement, proof *Proof) *big.Int {
+	transcript.AppendMessage([]byte("protocol"), []byte("paillier encryption in range"))
+	params.appendTo(transcript)
+	transcript.AppendMessage([]byte("N0"), statement.PublicKey.N.Bytes())
+	transcript.AppendMessage([]byte("K"), (*big.Int)(statement.Ciphertext).Bytes())
+	transcript.AppendMessage([]byte("bits"), big.NewInt(int64(statement.Bits)).Bytes())
+	transcript.AppendMessage([]byte("S"), proof.S.Bytes())
+	transcript.AppendMessage([]byte("A"), proof.A.Bytes())
+	transcript.AppendMessage([]byte("C"), proof.C.Bytes())
+	return new(big.Int).SetBytes(transcript.ExtractBytes([]byte("challenge"), ChallengeBits/8))
+}
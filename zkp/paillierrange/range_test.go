@@ -0,0 +1,124 @@
+package paillierrange
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/transcript"
+	"github.com/go-sonr/crypto/internal"
+	"github.com/go-sonr/crypto/paillier"
+)
+
+var testPrimes = []*big.Int{
+	internal.B10("186141419611617071752010179586510154515933389116254425631491755419216243670159714804545944298892950871169229878325987039840135057969555324774918895952900547869933648175107076399993833724447909579697857041081987997463765989497319509683575289675966710007879762972723174353568113668226442698275449371212397561567"),
+	internal.B10("94210786053667323206442523040419729883258172350738703980637961803118626748668924192069593010365236618255120977661397310932923345291377692570649198560048403943687994859423283474169530971418656709749020402756179383990602363122039939937953514870699284906666247063852187255623958659551404494107714695311474384687"),
+	internal.B10("130291226847076770981564372061529572170236135412763130013877155698259035960569046218348763182598589633420963942796327547969527085797839549642610021986391589746295634536750785366034581957858065740296991986002552598751827526181747791647357767502200771965093659353354985289411489453223546075843993686648576029043"),
+	internal.B10("172938910323633442195852028319756134734590277522945546987913328782597284762767185925315797321999389252040294991952361905020940252121762387957669654615602135429944435719699091344247805645764550860505536884031064967454028383404046221898300153428182409080298694828920944094158777327533157774919783417586902830043"),
+}
+
+func newTestKeys(t *testing.T) (*paillier.SecretKey, *Pedersen) {
+	sk, err := paillier.NewSecretKey(testPrimes[0], testPrimes[1])
+	require.NoError(t, err)
+	verifierKey, err := paillier.NewSecretKey(testPrimes[2], testPrimes[3])
+	require.NoError(t, err)
+	ped, err := NewPedersen(verifierKey)
+	require.NoError(t, err)
+	return sk, ped
+}
+
+func TestParamsProof(t *testing.T) {
+	_, ped := newTestKeys(t)
+	proof, err := ped.Prove(transcript.New("test"))
+	require.NoError(t, err)
+	require.NoError(t, proof.Verify(transcript.New("test"), ped.Params))
+	require.Error(t, proof.Verify(transcript.New("other"), ped.Params))
+
+	// s outside the group generated by t
+	bad := *ped.Params
+	bad.S = new(big.Int).Add(bad.S, big.NewInt(1))
+	require.Error(t, proof.Verify(transcript.New("test"), &bad))
+
+	proof.Z = proof.Z[1:]
+	require.Error(t, proof.Verify(transcript.New("test"), ped.Params))
+}
+
+func TestRangeProof(t *testing.T) {
+	sk, ped := newTestKeys(t)
+	pk := &sk.PublicKey
+	for _, k := range []*big.Int{
+		big.NewInt(0),
+		big.NewInt(42),
+		big.NewInt(-42),
+		new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1)),
+	} {
+		c, rho, err := pk.Encrypt(new(big.Int).Mod(k, pk.N))
+		require.NoError(t, err)
+		statement := &Statement{PublicKey: pk, Ciphertext: c, Bits: 256}
+		proof, err := Prove(transcript.New("test"), ped.Params, statement, k, rho)
+		require.NoError(t, err)
+		require.NoError(t, proof.Verify(transcript.New("test"), ped.Params, statement))
+	}
+}
+
+func TestRangeProofFails(t *testing.T) {
+	sk, ped := newTestKeys(t)
+	pk := &sk.PublicKey
+	k := big.NewInt(1000)
+	c, rho, err := pk.Encrypt(k)
+	require.NoError(t, err)
+	statement := &Statement{PublicKey: pk, Ciphertext: c, Bits: 256}
+	proof, err := Prove(transcript.New("test"), ped.Params, statement, k, rho)
+	require.NoError(t, err)
+
+	require.Error(t, proof.Verify(transcript.New("other"), ped.Params, statement))
+
+	tampered := *proof
+	tampered.Z1 = new(big.Int).Add(proof.Z1, big.NewInt(1))
+	require.Error(t, tampered.Verify(transcript.New("test"), ped.Params, statement))
+
+	tampered = *proof
+	tampered.Z1 = new(big.Int).Lsh(big.NewInt(1), statement.Bits+SlackBits)
+	require.Error(t, tampered.Verify(transcript.New("test"), ped.Params, statement))
+
+	// the proof is for a different ciphertext
+	other, _, err := pk.Encrypt(k)
+	require.NoError(t, err)
+	require.Error(t, proof.Verify(transcript.New("test"), ped.Params, &Statement{PublicKey: pk, Ciphertext: other, Bits: 256}))
+
+	require.Error(t, (&Proof{}).Verify(transcript.New("test"), ped.Params, statement))
+}
+
+func TestRangeProofWitnessOutOfRange(t *testing.T) {
+	sk, ped := newTestKeys(t)
+	pk := &sk.PublicKey
+	k := new(big.Int).Lsh(big.NewInt(1), 256)
+	c, rho, err := pk.Encrypt(k)
+	require.NoError(t, err)
+	statement := &Statement{PublicKey: pk, Ciphertext: c, Bits: 256}
+	_, err = Prove(transcript.New("test"), ped.Params, statement, k, rho)
+	require.Error(t, err)
+
+	// a dishonest prover that skips the check proves a 1001 bit plaintext
+	k = new(big.Int).Lsh(big.NewInt(1), 1000)
+	c, rho, err = pk.Encrypt(k)
+	require.NoError(t, err)
+	statement = &Statement{PublicKey: pk, Ciphertext: c, Bits: 1001}
+	proof, err := Prove(transcript.New("test"), ped.Params, statement, k, rho)
+	require.NoError(t, err)
+	statement.Bits = 256
+	require.Error(t, proof.Verify(transcript.New("test"), ped.Params, statement))
+}
+
+func TestRangeProofModulusTooSmall(t *testing.T) {
+	sk, ped := newTestKeys(t)
+	pk := &sk.PublicKey
+	c, rho, err := pk.Encrypt(big.NewInt(1))
+	require.NoError(t, err)
+	statement := &Statement{PublicKey: pk, Ciphertext: c, Bits: uint(pk.N.BitLen()) - SlackBits}
+	_, err = Prove(transcript.New("test"), ped.Params, statement, big.NewInt(1), rho)
+	require.Error(t, err)
+	_, err = Prove(transcript.New("test"), nil, statement, big.NewInt(1), rho)
+	require.ErrorIs(t, err, internal.ErrNilArguments)
+}
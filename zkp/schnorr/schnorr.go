@@ -6,17 +6,21 @@
 
 // Package schnorr implements a Schnorr proof, as described and used in Doerner, et al. https://eprint.iacr.org/2018/499.pdf
 // see Functionalities 6. it also implements a "committed" version, as described in Functionality 7.
+// Proofs are produced and checked by the DLog statement of the zkp/sigma engine.
 package schnorr
 
 import (
-	"crypto/rand"
+	"bytes"
 	"crypto/subtle"
+	"encoding/gob"
 	"fmt"
 
 	"github.com/pkg/errors"
 	"golang.org/x/crypto/sha3"
 
 	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
+	"github.com/go-sonr/crypto/zkp/sigma"
 )
 
 type Commitment = []byte
@@ -48,64 +52,81 @@ func NewProver(curve *curves.Curve, basepoint curves.Point, uniqueSessionId []by
 	}
 }
 
-// Prove generates and returns a Schnorr proof, given the scalar witness `x`.
-// in the process, it will actually also construct the statement (just one curve mult in this case)
-func (p *Prover) Prove(x curves.Scalar) (*Proof, error) {
-	// assumes that params, and pub are already populated. populates the fields c and s...
-	var err error
-	result := &Proof{}
-	result.Statement = p.basePoint.Mul(x)
-	k := p.curve.Scalar.Random(rand.Reader)
-	random := p.basePoint.Mul(k)
-	hash := sha3.New256()
-	if _, err = hash.Write(p.uniqueSessionId); err != nil {
-		return nil, errors.Wrap(err, "writing salt to hash in schnorr prove")
+type proverState struct {
+	Curve           string
+	BasePoint       []byte
+	UniqueSessionId []byte
+}
+
+// MarshalBinary serializes the prover, so that a protocol using it can be resumed
+func (p *Prover) MarshalBinary() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	err := gob.NewEncoder(buf).Encode(&proverState{
+		Curve:           p.curve.Name,
+		BasePoint:       p.basePoint.ToAffineCompressed(),
+		UniqueSessionId: p.uniqueSessionId,
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
 	}
-	if _, err = hash.Write(p.basePoint.ToAffineCompressed()); err != nil {
-		return nil, errors.Wrap(err, "writing basePoint to hash in schnorr prove")
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary restores a prover serialized by MarshalBinary
+func (p *Prover) UnmarshalBinary(data []byte) error {
+	state := new(proverState)
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(state); err != nil {
+		return errors.Wrap(err, "decoding prover")
 	}
-	if _, err = hash.Write(result.Statement.ToAffineCompressed()); err != nil {
-		return nil, errors.Wrap(err, "writing statement to hash in schnorr prove")
+	curve := curves.GetCurveByName(state.Curve)
+	if curve == nil {
+		return fmt.Errorf("unknown curve %s", state.Curve)
 	}
-	if _, err = hash.Write(random.ToAffineCompressed()); err != nil {
-		return nil, errors.Wrap(err, "writing point K to hash in schnorr prove")
+	basePoint, err := curve.Point.FromAffineCompressed(state.BasePoint)
+	if err != nil {
+		return errors.Wrap(err, "decoding prover base point")
 	}
-	result.C, err = p.curve.Scalar.SetBytes(hash.Sum(nil))
+	*p = Prover{curve: curve, basePoint: basePoint, uniqueSessionId: state.UniqueSessionId}
+	return nil
+}
+
+// Prove generates and returns a Schnorr proof, given the scalar witness `x`.
+// in the process, it will actually also construct the statement (just one curve mult in this case)
+func (p *Prover) Prove(x curves.Scalar) (*Proof, error) {
+	statement := p.basePoint.Mul(x)
+	proof, err := sigma.Prove(sessionTranscript(p.uniqueSessionId), sigma.DLog(p.basePoint, statement), sigma.Secrets(x))
 	if err != nil {
-		return nil, errors.Wrap(err, "writing point K to hash in schnorr prove")
+		return nil, errors.Wrap(err, "schnorr prove")
 	}
-	result.S = result.C.Mul(x).Add(k)
-	return result, nil
+	return &Proof{C: proof.Challenge, S: proof.Responses[0], Statement: statement}, nil
 }
 
 // Verify verifies the `proof`, given the prover parameters `scalar` and `curve`.
 // As for the prover, we allow `basePoint == nil`, in this case, it's auto-assigned to be the group's default generator.
 func Verify(proof *Proof, curve *curves.Curve, basepoint curves.Point, uniqueSessionId []byte) error {
+	if proof == nil || proof.C == nil || proof.S == nil || proof.Statement == nil {
+		return fmt.Errorf("schnorr verification failed")
+	}
 	if basepoint == nil {
 		basepoint = curve.NewGeneratorPoint()
 	}
-	gs := basepoint.Mul(proof.S)
-	xc := proof.Statement.Mul(proof.C.Neg())
-	random := gs.Add(xc)
-	hash := sha3.New256()
-	if _, err := hash.Write(uniqueSessionId); err != nil {
-		return errors.Wrap(err, "writing salt to hash in schnorr verify")
-	}
-	if _, err := hash.Write(basepoint.ToAffineCompressed()); err != nil {
-		return errors.Wrap(err, "writing basePoint to hash in schnorr verify")
-	}
-	if _, err := hash.Write(proof.Statement.ToAffineCompressed()); err != nil {
-		return errors.Wrap(err, "writing statement to hash in schnorr verify")
-	}
-	if _, err := hash.Write(random.ToAffineCompressed()); err != nil {
-		return errors.Wrap(err, "writing point K to hash in schnorr verify")
-	}
-	if subtle.ConstantTimeCompare(proof.C.Bytes(), hash.Sum(nil)) != 1 {
-		return fmt.Errorf("schnorr verification failed")
+	err := sigma.Verify(sessionTranscript(uniqueSessionId), sigma.DLog(basepoint, proof.Statement), &sigma.Proof{
+		Challenge: proof.C,
+		Responses: []curves.Scalar{proof.S},
+	})
+	if err != nil {
+		return errors.Wrap(err, "schnorr verification failed")
 	}
 	return nil
 }
 
+// sessionTranscript binds a proof to the session id, the statement and commitment are appended by the sigma engine
+func sessionTranscript(uniqueSessionId []byte) *transcript.Transcript {
+	t := transcript.New("go-sonr schnorr proof")
+	t.AppendMessage([]byte("session id"), uniqueSessionId)
+	return t
+}
+
 // ProveCommit generates _and_ commits to a schnorr proof which is later revealed; see Functionality 7.
 // returns the Proof and Commitment.
 func (p *Prover) ProveCommit(x curves.Scalar) (*Proof, Commitment, error) {
@@ -0,0 +1,337 @@
+// Package sigma composes Sigma protocols for statements about discrete logs
+// and makes them non-interactive with the Fiat-Shamir transform.
+//
+// A statement is declared rather than implemented: Linear relations of the
+// form Y_j = Σ x_i·B_{j,i} cover knowledge of a discrete log (DLog), equality
+// of discrete logs across bases (DLEQ) and knowledge of a representation
+// (Representation), and And and Or combine statements into arbitrary trees.
+// AND composition answers every statement with the same challenge; OR
+// composition follows Cramer, Damgård and Schoenmakers,
+// https://link.springer.com/chapter/10.1007/3-540-48658-5_19, simulating
+// every branch but the one the prover has a witness for and splitting the
+// challenge between the branches.
+//
+// The challenge is drawn from a core/transcript transcript after the statement and all
+// commitments are appended, so callers bind proofs to their session by
+// appending to the transcript before proving and verifying. Proofs are in
+// challenge-response form: the verifier recomputes the commitments from the
+// responses and checks that they hash to the challenge.
+package sigma
+
+import (
+	crand "crypto/rand"
+	"fmt"
+	"io"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
+	"github.com/go-sonr/crypto/internal"
+)
+
+const domain = "go-sonr sigma protocol v1"
+
+// Witness holds the secrets of a statement in the shape of the statement
+type Witness struct {
+	secrets  []curves.Scalar
+	children []*Witness
+	branch   int
+}
+
+// Secrets is the witness of a linear statement
+func Secrets(secrets ...curves.Scalar) *Witness {
+	return &Witness{secrets: secrets}
+}
+
+// AndWitness is the witness of an AND statement, one witness per statement
+func AndWitness(witnesses ...*Witness) *Witness {
+	return &Witness{children: witnesses}
+}
+
+// OrWitness is the witness of an OR statement, the witness of its statement with index branch
+func OrWitness(branch int, witness *Witness) *Witness {
+	return &Witness{children: []*Witness{witness}, branch: branch}
+}
+
+// Proof is a non-interactive proof of a statement. Challenges holds the
+// challenges of all but the last branch of every OR statement, and Responses
+// the responses for the secrets of every linear statement, both in depth-first
+// order of the statement.
+type Proof struct {
+	Challenge  curves.Scalar
+	Challenges []curves.Scalar
+	Responses  []curves.Scalar
+}
+
+// Prove proves statement with witness, drawing randomness from crypto/rand
+func Prove(transcript *transcript.Transcript, statement *Statement, witness *Witness) (*Proof, error) {
+	return ProveFromReader(transcript, statement, witness, crand.Reader)
+}
+
+// ProveFromReader proves statement with witness, drawing randomness from reader
+func ProveFromReader(transcript *transcript.Transcript, statement *Statement, witness *Witness, reader io.Reader) (*Proof, error) {
+	if transcript == nil || reader == nil {
+		return nil, internal.ErrNilArguments
+	}
+	zero, err := statement.scalar()
+	if err != nil {
+		return nil, err
+	}
+	p := &prover{zero: zero, reader: reader}
+	root, err := p.commit(statement, witness)
+	if err != nil {
+		return nil, err
+	}
+	c, err := challenge(transcript, zero, statement, root.allCommitments(nil))
+	if err != nil {
+		return nil, err
+	}
+	root.respond(c)
+	proof := &Proof{Challenge: c}
+	root.flatten(proof)
+	return proof, nil
+}
+
+// Verify checks that proof proves statement for the given transcript
+func Verify(transcript *transcript.Transcript, statement *Statement, proof *Proof) error {
+	if transcript == nil || proof == nil || proof.Challenge == nil {
+		return internal.ErrNilArguments
+	}
+	zero, err := statement.scalar()
+	if err != nil {
+		return err
+	}
+	v := &verifier{challenges: proof.Challenges, responses: proof.Responses}
+	commitments, err := v.commitments(statement, proof.Challenge, nil)
+	if err != nil {
+		return err
+	}
+	if len(v.challenges) != 0 || len(v.responses) != 0 {
+		return fmt.Errorf("proof does not match the statement")
+	}
+	c, err := challenge(transcript, zero, statement, commitments)
+	if err != nil {
+		return err
+	}
+	if c.Cmp(proof.Challenge) != 0 {
+		return fmt.Errorf("invalid proof")
+	}
+	return nil
+}
+
+func challenge(transcript *transcript.Transcript, zero curves.Scalar, statement *Statement, commitments []curves.Point) (curves.Scalar, error) {
+	transcript.AppendMessage([]byte("protocol"), []byte(domain))
+	transcript.AppendMessage([]byte("statement"), statement.bytes())
+	for _, a := range commitments {
+		transcript.AppendMessage([]byte("commitment"), appendPoint(nil, a))
+	}
+	return transcript.ChallengeScalar([]byte("challenge"), zero)
+}
+
+type prover struct {
+	zero   curves.Scalar
+	reader io.Reader
+}
+
+// node is the prover's state for one statement of the tree
+type node struct {
+	statement   *Statement
+	secrets     []curves.Scalar
+	nonces      []curves.Scalar
+	branch      int
+	simulated   bool
+	challenge   curves.Scalar
+	responses   []curves.Scalar
+	commitments []curves.Point
+	children    []*node
+}
+
+// commit draws the nonces of the statements the prover has a witness for and simulates the others
+func (p *prover) commit(s *Statement, w *Witness) (*node, error) {
+	if w == nil {
+		return nil, internal.ErrNilArguments
+	}
+	n := &node{statement: s}
+	switch s.kind {
+	case linear:
+		if len(w.secrets) != s.secrets {
+			return nil, fmt.Errorf("expected %d secrets, got %d", s.secrets, len(w.secrets))
+		}
+		for _, x := range w.secrets {
+			if x == nil {
+				return nil, internal.ErrNilArguments
+			}
+		}
+		for _, eq := range s.equations {
+			if !combine(eq.Terms, w.secrets).Equal(eq.Public) {
+				return nil, fmt.Errorf("witness does not satisfy the statement")
+			}
+		}
+		n.secrets = w.secrets
+		n.nonces = make([]curves.Scalar, s.secrets)
+		for i := range n.nonces {
+			n.nonces[i] = p.zero.Random(p.reader)
+		}
+		n.commitments = make([]curves.Point, len(s.equations))
+		for j, eq := range s.equations {
+			n.commitments[j] = combine(eq.Terms, n.nonces)
+		}
+	case and:
+		if len(w.children) != len(s.children) {
+			return nil, fmt.Errorf("expected %d witnesses, got %d", len(s.children), len(w.children))
+		}
+		for i, child := range s.children {
+			c, err := p.commit(child, w.children[i])
+			if err != nil {
+				return nil, err
+			}
+			n.children = append(n.children, c)
+		}
+	case or:
+		if len(w.children) != 1 || w.branch < 0 || w.branch >= len(s.children) {
+			return nil, fmt.Errorf("invalid witness for an OR statement")
+		}
+		n.branch = w.branch
+		for i, child := range s.children {
+			if i == w.branch {
+				c, err := p.commit(child, w.children[0])
+				if err != nil {
+					return nil, err
+				}
+				n.children = append(n.children, c)
+			} else {
+				n.children = append(n.children, p.simulate(child, p.zero.Random(p.reader)))
+			}
+		}
+	}
+	return n, nil
+}
+
+// simulate produces an accepting transcript of s for the challenge c without a witness
+func (p *prover) simulate(s *Statement, c curves.Scalar) *node {
+	n := &node{statement: s, simulated: true, challenge: c}
+	switch s.kind {
+	case linear:
+		n.responses = make([]curves.Scalar, s.secrets)
+		for i := range n.responses {
+			n.responses[i] = p.zero.Random(p.reader)
+		}
+		n.commitments = s.commitments(n.responses, c)
+	case and:
+		for _, child := range s.children {
+			n.children = append(n.children, p.simulate(child, c))
+		}
+	case or:
+		last := c
+		for i, child := range s.children {
+			ci := last
+			if i < len(s.children)-1 {
+				ci = p.zero.Random(p.reader)
+				last = last.Sub(ci)
+			}
+			n.children = append(n.children, p.simulate(child, ci))
+		}
+	}
+	return n
+}
+
+// respond answers the challenge c, s_i = k_i + c·x_i
+func (n *node) respond(c curves.Scalar) {
+	if n.simulated {
+		return
+	}
+	n.challenge = c
+	switch n.statement.kind {
+	case linear:
+		n.responses = make([]curves.Scalar, len(n.nonces))
+		for i, k := range n.nonces {
+			n.responses[i] = k.Add(c.Mul(n.secrets[i]))
+		}
+	case and:
+		for _, child := range n.children {
+			child.respond(c)
+		}
+	case or:
+		// the real branch gets what is left after the simulated branches
+		rest := c
+		for i, child := range n.children {
+			if i != n.branch {
+				rest = rest.Sub(child.challenge)
+			}
+		}
+		n.children[n.branch].respond(rest)
+	}
+}
+
+func (n *node) allCommitments(out []curves.Point) []curves.Point {
+	out = append(out, n.commitments...)
+	for _, child := range n.children {
+		out = child.allCommitments(out)
+	}
+	return out
+}
+
+func (n *node) flatten(proof *Proof) {
+	proof.Responses = append(proof.Responses, n.responses...)
+	if n.statement.kind == or {
+		for _, child := range n.children[:len(n.children)-1] {
+			proof.Challenges = append(proof.Challenges, child.challenge)
+		}
+	}
+	for _, child := range n.children {
+		child.flatten(proof)
+	}
+}
+
+type verifier struct {
+	challenges []curves.Scalar
+	responses  []curves.Scalar
+}
+
+// commitments recomputes the commitments of s for the challenge c from the
+// responses and branch challenges of the proof, in the order of the prover
+func (v *verifier) commitments(s *Statement, c curves.Scalar, out []curves.Point) ([]curves.Point, error) {
+	switch s.kind {
+	case linear:
+		if len(v.responses) < s.secrets {
+			return nil, fmt.Errorf("proof does not match the statement")
+		}
+		responses := v.responses[:s.secrets]
+		v.responses = v.responses[s.secrets:]
+		for _, r := range responses {
+			if r == nil {
+				return nil, internal.ErrNilArguments
+			}
+		}
+		return append(out, s.commitments(responses, c)...), nil
+	case and:
+		var err error
+		for _, child := range s.children {
+			if out, err = v.commitments(child, c, out); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	default:
+		count := len(s.children) - 1
+		if len(v.challenges) < count {
+			return nil, fmt.Errorf("proof does not match the statement")
+		}
+		challenges := v.challenges[:count]
+		v.challenges = v.challenges[count:]
+		last := c
+		for _, ci := range challenges {
+			if ci == nil {
+				return nil, internal.ErrNilArguments
+			}
+			last = last.Sub(ci)
+		}
+		challenges = append(append([]curves.Scalar{}, challenges...), last)
+		var err error
+		for i, child := range s.children {
+			if out, err = v.commitments(child, challenges[i], out); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	}
+}
@@ -0,0 +1,140 @@
+package sigma
+
+import (
+	crand "crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/core/transcript"
+)
+
+func TestDLog(t *testing.T) {
+	for _, curve := range []*curves.Curve{curves.K256(), curves.P256(), curves.ED25519(), curves.PALLAS(), curves.BLS12381G1()} {
+		x := curve.Scalar.Random(crand.Reader)
+		g := curve.NewGeneratorPoint()
+		statement := DLog(g, g.Mul(x))
+		proof, err := Prove(transcript.New("test"), statement, Secrets(x))
+		require.NoError(t, err, curve.Name)
+		require.NoError(t, Verify(transcript.New("test"), statement, proof), curve.Name)
+		require.Error(t, Verify(transcript.New("other"), statement, proof), curve.Name)
+		require.Error(t, Verify(transcript.New("test"), DLog(g, g.Mul(x.Double())), proof), curve.Name)
+
+		_, err = Prove(transcript.New("test"), statement, Secrets(x.Double()))
+		require.Error(t, err, curve.Name)
+	}
+}
+
+func TestDLEQ(t *testing.T) {
+	curve := curves.K256()
+	x := curve.Scalar.Random(crand.Reader)
+	g := curve.NewGeneratorPoint()
+	h := curve.Point.Hash([]byte("h"))
+	statement := DLEQ(g, g.Mul(x), h, h.Mul(x))
+	proof, err := Prove(transcript.New("test"), statement, Secrets(x))
+	require.NoError(t, err)
+	require.NoError(t, Verify(transcript.New("test"), statement, proof))
+
+	// Different logs cannot be proven equal
+	y := curve.Scalar.Random(crand.Reader)
+	_, err = Prove(transcript.New("test"), DLEQ(g, g.Mul(x), h, h.Mul(y)), Secrets(x))
+	require.Error(t, err)
+	require.Error(t, Verify(transcript.New("test"), DLEQ(g, g.Mul(x), h, h.Mul(y)), proof))
+}
+
+func TestDLEQAcrossGroups(t *testing.T) {
+	g1 := curves.BLS12381G1().NewGeneratorPoint()
+	g2 := curves.BLS12381G2().NewGeneratorPoint()
+	x := curves.BLS12381G1().Scalar.Random(crand.Reader)
+	statement := DLEQ(g1, g1.Mul(x), g2, g2.Mul(x))
+	proof, err := Prove(transcript.New("test"), statement, Secrets(x))
+	require.NoError(t, err)
+	require.NoError(t, Verify(transcript.New("test"), statement, proof))
+
+	// Groups of different order cannot be mixed
+	k := curves.K256().NewGeneratorPoint()
+	_, err = Prove(transcript.New("test"), DLEQ(g1, g1.Mul(x), k, k), Secrets(x))
+	require.Error(t, err)
+}
+
+func TestRepresentation(t *testing.T) {
+	curve := curves.P256()
+	g := curve.NewGeneratorPoint()
+	h := curve.Point.Hash([]byte("h"))
+	m := curve.Scalar.Random(crand.Reader)
+	r := curve.Scalar.Random(crand.Reader)
+	statement := Representation(g.Mul(m).Add(h.Mul(r)), g, h)
+	proof, err := Prove(transcript.New("test"), statement, Secrets(m, r))
+	require.NoError(t, err)
+	require.Len(t, proof.Responses, 2)
+	require.NoError(t, Verify(transcript.New("test"), statement, proof))
+
+	// A truncated proof does not verify
+	require.Error(t, Verify(transcript.New("test"), statement, &Proof{Challenge: proof.Challenge, Responses: proof.Responses[:1]}))
+	_, err = Prove(transcript.New("test"), statement, Secrets(m))
+	require.Error(t, err)
+}
+
+func TestComposition(t *testing.T) {
+	curve := curves.K256()
+	g := curve.NewGeneratorPoint()
+	h := curve.Point.Hash([]byte("h"))
+	x := curve.Scalar.Random(crand.Reader)
+	y := curve.Scalar.Random(crand.Reader)
+	X, Y := g.Mul(x), g.Mul(y)
+	unknown := curve.Point.Hash([]byte("unknown"))
+
+	// (X = x·G AND Y = y·G) OR (U = u·G AND H = u·G) with the first branch known
+	statement := Or(And(DLog(g, X), DLog(g, Y)), DLEQ(g, unknown, h, unknown))
+	witness := OrWitness(0, AndWitness(Secrets(x), Secrets(y)))
+	proof, err := Prove(transcript.New("test"), statement, witness)
+	require.NoError(t, err)
+	require.Len(t, proof.Challenges, 1)
+	require.Len(t, proof.Responses, 3)
+	require.NoError(t, Verify(transcript.New("test"), statement, proof))
+
+	// The last branch of an OR
+	statement = Or(DLog(g, unknown), DLog(h, unknown), Representation(X.Add(h.Mul(y)), g, h))
+	proof, err = Prove(transcript.New("test"), statement, OrWitness(2, Secrets(x, y)))
+	require.NoError(t, err)
+	require.NoError(t, Verify(transcript.New("test"), statement, proof))
+
+	// Branch challenges must add up to the challenge
+	proof.Challenges[0] = proof.Challenges[0].Add(curve.Scalar.One())
+	require.Error(t, Verify(transcript.New("test"), statement, proof))
+
+	// Proofs of no branch fail
+	_, err = Prove(transcript.New("test"), statement, OrWitness(0, Secrets(x)))
+	require.Error(t, err)
+	_, err = Prove(transcript.New("test"), statement, OrWitness(3, Secrets(x)))
+	require.Error(t, err)
+
+	// OR nested within AND
+	statement = And(DLog(g, X), Or(DLog(g, unknown), DLog(h, h.Mul(y))))
+	proof, err = Prove(transcript.New("test"), statement, AndWitness(Secrets(x), OrWitness(1, Secrets(y))))
+	require.NoError(t, err)
+	require.NoError(t, Verify(transcript.New("test"), statement, proof))
+	require.Error(t, Verify(transcript.New("test"), And(DLog(g, Y), Or(DLog(g, unknown), DLog(h, h.Mul(y)))), proof))
+}
+
+func TestInvalidStatements(t *testing.T) {
+	curve := curves.K256()
+	g := curve.NewGeneratorPoint()
+	x := curve.Scalar.One()
+	for _, statement := range []*Statement{
+		nil,
+		Linear(0),
+		Linear(1),
+		Linear(2, Equation{Public: g, Terms: []Term{{Secret: 0, Base: g}}}),
+		Linear(1, Equation{Public: g, Terms: []Term{{Secret: 1, Base: g}}}),
+		Linear(1, Equation{Public: g}),
+		DLog(nil, g),
+		And(),
+		Or(),
+	} {
+		_, err := Prove(transcript.New("test"), statement, Secrets(x))
+		require.Error(t, err)
+		require.Error(t, Verify(transcript.New("test"), statement, &Proof{Challenge: x, Responses: []curves.Scalar{x}}))
+	}
+}
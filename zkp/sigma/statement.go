@@ -0,0 +1,204 @@
+package sigma
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/go-sonr/crypto/core/curves"
+	"github.com/go-sonr/crypto/internal"
+)
+
+type kind byte
+
+const (
+	linear kind = 'L'
+	and    kind = 'A'
+	or     kind = 'O'
+)
+
+// Term is the product of the secret with index Secret and the point Base
+type Term struct {
+	Secret int
+	Base   curves.Point
+}
+
+// Equation states that Public is the sum of its terms
+type Equation struct {
+	Public curves.Point
+	Terms  []Term
+}
+
+// Statement is a relation between secret scalars and public points. It is
+// either a linear relation or the AND or OR composition of other statements.
+type Statement struct {
+	kind      kind
+	secrets   int
+	equations []Equation
+	children  []*Statement
+}
+
+// Linear is the statement that the prover knows secrets x_0..x_{secrets-1} such
+// that every equation holds. Secrets shared by several equations are proven to
+// be equal.
+func Linear(secrets int, equations ...Equation) *Statement {
+	return &Statement{kind: linear, secrets: secrets, equations: equations}
+}
+
+// DLog is the statement that the prover knows x with public = x·base
+func DLog(base, public curves.Point) *Statement {
+	return Linear(1, Equation{Public: public, Terms: []Term{{Secret: 0, Base: base}}})
+}
+
+// DLEQ is the statement that the prover knows x with x1 = x·g1 and x2 = x·g2.
+// The two bases may lie in different groups as long as the groups have the
+// same order, like G1 and G2 of BLS12-381.
+func DLEQ(g1, x1, g2, x2 curves.Point) *Statement {
+	return Linear(1,
+		Equation{Public: x1, Terms: []Term{{Secret: 0, Base: g1}}},
+		Equation{Public: x2, Terms: []Term{{Secret: 0, Base: g2}}},
+	)
+}
+
+// Representation is the statement that the prover knows x_i with public = Σ x_i·bases[i],
+// for example the opening of a Pedersen commitment
+func Representation(public curves.Point, bases ...curves.Point) *Statement {
+	terms := make([]Term, len(bases))
+	for i, base := range bases {
+		terms[i] = Term{Secret: i, Base: base}
+	}
+	return Linear(len(bases), Equation{Public: public, Terms: terms})
+}
+
+// And is the statement that the prover knows witnesses of all statements
+func And(statements ...*Statement) *Statement {
+	return &Statement{kind: and, children: statements}
+}
+
+// Or is the statement that the prover knows a witness of at least one of the
+// statements, without revealing which
+func Or(statements ...*Statement) *Statement {
+	return &Statement{kind: or, children: statements}
+}
+
+// scalar returns the zero scalar of the statement's group after checking that
+// the statement is well formed and all its points are in groups of the same order
+func (s *Statement) scalar() (curves.Scalar, error) {
+	var zero curves.Scalar
+	var order *big.Int
+	err := s.walk(func(p curves.Point) error {
+		if p == nil {
+			return internal.ErrNilArguments
+		}
+		if !p.IsOnCurve() {
+			return internal.ErrNotOnCurve
+		}
+		o := p.Scalar().One().Neg().BigInt()
+		if order == nil {
+			zero, order = p.Scalar(), o
+		} else if order.Cmp(o) != 0 {
+			return fmt.Errorf("points of a statement must be in groups of the same order")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return zero, nil
+}
+
+// walk checks the shape of the statement and calls f on every point
+func (s *Statement) walk(f func(p curves.Point) error) error {
+	if s == nil {
+		return internal.ErrNilArguments
+	}
+	switch s.kind {
+	case linear:
+		if s.secrets < 1 || len(s.equations) == 0 {
+			return fmt.Errorf("linear statement needs at least one secret and one equation")
+		}
+		used := make([]bool, s.secrets)
+		for _, eq := range s.equations {
+			if len(eq.Terms) == 0 {
+				return fmt.Errorf("equation has no terms")
+			}
+			if err := f(eq.Public); err != nil {
+				return err
+			}
+			for _, t := range eq.Terms {
+				if t.Secret < 0 || t.Secret >= s.secrets {
+					return fmt.Errorf("invalid secret index %d", t.Secret)
+				}
+				used[t.Secret] = true
+				if err := f(t.Base); err != nil {
+					return err
+				}
+			}
+		}
+		for i, u := range used {
+			if !u {
+				return fmt.Errorf("secret %d is not used", i)
+			}
+		}
+	case and, or:
+		if len(s.children) == 0 {
+			return fmt.Errorf("composed statement needs at least one statement")
+		}
+		for _, child := range s.children {
+			if err := child.walk(f); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("invalid statement")
+	}
+	return nil
+}
+
+// bytes encodes the statement for the transcript
+func (s *Statement) bytes() []byte {
+	out := []byte{byte(s.kind)}
+	switch s.kind {
+	case linear:
+		out = binary.BigEndian.AppendUint32(out, uint32(s.secrets))
+		out = binary.BigEndian.AppendUint32(out, uint32(len(s.equations)))
+		for _, eq := range s.equations {
+			out = appendPoint(out, eq.Public)
+			out = binary.BigEndian.AppendUint32(out, uint32(len(eq.Terms)))
+			for _, t := range eq.Terms {
+				out = binary.BigEndian.AppendUint32(out, uint32(t.Secret))
+				out = appendPoint(out, t.Base)
+			}
+		}
+	default:
+		out = binary.BigEndian.AppendUint32(out, uint32(len(s.children)))
+		for _, child := range s.children {
+			out = append(out, child.bytes()...)
+		}
+	}
+	return out
+}
+
+// commitments returns A_j = Σ s_i·B_{j,i} - c·Y_j, which for s_i = k_i + c·x_i
+// is the commitment Σ k_i·B_{j,i} of the nonces
+func (s *Statement) commitments(responses []curves.Scalar, c curves.Scalar) []curves.Point {
+	out := make([]curves.Point, len(s.equations))
+	for j, eq := range s.equations {
+		out[j] = combine(eq.Terms, responses).Sub(eq.Public.Mul(c))
+	}
+	return out
+}
+
+// combine returns Σ values[t.Secret]·t.Base
+func combine(terms []Term, values []curves.Scalar) curves.Point {
+	sum := terms[0].Base.Mul(values[terms[0].Secret])
+	for _, t := range terms[1:] {
+		sum = sum.Add(t.Base.Mul(values[t.Secret]))
+	}
+	return sum
+}
+
+func appendPoint(out []byte, p curves.Point) []byte {
+	out = append(out, p.CurveName()...)
+	return append(out, p.ToAffineCompressed()...)
+}